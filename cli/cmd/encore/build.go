@@ -3,11 +3,16 @@ package main
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
 	"encr.dev/pkg/appfile"
@@ -73,6 +78,161 @@ func init() {
 	targetArch.AddFlag(dockerBuildCmd)
 	rootCmd.AddCommand(buildCmd)
 	buildCmd.AddCommand(dockerBuildCmd)
+
+	var licensesJSON bool
+	licensesCmd := &cobra.Command{
+		Use:   "licenses",
+		Short: "licenses reports the licenses of your application's dependencies",
+		Long: `Reports the licenses of the application's dependencies, as embedded in
+docker images built with 'encore build docker' at /encore/licenses.json.
+
+Dependencies licensed under licenses denied by build.licenses.deny in encore.app
+are reported as violations, which also fail 'encore build docker'.
+The exit code is 1 if there are any violations.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			licenseReport(appRoot, licensesJSON)
+		},
+	}
+	licensesCmd.Flags().BoolVar(&licensesJSON, "json", false, "output the report as JSON")
+	buildCmd.AddCommand(licensesCmd)
+
+	var provenanceJSON bool
+	provenanceCmd := &cobra.Command{
+		Use:   "provenance",
+		Short: "provenance reports the provenance metadata embedded in builds of your application",
+		Long: `Reports the provenance metadata that building the application in its current
+state embeds in its artifacts: the source commit, toolchain versions and a hash of
+the infrastructure the application requires. Docker images built with
+'encore build docker' include it at /encore/build-info.json.
+
+Builds are reproducible when the application has no uncommitted changes,
+or when the SOURCE_DATE_EPOCH environment variable is set.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			buildProvenance(appRoot, provenanceJSON)
+		},
+	}
+	provenanceCmd.Flags().BoolVar(&provenanceJSON, "json", false, "output the provenance as JSON")
+	buildCmd.AddCommand(provenanceCmd)
+
+	var (
+		importOutput   string
+		importMappings map[string]string
+	)
+	importInfraCmd := &cobra.Command{
+		Use:   "import-infra STATE_FILE",
+		Short: "import-infra generates an infra configuration using existing cloud resources in Terraform state",
+		Long: `Generates an infra configuration for self-hosting the application, mapping the
+infrastructure resources it declares to the existing cloud resources recorded
+in Terraform state. Use 'terraform state pull' to get the state from a remote backend.
+
+Encore resources are mapped to the cloud resources tagged or labeled with
+encore-name=NAME, named like them, or with names ending with their names.
+Use --map to map them explicitly to Terraform resource addresses.
+
+Credentials are not copied from the state, but referenced from environment variables.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			importInfra(appRoot, args[0], importOutput, importMappings)
+		},
+	}
+	importInfraCmd.Flags().StringVarP(&importOutput, "output", "o", "", "infra configuration file to write (defaults to stdout)")
+	importInfraCmd.Flags().StringToStringVar(&importMappings, "map", nil, "map Encore resources to Terraform resources, like users=aws_db_instance.main")
+	buildCmd.AddCommand(importInfraCmd)
+}
+
+func buildProvenance(appRoot string, asJSON bool) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.BuildProvenance(ctx, &daemonpb.BuildProvenanceRequest{
+		AppRoot: appRoot,
+		Environ: os.Environ(),
+	})
+	if err != nil {
+		fatal(err)
+	}
+
+	if asJSON {
+		data, err := protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+			Multiline:       true,
+		}.Marshal(resp)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	revision := resp.Revision
+	if revision == "" {
+		revision = "unknown"
+	}
+	if resp.UncommittedChanges {
+		revision += " (with uncommitted changes)"
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Revision:\t%s\n", revision)
+	_, _ = fmt.Fprintf(tw, "Compiler:\t%s\n", resp.EncoreCompiler)
+	for _, name := range slices.Sorted(maps.Keys(resp.Toolchains)) {
+		_, _ = fmt.Fprintf(tw, "Toolchain:\t%s %s\n", name, resp.Toolchains[name])
+	}
+	_, _ = fmt.Fprintf(tw, "Infra hash:\t%s\n", resp.InfraHash)
+	if resp.BuildTime != "" {
+		_, _ = fmt.Fprintf(tw, "Build time:\t%s\n", resp.BuildTime)
+	} else {
+		_, _ = fmt.Fprintf(tw, "Build time:\tnot reproducible; commit your changes or set SOURCE_DATE_EPOCH\n")
+	}
+	_ = tw.Flush()
+}
+
+func licenseReport(appRoot string, asJSON bool) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.LicenseReport(ctx, &daemonpb.LicenseReportRequest{AppRoot: appRoot})
+	if err != nil {
+		fatal(err)
+	}
+
+	if asJSON {
+		data, err := protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+			Multiline:       true,
+		}.Marshal(resp)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(string(data))
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "DEPENDENCY\tVERSION\tLICENSE")
+		for _, dep := range resp.Dependencies {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", dep.Name, dep.Version, dep.License)
+		}
+		_ = tw.Flush()
+
+		if len(resp.Violations) > 0 {
+			fmt.Printf("\n%d dependencies are licensed under denied licenses:\n", len(resp.Violations))
+			for _, v := range resp.Violations {
+				fmt.Printf("  %s@%s: %s (denied: %s)\n", v.Dependency.Name, v.Dependency.Version,
+					v.Dependency.License, strings.Join(v.Denied, ", "))
+			}
+		}
+	}
+
+	if len(resp.Violations) > 0 {
+		os.Exit(1)
+	}
 }
 
 type buildParams struct {
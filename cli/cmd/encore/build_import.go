@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/proto"
+
+	"encore.dev/appruntime/exported/config/infra"
+	"encr.dev/pkg/tfimport"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// importInfra generates an infra config for the app at appRoot
+// from the Terraform state at statePath, writing it to output.
+func importInfra(appRoot, statePath, output string, mappings map[string]string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		fatal(err)
+	}
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot: appRoot,
+		Environ: os.Environ(),
+		Format:  daemonpb.DumpMetaRequest_FORMAT_PROTO,
+	})
+	if err != nil {
+		fatal(err)
+	}
+	md := &meta.Data{}
+	if err := proto.Unmarshal(resp.Meta, md); err != nil {
+		fatalf("unable to parse app metadata: %v", err)
+	}
+
+	res, err := tfimport.Import(data, md, tfimport.Options{Mappings: mappings})
+	if err != nil {
+		fatal(err)
+	}
+	cfg, err := json.MarshalIndent(res.Config, "", "  ")
+	if err != nil {
+		fatal(err)
+	}
+	cfg = append(cfg, '\n')
+	if output == "" {
+		_, _ = os.Stdout.Write(cfg)
+	} else if err := os.WriteFile(output, cfg, 0o644); err != nil {
+		fatal(err)
+	}
+
+	// Report to stderr so the config can be piped.
+	if len(res.Matches) > 0 {
+		tw := tabwriter.NewWriter(os.Stderr, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "KIND\tNAME\tTERRAFORM RESOURCE")
+		for _, m := range res.Matches {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", m.Kind, m.Name, m.Address)
+		}
+		_ = tw.Flush()
+	}
+	if len(res.Unmatched) > 0 {
+		fmt.Fprintf(os.Stderr, "\n%d resources could not be mapped and must be configured manually:\n", len(res.Unmatched))
+		for _, u := range res.Unmatched {
+			fmt.Fprintf(os.Stderr, "  %s %s: %s\n", u.Kind, u.Name, u.Reason)
+		}
+	}
+
+	envVars, _ := infra.Validate(res.Config)
+	if len(envVars) > 0 {
+		byName := make(map[string]infra.EnvDesc)
+		for _, env := range envVars {
+			byName[env.Name] = env
+		}
+		fmt.Fprintln(os.Stderr, "\nThe configuration reads these environment variables at runtime:")
+		for _, name := range slices.Sorted(maps.Keys(byName)) {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", name, byName[name].Description)
+		}
+	}
+}
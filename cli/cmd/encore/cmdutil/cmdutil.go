@@ -131,6 +131,7 @@ func Fatal(args ...any) {
 		}
 	}
 
+	RestoreTerminal()
 	red := color.New(color.FgRed)
 	_, _ = red.Fprint(os.Stderr, "error: ")
 	_, _ = red.Fprintln(os.Stderr, args...)
@@ -0,0 +1,58 @@
+package cmdutil
+
+import (
+	"os"
+	"sync"
+)
+
+// termRestore holds the function restoring the terminal switched by ReadKeys,
+// so it's restored before exiting with Fatal too.
+var termRestore struct {
+	mu sync.Mutex
+	fn func()
+}
+
+// ReadKeys switches the terminal f to read key presses as they're typed,
+// without echoing them, and sends them on the returned channel.
+// Unlike in raw mode, Ctrl-C still interrupts the process and output
+// is still processed. The returned function restores the terminal,
+// and can be called more than once.
+func ReadKeys(f *os.File) (keys <-chan byte, restore func(), err error) {
+	restoreMode, err := enableKeyInput(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	var once sync.Once
+	restore = func() { once.Do(restoreMode) }
+
+	termRestore.mu.Lock()
+	termRestore.fn = restore
+	termRestore.mu.Unlock()
+
+	ch := make(chan byte)
+	go func() {
+		defer close(ch)
+		var buf [1]byte
+		for {
+			n, err := f.Read(buf[:])
+			if err != nil {
+				return
+			} else if n == 1 {
+				ch <- buf[0]
+			}
+		}
+	}()
+	return ch, restore, nil
+}
+
+// RestoreTerminal restores the terminal switched by ReadKeys, if any.
+// Call it before exiting the process without returning.
+func RestoreTerminal() {
+	termRestore.mu.Lock()
+	restore := termRestore.fn
+	termRestore.fn = nil
+	termRestore.mu.Unlock()
+	if restore != nil {
+		restore()
+	}
+}
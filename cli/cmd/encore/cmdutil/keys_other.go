@@ -0,0 +1,14 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+func enableKeyInput(f *os.File) (restore func(), err error) {
+	return nil, fmt.Errorf("reading key presses is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,25 @@
+//go:build windows
+// +build windows
+
+package cmdutil
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+func enableKeyInput(f *os.File) (restore func(), err error) {
+	h := windows.Handle(f.Fd())
+	var orig uint32
+	if err := windows.GetConsoleMode(h, &orig); err != nil {
+		return nil, err
+	}
+
+	// Disable line buffering and echoing only.
+	mode := orig &^ (windows.ENABLE_LINE_INPUT | windows.ENABLE_ECHO_INPUT)
+	if err := windows.SetConsoleMode(h, mode); err != nil {
+		return nil, err
+	}
+	return func() { _ = windows.SetConsoleMode(h, orig) }, nil
+}
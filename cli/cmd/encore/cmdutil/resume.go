@@ -0,0 +1,107 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/proto/encore/daemon"
+)
+
+// ResumableStream is a CommandOutputStream of a resumable command
+// that transparently resumes the stream if the connection to the daemon
+// is interrupted. Canceling its context cancels the command.
+type ResumableStream struct {
+	ctx    context.Context
+	daemon daemon.DaemonClient
+	stream CommandOutputStream
+
+	token    string // session token; empty until received
+	received uint64 // number of non-session messages received or lost
+}
+
+// Resumable wraps stream, which streams the output of a command
+// started with resumable set, so that it is resumed if interrupted.
+func Resumable(ctx context.Context, daemonClient daemon.DaemonClient, stream CommandOutputStream) *ResumableStream {
+	return &ResumableStream{ctx: ctx, daemon: daemonClient, stream: stream}
+}
+
+func (r *ResumableStream) Recv() (*daemon.CommandMessage, error) {
+	attempts := 0
+	for {
+		msg, err := r.stream.Recv()
+		if err == nil {
+			attempts = 0
+			if sess, ok := msg.Msg.(*daemon.CommandMessage_Session); ok {
+				r.token = sess.Session.Token
+				if lost := sess.Session.Lost; lost > 0 {
+					// The messages following the session message come after the lost ones.
+					r.received += lost
+					_, _ = fmt.Fprintf(os.Stderr, "encore: %d messages were lost while disconnected\n", lost)
+				}
+				continue
+			}
+			r.received++
+			return msg, nil
+		}
+
+		if r.token == "" {
+			return nil, err
+		} else if r.ctx.Err() != nil {
+			r.cancel()
+			return nil, err
+		} else if status.Code(err) != codes.Unavailable {
+			return nil, err
+		}
+
+		// The connection to the daemon was interrupted; resume the stream.
+		const maxAttempts = 10
+		if attempts >= maxAttempts {
+			return nil, status.Error(codes.FailedPrecondition, "encore: lost connection to the daemon")
+		}
+		if attempts == 0 {
+			_, _ = fmt.Fprintln(os.Stderr, "encore: lost connection to the daemon, reconnecting...")
+		}
+		select {
+		case <-r.ctx.Done():
+			r.cancel()
+			return nil, r.ctx.Err()
+		case <-time.After(time.Duration(attempts) * 500 * time.Millisecond):
+		}
+		attempts++
+
+		stream, err := r.daemon.ResumeStream(r.ctx, &daemon.ResumeStreamRequest{
+			Token:    r.token,
+			Received: r.received,
+		})
+		if err != nil {
+			continue
+		}
+		r.stream = &resumedStream{stream}
+	}
+}
+
+// cancel cancels the command, as the user interrupted it.
+func (r *ResumableStream) cancel() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, _ = r.daemon.CancelStream(ctx, &daemon.CancelStreamRequest{Token: r.token})
+}
+
+// resumedStream reports a missing command as FailedPrecondition,
+// since ResumeStream reports errors on the first Recv rather than when called.
+type resumedStream struct {
+	daemon.Daemon_ResumeStreamClient
+}
+
+func (s *resumedStream) Recv() (*daemon.CommandMessage, error) {
+	msg, err := s.Daemon_ResumeStreamClient.Recv()
+	if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+		return nil, status.Error(codes.FailedPrecondition, "encore: unable to resume: "+st.Message())
+	}
+	return msg, err
+}
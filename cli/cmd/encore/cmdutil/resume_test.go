@@ -0,0 +1,95 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/proto/encore/daemon"
+)
+
+// testStream returns msgs and then err.
+type testStream struct {
+	grpc.ClientStream
+	msgs []*daemon.CommandMessage
+	err  error
+}
+
+func (s *testStream) Recv() (*daemon.CommandMessage, error) {
+	if len(s.msgs) == 0 {
+		return nil, s.err
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
+// testDaemon records the ResumeStream requests and resumes with the next of streams.
+type testDaemon struct {
+	daemon.DaemonClient
+	resumes []*daemon.ResumeStreamRequest
+	streams []*testStream
+}
+
+func (d *testDaemon) ResumeStream(ctx context.Context, req *daemon.ResumeStreamRequest, opts ...grpc.CallOption) (daemon.Daemon_ResumeStreamClient, error) {
+	d.resumes = append(d.resumes, req)
+	stream := d.streams[0]
+	d.streams = d.streams[1:]
+	return stream, nil
+}
+
+func sessionMsg(token string, lost uint64) *daemon.CommandMessage {
+	return &daemon.CommandMessage{Msg: &daemon.CommandMessage_Session{
+		Session: &daemon.CommandSession{Token: token, Lost: lost},
+	}}
+}
+
+func outputMsg(s string) *daemon.CommandMessage {
+	return &daemon.CommandMessage{Msg: &daemon.CommandMessage_Output{
+		Output: &daemon.CommandOutput{Stdout: []byte(s)},
+	}}
+}
+
+func TestResumableStreamCountsLostMessages(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection reset")
+	first := &testStream{
+		msgs: []*daemon.CommandMessage{sessionMsg("tok", 0), outputMsg("a"), outputMsg("b")},
+		err:  unavailable,
+	}
+	d := &testDaemon{streams: []*testStream{
+		// Three messages were dropped from the buffer while disconnected.
+		{msgs: []*daemon.CommandMessage{sessionMsg("tok", 3), outputMsg("f")}, err: unavailable},
+		{msgs: []*daemon.CommandMessage{sessionMsg("tok", 0), outputMsg("g")}, err: io.EOF},
+	}}
+	r := Resumable(context.Background(), d, first)
+
+	var got []string
+	for {
+		msg, err := r.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(msg.GetOutput().Stdout))
+	}
+
+	if want := []string{"a", "b", "f", "g"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got outputs %v, want %v", got, want)
+	}
+	if len(d.resumes) != 2 {
+		t.Fatalf("got %d resumes, want 2", len(d.resumes))
+	}
+	if got := d.resumes[0].Received; got != 2 {
+		t.Errorf("first resume received = %d, want 2", got)
+	}
+	// a and b received, c, d and e lost, and f received.
+	if got := d.resumes[1].Received; got != 6 {
+		t.Errorf("second resume received = %d, want 6", got)
+	}
+}
@@ -3,6 +3,7 @@ package cmdutil
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,6 +17,7 @@ import (
 	"golang.org/x/crypto/ssh/terminal"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"encr.dev/pkg/ansi"
 	"encr.dev/proto/encore/daemon"
@@ -92,6 +94,7 @@ func StreamCommandOutput(stream CommandOutputStream, converter OutputConverter)
 			case err == io.EOF || st.Code() == codes.Canceled || strings.HasSuffix(err.Error(), "error reading from server: EOF"):
 				return 0
 			default:
+				RestoreTerminal()
 				log.Fatal().Err(err).Msg("connection failure")
 			}
 		}
@@ -104,6 +107,20 @@ func StreamCommandOutput(stream CommandOutputStream, converter OutputConverter)
 			if m.Output.Stderr != nil {
 				_, _ = errWrite.Write(m.Output.Stderr)
 			}
+		case *daemon.CommandMessage_OutputBatch:
+			frames, err := outputFrames(m.OutputBatch)
+			if err != nil {
+				RestoreTerminal()
+				log.Fatal().Err(err).Msg("invalid output")
+			}
+			for _, f := range frames {
+				if f.Stdout != nil {
+					_, _ = outWrite.Write(f.Stdout)
+				}
+				if f.Stderr != nil {
+					_, _ = errWrite.Write(f.Stderr)
+				}
+			}
 		case *daemon.CommandMessage_Errors:
 			DisplayError(os.Stderr, m.Errors.Errinsrc)
 
@@ -113,6 +130,30 @@ func StreamCommandOutput(stream CommandOutputStream, converter OutputConverter)
 	}
 }
 
+// outputFrames returns the output frames of a batch, decompressing it if necessary.
+func outputFrames(batch *daemon.CommandOutputBatch) ([]*daemon.CommandOutput, error) {
+	switch batch.Compression {
+	case daemon.OutputCompression_OUTPUT_COMPRESSION_NONE:
+		return batch.Frames, nil
+	case daemon.OutputCompression_OUTPUT_COMPRESSION_GZIP:
+		zr, err := gzip.NewReader(bytes.NewReader(batch.Compressed))
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, err
+		}
+		var decoded daemon.CommandOutputBatch
+		if err := proto.Unmarshal(data, &decoded); err != nil {
+			return nil, err
+		}
+		return decoded.Frames, nil
+	default:
+		return nil, fmt.Errorf("unknown output compression %v", batch.Compression)
+	}
+}
+
 type ConvertLogOptions struct {
 	Color bool
 }
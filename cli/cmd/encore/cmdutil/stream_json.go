@@ -0,0 +1,131 @@
+package cmdutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"encr.dev/proto/encore/daemon"
+)
+
+// StreamCommandJSON is like StreamCommandOutput, but writes everything the
+// command streams to w as newline-delimited JSON objects, one per event.
+//
+// Each object has a "type" field describing its contents:
+//   - "event": a structured event, such as build progress, in the "event" field
+//   - "output": a line of output in the "line" field, from the stream in the "stream" field;
+//     lines that are JSON log entries are included as objects in the "log" field instead
+//   - "errors": compilation errors, in the "errors" field
+//   - "exit": the command's exit code, in the "code" field
+func StreamCommandJSON(stream CommandOutputStream, w io.Writer) int {
+	enc := &jsonEventWriter{enc: json.NewEncoder(w)}
+	stdout := &jsonLineWriter{enc: enc, stream: "stdout"}
+	stderr := &jsonLineWriter{enc: enc, stream: "stderr"}
+	defer stdout.Flush()
+	defer stderr.Flush()
+
+	exit := func(code int) int {
+		stdout.Flush()
+		stderr.Flush()
+		enc.write(map[string]any{"type": "exit", "code": code})
+		return code
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			st := status.Convert(err)
+			switch {
+			case st.Code() == codes.FailedPrecondition:
+				_, _ = fmt.Fprintln(os.Stderr, st.Message())
+				return exit(1)
+			case err == io.EOF || st.Code() == codes.Canceled || strings.HasSuffix(err.Error(), "error reading from server: EOF"):
+				return exit(0)
+			default:
+				log.Fatal().Err(err).Msg("connection failure")
+			}
+		}
+
+		switch m := msg.Msg.(type) {
+		case *daemon.CommandMessage_Output:
+			stdout.Write(m.Output.Stdout)
+			stderr.Write(m.Output.Stderr)
+		case *daemon.CommandMessage_OutputBatch:
+			frames, err := outputFrames(m.OutputBatch)
+			if err != nil {
+				log.Fatal().Err(err).Msg("invalid output")
+			}
+			for _, f := range frames {
+				stdout.Write(f.Stdout)
+				stderr.Write(f.Stderr)
+			}
+		case *daemon.CommandMessage_Event:
+			data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m.Event)
+			if err != nil {
+				log.Fatal().Err(err).Msg("invalid event")
+			}
+			enc.write(map[string]any{"type": "event", "event": json.RawMessage(data)})
+		case *daemon.CommandMessage_Errors:
+			if json.Valid(m.Errors.Errinsrc) {
+				enc.write(map[string]any{"type": "errors", "errors": json.RawMessage(m.Errors.Errinsrc)})
+			}
+		case *daemon.CommandMessage_Exit:
+			return exit(int(m.Exit.Code))
+		}
+	}
+}
+
+// jsonEventWriter writes JSON objects, one per line.
+type jsonEventWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonEventWriter) write(v any) {
+	if err := w.enc.Encode(v); err != nil {
+		log.Fatal().Err(err).Msg("unable to write output")
+	}
+}
+
+// jsonLineWriter splits output into lines and writes an "output" object per line.
+type jsonLineWriter struct {
+	enc    *jsonEventWriter
+	stream string
+	buf    []byte // incomplete line
+}
+
+func (w *jsonLineWriter) Write(data []byte) {
+	w.buf = append(w.buf, data...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			return
+		}
+		w.writeLine(w.buf[:idx])
+		w.buf = w.buf[idx+1:]
+	}
+}
+
+// Flush writes the incomplete line, if any.
+func (w *jsonLineWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *jsonLineWriter) writeLine(line []byte) {
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	if len(line) > 0 && line[0] == '{' && json.Valid(line) {
+		w.enc.write(map[string]any{"type": "output", "stream": w.stream, "log": json.RawMessage(bytes.Clone(line))})
+		return
+	}
+	w.enc.write(map[string]any{"type": "output", "stream": w.stream, "line": string(line)})
+}
@@ -37,17 +37,23 @@ import (
 	"encr.dev/cli/daemon"
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/dash"
+	"encr.dev/cli/daemon/dbseed"
 	"encr.dev/cli/daemon/engine"
 	"encr.dev/cli/daemon/engine/trace2"
 	"encr.dev/cli/daemon/engine/trace2/sqlite"
 	"encr.dev/cli/daemon/mcp"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/objects"
+	"encr.dev/cli/daemon/ports"
 	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/runhistory"
 	"encr.dev/cli/daemon/secret"
 	"encr.dev/cli/daemon/sqldb"
 	"encr.dev/cli/daemon/sqldb/docker"
 	"encr.dev/cli/daemon/sqldb/external"
+	"encr.dev/cli/daemon/sqldb/mysql"
+	"encr.dev/cli/internal/bindaddr"
+	"encr.dev/cli/internal/localauth"
 	"encr.dev/internal/conf"
 	"encr.dev/internal/env"
 	"encr.dev/pkg/eerror"
@@ -103,12 +109,15 @@ type Daemon struct {
 	ObjectStorage *retryingTCPListener
 	MCP           *retryingTCPListener
 	EncoreDB      *sql.DB
+	LocalAuth     *localauth.Authenticator
+	Ports         *ports.Registry
 
 	Apps          *apps.Manager
 	Secret        *secret.Manager
 	RunMgr        *run.Manager
 	NS            *namespace.Manager
 	ClusterMgr    *sqldb.ClusterManager
+	MySQLMgr      *mysql.ClusterManager
 	ObjectsMgr    *objects.ClusterManager
 	MCPMgr        *mcp.Manager
 	PublicBuckets *objects.PublicBucketServer
@@ -133,6 +142,8 @@ func (d *Daemon) init(ctx context.Context) {
 	d.ObjectStorage = d.listenTCPRetry("objectstorage", env.EncoreObjectStorageListAddr(), 9800)
 	d.MCP = d.listenTCPRetry("mcp", env.EncoreMCPSSEListenAddr(), 9900)
 	d.EncoreDB = d.openDB()
+	d.LocalAuth = d.localAuth()
+	d.Ports = d.reservePorts()
 
 	d.Apps = apps.NewManager(d.EncoreDB)
 	d.close = append(d.close, d.Apps)
@@ -153,6 +164,7 @@ func (d *Daemon) init(ctx context.Context) {
 	d.NS = namespace.NewManager(d.EncoreDB)
 	d.Secret = secret.New()
 	d.ClusterMgr = sqldb.NewClusterManager(sqldbDriver, d.Apps, d.NS, d.Secret)
+	d.MySQLMgr = mysql.NewClusterManager()
 	d.ObjectsMgr = objects.NewClusterManager(d.NS)
 	d.PublicBuckets = objects.NewPublicBucketServer("http://"+d.ObjectStorage.ClientAddr(), d.ObjectsMgr.PersistentStoreFallback)
 
@@ -166,8 +178,12 @@ func (d *Daemon) init(ctx context.Context) {
 		DashBaseURL:   fmt.Sprintf("http://%s", d.Dash.ClientAddr()),
 		Secret:        d.Secret,
 		ClusterMgr:    d.ClusterMgr,
+		MySQLMgr:      d.MySQLMgr,
 		ObjectsMgr:    d.ObjectsMgr,
 		PublicBuckets: d.PublicBuckets,
+		Seeds:         dbseed.NewStore(d.EncoreDB),
+		LocalAuth:     d.LocalAuth,
+		Ports:         d.Ports,
 	}
 	d.MCPMgr = mcp.NewManager(
 		d.Apps,
@@ -180,10 +196,21 @@ func (d *Daemon) init(ctx context.Context) {
 
 	// Register namespace deletion handlers.
 	d.NS.RegisterDeletionHandler(d.ClusterMgr)
+	d.NS.RegisterDeletionHandler(d.MySQLMgr)
 	d.NS.RegisterDeletionHandler(d.RunMgr)
 	d.NS.RegisterDeletionHandler(d.ObjectsMgr)
+	d.NS.RegisterDeletionHandler(d.RunMgr.Seeds)
 
-	d.Server = daemon.New(d.Apps, d.RunMgr, d.ClusterMgr, d.Secret, d.NS, d.MCPMgr)
+	confDir, err := conf.Dir()
+	if err != nil {
+		fatal(err)
+	}
+	runHistory := runhistory.New(d.EncoreDB, filepath.Join(confDir, "runs"))
+	if err := runHistory.Abandon(ctx); err != nil {
+		log.Error().Err(err).Msg("unable to update run history")
+	}
+
+	d.Server = daemon.New(d.Apps, d.RunMgr, d.ClusterMgr, d.Secret, d.NS, d.MCPMgr, d.Trace, runHistory)
 }
 
 func (d *Daemon) serve() {
@@ -273,7 +300,7 @@ func (d *Daemon) serveDBProxy() {
 
 func (d *Daemon) serveMCP() {
 	log.Info().Stringer("addr", d.MCP.Addr()).Msg("serving mcp")
-	d.exit <- d.MCPMgr.Serve(d.MCP)
+	d.exit <- d.LocalAuth.Serve(d.MCP, d.MCPMgr.Handler())
 }
 
 func (d *Daemon) serveObjects() {
@@ -284,7 +311,7 @@ func (d *Daemon) serveObjects() {
 func (d *Daemon) serveDash() {
 	log.Info().Stringer("addr", d.Dash.Addr()).Msg("serving dash")
 	srv := dash.NewServer(d.Apps, d.RunMgr, d.NS, d.Trace, d.Dash.Port())
-	d.exit <- http.Serve(d.Dash, srv)
+	d.exit <- d.LocalAuth.Serve(d.Dash, srv)
 }
 
 func (d *Daemon) serveDebug() {
@@ -296,24 +323,59 @@ func (d *Daemon) serveDebug() {
 	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
 	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
-	d.exit <- http.Serve(d.Debug, mux)
+	d.exit <- d.LocalAuth.Serve(d.Debug, mux)
+}
+
+// localAuth returns the authenticator for the dashboard, the MCP endpoint
+// and the debug server, warning if they're exposed to the network without it.
+func (d *Daemon) localAuth() *localauth.Authenticator {
+	auth, err := localauth.Configured()
+	if err != nil {
+		fatal(err)
+	}
+	if auth.Mode() == localauth.ModeNone {
+		for _, ln := range []*retryingTCPListener{d.Dash, d.MCP} {
+			if !ln.addr.Addr().IsLoopback() {
+				log.Warn().Str("component", ln.component).Stringer("addr", ln.Addr()).
+					Msg("listening on a non-loopback address without authentication; " +
+						"set the daemon.auth config setting to require it")
+			}
+		}
+	}
+	return auth
 }
 
 // listenTCPRetry listens for TCP connections on the given port, retrying
 // in the background if it's already in use.
 func (d *Daemon) listenTCPRetry(component string, addrOverride option.Option[string], defaultPort uint16) *retryingTCPListener {
-	addr, err := parseInterface(addrOverride.GetOrElse("127.0.0.1:0"))
+	addr, err := parseInterface(addrOverride.GetOrElse(bindaddr.LoopbackAddr(0)))
 	if err != nil {
 		log.Fatal().Str("component", component).Err(err).Msg("failed to parse interface")
 	}
 	if addr.Port() == 0 {
 		addr = netip.AddrPortFrom(addr.Addr(), defaultPort)
 	}
+	if warning, err := bindaddr.Configured("").Check(component, addr.String()); err != nil {
+		log.Error().Err(err).Msgf("listening on %s instead", bindaddr.Loopback())
+		addr = netip.AddrPortFrom(netip.MustParseAddr(bindaddr.Loopback()), addr.Port())
+	} else if warning != "" {
+		log.Warn().Str("component", component).Msg(warning)
+	}
 	ln := listenLocalhostTCP(component, addr)
 	d.closeOnExit(ln)
 	return ln
 }
 
+// reservePorts creates the port registry and reserves the ports
+// the daemon listens on, so apps and other subsystems avoid them.
+func (d *Daemon) reservePorts() *ports.Registry {
+	reg := ports.NewRegistry(d.EncoreDB)
+	for _, ln := range []*retryingTCPListener{d.Dash, d.DBProxy, d.Runtime, d.Debug, d.ObjectStorage, d.MCP} {
+		reg.Reserve(ports.Key{Component: ln.component}, ln.Port())
+	}
+	return reg
+}
+
 func (d *Daemon) openDB() *sql.DB {
 	dir, err := conf.Dir()
 	if err != nil {
@@ -555,15 +617,9 @@ func (ln *retryingTCPListener) Addr() net.Addr {
 }
 
 func (ln *retryingTCPListener) ClientAddr() string {
-	// If our addr is 0.0.0.0 or the ipv6 equivalent, return 127.0.0.1 instead
-	// so that clients can connect to us.
-	if ln.addr.Addr().IsUnspecified() {
-		if ln.addr.Addr().Is6() {
-			return fmt.Sprintf("[::1]:%d", ln.addr.Port())
-		}
-		return fmt.Sprintf("127.0.0.1:%d", ln.addr.Port())
-	}
-	return ln.addr.String()
+	// If our addr is 0.0.0.0 or the ipv6 equivalent, return the loopback
+	// address instead so that clients can connect to us.
+	return bindaddr.DialAddr(ln.addr.String())
 }
 
 func (ln *retryingTCPListener) Port() int {
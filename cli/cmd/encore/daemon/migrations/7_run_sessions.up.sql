@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS run_session (
+    id TEXT PRIMARY KEY, -- the run id
+    app_id TEXT NOT NULL, -- platform_id or local_id
+    name TEXT NOT NULL, -- empty if the run wasn't named
+    namespace TEXT NOT NULL,
+    git_commit TEXT NOT NULL, -- empty if the app isn't in a git repository
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP NULL,
+    exit_reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX run_session_app ON run_session (app_id, started_at);
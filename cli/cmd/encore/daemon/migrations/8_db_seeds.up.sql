@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS db_seed (
+    app_id TEXT NOT NULL, -- platform_id or local_id
+    namespace_id TEXT NOT NULL,
+    database TEXT NOT NULL,
+    seed TEXT NOT NULL,
+    checksum TEXT NOT NULL, -- empty for Go seeds
+    applied_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (app_id, namespace_id, database, seed)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS port_assignment (
+    app_id TEXT NOT NULL, -- platform_id or local_id, empty for daemon components
+    component TEXT NOT NULL,
+    requested INTEGER NOT NULL, -- the port asked for, 0 for any port
+    port INTEGER NOT NULL,
+    assigned_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (app_id, component, requested)
+);
@@ -7,7 +7,6 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/rs/zerolog/log"
@@ -16,7 +15,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
-	"encr.dev/cli/daemon/sqldb/docker"
+	"encr.dev/cli/internal/sqlshell"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
@@ -27,6 +26,7 @@ var dbCmd = &cobra.Command{
 
 var (
 	resetAll  bool
+	resetSeed bool
 	testDB    bool
 	shadowDB  bool
 	write     bool
@@ -49,7 +49,7 @@ func getDBRole() daemonpb.DBRole {
 }
 
 var dbResetCmd = &cobra.Command{
-	Use:   "reset <database-names...|--all>",
+	Use:   "reset <database-names...|--all> [--seed]",
 	Short: "Resets the databases with the given names. Use --all to reset all databases.",
 
 	Run: func(command *cobra.Command, args []string) {
@@ -73,6 +73,7 @@ var dbResetCmd = &cobra.Command{
 			DatabaseNames: dbNames,
 			ClusterType:   dbClusterType(),
 			Namespace:     nonZeroPtr(nsName),
+			Seed:          resetSeed,
 		})
 		if err != nil {
 			fatal("reset databases: ", err)
@@ -81,7 +82,10 @@ var dbResetCmd = &cobra.Command{
 	},
 }
 
-var dbEnv string
+var (
+	dbEnv        string
+	builtinShell bool
+)
 
 var dbShellCmd = &cobra.Command{
 	Use:   "shell DATABASE_NAME [--env=<name>] [--test|--shadow]",
@@ -89,6 +93,9 @@ var dbShellCmd = &cobra.Command{
 	Long: `Defaults to connecting to your local environment.
 Specify --env to connect to another environment.
 
+Uses psql if it's installed, and otherwise a built-in SQL shell.
+Use --builtin to always use the built-in shell.
+
 Use --test to connect to databases used for integration testing.
 Use --shadow to connect to the shadow database, used for database drift detection
 when using tools like Prisma.
@@ -143,24 +150,19 @@ when using tools like Prisma.
 		}
 
 		// If we have the psql binary, use that.
-		// Otherwise fall back to docker.
-		var cmd *exec.Cmd
-		if p, err := exec.LookPath("psql"); err == nil {
-			cmd = exec.Command(p, resp.Dsn)
-		} else {
-			fmt.Fprintln(os.Stderr, "encore: no 'psql' executable found in $PATH; using docker to run 'psql' instead.\n\nNote: install psql to hide this message.")
-			dsn := resp.Dsn
-
-			if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
-				// Docker for {Mac, Windows}'s networking setup requires
-				// using "host.docker.internal" instead of "localhost"
-				for _, rep := range []string{"localhost", "127.0.0.1"} {
-					dsn = strings.Replace(dsn, rep, "host.docker.internal", -1)
-				}
+		// Otherwise fall back to the built-in shell.
+		psql, err := exec.LookPath("psql")
+		if builtinShell || err != nil {
+			if !builtinShell {
+				fmt.Fprintln(os.Stderr, "encore: no 'psql' executable found in $PATH; using the built-in SQL shell instead.\n\nNote: install psql to hide this message.")
 			}
-
-			cmd = exec.Command("docker", "run", "-it", "--rm", "--network=host", docker.Image, "psql", dsn)
+			if err := sqlshell.Run(ctx, resp.Dsn, os.Stdin, os.Stdout); err != nil {
+				fatal(err)
+			}
+			return
 		}
+
+		cmd := exec.Command(psql, resp.Dsn)
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		cmd.Stdin = os.Stdin
@@ -298,6 +300,7 @@ func init() {
 	dbResetCmd.Flags().BoolVar(&resetAll, "all", false, "Reset all services in the application")
 	dbResetCmd.Flags().BoolVarP(&testDB, "test", "t", false, "Reset databases in the test cluster instead")
 	dbResetCmd.Flags().BoolVar(&shadowDB, "shadow", false, "Reset databases in the shadow cluster instead")
+	dbResetCmd.Flags().BoolVar(&resetSeed, "seed", false, "Apply the databases' seeds after resetting them")
 	dbCmd.AddCommand(dbResetCmd)
 
 	dbShellCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
@@ -307,12 +310,13 @@ func init() {
 	dbShellCmd.Flags().BoolVar(&write, "write", false, "Connect with write privileges")
 	dbShellCmd.Flags().BoolVar(&admin, "admin", false, "Connect with admin privileges")
 	dbShellCmd.Flags().BoolVar(&superuser, "superuser", false, "Connect as a superuser")
+	dbShellCmd.Flags().BoolVar(&builtinShell, "builtin", false, "Use the built-in SQL shell instead of psql")
 	dbShellCmd.MarkFlagsMutuallyExclusive("write", "admin", "superuser")
 	dbCmd.AddCommand(dbShellCmd)
 
 	dbProxyCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
 	dbProxyCmd.Flags().StringVarP(&dbEnv, "env", "e", "local", "Environment name to connect to (such as \"prod\")")
-	dbProxyCmd.Flags().Int32VarP(&dbProxyPort, "port", "p", 0, "Port to listen on (defaults to a random port)")
+	dbProxyCmd.Flags().Int32VarP(&dbProxyPort, "port", "p", 0, "Port to listen on (defaults to the port the proxy used last time, or a random port)")
 	dbProxyCmd.Flags().BoolVarP(&testDB, "test", "t", false, "Connect to the integration test database (implies --env=local)")
 	dbProxyCmd.Flags().BoolVar(&shadowDB, "shadow", false, "Connect to the shadow database (implies --env=local)")
 	dbProxyCmd.Flags().BoolVar(&write, "write", false, "Connect with write privileges")
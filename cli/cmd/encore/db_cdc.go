@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var dbCDCCmd = &cobra.Command{
+	Use:   "cdc",
+	Short: "Change data capture (CDC) for streaming database changes to pipelines",
+}
+
+func init() {
+	format := cmdutil.Oneof{
+		Value:     "debezium",
+		Allowed:   []string{"debezium", "sql"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Configuration format",
+	}
+	var output string
+
+	configCmd := &cobra.Command{
+		Use:   "config [<db-name>...] [--format=debezium|sql] [--output=<dir>]",
+		Short: "Generates CDC connector configuration for the app's databases",
+		Long: `Generates change data capture configuration for the given databases
+(or all databases if none are given), based on the tables declared by their migrations.
+
+With --format=debezium a Debezium PostgreSQL connector configuration is written
+to <output>/<db-name>.json, for registering with Kafka Connect. The connection
+parameters are read from environment variables like ORDERS_DB_HOST.
+
+With --format=sql a script that sets up the publication and replication slot
+the connector uses is written to <output>/<db-name>.sql.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			ctx := context.Background()
+			daemon := setupDaemon(ctx)
+
+			req := &daemonpb.DBCDCConfigRequest{
+				AppRoot:       appRoot,
+				DatabaseNames: args,
+				Format:        daemonpb.DBCDCConfigRequest_FORMAT_DEBEZIUM,
+			}
+			if format.Value == "sql" {
+				req.Format = daemonpb.DBCDCConfigRequest_FORMAT_SQL
+			}
+			resp, err := daemon.DBCDCConfig(ctx, req)
+			if err != nil {
+				fatal(err)
+			}
+
+			if err := os.MkdirAll(output, 0755); err != nil {
+				fatal(err)
+			}
+			for _, f := range resp.Files {
+				if err := os.WriteFile(filepath.Join(output, f.Name), f.Content, 0644); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Printf("successfully generated %d files in %s.\n", len(resp.Files), output)
+		},
+	}
+	format.AddFlag(configCmd)
+	configCmd.Flags().StringVarP(&output, "output", "o", "cdc", "The directory to write the configuration to")
+	_ = configCmd.MarkFlagDirname("output")
+
+	var topic string
+	streamCmd := &cobra.Command{
+		Use:   "stream <db-name> [--topic=<name>]",
+		Short: "Streams change events from a database of the running app",
+		Long: `Streams insert, update and delete events from a database of the app
+started with 'encore run', for testing data pipelines locally.
+
+Each event is written to stdout as a line of JSON. With --topic the events are
+also published to the given Pub/Sub topic, so they can be consumed by subscriptions.
+
+Change events are captured with triggers that are removed when the command exits.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			interrupt := make(chan os.Signal, 1)
+			signal.Notify(interrupt, os.Interrupt)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				<-interrupt
+				cancel()
+			}()
+
+			daemon := setupDaemon(ctx)
+			stream, err := daemon.DBCDCStream(ctx, &daemonpb.DBCDCStreamRequest{
+				AppRoot:      appRoot,
+				DatabaseName: args[0],
+				Topic:        nonZeroPtr(topic),
+			})
+			if err != nil {
+				fatal("stream database changes: ", err)
+			}
+			os.Exit(cmdutil.StreamCommandOutput(stream, nil))
+		},
+	}
+	streamCmd.Flags().StringVar(&topic, "topic", "", "Pub/Sub topic to publish the change events to")
+
+	dbCDCCmd.AddCommand(configCmd)
+	dbCDCCmd.AddCommand(streamCmd)
+	dbCmd.AddCommand(dbCDCCmd)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+func init() {
+	var (
+		env      string
+		against  string
+		write    bool
+		name     string
+		exitCode bool
+	)
+	dbDiffCmd := &cobra.Command{
+		Use:   "diff <database-name> [--env=<name>] [--against=<env>] [--write [--name=<description>]]",
+		Short: "Drafts a migration for the difference between a database's live schema and its migrations",
+		Long: `Compares the live schema of a database against the schema produced by
+applying all its migrations, and prints a draft migration that turns the
+latter into the former. Use it to catch schema drift, or to write a migration
+for changes made to the database by hand.
+
+The migrations are applied from scratch to the database in the test cluster
+of the namespace, which is recreated in the process.
+
+By default the database in the local namespace is compared. Specify --env
+to compare the database in a cloud environment instead, and --against to
+compare against another environment ("local" for the local namespace)
+instead of the migrations.
+
+Use --write to write the draft as the next migration of the database.
+Review it before applying it: the draft doesn't preserve data across
+changes it can't express, like renamed columns, which show up as a
+dropped and an added column.`,
+		Args: cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.DBDiff(ctx, &daemonpb.DBDiffRequest{
+				AppRoot:      appRoot,
+				DatabaseName: args[0],
+				EnvName:      env,
+				Namespace:    nonZeroPtr(nsName),
+				AgainstEnv:   against,
+			})
+			if err != nil {
+				fatal("diff schema: ", err)
+			}
+
+			if len(resp.Statements) == 0 && len(resp.Notes) == 0 {
+				fmt.Fprintf(os.Stderr, "The schema of database %s in %s matches %s.\n", args[0], resp.To, resp.From)
+				return
+			}
+			draft := draftMigration(args[0], resp)
+			if !write {
+				fmt.Print(draft)
+			} else {
+				filename := fmt.Sprintf("%d_%s.up.sql", resp.NextVersion, name)
+				path := filepath.Join(resp.MigrationDir, filename)
+				if _, err := os.Stat(path); err == nil {
+					fatalf("migration %s already exists", filename)
+				} else if !errors.Is(err, fs.ErrNotExist) {
+					fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(draft), 0644); err != nil {
+					fatal(err)
+				}
+				fmt.Fprintf(os.Stderr, "Wrote the draft migration to %s. Review it before applying it.\n", path)
+			}
+			if exitCode {
+				os.Exit(1)
+			}
+		},
+	}
+	dbDiffCmd.Flags().StringVarP(&env, "env", "e", "local", "Environment name with the live schema to compare (such as \"staging\")")
+	dbDiffCmd.Flags().StringVar(&against, "against", "", "Environment name to compare against instead of the migrations")
+	dbDiffCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+	dbDiffCmd.Flags().BoolVar(&write, "write", false, "Write the draft as the next migration of the database")
+	dbDiffCmd.Flags().StringVar(&name, "name", "schema_diff", "Description in the filename of the migration written with --write")
+	dbDiffCmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit with status 1 if the schemas differ")
+
+	dbCmd.AddCommand(dbDiffCmd)
+}
+
+// draftMigration renders the statements of a schema diff as a migration,
+// with the data they destroy and the differences they don't cover as comments.
+func draftMigration(dbName string, resp *daemonpb.DBDiffResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- Draft migration of database %s, generated by 'encore db diff'.\n", dbName)
+	fmt.Fprintf(&b, "-- It turns the schema of %s into the schema of %s.\n", resp.From, resp.To)
+	for _, n := range resp.Notes {
+		fmt.Fprintf(&b, "-- TODO: %s\n", n)
+	}
+	for _, stmt := range resp.Statements {
+		b.WriteString("\n")
+		for _, w := range stmt.Warnings {
+			fmt.Fprintf(&b, "-- warning: %s\n", w)
+		}
+		fmt.Fprintf(&b, "%s;\n", stmt.Sql)
+	}
+	return b.String()
+}
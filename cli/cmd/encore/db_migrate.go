@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v3"
+	"github.com/spf13/cobra"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+func init() {
+	var (
+		plan bool
+		env  string
+	)
+	dbMigrateCmd := &cobra.Command{
+		Use:   "migrate --plan [<database-names...>] [--env=<name>]",
+		Short: "Shows the migrations that would be applied to the app's databases",
+		Long: `Shows the pending migrations that would be applied to the given databases
+(or all databases if none are given), without applying them.
+
+For each pending migration the statements it runs are previewed, with warnings
+for statements that destroy data, like dropping tables or columns.
+
+Specify --env to plan the migrations of a cloud environment
+instead of the local namespace.`,
+
+		Run: func(cmd *cobra.Command, args []string) {
+			if !plan {
+				fatal("encore db migrate only supports --plan: migrations are applied by 'encore run' and when deploying")
+			}
+			appRoot, _ := determineAppRoot()
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.DBMigrationPlan(ctx, &daemonpb.DBMigrationPlanRequest{
+				AppRoot:       appRoot,
+				DatabaseNames: args,
+				EnvName:       env,
+				Namespace:     nonZeroPtr(nsName),
+			})
+			if err != nil {
+				fatal("plan migrations: ", err)
+			}
+			printMigrationPlan(resp, env)
+		},
+	}
+	dbMigrateCmd.Flags().BoolVar(&plan, "plan", false, "Show the pending migrations without applying them")
+	dbMigrateCmd.Flags().StringVarP(&env, "env", "e", "local", "Environment name to plan the migrations for (such as \"prod\")")
+	dbMigrateCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+
+	dbCmd.AddCommand(dbMigrateCmd)
+}
+
+func printMigrationPlan(resp *daemonpb.DBMigrationPlanResponse, env string) {
+	if len(resp.Databases) == 0 {
+		fmt.Println("The app has no databases.")
+		return
+	}
+
+	total, warnings := 0, 0
+	for _, db := range resp.Databases {
+		if len(db.Pending) == 0 {
+			fmt.Printf("Database %s is up to date.\n", db.Name)
+			continue
+		}
+		total += len(db.Pending)
+
+		fmt.Printf("Database %s: %d pending migration(s)", db.Name, len(db.Pending))
+		if !db.Exists {
+			fmt.Print(" (the database will be created)")
+		}
+		fmt.Println()
+		for _, m := range db.Pending {
+			fmt.Printf("\n  %s", aurora.Bold(m.Filename))
+			if m.Dirty {
+				fmt.Print(aurora.Yellow(" (failed previously, will be retried)"))
+			}
+			fmt.Println()
+			for _, stmt := range m.Statements {
+				fmt.Printf("    %s\n", previewStatement(stmt.Sql))
+				for _, w := range stmt.Warnings {
+					fmt.Printf("      %s\n", aurora.Red("warning: "+w))
+					warnings++
+				}
+			}
+		}
+		fmt.Println()
+	}
+
+	if total == 0 {
+		fmt.Println("No migrations would be applied.")
+		return
+	}
+	target := "the local namespace"
+	if env != "local" {
+		target = fmt.Sprintf("environment %s", env)
+	}
+	fmt.Printf("%d migration(s) would be applied to %s, with %d destructive statement warning(s). Nothing was applied.\n",
+		total, target, warnings)
+}
+
+// previewStatement returns a single-line preview of a migration statement.
+func previewStatement(stmt string) string {
+	const maxLen = 100
+	s := strings.Join(strings.Fields(stmt), " ")
+	if r := []rune(s); len(r) > maxLen {
+		s = string(r[:maxLen-3]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+func init() {
+	var (
+		traceID string
+		limit   int32
+	)
+	dbQueriesCmd := &cobra.Command{
+		Use:   "queries [--trace=<id>] [--limit=<n>]",
+		Short: "Shows the queries the app made to its local databases",
+		Long: `Shows the queries the app made to its local databases, with how long
+they took, how many rows they returned or affected, and the trace of
+the request that made them.
+
+The queries are recorded by the database proxy while the app runs.
+The most recent ones are kept in memory until the daemon restarts.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			req := &daemonpb.DBQueryLogRequest{AppRoot: appRoot, Limit: limit}
+			if traceID != "" {
+				req.TraceId = proto.String(traceID)
+			}
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.DBQueryLog(ctx, req)
+			if err != nil {
+				fatal(err)
+			}
+			if len(resp.Queries) == 0 {
+				fmt.Println("No queries have been logged.")
+				return
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			_, _ = fmt.Fprintln(tw, "TIME\tDATABASE\tDURATION\tROWS\tTRACE\tQUERY")
+			for _, q := range resp.Queries {
+				at := q.Time
+				if t, err := time.Parse(time.RFC3339Nano, q.Time); err == nil {
+					at = t.Local().Format("15:04:05.000")
+				}
+				rows := fmt.Sprint(q.Rows)
+				if q.Error != nil {
+					rows = "error: " + *q.Error
+				}
+				_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", at, q.Database,
+					time.Duration(q.DurationNanos).Round(time.Microsecond), rows,
+					q.GetTraceId(), strings.Join(strings.Fields(q.Query), " "))
+			}
+			_ = tw.Flush()
+		},
+	}
+	dbQueriesCmd.Flags().StringVar(&traceID, "trace", "", "Only show the queries made by the request with the given trace id")
+	dbQueriesCmd.Flags().Int32Var(&limit, "limit", 50, "The number of most recent queries to show (0 shows all)")
+	dbCmd.AddCommand(dbQueriesCmd)
+}
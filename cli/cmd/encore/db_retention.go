@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var dbRetentionCmd = &cobra.Command{
+	Use:   "retention",
+	Short: "Inspects the data retention policies of the running app",
+}
+
+func init() {
+	var subject string
+	dryRunCmd := &cobra.Command{
+		Use:   "dry-run [--subject=<id>]",
+		Short: "Shows what the data retention policies of the running app would delete",
+		Long: `Shows how many rows each data retention policy of the running app would
+delete if it were enforced now, without deleting anything.
+
+With --subject, also shows how many rows each subject deletion handler
+would delete when deleting the data of the given subject.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			retentionDryRun(appRoot, subject)
+		},
+	}
+	dryRunCmd.Flags().StringVar(&subject, "subject", "", "The id of a subject to dry run the deletion of")
+
+	dbRetentionCmd.AddCommand(dryRunCmd)
+	dbCmd.AddCommand(dbRetentionCmd)
+}
+
+func retentionDryRun(appRoot, subject string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	req := &daemonpb.RetentionDryRunRequest{AppRoot: appRoot}
+	if subject != "" {
+		req.SubjectId = proto.String(subject)
+	}
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.RetentionDryRun(ctx, req)
+	if err != nil {
+		fatal(err)
+	}
+
+	if len(resp.Policies) == 0 {
+		fmt.Println("The app has no data retention policies.")
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "POLICY\tTABLE\tMAX AGE\tWOULD DELETE")
+		for _, p := range resp.Policies {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.Name, p.Table, p.MaxAge, dryRunCount(p.WouldDelete, p.Error))
+		}
+		_ = tw.Flush()
+	}
+
+	if subject == "" {
+		return
+	}
+	fmt.Println()
+	if len(resp.Subjects) == 0 {
+		fmt.Println("The app has no subject deletion handlers.")
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "HANDLER\tWOULD DELETE FOR %s\n", subject)
+	for _, s := range resp.Subjects {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\n", s.Handler, dryRunCount(s.WouldDelete, s.Error))
+	}
+	_ = tw.Flush()
+}
+
+// dryRunCount formats the number of rows a dry run reported, or its error.
+func dryRunCount(n int64, errMsg string) string {
+	if errMsg != "" {
+		return "error: " + errMsg
+	}
+	return fmt.Sprint(n)
+}
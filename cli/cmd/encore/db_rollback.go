@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+func init() {
+	var (
+		steps           int
+		to              uint64
+		env             string
+		dryRun          bool
+		yes             bool
+		allowProduction bool
+	)
+	dbRollbackCmd := &cobra.Command{
+		Use:   "rollback <database-name> [--steps=N | --to=VERSION] [--env=<name>] [--dry-run]",
+		Short: "Rolls back the most recently applied migrations of a database",
+		Long: `Rolls back the most recently applied migrations of a database by running
+their down migrations: the files with the same name as the migration, ending
+in .down.sql instead of .up.sql, like 2_add_email.down.sql.
+
+By default the last applied migration is rolled back. Use --steps to roll back
+more migrations, or --to to roll back the migrations after the given version.
+--to=0 rolls back all migrations.
+
+Specify --env to roll back the migrations of a cloud environment instead of
+the local namespace. The down migrations are read from the app in the current
+directory, so check out the version of the app deployed to the environment
+first. Rolling back requires confirming the environment name, or --yes,
+and production environments additionally require --allow-production.
+
+The rolled back migrations are applied again the next time the app runs
+or is deployed, unless their migration files are removed.`,
+		Args: cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			if cmd.Flags().Changed("steps") && cmd.Flags().Changed("to") {
+				fatal("--steps and --to cannot be combined")
+			} else if steps <= 0 {
+				fatal("--steps must be positive")
+			}
+			appRoot, _ := determineAppRoot()
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			daemon := setupDaemon(ctx)
+			req := &daemonpb.DBRollbackRequest{
+				AppRoot:         appRoot,
+				DatabaseName:    args[0],
+				Steps:           int32(steps),
+				EnvName:         env,
+				Namespace:       nonZeroPtr(nsName),
+				DryRun:          dryRun,
+				AllowProduction: allowProduction,
+			}
+			if cmd.Flags().Changed("to") {
+				req.ToVersion = &to
+			}
+			rollback := func(req *daemonpb.DBRollbackRequest) int {
+				stream, err := daemon.DBRollback(ctx, req)
+				if err != nil {
+					fatal("roll back migrations: ", err)
+				}
+				return cmdutil.StreamCommandOutput(stream, nil)
+			}
+
+			if env != "local" && !dryRun {
+				if !yes {
+					// Show what would be rolled back before asking for confirmation.
+					plan := proto.Clone(req).(*daemonpb.DBRollbackRequest)
+					plan.DryRun = true
+					if code := rollback(plan); code != 0 {
+						os.Exit(code)
+					}
+					confirmRollback(env)
+				}
+				req.ConfirmEnv = env
+			}
+			os.Exit(rollback(req))
+		},
+	}
+	dbRollbackCmd.Flags().IntVar(&steps, "steps", 1, "Number of migrations to roll back")
+	dbRollbackCmd.Flags().Uint64Var(&to, "to", 0, "Roll back the migrations after this version (0 rolls back all migrations)")
+	dbRollbackCmd.Flags().StringVarP(&env, "env", "e", "local", "Environment name to roll back the migrations of (such as \"staging\")")
+	dbRollbackCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+	dbRollbackCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the migrations that would be rolled back without rolling them back")
+	dbRollbackCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Roll back the migrations of a cloud environment without asking for confirmation")
+	dbRollbackCmd.Flags().BoolVar(&allowProduction, "allow-production", false, "Allow rolling back the migrations of a production environment")
+
+	dbCmd.AddCommand(dbRollbackCmd)
+}
+
+// confirmRollback asks the user to confirm rolling back the migrations
+// of the environment env by typing its name, and exits if they don't.
+func confirmRollback(env string) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fatal("rolling back the migrations of a cloud environment must be confirmed: use --yes when not running interactively")
+	}
+	fmt.Fprintf(os.Stderr, "Type the name of the environment (%s) to confirm the rollback: ", env)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil || strings.TrimSpace(line) != env {
+		fatal("rollback aborted")
+	}
+}
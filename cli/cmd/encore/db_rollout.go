@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/pkg/expandcontract"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+var dbRolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Expand/contract tooling for zero-downtime schema migrations",
+	Long: `Helps roll out schema migrations without downtime by splitting them
+into an expand phase, which both the previous and the new version of the app
+can run against, and a contract phase that is applied once the previous version is gone.`,
+}
+
+func init() {
+	var migration uint64
+
+	planCmd := &cobra.Command{
+		Use:   "plan <db-name> [--migration=<number>]",
+		Short: "Analyzes a migration for changes that break the running app during a rollout",
+		Long: `Analyzes a migration (the latest one by default) for changes that break
+queries of the previous version of the app while the new version is rolled out,
+lists the queries in the app that would break, and prints the expand and
+contract migrations the migration can be split into.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			db := rolloutDatabase(appRoot, args[0])
+			m, plan := planRollout(appRoot, db, migration)
+
+			if len(plan.Changes) == 0 {
+				fmt.Printf("Migration %s is safe to apply while the previous version of the app is running.\n", m.Filename)
+				return
+			}
+
+			queries, err := expandcontract.FindQueries(appRoot)
+			if err != nil {
+				fatal(err)
+			}
+			fmt.Printf("Migration %s makes %d breaking change(s):\n\n", m.Filename, len(plan.Changes))
+			for _, c := range plan.Changes {
+				fmt.Printf("  %s\n    %s\n", c, c.Risk())
+				for _, q := range c.AffectedQueries(queries) {
+					fmt.Printf("    affects query at %s:%d\n", q.File, q.Line)
+				}
+				fmt.Println()
+			}
+			fmt.Printf("-- Expand migration:\n%s\n", plan.ExpandSQL())
+			fmt.Printf("-- Contract migration:\n%s\n", plan.ContractSQL())
+			fmt.Printf("Run 'encore db rollout split %s' to split the migration.\n", db.Name)
+		},
+	}
+	planCmd.Flags().Uint64Var(&migration, "migration", 0, "The migration number to analyze (defaults to the latest)")
+
+	splitCmd := &cobra.Command{
+		Use:   "split <db-name> [--migration=<number>]",
+		Short: "Splits a migration into expand and contract migrations",
+		Long: `Splits a migration (the latest one by default) into expand and contract migrations.
+
+The migration file is rewritten to contain the expand phase, and the contract
+phase is written to the contract directory of the migration directory,
+which Encore doesn't apply. Promote it with 'encore db rollout contract'
+once no environment runs the previous version of the app.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			db := rolloutDatabase(appRoot, args[0])
+			m, plan := planRollout(appRoot, db, migration)
+			if len(plan.Contract) == 0 {
+				fmt.Printf("Migration %s makes no breaking changes, nothing to split.\n", m.Filename)
+				return
+			}
+
+			dir := expandcontract.MigrationDir(appRoot, db)
+			state, err := expandcontract.LoadState(dir)
+			if err != nil {
+				fatalf("unable to load rollout state: %v", err)
+			}
+			if _, ok := state.Rollouts[m.Filename]; ok {
+				fatalf("migration %s has already been split", m.Filename)
+			}
+
+			if err := os.MkdirAll(filepath.Join(dir, expandcontract.ContractDir), 0755); err != nil {
+				fatal(err)
+			}
+			expand := fmt.Sprintf("-- Expand phase. The contract phase is in %s/%s.\n\n%s",
+				expandcontract.ContractDir, m.Filename, plan.ExpandSQL())
+			contract := fmt.Sprintf("-- Contract phase of %s.\n\n%s", m.Filename, plan.ContractSQL())
+			if err := os.WriteFile(filepath.Join(dir, expandcontract.ContractDir, m.Filename), []byte(contract), 0644); err != nil {
+				fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, m.Filename), []byte(expand), 0644); err != nil {
+				fatal(err)
+			}
+
+			state.Rollouts[m.Filename] = &expandcontract.Rollout{ContractFile: m.Filename}
+			if err := state.Save(dir); err != nil {
+				fatalf("unable to save rollout state: %v", err)
+			}
+			fmt.Printf("Split %s into expand and contract migrations.\n", m.Filename)
+			fmt.Printf("Review the contract migration in %s.\n", filepath.Join(dir, expandcontract.ContractDir, m.Filename))
+		},
+	}
+	splitCmd.Flags().Uint64Var(&migration, "migration", 0, "The migration number to split (defaults to the latest)")
+
+	statusCmd := &cobra.Command{
+		Use:   "status <db-name>",
+		Short: "Shows the phase of each environment in the database's rollouts",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			db := rolloutDatabase(appRoot, args[0])
+			state, err := expandcontract.LoadState(expandcontract.MigrationDir(appRoot, db))
+			if err != nil {
+				fatalf("unable to load rollout state: %v", err)
+			}
+			if len(state.Rollouts) == 0 {
+				fmt.Printf("No rollouts for database %s.\n", db.Name)
+				return
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			_, _ = fmt.Fprintln(tw, "MIGRATION\tCONTRACT\tENVIRONMENT\tPHASE")
+			for _, name := range slices.Sorted(maps.Keys(state.Rollouts)) {
+				r := state.Rollouts[name]
+				contract := "pending"
+				if r.Promoted != "" {
+					contract = r.Promoted
+				}
+				if len(r.Environments) == 0 {
+					_, _ = fmt.Fprintf(tw, "%s\t%s\t-\t-\n", name, contract)
+				}
+				for _, env := range slices.Sorted(maps.Keys(r.Environments)) {
+					_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name, contract, env, r.Environments[env])
+				}
+			}
+			_ = tw.Flush()
+		},
+	}
+
+	var env string
+	phase := cmdutil.Oneof{
+		Value:   string(expandcontract.Expand),
+		Allowed: []string{string(expandcontract.Expand), string(expandcontract.Contract)},
+		Flag:    "phase",
+		Desc:    "The phase the environment is in",
+	}
+	markCmd := &cobra.Command{
+		Use:   "mark <db-name> <migration> --env=<name> [--phase=expand|contract]",
+		Short: "Records the phase an environment is in for a rollout",
+		Long: `Records the phase an environment is in for a rollout, identified by the
+number or filename of the split migration.
+
+Mark an environment as being in the expand phase once the expand migration is
+applied there, and in the contract phase once the contract migration is.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			db := rolloutDatabase(appRoot, args[0])
+			dir := expandcontract.MigrationDir(appRoot, db)
+			state, err := expandcontract.LoadState(dir)
+			if err != nil {
+				fatalf("unable to load rollout state: %v", err)
+			}
+			name, r := findRollout(state, args[1])
+			r.SetPhase(env, expandcontract.Phase(phase.Value))
+			if err := state.Save(dir); err != nil {
+				fatalf("unable to save rollout state: %v", err)
+			}
+			fmt.Printf("Marked environment %s as in the %s phase of %s.\n", env, phase.Value, name)
+		},
+	}
+	markCmd.Flags().StringVarP(&env, "env", "e", "", "The environment name")
+	_ = markCmd.MarkFlagRequired("env")
+	phase.AddFlag(markCmd)
+
+	contractCmd := &cobra.Command{
+		Use:   "contract <db-name> <migration>",
+		Short: "Promotes the contract phase of a rollout to a migration",
+		Long: `Promotes the contract phase of a rollout, identified by the number or
+filename of the split migration, to a new migration that is applied
+with the next deploy.
+
+Only do this once no environment runs the version of the app
+from before the expand migration.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			db := rolloutDatabase(appRoot, args[0])
+			dir := expandcontract.MigrationDir(appRoot, db)
+			state, err := expandcontract.LoadState(dir)
+			if err != nil {
+				fatalf("unable to load rollout state: %v", err)
+			}
+			name, r := findRollout(state, args[1])
+			if r.Promoted != "" {
+				fatalf("the contract phase of %s has already been promoted to %s", name, r.Promoted)
+			}
+			if len(r.Environments) == 0 {
+				fmt.Fprintf(os.Stderr, "warning: no environments have been marked as expanded for %s\n", name)
+			}
+
+			var next uint64
+			for _, m := range db.Migrations {
+				next = max(next, m.Number+1)
+			}
+			desc := "_contract"
+			if m := migrationFilenameRe.FindStringSubmatch(name); m != nil {
+				desc = m[1] + desc
+			}
+			promoted := fmt.Sprintf("%d%s.up.sql", next, desc)
+
+			src := filepath.Join(dir, expandcontract.ContractDir, r.ContractFile)
+			data, err := os.ReadFile(src)
+			if err != nil {
+				fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, promoted), data, 0644); err != nil {
+				fatal(err)
+			}
+			if err := os.Remove(src); err != nil {
+				fatal(err)
+			}
+			r.Promoted = promoted
+			if err := state.Save(dir); err != nil {
+				fatalf("unable to save rollout state: %v", err)
+			}
+			fmt.Printf("Promoted the contract phase of %s to migration %s.\n", name, promoted)
+		},
+	}
+
+	dbRolloutCmd.AddCommand(planCmd, splitCmd, statusCmd, markCmd, contractCmd)
+	dbCmd.AddCommand(dbRolloutCmd)
+}
+
+var migrationFilenameRe = regexp.MustCompile(`^\d+(_[^.]+)?\.up\.sql$`)
+
+// rolloutDatabase returns the metadata of the database with the given name.
+func rolloutDatabase(appRoot, name string) *meta.SQLDatabase {
+	ctx := context.Background()
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot: appRoot,
+		Environ: os.Environ(),
+		Format:  daemonpb.DumpMetaRequest_FORMAT_PROTO,
+	})
+	if err != nil {
+		fatal(err)
+	}
+	md := &meta.Data{}
+	if err := proto.Unmarshal(resp.Meta, md); err != nil {
+		fatalf("unable to parse app metadata: %v", err)
+	}
+	for _, db := range md.SqlDatabases {
+		if db.Name == name {
+			return db
+		}
+	}
+	fatalf("database %s not found", name)
+	return nil
+}
+
+// planRollout plans the split of the migration with the given number,
+// or the latest migration if it's zero.
+func planRollout(appRoot string, db *meta.SQLDatabase, number uint64) (expandcontract.Migration, *expandcontract.Plan) {
+	migrations, err := expandcontract.Migrations(appRoot, db)
+	if err != nil {
+		fatal(err)
+	} else if len(migrations) == 0 {
+		fatalf("database %s has no migrations", db.Name)
+	}
+
+	idx := len(migrations) - 1
+	if number != 0 {
+		idx = slices.IndexFunc(migrations, func(m expandcontract.Migration) bool { return m.Number == number })
+		if idx < 0 {
+			fatalf("database %s has no migration %d", db.Name, number)
+		}
+	}
+	before := expandcontract.BuildSchema(migrations[:idx])
+	return migrations[idx], expandcontract.NewPlan(before, migrations[idx].SQL)
+}
+
+// findRollout finds the rollout of the migration with the given number or filename.
+func findRollout(state *expandcontract.State, migration string) (string, *expandcontract.Rollout) {
+	if r, ok := state.Rollouts[migration]; ok {
+		return migration, r
+	}
+	if n, err := strconv.ParseUint(migration, 10, 64); err == nil {
+		for name, r := range state.Rollouts {
+			if m := migrationNumberRe.FindStringSubmatch(name); m != nil && m[1] == strconv.FormatUint(n, 10) {
+				return name, r
+			}
+		}
+	}
+	fatalf("no rollout for migration %s", migration)
+	return "", nil
+}
+
+var migrationNumberRe = regexp.MustCompile(`^0*(\d+)`)
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+func init() {
+	var (
+		force bool
+		list  bool
+	)
+	dbSeedCmd := &cobra.Command{
+		Use:   "seed [<database-names...>] [--force] [--list]",
+		Short: "Seeds the local databases with the seeds declared in encore.app",
+		Long: `Applies the seeds declared for the app's databases in encore.app that
+haven't been applied in the namespace. If no database names are given
+the seeds of all databases are applied.
+
+Use --force to apply seeds that have already been applied again,
+and --list to list the seeds and whether they've been applied.`,
+
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			if list {
+				if len(args) > 0 || force {
+					fatal("--list cannot be combined with database names or --force")
+				}
+				listDBSeeds(appRoot)
+				return
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			daemon := setupDaemon(ctx)
+			stream, err := daemon.DBSeed(ctx, &daemonpb.DBSeedRequest{
+				AppRoot:       appRoot,
+				DatabaseNames: args,
+				Namespace:     nonZeroPtr(nsName),
+				Force:         force,
+				Environ:       os.Environ(),
+			})
+			if err != nil {
+				fatal("seed databases: ", err)
+			}
+			os.Exit(cmdutil.StreamCommandOutput(stream, nil))
+		},
+	}
+	dbSeedCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+	dbSeedCmd.Flags().BoolVar(&force, "force", false, "Apply seeds that have already been applied again")
+	dbSeedCmd.Flags().BoolVar(&list, "list", false, "List the seeds and whether they've been applied")
+
+	dbCmd.AddCommand(dbSeedCmd)
+}
+
+func listDBSeeds(appRoot string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.DBSeedStatus(ctx, &daemonpb.DBSeedStatusRequest{
+		AppRoot:   appRoot,
+		Namespace: nonZeroPtr(nsName),
+	})
+	if err != nil {
+		fatal(err)
+	}
+	if len(resp.Seeds) == 0 {
+		fmt.Println("The app declares no database seeds.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "DATABASE\tSEED\tKIND\tPATH\tAPPLIED")
+	for _, s := range resp.Seeds {
+		applied := "no"
+		if s.AppliedAt != nil {
+			applied = *s.AppliedAt
+			if t, err := time.Parse(time.RFC3339, applied); err == nil {
+				applied = t.Local().Format("Jan 2 15:04:05")
+			}
+			if s.Changed {
+				applied += " (changed since)"
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.Database, s.Name, s.Kind, s.Path, applied)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var dbSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Exports and imports the local databases as portable snapshots",
+	Long: `Dumps the databases of a namespace to a snapshot file, and restores
+snapshots into another namespace or on another machine. Use it to share
+a reproducible dataset, or to save and return to the state of a namespace.`,
+}
+
+func init() {
+	var (
+		dbNames []string
+		force   bool
+	)
+	createCmd := &cobra.Command{
+		Use:   "create [<file>] [--db=<name>...] [--force]",
+		Short: "Dumps the local databases to a snapshot file",
+		Long: `Dumps the databases of the namespace to a snapshot file, by default
+<namespace>-<timestamp>.encore-snapshot in the current directory.
+Databases that haven't been created in the namespace yet are left out.`,
+		Args: cobra.MaximumNArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			path := fmt.Sprintf("%s-%s.encore-snapshot", cmp.Or(nsName, "active"), time.Now().Format("20060102-150405"))
+			if len(args) > 0 {
+				path = args[0]
+			}
+			path, err := filepath.Abs(path)
+			if err != nil {
+				fatal(err)
+			}
+			if !force {
+				if _, err := os.Stat(path); err == nil {
+					fatalf("%s already exists (use --force to overwrite it)", path)
+				} else if !errors.Is(err, fs.ErrNotExist) {
+					fatal(err)
+				}
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.DBSnapshotCreate(ctx, &daemonpb.DBSnapshotCreateRequest{
+				AppRoot:       appRoot,
+				Namespace:     nonZeroPtr(nsName),
+				DatabaseNames: dbNames,
+				Path:          path,
+			})
+			if err != nil {
+				fatal("create snapshot: ", err)
+			}
+			if len(resp.Databases) == 0 {
+				fmt.Fprintf(os.Stderr, "Wrote an empty snapshot of namespace %s to %s: no databases have been created yet.\n", resp.Namespace, path)
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Wrote a snapshot of namespace %s to %s.\n", resp.Namespace, path)
+			fmt.Fprintf(os.Stderr, "Databases: %s\n", strings.Join(resp.Databases, ", "))
+		},
+	}
+	createCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+	createCmd.Flags().StringSliceVar(&dbNames, "db", nil, "Databases to snapshot (defaults to all)")
+	createCmd.Flags().BoolVar(&force, "force", false, "Overwrite the file if it exists")
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore <file> [--db=<name>...]",
+		Short: "Restores the databases in a snapshot file into the local namespace",
+		Long: `Restores the databases in a snapshot file into the namespace, replacing
+the databases of the same name. Databases in the snapshot the app doesn't
+declare are skipped.
+
+The restored databases keep the migrations applied when the snapshot was
+created; newer migrations are applied the next time the app runs.`,
+		Args: cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			path, err := filepath.Abs(args[0])
+			if err != nil {
+				fatal(err)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.DBSnapshotRestore(ctx, &daemonpb.DBSnapshotRestoreRequest{
+				AppRoot:       appRoot,
+				Namespace:     nonZeroPtr(nsName),
+				DatabaseNames: dbNames,
+				Path:          path,
+			})
+			if err != nil {
+				fatal("restore snapshot: ", err)
+			}
+			fmt.Fprintf(os.Stderr, "Restored a snapshot of namespace %s (app %s), created %s.\n", resp.Namespace, resp.AppId, resp.CreatedAt)
+			if len(resp.Databases) > 0 {
+				fmt.Fprintf(os.Stderr, "Databases: %s\n", strings.Join(resp.Databases, ", "))
+			}
+			if len(resp.Skipped) > 0 {
+				fmt.Fprintf(os.Stderr, "Skipped: %s\n", strings.Join(resp.Skipped, ", "))
+			}
+		},
+	}
+	restoreCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
+	restoreCmd.Flags().StringSliceVar(&dbNames, "db", nil, "Databases to restore (defaults to all)")
+
+	dbSnapshotCmd.AddCommand(createCmd, restoreCmd)
+	dbCmd.AddCommand(dbSnapshotCmd)
+}
@@ -1,12 +1,21 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
 	daemonpb "encr.dev/proto/encore/daemon"
@@ -70,9 +79,162 @@ func init() {
 
 	format.AddFlag(dumpMeta)
 	dumpMeta.Flags().BoolVar(&p.ParseTests, "tests", false, "Parse tests as well")
+
+	var deadlinesTraceID string
+	var deadlinesLimit int
+	deadlines := &cobra.Command{
+		Use:   "deadlines",
+		Short: "Reports requests whose deadline was exceeded, canceled, or not propagated",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			analyzeDeadlines(appRoot, deadlinesTraceID, deadlinesLimit)
+		},
+	}
+	deadlines.Flags().StringVar(&deadlinesTraceID, "trace", "", "Only analyze the trace with the given id")
+	deadlines.Flags().IntVar(&deadlinesLimit, "limit", 100, "Maximum number of recent traces to analyze")
+
+	usageBy := cmdutil.Oneof{
+		Value:   "endpoint",
+		Allowed: []string{"endpoint", "uid"},
+		Flag:    "by",
+		Desc:    "Group the usage by endpoint or by authenticated user",
+	}
+	usageFormat := cmdutil.Oneof{
+		Value:     "table",
+		Allowed:   []string{"table", "json", "csv"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Output format",
+	}
+	var usageLimit int
+	usage := &cobra.Command{
+		Use:   "usage",
+		Short: "Reports which endpoints and users drive database, cache, Pub/Sub and HTTP usage",
+		Long: "Attributes the database queries, cache operations, Pub/Sub publishes and external\n" +
+			"HTTP calls in recently recorded traces to the requests that made them, and reports\n" +
+			"the totals by endpoint or by authenticated user. Use --format=csv or --format=json\n" +
+			"to export the report.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			by := daemonpb.UsageReportRequest_GROUP_BY_ENDPOINT
+			if usageBy.Value == "uid" {
+				by = daemonpb.UsageReportRequest_GROUP_BY_UID
+			}
+			usageReport(appRoot, by, usageLimit, usageFormat.Value)
+		},
+	}
+	usageBy.AddFlag(usage)
+	usageFormat.AddFlag(usage)
+	usage.Flags().IntVar(&usageLimit, "limit", 1000, "Maximum number of recent traces to analyze")
+
+	var bundlesJSON bool
+	bundles := &cobra.Command{
+		Use:   "bundles [trace-id]",
+		Short: "Lists the debug bundles of failed requests, or shows the bundles of a trace",
+		Long: "Lists the debug bundles captured for failed requests of an app run with 'encore run --debug-bundles'.\n" +
+			"Given a trace id, it outputs the bundles of that trace: the request payload,\n" +
+			"database rows read and Pub/Sub messages published by the failed requests.",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			var traceID string
+			if len(args) > 0 {
+				traceID = args[0]
+			}
+			showDebugBundles(appRoot, traceID, bundlesJSON)
+		},
+	}
+	bundles.Flags().BoolVar(&bundlesJSON, "json", false, "Output the bundles as JSON lines")
+
+	logpointCmd := &cobra.Command{
+		Use:   "logpoint",
+		Short: "Manages logpoints: locations in a running app at which expressions are logged",
+		Long: "Logpoints evaluate Go expressions each time a location in a running service is reached\n" +
+			"and write their values to the output of 'encore run', without changing the code or\n" +
+			"restarting the app. They use Delve (dlv) and are removed when the app reloads.\n" +
+			"Run the app with 'encore run --debug' for variables not to be optimized away.",
+	}
+
+	var logpointCond string
+	logpointAdd := &cobra.Command{
+		Use:   "add <service> <location> <expr>...",
+		Short: "Adds a logpoint at a \"file.go:line\" or function (such as \"users.Get\") of a service",
+		Args:  cobra.MinimumNArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			addLogpoint(appRoot, args[0], args[1], args[2:], logpointCond)
+		},
+	}
+	logpointAdd.Flags().StringVar(&logpointCond, "if", "", "Only log when the given Go expression is true")
+
+	logpointList := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Lists the logpoints of the running app",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			listLogpoints(appRoot)
+		},
+	}
+
+	logpointRemove := &cobra.Command{
+		Use:     "remove <id>...",
+		Aliases: []string{"rm"},
+		Short:   "Removes logpoints",
+		Args:    cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			removeLogpoints(appRoot, args)
+		},
+	}
+	logpointCmd.AddCommand(logpointAdd, logpointList, logpointRemove)
+
+	var goroutinesRaw bool
+	var goroutinesMinWait int
+	goroutinesCmd := &cobra.Command{
+		Use:   "goroutines [service...]",
+		Short: "Dumps the goroutines of the running app and reports likely deadlocks",
+		Long: "Captures a dump of all goroutines of each process of the running app, or of the\n" +
+			"processes running the given services, without stopping them. Identical goroutines\n" +
+			"are grouped, and goroutines in the app's code that have been blocked on a channel,\n" +
+			"mutex or similar for a while are reported as suspected deadlocks.",
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			dumpGoroutines(appRoot, args, goroutinesMinWait, goroutinesRaw)
+		},
+	}
+	goroutinesCmd.Flags().BoolVar(&goroutinesRaw, "raw", false, "Output the full goroutine dumps instead of the report")
+	goroutinesCmd.Flags().IntVar(&goroutinesMinWait, "min-wait", 1, "Minutes a goroutine must have been blocked to be suspected deadlocked")
+
+	var startupJSON bool
+	startupCmd := &cobra.Command{
+		Use:   "startup",
+		Short: "Reports where the time went when the processes of the running app started",
+		Long: "Reports how long each process of the running app took to start, broken down into\n" +
+			"package initialization (including setting up infrastructure resources), runtime setup\n" +
+			"and service initialization, along with the slowest resources and services and how long\n" +
+			"starting the app waited for database migrations. Built apps log the same breakdown\n" +
+			"when they start.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			startupReport(appRoot, startupJSON)
+		},
+	}
+	startupCmd.Flags().BoolVar(&startupJSON, "json", false, "Output the report as JSON")
+
 	rootCmd.AddCommand(debugCmd)
 	debugCmd.AddCommand(buildCmd)
 	debugCmd.AddCommand(dumpMeta)
+	debugCmd.AddCommand(deadlines)
+	debugCmd.AddCommand(usage)
+	debugCmd.AddCommand(bundles)
+	debugCmd.AddCommand(logpointCmd)
+	debugCmd.AddCommand(goroutinesCmd)
+	debugCmd.AddCommand(startupCmd)
 }
 
 func runDebugBuild(appRoot, relPath string) {
@@ -119,3 +281,351 @@ func dumpMeta(p dumpMetaParams) {
 	}
 	_, _ = os.Stdout.Write(resp.Meta)
 }
+
+func analyzeDeadlines(appRoot, traceID string, limit int) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	req := &daemonpb.AnalyzeDeadlinesRequest{
+		AppRoot: appRoot,
+		Limit:   int32(limit),
+	}
+	if traceID != "" {
+		req.TraceId = &traceID
+	}
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.AnalyzeDeadlines(ctx, req)
+	if err != nil {
+		fatal(err)
+	}
+
+	if len(resp.Findings) == 0 {
+		fmt.Printf("No deadline issues found in %d trace(s).\n", resp.TracesAnalyzed)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TRACE\tSPAN\tENDPOINT\tISSUE\tDEADLINE\tDURATION\tCAUSE")
+	for _, f := range resp.Findings {
+		deadline := "-"
+		if f.DeadlineRemainingNanos != nil {
+			deadline = time.Duration(*f.DeadlineRemainingNanos).String()
+		} else if f.ParentDeadlineRemainingNanos != nil {
+			deadline = "none (caller: " + time.Duration(*f.ParentDeadlineRemainingNanos).String() + ")"
+		}
+		issue := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(f.Issue.String(), "ISSUE_"), "_", " "))
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s.%s\t%s\t%s\t%s\t%s\n",
+			f.TraceId, f.SpanId, f.ServiceName, f.EndpointName, issue, deadline,
+			time.Duration(f.DurationNanos), f.GetCancelCause())
+	}
+	_ = w.Flush()
+	fmt.Printf("\nFound %d deadline issue(s) in %d trace(s).\n", len(resp.Findings), resp.TracesAnalyzed)
+}
+
+func usageReport(appRoot string, by daemonpb.UsageReportRequest_GroupBy, limit int, format string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.UsageReport(ctx, &daemonpb.UsageReportRequest{
+		AppRoot: appRoot,
+		GroupBy: by,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		fatal(err)
+	}
+
+	key := func(g *daemonpb.UsageGroup) string {
+		if by == daemonpb.UsageReportRequest_GROUP_BY_UID {
+			if g.Uid == "" {
+				return "(unauthenticated)"
+			}
+			return g.Uid
+		}
+		return g.ServiceName + "." + g.EndpointName
+	}
+
+	switch format {
+	case "json":
+		data, err := protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+			Multiline:       true,
+		}.Marshal(resp)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(string(data))
+
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		keyCol := "endpoint"
+		if by == daemonpb.UsageReportRequest_GROUP_BY_UID {
+			keyCol = "uid"
+		}
+		_ = w.Write([]string{keyCol, "requests", "request_ms", "db_queries", "db_ms", "cache_ops", "cache_ms",
+			"publishes", "publish_ms", "http_calls", "http_ms"})
+		ms := func(n uint64) string { return strconv.FormatFloat(float64(n)/1e6, 'f', 3, 64) }
+		for _, g := range resp.Groups {
+			_ = w.Write([]string{key(g),
+				strconv.Itoa(int(g.Requests)), ms(g.RequestNanos),
+				strconv.Itoa(int(g.DbQueries)), ms(g.DbNanos),
+				strconv.Itoa(int(g.CacheOps)), ms(g.CacheNanos),
+				strconv.Itoa(int(g.Publishes)), ms(g.PublishNanos),
+				strconv.Itoa(int(g.HttpCalls)), ms(g.HttpNanos),
+			})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			fatal(err)
+		}
+
+	default:
+		if len(resp.Groups) == 0 {
+			fmt.Printf("No requests found in %d trace(s).\n", resp.TracesAnalyzed)
+			return
+		}
+		keyCol := "ENDPOINT"
+		if by == daemonpb.UsageReportRequest_GROUP_BY_UID {
+			keyCol = "UID"
+		}
+		usage := func(n int32, nanos uint64) string {
+			if n == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%d (%s)", n, time.Duration(nanos).Round(time.Microsecond))
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(w, "%s\tREQUESTS\tDB QUERIES\tCACHE OPS\tPUBLISHES\tHTTP CALLS\n", keyCol)
+		for _, g := range resp.Groups {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", key(g),
+				usage(g.Requests, g.RequestNanos), usage(g.DbQueries, g.DbNanos), usage(g.CacheOps, g.CacheNanos),
+				usage(g.Publishes, g.PublishNanos), usage(g.HttpCalls, g.HttpNanos))
+		}
+		_ = w.Flush()
+		fmt.Printf("\nAnalyzed %d trace(s). Durations are totals.\n", resp.TracesAnalyzed)
+	}
+}
+
+func showDebugBundles(appRoot, traceID string, asJSON bool) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	req := &daemonpb.DebugBundlesRequest{AppRoot: appRoot}
+	if traceID != "" {
+		req.TraceId = &traceID
+	}
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.DebugBundles(ctx, req)
+	if err != nil {
+		fatal(err)
+	}
+
+	if asJSON || traceID != "" {
+		for _, b := range resp.Bundles {
+			if asJSON {
+				_, _ = fmt.Fprintf(os.Stdout, "%s\n", b.Data)
+				continue
+			}
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, b.Data, "", "  "); err != nil {
+				fatal(err)
+			}
+			buf.WriteByte('\n')
+			_, _ = buf.WriteTo(os.Stdout)
+		}
+		if traceID != "" && len(resp.Bundles) == 0 {
+			fatalf("no debug bundles found for trace %s", traceID)
+		}
+		return
+	}
+
+	if len(resp.Bundles) == 0 {
+		fmt.Println("No debug bundles captured. Run your app with 'encore run --debug-bundles' to capture them.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TIME\tTRACE\tENDPOINT\tCODE\tERROR")
+	for _, b := range resp.Bundles {
+		errMsg, _, _ := strings.Cut(b.Error, "\n")
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s.%s\t%s\t%s\n", b.Time, b.TraceId, b.Service, b.Endpoint, b.Code, errMsg)
+	}
+	_ = w.Flush()
+}
+
+func addLogpoint(appRoot, service, location string, exprs []string, cond string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	// Resolve file locations relative to the working directory,
+	// so they don't match files with the same name elsewhere.
+	if file, line, ok := strings.Cut(location, ":"); ok && strings.HasSuffix(file, ".go") {
+		if abs, err := filepath.Abs(file); err == nil {
+			if _, err := os.Stat(abs); err == nil {
+				location = abs + ":" + line
+			}
+		}
+	}
+
+	req := &daemonpb.AddLogpointRequest{
+		AppRoot:  appRoot,
+		Service:  service,
+		Location: location,
+		Exprs:    exprs,
+	}
+	if cond != "" {
+		req.Condition = &cond
+	}
+
+	daemon := setupDaemon(ctx)
+	lp, err := daemon.AddLogpoint(ctx, req)
+	if err != nil {
+		fatal(err)
+	}
+	fmt.Printf("Added logpoint #%d. Its values are written to the output of 'encore run'.\n", lp.Id)
+}
+
+func listLogpoints(appRoot string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.ListLogpoints(ctx, &daemonpb.ListLogpointsRequest{AppRoot: appRoot})
+	if err != nil {
+		fatal(err)
+	}
+	if len(resp.Logpoints) == 0 {
+		fmt.Println("No logpoints. Add one with 'encore debug logpoint add'.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tSERVICE\tLOCATION\tEXPRESSIONS\tCONDITION")
+	for _, lp := range resp.Logpoints {
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", lp.Id, lp.Service, lp.Location, strings.Join(lp.Exprs, ", "), lp.GetCondition())
+	}
+	_ = w.Flush()
+}
+
+func removeLogpoints(appRoot string, ids []string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	for _, arg := range ids {
+		id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 32)
+		if err != nil {
+			fatalf("invalid logpoint id %q", arg)
+		}
+		if _, err := daemon.RemoveLogpoint(ctx, &daemonpb.RemoveLogpointRequest{AppRoot: appRoot, Id: int32(id)}); err != nil {
+			fatal(err)
+		}
+	}
+}
+
+func dumpGoroutines(appRoot string, services []string, minWait int, raw bool) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.GoroutineDump(ctx, &daemonpb.GoroutineDumpRequest{
+		AppRoot:        appRoot,
+		Services:       services,
+		MinWaitMinutes: int32(minWait),
+	})
+	if err != nil {
+		fatal(err)
+	}
+
+	for i, p := range resp.Processes {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("=== Process %d (%s)\n", p.Pid, strings.Join(p.Services, ", "))
+		switch {
+		case p.Error != "":
+			fmt.Printf("error: %s\n", p.Error)
+		case raw:
+			fmt.Print(p.Dump)
+		default:
+			fmt.Print(p.Report)
+		}
+	}
+}
+
+// maxStartupItems is the number of resources and services
+// listed in the startup report of each process.
+const maxStartupItems = 5
+
+func startupReport(appRoot string, asJSON bool) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.StartupReport(ctx, &daemonpb.StartupReportRequest{AppRoot: appRoot})
+	if err != nil {
+		fatal(err)
+	}
+
+	if asJSON {
+		data, err := protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+			Multiline:       true,
+		}.Marshal(resp)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	dur := func(ns int64) string { return time.Duration(ns).Round(time.Millisecond).String() }
+	if resp.MigrationsWaitNs > 0 {
+		fmt.Printf("Starting the app waited %s for database migrations after compiling.\n\n", dur(resp.MigrationsWaitNs))
+	}
+	for i, p := range resp.Processes {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("=== Process %d (%s)\n", p.Pid, strings.Join(p.Services, ", "))
+		if p.Error != "" {
+			fmt.Printf("error: %s\n", p.Error)
+			continue
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		if p.Complete {
+			_, _ = fmt.Fprintf(tw, "total\t%s\n", dur(p.TotalNs))
+		} else {
+			_, _ = fmt.Fprintf(tw, "total\t(still initializing services)\n")
+		}
+		_, _ = fmt.Fprintf(tw, "  package init\t%s\t(of which resource setup %s)\n", dur(p.PackageInitNs), dur(p.ResourceSetupNs))
+		_, _ = fmt.Fprintf(tw, "  runtime setup\t%s\n", dur(p.RuntimeSetupNs))
+		if p.Complete {
+			_, _ = fmt.Fprintf(tw, "  service init\t%s\n", dur(p.ServiceInitNs))
+		}
+		_ = tw.Flush()
+
+		list := func(title string, timings []*daemonpb.StartupTiming) {
+			if len(timings) == 0 {
+				return
+			}
+			fmt.Printf("%s:\n", title)
+			tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			for _, t := range timings[:min(len(timings), maxStartupItems)] {
+				name := t.Name
+				if t.Kind != "" {
+					name = t.Kind + " " + t.Name
+				}
+				_, _ = fmt.Fprintf(tw, "  %s\t%s\n", name, dur(t.DurationNs))
+			}
+			_ = tw.Flush()
+		}
+		list("slowest resources", p.Resources)
+		list("slowest services", p.ServicesInit)
+	}
+}
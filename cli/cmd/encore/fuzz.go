@@ -0,0 +1,366 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/logrusorgru/aurora/v3"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/pkg/fuzzgen"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+type fuzzParams struct {
+	BaseURL    string
+	Iterations int
+	Seed       int64
+	Modes      []fuzzgen.Mode
+	Headers    http.Header
+	OutDir     string
+	GoCorpus   bool
+}
+
+func init() {
+	var (
+		p       fuzzParams
+		all     bool
+		modes   []string
+		headers []string
+	)
+
+	fuzzCmd := &cobra.Command{
+		Use:   "fuzz [service.endpoint | service]... [--all]",
+		Short: "Fuzz tests API endpoints of the locally running app",
+		Long: `Fuzz tests API endpoints of the locally running app.
+
+Requests are generated from the endpoints' request schemas. By default a mix of
+schema-valid requests, boundary values, and invalid requests is sent.
+Requests that fail with a 5xx status code (including panics) or that
+make the app close the connection are reported, and a reproducer
+file is written for each unique failure.
+
+The app must already be running (with "encore run").`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 && !all {
+				fatal("no endpoints specified; specify endpoints to fuzz or use --all")
+			}
+			p.Modes = nil
+			for _, m := range modes {
+				mode, err := fuzzgen.ParseMode(m)
+				if err != nil {
+					fatal(err)
+				}
+				p.Modes = append(p.Modes, mode)
+			}
+			p.Headers = make(http.Header)
+			for _, h := range headers {
+				key, val, ok := strings.Cut(h, ":")
+				if !ok {
+					fatalf("invalid header %q: must be in the form 'Key: Value'", h)
+				}
+				p.Headers.Add(strings.TrimSpace(key), strings.TrimSpace(val))
+			}
+			if p.Seed == 0 {
+				p.Seed = time.Now().UnixNano()
+			}
+
+			appRoot, _ := determineAppRoot()
+			if p.OutDir == "" {
+				p.OutDir = filepath.Join(appRoot, ".encore", "fuzz")
+			}
+			runFuzz(appRoot, args, p)
+		},
+	}
+	fuzzCmd.Flags().BoolVar(&all, "all", false, "Fuzz all endpoints")
+	fuzzCmd.Flags().StringVar(&p.BaseURL, "url", "http://localhost:4000", "Base URL of the running app")
+	fuzzCmd.Flags().IntVarP(&p.Iterations, "iterations", "n", 100, "Number of requests to send per endpoint")
+	fuzzCmd.Flags().Int64Var(&p.Seed, "seed", 0, "Seed for generating requests (defaults to a random seed)")
+	fuzzCmd.Flags().StringSliceVar(&modes, "mode", []string{"valid", "boundary", "invalid"}, "Kinds of requests to generate (valid, boundary, invalid)")
+	fuzzCmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "Header to add to every request, like 'Authorization: Bearer token' (can be repeated)")
+	fuzzCmd.Flags().StringVar(&p.OutDir, "out", "", "Directory to write reproducer files to (defaults to .encore/fuzz in the app root)")
+	fuzzCmd.Flags().BoolVar(&p.GoCorpus, "go-corpus", false, "Also add the bodies of failing requests to the seed corpus of Go fuzz tests named Fuzz<Endpoint>")
+
+	var replayURL string
+	replayCmd := &cobra.Command{
+		Use:   "replay <reproducer-file>...",
+		Short: "Replays requests from fuzz reproducer files",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			replayFuzz(replayURL, args)
+		},
+	}
+	replayCmd.Flags().StringVar(&replayURL, "url", "http://localhost:4000", "Base URL of the running app")
+
+	fuzzCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(fuzzCmd)
+}
+
+// fuzzReproducer is the contents of a reproducer file.
+type fuzzReproducer struct {
+	Endpoint string           `json:"endpoint"`
+	Mode     string           `json:"mode"`
+	Seed     int64            `json:"seed"`
+	Request  *fuzzgen.Request `json:"request"`
+	Status   int              `json:"status,omitempty"`
+	Response string           `json:"response,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// fuzzResult is the outcome of sending a fuzz request.
+type fuzzResult struct {
+	Status int
+	Body   string
+	Err    error
+}
+
+// failed reports whether the result indicates a bug in the app.
+func (r fuzzResult) failed() bool {
+	return r.Err != nil || r.Status >= 500
+}
+
+// signature identifies the failure, for deduplicating reports.
+func (r fuzzResult) signature() string {
+	if r.Err != nil {
+		return "error: " + r.Err.Error()
+	}
+	firstLine, _, _ := strings.Cut(r.Body, "\n")
+	return fmt.Sprintf("%d: %s", r.Status, firstLine)
+}
+
+func runFuzz(appRoot string, selectors []string, p fuzzParams) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot: appRoot,
+		Environ: os.Environ(),
+		Format:  daemonpb.DumpMetaRequest_FORMAT_PROTO,
+	})
+	if err != nil {
+		fatal(err)
+	}
+	md := &meta.Data{}
+	if err := proto.Unmarshal(resp.Meta, md); err != nil {
+		fatalf("unable to parse app metadata: %v", err)
+	}
+
+	rpcs, err := selectFuzzEndpoints(md, selectors)
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Fuzzing %d endpoint(s) at %s (seed %d)\n", len(rpcs), p.BaseURL, p.Seed)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	gen := fuzzgen.New(md, rand.NewSource(p.Seed))
+
+	type summary struct {
+		endpoint string
+		requests int
+		failures map[string]int // signature -> count
+	}
+	var summaries []*summary
+	var reproducers []string
+
+	for _, rpc := range rpcs {
+		endpoint := rpc.ServiceName + "." + rpc.Name
+		s := &summary{endpoint: endpoint, failures: make(map[string]int)}
+		summaries = append(summaries, s)
+
+		for i := 0; i < p.Iterations && ctx.Err() == nil; i++ {
+			mode := p.Modes[i%len(p.Modes)]
+			req := gen.Request(rpc, mode)
+			res := sendFuzzRequest(ctx, client, p.BaseURL, req, p.Headers)
+			s.requests++
+			if !res.failed() || ctx.Err() != nil {
+				continue
+			}
+
+			sig := res.signature()
+			s.failures[sig]++
+			if s.failures[sig] > 1 {
+				continue
+			}
+
+			fmt.Fprintf(os.Stderr, "%s %s %s: %s\n", aurora.Red("FAIL"), endpoint, mode, sig)
+			repro := fuzzReproducer{
+				Endpoint: endpoint,
+				Mode:     mode.String(),
+				Seed:     p.Seed,
+				Request:  req,
+				Status:   res.Status,
+				Response: res.Body,
+			}
+			if res.Err != nil {
+				repro.Error = res.Err.Error()
+			}
+			path, err := writeFuzzReproducer(p.OutDir, repro)
+			if err != nil {
+				fatalf("unable to write reproducer: %v", err)
+			}
+			reproducers = append(reproducers, path)
+
+			if p.GoCorpus && req.Body != "" {
+				if err := writeGoFuzzSeed(appRoot, md, rpc, req.Body); err != nil {
+					fatalf("unable to write Go fuzz seed: %v", err)
+				}
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "\nENDPOINT\tREQUESTS\tFAILURES\tUNIQUE")
+	failed := false
+	for _, s := range summaries {
+		total := 0
+		for _, n := range s.failures {
+			total += n
+		}
+		failed = failed || total > 0
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", s.endpoint, s.requests, total, len(s.failures))
+	}
+	_ = w.Flush()
+
+	if len(reproducers) > 0 {
+		fmt.Printf("\nWrote %d reproducer(s) to %s.\nReplay them with: encore fuzz replay <file>\n", len(reproducers), p.OutDir)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// selectFuzzEndpoints returns the endpoints matching the selectors,
+// which are either "service" or "service.endpoint".
+// If there are no selectors it returns all endpoints.
+func selectFuzzEndpoints(md *meta.Data, selectors []string) ([]*meta.RPC, error) {
+	var rpcs []*meta.RPC
+	if len(selectors) == 0 {
+		for _, svc := range md.Svcs {
+			rpcs = append(rpcs, svc.Rpcs...)
+		}
+		return rpcs, nil
+	}
+
+	for _, sel := range selectors {
+		svcName, epName, _ := strings.Cut(sel, ".")
+		found := false
+		for _, svc := range md.Svcs {
+			if svc.Name != svcName {
+				continue
+			}
+			for _, rpc := range svc.Rpcs {
+				if epName == "" || rpc.Name == epName {
+					rpcs = append(rpcs, rpc)
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no endpoint matching %q", sel)
+		}
+	}
+	return rpcs, nil
+}
+
+func sendFuzzRequest(ctx context.Context, client *http.Client, baseURL string, r *fuzzgen.Request, headers http.Header) fuzzResult {
+	req, err := http.NewRequestWithContext(ctx, r.Method, strings.TrimSuffix(baseURL, "/")+r.Path, strings.NewReader(r.Body))
+	if err != nil {
+		return fuzzResult{Err: err}
+	}
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+	for k, v := range headers {
+		req.Header[k] = v
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fuzzResult{Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fuzzResult{Status: resp.StatusCode, Body: string(body)}
+}
+
+// writeFuzzReproducer writes repro to dir, returning the path of the file.
+func writeFuzzReproducer(dir string, repro fuzzReproducer) (string, error) {
+	data, err := json.MarshalIndent(repro, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.json", repro.Endpoint, hex.EncodeToString(sum[:6])))
+	return path, os.WriteFile(path, data, 0644)
+}
+
+// writeGoFuzzSeed adds body to the seed corpus of the Go fuzz test Fuzz<Endpoint>
+// in the endpoint's service package, for fuzz tests of the form:
+//
+//	func FuzzCreate(f *testing.F) {
+//		f.Fuzz(func(t *testing.T, body []byte) { ... })
+//	}
+func writeGoFuzzSeed(appRoot string, md *meta.Data, rpc *meta.RPC, body string) error {
+	var relPath string
+	for _, svc := range md.Svcs {
+		if svc.Name == rpc.ServiceName {
+			relPath = svc.RelPath
+		}
+	}
+
+	dir := filepath.Join(appRoot, filepath.FromSlash(relPath), "testdata", "fuzz", "Fuzz"+rpc.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data := fmt.Sprintf("go test fuzz v1\n[]byte(%q)\n", body)
+	sum := sha256.Sum256([]byte(data))
+	return os.WriteFile(filepath.Join(dir, hex.EncodeToString(sum[:8])), []byte(data), 0644)
+}
+
+func replayFuzz(baseURL string, files []string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	failed := false
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fatal(err)
+		}
+		var repro fuzzReproducer
+		if err := json.Unmarshal(data, &repro); err != nil || repro.Request == nil {
+			fatalf("%s: invalid reproducer file", file)
+		}
+
+		res := sendFuzzRequest(ctx, client, baseURL, repro.Request, nil)
+		status := aurora.Green("ok")
+		if res.failed() {
+			status = aurora.Red("FAIL")
+			failed = true
+		}
+		fmt.Printf("%s %s %s %s: %s\n", status, repro.Endpoint, repro.Request.Method, repro.Request.Path, res.signature())
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -5,15 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
 	"encr.dev/cli/internal/manifest"
 	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/clientgen"
+	"encr.dev/pkg/envdocs"
+	"encr.dev/pkg/openapiimport"
 	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 func init() {
@@ -35,6 +41,8 @@ func init() {
 		tsSharedTypes                  bool
 		target                         string
 		tsDefaultClient                string
+		tsHooks                        string
+		tsSchemas                      string
 	)
 
 	genClientCmd := &cobra.Command{
@@ -135,6 +143,8 @@ To further narrow down the services to generate, use the '--services' flag.
 				OpenapiExcludePrivateEndpoints: &openAPIExcludePrivateEndpoints,
 				TsSharedTypes:                  &tsSharedTypes,
 				TsClientTarget:                 &tsDefaultClient,
+				TsHooks:                        &tsHooks,
+				TsSchemas:                      &tsSchemas,
 				AppRoot:                        appRoot,
 			})
 			if err != nil {
@@ -179,8 +189,286 @@ which may require the user-facing wrapper code to be manually generated.`,
 		},
 	}
 
+	var (
+		fromOpenAPI string
+		svcOutput   string
+	)
+	genServiceCmd := &cobra.Command{
+		Use:   "service <name> --from-openapi=<spec>",
+		Short: "Generates a service from an OpenAPI specification",
+		Long: `Generates the stubs of a service implementing the API described by an OpenAPI specification.
+
+Each operation becomes an API endpoint whose handler returns an "unimplemented" error,
+and the schemas it uses become request and response types. Parts of the specification
+that cannot be represented in an Encore API are described by TODO comments.
+
+The specification can be a JSON or YAML file, or an http(s) URL.
+The service is generated in the app's language, in the directory <name>
+relative to the current directory unless --output is given.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if fromOpenAPI == "" {
+				fatal("specify the OpenAPI specification with --from-openapi.")
+			}
+			appRoot, _ := determineAppRoot()
+			lang, err := appfile.AppLang(appRoot)
+			if err != nil {
+				fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+			doc, err := openapiimport.Load(ctx, fromOpenAPI)
+			if err != nil {
+				fatal(err)
+			}
+			files, err := openapiimport.Generate(doc, openapiimport.Options{Service: args[0], Lang: lang})
+			if err != nil {
+				fatal(err)
+			}
+
+			dir := svcOutput
+			if dir == "" {
+				dir = args[0]
+			}
+			for _, f := range files {
+				if _, err := os.Stat(filepath.Join(dir, f.Name)); err == nil {
+					fatalf("%s already exists; remove it or choose another directory with --output.", filepath.Join(dir, f.Name))
+				}
+			}
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				fatal(err)
+			}
+			for _, f := range files {
+				if err := os.WriteFile(filepath.Join(dir, f.Name), f.Content, 0644); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Printf("successfully generated service %s in %s.\n", args[0], dir)
+		},
+	}
+	genServiceCmd.Flags().StringVar(&fromOpenAPI, "from-openapi", "", "The OpenAPI specification to generate the service from (file path or URL)")
+	_ = genServiceCmd.MarkFlagFilename("from-openapi", "json", "yaml", "yml")
+	genServiceCmd.Flags().StringVarP(&svcOutput, "output", "o", "", "The directory to generate the service in (defaults to the service name)")
+	_ = genServiceCmd.MarkFlagDirname("output")
+
+	var (
+		ingestSchema string
+		ingestEvent  string
+		ingestTopic  string
+		ingestPath   string
+		ingestAuth   string
+		ingestAttrs  []string
+		ingestOutput string
+	)
+	genIngestCmd := &cobra.Command{
+		Use:   "ingest <service> --schema=<schema> [--auth=hmac|token|auth] [--attr=<property>[=<attribute>]]",
+		Short: "Generates an endpoint ingesting external events into a Pub/Sub topic",
+		Long: `Generates an endpoint that receives events from external systems, like webhooks
+or IoT devices, validates them against a JSON Schema and publishes them to a Pub/Sub topic.
+
+The schema can be a JSON or YAML file, or a schema in an OpenAPI specification
+referenced like "spec.yaml#/components/schemas/Event". The event type, its validation,
+the topic and the endpoint are generated in the file ingest_<event> in the directory
+<service> relative to the current directory, unless --output is given.
+
+Requests are authenticated with --auth:
+  hmac   the X-Signature header holds the HMAC-SHA256 of the body, signed with a secret (default)
+  token  the Authorization header holds a secret as a bearer token
+  auth   the app's auth handler authenticates requests
+
+Top-level properties of the event are published as message attributes with --attr,
+optionally under another name like --attr=device_id=device.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if ingestSchema == "" {
+				fatal("specify the event schema with --schema.")
+			}
+			appRoot, _ := determineAppRoot()
+			lang, err := appfile.AppLang(appRoot)
+			if err != nil {
+				fatal(err)
+			}
+
+			attrs := make(map[string]string)
+			for _, attr := range ingestAttrs {
+				prop, name, _ := strings.Cut(attr, "=")
+				attrs[prop] = name
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+			schema, err := openapiimport.LoadSchema(ctx, ingestSchema)
+			if err != nil {
+				fatal(err)
+			}
+			files, err := openapiimport.GenerateIngest(schema, openapiimport.IngestOptions{
+				Service: args[0],
+				Lang:    lang,
+				Event:   ingestEvent,
+				Topic:   ingestTopic,
+				Path:    ingestPath,
+				Auth:    openapiimport.IngestAuth(ingestAuth),
+				Attrs:   attrs,
+			})
+			if err != nil {
+				fatal(err)
+			}
+
+			dir := ingestOutput
+			if dir == "" {
+				dir = args[0]
+			}
+			var generated []string
+			for _, f := range files {
+				_, err := os.Stat(filepath.Join(dir, f.Name))
+				switch {
+				case err == nil && f.Name == "encore.service.ts":
+					// The service already exists.
+					continue
+				case err == nil:
+					fatalf("%s already exists; remove it or choose another event name with --event.", filepath.Join(dir, f.Name))
+				}
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, f.Name), f.Content, 0644); err != nil {
+					fatal(err)
+				}
+				generated = append(generated, filepath.Join(dir, f.Name))
+			}
+			fmt.Printf("successfully generated %s.\n", strings.Join(generated, ", "))
+			if ingestAuth != string(openapiimport.IngestAuthHandler) {
+				fmt.Println("set the secret authenticating requests with 'encore secret set'; see the generated endpoint for its name.")
+			}
+		},
+	}
+	genIngestCmd.Flags().StringVar(&ingestSchema, "schema", "", "The JSON Schema of the events (file path, or OpenAPI specification with a #/components/schemas/<name> reference)")
+	_ = genIngestCmd.MarkFlagFilename("schema", "json", "yaml", "yml")
+	genIngestCmd.Flags().StringVar(&ingestEvent, "event", "", "The name of the event type (defaults to the schema name or title)")
+	genIngestCmd.Flags().StringVar(&ingestTopic, "topic", "", "The name of the topic to publish events to (defaults to the event name in kebab-case)")
+	genIngestCmd.Flags().StringVar(&ingestPath, "path", "", "The path of the endpoint (defaults to /ingest/<topic>)")
+	genIngestCmd.Flags().StringVar(&ingestAuth, "auth", string(openapiimport.IngestHMAC), "How requests are authenticated (\"hmac\", \"token\" or \"auth\")")
+	_ = genIngestCmd.RegisterFlagCompletionFunc("auth", cmdutil.AutoCompleteFromStaticList(
+		"hmac\tAn HMAC-SHA256 signature of the body in the X-Signature header",
+		"token\tA bearer token in the Authorization header",
+		"auth\tThe app's auth handler",
+	))
+	genIngestCmd.Flags().StringArrayVar(&ingestAttrs, "attr", nil, "A top-level property to publish as a message attribute, as <property>[=<attribute>] (repeatable)")
+	genIngestCmd.Flags().StringVarP(&ingestOutput, "output", "o", "", "The directory of the service (defaults to the service name)")
+	_ = genIngestCmd.MarkFlagDirname("output")
+
+	var (
+		schemaFormat string
+		schemaOutput string
+	)
+	genSchemasCmd := &cobra.Command{
+		Use:   "schemas [--format=jsonschema|avro] [--output=<dir>]",
+		Short: "Generates JSON Schema or Avro definitions of your app's types",
+		Long: `Generates a schema for the request and response type of each API endpoint,
+and the message type of each Pub/Sub topic, for use with data pipelines and schema registries.
+
+The schemas are written to <output>/<service>/<Endpoint>.request.json (or .avsc for Avro),
+<output>/<service>/<Endpoint>.response.json and <output>/pubsub/<topic>.json.
+
+To regenerate the schemas on every build, configure "schema_export" in the encore.app file.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			ctx := context.Background()
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.ExportSchemas(ctx, &daemonpb.ExportSchemasRequest{
+				AppRoot: appRoot,
+				Format:  schemaFormat,
+			})
+			if err != nil {
+				fatal(err)
+			}
+
+			ext := ".json"
+			if schemaFormat == "avro" {
+				ext = ".avsc"
+			}
+			for _, s := range resp.Schemas {
+				dst := filepath.Join(schemaOutput, filepath.FromSlash(s.Name)+ext)
+				if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+					fatal(err)
+				}
+				if err := os.WriteFile(dst, s.Content, 0644); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Printf("successfully generated %d schemas in %s.\n", len(resp.Schemas), schemaOutput)
+		},
+	}
+	genSchemasCmd.Flags().StringVarP(&schemaFormat, "format", "f", "jsonschema", "The schema format (\"jsonschema\" or \"avro\")")
+	_ = genSchemasCmd.RegisterFlagCompletionFunc("format", cmdutil.AutoCompleteFromStaticList(
+		"jsonschema\tJSON Schema (draft 2020-12)",
+		"avro\tApache Avro",
+	))
+	genSchemasCmd.Flags().StringVarP(&schemaOutput, "output", "o", "schemas", "The directory to write the schemas to")
+	_ = genSchemasCmd.MarkFlagDirname("output")
+
+	var (
+		envDocsFormat string
+		envDocsOutput string
+	)
+	genEnvDocsCmd := &cobra.Command{
+		Use:   "env-docs [--format=markdown|dotenv] [--output=<file>]",
+		Short: "Generates documentation of the environment variables your app declares",
+		Long: `Generates documentation of the environment variables declared with envvar.New,
+with their descriptions, whether they're required, their formats and the services using them.
+
+With --format=markdown (the default) a Markdown table is generated, for operations teams.
+With --format=dotenv a dotenv file with an empty value for each variable is generated,
+to use as a template for --env-file.`,
+		Args: cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			ctx := context.Background()
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+				AppRoot: appRoot,
+				Environ: os.Environ(),
+				Format:  daemonpb.DumpMetaRequest_FORMAT_PROTO,
+			})
+			if err != nil {
+				fatal(err)
+			}
+			md := &meta.Data{}
+			if err := proto.Unmarshal(resp.Meta, md); err != nil {
+				fatalf("unable to parse app metadata: %v", err)
+			}
+
+			var out []byte
+			switch envDocsFormat {
+			case "markdown":
+				out = envdocs.Markdown(md)
+			case "dotenv":
+				out = envdocs.Dotenv(md)
+			default:
+				fatalf("unknown format %q; use \"markdown\" or \"dotenv\"", envDocsFormat)
+			}
+			if envDocsOutput == "" {
+				_, _ = os.Stdout.Write(out)
+			} else if err := os.WriteFile(envDocsOutput, out, 0644); err != nil {
+				fatal(err)
+			}
+		},
+	}
+	genEnvDocsCmd.Flags().StringVarP(&envDocsFormat, "format", "f", "markdown", "The documentation format (\"markdown\" or \"dotenv\")")
+	_ = genEnvDocsCmd.RegisterFlagCompletionFunc("format", cmdutil.AutoCompleteFromStaticList(
+		"markdown\tA Markdown table",
+		"dotenv\tA dotenv file template",
+	))
+	genEnvDocsCmd.Flags().StringVarP(&envDocsOutput, "output", "o", "", "The file to write the documentation to (defaults to stdout)")
+
 	genCmd.AddCommand(genClientCmd)
 	genCmd.AddCommand(genWrappersCmd)
+	genCmd.AddCommand(genServiceCmd)
+	genCmd.AddCommand(genIngestCmd)
+	genCmd.AddCommand(genSchemasCmd)
+	genCmd.AddCommand(genEnvDocsCmd)
 
 	genClientCmd.Flags().StringVarP(&lang, "lang", "l", "", "The language to generate code for (\"typescript\", \"javascript\", \"go\", and \"openapi\" are supported)")
 	_ = genClientCmd.RegisterFlagCompletionFunc("lang", cmdutil.AutoCompleteFromStaticList(
@@ -205,6 +493,17 @@ which may require the user-facing wrapper code to be manually generated.`,
 		BoolVar(&openAPIExcludePrivateEndpoints, "openapi-exclude-private-endpoints", false, "Exclude private endpoints from the OpenAPI spec")
 	genClientCmd.Flags().
 		BoolVar(&tsSharedTypes, "ts:shared-types", false, "Import types from ~backend instead of re-generating them")
+	genClientCmd.Flags().
+		StringVar(&tsHooks, "ts:hooks", "", "Also generate React hooks for the TypeScript client using the given library (\"react-query\" or \"swr\")")
+	_ = genClientCmd.RegisterFlagCompletionFunc("ts:hooks", cmdutil.AutoCompleteFromStaticList(
+		"react-query\tHooks using TanStack Query (@tanstack/react-query)",
+		"swr\tHooks using SWR",
+	))
+	genClientCmd.Flags().
+		StringVar(&tsSchemas, "ts:schemas", "", "Also generate schemas for validating the request types in the TypeScript client using the given library (\"zod\")")
+	_ = genClientCmd.RegisterFlagCompletionFunc("ts:schemas", cmdutil.AutoCompleteFromStaticList(
+		"zod\tSchemas using Zod",
+	))
 	genClientCmd.Flags().StringVar(&target, "target", "", "An optional target for the client (\"leap\")")
 	_ = genClientCmd.RegisterFlagCompletionFunc("target", cmdutil.AutoCompleteFromStaticList(
 		"leap\tA TypeScript client for apps created with Leap (https://leap.new) ",
@@ -15,27 +15,70 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
+	"encr.dev/cli/cmd/encore/cmdutil"
 	"encr.dev/cli/internal/platform"
 	"encr.dev/pkg/appfile"
+	daemonpb "encr.dev/proto/encore/daemon"
 )
 
 var (
-	logsEnv   string
-	logsJSON  bool
-	logsQuiet bool
+	logsEnv      string
+	logsJSON     bool
+	logsQuiet    bool
+	logsLocal    bool
+	logsServices []string
 )
 
 var logsCmd = &cobra.Command{
-	Use:   "logs [--env=prod] [--json]",
+	Use:   "logs [--env=prod] [--json] [--local [--service=name]]",
 	Short: "Streams logs from your application",
+	Long: `Streams logs from your application.
+
+By default the logs are streamed from an environment in Encore Cloud.
+With --local the command attaches to the output of the app started with
+'encore run' in another terminal, until the app stops.`,
 
 	DisableFlagsInUseLine: true,
 	Run: func(cmd *cobra.Command, args []string) {
 		appRoot, _ := determineAppRoot()
+		if logsLocal {
+			if logsEnv != "" {
+				fatal("--env cannot be used with --local")
+			}
+			attachLocalLogs(appRoot)
+			return
+		} else if len(logsServices) > 0 {
+			fatal("--service can only be used with --local")
+		}
 		streamLogs(appRoot, logsEnv)
 	},
 }
 
+// attachLocalLogs streams the output of the app running locally.
+func attachLocalLogs(appRoot string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	stream, err := daemon.AttachLogs(ctx, &daemonpb.AttachLogsRequest{
+		AppRoot:  appRoot,
+		Services: logsServices,
+	})
+	if err != nil {
+		fatal("attach to logs: ", err)
+	}
+
+	if !logsQuiet {
+		fmt.Fprintln(os.Stderr, aurora.Gray(12, "Connected, waiting for logs..."))
+	}
+
+	var converter cmdutil.OutputConverter
+	if !logsJSON {
+		converter = cmdutil.ConvertJSONLogs()
+	}
+	os.Exit(cmdutil.StreamCommandOutput(stream, converter))
+}
+
 func streamLogs(appRoot, envName string) {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
@@ -137,4 +180,6 @@ func init() {
 	logsCmd.Flags().StringVarP(&logsEnv, "env", "e", "", "Environment name to stream logs from (defaults to the primary environment)")
 	logsCmd.Flags().BoolVar(&logsJSON, "json", false, "Whether to print logs in raw JSON format")
 	logsCmd.Flags().BoolVarP(&logsQuiet, "quiet", "q", false, "Whether to print initial message when the command is waiting for logs")
+	logsCmd.Flags().BoolVar(&logsLocal, "local", false, "Attach to the logs of the app running locally with 'encore run'")
+	logsCmd.Flags().StringSliceVar(&logsServices, "service", nil, "Only stream the logs of the given services (with --local)")
 }
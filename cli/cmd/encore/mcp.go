@@ -20,6 +20,7 @@ import (
 	"encr.dev/cli/cmd/encore/cmdutil"
 	"encr.dev/cli/cmd/encore/root"
 	"encr.dev/cli/internal/jsonrpc2"
+	"encr.dev/cli/internal/localauth"
 )
 
 var mcpCmd = &cobra.Command{
@@ -43,13 +44,22 @@ var startCmd = &cobra.Command{
 		setupDaemon(ctx)
 
 		_, _ = fmt.Fprintf(os.Stderr, "  MCP Service is running!\n\n")
-		_, _ = fmt.Fprintf(os.Stderr, "  MCP SSE URL:        %s\n", aurora.Cyan(fmt.Sprintf(
-			"http://localhost:%d/sse?app=%s", mcpPort, appID)))
+		_, _ = fmt.Fprintf(os.Stderr, "  MCP SSE URL:        %s\n", aurora.Cyan(sseURL(appID)))
 		_, _ = fmt.Fprintf(os.Stderr, "  MCP stdio Command:  %s\n", aurora.Cyan(fmt.Sprintf(
 			"encore mcp run --app=%s", appID)))
 	},
 }
 
+// sseURL returns the URL of the MCP SSE endpoint for the given app,
+// including the local token if the daemon requires it.
+func sseURL(appID string) string {
+	auth, err := localauth.Configured()
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	return auth.URL(fmt.Sprintf("http://localhost:%d/sse?app=%s", mcpPort, appID))
+}
+
 type sseConnection struct {
 	read  func() (typ, data string, err error)
 	close func() error
@@ -140,7 +150,7 @@ func (c *sseConnection) connect(ctx context.Context) error {
 	c.requestIDs = make(map[jsonrpc2.ID]struct{})
 	c.mu.Unlock()
 
-	resp, err := c.client.Get(fmt.Sprintf("http://localhost:%d/sse?app=%s", mcpPort, c.appID))
+	resp, err := c.client.Get(sseURL(c.appID))
 	if err != nil {
 		return err
 	}
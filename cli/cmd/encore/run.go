@@ -7,14 +7,24 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/logrusorgru/aurora/v3"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
 	"encr.dev/cli/cmd/encore/root"
+	"encr.dev/cli/internal/bindaddr"
+	browserpkg "encr.dev/cli/internal/browser"
 	"encr.dev/cli/internal/onboarding"
+	"encr.dev/pkg/environ"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
@@ -43,6 +53,22 @@ var (
 	port               uint
 	jsonLogs           bool
 	scrubSensitiveData bool
+	compressOutput     bool
+	debugBundles       bool
+	dapPort            uint
+	vulnScan           bool
+	envFiles           []string
+	envVars            []string
+	skipReadyCheck     bool
+	serveTLS           bool
+	serveProfiles      bool
+	detach             bool
+	attach             bool
+	services           []string
+	upstream           string
+	offline            bool
+	listenExternal     bool
+	sessionName        string
 	browser            = cmdutil.Oneof{
 		Value:     "auto",
 		Allowed:   []string{"auto", "never", "always"},
@@ -51,6 +77,36 @@ var (
 		Desc:      "Whether to open the local development dashboard in the browser on startup",
 		TypeDesc:  "string",
 	}
+	emulate = cmdutil.Oneof{
+		Value:     "development",
+		Allowed:   []string{"development", "production"},
+		Flag:      "emulate",
+		FlagShort: "", // no short flag
+		Desc:      "Environment to emulate (\"production\" enables prod-parity mode)",
+		TypeDesc:  "string",
+	}
+	bindPolicy = cmdutil.Oneof{
+		Value:     "",
+		Allowed:   []string{"warn", "loopback", "allow"},
+		Flag:      "bind-policy",
+		FlagShort: "", // no short flag
+		Desc:      "Whether to allow listening on addresses reachable from other devices on the network (overrides the bind.policy config setting)",
+		TypeDesc:  "string",
+	}
+	ifRunning = cmdutil.Oneof{
+		Value:     "fail",
+		Allowed:   []string{"fail", "attach", "takeover"},
+		Flag:      "if-running",
+		FlagShort: "", // no short flag
+		Desc:      "What to do if the app is already running in the namespace (\"takeover\" stops the running app and runs it anew)",
+		TypeDesc:  "string",
+	}
+	runOutput = cmdutil.Oneof{
+		Value:    "text",
+		Allowed:  []string{"text", "json"},
+		Desc:     "Output format (\"json\" streams build progress, errors, logs and the exit code as newline-delimited JSON)",
+		TypeDesc: "string",
+	}
 )
 
 func init() {
@@ -60,12 +116,19 @@ func init() {
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			appRoot, wd := determineAppRoot()
+			if attach {
+				attachApp(appRoot)
+				return
+			}
 			// If the user didn't explicitly set --watch and we're in debug mode, disable watching
 			// as we typically don't want to swap the process when the user is debugging.
 			if !cmd.Flag("watch").Changed && debug.Value != "" {
 				watch = false
 			}
-			runApp(appRoot, wd)
+			if upstream != "" && len(services) == 0 {
+				fatal("--upstream requires --service")
+			}
+			runApp(appRoot, wd, cmd.Flag("port").Changed)
 		},
 	}
 
@@ -73,37 +136,181 @@ func init() {
 
 	rootCmd.AddCommand(runCmd)
 	runCmd.Flags().BoolVarP(&watch, "watch", "w", true, "Watch for changes and live-reload")
-	runCmd.Flags().StringVar(&listen, "listen", "", "Address to listen on (for example \"0.0.0.0:4000\")")
-	runCmd.Flags().UintVarP(&port, "port", "p", 4000, "Port to listen on")
+	runCmd.Flags().StringVar(&listen, "listen", "", "Address to listen on (for example \"0.0.0.0:4000\" or \"[::1]:4000\")")
+	runCmd.Flags().BoolVar(&listenExternal, "listen-external", false, "Listen on all interfaces, for other devices on the local network to call the API")
+	runCmd.Flags().UintVarP(&port, "port", "p", 4000, "Port to listen on (if not set and 4000 is in use, the next available port is used; 0 picks a random available port)")
 	runCmd.Flags().BoolVar(&jsonLogs, "json", false, "Display logs in JSON format")
 	runCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
 	runCmd.Flags().BoolVar(&color, "color", isTerm, "Whether to display colorized output")
 	runCmd.Flags().BoolVar(&noColor, "no-color", false, "Equivalent to --color=false")
 	runCmd.Flags().BoolVar(&scrubSensitiveData, "redact", false, "Redact sensitive data in traces when running locally")
+	runCmd.Flags().BoolVar(&compressOutput, "compress-output", false, "Compress the app's output streamed from the daemon (for remote daemon connections)")
+	runCmd.Flags().UintVar(&dapPort, "dap-port", 2345, "Port to serve the Delve debug adapter (DAP) on with --debug=enabled (0 to disable)")
+	runCmd.Flags().BoolVar(&vulnScan, "vuln-scan", false, "Scan dependencies for known vulnerabilities after each build (see 'encore vuln')")
+	runCmd.Flags().BoolVar(&debugBundles, "debug-bundles", false, "Capture the payload, database rows read and messages published by failed requests (see 'encore debug bundles')")
+	runCmd.Flags().StringArrayVar(&envFiles, "env-file", nil, "Load environment variables from a dotenv file (can be repeated; later files take precedence)")
+	runCmd.Flags().StringArrayVarP(&envVars, "env", "e", nil, "Set an environment variable as KEY=VALUE (can be repeated; takes precedence over the environment and --env-file)")
+	runCmd.Flags().BoolVar(&skipReadyCheck, "skip-ready-check", false, "Announce the app as running without waiting for its services to finish initializing")
+	runCmd.Flags().BoolVar(&serveTLS, "tls", false, "Also serve the app over HTTPS, with a locally-trusted certificate")
+	runCmd.Flags().BoolVar(&serveProfiles, "profile", false, "Serve the pprof profiling endpoints of each process on a dedicated port (Go apps only)")
+	runCmd.Flags().BoolVarP(&detach, "detach", "d", false, "Run the app in the background (see 'encore run --attach' and 'encore stop')")
+	runCmd.Flags().BoolVar(&attach, "attach", false, "Attach to the output of the app running in the background")
+	runCmd.Flags().StringSliceVar(&services, "service", nil, "Only start the given services, along with the gateways (comma-separated or repeated)")
+	runCmd.Flags().StringVar(&upstream, "upstream", "", "Base URL of an environment to proxy calls to the services not started with --service to (for example \"https://staging-my-app.encr.app\")")
+	runCmd.Flags().BoolVar(&offline, "offline", false, "Run without network access, skipping update checks and using the secrets last synced")
+	runCmd.Flags().StringVar(&sessionName, "name", "", "Name of the run session, to look it up with 'encore run history'")
+	runCmd.MarkFlagsMutuallyExclusive("detach", "attach")
+	runCmd.MarkFlagsMutuallyExclusive("listen", "listen-external")
 	runCmd.Flags().MarkHidden("no-color")
 	logLevel.AddFlag(runCmd)
 	debug.AddFlag(runCmd)
 	browser.AddFlag(runCmd)
+	emulate.AddFlag(runCmd)
+	bindPolicy.AddFlag(runCmd)
+	ifRunning.AddFlag(runCmd)
+	runOutput.AddFlag(runCmd)
+
+	output := cmdutil.Oneof{Value: "columns", Allowed: []string{"columns", "json"}}
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the running apps and the addresses they listen on",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.ListRuns(ctx, &daemonpb.ListRunsRequest{})
+			if err != nil {
+				fatal(err)
+			}
+
+			if output.Value == "json" {
+				out, err := protojson.MarshalOptions{
+					UseProtoNames:   true,
+					EmitUnpopulated: true,
+					Multiline:       true,
+				}.Marshal(resp)
+				if err != nil {
+					fatal(err)
+				}
+				_, _ = fmt.Fprintln(os.Stdout, string(out))
+				return
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.StripEscape)
+			_, _ = fmt.Fprint(w, "APP\tADDRESS\tNAMESPACE\tROOT\n")
+			for _, r := range resp.Runs {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.AppId, r.ListenAddr, r.Namespace, r.AppRoot)
+			}
+			_ = w.Flush()
+		},
+	}
+	output.AddFlag(listCmd)
+	runCmd.AddCommand(listCmd)
+
+	historyOutput := cmdutil.Oneof{Value: "columns", Allowed: []string{"columns", "json"}}
+	var historyLimit int
+	historyCmd := &cobra.Command{
+		Use:   "history [session]",
+		Short: "Lists the past runs of the app, or shows the logs and traces of one",
+		Long: `Lists the past runs of the app, or shows the logs and traces of one.
+
+Each run is recorded as a session, with when it started and ended, the namespace
+and git commit it ran with and why it exited. Name a run with 'encore run --name'
+to look it up by name; the most recent session with the name is shown.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			if len(args) == 0 {
+				listRunSessions(appRoot, historyLimit, historyOutput.Value == "json")
+			} else {
+				showRunSession(appRoot, args[0], historyOutput.Value == "json")
+			}
+		},
+	}
+	historyOutput.AddFlag(historyCmd)
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 20, "Maximum number of sessions to list (0 lists all)")
+	runCmd.AddCommand(historyCmd)
+
+	stopCmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stops the running app",
+		Long:  "Stops the app, whether it runs in the background with 'encore run --detach' or in another terminal.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			daemon := setupDaemon(ctx)
+			if _, err := daemon.StopRun(ctx, &daemonpb.StopRunRequest{AppRoot: appRoot}); err != nil {
+				if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+					fatal(st.Message())
+				}
+				fatal(err)
+			}
+			fmt.Fprintln(os.Stderr, "Stopped the app.")
+		},
+	}
+	rootCmd.AddCommand(stopCmd)
 }
 
-// runApp runs the app.
-func runApp(appRoot, wd string) {
+// attachApp attaches to the output of the app running in the background.
+// Interrupting it detaches, leaving the app running.
+func attachApp(appRoot string) {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 
+	daemon := setupDaemon(ctx)
+	stream, err := daemon.AttachRun(ctx, &daemonpb.AttachRunRequest{AppRoot: appRoot})
+	if err != nil {
+		fatal(err)
+	}
+	var converter cmdutil.OutputConverter
+	if !jsonLogs {
+		converter = cmdutil.ConvertJSONLogs(cmdutil.Colorize(color && !noColor))
+	}
+	code := cmdutil.StreamCommandOutput(stream, converter)
+	if ctx.Err() != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "\nDetached; the app is still running. Stop it with 'encore stop'.")
+	}
+	os.Exit(code)
+}
+
+// runApp runs the app. If portSet is false and the default port is in use,
+// the app runs on the next available port.
+func runApp(appRoot, wd string, portSet bool) {
+	// Stop the app when the terminal is closed or we're terminated,
+	// rather than leaving it running without a client.
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer cancel()
+
 	// Determine listen addr.
 	var listenAddr string
+	autoPort := !portSet
 
-	if listen == "" {
+	if listenExternal {
+		// Listen on all IPv4 and IPv6 interfaces.
+		listenAddr = net.JoinHostPort("", strconv.Itoa(int(port)))
+	} else if listen == "" {
 		// If we have no listen address at all, listen on localhost.
 		// (we do this so MacOS's firewall doesn't ask for permission for the daemon to listen on all interfaces)
-		listenAddr = fmt.Sprintf("127.0.0.1:%d", port)
+		listenAddr = bindaddr.LoopbackAddr(int(port))
 	} else if _, _, err := net.SplitHostPort(listen); err == nil {
 		// If --listen is given with a port, use that directly and ignore --port.
 		listenAddr = listen
+		autoPort = false
 	} else {
 		// Otherwise use --listen as the host and --port as the port.
-		listenAddr = net.JoinHostPort(listen, strconv.Itoa(int(port)))
+		// The host may be an IPv6 address with or without brackets, like "[::1]".
+		listenAddr = net.JoinHostPort(bindaddr.SplitHost(listen), strconv.Itoa(int(port)))
+	}
+
+	environ, interpolatedKeys, err := runEnviron(os.Environ(), envFiles, envVars)
+	if err != nil {
+		fatal(err)
 	}
 
 	browserMode := daemonpb.RunRequest_BROWSER_AUTO
@@ -124,32 +331,99 @@ func runApp(appRoot, wd string) {
 		debugMode = daemonpb.RunRequest_DEBUG_BREAK
 	}
 
+	var dapAddr string
+	if debugMode == daemonpb.RunRequest_DEBUG_ENABLED && dapPort != 0 {
+		dapAddr = bindaddr.LoopbackAddr(int(dapPort))
+	}
+
+	compression := daemonpb.OutputCompression_OUTPUT_COMPRESSION_NONE
+	if compressOutput {
+		compression = daemonpb.OutputCompression_OUTPUT_COMPRESSION_GZIP
+	}
+
+	emulation := daemonpb.RunRequest_EMULATION_DEVELOPMENT
+	if emulate.Value == "production" {
+		emulation = daemonpb.RunRequest_EMULATION_PRODUCTION
+	}
+
+	var ifRunningMode daemonpb.RunRequest_IfRunning
+	switch ifRunning.Value {
+	case "attach":
+		ifRunningMode = daemonpb.RunRequest_IF_RUNNING_ATTACH
+	case "takeover":
+		ifRunningMode = daemonpb.RunRequest_IF_RUNNING_TAKEOVER
+	}
+
+	structured := runOutput.Value == "json"
+	interactive := !structured && !detach &&
+		term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+
 	daemon := setupDaemon(ctx)
 	stream, err := daemon.Run(ctx, &daemonpb.RunRequest{
-		AppRoot:            appRoot,
-		DebugMode:          debugMode,
-		Watch:              watch,
-		WorkingDir:         wd,
-		ListenAddr:         listenAddr,
-		Environ:            os.Environ(),
-		TraceFile:          root.TraceFile,
-		Namespace:          nonZeroPtr(nsName),
-		Browser:            browserMode,
-		LogLevel:           nonZeroPtr(logLevel.Value),
-		ScrubSensitiveData: scrubSensitiveData,
-		NonInteractive:     !term.IsTerminal(int(os.Stderr.Fd())),
+		AppRoot:             appRoot,
+		DebugMode:           debugMode,
+		Watch:               watch,
+		WorkingDir:          wd,
+		ListenAddr:          listenAddr,
+		Environ:             environ,
+		InterpolatedEnvKeys: interpolatedKeys,
+		TraceFile:           root.TraceFile,
+		Namespace:           nonZeroPtr(nsName),
+		Browser:             browserMode,
+		LogLevel:            nonZeroPtr(logLevel.Value),
+		ScrubSensitiveData:  scrubSensitiveData,
+		NonInteractive:      !term.IsTerminal(int(os.Stderr.Fd())),
+		Emulation:           emulation,
+		Resumable:           true,
+		BatchOutput:         true,
+		OutputCompression:   compression,
+		DebugBundles:        debugBundles,
+		DapListenAddr:       dapAddr,
+		VulnScan:            vulnScan,
+		AutoPort:            autoPort,
+		SkipReadyCheck:      skipReadyCheck,
+		Tls:                 serveTLS,
+		Profile:             serveProfiles,
+		Detach:              detach,
+		Services:            services,
+		Upstream:            upstream,
+		StructuredOutput:    structured,
+		InteractiveControls: interactive,
+		Offline:             offline,
+		ListenExternal:      listenExternal,
+		SessionName:         sessionName,
+		BindPolicy:          nonZeroPtr(bindPolicy.Value),
+		IfRunning:           ifRunningMode,
 	})
 	if err != nil {
 		fatal(err)
 	}
 
+	if structured {
+		os.Exit(cmdutil.StreamCommandJSON(cmdutil.Resumable(ctx, daemon, stream), os.Stdout))
+	}
+
 	cmdutil.ClearTerminalExceptFirstNLines(1)
 
+	// Production emulation uses the production log format (structured JSON).
 	var converter cmdutil.OutputConverter
-	if !jsonLogs {
+	if !jsonLogs && emulation != daemonpb.RunRequest_EMULATION_PRODUCTION {
 		converter = cmdutil.ConvertJSONLogs(cmdutil.Colorize(color && !noColor))
 	}
-	code := cmdutil.StreamCommandOutput(stream, converter)
+	restoreTerm := func() {}
+	if interactive {
+		restoreTerm = handleRunControls(ctx, cancel, daemon, appRoot)
+	}
+	// Restore the terminal on panics too. Exiting skips deferred calls,
+	// so it's also restored explicitly below.
+	defer restoreTerm()
+	code := cmdutil.StreamCommandOutput(cmdutil.Resumable(ctx, daemon, stream), converter)
+	restoreTerm()
+	if detach && code == 0 && ctx.Err() == nil {
+		_, _ = fmt.Println(aurora.Sprintf("The app is running in the background. Attach to it with %s, or stop it with %s.",
+			aurora.Cyan("encore run --attach"), aurora.Cyan("encore stop")))
+		os.Exit(0)
+	}
 	if code == 0 {
 		if state, err := onboarding.Load(); err == nil {
 			if state.DeployHint.Set() {
@@ -162,5 +436,109 @@ func runApp(appRoot, wd string) {
 	os.Exit(code)
 }
 
+// handleRunControls handles the keyboard controls of the app running at appRoot
+// until ctx is canceled, which quitting does by calling cancel.
+// It returns a function restoring the terminal.
+func handleRunControls(ctx context.Context, cancel context.CancelFunc, daemon daemonpb.DaemonClient, appRoot string) (restore func()) {
+	keys, restore, err := cmdutil.ReadKeys(os.Stdin)
+	if err != nil {
+		// The controls are a convenience; run without them.
+		return func() {}
+	}
+
+	control := func(action daemonpb.ControlRunRequest_Action) {
+		_, err := daemon.ControlRun(ctx, &daemonpb.ControlRunRequest{AppRoot: appRoot, Action: action})
+		if err != nil && ctx.Err() == nil {
+			_, _ = fmt.Fprintln(os.Stderr, aurora.Red(fmt.Sprintf("error: %v", err)))
+		}
+	}
+	openDashboard := func() {
+		resp, err := daemon.ListRuns(ctx, &daemonpb.ListRunsRequest{AppRoot: appRoot})
+		if err != nil || len(resp.Runs) == 0 {
+			return
+		}
+		if !browserpkg.Open(resp.Runs[0].DashboardUrl) {
+			_, _ = fmt.Fprintf(os.Stderr, "Open the dashboard at %s\n", aurora.Cyan(resp.Runs[0].DashboardUrl))
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case key, ok := <-keys:
+				if !ok {
+					return
+				}
+				switch key {
+				case 'r':
+					go control(daemonpb.ControlRunRequest_ACTION_RESTART)
+				case 't':
+					go control(daemonpb.ControlRunRequest_ACTION_TOGGLE_TRACE_SAMPLING)
+				case 'o':
+					go openDashboard()
+				case 'q':
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return restore
+}
+
+// runEnviron computes the environment to run the app with.
+// Variables set with --env take precedence over those in the environment,
+// which in turn take precedence over those loaded from env files,
+// with later files taking precedence over earlier ones.
+//
+// It also returns the names of the variables whose values come from
+// --env or env files, which the daemon interpolates before starting the app.
+// Values inherited from the environment are left as-is.
+func runEnviron(base, envFiles, envVars []string) ([]string, []string, error) {
+	var layers []environ.Environ
+	for _, path := range envFiles {
+		env, err := environ.ReadDotenvFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--env-file: %w", err)
+		}
+		layers = append(layers, env)
+	}
+	baseLayer := len(layers)
+	layers = append(layers, base)
+	for _, kv := range envVars {
+		if key, _, ok := strings.Cut(kv, "="); !ok || key == "" {
+			return nil, nil, fmt.Errorf("--env: invalid variable %q, expected KEY=VALUE", kv)
+		}
+	}
+	layers = append(layers, envVars)
+
+	// Keep only the value with the highest precedence for each variable,
+	// so that all consumers of the environment agree on its value.
+	var keys []string
+	values := make(map[string]string)
+	fromBase := make(map[string]bool)
+	for i, layer := range layers {
+		for _, kv := range layer {
+			key, _, _ := strings.Cut(kv, "=")
+			if _, seen := values[key]; !seen {
+				keys = append(keys, key)
+			}
+			values[key] = kv
+			fromBase[key] = i == baseLayer
+		}
+	}
+	result := make([]string, 0, len(keys))
+	var interpolated []string
+	for _, key := range keys {
+		result = append(result, values[key])
+		if !fromBase[key] {
+			interpolated = append(interpolated, key)
+		}
+	}
+	return result, interpolated, nil
+}
+
 func init() {
 }
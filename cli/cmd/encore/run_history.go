@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/logrusorgru/aurora/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// listRunSessions lists the recorded run sessions of the app at appRoot.
+func listRunSessions(appRoot string, limit int, jsonOutput bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.ListRunSessions(ctx, &daemonpb.ListRunSessionsRequest{
+		AppRoot: appRoot,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		fatal(err)
+	}
+	if jsonOutput {
+		printProtoJSON(resp)
+		return
+	}
+	if len(resp.Sessions) == 0 {
+		_, _ = fmt.Fprintln(os.Stderr, "No runs recorded yet.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.StripEscape)
+	_, _ = fmt.Fprint(w, "ID\tNAME\tSTARTED\tDURATION\tNAMESPACE\tCOMMIT\tEXIT\n")
+	for _, sess := range resp.Sessions {
+		started, duration := runSessionTimes(sess)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", sess.Id, orDash(sess.Name),
+			started, duration, sess.Namespace, orDash(sess.GitCommit), runSessionExit(sess))
+	}
+	_ = w.Flush()
+}
+
+// showRunSession shows a recorded run session of the app at appRoot,
+// along with its output and the traces recorded while it ran.
+func showRunSession(appRoot, session string, jsonOutput bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.GetRunSession(ctx, &daemonpb.GetRunSessionRequest{
+		AppRoot: appRoot,
+		Session: session,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			fatal(st.Message())
+		}
+		fatal(err)
+	}
+	if jsonOutput {
+		printProtoJSON(resp)
+		return
+	}
+
+	sess := resp.Session
+	started, duration := runSessionTimes(sess)
+	_, _ = fmt.Fprintf(os.Stdout, "%-12s%s\n", "Session:", sess.Id)
+	if sess.Name != "" {
+		_, _ = fmt.Fprintf(os.Stdout, "%-12s%s\n", "Name:", sess.Name)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "%-12s%s\n", "Started:", started)
+	_, _ = fmt.Fprintf(os.Stdout, "%-12s%s\n", "Duration:", duration)
+	_, _ = fmt.Fprintf(os.Stdout, "%-12s%s\n", "Namespace:", sess.Namespace)
+	_, _ = fmt.Fprintf(os.Stdout, "%-12s%s\n", "Commit:", orDash(sess.GitCommit))
+	_, _ = fmt.Fprintf(os.Stdout, "%-12s%s\n", "Exit:", runSessionExit(sess))
+
+	_, _ = fmt.Fprintf(os.Stdout, "\n%s\n", aurora.Bold(fmt.Sprintf("Traces (%d)", len(resp.Traces))))
+	if len(resp.Traces) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', tabwriter.StripEscape)
+		_, _ = fmt.Fprint(w, "TRACE ID\tSTARTED\tENDPOINT\tDURATION\tSTATUS\n")
+		for _, tr := range resp.Traces {
+			endpoint := tr.Service
+			if tr.Endpoint != nil {
+				endpoint += "." + *tr.Endpoint
+			}
+			traceStatus := "ok"
+			if tr.IsError {
+				traceStatus = "error"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", tr.TraceId, formatRunTime(tr.StartedAt),
+				orDash(endpoint), time.Duration(tr.DurationNanos).Round(time.Microsecond), traceStatus)
+		}
+		_ = w.Flush()
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "\n%s\n", aurora.Bold("Logs"))
+	convert := cmdutil.ConvertJSONLogs()
+	for _, line := range bytes.SplitAfter(resp.Output, []byte("\n")) {
+		_, _ = os.Stdout.Write(convert(line))
+	}
+}
+
+// runSessionTimes formats when sess started and how long it ran for.
+func runSessionTimes(sess *daemonpb.RunSession) (started, duration string) {
+	start, err := time.Parse(time.RFC3339, sess.StartedAt)
+	if err != nil {
+		return sess.StartedAt, "-"
+	}
+	started = start.Local().Format("Jan 2 15:04:05")
+	if sess.EndedAt == nil {
+		if sess.ExitReason != "" {
+			return started, "-" // the daemon stopped while it was running
+		}
+		return started, time.Since(start).Round(time.Second).String() + " (running)"
+	}
+	end, err := time.Parse(time.RFC3339, *sess.EndedAt)
+	if err != nil {
+		return started, "-"
+	}
+	return started, end.Sub(start).Round(time.Second).String()
+}
+
+// runSessionExit describes why sess exited.
+func runSessionExit(sess *daemonpb.RunSession) string {
+	if sess.EndedAt == nil && sess.ExitReason == "" {
+		return "running"
+	}
+	return sess.ExitReason
+}
+
+func formatRunTime(ts string) string {
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		return t.Local().Format("Jan 2 15:04:05.000")
+	}
+	return ts
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func printProtoJSON(msg proto.Message) {
+	out, err := protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+		Multiline:       true,
+	}.Marshal(msg)
+	if err != nil {
+		fatal(err)
+	}
+	_, _ = fmt.Fprintln(os.Stdout, string(out))
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRunEnviron(t *testing.T) {
+	dir := t.TempDir()
+	writeEnvFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	first := writeEnvFile("first.env", "FILE=first\nFILES=first\nBASE=file\nFLAG=file\nONLY_FILE=1\n")
+	second := writeEnvFile("second.env", "FILES=second\n")
+
+	base := []string{"BASE=base", "FLAG=base", "HOME=/home/test"}
+	flags := []string{"FLAG=flag", "ONLY_FLAG=${HOME}"}
+
+	env, interpolated, err := runEnviron(base, []string{first, second}, flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each variable appears once, with the value of highest precedence:
+	// --env values, then the process environment, then later env files.
+	want := []string{
+		"FILE=first",
+		"FILES=second",
+		"BASE=base",
+		"FLAG=flag",
+		"ONLY_FILE=1",
+		"HOME=/home/test",
+		"ONLY_FLAG=${HOME}",
+	}
+	if !slices.Equal(env, want) {
+		t.Errorf("env = %v, want %v", env, want)
+	}
+
+	// Only values from env files and --env are interpolated.
+	slices.Sort(interpolated)
+	wantInterpolated := []string{"FILE", "FILES", "FLAG", "ONLY_FILE", "ONLY_FLAG"}
+	if !slices.Equal(interpolated, wantInterpolated) {
+		t.Errorf("interpolated = %v, want %v", interpolated, wantInterpolated)
+	}
+}
+
+func TestRunEnvironErrors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.env")
+	if err := os.WriteFile(invalid, []byte("FOO\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		envFiles []string
+		envVars  []string
+		wantErr  string
+	}{
+		{name: "missing_file", envFiles: []string{filepath.Join(dir, "missing.env")}, wantErr: "--env-file: open "},
+		{name: "invalid_file", envFiles: []string{invalid}, wantErr: "--env-file: " + invalid + ": line 1: expected KEY=VALUE"},
+		{name: "invalid_var", envVars: []string{"FOO"}, wantErr: `--env: invalid variable "FOO", expected KEY=VALUE`},
+		{name: "empty_key", envVars: []string{"=foo"}, wantErr: `--env: invalid variable "=foo", expected KEY=VALUE`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := runEnviron(nil, tt.envFiles, tt.envVars)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("got err %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -173,6 +173,16 @@ func init() {
 			return nil
 		},
 	}
+	sqlcCmd := &cobra.Command{
+		Use:                "sqlc [args...]",
+		Short:              "Runs the version of sqlc embedded in Encore, which generates the app's sqlc query packages",
+		Hidden:             true,
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			os.Exit(cli.Run(args))
+		},
+	}
 	rootCmd.AddCommand(genCmd)
 	rootCmd.AddCommand(pluginCmd)
+	rootCmd.AddCommand(sqlcCmd)
 }
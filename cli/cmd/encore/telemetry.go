@@ -118,7 +118,7 @@ func init() {
 		if update {
 			go updateTelemetry(cmd.Context())
 		}
-		if telemetry.ShouldShowWarning() && !isCommand(cmd, "version", "completion") {
+		if telemetry.ShouldShowWarning() && !isCommand(cmd, "version", "completion", "sqlc") {
 			fmt.Println()
 			fmt.Println(aurora.Sprintf("%s: This CLI tool collects usage data to help us improve Encore.", aurora.Bold("Note")))
 			fmt.Println(aurora.Sprintf("      You can disable this by running '%s'.\n", aurora.Yellow("encore telemetry disable")))
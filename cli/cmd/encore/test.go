@@ -10,14 +10,19 @@ import (
 	"os/signal"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"syscall"
+	"text/tabwriter"
 	"time"
 
+	"github.com/logrusorgru/aurora/v3"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/pkg/benchcmp"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
@@ -33,6 +38,7 @@ var testCmd = &cobra.Command{
 			codegenDebug bool
 			prepareOnly  bool
 			noColor      bool
+			opts         = testOptions{benchThreshold: 0.1}
 		)
 		// Support specific args but otherwise let all args be passed on to "go test"
 		for i := 0; i < len(args); i++ {
@@ -57,6 +63,23 @@ var testCmd = &cobra.Command{
 						i--
 					}
 				}
+			} else if value, rest, ok := cutValueFlag(args, i, "--db-isolation"); ok {
+				opts.dbIsolation, args = value, rest
+				i--
+			} else if value, rest, ok := cutValueFlag(args, i, "--bench-save"); ok {
+				opts.benchSave, args = value, rest
+				i--
+			} else if value, rest, ok := cutValueFlag(args, i, "--bench-compare"); ok {
+				opts.benchCompare, args = value, rest
+				i--
+			} else if value, rest, ok := cutValueFlag(args, i, "--bench-threshold"); ok {
+				args = rest
+				i--
+				threshold, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+				if err != nil || threshold < 0 {
+					fatalf("invalid --bench-threshold %q: must be a non-negative percentage", value)
+				}
+				opts.benchThreshold = threshold / 100
 			} else if arg == "--codegen-debug" {
 				codegenDebug = true
 				args = slices.Delete(args, i, i+1)
@@ -73,7 +96,7 @@ var testCmd = &cobra.Command{
 		}
 
 		appRoot, relPath := determineAppRoot()
-		exitCode, err := runTests(appRoot, relPath, args, traceFile, codegenDebug, prepareOnly, noColor)
+		exitCode, err := runTests(appRoot, relPath, args, traceFile, codegenDebug, prepareOnly, noColor, opts)
 		if err != nil {
 			fatal(err)
 		}
@@ -81,9 +104,35 @@ var testCmd = &cobra.Command{
 	},
 }
 
-func runTests(appRoot, testDir string, args []string, traceFile string, codegenDebug, prepareOnly, noColor bool) (int, error) {
+// testOptions are the options for running tests that are
+// handled by Encore rather than passed on to "go test".
+type testOptions struct {
+	dbIsolation string
+
+	benchSave      string  // file to save benchmark results to
+	benchCompare   string  // baseline file to compare benchmark results against
+	benchThreshold float64 // relative change considered a regression
+}
+
+// cutValueFlag reports whether args[i] is the flag with the given name,
+// given either as "--name=value" or "--name value". If so it returns
+// the flag's value and args with the flag removed.
+func cutValueFlag(args []string, i int, name string) (value string, rest []string, ok bool) {
+	arg := args[i]
+	if v, found := strings.CutPrefix(arg, name+"="); found {
+		return v, slices.Delete(args, i, i+1), true
+	} else if arg != name {
+		return "", args, false
+	} else if i+1 < len(args) {
+		return args[i+1], slices.Delete(args, i, i+2), true
+	}
+	fatalf("flag %s requires a value", name)
+	return "", args, false
+}
+
+func runTests(appRoot, testDir string, args []string, traceFile string, codegenDebug, prepareOnly, noColor bool, opts testOptions) (int, error) {
 	interrupt := make(chan os.Signal, 1)
-	signal.Notify(interrupt, os.Interrupt)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
@@ -115,11 +164,12 @@ func runTests(appRoot, testDir string, args []string, traceFile string, codegenD
 	packageJsonPath := filepath.Join(appRoot, "package.json")
 	if _, err := os.Stat(packageJsonPath); err == nil || prepareOnly {
 		spec, err := daemon.TestSpec(ctx, &daemonpb.TestSpecRequest{
-			AppRoot:    appRoot,
-			WorkingDir: testDir,
-			Args:       args,
-			Environ:    os.Environ(),
-			TempDir:    tempDir,
+			AppRoot:     appRoot,
+			WorkingDir:  testDir,
+			Args:        args,
+			Environ:     os.Environ(),
+			TempDir:     tempDir,
+			DbIsolation: opts.dbIsolation,
 		})
 		if status.Code(err) == codes.NotFound {
 			return 1, errors.New("application does not define any tests.\nNote: Add a 'test' script command to package.json to run tests.")
@@ -159,11 +209,90 @@ func runTests(appRoot, testDir string, args []string, traceFile string, codegenD
 		TraceFile:    nonZeroPtr(traceFile),
 		CodegenDebug: codegenDebug,
 		TempDir:      tempDir,
+		DbIsolation:  opts.dbIsolation,
+		Resumable:    true,
 	})
 	if err != nil {
 		return 1, err
 	}
-	return cmdutil.StreamCommandOutput(stream, converter), nil
+	output := cmdutil.Resumable(ctx, daemon, stream)
+
+	if opts.benchSave == "" && opts.benchCompare == "" {
+		return cmdutil.StreamCommandOutput(output, converter), nil
+	}
+
+	parser := benchcmp.NewParser()
+	exitCode := cmdutil.StreamCommandOutput(output, parseBenchmarks(parser, converter))
+	if code := reportBenchmarks(parser.Results(), opts); exitCode == 0 {
+		exitCode = code
+	}
+	return exitCode, nil
+}
+
+// parseBenchmarks returns a converter that feeds the test output
+// to the parser before passing it on to converter.
+func parseBenchmarks(parser *benchcmp.Parser, converter cmdutil.OutputConverter) cmdutil.OutputConverter {
+	return func(line []byte) []byte {
+		if len(line) > 0 && line[0] == '{' {
+			// Output from "go test -json".
+			var ev testJSONEvent
+			if err := json.Unmarshal(line, &ev); err == nil && ev.Action == "output" && ev.Output != nil {
+				parser.ParseLine(ev.Package, string(*ev.Output))
+			}
+		} else {
+			parser.ParseLine("", string(line))
+		}
+		return converter(line)
+	}
+}
+
+// reportBenchmarks saves the benchmark results and compares them against the baseline,
+// according to opts. It returns the exit code to use if the tests passed.
+func reportBenchmarks(results []*benchcmp.Result, opts testOptions) int {
+	if len(results) == 0 {
+		_, _ = fmt.Fprintln(os.Stderr, "encore: no benchmark results found (did you pass -bench?)")
+		return 1
+	}
+
+	exitCode := 0
+	if opts.benchCompare != "" {
+		baseline, err := benchcmp.ReadBaseline(opts.benchCompare)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "encore: unable to read benchmark baseline: %v\n", err)
+			return 1
+		}
+
+		deltas := benchcmp.Compare(baseline, results, opts.benchThreshold)
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "\nBENCHMARK\tUNIT\tBASELINE\tCURRENT\tCHANGE\t\n")
+		regressions := 0
+		for _, d := range deltas {
+			status := ""
+			if d.Regression {
+				status = aurora.Red("REGRESSION").String()
+				regressions++
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%.4g\t%.4g\t%+.1f%%\t%s\n",
+				d.Name, d.Unit, d.Old, d.New, d.Change*100, status)
+		}
+		_ = tw.Flush()
+
+		if regressions > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "\n%d benchmark metric(s) regressed by more than %.0f%% compared to %s\n",
+				regressions, opts.benchThreshold*100, opts.benchCompare)
+			exitCode = 1
+		}
+	}
+
+	if opts.benchSave != "" {
+		if err := benchcmp.WriteBaseline(opts.benchSave, results); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "encore: unable to save benchmark results: %v\n", err)
+			return 1
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "Saved %d benchmark result(s) to %s\n", len(results), opts.benchSave)
+	}
+	return exitCode
 }
 
 func init() {
@@ -176,6 +305,10 @@ func init() {
 	testCmd.Flags().Bool("prepare", false, "Prepare for running tests (without running them)")
 	testCmd.Flags().String("trace", "", "Specifies a trace file to write trace information about the parse and compilation process to.")
 	testCmd.Flags().Bool("no-color", false, "Disable colorized output")
+	testCmd.Flags().String("bench-save", "", "Save the benchmark results to the given baseline file")
+	testCmd.Flags().String("bench-compare", "", "Compare the benchmark results against the given baseline file and fail on regressions")
+	testCmd.Flags().String("bench-threshold", "10%", "The change in a benchmark metric considered a regression when using --bench-compare")
+	testCmd.Flags().String("db-isolation", "", "Isolate SQL databases to each test (\"test\") or test binary (\"package\") by cloning a migrated template database")
 
 }
 
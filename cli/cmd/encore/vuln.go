@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+func init() {
+	var (
+		all     bool
+		refresh bool
+		asJSON  bool
+	)
+	vulnCmd := &cobra.Command{
+		Use:   "vuln",
+		Short: "Reports known vulnerabilities in the app's dependencies",
+		Long: `Scans the app's dependencies for known vulnerabilities, using govulncheck
+for Go apps and npm audit for TypeScript apps. The result is cached until
+the dependencies change.
+
+For Go apps only vulnerabilities in code the app calls are reported by default;
+use --all to include vulnerable dependencies whose vulnerable code is not called.
+The exit code is 1 if any vulnerabilities were reported.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			scanVulns(appRoot, all, refresh, asJSON)
+		},
+	}
+	vulnCmd.Flags().BoolVar(&all, "all", false, "Include vulnerabilities in code the app does not call")
+	vulnCmd.Flags().BoolVar(&refresh, "refresh", false, "Scan even if the dependencies did not change since the last scan")
+	vulnCmd.Flags().BoolVar(&asJSON, "json", false, "Output the vulnerabilities as JSON")
+	rootCmd.AddCommand(vulnCmd)
+}
+
+func scanVulns(appRoot string, all, refresh, asJSON bool) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.VulnScan(ctx, &daemonpb.VulnScanRequest{
+		AppRoot:            appRoot,
+		IncludeUnreachable: all,
+		Refresh:            refresh,
+	})
+	if err != nil {
+		fatal(err)
+	}
+
+	if asJSON {
+		data, err := protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+			Multiline:       true,
+		}.Marshal(resp)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(string(data))
+	} else if len(resp.Vulnerabilities) == 0 {
+		fmt.Printf("No vulnerabilities found (scanned with %s).\n", resp.Scanner)
+	} else {
+		for _, v := range resp.Vulnerabilities {
+			module := v.Module
+			if v.Version != "" {
+				module += "@" + v.Version
+			}
+			fmt.Printf("%s: %s\n", v.Id, v.Summary)
+			fmt.Printf("  Module:   %s\n", module)
+			if v.FixedVersion != "" {
+				fmt.Printf("  Fixed in: %s\n", v.FixedVersion)
+			} else {
+				fmt.Printf("  Fixed in: no fix available\n")
+			}
+			if v.Severity != "" {
+				fmt.Printf("  Severity: %s\n", v.Severity)
+			}
+			if v.Reachability != daemonpb.Vulnerability_REACHABILITY_UNKNOWN {
+				fmt.Printf("  Usage:    %s\n", strings.ToLower(strings.TrimPrefix(v.Reachability.String(), "REACHABILITY_")))
+			}
+			if len(v.Trace) > 0 {
+				fmt.Printf("  Called:   %s\n", strings.Join(v.Trace, " → "))
+			}
+			fmt.Printf("  More:     %s\n\n", v.Url)
+		}
+		fmt.Printf("Found %d vulnerabilities (scanned with %s).\n", len(resp.Vulnerabilities), resp.Scanner)
+	}
+	if !asJSON && resp.Unreachable > 0 {
+		fmt.Printf("%d more in code the app does not call; use --all to include them.\n", resp.Unreachable)
+	}
+
+	if len(resp.Vulnerabilities) > 0 {
+		os.Exit(1)
+	}
+}
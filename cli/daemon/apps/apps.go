@@ -420,6 +420,12 @@ func (i *Instance) GlobalCORS() (appfile.CORS, error) {
 
 }
 
+// GatewayTransforms returns the transformations the API gateways
+// apply to requests and responses for the app.
+func (i *Instance) GatewayTransforms() ([]appfile.GatewayTransform, error) {
+	return appfile.GatewayTransforms(i.root)
+}
+
 func (i *Instance) Watch(fn WatchFunc) (WatchSubscriptionID, error) {
 	if err := i.beginWatch(); err != nil {
 		return 0, err
@@ -0,0 +1,202 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/nsqio/go-nsq"
+	"github.com/rs/xid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/run"
+	"encr.dev/pkg/builder"
+	"encr.dev/pkg/builder/builderimpl"
+	"encr.dev/pkg/cdc"
+	"encr.dev/pkg/fns"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// DBCDCConfig generates change data capture configuration for the app's databases.
+func (s *Server) DBCDCConfig(ctx context.Context, req *daemonpb.DBCDCConfigRequest) (*daemonpb.DBCDCConfigResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to query app info: %v", err)
+	}
+	expSet, err := app.Experiments(nil)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse app experiments: %v", err)
+	}
+
+	bld := builderimpl.Resolve(app.Lang(), expSet)
+	defer fns.CloseIgnore(bld)
+	prepareResult, err := bld.Prepare(ctx, builder.PrepareParams{
+		Build:      builder.DefaultBuildInfo(),
+		App:        app,
+		WorkingDir: ".",
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to prepare app: %v", err)
+	}
+	parse, err := bld.Parse(ctx, builder.ParseParams{
+		Build:       builder.DefaultBuildInfo(),
+		App:         app,
+		Experiments: expSet,
+		WorkingDir:  ".",
+		ParseTests:  false,
+		Prepare:     prepareResult,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse app metadata: %v", err)
+	}
+
+	for _, name := range req.DatabaseNames {
+		if findDatabase(parse.Meta, name) == nil {
+			return nil, status.Errorf(codes.NotFound, "database %s not found", name)
+		}
+	}
+
+	resp := &daemonpb.DBCDCConfigResponse{}
+	for _, db := range parse.Meta.SqlDatabases {
+		if len(req.DatabaseNames) > 0 && !slices.Contains(req.DatabaseNames, db.Name) {
+			continue
+		}
+		tables, err := cdc.Tables(app.Root(), db)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "database %s: %v", db.Name, err)
+		}
+
+		file := &daemonpb.DBCDCConfigResponse_File{}
+		switch req.Format {
+		case daemonpb.DBCDCConfigRequest_FORMAT_SQL:
+			file.Name = db.Name + ".sql"
+			file.Content = []byte(cdc.ReplicationSQL(db, tables))
+		default:
+			file.Name = db.Name + ".json"
+			file.Content, err = cdc.DebeziumConnector(app.PlatformID(), db, tables)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "database %s: %v", db.Name, err)
+			}
+		}
+		resp.Files = append(resp.Files, file)
+	}
+	return resp, nil
+}
+
+// DBCDCStream streams change events from a database of the running app,
+// optionally publishing them to one of its Pub/Sub topics.
+func (s *Server) DBCDCStream(req *daemonpb.DBCDCStreamRequest, stream daemonpb.Daemon_DBCDCStreamServer) error {
+	ctx := stream.Context()
+	slog := &streamLog{stream: stream, buffered: false}
+	sendErr := func(err error) error {
+		_, _ = fmt.Fprintln(slog.Stderr(false), err)
+		streamExit(stream, 1)
+		return nil
+	}
+
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return sendErr(err)
+	}
+	r := s.mgr.FindRunByAppID(app.PlatformOrLocalID())
+	if r == nil {
+		return sendErr(fmt.Errorf("the app is not running; start it with 'encore run' to stream database changes"))
+	}
+	md, err := r.App.CachedMetadata()
+	if err != nil {
+		return sendErr(err)
+	}
+
+	dbMeta := findDatabase(md, req.DatabaseName)
+	if dbMeta == nil {
+		return sendErr(fmt.Errorf("database %s not found", req.DatabaseName))
+	}
+	tables, err := cdc.Tables(app.Root(), dbMeta)
+	if err != nil {
+		return sendErr(err)
+	} else if len(tables) == 0 {
+		return sendErr(fmt.Errorf("database %s has no tables", req.DatabaseName))
+	}
+
+	publish := func(*cdc.Event, []byte) error { return nil }
+	if req.Topic != nil {
+		if !slices.ContainsFunc(md.PubsubTopics, func(t *meta.PubSubTopic) bool { return t.Name == *req.Topic }) {
+			return sendErr(fmt.Errorf("topic %s not found", *req.Topic))
+		}
+		nsqd := r.ResourceManager.GetPubSub()
+		if nsqd == nil {
+			return sendErr(fmt.Errorf("pubsub is not running"))
+		}
+		producer, err := nsq.NewProducer(nsqd.Addr(), nsq.NewConfig())
+		if err != nil {
+			return sendErr(err)
+		}
+		producer.SetLoggerLevel(nsq.LogLevelError)
+		defer producer.Stop()
+
+		nsqTopic := run.NSQTopicName(*req.Topic)
+		publish = func(ev *cdc.Event, data []byte) error {
+			// Wrap the message like the runtime does.
+			msg, err := json.Marshal(map[string]any{
+				"ID":         xid.New().String(),
+				"Attributes": map[string]string{"table": ev.Schema + "." + ev.Table, "op": ev.Op},
+				"Data":       json.RawMessage(data),
+			})
+			if err != nil {
+				return err
+			}
+			return producer.Publish(nsqTopic, msg)
+		}
+	}
+
+	cluster := r.ResourceManager.GetSQLCluster()
+	if cluster == nil {
+		return sendErr(fmt.Errorf("the app's database cluster is not running"))
+	}
+	db, ok := cluster.GetDB(req.DatabaseName)
+	if !ok {
+		return sendErr(fmt.Errorf("database %s not found", req.DatabaseName))
+	}
+	select {
+	case <-db.Ready():
+	case <-ctx.Done():
+		return nil
+	}
+	info, err := cluster.Info(ctx)
+	if err != nil {
+		return sendErr(err)
+	}
+	role, ok := info.Encore.Superuser()
+	if !ok {
+		return sendErr(fmt.Errorf("unable to find superuser role"))
+	}
+	_, _ = fmt.Fprintf(slog.Stderr(false), "streaming changes to %d tables in database %s\n", len(tables), req.DatabaseName)
+	err = cdc.Stream(ctx, info.ConnURI(db.ApplicationCloudName(), role), req.DatabaseName, tables, func(ev *cdc.Event) error {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		if err := publish(ev, data); err != nil {
+			return fmt.Errorf("publish change event: %v", err)
+		}
+		_, err = fmt.Fprintf(slog.Stdout(false), "%s\n", data)
+		return err
+	})
+	if err != nil {
+		return sendErr(err)
+	}
+	streamExit(stream, 0)
+	return nil
+}
+
+func findDatabase(md *meta.Data, name string) *meta.SQLDatabase {
+	for _, db := range md.SqlDatabases {
+		if db.Name == name {
+			return db
+		}
+	}
+	return nil
+}
@@ -25,15 +25,18 @@ func (s *Server) Check(req *daemonpb.CheckRequest, stream daemonpb.Daemon_CheckS
 		Tests:        req.ParseTests,
 	})
 
-	exitCode := 0
 	if err != nil {
-		exitCode = 1
 		log.Error().Msg(err.Error())
 	}
 
 	if req.CodegenDebug && buildDir != "" {
 		log.Info().Msgf("wrote generated code to: %s", buildDir)
 	}
-	streamExit(stream, exitCode)
+	if err != nil {
+		// Everything Check does is part of building the app.
+		streamFailure(stream, daemonpb.ExitCategory_EXIT_CATEGORY_BUILD)
+	} else {
+		streamExit(stream, 0)
+	}
 	return nil
 }
@@ -1,29 +1,67 @@
 package daemon
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
 
 	"github.com/logrusorgru/aurora/v3"
 
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/internal/devtls"
+	"encr.dev/cli/daemon/ports"
 	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/cli/internal/onboarding"
+	"encr.dev/internal/conf"
 	"encr.dev/pkg/errlist"
+	daemonpb "encr.dev/proto/encore/daemon"
 	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 // OnStart implements run.EventListener.
 func (s *Server) OnStart(r *run.Run) {}
 
-func (s *Server) OnCompileStart(r *run.Run) {}
+// OnCompileStart implements run.EventListener.
+func (s *Server) OnCompileStart(r *run.Run) {
+	s.runEvent(r, &daemonpb.CommandEvent{Event: &daemonpb.CommandEvent_Reload{
+		Reload: &daemonpb.ReloadEvent{State: daemonpb.ReloadEvent_STATE_STARTED},
+	}})
+}
 
 // OnReload implements run.EventListener.
-func (s *Server) OnReload(r *run.Run) {}
+func (s *Server) OnReload(r *run.Run) {
+	s.runEvent(r, &daemonpb.CommandEvent{Event: &daemonpb.CommandEvent_Reload{
+		Reload: &daemonpb.ReloadEvent{State: daemonpb.ReloadEvent_STATE_DONE},
+	}})
+}
+
+// OnStaleBuild implements run.EventListener.
+func (s *Server) OnStaleBuild(r *run.Run) {
+	s.runEvent(r, &daemonpb.CommandEvent{Event: &daemonpb.CommandEvent_Reload{
+		Reload: &daemonpb.ReloadEvent{State: daemonpb.ReloadEvent_STATE_STALE},
+	}})
+}
+
+// runEvent sends ev to the client running r, if it requested structured events.
+// Events about the initial build are sent by runApp, before r's stream is registered.
+func (s *Server) runEvent(r *run.Run, ev *daemonpb.CommandEvent) {
+	s.mu.Lock()
+	slog, ok := s.streams[r.ID]
+	s.mu.Unlock()
+
+	if ok {
+		slog.Event(ev)
+	}
+}
 
 // OnStop implements run.EventListener.
 func (s *Server) OnStop(r *run.Run) {}
@@ -37,6 +75,8 @@ func (s *Server) OnStdout(r *run.Run, line []byte) {
 	if ok {
 		_, _ = slog.Stdout(true).Write(line)
 	}
+	s.writeSessionLog(r, line)
+	s.logSubs.publish(r.ID, line, false)
 }
 
 // OnStderr implements run.EventListener.
@@ -48,6 +88,8 @@ func (s *Server) OnStderr(r *run.Run, line []byte) {
 	if ok {
 		_, _ = slog.Stderr(true).Write(line)
 	}
+	s.writeSessionLog(r, line)
+	s.logSubs.publish(r.ID, line, true)
 }
 
 func (s *Server) OnError(r *run.Run, err *errlist.List) {
@@ -98,8 +140,7 @@ func findAvailableAddr(startAddr string) (host string, port int, ok bool) {
 	}
 
 	for p := startPort + 1; p <= startPort+10 && p <= 65535; p++ {
-		addr := host + ":" + strconv.Itoa(p)
-		ln, err := net.Listen("tcp", addr)
+		ln, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(p)))
 		if err == nil {
 			_ = ln.Close()
 			return host, p, true
@@ -108,6 +149,86 @@ func findAvailableAddr(startAddr string) (host string, port int, ok bool) {
 	return "", 0, false
 }
 
+// checkBindAddr checks listening on addr against the bind policy,
+// writing a warning to w if the policy warns about it.
+func checkBindAddr(w io.Writer, policy bindaddr.Policy, component, addr string) error {
+	warning, err := policy.Check(component, addr)
+	if err != nil {
+		return err
+	} else if warning != "" {
+		_, _ = fmt.Fprintln(w, aurora.Sprintf(aurora.Yellow("Warning: %s. Set the bind.policy config setting to \"loopback\" to prevent it, or to \"allow\" to silence this warning."), warning))
+	}
+	return nil
+}
+
+// listenApp listens on addr for app. With autoPort, if addr's port isn't
+// available it listens on the port the app was assigned before, or on
+// the next available port; see [ports.Registry.Listen].
+func (s *Server) listenApp(ctx context.Context, app *apps.Instance, addr string, autoPort bool) (net.Listener, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return net.Listen("tcp", addr)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return net.Listen("tcp", addr)
+	}
+	return s.mgr.Ports.Listen(ctx, ports.Key{AppID: app.PlatformOrLocalID(), Component: "app"}, host, port, autoPort)
+}
+
+// lanAddrs returns the private IPv4 addresses of the machine's network
+// interfaces that are up, for other devices on the local network to reach it at,
+// followed by their unique local and global IPv6 addresses. Link-local IPv6
+// addresses are left out as they need an interface zone to be reached.
+func lanAddrs() []string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	var addrs, addrs6 []string
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		ifaceAddrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range ifaceAddrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				if ip := ipNet.IP.To4(); ip != nil {
+					if ip.IsPrivate() {
+						addrs = append(addrs, ip.String())
+					}
+				} else if ip := ipNet.IP; ip.IsPrivate() || ip.IsGlobalUnicast() {
+					addrs6 = append(addrs6, ip.String())
+				}
+			}
+		}
+	}
+	slices.Sort(addrs)
+	slices.Sort(addrs6)
+	return append(slices.Compact(addrs), slices.Compact(addrs6)...)
+}
+
+// listenTLS wraps ln to also serve HTTPS, with a certificate issued
+// by the local certificate authority, which is created on first use.
+func listenTLS(ln net.Listener) (net.Listener, *devtls.CA, error) {
+	dir, err := conf.Dir()
+	if err != nil {
+		return nil, nil, err
+	}
+	ca, err := devtls.LoadCA(filepath.Join(dir, "tls"))
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, err := ca.ServerConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	return devtls.Listener(ln, cfg), ca, nil
+}
+
 func genCurlCommand(run *run.Run, md *meta.Data, rpc *meta.RPC) string {
 	var payload []byte
 	method := rpc.HttpMethods[0]
@@ -0,0 +1,96 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"net/netip"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/compose"
+)
+
+// composeRun is the docker-compose integration of a run.
+type composeRun struct {
+	app   *apps.Instance
+	cfg   *appfile.Compose
+	stack *compose.Stack
+
+	// ln is the listener on the stack's network, if the app
+	// is not already reachable from it.
+	ln net.Listener
+
+	// addr is the address the stack's containers reach the app at.
+	addr string
+
+	removeProxyConfig func()
+}
+
+// setupCompose sets up running the app alongside the docker-compose stack
+// configured in its encore.app file, if any. It returns nil if none is configured.
+func setupCompose(ctx context.Context, app *apps.Instance, listenAddr string) (*composeRun, error) {
+	cfg, err := appfile.ComposeConfig(app.Root())
+	if err != nil || cfg == nil {
+		return nil, err
+	}
+	stack, err := compose.Load(ctx, app.Root(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	host, port, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid listen address")
+	}
+	cr := &composeRun{app: app, cfg: cfg, stack: stack}
+	gwHost := stack.GatewayHost()
+	cr.addr = net.JoinHostPort(gwHost, port)
+
+	// Listen on the network's gateway so the stack's containers can reach the app,
+	// unless we're already listening on all interfaces.
+	if gw, err := netip.ParseAddr(gwHost); err == nil {
+		if ip, err := netip.ParseAddr(host); host != "" && (err != nil || !ip.IsUnspecified()) {
+			cr.ln, err = net.Listen("tcp", net.JoinHostPort(gw.String(), port))
+			if err != nil {
+				return nil, errors.Wrapf(err, "listen on docker network %s", stack.Network)
+			}
+		}
+	}
+	return cr, nil
+}
+
+// Environ returns the environment variables resolving the stack's services.
+func (cr *composeRun) Environ() []string {
+	return cr.stack.Environ()
+}
+
+// RegisterProxy registers the app with the stack's reverse proxy, if configured.
+func (cr *composeRun) RegisterProxy() error {
+	if cr.cfg.Proxy == nil {
+		return nil
+	}
+	name := "encore-" + cr.app.PlatformOrLocalID()
+	remove, err := compose.WriteProxyConfig(cr.app.Root(), cr.cfg.Proxy, name, cr.addr)
+	if err != nil {
+		return err
+	}
+	cr.removeProxyConfig = remove
+	return nil
+}
+
+// Close deregisters the app from the reverse proxy and stops listening on the stack's network.
+func (cr *composeRun) Close() {
+	if cr.removeProxyConfig != nil {
+		cr.removeProxyConfig()
+	}
+	if cr.ln != nil {
+		_ = cr.ln.Close()
+	}
+}
+
+// Summary describes how the stack reaches the app, for display.
+func (cr *composeRun) Summary() string {
+	return cr.stack.Network + " (app reachable at http://" + cr.addr + ")"
+}
@@ -19,9 +19,11 @@ import (
 	"google.golang.org/grpc/status"
 
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/engine/trace2"
 	"encr.dev/cli/daemon/mcp"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/runhistory"
 	"encr.dev/cli/daemon/secret"
 	"encr.dev/cli/daemon/sqldb"
 	"encr.dev/cli/internal/platform"
@@ -47,9 +49,13 @@ type Server struct {
 	sm   *secret.Manager
 	ns   *namespace.Manager
 	mcp  *mcp.Manager
+	tr   trace2.Store
 
-	mu      sync.Mutex
-	streams map[string]runStreamSink // run id -> stream
+	history *runhistory.Store // recorded run sessions
+
+	mu          sync.Mutex
+	streams     map[string]runStreamSink // run id -> stream
+	sessionLogs map[string]io.Writer     // run id -> output of the run session
 
 	availableVerInit sync.Once
 	availableVer     atomic.Value // string
@@ -57,11 +63,16 @@ type Server struct {
 	appDebounceMu sync.Mutex
 	appDebouncers map[*apps.Instance]*regenerateCodeDebouncer
 
+	sessions  sessionRegistry       // resumable commands
+	logpoints logpointRegistry      // logpoints of running apps
+	logSubs   logSubscriberRegistry // clients attached to the output of running apps
+	runGuard  runGuard              // apps running in each namespace
+
 	daemonpb.UnimplementedDaemonServer
 }
 
 // New creates a new Server.
-func New(appsMgr *apps.Manager, mgr *run.Manager, cm *sqldb.ClusterManager, sm *secret.Manager, ns *namespace.Manager, mcp *mcp.Manager) *Server {
+func New(appsMgr *apps.Manager, mgr *run.Manager, cm *sqldb.ClusterManager, sm *secret.Manager, ns *namespace.Manager, mcp *mcp.Manager, tr trace2.Store, history *runhistory.Store) *Server {
 	srv := &Server{
 		apps:    appsMgr,
 		mgr:     mgr,
@@ -69,8 +80,12 @@ func New(appsMgr *apps.Manager, mgr *run.Manager, cm *sqldb.ClusterManager, sm *
 		sm:      sm,
 		ns:      ns,
 		mcp:     mcp,
+		tr:      tr,
+		history: history,
 		streams: make(map[string]runStreamSink),
 
+		sessionLogs: make(map[string]io.Writer),
+
 		appDebouncers: make(map[*apps.Instance]*regenerateCodeDebouncer),
 	}
 
@@ -174,6 +189,12 @@ func (s *Server) GenClient(ctx context.Context, params *daemonpb.GenClientReques
 	if params.TsClientTarget != nil {
 		opts.TSClientTarget = *params.TsClientTarget
 	}
+	if params.TsHooks != nil {
+		opts.TSHooks = clientgentypes.TSHooks(*params.TsHooks)
+	}
+	if params.TsSchemas != nil {
+		opts.TSSchemas = clientgentypes.TSSchemas(*params.TsSchemas)
+	}
 	code, err := clientgen.Client(lang, params.AppId, md, servicesToGenerate, tagSet, opts)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -270,6 +291,7 @@ type runStreamSink interface {
 	Stdout(buffer bool) io.Writer
 	Stderr(buffer bool) io.Writer
 	Error(err *errlist.List)
+	Event(ev *daemonpb.CommandEvent)
 }
 
 func newStreamLogger(slog *streamLog) zerolog.Logger {
@@ -308,9 +330,10 @@ type streamLog struct {
 	stream commandStream
 	mu     sync.Mutex
 
-	buffered bool
-	stdout   *bytes.Buffer // lazily allocated
-	stderr   *bytes.Buffer // lazily allocated
+	structured bool // whether the client requested structured events
+	buffered   bool
+	stdout     *bytes.Buffer // lazily allocated
+	stderr     *bytes.Buffer // lazily allocated
 }
 
 func (log *streamLog) Stdout(buffer bool) io.Writer {
@@ -327,6 +350,21 @@ func (log *streamLog) Error(err *errlist.List) {
 	_ = err.SendToStream(log.stream)
 }
 
+// Event sends ev if the client requested structured events.
+func (log *streamLog) Event(ev *daemonpb.CommandEvent) {
+	if !log.structured {
+		return
+	}
+	_ = log.Send(&daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Event{Event: ev}})
+}
+
+// Send sends msg on the stream, serialized with the output.
+func (log *streamLog) Send(msg *daemonpb.CommandMessage) error {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	return log.stream.Send(msg)
+}
+
 func (log *streamLog) FlushBuffers() {
 	var stdout, stderr []byte
 	log.mu.Lock()
@@ -0,0 +1,93 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+)
+
+// message is a Debug Adapter Protocol message.
+// Only the fields the proxy inspects are decoded;
+// raw holds the full message for forwarding it unchanged.
+type message struct {
+	Seq        int             `json:"seq"`
+	Type       string          `json:"type"` // "request", "response" or "event"
+	Command    string          `json:"command,omitempty"`
+	RequestSeq int             `json:"request_seq,omitempty"`
+	Arguments  json.RawMessage `json:"arguments,omitempty"`
+
+	raw []byte
+}
+
+// conn reads and writes DAP messages on a connection.
+type conn struct {
+	r *bufio.Reader
+	w io.Writer
+
+	mu sync.Mutex // protects writes to w
+}
+
+func newConn(rw io.ReadWriter) *conn {
+	return &conn{r: bufio.NewReader(rw), w: rw}
+}
+
+// read reads the next message.
+func (c *conn) read() (*message, error) {
+	hdr, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(hdr.Get("Content-Length"))
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid Content-Length header %q", hdr.Get("Content-Length"))
+	}
+	raw := make([]byte, n)
+	if _, err := io.ReadFull(c.r, raw); err != nil {
+		return nil, err
+	}
+
+	msg := &message{raw: raw}
+	if err := json.Unmarshal(raw, msg); err != nil {
+		return nil, fmt.Errorf("invalid message: %v", err)
+	}
+	return msg, nil
+}
+
+// write writes a raw message.
+func (c *conn) write(raw []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(raw)); err != nil {
+		return err
+	}
+	_, err := c.w.Write(raw)
+	return err
+}
+
+// writeJSON writes v as a message.
+func (c *conn) writeJSON(v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.write(raw)
+}
+
+// errorResponse is a response to req reporting that it failed.
+func errorResponse(req *message, seq int, msg string) map[string]any {
+	return map[string]any{
+		"seq":         seq,
+		"type":        "response",
+		"request_seq": req.Seq,
+		"command":     req.Command,
+		"success":     false,
+		"message":     msg,
+		"body": map[string]any{
+			"error": map[string]any{"id": 1, "format": msg, "showUser": true},
+		},
+	}
+}
@@ -0,0 +1,296 @@
+// Package dap implements a Debug Adapter Protocol proxy that debugs
+// the processes of a run with Delve through a single DAP endpoint.
+//
+// An editor connects to the proxy and sends an attach (or launch) request.
+// The proxy starts a Delve DAP server for the connection and attaches it to
+// the first selected process. For each other selected process it asks the
+// editor to start a child debug session with a "processName" argument, which
+// connects to the proxy again and is attached to that process.
+//
+// The processes to debug are selected with a "services" argument listing
+// service or gateway names. If it is omitted all processes are debugged.
+package dap
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/delve"
+	"encr.dev/cli/internal/bindaddr"
+)
+
+// Target is a process that can be debugged.
+type Target struct {
+	// Name is the name of the service or gateway running in the process.
+	Name string
+	// Pid is the OS process id.
+	Pid int
+}
+
+// Proxy is a DAP proxy for debugging the processes of a run.
+type Proxy struct {
+	// Targets returns the processes that can be debugged.
+	// Processes running several services are listed once per service.
+	Targets func() []Target
+
+	// StartAdapter starts a debug adapter and returns a connection to it.
+	// If nil, a Delve DAP server is started.
+	StartAdapter func(ctx context.Context) (io.ReadWriteCloser, error)
+}
+
+// Serve accepts editor connections on ln until it is closed.
+func (p *Proxy) Serve(ln net.Listener) error {
+	for {
+		c, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := p.handle(c); err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				log.Error().Err(err).Msg("dap: debug session failed")
+			}
+		}()
+	}
+}
+
+// reverseSeq is the first seq used for requests sent to the editor by the proxy,
+// chosen so as not to collide with those sent by the debug adapter.
+const reverseSeq = 1 << 30
+
+// handle proxies a debug session between an editor and a debug adapter.
+func (p *Proxy) handle(c net.Conn) error {
+	defer func() { _ = c.Close() }()
+	client := newConn(c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	init, err := client.read()
+	if err != nil {
+		return err
+	} else if init.Command != "initialize" {
+		return client.writeJSON(errorResponse(init, 1, "expected initialize request"))
+	}
+
+	start := p.StartAdapter
+	if start == nil {
+		start = startDelve
+	}
+	ac, err := start(ctx)
+	if err != nil {
+		return client.writeJSON(errorResponse(init, 1, err.Error()))
+	}
+	defer func() { _ = ac.Close() }()
+	adapter := newConn(ac)
+	if err := adapter.write(init.raw); err != nil {
+		return err
+	}
+
+	// Forward everything from the adapter to the editor.
+	go func() {
+		defer func() { _ = c.Close() }()
+		for {
+			msg, err := adapter.read()
+			if err != nil {
+				return
+			}
+			if err := client.write(msg.raw); err != nil {
+				return
+			}
+		}
+	}()
+
+	var seq atomic.Int64
+	seq.Store(reverseSeq)
+	for {
+		msg, err := client.read()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case msg.Type == "response" && msg.Command == "startDebugging":
+			// A response to a request sent by the proxy; don't forward it.
+			continue
+
+		case msg.Type == "request" && (msg.Command == "attach" || msg.Command == "launch"):
+			raw, children, err := p.rewriteAttach(msg)
+			if err != nil {
+				if err := client.writeJSON(errorResponse(msg, int(seq.Add(1)), err.Error())); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := adapter.write(raw); err != nil {
+				return err
+			}
+			for _, child := range children {
+				if err := client.writeJSON(map[string]any{
+					"seq":     seq.Add(1),
+					"type":    "request",
+					"command": "startDebugging",
+					"arguments": map[string]any{
+						"request":       "attach",
+						"configuration": child,
+					},
+				}); err != nil {
+					return err
+				}
+			}
+
+		default:
+			if err := adapter.write(msg.raw); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// rewriteAttach rewrites an attach or launch request to attach the debug adapter
+// to a process. It returns the rewritten request and the configurations of the
+// child sessions to start for the other selected processes.
+func (p *Proxy) rewriteAttach(msg *message) (raw []byte, children []map[string]any, err error) {
+	var args map[string]any
+	if len(msg.Arguments) > 0 {
+		if err := json.Unmarshal(msg.Arguments, &args); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid arguments")
+		}
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+
+	var targets []Target
+	if name, ok := args["processName"].(string); ok {
+		// A child session attaching to a single process.
+		targets, err = p.selectTargets([]string{name})
+	} else {
+		var names []string
+		if svcs, ok := args["services"].([]any); ok {
+			for _, s := range svcs {
+				if s, ok := s.(string); ok {
+					names = append(names, s)
+				}
+			}
+		}
+		targets, err = p.selectTargets(names)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, t := range targets[1:] {
+		child := make(map[string]any, len(args)+1)
+		for k, v := range args {
+			child[k] = v
+		}
+		delete(child, "services")
+		child["request"] = "attach"
+		child["processName"] = t.Name
+		if name, ok := args["name"].(string); ok {
+			child["name"] = name + ": " + t.Name
+		} else {
+			child["name"] = t.Name
+		}
+		children = append(children, child)
+	}
+
+	args["mode"] = "local"
+	args["processId"] = targets[0].Pid
+	delete(args, "services")
+	delete(args, "processName")
+	delete(args, "program")
+
+	var req map[string]any
+	if err := json.Unmarshal(msg.raw, &req); err != nil {
+		return nil, nil, err
+	}
+	req["command"] = "attach"
+	req["arguments"] = args
+	raw, err = json.Marshal(req)
+	return raw, children, err
+}
+
+// selectTargets selects the processes running the named services or gateways,
+// or all processes if names is empty. Each process is selected once.
+func (p *Proxy) selectTargets(names []string) ([]Target, error) {
+	all := p.Targets()
+	var selected []Target
+	add := func(t Target) {
+		if !slices.ContainsFunc(selected, func(s Target) bool { return s.Pid == t.Pid }) {
+			selected = append(selected, t)
+		}
+	}
+
+	if len(names) == 0 {
+		for _, t := range all {
+			add(t)
+		}
+	}
+	for _, name := range names {
+		idx := slices.IndexFunc(all, func(t Target) bool { return t.Name == name })
+		if idx < 0 {
+			var known []string
+			for _, t := range all {
+				known = append(known, t.Name)
+			}
+			return nil, errors.Newf("unknown service %q (running: %s)", name, strings.Join(known, ", "))
+		}
+		add(all[idx])
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("no processes are running")
+	}
+	return selected, nil
+}
+
+// startDelve starts a Delve DAP server that connects back to the proxy.
+func startDelve(ctx context.Context) (io.ReadWriteCloser, error) {
+	dlv, err := delve.Find()
+	if err != nil {
+		return nil, err
+	}
+
+	ln, err := bindaddr.ListenLoopback(0)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = ln.Close() }()
+
+	cmd := exec.CommandContext(ctx, dlv, "dap", "--client-addr="+ln.Addr().String())
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "start dlv")
+	}
+	go func() { _ = cmd.Wait() }()
+
+	type result struct {
+		c   net.Conn
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		c, err := ln.Accept()
+		ch <- result{c, err}
+	}()
+	select {
+	case res := <-ch:
+		return res.c, res.err
+	case <-time.After(10 * time.Second):
+		_ = cmd.Process.Kill()
+		return nil, errors.New("timed out waiting for dlv to connect")
+	}
+}
@@ -0,0 +1,86 @@
+package dap
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestProxyAttach(t *testing.T) {
+	c := qt.New(t)
+
+	editorSide, proxySide := net.Pipe()
+	adapterProxySide, adapterSide := net.Pipe()
+	p := &Proxy{
+		Targets: func() []Target {
+			return []Target{
+				{Name: "api-gateway", Pid: 10},
+				{Name: "billing", Pid: 11},
+				{Name: "users", Pid: 12},
+				{Name: "users-worker", Pid: 12},
+			}
+		},
+		StartAdapter: func(ctx context.Context) (io.ReadWriteCloser, error) {
+			return adapterProxySide, nil
+		},
+	}
+	go func() { _ = p.handle(proxySide) }()
+	defer func() { _ = editorSide.Close() }()
+
+	editor, adapter := newConn(editorSide), newConn(adapterSide)
+	send := func(conn *conn, v any) {
+		go func() { c.Check(conn.writeJSON(v), qt.IsNil) }()
+	}
+	decode := func(conn *conn) map[string]any {
+		msg, err := conn.read()
+		c.Assert(err, qt.IsNil)
+		var m map[string]any
+		c.Assert(json.Unmarshal(msg.raw, &m), qt.IsNil)
+		return m
+	}
+
+	send(editor, map[string]any{"seq": 1, "type": "request", "command": "initialize"})
+	c.Assert(decode(adapter)["command"], qt.Equals, "initialize")
+
+	send(editor, map[string]any{"seq": 2, "type": "request", "command": "attach", "arguments": map[string]any{
+		"name":     "Encore",
+		"services": []string{"billing", "users", "users-worker"},
+	}})
+	attach := decode(adapter)
+	c.Assert(attach["arguments"], qt.DeepEquals, map[string]any{
+		"name":      "Encore",
+		"mode":      "local",
+		"processId": float64(11),
+	})
+
+	start := decode(editor)
+	c.Assert(start["command"], qt.Equals, "startDebugging")
+	c.Assert(start["arguments"], qt.DeepEquals, map[string]any{
+		"request": "attach",
+		"configuration": map[string]any{
+			"name":        "Encore: users",
+			"request":     "attach",
+			"processName": "users",
+		},
+	})
+
+	// Responses to the proxy's requests are not forwarded.
+	send(editor, map[string]any{"seq": 3, "type": "response", "command": "startDebugging", "request_seq": start["seq"], "success": true})
+	send(editor, map[string]any{"seq": 4, "type": "request", "command": "configurationDone"})
+	c.Assert(decode(adapter)["command"], qt.Equals, "configurationDone")
+}
+
+func TestProxyUnknownService(t *testing.T) {
+	c := qt.New(t)
+	p := &Proxy{Targets: func() []Target { return []Target{{Name: "api-gateway", Pid: 10}} }}
+	_, err := p.selectTargets([]string{"billing"})
+	c.Assert(err, qt.ErrorMatches, `unknown service "billing" \(running: api-gateway\)`)
+
+	targets, err := p.selectTargets(nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(targets, qt.DeepEquals, []Target{{Name: "api-gateway", Pid: 10}})
+}
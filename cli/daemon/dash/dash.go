@@ -105,6 +105,41 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 		}
 		res, err := h.Transaction(ctx, p)
 		return reply(ctx, res, err)
+	case "db/pool-stats":
+		var params struct {
+			AppID string
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+
+		runInstance := h.run.FindRunByAppID(params.AppID)
+		if runInstance == nil {
+			return reply(ctx, []run.DBPoolStats{}, nil)
+		}
+		stats, err := runInstance.PoolStats(ctx)
+		return reply(ctx, stats, err)
+	case "db/query-log":
+		var params struct {
+			AppID   string `json:"app_id"`
+			TraceID string `json:"trace_id"` // if set, only the queries of the trace
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+
+		queries := h.run.ClusterMgr.QueryLog(params.AppID)
+		if err := sqldb.LinkTraces(ctx, h.tr, params.AppID, params.TraceID, queries); err != nil {
+			log.Error().Err(err).Msg("dash: could not link queries with traces")
+			return reply(ctx, nil, err)
+		}
+		if params.TraceID != "" {
+			queries = slices.DeleteFunc(queries, func(q *sqldb.LoggedQuery) bool { return q.TraceID != params.TraceID })
+		}
+		if queries == nil {
+			queries = []*sqldb.LoggedQuery{}
+		}
+		return reply(ctx, queries, nil)
 	case "onboarding/get":
 		state, err := onboarding.Load()
 		if err != nil {
@@ -359,6 +394,38 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 		status := buildDbMigrationStatus(ctx, appMeta, cluster)
 
 		return reply(ctx, status, nil)
+	case "owners/list":
+		var params struct {
+			AppID string
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+
+		appMeta, err := h.GetMeta(params.AppID)
+		if err != nil {
+			return reply(ctx, nil, err)
+		}
+		return reply(ctx, buildServiceOwners(appMeta), nil)
+	case "deprecations/report":
+		telemetry.Send("deprecations.report")
+		var params struct {
+			AppID string `json:"app_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+
+		appMeta, err := h.GetMeta(params.AppID)
+		if err != nil {
+			return reply(ctx, nil, err)
+		}
+
+		report, err := buildDeprecationReport(ctx, h.tr, params.AppID, appMeta)
+		if err != nil {
+			log.Error().Err(err).Msg("dash: could not build deprecation report")
+		}
+		return reply(ctx, report, err)
 	case "api-call":
 		telemetry.Send("api.call")
 		var params run.ApiCallParams
@@ -662,6 +729,8 @@ var _ run.EventListener = (*Server)(nil)
 
 // OnStart notifies active websocket clients about the started run.
 func (s *Server) OnStart(r *run.Run) {
+	s.schemas.update(r)
+
 	status, err := buildAppStatus(r.App, r)
 	if err != nil {
 		log.Error().Err(err).Msg("dash: could not build app status")
@@ -671,7 +740,7 @@ func (s *Server) OnStart(r *run.Run) {
 	// Open the browser if needed.
 	browserMode := r.Params.Browser
 	if browserMode == run.BrowserModeAlways || (browserMode == run.BrowserModeAuto && !s.hasClients()) {
-		u := fmt.Sprintf("http://localhost:%d/%s", s.dashPort, r.App.PlatformOrLocalID())
+		u := s.run.LocalAuth.URL(fmt.Sprintf("http://localhost:%d/%s", s.dashPort, r.App.PlatformOrLocalID()))
 		browser.Open(u)
 	}
 
@@ -698,6 +767,8 @@ func (s *Server) OnCompileStart(r *run.Run) {
 
 // OnReload notifies active websocket clients about the reloaded run.
 func (s *Server) OnReload(r *run.Run) {
+	s.schemas.update(r)
+
 	status, err := buildAppStatus(r.App, r)
 	if err != nil {
 		log.Error().Err(err).Msg("dash: could not build app status")
@@ -710,6 +781,21 @@ func (s *Server) OnReload(r *run.Run) {
 	})
 }
 
+// OnStaleBuild notifies active websocket clients that the run failed to rebuild,
+// and keeps serving the previous build.
+func (s *Server) OnStaleBuild(r *run.Run) {
+	status, err := buildAppStatus(r.App, r)
+	if err != nil {
+		log.Error().Err(err).Msg("dash: could not build app status")
+		return
+	}
+
+	s.notify(&notification{
+		Method: "process/stale-build",
+		Params: status,
+	})
+}
+
 // OnStop notifies active websocket clients about the stopped run.
 func (s *Server) OnStop(r *run.Run) {
 	status, err := buildAppStatus(r.App, nil)
@@ -739,7 +825,12 @@ func (s *Server) OnError(r *run.Run, err *errlist.List) {
 		return
 	}
 
-	status, statusErr := buildAppStatus(r.App, nil)
+	// A run with a stale build is still running the previous build.
+	var running *run.Run
+	if r.StaleBuild() {
+		running = r
+	}
+	status, statusErr := buildAppStatus(r.App, running)
 	if statusErr != nil {
 		log.Error().Err(statusErr).Msg("dash: could not build app status")
 		return
@@ -796,6 +887,7 @@ type appStatus struct {
 	APIEncoding  *encoding.APIEncoding `json:"apiEncoding,omitempty"`
 	Compiling    bool                  `json:"compiling"`
 	CompileError string                `json:"compileError,omitempty"`
+	StaleBuild   bool                  `json:"staleBuild"` // the last rebuild failed; the previous build is serving
 }
 
 type dbMigrationHistory struct {
@@ -810,6 +902,29 @@ type dbMigration struct {
 	Applied     bool   `json:"applied"`
 }
 
+type serviceOwner struct {
+	Service      string `json:"service"`
+	Team         string `json:"team"`
+	SlackChannel string `json:"slackChannel,omitempty"`
+	OnCall       string `json:"onCall,omitempty"`
+}
+
+// buildServiceOwners lists the owners of the app's services, for services that have one.
+func buildServiceOwners(appMeta *meta.Data) []serviceOwner {
+	owners := []serviceOwner{}
+	for _, svc := range appMeta.GetSvcs() {
+		if o := svc.GetOwner(); o != nil {
+			owners = append(owners, serviceOwner{
+				Service:      svc.Name,
+				Team:         o.Team,
+				SlackChannel: o.SlackChannel,
+				OnCall:       o.OnCall,
+			})
+		}
+	}
+	return owners
+}
+
 func buildAppStatus(app *apps.Instance, runInstance *run.Run) (s appStatus, err error) {
 	// Now try and grab latest metadata for the app
 	var md *meta.Data
@@ -855,6 +970,7 @@ func buildAppStatus(app *apps.Instance, runInstance *run.Run) (s appStatus, err
 		resp.Running = true
 		resp.PID = runInstance.ID
 		resp.Addr = runInstance.ListenAddr
+		resp.StaleBuild = runInstance.StaleBuild()
 	}
 
 	return resp, nil
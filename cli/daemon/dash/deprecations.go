@@ -0,0 +1,200 @@
+package dash
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// deprecationReport lists the deprecated API surface of an app,
+// along with the local traffic it still receives.
+type deprecationReport struct {
+	Endpoints []deprecatedEndpoint `json:"endpoints"`
+	Fields    []deprecatedField    `json:"fields"`
+}
+
+type deprecatedEndpoint struct {
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+	Notice   string `json:"notice"`
+	endpointTraffic
+}
+
+type deprecatedField struct {
+	// Type is the name of the type the field is defined in,
+	// or the endpoint name if it's defined in an anonymous struct.
+	Type   string `json:"type"`
+	Field  string `json:"field"`
+	Notice string `json:"notice"`
+
+	// Endpoints are the endpoints whose request or response contains the field.
+	Endpoints []string `json:"endpoints"`
+	endpointTraffic
+}
+
+// endpointTraffic summarizes the local (non-test) requests an endpoint received,
+// among the traces currently stored.
+type endpointTraffic struct {
+	Calls    int        `json:"calls"`
+	LastCall *time.Time `json:"last_call"`
+}
+
+// maxDeprecationTraces is the maximum number of traces considered per endpoint.
+const maxDeprecationTraces = 1000
+
+func (t *endpointTraffic) add(o endpointTraffic) {
+	t.Calls += o.Calls
+	if o.LastCall != nil && (t.LastCall == nil || o.LastCall.After(*t.LastCall)) {
+		t.LastCall = o.LastCall
+	}
+}
+
+func buildDeprecationReport(ctx context.Context, tr trace2.Store, appID string, md *meta.Data) (*deprecationReport, error) {
+	report := &deprecationReport{
+		Endpoints: []deprecatedEndpoint{},
+		Fields:    []deprecatedField{},
+	}
+
+	traffic := make(map[string]endpointTraffic) // keyed by "service.endpoint"
+	getTraffic := func(svc, ep string) (endpointTraffic, error) {
+		key := svc + "." + ep
+		if t, ok := traffic[key]; ok {
+			return t, nil
+		}
+
+		var t endpointTraffic
+		noTests := false
+		err := tr.List(ctx, &trace2.Query{
+			AppID:      appID,
+			Service:    svc,
+			Endpoint:   ep,
+			TestFilter: &noTests,
+			Limit:      maxDeprecationTraces,
+		}, func(s *tracepb2.SpanSummary) bool {
+			t.Calls++
+			if s.StartedAt != nil {
+				started := s.StartedAt.AsTime()
+				if t.LastCall == nil || started.After(*t.LastCall) {
+					t.LastCall = &started
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return t, err
+		}
+		traffic[key] = t
+		return t, nil
+	}
+
+	fields := make(map[string]*deprecatedField) // keyed by "type.field"
+	for _, svc := range md.Svcs {
+		for _, rpc := range svc.Rpcs {
+			epName := svc.Name + "." + rpc.Name
+			w := &deprecatedFieldWalker{md: md, seen: make(map[uint32]bool)}
+			w.walk(rpc.RequestSchema, epName)
+			w.walk(rpc.ResponseSchema, epName)
+			if rpc.Deprecated == nil && len(w.found) == 0 {
+				continue
+			}
+
+			t, err := getTraffic(svc.Name, rpc.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			if rpc.Deprecated != nil {
+				report.Endpoints = append(report.Endpoints, deprecatedEndpoint{
+					Service:         svc.Name,
+					Endpoint:        rpc.Name,
+					Notice:          *rpc.Deprecated,
+					endpointTraffic: t,
+				})
+			}
+
+			for _, f := range w.found {
+				key := f.typ + "." + f.field.Name
+				df, ok := fields[key]
+				if !ok {
+					df = &deprecatedField{
+						Type:   f.typ,
+						Field:  f.field.Name,
+						Notice: f.field.GetDeprecated(),
+					}
+					fields[key] = df
+				}
+				if !slices.Contains(df.Endpoints, epName) {
+					df.Endpoints = append(df.Endpoints, epName)
+					df.add(t)
+				}
+			}
+		}
+	}
+
+	for _, f := range fields {
+		report.Fields = append(report.Fields, *f)
+	}
+	slices.SortFunc(report.Fields, func(a, b deprecatedField) int {
+		return strings.Compare(a.Type+"."+a.Field, b.Type+"."+b.Field)
+	})
+	return report, nil
+}
+
+// deprecatedFieldWalker finds the deprecated struct fields reachable from a type.
+type deprecatedFieldWalker struct {
+	md    *meta.Data
+	seen  map[uint32]bool // decls already walked
+	found []foundField
+}
+
+type foundField struct {
+	typ   string
+	field *schema.Field
+}
+
+func (w *deprecatedFieldWalker) walk(typ *schema.Type, typeName string) {
+	if typ == nil {
+		return
+	}
+
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		for _, arg := range t.Named.TypeArguments {
+			w.walk(arg, typeName)
+		}
+		if w.seen[t.Named.Id] || int(t.Named.Id) >= len(w.md.Decls) {
+			return
+		}
+		w.seen[t.Named.Id] = true
+		decl := w.md.Decls[t.Named.Id]
+		w.walk(decl.Type, decl.Loc.GetPkgName()+"."+decl.Name)
+	case *schema.Type_Struct:
+		for _, f := range t.Struct.Fields {
+			if f.Deprecated != nil {
+				w.found = append(w.found, foundField{typ: typeName, field: f})
+			}
+			w.walk(f.Typ, typeName)
+		}
+	case *schema.Type_Map:
+		w.walk(t.Map.Key, typeName)
+		w.walk(t.Map.Value, typeName)
+	case *schema.Type_List:
+		w.walk(t.List.Elem, typeName)
+	case *schema.Type_Pointer:
+		w.walk(t.Pointer.Base, typeName)
+	case *schema.Type_Option:
+		w.walk(t.Option.Value, typeName)
+	case *schema.Type_Union:
+		for _, u := range t.Union.Types {
+			w.walk(u, typeName)
+		}
+	case *schema.Type_Config:
+		w.walk(t.Config.Elem, typeName)
+	}
+}
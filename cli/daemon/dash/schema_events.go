@@ -0,0 +1,194 @@
+package dash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/cli/daemon/run"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// schemaEventsPath is the path of the Server-Sent Events stream notifying
+// frontend dev servers about changes to the API schema of running apps,
+// so they can regenerate their clients.
+const schemaEventsPath = "/__encore/schema-events"
+
+// schemaEvent reports the current version of an app's API schema.
+type schemaEvent struct {
+	AppID string `json:"appID"`
+	// Version identifies the API schema. It changes whenever
+	// the endpoints or the types they use change.
+	Version string `json:"version"`
+}
+
+// schemaEvents tracks the API schema versions of the running apps
+// and streams changes to them to subscribers.
+type schemaEvents struct {
+	mu       sync.Mutex
+	versions map[string]string // app id -> schema version
+	subs     map[chan schemaEvent]struct{}
+}
+
+func newSchemaEvents() *schemaEvents {
+	return &schemaEvents{
+		versions: make(map[string]string),
+		subs:     make(map[chan schemaEvent]struct{}),
+	}
+}
+
+// update records the API schema of the given run.
+func (e *schemaEvents) update(r *run.Run) {
+	proc := r.ProcGroup()
+	if proc == nil || proc.Meta == nil {
+		return
+	}
+	e.set(r.App.PlatformOrLocalID(), proc.Meta)
+}
+
+// set records the API schema of the given app,
+// notifying subscribers if it changed.
+func (e *schemaEvents) set(appID string, md *meta.Data) {
+	ev := schemaEvent{AppID: appID, Version: apiSchemaVersion(md)}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.versions[ev.AppID] == ev.Version {
+		return
+	}
+	e.versions[ev.AppID] = ev.Version
+	for ch := range e.subs {
+		select {
+		case ch <- ev:
+		default:
+			// The subscriber is falling behind; it'll get the next change.
+		}
+	}
+}
+
+// subscribe returns a channel receiving schema changes, along with the
+// current schema versions. The channel must be released with unsubscribe.
+func (e *schemaEvents) subscribe() (ch chan schemaEvent, current []schemaEvent) {
+	ch = make(chan schemaEvent, 10)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.subs[ch] = struct{}{}
+	for appID, version := range e.versions {
+		current = append(current, schemaEvent{AppID: appID, Version: version})
+	}
+	return ch, current
+}
+
+func (e *schemaEvents) unsubscribe(ch chan schemaEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.subs, ch)
+}
+
+// ServeHTTP streams the schema versions as Server-Sent Events.
+// Each event is sent when an app starts or its API schema changes on reload,
+// optionally limited to the app given by the "app" query parameter.
+func (e *schemaEvents) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	appID := req.URL.Query().Get("app")
+
+	ch, current := e.subscribe()
+	defer e.unsubscribe(ch)
+
+	// Allow dev servers on other origins to subscribe from the browser.
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(ev schemaEvent) error {
+		if appID != "" && ev.AppID != appID {
+			return nil
+		}
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "event: schema\ndata: %s\n\n", data)
+		return err
+	}
+
+	for _, ev := range current {
+		if err := send(ev); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	// Send comments periodically to keep idle connections open through proxies.
+	keepalive := time.NewTicker(30 * time.Second)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case ev := <-ch:
+			if err := send(ev); err != nil {
+				log.Debug().Err(err).Msg("dash: could not send schema event")
+				return
+			}
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// apiSchemaVersion computes a version identifying the API schema in md:
+// the endpoints of each service and the types they use. Source locations
+// are excluded, so moving code around doesn't change the version.
+func apiSchemaVersion(md *meta.Data) string {
+	stripLoc := func(loc *schema.Loc) *schema.Loc {
+		if loc == nil {
+			return nil
+		}
+		return &schema.Loc{PkgName: loc.PkgName}
+	}
+
+	api := &meta.Data{}
+	for _, svc := range md.Svcs {
+		s := &meta.Service{Name: svc.Name}
+		for _, rpc := range svc.Rpcs {
+			rpc = proto.Clone(rpc).(*meta.RPC)
+			rpc.Loc = stripLoc(rpc.Loc)
+			s.Rpcs = append(s.Rpcs, rpc)
+		}
+		api.Svcs = append(api.Svcs, s)
+	}
+	for _, decl := range md.Decls {
+		decl = proto.Clone(decl).(*schema.Decl)
+		decl.Loc = stripLoc(decl.Loc)
+		api.Decls = append(api.Decls, decl)
+	}
+	if md.AuthHandler != nil {
+		api.AuthHandler = proto.Clone(md.AuthHandler).(*meta.AuthHandler)
+		api.AuthHandler.Loc = stripLoc(api.AuthHandler.Loc)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(api)
+	if err != nil {
+		// Should never happen; report a version that's always considered changed.
+		return fmt.Sprintf("unknown-%d", time.Now().UnixNano())
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,77 @@
+package dash
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func testAPIMeta(rpcName string, line int32) *meta.Data {
+	return &meta.Data{
+		Svcs: []*meta.Service{{
+			Name:    "svc",
+			RelPath: "svc",
+			Rpcs: []*meta.RPC{{
+				Name:        rpcName,
+				ServiceName: "svc",
+				Loc:         &schema.Loc{PkgName: "svc", Filename: "svc.go", SrcLineStart: line},
+			}},
+		}},
+	}
+}
+
+func TestAPISchemaVersion(t *testing.T) {
+	c := qt.New(t)
+	v := apiSchemaVersion(testAPIMeta("Get", 10))
+	c.Assert(v, qt.HasLen, 16)
+
+	// Moving code around doesn't change the version.
+	c.Assert(apiSchemaVersion(testAPIMeta("Get", 20)), qt.Equals, v)
+
+	// Neither do changes outside the API.
+	md := testAPIMeta("Get", 10)
+	md.SqlDatabases = []*meta.SQLDatabase{{Name: "db"}}
+	c.Assert(apiSchemaVersion(md), qt.Equals, v)
+
+	c.Assert(apiSchemaVersion(testAPIMeta("List", 10)), qt.Not(qt.Equals), v)
+}
+
+func TestSchemaEvents(t *testing.T) {
+	c := qt.New(t)
+	events := newSchemaEvents()
+	events.set("app", testAPIMeta("Get", 10))
+	events.set("other", testAPIMeta("Get", 10))
+
+	srv := httptest.NewServer(events)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?app=app")
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.Header.Get("Content-Type"), qt.Equals, "text/event-stream")
+
+	lines := bufio.NewScanner(resp.Body)
+	next := func() string {
+		var ev []string
+		for lines.Scan() && lines.Text() != "" {
+			ev = append(ev, lines.Text())
+		}
+		return strings.Join(ev, "\n")
+	}
+
+	// The current version is sent on connect.
+	c.Assert(next(), qt.Equals, `event: schema`+"\n"+`data: {"appID":"app","version":"`+apiSchemaVersion(testAPIMeta("Get", 10))+`"}`)
+
+	// Unchanged schemas and other apps aren't reported.
+	events.set("app", testAPIMeta("Get", 20))
+	events.set("other", testAPIMeta("List", 10))
+	events.set("app", testAPIMeta("List", 10))
+	c.Assert(next(), qt.Equals, `event: schema`+"\n"+`data: {"appID":"app","version":"`+apiSchemaVersion(testAPIMeta("List", 10))+`"}`)
+}
@@ -19,6 +19,7 @@ import (
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/run"
 	"encr.dev/cli/internal/jsonrpc2"
+	"encr.dev/cli/internal/localauth"
 	"encr.dev/internal/conf"
 	"encr.dev/pkg/fns"
 )
@@ -52,6 +53,7 @@ func NewServer(appsMgr *apps.Manager, runMgr *run.Manager, nsMgr *namespace.Mana
 		traceCh:  make(chan trace2.NewSpanEvent, 10),
 		clients:  make(map[chan<- *notification]struct{}),
 		ai:       aiMgr,
+		schemas:  newSchemaEvents(),
 	}
 
 	runMgr.AddListener(s)
@@ -71,6 +73,7 @@ type Server struct {
 	dashPort int
 	traceCh  chan trace2.NewSpanEvent
 	ai       *ai.Manager
+	schemas  *schemaEvents
 
 	mu      sync.Mutex
 	clients map[chan<- *notification]struct{}
@@ -82,7 +85,14 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		s.WebSocket(w, req)
 	case "/__graphql":
 		s.apiProxy.ServeHTTP(w, req)
+	case schemaEventsPath:
+		s.schemas.ServeHTTP(w, req)
 	default:
+		// Don't pass the local token on to the remote dashboard.
+		if q := req.URL.Query(); q.Has(localauth.TokenParam) {
+			q.Del(localauth.TokenParam)
+			req.URL.RawQuery = q.Encode()
+		}
 		s.proxy.ServeHTTP(w, req)
 	}
 }
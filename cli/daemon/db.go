@@ -5,14 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"net"
-	"strconv"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/ports"
+	"encr.dev/cli/daemon/run"
 	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/cli/internal/platform"
 	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/builder"
@@ -20,6 +24,7 @@ import (
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/pgproxy"
 	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 func toRoleType(role daemonpb.DBRole) sqldb.RoleType {
@@ -54,7 +59,7 @@ func (s *Server) DBConnect(ctx context.Context, req *daemonpb.DBConnectRequest)
 	if err != nil {
 		return nil, err
 	}
-	dsn := fmt.Sprintf("postgresql://encore:%s@127.0.0.1:%d/%s?sslmode=disable", passwd, port, req.DbName)
+	dsn := fmt.Sprintf("postgresql://encore:%s@%s/%s?sslmode=disable", passwd, bindaddr.LoopbackAddr(port), req.DbName)
 	return &daemonpb.DBConnectResponse{Dsn: dsn}, nil
 }
 
@@ -160,8 +165,8 @@ func (s *Server) dbConnectLocal(ctx context.Context, req *daemonpb.DBConnectRequ
 	}
 	log.Info().Msg("created database cluster")
 
-	dsn := fmt.Sprintf("postgresql://%s:%s@127.0.0.1:%d/%s?sslmode=disable",
-		app.PlatformOrLocalID(), passwd, s.mgr.DBProxyPort, req.DbName)
+	dsn := fmt.Sprintf("postgresql://%s:%s@%s/%s?sslmode=disable",
+		app.PlatformOrLocalID(), passwd, bindaddr.LoopbackAddr(s.mgr.DBProxyPort), req.DbName)
 	return &daemonpb.DBConnectResponse{Dsn: dsn}, nil
 }
 
@@ -177,7 +182,18 @@ func (s *Server) DBProxy(params *daemonpb.DBProxyRequest, stream daemonpb.Daemon
 		return errNotLinked
 	}
 
-	ln, err := (&net.ListenConfig{}).Listen(ctx, "tcp", "127.0.0.1:"+strconv.Itoa(int(params.Port)))
+	// Without a port, reuse the port the proxy was assigned before
+	// so clients configured with it keep working.
+	auto := params.Port == 0
+	key := ports.Key{AppID: appID, Component: "dbproxy:" + params.EnvName}
+	if appID == "" {
+		app, err := s.apps.Track(params.AppRoot)
+		if err != nil {
+			return err
+		}
+		key.AppID = app.PlatformOrLocalID()
+	}
+	ln, err := s.mgr.Ports.Listen(ctx, key, bindaddr.Loopback(), int(params.Port), auto)
 	if err != nil {
 		return status.Error(codes.FailedPrecondition, err.Error())
 	}
@@ -314,25 +330,84 @@ func (s *Server) DBReset(req *daemonpb.DBResetRequest, stream daemonpb.Daemon_DB
 		return nil
 	}
 
-	expSet, err := app.Experiments(nil)
+	// Parse the app to figure out what infrastructure is needed.
+	md, err := parseAppMeta(stream.Context(), app)
 	if err != nil {
 		sendErr(err)
 		return nil
 	}
 
-	// Parse the app to figure out what infrastructure is needed.
+	clusterNS, err := s.namespaceOrActive(stream.Context(), app, req.Namespace)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+
+	clusterType := getClusterType(req)
+	if req.Seed && clusterType != sqldb.Run {
+		sendErr(errors.New("seeds can only be applied to the databases of the run cluster"))
+		return nil
+	}
+	cluster, err := s.startCluster(stream.Context(), app, clusterType, clusterNS)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+	err = cluster.Recreate(stream.Context(), req.AppRoot, req.DatabaseNames, md)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+
+	// Seeds are only tracked for the namespace's run cluster.
+	if clusterType != sqldb.Run {
+		return nil
+	}
+	if err := s.mgr.Seeds.Forget(stream.Context(), app.PlatformOrLocalID(), clusterNS.ID, req.DatabaseNames); err != nil {
+		sendErr(err)
+		return nil
+	}
+	seeds, err := appfile.SeedsConfig(app.Root())
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+	if req.Seed || (seeds != nil && seeds.AutoApply) {
+		slog := &streamLog{stream: stream}
+		err := s.mgr.ApplySeeds(stream.Context(), run.ApplySeedsParams{
+			App:       app,
+			NS:        clusterNS,
+			Cluster:   cluster,
+			Databases: req.DatabaseNames,
+			Quiet:     !req.Seed,
+			Stdout:    slog.Stdout(false),
+			Stderr:    slog.Stderr(false),
+		})
+		if err != nil {
+			sendErr(err)
+		}
+	}
+	return nil
+}
+
+// parseAppMeta parses the app to determine its metadata.
+func parseAppMeta(ctx context.Context, app *apps.Instance) (*meta.Data, error) {
+	expSet, err := app.Experiments(nil)
+	if err != nil {
+		return nil, err
+	}
+
 	bld := builderimpl.Resolve(app.Lang(), expSet)
 	defer fns.CloseIgnore(bld)
-	prepareResult, err := bld.Prepare(stream.Context(), builder.PrepareParams{
+	prepareResult, err := bld.Prepare(ctx, builder.PrepareParams{
 		Build:      builder.DefaultBuildInfo(),
 		App:        app,
 		WorkingDir: ".",
 	})
 	if err != nil {
-		sendErr(err)
-		return nil
+		return nil, err
 	}
-	parse, err := bld.Parse(stream.Context(), builder.ParseParams{
+	parse, err := bld.Parse(ctx, builder.ParseParams{
 		Build:       builder.DefaultBuildInfo(),
 		App:         app,
 		Experiments: expSet,
@@ -341,35 +416,27 @@ func (s *Server) DBReset(req *daemonpb.DBResetRequest, stream daemonpb.Daemon_DB
 		Prepare:     prepareResult,
 	})
 	if err != nil {
-		sendErr(err)
-		return nil
-	}
-
-	clusterNS, err := s.namespaceOrActive(stream.Context(), app, req.Namespace)
-	if err != nil {
-		sendErr(err)
-		return nil
+		return nil, err
 	}
+	return parse.Meta, nil
+}
 
-	clusterType := getClusterType(req)
-	clusterID := sqldb.GetClusterID(app, clusterType, clusterNS)
+// startCluster starts the database cluster of the given type for the app's namespace,
+// creating it if it doesn't exist.
+func (s *Server) startCluster(ctx context.Context, app *apps.Instance, clusterType sqldb.ClusterType, ns *namespace.Namespace) (*sqldb.Cluster, error) {
+	clusterID := sqldb.GetClusterID(app, clusterType, ns)
 	cluster, ok := s.cm.Get(clusterID)
 	if !ok {
-		cluster = s.cm.Create(stream.Context(), &sqldb.CreateParams{
+		cluster = s.cm.Create(ctx, &sqldb.CreateParams{
 			ClusterID: clusterID,
 			Memfs:     clusterType.Memfs(),
 		})
 	}
 
-	if _, err := cluster.Start(stream.Context(), nil); err != nil {
-		sendErr(err)
-		return nil
-	}
-	err = cluster.Recreate(stream.Context(), req.AppRoot, req.DatabaseNames, parse.Meta)
-	if err != nil {
-		sendErr(err)
+	if _, err := cluster.Start(ctx, nil); err != nil {
+		return nil, err
 	}
-	return nil
+	return cluster, nil
 }
 
 func serveProxy(ctx context.Context, ln net.Listener, handler func(context.Context, net.Conn)) error {
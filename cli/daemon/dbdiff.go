@@ -0,0 +1,131 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/expandcontract"
+	"encr.dev/pkg/schemadiff"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// DBDiff compares the live schema of a database against the schema its
+// migrations produce, or another environment, and drafts a migration for the difference.
+func (s *Server) DBDiff(ctx context.Context, req *daemonpb.DBDiffRequest) (*daemonpb.DBDiffResponse, error) {
+	if req.AgainstEnv == req.EnvName {
+		return nil, fmt.Errorf("cannot compare environment %s against itself", req.EnvName)
+	}
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	md, err := parseAppMeta(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	dbs := sqldb.Databases(md)
+	idx := slices.IndexFunc(dbs, func(db *meta.SQLDatabase) bool { return db.Name == req.DatabaseName })
+	if idx < 0 {
+		return nil, fmt.Errorf("database %q not found", req.DatabaseName)
+	}
+	db := dbs[idx]
+
+	to, toDesc, err := s.envSchema(ctx, app, db, req.EnvName, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		from     *schemadiff.Schema
+		fromDesc string
+	)
+	if req.AgainstEnv == "" {
+		from, err = s.migratedSchema(ctx, app, md, db, req.Namespace)
+		fromDesc = "the migrations"
+	} else {
+		from, fromDesc, err = s.envSchema(ctx, app, db, req.AgainstEnv, req.Namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stmts, notes := schemadiff.Diff(from, to)
+	resp := &daemonpb.DBDiffResponse{
+		From:         fromDesc,
+		To:           toDesc,
+		Notes:        notes,
+		MigrationDir: expandcontract.MigrationDir(app.Root(), db),
+		NextVersion:  1,
+	}
+	for _, m := range db.Migrations {
+		resp.NextVersion = max(resp.NextVersion, m.Number+1)
+	}
+	for _, stmt := range stmts {
+		resp.Statements = append(resp.Statements, &daemonpb.DBDiffResponse_Statement{
+			Sql:      stmt,
+			Warnings: expandcontract.Destructive(stmt),
+		})
+	}
+	return resp, nil
+}
+
+// envSchema loads the live schema of db in the environment envName,
+// or the namespace nsName for "local", and describes where it's from.
+func (s *Server) envSchema(ctx context.Context, app *apps.Instance, db *meta.SQLDatabase, envName string, nsName *string) (*schemadiff.Schema, string, error) {
+	if envName != "local" {
+		appID, err := appfile.Slug(app.Root())
+		if err != nil {
+			return nil, "", err
+		} else if appID == "" {
+			return nil, "", errNotLinked
+		}
+		schema, err := sqldb.RemoteSchema(ctx, appID, envName, db.Name)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "load the schema of database %s in environment %s", db.Name, envName)
+		}
+		return schema, fmt.Sprintf("environment %s", envName), nil
+	}
+
+	ns, err := s.namespaceOrActive(ctx, app, nsName)
+	if err != nil {
+		return nil, "", err
+	}
+	cluster, err := s.startCluster(ctx, app, sqldb.Run, ns)
+	if err != nil {
+		return nil, "", err
+	}
+	schema, exists, err := cluster.Schema(ctx, db.Name)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "load the schema of database %s", db.Name)
+	} else if !exists {
+		return nil, "", fmt.Errorf("database %s has not been created in namespace %s", db.Name, ns.Name)
+	}
+	return schema, fmt.Sprintf("namespace %s", ns.Name), nil
+}
+
+// migratedSchema returns the schema produced by applying all migrations of db.
+// It recreates the database in the namespace's test cluster to apply them from scratch.
+func (s *Server) migratedSchema(ctx context.Context, app *apps.Instance, md *meta.Data, db *meta.SQLDatabase, nsName *string) (*schemadiff.Schema, error) {
+	ns, err := s.namespaceOrActive(ctx, app, nsName)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := s.startCluster(ctx, app, sqldb.Test, ns)
+	if err != nil {
+		return nil, err
+	}
+	if err := cluster.Recreate(ctx, app.Root(), []string{db.Name}, md); err != nil {
+		return nil, errors.Wrapf(err, "apply the migrations of database %s", db.Name)
+	}
+	schema, _, err := cluster.Schema(ctx, db.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load the schema of database %s", db.Name)
+	}
+	return schema, nil
+}
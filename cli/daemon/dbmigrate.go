@@ -0,0 +1,124 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/expandcontract"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// DBMigrationPlan reports the migrations that would be applied to the app's
+// databases in a namespace or cloud environment, without applying them.
+func (s *Server) DBMigrationPlan(ctx context.Context, req *daemonpb.DBMigrationPlanRequest) (*daemonpb.DBMigrationPlanResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	md, err := parseAppMeta(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+
+	dbs := sqldb.Databases(md)
+	var databases []*meta.SQLDatabase
+	if len(req.DatabaseNames) > 0 {
+		for _, name := range req.DatabaseNames {
+			idx := slices.IndexFunc(dbs, func(db *meta.SQLDatabase) bool { return db.Name == name })
+			if idx < 0 {
+				return nil, fmt.Errorf("database %q not found", name)
+			}
+			databases = append(databases, dbs[idx])
+		}
+	} else {
+		databases = dbs
+	}
+
+	// applied reports the applied migrations of a database,
+	// and whether the database exists.
+	var applied func(db *meta.SQLDatabase) (map[uint64]bool, bool, error)
+	if req.EnvName == "local" {
+		ns, err := s.namespaceOrActive(ctx, app, req.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		cluster, err := s.startCluster(ctx, app, sqldb.Run, ns)
+		if err != nil {
+			return nil, err
+		}
+		// External databases are not migrated by Encore.
+		for _, name := range req.DatabaseNames {
+			if cluster.IsExternalDB(name) {
+				return nil, fmt.Errorf("cannot plan migrations of %q: it's an external database", name)
+			}
+		}
+		databases = slices.DeleteFunc(databases, func(db *meta.SQLDatabase) bool {
+			return cluster.IsExternalDB(db.Name)
+		})
+		applied = func(db *meta.SQLDatabase) (map[uint64]bool, bool, error) {
+			return cluster.AppliedMigrations(ctx, db.Name)
+		}
+	} else {
+		appID, err := appfile.Slug(req.AppRoot)
+		if err != nil {
+			return nil, err
+		} else if appID == "" {
+			return nil, errNotLinked
+		}
+		applied = func(db *meta.SQLDatabase) (map[uint64]bool, bool, error) {
+			versions, err := sqldb.RemoteAppliedMigrations(ctx, appID, req.EnvName, db.Name)
+			return versions, err == nil, err
+		}
+	}
+
+	resp := &daemonpb.DBMigrationPlanResponse{}
+	for _, db := range databases {
+		versions, exists, err := applied(db)
+		if err != nil {
+			return nil, errors.Wrapf(err, "list applied migrations of database %s", db.Name)
+		}
+		plan, err := migrationPlan(app.Root(), db, versions)
+		if err != nil {
+			return nil, err
+		}
+		plan.Exists = exists
+		resp.Databases = append(resp.Databases, plan)
+	}
+	return resp, nil
+}
+
+// migrationPlan computes the pending migrations of db given its applied versions,
+// with the statements of each migration and the data they destroy.
+func migrationPlan(appRoot string, db *meta.SQLDatabase, applied map[uint64]bool) (*daemonpb.DBMigrationPlanResponse_Database, error) {
+	migrations, err := expandcontract.Migrations(appRoot, db)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &daemonpb.DBMigrationPlanResponse_Database{Name: db.Name}
+	for _, pending := range sqldb.PendingMigrations(db, applied) {
+		idx := slices.IndexFunc(migrations, func(m expandcontract.Migration) bool { return m.Number == pending.Number })
+		if idx < 0 {
+			return nil, fmt.Errorf("migration %s of database %s not found", pending.Filename, db.Name)
+		}
+		m := &daemonpb.DBMigrationPlanResponse_Migration{
+			Number:   pending.Number,
+			Filename: pending.Filename,
+			Dirty:    applied[pending.Number],
+		}
+		for _, stmt := range expandcontract.SplitStatements(migrations[idx].SQL) {
+			m.Statements = append(m.Statements, &daemonpb.DBMigrationPlanResponse_Statement{
+				Sql:      stmt,
+				Warnings: expandcontract.Destructive(stmt),
+			})
+		}
+		plan.Pending = append(plan.Pending, m)
+	}
+	return plan, nil
+}
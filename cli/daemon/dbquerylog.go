@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/sqldb"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// DBQueryLog returns the queries an app made to its local databases,
+// linked with the traces of the requests that made them.
+func (s *Server) DBQueryLog(ctx context.Context, req *daemonpb.DBQueryLogRequest) (*daemonpb.DBQueryLogResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	appID := app.PlatformOrLocalID()
+	queries := s.cm.QueryLog(appID)
+	if err := sqldb.LinkTraces(ctx, s.tr, appID, req.GetTraceId(), queries); err != nil {
+		return nil, errors.Wrap(err, "link queries with traces")
+	}
+
+	resp := &daemonpb.DBQueryLogResponse{}
+	for _, q := range queries {
+		if req.TraceId != nil && q.TraceID != *req.TraceId {
+			continue
+		}
+		pq := &daemonpb.DBQueryLogResponse_Query{
+			Time:          q.Time.Format(time.RFC3339Nano),
+			Database:      q.Database,
+			Query:         q.Query,
+			DurationNanos: q.Duration.Nanoseconds(),
+			Rows:          q.Rows,
+		}
+		if q.Err != "" {
+			pq.Error = &q.Err
+		}
+		if q.TraceID != "" {
+			pq.TraceId, pq.SpanId = &q.TraceID, &q.SpanID
+		}
+		resp.Queries = append(resp.Queries, pq)
+	}
+	if n := int(req.Limit); n > 0 && len(resp.Queries) > n {
+		resp.Queries = resp.Queries[len(resp.Queries)-n:]
+	}
+	return resp, nil
+}
@@ -0,0 +1,205 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/cli/internal/platform"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/expandcontract"
+	"encr.dev/pkg/option"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// DBRollback rolls back the most recently applied migrations of a database
+// in a namespace or cloud environment by running their down migrations.
+func (s *Server) DBRollback(req *daemonpb.DBRollbackRequest, stream daemonpb.Daemon_DBRollbackServer) error {
+	ctx := stream.Context()
+	slog := &streamLog{stream: stream}
+	stdout := slog.Stdout(false)
+	if err := s.dbRollback(ctx, req, stdout); err != nil {
+		_, _ = fmt.Fprintln(slog.Stderr(false), err)
+		streamExit(stream, 1)
+		return nil
+	}
+	streamExit(stream, 0)
+	return nil
+}
+
+func (s *Server) dbRollback(ctx context.Context, req *daemonpb.DBRollbackRequest, w io.Writer) error {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return err
+	}
+	md, err := parseAppMeta(ctx, app)
+	if err != nil {
+		return err
+	}
+	dbs := sqldb.Databases(md)
+	idx := slices.IndexFunc(dbs, func(db *meta.SQLDatabase) bool { return db.Name == req.DatabaseName })
+	if idx < 0 {
+		return fmt.Errorf("database %q not found", req.DatabaseName)
+	}
+	db := dbs[idx]
+	to := option.FromPointer(req.ToVersion)
+
+	var (
+		applied  map[uint64]bool
+		target   string
+		rollback func(rollbacks []sqldb.Rollback, done func(*meta.DBMigration)) error
+	)
+	if req.EnvName == "local" {
+		ns, err := s.namespaceOrActive(ctx, app, req.Namespace)
+		if err != nil {
+			return err
+		}
+		cluster, err := s.startCluster(ctx, app, sqldb.Run, ns)
+		if err != nil {
+			return err
+		} else if cluster.IsExternalDB(db.Name) {
+			return fmt.Errorf("cannot roll back migrations of %q: it's an external database", db.Name)
+		}
+		var exists bool
+		applied, exists, err = cluster.AppliedMigrations(ctx, db.Name)
+		if err != nil {
+			return errors.Wrapf(err, "list applied migrations of database %s", db.Name)
+		} else if !exists {
+			return fmt.Errorf("database %s has not been created in namespace %s", db.Name, ns.Name)
+		}
+		target = fmt.Sprintf("namespace %s", ns.Name)
+		rollback = func(rollbacks []sqldb.Rollback, done func(*meta.DBMigration)) error {
+			return cluster.Rollback(ctx, app.Root(), db, rollbacks, done)
+		}
+	} else {
+		appID, err := appfile.Slug(req.AppRoot)
+		if err != nil {
+			return err
+		} else if appID == "" {
+			return errNotLinked
+		}
+		if !req.DryRun {
+			if err := checkCloudRollback(ctx, appID, req); err != nil {
+				return err
+			}
+		}
+		applied, err = sqldb.RemoteAppliedMigrations(ctx, appID, req.EnvName, db.Name)
+		if err != nil {
+			return errors.Wrapf(err, "list applied migrations of database %s", db.Name)
+		}
+		target = fmt.Sprintf("environment %s", req.EnvName)
+		rollback = func(rollbacks []sqldb.Rollback, done func(*meta.DBMigration)) error {
+			if err := checkDeployedMigrations(ctx, appID, req.EnvName, db, rollbacks); err != nil {
+				return err
+			}
+			return sqldb.RemoteRollback(ctx, appID, req.EnvName, app.Root(), db, rollbacks, done)
+		}
+	}
+
+	rollbacks, err := sqldb.Rollbacks(db, applied, int(req.Steps), to)
+	if err != nil {
+		return err
+	} else if len(rollbacks) == 0 {
+		_, _ = fmt.Fprintf(w, "No migrations of database %s to roll back in %s.\n", db.Name, target)
+		return nil
+	}
+	if err := printRollbackPlan(w, app.Root(), db, rollbacks, target, req.DryRun); err != nil {
+		return err
+	}
+	if req.DryRun {
+		_, _ = fmt.Fprintln(w, "Nothing was rolled back.")
+		return nil
+	}
+
+	err = rollback(rollbacks, func(m *meta.DBMigration) {
+		_, _ = fmt.Fprintf(w, "Rolled back %s.\n", m.Filename)
+	})
+	if err != nil {
+		return err
+	}
+
+	when := "the next time the app runs"
+	if req.EnvName != "local" {
+		when = "the next time the environment is deployed"
+	}
+	_, _ = fmt.Fprintf(w, "\nThe migrations will be applied again %s, unless their migration files are removed.\n", when)
+	return nil
+}
+
+// checkCloudRollback checks that rolling back the migrations of a cloud
+// environment has been confirmed, and that it's allowed for the environment.
+func checkCloudRollback(ctx context.Context, appID string, req *daemonpb.DBRollbackRequest) error {
+	if req.ConfirmEnv != req.EnvName {
+		return fmt.Errorf("rolling back migrations of environment %s must be confirmed", req.EnvName)
+	}
+	envs, err := platform.ListEnvs(ctx, appID)
+	if err != nil {
+		return errors.Wrap(err, "list environments")
+	}
+	idx := slices.IndexFunc(envs, func(env *platform.Env) bool { return env.Slug == req.EnvName })
+	if idx < 0 {
+		return fmt.Errorf("environment %s not found", req.EnvName)
+	} else if envs[idx].Type == "production" && !req.AllowProduction {
+		return fmt.Errorf("environment %s is a production environment: rolling back its migrations requires --allow-production", req.EnvName)
+	}
+	return nil
+}
+
+// checkDeployedMigrations checks that the migrations to roll back are the
+// migrations deployed to the environment, so the down migrations in the app
+// revert what was applied.
+func checkDeployedMigrations(ctx context.Context, appID, envName string, db *meta.SQLDatabase, rollbacks []sqldb.Rollback) error {
+	md, err := platform.GetEnvMeta(ctx, appID, envName)
+	if err != nil {
+		return errors.Wrapf(err, "get the metadata of environment %s", envName)
+	}
+	dbs := sqldb.Databases(md)
+	idx := slices.IndexFunc(dbs, func(d *meta.SQLDatabase) bool { return d.Name == db.Name })
+	if idx < 0 {
+		return fmt.Errorf("database %s is not deployed to environment %s", db.Name, envName)
+	}
+	for _, r := range rollbacks {
+		deployed := slices.ContainsFunc(dbs[idx].Migrations, func(m *meta.DBMigration) bool {
+			return m.Number == r.Migration.Number && m.Filename == r.Migration.Filename
+		})
+		if !deployed {
+			return fmt.Errorf("migration %s doesn't match the migrations deployed to environment %s: "+
+				"check out the deployed version of the app before rolling back", r.Migration.Filename, envName)
+		}
+	}
+	return nil
+}
+
+// printRollbackPlan writes the migrations that are rolled back to w,
+// with the statements of their down migrations and the data they destroy.
+func printRollbackPlan(w io.Writer, appRoot string, db *meta.SQLDatabase, rollbacks []sqldb.Rollback, target string, dryRun bool) error {
+	verb := "Rolling back"
+	if dryRun {
+		verb = "Would roll back"
+	}
+	_, _ = fmt.Fprintf(w, "%s %d migration(s) of database %s in %s:\n", verb, len(rollbacks), db.Name, target)
+	for _, r := range rollbacks {
+		path := filepath.Join(appRoot, *db.MigrationRelPath, r.Migration.DownFilename)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "read down migration")
+		}
+		_, _ = fmt.Fprintf(w, "\n  %s (with %s)\n", r.Migration.Filename, r.Migration.DownFilename)
+		for _, stmt := range expandcontract.SplitStatements(string(data)) {
+			_, _ = fmt.Fprintf(w, "    %s\n", strings.Join(strings.Fields(stmt), " "))
+			for _, warning := range expandcontract.Destructive(stmt) {
+				_, _ = fmt.Fprintf(w, "      warning: %s\n", warning)
+			}
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+	return nil
+}
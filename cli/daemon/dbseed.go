@@ -0,0 +1,151 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"encr.dev/cli/daemon/dbseed"
+	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/pkg/appfile"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// DBSeed applies the seeds declared for the app's databases
+// that haven't been applied in the namespace.
+func (s *Server) DBSeed(req *daemonpb.DBSeedRequest, stream daemonpb.Daemon_DBSeedServer) error {
+	ctx := stream.Context()
+	slog := &streamLog{stream: stream}
+	sendErr := func(err error) {
+		_, _ = fmt.Fprintln(slog.Stderr(false), err)
+		streamExit(stream, 1)
+	}
+
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+	cfg, err := appfile.SeedsConfig(app.Root())
+	if err != nil {
+		sendErr(err)
+		return nil
+	} else if cfg == nil || len(cfg.Databases) == 0 {
+		sendErr(fmt.Errorf("no seeds declared in %s", appfile.Name))
+		return nil
+	}
+
+	md, err := parseAppMeta(ctx, app)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+	dbs := sqldb.Databases(md)
+	var databases []*meta.SQLDatabase
+	if len(req.DatabaseNames) > 0 {
+		for _, name := range req.DatabaseNames {
+			idx := slices.IndexFunc(dbs, func(db *meta.SQLDatabase) bool { return db.Name == name })
+			if idx < 0 {
+				sendErr(fmt.Errorf("database %q not found", name))
+				return nil
+			} else if len(cfg.Databases[name]) == 0 {
+				sendErr(fmt.Errorf("no seeds declared for database %q", name))
+				return nil
+			}
+			databases = append(databases, dbs[idx])
+		}
+	} else {
+		for _, db := range dbs {
+			if len(cfg.Databases[db.Name]) > 0 {
+				databases = append(databases, db)
+			}
+		}
+	}
+
+	ns, err := s.namespaceOrActive(ctx, app, req.Namespace)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+	cluster, err := s.startCluster(ctx, app, sqldb.Run, ns)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+	if err := cluster.SetupAndMigrate(ctx, app.Root(), databases); err != nil {
+		sendErr(err)
+		return nil
+	}
+
+	var names []string
+	for _, db := range databases {
+		names = append(names, db.Name)
+	}
+	err = s.mgr.ApplySeeds(ctx, run.ApplySeedsParams{
+		App:       app,
+		NS:        ns,
+		Cluster:   cluster,
+		Databases: names,
+		Force:     req.Force,
+		Environ:   req.Environ,
+		Stdout:    slog.Stdout(false),
+		Stderr:    slog.Stderr(false),
+	})
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+	streamExit(stream, 0)
+	return nil
+}
+
+// DBSeedStatus lists the seeds declared for the app's databases,
+// and whether they've been applied in the namespace.
+func (s *Server) DBSeedStatus(ctx context.Context, req *daemonpb.DBSeedStatusRequest) (*daemonpb.DBSeedStatusResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := s.namespaceOrActive(ctx, app, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := appfile.SeedsConfig(app.Root())
+	if err != nil {
+		return nil, err
+	}
+	seeds, err := dbseed.Load(app.Root(), cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := s.mgr.Seeds.List(ctx, app.PlatformOrLocalID(), ns.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &daemonpb.DBSeedStatusResponse{}
+	for _, seed := range seeds {
+		pb := &daemonpb.DBSeedStatusResponse_Seed{
+			Database: seed.Database,
+			Name:     seed.Name,
+			Kind:     "sql",
+			Path:     seed.SQL,
+		}
+		if seed.Go != "" {
+			pb.Kind, pb.Path = "go", seed.Go
+		}
+		idx := slices.IndexFunc(applied, func(a *dbseed.Applied) bool {
+			return a.Database == seed.Database && a.Seed == seed.Name
+		})
+		if idx >= 0 {
+			appliedAt := applied[idx].AppliedAt.Format(time.RFC3339)
+			pb.AppliedAt = &appliedAt
+			pb.Changed = applied[idx].Checksum != seed.Checksum
+		}
+		resp.Seeds = append(resp.Seeds, pb)
+	}
+	return resp, nil
+}
@@ -0,0 +1,96 @@
+// Package dbseed loads the seeds declared for an app's databases in encore.app,
+// and records which seeds have been applied to the databases of each namespace.
+package dbseed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/appfile"
+)
+
+// Seed is a seed of a database.
+type Seed struct {
+	appfile.Seed
+
+	// Database is the name of the database the seed is for.
+	Database string
+
+	// Script is the contents of the SQL file, for SQL seeds.
+	Script []byte
+
+	// Checksum is the checksum of Script, for SQL seeds.
+	// It's empty for Go seeds.
+	Checksum string
+}
+
+// String returns the seed's name qualified by its database, like "users/admins".
+func (s *Seed) String() string {
+	return s.Database + "/" + s.Name
+}
+
+// Load loads the seeds declared in cfg for the app located at appRoot,
+// ordered by database name and then in the order they're declared in.
+// If databases is non-nil only the seeds of those databases are loaded.
+func Load(appRoot string, cfg *appfile.Seeds, databases []string) ([]*Seed, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	var dbNames []string
+	for name := range cfg.Databases {
+		if databases == nil || slices.Contains(databases, name) {
+			dbNames = append(dbNames, name)
+		}
+	}
+	slices.Sort(dbNames)
+
+	var seeds []*Seed
+	for _, dbName := range dbNames {
+		for _, decl := range cfg.Databases[dbName] {
+			seed := &Seed{Seed: decl, Database: dbName}
+			if decl.SQL != "" {
+				script, err := os.ReadFile(filepath.Join(appRoot, filepath.FromSlash(decl.SQL)))
+				if err != nil {
+					return nil, errors.Wrapf(err, "read seed %s", seed)
+				}
+				sum := sha256.Sum256(script)
+				seed.Script = script
+				seed.Checksum = hex.EncodeToString(sum[:])
+			}
+			seeds = append(seeds, seed)
+		}
+	}
+	return seeds, nil
+}
+
+// Pending returns the seeds that haven't been applied, given those that have.
+// If force is true all seeds are pending.
+//
+// Otherwise changed lists the SQL seeds that have been applied,
+// but whose script has changed since.
+func Pending(seeds []*Seed, applied []*Applied, force bool) (pending, changed []*Seed) {
+	if force {
+		return seeds, nil
+	}
+
+	type key struct{ database, seed string }
+	checksums := make(map[key]string, len(applied))
+	for _, a := range applied {
+		checksums[key{a.Database, a.Seed}] = a.Checksum
+	}
+	for _, seed := range seeds {
+		checksum, ok := checksums[key{seed.Database, seed.Name}]
+		if !ok {
+			pending = append(pending, seed)
+		} else if checksum != seed.Checksum {
+			changed = append(changed, seed)
+		}
+	}
+	return pending, changed
+}
@@ -0,0 +1,183 @@
+package dbseed
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"encr.dev/pkg/appfile"
+)
+
+const testSchema = `
+CREATE TABLE db_seed (
+	app_id TEXT NOT NULL,
+	namespace_id TEXT NOT NULL,
+	database TEXT NOT NULL,
+	seed TEXT NOT NULL,
+	checksum TEXT NOT NULL,
+	applied_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (app_id, namespace_id, database, seed)
+);
+`
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(testSchema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return NewStore(db)
+}
+
+func TestLoad(t *testing.T) {
+	appRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(appRoot, "users", "seeds"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(appRoot, "users", "seeds", "admins.sql"), []byte("INSERT INTO users VALUES (1);"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &appfile.Seeds{Databases: map[string][]appfile.Seed{
+		"users": {
+			{Name: "admins", SQL: "users/seeds/admins.sql"},
+			{Name: "demo", Go: "./users/seeds/demo"},
+		},
+		"orders": {
+			{Name: "demo", Go: "./orders/seeds"},
+		},
+	}}
+
+	seeds, err := Load(appRoot, cfg, nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	var got []string
+	for _, s := range seeds {
+		got = append(got, s.String())
+	}
+	if want := []string{"orders/demo", "users/admins", "users/demo"}; !slices.Equal(got, want) {
+		t.Errorf("got seeds %v, want %v", got, want)
+	}
+	if seeds[1].Checksum == "" || string(seeds[1].Script) != "INSERT INTO users VALUES (1);" {
+		t.Errorf("SQL seed not loaded: %+v", seeds[1])
+	}
+	if seeds[2].Checksum != "" {
+		t.Errorf("got checksum %q for Go seed, want none", seeds[2].Checksum)
+	}
+
+	seeds, err = Load(appRoot, cfg, []string{"orders"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(seeds) != 1 || seeds[0].String() != "orders/demo" {
+		t.Errorf("got seeds %v, want only orders/demo", seeds)
+	}
+
+	cfg.Databases["users"][0].SQL = "users/seeds/missing.sql"
+	if _, err := Load(appRoot, cfg, nil); err == nil {
+		t.Error("Load: got no error for missing SQL file")
+	}
+}
+
+func TestPending(t *testing.T) {
+	seeds := []*Seed{
+		{Seed: appfile.Seed{Name: "admins", SQL: "admins.sql"}, Database: "users", Checksum: "new"},
+		{Seed: appfile.Seed{Name: "roles", SQL: "roles.sql"}, Database: "users", Checksum: "same"},
+		{Seed: appfile.Seed{Name: "demo", Go: "./demo"}, Database: "users"},
+		{Seed: appfile.Seed{Name: "demo", Go: "./demo"}, Database: "orders"},
+	}
+	applied := []*Applied{
+		{Database: "users", Seed: "admins", Checksum: "old"},
+		{Database: "users", Seed: "roles", Checksum: "same"},
+		{Database: "users", Seed: "demo"},
+	}
+
+	pending, changed := Pending(seeds, applied, false)
+	if len(pending) != 1 || pending[0] != seeds[3] {
+		t.Errorf("got pending %v, want [orders/demo]", pending)
+	}
+	if len(changed) != 1 || changed[0] != seeds[0] {
+		t.Errorf("got changed %v, want [users/admins]", changed)
+	}
+
+	pending, changed = Pending(seeds, applied, true)
+	if len(pending) != len(seeds) || len(changed) != 0 {
+		t.Errorf("got pending %v and changed %v with force, want all pending", pending, changed)
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, a := range []*Applied{
+		{Database: "users", Seed: "admins", Checksum: "abc"},
+		{Database: "users", Seed: "demo"},
+		{Database: "orders", Seed: "demo"},
+	} {
+		a.AppliedAt = at.Add(time.Duration(i) * time.Minute)
+		if err := s.Record(ctx, "app", "ns1", a); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	if err := s.Record(ctx, "app", "ns2", &Applied{Database: "users", Seed: "admins", AppliedAt: at}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	// Re-applying a seed replaces the record.
+	if err := s.Record(ctx, "app", "ns1", &Applied{Database: "users", Seed: "admins", Checksum: "def", AppliedAt: at.Add(time.Hour)}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	applied, err := s.List(ctx, "app", "ns1")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got, want := names(applied), []string{"orders/demo", "users/demo", "users/admins"}; !slices.Equal(got, want) {
+		t.Errorf("got applied %v, want %v", got, want)
+	}
+	if applied[2].Checksum != "def" {
+		t.Errorf("got checksum %q, want %q", applied[2].Checksum, "def")
+	}
+
+	if err := s.Forget(ctx, "app", "ns1", []string{"users"}); err != nil {
+		t.Fatalf("Forget: %v", err)
+	}
+	applied, err = s.List(ctx, "app", "ns1")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if got, want := names(applied), []string{"orders/demo"}; !slices.Equal(got, want) {
+		t.Errorf("got applied %v after forgetting users, want %v", got, want)
+	}
+
+	if err := s.Forget(ctx, "app", "ns1", nil); err != nil {
+		t.Fatalf("Forget: %v", err)
+	}
+	if applied, _ := s.List(ctx, "app", "ns1"); len(applied) != 0 {
+		t.Errorf("got applied %v after forgetting all, want none", names(applied))
+	}
+	if applied, _ := s.List(ctx, "app", "ns2"); len(applied) != 1 {
+		t.Errorf("got applied %v in other namespace, want users/admins", names(applied))
+	}
+}
+
+func names(applied []*Applied) []string {
+	var names []string
+	for _, a := range applied {
+		names = append(names, a.Database+"/"+a.Seed)
+	}
+	return names
+}
@@ -0,0 +1,94 @@
+package dbseed
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
+)
+
+// Applied is a seed applied to a database.
+type Applied struct {
+	Database  string
+	Seed      string
+	Checksum  string // empty for Go seeds
+	AppliedAt time.Time
+}
+
+// Store records the seeds applied to the databases of each namespace.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore returns a Store using db.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// List returns the seeds applied to the databases of an app's namespace,
+// ordered by database and then by when they were applied.
+func (s *Store) List(ctx context.Context, appID string, nsID namespace.ID) ([]*Applied, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT database, seed, checksum, applied_at
+		FROM db_seed
+		WHERE app_id = ? AND namespace_id = ?
+		ORDER BY database, applied_at
+	`, appID, nsID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list applied seeds")
+	}
+	defer rows.Close()
+
+	var applied []*Applied
+	for rows.Next() {
+		var a Applied
+		if err := rows.Scan(&a.Database, &a.Seed, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, errors.Wrap(err, "scan applied seed")
+		}
+		applied = append(applied, &a)
+	}
+	return applied, errors.Wrap(rows.Err(), "list applied seeds")
+}
+
+// Record records that a seed has been applied to a database of an app's namespace,
+// replacing any earlier record of it being applied.
+func (s *Store) Record(ctx context.Context, appID string, nsID namespace.ID, a *Applied) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO db_seed (app_id, namespace_id, database, seed, checksum, applied_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, appID, nsID, a.Database, a.Seed, a.Checksum, a.AppliedAt)
+	return errors.Wrap(err, "record applied seed")
+}
+
+// Forget forgets the seeds applied to the given databases of an app's namespace,
+// such as when they're reset. If databases is nil it forgets those of all databases.
+func (s *Store) Forget(ctx context.Context, appID string, nsID namespace.ID, databases []string) error {
+	query := `DELETE FROM db_seed WHERE app_id = ? AND namespace_id = ?`
+	args := []any{appID, nsID}
+	if databases != nil {
+		if len(databases) == 0 {
+			return nil
+		}
+		query += ` AND database IN (?` + strings.Repeat(", ?", len(databases)-1) + `)`
+		for _, name := range databases {
+			args = append(args, name)
+		}
+	}
+	_, err := s.db.ExecContext(ctx, query, args...)
+	return errors.Wrap(err, "forget applied seeds")
+}
+
+// CanDeleteNamespace implements namespace.DeletionHandler.
+func (s *Store) CanDeleteNamespace(ctx context.Context, app *apps.Instance, ns *namespace.Namespace) error {
+	return nil
+}
+
+// DeleteNamespace implements namespace.DeletionHandler.
+func (s *Store) DeleteNamespace(ctx context.Context, app *apps.Instance, ns *namespace.Namespace) error {
+	return s.Forget(ctx, app.PlatformOrLocalID(), ns.ID, nil)
+}
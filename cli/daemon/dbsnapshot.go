@@ -0,0 +1,113 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/sqldb"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// DBSnapshotCreate dumps the app's databases in a namespace to a snapshot archive.
+func (s *Server) DBSnapshotCreate(ctx context.Context, req *daemonpb.DBSnapshotCreateRequest) (*daemonpb.DBSnapshotResponse, error) {
+	app, ns, dbs, err := s.snapshotDatabases(ctx, req.AppRoot, req.Namespace, req.DatabaseNames)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write to a temporary file next to the destination so a failed
+	// snapshot doesn't leave a partial archive behind.
+	f, err := os.CreateTemp(filepath.Dir(req.Path), ".encore-snapshot-*")
+	if err != nil {
+		return nil, errors.Wrap(err, "create snapshot file")
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	m, err := s.cm.CreateSnapshot(ctx, app, ns, dbs, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "create snapshot")
+	}
+	if err := os.Rename(f.Name(), req.Path); err != nil {
+		return nil, errors.Wrap(err, "write snapshot")
+	}
+
+	resp := snapshotResponse(m)
+	for _, db := range m.Databases {
+		resp.Databases = append(resp.Databases, db.Name)
+	}
+	return resp, nil
+}
+
+// DBSnapshotRestore restores the databases in a snapshot archive into a namespace.
+func (s *Server) DBSnapshotRestore(ctx context.Context, req *daemonpb.DBSnapshotRestoreRequest) (*daemonpb.DBSnapshotResponse, error) {
+	app, ns, dbs, err := s.snapshotDatabases(ctx, req.AppRoot, req.Namespace, req.DatabaseNames)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(req.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open snapshot")
+	}
+	defer func() { _ = f.Close() }()
+
+	m, skipped, err := s.cm.RestoreSnapshot(ctx, app, ns, dbs, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "restore snapshot")
+	}
+	resp := snapshotResponse(m)
+	resp.Skipped = skipped
+	for _, db := range m.Databases {
+		if !slices.Contains(skipped, db.Name) {
+			resp.Databases = append(resp.Databases, db.Name)
+		}
+	}
+	return resp, nil
+}
+
+// snapshotDatabases resolves the app, the namespace and the databases to snapshot or restore.
+// If names is empty all the app's databases are included.
+func (s *Server) snapshotDatabases(ctx context.Context, appRoot string, nsName *string, names []string) (*apps.Instance, *namespace.Namespace, []*meta.SQLDatabase, error) {
+	app, err := s.apps.Track(appRoot)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ns, err := s.namespaceOrActive(ctx, app, nsName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	md, err := parseAppMeta(ctx, app)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	dbs := sqldb.Databases(md)
+	if len(names) == 0 {
+		return app, ns, dbs, nil
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(dbs, func(db *meta.SQLDatabase) bool { return db.Name == name }) {
+			return nil, nil, nil, fmt.Errorf("database %q not found", name)
+		}
+	}
+	dbs = slices.DeleteFunc(dbs, func(db *meta.SQLDatabase) bool { return !slices.Contains(names, db.Name) })
+	return app, ns, dbs, nil
+}
+
+func snapshotResponse(m *sqldb.SnapshotManifest) *daemonpb.DBSnapshotResponse {
+	return &daemonpb.DBSnapshotResponse{
+		AppId:     m.AppID,
+		Namespace: m.Namespace,
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+	}
+}
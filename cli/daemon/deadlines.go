@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	daemonpb "encr.dev/proto/encore/daemon"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// AnalyzeDeadlines analyzes recorded traces for deadline and cancellation issues.
+func (s *Server) AnalyzeDeadlines(ctx context.Context, req *daemonpb.AnalyzeDeadlinesRequest) (*daemonpb.AnalyzeDeadlinesResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	appID := app.PlatformOrLocalID()
+
+	var traceIDs []string
+	if req.TraceId != nil {
+		traceIDs = []string{*req.TraceId}
+	} else {
+		q := &trace2.Query{AppID: appID, Limit: int(req.Limit)}
+		err := s.tr.List(ctx, q, func(sp *tracepb2.SpanSummary) bool {
+			traceIDs = append(traceIDs, sp.TraceId)
+			return true
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to list traces: %v", err)
+		}
+	}
+
+	resp := &daemonpb.AnalyzeDeadlinesResponse{}
+	for _, traceID := range traceIDs {
+		var events []*tracepb2.TraceEvent
+		err := s.tr.Get(ctx, appID, traceID, func(ev *tracepb2.TraceEvent) bool {
+			events = append(events, ev)
+			return true
+		})
+		if errors.Is(err, trace2.ErrNotFound) && req.TraceId != nil {
+			return nil, status.Errorf(codes.NotFound, "trace %s not found", traceID)
+		} else if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to get trace %s: %v", traceID, err)
+		}
+
+		resp.TracesAnalyzed++
+		for _, f := range trace2.AnalyzeDeadlines(events) {
+			resp.Findings = append(resp.Findings, deadlineFindingToProto(f))
+		}
+	}
+	return resp, nil
+}
+
+func deadlineFindingToProto(f trace2.DeadlineFinding) *daemonpb.DeadlineFinding {
+	pb := &daemonpb.DeadlineFinding{
+		TraceId:                      f.TraceID,
+		SpanId:                       f.SpanID,
+		ServiceName:                  f.Service,
+		EndpointName:                 f.Endpoint,
+		DurationNanos:                uint64(f.Duration),
+		DeadlineRemainingNanos:       durationToNanos(f.DeadlineRemaining),
+		ParentDeadlineRemainingNanos: durationToNanos(f.ParentDeadlineRemaining),
+	}
+	if f.ParentSpanID != "" {
+		pb.ParentSpanId = &f.ParentSpanID
+	}
+	if f.CancelCause != "" {
+		pb.CancelCause = &f.CancelCause
+	}
+
+	switch f.Issue {
+	case trace2.DeadlineExceeded:
+		pb.Issue = daemonpb.DeadlineFinding_ISSUE_DEADLINE_EXCEEDED
+	case trace2.DeadlineNotPropagated:
+		pb.Issue = daemonpb.DeadlineFinding_ISSUE_DEADLINE_NOT_PROPAGATED
+	case trace2.Canceled:
+		pb.Issue = daemonpb.DeadlineFinding_ISSUE_CANCELED
+	}
+	return pb
+}
+
+func durationToNanos(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
+	}
+	n := int64(*d)
+	return &n
+}
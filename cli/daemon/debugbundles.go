@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// DebugBundles returns the debug bundles captured for failed requests of an app.
+func (s *Server) DebugBundles(ctx context.Context, req *daemonpb.DebugBundlesRequest) (*daemonpb.DebugBundlesResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &daemonpb.DebugBundlesResponse{}
+	for _, b := range s.mgr.DebugBundles(app.PlatformOrLocalID()) {
+		if req.TraceId != nil && b.TraceID != *req.TraceId {
+			continue
+		}
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to encode debug bundle: %v", err)
+		}
+		endpoint := b.Endpoint
+		if b.Subscription != "" {
+			endpoint = b.Subscription
+		}
+		resp.Bundles = append(resp.Bundles, &daemonpb.DebugBundle{
+			TraceId:  b.TraceID,
+			SpanId:   b.SpanID,
+			Service:  b.Service,
+			Endpoint: endpoint,
+			Code:     b.Code,
+			Error:    b.Error,
+			Time:     b.Time.Format(time.RFC3339),
+			Data:     data,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,35 @@
+// Package delve starts and controls Delve debugger sessions
+// for the processes of a run.
+package delve
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Find finds the dlv binary on PATH or in the Go bin directory.
+func Find() (string, error) {
+	if path, err := exec.LookPath("dlv"); err == nil {
+		return path, nil
+	}
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			gopath = filepath.Join(home, "go")
+		}
+	}
+	if gopath != "" {
+		path := filepath.Join(gopath, "bin", "dlv")
+		if runtime.GOOS == "windows" {
+			path += ".exe"
+		}
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("dlv not found: install it with 'go install github.com/go-delve/delve/cmd/dlv@latest'")
+}
@@ -0,0 +1,423 @@
+package delve
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/internal/bindaddr"
+)
+
+// Logpoint is a location in a process at which expressions are
+// evaluated and reported each time it is reached, without stopping
+// the process for longer than it takes to evaluate them.
+type Logpoint struct {
+	// ID identifies the logpoint in its hits.
+	ID int
+	// Location is a Delve location expression, such as "users.go:42" or "users.Get".
+	Location string
+	// Exprs are the expressions to evaluate.
+	Exprs []string
+	// Cond, if non-empty, is an expression that must be true for the logpoint to be hit.
+	Cond string
+}
+
+// Hit describes a logpoint being reached.
+type Hit struct {
+	LogpointID int
+	File       string
+	Line       int
+	Function   string
+	Values     []Value
+}
+
+// Value is the value of a logpoint expression.
+type Value struct {
+	Expr  string
+	Value string // the formatted value, if Err is empty
+	Err   string // why the expression could not be evaluated, if it failed
+}
+
+// Session is a headless Delve session attached to a process,
+// reporting the hits of the logpoints added to it.
+type Session struct {
+	Pid int
+
+	cmd    *exec.Cmd
+	client *rpc.Client
+	onHit  func(Hit)
+
+	mu       sync.Mutex  // serializes requests
+	requests chan func() // requests for the session loop to run while the process is halted
+	done     chan struct{}
+	err      error // why the session ended; set before done is closed
+
+	// Owned by the session loop.
+	logpoints map[int]int // logpoint id by Delve breakpoint id
+	detached  bool
+}
+
+// Attach starts a Delve server attached to the process pid.
+// onHit is called from a single goroutine for each logpoint hit.
+func Attach(ctx context.Context, pid int, onHit func(Hit)) (*Session, error) {
+	dlv, err := Find()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(dlv, "attach", strconv.Itoa(pid), "--headless", "--api-version=2", "--listen="+bindaddr.LoopbackAddr(0))
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "start dlv")
+	}
+
+	// Delve reports the address it listens on once it has attached.
+	addrc := make(chan string, 1)
+	go func() {
+		const prefix = "API server listening at: "
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			if addr, ok := strings.CutPrefix(scanner.Text(), prefix); ok {
+				addrc <- strings.TrimSpace(addr)
+				break
+			}
+		}
+		_, _ = io.Copy(io.Discard, stdout)
+		close(addrc)
+	}()
+
+	var addr string
+	select {
+	case addr = <-addrc:
+	case <-ctx.Done():
+	case <-time.After(10 * time.Second):
+	}
+	if addr == "" {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, errors.Newf("dlv failed to attach to process %d", pid)
+	}
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, errors.Wrap(err, "connect to dlv")
+	}
+
+	s := &Session{
+		Pid:       pid,
+		cmd:       cmd,
+		client:    jsonrpc.NewClient(conn),
+		onHit:     onHit,
+		requests:  make(chan func(), 1),
+		done:      make(chan struct{}),
+		logpoints: make(map[int]int),
+	}
+	go s.loop()
+	return s, nil
+}
+
+// Done returns a channel that is closed when the session has ended,
+// either because it was closed or because the process exited.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Add adds a logpoint.
+func (s *Session) Add(lp Logpoint) error {
+	return s.do(func() error {
+		var out createBreakpointOut
+		err := s.client.Call("RPCServer.CreateBreakpoint", createBreakpointIn{
+			Breakpoint: breakpoint{
+				Tracepoint: true,
+				Cond:       lp.Cond,
+				Variables:  lp.Exprs,
+				LoadArgs:   &defaultLoadConfig,
+			},
+			LocExpr: lp.Location,
+		}, &out)
+		if err != nil {
+			return errors.Wrapf(err, "set logpoint at %s", lp.Location)
+		}
+		s.logpoints[out.Breakpoint.ID] = lp.ID
+		return nil
+	})
+}
+
+// Remove removes a logpoint.
+func (s *Session) Remove(id int) error {
+	return s.do(func() error {
+		for bpID, lpID := range s.logpoints {
+			if lpID == id {
+				delete(s.logpoints, bpID)
+				return s.client.Call("RPCServer.ClearBreakpoint", clearBreakpointIn{ID: bpID}, &struct{}{})
+			}
+		}
+		return nil
+	})
+}
+
+// Close detaches from the process, leaving it running, and stops Delve.
+func (s *Session) Close() error {
+	err := s.do(func() error {
+		s.detached = true
+		return s.client.Call("RPCServer.Detach", detachIn{Kill: false}, &struct{}{})
+	})
+	_ = s.client.Close()
+	_ = s.cmd.Process.Kill()
+	_ = s.cmd.Wait()
+	return err
+}
+
+// do runs fn in the session loop while the process is halted.
+func (s *Session) do(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	errc := make(chan error, 1)
+	select {
+	case s.requests <- func() { errc <- fn() }:
+	case <-s.done:
+		return s.err
+	}
+
+	for {
+		// Halt the process to make the pending continue return.
+		// Retry in case it raced with a logpoint hit.
+		_ = s.client.Call("RPCServer.Command", debuggerCommand{Name: "halt"}, &commandOut{})
+		select {
+		case err := <-errc:
+			return err
+		case <-s.done:
+			return s.err
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
+
+// loop continues the process, reporting logpoint hits
+// and running requests whenever it stops.
+func (s *Session) loop() {
+	defer close(s.done)
+	for {
+		var out commandOut
+		if err := s.client.Call("RPCServer.Command", debuggerCommand{Name: "continue"}, &out); err != nil {
+			s.err = errors.Wrap(err, "debugger session ended")
+			return
+		} else if out.State.Exited {
+			s.err = errors.Newf("process %d exited with status %d", s.Pid, out.State.ExitStatus)
+			return
+		}
+
+		for _, th := range out.State.Threads {
+			if th.Breakpoint == nil || th.BreakpointInfo == nil {
+				continue
+			}
+			id, ok := s.logpoints[th.Breakpoint.ID]
+			if !ok {
+				continue
+			}
+			hit := Hit{LogpointID: id, File: th.File, Line: th.Line}
+			if th.Function != nil {
+				hit.Function = th.Function.Name
+			}
+			for _, v := range th.BreakpointInfo.Variables {
+				val := Value{Expr: v.Name}
+				if v.Unreadable != "" {
+					val.Err = v.Unreadable
+				} else {
+					val.Value = formatValue(v)
+				}
+				hit.Values = append(hit.Values, val)
+			}
+			s.onHit(hit)
+		}
+
+		select {
+		case fn := <-s.requests:
+			fn()
+			if s.detached {
+				s.err = errors.New("debugger session closed")
+				return
+			}
+		default:
+		}
+	}
+}
+
+// formatValue formats a variable loaded by Delve, similarly to Go syntax.
+func formatValue(v variable) string {
+	if v.Unreadable != "" {
+		return "(unreadable: " + v.Unreadable + ")"
+	}
+
+	var b strings.Builder
+	writeList := func(open, close string, elems []string, n int) {
+		b.WriteString(open)
+		b.WriteString(strings.Join(elems, ", "))
+		if n > len(elems) {
+			b.WriteString(", ...+" + strconv.Itoa(n-len(elems)))
+		}
+		b.WriteString(close)
+	}
+
+	switch v.Kind {
+	case reflect.String:
+		s := strconv.Quote(v.Value)
+		if v.Len > int64(len(v.Value)) {
+			s += "...+" + strconv.FormatInt(v.Len-int64(len(v.Value)), 10)
+		}
+		return s
+
+	case reflect.Ptr, reflect.UnsafePointer:
+		if len(v.Children) == 0 || v.Children[0].Addr == 0 {
+			return "nil"
+		} else if v.Children[0].OnlyAddr {
+			return "(" + v.Type + ")(0x" + strconv.FormatUint(v.Children[0].Addr, 16) + ")"
+		}
+		return "&" + formatValue(v.Children[0])
+
+	case reflect.Interface:
+		if len(v.Children) == 0 || v.Children[0].Kind == reflect.Invalid {
+			return "nil"
+		}
+		return formatValue(v.Children[0])
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind == reflect.Slice && v.Base == 0 {
+			return "nil"
+		}
+		elems := make([]string, len(v.Children))
+		for i, c := range v.Children {
+			elems[i] = formatValue(c)
+		}
+		writeList(v.Type+"{", "}", elems, int(v.Len))
+		return b.String()
+
+	case reflect.Map:
+		if v.Base == 0 {
+			return "nil"
+		}
+		var elems []string
+		for i := 0; i+1 < len(v.Children); i += 2 {
+			elems = append(elems, formatValue(v.Children[i])+": "+formatValue(v.Children[i+1]))
+		}
+		writeList(v.Type+"{", "}", elems, int(v.Len))
+		return b.String()
+
+	case reflect.Struct:
+		elems := make([]string, len(v.Children))
+		for i, c := range v.Children {
+			elems[i] = c.Name + ": " + formatValue(c)
+		}
+		writeList(v.Type+"{", "}", elems, len(elems))
+		return b.String()
+
+	default:
+		return v.Value
+	}
+}
+
+// The types below mirror the parts of Delve's JSON-RPC API
+// (github.com/go-delve/delve/service/rpc2) used by sessions.
+
+var defaultLoadConfig = loadConfig{
+	FollowPointers:     true,
+	MaxVariableRecurse: 2,
+	MaxStringLen:       256,
+	MaxArrayValues:     32,
+	MaxStructFields:    -1,
+}
+
+type loadConfig struct {
+	FollowPointers     bool
+	MaxVariableRecurse int
+	MaxStringLen       int
+	MaxArrayValues     int
+	MaxStructFields    int
+}
+
+type breakpoint struct {
+	ID         int         `json:"id"`
+	File       string      `json:"file"`
+	Line       int         `json:"line"`
+	Cond       string      `json:"Cond"`
+	Tracepoint bool        `json:"continue"`
+	Variables  []string    `json:"variables,omitempty"`
+	LoadArgs   *loadConfig `json:"LoadArgs"`
+}
+
+type createBreakpointIn struct {
+	Breakpoint breakpoint
+	LocExpr    string
+}
+
+type createBreakpointOut struct {
+	Breakpoint breakpoint
+}
+
+type clearBreakpointIn struct {
+	ID int `json:"Id"`
+}
+
+type detachIn struct {
+	Kill bool
+}
+
+type debuggerCommand struct {
+	Name string `json:"name"`
+}
+
+type commandOut struct {
+	State debuggerState
+}
+
+type debuggerState struct {
+	Threads    []*thread `json:"Threads"`
+	Exited     bool      `json:"exited"`
+	ExitStatus int       `json:"exitStatus"`
+}
+
+type thread struct {
+	File           string          `json:"file"`
+	Line           int             `json:"line"`
+	Function       *function       `json:"function,omitempty"`
+	Breakpoint     *breakpoint     `json:"breakPoint,omitempty"`
+	BreakpointInfo *breakpointInfo `json:"breakPointInfo,omitempty"`
+}
+
+type function struct {
+	Name string `json:"name"`
+}
+
+type breakpointInfo struct {
+	Variables []variable `json:"variables,omitempty"`
+}
+
+type variable struct {
+	Name       string       `json:"name"`
+	Addr       uint64       `json:"addr"`
+	OnlyAddr   bool         `json:"onlyAddr"`
+	Type       string       `json:"type"`
+	Kind       reflect.Kind `json:"kind"`
+	Value      string       `json:"value"`
+	Len        int64        `json:"len"`
+	Children   []variable   `json:"children"`
+	Base       uint64       `json:"base"`
+	Unreadable string       `json:"unreadable"`
+}
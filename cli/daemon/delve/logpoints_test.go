@@ -0,0 +1,62 @@
+package delve
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestFormatValue(t *testing.T) {
+	c := qt.New(t)
+	str := func(s string) variable { return variable{Kind: reflect.String, Value: s, Len: int64(len(s))} }
+	num := func(s string) variable { return variable{Kind: reflect.Int, Value: s} }
+
+	tests := []struct {
+		name string
+		v    variable
+		want string
+	}{
+		{"string", str(`hi "there"`), `"hi \"there\""`},
+		{"truncated string", variable{Kind: reflect.String, Value: "abc", Len: 10}, `"abc"...+7`},
+		{"int", num("42"), "42"},
+		{"nil pointer", variable{Kind: reflect.Ptr, Type: "*users.User", Children: []variable{{}}}, "nil"},
+		{"struct pointer", variable{Kind: reflect.Ptr, Type: "*users.User", Children: []variable{{
+			Kind: reflect.Struct, Type: "users.User", Addr: 0xc000010000,
+			Children: []variable{
+				{Name: "Name", Kind: reflect.String, Value: "ann", Len: 3},
+				{Name: "Age", Kind: reflect.Int, Value: "30"},
+			},
+		}}}, `&users.User{Name: "ann", Age: 30}`},
+		{"nil slice", variable{Kind: reflect.Slice, Type: "[]int"}, "nil"},
+		{"truncated slice", variable{Kind: reflect.Slice, Type: "[]int", Base: 1, Len: 5, Children: []variable{num("1"), num("2")}}, "[]int{1, 2, ...+3}"},
+		{"map", variable{Kind: reflect.Map, Type: "map[string]int", Base: 1, Len: 1, Children: []variable{str("a"), num("1")}}, `map[string]int{"a": 1}`},
+		{"nil interface", variable{Kind: reflect.Interface, Type: "error", Children: []variable{{Kind: reflect.Invalid}}}, "nil"},
+		{"unreadable", variable{Unreadable: "eval error: could not find symbol value for x"}, "(unreadable: eval error: could not find symbol value for x)"},
+	}
+	for _, test := range tests {
+		c.Run(test.name, func(c *qt.C) {
+			c.Assert(formatValue(test.v), qt.Equals, test.want)
+		})
+	}
+}
+
+func TestDecodeDebuggerState(t *testing.T) {
+	c := qt.New(t)
+
+	// A trimmed response from Delve's RPCServer.Command for a tracepoint hit.
+	const resp = `{"State": {"Running": false, "Threads": [{"id": 1, "file": "/app/users/users.go", "line": 42,
+		"function": {"name": "encore.app/users.Get"},
+		"breakPoint": {"id": 3, "file": "/app/users/users.go", "line": 42, "continue": true, "variables": ["id"]},
+		"breakPointInfo": {"variables": [{"name": "id", "kind": 2, "value": "7", "children": []}]}}],
+		"exited": false, "exitStatus": 0}}`
+	var out commandOut
+	c.Assert(json.Unmarshal([]byte(resp), &out), qt.IsNil)
+	c.Assert(out.State.Threads, qt.HasLen, 1)
+	th := out.State.Threads[0]
+	c.Assert(th.Breakpoint.ID, qt.Equals, 3)
+	c.Assert(th.Breakpoint.Tracepoint, qt.IsTrue)
+	c.Assert(th.Function.Name, qt.Equals, "encore.app/users.Get")
+	c.Assert(formatValue(th.BreakpointInfo.Variables[0]), qt.Equals, "7")
+}
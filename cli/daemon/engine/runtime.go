@@ -2,12 +2,14 @@ package engine
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/cockroachdb/errors"
 
+	"encore.dev/appruntime/exported/debugbundle"
 	tracemodel "encore.dev/appruntime/exported/trace2"
 	"encr.dev/cli/daemon/engine/trace2"
 	"encr.dev/cli/daemon/run"
@@ -28,6 +30,8 @@ func (s *server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	switch req.URL.Path {
 	case "/trace":
 		s.RecordTrace(w, req)
+	case "/debug-bundle":
+		s.RecordDebugBundle(w, req)
 	default:
 		http.Error(w, "Not Found", http.StatusNotFound)
 	}
@@ -47,6 +51,23 @@ func (s *server) RecordTrace(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// RecordDebugBundle records a debug bundle of a failed request to a running app.
+func (s *server) RecordDebugBundle(w http.ResponseWriter, req *http.Request) {
+	pid := req.Header.Get("X-Encore-Env-ID")
+	proc := s.runMgr.FindProc(pid)
+	if proc == nil {
+		http.Error(w, fmt.Sprintf("process %q is not running", pid), http.StatusBadRequest)
+		return
+	}
+
+	var b debugbundle.Bundle
+	if err := json.NewDecoder(req.Body).Decode(&b); err != nil {
+		http.Error(w, "unable to parse debug bundle: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.runMgr.RecordDebugBundle(proc.Run.App.PlatformOrLocalID(), &b)
+}
+
 func (s *server) parseTraceData(req *http.Request) (d trace2.RecordData, err error) {
 	// Parse trace version
 	traceVersion := req.Header.Get("X-Encore-Trace-Version")
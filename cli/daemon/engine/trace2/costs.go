@@ -0,0 +1,225 @@
+package trace2
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// Usage is the resource usage attributed to one or more requests.
+type Usage struct {
+	Requests    int
+	RequestTime time.Duration // total duration of the requests
+
+	DBQueries int
+	DBTime    time.Duration
+
+	CacheOps  int
+	CacheTime time.Duration
+
+	Publishes   int // Pub/Sub messages published
+	PublishTime time.Duration
+
+	HTTPCalls int // outgoing HTTP calls to external services
+	HTTPTime  time.Duration
+}
+
+// Add adds the usage in o to u.
+func (u *Usage) Add(o Usage) {
+	u.Requests += o.Requests
+	u.RequestTime += o.RequestTime
+	u.DBQueries += o.DBQueries
+	u.DBTime += o.DBTime
+	u.CacheOps += o.CacheOps
+	u.CacheTime += o.CacheTime
+	u.Publishes += o.Publishes
+	u.PublishTime += o.PublishTime
+	u.HTTPCalls += o.HTTPCalls
+	u.HTTPTime += o.HTTPTime
+}
+
+// ResourceTime is the total time spent on databases, caches, Pub/Sub and external HTTP calls.
+func (u *Usage) ResourceTime() time.Duration {
+	return u.DBTime + u.CacheTime + u.PublishTime + u.HTTPTime
+}
+
+// RequestUsage is the resource usage of a single request.
+type RequestUsage struct {
+	TraceID  string
+	SpanID   string
+	Service  string
+	Endpoint string
+	UID      string // the authenticated user, if any
+	Usage
+}
+
+// AttributeUsage attributes the database queries, cache operations,
+// Pub/Sub publishes and external HTTP calls in the events of a single
+// trace to the requests that made them.
+//
+// Usage is attributed to the request that made it directly, not to its
+// callers, so the usage of a request that calls other endpoints only
+// includes the time spent waiting for them in its RequestTime.
+// Operations that have not completed are not counted.
+func AttributeUsage(events []*tracepb2.TraceEvent) []RequestUsage {
+	type op struct {
+		spanID uint64
+		start  time.Time
+		kind   int
+	}
+	const (
+		opDB = iota
+		opCache
+		opPublish
+		opHTTP
+	)
+
+	reqs := make(map[uint64]*RequestUsage)
+	var order []uint64
+	get := func(ev *tracepb2.TraceEvent) *RequestUsage {
+		r, ok := reqs[ev.SpanId]
+		if !ok {
+			r = &RequestUsage{TraceID: EncodeTraceID(ev.TraceId), SpanID: EncodeSpanID(ev.SpanId)}
+			reqs[ev.SpanId] = r
+			order = append(order, ev.SpanId)
+		}
+		return r
+	}
+
+	// Operations are recorded as start and end events, correlated by event id.
+	starts := make(map[uint64]op)
+	type end struct {
+		corrID uint64
+		at     time.Time
+	}
+	var ends []end
+
+	for _, ev := range events {
+		switch e := ev.Event.(type) {
+		case *tracepb2.TraceEvent_SpanStart:
+			if req := e.SpanStart.GetRequest(); req != nil {
+				r := get(ev)
+				r.Service, r.Endpoint = req.ServiceName, req.EndpointName
+				if uid := req.GetUid(); uid != "" {
+					r.UID = uid
+				}
+			}
+		case *tracepb2.TraceEvent_SpanEnd:
+			if req := e.SpanEnd.GetRequest(); req != nil {
+				r := get(ev)
+				r.Requests = 1
+				r.RequestTime = time.Duration(e.SpanEnd.DurationNanos)
+				if uid := req.GetUid(); uid != "" {
+					r.UID = uid
+				}
+			}
+		case *tracepb2.TraceEvent_SpanEvent:
+			at := ev.EventTime.AsTime()
+			switch e.SpanEvent.Data.(type) {
+			case *tracepb2.SpanEvent_DbQueryStart:
+				starts[ev.EventId] = op{ev.SpanId, at, opDB}
+			case *tracepb2.SpanEvent_CacheCallStart:
+				starts[ev.EventId] = op{ev.SpanId, at, opCache}
+			case *tracepb2.SpanEvent_PubsubPublishStart:
+				starts[ev.EventId] = op{ev.SpanId, at, opPublish}
+			case *tracepb2.SpanEvent_HttpCallStart:
+				starts[ev.EventId] = op{ev.SpanId, at, opHTTP}
+			case *tracepb2.SpanEvent_DbQueryEnd, *tracepb2.SpanEvent_CacheCallEnd,
+				*tracepb2.SpanEvent_PubsubPublishEnd, *tracepb2.SpanEvent_HttpCallEnd:
+				if id := e.SpanEvent.CorrelationEventId; id != nil {
+					ends = append(ends, end{*id, at})
+				}
+			}
+		}
+	}
+
+	for _, e := range ends {
+		o, ok := starts[e.corrID]
+		if !ok {
+			continue
+		}
+		r, ok := reqs[o.spanID]
+		if !ok {
+			// The operation was made outside of a request,
+			// such as by a Pub/Sub subscription handler.
+			continue
+		}
+		d := max(e.at.Sub(o.start), 0)
+		switch o.kind {
+		case opDB:
+			r.DBQueries++
+			r.DBTime += d
+		case opCache:
+			r.CacheOps++
+			r.CacheTime += d
+		case opPublish:
+			r.Publishes++
+			r.PublishTime += d
+		case opHTTP:
+			r.HTTPCalls++
+			r.HTTPTime += d
+		}
+	}
+
+	result := make([]RequestUsage, 0, len(order))
+	for _, id := range order {
+		if r := reqs[id]; r.Endpoint != "" {
+			result = append(result, *r)
+		}
+	}
+	return result
+}
+
+// UsageGrouping is how AggregateUsage groups requests.
+type UsageGrouping int
+
+const (
+	// ByEndpoint groups requests by the endpoint they called.
+	ByEndpoint UsageGrouping = iota
+	// ByUID groups requests by the authenticated user that made them.
+	ByUID
+)
+
+// UsageGroup is the aggregated usage of a group of requests.
+type UsageGroup struct {
+	Service  string // set when grouping by endpoint
+	Endpoint string // set when grouping by endpoint
+	UID      string // set when grouping by user; empty for unauthenticated requests
+	Usage
+}
+
+// AggregateUsage aggregates the usage of requests by endpoint or user,
+// sorted by the time spent on resources, most first.
+func AggregateUsage(reqs []RequestUsage, by UsageGrouping) []UsageGroup {
+	type key struct{ service, endpoint, uid string }
+	groups := make(map[key]*UsageGroup)
+	for _, r := range reqs {
+		k := key{uid: r.UID}
+		if by == ByEndpoint {
+			k = key{service: r.Service, endpoint: r.Endpoint}
+		}
+		g, ok := groups[k]
+		if !ok {
+			g = &UsageGroup{Service: k.service, Endpoint: k.endpoint, UID: k.uid}
+			groups[k] = g
+		}
+		g.Add(r.Usage)
+	}
+
+	result := make([]UsageGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	slices.SortFunc(result, func(a, b UsageGroup) int {
+		return cmp.Or(
+			cmp.Compare(b.ResourceTime(), a.ResourceTime()),
+			cmp.Compare(b.Requests, a.Requests),
+			cmp.Compare(a.Service, b.Service),
+			cmp.Compare(a.Endpoint, b.Endpoint),
+			cmp.Compare(a.UID, b.UID),
+		)
+	})
+	return result
+}
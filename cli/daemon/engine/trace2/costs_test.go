@@ -0,0 +1,103 @@
+package trace2
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+func TestAttributeUsage(t *testing.T) {
+	c := qt.New(t)
+	traceID := &tracepb2.TraceID{High: 1, Low: 2}
+	uid := "user-1"
+
+	at := func(d time.Duration) *timestamppb.Timestamp { return timestamppb.New(time.Unix(0, int64(d))) }
+	start := func(spanID uint64, endpoint string) *tracepb2.TraceEvent {
+		return &tracepb2.TraceEvent{
+			TraceId:   traceID,
+			SpanId:    spanID,
+			EventTime: at(0),
+			Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+				Data: &tracepb2.SpanStart_Request{Request: &tracepb2.RequestSpanStart{
+					ServiceName:  "svc",
+					EndpointName: endpoint,
+				}},
+			}},
+		}
+	}
+	end := func(spanID uint64, dur time.Duration, uid *string) *tracepb2.TraceEvent {
+		return &tracepb2.TraceEvent{
+			TraceId: traceID,
+			SpanId:  spanID,
+			Event: &tracepb2.TraceEvent_SpanEnd{SpanEnd: &tracepb2.SpanEnd{
+				DurationNanos: uint64(dur),
+				Data:          &tracepb2.SpanEnd_Request{Request: &tracepb2.RequestSpanEnd{Uid: uid}},
+			}},
+		}
+	}
+	event := func(spanID, eventID uint64, t time.Duration, corr *uint64, ev *tracepb2.SpanEvent) *tracepb2.TraceEvent {
+		ev.CorrelationEventId = corr
+		return &tracepb2.TraceEvent{
+			TraceId:   traceID,
+			SpanId:    spanID,
+			EventId:   eventID,
+			EventTime: at(t),
+			Event:     &tracepb2.TraceEvent_SpanEvent{SpanEvent: ev},
+		}
+	}
+	ref := func(id uint64) *uint64 { return &id }
+
+	reqs := AttributeUsage([]*tracepb2.TraceEvent{
+		start(1, "Get"),
+		event(1, 10, 1*time.Millisecond, nil, &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_DbQueryStart{}}),
+		event(1, 11, 4*time.Millisecond, ref(10), &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_DbQueryEnd{}}),
+		event(1, 12, 5*time.Millisecond, nil, &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_CacheCallStart{}}),
+		event(1, 13, 6*time.Millisecond, ref(12), &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_CacheCallEnd{}}),
+		// An HTTP call that has not completed is not counted.
+		event(1, 14, 6*time.Millisecond, nil, &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_HttpCallStart{}}),
+		end(1, 10*time.Millisecond, &uid),
+
+		start(2, "Publish"),
+		event(2, 20, 1*time.Millisecond, nil, &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_PubsubPublishStart{}}),
+		event(2, 21, 3*time.Millisecond, ref(20), &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_PubsubPublishEnd{}}),
+		event(2, 22, 3*time.Millisecond, nil, &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_HttpCallStart{}}),
+		event(2, 23, 8*time.Millisecond, ref(22), &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_HttpCallEnd{}}),
+		end(2, 9*time.Millisecond, nil),
+	})
+
+	c.Assert(reqs, qt.HasLen, 2)
+	c.Assert(reqs[0].Endpoint, qt.Equals, "Get")
+	c.Assert(reqs[0].UID, qt.Equals, uid)
+	c.Assert(reqs[0].Usage, qt.DeepEquals, Usage{
+		Requests: 1, RequestTime: 10 * time.Millisecond,
+		DBQueries: 1, DBTime: 3 * time.Millisecond,
+		CacheOps: 1, CacheTime: time.Millisecond,
+	})
+	c.Assert(reqs[1].Endpoint, qt.Equals, "Publish")
+	c.Assert(reqs[1].UID, qt.Equals, "")
+	c.Assert(reqs[1].Usage, qt.DeepEquals, Usage{
+		Requests: 1, RequestTime: 9 * time.Millisecond,
+		Publishes: 1, PublishTime: 2 * time.Millisecond,
+		HTTPCalls: 1, HTTPTime: 5 * time.Millisecond,
+	})
+
+	// Aggregate a second request by the same user to the Get endpoint.
+	reqs = append(reqs, reqs[0])
+	byEndpoint := AggregateUsage(reqs, ByEndpoint)
+	c.Assert(byEndpoint, qt.HasLen, 2)
+	c.Assert(byEndpoint[0].Endpoint, qt.Equals, "Get")
+	c.Assert(byEndpoint[0].Requests, qt.Equals, 2)
+	c.Assert(byEndpoint[0].ResourceTime(), qt.Equals, 8*time.Millisecond)
+	c.Assert(byEndpoint[1].Endpoint, qt.Equals, "Publish")
+
+	byUID := AggregateUsage(reqs, ByUID)
+	c.Assert(byUID, qt.HasLen, 2)
+	c.Assert(byUID[0].UID, qt.Equals, uid)
+	c.Assert(byUID[0].Endpoint, qt.Equals, "")
+	c.Assert(byUID[0].DBQueries, qt.Equals, 2)
+	c.Assert(byUID[1].UID, qt.Equals, "")
+}
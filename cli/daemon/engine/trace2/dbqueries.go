@@ -0,0 +1,57 @@
+package trace2
+
+import (
+	"slices"
+	"time"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// DBQuery is a database query recorded in a trace.
+type DBQuery struct {
+	TraceID string
+	SpanID  string
+	Query   string
+
+	// Start and End are when the app started and finished the query.
+	Start, End time.Time
+}
+
+// DBQueries returns the completed database queries in the events
+// of a single trace, ordered by when they were started.
+func DBQueries(events []*tracepb2.TraceEvent) []DBQuery {
+	type end struct {
+		corrID uint64
+		at     time.Time
+	}
+	starts := make(map[uint64]*tracepb2.TraceEvent) // event id -> query start
+	var ends []end
+
+	// Events may be out of order, so pair them up once all are read.
+	for _, ev := range events {
+		se := ev.GetSpanEvent()
+		switch {
+		case se.GetDbQueryStart() != nil:
+			starts[ev.EventId] = ev
+		case se.GetDbQueryEnd() != nil && se.CorrelationEventId != nil:
+			ends = append(ends, end{*se.CorrelationEventId, ev.EventTime.AsTime()})
+		}
+	}
+
+	var queries []DBQuery
+	for _, e := range ends {
+		start, ok := starts[e.corrID]
+		if !ok {
+			continue
+		}
+		queries = append(queries, DBQuery{
+			TraceID: EncodeTraceID(start.TraceId),
+			SpanID:  EncodeSpanID(start.SpanId),
+			Query:   start.GetSpanEvent().GetDbQueryStart().Query,
+			Start:   start.EventTime.AsTime(),
+			End:     e.at,
+		})
+	}
+	slices.SortFunc(queries, func(a, b DBQuery) int { return a.Start.Compare(b.Start) })
+	return queries
+}
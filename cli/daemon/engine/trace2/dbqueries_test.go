@@ -0,0 +1,56 @@
+package trace2
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+func TestDBQueries(t *testing.T) {
+	c := qt.New(t)
+	traceID := &tracepb2.TraceID{High: 1, Low: 2}
+
+	at := func(d time.Duration) *timestamppb.Timestamp { return timestamppb.New(time.Unix(0, int64(d))) }
+	event := func(spanID, eventID uint64, t time.Duration, corr *uint64, ev *tracepb2.SpanEvent) *tracepb2.TraceEvent {
+		ev.CorrelationEventId = corr
+		return &tracepb2.TraceEvent{
+			TraceId:   traceID,
+			SpanId:    spanID,
+			EventId:   eventID,
+			EventTime: at(t),
+			Event:     &tracepb2.TraceEvent_SpanEvent{SpanEvent: ev},
+		}
+	}
+	ref := func(id uint64) *uint64 { return &id }
+	queryStart := func(q string) *tracepb2.SpanEvent {
+		return &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_DbQueryStart{DbQueryStart: &tracepb2.DBQueryStart{Query: q}}}
+	}
+	queryEnd := func() *tracepb2.SpanEvent {
+		return &tracepb2.SpanEvent{Data: &tracepb2.SpanEvent_DbQueryEnd{DbQueryEnd: &tracepb2.DBQueryEnd{}}}
+	}
+
+	queries := DBQueries([]*tracepb2.TraceEvent{
+		// The end of the second query is read before the first one's.
+		event(2, 20, 5*time.Millisecond, nil, queryStart("SELECT 2")),
+		event(2, 21, 7*time.Millisecond, ref(20), queryEnd()),
+		event(1, 10, 1*time.Millisecond, nil, queryStart("SELECT 1")),
+		event(1, 11, 4*time.Millisecond, ref(10), queryEnd()),
+		// A query that has not completed is left out.
+		event(1, 12, 8*time.Millisecond, nil, queryStart("SELECT 3")),
+	})
+
+	c.Assert(queries, qt.DeepEquals, []DBQuery{
+		{
+			TraceID: EncodeTraceID(traceID), SpanID: EncodeSpanID(1), Query: "SELECT 1",
+			Start: at(1 * time.Millisecond).AsTime(), End: at(4 * time.Millisecond).AsTime(),
+		},
+		{
+			TraceID: EncodeTraceID(traceID), SpanID: EncodeSpanID(2), Query: "SELECT 2",
+			Start: at(5 * time.Millisecond).AsTime(), End: at(7 * time.Millisecond).AsTime(),
+		},
+	})
+}
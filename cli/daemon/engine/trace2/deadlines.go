@@ -0,0 +1,168 @@
+package trace2
+
+import (
+	"sort"
+	"time"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// DeadlineIssue describes a problem with how a request handled
+// its context deadline or cancellation.
+type DeadlineIssue int
+
+const (
+	// DeadlineExceeded means the request completed after its deadline.
+	DeadlineExceeded DeadlineIssue = iota + 1
+
+	// DeadlineNotPropagated means the request was called by a request
+	// that had a deadline, but the request itself did not have one.
+	DeadlineNotPropagated
+
+	// Canceled means the request's context was canceled before
+	// the request completed, for a reason other than its deadline.
+	Canceled
+)
+
+func (i DeadlineIssue) String() string {
+	switch i {
+	case DeadlineExceeded:
+		return "deadline exceeded"
+	case DeadlineNotPropagated:
+		return "deadline not propagated"
+	case Canceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+// DeadlineFinding is a request flagged by AnalyzeDeadlines.
+type DeadlineFinding struct {
+	Issue        DeadlineIssue
+	TraceID      string
+	SpanID       string
+	ParentSpanID string // empty if the request has no parent in the trace
+	Service      string
+	Endpoint     string
+
+	// Duration is how long the request took.
+	// It is zero if the request has not completed.
+	Duration time.Duration
+
+	// DeadlineRemaining is the time remaining until the deadline when the
+	// request started. It is nil if the request had no deadline.
+	DeadlineRemaining *time.Duration
+
+	// ParentDeadlineRemaining is the time remaining until the parent
+	// request's deadline when the parent started, if any.
+	ParentDeadlineRemaining *time.Duration
+
+	// CancelCause is the cause of the context cancellation, if any.
+	CancelCause string
+}
+
+// AnalyzeDeadlines analyzes the events of a single trace and reports requests
+// whose deadline was exceeded, whose context was canceled, or that were called
+// with a deadline that was not propagated to them.
+//
+// Traces recorded with a protocol version that does not include deadline
+// information yield no findings.
+func AnalyzeDeadlines(events []*tracepb2.TraceEvent) []DeadlineFinding {
+	type requestSpan struct {
+		traceID  string
+		spanID   uint64
+		startAt  time.Time
+		parentID *uint64
+		start    *tracepb2.RequestSpanStart
+		end      *tracepb2.RequestSpanEnd
+		duration time.Duration
+	}
+
+	spans := make(map[uint64]*requestSpan)
+	get := func(ev *tracepb2.TraceEvent) *requestSpan {
+		sp, ok := spans[ev.SpanId]
+		if !ok {
+			sp = &requestSpan{traceID: EncodeTraceID(ev.TraceId), spanID: ev.SpanId}
+			spans[ev.SpanId] = sp
+		}
+		return sp
+	}
+
+	for _, ev := range events {
+		switch e := ev.Event.(type) {
+		case *tracepb2.TraceEvent_SpanStart:
+			if req := e.SpanStart.GetRequest(); req != nil {
+				sp := get(ev)
+				sp.start = req
+				sp.startAt = ev.EventTime.AsTime()
+				sp.parentID = e.SpanStart.ParentSpanId
+			}
+		case *tracepb2.TraceEvent_SpanEnd:
+			if req := e.SpanEnd.GetRequest(); req != nil {
+				sp := get(ev)
+				sp.end = req
+				sp.duration = time.Duration(e.SpanEnd.DurationNanos)
+			}
+		}
+	}
+
+	sorted := make([]*requestSpan, 0, len(spans))
+	for _, sp := range spans {
+		if sp.start != nil {
+			sorted = append(sorted, sp)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].startAt.Equal(sorted[j].startAt) {
+			return sorted[i].startAt.Before(sorted[j].startAt)
+		}
+		return sorted[i].spanID < sorted[j].spanID
+	})
+
+	var findings []DeadlineFinding
+	for _, sp := range sorted {
+		f := DeadlineFinding{
+			TraceID:           sp.traceID,
+			SpanID:            EncodeSpanID(sp.spanID),
+			Service:           sp.start.ServiceName,
+			Endpoint:          sp.start.EndpointName,
+			Duration:          sp.duration,
+			DeadlineRemaining: nanosToDuration(sp.start.DeadlineRemainingNanos),
+		}
+
+		var parent *requestSpan
+		if sp.parentID != nil {
+			f.ParentSpanID = EncodeSpanID(*sp.parentID)
+			if p := spans[*sp.parentID]; p != nil && p.start != nil {
+				parent = p
+				f.ParentDeadlineRemaining = nanosToDuration(p.start.DeadlineRemainingNanos)
+			}
+		}
+
+		if end := sp.end; end != nil {
+			f.CancelCause = end.GetCancelCause()
+			if end.DeadlineExceeded {
+				f.Issue = DeadlineExceeded
+				findings = append(findings, f)
+			} else if end.CancelCause != nil {
+				f.Issue = Canceled
+				findings = append(findings, f)
+			}
+		}
+
+		if parent != nil && parent.start.DeadlineRemainingNanos != nil && sp.start.DeadlineRemainingNanos == nil {
+			f.Issue = DeadlineNotPropagated
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+func nanosToDuration(nanos *int64) *time.Duration {
+	if nanos == nil {
+		return nil
+	}
+	d := time.Duration(*nanos)
+	return &d
+}
@@ -0,0 +1,71 @@
+package trace2
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+func TestAnalyzeDeadlines(t *testing.T) {
+	c := qt.New(t)
+	traceID := &tracepb2.TraceID{High: 1, Low: 2}
+	ptr := func(n int64) *int64 { return &n }
+
+	start := func(spanID uint64, at time.Duration, parent *uint64, remaining *int64) *tracepb2.TraceEvent {
+		return &tracepb2.TraceEvent{
+			TraceId:   traceID,
+			SpanId:    spanID,
+			EventTime: timestamppb.New(time.Unix(0, int64(at))),
+			Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+				ParentSpanId: parent,
+				Data: &tracepb2.SpanStart_Request{Request: &tracepb2.RequestSpanStart{
+					ServiceName:            "svc",
+					EndpointName:           "ep",
+					DeadlineRemainingNanos: remaining,
+				}},
+			}},
+		}
+	}
+	end := func(spanID uint64, dur time.Duration, cause *string, exceeded bool) *tracepb2.TraceEvent {
+		return &tracepb2.TraceEvent{
+			TraceId: traceID,
+			SpanId:  spanID,
+			Event: &tracepb2.TraceEvent_SpanEnd{SpanEnd: &tracepb2.SpanEnd{
+				DurationNanos: uint64(dur),
+				Data: &tracepb2.SpanEnd_Request{Request: &tracepb2.RequestSpanEnd{
+					CancelCause:      cause,
+					DeadlineExceeded: exceeded,
+				}},
+			}},
+		}
+	}
+
+	root, child, grandchild := uint64(1), uint64(2), uint64(3)
+	cause := "context deadline exceeded"
+	findings := AnalyzeDeadlines([]*tracepb2.TraceEvent{
+		// Events may be out of order.
+		end(root, 2*time.Second, &cause, true),
+		start(root, 0, nil, ptr(int64(time.Second))),
+		start(child, time.Millisecond, &root, nil),
+		end(child, time.Second, nil, false),
+		start(grandchild, 2*time.Millisecond, &child, nil),
+		end(grandchild, time.Millisecond, nil, false),
+	})
+
+	c.Assert(findings, qt.HasLen, 2)
+	c.Assert(findings[0].Issue, qt.Equals, DeadlineExceeded)
+	c.Assert(findings[0].SpanID, qt.Equals, EncodeSpanID(root))
+	c.Assert(findings[0].CancelCause, qt.Equals, cause)
+	c.Assert(*findings[0].DeadlineRemaining, qt.Equals, time.Second)
+
+	// Only the direct child of the span with a deadline is flagged.
+	c.Assert(findings[1].Issue, qt.Equals, DeadlineNotPropagated)
+	c.Assert(findings[1].SpanID, qt.Equals, EncodeSpanID(child))
+	c.Assert(findings[1].ParentSpanID, qt.Equals, EncodeSpanID(root))
+	c.Assert(findings[1].DeadlineRemaining, qt.IsNil)
+	c.Assert(*findings[1].ParentDeadlineRemaining, qt.Equals, time.Second)
+}
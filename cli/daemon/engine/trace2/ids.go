@@ -0,0 +1,29 @@
+package trace2
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// EncodeTraceID encodes the trace id as a human-readable string.
+func EncodeTraceID(id *tracepb2.TraceID) string {
+	var b [16]byte
+	binary.LittleEndian.PutUint64(b[0:8], id.Low)
+	binary.LittleEndian.PutUint64(b[8:16], id.High)
+	return base32hex.EncodeToString(b[:])
+}
+
+// EncodeSpanID encodes the span id as a human-readable string.
+func EncodeSpanID(id uint64) string {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], id)
+	return base32hex.EncodeToString(b[:])
+}
+
+var (
+	// base32hex is a lowercase base32 hex encoding without padding
+	// that preserves lexicographic sort order.
+	base32hex = base32.NewEncoding("0123456789abcdefghijklmnopqrstuv").WithPadding(base32.NoPadding)
+)
@@ -99,7 +99,7 @@ func writeRootRequest(t *testing.T, s *Store, traceID *tracepb2.TraceID, spanID
 	if err := s.WriteEvents(ctx, meta, []*tracepb2.TraceEvent{start, end}); err != nil {
 		t.Fatalf("write events: %v", err)
 	}
-	return encodeTraceID(traceID)
+	return trace2.EncodeTraceID(traceID)
 }
 
 func listIDs(t *testing.T, s *Store, q *trace2.Query) []string {
@@ -120,7 +120,7 @@ func TestList_Filters(t *testing.T) {
 	s := newTestStore(t)
 
 	parent := &tracepb2.TraceID{High: 11, Low: 22}
-	parentID := encodeTraceID(parent)
+	parentID := trace2.EncodeTraceID(parent)
 
 	// Child trace triggered by `parent`.
 	child := writeRootRequest(t, s, &tracepb2.TraceID{High: 1, Low: 2}, 100,
@@ -175,7 +175,7 @@ func TestList_Filters(t *testing.T) {
 	})
 
 	t.Run("parent_trace_id_no_match", func(t *testing.T) {
-		ids := listIDs(t, s, &trace2.Query{ParentTraceID: encodeTraceID(&tracepb2.TraceID{High: 99, Low: 99})})
+		ids := listIDs(t, s, &trace2.Query{ParentTraceID: trace2.EncodeTraceID(&tracepb2.TraceID{High: 99, Low: 99})})
 		if len(ids) != 0 {
 			t.Fatalf("parent trace filter (no match): got %v, want none", ids)
 		}
@@ -3,8 +3,6 @@ package sqlite
 import (
 	"context"
 	"database/sql"
-	"encoding/base32"
-	"encoding/binary"
 	"net/http"
 	"strings"
 	"time"
@@ -155,7 +153,7 @@ func (s *Store) insertEvent(ctx context.Context, meta *trace2.Meta, ev *tracepbc
 		INSERT INTO trace_event (
 			app_id, trace_id, span_id, event_data)
 		VALUES (?, ?, ?, ?)
-	`, meta.AppID, encodeTraceID(ev.TraceId), encodeSpanID(ev.SpanId), data)
+	`, meta.AppID, trace2.EncodeTraceID(ev.TraceId), trace2.EncodeSpanID(ev.SpanId), data)
 	if err != nil {
 		return errors.Wrap(err, "insert trace span event")
 	}
@@ -180,7 +178,7 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 	// regardless of span type, so compute it once for all the branches below.
 	var parentTraceID *string
 	if pt := start.GetParentTraceId(); pt != nil {
-		encoded := encodeTraceID(pt)
+		encoded := trace2.EncodeTraceID(pt)
 		parentTraceID = &encoded
 	}
 
@@ -188,7 +186,7 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 		extRequestID := req.RequestHeaders[http.CanonicalHeaderKey("X-Request-ID")]
 		var parentSpanID *string
 		if start.ParentSpanId != nil {
-			encodedParentSpanID := encodeSpanID(*start.ParentSpanId)
+			encodedParentSpanID := trace2.EncodeSpanID(*start.ParentSpanId)
 			parentSpanID = &encodedParentSpanID
 		}
 		_, err := s.db.ExecContext(ctx, `
@@ -204,7 +202,7 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 				parent_span_id = excluded.parent_span_id,
 				parent_trace_id = excluded.parent_trace_id,
 				caller_event_id = excluded.caller_event_id
-		`, meta.AppID, encodeTraceID(ev.TraceId), encodeSpanID(ev.SpanId),
+		`, meta.AppID, trace2.EncodeTraceID(ev.TraceId), trace2.EncodeSpanID(ev.SpanId),
 			tracepbcli.SpanSummary_REQUEST, ev.EventTime.AsTime().UnixNano(),
 			isRoot, req.ServiceName, req.EndpointName, extRequestID, parentSpanID, parentTraceID, start.CallerEventId)
 		if err != nil {
@@ -216,7 +214,7 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 	if auth := start.GetAuth(); auth != nil {
 		var parentSpanID *string
 		if start.ParentSpanId != nil {
-			encodedParentSpanID := encodeSpanID(*start.ParentSpanId)
+			encodedParentSpanID := trace2.EncodeSpanID(*start.ParentSpanId)
 			parentSpanID = &encodedParentSpanID
 		}
 		_, err := s.db.ExecContext(ctx, `
@@ -231,7 +229,7 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 				parent_span_id = excluded.parent_span_id,
 				parent_trace_id = excluded.parent_trace_id,
 				caller_event_id = excluded.caller_event_id
-		`, meta.AppID, encodeTraceID(ev.TraceId), encodeSpanID(ev.SpanId),
+		`, meta.AppID, trace2.EncodeTraceID(ev.TraceId), trace2.EncodeSpanID(ev.SpanId),
 			tracepbcli.SpanSummary_AUTH, ev.EventTime.AsTime().UnixNano(),
 			isRoot, auth.ServiceName, auth.EndpointName, parentSpanID, parentTraceID, start.CallerEventId)
 		if err != nil {
@@ -243,7 +241,7 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 	if msg := start.GetPubsubMessage(); msg != nil {
 		var parentSpanID *string
 		if start.ParentSpanId != nil {
-			encodedParentSpanID := encodeSpanID(*start.ParentSpanId)
+			encodedParentSpanID := trace2.EncodeSpanID(*start.ParentSpanId)
 			parentSpanID = &encodedParentSpanID
 		}
 		_, err := s.db.ExecContext(ctx, `
@@ -261,7 +259,7 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 				parent_span_id = excluded.parent_span_id,
 				parent_trace_id = excluded.parent_trace_id,
 				caller_event_id = excluded.caller_event_id
-		`, meta.AppID, encodeTraceID(ev.TraceId), encodeSpanID(ev.SpanId),
+		`, meta.AppID, trace2.EncodeTraceID(ev.TraceId), trace2.EncodeSpanID(ev.SpanId),
 			tracepbcli.SpanSummary_PUBSUB_MESSAGE, ev.EventTime.AsTime().UnixNano(),
 			isRoot, msg.ServiceName, msg.TopicName, msg.SubscriptionName, msg.MessageId, parentSpanID, parentTraceID, start.CallerEventId)
 		if err != nil {
@@ -273,7 +271,7 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 	if msg := start.GetTest(); msg != nil {
 		var parentSpanID *string
 		if start.ParentSpanId != nil {
-			encodedParentSpanID := encodeSpanID(*start.ParentSpanId)
+			encodedParentSpanID := trace2.EncodeSpanID(*start.ParentSpanId)
 			parentSpanID = &encodedParentSpanID
 		}
 		_, err := s.db.ExecContext(ctx, `
@@ -288,7 +286,7 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 				parent_span_id = excluded.parent_span_id,
 				parent_trace_id = excluded.parent_trace_id,
 				caller_event_id = excluded.caller_event_id
-		`, meta.AppID, encodeTraceID(ev.TraceId), encodeSpanID(ev.SpanId),
+		`, meta.AppID, trace2.EncodeTraceID(ev.TraceId), trace2.EncodeSpanID(ev.SpanId),
 			tracepbcli.SpanSummary_TEST, ev.EventTime.AsTime().UnixNano(),
 			isRoot, msg.ServiceName, msg.TestName, msg.Uid, msg.TestFile, msg.TestLine, parentSpanID, parentTraceID, start.CallerEventId)
 		if err != nil {
@@ -301,8 +299,8 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 }
 
 func (s *Store) updateSpanEndIndex(ctx context.Context, meta *trace2.Meta, ev *tracepbcli.TraceEvent, end *tracepbcli.SpanEnd) (err error) {
-	traceID := encodeTraceID(ev.TraceId)
-	spanID := encodeSpanID(ev.SpanId)
+	traceID := trace2.EncodeTraceID(ev.TraceId)
+	spanID := trace2.EncodeSpanID(ev.SpanId)
 
 	defer func() {
 		if err == nil {
@@ -389,29 +387,3 @@ func (s *Store) updateSpanEndIndex(ctx context.Context, meta *trace2.Meta, ev *t
 
 	return nil
 }
-
-var (
-	binBE = binary.BigEndian
-	binLE = binary.LittleEndian
-)
-
-// encodeTraceID encodes the trace id as a human-readable string.
-func encodeTraceID(id *tracepbcli.TraceID) string {
-	var b [16]byte
-	binLE.PutUint64(b[0:8], id.Low)
-	binLE.PutUint64(b[8:16], id.High)
-	return base32hex.EncodeToString(b[:])
-}
-
-// encodeSpanID encodes the span id as a human-readable string.
-func encodeSpanID(id uint64) string {
-	var b [8]byte
-	binLE.PutUint64(b[:], id)
-	return base32hex.EncodeToString(b[:])
-}
-
-var (
-	// base32hex is a lowercase base32 hex encoding without padding
-	// that preserves lexicographic sort order.
-	base32hex = base32.NewEncoding("0123456789abcdefghijklmnopqrstuv").WithPadding(base32.NoPadding)
-)
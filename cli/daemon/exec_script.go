@@ -34,7 +34,7 @@ func (s *Server) ExecScript(req *daemonpb.ExecScriptRequest, stream daemonpb.Dae
 			}
 			slog.Stderr(false).Write([]byte(errStr))
 		}
-		streamExit(stream, 1)
+		streamError(stream, err)
 	}
 
 	ctx, tracer, err := s.beginTracing(ctx, req.AppRoot, req.WorkingDir, req.TraceFile)
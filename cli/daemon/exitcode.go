@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"errors"
+
+	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/run/infra"
+	"encr.dev/internal/conf"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// exitCodes are the exit codes of commands failing with each category.
+var exitCodes = map[daemonpb.ExitCategory]int{
+	daemonpb.ExitCategory_EXIT_CATEGORY_UNSPECIFIED:      1,
+	daemonpb.ExitCategory_EXIT_CATEGORY_TEST_FAILURE:     1,
+	daemonpb.ExitCategory_EXIT_CATEGORY_BUILD:            10,
+	daemonpb.ExitCategory_EXIT_CATEGORY_INFRA:            11,
+	daemonpb.ExitCategory_EXIT_CATEGORY_PORT_CONFLICT:    12,
+	daemonpb.ExitCategory_EXIT_CATEGORY_AUTH_REQUIRED:    13,
+	daemonpb.ExitCategory_EXIT_CATEGORY_VERSION_MISMATCH: 14,
+	daemonpb.ExitCategory_EXIT_CATEGORY_ALREADY_RUNNING:  15,
+}
+
+// failureCategory categorizes the error a command failed with.
+func failureCategory(err error) daemonpb.ExitCategory {
+	switch {
+	case errors.Is(err, conf.ErrNotLoggedIn):
+		return daemonpb.ExitCategory_EXIT_CATEGORY_AUTH_REQUIRED
+	case errors.Is(err, run.ErrTestsFailed):
+		return daemonpb.ExitCategory_EXIT_CATEGORY_TEST_FAILURE
+	case run.IsBuildError(err):
+		return daemonpb.ExitCategory_EXIT_CATEGORY_BUILD
+	case errors.Is(err, infra.ErrStart):
+		return daemonpb.ExitCategory_EXIT_CATEGORY_INFRA
+	case errIsAddrInUse(err):
+		return daemonpb.ExitCategory_EXIT_CATEGORY_PORT_CONFLICT
+	default:
+		return daemonpb.ExitCategory_EXIT_CATEGORY_UNSPECIFIED
+	}
+}
+
+// streamFailure reports that the command failed with the given category.
+func streamFailure(stream commandStream, category daemonpb.ExitCategory) {
+	_ = stream.Send(&daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Exit{
+		Exit: &daemonpb.CommandExit{
+			Code:     int32(exitCodes[category]),
+			Category: category,
+		},
+	}})
+}
+
+// streamError reports that the command failed with err.
+func streamError(stream commandStream, err error) {
+	streamFailure(stream, failureCategory(err))
+}
@@ -19,10 +19,8 @@ func (s *Server) Export(req *daemonpb.ExportRequest, stream daemonpb.Daemon_Expo
 		return nil
 	}
 
-	exitCode := 0
 	success, err := export.Docker(stream.Context(), app, req, log, slog)
 	if err != nil {
-		exitCode = 1
 		if list, ok := err.(scanner.ErrorList); ok {
 			for _, e := range list {
 				log.Error().Msg(e.Error())
@@ -30,10 +28,11 @@ func (s *Server) Export(req *daemonpb.ExportRequest, stream daemonpb.Daemon_Expo
 		} else {
 			log.Error().Msg(err.Error())
 		}
+		streamError(stream, err)
 	} else if !success {
-		exitCode = 1
+		streamExit(stream, 1)
+	} else {
+		streamExit(stream, 0)
 	}
-
-	streamExit(stream, exitCode)
 	return nil
 }
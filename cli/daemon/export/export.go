@@ -5,7 +5,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,6 +22,8 @@ import (
 
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/internal/runlog"
+	"encr.dev/cli/daemon/internal/sqlcgen"
+	"encr.dev/cli/daemon/run"
 	"encr.dev/internal/env"
 	"encr.dev/internal/version"
 	"encr.dev/pkg/appfile"
@@ -29,6 +33,8 @@ import (
 	"encr.dev/pkg/dockerbuild"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
+	"encr.dev/pkg/provenance"
+	"encr.dev/pkg/schemaexport"
 	"encr.dev/pkg/vcs"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
@@ -57,10 +63,16 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 		KeepOutput:         false,
 		Revision:           vcsRevision.Revision,
 		UncommittedChanges: vcsRevision.Uncommitted,
+		Reproducible:       true,
 
 		// Use the local JS runtime if this is a development build.
 		UseLocalJSRuntime: version.Channel == version.DevBuild,
 	}
+	buildTime, reproducible := provenance.BuildTime(req.Environ, vcsRevision)
+	if !reproducible {
+		log.Info().Msg("the app has uncommitted changes and SOURCE_DATE_EPOCH is not set; the image will not be reproducible")
+		buildTime = time.Now()
+	}
 	appLang := app.Lang()
 	bld := builderimpl.Resolve(appLang, expSet)
 	defer fns.CloseIgnore(bld)
@@ -84,6 +96,11 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 		}
 	}
 
+	// Generate the app's sqlc query packages before parsing the code using them.
+	if err := sqlcgen.Generate(ctx, app.Root()); err != nil {
+		return false, err
+	}
+
 	parse, err := bld.Parse(ctx, builder.ParseParams{
 		Build:       buildInfo,
 		App:         app,
@@ -99,6 +116,17 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 		log.Info().Err(err).Msg("failed to cache metadata")
 		return false, errors.Wrap(err, "cache metadata")
 	}
+	if err := schemaexport.Write(app.Root(), parse.Meta); err != nil {
+		return false, errors.Wrap(err, "export schemas")
+	}
+
+	licenseReport, violations, err := LicenseReport(ctx, app)
+	if err != nil {
+		return false, err
+	} else if len(violations) > 0 {
+		return false, licenseViolationsError(violations)
+	}
+	log.Info().Msgf("collected licenses of %d dependencies", len(licenseReport.Dependencies))
 
 	log.Info().Msgf("compiling Encore application for %s/%s", req.Goos, req.Goarch)
 	result, err := bld.Compile(ctx, builder.CompileParams{
@@ -112,7 +140,7 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 
 	if err != nil {
 		log.Info().Err(err).Msg("compilation failed")
-		return false, errors.Wrap(err, "compilation failed")
+		return false, errors.Mark(errors.Wrap(err, "compilation failed"), run.ErrBuild)
 	}
 
 	if hooks.PostBuild.IsSet() {
@@ -143,6 +171,7 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 		Runtimes:          dockerbuild.HostPath(env.EncoreRuntimesPath()),
 		NodeRuntime:       crossNodeRuntime,
 		ProcessPerService: buildSettings.Docker.ProcessPerService,
+		BuildInfo:         BuildProvenance(app, parse.Meta, vcsRevision),
 	}
 
 	if buildSettings.Docker.BundleSource || appLang == appfile.LangTS {
@@ -183,19 +212,30 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 	if err != nil {
 		return false, errors.Wrap(err, "describe docker image")
 	}
+	licenseReport.GeneratedAt = buildTime
+	licenseData, err := json.MarshalIndent(licenseReport, "", "  ")
+	if err != nil {
+		return false, errors.Wrap(err, "marshal license report")
+	}
+	spec.WriteFiles[licenseReportPath] = licenseData
 
 	cors, err := app.GlobalCORS()
 	if err != nil {
 		return false, errors.Wrap(err, "get global CORS")
 	}
+	transforms, err := app.GatewayTransforms()
+	if err != nil {
+		return false, errors.Wrap(err, "get gateway transforms")
+	}
 	var logResponse string
 	if !req.SkipInfraConf {
 		cfg, infraCfgOutput, err := buildAndValidateInfraConfig(EmbeddedInfraConfigParams{
-			File:       dockerbuild.HostPath(req.InfraConfPath),
-			Services:   req.Services,
-			Gateways:   req.Gateways,
-			GlobalCORS: cors,
-			Meta:       parse.Meta,
+			File:              dockerbuild.HostPath(req.InfraConfPath),
+			Services:          req.Services,
+			Gateways:          req.Gateways,
+			GlobalCORS:        cors,
+			GatewayTransforms: transforms,
+			Meta:              parse.Meta,
 		})
 		logResponse = infraCfgOutput
 		if err != nil {
@@ -221,12 +261,13 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 		if err != nil {
 			return false, err
 		}
-		for svcName, cfgStr := range cfgs.Configs {
+		// Add the configs in a fixed order so the image config is reproducible.
+		for _, svcName := range slices.Sorted(maps.Keys(cfgs.Configs)) {
 			spec.Env = append(spec.Env, fmt.Sprintf(
 				"%s%s=%s",
 				"ENCORE_CFG_",
 				strings.ToUpper(svcName),
-				base64.RawURLEncoding.EncodeToString([]byte(cfgStr)),
+				base64.RawURLEncoding.EncodeToString([]byte(cfgs.Configs[svcName])),
 			))
 		}
 	}
@@ -248,7 +289,7 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 		supervisorPath = option.Some(binary)
 	}
 	img, err := dockerbuild.BuildImage(ctx, spec, dockerbuild.ImageBuildConfig{
-		BuildTime:         time.Now(),
+		BuildTime:         buildTime,
 		BaseImageOverride: baseImgOverride,
 		AddCACerts:        option.Some[dockerbuild.ImagePath](""),
 		SupervisorPath:    supervisorPath,
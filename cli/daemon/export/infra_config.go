@@ -1,10 +1,12 @@
 package export
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -39,6 +41,9 @@ type EmbeddedInfraConfigParams struct {
 	// CORS config to include in the image.
 	GlobalCORS appfile.CORS
 
+	// Gateway transforms to include in the image.
+	GatewayTransforms []appfile.GatewayTransform
+
 	Meta *meta.Data
 }
 
@@ -180,14 +185,33 @@ func buildAndValidateInfraConfig(params EmbeddedInfraConfigParams) (*infra.Infra
 	databases := fns.FlatMap(maps.Values(hostedSvcs), func(db *meta.Service) []string {
 		return db.Databases
 	})
+	// Search indexes stored in Postgres need their database too.
+	if len(md.SearchIndexes) > 0 {
+		for _, search := range infraCfg.Search {
+			if search.Type == "postgres" {
+				databases = append(databases, search.Database)
+			}
+		}
+	}
 	slices.Sort(databases)
 	databases = slices.Compact(databases)
 
-	for _, sqlServer := range infraCfg.SQLServers {
+	for i, sqlServer := range infraCfg.SQLServers {
 		for name := range sqlServer.Databases {
 			databases, ok = fns.Delete(databases, name)
 			if !ok {
 				delete(sqlServer.Databases, name)
+				continue
+			}
+
+			// The database must be on a server running the engine it's declared with.
+			engine := "postgres"
+			if db, found := fns.Find(md.SqlDatabases, func(db *meta.SQLDatabase) bool { return db.Name == name }); found && db.Engine == meta.SQLDatabase_MYSQL {
+				engine = "mysql"
+			}
+			if serverEngine := cmp.Or(sqlServer.Engine, "postgres"); serverEngine != engine {
+				path := infra.JSONPath("sql_servers").Append(infra.JSONPath(strconv.Itoa(i))).Append("databases").Append(infra.JSONPath(name))
+				validationErrors[path] = errors.Newf("Database uses the %s engine, but the server's engine is %s", engine, serverEngine)
 			}
 		}
 	}
@@ -308,10 +332,34 @@ func buildAndValidateInfraConfig(params EmbeddedInfraConfigParams) (*infra.Infra
 		missing["Buckets"] = buckets
 	}
 
+	// Validate search index config
+	indexes := fns.Map(md.SearchIndexes, (*meta.SearchIndex).GetName)
+	for _, search := range infraCfg.Search {
+		for name := range search.Indexes {
+			indexes, ok = fns.Delete(indexes, name)
+			if !ok {
+				delete(search.Indexes, name)
+			}
+		}
+	}
+	infraCfg.Search = slices.DeleteFunc(infraCfg.Search, func(s *infra.Search) bool {
+		return len(s.Indexes) == 0
+	})
+	if len(indexes) > 0 {
+		missing["Search Indexes"] = indexes
+	}
+
 	// Copy CORS config
 	cors := infra.CORS(params.GlobalCORS)
 	infraCfg.CORS = &cors
 
+	// Copy gateway transforms
+	infraCfg.GatewayTransforms = nil
+	for _, t := range params.GatewayTransforms {
+		t := infra.GatewayTransform(t)
+		infraCfg.GatewayTransforms = append(infraCfg.GatewayTransforms, &t)
+	}
+
 	if len(missing) > 0 || len(validationErrors) > 0 {
 		return nil, "", configError(missing, validationErrors)
 	}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/dockerbuild"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestInfraConfigSQLEngines(t *testing.T) {
+	md := &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "orders", Databases: []string{"orders"}},
+			{Name: "users", Databases: []string{"users"}},
+		},
+		SqlDatabases: []*meta.SQLDatabase{
+			{Name: "orders", Engine: meta.SQLDatabase_MYSQL},
+			{Name: "users", Engine: meta.SQLDatabase_POSTGRES},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name: "matching_engines",
+			config: `{"sql_servers": [
+				{"host": "mysql:3306", "engine": "mysql", "databases": {"orders": {"username": "encore", "password": "secret"}}},
+				{"host": "postgres:5432", "databases": {"users": {"username": "encore", "password": "secret"}}}
+			]}`,
+		},
+		{
+			name: "mysql_db_on_default_server",
+			config: `{"sql_servers": [
+				{"host": "postgres:5432", "databases": {
+					"orders": {"username": "encore", "password": "secret"},
+					"users": {"username": "encore", "password": "secret"}
+				}}
+			]}`,
+			wantErr: "Database uses the mysql engine, but the server's engine is postgres",
+		},
+		{
+			name: "postgres_db_on_mysql_server",
+			config: `{"sql_servers": [
+				{"host": "mysql:3306", "engine": "mysql", "databases": {
+					"orders": {"username": "encore", "password": "secret"},
+					"users": {"username": "encore", "password": "secret"}
+				}}
+			]}`,
+			wantErr: "Database uses the postgres engine, but the server's engine is mysql",
+		},
+		{
+			name: "unknown_server_engine",
+			config: `{"sql_servers": [
+				{"host": "db:1433", "engine": "mssql", "databases": {"orders": {"username": "encore", "password": "secret"}}},
+				{"host": "postgres:5432", "databases": {"users": {"username": "encore", "password": "secret"}}}
+			]}`,
+			wantErr: "Must be one of: [ postgres mysql]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			path := filepath.Join(t.TempDir(), "infra.config.json")
+			c.Assert(os.WriteFile(path, []byte(tt.config), 0644), qt.IsNil)
+
+			cfg, _, err := buildAndValidateInfraConfig(EmbeddedInfraConfigParams{
+				File: dockerbuild.HostPath(path),
+				Meta: md,
+			})
+			if tt.wantErr != "" {
+				c.Assert(err, qt.IsNotNil)
+				c.Assert(err.Error(), qt.Contains, tt.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(cfg.SQLServers, qt.HasLen, 2)
+			c.Assert(cfg.SQLServers[0].Engine, qt.Equals, "mysql")
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package export
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/internal/env"
+	"encr.dev/pkg/dockerbuild"
+	"encr.dev/pkg/licenses"
+)
+
+// licenseReportPath is the path in the image where the license report is written.
+const licenseReportPath dockerbuild.ImagePath = "/encore/licenses.json"
+
+// LicenseReport collects the licenses of the app's dependencies
+// and checks them against the app's license deny list.
+func LicenseReport(ctx context.Context, app *apps.Instance) (*licenses.Report, []licenses.Violation, error) {
+	settings, err := app.BuildSettings()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get build settings")
+	}
+
+	var goBin string
+	if goroot, ok := env.OptEncoreGoRoot().Get(); ok {
+		goBin = filepath.Join(goroot, "bin", "go")
+	}
+	rep, err := licenses.Collect(ctx, app.Root(), app.Lang(), goBin)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "collect dependency licenses")
+	}
+	return rep, licenses.Check(rep, settings.Licenses.Deny, settings.Licenses.Ignore), nil
+}
+
+// licenseViolationsError returns an error describing the license violations.
+func licenseViolationsError(violations []licenses.Violation) error {
+	var b strings.Builder
+	b.WriteString("dependencies are licensed under denied licenses:")
+	for _, v := range violations {
+		b.WriteString("\n  ")
+		b.WriteString(v.String())
+	}
+	b.WriteString("\n\nChange the dependencies, or update build.licenses in encore.app to allow them.")
+	return errors.New(b.String())
+}
@@ -0,0 +1,36 @@
+package export
+
+import (
+	"fmt"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/internal/env"
+	"encr.dev/internal/version"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/dockerbuild"
+	"encr.dev/pkg/provenance"
+	"encr.dev/pkg/vcs"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// BuildProvenance describes the inputs of a build of the app,
+// as embedded in the images it exports.
+func BuildProvenance(app *apps.Instance, md *meta.Data, rev vcs.Status) dockerbuild.BuildInfo {
+	toolchains := map[string]string{"encore": version.Version}
+	if app.Lang() == appfile.LangGo {
+		if goroot, ok := env.OptEncoreGoRoot().Get(); ok {
+			if v := provenance.GoVersion(goroot); v != "" {
+				toolchains["go"] = v
+			}
+		}
+	}
+	return dockerbuild.BuildInfo{
+		EncoreCompiler: fmt.Sprintf("EncoreCLI/%s", version.Version),
+		AppCommit: dockerbuild.CommitInfo{
+			Revision:    rev.Revision,
+			Uncommitted: rev.Uncommitted,
+		},
+		Toolchains: toolchains,
+		InfraHash:  provenance.InfraHash(md),
+	}
+}
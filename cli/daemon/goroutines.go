@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/internal/goroutines"
+	"encr.dev/cli/daemon/run"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// GoroutineDump captures goroutine dumps of the processes of a running app.
+func (s *Server) GoroutineDump(ctx context.Context, req *daemonpb.GoroutineDumpRequest) (*daemonpb.GoroutineDumpResponse, error) {
+	r, err := s.runningApp(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	pg := r.ProcGroup()
+	if pg == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the app is not running")
+	}
+
+	// Group the services and gateways by the process running them.
+	var procs []*run.Proc
+	names := make(map[*run.Proc][]string)
+	for _, byName := range []map[string]*run.Proc{pg.Gateways, pg.Services} {
+		for _, name := range slices.Sorted(maps.Keys(byName)) {
+			p := byName[name]
+			if !p.Started.Load() {
+				continue
+			}
+			if _, ok := names[p]; !ok {
+				procs = append(procs, p)
+			}
+			names[p] = append(names[p], name)
+		}
+	}
+	if len(req.Services) > 0 {
+		for _, svc := range req.Services {
+			if !slices.ContainsFunc(procs, func(p *run.Proc) bool { return slices.Contains(names[p], svc) }) {
+				return nil, status.Errorf(codes.NotFound, "service %q is not running", svc)
+			}
+		}
+		procs = slices.DeleteFunc(procs, func(p *run.Proc) bool {
+			return !slices.ContainsFunc(names[p], func(n string) bool { return slices.Contains(req.Services, n) })
+		})
+	}
+
+	minWait := int(req.MinWaitMinutes)
+	if minWait <= 0 {
+		minWait = 1
+	}
+
+	resp := &daemonpb.GoroutineDumpResponse{Processes: make([]*daemonpb.ProcessGoroutineDump, len(procs))}
+	var eg errgroup.Group
+	for i, p := range procs {
+		dump := &daemonpb.ProcessGoroutineDump{Services: names[p], Pid: int32(p.Pid)}
+		resp.Processes[i] = dump
+		eg.Go(func() error {
+			data, err := p.GoroutineDump(ctx)
+			if err != nil {
+				dump.Error = err.Error()
+				return nil
+			}
+			dump.Dump = string(data)
+
+			gs, err := goroutines.Parse(data)
+			if err != nil {
+				dump.Error = "unable to parse goroutine dump: " + err.Error()
+				return nil
+			}
+			report := goroutines.Analyze(gs, pg.Meta.ModulePath, minWait)
+			var b strings.Builder
+			_ = report.Write(&b)
+			dump.Report = b.String()
+			dump.SuspectedDeadlocks = int32(report.Suspects())
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	return resp, nil
+}
@@ -0,0 +1,312 @@
+// Package devtls provides locally-trusted TLS certificates for serving
+// running apps over HTTPS during local development.
+//
+// Certificates are issued by a local certificate authority. If mkcert's
+// CA exists it's reused, as it's typically already trusted by the system
+// and browsers. Otherwise Encore creates its own CA, which must be trusted
+// once for browsers to accept the certificates.
+package devtls
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+const (
+	caCertFile = "rootCA.pem"
+	caKeyFile  = "rootCA-key.pem"
+)
+
+// CA is a local certificate authority issuing development certificates.
+type CA struct {
+	// Dir is the directory holding the CA's certificate and key.
+	Dir string
+
+	// Created reports whether the CA was created by LoadCA,
+	// meaning it's not trusted yet.
+	Created bool
+
+	// Mkcert reports whether the CA is mkcert's.
+	Mkcert bool
+
+	cert *x509.Certificate
+	key  any
+}
+
+// CertPath is the path to the CA's certificate.
+func (ca *CA) CertPath() string {
+	return filepath.Join(ca.Dir, caCertFile)
+}
+
+// LoadCA loads mkcert's CA if it exists, and otherwise the CA in dir,
+// creating it if it doesn't exist.
+func LoadCA(dir string) (*CA, error) {
+	if mkcertDir := mkcertCARoot(); mkcertDir != "" {
+		if ca, err := readCA(mkcertDir); err == nil {
+			ca.Mkcert = true
+			return ca, nil
+		}
+	}
+
+	ca, err := readCA(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return createCA(dir)
+	}
+	return ca, err
+}
+
+// mkcertCARoot returns the directory of mkcert's CA, following
+// the same rules as mkcert, or "" if it can't be determined.
+func mkcertCARoot() string {
+	if dir := os.Getenv("CAROOT"); dir != "" {
+		return dir
+	}
+	var dir string
+	switch runtime.GOOS {
+	case "windows":
+		dir = os.Getenv("LocalAppData")
+	case "darwin":
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, "Library", "Application Support")
+		}
+	default:
+		dir = os.Getenv("XDG_DATA_HOME")
+		if dir == "" {
+			if home, err := os.UserHomeDir(); err == nil {
+				dir = filepath.Join(home, ".local", "share")
+			}
+		}
+	}
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "mkcert")
+}
+
+func readCA(dir string) (*CA, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, caCertFile))
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, caKeyFile))
+	if err != nil {
+		return nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, fmt.Errorf("invalid CA in %s: not PEM-encoded", dir)
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificate in %s: %v", dir, err)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA key in %s: %v", dir, err)
+	}
+	return &CA{Dir: dir, cert: cert, key: key}, nil
+}
+
+func createCA(dir string) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{"Encore development CA"},
+			CommonName:   "Encore development CA",
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(filepath.Join(dir, caKeyFile), keyPEM, 0o400); err != nil {
+		return nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, caCertFile), certPEM, 0o644); err != nil {
+		return nil, err
+	}
+	return &CA{Dir: dir, Created: true, cert: cert, key: key}, nil
+}
+
+// ServerConfig returns a TLS config with a certificate issued by the CA
+// for localhost and the loopback addresses.
+func (ca *CA) ServerConfig() (*tls.Config, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{"Encore development certificate"},
+		},
+		DNSNames:    []string{"localhost", "*.localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:   time.Now().Add(-time.Hour),
+		// Browsers reject certificates valid for more than 825 days.
+		NotAfter:    time.Now().AddDate(2, 0, 0),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("issue certificate: %v", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{der, ca.cert.Raw},
+			PrivateKey:  key,
+		}},
+		// Only offer HTTP/1.1, as the HTTP server sees the decrypted
+		// connections as plaintext and wouldn't serve HTTP/2 over them.
+		NextProtos: []string{"http/1.1"},
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+func randomSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// TrustCommand returns the command trusting the CA system-wide on the
+// current platform, for users to run themselves as it requires privileges.
+func (ca *CA) TrustCommand() string {
+	path := ca.CertPath()
+	switch runtime.GOOS {
+	case "darwin":
+		return fmt.Sprintf("sudo security add-trusted-cert -d -r trustRoot -k /Library/Keychains/System.keychain %q", path)
+	case "windows":
+		return fmt.Sprintf("certutil -addstore -user Root %q", path)
+	default:
+		return fmt.Sprintf("sudo cp %q /usr/local/share/ca-certificates/encore-dev-ca.crt && sudo update-ca-certificates", path)
+	}
+}
+
+// Listener returns a listener accepting both TLS and plaintext connections on ln,
+// terminating TLS with config. Plaintext connections are kept working for clients
+// such as the daemon and the app itself, which don't trust the CA.
+func Listener(ln net.Listener, config *tls.Config) net.Listener {
+	return &listener{Listener: ln, config: config}
+}
+
+type listener struct {
+	net.Listener
+	config *tls.Config
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &sniffConn{Conn: conn, r: bufio.NewReader(conn), config: l.config}, nil
+}
+
+// sniffConn is a connection that determines whether it's a TLS connection
+// on the first read, by peeking at the first byte for a TLS handshake record.
+// The detection happens on read rather than in Accept so a slow client
+// doesn't block accepting other connections.
+type sniffConn struct {
+	net.Conn
+	r      *bufio.Reader
+	config *tls.Config
+
+	conn net.Conn // the connection to read and write, once determined
+	err  error
+}
+
+// recordTypeHandshake is the type of the TLS record starting a TLS connection.
+const recordTypeHandshake = 0x16
+
+func (c *sniffConn) init() error {
+	if c.conn != nil || c.err != nil {
+		return c.err
+	}
+	b, err := c.r.Peek(1)
+	if err != nil {
+		c.err = err
+		return err
+	}
+	plain := &bufferedConn{Conn: c.Conn, r: c.r}
+	if b[0] == recordTypeHandshake {
+		c.conn = tls.Server(plain, c.config)
+	} else {
+		c.conn = plain
+	}
+	return nil
+}
+
+func (c *sniffConn) Read(p []byte) (int, error) {
+	if err := c.init(); err != nil {
+		return 0, err
+	}
+	return c.conn.Read(p)
+}
+
+func (c *sniffConn) Write(p []byte) (int, error) {
+	if err := c.init(); err != nil {
+		return 0, err
+	}
+	return c.conn.Write(p)
+}
+
+// bufferedConn is a connection reading from a buffered reader.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
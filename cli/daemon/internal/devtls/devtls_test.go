@@ -0,0 +1,64 @@
+package devtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestListener(t *testing.T) {
+	t.Setenv("CAROOT", t.TempDir()) // don't use mkcert's CA
+	ca, err := LoadCA(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	} else if !ca.Created || ca.Mkcert {
+		t.Fatalf("got created=%v mkcert=%v, want a new CA", ca.Created, ca.Mkcert)
+	}
+
+	// Loading the CA again reuses it.
+	again, err := LoadCA(ca.Dir)
+	if err != nil {
+		t.Fatal(err)
+	} else if again.Created || !again.cert.Equal(ca.cert) {
+		t.Fatal("CA was not reused")
+	}
+
+	cfg, err := ca.ServerConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	})}
+	go func() { _ = srv.Serve(Listener(ln, cfg)) }()
+	defer func() { _ = srv.Close() }()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	tlsClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	for _, url := range []string{
+		"http://127.0.0.1:" + strconv.Itoa(port),
+		"https://127.0.0.1:" + strconv.Itoa(port),
+		"https://localhost:" + strconv.Itoa(port),
+	} {
+		resp, err := tlsClient.Get(url)
+		if err != nil {
+			t.Fatalf("GET %s: %v", url, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if string(body) != "ok" {
+			t.Fatalf("GET %s: got body %q, want %q", url, body, "ok")
+		}
+	}
+}
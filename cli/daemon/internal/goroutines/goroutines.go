@@ -0,0 +1,273 @@
+// Package goroutines parses goroutine dumps and analyzes them
+// for goroutines that are likely deadlocked.
+package goroutines
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Goroutine is a goroutine in a dump.
+type Goroutine struct {
+	ID          int
+	State       string // wait reason or status, such as "chan receive" or "running"
+	WaitMinutes int    // how long the goroutine has been blocked, rounded down
+	Frames      []Frame
+	CreatedBy   *Frame // the go statement that started the goroutine, if known
+}
+
+// Frame is a stack frame.
+type Frame struct {
+	Func string // fully qualified function name, such as "encore.app/users.(*Service).Get"
+	File string
+	Line int
+}
+
+var headerRe = regexp.MustCompile(`^goroutine (\d+) \[([^\]]*)\]:$`)
+
+// Parse parses a goroutine dump in the format of an unrecovered panic,
+// as written by runtime/pprof with debug=2.
+func Parse(dump []byte) ([]*Goroutine, error) {
+	var (
+		gs  []*Goroutine
+		g   *Goroutine
+		fn  string // function of the frame whose file line is next
+		sc  = bufio.NewScanner(bytes.NewReader(dump))
+		cre bool // whether fn is a "created by" frame
+	)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			g, fn = nil, ""
+
+		case g == nil:
+			m := headerRe.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			id, _ := strconv.Atoi(m[1])
+			g = &Goroutine{ID: id}
+			for i, part := range strings.Split(m[2], ", ") {
+				if i == 0 {
+					g.State = part
+				} else if n, ok := strings.CutSuffix(part, " minutes"); ok {
+					g.WaitMinutes, _ = strconv.Atoi(n)
+				} else if part == "1 minute" {
+					g.WaitMinutes = 1
+				}
+			}
+			gs = append(gs, g)
+
+		case strings.HasPrefix(line, "\t"):
+			if fn == "" {
+				continue
+			}
+			file, lineNo := parseLocation(strings.TrimPrefix(line, "\t"))
+			f := Frame{Func: fn, File: file, Line: lineNo}
+			if cre {
+				g.CreatedBy = &f
+			} else {
+				g.Frames = append(g.Frames, f)
+			}
+			fn = ""
+
+		default:
+			if after, ok := strings.CutPrefix(line, "created by "); ok {
+				fn, cre = after, true
+				if i := strings.Index(fn, " in goroutine "); i >= 0 {
+					fn = fn[:i]
+				}
+			} else {
+				fn, cre = trimArgs(line), false
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return gs, nil
+}
+
+// parseLocation parses a "/path/file.go:12 +0x1d" location.
+func parseLocation(s string) (file string, line int) {
+	if i := strings.LastIndex(s, " +0x"); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.LastIndexByte(s, ':'); i >= 0 {
+		if n, err := strconv.Atoi(s[i+1:]); err == nil {
+			return s[:i], n
+		}
+	}
+	return s, 0
+}
+
+// trimArgs trims the argument list from a "pkg.fn(0x1, 0x2)" frame.
+func trimArgs(s string) string {
+	if strings.HasSuffix(s, ")") {
+		if i := strings.LastIndexByte(s, '('); i > 0 {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// Report is the result of analyzing a goroutine dump.
+type Report struct {
+	Total  int
+	Groups []*Group // suspected deadlocks first, then by size
+}
+
+// Suspects returns the number of goroutines suspected to be deadlocked.
+func (r *Report) Suspects() int {
+	n := 0
+	for _, g := range r.Groups {
+		if g.Suspect != "" {
+			n += len(g.IDs)
+		}
+	}
+	return n
+}
+
+// Group is a group of goroutines with the same state and stack.
+type Group struct {
+	State       string
+	WaitMinutes int // the longest wait in the group
+	IDs         []int
+	Frames      []Frame
+	CreatedBy   *Frame
+
+	// Suspect, if non-empty, explains why the goroutines look deadlocked.
+	Suspect string
+}
+
+// blockingStates are the wait reasons of goroutines blocked on other goroutines.
+var blockingStates = map[string]string{
+	"chan send":               "a channel send",
+	"chan receive":            "a channel receive",
+	"select":                  "a select",
+	"sync.Mutex.Lock":         "a mutex",
+	"sync.RWMutex.Lock":       "a mutex",
+	"sync.RWMutex.RLock":      "a mutex",
+	"semacquire":              "a mutex or semaphore",
+	"sync.WaitGroup.Wait":     "a WaitGroup",
+	"sync.Cond.Wait":          "a condition variable",
+	"chan send (nil chan)":    "a send on a nil channel",
+	"chan receive (nil chan)": "a receive on a nil channel",
+	"select (no cases)":       "an empty select",
+}
+
+// Analyze groups the goroutines and flags those likely deadlocked:
+// goroutines in the app's code blocked on another goroutine for at
+// least minWait minutes, or forever on a nil channel or empty select.
+// appModule is the module path of the app, such as "encore.app".
+func Analyze(gs []*Goroutine, appModule string, minWait int) *Report {
+	r := &Report{Total: len(gs)}
+	byKey := make(map[string]*Group)
+	for _, g := range gs {
+		key := groupKey(g)
+		grp := byKey[key]
+		if grp == nil {
+			grp = &Group{State: g.State, Frames: g.Frames, CreatedBy: g.CreatedBy}
+			byKey[key] = grp
+			r.Groups = append(r.Groups, grp)
+		}
+		grp.IDs = append(grp.IDs, g.ID)
+		grp.WaitMinutes = max(grp.WaitMinutes, g.WaitMinutes)
+	}
+
+	for _, grp := range r.Groups {
+		what, blocked := blockingStates[grp.State]
+		if !blocked || !inApp(grp.Frames, appModule) {
+			continue
+		}
+		forever := strings.Contains(grp.State, "nil chan") || grp.State == "select (no cases)"
+		switch {
+		case forever:
+			grp.Suspect = fmt.Sprintf("blocked forever on %s", what)
+		case grp.WaitMinutes >= minWait:
+			grp.Suspect = fmt.Sprintf("blocked on %s for %s", what, minutes(grp.WaitMinutes))
+		}
+	}
+
+	slices.SortStableFunc(r.Groups, func(a, b *Group) int {
+		if (a.Suspect != "") != (b.Suspect != "") {
+			if a.Suspect != "" {
+				return -1
+			}
+			return 1
+		}
+		return len(b.IDs) - len(a.IDs)
+	})
+	return r
+}
+
+func groupKey(g *Goroutine) string {
+	var b strings.Builder
+	b.WriteString(g.State)
+	for _, f := range g.Frames {
+		fmt.Fprintf(&b, "\n%s %s:%d", f.Func, f.File, f.Line)
+	}
+	if g.CreatedBy != nil {
+		fmt.Fprintf(&b, "\ncreated by %s %s:%d", g.CreatedBy.Func, g.CreatedBy.File, g.CreatedBy.Line)
+	}
+	return b.String()
+}
+
+// inApp reports whether any of the frames is in the app module.
+func inApp(frames []Frame, appModule string) bool {
+	return slices.ContainsFunc(frames, func(f Frame) bool {
+		return f.Func == appModule || strings.HasPrefix(f.Func, appModule+"/") || strings.HasPrefix(f.Func, appModule+".")
+	})
+}
+
+func minutes(n int) string {
+	if n == 1 {
+		return "1 minute"
+	}
+	return strconv.Itoa(n) + " minutes"
+}
+
+// Write writes the report in a human-readable form.
+func (r *Report) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%d goroutines in %d groups", r.Total, len(r.Groups))
+	if n := r.Suspects(); n > 0 {
+		fmt.Fprintf(bw, ", %d suspected deadlocked", n)
+	}
+	bw.WriteString("\n")
+
+	for _, grp := range r.Groups {
+		bw.WriteString("\n")
+		if grp.Suspect != "" {
+			fmt.Fprintf(bw, "SUSPECTED DEADLOCK: %s\n", grp.Suspect)
+		}
+		ids := make([]string, len(grp.IDs))
+		for i, id := range grp.IDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		noun := "goroutines"
+		if len(grp.IDs) == 1 {
+			noun = "goroutine"
+		}
+		state := grp.State
+		if grp.WaitMinutes > 0 {
+			state += ", " + minutes(grp.WaitMinutes)
+		}
+		fmt.Fprintf(bw, "%d %s [%s]: %s\n", len(grp.IDs), noun, state, strings.Join(ids, ", "))
+		for _, f := range grp.Frames {
+			fmt.Fprintf(bw, "    %s\n        %s:%d\n", f.Func, f.File, f.Line)
+		}
+		if f := grp.CreatedBy; f != nil {
+			fmt.Fprintf(bw, "    created by %s\n        %s:%d\n", f.Func, f.File, f.Line)
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,84 @@
+package goroutines
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+const dump = `goroutine 1 [IO wait]:
+internal/poll.runtime_pollWait(0x7f, 0x72)
+	/usr/local/go/src/runtime/netpoll.go:351 +0x85
+net/http.(*Server).Serve(0xc0001, {0x1, 0xc0002})
+	/usr/local/go/src/net/http/server.go:3330 +0x30c
+main.main()
+	/app/main.go:10 +0x1d
+
+goroutine 21 [sync.Mutex.Lock, 3 minutes]:
+sync.(*Mutex).Lock(...)
+	/usr/local/go/src/sync/mutex.go:90
+encore.app/users.(*cache).get(0xc0003, {0xc0004, 0x5})
+	/app/users/cache.go:20 +0x45
+created by encore.app/users.initService in goroutine 1
+	/app/users/service.go:14 +0x9a
+
+goroutine 22 [sync.Mutex.Lock, 2 minutes]:
+sync.(*Mutex).Lock(...)
+	/usr/local/go/src/sync/mutex.go:90
+encore.app/users.(*cache).get(0xc0005, {0xc0006, 0x5})
+	/app/users/cache.go:20 +0x45
+created by encore.app/users.initService in goroutine 1
+	/app/users/service.go:14 +0x9a
+
+goroutine 30 [select, 10 minutes]:
+github.com/jackc/puddle/v2.(*Pool[...]).backgroundHealthCheck(0xc0007)
+	/go/pkg/mod/github.com/jackc/puddle/v2/pool.go:100 +0x1f
+
+goroutine 31 [chan receive (nil chan)]:
+encore.app/billing.wait()
+	/app/billing/billing.go:5 +0x12
+`
+
+func TestParse(t *testing.T) {
+	c := qt.New(t)
+	gs, err := Parse([]byte(dump))
+	c.Assert(err, qt.IsNil)
+	c.Assert(gs, qt.HasLen, 5)
+
+	g := gs[1]
+	c.Assert(g.ID, qt.Equals, 21)
+	c.Assert(g.State, qt.Equals, "sync.Mutex.Lock")
+	c.Assert(g.WaitMinutes, qt.Equals, 3)
+	c.Assert(g.Frames, qt.DeepEquals, []Frame{
+		{Func: "sync.(*Mutex).Lock", File: "/usr/local/go/src/sync/mutex.go", Line: 90},
+		{Func: "encore.app/users.(*cache).get", File: "/app/users/cache.go", Line: 20},
+	})
+	c.Assert(g.CreatedBy, qt.DeepEquals, &Frame{Func: "encore.app/users.initService", File: "/app/users/service.go", Line: 14})
+}
+
+func TestAnalyze(t *testing.T) {
+	c := qt.New(t)
+	gs, err := Parse([]byte(dump))
+	c.Assert(err, qt.IsNil)
+
+	r := Analyze(gs, "encore.app", 1)
+	c.Assert(r.Total, qt.Equals, 5)
+	c.Assert(r.Groups, qt.HasLen, 4)
+	c.Assert(r.Suspects(), qt.Equals, 3)
+
+	// Identical stacks are grouped, and suspects come first.
+	c.Assert(r.Groups[0].IDs, qt.DeepEquals, []int{21, 22})
+	c.Assert(r.Groups[0].Suspect, qt.Equals, "blocked on a mutex for 3 minutes")
+	c.Assert(r.Groups[1].IDs, qt.DeepEquals, []int{31})
+	c.Assert(r.Groups[1].Suspect, qt.Equals, "blocked forever on a receive on a nil channel")
+
+	// Long waits outside the app's code are expected.
+	c.Assert(r.Groups[3].IDs, qt.DeepEquals, []int{30})
+	c.Assert(r.Groups[3].Suspect, qt.Equals, "")
+
+	var b strings.Builder
+	c.Assert(r.Write(&b), qt.IsNil)
+	c.Assert(b.String(), qt.Contains, "5 goroutines in 4 groups, 3 suspected deadlocked\n")
+	c.Assert(b.String(), qt.Contains, "SUSPECTED DEADLOCK: blocked on a mutex for 3 minutes\n2 goroutines [sync.Mutex.Lock, 3 minutes]: 21, 22\n")
+}
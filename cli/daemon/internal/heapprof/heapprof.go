@@ -0,0 +1,233 @@
+// Package heapprof parses heap profiles and compares them
+// to find the allocation sites whose live memory keeps growing.
+package heapprof
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Profile is a heap profile.
+type Profile struct {
+	HeapAlloc uint64          // bytes of allocated heap objects
+	Sites     map[Site]*Usage // live memory by allocation site
+}
+
+// Site is where memory was allocated.
+type Site struct {
+	Func string // fully qualified function name, such as "encore.app/users.(*cache).put"
+	File string
+	Line int
+
+	// Caller is the innermost function in the app's code that led to the allocation,
+	// if the allocation happened outside of it, such as in a library.
+	Caller string
+}
+
+func (s Site) String() string {
+	str := fmt.Sprintf("%s %s:%d", s.Func, s.File, s.Line)
+	if s.Caller != "" {
+		str += " (called from " + s.Caller + ")"
+	}
+	return str
+}
+
+// Usage is the live memory allocated at a site, estimated from the
+// sampled allocations in the profile.
+type Usage struct {
+	Objects int64
+	Bytes   int64
+}
+
+var (
+	headerRe = regexp.MustCompile(`^heap profile: \d+: \d+ \[\d+: \d+\] @ heap/(\d+)$`)
+	recordRe = regexp.MustCompile(`^(\d+): (\d+) \[\d+: \d+\] @`)
+	offsetRe = regexp.MustCompile(`\+0x[0-9a-f]+$`)
+)
+
+// Parse parses a heap profile in the text format written by runtime/pprof
+// with debug=1. appModule is the module path of the app, such as "encore.app",
+// used to attribute allocations outside the app to the app code calling them.
+func Parse(data []byte, appModule string) (*Profile, error) {
+	p := &Profile{Sites: make(map[Site]*Usage)}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	if !sc.Scan() {
+		return nil, fmt.Errorf("empty heap profile")
+	}
+	m := headerRe.FindStringSubmatch(sc.Text())
+	if m == nil {
+		return nil, fmt.Errorf("invalid heap profile header %q", sc.Text())
+	}
+	// The header reports twice the sampling rate.
+	rate, _ := strconv.ParseFloat(m[1], 64)
+	rate /= 2
+
+	var (
+		objects, size int64   // of the current record
+		frames        []Frame // of the current record
+		inRecord      bool
+	)
+	flush := func() {
+		if inRecord && len(frames) > 0 && objects > 0 {
+			site := siteOf(frames, appModule)
+			u := p.Sites[site]
+			if u == nil {
+				u = &Usage{}
+				p.Sites[site] = u
+			}
+			scale := scaleFactor(float64(size)/float64(objects), rate)
+			u.Objects += int64(math.Round(float64(objects) * scale))
+			u.Bytes += int64(math.Round(float64(size) * scale))
+		}
+		inRecord, frames = false, nil
+	}
+
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case recordRe.MatchString(line):
+			flush()
+			m := recordRe.FindStringSubmatch(line)
+			objects, _ = strconv.ParseInt(m[1], 10, 64)
+			size, _ = strconv.ParseInt(m[2], 10, 64)
+			inRecord = true
+
+		case strings.HasPrefix(line, "# HeapAlloc = "):
+			p.HeapAlloc, _ = strconv.ParseUint(strings.TrimPrefix(line, "# HeapAlloc = "), 10, 64)
+
+		case inRecord && strings.HasPrefix(line, "#\t"):
+			// Frames are written as "#\tpc\tfunc+offset\tfile:line",
+			// with a variable number of tabs between the fields.
+			fields := slices.DeleteFunc(strings.Split(line, "\t"), func(s string) bool { return s == "" })
+			if len(fields) < 4 {
+				continue
+			}
+			file, lineNo := fields[3], 0
+			if i := strings.LastIndexByte(file, ':'); i >= 0 {
+				lineNo, _ = strconv.Atoi(file[i+1:])
+				file = file[:i]
+			}
+			frames = append(frames, Frame{Func: offsetRe.ReplaceAllString(fields[2], ""), File: file, Line: lineNo})
+
+		case line == "":
+			flush()
+		}
+	}
+	flush()
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Frame is a stack frame.
+type Frame struct {
+	Func string
+	File string
+	Line int
+}
+
+// siteOf returns the allocation site of a stack, innermost frame first.
+func siteOf(frames []Frame, appModule string) Site {
+	site := Site{Func: frames[0].Func, File: frames[0].File, Line: frames[0].Line}
+	if !inApp(frames[0].Func, appModule) {
+		for _, f := range frames[1:] {
+			if inApp(f.Func, appModule) {
+				site.Caller = fmt.Sprintf("%s %s:%d", f.Func, f.File, f.Line)
+				break
+			}
+		}
+	}
+	return site
+}
+
+func inApp(fn, appModule string) bool {
+	return appModule != "" && (strings.HasPrefix(fn, appModule+"/") || strings.HasPrefix(fn, appModule+"."))
+}
+
+// scaleFactor returns the factor to scale sampled allocations of the given
+// average size with, to estimate the actual allocations. An allocation of
+// size bytes is sampled with probability 1-exp(-size/rate).
+func scaleFactor(avgSize, rate float64) float64 {
+	if rate <= 1 || avgSize <= 0 {
+		return 1
+	}
+	return 1 / (1 - math.Exp(-avgSize/rate))
+}
+
+// Growth is the growth in live memory at an allocation site between two profiles.
+type Growth struct {
+	Site    Site
+	Base    Usage
+	Current Usage
+}
+
+// Bytes returns by how many bytes the live memory at the site grew.
+func (g Growth) Bytes() int64 {
+	return g.Current.Bytes - g.Base.Bytes
+}
+
+// Objects returns by how many objects the live objects at the site grew.
+func (g Growth) Objects() int64 {
+	return g.Current.Objects - g.Base.Objects
+}
+
+// Compare returns the allocation sites whose live memory grew from base to cur,
+// the largest growth first.
+func Compare(base, cur *Profile) []Growth {
+	var growth []Growth
+	for site, u := range cur.Sites {
+		g := Growth{Site: site, Current: *u}
+		if b := base.Sites[site]; b != nil {
+			g.Base = *b
+		}
+		if g.Bytes() > 0 {
+			growth = append(growth, g)
+		}
+	}
+	slices.SortFunc(growth, func(a, b Growth) int {
+		if c := cmp.Compare(b.Bytes(), a.Bytes()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Site.String(), b.Site.String())
+	})
+	return growth
+}
+
+// WriteReport writes a report comparing the allocation sites of two profiles,
+// listing at most limit sites, or all of them if limit is 0.
+func WriteReport(w io.Writer, base, cur *Profile, limit int) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "heap grew from %s to %s\n", humanize.IBytes(base.HeapAlloc), humanize.IBytes(cur.HeapAlloc))
+
+	growth := Compare(base, cur)
+	if len(growth) == 0 {
+		bw.WriteString("no allocation site grew\n")
+		return bw.Flush()
+	}
+	if limit > 0 && len(growth) > limit {
+		growth = growth[:limit]
+	}
+	bw.WriteString("\nallocation sites by growth in live memory (estimated from sampled allocations):\n")
+	for _, g := range growth {
+		fmt.Fprintf(bw, "\n+%s (%s -> %s), +%d objects\n", humanize.IBytes(uint64(g.Bytes())),
+			humanize.IBytes(uint64(g.Base.Bytes)), humanize.IBytes(uint64(g.Current.Bytes)), g.Objects())
+		fmt.Fprintf(bw, "    %s\n        %s:%d\n", g.Site.Func, g.Site.File, g.Site.Line)
+		if g.Site.Caller != "" {
+			fmt.Fprintf(bw, "    called from %s\n", g.Site.Caller)
+		}
+	}
+	return bw.Flush()
+}
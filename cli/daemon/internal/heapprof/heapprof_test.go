@@ -0,0 +1,88 @@
+package heapprof
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+const baseProfile = `heap profile: 12: 49152 [20: 81920] @ heap/1048576
+10: 40960 [10: 40960] @ 0x47c0b3 0x4de767 0x4de733
+#	0x4de766	encore.app/users.(*cache).put+0x46		/app/users/cache.go:20
+#	0x4de732	encore.app/users.Get+0x12			/app/users/users.go:14
+
+2: 8192 [10: 40960] @ 0x47c0b3 0x4de999 0x4de733
+#	0x4de998	encoding/json.Marshal+0x18	/usr/local/go/src/encoding/json/encode.go:160
+#	0x4de732	encore.app/users.Get+0x12	/app/users/users.go:30
+
+
+# runtime.MemStats
+# Alloc = 4167872
+# HeapAlloc = 4167872
+# HeapSys = 12320768
+`
+
+const curProfile = `heap profile: 42: 172032 [50: 204800] @ heap/1048576
+40: 163840 [40: 163840] @ 0x47c0b3 0x4de767 0x4de733
+#	0x4de766	encore.app/users.(*cache).put+0x46		/app/users/cache.go:20
+#	0x4de732	encore.app/users.Get+0x12			/app/users/users.go:14
+
+2: 8192 [10: 40960] @ 0x47c0b3 0x4de999 0x4de733
+#	0x4de998	encoding/json.Marshal+0x18	/usr/local/go/src/encoding/json/encode.go:160
+#	0x4de732	encore.app/users.Get+0x12	/app/users/users.go:30
+
+
+# runtime.MemStats
+# Alloc = 20971520
+# HeapAlloc = 20971520
+# HeapSys = 33554432
+`
+
+func TestParse(t *testing.T) {
+	c := qt.New(t)
+	p, err := Parse([]byte(baseProfile), "encore.app")
+	c.Assert(err, qt.IsNil)
+	c.Assert(p.HeapAlloc, qt.Equals, uint64(4167872))
+	c.Assert(p.Sites, qt.HasLen, 2)
+
+	cache := p.Sites[Site{Func: "encore.app/users.(*cache).put", File: "/app/users/cache.go", Line: 20}]
+	c.Assert(cache, qt.IsNotNil)
+	// 10 sampled 4KiB objects with a sampling rate of 512KiB are estimated
+	// to be 10/(1-exp(-4096/524288)) ≈ 1285 objects.
+	c.Assert(cache.Objects, qt.Equals, int64(1285))
+	c.Assert(cache.Bytes, qt.Equals, int64(5263387))
+
+	json := p.Sites[Site{
+		Func:   "encoding/json.Marshal",
+		File:   "/usr/local/go/src/encoding/json/encode.go",
+		Line:   160,
+		Caller: "encore.app/users.Get /app/users/users.go:30",
+	}]
+	c.Assert(json, qt.IsNotNil)
+}
+
+func TestParseInvalid(t *testing.T) {
+	c := qt.New(t)
+	_, err := Parse([]byte("goroutine 1 [running]:\n"), "encore.app")
+	c.Assert(err, qt.ErrorMatches, `invalid heap profile header .*`)
+}
+
+func TestCompare(t *testing.T) {
+	c := qt.New(t)
+	base, err := Parse([]byte(baseProfile), "encore.app")
+	c.Assert(err, qt.IsNil)
+	cur, err := Parse([]byte(curProfile), "encore.app")
+	c.Assert(err, qt.IsNil)
+
+	growth := Compare(base, cur)
+	c.Assert(growth, qt.HasLen, 1)
+	c.Assert(growth[0].Site.Func, qt.Equals, "encore.app/users.(*cache).put")
+	c.Assert(growth[0].Objects() > 0, qt.IsTrue)
+
+	var b strings.Builder
+	c.Assert(WriteReport(&b, base, cur, 10), qt.IsNil)
+	c.Assert(b.String(), qt.Contains, "heap grew from 4.0 MiB to 20 MiB\n")
+	c.Assert(b.String(), qt.Contains, "    encore.app/users.(*cache).put\n        /app/users/cache.go:20\n")
+	c.Assert(b.String(), qt.Not(qt.Contains), "encoding/json")
+}
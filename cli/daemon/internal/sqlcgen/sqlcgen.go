@@ -0,0 +1,181 @@
+// Package sqlcgen runs sqlc code generation for the sqlc configurations in an app,
+// keeping the query packages sqlc generates from the app's migrations up to date
+// when the app is built.
+//
+// sqlc is run in a subprocess of the Encore binary, which embeds it,
+// so it doesn't need to be installed separately.
+package sqlcgen
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	cerrors "github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/errinsrc"
+	"encr.dev/pkg/errors"
+)
+
+// configNames are the names of sqlc configuration files, in the order sqlc looks for them.
+var configNames = []string{"sqlc.yaml", "sqlc.yml", "sqlc.json"}
+
+// generatedHeader is the comment sqlc starts the files it generates with.
+const generatedHeader = "// Code generated by sqlc. DO NOT EDIT."
+
+// IsConfig reports whether path is a sqlc configuration file.
+func IsConfig(path string) bool {
+	return slices.Contains(configNames, filepath.Base(path))
+}
+
+// IsGenerated reports whether the file at path was generated by sqlc.
+func IsGenerated(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	header := make([]byte, len(generatedHeader))
+	_, err = io.ReadFull(f, header)
+	return err == nil && string(header) == generatedHeader
+}
+
+// FindConfigs returns the paths of the sqlc configuration files in the app at appRoot.
+// Hidden directories, node_modules and vendor directories are skipped.
+func FindConfigs(appRoot string) ([]string, error) {
+	var configs []string
+	err := filepath.WalkDir(appRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != appRoot && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			// sqlc only reads the first configuration file it finds in a directory.
+			for _, cfg := range configNames {
+				if _, err := os.Stat(filepath.Join(path, cfg)); err == nil {
+					configs = append(configs, filepath.Join(path, cfg))
+					break
+				}
+			}
+		}
+		return nil
+	})
+	return configs, cerrors.Wrap(err, "find sqlc configurations")
+}
+
+// Generate runs sqlc generation for the sqlc configurations in the app at appRoot
+// whose generated code is out of date, leaving up-to-date code untouched.
+//
+// The errors sqlc reports, like queries referencing columns the migrations
+// don't create, are returned as an [errinsrc.List] at their locations.
+func Generate(ctx context.Context, appRoot string) error {
+	configs, err := FindConfigs(appRoot)
+	if err != nil {
+		return err
+	}
+
+	var errs errinsrc.List
+	for _, cfg := range configs {
+		// sqlc diff exits with a non-zero status if the generated code
+		// is out of date, or if generating it fails.
+		if _, err := run(ctx, cfg, "diff"); err == nil {
+			continue
+		} else if !isExitError(err) {
+			return err
+		}
+
+		stderr, err := run(ctx, cfg, "generate")
+		if err == nil {
+			continue
+		} else if !isExitError(err) {
+			return err
+		}
+		errs = append(errs, parseErrors(appRoot, cfg, stderr, err)...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// run runs the sqlc command cmd for the configuration at cfg, returning its stderr.
+func run(ctx context.Context, cfg, cmd string) (stderr []byte, err error) {
+	var buf bytes.Buffer
+	c := exec.CommandContext(ctx, os.Args[0], "sqlc", cmd, "--file="+filepath.Base(cfg), "--no-remote")
+	c.Dir = filepath.Dir(cfg)
+	c.Stderr = &buf
+	err = c.Run()
+	if err != nil && !isExitError(err) {
+		return nil, cerrors.Wrap(err, "run sqlc")
+	}
+	return buf.Bytes(), err
+}
+
+func isExitError(err error) bool {
+	var exitErr *exec.ExitError
+	return cerrors.As(err, &exitErr)
+}
+
+var (
+	errRange = errors.Range(
+		"sqlc",
+		"Encore runs sqlc to generate the query packages configured in sqlc.yaml files when building the app. "+
+			"For more information, see https://encore.dev/docs/primitives/databases",
+		errors.WithRangeSize(5),
+	)
+
+	errQuery = errRange.Newf(
+		"sqlc error",
+		"sqlc was unable to generate code for the configuration %s: %s",
+	)
+)
+
+// errorLine matches the "<file>:<line>:<column>: <message>" lines sqlc reports errors in files with.
+var errorLine = regexp.MustCompile(`^(.+?):(\d+):(\d+): (.+)$`)
+
+// parseErrors parses the errors sqlc wrote to stderr for the configuration at cfg,
+// falling back to runErr if it didn't write any.
+func parseErrors(appRoot, cfg string, stderr []byte, runErr error) errinsrc.List {
+	var errs errinsrc.List
+	dir := filepath.Dir(cfg)
+	if rel, err := filepath.Rel(appRoot, cfg); err == nil {
+		cfg = filepath.ToSlash(rel)
+	}
+	sc := bufio.NewScanner(bytes.NewReader(stderr))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "# package ") {
+			continue
+		}
+
+		tmpl := errQuery(cfg, line)
+		if m := errorLine.FindStringSubmatch(line); m != nil {
+			filename := m[1]
+			if !filepath.IsAbs(filename) {
+				filename = filepath.Join(dir, filename)
+			}
+			lineNo, _ := strconv.Atoi(m[2])
+			col, _ := strconv.Atoi(m[3])
+			pos := token.Position{Filename: filename, Line: lineNo, Column: max(col, 1)}
+			tmpl = errQuery(cfg, m[4]).AtGoPosition(pos, pos)
+		}
+		errs = append(errs, errinsrc.FromTemplate(tmpl, nil))
+	}
+	if len(errs) == 0 {
+		errs = append(errs, errinsrc.FromTemplate(errQuery(cfg, runErr), nil))
+	}
+	return errs
+}
@@ -0,0 +1,67 @@
+package sqlcgen
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestFindConfigs(t *testing.T) {
+	c := qt.New(t)
+	root := t.TempDir()
+	for _, path := range []string{
+		"users/sqlc.yaml",
+		"billing/sqlc.json",
+		"billing/sqlc.yaml",
+		"node_modules/pkg/sqlc.yaml",
+		".git/sqlc.yaml",
+	} {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0755), qt.IsNil)
+		c.Assert(os.WriteFile(path, []byte("version: \"2\"\n"), 0644), qt.IsNil)
+	}
+
+	configs, err := FindConfigs(root)
+	c.Assert(err, qt.IsNil)
+	c.Assert(configs, qt.DeepEquals, []string{
+		filepath.Join(root, "billing", "sqlc.yaml"),
+		filepath.Join(root, "users", "sqlc.yaml"),
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	c := qt.New(t)
+	root := t.TempDir()
+	cfg := filepath.Join(root, "users", "sqlc.yaml")
+	stderr := []byte("# package db\n" +
+		"db/query.sql:3:8: column \"nme\" does not exist\n" +
+		"error parsing configuration file: unknown engine\n")
+
+	errs := parseErrors(root, cfg, stderr, errors.New("exit status 1"))
+	c.Assert(errs, qt.HasLen, 2)
+	c.Assert(errs[0].Params.Summary, qt.Equals, `sqlc was unable to generate code for the configuration users/sqlc.yaml: column "nme" does not exist`)
+	c.Assert(errs[0].Params.Locations, qt.HasLen, 1)
+	c.Assert(errs[0].Params.Locations[0].File.FullPath, qt.Equals, filepath.Join(root, "users", "db", "query.sql"))
+	c.Assert(errs[1].Params.Summary, qt.Contains, "unknown engine")
+	c.Assert(errs[1].Params.Locations, qt.HasLen, 0)
+
+	errs = parseErrors(root, cfg, nil, errors.New("exit status 1"))
+	c.Assert(errs, qt.HasLen, 1)
+	c.Assert(errs[0].Params.Summary, qt.Contains, "exit status 1")
+}
+
+func TestIsGenerated(t *testing.T) {
+	c := qt.New(t)
+	dir := t.TempDir()
+	gen := filepath.Join(dir, "models.go")
+	c.Assert(os.WriteFile(gen, []byte(generatedHeader+"\n// versions:\n\npackage db\n"), 0644), qt.IsNil)
+	hand := filepath.Join(dir, "db.go")
+	c.Assert(os.WriteFile(hand, []byte("package db\n"), 0644), qt.IsNil)
+
+	c.Assert(IsGenerated(gen), qt.IsTrue)
+	c.Assert(IsGenerated(hand), qt.IsFalse)
+	c.Assert(IsGenerated(filepath.Join(dir, "missing.go")), qt.IsFalse)
+}
@@ -0,0 +1,48 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/export"
+	"encr.dev/pkg/licenses"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// LicenseReport reports the licenses of the dependencies of an app.
+func (s *Server) LicenseReport(ctx context.Context, req *daemonpb.LicenseReportRequest) (*daemonpb.LicenseReportResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	rep, violations, err := export.LicenseReport(ctx, app)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	resp := &daemonpb.LicenseReportResponse{
+		GeneratedAt: rep.GeneratedAt.Format(time.RFC3339),
+	}
+	for _, dep := range rep.Dependencies {
+		resp.Dependencies = append(resp.Dependencies, dependencyLicenseToProto(dep))
+	}
+	for _, v := range violations {
+		resp.Violations = append(resp.Violations, &daemonpb.LicenseViolation{
+			Dependency: dependencyLicenseToProto(v.Dependency),
+			Denied:     v.Denied,
+		})
+	}
+	return resp, nil
+}
+
+func dependencyLicenseToProto(dep licenses.Dependency) *daemonpb.DependencyLicense {
+	return &daemonpb.DependencyLicense{
+		Name:        dep.Name,
+		Version:     dep.Version,
+		License:     dep.License,
+		LicenseFile: dep.LicenseFile,
+	}
+}
@@ -0,0 +1,213 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/logrusorgru/aurora/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/dap"
+	"encr.dev/cli/daemon/delve"
+	"encr.dev/cli/daemon/run"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// logpointRegistry keeps track of the logpoints of running apps
+// and the debugger sessions serving them, one per process.
+type logpointRegistry struct {
+	mu       sync.Mutex
+	nextID   int32
+	sessions map[int]*delve.Session // pid -> session
+	points   map[int32]*logpoint    // id -> logpoint
+}
+
+type logpoint struct {
+	pb    *daemonpb.Logpoint
+	appID string
+	pid   int
+}
+
+// AddLogpoint adds a logpoint to a service of a running app.
+func (s *Server) AddLogpoint(ctx context.Context, req *daemonpb.AddLogpointRequest) (*daemonpb.Logpoint, error) {
+	r, err := s.runningApp(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Exprs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no expressions given")
+	}
+
+	targets := dapTargets(r)
+	idx := slices.IndexFunc(targets, func(t dap.Target) bool { return t.Name == req.Service })
+	if idx < 0 {
+		names := make([]string, len(targets))
+		for i, t := range targets {
+			names[i] = t.Name
+		}
+		return nil, status.Errorf(codes.NotFound, "service %q is not running (running: %s)", req.Service, strings.Join(names, ", "))
+	}
+	pid := targets[idx].Pid
+
+	reg := &s.logpoints
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	sess := reg.sessions[pid]
+	if sess == nil {
+		sess, err = delve.Attach(ctx, pid, func(h delve.Hit) {
+			s.mgr.RunStderr(r, formatLogpointHit(h))
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "unable to attach debugger: %v", err)
+		}
+		if reg.sessions == nil {
+			reg.sessions = make(map[int]*delve.Session)
+			reg.points = make(map[int32]*logpoint)
+		}
+		reg.sessions[pid] = sess
+		go s.awaitLogpointSession(r, sess)
+	}
+
+	reg.nextID++
+	lp := &logpoint{
+		pb: &daemonpb.Logpoint{
+			Id:        reg.nextID,
+			Service:   req.Service,
+			Location:  req.Location,
+			Exprs:     req.Exprs,
+			Condition: req.Condition,
+		},
+		appID: r.App.PlatformOrLocalID(),
+		pid:   pid,
+	}
+	if err := sess.Add(delve.Logpoint{
+		ID:       int(lp.pb.Id),
+		Location: req.Location,
+		Exprs:    req.Exprs,
+		Cond:     req.GetCondition(),
+	}); err != nil {
+		reg.closeIfUnused(pid)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	reg.points[lp.pb.Id] = lp
+	return lp.pb, nil
+}
+
+// ListLogpoints lists the logpoints of a running app.
+func (s *Server) ListLogpoints(ctx context.Context, req *daemonpb.ListLogpointsRequest) (*daemonpb.ListLogpointsResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	reg := &s.logpoints
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	resp := &daemonpb.ListLogpointsResponse{}
+	for _, lp := range reg.points {
+		if lp.appID == app.PlatformOrLocalID() {
+			resp.Logpoints = append(resp.Logpoints, lp.pb)
+		}
+	}
+	slices.SortFunc(resp.Logpoints, func(a, b *daemonpb.Logpoint) int { return int(a.Id - b.Id) })
+	return resp, nil
+}
+
+// RemoveLogpoint removes a logpoint of a running app.
+func (s *Server) RemoveLogpoint(ctx context.Context, req *daemonpb.RemoveLogpointRequest) (*empty.Empty, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	reg := &s.logpoints
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	lp, ok := reg.points[req.Id]
+	if !ok || lp.appID != app.PlatformOrLocalID() {
+		return nil, status.Errorf(codes.NotFound, "logpoint %d not found", req.Id)
+	}
+	delete(reg.points, req.Id)
+	if sess := reg.sessions[lp.pid]; sess != nil {
+		if err := sess.Remove(int(req.Id)); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to remove logpoint: %v", err)
+		}
+	}
+	reg.closeIfUnused(lp.pid)
+	return &empty.Empty{}, nil
+}
+
+// runningApp returns the run of the app at appRoot.
+func (s *Server) runningApp(appRoot string) (*run.Run, error) {
+	app, err := s.apps.Track(appRoot)
+	if err != nil {
+		return nil, err
+	}
+	r := s.mgr.FindRunByAppID(app.PlatformOrLocalID())
+	if r == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the app is not running")
+	}
+	return r, nil
+}
+
+// awaitLogpointSession removes the logpoints of a session when it ends,
+// which happens when its process exits, such as when the app is reloaded.
+func (s *Server) awaitLogpointSession(r *run.Run, sess *delve.Session) {
+	<-sess.Done()
+
+	reg := &s.logpoints
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if reg.sessions[sess.Pid] == sess {
+		delete(reg.sessions, sess.Pid)
+	}
+	removed := 0
+	for id, lp := range reg.points {
+		if lp.pid == sess.Pid {
+			delete(reg.points, id)
+			removed++
+		}
+	}
+	if removed > 0 {
+		s.mgr.RunStderr(r, []byte(aurora.Sprintf(aurora.Yellow("Removed %d logpoint(s) as the process they were added to exited.\n"), removed)))
+	}
+}
+
+// closeIfUnused closes the session of the process pid if it has no logpoints.
+// reg.mu must be held.
+func (reg *logpointRegistry) closeIfUnused(pid int) {
+	for _, lp := range reg.points {
+		if lp.pid == pid {
+			return
+		}
+	}
+	if sess := reg.sessions[pid]; sess != nil {
+		delete(reg.sessions, pid)
+		_ = sess.Close()
+	}
+}
+
+// formatLogpointHit formats a logpoint hit as a line of run output.
+func formatLogpointHit(h delve.Hit) []byte {
+	var b strings.Builder
+	b.WriteString(aurora.Magenta(fmt.Sprintf("logpoint #%d", h.LogpointID)).String())
+	b.WriteString(aurora.Gray(12, fmt.Sprintf(" %s:%d", filepath.Base(h.File), h.Line)).String())
+	for _, v := range h.Values {
+		b.WriteByte(' ')
+		b.WriteString(aurora.Cyan(v.Expr + "=").String())
+		if v.Err != "" {
+			b.WriteString(aurora.Red("<" + v.Err + ">").String())
+		} else {
+			b.WriteString(v.Value)
+		}
+	}
+	b.WriteByte('\n')
+	return []byte(b.String())
+}
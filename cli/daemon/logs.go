@@ -0,0 +1,155 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"encr.dev/cli/daemon/run"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// logSubscriberBuffer is the number of lines buffered for a log subscriber.
+// Lines are dropped when a subscriber falls further behind than that,
+// so a slow client never blocks the app's output.
+const logSubscriberBuffer = 1024
+
+// logSubscriberRegistry tracks the clients attached to the output of running apps.
+type logSubscriberRegistry struct {
+	mu   sync.Mutex
+	subs map[string]map[*logSubscriber]bool // run id -> subscribers
+}
+
+// logSubscriber is a client attached to the output of a running app.
+type logSubscriber struct {
+	services []string     // if non-empty, only the logs of these services are sent
+	lines    chan logLine // lines to send
+
+	mu      sync.Mutex
+	dropped int // number of lines dropped since the last line sent
+}
+
+type logLine struct {
+	data   []byte
+	stderr bool
+}
+
+func (reg *logSubscriberRegistry) add(runID string, sub *logSubscriber) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if reg.subs == nil {
+		reg.subs = make(map[string]map[*logSubscriber]bool)
+	}
+	if reg.subs[runID] == nil {
+		reg.subs[runID] = make(map[*logSubscriber]bool)
+	}
+	reg.subs[runID][sub] = true
+}
+
+func (reg *logSubscriberRegistry) remove(runID string, sub *logSubscriber) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	delete(reg.subs[runID], sub)
+	if len(reg.subs[runID]) == 0 {
+		delete(reg.subs, runID)
+	}
+}
+
+// publish sends a line of output of the given run to its subscribers.
+func (reg *logSubscriberRegistry) publish(runID string, line []byte, stderr bool) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	subs := reg.subs[runID]
+	if len(subs) == 0 {
+		return
+	}
+
+	// The line is owned by the caller, so copy it.
+	l := logLine{data: slices.Clone(line), stderr: stderr}
+	var svc string
+	var parsed bool
+	for sub := range subs {
+		if len(sub.services) > 0 {
+			if !parsed {
+				svc, parsed = logLineService(line), true
+			}
+			if !slices.Contains(sub.services, svc) {
+				continue
+			}
+		}
+		select {
+		case sub.lines <- l:
+		default:
+			sub.mu.Lock()
+			sub.dropped++
+			sub.mu.Unlock()
+		}
+	}
+}
+
+// takeDropped returns the number of lines dropped since it was last called.
+func (sub *logSubscriber) takeDropped() int {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	n := sub.dropped
+	sub.dropped = 0
+	return n
+}
+
+// logLineService returns the service that wrote a structured log line,
+// or "" if the line isn't a structured log line.
+func logLineService(line []byte) string {
+	var entry struct {
+		Service string `json:"service"`
+	}
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return ""
+	}
+	return entry.Service
+}
+
+// AttachLogs streams the output of a running app until the app stops
+// or the client disconnects.
+func (s *Server) AttachLogs(req *daemonpb.AttachLogsRequest, stream daemonpb.Daemon_AttachLogsServer) error {
+	ctx := stream.Context()
+	slog := &streamLog{stream: stream, buffered: false}
+	sendErr := func(err error) error {
+		_, _ = fmt.Fprintln(slog.Stderr(false), err)
+		streamExit(stream, 1)
+		return nil
+	}
+
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return sendErr(err)
+	}
+	r := s.mgr.FindRunByAppID(app.PlatformOrLocalID())
+	if r == nil {
+		return sendErr(fmt.Errorf("the app is not running; start it with 'encore run' to attach to its logs"))
+	}
+	if len(req.Services) > 0 {
+		if err := checkServicesExist(r, req.Services); err != nil {
+			return sendErr(err)
+		}
+	}
+
+	s.streamRunOutput(ctx, r, req.Services, stream, slog)
+	return nil
+}
+
+// checkServicesExist reports an error if any of the services
+// are not part of the running app.
+func checkServicesExist(r *run.Run, services []string) error {
+	md, err := r.App.CachedMetadata()
+	if err != nil {
+		return err
+	}
+	for _, name := range services {
+		if !slices.ContainsFunc(md.Svcs, func(svc *meta.Service) bool { return svc.Name == name }) {
+			return fmt.Errorf("service %s not found", name)
+		}
+	}
+	return nil
+}
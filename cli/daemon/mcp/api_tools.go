@@ -15,6 +15,7 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/schemautil"
 	metav1 "encr.dev/proto/encore/parser/meta/v1"
@@ -127,7 +128,7 @@ func (m *Manager) callEndpoint(ctx context.Context, request mcp.CallToolRequest)
 	// Get the app's run instance
 	appRun := m.run.FindRunByAppID(inst.PlatformOrLocalID())
 	if appRun == nil {
-		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		ln, err := bindaddr.ListenLoopback(0)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create listener: %w", err)
 		}
@@ -138,7 +139,7 @@ func (m *Manager) callEndpoint(ctx context.Context, request mcp.CallToolRequest)
 			WorkingDir: "/",
 			Watch:      true,
 			Listener:   ln,
-			ListenAddr: "127.0.0.1:" + fmt.Sprint(port),
+			ListenAddr: bindaddr.LoopbackAddr(port),
 			Environ:    os.Environ(),
 			OpsTracker: nil,
 			Browser:    run.BrowserModeNever,
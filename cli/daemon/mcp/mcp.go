@@ -3,7 +3,6 @@ package mcp
 import (
 	"context"
 	"fmt"
-	"net"
 	"net/http"
 
 	"github.com/mark3labs/mcp-go/server"
@@ -116,8 +115,9 @@ func addAppToContext(ctx context.Context, r *http.Request) context.Context {
 	return ctx
 }
 
-func (m *Manager) Serve(listener net.Listener) error {
-	return http.Serve(listener, m.sse)
+// Handler returns the http.Handler serving the MCP SSE endpoint.
+func (m *Manager) Handler() http.Handler {
+	return m.sse
 }
 
 func (m *Manager) getApp(ctx context.Context) (*apps.Instance, error) {
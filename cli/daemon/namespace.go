@@ -69,6 +69,9 @@ func (s *Server) SwitchNamespace(ctx context.Context, req *daemonpb.SwitchNamesp
 
 func (s *Server) namespaceOrActive(ctx context.Context, app *apps.Instance, ns *string) (*namespace.Namespace, error) {
 	if ns == nil {
+		if branchNS, ok, err := s.gitBranchNamespace(ctx, app); err != nil || ok {
+			return branchNS, err
+		}
 		return s.ns.GetActive(ctx, app)
 	}
 	return s.ns.GetByName(ctx, app, namespace.Name(*ns))
@@ -0,0 +1,74 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/internal/userconfig"
+	"encr.dev/pkg/vcs"
+)
+
+// gitBranchNamespace switches to the namespace named after the git branch
+// checked out in the app, if the "namespace.git_branch" option is enabled.
+// If the namespace doesn't exist it is created, starting with a copy of the
+// databases of the previously active namespace.
+//
+// It reports false if the option is disabled or no branch is checked out.
+func (s *Server) gitBranchNamespace(ctx context.Context, app *apps.Instance) (*namespace.Namespace, bool, error) {
+	if cfg, err := userconfig.ForApp(app.Root()).Get(); err != nil || !cfg.NamespaceGitBranch {
+		return nil, false, nil
+	}
+	branch, ok := vcs.GitBranch(app.Root())
+	if !ok {
+		return nil, false, nil
+	}
+	name := namespace.Name(branch)
+
+	active, err := s.ns.GetActive(ctx, app)
+	if err != nil {
+		return nil, false, err
+	} else if active.Name == name {
+		return active, true, nil
+	}
+
+	if _, err := s.ns.GetByName(ctx, app, name); errors.Is(err, namespace.ErrNotFound) {
+		created, err := s.ns.Create(ctx, app, name)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := s.copyNamespaceDatabases(ctx, app, active, created); err != nil {
+			if delErr := s.ns.Delete(ctx, app, name); delErr != nil {
+				log.Error().Err(delErr).Str("namespace", string(name)).Msg("unable to delete namespace after failed copy")
+			}
+			return nil, false, err
+		}
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	ns, err := s.ns.Switch(ctx, app, name)
+	if err != nil {
+		return nil, false, err
+	}
+	log.Info().Str("namespace", string(name)).Msg("switched to namespace of git branch")
+	return ns, true, nil
+}
+
+// copyNamespaceDatabases copies the databases of the app from one namespace to another.
+// It does nothing if the app has no databases, or hasn't been parsed yet.
+func (s *Server) copyNamespaceDatabases(ctx context.Context, app *apps.Instance, from, to *namespace.Namespace) error {
+	md, err := app.CachedMetadata()
+	if err != nil || md == nil {
+		return nil
+	}
+	dbs := sqldb.Databases(md)
+	if len(dbs) == 0 {
+		return nil
+	}
+	return s.cm.CopyNamespace(ctx, app, from, to, dbs)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/pkg/emulators/storage/gcsemu"
 	"github.com/cockroachdb/errors"
 	"github.com/rs/xid"
@@ -65,7 +66,7 @@ func (s *Server) Start() error {
 			s.public.Register(s.id, s.store)
 		}
 		mux := http.NewServeMux()
-		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		ln, err := bindaddr.ListenLoopback(0)
 		if err != nil {
 			return errors.Wrap(err, "listen tcp")
 		}
@@ -0,0 +1,576 @@
+package objects
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/pem"
+	"io"
+	"mime"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/pkg/sftp"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/api/storage/v1"
+
+	"encr.dev/cli/daemon/ports"
+	"encr.dev/cli/internal/bindaddr"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/emulators/storage/gcsemu"
+	"encr.dev/pkg/fns"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// DefaultSFTPPort is the port the SFTP server listens on, if available
+// and the app doesn't configure one.
+const DefaultSFTPPort = 2022
+
+// SFTPServer is an SFTP server that maps top-level directories to the buckets
+// of an Object Storage server, so files delivered over SFTP end up in buckets.
+// It only listens on the loopback interface.
+type SFTPServer struct {
+	store     gcsemu.Store
+	cfg       *appfile.LocalSFTP
+	config    *ssh.ServerConfig
+	generated string // password generated for this run, if not configured
+
+	mu    sync.Mutex
+	dirs  map[string]string // directory name -> bucket name
+	mkdir map[string]bool   // directories created by clients, as "bucket/key"
+	conns map[net.Conn]bool
+	ln    net.Listener
+}
+
+// NewSFTPServer creates an SFTP server serving the buckets of srv.
+// The server's host key is read from hostKeyPath, and generated if it doesn't exist.
+// Without a configured password or authorized keys, clients log in
+// with a password generated for the server, which is included in its URL.
+func NewSFTPServer(srv *Server, cfg *appfile.LocalSFTP, appRoot, hostKeyPath string) (*SFTPServer, error) {
+	hostKey, err := loadHostKey(hostKeyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "load sftp host key")
+	}
+
+	user, password := cfg.User, cfg.Password
+	if user == "" {
+		user = "encore"
+	}
+	var generated string
+	if password == "" && cfg.AuthorizedKeys == "" {
+		generated = rand.Text()
+		password = generated
+	}
+
+	config := &ssh.ServerConfig{}
+	if password != "" {
+		config.PasswordCallback = func(conn ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if conn.User() == user && subtle.ConstantTimeCompare(pass, []byte(password)) == 1 {
+				return nil, nil
+			}
+			return nil, errors.New("invalid username or password")
+		}
+	}
+	if cfg.AuthorizedKeys != "" {
+		keys, err := readAuthorizedKeys(filepath.Join(appRoot, cfg.AuthorizedKeys))
+		if err != nil {
+			return nil, err
+		}
+		config.PublicKeyCallback = func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == user && slices.ContainsFunc(keys, func(k ssh.PublicKey) bool {
+				return bytes.Equal(k.Marshal(), key.Marshal())
+			}) {
+				return nil, nil
+			}
+			return nil, errors.New("unknown public key")
+		}
+	}
+	config.AddHostKey(hostKey)
+
+	return &SFTPServer{
+		store:     srv.store,
+		cfg:       cfg,
+		config:    config,
+		generated: generated,
+		mkdir:     make(map[string]bool),
+		conns:     make(map[net.Conn]bool),
+	}, nil
+}
+
+// Initialize maps the directories to the buckets of the given app.
+func (s *SFTPServer) Initialize(md *meta.Data) error {
+	dirs := make(map[string]string)
+	for _, b := range md.Buckets {
+		dirs[b.Name] = b.Name
+	}
+	if len(s.cfg.Directories) > 0 {
+		for dir, bucket := range s.cfg.Directories {
+			if _, ok := dirs[bucket]; !ok {
+				return errors.Newf("local_sftp: directory %q is mapped to unknown bucket %q", dir, bucket)
+			}
+		}
+		dirs = s.cfg.Directories
+	}
+
+	s.mu.Lock()
+	s.dirs = dirs
+	s.mu.Unlock()
+	return nil
+}
+
+// Start starts listening for connections on the port configured by the app.
+// Without one it listens on DefaultSFTPPort if available, and otherwise
+// on another port assigned to the app through reg.
+func (s *SFTPServer) Start(ctx context.Context, reg *ports.Registry, appID string) error {
+	port, auto := s.cfg.Port, s.cfg.Port == 0
+	if auto {
+		port = DefaultSFTPPort
+	}
+	key := ports.Key{AppID: appID, Component: "sftp"}
+	ln, err := reg.Listen(ctx, key, bindaddr.Loopback(), port, auto)
+	if err != nil {
+		return errors.Wrap(err, "start sftp server")
+	}
+	s.ln = ln
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serveConn(conn)
+		}
+	}()
+	return nil
+}
+
+// Stop closes the listener and all open connections.
+func (s *SFTPServer) Stop() {
+	if s.ln != nil {
+		_ = s.ln.Close()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+}
+
+// URL returns the URL clients connect to,
+// including the generated password if there is one.
+func (s *SFTPServer) URL() string {
+	user := s.cfg.User
+	if user == "" {
+		user = "encore"
+	}
+	u := &url.URL{Scheme: "sftp", User: url.User(user), Host: s.ln.Addr().String()}
+	if s.generated != "" {
+		u.User = url.UserPassword(user, s.generated)
+	}
+	return u.String()
+}
+
+// Directories returns the mapping from directory names to bucket names.
+func (s *SFTPServer) Directories() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dirs
+}
+
+func (s *SFTPServer) serveConn(nConn net.Conn) {
+	s.mu.Lock()
+	s.conns[nConn] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, nConn)
+		s.mu.Unlock()
+		_ = nConn.Close()
+	}()
+
+	conn, chans, reqs, err := ssh.NewServerConn(nConn, s.config)
+	if err != nil {
+		log.Debug().Err(err).Msg("sftp handshake failed")
+		return
+	}
+	defer fns.CloseIgnore(conn)
+	go ssh.DiscardRequests(reqs)
+
+	for newCh := range chans {
+		if newCh.ChannelType() != "session" {
+			_ = newCh.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		ch, reqs, err := newCh.Accept()
+		if err != nil {
+			continue
+		}
+		go s.serveSession(ch, reqs)
+	}
+}
+
+// serveSession serves the sftp subsystem on a session channel.
+func (s *SFTPServer) serveSession(ch ssh.Channel, reqs <-chan *ssh.Request) {
+	defer fns.CloseIgnore(ch)
+	for req := range reqs {
+		// The payload of subsystem requests is the length-prefixed subsystem name.
+		ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+		_ = req.Reply(ok, nil)
+		if !ok {
+			continue
+		}
+
+		go ssh.DiscardRequests(reqs)
+		fs := &bucketFS{s: s}
+		srv := sftp.NewRequestServer(ch, sftp.Handlers{FileGet: fs, FilePut: fs, FileCmd: fs, FileList: fs})
+		if err := srv.Serve(); err != nil && !errors.Is(err, io.EOF) {
+			log.Debug().Err(err).Msg("sftp session failed")
+		}
+		_ = srv.Close()
+		return
+	}
+}
+
+// bucketFS implements the SFTP file system on top of the buckets.
+type bucketFS struct {
+	s *SFTPServer
+}
+
+// resolve resolves the path p to a bucket and a key within it.
+// The root directory resolves to an empty bucket and key, and the
+// directories mapped to buckets to an empty key.
+func (fs *bucketFS) resolve(p string) (bucket, key string, err error) {
+	p = strings.Trim(path.Clean("/"+p), "/")
+	if p == "" {
+		return "", "", nil
+	}
+	dir, key, _ := strings.Cut(p, "/")
+	fs.s.mu.Lock()
+	bucket, ok := fs.s.dirs[dir]
+	fs.s.mu.Unlock()
+	if !ok {
+		return "", "", os.ErrNotExist
+	}
+	return bucket, key, nil
+}
+
+func (fs *bucketFS) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	bucket, key, err := fs.resolve(r.Filepath)
+	if err != nil {
+		return nil, err
+	} else if key == "" {
+		return nil, errors.Newf("%s is a directory", r.Filepath)
+	}
+	obj, data, err := fs.s.store.Get("", bucket, key)
+	if err != nil {
+		return nil, err
+	} else if obj == nil {
+		return nil, os.ErrNotExist
+	}
+	return bytes.NewReader(data), nil
+}
+
+func (fs *bucketFS) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	bucket, key, err := fs.resolve(r.Filepath)
+	if err != nil {
+		return nil, err
+	} else if key == "" {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	w := &objectWriter{store: fs.s.store, bucket: bucket, key: key}
+	if !r.Pflags().Trunc {
+		// Keep the existing contents, for clients resuming uploads.
+		if obj, data, err := fs.s.store.Get("", bucket, key); err == nil && obj != nil {
+			w.buf = slices.Clone(data)
+		}
+	}
+	return w, nil
+}
+
+func (fs *bucketFS) Filecmd(r *sftp.Request) error {
+	bucket, key, err := fs.resolve(r.Filepath)
+	if err != nil {
+		return err
+	}
+
+	switch r.Method {
+	case "Setstat":
+		// Objects have no permissions or times to set.
+		return nil
+
+	case "Rename":
+		dstBucket, dstKey, err := fs.resolve(r.Target)
+		if err != nil {
+			return err
+		} else if key == "" || dstKey == "" {
+			return sftp.ErrSSHFxPermissionDenied
+		}
+		if ok, err := fs.s.store.Copy(bucket, key, dstBucket, dstKey); err != nil {
+			return err
+		} else if !ok {
+			// Directories can't be renamed, as it would mean copying all objects within them.
+			if fs.isDir(r.Context(), bucket, key) {
+				return sftp.ErrSSHFxOpUnsupported
+			}
+			return os.ErrNotExist
+		}
+		return fs.s.store.Delete(bucket, key)
+
+	case "Remove":
+		if key == "" {
+			return sftp.ErrSSHFxPermissionDenied
+		}
+		if obj, err := fs.s.store.GetMeta("", bucket, key); err != nil {
+			return err
+		} else if obj == nil {
+			return os.ErrNotExist
+		}
+		return fs.s.store.Delete(bucket, key)
+
+	case "Mkdir":
+		if key == "" {
+			return sftp.ErrSSHFxPermissionDenied
+		}
+		// Buckets have no directories, so remember it until files are written to it.
+		fs.s.mu.Lock()
+		fs.s.mkdir[bucket+"/"+key] = true
+		fs.s.mu.Unlock()
+		return nil
+
+	case "Rmdir":
+		if key == "" {
+			return sftp.ErrSSHFxPermissionDenied
+		}
+		entries, err := fs.list(r.Context(), bucket, key)
+		if err != nil {
+			return err
+		} else if len(entries) > 0 {
+			return errors.Newf("%s is not empty", r.Filepath)
+		}
+		fs.s.mu.Lock()
+		delete(fs.s.mkdir, bucket+"/"+key)
+		fs.s.mu.Unlock()
+		return nil
+	}
+	return sftp.ErrSSHFxOpUnsupported
+}
+
+func (fs *bucketFS) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	bucket, key, err := fs.resolve(r.Filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r.Method {
+	case "List":
+		if bucket == "" {
+			var entries listerAt
+			for dir := range fs.s.Directories() {
+				entries = append(entries, &fileInfo{name: dir, dir: true})
+			}
+			return entries, nil
+		}
+		if key != "" && !fs.isDir(r.Context(), bucket, key) {
+			return nil, os.ErrNotExist
+		}
+		return fs.list(r.Context(), bucket, key)
+
+	case "Stat":
+		name := path.Base(r.Filepath)
+		if key == "" || fs.isDir(r.Context(), bucket, key) {
+			return listerAt{&fileInfo{name: name, dir: true}}, nil
+		}
+		obj, err := fs.s.store.GetMeta("", bucket, key)
+		if err != nil {
+			return nil, err
+		} else if obj == nil {
+			return nil, os.ErrNotExist
+		}
+		return listerAt{objectInfo(name, obj)}, nil
+	}
+	return nil, sftp.ErrSSHFxOpUnsupported
+}
+
+// isDir reports whether the key is a directory: a prefix of other keys,
+// or a directory created by a client.
+func (fs *bucketFS) isDir(ctx context.Context, bucket, key string) bool {
+	fs.s.mu.Lock()
+	made := fs.s.mkdir[bucket+"/"+key]
+	fs.s.mu.Unlock()
+	if made {
+		return true
+	}
+	entries, err := fs.list(ctx, bucket, key)
+	return err == nil && len(entries) > 0
+}
+
+// list lists the entries of the directory dir within bucket.
+func (fs *bucketFS) list(ctx context.Context, bucket, dir string) (listerAt, error) {
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+
+	var entries listerAt
+	seen := make(map[string]bool)
+	err := fs.s.store.Walk(ctx, bucket, func(_ context.Context, filename string, fInfo os.FileInfo) error {
+		if fInfo != nil && fInfo.IsDir() {
+			return nil
+		}
+		filename = filepath.ToSlash(filename)
+		rest, ok := strings.CutPrefix(filename, prefix)
+		if !ok || rest == "" {
+			return nil
+		}
+		if name, _, isDir := strings.Cut(rest, "/"); isDir {
+			if !seen[name] {
+				seen[name] = true
+				entries = append(entries, &fileInfo{name: name, dir: true})
+			}
+			return nil
+		}
+		obj, err := fs.s.store.GetMeta("", bucket, filename)
+		if err != nil || obj == nil {
+			return err
+		}
+		entries = append(entries, objectInfo(rest, obj))
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	fs.s.mu.Lock()
+	defer fs.s.mu.Unlock()
+	for made := range fs.s.mkdir {
+		rest, ok := strings.CutPrefix(made, bucket+"/"+prefix)
+		if name, _, _ := strings.Cut(rest, "/"); ok && name != "" && !seen[name] {
+			seen[name] = true
+			entries = append(entries, &fileInfo{name: name, dir: true})
+		}
+	}
+	return entries, nil
+}
+
+// objectWriter buffers the contents written to an object,
+// and stores the object when it's closed.
+type objectWriter struct {
+	store       gcsemu.Store
+	bucket, key string
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (w *objectWriter) WriteAt(p []byte, off int64) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if end := int(off) + len(p); end > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, end-len(w.buf))...)
+	}
+	copy(w.buf[off:], p)
+	return len(p), nil
+}
+
+func (w *objectWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.store.Add(w.bucket, w.key, w.buf, &storage.Object{
+		ContentType: mime.TypeByExtension(path.Ext(w.key)),
+	})
+}
+
+// listerAt implements sftp.ListerAt for a slice of entries.
+type listerAt []os.FileInfo
+
+func (l listerAt) ListAt(entries []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(entries, l[offset:])
+	if n < len(entries) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// fileInfo implements os.FileInfo for objects and directories.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func objectInfo(name string, obj *storage.Object) *fileInfo {
+	modTime, _ := time.Parse(time.RFC3339Nano, obj.Updated)
+	return &fileInfo{name: name, size: int64(obj.Size), modTime: modTime}
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.dir }
+func (fi *fileInfo) Sys() any           { return nil }
+
+func (fi *fileInfo) Mode() os.FileMode {
+	if fi.dir {
+		return os.ModeDir | 0o755
+	}
+	return 0o644
+}
+
+// loadHostKey reads the SSH host key at path, generating it if it doesn't exist.
+func loadHostKey(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		block, err := ssh.MarshalPrivateKey(key, "encore sftp host key")
+		if err != nil {
+			return nil, err
+		}
+		data = pem.EncodeToMemory(block)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, err
+		} else if err := os.WriteFile(path, data, 0o600); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return ssh.ParsePrivateKey(data)
+}
+
+// readAuthorizedKeys reads the public keys in the authorized_keys file at path.
+func readAuthorizedKeys(path string) ([]ssh.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read sftp authorized keys")
+	}
+	var keys []ssh.PublicKey
+	for len(bytes.TrimSpace(data)) > 0 {
+		key, _, _, rest, err := ssh.ParseAuthorizedKey(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse %s", path)
+		}
+		keys = append(keys, key)
+		data = rest
+	}
+	return keys, nil
+}
@@ -0,0 +1,222 @@
+package objects
+
+import (
+	"io"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/pkg/sftp"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/emulators/storage/gcsemu"
+)
+
+// newTestFS returns a file system over file-backed buckets,
+// as used by the servers 'encore run' starts SFTP servers for.
+func newTestFS(t *testing.T) *bucketFS {
+	t.Helper()
+	s := &SFTPServer{
+		store: gcsemu.NewFileStore(t.TempDir()),
+		cfg:   &appfile.LocalSFTP{},
+		dirs:  map[string]string{"inbound": "uploads", "outbound": "reports"},
+		mkdir: make(map[string]bool),
+	}
+	return &bucketFS{s: s}
+}
+
+// put writes an object through the file system.
+func put(t *testing.T, fs *bucketFS, p, contents string) {
+	t.Helper()
+	w, err := fs.Filewrite(sftp.NewRequest("Put", p))
+	if err != nil {
+		t.Fatalf("write %s: %v", p, err)
+	}
+	if _, err := w.WriteAt([]byte(contents), 0); err != nil {
+		t.Fatalf("write %s: %v", p, err)
+	}
+	if err := w.(io.Closer).Close(); err != nil {
+		t.Fatalf("close %s: %v", p, err)
+	}
+}
+
+// get reads an object through the file system.
+func get(t *testing.T, fs *bucketFS, p string) string {
+	t.Helper()
+	r, err := fs.Fileread(sftp.NewRequest("Get", p))
+	if err != nil {
+		t.Fatalf("read %s: %v", p, err)
+	}
+	data, err := io.ReadAll(io.NewSectionReader(r, 0, 1<<20))
+	if err != nil {
+		t.Fatalf("read %s: %v", p, err)
+	}
+	return string(data)
+}
+
+// list returns the names of the entries of the directory p,
+// with a trailing slash for directories.
+func list(t *testing.T, fs *bucketFS, p string) []string {
+	t.Helper()
+	l, err := fs.Filelist(sftp.NewRequest("List", p))
+	if err != nil {
+		t.Fatalf("list %s: %v", p, err)
+	}
+	var names []string
+	for _, fi := range l.(listerAt) {
+		name := fi.Name()
+		if fi.IsDir() {
+			name += "/"
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestBucketFSResolve(t *testing.T) {
+	fs := newTestFS(t)
+	tests := []struct {
+		path        string
+		bucket, key string
+		err         error
+	}{
+		{path: "/", bucket: "", key: ""},
+		{path: "", bucket: "", key: ""},
+		{path: "/inbound", bucket: "uploads", key: ""},
+		{path: "/inbound/", bucket: "uploads", key: ""},
+		{path: "/inbound/a/b.csv", bucket: "uploads", key: "a/b.csv"},
+		{path: "inbound//a/./b.csv", bucket: "uploads", key: "a/b.csv"},
+		{path: "/outbound/../inbound/c.csv", bucket: "uploads", key: "c.csv"},
+		{path: "/../../inbound/c.csv", bucket: "uploads", key: "c.csv"},
+		{path: "/uploads/c.csv", err: os.ErrNotExist},
+	}
+	for _, tt := range tests {
+		bucket, key, err := fs.resolve(tt.path)
+		if err != tt.err || bucket != tt.bucket || key != tt.key {
+			t.Errorf("resolve(%q) = %q, %q, %v, want %q, %q, %v", tt.path, bucket, key, err, tt.bucket, tt.key, tt.err)
+		}
+	}
+}
+
+func TestBucketFSWrite(t *testing.T) {
+	fs := newTestFS(t)
+	put(t, fs, "/inbound/2024/statement.csv", "a,b\n")
+
+	obj, data, err := fs.s.store.Get("", "uploads", "2024/statement.csv")
+	if err != nil || obj == nil {
+		t.Fatalf("object not stored: %v", err)
+	}
+	if string(data) != "a,b\n" {
+		t.Errorf("object contents = %q, want %q", data, "a,b\n")
+	}
+	if got := get(t, fs, "/inbound/2024/statement.csv"); got != "a,b\n" {
+		t.Errorf("read %q, want %q", got, "a,b\n")
+	}
+
+	// Writing without truncating keeps the existing contents, for resumed uploads.
+	w, err := fs.Filewrite(sftp.NewRequest("Put", "/inbound/2024/statement.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.WriteAt([]byte("c,d\n"), 4)
+	if err := w.(io.Closer).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := get(t, fs, "/inbound/2024/statement.csv"); got != "a,b\nc,d\n" {
+		t.Errorf("read %q after resuming, want %q", got, "a,b\nc,d\n")
+	}
+
+	// Files can't be written to the root or as top-level directories.
+	for _, p := range []string{"/", "/inbound"} {
+		if _, err := fs.Filewrite(sftp.NewRequest("Put", p)); err != sftp.ErrSSHFxPermissionDenied {
+			t.Errorf("write %s: got err %v, want permission denied", p, err)
+		}
+	}
+}
+
+func TestBucketFSList(t *testing.T) {
+	fs := newTestFS(t)
+	put(t, fs, "/inbound/a.csv", "a")
+	put(t, fs, "/inbound/2024/b.csv", "b")
+	put(t, fs, "/inbound/2024/q1/c.csv", "c")
+	if err := fs.Filecmd(sftp.NewRequest("Mkdir", "/inbound/empty")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "/", want: []string{"inbound/", "outbound/"}},
+		{path: "/inbound", want: []string{"2024/", "a.csv", "empty/"}},
+		{path: "/inbound/2024", want: []string{"b.csv", "q1/"}},
+		{path: "/inbound/empty", want: nil},
+		{path: "/outbound", want: nil},
+	}
+	for _, tt := range tests {
+		if got := list(t, fs, tt.path); !slices.Equal(got, tt.want) {
+			t.Errorf("list %s = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	if _, err := fs.Filelist(sftp.NewRequest("List", "/inbound/missing")); err != os.ErrNotExist {
+		t.Errorf("list missing directory: got err %v, want not exist", err)
+	}
+
+	stat := func(p string) os.FileInfo {
+		t.Helper()
+		l, err := fs.Filelist(sftp.NewRequest("Stat", p))
+		if err != nil {
+			t.Fatalf("stat %s: %v", p, err)
+		}
+		return l.(listerAt)[0]
+	}
+	if fi := stat("/inbound/2024"); !fi.IsDir() {
+		t.Errorf("stat /inbound/2024: not a directory")
+	}
+	if fi := stat("/inbound/a.csv"); fi.IsDir() || fi.Size() != 1 {
+		t.Errorf("stat /inbound/a.csv: dir %v, size %d, want a file of size 1", fi.IsDir(), fi.Size())
+	}
+}
+
+func TestBucketFSRename(t *testing.T) {
+	fs := newTestFS(t)
+	put(t, fs, "/inbound/upload.tmp", "data")
+	put(t, fs, "/inbound/dir/file.csv", "x")
+
+	rename := func(from, to string) error {
+		r := sftp.NewRequest("Rename", from)
+		r.Target = to
+		return fs.Filecmd(r)
+	}
+
+	// Clients often upload to a temporary name and rename the file afterwards.
+	if err := rename("/inbound/upload.tmp", "/inbound/2024/statement.csv"); err != nil {
+		t.Fatal(err)
+	}
+	if got := get(t, fs, "/inbound/2024/statement.csv"); got != "data" {
+		t.Errorf("read %q after rename, want %q", got, "data")
+	}
+	if obj, _, _ := fs.s.store.Get("", "uploads", "upload.tmp"); obj != nil {
+		t.Error("source of rename not deleted")
+	}
+
+	// Files can be renamed across buckets.
+	if err := rename("/inbound/2024/statement.csv", "/outbound/statement.csv"); err != nil {
+		t.Fatal(err)
+	}
+	if got := get(t, fs, "/outbound/statement.csv"); got != "data" {
+		t.Errorf("read %q after rename across buckets, want %q", got, "data")
+	}
+
+	if err := rename("/inbound/missing.csv", "/inbound/other.csv"); err != os.ErrNotExist {
+		t.Errorf("rename missing file: got err %v, want not exist", err)
+	}
+	if err := rename("/inbound/dir", "/inbound/other"); err != sftp.ErrSSHFxOpUnsupported {
+		t.Errorf("rename directory: got err %v, want unsupported", err)
+	}
+	if err := rename("/inbound", "/outbound/inbound"); err != sftp.ErrSSHFxPermissionDenied {
+		t.Errorf("rename top-level directory: got err %v, want permission denied", err)
+	}
+}
@@ -0,0 +1,117 @@
+package daemon
+
+import (
+	"bytes"
+	"compress/gzip"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+const (
+	// outputBatchInterval is how long output is batched before being sent.
+	outputBatchInterval = 50 * time.Millisecond
+
+	// maxOutputBatchSize is the number of bytes of output that causes
+	// a batch to be sent right away.
+	maxOutputBatchSize = 64 * 1024
+)
+
+// batchedStream is a command stream that batches output frames into
+// CommandOutputBatch messages, optionally compressing them.
+// Other messages are sent as-is, after any pending output.
+type batchedStream struct {
+	commandServerStream
+	compression daemonpb.OutputCompression
+
+	mu      sync.Mutex
+	frames  []*daemonpb.CommandOutput
+	size    int
+	timer   *time.Timer // flushes the batch; nil if no batch is pending
+	sendErr error       // error from sending a batch from the timer
+}
+
+func newBatchedStream(stream commandServerStream, compression daemonpb.OutputCompression) *batchedStream {
+	return &batchedStream{commandServerStream: stream, compression: compression}
+}
+
+func (s *batchedStream) Send(msg *daemonpb.CommandMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out, ok := msg.Msg.(*daemonpb.CommandMessage_Output)
+	if !ok {
+		if err := s.flush(); err != nil {
+			return err
+		}
+		return s.commandServerStream.Send(msg)
+	}
+
+	if err := s.sendErr; err != nil {
+		s.sendErr = nil
+		return err
+	}
+	// The output is owned by the caller, which may reuse it once Send returns,
+	// so copy it before buffering it.
+	frame := proto.Clone(out.Output).(*daemonpb.CommandOutput)
+	s.frames = append(s.frames, frame)
+	s.size += len(frame.Stdout) + len(frame.Stderr)
+	if s.size >= maxOutputBatchSize {
+		return s.flush()
+	}
+	if s.timer == nil {
+		s.timer = time.AfterFunc(outputBatchInterval, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if err := s.flush(); err != nil {
+				s.sendErr = err
+			}
+		})
+	}
+	return nil
+}
+
+// Close sends any pending output.
+func (s *batchedStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flush()
+}
+
+// flush sends the pending output, if any. It must be called with s.mu held.
+func (s *batchedStream) flush() error {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if len(s.frames) == 0 {
+		return nil
+	}
+	batch := &daemonpb.CommandOutputBatch{Frames: s.frames}
+	s.frames, s.size = nil, 0
+
+	if s.compression == daemonpb.OutputCompression_OUTPUT_COMPRESSION_GZIP {
+		data, err := proto.Marshal(batch)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return err
+		} else if err := zw.Close(); err != nil {
+			return err
+		}
+		batch = &daemonpb.CommandOutputBatch{
+			Compression: s.compression,
+			Compressed:  buf.Bytes(),
+		}
+	}
+
+	return s.commandServerStream.Send(&daemonpb.CommandMessage{
+		Msg: &daemonpb.CommandMessage_OutputBatch{OutputBatch: batch},
+	})
+}
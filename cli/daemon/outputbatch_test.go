@@ -0,0 +1,216 @@
+package daemon
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// syncStream is a testStream that can be sent to concurrently,
+// for the batches sent by the flush timer.
+type syncStream struct {
+	testStream
+	mu   sync.Mutex
+	sent chan struct{} // receives a value for every message sent
+}
+
+func newSyncStream() *syncStream {
+	return &syncStream{testStream: *newTestStream(), sent: make(chan struct{}, 100)}
+}
+
+func (s *syncStream) Send(msg *daemonpb.CommandMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msgs = append(s.msgs, msg)
+	s.sent <- struct{}{}
+	return nil
+}
+
+func (s *syncStream) messages() []*daemonpb.CommandMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*daemonpb.CommandMessage(nil), s.msgs...)
+}
+
+// decodeBatch returns the frames of batch, decompressing them if necessary.
+func decodeBatch(batch *daemonpb.CommandOutputBatch) ([]*daemonpb.CommandOutput, error) {
+	if batch.Compression == daemonpb.OutputCompression_OUTPUT_COMPRESSION_NONE {
+		return batch.Frames, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(batch.Compressed))
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	var decoded daemonpb.CommandOutputBatch
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded.Frames, nil
+}
+
+// batchOutput returns the stdout of the frames of the batch in msg.
+func batchOutput(t *testing.T, msg *daemonpb.CommandMessage) []string {
+	t.Helper()
+	batch := msg.GetOutputBatch()
+	if batch == nil {
+		t.Fatalf("got %T, want an output batch", msg.Msg)
+	}
+	frames, err := decodeBatch(batch)
+	if err != nil {
+		t.Fatalf("decode batch: %v", err)
+	}
+	var out []string
+	for _, f := range frames {
+		out = append(out, string(f.Stdout))
+	}
+	return out
+}
+
+func TestBatchedStreamFlushesBeforeOtherMessages(t *testing.T) {
+	stream := newSyncStream()
+	s := newBatchedStream(stream, daemonpb.OutputCompression_OUTPUT_COMPRESSION_NONE)
+
+	_ = s.Send(output("a"))
+	_ = s.Send(output("b"))
+	if n := len(stream.messages()); n != 0 {
+		t.Fatalf("sent %d messages before flushing, want 0", n)
+	}
+	exit := &daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Exit{Exit: &daemonpb.CommandExit{Code: 1}}}
+	if err := s.Send(exit); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := stream.messages()
+	if len(msgs) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(msgs))
+	}
+	if got, want := fmt.Sprint(batchOutput(t, msgs[0])), "[a b]"; got != want {
+		t.Errorf("batch = %s, want %s", got, want)
+	}
+	if msgs[1] != exit {
+		t.Errorf("second message = %v, want the exit message", msgs[1])
+	}
+}
+
+func TestBatchedStreamFlushTimer(t *testing.T) {
+	stream := newSyncStream()
+	s := newBatchedStream(stream, daemonpb.OutputCompression_OUTPUT_COMPRESSION_NONE)
+
+	_ = s.Send(output("a"))
+	_ = s.Send(output("b"))
+	select {
+	case <-stream.sent:
+	case <-time.After(10 * outputBatchInterval):
+		t.Fatal("batch not flushed by the timer")
+	}
+
+	msgs := stream.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(msgs))
+	}
+	if got, want := fmt.Sprint(batchOutput(t, msgs[0])), "[a b]"; got != want {
+		t.Errorf("batch = %s, want %s", got, want)
+	}
+
+	// Nothing is left to flush.
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(stream.messages()); n != 1 {
+		t.Errorf("sent %d messages after closing, want 1", n)
+	}
+}
+
+func TestBatchedStreamMaxSize(t *testing.T) {
+	stream := newSyncStream()
+	s := newBatchedStream(stream, daemonpb.OutputCompression_OUTPUT_COMPRESSION_NONE)
+
+	_ = s.Send(output("a"))
+	_ = s.Send(output(string(bytes.Repeat([]byte("x"), maxOutputBatchSize))))
+	msgs := stream.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("sent %d messages, want a full batch sent right away", len(msgs))
+	}
+	if got := batchOutput(t, msgs[0]); len(got) != 2 {
+		t.Errorf("batch has %d frames, want 2", len(got))
+	}
+}
+
+func TestBatchedStreamCopiesOutput(t *testing.T) {
+	stream := newSyncStream()
+	s := newBatchedStream(stream, daemonpb.OutputCompression_OUTPUT_COMPRESSION_NONE)
+
+	// Send output from a buffer that is reused between writes,
+	// like the run's log writer does.
+	var buf bytes.Buffer
+	for _, line := range []string{"first\n", "second\n"} {
+		buf.WriteString(line)
+		out := &daemonpb.CommandOutput{Stdout: buf.Bytes()}
+		if err := s.Send(&daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Output{Output: out}}); err != nil {
+			t.Fatal(err)
+		}
+		buf.Reset()
+	}
+	buf.WriteString("overwritten\n")
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := stream.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(msgs))
+	}
+	if got, want := fmt.Sprintf("%q", batchOutput(t, msgs[0])), `["first\n" "second\n"]`; got != want {
+		t.Errorf("batch = %s, want %s", got, want)
+	}
+}
+
+func TestBatchedStreamGzip(t *testing.T) {
+	stream := newSyncStream()
+	s := newBatchedStream(stream, daemonpb.OutputCompression_OUTPUT_COMPRESSION_GZIP)
+
+	want := []*daemonpb.CommandOutput{
+		{Stdout: []byte("hello\n")},
+		{Stderr: []byte("warning\n")},
+		{Stdout: bytes.Repeat([]byte("compressible "), 100)},
+	}
+	for _, f := range want {
+		_ = s.Send(&daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Output{Output: f}})
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := stream.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(msgs))
+	}
+	batch := msgs[0].GetOutputBatch()
+	if batch.Compression != daemonpb.OutputCompression_OUTPUT_COMPRESSION_GZIP || len(batch.Frames) != 0 {
+		t.Fatalf("batch is not compressed: %v", batch)
+	}
+	got, err := decodeBatch(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d frames, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !bytes.Equal(got[i].Stdout, want[i].Stdout) || !bytes.Equal(got[i].Stderr, want[i].Stderr) {
+			t.Errorf("frame %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
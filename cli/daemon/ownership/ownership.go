@@ -0,0 +1,197 @@
+// Package ownership maps the services of an app, and the files and stack traces within them,
+// to the teams owning them, so build errors and crashes can be routed to the right team.
+package ownership
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"encr.dev/pkg/errinsrc"
+	"encr.dev/pkg/errlist"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Index indexes the owners of the services of an app.
+type Index struct {
+	appRoot string
+	svcs    []*meta.Service // sorted by descending rel path length, so the innermost service matches first
+	pathRe  *regexp.Regexp  // matches file paths within the app root
+}
+
+// New returns an index of the service owners declared in the metadata of the app at appRoot.
+func New(appRoot string, md *meta.Data) *Index {
+	svcs := make([]*meta.Service, len(md.Svcs))
+	copy(svcs, md.Svcs)
+	depth := func(svc *meta.Service) int {
+		if svc.RelPath == "." {
+			return 0
+		}
+		return len(svc.RelPath)
+	}
+	sort.SliceStable(svcs, func(i, j int) bool {
+		return depth(svcs[i]) > depth(svcs[j])
+	})
+
+	root := filepath.ToSlash(filepath.Clean(appRoot))
+	return &Index{
+		appRoot: appRoot,
+		svcs:    svcs,
+		pathRe:  regexp.MustCompile(regexp.QuoteMeta(root) + `/([^\s:()'"]+)`),
+	}
+}
+
+// HasOwners reports whether any service of the app has an owner.
+func (idx *Index) HasOwners() bool {
+	for _, svc := range idx.svcs {
+		if svc.Owner != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Owner returns the owner of the given service, or nil if it has none.
+func (idx *Index) Owner(service string) *meta.ServiceOwner {
+	for _, svc := range idx.svcs {
+		if svc.Name == service {
+			return svc.Owner
+		}
+	}
+	return nil
+}
+
+// ServiceForFile returns the name of the service containing the file at path,
+// which is either absolute or relative to the app root.
+func (idx *Index) ServiceForFile(path string) (service string, ok bool) {
+	if filepath.IsAbs(path) {
+		rel, err := filepath.Rel(idx.appRoot, path)
+		if err != nil {
+			return "", false
+		}
+		path = rel
+	}
+	path = filepath.ToSlash(path)
+	if path == ".." || strings.HasPrefix(path, "../") {
+		return "", false
+	}
+
+	for _, svc := range idx.svcs {
+		if svc.RelPath == "." || path == svc.RelPath || strings.HasPrefix(path, svc.RelPath+"/") {
+			return svc.Name, true
+		}
+	}
+	return "", false
+}
+
+// ServiceForStack returns the name of the service a crash happened in,
+// given the output of the crashed process. It reports the service of the
+// innermost frame within the app of the last panic's stack trace,
+// or of the first file within the app mentioned if there is no panic.
+func (idx *Index) ServiceForStack(output []byte) (service string, ok bool) {
+	s := filepath.ToSlash(string(output))
+	if i := strings.LastIndex(s, "panic:"); i >= 0 {
+		s = s[i:]
+	}
+	for _, m := range idx.pathRe.FindAllStringSubmatch(s, -1) {
+		if svc, ok := idx.ServiceForFile(filepath.FromSlash(m[1])); ok {
+			return svc, true
+		}
+	}
+	return "", false
+}
+
+// Describe describes the team owning a service and how to reach it,
+// like "team payments (#payments, on-call: payments-primary)".
+func Describe(o *meta.ServiceOwner) string {
+	var contacts []string
+	if o.SlackChannel != "" {
+		contacts = append(contacts, o.SlackChannel)
+	}
+	if o.OnCall != "" {
+		contacts = append(contacts, "on-call: "+o.OnCall)
+	}
+	if len(contacts) == 0 {
+		return "team " + o.Team
+	}
+	return fmt.Sprintf("team %s (%s)", o.Team, strings.Join(contacts, ", "))
+}
+
+// BuildErrorSummary summarizes which teams own the code the given build errors are in.
+// It returns the empty string if no service of the app has an owner.
+func (idx *Index) BuildErrorSummary(errs *errlist.List) string {
+	if errs == nil || len(errs.List) == 0 || !idx.HasOwners() {
+		return ""
+	}
+
+	type group struct {
+		owner    *meta.ServiceOwner
+		services []string
+		errors   int
+	}
+	var (
+		groups  = make(map[string]*group) // keyed by team
+		teams   []string
+		unowned int
+	)
+	for _, e := range errs.List {
+		owner, service := idx.errorOwner(e)
+		if owner == nil {
+			unowned++
+			continue
+		}
+		g, ok := groups[owner.Team]
+		if !ok {
+			g = &group{owner: owner}
+			groups[owner.Team] = g
+			teams = append(teams, owner.Team)
+		}
+		g.errors++
+		if !slices.Contains(g.services, service) {
+			g.services = append(g.services, service)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("Build errors by owning team:\n")
+	sort.Strings(teams)
+	for _, team := range teams {
+		g := groups[team]
+		fmt.Fprintf(&b, "  %s: %s in %s\n", Describe(g.owner), pluralErrors(g.errors), strings.Join(g.services, ", "))
+	}
+	if unowned > 0 {
+		fmt.Fprintf(&b, "  no owner: %s\n", pluralErrors(unowned))
+	}
+	return b.String()
+}
+
+// errorOwner returns the owner of the service the first located
+// file of an error is in, and the name of the service.
+func (idx *Index) errorOwner(e *errinsrc.ErrInSrc) (*meta.ServiceOwner, string) {
+	for _, loc := range e.Params.Locations {
+		if loc.File == nil {
+			continue
+		}
+		path := loc.File.FullPath
+		if path == "" {
+			path = loc.File.RelPath
+		}
+		if svc, ok := idx.ServiceForFile(path); ok {
+			if o := idx.Owner(svc); o != nil {
+				return o, svc
+			}
+			return nil, svc
+		}
+	}
+	return nil, ""
+}
+
+func pluralErrors(n int) string {
+	if n == 1 {
+		return "1 error"
+	}
+	return fmt.Sprintf("%d errors", n)
+}
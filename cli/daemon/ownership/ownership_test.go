@@ -0,0 +1,102 @@
+package ownership
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/errinsrc"
+	"encr.dev/pkg/errinsrc/srcerrors"
+	"encr.dev/pkg/errlist"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func testIndex() *Index {
+	return New("/app", &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "billing", RelPath: "billing", Owner: &meta.ServiceOwner{Team: "payments", SlackChannel: "#payments", OnCall: "payments-primary"}},
+			{Name: "invoices", RelPath: "billing/invoices", Owner: &meta.ServiceOwner{Team: "finance"}},
+			{Name: "users", RelPath: "users"},
+		},
+	})
+}
+
+func TestServiceForFile(t *testing.T) {
+	c := qt.New(t)
+	idx := testIndex()
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/app/billing/billing.go", "billing"},
+		{"billing/internal/tax/tax.go", "billing"},
+		{"/app/billing/invoices/invoices.ts", "invoices"},
+		{"/app/billingx/x.go", ""},
+		{"/app/users/users.go", "users"},
+		{"/other/billing/billing.go", ""},
+	}
+	for _, test := range tests {
+		got, _ := idx.ServiceForFile(filepath.FromSlash(test.path))
+		c.Check(got, qt.Equals, test.want, qt.Commentf("%s", test.path))
+	}
+}
+
+func TestServiceForStack(t *testing.T) {
+	c := qt.New(t)
+	idx := testIndex()
+
+	got, ok := idx.ServiceForStack([]byte(`request to /app/users/users.go failed
+panic: runtime error: invalid memory address or nil pointer dereference
+
+goroutine 42 [running]:
+encore.dev/appruntime/apisdk/api.(*Handler).Handle(...)
+	/encore/runtimes/go/appruntime/apisdk/api/handler.go:120 +0x1a
+encore.app/billing/invoices.Create(...)
+	/app/billing/invoices/invoices.go:31 +0x3b
+encore.app/billing.Charge(...)
+	/app/billing/billing.go:12 +0x44
+`))
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(got, qt.Equals, "invoices")
+
+	got, ok = idx.ServiceForStack([]byte("TypeError: x is undefined\n    at charge (/app/billing/charge.ts:10:5)\n"))
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(got, qt.Equals, "billing")
+
+	_, ok = idx.ServiceForStack([]byte("fatal error: out of memory\n"))
+	c.Assert(ok, qt.IsFalse)
+}
+
+func TestDescribe(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(Describe(&meta.ServiceOwner{Team: "finance"}), qt.Equals, "team finance")
+	c.Assert(Describe(&meta.ServiceOwner{Team: "payments", SlackChannel: "#payments", OnCall: "payments-primary"}),
+		qt.Equals, "team payments (#payments, on-call: payments-primary)")
+}
+
+func TestBuildErrorSummary(t *testing.T) {
+	c := qt.New(t)
+	idx := testIndex()
+
+	readFile := func(string) ([]byte, error) { return []byte("package foo\n"), nil }
+	errAt := func(path string) *errinsrc.ErrInSrc {
+		pos := token.Position{Filename: filepath.FromSlash(path), Line: 1, Column: 1}
+		return srcerrors.GenericError(pos, "syntax error", readFile)
+	}
+	errs := &errlist.List{List: []*errinsrc.ErrInSrc{
+		errAt("/app/billing/billing.go"),
+		errAt("/app/billing/tax.go"),
+		errAt("/app/billing/invoices/invoices.go"),
+		errAt("/app/users/users.go"),
+	}}
+	c.Assert(idx.BuildErrorSummary(errs), qt.Equals, `Build errors by owning team:
+  team finance: 1 error in invoices
+  team payments (#payments, on-call: payments-primary): 2 errors in billing
+  no owner: 1 error
+`)
+
+	unowned := New("/app", &meta.Data{Svcs: []*meta.Service{{Name: "users", RelPath: "users"}}})
+	c.Assert(unowned.BuildErrorSummary(errs), qt.Equals, "")
+}
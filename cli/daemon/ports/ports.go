@@ -0,0 +1,218 @@
+// Package ports coordinates the TCP ports the daemon and the apps it runs listen on.
+//
+// Subsystems listen through a [Registry] so they don't pick ports another
+// subsystem is already listening on, and so each app gets the same ports
+// across runs and daemon restarts instead of whichever port happened to be free.
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// autoRange is the number of ports after the requested one
+// Listen tries before letting the OS pick a port.
+const autoRange = 100
+
+// assignmentTTL is how long a port stays assigned to a key that doesn't listen on it.
+const assignmentTTL = 30 * 24 * time.Hour
+
+// Key identifies what listens on a port.
+type Key struct {
+	AppID     string // platform_id or local_id, empty for daemon components
+	Component string // such as "app", "dbproxy" or "smtp"
+}
+
+func (k Key) String() string {
+	if k.AppID == "" {
+		return k.Component
+	}
+	return k.AppID + "/" + k.Component
+}
+
+// Registry keeps track of the ports that are being listened on,
+// and of the ports assigned to each key the last time it listened.
+//
+// A nil *Registry listens without coordinating with other subsystems.
+type Registry struct {
+	db *sql.DB
+
+	mu   sync.Mutex
+	held map[int]Key // port -> key listening on it
+}
+
+// NewRegistry returns a Registry persisting port assignments in db.
+func NewRegistry(db *sql.DB) *Registry {
+	return &Registry{db: db, held: make(map[int]Key)}
+}
+
+// Reserve marks port as listened on by key, for listeners
+// the registry doesn't create itself, like the daemon's own.
+func (r *Registry) Reserve(key Key, port int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.held[port] = key
+}
+
+// Owner returns the key listening on port, if any.
+func (r *Registry) Owner(port int) (Key, bool) {
+	if r == nil {
+		return Key{}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key, ok := r.held[port]
+	return key, ok
+}
+
+// Listen listens for TCP connections on host at port for key.
+//
+// With auto, if port is 0 or isn't available Listen tries the port assigned
+// to key the last time it asked for port, and then the ports after port,
+// before letting the OS pick a port. Ports other keys listen on are skipped,
+// and ports assigned to other keys are tried after the unassigned ones. Otherwise, Listen only listens on port.
+//
+// The port is held for key until the returned listener is closed.
+func (r *Registry) Listen(ctx context.Context, key Key, host string, port int, auto bool) (net.Listener, error) {
+	var lc net.ListenConfig
+	if r == nil {
+		return lc.Listen(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	}
+
+	candidates := r.candidates(ctx, key, port, auto)
+	var lastErr error
+	for _, p := range candidates {
+		ln, err := lc.Listen(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(p)))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		// Ports picked by the OS without auto aren't meant to be stable.
+		persist := auto || port != 0
+		return r.hold(ctx, key, port, ln, persist), nil
+	}
+	return nil, lastErr
+}
+
+// candidates returns the ports for key to try listening on, in order.
+func (r *Registry) candidates(ctx context.Context, key Key, port int, auto bool) []int {
+	if !auto {
+		return []int{port}
+	}
+
+	assigned, others, err := r.assignments(ctx, key, port)
+	if err != nil {
+		// Assignments only make ports stable, so carry on without them.
+		log.Error().Err(err).Str("key", key.String()).Msg("unable to load port assignments")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	free := func(p int) bool {
+		owner, ok := r.held[p]
+		return !ok || owner == key
+	}
+
+	var ports []int
+	if port != 0 && free(port) {
+		ports = append(ports, port)
+	}
+	if assigned != 0 && assigned != port && free(assigned) {
+		ports = append(ports, assigned)
+	}
+	if port != 0 {
+		// Try the ports assigned to other keys last, so they're likely to keep them.
+		var reserved []int
+		for p := port + 1; p <= port+autoRange && p <= 65535; p++ {
+			switch {
+			case p == assigned || !free(p):
+			case others[p]:
+				reserved = append(reserved, p)
+			default:
+				ports = append(ports, p)
+			}
+		}
+		ports = append(ports, reserved...)
+	}
+	return append(ports, 0)
+}
+
+// assignments returns the port assigned to key when it asked for requested,
+// and the ports assigned to other keys.
+func (r *Registry) assignments(ctx context.Context, key Key, requested int) (assigned int, others map[int]bool, err error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT app_id, component, requested, port
+		FROM port_assignment
+		WHERE assigned_at > ?
+	`, time.Now().Add(-assignmentTTL))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "list port assignments")
+	}
+	defer rows.Close()
+
+	others = make(map[int]bool)
+	for rows.Next() {
+		var (
+			k      Key
+			req, p int
+		)
+		if err := rows.Scan(&k.AppID, &k.Component, &req, &p); err != nil {
+			return 0, nil, errors.Wrap(err, "scan port assignment")
+		}
+		if k != key {
+			others[p] = true
+		} else if req == requested {
+			assigned = p
+		}
+	}
+	return assigned, others, errors.Wrap(rows.Err(), "list port assignments")
+}
+
+// hold records ln's port as listened on by key until ln is closed,
+// and if persist is true as assigned to key for the requested port.
+func (r *Registry) hold(ctx context.Context, key Key, requested int, ln net.Listener, persist bool) net.Listener {
+	port := ln.Addr().(*net.TCPAddr).Port
+	r.mu.Lock()
+	r.held[port] = key
+	r.mu.Unlock()
+
+	if persist {
+		_, err := r.db.ExecContext(ctx, `
+			INSERT OR REPLACE INTO port_assignment (app_id, component, requested, port, assigned_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, key.AppID, key.Component, requested, port, time.Now())
+		if err != nil {
+			log.Error().Err(err).Str("key", key.String()).Int("port", port).Msg("unable to record port assignment")
+		}
+	}
+
+	return &listener{Listener: ln, release: func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.held[port] == key {
+			delete(r.held, port)
+		}
+	}}
+}
+
+// listener releases its port in the registry when closed.
+type listener struct {
+	net.Listener
+	once    sync.Once
+	release func()
+}
+
+func (ln *listener) Close() error {
+	ln.once.Do(ln.release)
+	return ln.Listener.Close()
+}
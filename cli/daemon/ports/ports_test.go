@@ -0,0 +1,145 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"os"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	schema, err := os.ReadFile("../../cmd/encore/daemon/migrations/9_port_assignments.up.sql")
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	if _, err := db.Exec(string(schema)); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return db
+}
+
+// freePort returns a port the OS considers free.
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func listen(t *testing.T, r *Registry, key Key, port int, auto bool) (net.Listener, int) {
+	t.Helper()
+	ln, err := r.Listen(context.Background(), key, "127.0.0.1", port, auto)
+	if err != nil {
+		t.Fatalf("listen for %s: %v", key, err)
+	}
+	return ln, ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestListenSkipsHeldPorts(t *testing.T) {
+	r := NewRegistry(newTestDB(t))
+	port := freePort(t)
+	r.Reserve(Key{Component: "dashboard"}, port)
+
+	ln, got := listen(t, r, Key{AppID: "a", Component: "app"}, port, true)
+	defer ln.Close()
+	if got == port {
+		t.Fatalf("listened on port %d reserved by the dashboard", port)
+	}
+	if owner, ok := r.Owner(got); !ok || owner.AppID != "a" {
+		t.Fatalf("Owner(%d) = %v, %v, want app a", got, owner, ok)
+	}
+
+	ln.Close()
+	if _, ok := r.Owner(got); ok {
+		t.Fatalf("port %d still held after closing the listener", got)
+	}
+}
+
+func TestListenStableAcrossRestarts(t *testing.T) {
+	db := newTestDB(t)
+	a, b, c := Key{AppID: "a", Component: "app"}, Key{AppID: "b", Component: "app"}, Key{AppID: "c", Component: "app"}
+	port := freePort(t)
+
+	// a gets the requested port, and b and c the next available ones.
+	r := NewRegistry(db)
+	lnA, portA := listen(t, r, a, port, true)
+	lnB, portB := listen(t, r, b, port, true)
+	lnC, portC := listen(t, r, c, port, true)
+	if portA != port || portB == port || portC == port {
+		t.Fatalf("got ports %d, %d and %d, want a on %d", portA, portB, portC, port)
+	}
+	lnA.Close()
+	lnB.Close()
+	lnC.Close()
+
+	// After a restart c keeps its port while b isn't running,
+	// and b gets its port back once it runs again.
+	r = NewRegistry(db)
+	lnA, got := listen(t, r, a, port, true)
+	defer lnA.Close()
+	if got != portA {
+		t.Fatalf("a got port %d after restart, want %d", got, portA)
+	}
+	lnC, got = listen(t, r, c, port, true)
+	defer lnC.Close()
+	if got != portC {
+		t.Fatalf("c got port %d after restart, want %d", got, portC)
+	}
+	lnB, got = listen(t, r, b, port, true)
+	defer lnB.Close()
+	if got != portB {
+		t.Fatalf("b got port %d after restart, want %d", got, portB)
+	}
+}
+
+func TestListenRequestedPortAssignedToOtherKey(t *testing.T) {
+	db := newTestDB(t)
+	a, b := Key{AppID: "a", Component: "app"}, Key{AppID: "b", Component: "app"}
+	port := freePort(t)
+
+	ln, _ := listen(t, NewRegistry(db), a, port, true)
+	ln.Close()
+
+	// The requested port is assigned to a, but free while a isn't running.
+	ln, got := listen(t, NewRegistry(db), b, port, true)
+	defer ln.Close()
+	if got != port {
+		t.Fatalf("b got port %d, want the free requested port %d", got, port)
+	}
+}
+
+func TestListenAnyPortReusesAssignment(t *testing.T) {
+	db := newTestDB(t)
+	key := Key{AppID: "a", Component: "dbproxy"}
+
+	ln, first := listen(t, NewRegistry(db), key, 0, true)
+	ln.Close()
+	ln, got := listen(t, NewRegistry(db), key, 0, true)
+	defer ln.Close()
+	if got != first {
+		t.Fatalf("got port %d, want the previously assigned port %d", got, first)
+	}
+}
+
+func TestListenExplicitPort(t *testing.T) {
+	r := NewRegistry(newTestDB(t))
+	port := freePort(t)
+
+	ln, _ := listen(t, r, Key{AppID: "a", Component: "app"}, port, false)
+	defer ln.Close()
+	if _, err := r.Listen(context.Background(), Key{AppID: "b", Component: "app"}, "127.0.0.1", port, false); err == nil {
+		t.Fatalf("listening on port %d in use succeeded without auto", port)
+	}
+}
@@ -0,0 +1,56 @@
+package daemon
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/internal/bindaddr"
+)
+
+// serveProfiles serves the pprof endpoints of the processes of r on
+// addresses of their own, keyed by process name, for the duration of the run.
+//
+// The processes serve the endpoints on new ports whenever the app reloads,
+// so the endpoints are proxied to keep the addresses stable.
+func serveProfiles(r *run.Run) (addrs map[string]string, stop func(), err error) {
+	var servers []*http.Server
+	stop = func() {
+		for _, srv := range servers {
+			_ = srv.Close()
+		}
+	}
+
+	addrs = make(map[string]string)
+	for name := range r.ProcGroup().ProfileAddrs() {
+		ln, err := bindaddr.ListenLoopback(0)
+		if err != nil {
+			stop()
+			return nil, nil, err
+		}
+		srv := &http.Server{Handler: profileProxy(r, name)}
+		go func() { _ = srv.Serve(ln) }()
+		servers = append(servers, srv)
+		addrs[name] = ln.Addr().String()
+	}
+	return addrs, stop, nil
+}
+
+// profileProxy proxies requests to the pprof endpoints of the
+// current process with the given name.
+func profileProxy(r *run.Run, name string) http.Handler {
+	return &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			var addr string
+			if pg := r.ProcGroup(); pg != nil {
+				addr = pg.ProfileAddrs()[name]
+			}
+			pr.SetURL(&url.URL{Scheme: "http", Host: addr})
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			http.Error(w, fmt.Sprintf("process %s is not running: %v", name, err), http.StatusBadGateway)
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/export"
+	"encr.dev/pkg/builder"
+	"encr.dev/pkg/builder/builderimpl"
+	"encr.dev/pkg/fns"
+	"encr.dev/pkg/provenance"
+	"encr.dev/pkg/vcs"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// BuildProvenance reports the provenance metadata of building the app in its current state.
+func (s *Server) BuildProvenance(ctx context.Context, req *daemonpb.BuildProvenanceRequest) (*daemonpb.BuildProvenanceResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to query app info: %v", err)
+	}
+	expSet, err := app.Experiments(req.Environ)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse app experiments: %v", err)
+	}
+
+	bld := builderimpl.Resolve(app.Lang(), expSet)
+	defer fns.CloseIgnore(bld)
+	prepareResult, err := bld.Prepare(ctx, builder.PrepareParams{
+		Build:      builder.DefaultBuildInfo(),
+		App:        app,
+		WorkingDir: ".",
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to prepare app: %v", err)
+	}
+	parse, err := bld.Parse(ctx, builder.ParseParams{
+		Build:       builder.DefaultBuildInfo(),
+		App:         app,
+		Experiments: expSet,
+		WorkingDir:  ".",
+		ParseTests:  false,
+		Prepare:     prepareResult,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse app metadata: %v", err)
+	}
+	if err := app.CacheMetadata(parse.Meta); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cache app metadata: %v", err)
+	}
+
+	rev := vcs.GetRevision(app.Root())
+	info := export.BuildProvenance(app, parse.Meta, rev)
+	resp := &daemonpb.BuildProvenanceResponse{
+		EncoreCompiler:     info.EncoreCompiler,
+		Revision:           info.AppCommit.Revision,
+		UncommittedChanges: info.AppCommit.Uncommitted,
+		Toolchains:         info.Toolchains,
+		InfraHash:          info.InfraHash,
+	}
+	if t, ok := provenance.BuildTime(req.Environ, rev); ok {
+		resp.BuildTime = t.Format(time.RFC3339)
+	}
+	return resp, nil
+}
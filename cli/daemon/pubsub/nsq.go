@@ -10,6 +10,8 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"go4.org/syncutil"
+
+	"encr.dev/cli/internal/bindaddr"
 )
 
 type NSQDaemon struct {
@@ -58,9 +60,9 @@ func (n *NSQDaemon) Start() error {
 
 			// Take the default address options and scope down to localhost (to prevent firewall warnings / permission requests)
 			// then set the port to 0 to allow any port to be used which is free
-			n.Opts.TCPAddress = "127.0.0.1:0"
-			n.Opts.HTTPAddress = "127.0.0.1:0"
-			n.Opts.HTTPSAddress = "127.0.0.1:0"
+			n.Opts.TCPAddress = bindaddr.LoopbackAddr(0)
+			n.Opts.HTTPAddress = bindaddr.LoopbackAddr(0)
+			n.Opts.HTTPSAddress = bindaddr.LoopbackAddr(0)
 			n.Opts.MaxMsgSize = 10 * 1024 * 1024 // 10MB
 		}
 		nsq, err := nsqd.New(n.Opts)
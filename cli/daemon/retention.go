@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/run"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// RetentionDryRun reports what the data retention policies of a running app would delete.
+func (s *Server) RetentionDryRun(ctx context.Context, req *daemonpb.RetentionDryRunRequest) (*daemonpb.RetentionDryRunResponse, error) {
+	r, err := s.runningApp(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	pg := r.ProcGroup()
+	if pg == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the app is not running")
+	}
+
+	var procs []*run.Proc
+	for _, byName := range []map[string]*run.Proc{pg.Gateways, pg.Services} {
+		for _, p := range byName {
+			if p.Started.Load() && !slices.Contains(procs, p) {
+				procs = append(procs, p)
+			}
+		}
+	}
+
+	// Every process registers all the policies and handlers of the app,
+	// but only reports the policies on the databases it uses.
+	// Merge the results, keyed by name.
+	var (
+		mu       sync.Mutex
+		policies = make(map[string]*daemonpb.RetentionPolicyDryRun)
+		subjects = make(map[string]*daemonpb.RetentionSubjectDryRun)
+	)
+	var eg errgroup.Group
+	for _, p := range procs {
+		eg.Go(func() error {
+			res, err := p.RetentionDryRun(ctx, req.GetSubjectId())
+			if err != nil {
+				return status.Errorf(codes.Unavailable, "process %d: %v", p.Pid, err)
+			} else if res == nil {
+				return nil
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, pol := range res.Policies {
+				policies[pol.Name] = &daemonpb.RetentionPolicyDryRun{
+					Name:        pol.Name,
+					Table:       pol.Table,
+					MaxAge:      pol.MaxAge,
+					WouldDelete: pol.WouldDelete,
+					Error:       pol.Error,
+				}
+			}
+			for _, sub := range res.Subjects {
+				// Handlers run in the process calling DeleteSubject,
+				// so keep the result of a process that could run it.
+				if prev, ok := subjects[sub.Handler]; ok && prev.Error == "" {
+					continue
+				}
+				subjects[sub.Handler] = &daemonpb.RetentionSubjectDryRun{
+					Handler:     sub.Handler,
+					WouldDelete: sub.WouldDelete,
+					Error:       sub.Error,
+				}
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	resp := &daemonpb.RetentionDryRunResponse{}
+	for _, name := range slices.Sorted(maps.Keys(policies)) {
+		resp.Policies = append(resp.Policies, policies[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(subjects)) {
+		resp.Subjects = append(resp.Subjects, subjects[name])
+	}
+	return resp, nil
+}
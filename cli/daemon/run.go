@@ -1,21 +1,35 @@
 package daemon
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net"
 	"net/url"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/logrusorgru/aurora/v3"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"encr.dev/cli/daemon/dap"
 	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/secret"
+	"encr.dev/cli/internal/bindaddr"
+	"encr.dev/cli/internal/update"
 	"encr.dev/internal/optracker"
 	"encr.dev/internal/userconfig"
 	"encr.dev/internal/version"
+	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
 	daemonpb "encr.dev/proto/encore/daemon"
@@ -23,29 +37,38 @@ import (
 
 // Run runs the application.
 func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer) error {
-	ctx := stream.Context()
-	slog := &streamLog{stream: stream, buffered: true}
-	stderr := slog.Stderr(false)
-
-	sendExit := func(code int32) {
-		_ = stream.Send(&daemonpb.CommandMessage{
-			Msg: &daemonpb.CommandMessage_Exit{Exit: &daemonpb.CommandExit{
-				Code: code,
-			}},
+	if req.Resumable || req.Detach {
+		sess := s.sessions.create(req.AppRoot, req.Detach)
+		return s.runSession(sess, stream, func(stream commandServerStream) {
+			_ = s.runApp(req, stream)
 		})
 	}
+	return s.runApp(req, stream)
+}
+
+func (s *Server) runApp(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer) error {
+	sessStream, _ := stream.(*sessionStream)
+	if req.BatchOutput || req.OutputCompression != daemonpb.OutputCompression_OUTPUT_COMPRESSION_NONE {
+		batched := newBatchedStream(stream, req.OutputCompression)
+		defer fns.CloseIgnore(batched)
+		stream = batched
+	}
+
+	ctx := stream.Context()
+	slog := &streamLog{stream: stream, buffered: true, structured: req.StructuredOutput}
+	stderr := slog.Stderr(false)
 
 	userConfig, err := userconfig.ForApp(req.AppRoot).Get()
 	if err != nil {
 		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to load config: %v"), err))
-		sendExit(1)
+		streamError(stream, err)
 		return nil
 	}
 
 	ctx, tracer, err := s.beginTracing(ctx, req.AppRoot, req.WorkingDir, req.TraceFile)
 	if err != nil {
 		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to begin tracing: %v"), err))
-		sendExit(1)
+		streamError(stream, err)
 		return nil
 	}
 	defer fns.CloseIgnore(tracer)
@@ -55,7 +78,53 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	if listenAddr == "" {
 		listenAddr = ":4000"
 	}
-	ln, err := net.Listen("tcp", listenAddr)
+	bindPolicy, err := bindaddr.Parse(req.GetBindPolicy())
+	if req.BindPolicy == nil {
+		bindPolicy, err = bindaddr.Parse(userConfig.BindPolicy)
+	}
+	if err == nil {
+		appPolicy := bindPolicy
+		if req.ListenExternal && appPolicy == bindaddr.PolicyWarn {
+			// Listening on all interfaces was explicitly requested.
+			appPolicy = bindaddr.PolicyAllow
+		}
+		err = checkBindAddr(stderr, appPolicy, "the app", listenAddr)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("Failed to run on %s - %v"), listenAddr, err))
+		streamError(stream, err)
+		return nil
+	}
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to resolve app: %v"), err))
+		streamError(stream, err)
+		return nil
+	}
+
+	ns, err := s.namespaceOrActive(ctx, app, req.Namespace)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to resolve namespace: %v"), err))
+		streamError(stream, err)
+		return nil
+	}
+
+	// Make sure the app isn't already running in the namespace
+	// before listening, so runs don't fight over ports and databases.
+	ctx, cancelRun := context.WithCancelCause(ctx)
+	defer cancelRun(nil)
+	guardKey := runGuardKey{appID: app.PlatformOrLocalID(), nsID: ns.ID}
+	var sess *session
+	if sessStream != nil {
+		sess = sessStream.sess
+	}
+	guard, ok := s.guardRun(ctx, req, guardKey, ns, cancelRun, sess, stream, slog)
+	if !ok {
+		return nil
+	}
+	defer s.runGuard.release(guardKey, guard)
+
+	ln, err := s.listenApp(ctx, app, listenAddr, req.AutoPort)
 	if err != nil {
 		if errIsAddrInUse(err) {
 			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("Failed to run on %s - port is already in use"), listenAddr))
@@ -64,39 +133,84 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 		}
 
 		if host, port, ok := findAvailableAddr(listenAddr); ok {
-			if host == "localhost" || host == "127.0.0.1" {
+			if host == "localhost" || host == bindaddr.Loopback() {
 				_, _ = fmt.Fprintf(stderr, "Note: port %d is available; specify %s to use it\n",
 					port, aurora.Sprintf(aurora.Cyan("--port=%d"), port))
 			} else {
-				_, _ = fmt.Fprintf(stderr, "Note: address %s:%d is available; specify %s to use it\n",
-					host, port, aurora.Sprintf(aurora.Cyan("--listen=%s:%d"), host, port))
+				addr := net.JoinHostPort(host, strconv.Itoa(port))
+				_, _ = fmt.Fprintf(stderr, "Note: address %s is available; specify %s to use it\n",
+					addr, aurora.Sprintf(aurora.Cyan("--listen=%s"), addr))
 			}
 		} else {
 			_, _ = fmt.Fprintf(stderr, "Note: specify %s to run on another port\n",
 				aurora.Cyan("--port=NUMBER"))
 		}
 
-		sendExit(1)
+		streamError(stream, err)
 		return nil
 	}
 	defer fns.CloseIgnore(ln)
 
-	app, err := s.apps.Track(req.AppRoot)
+	// With auto_port or port 0 the port we listen on may differ from the requested one.
+	if host, reqPort, err := net.SplitHostPort(listenAddr); err == nil {
+		if port := strconv.Itoa(ln.Addr().(*net.TCPAddr).Port); reqPort == "0" {
+			listenAddr = net.JoinHostPort(host, port)
+		} else if port != reqPort {
+			// The requested port is in use, by another app or another process.
+			reason := "is unavailable"
+			for _, r := range s.mgr.ListRuns() {
+				if _, p, err := net.SplitHostPort(r.ListenAddr); err == nil && p == reqPort {
+					reason = "is already in use by " + r.App.Name()
+					break
+				}
+			}
+			_, _ = fmt.Fprintf(stderr, "Note: port %s %s; running on port %s instead\n", reqPort, reason, port)
+			listenAddr = net.JoinHostPort(host, port)
+		}
+	}
+
+	if req.Tls {
+		tlsLn, ca, err := listenTLS(ln)
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("Failed to set up HTTPS: %v"), err))
+			streamError(stream, err)
+			return nil
+		}
+		ln = tlsLn
+		if ca.Created {
+			_, _ = fmt.Fprintf(stderr, "Note: created a local certificate authority for HTTPS in %s.\n", ca.Dir)
+			_, _ = fmt.Fprintf(stderr, "To make browsers trust it, run:\n\n    %s\n\n", aurora.Cyan(ca.TrustCommand()))
+		}
+	}
+
+	compose, err := setupCompose(ctx, app, listenAddr)
 	if err != nil {
-		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to resolve app: %v"), err))
-		sendExit(1)
+		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to set up docker-compose integration: %v"), err))
+		streamFailure(stream, daemonpb.ExitCategory_EXIT_CATEGORY_INFRA)
 		return nil
 	}
+	environ := req.Environ
+	var extraListeners []net.Listener
+	if compose != nil {
+		defer compose.Close()
+		// Let the user's environment take precedence.
+		environ = append(compose.Environ(), environ...)
+		if compose.ln != nil {
+			extraListeners = append(extraListeners, compose.ln)
+		}
+	}
 
-	ns, err := s.namespaceOrActive(ctx, app, req.Namespace)
+	shutdown, err := run.LoadGracefulShutdown(app.Root())
 	if err != nil {
-		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to resolve namespace: %v"), err))
-		sendExit(1)
+		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("invalid graceful shutdown config: %v"), err))
+		streamError(stream, err)
 		return nil
 	}
 
 	var ops *optracker.OpTracker
-	if req.NonInteractive {
+	if req.StructuredOutput {
+		ops = optracker.NewEventMode(slog)
+	} else if req.NonInteractive {
 		ops = optracker.NewLineMode(stderr)
 	} else {
 		ops = optracker.New(stderr, stream)
@@ -106,7 +220,10 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	// Check for available update before we start the proc
 	// so the output from the proc doesn't race with our
 	// prints below.
-	newVer := s.availableUpdate()
+	var newVer *update.LatestVersion
+	if !req.Offline {
+		newVer = s.availableUpdate()
+	}
 
 	// If force upgrade has been enabled, we force the upgrade now before we try and run the app
 	if newVer != nil && newVer.ForceUpgrade {
@@ -121,8 +238,9 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 		}
 
 		slog.FlushBuffers()
-		sendExit(1) // Kill the client
-		os.Exit(1)  // Kill the daemon too
+		// Kill the client, and the daemon too.
+		streamFailure(stream, daemonpb.ExitCategory_EXIT_CATEGORY_VERSION_MISMATCH)
+		os.Exit(1)
 		return nil
 	}
 
@@ -130,11 +248,42 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	// before output starts.
 	s.mu.Lock()
 
-	// If the listen addr contains no interface, render it as "localhost:port"
-	// instead of just ":port".
-	displayListenAddr := req.ListenAddr
-	if strings.HasPrefix(listenAddr, ":") {
-		displayListenAddr = "localhost" + req.ListenAddr
+	// If the listen addr listens on all interfaces, like ":port" or "[::]:port",
+	// render it as "localhost:port".
+	displayListenAddr := bindaddr.DisplayAddr(listenAddr)
+
+	// With listen_external, report the addresses the app is reachable at from
+	// other devices.
+	var externalHosts []string
+	if req.ListenExternal {
+		externalHosts = lanAddrs()
+	}
+	slog.Event(&daemonpb.CommandEvent{Event: &daemonpb.CommandEvent_Listen{
+		Listen: &daemonpb.ListenEvent{ListenAddr: displayListenAddr},
+	}})
+
+	var upstream *url.URL
+	if req.Upstream != "" {
+		upstream, err = url.Parse(req.Upstream)
+		if err != nil || (upstream.Scheme != "http" && upstream.Scheme != "https") || upstream.Host == "" {
+			err = fmt.Errorf("invalid upstream %q: must be an http or https URL", req.Upstream)
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("%v"), err))
+			s.mu.Unlock()
+			streamError(stream, err)
+			return nil
+		}
+	}
+
+	profile := req.Profile
+	if profile && app.Lang() != appfile.LangGo {
+		_, _ = fmt.Fprintln(stderr, aurora.Yellow("Note: --profile is only supported for Go apps; ignoring it."))
+		profile = false
+	}
+
+	vulnScan := req.VulnScan
+	if vulnScan && req.Offline {
+		_, _ = fmt.Fprintln(stderr, aurora.Yellow("Note: --vuln-scan requires network access; ignoring it in offline mode."))
+		vulnScan = false
 	}
 
 	browser := run.BrowserModeFromProto(req.Browser)
@@ -143,23 +292,40 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	}
 
 	runInstance, err := s.mgr.Start(ctx, run.StartParams{
-		App:                app,
-		NS:                 ns,
-		WorkingDir:         req.WorkingDir,
-		Listener:           ln,
-		ListenAddr:         displayListenAddr,
-		Watch:              req.Watch,
-		Environ:            req.Environ,
-		OpsTracker:         ops,
-		Browser:            browser,
-		Debug:              run.DebugModeFromProto(req.DebugMode),
-		LogLevel:           option.FromPointer(req.LogLevel),
-		ScrubSensitiveData: req.ScrubSensitiveData,
+		App:                 app,
+		NS:                  ns,
+		WorkingDir:          req.WorkingDir,
+		Listener:            ln,
+		ExtraListeners:      extraListeners,
+		ListenAddr:          displayListenAddr,
+		Watch:               req.Watch,
+		Environ:             environ,
+		InterpolatedEnvKeys: req.InterpolatedEnvKeys,
+		OpsTracker:          ops,
+		Browser:             browser,
+		Debug:               run.DebugModeFromProto(req.DebugMode),
+		LogLevel:            option.FromPointer(req.LogLevel),
+		ScrubSensitiveData:  req.ScrubSensitiveData,
+		Emulation:           run.EmulationProfileFromProto(req.Emulation),
+		DebugBundles:        req.DebugBundles,
+		VulnScan:            vulnScan,
+		GracefulShutdown:    shutdown,
+		TLS:                 req.Tls,
+		Profile:             profile,
+		Services:            req.Services,
+		Upstream:            upstream,
+		Offline:             req.Offline,
+		ExternalHosts:       externalHosts,
+		HookStdout:          slog.Stdout(true),
+		HookStderr:          slog.Stderr(true),
 	})
 	if err != nil {
 		s.mu.Unlock()
 		if errList := run.AsErrorList(err); errList != nil {
 			_ = errList.SendToStream(stream)
+			if summary := run.OwnerSummary(app, errList); summary != "" {
+				_, _ = stderr.Write([]byte(summary))
+			}
 		} else {
 			errStr := err.Error()
 			if !strings.HasSuffix(errStr, "\n") {
@@ -167,16 +333,47 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 			}
 			_, _ = stderr.Write([]byte(errStr))
 		}
-		sendExit(1)
+		streamError(stream, err)
 		return nil
 	}
 	defer runInstance.Close()
+	guard.setStarted(runInstance)
 	s.streams[runInstance.ID] = slog
 	s.mu.Unlock()
+	endSession := s.recordRunSession(runInstance, req.SessionName)
 
 	ops.AllDone()
 
-	secrets, _ := s.sm.Load(app).Get(ctx, nil)
+	var dapAddr string
+	if req.DebugMode == daemonpb.RunRequest_DEBUG_ENABLED && req.DapListenAddr != "" {
+		if err := checkBindAddr(stderr, bindPolicy, "the debug adapter", req.DapListenAddr); err != nil {
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Yellow("Failed to serve debug adapter on %s: %v"), req.DapListenAddr, err))
+		} else if dapLn, err := net.Listen("tcp", req.DapListenAddr); err != nil {
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Yellow("Failed to serve debug adapter on %s: %v"), req.DapListenAddr, err))
+		} else {
+			defer fns.CloseIgnore(dapLn)
+			proxy := &dap.Proxy{Targets: func() []dap.Target { return dapTargets(runInstance) }}
+			go func() { _ = proxy.Serve(dapLn) }()
+			dapAddr = dapLn.Addr().String()
+		}
+	}
+
+	var profileAddrs map[string]string
+	if profile {
+		addrs, stop, err := serveProfiles(runInstance)
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Yellow("Failed to serve profiling endpoints: %v"), err))
+		} else {
+			defer stop()
+			profileAddrs = addrs
+		}
+	}
+
+	secretsLoader := s.sm.Load
+	if req.Offline {
+		secretsLoader = s.sm.LoadCached
+	}
+	secrets, _ := secretsLoader(app).Get(ctx, nil)
 	externalDBs := map[string]string{}
 	for key, val := range secrets.Values {
 		if db, ok := strings.CutPrefix(key, "sqldb::"); ok {
@@ -198,28 +395,120 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 
 		}
 	}
-	_, _ = stderr.Write([]byte("\n"))
-	_, _ = fmt.Fprintf(stderr, "  Encore development server running!\n\n")
+	if !req.SkipReadyCheck {
+		// Don't announce the app as running until its services have initialized.
+		slow := time.AfterFunc(2*time.Second, func() {
+			_, _ = fmt.Fprintln(stderr, "Waiting for services to finish initializing...")
+		})
+		err := runInstance.ProcGroup().WaitReady(ctx, run.DefaultReadyTimeout)
+		slow.Stop()
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, aurora.Yellow(fmt.Sprintf("Warning: %v", err)))
+		}
+	}
+
+	dashboardURL := s.mgr.DashboardURL(app.PlatformOrLocalID())
+	slog.Event(&daemonpb.CommandEvent{Event: &daemonpb.CommandEvent_RunStarted{
+		RunStarted: &daemonpb.RunStartedEvent{
+			BaseUrl:      runInstance.BaseURL(),
+			ListenAddr:   runInstance.ListenAddr,
+			DashboardUrl: dashboardURL,
+			Namespace:    string(runInstance.NS.Name),
+		},
+	}})
+
+	// The banner is replaced by the event above for clients requesting structured output.
+	var banner io.Writer = stderr
+	if req.StructuredOutput {
+		banner = io.Discard
+	}
 
-	_, _ = fmt.Fprintf(stderr, "  Your API is running at:     %s\n", aurora.Cyan("http://"+runInstance.ListenAddr))
-	_, _ = fmt.Fprintf(stderr, "  Development Dashboard URL:  %s\n", aurora.Cyan(fmt.Sprintf(
-		"%s/%s", s.mgr.DashBaseURL, app.PlatformOrLocalID())))
-	_, _ = fmt.Fprintf(stderr, "  MCP SSE URL:                %s\n", aurora.Cyan(fmt.Sprintf(
-		"%s/sse?appID=%s", s.mcp.BaseURL, app.PlatformOrLocalID())))
+	_, _ = banner.Write([]byte("\n"))
+	_, _ = fmt.Fprintf(banner, "  Encore development server running!\n\n")
+
+	_, _ = fmt.Fprintf(banner, "  Your API is running at:     %s\n", aurora.Cyan(runInstance.BaseURL()))
+	if req.ListenExternal {
+		scheme, _, _ := strings.Cut(runInstance.BaseURL(), "://")
+		_, port, _ := net.SplitHostPort(runInstance.ListenAddr)
+		if len(externalHosts) == 0 {
+			_, _ = fmt.Fprintf(banner, "  %-28s%s\n", "On your network:", aurora.Yellow("no network address found"))
+		}
+		for i, host := range externalHosts {
+			label := "On your network:"
+			if i > 0 {
+				label = ""
+			}
+			_, _ = fmt.Fprintf(banner, "  %-28s%s\n", label, aurora.Cyan(scheme+"://"+net.JoinHostPort(host, port)))
+		}
+	}
+	if hosts := runInstance.HostMappings(); len(hosts) > 0 {
+		scheme, _, _ := strings.Cut(runInstance.BaseURL(), "://")
+		_, port, _ := net.SplitHostPort(runInstance.ListenAddr)
+		_, _ = fmt.Fprintln(banner, "  Local hosts:")
+		for _, h := range hosts {
+			kind := "service"
+			if h.Gateway != "" {
+				kind = "gateway"
+			}
+			_, _ = fmt.Fprintf(banner, "     %s -> %s %s\n",
+				aurora.Cyan(scheme+"://"+net.JoinHostPort(h.Host, port)), kind, h.Target())
+		}
+	}
+	_, _ = fmt.Fprintf(banner, "  Development Dashboard URL:  %s\n", aurora.Cyan(dashboardURL))
+	_, _ = fmt.Fprintf(banner, "  MCP SSE URL:                %s\n", aurora.Cyan(s.mgr.LocalAuth.URL(fmt.Sprintf(
+		"%s/sse?appID=%s", s.mcp.BaseURL, app.PlatformOrLocalID()))))
 
 	if ns := runInstance.NS; !ns.Active || ns.Name != "default" {
-		_, _ = fmt.Fprintf(stderr, "  Namespace:                  %s\n", aurora.Cyan(ns.Name))
+		_, _ = fmt.Fprintf(banner, "  Namespace:                  %s\n", aurora.Cyan(ns.Name))
 		if len(externalDBs) > 0 {
-			_, _ = fmt.Fprintln(stderr, "  External databases:")
+			_, _ = fmt.Fprintln(banner, "  External databases:")
 		}
 	}
 	for db, connStr := range externalDBs {
-		_, _ = fmt.Fprintf(stderr, "     %s: %s\n", db, aurora.Cyan(connStr))
+		_, _ = fmt.Fprintf(banner, "     %s: %s\n", db, aurora.Cyan(connStr))
+	}
+	if compose != nil {
+		_, _ = fmt.Fprintf(banner, "  Docker Compose network:     %s\n", aurora.Cyan(compose.Summary()))
+		if err := compose.RegisterProxy(); err != nil {
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Yellow("  Failed to register with reverse proxy: %v"), err))
+		}
+	}
+	if sftp := runInstance.ResourceManager.GetSFTP(); sftp != nil {
+		_, _ = fmt.Fprintf(banner, "  SFTP server:                %s\n", aurora.Cyan(sftp.URL()))
+		dirs := sftp.Directories()
+		for _, dir := range slices.Sorted(maps.Keys(dirs)) {
+			_, _ = fmt.Fprintf(banner, "     /%s -> bucket %s\n", dir, dirs[dir])
+		}
+	}
+	if len(req.Services) > 0 {
+		others := "stubbed"
+		if upstream != nil {
+			others = "proxied to " + upstream.String()
+		}
+		_, _ = fmt.Fprintf(banner, "  Services:                   %s (others %s)\n", aurora.Cyan(strings.Join(req.Services, ", ")), others)
+	}
+	if smtp := runInstance.SMTPURL(); smtp != "" {
+		_, _ = fmt.Fprintf(banner, "  SMTP server:                %s\n", aurora.Cyan(smtp))
+	}
+	if req.Offline {
+		_, _ = fmt.Fprintf(banner, "  Offline mode:               %s\n", aurora.Yellow(offlineNotice(app.PlatformID(), secrets)))
+	}
+	if emulation := runInstance.Params.Emulation; emulation.IsProduction() {
+		_, _ = fmt.Fprintf(banner, "  Emulating environment:      %s\n", aurora.Yellow(emulation.Name))
 	}
 	if req.DebugMode == daemonpb.RunRequest_DEBUG_ENABLED {
 		// Print the pid for debugging. Currently we only support this if we have a default gateway.
 		if gw, ok := runInstance.ProcGroup().Gateways["api-gateway"]; ok {
-			_, _ = fmt.Fprintf(stderr, "  Process ID:                 %d\n", aurora.Cyan(gw.Pid))
+			_, _ = fmt.Fprintf(banner, "  Process ID:                 %d\n", aurora.Cyan(gw.Pid))
+		}
+	}
+	if dapAddr != "" {
+		_, _ = fmt.Fprintf(banner, "  Debug adapter (DAP):        %s\n", aurora.Cyan(dapAddr))
+	}
+	if len(profileAddrs) > 0 {
+		_, _ = fmt.Fprintln(banner, "  Profiling (pprof):")
+		for _, name := range slices.Sorted(maps.Keys(profileAddrs)) {
+			_, _ = fmt.Fprintf(banner, "     %s: %s\n", name, aurora.Cyan("http://"+profileAddrs[name]+"/debug/pprof/"))
 		}
 	}
 	// Log which experiments are enabled, if any
@@ -228,30 +517,41 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 		for i, e := range exp {
 			strs[i] = string(e)
 		}
-		_, _ = fmt.Fprintf(stderr, "  Enabled experiment(s):      %s\n", aurora.Yellow(strings.Join(strs, ", ")))
+		_, _ = fmt.Fprintf(banner, "  Enabled experiment(s):      %s\n", aurora.Yellow(strings.Join(strs, ", ")))
 	}
 
 	// If there's a newer version available, print a message.
 	if newVer != nil {
 		if newVer.SecurityUpdate {
-			_, _ = stderr.Write([]byte(aurora.Sprintf(
+			_, _ = banner.Write([]byte(aurora.Sprintf(
 				aurora.Yellow("\n  New Encore release available with security updates: %s (you have %s)\n  Update with: encore version update\n"),
 				newVer.Version(), version.Version)))
 
 			if newVer.SecurityNotes != "" {
-				_, _ = stderr.Write([]byte(aurora.Sprintf(
+				_, _ = banner.Write([]byte(aurora.Sprintf(
 					aurora.Faint("\n  %s\n"),
 					newVer.SecurityNotes)))
 			}
 		} else {
-			_, _ = stderr.Write([]byte(aurora.Sprintf(
+			_, _ = banner.Write([]byte(aurora.Sprintf(
 				aurora.Faint("\n  New Encore release available: %s (you have %s)\n  Update with: encore version update\n"),
 				newVer.Version(), version.Version)))
 		}
 	}
-	_, _ = stderr.Write([]byte("\n"))
+	if req.InteractiveControls {
+		_, _ = fmt.Fprintf(banner, "\n  %s\n", aurora.Faint(
+			"Press r to restart, o to open the dashboard, t to toggle trace sampling, q to quit"))
+	}
+	_, _ = banner.Write([]byte("\n"))
 
 	slog.FlushBuffers()
+	if req.Detach && sessStream != nil {
+		// The app keeps running in the background; end the client's stream.
+		if batched, ok := stream.(*batchedStream); ok {
+			_ = batched.Close()
+		}
+		sessStream.sess.release()
+	}
 
 	go func() {
 		// Wait a little bit for the app to start
@@ -259,16 +559,144 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 		case <-runInstance.Done():
 			return
 		case <-time.After(5 * time.Second):
-			if proc := runInstance.ProcGroup(); proc != nil {
+			if proc := runInstance.ProcGroup(); proc != nil && !req.StructuredOutput {
 				showFirstRunExperience(runInstance, proc.Meta, stderr)
 			}
 		}
 	}()
 
 	<-runInstance.Done() // wait for run to complete
+	reason := runExitReason(ctx, runInstance)
+	if errors.Is(context.Cause(ctx), errRunTakenOver) {
+		reason = "taken over"
+		_, _ = fmt.Fprintln(stderr, aurora.Yellow("Stopped: the app was taken over by another encore run"))
+	}
+	endSession(reason)
 
 	s.mu.Lock()
 	delete(s.streams, runInstance.ID)
 	s.mu.Unlock()
 	return nil
 }
+
+// offlineNotice describes the data an app runs with in offline mode.
+func offlineNotice(platformID string, secrets *secret.Data) string {
+	switch {
+	case platformID == "":
+		return "skipping update checks"
+	case secrets == nil || secrets.Synced.IsZero():
+		return "no cached secrets; run online once to sync them"
+	default:
+		return "using secrets last synced " + secrets.Synced.Local().Format("Jan 2 15:04")
+	}
+}
+
+// ListRuns lists the apps currently running and the addresses they listen on.
+func (s *Server) ListRuns(ctx context.Context, req *daemonpb.ListRunsRequest) (*daemonpb.ListRunsResponse, error) {
+	var appID string
+	if req.AppRoot != "" {
+		app, err := s.apps.Track(req.AppRoot)
+		if err != nil {
+			return nil, err
+		}
+		appID = app.PlatformOrLocalID()
+	}
+
+	resp := &daemonpb.ListRunsResponse{}
+	for _, r := range s.mgr.ListRuns() {
+		if appID != "" && r.App.PlatformOrLocalID() != appID {
+			continue
+		}
+		info := &daemonpb.RunInfo{
+			Id:           r.ID,
+			AppId:        r.App.PlatformOrLocalID(),
+			AppRoot:      r.App.Root(),
+			ListenAddr:   r.ListenAddr,
+			DashboardUrl: s.mgr.DashboardURL(r.App.PlatformOrLocalID()),
+		}
+		if r.NS != nil {
+			info.Namespace = string(r.NS.Name)
+		}
+		resp.Runs = append(resp.Runs, info)
+	}
+	return resp, nil
+}
+
+// AttachRun attaches to the output of an app running in the background,
+// starting with its buffered output, until the app stops or the client disconnects.
+func (s *Server) AttachRun(req *daemonpb.AttachRunRequest, stream daemonpb.Daemon_AttachRunServer) error {
+	sess, ok := s.sessions.findRun(req.AppRoot)
+	if !ok || !sess.persistent {
+		return status.Error(codes.FailedPrecondition, "the app is not running in the background; start it with 'encore run --detach'")
+	}
+	client, err := sess.attach(stream, sess.bufferStart())
+	if err != nil {
+		return err
+	}
+	sess.wait(client)
+	return nil
+}
+
+// StopRun stops the app running at the given app root, whether it runs
+// in the background or is attached to a client. It returns once the app has stopped.
+func (s *Server) StopRun(ctx context.Context, req *daemonpb.StopRunRequest) (*empty.Empty, error) {
+	sess, ok := s.sessions.findRun(req.AppRoot)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "the app is not running")
+	}
+	sess.cancel()
+
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	if r := s.mgr.FindRunByAppID(app.PlatformOrLocalID()); r != nil {
+		select {
+		case <-r.Done():
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return &empty.Empty{}, nil
+}
+
+// ControlRun performs an action on the app running at the given app root,
+// reporting its progress in the app's output. It returns once the action is done.
+func (s *Server) ControlRun(ctx context.Context, req *daemonpb.ControlRunRequest) (*empty.Empty, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	r := s.mgr.FindRunByAppID(app.PlatformOrLocalID())
+	if r == nil {
+		return nil, status.Error(codes.NotFound, "the app is not running")
+	}
+
+	switch req.Action {
+	case daemonpb.ControlRunRequest_ACTION_RESTART:
+		r.Restart()
+	case daemonpb.ControlRunRequest_ACTION_TOGGLE_TRACE_SAMPLING:
+		r.ToggleTraceSampling()
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown action %v", req.Action)
+	}
+	return &empty.Empty{}, nil
+}
+
+// dapTargets returns the processes of a run that can be debugged,
+// by the name of the services and gateways they run.
+func dapTargets(r *run.Run) []dap.Target {
+	pg := r.ProcGroup()
+	if pg == nil {
+		return nil
+	}
+	var targets []dap.Target
+	for _, procs := range []map[string]*run.Proc{pg.Gateways, pg.Services} {
+		for _, name := range slices.Sorted(maps.Keys(procs)) {
+			if p := procs[name]; p.Started.Load() && p.Pid != 0 {
+				targets = append(targets, dap.Target{Name: name, Pid: p.Pid})
+			}
+		}
+	}
+	return targets
+}
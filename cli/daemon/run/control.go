@@ -0,0 +1,103 @@
+package run
+
+import (
+	"strings"
+
+	"encr.dev/pkg/option"
+)
+
+// Restart rebuilds and restarts the app, as when it's reloaded on changes,
+// reporting the progress in the run's output.
+func (r *Run) Restart() {
+	r.reloadAndReport("Restarting...\n")
+}
+
+// reloadAndReport reloads the app after writing msg to the run's output,
+// and reports whether the reload succeeded. Reloads are serialized,
+// as they can be triggered both by changes and by the user.
+func (r *Run) reloadAndReport(msg string) {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	r.Mgr.RunStdout(r, []byte(msg))
+	wasStale := r.StaleBuild()
+	if err := r.Reload(); err != nil {
+		if errList := AsErrorList(err); errList != nil {
+			r.Mgr.RunError(r, errList)
+			if summary := OwnerSummary(r.App, errList); summary != "" {
+				r.Mgr.RunStderr(r, []byte(summary))
+			}
+		} else {
+			errStr := err.Error()
+			if !strings.HasSuffix(errStr, "\n") {
+				errStr += "\n"
+			}
+			r.Mgr.RunStderr(r, []byte(errStr))
+		}
+		if r.StaleBuild() {
+			r.Mgr.RunStderr(r, []byte("Stale build: the previous build keeps serving requests until the next successful build.\n"))
+		}
+	} else if wasStale {
+		r.Mgr.RunStdout(r, []byte("Reloaded successfully, replacing the stale build.\n"))
+	} else {
+		r.Mgr.RunStdout(r, []byte("Reloaded successfully.\n"))
+	}
+}
+
+// ToggleTraceSampling turns off the sampling of traces if it's on,
+// and back on otherwise. The app's processes are restarted to apply it,
+// without rebuilding the app.
+func (r *Run) ToggleTraceSampling() {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	sampled := r.traceSamplingOff.Load()
+	r.traceSamplingOff.Store(!sampled)
+	if sampled {
+		r.Mgr.RunStdout(r, []byte("Trace sampling turned on, restarting...\n"))
+	} else {
+		r.Mgr.RunStdout(r, []byte("Trace sampling turned off, restarting...\n"))
+	}
+
+	p := r.ProcGroup()
+	if p == nil {
+		return
+	}
+	if err := r.restartProcGroup(p); err != nil {
+		r.log.Error().Err(err).Msg("could not restart app")
+		r.Mgr.RunStderr(r, []byte("error: could not restart the app: "+err.Error()+"\n"))
+		return
+	}
+	r.Mgr.RunStdout(r, []byte("Restarted successfully.\n"))
+}
+
+// traceSamplingRate returns the trace sampling rate to start processes with.
+func (r *Run) traceSamplingRate(emulation EmulationProfile) option.Option[float64] {
+	if r.traceSamplingOff.Load() {
+		return option.Some(0.0)
+	}
+	return emulation.TraceSamplingRate
+}
+
+// restartProcGroup restarts the processes of p without rebuilding the app,
+// by starting new processes with the same parameters and switching over to them.
+// It does nothing if p is replaced in the meantime, such as by a reload.
+func (r *Run) restartProcGroup(p *ProcGroup) error {
+	params := *p.startParams
+	params.IsReload = true    // wait for the gateways to be ready
+	params.MigrationsWait = 0 // the migrations have already been run
+	newProcess, err := r.StartProcGroup(&params)
+	if err != nil {
+		return err
+	}
+	if !r.proc.CompareAndSwap(p, newProcess) {
+		// The app was reloaded while restarting.
+		newProcess.Close()
+		return nil
+	}
+	go p.Close()
+	for _, ln := range r.Mgr.listeners {
+		ln.OnReload(r)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package run
+
+import (
+	"slices"
+
+	"encore.dev/appruntime/exported/debugbundle"
+)
+
+// maxDebugBundles is the maximum number of debug bundles kept per app.
+const maxDebugBundles = 100
+
+// RecordDebugBundle records a debug bundle of a failed request to an app,
+// discarding the oldest bundle if the app has more than maxDebugBundles.
+func (mgr *Manager) RecordDebugBundle(appID string, b *debugbundle.Bundle) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.bundles == nil {
+		mgr.bundles = make(map[string][]*debugbundle.Bundle)
+	}
+	bundles := append(mgr.bundles[appID], b)
+	if n := len(bundles) - maxDebugBundles; n > 0 {
+		bundles = slices.Delete(bundles, 0, n)
+	}
+	mgr.bundles[appID] = bundles
+}
+
+// DebugBundles returns the recorded debug bundles of an app, most recent first.
+func (mgr *Manager) DebugBundles(appID string) []*debugbundle.Bundle {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	bundles := slices.Clone(mgr.bundles[appID])
+	slices.Reverse(bundles)
+	return bundles
+}
@@ -0,0 +1,287 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog"
+
+	"encr.dev/cli/daemon/ports"
+	"encr.dev/cli/internal/bindaddr"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// DefaultSMTPPort is the port the local SMTP server listens on, if available.
+const DefaultSMTPPort = 2525
+
+// maxEmailSize is the maximum size of messages accepted by the local SMTP server.
+const maxEmailSize = 32 << 20
+
+// smtpServer is a local SMTP server delivering messages
+// to the inbound email addresses of a running app.
+type smtpServer struct {
+	run *Run
+	ln  net.Listener
+	log zerolog.Logger
+
+	mu       sync.Mutex
+	inbounds []*meta.InboundEmail
+}
+
+// startSMTP starts the local SMTP server if the app declares inbound email
+// addresses and it isn't already running, and updates its addresses otherwise.
+func (r *Run) startSMTP(md *meta.Data) {
+	if s := r.smtp.Load(); s != nil {
+		s.mu.Lock()
+		s.inbounds = md.InboundEmails
+		s.mu.Unlock()
+		return
+	} else if len(md.InboundEmails) == 0 {
+		return
+	}
+
+	// If the default port is used by another app, pick another one.
+	key := ports.Key{AppID: r.App.PlatformOrLocalID(), Component: "smtp"}
+	ln, err := r.Mgr.Ports.Listen(r.ctx, key, bindaddr.Loopback(), DefaultSMTPPort, true)
+	if err != nil {
+		r.log.Error().Err(err).Msg("unable to start local SMTP server")
+		return
+	}
+
+	s := &smtpServer{
+		run:      r,
+		ln:       ln,
+		log:      r.log.With().Str("component", "smtp").Logger(),
+		inbounds: md.InboundEmails,
+	}
+	r.smtp.Store(s)
+	go s.serve()
+}
+
+// SMTPURL returns the URL of the local SMTP server,
+// or "" if it isn't running.
+func (r *Run) SMTPURL() string {
+	s := r.smtp.Load()
+	if s == nil {
+		return ""
+	}
+	return "smtp://" + s.ln.Addr().String()
+}
+
+// stopSMTP stops the local SMTP server, if running.
+func (r *Run) stopSMTP() {
+	if s := r.smtp.Load(); s != nil {
+		_ = s.ln.Close()
+	}
+}
+
+func (s *smtpServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// smtpSession is the state of an SMTP transaction.
+type smtpSession struct {
+	from  string
+	rcpts map[*meta.InboundEmail][]string // recipients by inbound email address
+}
+
+func (s *smtpServer) handle(c net.Conn) {
+	defer func() { _ = c.Close() }()
+	conn := textproto.NewConn(c)
+	reply := func(format string, args ...any) bool {
+		_ = c.SetWriteDeadline(time.Now().Add(time.Minute))
+		return conn.PrintfLine(format, args...) == nil
+	}
+
+	if !reply("220 localhost Encore SMTP server ready") {
+		return
+	}
+	var sess *smtpSession
+	for {
+		_ = c.SetReadDeadline(time.Now().Add(5 * time.Minute))
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+
+		var ok bool
+		switch strings.ToUpper(cmd) {
+		case "EHLO":
+			sess = nil
+			ok = reply("250-localhost") && reply("250-8BITMIME") && reply("250-PIPELINING") &&
+				reply("250 SIZE %d", maxEmailSize)
+		case "HELO":
+			sess = nil
+			ok = reply("250 localhost")
+		case "MAIL":
+			from, valid := smtpPath(arg, "FROM:")
+			if !valid {
+				ok = reply("501 5.5.4 Syntax: MAIL FROM:<address>")
+				break
+			}
+			sess = &smtpSession{from: from, rcpts: make(map[*meta.InboundEmail][]string)}
+			ok = reply("250 2.1.0 OK")
+		case "RCPT":
+			rcpt, valid := smtpPath(arg, "TO:")
+			switch {
+			case sess == nil:
+				ok = reply("503 5.5.1 Need MAIL command first")
+			case !valid || rcpt == "":
+				ok = reply("501 5.5.4 Syntax: RCPT TO:<address>")
+			default:
+				inbound := s.route(rcpt)
+				if inbound == nil {
+					ok = reply("550 5.1.1 No inbound email address matches <%s>", rcpt)
+					break
+				}
+				sess.rcpts[inbound] = append(sess.rcpts[inbound], rcpt)
+				ok = reply("250 2.1.5 OK")
+			}
+		case "DATA":
+			if sess == nil || len(sess.rcpts) == 0 {
+				ok = reply("503 5.5.1 Need RCPT command first")
+				break
+			}
+			if !reply("354 End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			_ = c.SetReadDeadline(time.Now().Add(5 * time.Minute))
+			data, err := io.ReadAll(io.LimitReader(conn.DotReader(), maxEmailSize+1))
+			if err != nil {
+				return
+			} else if len(data) > maxEmailSize {
+				// Discard the rest of the message.
+				_, _ = io.Copy(io.Discard, conn.DotReader())
+				ok = reply("552 5.3.4 Message too big")
+			} else if err := s.deliver(sess, data); err != nil {
+				ok = reply("451 4.3.0 %s", strings.ReplaceAll(err.Error(), "\n", " "))
+			} else {
+				ok = reply("250 2.0.0 OK")
+			}
+			sess = nil
+		case "RSET":
+			sess = nil
+			ok = reply("250 2.0.0 OK")
+		case "NOOP":
+			ok = reply("250 2.0.0 OK")
+		case "VRFY":
+			ok = reply("252 2.5.0 Cannot verify user")
+		case "QUIT":
+			reply("221 2.0.0 Bye")
+			return
+		default:
+			ok = reply("502 5.5.2 Command not implemented")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// smtpPath parses the address of a MAIL or RCPT command argument,
+// such as "FROM:<alice@example.com> SIZE=1024".
+func smtpPath(arg, prefix string) (addr string, ok bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+	arg = strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(arg, "<") {
+		return "", false
+	}
+	end := strings.IndexByte(arg, '>')
+	if end < 0 {
+		return "", false
+	}
+	return arg[1:end], true
+}
+
+// route returns the inbound email address a message to rcpt is delivered to,
+// or nil if there is none.
+//
+// The local part of the recipient, up to any "+", is the name of the address.
+// If the app has a single inbound email address it receives all messages.
+func (s *smtpServer) route(rcpt string) *meta.InboundEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	local, _, _ := strings.Cut(rcpt, "@")
+	local, _, _ = strings.Cut(local, "+")
+	for _, in := range s.inbounds {
+		if strings.EqualFold(in.Name, local) {
+			return in
+		}
+	}
+	if len(s.inbounds) == 1 {
+		return s.inbounds[0]
+	}
+	return nil
+}
+
+// deliver delivers a message to the inbound email addresses of its recipients.
+func (s *smtpServer) deliver(sess *smtpSession, data []byte) error {
+	pg := s.run.ProcGroup()
+	if pg == nil {
+		return errors.New("app is not running")
+	}
+
+	for inbound, rcpts := range sess.rcpts {
+		// Services run in the gateway's process unless they run in their own.
+		proc, ok := pg.Services[inbound.ServiceName]
+		if !ok {
+			proc, ok = pg.Gateways["api-gateway"]
+		}
+		if !ok {
+			return errors.Newf("service %s is not running", inbound.ServiceName)
+		}
+
+		err := proc.deliverEmail(s.run.ctx, inbound.Name, rcpts, data)
+		if err != nil {
+			s.log.Error().Err(err).Str("inbound", inbound.Name).Str("from", sess.from).Msg("unable to deliver email")
+			return errors.Wrapf(err, "deliver to %s", inbound.Name)
+		}
+	}
+	return nil
+}
+
+// deliverEmail delivers a raw message to an inbound email address of the process.
+func (p *Proc) deliverEmail(ctx context.Context, inbound string, rcpts []string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	u := fmt.Sprintf("http://%s/__encore/email/inbound/%s", p.listenAddr, inbound)
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "message/rfc822")
+	req.Header.Set("X-Encore-Email-Recipients", strings.Join(rcpts, ","))
+	addAuthKeyToRequest(req, p.group.authKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "unable to reach process")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Newf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
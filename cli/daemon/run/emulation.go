@@ -0,0 +1,102 @@
+package run
+
+import (
+	"strings"
+
+	"encr.dev/pkg/option"
+	daemonpb "encr.dev/proto/encore/daemon"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// EmulationProfile describes what kind of environment a local run emulates.
+//
+// The default profile is tuned for a pleasant development experience,
+// while the production profile mirrors the settings used when deployed
+// so that "works locally, fails in prod" issues surface earlier.
+type EmulationProfile struct {
+	// Name is the human-readable name of the profile.
+	Name string
+
+	// EnvType is the environment type reported to the app.
+	EnvType runtimev1.Environment_Type
+
+	// StrictCORS, if true, enforces the CORS configuration from the
+	// encore.app file instead of allowing all origins.
+	StrictCORS bool
+
+	// DevAccess, if true, enables dev-only conveniences: requests proxied
+	// by the daemon are authenticated as coming from the Encore Platform
+	// (granting access to e.g. private endpoints) and internal error
+	// messages are included in API responses.
+	DevAccess bool
+
+	// TraceSamplingRate is the default trace sampling rate, if any.
+	// ENCORE_TRACE_SAMPLING_RATE takes precedence when set.
+	TraceSamplingRate option.Option[float64]
+
+	// Environ are default environment variables (e.g. resource limits)
+	// to set for each process. Variables already set by the user
+	// take precedence.
+	Environ []string
+}
+
+var (
+	// EmulateDevelopment is the default profile used for local development.
+	EmulateDevelopment = EmulationProfile{
+		Name:       "development",
+		EnvType:    runtimev1.Environment_TYPE_DEVELOPMENT,
+		StrictCORS: false,
+		DevAccess:  true,
+	}
+
+	// EmulateProduction runs the app with production-like settings.
+	EmulateProduction = EmulationProfile{
+		Name:              "production",
+		EnvType:           runtimev1.Environment_TYPE_PRODUCTION,
+		StrictCORS:        true,
+		DevAccess:         false,
+		TraceSamplingRate: option.Some(0.1),
+		Environ: []string{
+			"GOMAXPROCS=2",
+			"GOMEMLIMIT=512MiB",
+			"NODE_OPTIONS=--max-old-space-size=512",
+		},
+	}
+)
+
+// IsProduction reports whether the profile emulates production.
+func (p EmulationProfile) IsProduction() bool {
+	return p.EnvType == runtimev1.Environment_TYPE_PRODUCTION
+}
+
+// ApplyEnviron returns environ with the profile's default
+// environment variables added, unless already set.
+func (p EmulationProfile) ApplyEnviron(environ []string) []string {
+	if len(p.Environ) == 0 {
+		return environ
+	}
+
+	isSet := make(map[string]bool, len(environ))
+	for _, kv := range environ {
+		key, _, _ := strings.Cut(kv, "=")
+		isSet[key] = true
+	}
+
+	var defaults []string
+	for _, kv := range p.Environ {
+		key, _, _ := strings.Cut(kv, "=")
+		if !isSet[key] {
+			defaults = append(defaults, kv)
+		}
+	}
+	return append(defaults, environ...)
+}
+
+func EmulationProfileFromProto(p daemonpb.RunRequest_EmulationProfile) EmulationProfile {
+	switch p {
+	case daemonpb.RunRequest_EMULATION_PRODUCTION:
+		return EmulateProduction
+	default:
+		return EmulateDevelopment
+	}
+}
@@ -0,0 +1,45 @@
+package run
+
+import (
+	"crypto/rand"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/internal/conf"
+)
+
+// localEncryptionKey returns the key encrypting the app's encrypted fields
+// when running locally, creating it on first use. The key is kept outside
+// the app's source tree so it isn't accidentally committed, and persisted
+// so data encrypted in earlier runs can still be decrypted.
+func localEncryptionKey(appID string) ([]byte, error) {
+	dir, err := conf.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "encryption", appID+".key")
+
+	key, err := os.ReadFile(path)
+	if err == nil {
+		if len(key) != 32 {
+			return nil, errors.Newf("invalid encryption key %s: must be 32 bytes, got %d", path, len(key))
+		}
+		return key, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, key, 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,87 @@
+package run
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/pkg/environ"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// interpolateEnviron expands the ${...} references in the values of the
+// variables set with --env and env files, as given by the run's InterpolatedEnvKeys.
+//
+// Besides other variables, values can reference the app's secrets
+// as ${secret:Name} and the connection string of its databases as ${db:name}.
+func (r *Run) interpolateEnviron(env []string, secrets map[string]string, md *meta.Data) ([]string, error) {
+	if len(r.Params.InterpolatedEnvKeys) == 0 {
+		return env, nil
+	}
+
+	resolve := func(ref string) (string, bool, error) {
+		kind, name, _ := strings.Cut(ref, ":")
+		switch kind {
+		case "secret":
+			val, ok := secrets[name]
+			return val, ok, nil
+		case "db":
+			return r.databaseURL(name, md)
+		default:
+			return "", false, errors.Newf("unknown reference kind %q, expected secret or db", kind)
+		}
+	}
+
+	env, err := environ.Environ(env).Interpolate(r.Params.InterpolatedEnvKeys, resolve)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid environment variable")
+	}
+	return env, nil
+}
+
+// databaseURL returns the connection string for the app's database with the given name.
+func (r *Run) databaseURL(name string, md *meta.Data) (string, bool, error) {
+	for _, db := range md.SqlDatabases {
+		if db.Name != name {
+			continue
+		}
+
+		if sqldb.IsMySQL(db) {
+			srv, err := r.ResourceManager.MySQLServerConfig()
+			if err != nil {
+				return "", false, err
+			}
+			cfg, err := r.ResourceManager.SQLDatabaseConfig(db)
+			if err != nil {
+				return "", false, err
+			}
+			u := &url.URL{
+				Scheme: "mysql",
+				User:   url.UserPassword(cfg.User, cfg.Password),
+				Host:   srv.Host,
+				Path:   "/" + cfg.DatabaseName,
+			}
+			return u.String(), true, nil
+		}
+
+		srv, err := r.ResourceManager.SQLServerConfig()
+		if err != nil {
+			return "", false, err
+		}
+		cfg, err := r.ResourceManager.SQLDatabaseConfig(db)
+		if err != nil {
+			return "", false, err
+		}
+		u := &url.URL{
+			Scheme:   "postgresql",
+			User:     url.UserPassword(cfg.User, cfg.Password),
+			Host:     srv.Host,
+			Path:     "/" + cfg.DatabaseName,
+			RawQuery: "sslmode=disable",
+		}
+		return u.String(), true, nil
+	}
+	return "", false, nil
+}
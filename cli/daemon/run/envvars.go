@@ -0,0 +1,57 @@
+package run
+
+import (
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"encore.dev/envvar"
+	"encr.dev/pkg/errinsrc"
+	"encr.dev/pkg/errlist"
+	"encr.dev/pkg/errors"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	envvarparser "encr.dev/v2/parser/infra/envvar"
+)
+
+// checkEnvVars checks the environment variables the app declares against
+// environ, reporting the required variables that are missing and the values
+// not matching their declared format as errors at their declarations.
+func checkEnvVars(appRoot string, md *meta.Data, environ []string) error {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if key, val, ok := strings.Cut(kv, "="); ok {
+			env[key] = val
+		}
+	}
+
+	errs := errlist.New(nil)
+	checked := make(map[string]bool)
+	for _, v := range md.EnvVars {
+		// The declarations of a variable agree on its requirements,
+		// so report each variable once.
+		if checked[v.Name] {
+			continue
+		}
+		checked[v.Name] = true
+
+		var tmpl errors.Template
+		if val := env[v.Name]; val == "" {
+			if !v.Required {
+				continue
+			}
+			tmpl = envvarparser.ErrMissing(v.Name)
+		} else if err := envvar.Format(v.Format).Validate(val); err != nil {
+			tmpl = envvarparser.ErrInvalidValue(v.Name, err)
+		} else {
+			continue
+		}
+
+		pos := token.Position{
+			Filename: filepath.Join(appRoot, filepath.FromSlash(v.Filepath)),
+			Line:     int(v.SrcLine),
+			Column:   int(v.SrcCol),
+		}
+		errs.List = append(errs.List, errinsrc.FromTemplate(tmpl.AtGoPosition(pos, pos), nil))
+	}
+	return errs.Err()
+}
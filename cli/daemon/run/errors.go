@@ -3,10 +3,37 @@ package run
 import (
 	"errors"
 
+	cerrors "github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/ownership"
 	"encr.dev/pkg/errlist"
 	"encr.dev/v2/internals/perr"
 )
 
+var (
+	// ErrBuild marks errors from building the app.
+	// Build errors reported as error lists are not marked; use IsBuildError.
+	ErrBuild = errors.New("build failed")
+
+	// ErrTestsFailed marks errors from running tests that failed.
+	ErrTestsFailed = errors.New("tests failed")
+)
+
+// IsBuildError reports whether err is from building the app.
+func IsBuildError(err error) bool {
+	return errors.Is(err, ErrBuild) || AsErrorList(err) != nil
+}
+
+// buildErr marks err as a build error. Error lists are returned as-is
+// so they can still be displayed with their source context.
+func buildErr(err error) error {
+	if err == nil || AsErrorList(err) != nil {
+		return err
+	}
+	return cerrors.Mark(err, ErrBuild)
+}
+
 func AsErrorList(err error) *errlist.List {
 	if errList := errlist.Convert(err); errList != nil {
 		return errList
@@ -18,3 +45,14 @@ func AsErrorList(err error) *errlist.List {
 	}
 	return nil
 }
+
+// OwnerSummary summarizes which teams own the code the build errors of app are in,
+// based on the app's last successfully parsed metadata. It returns the empty string
+// if the app has no service owners or its metadata is unknown.
+func OwnerSummary(app *apps.Instance, errs *errlist.List) string {
+	md, err := app.CachedMetadata()
+	if err != nil || md == nil {
+		return ""
+	}
+	return ownership.New(app.Root(), md).BuildErrorSummary(errs)
+}
@@ -67,7 +67,7 @@ func (mgr *Manager) ExecSpec(ctx context.Context, p ExecSpecParams) (*ExecSpecRe
 		return nil, err
 	}
 
-	rm := infra.NewResourceManager(p.App, mgr.ClusterMgr, mgr.ObjectsMgr, mgr.PublicBuckets, p.NS, p.Environ, mgr.DBProxyPort, false)
+	rm := infra.NewResourceManager(p.App, mgr.ClusterMgr, mgr.MySQLMgr, mgr.ObjectsMgr, mgr.PublicBuckets, p.NS, p.Environ, mgr.Ports, mgr.DBProxyPort, false)
 
 	tracker := p.OpTracker
 	jobs := optracker.NewAsyncBuildJobs(ctx, p.App.PlatformOrLocalID(), tracker)
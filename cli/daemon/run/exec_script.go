@@ -65,7 +65,7 @@ func (mgr *Manager) ExecScript(ctx context.Context, p ExecScriptParams) (err err
 		return err
 	}
 
-	rm := infra.NewResourceManager(p.App, mgr.ClusterMgr, mgr.ObjectsMgr, mgr.PublicBuckets, p.NS, p.Environ, mgr.DBProxyPort, false)
+	rm := infra.NewResourceManager(p.App, mgr.ClusterMgr, mgr.MySQLMgr, mgr.ObjectsMgr, mgr.PublicBuckets, p.NS, p.Environ, mgr.Ports, mgr.DBProxyPort, false)
 	defer rm.StopAll()
 
 	tracker := p.OpTracker
@@ -103,7 +103,7 @@ func (mgr *Manager) ExecScript(ctx context.Context, p ExecScriptParams) (err err
 	})
 	if err != nil {
 		tracker.Fail(parseOp, errors.New("prepare error"))
-		return err
+		return buildErr(err)
 	}
 	parse, err := bld.Parse(ctx, builder.ParseParams{
 		Build:       buildInfo,
@@ -116,7 +116,7 @@ func (mgr *Manager) ExecScript(ctx context.Context, p ExecScriptParams) (err err
 	if err != nil {
 		// Don't use the error itself in tracker.Fail, as it will lead to duplicate error output.
 		tracker.Fail(parseOp, errors.New("parse error"))
-		return err
+		return buildErr(err)
 	}
 	if err := p.App.CacheMetadata(parse.Meta); err != nil {
 		return errors.Wrap(err, "cache metadata")
@@ -163,7 +163,7 @@ func (mgr *Manager) ExecScript(ctx context.Context, p ExecScriptParams) (err err
 			WorkingDir:  p.WorkingDir,
 		})
 		if err != nil {
-			return errors.Wrap(err, "compile error on exec")
+			return buildErr(errors.Wrap(err, "compile error on exec"))
 		}
 		return nil
 	})
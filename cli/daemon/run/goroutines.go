@@ -0,0 +1,33 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+)
+
+// GoroutineDump returns a dump of all goroutines of the process,
+// in the format of an unrecovered panic.
+func (p *Proc) GoroutineDump(ctx context.Context) ([]byte, error) {
+	url := fmt.Sprintf("http://%s/__encore/debug/goroutines", p.listenAddr)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	addAuthKeyToRequest(req, p.group.authKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to reach process")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.New("the process does not support goroutine dumps (only Go apps do)")
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
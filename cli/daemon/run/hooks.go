@@ -0,0 +1,105 @@
+package run
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/logrusorgru/aurora/v3"
+
+	"encr.dev/internal/userconfig"
+	"encr.dev/pkg/appfile"
+)
+
+// runHooks are the commands to execute around starting an app,
+// with the app's hooks in encore.app before those in the user config.
+type runHooks struct {
+	preStart  []appfile.Hook
+	postStart []appfile.Hook
+}
+
+// loadRunHooks loads the run hooks of the app at appRoot.
+func loadRunHooks(appRoot string) (runHooks, error) {
+	var hooks runHooks
+	cfg, err := appfile.RunHooksConfig(appRoot)
+	if err != nil {
+		return hooks, errors.Wrap(err, "unable to parse encore.app")
+	} else if cfg != nil {
+		if cfg.PreStart.IsSet() {
+			hooks.preStart = append(hooks.preStart, cfg.PreStart)
+		}
+		if cfg.PostStart.IsSet() {
+			hooks.postStart = append(hooks.postStart, cfg.PostStart)
+		}
+	}
+
+	user, err := userconfig.ForApp(appRoot).Get()
+	if err != nil {
+		return hooks, errors.Wrap(err, "unable to load user config")
+	}
+	if user.RunHooksPreStart != "" {
+		hooks.preStart = append(hooks.preStart, appfile.Hook{Command: user.RunHooksPreStart})
+	}
+	if user.RunHooksPostStart != "" {
+		hooks.postStart = append(hooks.postStart, appfile.Hook{Command: user.RunHooksPostStart})
+	}
+	return hooks, nil
+}
+
+// runPreStartHooks executes the prestart hooks in order,
+// stopping at the first one that fails.
+func (r *Run) runPreStartHooks(hooks []appfile.Hook) error {
+	for _, hook := range hooks {
+		stdout, stderr := r.hookOutput()
+		fmt.Fprintf(stderr, "%s\n", aurora.Gray(16, "Running prestart hook: "+hook.Command))
+		if err := r.hookWithEnv(hook).Run(r.ctx, r.App.Root(), stdout, stderr); err != nil {
+			return errors.Wrapf(err, "prestart hook %q failed", hook.Command)
+		}
+	}
+	return nil
+}
+
+// startPostStartHooks starts the poststart hooks, which may keep running
+// for as long as the app runs. Failures are reported but don't stop the app.
+func (r *Run) startPostStartHooks(hooks []appfile.Hook) {
+	for _, hook := range hooks {
+		go func() {
+			stdout, stderr := r.hookOutput()
+			fmt.Fprintf(stderr, "%s\n", aurora.Gray(16, "Running poststart hook: "+hook.Command))
+			err := r.hookWithEnv(hook).Run(r.ctx, r.App.Root(), stdout, stderr)
+			if err != nil && r.ctx.Err() == nil {
+				fmt.Fprintf(stderr, "%s\n", aurora.Red(fmt.Sprintf("poststart hook %q failed: %v", hook.Command, err)))
+			}
+		}()
+	}
+}
+
+// hookOutput returns the writers for the output of the run hooks.
+func (r *Run) hookOutput() (stdout, stderr io.Writer) {
+	stdout, stderr = r.Params.HookStdout, r.Params.HookStderr
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+	return stdout, stderr
+}
+
+// hookWithEnv returns hook with the environment of the run added,
+// along with ENCORE_BASE_URL set to the base URL of the app's API.
+func (r *Run) hookWithEnv(hook appfile.Hook) appfile.Hook {
+	env := make(map[string]string, len(r.Params.Environ)+len(hook.Env)+1)
+	for _, kv := range r.Params.Environ {
+		if key, val, ok := strings.Cut(kv, "="); ok {
+			env[key] = val
+		}
+	}
+	env["ENCORE_BASE_URL"] = r.BaseURL()
+	for key, val := range hook.Env {
+		env[key] = val
+	}
+	hook.Env = env
+	return hook
+}
@@ -0,0 +1,108 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"encr.dev/cli/internal/bindaddr"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// hostRouter routes requests to the services and gateways mapped to local
+// hostnames, so host-based routing can be exercised locally.
+// Requests to hosts that aren't mapped are routed as usual.
+type hostRouter struct {
+	mu    sync.Mutex
+	hosts map[string]HostMapping // keyed by lowercase hostname
+}
+
+// HostMapping maps a local hostname to a service or a gateway.
+type HostMapping struct {
+	Host string
+
+	// Exactly one of Service and Gateway is set.
+	Service string // requests are limited to the service's endpoints
+	Gateway string // requests are routed through the gateway
+}
+
+// Target returns the name of the service or gateway the host is mapped to.
+func (m HostMapping) Target() string {
+	if m.Service != "" {
+		return m.Service
+	}
+	return m.Gateway
+}
+
+// parseHosts parses the configured host mappings, resolving their targets in md.
+func parseHosts(cfg map[string]string, md *meta.Data) (map[string]HostMapping, error) {
+	hosts := make(map[string]HostMapping, len(cfg))
+	for host, target := range cfg {
+		if host == "" || strings.ContainsAny(host, ":/") {
+			return nil, fmt.Errorf("invalid local host %q: must be a hostname without a port", host)
+		}
+		m := HostMapping{Host: strings.ToLower(host)}
+		if slices.ContainsFunc(md.Svcs, func(s *meta.Service) bool { return s.Name == target }) {
+			m.Service = target
+		} else if slices.ContainsFunc(md.Gateways, func(g *meta.Gateway) bool { return g.EncoreName == target }) {
+			m.Gateway = target
+		} else {
+			return nil, fmt.Errorf("invalid local host %q: no service or gateway named %q", host, target)
+		}
+		hosts[m.Host] = m
+	}
+	return hosts, nil
+}
+
+// setHosts updates the host mappings to hosts, as parsed by parseHosts.
+func (h *hostRouter) setHosts(hosts map[string]HostMapping) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hosts = hosts
+}
+
+// lookup returns the mapping of the host the request was made to, if any.
+func (h *hostRouter) lookup(req *http.Request) (HostMapping, bool) {
+	host := bindaddr.SplitHost(req.Host)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m, ok := h.hosts[strings.ToLower(host)]
+	return m, ok
+}
+
+// list returns the host mappings, sorted by host.
+func (h *hostRouter) list() []HostMapping {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hosts := slices.Sorted(maps.Keys(h.hosts))
+	mappings := make([]HostMapping, len(hosts))
+	for i, host := range hosts {
+		mappings[i] = h.hosts[host]
+	}
+	return mappings
+}
+
+// gatewayHosts returns the hosts mapped to the given gateway.
+func (h *hostRouter) gatewayHosts(gateway string) []string {
+	var hosts []string
+	for _, m := range h.list() {
+		if m.Gateway == gateway {
+			hosts = append(hosts, m.Host)
+		}
+	}
+	return hosts
+}
+
+func serveHostNotFound(w http.ResponseWriter, m HostMapping) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    "not_found",
+		"message": fmt.Sprintf("endpoint not found: host %s only serves the endpoints of service %s", m.Host, m.Service),
+		"details": nil,
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"time"
 
 	"encore.dev/appruntime/exported/config"
@@ -15,6 +16,25 @@ import (
 // ServeHTTP implements http.Handler by forwarding the request to the currently running process.
 func (r *Run) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	proc := r.proc.Load().(*ProcGroup)
+	host, mapped := r.hosts.lookup(req)
+	if mapped && host.Service != "" {
+		if route, ok := proc.noopGW.LookupRoute(req); !ok || string(route.Dest) != host.Service {
+			serveHostNotFound(w, host)
+			return
+		}
+	}
+	if r.quotas.enabled() {
+		if route, ok := proc.noopGW.LookupRoute(req); ok {
+			if ok, retryAfter := r.quotas.allow(route.Endpoint, req, time.Now()); !ok {
+				serveQuotaExceeded(w, route.Endpoint, retryAfter)
+				return
+			}
+		}
+	}
+	if gw, ok := proc.Gateways[host.Gateway]; mapped && ok {
+		gw.ProxyReq(w, req)
+		return
+	}
 	proc.ProxyReq(w, req)
 }
 
@@ -36,4 +56,21 @@ func addAuthKeyToRequest(req *http.Request, authKey config.EncoreAuthKey) {
 	req.Header.Set("X-Encore-Auth", auth)
 }
 
+// authenticateProxied adds the auth key to out, a request proxied from in,
+// unless the test header is set or in was made from another machine.
+// Requests are authenticated as the platform so the local development
+// dashboard and tools can call internal endpoints.
+func (pg *ProcGroup) authenticateProxied(out, in *http.Request) {
+	if pg.devAccess && out.Header.Get(TestHeaderDisablePlatformAuth) == "" && isLocalRequest(in) {
+		addAuthKeyToRequest(out, pg.authKey)
+	}
+}
+
+// isLocalRequest reports whether req was made from the local machine.
+// It's based on the connection rather than the Host header, which the client controls.
+func isLocalRequest(req *http.Request) bool {
+	addr, err := netip.ParseAddrPort(req.RemoteAddr)
+	return err == nil && addr.Addr().Unmap().IsLoopback()
+}
+
 const TestHeaderDisablePlatformAuth = "X-Encore-Test-Disable-Platform-Auth"
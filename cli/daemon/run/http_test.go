@@ -0,0 +1,57 @@
+package run
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestAuthenticateProxied(t *testing.T) {
+	pg := &ProcGroup{
+		devAccess: true,
+		authKey:   config.EncoreAuthKey{KeyID: 1, Data: []byte("secret")},
+	}
+
+	tests := []struct {
+		name       string
+		host       string
+		remoteAddr string
+		testHeader bool
+		want       bool
+	}{
+		{name: "loopback_ipv4", host: "localhost:4000", remoteAddr: "127.0.0.1:51234", want: true},
+		{name: "loopback_ipv6", host: "localhost:4000", remoteAddr: "[::1]:51234", want: true},
+		{name: "loopback_ipv4_mapped", host: "localhost:4000", remoteAddr: "[::ffff:127.0.0.1]:51234", want: true},
+		{name: "lan_client", host: "192.168.1.10:4000", remoteAddr: "192.168.1.20:51234", want: false},
+		{name: "spoofed_host", host: "localhost:4000", remoteAddr: "192.168.1.20:51234", want: false},
+		{name: "spoofed_loopback_host", host: "127.0.0.1:4000", remoteAddr: "10.0.0.5:51234", want: false},
+		{name: "invalid_remote_addr", host: "localhost:4000", remoteAddr: "", want: false},
+		{name: "test_header", host: "localhost:4000", remoteAddr: "127.0.0.1:51234", testHeader: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := httptest.NewRequest("GET", "/svc.Endpoint", nil)
+			in.Host = tt.host
+			in.RemoteAddr = tt.remoteAddr
+			if tt.testHeader {
+				in.Header.Set(TestHeaderDisablePlatformAuth, "1")
+			}
+			out := in.Clone(in.Context())
+
+			pg.authenticateProxied(out, in)
+			if got := out.Header.Get("X-Encore-Auth") != ""; got != tt.want {
+				t.Errorf("authenticated = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Without dev access nothing is authenticated.
+	in := httptest.NewRequest("GET", "/svc.Endpoint", nil)
+	in.RemoteAddr = "127.0.0.1:51234"
+	out := in.Clone(in.Context())
+	(&ProcGroup{authKey: pg.authKey}).authenticateProxied(out, in)
+	if out.Header.Get("X-Encore-Auth") != "" {
+		t.Error("authenticated without dev access")
+	}
+}
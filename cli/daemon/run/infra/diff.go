@@ -0,0 +1,138 @@
+package infra
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/cli/daemon/sqldb"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// ChangeOp describes how an infrastructure resource declaration changed.
+type ChangeOp string
+
+const (
+	Added    ChangeOp = "added"
+	Removed  ChangeOp = "removed"
+	Modified ChangeOp = "modified"
+)
+
+// Change is a change to an infrastructure resource declaration
+// between two versions of an application.
+type Change struct {
+	Kind string // "topic", "subscription", "bucket", "cron job", "database" or "cache cluster"
+	Name string
+	Op   ChangeOp
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %s", c.Kind, c.Name, c.Op)
+}
+
+// Changes are the infrastructure changes between two versions of an application.
+type Changes []Change
+
+// Has reports whether there are changes to resources of the given kind.
+func (cs Changes) Has(kind string) bool {
+	return slices.ContainsFunc(cs, func(c Change) bool { return c.Kind == kind })
+}
+
+// Names returns the names of the resources of the given kind
+// that were added or modified.
+func (cs Changes) Names(kind string) []string {
+	var names []string
+	for _, c := range cs {
+		if c.Kind == kind && c.Op != Removed {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// Diff computes the infrastructure resource declarations that changed
+// between prev and next. Changes to documentation are ignored.
+func Diff(prev, next *meta.Data) Changes {
+	var cs Changes
+
+	subs := func(md *meta.Data) map[string]*meta.PubSubTopic_Subscription {
+		m := make(map[string]*meta.PubSubTopic_Subscription)
+		for _, t := range md.PubsubTopics {
+			for _, s := range t.Subscriptions {
+				m[t.Name+"/"+s.Name] = s
+			}
+		}
+		return m
+	}
+
+	cs = diffResources(cs, "topic",
+		byKey(prev.PubsubTopics, (*meta.PubSubTopic).GetName),
+		byKey(next.PubsubTopics, (*meta.PubSubTopic).GetName),
+		func(t *meta.PubSubTopic) {
+			t.Doc = nil
+			t.Publishers = nil
+			t.Subscriptions = nil
+		})
+	cs = diffResources(cs, "subscription", subs(prev), subs(next), nil)
+	cs = diffResources(cs, "bucket",
+		byKey(prev.Buckets, (*meta.Bucket).GetName),
+		byKey(next.Buckets, (*meta.Bucket).GetName),
+		func(b *meta.Bucket) { b.Doc = nil })
+	cs = diffResources(cs, "cron job",
+		byKey(prev.CronJobs, (*meta.CronJob).GetId),
+		byKey(next.CronJobs, (*meta.CronJob).GetId),
+		func(j *meta.CronJob) { j.Doc = nil })
+	cs = diffResources(cs, "database",
+		byKey(sqldb.Databases(prev), (*meta.SQLDatabase).GetName),
+		byKey(sqldb.Databases(next), (*meta.SQLDatabase).GetName),
+		func(db *meta.SQLDatabase) { db.Doc = nil })
+	cs = diffResources(cs, "cache cluster",
+		byKey(prev.CacheClusters, (*meta.CacheCluster).GetName),
+		byKey(next.CacheClusters, (*meta.CacheCluster).GetName),
+		func(c *meta.CacheCluster) {
+			c.Doc = ""
+			c.Keyspaces = nil
+		})
+	return cs
+}
+
+func byKey[T any](resources []T, key func(T) string) map[string]T {
+	m := make(map[string]T, len(resources))
+	for _, r := range resources {
+		m[key(r)] = r
+	}
+	return m
+}
+
+// diffResources appends the changes between the resources prev and next,
+// keyed by name, to cs. Resources are compared after clearing the fields
+// that don't matter for provisioning using normalize, if non-nil.
+func diffResources[T proto.Message](cs Changes, kind string, prev, next map[string]T, normalize func(T)) Changes {
+	norm := func(v T) T {
+		if normalize == nil {
+			return v
+		}
+		v = proto.Clone(v).(T)
+		normalize(v)
+		return v
+	}
+
+	var found []Change
+	for name, v := range next {
+		if p, ok := prev[name]; !ok {
+			found = append(found, Change{Kind: kind, Name: name, Op: Added})
+		} else if !proto.Equal(norm(p), norm(v)) {
+			found = append(found, Change{Kind: kind, Name: name, Op: Modified})
+		}
+	}
+	for name := range prev {
+		if _, ok := next[name]; !ok {
+			found = append(found, Change{Kind: kind, Name: name, Op: Removed})
+		}
+	}
+
+	slices.SortFunc(found, func(a, b Change) int { return cmp.Compare(a.Name, b.Name) })
+	return append(cs, found...)
+}
@@ -0,0 +1,84 @@
+package infra
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestDiff(t *testing.T) {
+	doc := func(s string) *string { return &s }
+	prev := &meta.Data{
+		PubsubTopics: []*meta.PubSubTopic{{
+			Name:          "orders",
+			Doc:           doc("Orders placed."),
+			Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "notify", AckDeadline: 30}},
+		}},
+		Buckets: []*meta.Bucket{
+			{Name: "avatars", Doc: doc("User avatars.")},
+			{Name: "exports"},
+		},
+		CronJobs: []*meta.CronJob{{Id: "cleanup", Schedule: "every 1h"}},
+		SqlDatabases: []*meta.SQLDatabase{{
+			Name:       "users",
+			Migrations: []*meta.DBMigration{{Filename: "1_init.up.sql", Number: 1}},
+		}},
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		c := qt.New(t)
+		c.Assert(Diff(prev, prev), qt.HasLen, 0)
+	})
+
+	t.Run("docs", func(t *testing.T) {
+		c := qt.New(t)
+		next := &meta.Data{
+			PubsubTopics: []*meta.PubSubTopic{{
+				Name:          "orders",
+				Doc:           doc("Orders placed by customers."),
+				Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "notify", AckDeadline: 30}},
+			}},
+			Buckets:      []*meta.Bucket{{Name: "avatars"}, {Name: "exports"}},
+			CronJobs:     []*meta.CronJob{{Id: "cleanup", Schedule: "every 1h", Doc: doc("Cleans up.")}},
+			SqlDatabases: prev.SqlDatabases,
+		}
+		c.Assert(Diff(prev, next), qt.HasLen, 0)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		c := qt.New(t)
+		next := &meta.Data{
+			PubsubTopics: []*meta.PubSubTopic{
+				{
+					Name:          "orders",
+					Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "notify", AckDeadline: 60}, {Name: "audit"}},
+				},
+				{Name: "invoices"},
+			},
+			Buckets:  []*meta.Bucket{{Name: "avatars", Public: true}},
+			CronJobs: []*meta.CronJob{{Id: "cleanup", Schedule: "every 2h"}},
+			SqlDatabases: []*meta.SQLDatabase{{
+				Name: "users",
+				Migrations: []*meta.DBMigration{
+					{Filename: "1_init.up.sql", Number: 1},
+					{Filename: "2_email.up.sql", Number: 2},
+				},
+			}},
+		}
+		changes := Diff(prev, next)
+		c.Assert(changes, qt.DeepEquals, Changes{
+			{Kind: "topic", Name: "invoices", Op: Added},
+			{Kind: "subscription", Name: "orders/audit", Op: Added},
+			{Kind: "subscription", Name: "orders/notify", Op: Modified},
+			{Kind: "bucket", Name: "avatars", Op: Modified},
+			{Kind: "bucket", Name: "exports", Op: Removed},
+			{Kind: "cron job", Name: "cleanup", Op: Modified},
+			{Kind: "database", Name: "users", Op: Modified},
+		})
+		c.Assert(changes.Has("bucket"), qt.IsTrue)
+		c.Assert(changes.Has("cache cluster"), qt.IsFalse)
+		c.Assert(changes.Names("bucket"), qt.DeepEquals, []string{"avatars"})
+	})
+}
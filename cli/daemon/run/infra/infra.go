@@ -3,6 +3,8 @@ package infra
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -16,10 +18,14 @@ import (
 	"encr.dev/cli/daemon/internal/debugflags"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/objects"
+	"encr.dev/cli/daemon/ports"
 	"encr.dev/cli/daemon/pubsub"
 	"encr.dev/cli/daemon/redis"
 	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/cli/daemon/sqldb/mysql"
+	"encr.dev/internal/conf"
 	"encr.dev/internal/optracker"
+	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/environ"
 	meta "encr.dev/proto/encore/parser/meta/v1"
 )
@@ -30,7 +36,9 @@ const (
 	PubSub  Type = "pubsub"
 	Cache   Type = "cache"
 	SQLDB   Type = "sqldb"
+	MySQL   Type = "mysql"
 	Objects Type = "objects"
+	SFTP    Type = "sftp"
 )
 
 const (
@@ -49,26 +57,32 @@ type ResourceManager struct {
 	app           *apps.Instance
 	dbProxyPort   int
 	sqlMgr        *sqldb.ClusterManager
+	mysqlMgr      *mysql.ClusterManager
 	objectsMgr    *objects.ClusterManager
 	publicBuckets *objects.PublicBucketServer
 	ns            *namespace.Namespace
 	environ       environ.Environ
+	ports         *ports.Registry
 	log           zerolog.Logger
 	forTests      bool
 
-	mutex   sync.Mutex
-	servers map[Type]Resource
+	mutex      sync.Mutex
+	servers    map[Type]Resource
+	migratedAt time.Time       // when database migrations last completed
+	unmigrated map[string]bool // databases whose migrations failed, by name
 }
 
-func NewResourceManager(app *apps.Instance, sqlMgr *sqldb.ClusterManager, objectsMgr *objects.ClusterManager, publicBuckets *objects.PublicBucketServer, ns *namespace.Namespace, environ environ.Environ, dbProxyPort int, forTests bool) *ResourceManager {
+func NewResourceManager(app *apps.Instance, sqlMgr *sqldb.ClusterManager, mysqlMgr *mysql.ClusterManager, objectsMgr *objects.ClusterManager, publicBuckets *objects.PublicBucketServer, ns *namespace.Namespace, environ environ.Environ, portsReg *ports.Registry, dbProxyPort int, forTests bool) *ResourceManager {
 	return &ResourceManager{
 		app:           app,
 		dbProxyPort:   dbProxyPort,
 		sqlMgr:        sqlMgr,
+		mysqlMgr:      mysqlMgr,
 		objectsMgr:    objectsMgr,
 		publicBuckets: publicBuckets,
 		ns:            ns,
 		environ:       environ,
+		ports:         portsReg,
 		forTests:      forTests,
 
 		servers: make(map[Type]Resource),
@@ -96,19 +110,128 @@ type Resource interface {
 // if they are not already running based on the given parse result
 func (rm *ResourceManager) StartRequiredServices(a *optracker.AsyncBuildJobs, md *meta.Data) {
 	if sqldb.IsUsed(md) && rm.GetSQLCluster() == nil {
-		a.Go("Creating PostgreSQL database cluster", true, 300*time.Millisecond, rm.StartSQLCluster(a, md))
+		a.Go("Creating PostgreSQL database cluster", true, 300*time.Millisecond, markStartErr(rm.StartSQLCluster(a, md)))
+	}
+
+	if len(sqldb.MySQLDatabases(md)) > 0 && rm.GetMySQLCluster() == nil {
+		a.Go("Creating MySQL database cluster", true, 300*time.Millisecond, markStartErr(rm.StartMySQLCluster(a, md)))
 	}
 
 	if pubsub.IsUsed(md) && rm.GetPubSub() == nil {
-		a.Go("Starting PubSub daemon", true, 250*time.Millisecond, rm.StartPubSub)
+		a.Go("Starting PubSub daemon", true, 250*time.Millisecond, markStartErr(rm.StartPubSub))
 	}
 
 	if redis.IsUsed(md) && rm.GetRedis() == nil {
-		a.Go("Starting Redis server", true, 250*time.Millisecond, rm.StartRedis)
+		a.Go("Starting Redis server", true, 250*time.Millisecond, markStartErr(rm.StartRedis))
 	}
 
 	if objects.IsUsed(md) && rm.GetObjects() == nil {
-		a.Go("Starting Object Storage server", true, 250*time.Millisecond, rm.StartObjects(md))
+		a.Go("Starting Object Storage server", true, 250*time.Millisecond, markStartErr(rm.StartObjects(md)))
+	}
+}
+
+// Reprovision updates the running services for a new version of the application,
+// provisioning only the resources that changed since the previous version.
+// Services that are not yet running but are now required are started.
+func (rm *ResourceManager) Reprovision(a *optracker.AsyncBuildJobs, changes Changes, md *meta.Data) {
+	rm.StartRequiredServices(a, md)
+
+	// Pub/Sub topics and subscriptions are created on demand by NSQ,
+	// and cache keyspaces don't need provisioning, so only buckets and
+	// databases need to be set up in already running services.
+	if srv := rm.GetObjects(); srv != nil && len(changes.Names("bucket")) > 0 {
+		a.Go("Creating object storage buckets", true, 250*time.Millisecond, markStartErr(func(ctx context.Context) error {
+			if err := srv.Initialize(md); err != nil {
+				return err
+			}
+			if sftp := rm.GetSFTP(); sftp != nil {
+				return sftp.Initialize(md)
+			}
+			return nil
+		}))
+	}
+
+	// Migrate the databases that changed, and retry the ones whose migrations failed.
+	if cluster := rm.GetSQLCluster(); cluster != nil && !rm.forTests {
+		if dbs := rm.databasesToMigrate(changes, sqldb.Databases(md)); len(dbs) > 0 {
+			a.Go("Running database migrations", true, 250*time.Millisecond, rm.migrate(cluster, dbs, "failed to setup db"))
+		}
+	}
+
+	if cluster := rm.GetMySQLCluster(); cluster != nil && !rm.forTests {
+		if dbs := rm.databasesToMigrate(changes, sqldb.MySQLDatabases(md)); len(dbs) > 0 {
+			a.Go("Running MySQL database migrations", true, 250*time.Millisecond, rm.migrate(cluster, dbs, "failed to setup mysql db"))
+		}
+	}
+}
+
+// databasesToMigrate returns the databases among allDBs that changed
+// or whose migrations failed.
+func (rm *ResourceManager) databasesToMigrate(changes Changes, allDBs []*meta.SQLDatabase) []*meta.SQLDatabase {
+	changed := changes.Names("database")
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+	return slices.DeleteFunc(slices.Clone(allDBs), func(db *meta.SQLDatabase) bool {
+		return !slices.Contains(changed, db.Name) && !rm.unmigrated[db.Name]
+	})
+}
+
+// migrator applies database migrations, like a PostgreSQL or MySQL cluster.
+type migrator interface {
+	SetupAndMigrate(ctx context.Context, appRoot string, dbs []*meta.SQLDatabase) error
+}
+
+// migrate returns a build job applying the migrations of dbs in cluster,
+// recording whether they were applied so failed migrations are retried on reload.
+func (rm *ResourceManager) migrate(cluster migrator, dbs []*meta.SQLDatabase, errMsg string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		err := cluster.SetupAndMigrate(ctx, rm.app.Root(), dbs)
+		rm.recordMigrations(dbs, err)
+		if err != nil {
+			rm.log.Error().Err(err).Msg(errMsg)
+			return err
+		}
+		return nil
+	}
+}
+
+// recordMigrations records the result of applying the migrations of dbs.
+func (rm *ResourceManager) recordMigrations(dbs []*meta.SQLDatabase, err error) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+	if rm.unmigrated == nil {
+		rm.unmigrated = make(map[string]bool)
+	}
+	for _, db := range dbs {
+		if err != nil {
+			rm.unmigrated[db.Name] = true
+		} else {
+			delete(rm.unmigrated, db.Name)
+		}
+	}
+	if err == nil {
+		rm.migratedAt = time.Now()
+	}
+}
+
+// MigratedAt returns when database migrations last completed,
+// or the zero time if they haven't been run.
+func (rm *ResourceManager) MigratedAt() time.Time {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+	return rm.migratedAt
+}
+
+// ErrStart marks errors from starting infrastructure resources.
+var ErrStart = errors.New("infrastructure failed to start")
+
+// markStartErr marks the errors returned by start with ErrStart.
+func markStartErr(start func(context.Context) error) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if err := start(ctx); err != nil {
+			return errors.Mark(err, ErrStart)
+		}
+		return nil
 	}
 }
 
@@ -190,10 +313,51 @@ func (rm *ResourceManager) StartObjects(md *meta.Data) func(context.Context) err
 		rm.mutex.Lock()
 		rm.servers[Objects] = srv
 		rm.mutex.Unlock()
+
+		if !rm.forTests {
+			return rm.startSFTP(srv, md)
+		}
 		return nil
 	}
 }
 
+// startSFTP starts the SFTP server mapping directories to the buckets
+// of srv, if the app configures one.
+func (rm *ResourceManager) startSFTP(srv *objects.Server, md *meta.Data) error {
+	cfg, err := appfile.LocalSFTPConfig(rm.app.Root())
+	if err != nil || cfg == nil {
+		return err
+	}
+	dir, err := conf.Dir()
+	if err != nil {
+		return err
+	}
+	sftp, err := objects.NewSFTPServer(srv, cfg, rm.app.Root(), filepath.Join(dir, "sftp", "host_key"))
+	if err != nil {
+		return err
+	} else if err := sftp.Initialize(md); err != nil {
+		return err
+	} else if err := sftp.Start(context.Background(), rm.ports, rm.app.PlatformOrLocalID()); err != nil {
+		return err
+	}
+
+	rm.mutex.Lock()
+	rm.servers[SFTP] = sftp
+	rm.mutex.Unlock()
+	return nil
+}
+
+// GetSFTP returns the SFTP server if it is running otherwise it returns nil
+func (rm *ResourceManager) GetSFTP() *objects.SFTPServer {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	if srv, found := rm.servers[SFTP]; found {
+		return srv.(*objects.SFTPServer)
+	}
+	return nil
+}
+
 // GetObjects returns the Object Storage server if it is running otherwise it returns nil
 func (rm *ResourceManager) GetObjects() *objects.Server {
 	rm.mutex.Lock()
@@ -245,20 +409,69 @@ func (rm *ResourceManager) StartSQLCluster(a *optracker.AsyncBuildJobs, md *meta
 				return nil
 			})
 		} else {
-			a.Go("Running database migrations", true, 250*time.Millisecond, func(ctx context.Context) error {
-				err := cluster.SetupAndMigrate(ctx, rm.app.Root(), md.SqlDatabases)
+			a.Go("Running database migrations", true, 250*time.Millisecond, rm.migrate(cluster, sqldb.Databases(md), "failed to setup db"))
+		}
+
+		return nil
+	}
+}
+
+// StartMySQLCluster starts the MySQL cluster for the databases using the MySQL engine.
+func (rm *ResourceManager) StartMySQLCluster(a *optracker.AsyncBuildJobs, md *meta.Data) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if rm.mysqlMgr == nil {
+			return fmt.Errorf("StartMySQLCluster: no MySQL cluster manager provided")
+		}
+
+		typ := sqldb.Run
+		if rm.forTests {
+			typ = sqldb.Test
+		}
+
+		if err := rm.mysqlMgr.Ready(); err != nil {
+			return err
+		}
+
+		cluster := rm.mysqlMgr.Create(&sqldb.CreateParams{
+			ClusterID: sqldb.GetClusterID(rm.app, typ, rm.ns),
+			Memfs:     typ.Memfs(),
+		})
+		if err := cluster.Start(ctx, a.Tracker()); err != nil {
+			return errors.Wrap(err, "failed to start mysql cluster")
+		}
+
+		rm.mutex.Lock()
+		rm.servers[MySQL] = cluster
+		rm.mutex.Unlock()
+
+		dbs := sqldb.MySQLDatabases(md)
+		if rm.forTests {
+			a.Go("Recreating MySQL databases", true, 250*time.Millisecond, func(ctx context.Context) error {
+				err := cluster.Recreate(ctx, rm.app.Root(), dbs)
 				if err != nil {
-					rm.log.Error().Err(err).Msg("failed to setup db")
+					rm.log.Error().Err(err).Msg("failed to recreate mysql db")
 					return err
 				}
 				return nil
 			})
+		} else {
+			a.Go("Running MySQL database migrations", true, 250*time.Millisecond, rm.migrate(cluster, dbs, "failed to setup mysql db"))
 		}
-
 		return nil
 	}
 }
 
+// GetMySQLCluster returns the MySQL cluster if it is running otherwise it returns nil
+func (rm *ResourceManager) GetMySQLCluster() *mysql.Cluster {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	if cluster, found := rm.servers[MySQL]; found {
+		return cluster.(*mysql.Cluster)
+	}
+	return nil
+}
+
 // GetSQLCluster returns the SQL cluster
 func (rm *ResourceManager) GetSQLCluster() *sqldb.Cluster {
 	rm.mutex.Lock()
@@ -286,7 +499,7 @@ func (rm *ResourceManager) UpdateConfig(cfg *config.Runtime, md *meta.Data, dbPr
 		serverID := len(cfg.SQLServers)
 		cfg.SQLServers = append(cfg.SQLServers, srv)
 
-		for _, db := range md.SqlDatabases {
+		for _, db := range sqldb.Databases(md) {
 			cfg.SQLDatabases = append(cfg.SQLDatabases, &config.SQLDatabase{
 				ServerID:     serverID,
 				EncoreName:   db.Name,
@@ -302,6 +515,43 @@ func (rm *ResourceManager) UpdateConfig(cfg *config.Runtime, md *meta.Data, dbPr
 		for _, db := range cfg.SQLDatabases {
 			db.MaxConnections = maxConns
 		}
+
+		// Search indexes are stored in the search database using Postgres full-text search.
+		if len(md.SearchIndexes) > 0 {
+			providerID := len(cfg.SearchProviders)
+			cfg.SearchProviders = append(cfg.SearchProviders, &config.SearchProvider{
+				Postgres: &config.PostgresSearchProvider{Database: sqldb.SearchDatabase},
+			})
+			if cfg.SearchIndexes == nil {
+				cfg.SearchIndexes = make(map[string]*config.SearchIndex)
+			}
+			for _, idx := range md.SearchIndexes {
+				cfg.SearchIndexes[idx.Name] = &config.SearchIndex{
+					ProviderID: providerID,
+					EncoreName: idx.Name,
+					CloudName:  idx.Name,
+				}
+			}
+		}
+	}
+
+	if cluster := rm.GetMySQLCluster(); cluster != nil {
+		// The database proxy only speaks the Postgres protocol,
+		// so connect to the MySQL cluster directly.
+		serverID := len(cfg.SQLServers)
+		cfg.SQLServers = append(cfg.SQLServers, &config.SQLServer{
+			Host:   cluster.Host(),
+			Engine: "mysql",
+		})
+		for _, db := range sqldb.MySQLDatabases(md) {
+			cfg.SQLDatabases = append(cfg.SQLDatabases, &config.SQLDatabase{
+				ServerID:     serverID,
+				EncoreName:   db.Name,
+				DatabaseName: db.Name,
+				User:         "encore",
+				Password:     cluster.Password,
+			})
+		}
 	}
 
 	if nsq := rm.GetPubSub(); nsq != nil {
@@ -386,8 +636,30 @@ func (rm *ResourceManager) SQLServerConfig() (config.SQLServer, error) {
 	return srvCfg, nil
 }
 
+// MySQLServerConfig returns the server configuration for databases using the MySQL engine.
+func (rm *ResourceManager) MySQLServerConfig() (config.SQLServer, error) {
+	cluster := rm.GetMySQLCluster()
+	if cluster == nil {
+		return config.SQLServer{}, errors.New("no MySQL cluster found")
+	}
+	return config.SQLServer{Host: cluster.Host(), Engine: "mysql"}, nil
+}
+
 // SQLDatabaseConfig returns the SQL server and database configuration for the given database.
 func (rm *ResourceManager) SQLDatabaseConfig(db *meta.SQLDatabase) (config.SQLDatabase, error) {
+	if sqldb.IsMySQL(db) {
+		cluster := rm.GetMySQLCluster()
+		if cluster == nil {
+			return config.SQLDatabase{}, errors.New("no MySQL cluster found")
+		}
+		return config.SQLDatabase{
+			EncoreName:   db.Name,
+			DatabaseName: db.Name,
+			User:         "encore",
+			Password:     cluster.Password,
+		}, nil
+	}
+
 	cluster := rm.GetSQLCluster()
 	if cluster == nil {
 		return config.SQLDatabase{}, errors.New("no SQL cluster found")
@@ -403,6 +675,11 @@ func (rm *ResourceManager) SQLDatabaseConfig(db *meta.SQLDatabase) (config.SQLDa
 		User:         user,
 		Password:     cluster.Password,
 	}
+	// Services granted read access connect with the read-only role.
+	if len(db.ReadAccess) > 0 && user != "encore" {
+		dbCfg.ReadOnlyUser = "encore-read"
+		dbCfg.ReadOnlyPassword = cluster.Password
+	}
 
 	return dbCfg, nil
 }
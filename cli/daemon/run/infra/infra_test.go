@@ -0,0 +1,69 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/daemon/apps"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// fakeMigrator records the databases it migrates, failing while err is set.
+type fakeMigrator struct {
+	err      error
+	migrated [][]string
+}
+
+func (m *fakeMigrator) SetupAndMigrate(_ context.Context, _ string, dbs []*meta.SQLDatabase) error {
+	var names []string
+	for _, db := range dbs {
+		names = append(names, db.Name)
+	}
+	m.migrated = append(m.migrated, names)
+	return m.err
+}
+
+func TestMigrationRetry(t *testing.T) {
+	c := qt.New(t)
+	app := apps.NewInstance(t.TempDir(), "migrate-test", "")
+	rm := NewResourceManager(app, nil, nil, nil, nil, nil, nil, nil, 0, false)
+	dbs := []*meta.SQLDatabase{{Name: "orders"}, {Name: "users"}}
+	names := func(dbs []*meta.SQLDatabase) []string {
+		var names []string
+		for _, db := range dbs {
+			names = append(names, db.Name)
+		}
+		return names
+	}
+
+	// Only the changed databases are migrated on reload.
+	changes := Changes{{Kind: "database", Name: "users", Op: Modified}}
+	c.Assert(names(rm.databasesToMigrate(changes, dbs)), qt.DeepEquals, []string{"users"})
+	c.Assert(rm.databasesToMigrate(nil, dbs), qt.HasLen, 0)
+
+	// Failed migrations are retried on later reloads, even without changes.
+	m := &fakeMigrator{err: errors.New("syntax error at line 1")}
+	err := rm.migrate(m, rm.databasesToMigrate(changes, dbs), "failed")(context.Background())
+	c.Assert(err, qt.ErrorMatches, "syntax error.*")
+	c.Assert(rm.MigratedAt().IsZero(), qt.IsTrue)
+	c.Assert(names(rm.databasesToMigrate(nil, dbs)), qt.DeepEquals, []string{"users"})
+
+	changes = Changes{{Kind: "database", Name: "orders", Op: Modified}}
+	c.Assert(names(rm.databasesToMigrate(changes, dbs)), qt.DeepEquals, []string{"orders", "users"})
+
+	// Once applied, they're no longer retried.
+	m.err = nil
+	err = rm.migrate(m, rm.databasesToMigrate(nil, dbs), "failed")(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(rm.MigratedAt().IsZero(), qt.IsFalse)
+	c.Assert(rm.databasesToMigrate(nil, dbs), qt.HasLen, 0)
+	c.Assert(m.migrated, qt.DeepEquals, [][]string{{"users"}, {"users"}})
+
+	// Databases that were removed aren't retried.
+	m.err = errors.New("failed")
+	_ = rm.migrate(m, dbs[:1], "failed")(context.Background())
+	c.Assert(rm.databasesToMigrate(nil, dbs[1:]), qt.HasLen, 0)
+}
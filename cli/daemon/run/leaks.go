@@ -0,0 +1,233 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dustin/go-humanize"
+	"github.com/logrusorgru/aurora/v3"
+
+	"encr.dev/cli/daemon/internal/heapprof"
+	"encr.dev/internal/userconfig"
+)
+
+// errHeapProfileUnsupported is reported by Proc.HeapProfile
+// for processes that don't serve heap profiles.
+var errHeapProfileUnsupported = errors.New("the process does not support heap profiles (only Go apps do)")
+
+// HeapProfile returns a heap profile of the process, in the text format
+// written by runtime/pprof with debug=1, taken after a garbage collection.
+func (p *Proc) HeapProfile(ctx context.Context) ([]byte, error) {
+	url := fmt.Sprintf("http://%s/__encore/debug/heap", p.listenAddr)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	addAuthKeyToRequest(req, p.group.authKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to reach process")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errHeapProfileUnsupported
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+const (
+	// minLeakGrowth is how much the heap of a process must grow at least to be
+	// reported, so small heaps growing by a large percentage aren't reported.
+	minLeakGrowth = 8 << 20
+
+	// maxLeakSites is the number of allocation sites included in leak warnings.
+	maxLeakSites = 5
+)
+
+// leakDetector monitors the heaps of the processes of a run for sustained growth.
+type leakDetector struct {
+	interval  time.Duration // how often heaps are measured
+	threshold float64       // relative growth that is reported, like 0.5 for 50%
+	heaps     map[string]*procHeap
+}
+
+// procHeap tracks the heap of a process.
+type procHeap struct {
+	proc     *Proc
+	base     *heapprof.Profile // first measurement
+	baseData []byte            // the raw profile of base
+	baseAt   time.Time
+	last     uint64 // heap size when last reported, or of base
+}
+
+// loadLeakDetector returns a leakDetector for the app at appRoot configured
+// in the user config, or nil if leak detection is disabled.
+func loadLeakDetector(appRoot string) (*leakDetector, error) {
+	user, err := userconfig.ForApp(appRoot).Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load user config")
+	} else if !user.RunLeaksDetect {
+		return nil, nil
+	}
+
+	interval, err := time.ParseDuration(user.RunLeaksInterval)
+	if err != nil || interval < 10*time.Second {
+		return nil, errors.Newf(`invalid user config run.leaks.interval %q: must be a duration of at least 10s, like "5m"`, user.RunLeaksInterval)
+	}
+	if user.RunLeaksThreshold <= 0 {
+		return nil, errors.Newf("invalid user config run.leaks.threshold %d: must be a positive percentage", user.RunLeaksThreshold)
+	}
+	return &leakDetector{
+		interval:  interval,
+		threshold: float64(user.RunLeaksThreshold) / 100,
+		heaps:     make(map[string]*procHeap),
+	}, nil
+}
+
+// monitorLeaks measures the heaps of the processes of the run every interval
+// until the run exits, warning about processes whose heap keeps growing.
+func (r *Run) monitorLeaks(d *leakDetector) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		pg := r.ProcGroup()
+		if pg == nil || r.StaleBuild() {
+			continue
+		}
+		if err := r.measureHeaps(d, pg); errors.Is(err, errHeapProfileUnsupported) {
+			r.Mgr.RunStderr(r, []byte(aurora.Gray(16, "note: leak detection is disabled: "+err.Error()).String()+"\n"))
+			return
+		}
+	}
+}
+
+// measureHeaps measures the heaps of the processes of pg,
+// and warns about those that grew beyond the threshold.
+func (r *Run) measureHeaps(d *leakDetector, pg *ProcGroup) error {
+	for p, names := range procNames(pg) {
+		ctx, cancel := context.WithTimeout(r.ctx, 30*time.Second)
+		data, err := p.HeapProfile(ctx)
+		cancel()
+		if errors.Is(err, errHeapProfileUnsupported) {
+			return err
+		} else if err != nil {
+			r.log.Debug().Err(err).Str("proc", p.name).Msg("unable to take heap profile")
+			continue
+		}
+		prof, err := heapprof.Parse(data, pg.Meta.ModulePath)
+		if err != nil {
+			r.log.Error().Err(err).Str("proc", p.name).Msg("unable to parse heap profile")
+			continue
+		}
+
+		h := d.heaps[p.name]
+		if h == nil || h.proc != p {
+			// The process is new, or was restarted; start over.
+			d.heaps[p.name] = &procHeap{proc: p, base: prof, baseData: data, baseAt: time.Now(), last: prof.HeapAlloc}
+			continue
+		}
+		if prof.HeapAlloc < h.last {
+			continue
+		}
+		growth := prof.HeapAlloc - h.last
+		if growth < minLeakGrowth || float64(growth) < d.threshold*float64(h.last) {
+			continue
+		}
+		h.last = prof.HeapAlloc
+		r.reportLeak(h, names, prof, data)
+	}
+	return nil
+}
+
+// reportLeak warns that the heap of the process running the named services
+// and gateways grew, listing the allocation sites that grew the most.
+// The heap profiles and the full report are written to the app's cache directory.
+func (r *Run) reportLeak(h *procHeap, names []string, cur *heapprof.Profile, curData []byte) {
+	growth := float64(cur.HeapAlloc-h.base.HeapAlloc) / float64(max(h.base.HeapAlloc, 1)) * 100
+	var b strings.Builder
+	b.WriteString("\n" + aurora.Yellow(fmt.Sprintf(
+		"warning: suspected memory leak in %s: the heap grew from %s to %s (+%.0f%%) in %s",
+		strings.Join(names, ", "), humanize.IBytes(h.base.HeapAlloc), humanize.IBytes(cur.HeapAlloc),
+		growth, time.Since(h.baseAt).Round(time.Minute))).String() + "\n")
+
+	sites := heapprof.Compare(h.base, cur)
+	if len(sites) > 0 {
+		b.WriteString("allocation sites that grew the most:\n")
+		for _, g := range sites[:min(len(sites), maxLeakSites)] {
+			file := g.Site.File
+			if rel, err := filepath.Rel(r.App.Root(), file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+			fmt.Fprintf(&b, "  +%-10s %s (%s:%d)\n", humanize.IBytes(uint64(g.Bytes())), g.Site.Func, file, g.Site.Line)
+		}
+	}
+
+	if dir, err := r.writeLeakReport(h, cur, curData); err != nil {
+		r.log.Error().Err(err).Msg("unable to write leak report")
+	} else {
+		b.WriteString(aurora.Gray(16, "note: the heap profiles and full report are in "+dir).String() + "\n")
+	}
+	b.WriteString("\n")
+	r.Mgr.RunStderr(r, []byte(b.String()))
+}
+
+// writeLeakReport writes the baseline and current heap profiles of a process
+// and a report comparing them to a new directory, and returns its path.
+func (r *Run) writeLeakReport(h *procHeap, cur *heapprof.Profile, curData []byte) (string, error) {
+	cacheDir, err := r.App.CachePath()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cacheDir, "leaks", r.ID, fmt.Sprintf("%s-%s", h.proc.name, time.Now().Format("20060102-150405")))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "process %s, measured from %s to %s\n",
+		h.proc.name, h.baseAt.Format(time.RFC3339), time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&report, "explore the growth with: go tool pprof -base base.heap current.heap\n\n")
+	_ = heapprof.WriteReport(&report, h.base, cur, 0)
+
+	for name, data := range map[string][]byte{
+		"base.heap":    h.baseData,
+		"current.heap": curData,
+		"report.txt":   []byte(report.String()),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return "", errors.WithStack(err)
+		}
+	}
+	return dir, nil
+}
+
+// procNames returns the started processes of pg and the
+// services and gateways each of them runs, sorted by name.
+func procNames(pg *ProcGroup) map[*Proc][]string {
+	names := make(map[*Proc][]string)
+	for _, byName := range []map[string]*Proc{pg.Gateways, pg.Services} {
+		for _, name := range slices.Sorted(maps.Keys(byName)) {
+			if p := byName[name]; p.Started.Load() {
+				names[p] = append(names[p], name)
+			}
+		}
+	}
+	return names
+}
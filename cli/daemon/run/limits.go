@@ -0,0 +1,131 @@
+package run
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dustin/go-humanize"
+
+	"encr.dev/internal/userconfig"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// procLimits are the resource limits of a process.
+// The zero value means no limits.
+type procLimits struct {
+	CPU    float64 // CPU limit in cores, or 0 for no limit
+	Memory uint64  // memory limit in bytes, or 0 for no limit
+}
+
+func (l procLimits) isZero() bool {
+	return l == procLimits{}
+}
+
+func (l procLimits) String() string {
+	var parts []string
+	if l.CPU > 0 {
+		parts = append(parts, fmt.Sprintf("cpu=%g", l.CPU))
+	}
+	if l.Memory > 0 {
+		parts = append(parts, "memory="+humanize.IBytes(l.Memory))
+	}
+	return strings.Join(parts, " ")
+}
+
+// limitsConfig is the user's configuration of the resource limits
+// of the processes started by the run manager.
+type limitsConfig struct {
+	def    procLimits            // limits of processes without specific limits
+	byName map[string]procLimits // limits of specific services and gateways
+}
+
+// loadLimitsConfig loads the resource limits from the user's configuration
+// of the app at appRoot, checking that the services and gateways they
+// refer to exist in md.
+func loadLimitsConfig(appRoot string, md *meta.Data) (*limitsConfig, error) {
+	user, err := userconfig.ForApp(appRoot).Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load user config")
+	}
+
+	cfg := &limitsConfig{byName: make(map[string]procLimits)}
+	err = parseLimits(cfg, "run.limits.cpu", user.RunLimitsCPU, func(l *procLimits, val string) error {
+		cpu, err := strconv.ParseFloat(val, 64)
+		if err != nil || cpu <= 0 || math.IsInf(cpu, 0) {
+			return errors.New("must be a positive number of cores")
+		}
+		l.CPU = cpu
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = parseLimits(cfg, "run.limits.memory", user.RunLimitsMemory, func(l *procLimits, val string) error {
+		mem, err := humanize.ParseBytes(val)
+		if err != nil || mem == 0 {
+			return errors.New(`must be a positive size, like "512MiB"`)
+		}
+		l.Memory = mem
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range cfg.byName {
+		if !slices.ContainsFunc(md.Svcs, func(s *meta.Service) bool { return s.Name == name }) &&
+			!slices.ContainsFunc(md.Gateways, func(g *meta.Gateway) bool { return g.EncoreName == name }) {
+			return nil, errors.Newf("invalid user config run.limits: no service or gateway named %q", name)
+		}
+	}
+	return cfg, nil
+}
+
+// parseLimits parses the comma-separated limits of the user config key,
+// which are either a default limit or "name=limit" entries,
+// setting them on cfg with set.
+func parseLimits(cfg *limitsConfig, key, val string, set func(l *procLimits, val string) error) error {
+	for _, entry := range strings.Split(val, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		name, limit, ok := strings.Cut(entry, "=")
+		if !ok {
+			limit, name = name, ""
+		}
+		name, limit = strings.TrimSpace(name), strings.TrimSpace(limit)
+
+		l := cfg.def
+		if name != "" {
+			l = cfg.byName[name]
+		}
+		if err := set(&l, limit); err != nil {
+			return errors.Newf("invalid limit %q in user config %s: %v", entry, key, err)
+		}
+		if name != "" {
+			cfg.byName[name] = l
+		} else {
+			cfg.def = l
+		}
+	}
+	return nil
+}
+
+// forProc returns the limits of the process running the named
+// service or gateway, falling back to the default limits.
+func (cfg *limitsConfig) forProc(name string) procLimits {
+	l := cfg.def
+	if specific, ok := cfg.byName[name]; ok {
+		if specific.CPU > 0 {
+			l.CPU = specific.CPU
+		}
+		if specific.Memory > 0 {
+			l.Memory = specific.Memory
+		}
+	}
+	return l
+}
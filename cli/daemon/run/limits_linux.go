@@ -0,0 +1,155 @@
+package run
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/cockroachdb/errors"
+)
+
+// cgroupRoot is where the cgroup v2 hierarchy is mounted.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cpuPeriod is the cgroup CPU period, in microseconds.
+const cpuPeriod = 100000
+
+// procLimiter limits the resources of a process with a cgroup.
+type procLimiter struct {
+	dir string   // the cgroup directory
+	fd  *os.File // the open cgroup directory, until the process has started
+}
+
+// newProcLimiter creates a cgroup with the limits l for the process
+// to be started by cmd. The name must be unique among running processes.
+func newProcLimiter(name string, cmd *exec.Cmd, l procLimits) (*procLimiter, error) {
+	parent, err := limitsCgroup()
+	if err != nil {
+		return nil, err
+	}
+	return createProcLimiter(filepath.Join(parent, name), cmd, l)
+}
+
+// createProcLimiter creates the cgroup dir with the limits l
+// for the process to be started by cmd.
+func createProcLimiter(dir string, cmd *exec.Cmd, l procLimits) (*procLimiter, error) {
+	if err := os.Mkdir(dir, 0o755); err != nil && !errors.Is(err, os.ErrExist) {
+		return nil, errors.Wrap(err, "create cgroup")
+	}
+	// Reset the limits in case the cgroup is left from a previous run.
+	cpuMax, memMax := "max", "max"
+	if l.CPU > 0 {
+		cpuMax = fmt.Sprintf("%d %d", int64(l.CPU*cpuPeriod), cpuPeriod)
+	}
+	if l.Memory > 0 {
+		memMax = strconv.FormatUint(l.Memory, 10)
+	}
+	if err := writeCgroupFile(dir, "cpu.max", cpuMax); err != nil {
+		return nil, err
+	}
+	if err := writeCgroupFile(dir, "memory.max", memMax); err != nil {
+		return nil, err
+	}
+
+	fd, err := os.Open(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "open cgroup")
+	}
+	// Start the process directly in the cgroup, so all its memory is accounted for.
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(fd.Fd())
+	return &procLimiter{dir: dir, fd: fd}, nil
+}
+
+// started is called once the process has been started.
+func (pl *procLimiter) started(*os.Process) error {
+	return pl.fd.Close()
+}
+
+// oomKilled reports whether the process was killed for exceeding its memory limit.
+func (pl *procLimiter) oomKilled() bool {
+	data, err := os.ReadFile(filepath.Join(pl.dir, "memory.events"))
+	if err != nil {
+		return false
+	}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		if n, ok := strings.CutPrefix(s.Text(), "oom_kill "); ok {
+			return n != "0"
+		}
+	}
+	return false
+}
+
+// release removes the cgroup once the process has exited.
+func (pl *procLimiter) release() {
+	_ = pl.fd.Close()
+	_ = os.Remove(pl.dir)
+}
+
+var limitsCgroupOnce struct {
+	sync.Once
+	dir string
+	err error
+}
+
+// limitsCgroup returns the cgroup under which the cgroups of the processes
+// are created, which is the daemon's own cgroup.
+//
+// As only leaf cgroups can hold processes once controllers are enabled
+// for their children, the daemon is moved into a child cgroup of its own.
+// This requires the daemon's cgroup to be delegated to the user and to not
+// hold other processes, as is the case when the daemon runs as its own
+// systemd unit, like with `systemd-run --user -p Delegate=yes encore daemon -f`.
+func limitsCgroup() (string, error) {
+	once := &limitsCgroupOnce
+	once.Do(func() {
+		once.dir, once.err = setupLimitsCgroup()
+	})
+	return once.dir, once.err
+}
+
+func setupLimitsCgroup() (string, error) {
+	data, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", errors.Wrap(err, "read cgroup")
+	}
+	var own string
+	for _, line := range strings.Split(string(data), "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			own = filepath.Join(cgroupRoot, path)
+		}
+	}
+	if own == "" {
+		return "", errors.New("cgroup v2 is not available")
+	}
+
+	daemon := filepath.Join(own, "daemon")
+	if err := os.Mkdir(daemon, 0o755); err != nil && !errors.Is(err, os.ErrExist) {
+		return "", errors.Wrapf(err, "create cgroup (is the cgroup %s delegated to the user?)", own)
+	}
+	if err := writeCgroupFile(daemon, "cgroup.procs", strconv.Itoa(os.Getpid())); err != nil {
+		return "", err
+	}
+	if err := writeCgroupFile(own, "cgroup.subtree_control", "+cpu +memory"); err != nil {
+		return "", errors.Wrapf(err, "enable cgroup controllers (does the cgroup %s hold other processes?)", own)
+	}
+	return own, nil
+}
+
+func writeCgroupFile(dir, name, val string) error {
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(val), 0); err != nil {
+		return errors.Wrapf(err, "write %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package run
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func readCgroupFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// fakeCgroup creates a directory with the interface files of a cgroup,
+// which the kernel creates along with a cgroup.
+func fakeCgroup(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "proc-search")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"cpu.max", "memory.max", "memory.events"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCreateProcLimiter(t *testing.T) {
+	dir := fakeCgroup(t)
+	cmd := exec.Command("true")
+
+	pl, err := createProcLimiter(dir, cmd, procLimits{CPU: 1.5, Memory: 512 << 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readCgroupFile(t, dir, "cpu.max"), "150000 100000"; got != want {
+		t.Errorf("cpu.max = %q, want %q", got, want)
+	}
+	if got, want := readCgroupFile(t, dir, "memory.max"), "536870912"; got != want {
+		t.Errorf("memory.max = %q, want %q", got, want)
+	}
+	// The process is started in the cgroup.
+	if attr := cmd.SysProcAttr; attr == nil || !attr.UseCgroupFD || attr.CgroupFD != int(pl.fd.Fd()) {
+		t.Errorf("SysProcAttr = %+v, want the process to start in the cgroup", attr)
+	}
+	if err := pl.started(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A cgroup left from a previous run has its limits reset.
+	if _, err := createProcLimiter(dir, exec.Command("true"), procLimits{Memory: 1 << 30}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readCgroupFile(t, dir, "cpu.max"), "max"; got != want {
+		t.Errorf("cpu.max after reset = %q, want %q", got, want)
+	}
+	if got, want := readCgroupFile(t, dir, "memory.max"), "1073741824"; got != want {
+		t.Errorf("memory.max after reset = %q, want %q", got, want)
+	}
+}
+
+func TestProcLimiterOOMKilled(t *testing.T) {
+	tests := []struct {
+		name   string
+		events string // the cgroup's memory.events, if any
+		want   bool
+	}{
+		{name: "no_events", want: false},
+		{name: "not_killed", events: "low 0\nhigh 0\nmax 3\noom 1\noom_kill 0\n", want: false},
+		{name: "killed", events: "low 0\nhigh 0\nmax 12\noom 1\noom_kill 1\n", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pl := &procLimiter{dir: fakeCgroup(t)}
+			if tt.events != "" {
+				if err := os.WriteFile(filepath.Join(pl.dir, "memory.events"), []byte(tt.events), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := pl.oomKilled(); got != tt.want {
+				t.Errorf("oomKilled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !linux && !windows
+
+package run
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/cockroachdb/errors"
+)
+
+// procLimiter is not supported on this platform.
+type procLimiter struct{}
+
+func newProcLimiter(name string, cmd *exec.Cmd, l procLimits) (*procLimiter, error) {
+	return nil, errors.New("resource limits are only supported on Linux and Windows")
+}
+
+func (pl *procLimiter) started(*os.Process) error { return nil }
+func (pl *procLimiter) oomKilled() bool           { return false }
+func (pl *procLimiter) release()                  {}
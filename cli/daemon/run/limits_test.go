@@ -0,0 +1,116 @@
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestLoadLimitsConfig(t *testing.T) {
+	md := &meta.Data{
+		Svcs:     []*meta.Service{{Name: "search"}, {Name: "users"}},
+		Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+	}
+
+	tests := []struct {
+		name    string
+		config  string                // the app's user config
+		want    map[string]procLimits // limits by process name
+		wantErr string
+	}{
+		{
+			name: "none",
+			want: map[string]procLimits{"search": {}, "api-gateway": {}},
+		},
+		{
+			name:   "default",
+			config: "run.limits.cpu = \"1.5\"\nrun.limits.memory = \"512MiB\"\n",
+			want: map[string]procLimits{
+				"search":      {CPU: 1.5, Memory: 512 << 20},
+				"api-gateway": {CPU: 1.5, Memory: 512 << 20},
+			},
+		},
+		{
+			// Specific limits override the default limits they set,
+			// and the default limits apply otherwise.
+			name:   "specific",
+			config: "run.limits.cpu = \"1, search=2\"\nrun.limits.memory = \"api-gateway=256MiB\"\n",
+			want: map[string]procLimits{
+				"search":      {CPU: 2},
+				"users":       {CPU: 1},
+				"api-gateway": {CPU: 1, Memory: 256 << 20},
+			},
+		},
+		{
+			name:    "invalid_cpu",
+			config:  "run.limits.cpu = \"search=-1\"\n",
+			wantErr: `invalid limit "search=-1" in user config run.limits.cpu: must be a positive number of cores`,
+		},
+		{
+			name:    "invalid_memory",
+			config:  "run.limits.memory = \"lots\"\n",
+			wantErr: `invalid limit "lots" in user config run.limits.memory`,
+		},
+		{
+			name:    "unknown_service",
+			config:  "run.limits.cpu = \"billing=1\"\n",
+			wantErr: `no service or gateway named "billing"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appRoot := t.TempDir()
+			if tt.config != "" {
+				path := filepath.Join(appRoot, ".encore", "config")
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				} else if err := os.WriteFile(path, []byte(tt.config), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := loadLimitsConfig(appRoot, md)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			for name, want := range tt.want {
+				if got := cfg.forProc(name); got != want {
+					t.Errorf("limits of %s = {%v}, want {%v}", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLimitsEnv(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits procLimits
+		want   []string
+	}{
+		{name: "none", want: []string{"FOO=bar"}},
+		{name: "cpu", limits: procLimits{CPU: 1.5}, want: []string{"FOO=bar", "ENCORE_CPU_LIMIT=1.5"}},
+		{
+			name:   "cpu_and_memory",
+			limits: procLimits{CPU: 2, Memory: 512 << 20},
+			want:   []string{"FOO=bar", "ENCORE_CPU_LIMIT=2", "ENCORE_MEMORY_LIMIT=536870912"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Proc{name: "search", limits: tt.limits}
+			if got := p.limitsEnv([]string{"FOO=bar"}); !slices.Equal(got, tt.want) {
+				t.Errorf("limitsEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package run
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"unsafe"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/sys/windows"
+)
+
+// procLimiter limits the resources of a process with a job object.
+type procLimiter struct {
+	job windows.Handle
+}
+
+// jobObjectCPURateControlInformation is JOBOBJECT_CPU_RATE_CONTROL_INFORMATION,
+// with the CpuRate member of its union.
+type jobObjectCPURateControlInformation struct {
+	ControlFlags uint32
+	CPURate      uint32 // in 1/100ths of a percent of all the CPUs
+}
+
+const (
+	jobObjectCPURateControlEnable  = 0x1
+	jobObjectCPURateControlHardCap = 0x4
+)
+
+// newProcLimiter creates a job object with the limits l for the process
+// to be started by cmd. The name must be unique among running processes.
+func newProcLimiter(name string, cmd *exec.Cmd, l procLimits) (*procLimiter, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "create job object")
+	}
+	pl := &procLimiter{job: job}
+
+	// Kill the process and any processes it started when the job is released.
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+	info.BasicLimitInformation.LimitFlags = windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+	if l.Memory > 0 {
+		info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_JOB_MEMORY
+		info.JobMemoryLimit = uintptr(l.Memory)
+	}
+	_, err = windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)))
+	if err != nil {
+		pl.release()
+		return nil, errors.Wrap(err, "set memory limit")
+	}
+
+	if l.CPU > 0 {
+		rate := jobObjectCPURateControlInformation{
+			ControlFlags: jobObjectCPURateControlEnable | jobObjectCPURateControlHardCap,
+			CPURate:      uint32(min(l.CPU/float64(runtime.NumCPU()), 1) * 10000),
+		}
+		_, err = windows.SetInformationJobObject(job, windows.JobObjectCpuRateControlInformation,
+			uintptr(unsafe.Pointer(&rate)), uint32(unsafe.Sizeof(rate)))
+		if err != nil {
+			pl.release()
+			return nil, errors.Wrap(err, "set cpu limit")
+		}
+	}
+	return pl, nil
+}
+
+// started is called once the process has been started.
+func (pl *procLimiter) started(proc *os.Process) error {
+	h, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(proc.Pid))
+	if err != nil {
+		return errors.Wrap(err, "open process")
+	}
+	defer func() { _ = windows.CloseHandle(h) }()
+	return errors.Wrap(windows.AssignProcessToJobObject(pl.job, h), "assign process to job object")
+}
+
+// oomKilled reports whether the process was killed for exceeding its memory limit.
+// Job objects fail allocations rather than killing processes, so it's always false.
+func (pl *procLimiter) oomKilled() bool {
+	return false
+}
+
+// release closes the job object once the process has exited.
+func (pl *procLimiter) release() {
+	_ = windows.CloseHandle(pl.job)
+}
@@ -11,12 +11,18 @@ import (
 
 	encore "encore.dev"
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/debugbundle"
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/dbseed"
 	"encr.dev/cli/daemon/objects"
+	"encr.dev/cli/daemon/ports"
 	"encr.dev/cli/daemon/run/infra"
 	"encr.dev/cli/daemon/secret"
 	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/cli/daemon/sqldb/mysql"
+	"encr.dev/cli/internal/localauth"
 	"encr.dev/pkg/errlist"
+	"encr.dev/pkg/vulnscan"
 	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
@@ -27,12 +33,26 @@ type Manager struct {
 	DashBaseURL   string // base url for the dev dashboard
 	Secret        *secret.Manager
 	ClusterMgr    *sqldb.ClusterManager
+	MySQLMgr      *mysql.ClusterManager
 	ObjectsMgr    *objects.ClusterManager
 	PublicBuckets *objects.PublicBucketServer
+	Seeds         *dbseed.Store            // applied database seeds
+	LocalAuth     *localauth.Authenticator // authenticates requests to the dev dashboard and MCP endpoint
+	Ports         *ports.Registry          // coordinates the ports apps and daemon subsystems listen on
 
 	listeners []EventListener
 	mu        sync.Mutex
-	runs      map[string]*Run // id -> run
+	runs      map[string]*Run                  // id -> run
+	bundles   map[string][]*debugbundle.Bundle // app id -> debug bundles, oldest first
+
+	vulnMu sync.Mutex                  // serializes vulnerability scans
+	vulns  map[string]*vulnscan.Report // app id -> latest vulnerability report
+}
+
+// DashboardURL returns the URL of the dev dashboard for the given app,
+// including the local token if the dashboard requires it.
+func (mgr *Manager) DashboardURL(appID string) string {
+	return mgr.LocalAuth.URL(fmt.Sprintf("%s/%s", mgr.DashBaseURL, appID))
 }
 
 // EventListener is the interface for listening to events
@@ -44,6 +64,9 @@ type EventListener interface {
 	OnCompileStart(r *Run)
 	// OnReload is called when a run reloads.
 	OnReload(r *Run)
+	// OnStaleBuild is called when a run fails to rebuild,
+	// leaving the previous build serving requests.
+	OnStaleBuild(r *Run)
 	// OnStop is called when a run stops.
 	OnStop(r *Run)
 	// OnStdout is called when a run outputs something on stdout.
@@ -85,12 +108,17 @@ func (mgr *Manager) FindRunByAppID(appID string) *Run {
 	return nil
 }
 
-// ListRuns provides a snapshot of all runs.
+// ListRuns provides a snapshot of all runs that have not exited.
 func (mgr *Manager) ListRuns() []*Run {
 	mgr.mu.Lock()
 	runs := make([]*Run, 0, len(mgr.runs))
 	for _, r := range mgr.runs {
-		runs = append(runs, r)
+		select {
+		case <-r.Done():
+			// exited
+		default:
+			runs = append(runs, r)
+		}
 	}
 	mgr.mu.Unlock()
 
@@ -0,0 +1,33 @@
+package run
+
+import (
+	"slices"
+	"testing"
+
+	"encr.dev/cli/daemon/apps"
+)
+
+func TestManagerSkipsExitedRuns(t *testing.T) {
+	newRun := func(id, appID string) *Run {
+		return &Run{ID: id, App: apps.NewInstance(t.TempDir(), appID, ""), exited: make(chan struct{})}
+	}
+	api, web, exited := newRun("1", "api"), newRun("2", "web"), newRun("3", "api")
+	close(exited.exited)
+	mgr := &Manager{runs: map[string]*Run{api.ID: api, web.ID: web, exited.ID: exited}}
+
+	var ids []string
+	for _, r := range mgr.ListRuns() {
+		ids = append(ids, r.ID)
+	}
+	if want := []string{api.ID, web.ID}; !slices.Equal(ids, want) {
+		t.Errorf("ListRuns() = runs %v, want %v", ids, want)
+	}
+	if got := mgr.FindRunByAppID("api"); got != api {
+		t.Errorf("FindRunByAppID(api) = %v, want the running run", got)
+	}
+
+	close(api.exited)
+	if got := mgr.FindRunByAppID("api"); got != nil {
+		t.Errorf("FindRunByAppID(api) = %v after the run exited, want nil", got)
+	}
+}
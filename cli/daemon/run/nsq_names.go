@@ -30,3 +30,9 @@ func ensureValidNSQName(name string) string {
 	}
 	return hashNSQName(name)
 }
+
+// NSQTopicName returns the name of the NSQ topic used for the Pub/Sub topic
+// with the given name when running locally.
+func NSQTopicName(topic string) string {
+	return ensureValidNSQName(topic)
+}
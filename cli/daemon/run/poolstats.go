@@ -0,0 +1,95 @@
+package run
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// DBPoolStats are statistics about the connection pools of a database.
+type DBPoolStats struct {
+	Name           string `json:"name"`
+	MaxConns       int    `json:"max_conns"`
+	OpenConns      int    `json:"open_conns"`
+	InUse          int    `json:"in_use"`
+	Idle           int    `json:"idle"`
+	WaitCount      int64  `json:"wait_count"`
+	WaitDurationNs int64  `json:"wait_duration_ns"`
+}
+
+// PoolStats asks the process for the statistics of the connection pools
+// of the databases it uses. It reports (nil, nil) if the process doesn't
+// support reporting them.
+func (p *Proc) PoolStats(ctx context.Context) ([]DBPoolStats, error) {
+	u := fmt.Sprintf("http://%s/__encore/sqldb/pool-stats", p.listenAddr)
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	addAuthKeyToRequest(req, p.group.authKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to reach process")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("unexpected status %s", resp.Status)
+	}
+
+	var res struct {
+		Databases []DBPoolStats `json:"databases"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "decode response")
+	}
+	return res.Databases, nil
+}
+
+// PoolStats returns the statistics of the connection pools of the databases
+// used by the running processes, sorted by database name. Each process has its
+// own pools, so the statistics of databases used by several processes are summed.
+func (r *Run) PoolStats(ctx context.Context) ([]DBPoolStats, error) {
+	pg := r.ProcGroup()
+	if pg == nil {
+		return nil, nil
+	}
+
+	byName := make(map[string]*DBPoolStats)
+	for p := range procNames(pg) {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		stats, err := p.PoolStats(ctx)
+		cancel()
+		if err != nil {
+			return nil, errors.Wrapf(err, "process %s", p.name)
+		}
+		for _, s := range stats {
+			sum, ok := byName[s.Name]
+			if !ok {
+				sum = &DBPoolStats{Name: s.Name}
+				byName[s.Name] = sum
+			}
+			sum.MaxConns += s.MaxConns
+			sum.OpenConns += s.OpenConns
+			sum.InUse += s.InUse
+			sum.Idle += s.Idle
+			sum.WaitCount += s.WaitCount
+			sum.WaitDurationNs += s.WaitDurationNs
+		}
+	}
+
+	res := make([]DBPoolStats, 0, len(byName))
+	for _, s := range byName {
+		res = append(res, *s)
+	}
+	slices.SortFunc(res, func(a, b DBPoolStats) int { return cmp.Compare(a.Name, b.Name) })
+	return res, nil
+}
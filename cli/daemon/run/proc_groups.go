@@ -2,6 +2,7 @@ package run
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -18,12 +20,15 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/cockroachdb/errors"
+	"github.com/dustin/go-humanize"
+	"github.com/logrusorgru/aurora/v3"
 	"github.com/rs/zerolog"
 
 	"encore.dev/appruntime/apisdk/api/transport"
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/experiments"
 	"encr.dev/cli/daemon/internal/sym"
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/internal/lookpath"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/fns"
@@ -42,6 +47,12 @@ type procGroupOptions struct {
 	Logger      RunLogger
 	WorkingDir  string
 	ConfigGen   *RuntimeConfigGenerator
+	Limits      *limitsConfig // resource limits of the processes
+	Profile     bool          // whether to serve the processes' pprof endpoints
+
+	// DevAccess, if true, authenticates proxied requests
+	// as coming from the Encore Platform.
+	DevAccess bool
 }
 
 func newProcGroup(opts procGroupOptions) *ProcGroup {
@@ -55,11 +66,15 @@ func newProcGroup(opts procGroupOptions) *ProcGroup {
 		logger:      opts.Logger,
 		log:         opts.Run.log.With().Str("proc_id", opts.ProcID).Logger(),
 		ConfigGen:   opts.ConfigGen,
+		limits:      opts.Limits,
+		profile:     opts.Profile,
 
 		symParsed: make(chan struct{}),
+		crashed:   make(chan struct{}),
 		Services:  make(map[string]*Proc),
 		Gateways:  make(map[string]*Proc),
 		authKey:   opts.AuthKey,
+		devAccess: opts.DevAccess,
 	}
 
 	p.procCond.L = &p.procMu
@@ -94,7 +109,19 @@ type ProcGroup struct {
 	// Used for proxying requests when there is no gateway.
 	noopGW *noopgateway.Gateway
 
+	startParams *StartProcGroupParams // the params the group was started with, for restarting it
+
+	closing   atomic.Bool   // whether the group is being closed or killed
+	crashOnce sync.Once     // guards crash and crashed
+	crash     *procCrash    // the first process to exit unexpectedly, if any
+	crashed   chan struct{} // closed when crash is set
+
+	profile   bool // whether the processes serve pprof endpoints
+	limits    *limitsConfig
+	limitsErr error // why limits could not be applied, if any; protected by procMu
+
 	authKey   config.EncoreAuthKey
+	devAccess bool // whether to authenticate proxied requests as the platform
 	sym       *sym.Table
 	symErr    error
 	symParsed chan struct{} // closed when sym and symErr are set
@@ -144,9 +171,65 @@ func (pg *ProcGroup) Start() (err error) {
 	return nil
 }
 
+// Crashed returns a channel that is closed when a process in the group
+// exits unexpectedly. See [ProcGroup.Crash] for the details.
+func (pg *ProcGroup) Crashed() <-chan struct{} {
+	return pg.crashed
+}
+
+// Crash describes the first process in the group to exit unexpectedly,
+// or nil if there is none.
+func (pg *ProcGroup) Crash() *procCrash {
+	select {
+	case <-pg.crashed:
+		return pg.crash
+	default:
+		return nil
+	}
+}
+
+// reportCrash records that the process p exited unexpectedly,
+// unless the group is being shut down.
+func (pg *ProcGroup) reportCrash(p *Proc, err error) {
+	if pg.closing.Load() || pg.ctx.Err() != nil {
+		return
+	}
+	pg.crashOnce.Do(func() {
+		pg.crash = &procCrash{Proc: p.name, Err: err, Uptime: time.Since(p.StartedAt)}
+		if w, ok := p.cmd.Stderr.(*logWriter); ok {
+			pg.crash.Stderr = w.Tail()
+		}
+		close(pg.crashed)
+	})
+}
+
+// crashOutputSize is how much of the end of the stderr output
+// of processes is kept to tell why they crashed.
+const crashOutputSize = 64 * 1024
+
+// procCrash describes a process exiting unexpectedly.
+type procCrash struct {
+	Proc   string        // the name of the process
+	Err    error         // the error the process exited with, if any
+	Uptime time.Duration // how long the process ran for
+	Stderr []byte        // the end of the process's stderr output, up to crashOutputSize bytes
+}
+
+func (c *procCrash) String() string {
+	reason := "exited unexpectedly"
+	if c.Err != nil {
+		reason = "exited with " + c.Err.Error()
+	}
+	if c.Uptime < 5*time.Second {
+		return fmt.Sprintf("process %s %s during startup", c.Proc, reason)
+	}
+	return fmt.Sprintf("process %s %s after running for %s", c.Proc, reason, c.Uptime.Round(time.Second))
+}
+
 // Close closes the process and waits for it to shutdown.
 // It can safely be called multiple times.
 func (pg *ProcGroup) Close() {
+	pg.closing.Store(true)
 	var wg sync.WaitGroup
 	pg.procMu.Lock()
 	wg.Add(len(pg.allProcesses))
@@ -165,6 +248,7 @@ func (pg *ProcGroup) Close() {
 // Kill kills all the processes in the group.
 // It does not wait for them to exit.
 func (pg *ProcGroup) Kill() {
+	pg.closing.Store(true)
 	pg.procMu.Lock()
 	defer pg.procMu.Unlock()
 
@@ -216,14 +300,12 @@ func (pg *ProcGroup) newProc(processName string, listenAddr netip.AddrPort) (*Pr
 			// Copy the host head over.
 			r.Out.Host = r.In.Host
 
-			// Add the auth key unless the test header is set.
-			if r.Out.Header.Get(TestHeaderDisablePlatformAuth) == "" {
-				addAuthKeyToRequest(r.Out, pg.authKey)
-			}
+			pg.authenticateProxied(r.Out, r.In)
 		},
 	}
 
 	p := &Proc{
+		name:       processName,
 		group:      pg,
 		log:        pg.log.With().Str("proc", processName).Logger(),
 		listenAddr: listenAddr,
@@ -243,9 +325,14 @@ func (pg *ProcGroup) NewAllInOneProc(spec builder.Cmd, listenAddr netip.AddrPort
 	if err != nil {
 		return err
 	}
+	p.limits = pg.limits.def
 
 	// Append both the command-specific env and the base environment.
 	env = append(env, spec.Env...)
+	env = p.limitsEnv(env)
+	if env, err = pg.profileEnv(p, env); err != nil {
+		return err
+	}
 
 	cwd := filepath.Join(pg.Run.App.Root(), pg.workingDir)
 	binary, err := lookpath.InDir(cwd, env, spec.Command[0])
@@ -262,7 +349,7 @@ func (pg *ProcGroup) NewAllInOneProc(spec builder.Cmd, listenAddr netip.AddrPort
 	// Proxy stdout and stderr to the given app logger, if any.
 	if l := pg.logger; l != nil {
 		cmd.Stdout = newLogWriter(pg.Run, l.RunStdout)
-		cmd.Stderr = newLogWriter(pg.Run, l.RunStderr)
+		cmd.Stderr = newLogWriter(pg.Run, l.RunStderr).keepTail(crashOutputSize)
 	}
 
 	p.cmd = cmd
@@ -285,9 +372,14 @@ func (pg *ProcGroup) NewProcForService(serviceName string, listenAddr netip.Addr
 		return err
 	}
 	pg.Services[serviceName] = p
+	p.limits = pg.limits.forProc(serviceName)
 
 	// Append both the command-specific env and the base environment.
 	env = append(env, spec.Env...)
+	env = p.limitsEnv(env)
+	if env, err = pg.profileEnv(p, env); err != nil {
+		return err
+	}
 
 	cwd := filepath.Join(pg.Run.App.Root(), pg.workingDir)
 	binary, err := lookpath.InDir(cwd, env, spec.Command[0])
@@ -304,7 +396,7 @@ func (pg *ProcGroup) NewProcForService(serviceName string, listenAddr netip.Addr
 	// Proxy stdout and stderr to the given app logger, if any.
 	if l := pg.logger; l != nil {
 		cmd.Stdout = newLogWriter(pg.Run, l.RunStdout)
-		cmd.Stderr = newLogWriter(pg.Run, l.RunStderr)
+		cmd.Stderr = newLogWriter(pg.Run, l.RunStderr).keepTail(crashOutputSize)
 	}
 
 	p.cmd = cmd
@@ -322,9 +414,14 @@ func (pg *ProcGroup) NewProcForGateway(gatewayName string, listenAddr netip.Addr
 		return err
 	}
 	pg.Gateways[gatewayName] = p
+	p.limits = pg.limits.forProc(gatewayName)
 
 	// Append both the command-specific env and the base environment.
 	env = append(env, spec.Env...)
+	env = p.limitsEnv(env)
+	if env, err = pg.profileEnv(p, env); err != nil {
+		return err
+	}
 
 	cwd := filepath.Join(pg.Run.App.Root(), pg.workingDir)
 	binary, err := lookpath.InDir(cwd, env, spec.Command[0])
@@ -345,7 +442,7 @@ func (pg *ProcGroup) NewProcForGateway(gatewayName string, listenAddr netip.Addr
 	// Proxy stdout and stderr to the given app logger, if any.
 	if l := pg.logger; l != nil {
 		cmd.Stdout = newLogWriter(pg.Run, l.RunStdout)
-		cmd.Stderr = newLogWriter(pg.Run, l.RunStderr)
+		cmd.Stderr = newLogWriter(pg.Run, l.RunStderr).keepTail(crashOutputSize)
 	}
 
 	p.cmd = cmd
@@ -353,6 +450,48 @@ func (pg *ProcGroup) NewProcForGateway(gatewayName string, listenAddr netip.Addr
 	return nil
 }
 
+// limitsEnv returns env with the resource limits of the process p, if any,
+// so the runtime can tune GOMAXPROCS and GOMEMLIMIT to them like it does
+// for container limits in the cloud, even where the limits aren't enforced.
+func (p *Proc) limitsEnv(env []string) []string {
+	if p.limits.CPU > 0 {
+		env = append(env, "ENCORE_CPU_LIMIT="+strconv.FormatFloat(p.limits.CPU, 'g', -1, 64))
+	}
+	if p.limits.Memory > 0 {
+		env = append(env, "ENCORE_MEMORY_LIMIT="+strconv.FormatUint(p.limits.Memory, 10))
+	}
+	return env
+}
+
+// profileEnv returns env with the address the process p is to serve
+// its pprof endpoints on, if profiling is enabled.
+func (pg *ProcGroup) profileEnv(p *Proc, env []string) ([]string, error) {
+	if !pg.profile {
+		return env, nil
+	}
+	ln, err := bindaddr.ListenLoopback(0)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not allocate pprof port")
+	}
+	p.PprofAddr = ln.Addr().String()
+	_ = ln.Close()
+	return append(env, "ENCORE_PPROF_ADDR="+p.PprofAddr), nil
+}
+
+// ProfileAddrs returns the addresses the processes serve their
+// pprof endpoints on, keyed by process name, if profiling is enabled.
+func (pg *ProcGroup) ProfileAddrs() map[string]string {
+	pg.procMu.Lock()
+	defer pg.procMu.Unlock()
+	addrs := make(map[string]string)
+	for _, p := range pg.allProcesses {
+		if p.PprofAddr != "" {
+			addrs[p.name] = p.PprofAddr
+		}
+	}
+	return addrs
+}
+
 type warning struct {
 	Title string
 	Help  string
@@ -366,11 +505,22 @@ func (pg *ProcGroup) Warnings() (rtn []warning) {
 		})
 	}
 
+	pg.procMu.Lock()
+	limitsErr := pg.limitsErr
+	pg.procMu.Unlock()
+	if limitsErr != nil {
+		rtn = append(rtn, warning{
+			Title: "resource limits not applied: " + limitsErr.Error(),
+			Help:  "the processes run without the limits configured in run.limits.\nsee https://encore.dev/docs/go/develop/resource-limits for more information",
+		})
+	}
+
 	return rtn
 }
 
 // Proc represents a single Encore process running within a [ProcGroup].
 type Proc struct {
+	name  string         // The name of the process, such as "api-gateway"
 	group *ProcGroup     // The group this process belongs to
 	log   zerolog.Logger // The logger for this process
 	exit  chan struct{}  // closed when the process has exited
@@ -379,6 +529,11 @@ type Proc struct {
 	listenAddr netip.AddrPort         // The port the HTTP server of the process should listen on
 	httpProxy  *httputil.ReverseProxy // The reverse proxy for the HTTP server of the process
 
+	limits  procLimits   // The resource limits of the process
+	limiter *procLimiter // Applies the limits, if any
+
+	PprofAddr string // The address the process serves pprof endpoints on, if profiling is enabled
+
 	// The following fields are only valid after Start() has been called.
 	Started   atomic.Bool // whether the process has started
 	StartedAt time.Time   // when the process started
@@ -403,10 +558,29 @@ func (p *Proc) start() error {
 		return nil
 	}
 
+	if !p.limits.isZero() {
+		limiter, err := newProcLimiter(p.group.ID+"-"+p.name, p.cmd, p.limits)
+		if err != nil {
+			p.log.Warn().Err(err).Msg("could not apply resource limits")
+			p.group.limitsErr = err
+		} else {
+			p.limiter = limiter
+		}
+	}
+
 	if err := p.cmd.Start(); err != nil {
+		if p.limiter != nil {
+			p.limiter.release()
+		}
 		return errors.Wrap(err, "could not start process")
 	}
-	p.log.Info().Str("addr", p.listenAddr.String()).Msg("process started")
+	p.log.Info().Str("addr", p.listenAddr.String()).Str("limits", p.limits.String()).Msg("process started")
+	if p.limiter != nil {
+		if err := p.limiter.started(p.cmd.Process); err != nil {
+			p.log.Warn().Err(err).Msg("could not apply resource limits")
+			p.group.limitsErr = err
+		}
+	}
 	p.group.runningProcs++
 
 	p.Pid = p.cmd.Process.Pid
@@ -430,6 +604,19 @@ func (p *Proc) start() error {
 				w.(*logWriter).Flush()
 			}
 		}
+
+		if p.limiter != nil {
+			if p.limiter.oomKilled() {
+				p.log.Error().Msg("process killed for exceeding its memory limit")
+				msg := fmt.Sprintf("\n%s\n", aurora.Red(fmt.Sprintf(
+					"error: process %s was killed for exceeding its memory limit of %s",
+					p.name, humanize.IBytes(p.limits.Memory))))
+				p.group.Run.Mgr.RunStderr(p.group.Run, []byte(msg))
+			}
+			p.limiter.release()
+		}
+
+		p.group.reportCrash(p, err)
 	}()
 
 	// When the process exits, decrement the running count for the group
@@ -455,7 +642,10 @@ func (p *Proc) Close() {
 		p.Kill()
 	}
 
-	timer := time.NewTimer(gracefulShutdownTime + (500 * time.Millisecond))
+	// Give the process a little longer than its own shutdown timeout
+	// so it can exit by itself.
+	timeout := p.group.ConfigGen.GracefulShutdown.withDefaults().Total
+	timer := time.NewTimer(timeout + (500 * time.Millisecond))
 	defer timer.Stop()
 
 	select {
@@ -516,16 +706,18 @@ func newNoopGateway(pg *ProcGroup) *noopgateway.Gateway {
 	}
 
 	desc := noopgwdesc.Describe(pg.Meta, svcDiscovery)
+	for name, baseURL := range pg.ConfigGen.ExternalServices {
+		if u, err := url.Parse(baseURL); err == nil {
+			desc.Services[noopgateway.ServiceName(name)] = noopgateway.Service{URL: u}
+		}
+	}
 	gw := noopgateway.New(desc)
 
 	gw.Rewrite = func(rp *httputil.ProxyRequest) {
 		// Copy the host head over.
 		rp.Out.Host = rp.In.Host
 
-		// Add the auth key unless the test header is set.
-		if rp.Out.Header.Get(TestHeaderDisablePlatformAuth) == "" {
-			addAuthKeyToRequest(rp.Out, pg.authKey)
-		}
+		pg.authenticateProxied(rp.Out, rp.In)
 	}
 
 	return gw
@@ -0,0 +1,40 @@
+package run
+
+import (
+	"maps"
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func TestProfileEnv(t *testing.T) {
+	pg := &ProcGroup{profile: true}
+	search, users := &Proc{name: "search", group: pg}, &Proc{name: "users", group: pg}
+	pg.allProcesses = []*Proc{search, users}
+
+	env, err := pg.profileEnv(search, []string{"FOO=bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := netip.ParseAddrPort(search.PprofAddr)
+	if err != nil || !addr.Addr().IsLoopback() || addr.Port() == 0 {
+		t.Fatalf("PprofAddr = %q, want a loopback address", search.PprofAddr)
+	}
+	if want := []string{"FOO=bar", "ENCORE_PPROF_ADDR=" + search.PprofAddr}; !slices.Equal(env, want) {
+		t.Errorf("profileEnv() = %v, want %v", env, want)
+	}
+
+	// Only the processes serving pprof endpoints are listed.
+	if got, want := pg.ProfileAddrs(), map[string]string{"search": search.PprofAddr}; !maps.Equal(got, want) {
+		t.Errorf("ProfileAddrs() = %v, want %v", got, want)
+	}
+
+	// Without profiling the process doesn't serve pprof endpoints.
+	pg.profile = false
+	if env, err := pg.profileEnv(users, []string{"FOO=bar"}); err != nil || !slices.Equal(env, []string{"FOO=bar"}) {
+		t.Errorf("profileEnv() without profiling = %v, %v, want the env unchanged", env, err)
+	}
+	if users.PprofAddr != "" {
+		t.Errorf("PprofAddr without profiling = %q, want none", users.PprofAddr)
+	}
+}
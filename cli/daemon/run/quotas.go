@@ -0,0 +1,141 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"encr.dev/pkg/appfile"
+)
+
+const defaultQuotaKeyHeader = "X-API-Key"
+
+// quotaSimulator simulates the per-API-key request quotas of a cloud API gateway,
+// rejecting requests that exceed them with 429 Too Many Requests.
+//
+// Its state is kept across reloads unless the quota configuration changes.
+type quotaSimulator struct {
+	mu       sync.Mutex
+	cfg      *appfile.LocalQuotas
+	limiters map[quotaKey]*rate.Limiter
+}
+
+type quotaKey struct {
+	endpoint string
+	apiKey   string
+}
+
+// validateQuotas reports whether the quota configuration is valid.
+func validateQuotas(cfg *appfile.LocalQuotas) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Default != nil {
+		if _, err := parseQuota(*cfg.Default); err != nil {
+			return fmt.Errorf("invalid default local quota: %v", err)
+		}
+	}
+	for endpoint, q := range cfg.Endpoints {
+		if _, err := parseQuota(q); err != nil {
+			return fmt.Errorf("invalid local quota for endpoint %s: %v", endpoint, err)
+		}
+	}
+	return nil
+}
+
+// setConfig updates the quota configuration, as validated by validateQuotas.
+// If it changed the quotas start over.
+func (s *quotaSimulator) setConfig(cfg *appfile.LocalQuotas) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !reflect.DeepEqual(s.cfg, cfg) {
+		s.cfg = cfg
+		s.limiters = nil
+	}
+}
+
+// enabled reports whether any quotas are configured.
+func (s *quotaSimulator) enabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg != nil
+}
+
+// allow reports whether a request to endpoint is within the quota.
+// If not it reports how long until the request would be allowed.
+func (s *quotaSimulator) allow(endpoint string, req *http.Request, now time.Time) (ok bool, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cfg == nil {
+		return true, 0
+	}
+
+	q, ok := s.cfg.Endpoints[endpoint]
+	if !ok {
+		if s.cfg.Default == nil {
+			return true, 0
+		}
+		q = *s.cfg.Default
+	}
+
+	header := s.cfg.KeyHeader
+	if header == "" {
+		header = defaultQuotaKeyHeader
+	}
+	key := quotaKey{endpoint: endpoint, apiKey: req.Header.Get(header)}
+	lim, ok := s.limiters[key]
+	if !ok {
+		lim, _ = parseQuota(q) // validated by setConfig
+		if s.limiters == nil {
+			s.limiters = make(map[quotaKey]*rate.Limiter)
+		}
+		s.limiters[key] = lim
+	}
+
+	r := lim.ReserveN(now, 1)
+	if delay := r.DelayFrom(now); delay > 0 {
+		// Don't consume the quota for rejected requests.
+		r.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// serveQuotaExceeded writes a response for a request that exceeded the quota of endpoint.
+func serveQuotaExceeded(w http.ResponseWriter, endpoint string, retryAfter time.Duration) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    "resource_exhausted",
+		"message": fmt.Sprintf("quota exceeded for endpoint %s (simulated)", endpoint),
+		"details": nil,
+	})
+}
+
+func parseQuota(q appfile.Quota) (*rate.Limiter, error) {
+	period := time.Second
+	if q.Period != "" {
+		var err error
+		if period, err = time.ParseDuration(q.Period); err != nil {
+			return nil, err
+		} else if period <= 0 {
+			return nil, fmt.Errorf("period must be positive")
+		}
+	}
+	if q.Requests <= 0 {
+		return nil, fmt.Errorf("requests must be positive")
+	}
+	burst := q.Burst
+	if burst <= 0 {
+		burst = q.Requests
+	}
+	return rate.NewLimiter(rate.Limit(float64(q.Requests)/period.Seconds()), burst), nil
+}
@@ -0,0 +1,147 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultReadyTimeout is the default time to wait for an app to become ready.
+const DefaultReadyTimeout = 30 * time.Second
+
+// WaitReady waits until all processes in the group are ready to serve requests,
+// meaning that all their services have finished initializing.
+//
+// If the processes are not ready within timeout it returns an error describing
+// what each process that is not ready is waiting for. If a process exits
+// before it's ready, it returns an error right away.
+func (pg *ProcGroup) WaitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pg.procMu.Lock()
+	procs := slices.Clone(pg.allProcesses)
+	pg.procMu.Unlock()
+
+	notReady := make([]string, len(procs))
+	exited := make([]bool, len(procs))
+	var g errgroup.Group
+	for i, p := range procs {
+		g.Go(func() error {
+			reason := p.waitReady(ctx)
+			if reason == procExited {
+				// The app won't become ready, so stop waiting for the other processes.
+				exited[i] = true
+				cancel()
+			}
+			if reason != "" {
+				notReady[i] = fmt.Sprintf("%s: %s", p.name, reason)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if slices.Contains(exited, true) {
+		var names []string
+		for i, p := range procs {
+			if exited[i] {
+				names = append(names, p.name)
+			}
+		}
+		slices.Sort(names)
+		return errors.Newf("app exited before it was ready: %s", strings.Join(names, ", "))
+	}
+
+	notReady = slices.DeleteFunc(notReady, func(s string) bool { return s == "" })
+	if len(notReady) > 0 {
+		slices.Sort(notReady)
+		return errors.Newf("app not ready after %v:\n  %s", timeout, strings.Join(notReady, "\n  "))
+	}
+	return nil
+}
+
+// procExited is the reason a process is not ready when it has exited.
+const procExited = "process exited"
+
+// waitReady polls the health check of the process until it reports
+// that it's ready, or ctx is done. If the process is not ready on return,
+// it returns the reason why, otherwise it returns "".
+func (p *Proc) waitReady(ctx context.Context) (reason string) {
+	url := fmt.Sprintf("http://%s/__encore/healthz", p.listenAddr)
+	client := &http.Client{Timeout: 2 * time.Second}
+
+	for {
+		select {
+		case <-p.exit:
+			return procExited
+		default:
+		}
+
+		ready, reason := checkReady(ctx, client, url)
+		if ready {
+			return ""
+		}
+
+		select {
+		case <-ctx.Done():
+			return reason
+		case <-p.exit:
+			return procExited
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// checkReady checks the health check at url once.
+func checkReady(ctx context.Context, client *http.Client, url string) (ready bool, reason string) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return false, err.Error()
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, "not listening for requests"
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, ""
+	case http.StatusNotFound:
+		// The process doesn't support health checks,
+		// so the best we can do is know that it's listening.
+		return true, ""
+	}
+
+	var body struct {
+		Message string `json:"message"`
+		Details struct {
+			Checks []struct {
+				Name   string `json:"name"`
+				Passed bool   `json:"passed"`
+				Error  string `json:"error"`
+			} `json:"checks"`
+		} `json:"details"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return false, fmt.Sprintf("health check returned status %d", resp.StatusCode)
+	}
+	var failed []string
+	for _, c := range body.Details.Checks {
+		if !c.Passed {
+			failed = append(failed, c.Error)
+		}
+	}
+	if len(failed) == 0 {
+		return false, body.Message
+	}
+	return false, strings.Join(failed, "; ")
+}
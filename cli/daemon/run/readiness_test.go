@@ -0,0 +1,98 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+// healthzProc returns a process serving its health check with handler.
+func healthzProc(t *testing.T, pg *ProcGroup, name string, handler http.HandlerFunc) *Proc {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	p := &Proc{
+		name:       name,
+		group:      pg,
+		exit:       make(chan struct{}),
+		listenAddr: netip.MustParseAddrPort(srv.Listener.Addr().String()),
+	}
+	pg.allProcesses = append(pg.allProcesses, p)
+	return p
+}
+
+func ready(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func notReady(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.Write([]byte(`{"message": "not ready", "details": {"checks": [
+		{"name": "db", "passed": true},
+		{"name": "svc", "passed": false, "error": "service svc is initializing"}
+	]}}`))
+}
+
+func TestWaitReady(t *testing.T) {
+	pg := &ProcGroup{}
+	healthzProc(t, pg, "gateway", ready)
+
+	// The process becomes ready after failing a few health checks.
+	var checks int
+	healthzProc(t, pg, "svc", func(w http.ResponseWriter, r *http.Request) {
+		if checks++; checks < 3 {
+			notReady(w, r)
+		} else {
+			ready(w, r)
+		}
+	})
+
+	if err := pg.WaitReady(context.Background(), 5*time.Second); err != nil {
+		t.Fatalf("WaitReady: %v", err)
+	}
+	if checks != 3 {
+		t.Errorf("got %d health checks, want 3", checks)
+	}
+}
+
+func TestWaitReadyTimeout(t *testing.T) {
+	pg := &ProcGroup{}
+	healthzProc(t, pg, "gateway", ready)
+	healthzProc(t, pg, "svc", notReady)
+
+	err := pg.WaitReady(context.Background(), 300*time.Millisecond)
+	if err == nil {
+		t.Fatal("WaitReady succeeded with a process that's not ready")
+	}
+	if got, want := err.Error(), "svc: service svc is initializing"; !strings.Contains(got, want) {
+		t.Errorf("error = %q, want it to contain %q", got, want)
+	}
+	if strings.Contains(err.Error(), "gateway") {
+		t.Errorf("error = %q, want it to only describe processes that aren't ready", err)
+	}
+}
+
+func TestWaitReadyProcessExits(t *testing.T) {
+	pg := &ProcGroup{}
+	healthzProc(t, pg, "gateway", notReady)
+	svc := healthzProc(t, pg, "svc", notReady)
+	time.AfterFunc(100*time.Millisecond, func() { close(svc.exit) })
+
+	// WaitReady stops waiting for all processes as soon as one exits.
+	start := time.Now()
+	err := pg.WaitReady(context.Background(), 30*time.Second)
+	if err == nil {
+		t.Fatal("WaitReady succeeded after a process exited")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("WaitReady returned after %v, want it to return once the process exited", elapsed)
+	}
+	if got, want := err.Error(), "app exited before it was ready: svc"; got != want {
+		t.Errorf("error = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,207 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/run/infra"
+	"encr.dev/cli/daemon/secret"
+	"encr.dev/pkg/builder"
+	"encr.dev/pkg/errlist"
+	"encr.dev/pkg/svcproxy"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// fakeBuilder builds an app without any processes to start,
+// failing to compile it while compileErr is set.
+type fakeBuilder struct {
+	builder.Impl
+	compileErr error
+}
+
+func (b *fakeBuilder) Prepare(context.Context, builder.PrepareParams) (*builder.PrepareResult, error) {
+	return &builder.PrepareResult{}, nil
+}
+
+func (b *fakeBuilder) Parse(context.Context, builder.ParseParams) (*builder.ParseResult, error) {
+	return &builder.ParseResult{Meta: &meta.Data{
+		Svcs: []*meta.Service{{Name: "admin"}, {Name: "billing"}},
+	}}, nil
+}
+
+func (b *fakeBuilder) Compile(context.Context, builder.CompileParams) (*builder.CompileResult, error) {
+	if b.compileErr != nil {
+		return nil, b.compileErr
+	}
+	return &builder.CompileResult{}, nil
+}
+
+func (b *fakeBuilder) ServiceConfigs(context.Context, builder.ServiceConfigsParams) (*builder.ServiceConfigsResult, error) {
+	return &builder.ServiceConfigsResult{}, nil
+}
+
+func (b *fakeBuilder) UseNewRuntimeConfig() bool { return false }
+func (b *fakeBuilder) NeedsMeta() bool           { return false }
+
+// reloadListener records the events of a run.
+type reloadListener struct {
+	mu      sync.Mutex
+	reloads int
+	stale   int
+	output  strings.Builder
+}
+
+func (l *reloadListener) OnStart(r *Run)        {}
+func (l *reloadListener) OnCompileStart(r *Run) {}
+func (l *reloadListener) OnStop(r *Run)         {}
+
+func (l *reloadListener) OnReload(r *Run) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reloads++
+}
+
+func (l *reloadListener) OnStaleBuild(r *Run) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stale++
+}
+
+func (l *reloadListener) OnStdout(r *Run, out []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.output.Write(out)
+}
+
+func (l *reloadListener) OnStderr(r *Run, out []byte) { l.OnStdout(r, out) }
+
+func (l *reloadListener) OnError(r *Run, err *errlist.List) {
+	l.OnStdout(r, []byte(err.Error()))
+}
+
+// takeOutput returns the output written since it was last called.
+func (l *reloadListener) takeOutput() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := l.output.String()
+	l.output.Reset()
+	return out
+}
+
+func writeAppFile(t *testing.T, appRoot, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(appRoot, "encore.app"), []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloadStaleBuild(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	appRoot := t.TempDir()
+	writeAppFile(t, appRoot, `{"local_hosts": {"admin.localhost": "admin"}}`)
+
+	app := apps.NewInstance(appRoot, "reload-test", "")
+	ln := &reloadListener{}
+	mgr := &Manager{}
+	mgr.AddListener(ln)
+	b := &fakeBuilder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	svcProxy, err := svcproxy.New(ctx, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{})
+	close(started)
+	r := &Run{
+		ID:              "run",
+		App:             app,
+		ListenAddr:      "127.0.0.1:4000",
+		SvcProxy:        svcProxy,
+		ResourceManager: infra.NewResourceManager(app, nil, nil, nil, nil, nil, nil, nil, 0, false),
+		Builder:         b,
+		log:             zerolog.Nop(),
+		Mgr:             mgr,
+		Params:          &StartParams{App: app},
+		secrets:         secret.New().LoadCached(app),
+		ctx:             ctx,
+		started:         started,
+	}
+
+	// The initial build.
+	if err := r.buildAndStart(ctx, nil, false); err != nil {
+		t.Fatalf("initial build: %v", err)
+	}
+	prev := r.ProcGroup()
+	if prev == nil {
+		t.Fatal("no proc after the initial build")
+	}
+	hosts := r.hosts.list()
+	if len(hosts) != 1 || hosts[0].Service != "admin" {
+		t.Fatalf("hosts = %v, want admin.localhost mapped to admin", hosts)
+	}
+
+	// A failed rebuild keeps the previous build, along with its configuration.
+	writeAppFile(t, appRoot, `{
+		"local_hosts": {"billing.localhost": "billing"},
+		"local_quotas": {"default": {"requests": 10, "period": "1m"}}
+	}`)
+	b.compileErr = errors.New("syntax error")
+	r.reloadAndReport("Changes detected, recompiling...\n")
+
+	if got := r.ProcGroup(); got != prev {
+		t.Error("failed rebuild replaced the proc")
+	}
+	if !r.StaleBuild() {
+		t.Error("StaleBuild() = false after a failed rebuild")
+	}
+	if hosts := r.hosts.list(); len(hosts) != 1 || hosts[0].Host != "admin.localhost" {
+		t.Errorf("hosts = %v after a failed rebuild, want the previous hosts", hosts)
+	}
+	if r.quotas.enabled() {
+		t.Error("quotas of the failed rebuild applied")
+	}
+	if ln.stale != 1 || ln.reloads != 0 {
+		t.Errorf("got %d stale build and %d reload events, want 1 and 0", ln.stale, ln.reloads)
+	}
+	if out := ln.takeOutput(); !strings.Contains(out, "syntax error") || !strings.Contains(out, "Stale build:") {
+		t.Errorf("output does not report the stale build:\n%s", out)
+	}
+
+	// A successful rebuild replaces the stale build.
+	b.compileErr = nil
+	r.reloadAndReport("Changes detected, recompiling...\n")
+
+	if got := r.ProcGroup(); got == prev || got == nil {
+		t.Error("successful rebuild did not replace the proc")
+	}
+	// The replaced proc is shut down, and the run waits for it.
+	r.draining.Wait()
+	if !prev.closing.Load() {
+		t.Error("replaced proc not closed")
+	}
+	if r.StaleBuild() {
+		t.Error("StaleBuild() = true after a successful rebuild")
+	}
+	if hosts := r.hosts.list(); len(hosts) != 1 || hosts[0].Host != "billing.localhost" {
+		t.Errorf("hosts = %v after a successful rebuild, want the new hosts", hosts)
+	}
+	if !r.quotas.enabled() {
+		t.Error("quotas of the successful rebuild not applied")
+	}
+	if ln.stale != 1 || ln.reloads != 1 {
+		t.Errorf("got %d stale build and %d reload events, want 1 and 1", ln.stale, ln.reloads)
+	}
+	if out := ln.takeOutput(); !strings.Contains(out, "Reloaded successfully, replacing the stale build.") {
+		t.Errorf("output does not report replacing the stale build:\n%s", out)
+	}
+}
@@ -0,0 +1,143 @@
+package run
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/logrusorgru/aurora/v3"
+
+	"encr.dev/cli/daemon/ownership"
+	"encr.dev/internal/userconfig"
+)
+
+// restartPolicy describes how an app that crashes is restarted.
+type restartPolicy struct {
+	maxRestarts int           // restarts in a row before giving up; 0 disables restarting
+	minDelay    time.Duration // delay before the first restart, doubled for every restart in a row
+	maxDelay    time.Duration // max delay between restarts
+	resetAfter  time.Duration // how long the app must run for before a crash no longer counts as in a row
+}
+
+// crashRestarter tracks the restarts of a crashing app according to a restart policy.
+type crashRestarter struct {
+	policy   restartPolicy
+	restarts int // restarts in a row
+}
+
+// newCrashRestarter returns a crashRestarter for the app at appRoot,
+// with the max restarts configured in the user config.
+func newCrashRestarter(appRoot string) *crashRestarter {
+	policy := restartPolicy{
+		maxRestarts: 5,
+		minDelay:    500 * time.Millisecond,
+		maxDelay:    30 * time.Second,
+		resetAfter:  time.Minute,
+	}
+	if user, err := userconfig.ForApp(appRoot).Get(); err == nil {
+		policy.maxRestarts = max(user.RunRestartMax, 0)
+	}
+	return &crashRestarter{policy: policy}
+}
+
+// next reports how long to wait before restarting an app that crashed after
+// running for the given time, and false if it should not be restarted.
+func (c *crashRestarter) next(uptime time.Duration) (delay time.Duration, ok bool) {
+	if uptime >= c.policy.resetAfter {
+		c.restarts = 0
+	}
+	if c.restarts >= c.policy.maxRestarts {
+		return 0, false
+	}
+	delay = c.policy.minDelay
+	for i := 0; i < c.restarts && delay < c.policy.maxDelay; i++ {
+		delay *= 2
+	}
+	c.restarts++
+	return min(delay, c.policy.maxDelay), true
+}
+
+// restartCrashed restarts the processes of p, one of which crashed,
+// with backoff according to c. It reports whether p was replaced,
+// either by restarting it or by the app being reloaded in the meantime.
+func (r *Run) restartCrashed(p *ProcGroup, c *crashRestarter) bool {
+	crash := p.Crash()
+	owner := crashOwner(r.App.Root(), p, crash)
+	delay, ok := c.next(crash.Uptime)
+	for {
+		if !ok {
+			r.giveUpRestarting(crash, c, owner)
+			return false
+		}
+		msg := fmt.Sprintf("\n%s\n", aurora.Red(fmt.Sprintf(
+			"error: %s; restarting in %s (restart %d of %d)",
+			crash, delay, c.restarts, c.policy.maxRestarts)))
+		if owner != "" {
+			msg += aurora.Gray(16, "note: "+owner).String() + "\n"
+		}
+		r.Mgr.RunStderr(r, []byte(msg))
+
+		select {
+		case <-r.ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		if r.ProcGroup() != p {
+			// The app was reloaded while waiting.
+			return true
+		}
+
+		err := r.restartProcGroup(p)
+		if err == nil {
+			r.log.Info().Str("crashed_proc", crash.Proc).Int("restarts", c.restarts).Msg("restarted crashed app")
+			return true
+		}
+
+		r.log.Error().Err(err).Msg("could not restart crashed app")
+		r.Mgr.RunStderr(r, []byte(fmt.Sprintf("\n%s\n", aurora.Red(fmt.Sprintf(
+			"error: could not restart the app: %v", err)))))
+		delay, ok = c.next(0)
+	}
+}
+
+// giveUpRestarting reports that the app won't be restarted after crash.
+// The owner describes the team owning the code that crashed, if known.
+func (r *Run) giveUpRestarting(crash *procCrash, c *crashRestarter, owner string) {
+	msg := aurora.Red(fmt.Sprintf("error: %s", crash)).String()
+	if c.policy.maxRestarts > 0 {
+		msg = aurora.Red(fmt.Sprintf("error: %s, and keeps crashing after %d restarts in a row; giving up",
+			crash, c.restarts)).String()
+	}
+	help := "note: see the output above for why it exited"
+	if r.Params.Watch {
+		help += "; the app is restarted when you make a change"
+	}
+	if owner != "" {
+		help += "\nnote: " + owner
+	}
+	r.Mgr.RunStderr(r, []byte("\n"+msg+"\n"+aurora.Gray(16, help).String()+"\n\n"))
+}
+
+// crashOwner describes the service the crash happened in and the team owning it,
+// like "the crash is in service billing, owned by team payments (#payments)".
+// It returns the empty string if the owner is not known.
+//
+// The service is determined from the stack trace in the output of the crashed
+// process, falling back to the service the process runs.
+func crashOwner(appRoot string, p *ProcGroup, crash *procCrash) string {
+	if p.Meta == nil {
+		return ""
+	}
+	idx := ownership.New(appRoot, p.Meta)
+	if !idx.HasOwners() {
+		return ""
+	}
+	svc, ok := idx.ServiceForStack(crash.Stderr)
+	if !ok {
+		svc = crash.Proc
+	}
+	o := idx.Owner(svc)
+	if o == nil {
+		return ""
+	}
+	return fmt.Sprintf("the crash is in service %s, owned by %s", svc, ownership.Describe(o))
+}
@@ -0,0 +1,83 @@
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCrashRestarterBackoff(t *testing.T) {
+	c := &crashRestarter{policy: restartPolicy{
+		maxRestarts: 10,
+		minDelay:    500 * time.Millisecond,
+		maxDelay:    30 * time.Second,
+		resetAfter:  time.Minute,
+	}}
+
+	// The delay doubles for every restart in a row, up to the max delay.
+	want := []time.Duration{
+		500 * time.Millisecond,
+		1 * time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		16 * time.Second,
+		30 * time.Second,
+		30 * time.Second,
+	}
+	for i, w := range want {
+		delay, ok := c.next(time.Second)
+		if !ok || delay != w {
+			t.Fatalf("restart %d: got %s, %v, want %s, true", i+1, delay, ok, w)
+		}
+	}
+
+	// Crashing after running for long enough starts over.
+	if delay, ok := c.next(time.Minute); !ok || delay != 500*time.Millisecond {
+		t.Fatalf("after reset: got %s, %v, want 500ms, true", delay, ok)
+	}
+	if delay, ok := c.next(59 * time.Second); !ok || delay != time.Second {
+		t.Fatalf("restart after reset: got %s, %v, want 1s, true", delay, ok)
+	}
+}
+
+func TestCrashRestarterMaxRestarts(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string // the app's user config, if any
+		want   int    // restarts before giving up
+	}{
+		{name: "default", want: 5},
+		{name: "disabled", config: "run.restart.max = 0\n", want: 0},
+		{name: "configured", config: "run.restart.max = 2\n", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appRoot := t.TempDir()
+			if tt.config != "" {
+				path := filepath.Join(appRoot, ".encore", "config")
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				} else if err := os.WriteFile(path, []byte(tt.config), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			c := newCrashRestarter(appRoot)
+			for i := range tt.want {
+				if _, ok := c.next(0); !ok {
+					t.Fatalf("gave up after %d restarts, want %d", i, tt.want)
+				}
+			}
+			if _, ok := c.next(0); ok {
+				t.Fatalf("restarted more than %d times", tt.want)
+			}
+
+			// Giving up isn't permanent: an app that ran for long enough is restarted again.
+			if _, ok := c.next(time.Minute); ok != (tt.want > 0) {
+				t.Errorf("after reset: got ok %v, want %v", ok, tt.want > 0)
+			}
+		})
+	}
+}
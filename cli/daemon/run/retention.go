@@ -0,0 +1,61 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/cockroachdb/errors"
+)
+
+// RetentionDryRun is what the data retention policies of a process
+// would delete if enforced now, and what its subject deletion handlers
+// would delete for a given subject.
+type RetentionDryRun struct {
+	Policies []struct {
+		Name        string `json:"name"`
+		Table       string `json:"table"`
+		MaxAge      string `json:"max_age"`
+		WouldDelete int64  `json:"would_delete"`
+		Error       string `json:"error"`
+	} `json:"policies"`
+	Subjects []struct {
+		Handler     string `json:"handler"`
+		WouldDelete int64  `json:"would_delete"`
+		Error       string `json:"error"`
+	} `json:"subjects"`
+}
+
+// RetentionDryRun asks the process what its data retention policies would delete,
+// and what deleting the given subject would delete, if non-empty.
+// It reports (nil, nil) if the process doesn't support data retention.
+func (p *Proc) RetentionDryRun(ctx context.Context, subjectID string) (*RetentionDryRun, error) {
+	u := fmt.Sprintf("http://%s/__encore/retention/dry-run", p.listenAddr)
+	if subjectID != "" {
+		u += "?" + url.Values{"subject": {subjectID}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	addAuthKeyToRequest(req, p.group.authKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to reach process")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("unexpected status %s", resp.Status)
+	}
+
+	var res RetentionDryRun
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "decode response")
+	}
+	return &res, nil
+}
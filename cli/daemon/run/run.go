@@ -8,15 +8,17 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/netip"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
 	"slices"
 	"sort"
-	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -30,11 +32,14 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/debugbundle"
 	"encore.dev/appruntime/exported/experiments"
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/internal/sqlcgen"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/run/infra"
 	"encr.dev/cli/daemon/secret"
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/internal/optracker"
 	"encr.dev/internal/userconfig"
 	"encr.dev/internal/version"
@@ -43,6 +48,7 @@ import (
 	"encr.dev/pkg/cueutil"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/promise"
+	"encr.dev/pkg/schemaexport"
 	"encr.dev/pkg/svcproxy"
 	"encr.dev/pkg/vcs"
 	daemonpb "encr.dev/proto/encore/daemon"
@@ -65,10 +71,20 @@ type Run struct {
 	Params  *StartParams
 	secrets *secret.LoadResult
 
-	ctx     context.Context // ctx is closed when the run is to exit
-	proc    atomic.Value    // current process
-	exited  chan struct{}   // exit is closed when the run has fully exited
-	started chan struct{}   // started is closed once the run has fully started
+	ctx      context.Context // ctx is closed when the run is to exit
+	proc     atomic.Value    // current process
+	draining sync.WaitGroup  // previous processes shutting down after a reload
+	exited   chan struct{}   // exit is closed when the run has fully exited
+	started  chan struct{}   // started is closed once the run has fully started
+
+	vulnHash atomic.Value // string; dependency hash of the last reported vulnerability scan
+	quotas   quotaSimulator
+	hosts    hostRouter
+	smtp     atomic.Pointer[smtpServer] // local SMTP server, if the app receives email
+
+	reloadMu         sync.Mutex  // serializes reloads and restarts
+	traceSamplingOff atomic.Bool // whether trace sampling was turned off with ToggleTraceSampling
+	stale            atomic.Bool // whether the last rebuild failed; see StaleBuild
 }
 
 // StartParams groups the parameters for the Run method.
@@ -89,10 +105,18 @@ type StartParams struct {
 	Listener   net.Listener // listener to use
 	ListenAddr string       // address we're listening on
 
+	// ExtraListeners are additional listeners to serve requests on,
+	// such as on the network of a docker-compose stack.
+	ExtraListeners []net.Listener
+
 	// Environ are the environment variables to set for the running app,
 	// in the same format as os.Environ().
 	Environ []string
 
+	// InterpolatedEnvKeys are the names of the variables in Environ whose
+	// values may contain ${...} references, expanded before starting the app.
+	InterpolatedEnvKeys []string
+
 	// The Ops tracker being used for this run
 	OpsTracker *optracker.OpTracker
 
@@ -107,6 +131,59 @@ type StartParams struct {
 
 	// ScrubSensitiveData enables scrubbing of sensitive data in local traces.
 	ScrubSensitiveData bool
+
+	// Emulation is the environment emulation profile to use.
+	// If unset it defaults to EmulateDevelopment.
+	Emulation EmulationProfile
+
+	// DebugBundles enables capturing debug bundles of failed requests.
+	DebugBundles bool
+
+	// VulnScan enables scanning the app's dependencies for
+	// known vulnerabilities after each build.
+	VulnScan bool
+
+	// GracefulShutdown configures how long the app is given
+	// to shut down gracefully. Zero timings use the defaults.
+	GracefulShutdown GracefulShutdown
+
+	// TLS reports whether the listener also serves HTTPS,
+	// making the API's base URL an https:// URL.
+	TLS bool
+
+	// Profile enables serving the pprof endpoints of each process
+	// on a port of its own. See [ProcGroup.ProfileAddrs].
+	Profile bool
+
+	// Services, if non-empty, are the only services to start,
+	// along with the gateways.
+	Services []string
+
+	// Upstream is the base URL of the environment calls to the services
+	// that aren't started are proxied to. If nil such calls fail.
+	Upstream *url.URL
+
+	// ExternalHosts are the addresses other devices on the network
+	// reach the app at, such as the machine's LAN address. The gateways
+	// accept requests made to them, but unlike requests made to localhost
+	// they aren't authenticated as coming from the Encore Platform.
+	ExternalHosts []string
+
+	// Offline, if true, runs the app without network access,
+	// using the secrets cached by a previous sync.
+	Offline bool
+
+	// HookStdout and HookStderr are where the output of the run hooks
+	// is written. If nil the output is discarded.
+	HookStdout, HookStderr io.Writer
+}
+
+// emulation returns the emulation profile to use for the run.
+func (p *StartParams) emulation() EmulationProfile {
+	if p.Emulation.Name == "" {
+		return EmulateDevelopment
+	}
+	return p.Emulation
 }
 
 // BrowserMode specifies how to open the browser when starting 'encore run'.
@@ -169,18 +246,25 @@ func (mgr *Manager) Start(ctx context.Context, params StartParams) (run *Run, er
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't create temp dir")
 	}
+	var secrets *secret.LoadResult
+	if params.Offline {
+		secrets = mgr.Secret.LoadCached(params.App)
+	} else {
+		secrets = mgr.Secret.Load(params.App)
+	}
+
 	run = &Run{
 		ID:              GenID(),
 		App:             params.App,
 		NS:              params.NS,
-		ResourceManager: infra.NewResourceManager(params.App, mgr.ClusterMgr, mgr.ObjectsMgr, mgr.PublicBuckets, params.NS, params.Environ, mgr.DBProxyPort, false),
+		ResourceManager: infra.NewResourceManager(params.App, mgr.ClusterMgr, mgr.MySQLMgr, mgr.ObjectsMgr, mgr.PublicBuckets, params.NS, params.Environ, mgr.Ports, mgr.DBProxyPort, false),
 		ListenAddr:      params.ListenAddr,
 		SvcProxy:        svcProxy,
 		log:             logger,
 		Mgr:             mgr,
 		Params:          &params,
 		TempDir:         tempDir,
-		secrets:         mgr.Secret.Load(params.App),
+		secrets:         secrets,
 		ctx:             ctx,
 		exited:          make(chan struct{}),
 		started:         make(chan struct{}),
@@ -237,6 +321,19 @@ type RunLogger interface {
 	RunStderr(r *Run, line []byte)
 }
 
+// HostMappings returns the local hostnames mapped to services and gateways.
+func (r *Run) HostMappings() []HostMapping {
+	return r.hosts.list()
+}
+
+// BaseURL returns the base URL of the app's API, for users to call it.
+func (r *Run) BaseURL() string {
+	if r.Params.TLS {
+		return "https://" + r.ListenAddr
+	}
+	return "http://" + r.ListenAddr
+}
+
 // ProcGroup returns the current running process.
 // It may have already exited.
 // If the proc has not yet started it may return nil.
@@ -262,12 +359,22 @@ func (r *Run) Done() <-chan struct{} {
 
 // Reload rebuilds the app and, if successful,
 // starts a new proc and switches over.
+//
+// If the rebuild fails the previous proc keeps serving requests,
+// and the run is marked as having a stale build until a rebuild succeeds.
 func (r *Run) Reload() error {
 	err := r.buildAndStart(r.ctx, nil, true)
 	if err != nil {
+		if r.ProcGroup() != nil && r.ctx.Err() == nil {
+			r.stale.Store(true)
+			for _, ln := range r.Mgr.listeners {
+				ln.OnStaleBuild(r)
+			}
+		}
 		return err
 	}
 
+	r.stale.Store(false)
 	for _, ln := range r.Mgr.listeners {
 		ln.OnReload(r)
 	}
@@ -275,6 +382,12 @@ func (r *Run) Reload() error {
 	return nil
 }
 
+// StaleBuild reports whether the last rebuild of the app failed,
+// in which case the previous build is still serving requests.
+func (r *Run) StaleBuild() bool {
+	return r.stale.Load()
+}
+
 // start starts the application and serves requests over HTTP using ln.
 func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 	defer func() {
@@ -286,6 +399,14 @@ func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 		}
 	}()
 
+	hooks, err := loadRunHooks(r.App.Root())
+	if err != nil {
+		return err
+	}
+	if err := r.runPreStartHooks(hooks.preStart); err != nil {
+		return err
+	}
+
 	err = r.buildAndStart(r.ctx, tracker, false)
 	if err != nil {
 		return err
@@ -308,26 +429,52 @@ func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 
 	// Run the http server until the app exits.
 	srv := &http.Server{Addr: ln.Addr().String(), Handler: handler}
-	go func() {
-		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
-			r.log.Error().Err(err).Msg("could not serve")
-		}
-	}()
+	for _, ln := range append([]net.Listener{ln}, r.Params.ExtraListeners...) {
+		go func() {
+			if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+				r.log.Error().Err(err).Msg("could not serve")
+			}
+		}()
+	}
 	go func() {
 		<-r.ctx.Done()
 		_ = srv.Close()
 	}()
+	r.startPostStartHooks(hooks.postStart)
+
+	if d, err := loadLeakDetector(r.App.Root()); err != nil {
+		r.Mgr.RunStderr(r, []byte("\n"+aurora.Red("warning: leak detection disabled: "+err.Error()).String()+"\n\n"))
+	} else if d != nil {
+		go r.monitorLeaks(d)
+	}
 
-	// Monitor the running proc and Close the app when it exits.
+	// Monitor the running proc, restart it if it crashes,
+	// and Close the app when it exits.
 	go func() {
+		restarter := newCrashRestarter(r.App.Root())
 		for {
 			p := r.proc.Load().(*ProcGroup)
-			<-p.Done()
+			select {
+			case <-p.Done():
+			case <-p.Crashed():
+			}
+			if p.Crash() != nil && r.ProcGroup() == p && r.ctx.Err() == nil {
+				if r.restartCrashed(p, restarter) {
+					continue
+				}
+				if r.Params.Watch {
+					// Keep running until the app is reloaded by a change.
+					r.waitForReload(p)
+				}
+				<-p.Done()
+			}
 			// p exited, but it could have been a reload.
 			// Check to make sure p is still the active proc.
 			p2 := r.proc.Load().(*ProcGroup)
 			if p2 == p {
-				// We're done.
+				// We're done, once the processes replaced by reloads have shut down.
+				r.draining.Wait()
+				r.stopSMTP()
 				for _, ln := range r.Mgr.listeners {
 					ln.OnStop(r)
 				}
@@ -339,6 +486,20 @@ func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 	return nil
 }
 
+// waitForReload waits until p is no longer the active proc,
+// or the run is closed.
+func (r *Run) waitForReload(p *ProcGroup) {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for r.ProcGroup() == p {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // buildAndStart builds the app, starts the proc, and cleans up
 // the build dir when it exits.
 // The proc exits when ctx is canceled.
@@ -404,7 +565,13 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		WorkingDir: r.Params.WorkingDir,
 	})
 	if err != nil {
-		return err
+		return buildErr(err)
+	}
+
+	// Generate the app's sqlc query packages before parsing the code using them.
+	if err := sqlcgen.Generate(procCtx, r.App.Root()); err != nil {
+		tracker.Fail(parseOp, errors.New("sqlc error"))
+		return buildErr(err)
 	}
 
 	parse, err := r.Builder.Parse(procCtx, builder.ParseParams{
@@ -418,22 +585,51 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 	if err != nil {
 		// Don't use the error itself in tracker.Fail, as it will lead to duplicate error output.
 		tracker.Fail(parseOp, errors.New("parse error"))
+		return buildErr(err)
+	}
+
+	appFile, err := r.App.AppFile()
+	if err != nil {
+		return errors.Wrap(err, "parse app file")
+	}
+	if err := validateQuotas(appFile.LocalQuotas); err != nil {
+		return err
+	}
+	hosts, err := parseHosts(appFile.LocalHosts, parse.Meta)
+	if err != nil {
 		return err
 	}
 
 	if err := r.App.CacheMetadata(parse.Meta); err != nil {
 		return errors.Wrap(err, "cache metadata")
 	}
+	if err := schemaexport.Write(r.App.Root(), parse.Meta); err != nil {
+		return errors.Wrap(err, "export schemas")
+	}
+	if err := checkEnvVars(r.App.Root(), parse.Meta, r.Params.Environ); err != nil {
+		tracker.Fail(parseOp, errors.New("invalid environment"))
+		return err
+	}
 	tracker.Done(parseOp, 500*time.Millisecond)
 	tracker.Done(topoOp, 300*time.Millisecond)
 
-	r.ResourceManager.StartRequiredServices(jobs, parse.Meta)
+	// On reload, only provision the infrastructure that changed
+	// since the running version of the app, if any.
+	var infraChanges infra.Changes
+	if isReload {
+		if prev := r.ProcGroup(); prev != nil {
+			infraChanges = infra.Diff(prev.Meta, parse.Meta)
+		}
+		r.ResourceManager.Reprovision(jobs, infraChanges, parse.Meta)
+	} else {
+		r.ResourceManager.StartRequiredServices(jobs, parse.Meta)
+	}
 
 	configProm := promise.New(func() (*builder.ServiceConfigsResult, error) {
 		return r.Builder.ServiceConfigs(ctx, builder.ServiceConfigsParams{
 			Parse: parse,
 			CueMeta: &cueutil.Meta{
-				APIBaseURL: fmt.Sprintf("http://%s", r.ListenAddr),
+				APIBaseURL: r.BaseURL(),
 				EnvName:    "local",
 				EnvType:    cueutil.EnvType_Development,
 				CloudType:  cueutil.CloudType_Local,
@@ -441,7 +637,10 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		})
 	})
 
-	var build *builder.CompileResult
+	var (
+		build      *builder.CompileResult
+		compiledAt time.Time
+	)
 	jobs.Go("Compiling application source code", false, 0, func(ctx context.Context) (err error) {
 		build, err = r.Builder.Compile(ctx, builder.CompileParams{
 			Build:       buildInfo,
@@ -453,8 +652,9 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 			Environ:     r.Params.Environ,
 		})
 		if err != nil {
-			return errors.Wrap(err, "compile error")
+			return buildErr(errors.Wrap(err, "compile error"))
 		}
+		compiledAt = time.Now()
 		return nil
 	})
 
@@ -472,6 +672,17 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		return err
 	}
 
+	// Database migrations run concurrently with compiling the app,
+	// so starting the app only waits for them if they take longer.
+	var migrationsWait time.Duration
+	if migratedAt := r.ResourceManager.MigratedAt(); migratedAt.After(compiledAt) {
+		migrationsWait = migratedAt.Sub(compiledAt)
+	}
+
+	if err := r.applyAutoSeeds(ctx, parse.Meta); err != nil {
+		return err
+	}
+
 	svcCfg, err := configProm.Get(ctx)
 	if err != nil {
 		return err
@@ -489,6 +700,7 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		WorkingDir:     r.Params.WorkingDir,
 		IsReload:       isReload,
 		Experiments:    expSet,
+		MigrationsWait: migrationsWait,
 	})
 	if err != nil {
 		tracker.Fail(startOp, err)
@@ -505,12 +717,32 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		}
 	}()
 
+	// Swap over to the new process, along with the configuration of the build,
+	// which is only applied now that the build has succeeded. The previous process
+	// is shut down gracefully in the background so that its in-flight requests can complete;
+	// the run doesn't exit until it has.
+	r.quotas.setConfig(appFile.LocalQuotas)
+	r.hosts.setHosts(hosts)
 	previousProcess := r.proc.Swap(newProcess)
 	if previousProcess != nil {
-		previousProcess.(*ProcGroup).Close()
+		r.draining.Add(1)
+		go func() {
+			defer r.draining.Done()
+			previousProcess.(*ProcGroup).Close()
+		}()
 	}
 
 	tracker.Done(startOp, 50*time.Millisecond)
+	r.startSMTP(parse.Meta)
+
+	if len(infraChanges) > 0 {
+		var buf bytes.Buffer
+		buf.WriteString("Infrastructure changes:\n")
+		for _, c := range infraChanges {
+			fmt.Fprintf(&buf, "  %s\n", c)
+		}
+		r.Mgr.RunStdout(r, buf.Bytes())
+	}
 
 	go func() {
 		// Wait one second before logging all the missing secrets.
@@ -524,6 +756,10 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		}
 	}()
 
+	if r.Params.VulnScan {
+		go r.reportVulns(procCtx)
+	}
+
 	return nil
 }
 
@@ -538,21 +774,32 @@ type StartProcGroupParams struct {
 	WorkingDir     string
 	IsReload       bool
 	Experiments    *experiments.Set
-}
 
-const gracefulShutdownTime = 10 * time.Second
+	// MigrationsWait is how long starting the processes waited
+	// for database migrations after the app was compiled.
+	MigrationsWait time.Duration
+}
 
 // StartProcGroup starts a single actual OS process for app.
 func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err error) {
 	pid := GenID()
+	emulation := r.Params.emulation()
 
-	userEnv := append([]string{
-		"ENCORE_RUNTIME_LOG=error",
-		// Always include internal messages when developing locally.
-		"ENCORE_API_INCLUDE_INTERNAL_MESSAGE=1",
-	}, params.Environ...)
+	userEnv := []string{"ENCORE_RUNTIME_LOG=error"}
+	if emulation.DevAccess {
+		// Include internal messages when developing locally.
+		userEnv = append(userEnv, "ENCORE_API_INCLUDE_INTERNAL_MESSAGE=1")
+	}
+	if r.Params.DebugBundles {
+		userEnv = append(userEnv, fmt.Sprintf("%s=http://localhost:%d/debug-bundle", debugbundle.EndpointEnvVar, r.Mgr.RuntimePort))
+	}
+	environ, err := r.interpolateEnviron(params.Environ, params.Secrets, params.Meta)
+	if err != nil {
+		return nil, err
+	}
+	userEnv = append(userEnv, emulation.ApplyEnviron(environ)...)
 
-	daemonProxyAddr, err := netip.ParseAddrPort(strings.ReplaceAll(r.ListenAddr, "localhost", "127.0.0.1"))
+	daemonProxyAddr, err := netip.ParseAddrPort(bindaddr.DialAddr(r.ListenAddr))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to parse listen address: %s", r.ListenAddr)
 	}
@@ -561,7 +808,7 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 	for _, gw := range params.Meta.Gateways {
 		gateways[gw.EncoreName] = GatewayConfig{
 			BaseURL:   gatewayBaseURL,
-			Hostnames: []string{"localhost"},
+			Hostnames: slices.Concat([]string{"localhost"}, r.Params.ExternalHosts, r.hosts.gatewayHosts(gw.EncoreName)),
 		}
 	}
 
@@ -580,6 +827,16 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 		}
 	}
 
+	limits, err := loadLimitsConfig(r.App.Root(), params.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	externalSvcs, err := r.externalServices(params.Meta)
+	if err != nil {
+		return nil, err
+	}
+
 	authKey := genAuthKey()
 	p = newProcGroup(procGroupOptions{
 		ProcID:  pid,
@@ -596,18 +853,27 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 			Gateways:          gateways,
 			DefinedSecrets:    params.Secrets,
 			SvcConfigs:        params.ServiceConfigs,
+			ExternalServices:  externalSvcs,
 			DeployID:          option.Some(fmt.Sprintf("run_%s", xid.New().String())),
 			IncludeMeta:       r.Builder.NeedsMeta(),
 			MetaPath:          metaPath,
 			RuntimeConfigPath: runtimeConfigPath,
 			LogLevel:          r.Params.LogLevel,
+			EnvType:           option.Some(emulation.EnvType),
+			StrictCORS:        emulation.StrictCORS,
+			TraceSamplingRate: r.traceSamplingRate(emulation),
+			GracefulShutdown:  r.Params.GracefulShutdown,
 		},
 		Experiments: params.Experiments,
 		Meta:        params.Meta,
 		Ctx:         params.Ctx,
 		WorkingDir:  params.WorkingDir,
 		Logger:      params.Logger,
+		Limits:      limits,
+		Profile:     r.Params.Profile,
+		DevAccess:   emulation.DevAccess,
 	})
+	p.startParams = params
 
 	if isSingleProc(params.Outputs) {
 		entrypoint := params.Outputs[0].GetEntrypoints()[0]
@@ -654,6 +920,10 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 				cmd := ep.Cmd.Expand(o.GetArtifactDir())
 				// create a process for each service
 				for _, svcName := range ep.Services {
+					if _, ok := externalSvcs[svcName]; ok {
+						continue
+					}
+
 					// Generate the environmental variables for the process
 					procConf, ok := svcConfs[svcName]
 					if !ok {
@@ -738,6 +1008,9 @@ type logWriter struct {
 	fn      func(r *Run, line []byte) // matches AppLogger.Log* signature
 	maxLine int                       // max line length, including '\n'
 	buf     *bytes.Buffer
+
+	maxTail int    // max size of tail; 0 disables keeping it
+	tail    []byte // the last lines forwarded to fn, up to maxTail bytes
 }
 
 func newLogWriter(run *Run, fn func(*Run, []byte)) *logWriter {
@@ -760,7 +1033,7 @@ func (w *logWriter) Write(b []byte) (int, error) {
 		// We have a line break; write the data to w.fn if it's not too long
 		if (w.buf.Len() + idx + 1) <= w.maxLine {
 			w.buf.Write(b[:idx+1])
-			w.fn(w.run, w.buf.Bytes())
+			w.forward(w.buf.Bytes())
 			w.buf.Reset()
 		}
 		b = b[idx+1:]
@@ -779,11 +1052,34 @@ func (w *logWriter) Write(b []byte) (int, error) {
 func (w *logWriter) Flush() {
 	if w.buf.Len() > 0 {
 		w.buf.WriteByte('\n')
-		w.fn(w.run, w.buf.Bytes())
+		w.forward(w.buf.Bytes())
 		w.buf.Reset()
 	}
 }
 
+// keepTail makes w keep the last n bytes of the lines written to it.
+func (w *logWriter) keepTail(n int) *logWriter {
+	w.maxTail = n
+	return w
+}
+
+// Tail returns the last lines written to w, if w keeps them.
+// It must not be called concurrently with any writes to w.
+func (w *logWriter) Tail() []byte {
+	return w.tail
+}
+
+// forward forwards line to w.fn, and keeps it in the tail.
+func (w *logWriter) forward(line []byte) {
+	w.fn(w.run, line)
+	if w.maxTail > 0 {
+		w.tail = append(w.tail, line...)
+		if over := len(w.tail) - w.maxTail; over > 0 {
+			w.tail = append(w.tail[:0], w.tail[over:]...)
+		}
+	}
+}
+
 // GenID generates a random run/process id.
 // It panics if it cannot get random bytes.
 func GenID() string {
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/netip"
 	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strconv"
@@ -24,6 +25,8 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"encore.dev/appruntime/exported/config"
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/cli/internal/bindaddr"
 	encoreEnv "encr.dev/internal/env"
 	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/fns"
@@ -52,7 +55,9 @@ type RuntimeConfigGenerator struct {
 	app interface {
 		PlatformID() string
 		PlatformOrLocalID() string
+		Root() string
 		GlobalCORS() (appfile.CORS, error)
+		GatewayTransforms() ([]appfile.GatewayTransform, error)
 		AppFile() (*appfile.File, error)
 		BuildSettings() (appfile.Build, error)
 	}
@@ -60,6 +65,7 @@ type RuntimeConfigGenerator struct {
 	// The infra manager to use
 	infraManager interface {
 		SQLServerConfig() (config.SQLServer, error)
+		MySQLServerConfig() (config.SQLServer, error)
 		PubSubProviderConfig() (config.PubsubProvider, error)
 
 		SQLDatabaseConfig(db *meta.SQLDatabase) (config.SQLDatabase, error)
@@ -91,11 +97,27 @@ type RuntimeConfigGenerator struct {
 	// Minimum log level, if any.
 	LogLevel option.Option[string]
 
+	// StrictCORS, if true, enforces the CORS configuration from the app file
+	// instead of allowing all origins.
+	StrictCORS bool
+
+	// The default trace sampling rate, if any.
+	// ENCORE_TRACE_SAMPLING_RATE takes precedence.
+	TraceSamplingRate option.Option[float64]
+
+	// GracefulShutdown configures the graceful shutdown of the app.
+	// Zero timings use the defaults.
+	GracefulShutdown GracefulShutdown
+
 	// The values of defined secrets.
 	DefinedSecrets map[string]string
 	// The configs, per service.
 	SvcConfigs map[string]string
 
+	// ExternalServices are the base URLs of the services that aren't
+	// run locally, by name. They're called without service authentication.
+	ExternalServices map[string]string
+
 	conf     *rtconfgen.Builder
 	authKeys []*runtimev1.EncoreAuthKey
 }
@@ -138,7 +160,7 @@ func (g *RuntimeConfigGenerator) initialize() error {
 		})
 
 		if traceEndpoint, ok := g.TraceEndpoint.Get(); ok {
-			sampleRate := 1.0
+			sampleRate := g.TraceSamplingRate.GetOrElse(1.0)
 			if val, err := strconv.ParseFloat(os.Getenv("ENCORE_TRACE_SAMPLING_RATE"), 64); err == nil {
 				sampleRate = min(max(val, 0), 1)
 			}
@@ -191,39 +213,53 @@ func (g *RuntimeConfigGenerator) initialize() error {
 			},
 		})
 
+		shutdown := g.GracefulShutdown.withDefaults()
 		g.conf.DefaultGracefulShutdown(&runtimev1.GracefulShutdown{
-			Total:         durationpb.New(10 * time.Second),
-			ShutdownHooks: durationpb.New(4 * time.Second),
-			Handlers:      durationpb.New(2 * time.Second),
+			Total:         durationpb.New(shutdown.Total),
+			ShutdownHooks: durationpb.New(shutdown.ShutdownHooks),
+			Handlers:      durationpb.New(shutdown.Handlers),
 		})
 
+		transforms, err := g.app.GatewayTransforms()
+		if err != nil {
+			return errors.Wrap(err, "failed to generate gateway transforms")
+		}
+
 		for _, gw := range g.md.Gateways {
 			cors, err := g.app.GlobalCORS()
 			if err != nil {
 				return errors.Wrap(err, "failed to generate global CORS config")
 			}
 
+			corsCfg := &runtimev1.Gateway_CORS{
+				Debug:               cors.Debug,
+				DisableCredentials:  false,
+				ExtraAllowedHeaders: cors.AllowHeaders,
+				ExtraExposedHeaders: cors.ExposeHeaders,
+
+				AllowedOriginsWithCredentials: &runtimev1.Gateway_CORS_UnsafeAllowAllOriginsWithCredentials{
+					UnsafeAllowAllOriginsWithCredentials: true,
+				},
+
+				AllowedOriginsWithoutCredentials: &runtimev1.Gateway_CORSAllowedOrigins{
+					AllowedOrigins: []string{"*"},
+				},
+
+				AllowPrivateNetworkAccess: true,
+			}
+			if g.StrictCORS {
+				corsCfg = strictCORSConfig(cors)
+			}
+
 			g.conf.Infra.Gateway(&runtimev1.Gateway{
 				Rid:        newRid(),
 				EncoreName: gw.EncoreName,
 				BaseUrl:    g.Gateways[gw.EncoreName].BaseURL,
 				Hostnames:  g.Gateways[gw.EncoreName].Hostnames,
-				Cors: &runtimev1.Gateway_CORS{
-					Debug:               cors.Debug,
-					DisableCredentials:  false,
-					ExtraAllowedHeaders: cors.AllowHeaders,
-					ExtraExposedHeaders: cors.ExposeHeaders,
-
-					AllowedOriginsWithCredentials: &runtimev1.Gateway_CORS_UnsafeAllowAllOriginsWithCredentials{
-						UnsafeAllowAllOriginsWithCredentials: true,
-					},
+				Cors:       corsCfg,
+				Transforms: gatewayTransforms(transforms),
 
-					AllowedOriginsWithoutCredentials: &runtimev1.Gateway_CORSAllowedOrigins{
-						AllowedOrigins: []string{"*"},
-					},
-
-					AllowPrivateNetworkAccess: true,
-				},
+				ClientMetadata: g.clientMetadata(appFile.ClientMetadata),
 			})
 		}
 
@@ -282,7 +318,7 @@ func (g *RuntimeConfigGenerator) initialize() error {
 			}
 		}
 
-		if len(g.md.SqlDatabases) > 0 {
+		if dbs := sqldb.Databases(g.md); len(dbs) > 0 {
 			srvConfig, err := g.infraManager.SQLServerConfig()
 			if err != nil {
 				return errors.Wrap(err, "failed to generate SQL server config")
@@ -306,7 +342,7 @@ func (g *RuntimeConfigGenerator) initialize() error {
 				TlsConfig: tlsConfig,
 			})
 
-			for _, db := range g.md.SqlDatabases {
+			for _, db := range dbs {
 				if externalDB, ok := g.DefinedSecrets["sqldb::"+db.Name]; ok {
 					var extCfg struct {
 						ConnectionString string `json:"connection_string"`
@@ -362,23 +398,106 @@ func (g *RuntimeConfigGenerator) initialize() error {
 						Password:      toSecret([]byte(dbConfig.Password)),
 						ClientCertRid: nil,
 					})
-					cluster.SQLDatabase(&runtimev1.SQLDatabase{
+					sqlDB := cluster.SQLDatabase(&runtimev1.SQLDatabase{
 						Rid:        newRid(),
 						EncoreName: dbConfig.EncoreName,
 						CloudName:  dbConfig.DatabaseName,
 						ConnPools:  nil,
-					}).AddConnectionPool(&runtimev1.SQLConnectionPool{
+					})
+					sqlDB.AddConnectionPool(&runtimev1.SQLConnectionPool{
 						IsReadonly:     false,
 						RoleRid:        roleRid,
 						MinConnections: int32(dbConfig.MinConnections),
 						MaxConnections: int32(dbConfig.MaxConnections),
 					})
 
+					// Add a read-only pool for the services granted read access.
+					if dbConfig.ReadOnlyUser != "" {
+						roRoleRid := fmt.Sprintf("role:%s:%s", cluster.Val.Rid, dbConfig.ReadOnlyUser)
+						g.conf.Infra.SQLRole(&runtimev1.SQLRole{
+							Rid:      roRoleRid,
+							Username: dbConfig.ReadOnlyUser,
+							Password: toSecret([]byte(dbConfig.ReadOnlyPassword)),
+						})
+						sqlDB.AddConnectionPool(&runtimev1.SQLConnectionPool{
+							IsReadonly:     true,
+							RoleRid:        roRoleRid,
+							MinConnections: int32(dbConfig.MinConnections),
+							MaxConnections: int32(dbConfig.MaxConnections),
+						})
+					}
+
+				}
+
+			}
+		}
+
+		if dbs := sqldb.MySQLDatabases(g.md); len(dbs) > 0 {
+			srvConfig, err := g.infraManager.MySQLServerConfig()
+			if err != nil {
+				return errors.Wrap(err, "failed to generate MySQL server config")
+			}
+
+			cluster := g.conf.Infra.SQLCluster(&runtimev1.SQLCluster{
+				Rid:    newRid(),
+				Engine: runtimev1.SQLEngine_SQL_ENGINE_MYSQL,
+			})
+			cluster.SQLServer(&runtimev1.SQLServer{
+				Rid:  newRid(),
+				Kind: runtimev1.ServerKind_SERVER_KIND_PRIMARY,
+				Host: srvConfig.Host,
+			})
+
+			for _, db := range dbs {
+				dbConfig, err := g.infraManager.SQLDatabaseConfig(db)
+				if err != nil {
+					return errors.Wrap(err, "failed to generate MySQL database config")
 				}
 
+				roleRid := fmt.Sprintf("role:%s:%s", cluster.Val.Rid, dbConfig.User)
+				g.conf.Infra.SQLRole(&runtimev1.SQLRole{
+					Rid:      roleRid,
+					Username: dbConfig.User,
+					Password: toSecret([]byte(dbConfig.Password)),
+				})
+				cluster.SQLDatabase(&runtimev1.SQLDatabase{
+					Rid:        newRid(),
+					EncoreName: dbConfig.EncoreName,
+					CloudName:  dbConfig.DatabaseName,
+				}).AddConnectionPool(&runtimev1.SQLConnectionPool{
+					RoleRid: roleRid,
+				})
 			}
 		}
 
+		if len(g.md.SearchIndexes) > 0 {
+			// Search indexes are stored in the search database using Postgres full-text search.
+			cluster := g.conf.Infra.SearchCluster(&runtimev1.SearchCluster{
+				Rid: newRid(),
+				Provider: &runtimev1.SearchCluster_Postgres_{
+					Postgres: &runtimev1.SearchCluster_Postgres{Database: sqldb.SearchDatabase},
+				},
+			})
+			for _, idx := range g.md.SearchIndexes {
+				cluster.SearchIndex(&runtimev1.SearchIndex{
+					Rid:        newRid(),
+					EncoreName: idx.Name,
+					CloudName:  idx.Name,
+				})
+			}
+		}
+
+		encKey, err := localEncryptionKey(g.app.PlatformOrLocalID())
+		if err != nil {
+			return errors.Wrap(err, "failed to load local encryption key")
+		}
+		g.conf.Infra.EncryptionKey(&runtimev1.EncryptionKey{
+			Rid: newRid(),
+			Provider: &runtimev1.EncryptionKey_Local_{
+				Local: &runtimev1.EncryptionKey_Local{Key: toSecret(encKey)},
+			},
+		})
+
 		if len(g.md.CacheClusters) > 0 {
 			for _, cl := range g.md.CacheClusters {
 				srvConfig, dbConfig, err := g.infraManager.RedisConfig(cl)
@@ -490,6 +609,76 @@ func (g *RuntimeConfigGenerator) initialize() error {
 	})
 }
 
+// gatewayTransforms converts the gateway transforms in the app file
+// to their runtime configuration.
+func gatewayTransforms(transforms []appfile.GatewayTransform) []*runtimev1.Gateway_Transform {
+	var out []*runtimev1.Gateway_Transform
+	for _, t := range transforms {
+		pb := &runtimev1.Gateway_Transform{
+			Endpoints:           t.Endpoints,
+			RequestHeaders:      t.RequestHeaders,
+			ResponseHeaders:     t.ResponseHeaders,
+			StripResponseFields: t.StripResponseFields,
+		}
+		for _, c := range t.Compression {
+			switch c {
+			case "gzip":
+				pb.Compression = append(pb.Compression, runtimev1.Gateway_COMPRESSION_GZIP)
+			case "br":
+				pb.Compression = append(pb.Compression, runtimev1.Gateway_COMPRESSION_BROTLI)
+			case "zstd":
+				pb.Compression = append(pb.Compression, runtimev1.Gateway_COMPRESSION_ZSTD)
+			}
+		}
+		out = append(out, pb)
+	}
+	return out
+}
+
+// clientMetadata converts the client metadata settings in the app file
+// to their runtime configuration, resolving the GeoIP database path
+// relative to the app root.
+func (g *RuntimeConfigGenerator) clientMetadata(m *appfile.ClientMetadata) *runtimev1.Gateway_ClientMetadata {
+	if m == nil {
+		return nil
+	}
+	db := m.GeoIPDatabase
+	if db != "" && !filepath.IsAbs(db) {
+		db = filepath.Join(g.app.Root(), db)
+	}
+	return &runtimev1.Gateway_ClientMetadata{
+		GeoipDatabase:  db,
+		ClientIpHeader: m.ClientIPHeader,
+	}
+}
+
+// strictCORSConfig returns the CORS configuration the app would get when
+// deployed, based on the global CORS settings in the app file.
+func strictCORSConfig(cors appfile.CORS) *runtimev1.Gateway_CORS {
+	withoutCreds := cors.AllowOriginsWithoutCredentials
+	if withoutCreds == nil {
+		withoutCreds = []string{"*"}
+	}
+	return &runtimev1.Gateway_CORS{
+		Debug:               cors.Debug,
+		DisableCredentials:  false,
+		ExtraAllowedHeaders: cors.AllowHeaders,
+		ExtraExposedHeaders: cors.ExposeHeaders,
+
+		AllowedOriginsWithCredentials: &runtimev1.Gateway_CORS_AllowedOrigins{
+			AllowedOrigins: &runtimev1.Gateway_CORSAllowedOrigins{
+				AllowedOrigins: cors.AllowOriginsWithCredentials,
+			},
+		},
+
+		AllowedOriginsWithoutCredentials: &runtimev1.Gateway_CORSAllowedOrigins{
+			AllowedOrigins: withoutCreds,
+		},
+
+		AllowPrivateNetworkAccess: false,
+	}
+}
+
 type ProcConfig struct {
 	// The runtime config to add to the process, if any.
 	Runtime option.Option[*runtimev1.RuntimeConfig]
@@ -510,8 +699,12 @@ func (g *RuntimeConfigGenerator) ProcPerService(proxy *svcproxy.SvcProxy) (servi
 
 	sd := &runtimev1.ServiceDiscovery{Services: make(map[string]*runtimev1.ServiceDiscovery_Location)}
 
+	g.addExternalServices(sd)
 	svcListenAddr := make(map[string]netip.AddrPort)
 	for _, svc := range g.md.Svcs {
+		if g.isExternal(svc.Name) {
+			continue
+		}
 		listenAddr, err := freeLocalhostAddress()
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "failed to find free localhost address")
@@ -533,6 +726,9 @@ func (g *RuntimeConfigGenerator) ProcPerService(proxy *svcproxy.SvcProxy) (servi
 
 	// Set up the service processes.
 	for _, svc := range g.md.Svcs {
+		if g.isExternal(svc.Name) {
+			continue
+		}
 		conf, err := g.conf.Deployment(newRid()).
 			ServiceDiscovery(sd).
 			HostsServices(svc.Name).
@@ -584,12 +780,16 @@ func (g *RuntimeConfigGenerator) AllInOneProc(useRuntimeConfigV2 bool) (*ProcCon
 
 	sd := &runtimev1.ServiceDiscovery{Services: make(map[string]*runtimev1.ServiceDiscovery_Location)}
 
+	g.addExternalServices(sd)
+
 	d := g.conf.Deployment(newRid()).ServiceDiscovery(sd)
 	for _, gw := range g.md.Gateways {
 		d.HostsGateways(gw.EncoreName)
 	}
 	for _, svc := range g.md.Svcs {
-		d.HostsServices(svc.Name)
+		if !g.isExternal(svc.Name) {
+			d.HostsServices(svc.Name)
+		}
 	}
 
 	conf, err := d.ReduceWithMeta(g.md).BuildRuntimeConfig()
@@ -633,9 +833,13 @@ func (g *RuntimeConfigGenerator) ProcPerServiceWithNewRuntimeConfig(proxy *svcpr
 
 	sd := &runtimev1.ServiceDiscovery{Services: make(map[string]*runtimev1.ServiceDiscovery_Location)}
 
+	g.addExternalServices(sd)
 	svcListenAddr := make(map[string]netip.AddrPort)
 	var svcNames []string
 	for _, svc := range g.md.Svcs {
+		if g.isExternal(svc.Name) {
+			continue
+		}
 		svcNames = append(svcNames, svc.Name)
 		listenAddr, err := freeLocalhostAddress()
 		if err != nil {
@@ -657,6 +861,9 @@ func (g *RuntimeConfigGenerator) ProcPerServiceWithNewRuntimeConfig(proxy *svcpr
 	}
 
 	for _, svc := range g.md.Svcs {
+		if g.isExternal(svc.Name) {
+			continue
+		}
 		conf, err = g.conf.Deployment(newRid()).
 			ServiceDiscovery(sd).
 			HostsServices(svc.Name).
@@ -697,6 +904,19 @@ func (g *RuntimeConfigGenerator) ProcPerServiceWithNewRuntimeConfig(proxy *svcpr
 	return
 }
 
+// isExternal reports whether the service isn't run locally.
+func (g *RuntimeConfigGenerator) isExternal(svcName string) bool {
+	_, ok := g.ExternalServices[svcName]
+	return ok
+}
+
+// addExternalServices adds the services that aren't run locally to sd.
+func (g *RuntimeConfigGenerator) addExternalServices(sd *runtimev1.ServiceDiscovery) {
+	for name, baseURL := range g.ExternalServices {
+		sd.Services[name] = &runtimev1.ServiceDiscovery_Location{BaseUrl: baseURL}
+	}
+}
+
 func (g *RuntimeConfigGenerator) ForTests(newRuntimeConf bool) (envs []string, err error) {
 	if err := g.initialize(); err != nil {
 		return nil, err
@@ -936,7 +1156,7 @@ func secretsUsedByServices(md *meta.Data, svcNames ...string) (secretNames map[s
 
 // freeLocalhostAddress returns the first free port number on the system.
 func freeLocalhostAddress() (netip.AddrPort, error) {
-	l, err := net.Listen("tcp", "127.0.0.1:0")
+	l, err := bindaddr.ListenLoopback(0)
 	if err != nil {
 		return netip.AddrPort{}, err
 	}
@@ -0,0 +1,177 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/mod/modfile"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/dbseed"
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/internal/optracker"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/paths"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// ApplySeedsParams groups the parameters for the ApplySeeds method.
+type ApplySeedsParams struct {
+	// App is the app to seed the databases of.
+	App *apps.Instance
+
+	// NS is the namespace the databases are in.
+	NS *namespace.Namespace
+
+	// Cluster is the running cluster of the namespace,
+	// with the databases set up and migrated.
+	Cluster *sqldb.Cluster
+
+	// Databases are the names of the databases to seed.
+	// If nil all databases with seeds are seeded.
+	Databases []string
+
+	// Force applies the seeds that have already been applied again.
+	Force bool
+
+	// Quiet only reports the seeds that are applied, and not
+	// when there are none or when applied seeds have changed.
+	Quiet bool
+
+	// Environ are the environment variables to execute Go seeds with,
+	// in the same format as os.Environ().
+	Environ []string
+
+	// Stdout and Stderr are where the progress and the output of the seeds are written.
+	Stdout, Stderr io.Writer
+}
+
+// ApplySeeds applies the seeds declared in encore.app that haven't been
+// applied to the databases of the namespace, recording them as applied.
+// It stops at the first seed that fails.
+func (mgr *Manager) ApplySeeds(ctx context.Context, p ApplySeedsParams) error {
+	cfg, err := appfile.SeedsConfig(p.App.Root())
+	if err != nil {
+		return errors.Wrap(err, "unable to parse encore.app")
+	}
+	seeds, err := dbseed.Load(p.App.Root(), cfg, p.Databases)
+	if err != nil {
+		return err
+	}
+
+	appID := p.App.PlatformOrLocalID()
+	applied, err := mgr.Seeds.List(ctx, appID, p.NS.ID)
+	if err != nil {
+		return err
+	}
+	pending, changed := dbseed.Pending(seeds, applied, p.Force)
+	if !p.Quiet {
+		for _, seed := range changed {
+			_, _ = fmt.Fprintf(p.Stderr, "warning: seed %s has changed since it was applied, use --force to apply it again\n", seed)
+		}
+	}
+	if len(pending) == 0 {
+		if !p.Quiet {
+			_, _ = fmt.Fprintln(p.Stdout, "No seeds to apply.")
+		}
+		return nil
+	}
+
+	for _, seed := range pending {
+		_, _ = fmt.Fprintf(p.Stdout, "Applying seed %s...\n", seed)
+		if err := mgr.applySeed(ctx, p, seed); err != nil {
+			return errors.Wrapf(err, "seed %s failed", seed)
+		}
+		err := mgr.Seeds.Record(ctx, appID, p.NS.ID, &dbseed.Applied{
+			Database:  seed.Database,
+			Seed:      seed.Name,
+			Checksum:  seed.Checksum,
+			AppliedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	_, _ = fmt.Fprintf(p.Stdout, "Applied %d seed(s).\n", len(pending))
+	return nil
+}
+
+func (mgr *Manager) applySeed(ctx context.Context, p ApplySeedsParams, seed *dbseed.Seed) error {
+	if seed.SQL != "" {
+		db, ok := p.Cluster.GetDB(seed.Database)
+		if !ok {
+			return errors.Newf("database %s not found", seed.Database)
+		}
+		return db.ExecScript(ctx, string(seed.Script))
+	}
+
+	if p.App.Lang() != appfile.LangGo {
+		return errors.New("Go seeds are only supported for Go apps")
+	}
+	modPath := filepath.Join(p.App.Root(), "go.mod")
+	modData, err := os.ReadFile(modPath)
+	if err != nil {
+		return err
+	}
+	mod, err := modfile.Parse(modPath, modData, nil)
+	if err != nil {
+		return err
+	}
+
+	return mgr.ExecScript(ctx, ExecScriptParams{
+		App:        p.App,
+		NS:         p.NS,
+		MainPkg:    paths.Pkg(mod.Module.Mod.Path).JoinSlash(paths.RelSlash(seed.Go)),
+		WorkingDir: ".",
+		Environ:    p.Environ,
+		Stdout:     p.Stdout,
+		Stderr:     p.Stderr,
+		OpTracker:  optracker.NewLineMode(io.Discard),
+	})
+}
+
+// applyAutoSeeds applies the pending seeds of the databases created
+// for the run, such as in a new namespace, if encore.app enables it.
+func (r *Run) applyAutoSeeds(ctx context.Context, md *meta.Data) error {
+	cfg, err := appfile.SeedsConfig(r.App.Root())
+	if err != nil {
+		return errors.Wrap(err, "unable to parse encore.app")
+	} else if cfg == nil || !cfg.AutoApply || r.NS == nil {
+		return nil
+	}
+	cluster := r.ResourceManager.GetSQLCluster()
+	if cluster == nil {
+		return nil
+	}
+
+	var created []string
+	for _, dbMeta := range sqldb.Databases(md) {
+		if _, ok := cfg.Databases[dbMeta.Name]; !ok {
+			continue
+		}
+		if db, ok := cluster.GetDB(dbMeta.Name); ok && db.Created() {
+			created = append(created, dbMeta.Name)
+		}
+	}
+	if len(created) == 0 {
+		return nil
+	}
+
+	stdout, stderr := r.hookOutput()
+	return r.Mgr.ApplySeeds(ctx, ApplySeedsParams{
+		App:       r.App,
+		NS:        r.NS,
+		Cluster:   cluster,
+		Databases: created,
+		Quiet:     true,
+		Environ:   r.Params.Environ,
+		Stdout:    stdout,
+		Stderr:    stderr,
+	})
+}
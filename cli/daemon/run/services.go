@@ -0,0 +1,42 @@
+package run
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// externalServices returns the base URLs of the services the run doesn't start,
+// by name, when it only starts some of them. Calls to them are proxied to
+// the upstream environment, or fail if there is none.
+func (r *Run) externalServices(md *meta.Data) (map[string]string, error) {
+	if len(r.Params.Services) == 0 {
+		return nil, nil
+	}
+
+	for _, name := range r.Params.Services {
+		if !slices.ContainsFunc(md.Svcs, func(svc *meta.Service) bool { return svc.Name == name }) {
+			names := make([]string, len(md.Svcs))
+			for i, svc := range md.Svcs {
+				names[i] = svc.Name
+			}
+			return nil, errors.Newf("unknown service %q (the app's services are: %s)", name, strings.Join(names, ", "))
+		}
+	}
+
+	external := make(map[string]string)
+	for _, svc := range md.Svcs {
+		if slices.Contains(r.Params.Services, svc.Name) {
+			continue
+		}
+		if r.Params.Upstream != nil {
+			external[svc.Name] = r.SvcProxy.RegisterUpstream(svc.Name, r.Params.Upstream)
+		} else {
+			external[svc.Name] = r.SvcProxy.RegisterStub(svc.Name)
+		}
+	}
+	return external, nil
+}
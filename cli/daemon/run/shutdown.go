@@ -0,0 +1,72 @@
+package run
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/appfile"
+)
+
+// GracefulShutdown configures how long the app is given to shut down gracefully
+// when the run is stopped or reloaded. Zero timings use the defaults.
+type GracefulShutdown struct {
+	// Total is how long the app is given to shut down before it is killed.
+	Total time.Duration
+
+	// Handlers is how long before Total runs out that the contexts
+	// passed to running API and Pub/Sub handlers are canceled.
+	Handlers time.Duration
+
+	// ShutdownHooks is how long before Total runs out that the context
+	// passed to shutdown hooks is canceled.
+	ShutdownHooks time.Duration
+}
+
+// DefaultGracefulShutdown is the graceful shutdown used when none is configured.
+var DefaultGracefulShutdown = GracefulShutdown{
+	Total:         10 * time.Second,
+	Handlers:      2 * time.Second,
+	ShutdownHooks: 4 * time.Second,
+}
+
+// withDefaults returns g with the zero timings replaced by their defaults.
+func (g GracefulShutdown) withDefaults() GracefulShutdown {
+	if g.Total == 0 {
+		g.Total = DefaultGracefulShutdown.Total
+	}
+	if g.Handlers == 0 {
+		g.Handlers = min(DefaultGracefulShutdown.Handlers, g.Total)
+	}
+	if g.ShutdownHooks == 0 {
+		g.ShutdownHooks = min(DefaultGracefulShutdown.ShutdownHooks, g.Total)
+	}
+	return g
+}
+
+// LoadGracefulShutdown loads the graceful shutdown configuration
+// of the app at appRoot from its encore.app file.
+func LoadGracefulShutdown(appRoot string) (GracefulShutdown, error) {
+	var g GracefulShutdown
+	cfg, err := appfile.GracefulShutdownConfig(appRoot)
+	if err != nil {
+		return g, errors.Wrap(err, "unable to parse encore.app")
+	} else if cfg == nil {
+		return g, nil
+	}
+
+	// The durations are validated by appfile.Parse.
+	parse := func(s string) time.Duration {
+		d, _ := time.ParseDuration(s)
+		return d
+	}
+	g.Total, g.Handlers, g.ShutdownHooks = parse(cfg.Total), parse(cfg.Handlers), parse(cfg.ShutdownHooks)
+
+	total := g.withDefaults().Total
+	if g.Handlers > total {
+		return g, errors.Newf("graceful_shutdown: handlers (%v) exceeds total (%v)", g.Handlers, total)
+	} else if g.ShutdownHooks > total {
+		return g, errors.Newf("graceful_shutdown: shutdown_hooks (%v) exceeds total (%v)", g.ShutdownHooks, total)
+	}
+	return g, nil
+}
@@ -0,0 +1,67 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// StartupReport is the breakdown of the startup of a process,
+// as reported by the Encore runtime.
+type StartupReport struct {
+	Complete      bool            `json:"complete"`
+	Total         time.Duration   `json:"total_ns"`
+	PackageInit   time.Duration   `json:"package_init_ns"`
+	ResourceSetup time.Duration   `json:"resource_setup_ns"`
+	RuntimeSetup  time.Duration   `json:"runtime_setup_ns"`
+	ServiceInit   time.Duration   `json:"service_init_ns"`
+	Resources     []StartupTiming `json:"resources"`
+	Services      []StartupTiming `json:"services"`
+}
+
+// StartupTiming is the time it took to set up a resource or initialize a service.
+type StartupTiming struct {
+	Kind     string        `json:"kind"`
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// StartupReport asks the process where the time went when it started.
+func (p *Proc) StartupReport(ctx context.Context) (*StartupReport, error) {
+	url := fmt.Sprintf("http://%s/__encore/debug/startup", p.listenAddr)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	addAuthKeyToRequest(req, p.group.authKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to reach process")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.New("the process does not support startup reports (only Go apps do)")
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("unexpected status %s", resp.Status)
+	}
+
+	var res StartupReport
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "decode response")
+	}
+	return &res, nil
+}
+
+// MigrationsWait is how long starting the processes of the group
+// waited for database migrations after the app was compiled.
+func (pg *ProcGroup) MigrationsWait() time.Duration {
+	if pg.startParams == nil {
+		return 0
+	}
+	return pg.startParams.MigrationsWait
+}
@@ -51,12 +51,16 @@ func (mgr *Manager) Test(ctx context.Context, params TestParams) (err error) {
 	}
 
 	workingDir := paths.RootedFSPath(params.App.Root(), params.WorkingDir)
-	return bld.RunTests(ctx, builder.RunTestsParams{
+	err = bld.RunTests(ctx, builder.RunTestsParams{
 		Spec:       spec,
 		WorkingDir: workingDir,
 		Stdout:     params.Stdout,
 		Stderr:     params.Stderr,
 	})
+	if err != nil {
+		return errors.Mark(err, ErrTestsFailed)
+	}
+	return nil
 }
 
 // TestSpecParams are the parameters for computing a test spec.
@@ -155,7 +159,7 @@ func (mgr *Manager) testSpec(ctx context.Context, bld builder.Impl, expSet *expe
 		WorkingDir: params.WorkingDir,
 	})
 	if err != nil {
-		return nil, err
+		return nil, buildErr(err)
 	}
 	parse, err := bld.Parse(ctx, builder.ParseParams{
 		Build:       buildInfo,
@@ -166,13 +170,13 @@ func (mgr *Manager) testSpec(ctx context.Context, bld builder.Impl, expSet *expe
 		Prepare:     prepareResult,
 	})
 	if err != nil {
-		return nil, err
+		return nil, buildErr(err)
 	}
 	if err := params.App.CacheMetadata(parse.Meta); err != nil {
 		return nil, errors.Wrap(err, "cache metadata")
 	}
 
-	rm := infra.NewResourceManager(params.App, mgr.ClusterMgr, mgr.ObjectsMgr, mgr.PublicBuckets, params.NS, nil, mgr.DBProxyPort, true)
+	rm := infra.NewResourceManager(params.App, mgr.ClusterMgr, mgr.MySQLMgr, mgr.ObjectsMgr, mgr.PublicBuckets, params.NS, nil, mgr.Ports, mgr.DBProxyPort, true)
 
 	jobs := optracker.NewAsyncBuildJobs(ctx, params.App.PlatformOrLocalID(), nil)
 	rm.StartRequiredServices(jobs, parse.Meta)
@@ -0,0 +1,83 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v3"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/vulnscan"
+)
+
+// VulnReport returns the report of known vulnerabilities in the dependencies
+// of an app. The dependencies are only scanned again if they changed since the
+// last scan, or if refresh is true.
+func (mgr *Manager) VulnReport(ctx context.Context, app *apps.Instance, refresh bool) (*vulnscan.Report, error) {
+	lang := vulnscan.Go
+	if app.Lang() == appfile.LangTS {
+		lang = vulnscan.TypeScript
+	}
+
+	// Serialize scans so concurrent callers share the result.
+	mgr.vulnMu.Lock()
+	defer mgr.vulnMu.Unlock()
+
+	appID := app.PlatformOrLocalID()
+	if prev := mgr.vulns[appID]; prev != nil && !refresh {
+		if hash, err := vulnscan.ManifestHash(app.Root(), lang); err == nil && hash == prev.Hash {
+			return prev, nil
+		}
+	}
+
+	rep, err := vulnscan.Scan(ctx, app.Root(), lang)
+	if err != nil {
+		return nil, err
+	}
+	if mgr.vulns == nil {
+		mgr.vulns = make(map[string]*vulnscan.Report)
+	}
+	mgr.vulns[appID] = rep
+	return rep, nil
+}
+
+// reportVulns scans the dependencies of the app and writes the reachable
+// vulnerabilities to the run output, unless they were already reported
+// for the same dependencies.
+func (r *Run) reportVulns(ctx context.Context) {
+	rep, err := r.Mgr.VulnReport(ctx, r.App, false)
+	if err != nil {
+		if ctx.Err() == nil {
+			line := aurora.Yellow(fmt.Sprintf("Vulnerability scan failed: %v", err)).String() + "\n"
+			r.Mgr.RunStderr(r, []byte(line))
+		}
+		return
+	}
+	if prev, _ := r.vulnHash.Swap(rep.Hash).(string); prev == rep.Hash {
+		return
+	}
+
+	for _, f := range rep.Reachable() {
+		module := f.Module
+		if f.Version != "" {
+			module += "@" + f.Version
+		}
+		fix := "no fix available"
+		if f.FixedVersion != "" {
+			fix = "fixed in " + f.FixedVersion
+		}
+		title := fmt.Sprintf("warning: %s: %s (%s, %s)", f.ID, f.Summary, module, fix)
+		note := "note: " + f.URL
+		if len(f.Trace) > 0 {
+			note = "note: called via " + strings.Join(f.Trace, " → ") + "\n      " + f.URL
+		}
+		line := "\n" + aurora.Red(title).String() + "\n" + aurora.Gray(16, note).String() + "\n"
+		r.Mgr.RunStderr(r, []byte(line))
+	}
+	if n := len(rep.Findings) - len(rep.Reachable()); n > 0 {
+		line := aurora.Gray(16, fmt.Sprintf("%d vulnerable dependencies are not called by the app; see 'encore vuln --all'.", n)).String() + "\n"
+		r.Mgr.RunStderr(r, []byte(line))
+	}
+}
@@ -3,32 +3,44 @@ package run
 import (
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/bep/debounce"
+	"github.com/cockroachdb/errors"
 
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/internal/sqlcgen"
+	"encr.dev/internal/userconfig"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/glob"
 	"encr.dev/pkg/watcher"
 )
 
 // watch watches the given app for changes, and reports
 // them on c.
 func (mgr *Manager) watch(run *Run) error {
+	cfg, err := loadWatchConfig(run.App.Root())
+	if err != nil {
+		return err
+	}
+
+	reload := func() {
+		run.reloadAndReport("Changes detected, recompiling...\n")
+	}
+
+	var debounced func(func())
+	if cfg.debounce > 0 {
+		debounced = debounce.New(cfg.debounce)
+	}
+
 	sub, err := run.App.Watch(func(i *apps.Instance, event []watcher.Event) {
-		if IgnoreEvents(event) {
+		if cfg.ignoreEvents(event) {
 			return
 		}
-
-		mgr.RunStdout(run, []byte("Changes detected, recompiling...\n"))
-		if err := run.Reload(); err != nil {
-			if errList := AsErrorList(err); errList != nil {
-				mgr.RunError(run, errList)
-			} else {
-				errStr := err.Error()
-				if !strings.HasSuffix(errStr, "\n") {
-					errStr += "\n"
-				}
-				mgr.RunStderr(run, []byte(errStr))
-			}
+		if debounced != nil {
+			debounced(reload)
 		} else {
-			mgr.RunStdout(run, []byte("Reloaded successfully.\n"))
+			reload()
 		}
 	})
 	if err != nil {
@@ -43,6 +55,94 @@ func (mgr *Manager) watch(run *Run) error {
 	return nil
 }
 
+// watchConfig configures which file changes reload a running app.
+type watchConfig struct {
+	root     string
+	ignore   []string
+	include  []string
+	debounce time.Duration
+}
+
+// loadWatchConfig loads the watch configuration of the app at appRoot
+// from its encore.app file, merged with the user's configuration.
+func loadWatchConfig(appRoot string) (*watchConfig, error) {
+	cfg := &watchConfig{root: appRoot}
+	w, err := appfile.WatchConfig(appRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse encore.app")
+	} else if w != nil {
+		cfg.ignore, cfg.include = w.Ignore, w.Include
+		if w.Debounce != "" {
+			// Validated by appfile.Parse.
+			cfg.debounce, _ = time.ParseDuration(w.Debounce)
+		}
+	}
+
+	user, err := userconfig.ForApp(appRoot).Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load user config")
+	}
+	ignore, err := splitPatterns("run.watch.ignore", user.RunWatchIgnore)
+	if err != nil {
+		return nil, err
+	}
+	include, err := splitPatterns("run.watch.include", user.RunWatchInclude)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ignore = append(cfg.ignore, ignore...)
+	cfg.include = append(cfg.include, include...)
+	if user.RunWatchDebounce != "" {
+		cfg.debounce, err = time.ParseDuration(user.RunWatchDebounce)
+		if err != nil {
+			return nil, errors.Newf("invalid user config run.watch.debounce %q", user.RunWatchDebounce)
+		}
+	}
+	return cfg, nil
+}
+
+// splitPatterns splits the comma-separated glob patterns
+// of the user config key.
+func splitPatterns(key, val string) ([]string, error) {
+	var patterns []string
+	for _, p := range strings.Split(val, ",") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		if !glob.Valid(p) {
+			return nil, errors.Newf("invalid pattern %q in user config %s", p, key)
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// ignoreEvents reports whether all events should be ignored.
+func (cfg *watchConfig) ignoreEvents(events []watcher.Event) bool {
+	for _, event := range events {
+		if !cfg.ignoreEvent(event) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cfg *watchConfig) ignoreEvent(ev watcher.Event) bool {
+	rel, err := filepath.Rel(cfg.root, ev.Path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		// The file is outside the app, like the runtime when developing Encore.
+		return ignoreEvent(ev)
+	}
+	rel = filepath.ToSlash(rel)
+	if isGenerated(ev.Path) {
+		// Reloading on generated code would loop, regardless of the configuration.
+		return true
+	} else if glob.MatchAny(cfg.include, rel) {
+		return false
+	}
+	return ignoreEvent(ev) || glob.MatchAny(cfg.ignore, rel)
+}
+
 // IgnoreEvents will return true if _all_ events are on files that should be ignored
 // as the do not impact the running app, or are the result of Encore itself generating code.
 func IgnoreEvents(events []watcher.Event) bool {
@@ -55,12 +155,16 @@ func IgnoreEvents(events []watcher.Event) bool {
 }
 
 func ignoreEvent(ev watcher.Event) bool {
-	filename := filepath.Base(ev.Path)
-	if strings.HasPrefix(strings.ToLower(filename), "encore.gen.") {
+	if isGenerated(ev.Path) {
 		// Ignore generated code
 		return true
 	}
 
+	// sqlc configurations change the query packages generated when building the app.
+	if sqlcgen.IsConfig(ev.Path) {
+		return false
+	}
+
 	// Ignore files which wouldn't impact the running app
 	ext := filepath.Ext(ev.Path)
 	switch ext {
@@ -71,3 +175,12 @@ func ignoreEvent(ev watcher.Event) bool {
 		return true
 	}
 }
+
+// isGenerated reports whether the file at path is generated by Encore,
+// including the query packages it generates with sqlc when building the app.
+func isGenerated(path string) bool {
+	if strings.HasPrefix(strings.ToLower(filepath.Base(path)), "encore.gen.") {
+		return true
+	}
+	return filepath.Ext(path) == ".go" && sqlcgen.IsGenerated(path)
+}
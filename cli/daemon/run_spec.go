@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"os/exec"
 	"strings"
@@ -16,6 +15,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 
 	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/internal/optracker"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/errlist"
@@ -72,7 +72,7 @@ func (s *Server) RunSpec(req *daemonpb.RunSpecRequest, stream daemonpb.Daemon_Ru
 
 	// Pre-bind a free local port and pass the listener to the manager so
 	// there is no race window between picking a port and the app binding to it.
-	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	ln, err := bindaddr.ListenLoopback(0)
 	if err != nil {
 		sendComplete(0, fmt.Sprintf("failed to allocate local port: %v", err))
 		return nil
@@ -263,6 +263,9 @@ func (s *runSpecSink) Error(err *errlist.List) {
 	_ = s.stream.Send(&daemonpb.RunSpecMessage{Msg: &daemonpb.RunSpecMessage_Output{Output: out}})
 }
 
+// Event implements runStreamSink. RunSpec clients don't receive structured events.
+func (s *runSpecSink) Event(ev *daemonpb.CommandEvent) {}
+
 type runSpecWriter struct {
 	sink   *runSpecSink
 	stderr bool
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+func TestAttachRun(t *testing.T) {
+	var s Server
+
+	// Only apps running in the background can be attached to.
+	err := s.AttachRun(&daemonpb.AttachRunRequest{AppRoot: "/app"}, newTestStream())
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("attach without a run: got %v, want FailedPrecondition", err)
+	}
+	fg := s.sessions.create("/app", false)
+	err = s.AttachRun(&daemonpb.AttachRunRequest{AppRoot: "/app"}, newTestStream())
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("attach to a foreground run: got %v, want FailedPrecondition", err)
+	}
+	fg.finish()
+
+	// The client detaches once the app has started in the background.
+	sess := s.sessions.create("/app", true)
+	client, err := sess.attach(newTestStream(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = sess.send(output("started"))
+	sess.release()
+	if !isDetached(client) {
+		t.Fatal("client not detached")
+	}
+	_ = sess.send(output("serving"))
+
+	// Attaching replays the output from the start, and lasts until the app stops.
+	stream := newTestStream()
+	done := make(chan error, 1)
+	go func() { done <- s.AttachRun(&daemonpb.AttachRunRequest{AppRoot: "/app"}, stream) }()
+	for !sess.attached() {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("AttachRun returned %v while the app is running", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	sess.finish()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(stream.outputs()), "[started serving]"; got != want {
+		t.Errorf("outputs = %s, want %s", got, want)
+	}
+}
+
+func TestStopRunNotRunning(t *testing.T) {
+	var s Server
+	finished := s.sessions.create("/app", true)
+	finished.finish()
+
+	_, err := s.StopRun(context.Background(), &daemonpb.StopRunRequest{AppRoot: "/app"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("got %v, want NotFound", err)
+	}
+}
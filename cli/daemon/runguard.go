@@ -0,0 +1,204 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/logrusorgru/aurora/v3"
+
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/run"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// errRunTakenOver is the cause a run is canceled with when another run
+// of the app in the same namespace takes it over.
+var errRunTakenOver = errors.New("taken over by another run")
+
+// runGuard keeps an app from being run more than once in the same namespace,
+// where the runs would fight over ports and databases.
+type runGuard struct {
+	mu   sync.Mutex
+	runs map[runGuardKey]*guardedRun
+}
+
+type runGuardKey struct {
+	appID string
+	nsID  namespace.ID
+}
+
+// guardedRun is a run of an app in a namespace,
+// from when it's claimed until it has stopped.
+type guardedRun struct {
+	cancel  context.CancelCauseFunc // stops the run
+	sess    *session                // session of the run, or nil if it isn't resumable
+	started chan struct{}           // closed once the run has started, or failed to
+	done    chan struct{}           // closed once the run has stopped
+
+	startOnce sync.Once
+	run       *run.Run // set before started is closed; nil if the run failed to start
+}
+
+// claim claims running the app in the namespace identified by key for a run in sess,
+// which is nil if the run isn't resumable. If another run has claimed it,
+// it returns that run instead.
+func (g *runGuard) claim(key runGuardKey, cancel context.CancelCauseFunc, sess *session) (claimed, existing *guardedRun) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if gr := g.runs[key]; gr != nil {
+		return nil, gr
+	}
+	if g.runs == nil {
+		g.runs = make(map[runGuardKey]*guardedRun)
+	}
+	gr := &guardedRun{
+		cancel:  cancel,
+		sess:    sess,
+		started: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	g.runs[key] = gr
+	return gr, nil
+}
+
+// release releases the claim of gr, once its run has stopped.
+func (g *runGuard) release(key runGuardKey, gr *guardedRun) {
+	gr.setStarted(nil)
+	g.mu.Lock()
+	if g.runs[key] == gr {
+		delete(g.runs, key)
+	}
+	g.mu.Unlock()
+	close(gr.done)
+}
+
+// setStarted records that the run has started as r,
+// or failed to start if r is nil.
+func (gr *guardedRun) setStarted(r *run.Run) {
+	gr.startOnce.Do(func() {
+		gr.run = r
+		close(gr.started)
+	})
+}
+
+// detached reports whether no client is attached to the run, as it runs
+// in the background or the client that started it disconnected.
+func (gr *guardedRun) detached() bool {
+	return gr.sess != nil && !gr.sess.attached()
+}
+
+// guardRun claims running app in ns for a run in sess, which is stopped by calling cancel.
+// If the app is already running in ns, it handles the run according to req.IfRunning
+// and reports whether to go ahead with the run. If it does, the returned claim must be
+// released once the run has stopped. A run no client is attached to is taken over
+// unless req.IfRunning says to attach to it.
+func (s *Server) guardRun(ctx context.Context, req *daemonpb.RunRequest, key runGuardKey, ns *namespace.Namespace,
+	cancel context.CancelCauseFunc, sess *session, stream commandStream, slog *streamLog) (*guardedRun, bool) {
+	stderr := slog.Stderr(false)
+	for {
+		gr, existing := s.runGuard.claim(key, cancel, sess)
+		if gr != nil {
+			return gr, true
+		}
+
+		switch ifRunning := req.IfRunning; {
+		case ifRunning == daemonpb.RunRequest_IF_RUNNING_ATTACH:
+			// Wait for the run to start, so there's output to attach to.
+			select {
+			case <-ctx.Done():
+				return nil, false
+			case <-existing.started:
+			}
+			if existing.run == nil {
+				// It failed to start; wait for its claim to be released and run the app instead.
+				select {
+				case <-ctx.Done():
+					return nil, false
+				case <-existing.done:
+				}
+				continue
+			}
+			r := existing.run
+			slog.Event(&daemonpb.CommandEvent{Event: &daemonpb.CommandEvent_RunStarted{
+				RunStarted: &daemonpb.RunStartedEvent{
+					BaseUrl:      r.BaseURL(),
+					ListenAddr:   r.ListenAddr,
+					DashboardUrl: s.mgr.DashboardURL(key.appID),
+					Namespace:    string(ns.Name),
+				},
+			}})
+			_, _ = fmt.Fprintf(stderr, "Attaching to the app already running at %s\n", aurora.Cyan(r.BaseURL()))
+			s.streamRunOutput(ctx, r, nil, stream, slog)
+			return nil, false
+
+		case ifRunning == daemonpb.RunRequest_IF_RUNNING_TAKEOVER || existing.detached():
+			if ifRunning == daemonpb.RunRequest_IF_RUNNING_TAKEOVER {
+				_, _ = fmt.Fprintf(stderr, "Stopping the app already running in namespace %s to take it over...\n", aurora.Cyan(ns.Name))
+			} else {
+				_, _ = fmt.Fprintf(stderr, "Stopping the app left running without a client in namespace %s to take it over...\n", aurora.Cyan(ns.Name))
+			}
+			existing.cancel(errRunTakenOver)
+			select {
+			case <-ctx.Done():
+				return nil, false
+			case <-existing.done:
+			}
+
+		default:
+			where := ""
+			if r := runningRun(existing); r != nil {
+				where = " at " + r.BaseURL()
+			}
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("The app is already running in namespace %s%s."), ns.Name, where))
+			_, _ = fmt.Fprintf(stderr, "Stop it with %s, or specify %s to attach to it or %s to replace it.\n",
+				aurora.Cyan("encore stop"), aurora.Cyan("--if-running=attach"), aurora.Cyan("--if-running=takeover"))
+			streamFailure(stream, daemonpb.ExitCategory_EXIT_CATEGORY_ALREADY_RUNNING)
+			return nil, false
+		}
+	}
+}
+
+// runningRun returns the run of gr if it has started, or nil otherwise.
+func runningRun(gr *guardedRun) *run.Run {
+	select {
+	case <-gr.started:
+		return gr.run
+	default:
+		return nil
+	}
+}
+
+// streamRunOutput streams the output of r until it stops or ctx is canceled.
+// If services is non-empty, only the logs of those services are streamed.
+func (s *Server) streamRunOutput(ctx context.Context, r *run.Run, services []string, stream commandStream, slog *streamLog) {
+	sub := &logSubscriber{
+		services: services,
+		lines:    make(chan logLine, logSubscriberBuffer),
+	}
+	s.logSubs.add(r.ID, sub)
+	defer s.logSubs.remove(r.ID, sub)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.Done():
+			_, _ = fmt.Fprintln(slog.Stderr(false), "the app stopped")
+			streamExit(stream, 0)
+			return
+		case l := <-sub.lines:
+			if n := sub.takeDropped(); n > 0 {
+				_, _ = fmt.Fprintf(slog.Stderr(false), "[%d log lines dropped: the client is too slow to keep up]\n", n)
+			}
+			w := slog.Stdout(false)
+			if l.stderr {
+				w = slog.Stderr(false)
+			}
+			if _, err := w.Write(l.data); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/run"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// stderr returns the stderr output sent to the stream, without colors.
+func (s *testStream) stderr() string {
+	var b strings.Builder
+	for _, msg := range s.msgs {
+		if o := msg.GetOutput(); o != nil {
+			b.Write(o.Stderr)
+		}
+	}
+	return ansiEscape.ReplaceAllString(b.String(), "")
+}
+
+// exitCategory returns the category of the exit message sent to the stream, if any.
+func (s *testStream) exitCategory() (daemonpb.ExitCategory, bool) {
+	for _, msg := range s.msgs {
+		if exit := msg.GetExit(); exit != nil {
+			return exit.Category, true
+		}
+	}
+	return 0, false
+}
+
+var testGuardKey = runGuardKey{appID: "app", nsID: "ns"}
+
+// guardTest is a Server with a run of the app claimed,
+// which releases its claim when canceled.
+type guardTest struct {
+	srv      *Server
+	existing *guardedRun
+	cause    chan error // receives the cause the existing run is canceled with
+}
+
+func newGuardTest(t *testing.T, sess *session) *guardTest {
+	t.Helper()
+	g := &guardTest{
+		srv:   &Server{mgr: &run.Manager{DashBaseURL: "http://localhost:9400"}},
+		cause: make(chan error, 1),
+	}
+	existing, _ := g.srv.runGuard.claim(testGuardKey, func(cause error) {
+		g.cause <- cause
+		go g.srv.runGuard.release(testGuardKey, g.existing)
+	}, sess)
+	if existing == nil {
+		t.Fatal("claim failed")
+	}
+	g.existing = existing
+	return g
+}
+
+// guardRun guards a run of the app handling an existing run according to ifRunning.
+func (g *guardTest) guardRun(ctx context.Context, ifRunning daemonpb.RunRequest_IfRunning) (*testStream, *guardedRun, bool) {
+	stream := newTestStream()
+	req := &daemonpb.RunRequest{IfRunning: ifRunning}
+	ns := &namespace.Namespace{ID: testGuardKey.nsID, Name: "default"}
+	slog := &streamLog{stream: stream, structured: true}
+	gr, ok := g.srv.guardRun(ctx, req, testGuardKey, ns, func(error) {}, nil, stream, slog)
+	return stream, gr, ok
+}
+
+func TestRunGuardClaim(t *testing.T) {
+	var g runGuard
+	first, existing := g.claim(testGuardKey, nil, nil)
+	if first == nil || existing != nil {
+		t.Fatalf("claim = %v, %v, want a claim", first, existing)
+	}
+	if claimed, existing := g.claim(testGuardKey, nil, nil); claimed != nil || existing != first {
+		t.Fatalf("second claim = %v, %v, want the first claim", claimed, existing)
+	}
+
+	// Other apps and namespaces are claimed separately.
+	if claimed, _ := g.claim(runGuardKey{appID: "app", nsID: "other"}, nil, nil); claimed == nil {
+		t.Error("claim in another namespace failed")
+	}
+	if claimed, _ := g.claim(runGuardKey{appID: "other", nsID: "ns"}, nil, nil); claimed == nil {
+		t.Error("claim of another app failed")
+	}
+
+	g.release(testGuardKey, first)
+	select {
+	case <-first.done:
+	default:
+		t.Error("done not closed on release")
+	}
+	if runningRun(first) != nil {
+		t.Error("released run without a started run has a run")
+	}
+	if claimed, _ := g.claim(testGuardKey, nil, nil); claimed == nil {
+		t.Fatal("claim after release failed")
+	}
+}
+
+func TestGuardRunFailsIfRunning(t *testing.T) {
+	g := newGuardTest(t, nil)
+	g.existing.setStarted(&run.Run{ListenAddr: "127.0.0.1:4000", Params: &run.StartParams{}})
+
+	stream, gr, ok := g.guardRun(context.Background(), daemonpb.RunRequest_IF_RUNNING_FAIL)
+	if ok || gr != nil {
+		t.Fatal("run went ahead while the app is running")
+	}
+	if cat, _ := stream.exitCategory(); cat != daemonpb.ExitCategory_EXIT_CATEGORY_ALREADY_RUNNING {
+		t.Errorf("exit category = %v, want ALREADY_RUNNING", cat)
+	}
+	if out := stream.stderr(); !strings.Contains(out, "already running in namespace default at http://127.0.0.1:4000") {
+		t.Errorf("output = %q, want it to report where the app is running", out)
+	}
+	select {
+	case <-g.cause:
+		t.Error("running app stopped")
+	default:
+	}
+}
+
+func TestGuardRunTakeover(t *testing.T) {
+	g := newGuardTest(t, nil)
+
+	_, gr, ok := g.guardRun(context.Background(), daemonpb.RunRequest_IF_RUNNING_TAKEOVER)
+	if !ok || gr == nil {
+		t.Fatal("run did not go ahead")
+	}
+	if cause := <-g.cause; !errors.Is(cause, errRunTakenOver) {
+		t.Errorf("running app stopped with %v, want %v", cause, errRunTakenOver)
+	}
+	if _, existing := g.srv.runGuard.claim(testGuardKey, nil, nil); existing != gr {
+		t.Error("run did not claim the app")
+	}
+}
+
+func TestGuardRunTakesOverDetachedRun(t *testing.T) {
+	var reg sessionRegistry
+	sess := reg.create("/app", true)
+	client, err := sess.attach(newTestStream(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newGuardTest(t, sess)
+
+	// The run fails while a client is attached to the existing run.
+	if _, _, ok := g.guardRun(context.Background(), daemonpb.RunRequest_IF_RUNNING_FAIL); ok {
+		t.Fatal("run went ahead while a client is attached to the running app")
+	}
+
+	// Once it runs in the background, it's taken over.
+	sess.release()
+	if !isDetached(client) {
+		t.Fatal("client not detached")
+	}
+	stream, gr, ok := g.guardRun(context.Background(), daemonpb.RunRequest_IF_RUNNING_FAIL)
+	if !ok || gr == nil {
+		t.Fatalf("run did not take over the detached run: %s", stream.stderr())
+	}
+	if cause := <-g.cause; !errors.Is(cause, errRunTakenOver) {
+		t.Errorf("running app stopped with %v, want %v", cause, errRunTakenOver)
+	}
+	if out := stream.stderr(); !strings.Contains(out, "left running without a client") {
+		t.Errorf("output = %q, want it to report taking over the app", out)
+	}
+}
+
+func TestGuardRunAttach(t *testing.T) {
+	g := newGuardTest(t, nil)
+	g.existing.setStarted(&run.Run{ListenAddr: "127.0.0.1:4000", Params: &run.StartParams{}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	stream, gr, ok := g.guardRun(ctx, daemonpb.RunRequest_IF_RUNNING_ATTACH)
+	if ok || gr != nil {
+		t.Fatal("run went ahead instead of attaching")
+	}
+	if len(stream.msgs) == 0 || stream.msgs[0].GetEvent().GetRunStarted().GetBaseUrl() != "http://127.0.0.1:4000" {
+		t.Errorf("first message = %v, want the run started event of the running app", stream.msgs)
+	}
+	if out := stream.stderr(); !strings.Contains(out, "Attaching to the app already running at http://127.0.0.1:4000") {
+		t.Errorf("output = %q, want it to report attaching", out)
+	}
+	select {
+	case <-g.cause:
+		t.Error("running app stopped")
+	default:
+	}
+}
+
+func TestGuardRunAttachFailedStart(t *testing.T) {
+	g := newGuardTest(t, nil)
+
+	// The existing run fails to start while attaching to it.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		g.srv.runGuard.release(testGuardKey, g.existing)
+	}()
+	_, gr, ok := g.guardRun(context.Background(), daemonpb.RunRequest_IF_RUNNING_ATTACH)
+	if !ok || gr == nil {
+		t.Fatal("run did not go ahead once the running app failed to start")
+	}
+}
+
+func TestGuardRunTakesOverDisconnectedRun(t *testing.T) {
+	var reg sessionRegistry
+	sess := reg.create("/app", false)
+	clientCtx, disconnect := context.WithCancel(context.Background())
+	client, err := sess.attach(&testStream{ctx: clientCtx}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waited := make(chan struct{})
+	go func() {
+		defer close(waited)
+		sess.wait(client)
+	}()
+	g := newGuardTest(t, sess)
+
+	if _, _, ok := g.guardRun(context.Background(), daemonpb.RunRequest_IF_RUNNING_FAIL); ok {
+		t.Fatal("run went ahead while a client is attached to the running app")
+	}
+
+	// The client goes away without canceling the run, like when it's killed.
+	// The next run takes over without waiting for the grace period.
+	disconnect()
+	<-waited
+	stream, gr, ok := g.guardRun(context.Background(), daemonpb.RunRequest_IF_RUNNING_FAIL)
+	if !ok || gr == nil {
+		t.Fatalf("run did not take over the disconnected run: %s", stream.stderr())
+	}
+	if cause := <-g.cause; !errors.Is(cause, errRunTakenOver) {
+		t.Errorf("running app stopped with %v, want %v", cause, errRunTakenOver)
+	}
+}
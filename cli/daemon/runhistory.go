@@ -0,0 +1,171 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/runhistory"
+	"encr.dev/pkg/vcs"
+	daemonpb "encr.dev/proto/encore/daemon"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// maxSessionTraces is the maximum number of traces returned for a run session.
+const maxSessionTraces = 1000
+
+// recordRunSession records a run session for r, saving its output until it ends.
+// It returns a function to call with the reason the run ended once it has.
+func (s *Server) recordRunSession(r *run.Run, name string) (end func(reason string)) {
+	sess := &runhistory.Session{
+		ID:        r.ID,
+		AppID:     r.App.PlatformOrLocalID(),
+		Name:      name,
+		GitCommit: gitCommit(r.App.Root()),
+		StartedAt: time.Now(),
+	}
+	if r.NS != nil {
+		sess.Namespace = string(r.NS.Name)
+	}
+
+	ctx := context.Background()
+	w, err := s.history.Start(ctx, sess)
+	if err != nil {
+		log.Error().Err(err).Str("run_id", r.ID).Msg("unable to record run session")
+		return func(string) {}
+	}
+	s.mu.Lock()
+	s.sessionLogs[r.ID] = w
+	s.mu.Unlock()
+
+	return func(reason string) {
+		s.mu.Lock()
+		delete(s.sessionLogs, r.ID)
+		s.mu.Unlock()
+		_ = w.Close()
+
+		if err := s.history.End(ctx, r.ID, time.Now(), reason); err != nil {
+			log.Error().Err(err).Str("run_id", r.ID).Msg("unable to record end of run session")
+		}
+	}
+}
+
+// writeSessionLog writes a line of output of r to its run session, if recorded.
+func (s *Server) writeSessionLog(r *run.Run, line []byte) {
+	s.mu.Lock()
+	w, ok := s.sessionLogs[r.ID]
+	s.mu.Unlock()
+
+	if ok {
+		_, _ = w.Write(line)
+	}
+}
+
+// runExitReason describes why r ended, given the context it ran with.
+func runExitReason(ctx context.Context, r *run.Run) string {
+	if ctx.Err() != nil {
+		return "stopped"
+	}
+	if p := r.ProcGroup(); p != nil {
+		if crash := p.Crash(); crash != nil {
+			return crash.String()
+		}
+	}
+	return "exited"
+}
+
+// gitCommit describes the git commit the app at appRoot is at,
+// or "" if it's not in a repository.
+func gitCommit(appRoot string) string {
+	rev := vcs.GetRevision(appRoot)
+	commit := rev.Revision
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	if commit != "" && rev.Uncommitted {
+		commit += "-dirty"
+	}
+	return commit
+}
+
+// ListRunSessions lists the recorded run sessions of an app, most recent first.
+func (s *Server) ListRunSessions(ctx context.Context, req *daemonpb.ListRunSessionsRequest) (*daemonpb.ListRunSessionsResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.history.List(ctx, app.PlatformOrLocalID(), int(req.Limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list run sessions: %v", err)
+	}
+
+	resp := &daemonpb.ListRunSessionsResponse{}
+	for _, sess := range sessions {
+		resp.Sessions = append(resp.Sessions, runSessionToProto(sess))
+	}
+	return resp, nil
+}
+
+// GetRunSession returns a recorded run session along with its output
+// and the traces recorded while it ran.
+func (s *Server) GetRunSession(ctx context.Context, req *daemonpb.GetRunSessionRequest) (*daemonpb.GetRunSessionResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	appID := app.PlatformOrLocalID()
+
+	sess, err := s.history.Get(ctx, appID, req.Session)
+	if errors.Is(err, runhistory.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "run session %q not found", req.Session)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to get run session: %v", err)
+	}
+
+	resp := &daemonpb.GetRunSessionResponse{Session: runSessionToProto(sess)}
+	if resp.Output, err = s.history.Output(sess.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to read run session output: %v", err)
+	}
+
+	q := &trace2.Query{AppID: appID, StartTime: sess.StartedAt, Limit: maxSessionTraces}
+	if sess.EndedAt != nil {
+		q.EndTime = *sess.EndedAt
+	}
+	err = s.tr.List(ctx, q, func(sp *tracepb2.SpanSummary) bool {
+		resp.Traces = append(resp.Traces, &daemonpb.RunSessionTrace{
+			TraceId:       sp.TraceId,
+			Service:       sp.ServiceName,
+			Endpoint:      sp.EndpointName,
+			StartedAt:     sp.StartedAt.AsTime().Format(time.RFC3339Nano),
+			DurationNanos: sp.GetDurationNanos(),
+			IsError:       sp.IsError,
+		})
+		return true
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list traces: %v", err)
+	}
+	return resp, nil
+}
+
+func runSessionToProto(sess *runhistory.Session) *daemonpb.RunSession {
+	pb := &daemonpb.RunSession{
+		Id:         sess.ID,
+		Name:       sess.Name,
+		Namespace:  sess.Namespace,
+		GitCommit:  sess.GitCommit,
+		StartedAt:  sess.StartedAt.Format(time.RFC3339),
+		ExitReason: sess.ExitReason,
+	}
+	if sess.EndedAt != nil {
+		endedAt := sess.EndedAt.Format(time.RFC3339)
+		pb.EndedAt = &endedAt
+	}
+	return pb
+}
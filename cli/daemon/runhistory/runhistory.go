@@ -0,0 +1,235 @@
+// Package runhistory records the apps run with 'encore run' and their output,
+// so past runs can be looked up after they've ended.
+package runhistory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ErrNotFound is reported when a session is not found.
+var ErrNotFound = errors.New("run session not found")
+
+const (
+	// maxSessions is the number of sessions kept per app.
+	// Older sessions are removed when new ones start.
+	maxSessions = 100
+
+	// maxOutputSize is the max size of the output kept per session.
+	maxOutputSize = 10 << 20
+)
+
+// Session is a recorded run of an app.
+type Session struct {
+	ID         string // the id of the run
+	AppID      string // platform_id or local_id
+	Name       string // empty if the run wasn't named
+	Namespace  string
+	GitCommit  string // empty if the app isn't in a git repository
+	StartedAt  time.Time
+	EndedAt    *time.Time // nil if the run hasn't ended
+	ExitReason string     // why the run ended, if it has
+}
+
+// Store records run sessions in a database,
+// with their output in files in a directory.
+type Store struct {
+	db        *sql.DB
+	outputDir string
+}
+
+// New returns a Store using db, keeping the output of the sessions in outputDir.
+func New(db *sql.DB, outputDir string) *Store {
+	return &Store{db: db, outputDir: outputDir}
+}
+
+// Start records the start of sess and returns a writer recording its output,
+// which must be closed when the session ends.
+func (s *Store) Start(ctx context.Context, sess *Session) (io.WriteCloser, error) {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO run_session (id, app_id, name, namespace, git_commit, started_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, sess.ID, sess.AppID, sess.Name, sess.Namespace, sess.GitCommit, sess.StartedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "record run session")
+	}
+	if err := s.prune(ctx, sess.AppID); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(s.outputDir, 0700); err != nil {
+		return nil, errors.Wrap(err, "create run output dir")
+	}
+	f, err := os.OpenFile(s.outputPath(sess.ID), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "create run output file")
+	}
+	return &cappedWriter{f: f, remaining: maxOutputSize}, nil
+}
+
+// End records the end of the session with the given id.
+func (s *Store) End(ctx context.Context, id string, endedAt time.Time, reason string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE run_session SET ended_at = ?, exit_reason = ? WHERE id = ?
+	`, endedAt, reason, id)
+	return errors.Wrap(err, "record end of run session")
+}
+
+// Abandon records the sessions that haven't ended as abandoned,
+// for when the daemon stopped while they were running.
+func (s *Store) Abandon(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE run_session SET exit_reason = 'the daemon stopped'
+		WHERE ended_at IS NULL AND exit_reason = ''
+	`)
+	return errors.Wrap(err, "abandon run sessions")
+}
+
+// List returns the most recent sessions of an app, most recent first.
+// If limit is 0 all sessions are returned.
+func (s *Store) List(ctx context.Context, appID string, limit int) ([]*Session, error) {
+	if limit <= 0 {
+		limit = -1 // no limit
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, app_id, name, namespace, git_commit, started_at, ended_at, exit_reason
+		FROM run_session
+		WHERE app_id = ?
+		ORDER BY started_at DESC
+		LIMIT ?
+	`, appID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "list run sessions")
+	}
+	defer rows.Close()
+
+	var sessions []*Session
+	for rows.Next() {
+		sess, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, sess)
+	}
+	return sessions, errors.Wrap(rows.Err(), "list run sessions")
+}
+
+// Get returns the session of an app with the given id, or the most recent
+// session with the given name. It reports ErrNotFound if there is none.
+func (s *Store) Get(ctx context.Context, appID, idOrName string) (*Session, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, app_id, name, namespace, git_commit, started_at, ended_at, exit_reason
+		FROM run_session
+		WHERE app_id = ? AND (id = ? OR name = ?)
+		ORDER BY id = ? DESC, started_at DESC
+		LIMIT 1
+	`, appID, idOrName, idOrName, idOrName)
+	sess, err := scanSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return sess, err
+}
+
+// Output returns the output recorded for the session with the given id.
+func (s *Store) Output(id string) ([]byte, error) {
+	data, err := os.ReadFile(s.outputPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, errors.Wrap(err, "read run output")
+}
+
+// prune removes the sessions of an app beyond the most recent maxSessions.
+func (s *Store) prune(ctx context.Context, appID string) error {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM run_session
+		WHERE app_id = ?
+		ORDER BY started_at DESC
+		LIMIT -1 OFFSET ?
+	`, appID, maxSessions)
+	if err != nil {
+		return errors.Wrap(err, "prune run sessions")
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return errors.Wrap(err, "prune run sessions")
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "prune run sessions")
+	}
+
+	for _, id := range ids {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM run_session WHERE id = ?`, id); err != nil {
+			return errors.Wrap(err, "prune run sessions")
+		}
+		_ = os.Remove(s.outputPath(id))
+	}
+	return nil
+}
+
+func (s *Store) outputPath(id string) string {
+	return filepath.Join(s.outputDir, id+".log")
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSession(row scanner) (*Session, error) {
+	var sess Session
+	err := row.Scan(&sess.ID, &sess.AppID, &sess.Name, &sess.Namespace, &sess.GitCommit,
+		&sess.StartedAt, &sess.EndedAt, &sess.ExitReason)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "scan run session")
+	}
+	return &sess, nil
+}
+
+// cappedWriter writes to f until remaining bytes have been written,
+// noting that the output was truncated and discarding the rest.
+type cappedWriter struct {
+	mu        sync.Mutex
+	f         *os.File
+	remaining int
+}
+
+func (w *cappedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.remaining <= 0 {
+		return len(p), nil
+	}
+	n := min(len(p), w.remaining)
+	if _, err := w.f.Write(p[:n]); err != nil {
+		return 0, err
+	}
+	w.remaining -= n
+	if w.remaining <= 0 {
+		_, _ = fmt.Fprintf(w.f, "\n[output truncated after %d MiB]\n", maxOutputSize>>20)
+	}
+	return len(p), nil
+}
+
+func (w *cappedWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f.Close()
+}
@@ -0,0 +1,162 @@
+package runhistory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const testSchema = `
+CREATE TABLE run_session (
+	id TEXT PRIMARY KEY,
+	app_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	namespace TEXT NOT NULL,
+	git_commit TEXT NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	ended_at TIMESTAMP NULL,
+	exit_reason TEXT NOT NULL DEFAULT ''
+);
+`
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(testSchema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return New(db, t.TempDir())
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, name := range []string{"bugfix", "", "bugfix"} {
+		w, err := s.Start(ctx, &Session{
+			ID:        fmt.Sprintf("run%d", i),
+			AppID:     "app",
+			Name:      name,
+			Namespace: "default",
+			GitCommit: "abc123",
+			StartedAt: start.Add(time.Duration(i) * time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = fmt.Fprintf(w, "output %d\n", i)
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.End(ctx, "run0", start.Add(30*time.Minute), "stopped"); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := s.List(ctx, "app", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, sess := range sessions {
+		ids = append(ids, sess.ID)
+	}
+	if got, want := strings.Join(ids, ","), "run2,run1,run0"; got != want {
+		t.Errorf("List: got %s, want %s", got, want)
+	}
+
+	// The most recent session with a name is returned.
+	sess, err := s.Get(ctx, "app", "bugfix")
+	if err != nil {
+		t.Fatal(err)
+	} else if sess.ID != "run2" {
+		t.Errorf("Get by name: got %s, want run2", sess.ID)
+	}
+
+	sess, err = s.Get(ctx, "app", "run0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sess.EndedAt == nil || !sess.EndedAt.Equal(start.Add(30*time.Minute)) || sess.ExitReason != "stopped" {
+		t.Errorf("Get by id: got ended_at %v, exit reason %q", sess.EndedAt, sess.ExitReason)
+	}
+	if out, err := s.Output("run0"); err != nil || string(out) != "output 0\n" {
+		t.Errorf("Output: got %q, %v", out, err)
+	}
+
+	if _, err := s.Get(ctx, "other-app", "run0"); err != ErrNotFound {
+		t.Errorf("Get for other app: got %v, want ErrNotFound", err)
+	}
+
+	if err := s.Abandon(ctx); err != nil {
+		t.Fatal(err)
+	}
+	sess, err = s.Get(ctx, "app", "run1")
+	if err != nil {
+		t.Fatal(err)
+	} else if sess.EndedAt != nil || sess.ExitReason != "the daemon stopped" {
+		t.Errorf("Abandon: got ended_at %v, exit reason %q", sess.EndedAt, sess.ExitReason)
+	}
+}
+
+func TestStore_Prune(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range maxSessions + 2 {
+		w, err := s.Start(ctx, &Session{
+			ID:        fmt.Sprintf("run%03d", i),
+			AppID:     "app",
+			StartedAt: start.Add(time.Duration(i) * time.Minute),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = w.Close()
+	}
+
+	sessions, err := s.List(ctx, "app", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != maxSessions {
+		t.Fatalf("got %d sessions, want %d", len(sessions), maxSessions)
+	}
+	if last := sessions[len(sessions)-1].ID; last != "run002" {
+		t.Errorf("oldest session: got %s, want run002", last)
+	}
+	if _, err := s.Get(ctx, "app", "run000"); err != ErrNotFound {
+		t.Errorf("pruned session: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestCappedWriter(t *testing.T) {
+	s := newTestStore(t)
+	w, err := s.Start(context.Background(), &Session{ID: "run", AppID: "app", StartedAt: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.(*cappedWriter).remaining = 5
+	for _, s := range []string{"abc", "defg", "hij"} {
+		if n, err := w.Write([]byte(s)); n != len(s) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", s, n, err)
+		}
+	}
+	_ = w.Close()
+
+	out, _ := s.Output("run")
+	if got, want := string(out), "abcde\n[output truncated after 10 MiB]\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -144,6 +144,17 @@ func (r *schemaRenderer) renderBuiltin(b schema.Builtin) {
 		r.WriteObjectEnd()
 	case schema.Builtin_USER_ID:
 		r.WriteString("userID")
+	case schema.Builtin_FILE:
+		r.WriteObjectStart()
+		r.WriteObjectField("filename")
+		r.WriteString("hello.txt")
+		r.WriteMore()
+		r.WriteObjectField("content_type")
+		r.WriteString("text/plain")
+		r.WriteMore()
+		r.WriteObjectField("data")
+		r.WriteString("aGVsbG8K") // "hello"
+		r.WriteObjectEnd()
 	default:
 		r.WriteString("<unknown>")
 	}
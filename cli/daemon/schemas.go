@@ -0,0 +1,69 @@
+package daemon
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/pkg/builder"
+	"encr.dev/pkg/builder/builderimpl"
+	"encr.dev/pkg/fns"
+	"encr.dev/pkg/schemaexport"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// ExportSchemas exports schemas of the request, response and Pub/Sub message types of an app.
+func (s *Server) ExportSchemas(ctx context.Context, req *daemonpb.ExportSchemasRequest) (*daemonpb.ExportSchemasResponse, error) {
+	format, err := schemaexport.ParseFormat(req.Format)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to query app info: %v", err)
+	}
+	expSet, err := app.Experiments(nil)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse app experiments: %v", err)
+	}
+
+	bld := builderimpl.Resolve(app.Lang(), expSet)
+	defer fns.CloseIgnore(bld)
+	prepareResult, err := bld.Prepare(ctx, builder.PrepareParams{
+		Build:      builder.DefaultBuildInfo(),
+		App:        app,
+		WorkingDir: ".",
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to prepare app: %v", err)
+	}
+	parse, err := bld.Parse(ctx, builder.ParseParams{
+		Build:       builder.DefaultBuildInfo(),
+		App:         app,
+		Experiments: expSet,
+		WorkingDir:  ".",
+		ParseTests:  false,
+		Prepare:     prepareResult,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse app metadata: %v", err)
+	}
+	if err := app.CacheMetadata(parse.Meta); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cache app metadata: %v", err)
+	}
+
+	schemas, err := schemaexport.Export(parse.Meta, format)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to export schemas: %v", err)
+	}
+	resp := &daemonpb.ExportSchemasResponse{}
+	for _, sc := range schemas {
+		resp.Schemas = append(resp.Schemas, &daemonpb.ExportSchemasResponse_Schema{
+			Name:    sc.Name,
+			Content: sc.Content,
+		})
+	}
+	return resp, nil
+}
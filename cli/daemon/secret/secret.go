@@ -50,8 +50,9 @@ type Data struct {
 }
 
 type LoadResult struct {
-	mgr *Manager
-	app *apps.Instance
+	mgr     *Manager
+	app     *apps.Instance
+	offline bool // if true, only the cached secrets are used
 
 	once    syncutil.Once
 	ch      <-chan singleflight.Result
@@ -74,6 +75,13 @@ func (mgr *Manager) Load(app *apps.Instance) *LoadResult {
 	return &LoadResult{mgr: mgr, app: app, ch: ch}
 }
 
+// LoadCached is like Load, but only uses the secrets cached by a previous
+// sync and never fetches them, for running without network access.
+// If the secrets were never synced, (*LoadResult).Get resolves to empty secret data.
+func (mgr *Manager) LoadCached(app *apps.Instance) *LoadResult {
+	return &LoadResult{mgr: mgr, app: app, offline: true}
+}
+
 // Get returns the result of the prefetch.
 // It blocks until the initial fetch is ready or until ctx is cancelled.
 // For subsequent calls to Get (such as during live reload), it returns any
@@ -92,6 +100,11 @@ func (lr *LoadResult) Get(ctx context.Context, expSet *experiments.Set) (data *D
 
 	if lr == nil || lr.app.PlatformID() == "" {
 		return &Data{}, nil
+	} else if lr.offline {
+		if cached, ok := lr.mgr.loadFromCache(lr.app.PlatformID()); ok {
+			return cached, nil
+		}
+		return &Data{}, nil
 	}
 
 	// Fetch the initial result the first time.
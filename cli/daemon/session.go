@@ -0,0 +1,372 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/rs/xid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+const (
+	// sessionGracePeriod is how long a resumable command keeps running after
+	// its client disconnects, and how long the output of a finished command
+	// is kept for a disconnected client to resume. A client that's still running
+	// resumes right away, so it's short: a client that was killed never resumes,
+	// and its command shouldn't keep running long after it.
+	sessionGracePeriod = 10 * time.Second
+
+	// maxSessionBuffer is the maximum number of messages buffered for resuming.
+	maxSessionBuffer = 10000
+)
+
+// commandServerStream is a server stream of CommandMessages,
+// such as Daemon_RunServer and Daemon_TestServer.
+type commandServerStream interface {
+	Send(*daemonpb.CommandMessage) error
+	grpc.ServerStream
+}
+
+// sessionRegistry keeps track of resumable commands.
+type sessionRegistry struct {
+	mu       sync.Mutex
+	sessions map[string]*session // token -> session
+}
+
+// session is a resumable command. It outlives the stream of the client
+// that started it, buffering its output so a client can resume it.
+type session struct {
+	token      string
+	appRoot    string // the app run by the command, if it runs an app
+	persistent bool   // keep running while no client is attached, instead of for a grace period
+	ctx        context.Context
+	cancel     context.CancelFunc
+	remove     func()
+
+	mu       sync.Mutex
+	msgs     []*daemonpb.CommandMessage // buffered messages
+	offset   uint64                     // number of messages dropped from the start of msgs
+	client   *sessionClient             // attached client, or nil
+	finished bool
+	timer    *time.Timer // cancels or removes the session while detached
+}
+
+// sessionClient is a client attached to a session.
+type sessionClient struct {
+	stream   commandServerStream
+	detached chan struct{} // closed when detached
+
+	// mu is held while sending to the stream, so the session
+	// isn't locked while a slow client receives messages.
+	mu   sync.Mutex
+	next uint64 // number of the next message to send; protected by mu
+}
+
+// runResumable runs cmd as a resumable command attached to stream.
+// The stream cmd is given stays usable when the client disconnects,
+// and the command keeps running for a grace period to let a client resume it.
+func (s *Server) runResumable(stream commandServerStream, cmd func(stream commandServerStream)) error {
+	return s.runSession(s.sessions.create("", false), stream, cmd)
+}
+
+// runSession runs cmd in sess, attached to stream.
+func (s *Server) runSession(sess *session, stream commandServerStream, cmd func(stream commandServerStream)) error {
+	client, err := sess.attach(stream, 0)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer sess.finish()
+		cmd(&sessionStream{ServerStream: stream, sess: sess})
+	}()
+	sess.wait(client)
+	return nil
+}
+
+// ResumeStream reattaches to the output stream of a resumable command.
+func (s *Server) ResumeStream(req *daemonpb.ResumeStreamRequest, stream daemonpb.Daemon_ResumeStreamServer) error {
+	sess, ok := s.sessions.get(req.Token)
+	if !ok {
+		return status.Error(codes.NotFound, "command not found: it may have finished, or the daemon may have restarted")
+	}
+	client, err := sess.attach(stream, req.Received)
+	if err != nil {
+		return err
+	}
+	sess.wait(client)
+	return nil
+}
+
+// CancelStream cancels a resumable command.
+func (s *Server) CancelStream(ctx context.Context, req *daemonpb.CancelStreamRequest) (*empty.Empty, error) {
+	if sess, ok := s.sessions.get(req.Token); ok {
+		sess.cancel()
+	}
+	return &empty.Empty{}, nil
+}
+
+// create creates a session for a command. If the command runs an app, appRoot is its root.
+// Persistent sessions keep running when no client is attached, until canceled.
+func (r *sessionRegistry) create(appRoot string, persistent bool) *session {
+	ctx, cancel := context.WithCancel(context.Background())
+	sess := &session{token: xid.New().String(), appRoot: appRoot, persistent: persistent, ctx: ctx, cancel: cancel}
+	sess.remove = func() {
+		r.mu.Lock()
+		delete(r.sessions, sess.token)
+		r.mu.Unlock()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sessions == nil {
+		r.sessions = make(map[string]*session)
+	}
+	r.sessions[sess.token] = sess
+	return sess
+}
+
+func (r *sessionRegistry) get(token string) (*session, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sess, ok := r.sessions[token]
+	return sess, ok
+}
+
+// findRun returns the unfinished session running the app at appRoot, if any.
+func (r *sessionRegistry) findRun(appRoot string) (*session, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, sess := range r.sessions {
+		if sess.appRoot == "" || sess.appRoot != appRoot {
+			continue
+		}
+		sess.mu.Lock()
+		finished := sess.finished
+		sess.mu.Unlock()
+		if !finished {
+			return sess, true
+		}
+	}
+	return nil, false
+}
+
+// attach attaches a client to the session, replacing any attached client.
+// The client is sent the buffered messages after the first received messages,
+// and told how many of the messages it missed are no longer buffered.
+func (sess *session) attach(stream commandServerStream, received uint64) (*sessionClient, error) {
+	client := &sessionClient{stream: stream, detached: make(chan struct{}), next: received}
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	sess.mu.Lock()
+	if sess.client != nil {
+		close(sess.client.detached)
+		sess.client = nil
+	}
+	var lost uint64
+	if received < sess.offset {
+		lost = sess.offset - received
+		client.next = sess.offset
+	}
+	finished := sess.finished
+	if !finished {
+		if sess.timer != nil {
+			sess.timer.Stop()
+			sess.timer = nil
+		}
+		sess.client = client
+	}
+	sess.mu.Unlock()
+
+	if err := stream.Send(&daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Session{
+		Session: &daemonpb.CommandSession{Token: sess.token, Lost: lost},
+	}}); err != nil {
+		sess.detach(client)
+		return nil, err
+	}
+	if err := sess.sendPending(client); err != nil {
+		sess.detach(client)
+		return nil, err
+	}
+
+	if finished {
+		close(client.detached)
+	}
+	return client, nil
+}
+
+// wait waits until the client is detached, either because the command finished,
+// another client attached, or the client disconnected.
+func (sess *session) wait(client *sessionClient) {
+	select {
+	case <-client.detached:
+	case <-client.stream.Context().Done():
+		sess.detach(client)
+	}
+
+	// Wait for a message being sent to finish,
+	// as the stream must not be used once the call has returned.
+	client.mu.Lock()
+	client.mu.Unlock()
+}
+
+// detach detaches the client, if it is still attached.
+func (sess *session) detach(client *sessionClient) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.client != client {
+		return
+	}
+	close(client.detached)
+	sess.client = nil
+	sess.expire()
+}
+
+// attached reports whether a client is attached to the session.
+func (sess *session) attached() bool {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.client != nil
+}
+
+// release detaches the attached client, if any, leaving the command running
+// until another client attaches or it is canceled. It is used by persistent sessions.
+func (sess *session) release() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if c := sess.client; c != nil {
+		close(c.detached)
+		sess.client = nil
+	}
+}
+
+// bufferStart returns the number of messages dropped from the start of the buffer,
+// for attaching a client that receives all the buffered messages.
+func (sess *session) bufferStart() uint64 {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.offset
+}
+
+// expire cancels the command if no client resumes it in time,
+// unless the session is persistent. It must be called with sess.mu held.
+func (sess *session) expire() {
+	if sess.persistent {
+		return
+	}
+	sess.timer = time.AfterFunc(sessionGracePeriod, func() {
+		sess.cancel()
+		sess.remove()
+	})
+}
+
+// send buffers msg and sends it to the attached client, if any.
+func (sess *session) send(msg *daemonpb.CommandMessage) error {
+	// The caller may reuse the data of msg once it's sent,
+	// so buffer a copy to replay.
+	msg = proto.Clone(msg).(*daemonpb.CommandMessage)
+
+	sess.mu.Lock()
+	sess.msgs = append(sess.msgs, msg)
+	if n := len(sess.msgs) - maxSessionBuffer; n > 0 {
+		sess.msgs = sess.msgs[n:]
+		sess.offset += uint64(n)
+	}
+	client := sess.client
+	sess.mu.Unlock()
+
+	if client != nil {
+		sess.deliver(client)
+	}
+	return nil
+}
+
+// deliver sends the client the buffered messages it hasn't been sent yet,
+// unless it has been detached.
+func (sess *session) deliver(client *sessionClient) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	select {
+	case <-client.detached:
+		return
+	default:
+	}
+	if err := sess.sendPending(client); err != nil {
+		// The client will notice the broken stream and resume.
+		sess.detach(client)
+	}
+}
+
+// sendPending sends the client the buffered messages it hasn't been sent yet.
+// If some of them are no longer buffered, as the client fell behind,
+// it's first told how many it lost.
+// It must be called with client.mu held, and not sess.mu.
+func (sess *session) sendPending(client *sessionClient) error {
+	sess.mu.Lock()
+	var lost uint64
+	if client.next < sess.offset {
+		lost = sess.offset - client.next
+		client.next = sess.offset
+	}
+	pending := sess.msgs[min(client.next-sess.offset, uint64(len(sess.msgs))):]
+	client.next += uint64(len(pending))
+	sess.mu.Unlock()
+
+	if lost > 0 {
+		if err := client.stream.Send(&daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Session{
+			Session: &daemonpb.CommandSession{Token: sess.token, Lost: lost},
+		}}); err != nil {
+			return err
+		}
+	}
+	for _, msg := range pending {
+		if err := client.stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish marks the command as finished. Its output is kept
+// for a grace period if no client is attached to receive it.
+func (sess *session) finish() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.finished = true
+	sess.cancel()
+
+	if c := sess.client; c != nil {
+		close(c.detached)
+		sess.client = nil
+		sess.remove()
+		return
+	}
+	if sess.timer != nil {
+		sess.timer.Stop()
+	}
+	sess.timer = time.AfterFunc(sessionGracePeriod, sess.remove)
+}
+
+// sessionStream is the stream a resumable command writes to.
+type sessionStream struct {
+	grpc.ServerStream
+	sess *session
+}
+
+func (s *sessionStream) Send(msg *daemonpb.CommandMessage) error {
+	return s.sess.send(msg)
+}
+
+// Context returns the context of the command, which is canceled
+// when the command is canceled rather than when the client disconnects.
+func (s *sessionStream) Context() context.Context {
+	return s.sess.ctx
+}
@@ -0,0 +1,298 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// testStream is a commandServerStream that records the messages sent to it.
+type testStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	msgs []*daemonpb.CommandMessage
+}
+
+func newTestStream() *testStream {
+	return &testStream{ctx: context.Background()}
+}
+
+func (s *testStream) Send(msg *daemonpb.CommandMessage) error {
+	// Like a gRPC stream, don't hold on to the data of msg once sent.
+	s.msgs = append(s.msgs, proto.Clone(msg).(*daemonpb.CommandMessage))
+	return nil
+}
+
+func (s *testStream) Context() context.Context { return s.ctx }
+
+// outputs returns the stdout of the output messages sent to the stream.
+func (s *testStream) outputs() []string {
+	var out []string
+	for _, msg := range s.msgs {
+		if o := msg.GetOutput(); o != nil {
+			out = append(out, string(o.Stdout))
+		}
+	}
+	return out
+}
+
+func output(s string) *daemonpb.CommandMessage {
+	return &daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Output{
+		Output: &daemonpb.CommandOutput{Stdout: []byte(s)},
+	}}
+}
+
+func isDetached(client *sessionClient) bool {
+	select {
+	case <-client.detached:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestSessionAttach(t *testing.T) {
+	var reg sessionRegistry
+	sess := reg.create("", false)
+	_ = sess.send(output("before"))
+
+	stream := newTestStream()
+	client, err := sess.attach(stream, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = sess.send(output("after"))
+
+	if len(stream.msgs) == 0 || stream.msgs[0].GetSession().GetToken() != sess.token {
+		t.Fatalf("first message is not the session: %v", stream.msgs)
+	}
+	if got := stream.msgs[0].GetSession().GetLost(); got != 0 {
+		t.Errorf("lost = %d, want 0", got)
+	}
+	if got, want := fmt.Sprint(stream.outputs()), "[before after]"; got != want {
+		t.Errorf("outputs = %s, want %s", got, want)
+	}
+	if isDetached(client) {
+		t.Error("client detached")
+	}
+
+	// Attaching another client detaches the first one.
+	other, err := sess.attach(newTestStream(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isDetached(client) {
+		t.Error("previous client not detached")
+	}
+	if isDetached(other) {
+		t.Error("new client detached")
+	}
+}
+
+func TestSessionDetachAndResume(t *testing.T) {
+	var reg sessionRegistry
+	sess := reg.create("", false)
+
+	stream := newTestStream()
+	client, err := sess.attach(stream, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = sess.send(output("a"))
+	_ = sess.send(output("b"))
+	sess.detach(client)
+	if !isDetached(client) {
+		t.Fatal("client not detached")
+	}
+	_ = sess.send(output("c"))
+	if got, want := fmt.Sprint(stream.outputs()), "[a b]"; got != want {
+		t.Errorf("outputs before detach = %s, want %s", got, want)
+	}
+
+	if _, ok := reg.get(sess.token); !ok {
+		t.Fatal("session removed while detached")
+	}
+	resumed := newTestStream()
+	if _, err := sess.attach(resumed, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(resumed.outputs()), "[c]"; got != want {
+		t.Errorf("outputs after resume = %s, want %s", got, want)
+	}
+}
+
+func TestSessionResumeAfterOverflow(t *testing.T) {
+	var reg sessionRegistry
+	sess := reg.create("", false)
+
+	const extra = 5
+	for i := range maxSessionBuffer + extra {
+		_ = sess.send(output(fmt.Sprint(i)))
+	}
+
+	stream := newTestStream()
+	if _, err := sess.attach(stream, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stream.msgs[0].GetSession().GetLost(), uint64(extra-2); got != want {
+		t.Errorf("lost = %d, want %d", got, want)
+	}
+
+	// The lost messages are reported in the session message rather than
+	// as output, so the client can count the messages it was sent.
+	outputs := stream.outputs()
+	if len(outputs) != len(stream.msgs)-1 {
+		t.Errorf("got %d outputs and %d messages, want only the session message not to be output", len(outputs), len(stream.msgs))
+	}
+	if len(outputs) != maxSessionBuffer {
+		t.Fatalf("got %d outputs, want %d", len(outputs), maxSessionBuffer)
+	}
+	if got, want := outputs[0], fmt.Sprint(extra); got != want {
+		t.Errorf("first output = %s, want %s", got, want)
+	}
+}
+
+func TestSessionFinish(t *testing.T) {
+	t.Run("attached", func(t *testing.T) {
+		var reg sessionRegistry
+		sess := reg.create("", false)
+		client, err := sess.attach(newTestStream(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sess.finish()
+
+		if !isDetached(client) {
+			t.Error("client not detached")
+		}
+		if sess.ctx.Err() == nil {
+			t.Error("session not canceled")
+		}
+		if _, ok := reg.get(sess.token); ok {
+			t.Error("session not removed")
+		}
+	})
+
+	t.Run("detached", func(t *testing.T) {
+		var reg sessionRegistry
+		sess := reg.create("", false)
+		_ = sess.send(output("done"))
+		sess.finish()
+
+		// The output is kept for a client to resume.
+		if _, ok := reg.get(sess.token); !ok {
+			t.Fatal("session removed")
+		}
+		stream := newTestStream()
+		client, err := sess.attach(stream, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isDetached(client) {
+			t.Error("client attached to finished session")
+		}
+		if got, want := fmt.Sprint(stream.outputs()), "[done]"; got != want {
+			t.Errorf("outputs = %s, want %s", got, want)
+		}
+	})
+}
+
+func TestSessionReplayCopiesMessages(t *testing.T) {
+	var reg sessionRegistry
+	sess := reg.create("", false)
+
+	// Send output from a buffer that is reused between writes.
+	var buf bytes.Buffer
+	for _, line := range []string{"first", "second"} {
+		buf.WriteString(line)
+		_ = sess.send(&daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Output{
+			Output: &daemonpb.CommandOutput{Stdout: buf.Bytes()},
+		}})
+		buf.Reset()
+	}
+	buf.WriteString("overwritten")
+
+	stream := newTestStream()
+	if _, err := sess.attach(stream, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(stream.outputs()), "[first second]"; got != want {
+		t.Errorf("outputs = %s, want %s", got, want)
+	}
+}
+
+// blockingStream is a testStream whose sends block until unblocked,
+// like the stream of a client that doesn't keep up.
+type blockingStream struct {
+	testStream
+	sending chan struct{} // receives a value when a send starts blocking
+	unblock chan struct{} // closed to unblock sends
+}
+
+func (s *blockingStream) Send(msg *daemonpb.CommandMessage) error {
+	if msg.GetSession() == nil {
+		s.sending <- struct{}{}
+		<-s.unblock
+	}
+	return s.testStream.Send(msg)
+}
+
+func TestSessionSlowClient(t *testing.T) {
+	var reg sessionRegistry
+	sess := reg.create("/app", false)
+
+	slow := &blockingStream{testStream: *newTestStream(), sending: make(chan struct{}, 1), unblock: make(chan struct{})}
+	slowClient, err := sess.attach(slow, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		_ = sess.send(output("a"))
+	}()
+	<-slow.sending
+
+	// The session can be used while the slow client receives the message.
+	done := make(chan struct{})
+	var stream *testStream
+	go func() {
+		defer close(done)
+		if _, ok := reg.findRun("/app"); !ok {
+			t.Error("run not found")
+		}
+		stream = newTestStream()
+		if _, err := sess.attach(stream, 0); err != nil {
+			t.Error(err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("session blocked by a slow client")
+	}
+	if got, want := fmt.Sprint(stream.outputs()), "[a]"; got != want {
+		t.Errorf("outputs = %s, want %s", got, want)
+	}
+	if !isDetached(slowClient) {
+		t.Error("slow client not detached")
+	}
+
+	// The slow client isn't sent messages once detached.
+	close(slow.unblock)
+	<-sent
+	_ = sess.send(output("b"))
+	if got, want := fmt.Sprint(slow.outputs()), "[a]"; got != want {
+		t.Errorf("slow client outputs = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprint(stream.outputs()), "[a b]"; got != want {
+		t.Errorf("outputs = %s, want %s", got, want)
+	}
+}
@@ -12,11 +12,13 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/rs/zerolog"
 	"go4.org/syncutil"
 	"golang.org/x/sync/errgroup"
 
 	"encr.dev/internal/optracker"
+	"encr.dev/pkg/schemadiff"
 	meta "encr.dev/proto/encore/parser/meta/v1"
 
 	// stdlib registers the "pgx" driver to database/sql.
@@ -234,6 +236,13 @@ func (c *Cluster) determineRoles(ctx context.Context, st *ClusterStatus, conn *p
 // initDB initializes the database for svc and adds it to c.dbs.
 // The cluster mutex must be held.
 func (c *Cluster) initDB(encoreName string) *DB {
+	db := c.newDB(encoreName)
+	c.dbs[encoreName] = db
+	return db
+}
+
+// newDB returns the database for svc, without adding it to c.dbs.
+func (c *Cluster) newDB(encoreName string) *DB {
 	driverName := encoreName
 	if !c.driver.Meta().ClusterIsolation {
 		driverName += fmt.Sprintf("-%s-%s", c.ID.NS.App.PlatformOrLocalID(), c.ID.Type)
@@ -260,7 +269,6 @@ func (c *Cluster) initDB(encoreName string) *DB {
 		ready: make(chan struct{}),
 		log:   c.log.With().Str("db", encoreName).Logger(),
 	}
-	c.dbs[encoreName] = db
 	return db
 }
 
@@ -271,7 +279,7 @@ func (c *Cluster) Setup(ctx context.Context, appRoot string, md *meta.Data) erro
 	g.SetLimit(50)
 	c.mu.Lock()
 
-	for _, dbMeta := range md.SqlDatabases {
+	for _, dbMeta := range Databases(md) {
 		dbMeta := dbMeta
 		db, ok := c.dbs[dbMeta.Name]
 		if c.isExternal(dbMeta.Name) {
@@ -315,6 +323,86 @@ func (c *Cluster) GetDB(name string) (*DB, bool) {
 	return db, ok
 }
 
+// AppliedMigrations reports the migrations applied to the given database,
+// as returned by LoadAppliedVersions, without creating or migrating it.
+// It reports exists=false if the database hasn't been created yet.
+func (c *Cluster) AppliedMigrations(ctx context.Context, name string) (applied map[uint64]bool, exists bool, err error) {
+	db, ok := c.GetDB(name)
+	if !ok {
+		db = c.newDB(name)
+		defer db.CloseConns()
+	}
+	applied, err = db.ListAppliedMigrations(ctx)
+	if pgErr := (*pgconn.PgError)(nil); errors.As(err, &pgErr) && pgErr.Code == pgInvalidCatalogName {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return applied, true, nil
+}
+
+// Schema loads the schema of the given database, without creating or migrating it.
+// It reports exists=false if the database hasn't been created yet.
+func (c *Cluster) Schema(ctx context.Context, name string) (schema *schemadiff.Schema, exists bool, err error) {
+	db, ok := c.GetDB(name)
+	if !ok {
+		db = c.newDB(name)
+		defer db.CloseConns()
+	}
+	schema, err = db.Schema(ctx)
+	if pgErr := (*pgconn.PgError)(nil); errors.As(err, &pgErr) && pgErr.Code == pgInvalidCatalogName {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return schema, true, nil
+}
+
+// Rollback rolls back migrations of the given database by running their
+// down migrations. done is called after each migration has been rolled back.
+func (c *Cluster) Rollback(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabase, rollbacks []Rollback, done func(*meta.DBMigration)) error {
+	db, ok := c.GetDB(dbMeta.Name)
+	if !ok {
+		db = c.newDB(dbMeta.Name)
+		defer db.CloseConns()
+	}
+	return db.Rollback(ctx, appRoot, dbMeta, rollbacks, done)
+}
+
+// CopyFrom copies the given databases from the cluster src, replacing the
+// databases of the same name in c. Both clusters must be running.
+// The copies aren't migrated, so migrations not yet applied in src
+// are applied when the databases in c are next migrated.
+func (c *Cluster) CopyFrom(ctx context.Context, src *Cluster, dbs []*meta.SQLDatabase) error {
+	for _, dbMeta := range dbs {
+		if c.IsExternalDB(dbMeta.Name) || src.IsExternalDB(dbMeta.Name) {
+			continue
+		}
+		if _, exists, err := src.AppliedMigrations(ctx, dbMeta.Name); err != nil {
+			return errors.Wrapf(err, "copy database %s", dbMeta.Name)
+		} else if !exists {
+			// Nothing to copy.
+			continue
+		}
+
+		c.mu.Lock()
+		db, ok := c.dbs[dbMeta.Name]
+		if !ok {
+			db = c.initDB(dbMeta.Name)
+		}
+		c.mu.Unlock()
+
+		srcDB, ok := src.GetDB(dbMeta.Name)
+		if !ok {
+			srcDB = src.newDB(dbMeta.Name)
+		}
+		if err := db.copyFrom(ctx, srcDB); err != nil {
+			return errors.Wrapf(err, "copy database %s", dbMeta.Name)
+		}
+	}
+	return nil
+}
+
 func (c *Cluster) IsExternalDB(name string) bool {
 	if c.isExternal == nil {
 		return false
@@ -337,7 +425,7 @@ func (c *Cluster) Recreate(ctx context.Context, appRoot string, databaseNames []
 	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(50)
 	c.mu.Lock()
-	for _, dbMeta := range md.SqlDatabases {
+	for _, dbMeta := range Databases(md) {
 		dbMeta := dbMeta
 		if filter == nil || filter[dbMeta.Name] {
 			db, ok := c.dbs[dbMeta.Name]
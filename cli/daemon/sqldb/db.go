@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"io/fs"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,6 +23,7 @@ import (
 	"encr.dev/cli/daemon/internal/debugflags"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
+	"encr.dev/pkg/schemadiff"
 	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
@@ -52,6 +56,10 @@ type DB struct {
 
 	migrated bool
 
+	// created indicates the application database was created by the daemon,
+	// as opposed to already existing.
+	created bool
+
 	// template indicates the database is backed by a template database.
 	template bool
 
@@ -100,9 +108,11 @@ func (db *DB) Setup(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabas
 	}
 
 	setupDB := func(cloudName string) error {
-		if err := db.doCreate(ctx, cloudName, option.None[string]()); err != nil {
+		created, err := db.doCreate(ctx, cloudName, option.None[string]())
+		if err != nil {
 			return errors.Wrapf(err, "create db %s: %v", cloudName, err)
 		}
+		db.created = db.created || created
 
 		if err := db.ensureRoles(ctx, cloudName, db.Cluster.Roles...); err != nil {
 			return fmt.Errorf("ensure db roles %s: %v", cloudName, err)
@@ -141,7 +151,7 @@ func (db *DB) Setup(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabas
 		}
 
 		// Then create the application database based on the template
-		if err := db.doCreate(ctx, db.ApplicationCloudName(), option.Some(tmplName)); err != nil {
+		if _, err := db.doCreate(ctx, db.ApplicationCloudName(), option.Some(tmplName)); err != nil {
 			return errors.Wrapf(err, "create db %s: %v", db.ApplicationCloudName(), err)
 		}
 
@@ -149,15 +159,60 @@ func (db *DB) Setup(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabas
 		if err := db.ensureRoles(ctx, db.ApplicationCloudName(), db.Cluster.Roles...); err != nil {
 			return fmt.Errorf("ensure db roles %s: %v", db.ApplicationCloudName(), err)
 		}
+
+		// Clean up databases cloned from the template by earlier test runs
+		// that were not dropped, like when a test binary was killed.
+		db.dropStaleClones(ctx)
 	}
 
 	return nil
 }
 
-func (db *DB) doCreate(ctx context.Context, cloudName string, template option.Option[string]) error {
+// staleCloneSuffix matches the suffix the runtime adds to the names of
+// databases cloned from the template database for isolated tests.
+var staleCloneSuffix = regexp.MustCompile(`^_[0-9a-v]{20}$`)
+
+// dropStaleClones drops the databases cloned from the template database
+// that are no longer in use. Failures are logged and otherwise ignored.
+func (db *DB) dropStaleClones(ctx context.Context) {
 	adm, err := db.connectSuperuser(ctx)
 	if err != nil {
-		return err
+		db.log.Debug().Err(err).Msg("unable to clean up cloned test databases")
+		return
+	}
+	defer func() { _ = adm.Close(context.Background()) }()
+
+	rows, err := adm.Query(ctx, `
+		SELECT datname FROM pg_database
+		WHERE starts_with(datname, $1)
+		AND datname NOT IN (SELECT datname FROM pg_stat_activity WHERE datname IS NOT NULL)
+	`, db.ApplicationCloudName()+"_")
+	if err != nil {
+		db.log.Debug().Err(err).Msg("unable to list cloned test databases")
+		return
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		db.log.Debug().Err(err).Msg("unable to list cloned test databases")
+		return
+	}
+
+	for _, name := range names {
+		if !staleCloneSuffix.MatchString(strings.TrimPrefix(name, db.ApplicationCloudName())) {
+			continue
+		}
+		// Don't terminate connections, the database may be in use by a concurrent test run.
+		_, err := adm.Exec(ctx, fmt.Sprintf("DROP DATABASE %s", (pgx.Identifier{name}).Sanitize()))
+		db.log.Debug().Err(err).Str("db", name).Msg("dropped cloned test database")
+	}
+}
+
+// doCreate creates the database unless it already exists,
+// reporting whether it was created.
+func (db *DB) doCreate(ctx context.Context, cloudName string, template option.Option[string]) (created bool, err error) {
+	adm, err := db.connectSuperuser(ctx)
+	if err != nil {
+		return false, err
 	}
 	defer func() { _ = adm.Close(context.Background()) }()
 
@@ -166,10 +221,11 @@ func (db *DB) doCreate(ctx context.Context, cloudName string, template option.Op
 	err = adm.QueryRow(ctx, "SELECT 1 FROM pg_database WHERE datname = $1", cloudName).Scan(&dummy)
 	owner, ok := db.Cluster.Roles.First(migratorRoles()...)
 	if !ok {
-		return errors.New("unable to find admin or superuser roles")
+		return false, errors.New("unable to find admin or superuser roles")
 	}
 
 	if errors.Is(err, pgx.ErrNoRows) {
+		created = true
 		db.log.Debug().Msg("creating database")
 		// Sanitize names since this query does not support query params
 		dbName := (pgx.Identifier{cloudName}).Sanitize()
@@ -184,8 +240,49 @@ func (db *DB) doCreate(ctx context.Context, cloudName string, template option.Op
 	}
 	if err != nil {
 		db.log.Error().Err(err).Msg("failed to create database")
+		return false, err
 	}
-	return err
+	return created, nil
+}
+
+// Created reports whether the database was created by the daemon
+// since it started, as opposed to already existing, such as
+// when it's in a new namespace or has been reset.
+func (db *DB) Created() bool {
+	db.setupMu.Lock()
+	defer db.setupMu.Unlock()
+	return db.created
+}
+
+// ExecScript executes a SQL script against the database in a transaction,
+// with the same role as migrations are run with.
+func (db *DB) ExecScript(ctx context.Context, script string) error {
+	info, err := db.Cluster.Info(ctx)
+	if err != nil {
+		return err
+	} else if info.Status != Running {
+		return errors.New("cluster not running")
+	}
+
+	role, ok := info.Encore.First(migratorRoles()...)
+	if !ok {
+		return errors.New("unable to find superuser or admin roles")
+	}
+	pool, err := sql.Open("pgx", info.ConnURI(db.ApplicationCloudName(), role))
+	if err != nil {
+		return err
+	}
+	defer fns.CloseIgnore(pool)
+
+	tx, err := pool.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to postgres")
+	}
+	defer func() { _ = tx.Rollback() }() // committed explicitly on success
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (db *DB) renameDB(ctx context.Context, from, to string) error {
@@ -319,6 +416,43 @@ func (db *DB) doMigrate(ctx context.Context, cloudName, appRoot string, dbMeta *
 	return nil
 }
 
+// Rollback rolls back migrations of the database by running their down migrations.
+// done is called after each migration has been rolled back.
+func (db *DB) Rollback(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabase, rollbacks []Rollback, done func(*meta.DBMigration)) error {
+	if dbMeta.MigrationRelPath == nil {
+		return errors.New("the database has no migrations")
+	}
+	info, err := db.Cluster.Info(ctx)
+	if err != nil {
+		return err
+	} else if info.Status != Running {
+		return errors.New("cluster not running")
+	}
+
+	role, ok := info.Encore.First(migratorRoles()...)
+	if !ok {
+		return errors.New("unable to find superuser or admin roles")
+	}
+	pool, err := sql.Open("pgx", info.ConnURI(db.ApplicationCloudName(), role))
+	if err != nil {
+		return err
+	}
+	defer fns.CloseIgnore(pool)
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to postgres")
+	}
+	defer fns.CloseIgnore(conn)
+
+	// Apply the rolled back migrations again the next time the database is set up.
+	db.setupMu.Lock()
+	db.migrated = false
+	db.setupMu.Unlock()
+
+	reader := NewOsMigrationReader(filepath.Join(appRoot, *dbMeta.MigrationRelPath))
+	return RunRollbacks(ctx, conn, dbMeta.AllowNonSequentialMigrations, reader, rollbacks, done)
+}
+
 func (db *DB) ListAppliedMigrations(ctx context.Context) (map[uint64]bool, error) {
 	conn, err := db.connectToDB(ctx)
 	if err != nil {
@@ -328,6 +462,16 @@ func (db *DB) ListAppliedMigrations(ctx context.Context) (map[uint64]bool, error
 	return LoadAppliedVersions(ctx, conn, "public", "schema_migrations")
 }
 
+// Schema loads the schema of the database.
+func (db *DB) Schema(ctx context.Context) (*schemadiff.Schema, error) {
+	conn, err := db.connectToDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer fns.CloseIgnore(conn)
+	return schemadiff.Load(ctx, conn)
+}
+
 func RunMigration(ctx context.Context, dbName string, allowNonSeq bool, conn *sql.Conn, mdSrc *MetadataSource) (err error) {
 	var (
 		dbDriver  database.Driver
@@ -410,6 +554,44 @@ func findClosestLowerVersion(first func() (uint, error), dirtyVer int, next func
 	return rtn, nil
 }
 
+// copyFrom replaces the database with a copy of the database src.
+func (db *DB) copyFrom(ctx context.Context, src *DB) error {
+	return db.replace(ctx, func(cloudName string) error {
+		return db.Cluster.driver.CopyDatabase(ctx, src.Cluster.ID, src.ApplicationCloudName(), db.Cluster.ID, cloudName)
+	})
+}
+
+// restoreFrom replaces the database with the dump read from r,
+// written by the cluster driver's DumpDatabase.
+func (db *DB) restoreFrom(ctx context.Context, r io.Reader) error {
+	return db.replace(ctx, func(cloudName string) error {
+		return db.Cluster.driver.RestoreDatabase(ctx, db.Cluster.ID, cloudName, r)
+	})
+}
+
+// replace replaces the database with a new database, filled by calling fill
+// with its name. The new database isn't migrated.
+func (db *DB) replace(ctx context.Context, fill func(cloudName string) error) error {
+	db.setupMu.Lock()
+	defer db.setupMu.Unlock()
+
+	if err := db.drop(ctx); err != nil {
+		return fmt.Errorf("drop database: %v", err)
+	}
+	cloudName := db.ApplicationCloudName()
+	if _, err := db.doCreate(ctx, cloudName, option.None[string]()); err != nil {
+		return errors.Wrapf(err, "create db %s", cloudName)
+	}
+	if err := db.ensureRoles(ctx, cloudName, db.Cluster.Roles...); err != nil {
+		return fmt.Errorf("ensure db roles %s: %v", cloudName, err)
+	}
+	if err := fill(cloudName); err != nil {
+		return err
+	}
+	db.migrated = false
+	return nil
+}
+
 func (db *DB) drop(ctx context.Context) error {
 	if err := db.doDrop(ctx, db.ApplicationCloudName()); err != nil {
 		return errors.Wrapf(err, "drop database %s", db.ApplicationCloudName())
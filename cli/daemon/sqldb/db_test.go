@@ -6,6 +6,9 @@ import (
 
 	qt "github.com/frankban/quicktest"
 	_ "github.com/golang-migrate/migrate/v4/source/file" // for running migrations from the filesystem
+
+	"encr.dev/pkg/option"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 func TestFindClosestVersion(t *testing.T) {
@@ -71,3 +74,159 @@ func TestFindClosestVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestPendingMigrations(t *testing.T) {
+	c := qt.New(t)
+	testCases := map[string]struct {
+		nonSeq   bool
+		applied  map[uint64]bool
+		expected []uint64
+	}{
+		"none_applied": {
+			applied:  map[uint64]bool{},
+			expected: []uint64{1, 2, 3},
+		},
+		"sequential": {
+			applied:  map[uint64]bool{2: false},
+			expected: []uint64{3},
+		},
+		"sequential_dirty": {
+			applied:  map[uint64]bool{2: true},
+			expected: []uint64{2, 3},
+		},
+		"sequential_all": {
+			applied:  map[uint64]bool{3: false},
+			expected: nil,
+		},
+		"non_sequential": {
+			nonSeq:   true,
+			applied:  map[uint64]bool{1: false, 3: false},
+			expected: []uint64{2},
+		},
+		"non_sequential_dirty": {
+			nonSeq:   true,
+			applied:  map[uint64]bool{1: false, 2: true},
+			expected: []uint64{2, 3},
+		},
+	}
+
+	for name, tc := range testCases {
+		c.Run(name, func(c *qt.C) {
+			db := &meta.SQLDatabase{
+				AllowNonSequentialMigrations: tc.nonSeq,
+				Migrations: []*meta.DBMigration{
+					{Number: 3, Filename: "3_c.up.sql"},
+					{Number: 1, Filename: "1_a.up.sql"},
+					{Number: 2, Filename: "2_b.up.sql"},
+				},
+			}
+			var got []uint64
+			for _, m := range PendingMigrations(db, tc.applied) {
+				got = append(got, m.Number)
+			}
+			c.Assert(got, qt.DeepEquals, tc.expected)
+		})
+	}
+}
+
+func TestRollbacks(t *testing.T) {
+	c := qt.New(t)
+	none := option.None[uint64]()
+	testCases := map[string]struct {
+		nonSeq   bool
+		applied  map[uint64]bool
+		steps    int
+		to       option.Option[uint64]
+		expected []uint64 // migrations to roll back
+		versions []uint64 // versions after each rollback, 0 for none
+		err      string
+	}{
+		"sequential_one": {
+			applied:  map[uint64]bool{3: false},
+			steps:    1,
+			to:       none,
+			expected: []uint64{3},
+			versions: []uint64{2},
+		},
+		"sequential_to": {
+			applied:  map[uint64]bool{3: false},
+			to:       option.Some[uint64](1),
+			expected: []uint64{3, 2},
+			versions: []uint64{2, 1},
+		},
+		"sequential_all": {
+			applied:  map[uint64]bool{2: false},
+			to:       option.Some[uint64](0),
+			expected: []uint64{2, 1},
+			versions: []uint64{1, 0},
+		},
+		"sequential_dirty": {
+			applied: map[uint64]bool{3: true},
+			steps:   1,
+			to:      none,
+			err:     "migration 3 failed to apply.*",
+		},
+		"too_many_steps": {
+			applied: map[uint64]bool{2: false},
+			steps:   3,
+			to:      none,
+			err:     `cannot roll back 3 migration\(s\): only 2 are applied`,
+		},
+		"not_applied": {
+			applied: map[uint64]bool{1: false},
+			to:      option.Some[uint64](2),
+			err:     "cannot roll back to migration 2: it's not applied",
+		},
+		"missing_file": {
+			applied: map[uint64]bool{4: false},
+			steps:   1,
+			to:      none,
+			err:     "cannot roll back migration 4: its migration file no longer exists",
+		},
+		"non_sequential": {
+			nonSeq:   true,
+			applied:  map[uint64]bool{1: false, 3: false},
+			steps:    2,
+			to:       none,
+			expected: []uint64{3, 1},
+			versions: []uint64{1, 0},
+		},
+	}
+
+	for name, tc := range testCases {
+		c.Run(name, func(c *qt.C) {
+			db := &meta.SQLDatabase{
+				AllowNonSequentialMigrations: tc.nonSeq,
+				Migrations: []*meta.DBMigration{
+					{Number: 1, Filename: "1_a.up.sql", DownFilename: "1_a.down.sql"},
+					{Number: 2, Filename: "2_b.up.sql", DownFilename: "2_b.down.sql"},
+					{Number: 3, Filename: "3_c.up.sql", DownFilename: "3_c.down.sql"},
+				},
+			}
+			rollbacks, err := Rollbacks(db, tc.applied, tc.steps, tc.to)
+			if tc.err != "" {
+				c.Assert(err, qt.ErrorMatches, tc.err)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			var got, versions []uint64
+			for _, r := range rollbacks {
+				got = append(got, r.Migration.Number)
+				versions = append(versions, r.Version.GetOrElse(0))
+			}
+			c.Assert(got, qt.DeepEquals, tc.expected)
+			c.Assert(versions, qt.DeepEquals, tc.versions)
+		})
+	}
+
+	c.Run("no_down_migration", func(c *qt.C) {
+		db := &meta.SQLDatabase{
+			Migrations: []*meta.DBMigration{
+				{Number: 1, Filename: "1_a.up.sql", DownFilename: "1_a.down.sql"},
+				{Number: 2, Filename: "2_b.up.sql"},
+			},
+		}
+		_, err := Rollbacks(db, map[uint64]bool{2: false}, 2, option.None[uint64]())
+		c.Assert(err, qt.ErrorMatches, "no down migration for 2_b.up.sql.*")
+	})
+}
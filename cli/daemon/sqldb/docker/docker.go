@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/exec"
 	"strings"
@@ -17,6 +18,7 @@ import (
 
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/pkg/idents"
 )
 
@@ -253,13 +255,10 @@ func (d *Driver) clusterStatus(ctx context.Context, id sqldb.ClusterID) (status
 			if len(ports) > 0 {
 				hostIP := ports[0].HostIP
 
-				// Podman can keep HostIP empty or 0.0.0.0.
+				// Podman can keep HostIP empty or 0.0.0.0, and Docker reports :: on IPv6 hosts,
+				// which DialAddr connects to on the loopback address.
 				// https://github.com/containers/podman/issues/17780
-				if hostIP == "" || hostIP == "0.0.0.0" {
-					hostIP = "127.0.0.1"
-				}
-
-				status.Config.Host = hostIP + ":" + ports[0].HostPort
+				status.Config.Host = bindaddr.DialAddr(net.JoinHostPort(hostIP, ports[0].HostPort))
 			}
 
 			// Read the Postgres config from the docker container's environment.
@@ -317,6 +316,85 @@ func (d *Driver) DestroyNamespaceData(ctx context.Context, ns *namespace.Namespa
 	return nil
 }
 
+func (d *Driver) CopyDatabase(ctx context.Context, src sqldb.ClusterID, srcName string, dst sqldb.ClusterID, dstName string) error {
+	srcStatus, srcContainer, err := d.clusterStatus(ctx, src)
+	if err != nil {
+		return err
+	} else if srcStatus.Status != sqldb.Running {
+		return errors.Newf("cluster %s is not running", src.NS.Name)
+	}
+	dstStatus, dstContainer, err := d.clusterStatus(ctx, dst)
+	if err != nil {
+		return err
+	} else if dstStatus.Status != sqldb.Running {
+		return errors.Newf("cluster %s is not running", dst.NS.Name)
+	}
+
+	// Stream a dump of the database from one container into the other.
+	// The Encore roles exist in every cluster, so ownership and grants are kept.
+	dump := exec.CommandContext(ctx, "docker", "exec", srcContainer,
+		"pg_dump", "--username="+srcStatus.Config.Superuser.Username, "--format=custom", srcName)
+	restore := exec.CommandContext(ctx, "docker", "exec", "-i", dstContainer,
+		"pg_restore", "--username="+dstStatus.Config.Superuser.Username, "--dbname="+dstName, "--exit-on-error")
+
+	var dumpErr, restoreErr bytes.Buffer
+	dump.Stderr, restore.Stderr = &dumpErr, &restoreErr
+	pipe, err := dump.StdoutPipe()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	restore.Stdin = pipe
+
+	if err := dump.Start(); err != nil {
+		return errors.Wrap(err, "dump database")
+	}
+	if err := restore.Run(); err != nil {
+		_ = dump.Wait()
+		return errors.Wrapf(err, "restore database: %s", restoreErr.Bytes())
+	}
+	if err := dump.Wait(); err != nil {
+		return errors.Wrapf(err, "dump database: %s", dumpErr.Bytes())
+	}
+	return nil
+}
+
+func (d *Driver) DumpDatabase(ctx context.Context, id sqldb.ClusterID, name string, w io.Writer) error {
+	status, container, err := d.clusterStatus(ctx, id)
+	if err != nil {
+		return err
+	} else if status.Status != sqldb.Running {
+		return errors.Newf("cluster %s is not running", id.NS.Name)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", "exec", container,
+		"pg_dump", "--username="+status.Config.Superuser.Username, "--format=custom", name)
+	cmd.Stdout, cmd.Stderr = w, &stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "dump database: %s", stderr.Bytes())
+	}
+	return nil
+}
+
+func (d *Driver) RestoreDatabase(ctx context.Context, id sqldb.ClusterID, name string, r io.Reader) error {
+	status, container, err := d.clusterStatus(ctx, id)
+	if err != nil {
+		return err
+	} else if status.Status != sqldb.Running {
+		return errors.Newf("cluster %s is not running", id.NS.Name)
+	}
+
+	// The Encore roles exist in every cluster, so ownership and grants are kept.
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", "exec", "-i", container,
+		"pg_restore", "--username="+status.Config.Superuser.Username, "--dbname="+name, "--exit-on-error")
+	cmd.Stdin, cmd.Stderr = r, &stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "restore database: %s", stderr.Bytes())
+	}
+	return nil
+}
+
 func (d *Driver) createVolumeIfNeeded(ctx context.Context, name string) error {
 	if err := exec.CommandContext(ctx, "docker", "volume", "inspect", name).Run(); err == nil {
 		return nil
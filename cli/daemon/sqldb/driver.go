@@ -3,6 +3,7 @@ package sqldb
 import (
 	"context"
 	"errors"
+	"io"
 
 	"github.com/rs/zerolog"
 
@@ -34,6 +35,21 @@ type Driver interface {
 	// ClusterStatus reports the current status of a cluster.
 	ClusterStatus(ctx context.Context, id ClusterID) (*ClusterStatus, error)
 
+	// CopyDatabase copies the contents of the database srcName in the cluster src
+	// to the existing, empty database dstName in the cluster dst.
+	// If a Driver doesn't support copying databases it reports ErrUnsupported.
+	CopyDatabase(ctx context.Context, src ClusterID, srcName string, dst ClusterID, dstName string) error
+
+	// DumpDatabase writes a dump of the database name in the cluster id to w,
+	// in a format RestoreDatabase reads.
+	// If a Driver doesn't support dumping databases it reports ErrUnsupported.
+	DumpDatabase(ctx context.Context, id ClusterID, name string, w io.Writer) error
+
+	// RestoreDatabase restores a dump written by DumpDatabase from r
+	// into the existing, empty database name in the cluster id.
+	// If a Driver doesn't support restoring databases it reports ErrUnsupported.
+	RestoreDatabase(ctx context.Context, id ClusterID, name string, r io.Reader) error
+
 	// CheckRequirements checks whether all the requirements are met
 	// to use the driver.
 	CheckRequirements(ctx context.Context) error
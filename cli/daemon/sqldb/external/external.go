@@ -3,6 +3,7 @@ package external
 
 import (
 	"context"
+	"io"
 
 	"github.com/rs/zerolog"
 
@@ -52,6 +53,18 @@ func (d *Driver) DestroyNamespaceData(ctx context.Context, ns *namespace.Namespa
 	return sqldb.ErrUnsupported
 }
 
+func (d *Driver) CopyDatabase(ctx context.Context, src sqldb.ClusterID, srcName string, dst sqldb.ClusterID, dstName string) error {
+	return sqldb.ErrUnsupported
+}
+
+func (d *Driver) DumpDatabase(ctx context.Context, id sqldb.ClusterID, name string, w io.Writer) error {
+	return sqldb.ErrUnsupported
+}
+
+func (d *Driver) RestoreDatabase(ctx context.Context, id sqldb.ClusterID, name string, r io.Reader) error {
+	return sqldb.ErrUnsupported
+}
+
 func (d *Driver) CheckRequirements(ctx context.Context) error {
 	return nil
 }
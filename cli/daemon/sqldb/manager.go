@@ -15,6 +15,7 @@ import (
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/secret"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 // NewClusterManager creates a new ClusterManager.
@@ -27,6 +28,7 @@ func NewClusterManager(driver Driver, apps *apps.Manager, ns *namespace.Manager,
 		ns:             ns,
 		clusters:       make(map[clusterKey]*Cluster),
 		backendKeyData: make(map[uint32]*Cluster),
+		queryLogs:      make(map[string]*queryLog),
 		secretMgr:      secretMgr,
 	}
 }
@@ -46,6 +48,9 @@ type ClusterManager struct {
 	// for forwarding cancel requests to the right cluster.
 	// Access is guarded by mu.
 	backendKeyData map[uint32]*Cluster
+	// queryLogs are the logs of the queries made through the proxy,
+	// keyed by app id. Access is guarded by mu.
+	queryLogs map[string]*queryLog
 }
 
 // ClusterID uniquely identifies a cluster.
@@ -195,6 +200,23 @@ func (cm *ClusterManager) DeleteNamespace(ctx context.Context, app *apps.Instanc
 	return err
 }
 
+// CopyNamespace copies the given databases of the namespace from to the namespace to,
+// starting the database clusters of both namespaces as necessary.
+// Databases of the same name in to are replaced.
+func (cm *ClusterManager) CopyNamespace(ctx context.Context, app *apps.Instance, from, to *namespace.Namespace, dbs []*meta.SQLDatabase) error {
+	if err := cm.Ready(); err != nil {
+		return err
+	}
+	src := cm.Create(ctx, &CreateParams{ClusterID: GetClusterID(app, Run, from)})
+	dst := cm.Create(ctx, &CreateParams{ClusterID: GetClusterID(app, Run, to)})
+	for _, c := range []*Cluster{src, dst} {
+		if _, err := c.Start(ctx, nil); err != nil {
+			return errors.Wrapf(err, "start cluster for namespace %s", c.ID.NS.Name)
+		}
+	}
+	return dst.CopyFrom(ctx, src, dbs)
+}
+
 func genPassword() string {
 	var data [8]byte
 	if _, err := rand.Read(data[:]); err != nil {
@@ -2,6 +2,7 @@ package sqldb
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"database/sql"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/hashicorp/go-multierror"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/lib/pq"
 
 	meta "encr.dev/proto/encore/parser/meta/v1"
@@ -88,6 +90,14 @@ func NewMetadataSource(reader MigrationReader, migrations []*meta.DBMigration) *
 	return src
 }
 
+// NewMySQLMetadataSource is like NewMetadataSource,
+// for databases using the MySQL engine.
+func NewMySQLMetadataSource(reader MigrationReader, migrations []*meta.DBMigration) *MetadataSource {
+	src := NewMetadataSource(reader, migrations)
+	src.mysql = true
+	return src
+}
+
 func (src *MetadataSource) validate() {
 	if src.err != nil {
 		return
@@ -107,6 +117,7 @@ func (src *MetadataSource) validate() {
 type MetadataSource struct {
 	MigrationReader
 	migrations []*meta.DBMigration
+	mysql      bool // whether the migrations are for a MySQL database
 	err        error
 }
 
@@ -125,6 +136,11 @@ func (src *MetadataSource) ReadUp(version uint) (r io.ReadCloser, identifier str
 	statement := fmt.Sprintf(
 		";\ninsert into schema_migrations (version, dirty) values (%d, false) ON CONFLICT (version) DO UPDATE SET dirty = false;",
 		version)
+	if src.mysql {
+		statement = fmt.Sprintf(
+			";\ninsert into schema_migrations (version, dirty) values (%d, false) ON DUPLICATE KEY UPDATE dirty = false;",
+			version)
+	}
 	return MultiReadCloser(
 		r,
 		strings.NewReader(statement),
@@ -163,7 +179,17 @@ func (src *MetadataSource) Next(version uint) (nextVersion uint, err error) {
 }
 
 func (src *MetadataSource) ReadDown(version uint) (r io.ReadCloser, identifier string, err error) {
-	return nil, "", os.ErrNotExist
+	m, err := src.migration(version, 0)
+	if err != nil {
+		return nil, "", err
+	} else if m.DownFilename == "" {
+		return nil, "", os.ErrNotExist
+	}
+	r, err = src.Read(&meta.DBMigration{Filename: m.DownFilename, Number: m.Number, Description: m.Description})
+	if err != nil {
+		return nil, "", err
+	}
+	return r, m.Description, nil
 }
 
 func (src *MetadataSource) migration(version uint, offset int) (*meta.DBMigration, error) {
@@ -293,6 +319,9 @@ func LoadAppliedVersions(ctx context.Context, conn *sql.Conn, schemaName, migrat
 				return appliedVersions, nil
 			}
 		}
+		if pgErr := (*pgconn.PgError)(nil); errors.As(err, &pgErr) && pgErr.Code == pgUndefinedTable {
+			return appliedVersions, nil
+		}
 		return nil, &database.Error{OrigErr: err, Query: []byte(query)}
 	}
 	defer rows.Close()
@@ -308,6 +337,47 @@ func LoadAppliedVersions(ctx context.Context, conn *sql.Conn, schemaName, migrat
 	return appliedVersions, nil
 }
 
+// Postgres error codes.
+const (
+	pgUndefinedTable     = "42P01" // the table doesn't exist
+	pgInvalidCatalogName = "3D000" // the database doesn't exist
+)
+
+// PendingMigrations returns the migrations of db that haven't been applied,
+// in the order they are applied. applied are the applied versions
+// as returned by LoadAppliedVersions.
+func PendingMigrations(db *meta.SQLDatabase, applied map[uint64]bool) []*meta.DBMigration {
+	migrations := slices.Clone(db.Migrations)
+	slices.SortStableFunc(migrations, func(a, b *meta.DBMigration) int {
+		return cmp.Compare(a.Number, b.Number)
+	})
+
+	// Sequential migrations only record the current version,
+	// and the migrations up to it have all been applied.
+	var current uint64
+	currentDirty := false
+	if !db.AllowNonSequentialMigrations {
+		for version, dirty := range applied {
+			if version >= current {
+				current, currentDirty = version, dirty
+			}
+		}
+	}
+
+	var pending []*meta.DBMigration
+	for _, m := range migrations {
+		dirty, ok := applied[m.Number]
+		switch {
+		case db.AllowNonSequentialMigrations && ok && !dirty:
+			continue
+		case !db.AllowNonSequentialMigrations && len(applied) > 0 && (m.Number < current || (m.Number == current && !currentDirty)):
+			continue
+		}
+		pending = append(pending, m)
+	}
+	return pending
+}
+
 func (p *nonSequentialDbDriver) loadAppliedVersions() error {
 	if p.appliedVersions != nil {
 		return nil
@@ -0,0 +1,380 @@
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/golang-migrate/migrate/v4"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/rs/zerolog"
+	"go4.org/syncutil"
+
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/cli/internal/bindaddr"
+	"encr.dev/internal/optracker"
+	"encr.dev/pkg/fns"
+	"encr.dev/pkg/idents"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+const (
+	// Image is the docker image used for MySQL clusters.
+	Image = "mysql:8.4"
+
+	rootPassword   = "mysql"
+	appUser        = "encore"
+	defaultDataDir = "/var/lib/mysql"
+)
+
+// Cluster represents a MySQL cluster running in a docker container.
+type Cluster struct {
+	ID       sqldb.ClusterID // cluster ID
+	Memfs    bool            // use an in-memory filesystem?
+	Password string          // randomly generated password for the encore user
+
+	log       zerolog.Logger
+	startOnce syncutil.Once
+
+	mu   sync.Mutex
+	host string // host:port the cluster listens on; set by Start
+}
+
+// Stop implements infra.Resource. The container is left running
+// so it can be reused by the next run.
+func (c *Cluster) Stop() {
+	// no-op
+}
+
+// Host returns the host:port the cluster listens on.
+// It is only set once the cluster has been started.
+func (c *Cluster) Host() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.host
+}
+
+// Start creates the cluster's container if necessary and starts it,
+// and ensures the encore user exists with the cluster's password.
+// If the cluster is already running it does nothing.
+func (c *Cluster) Start(ctx context.Context, tracker *optracker.OpTracker) error {
+	return c.startOnce.Do(func() error {
+		c.log.Debug().Msg("starting mysql cluster")
+		host, err := c.startContainer(ctx, tracker)
+		if err != nil {
+			c.log.Error().Err(err).Msg("failed to start mysql cluster")
+			return err
+		}
+
+		root, err := c.waitForConn(ctx, host)
+		if err != nil {
+			return err
+		}
+		defer fns.CloseIgnore(root)
+
+		// The password is generated anew by every daemon, so update it for existing users.
+		for _, stmt := range []string{
+			"CREATE USER IF NOT EXISTS ?@'%' IDENTIFIED BY ?",
+			"ALTER USER ?@'%' IDENTIFIED BY ?",
+		} {
+			if _, err := root.ExecContext(ctx, stmt, appUser, c.Password); err != nil {
+				return errors.Wrap(err, "create encore user")
+			}
+		}
+		if _, err := root.ExecContext(ctx, "GRANT ALL PRIVILEGES ON *.* TO ?@'%'", appUser); err != nil {
+			return errors.Wrap(err, "grant privileges to encore user")
+		}
+
+		c.mu.Lock()
+		c.host = host
+		c.mu.Unlock()
+		c.log.Debug().Str("hostport", host).Msg("successfully started mysql cluster")
+		return nil
+	})
+}
+
+// startContainer starts the cluster's container, creating it if it doesn't exist,
+// and returns the host:port it listens on.
+func (c *Cluster) startContainer(ctx context.Context, tracker *optracker.OpTracker) (string, error) {
+	if ok, err := imageExists(ctx); err != nil {
+		return "", errors.Wrap(err, "check docker image")
+	} else if !ok {
+		pullOp := tracker.Add("Pulling MySQL docker image", time.Now())
+		cmd := exec.CommandContext(ctx, "docker", "pull", Image)
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			tracker.Fail(pullOp, err)
+			return "", errors.Wrap(err, "pull docker image")
+		}
+		tracker.Done(pullOp, 0)
+	}
+
+	cname := containerName(c.ID)
+	running, host, found, err := inspectContainer(ctx, cname)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case found && running:
+		return host, nil
+	case found:
+		if out, err := exec.CommandContext(ctx, "docker", "start", cname).CombinedOutput(); err != nil {
+			return "", errors.Wrapf(err, "could not start mysql container: %s", out)
+		}
+	default:
+		args := []string{
+			"run", "-d",
+			"-p", "3306",
+			"-e", "MYSQL_ROOT_PASSWORD=" + rootPassword,
+			"--name", cname,
+		}
+		if c.Memfs {
+			args = append(args, "--mount", "type=tmpfs,destination="+defaultDataDir)
+		} else {
+			vol := volumeName(c.ID.NS)
+			if err := exec.CommandContext(ctx, "docker", "volume", "inspect", vol).Run(); err != nil {
+				if out, err := exec.CommandContext(ctx, "docker", "volume", "create", vol).CombinedOutput(); err != nil {
+					return "", errors.Wrapf(err, "create volume %s: %s", vol, out)
+				}
+			}
+			args = append(args, "-v", vol+":"+defaultDataDir)
+		}
+		args = append(args, Image)
+		if out, err := exec.CommandContext(ctx, "docker", args...).CombinedOutput(); err != nil {
+			return "", errors.Wrapf(err, "could not start mysql database as docker container: %s", out)
+		}
+	}
+
+	// Wait for docker to assign the port.
+	for i := 0; i < 20; i++ {
+		_, host, _, err := inspectContainer(ctx, cname)
+		if err != nil {
+			return "", err
+		} else if host != "" {
+			return host, nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return "", errors.New("timed out waiting for mysql cluster to start")
+}
+
+// waitForConn waits for the MySQL server at host to accept connections,
+// and returns a connection pool to it as the root user.
+// Initializing a new data directory can take a while.
+func (c *Cluster) waitForConn(ctx context.Context, host string) (*sql.DB, error) {
+	db, err := open(host, "root", rootPassword, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < 240; i++ {
+		pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err = db.PingContext(pingCtx)
+		cancel()
+		if err == nil {
+			return db, nil
+		} else if ctx.Err() != nil {
+			break
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	_ = db.Close()
+	return nil, fmt.Errorf("mysql database did not come up: %v", err)
+}
+
+// SetupAndMigrate creates the given databases if they don't exist
+// and runs their migrations.
+func (c *Cluster) SetupAndMigrate(ctx context.Context, appRoot string, dbs []*meta.SQLDatabase) error {
+	for _, db := range dbs {
+		if err := c.setup(ctx, appRoot, db, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Recreate drops and recreates the given databases and runs their migrations.
+func (c *Cluster) Recreate(ctx context.Context, appRoot string, dbs []*meta.SQLDatabase) error {
+	for _, db := range dbs {
+		if err := c.setup(ctx, appRoot, db, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) setup(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabase, recreate bool) error {
+	host := c.Host()
+	if host == "" {
+		return errors.New("mysql cluster not running")
+	}
+
+	admin, err := open(host, appUser, c.Password, "")
+	if err != nil {
+		return err
+	}
+	defer fns.CloseIgnore(admin)
+	if recreate {
+		if _, err := admin.ExecContext(ctx, "DROP DATABASE IF EXISTS "+quoteIdent(dbMeta.Name)); err != nil {
+			return errors.Wrapf(err, "drop database %s", dbMeta.Name)
+		}
+	}
+	if _, err := admin.ExecContext(ctx, "CREATE DATABASE IF NOT EXISTS "+quoteIdent(dbMeta.Name)); err != nil {
+		return errors.Wrapf(err, "create database %s", dbMeta.Name)
+	}
+
+	if c.ID.Type == sqldb.Shadow || len(dbMeta.Migrations) == 0 || dbMeta.MigrationRelPath == nil {
+		return nil
+	}
+	if err := c.migrate(ctx, host, appRoot, dbMeta); err != nil {
+		return fmt.Errorf("could not migrate database %s: %v", dbMeta.Name, err)
+	}
+	return nil
+}
+
+// migrate runs the migrations of the given database.
+func (c *Cluster) migrate(ctx context.Context, host, appRoot string, dbMeta *meta.SQLDatabase) error {
+	if dbMeta.AllowNonSequentialMigrations {
+		return errors.New("non-sequential migrations are not supported for databases using the MySQL engine")
+	}
+
+	pool, err := open(host, appUser, c.Password, dbMeta.Name)
+	if err != nil {
+		return err
+	}
+	defer fns.CloseIgnore(pool)
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to mysql")
+	}
+	dbDriver, err := migratemysql.WithConnection(ctx, conn, &migratemysql.Config{})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to mysql")
+	}
+
+	path := filepath.Join(appRoot, *dbMeta.MigrationRelPath)
+	src := sqldb.NewMySQLMetadataSource(sqldb.NewOsMigrationReader(path), dbMeta.Migrations)
+	m, err := migrate.NewWithInstance("src", src, "mysql", dbDriver)
+	if err != nil {
+		return errors.Wrap(err, "failed to create migration instance")
+	}
+
+	// Unlike Postgres, MySQL can't roll back schema changes, so a migration that
+	// failed halfway may have been partially applied. Leave it to the user to
+	// clean up instead of resetting the dirty flag and retrying.
+	err = m.Up()
+	var dirty migrate.ErrDirty
+	switch {
+	case errors.Is(err, migrate.ErrNoChange):
+		return nil
+	case errors.As(err, &dirty):
+		return errors.Newf("migration %d previously failed and may have been partially applied; "+
+			"fix the database and reset the dirty flag in the schema_migrations table to continue", dirty.Version)
+	default:
+		return errors.Wrap(err, "failed to migrate database")
+	}
+}
+
+// open opens a connection pool to the MySQL server at host.
+func open(host, user, password, dbName string) (*sql.DB, error) {
+	cfg := mysqldriver.NewConfig()
+	cfg.Net, cfg.Addr = "tcp", host
+	cfg.User, cfg.Passwd, cfg.DBName = user, password, dbName
+	cfg.MultiStatements = true   // migrations consist of multiple statements
+	cfg.InterpolateParams = true // account management statements can't be prepared
+	connector, err := mysqldriver.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
+}
+
+func quoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// inspectContainer reports the state of the container with the given name,
+// and the host:port its MySQL port is published on.
+func inspectContainer(ctx context.Context, name string) (running bool, host string, found bool, err error) {
+	out, err := exec.CommandContext(ctx, "docker", "container", "inspect", name).CombinedOutput()
+	if errors.Is(err, exec.ErrNotFound) {
+		return false, "", false, errors.New("docker not found: is it installed and in your PATH?")
+	} else if err != nil {
+		if isNotFound(out) {
+			return false, "", false, nil
+		}
+		return false, "", false, errors.Wrapf(err, "docker container inspect failed: %s", out)
+	}
+
+	var resp []struct {
+		State struct {
+			Running bool
+		}
+		NetworkSettings struct {
+			Ports map[string][]struct {
+				HostIP   string
+				HostPort string
+			}
+		}
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return false, "", false, errors.Wrap(err, "parse `docker container inspect` response")
+	} else if len(resp) == 0 {
+		return false, "", false, nil
+	}
+	if ports := resp[0].NetworkSettings.Ports["3306/tcp"]; len(ports) > 0 {
+		// Podman can keep HostIP empty or 0.0.0.0, and Docker reports :: on IPv6 hosts,
+		// which DialAddr connects to on the loopback address.
+		host = bindaddr.DialAddr(net.JoinHostPort(ports[0].HostIP, ports[0].HostPort))
+	}
+	return resp[0].State.Running, host, true, nil
+}
+
+func imageExists(ctx context.Context) (bool, error) {
+	out, err := exec.CommandContext(ctx, "docker", "image", "inspect", Image).CombinedOutput()
+	switch {
+	case err == nil:
+		return true, nil
+	case bytes.Contains(out, []byte("No such image")), bytes.Contains(out, []byte("failed to find image")):
+		return false, nil
+	default:
+		return false, errors.Wrapf(err, "docker image inspect failed: %s", Image)
+	}
+}
+
+// isNotFound reports whether the docker output reports a missing container or volume.
+// Docker and Podman differ in capitalization.
+func isNotFound(out []byte) bool {
+	return bytes.Contains(bytes.ToLower(out), []byte("no such"))
+}
+
+func isDockerRunning(ctx context.Context) bool {
+	return exec.CommandContext(ctx, "docker", "info").Run() == nil
+}
+
+// containerName computes the container name for a given cluster.
+func containerName(id sqldb.ClusterID) string {
+	name := "mysql-" + id.NS.App.PlatformOrLocalID()
+	if id.Type != sqldb.Run {
+		name += "-" + string(id.Type)
+	}
+	nsName := idents.Convert(string(id.NS.Name), idents.KebabCase)
+	return name + "-" + nsName + "-" + string(id.NS.ID)
+}
+
+// volumeName computes the name of the docker volume storing the data of a namespace.
+func volumeName(ns *namespace.Namespace) string {
+	nsName := idents.Convert(string(ns.Name), idents.KebabCase)
+	return fmt.Sprintf("mysql-%s-%s-%s", ns.App.PlatformOrLocalID(), ns.ID, nsName)
+}
@@ -0,0 +1,120 @@
+// Package mysql manages the local MySQL clusters backing
+// the databases of an app that use the MySQL engine.
+package mysql
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os/exec"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/sqldb"
+)
+
+// NewClusterManager creates a new ClusterManager.
+func NewClusterManager() *ClusterManager {
+	return &ClusterManager{
+		log:      log.Logger,
+		clusters: make(map[string]*Cluster),
+	}
+}
+
+// A ClusterManager manages running local MySQL clusters.
+type ClusterManager struct {
+	log zerolog.Logger
+
+	mu       sync.Mutex
+	clusters map[string]*Cluster // keyed by clusterKey
+}
+
+func clusterKey(id sqldb.ClusterID) string {
+	return fmt.Sprintf("%s-%s", id.NS.ID, id.Type)
+}
+
+// Ready reports whether the cluster manager is ready and all requirements are met.
+func (cm *ClusterManager) Ready() error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return errors.New("This application requires docker to run since it uses a MySQL database. Install docker first.")
+	} else if !isDockerRunning(context.Background()) {
+		return errors.New("The docker daemon is not running. Start it first.")
+	}
+	return nil
+}
+
+// Create creates a MySQL cluster but does not start it.
+// If the cluster already exists it is returned.
+func (cm *ClusterManager) Create(params *sqldb.CreateParams) *Cluster {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	key := clusterKey(params.ClusterID)
+	if c, ok := cm.clusters[key]; ok {
+		return c
+	}
+	c := &Cluster{
+		ID:       params.ClusterID,
+		Memfs:    params.Memfs,
+		Password: genPassword(),
+		log:      cm.log.With().Interface("mysql_cluster", params.ClusterID).Logger(),
+	}
+	cm.clusters[key] = c
+	return c
+}
+
+// Get retrieves the cluster keyed by id.
+func (cm *ClusterManager) Get(id sqldb.ClusterID) (*Cluster, bool) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	c, ok := cm.clusters[clusterKey(id)]
+	return c, ok
+}
+
+// CanDeleteNamespace implements namespace.DeletionHandler.
+func (cm *ClusterManager) CanDeleteNamespace(ctx context.Context, app *apps.Instance, ns *namespace.Namespace) error {
+	if _, ok := cm.Get(sqldb.GetClusterID(app, sqldb.Run, ns)); !ok {
+		return nil
+	} else if !isDockerRunning(ctx) {
+		return errors.New("cannot delete mysql database: docker is not running")
+	}
+	return nil
+}
+
+// DeleteNamespace implements namespace.DeletionHandler.
+func (cm *ClusterManager) DeleteNamespace(ctx context.Context, app *apps.Instance, ns *namespace.Namespace) error {
+	cm.mu.Lock()
+	for key, c := range cm.clusters {
+		if c.ID.NS.ID == ns.ID {
+			delete(cm.clusters, key)
+		}
+	}
+	cm.mu.Unlock()
+
+	// The clusters may have been created by a previous daemon,
+	// so remove the containers and volume regardless of whether we know of them.
+	for _, typ := range []sqldb.ClusterType{sqldb.Run, sqldb.Test, sqldb.Shadow} {
+		id := sqldb.GetClusterID(app, typ, ns)
+		if out, err := exec.CommandContext(ctx, "docker", "rm", "-f", containerName(id)).CombinedOutput(); err != nil && !isNotFound(out) {
+			return errors.Wrapf(err, "could not delete mysql cluster: %s", out)
+		}
+	}
+	if out, err := exec.CommandContext(ctx, "docker", "volume", "rm", "-f", volumeName(ns)).CombinedOutput(); err != nil && !isNotFound(out) {
+		return errors.Wrapf(err, "could not delete volume %s: %s", volumeName(ns), out)
+	}
+	return nil
+}
+
+func genPassword() string {
+	var data [8]byte
+	if _, err := rand.Read(data[:]); err != nil {
+		log.Fatal().Err(err).Msg("unable to generate random data")
+	}
+	return base64.RawURLEncoding.EncodeToString(data[:])
+}
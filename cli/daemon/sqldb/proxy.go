@@ -71,7 +71,8 @@ func (cm *ClusterManager) ProxyConn(client net.Conn, waitForSetup bool) error {
 	// If the username is "encore" we're connecting to a database cluster
 	// which may not be local
 	var cluster *Cluster
-	if startup.Username == "encore" || startup.Username == "encore-service" || startup.Username == "encore-migrator" || startup.Username == "encore-superuser" {
+	if startup.Username == "encore" || startup.Username == "encore-service" || startup.Username == "encore-migrator" ||
+		startup.Username == "encore-superuser" || startup.Username == "encore-read" {
 		password := startup.Password
 		found, ok := cm.LookupPassword(password)
 		if !ok {
@@ -232,6 +233,9 @@ func (cm *ClusterManager) ProxyConn(client net.Conn, waitForSetup bool) error {
 			role, _ = info.Encore.First(RoleMigrator, RoleAdmin, RoleSuperuser)
 		case "encore-service":
 			role, _ = info.Encore.First(RoleService, RoleAdmin, RoleSuperuser)
+		case "encore-read":
+			// Used by services granted read access to another service's database.
+			role, _ = info.Encore.First(RoleRead, RoleService, RoleAdmin, RoleSuperuser)
 		default:
 			role, _ = info.Encore.First(RoleAdmin, RoleSuperuser)
 		}
@@ -288,7 +292,7 @@ func (cm *ClusterManager) ProxyConn(client net.Conn, waitForSetup bool) error {
 		}()
 	}
 
-	return pgproxy.CopySteadyState(cl.Backend, fe)
+	return pgproxy.CopySteadyStateObserved(cl.Backend, fe, cm.queryObserver(cluster, dbname))
 }
 
 // PreauthProxyConn is a pre-authenticated proxy conn directly specifically to the given cluster.
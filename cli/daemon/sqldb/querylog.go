@@ -0,0 +1,288 @@
+package sqldb
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgproto3/v2"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/pkg/pgproxy"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// maxLoggedQueries is the number of queries kept in the query log of each app.
+const maxLoggedQueries = 1000
+
+// LoggedQuery is a query an app made through the database proxy.
+type LoggedQuery struct {
+	Time     time.Time `json:"time"`     // when the query was sent to the database
+	Database string    `json:"database"` // the database, as named by the app
+	Query    string    `json:"query"`
+
+	Duration time.Duration `json:"duration_ns"`     // until the database completed the query
+	Rows     int64         `json:"rows"`            // rows returned or affected
+	Err      string        `json:"error,omitempty"` // the error the database reported, if any
+
+	// TraceID and SpanID identify the request that made the query,
+	// once linked with LinkTraces.
+	TraceID string `json:"trace_id,omitempty"`
+	SpanID  string `json:"span_id,omitempty"`
+}
+
+// QueryLog returns the queries the app with the given id made
+// through the database proxy, oldest first.
+func (cm *ClusterManager) QueryLog(appID string) []*LoggedQuery {
+	cm.mu.Lock()
+	l := cm.queryLogs[appID]
+	cm.mu.Unlock()
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	queries := make([]*LoggedQuery, len(l.queries))
+	for i, q := range l.queries {
+		c := *q
+		queries[i] = &c
+	}
+	return queries
+}
+
+// queryLog is the log of the queries an app made, oldest first.
+type queryLog struct {
+	mu      sync.Mutex
+	queries []*LoggedQuery
+}
+
+func (l *queryLog) add(q *LoggedQuery) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.queries) >= maxLoggedQueries {
+		l.queries = slices.Delete(l.queries, 0, len(l.queries)-maxLoggedQueries+1)
+	}
+	l.queries = append(l.queries, q)
+}
+
+// queryObserver returns the observer recording the queries made on a connection
+// to the given database of cluster, or nil if its queries aren't recorded.
+func (cm *ClusterManager) queryObserver(cluster *Cluster, database string) pgproxy.Observer {
+	// Only record the queries of apps, not those made to diff migrations.
+	if cluster.ID.NS == nil || cluster.ID.Type == Shadow {
+		return nil
+	}
+	appID := cluster.ID.NS.App.PlatformOrLocalID()
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	l, ok := cm.queryLogs[appID]
+	if !ok {
+		l = &queryLog{}
+		cm.queryLogs[appID] = l
+	}
+	return &queryRecorder{
+		log:        l,
+		database:   database,
+		statements: make(map[string]string),
+		portals:    make(map[string]string),
+	}
+}
+
+// queryRecorder records the queries made on a single connection.
+//
+// Queries are answered in the order they are sent, so the queries
+// waiting for a response are kept in a queue.
+type queryRecorder struct {
+	log      *queryLog
+	database string
+
+	mu         sync.Mutex
+	statements map[string]string // prepared statement name -> query
+	portals    map[string]string // portal name -> query
+	pending    []*pendingQuery
+}
+
+type pendingQuery struct {
+	q *LoggedQuery
+
+	// simple is whether the query was sent with the simple query protocol,
+	// which may run multiple statements and completes with ReadyForQuery.
+	simple bool
+
+	// taggedRows is the sum of the rows in the command tags of a simple query's
+	// statements, and tagged whether any of them had one.
+	taggedRows int64
+	tagged     bool
+}
+
+// FrontendMessage implements pgproxy.Observer.
+func (r *queryRecorder) FrontendMessage(msg pgproto3.FrontendMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch msg := msg.(type) {
+	case *pgproto3.Query:
+		r.start(msg.String, true)
+	case *pgproto3.Parse:
+		r.statements[msg.Name] = msg.Query
+	case *pgproto3.Bind:
+		r.portals[msg.DestinationPortal] = r.statements[msg.PreparedStatement]
+	case *pgproto3.Execute:
+		r.start(r.portals[msg.Portal], false)
+	case *pgproto3.Close:
+		if msg.ObjectType == 'S' {
+			delete(r.statements, msg.Name)
+		} else {
+			delete(r.portals, msg.Name)
+		}
+	}
+}
+
+// BackendMessage implements pgproxy.Observer.
+func (r *queryRecorder) BackendMessage(msg pgproto3.BackendMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.pending) == 0 {
+		return
+	}
+	p := r.pending[0]
+	switch msg := msg.(type) {
+	case *pgproto3.DataRow:
+		p.q.Rows++
+	case *pgproto3.CommandComplete:
+		if rows, ok := parseRows(msg.CommandTag); ok {
+			p.taggedRows += rows
+			p.tagged = true
+		}
+		if !p.simple {
+			r.finish()
+		}
+	case *pgproto3.EmptyQueryResponse, *pgproto3.PortalSuspended:
+		if !p.simple {
+			r.finish()
+		}
+	case *pgproto3.ErrorResponse:
+		p.q.Err = msg.Message
+		if !p.simple {
+			// The database skips the rest of the pipeline until the next Sync,
+			// so the queries after it won't be answered.
+			r.finish()
+			r.pending = r.pending[:0]
+		}
+	case *pgproto3.ReadyForQuery:
+		if p.simple {
+			r.finish()
+		}
+	}
+}
+
+// start records that query was sent to the database.
+func (r *queryRecorder) start(query string, simple bool) {
+	r.pending = append(r.pending, &pendingQuery{
+		q:      &LoggedQuery{Time: time.Now(), Database: r.database, Query: query},
+		simple: simple,
+	})
+}
+
+// finish records that the first pending query completed.
+func (r *queryRecorder) finish() {
+	p := r.pending[0]
+	r.pending = r.pending[1:]
+	p.q.Duration = time.Since(p.q.Time)
+	if p.tagged {
+		// Statements like INSERT don't return rows but report those they affected.
+		p.q.Rows = p.taggedRows
+	}
+	r.log.add(p.q)
+}
+
+// parseRows parses the number of rows from a command tag,
+// like "SELECT 5" or "INSERT 0 1".
+func parseRows(tag []byte) (int64, bool) {
+	idx := bytes.LastIndexByte(tag, ' ')
+	if idx < 0 {
+		return 0, false
+	}
+	rows, err := strconv.ParseInt(string(tag[idx+1:]), 10, 64)
+	return rows, err == nil
+}
+
+// LinkTraces links the queries logged for an app with the requests that made them,
+// by matching them with the queries recorded in the app's traces in store.
+// If traceID is non-empty, only that trace is considered.
+func LinkTraces(ctx context.Context, store trace2.Store, appID, traceID string, queries []*LoggedQuery) error {
+	if len(queries) == 0 {
+		return nil
+	}
+
+	traceIDs := []string{traceID}
+	if traceID == "" {
+		// Requests start before the queries they make, so look further back.
+		q := &trace2.Query{
+			AppID:     appID,
+			StartTime: queries[0].Time.Add(-time.Minute),
+			EndTime:   queries[len(queries)-1].Time,
+			Limit:     500,
+		}
+		traceIDs = traceIDs[:0]
+		err := store.List(ctx, q, func(s *tracepb2.SpanSummary) bool {
+			if !slices.Contains(traceIDs, s.TraceId) {
+				traceIDs = append(traceIDs, s.TraceId)
+			}
+			return true
+		})
+		if err != nil {
+			return errors.Wrap(err, "list traces")
+		}
+	}
+
+	var traced []trace2.DBQuery
+	for _, id := range traceIDs {
+		var events []*tracepb2.TraceEvent
+		err := store.Get(ctx, appID, id, func(ev *tracepb2.TraceEvent) bool {
+			events = append(events, ev)
+			return true
+		})
+		if errors.Is(err, trace2.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "get trace")
+		}
+		traced = append(traced, trace2.DBQueries(events)...)
+	}
+	linkQueries(queries, traced)
+	return nil
+}
+
+// linkSlack is how far outside of the time a traced query was made
+// a logged query can be, to account for clock resolution.
+const linkSlack = time.Millisecond
+
+// linkQueries links each traced query with the earliest unlinked query in logged
+// with the same query text that was made while the traced query was running.
+func linkQueries(logged []*LoggedQuery, traced []trace2.DBQuery) {
+	slices.SortFunc(traced, func(a, b trace2.DBQuery) int { return a.Start.Compare(b.Start) })
+	for _, t := range traced {
+		query := normalizeQuery(t.Query)
+		for _, q := range logged {
+			if q.TraceID != "" || q.Time.Before(t.Start.Add(-linkSlack)) || q.Time.After(t.End.Add(linkSlack)) {
+				continue
+			}
+			if normalizeQuery(q.Query) == query {
+				q.TraceID, q.SpanID = t.TraceID, t.SpanID
+				break
+			}
+		}
+	}
+}
+
+// normalizeQuery collapses the whitespace in query, which drivers may change.
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
@@ -0,0 +1,137 @@
+package sqldb
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/jackc/pgproto3/v2"
+
+	"encr.dev/cli/daemon/engine/trace2"
+)
+
+func TestQueryRecorder(t *testing.T) {
+	c := qt.New(t)
+	l := &queryLog{}
+	r := &queryRecorder{log: l, database: "users", statements: map[string]string{}, portals: map[string]string{}}
+	frontend := func(msgs ...pgproto3.FrontendMessage) {
+		for _, msg := range msgs {
+			r.FrontendMessage(msg)
+		}
+	}
+	backend := func(msgs ...pgproto3.BackendMessage) {
+		for _, msg := range msgs {
+			r.BackendMessage(msg)
+		}
+	}
+
+	// A pipeline of prepared statements using the extended protocol.
+	frontend(
+		&pgproto3.Parse{Name: "stmt1", Query: "SELECT id FROM users"},
+		&pgproto3.Bind{PreparedStatement: "stmt1"},
+		&pgproto3.Execute{},
+		&pgproto3.Parse{Query: "UPDATE users SET name = $1"},
+		&pgproto3.Bind{},
+		&pgproto3.Execute{},
+		&pgproto3.Sync{},
+	)
+	backend(
+		&pgproto3.ParseComplete{},
+		&pgproto3.BindComplete{},
+		&pgproto3.DataRow{},
+		&pgproto3.DataRow{},
+		&pgproto3.CommandComplete{CommandTag: []byte("SELECT 2")},
+		&pgproto3.ParseComplete{},
+		&pgproto3.BindComplete{},
+		&pgproto3.CommandComplete{CommandTag: []byte("UPDATE 3")},
+		&pgproto3.ReadyForQuery{},
+	)
+
+	// A failing statement skips the rest of the pipeline.
+	frontend(
+		&pgproto3.Bind{PreparedStatement: "stmt1"},
+		&pgproto3.Execute{},
+		&pgproto3.Bind{PreparedStatement: "stmt1"},
+		&pgproto3.Execute{},
+		&pgproto3.Sync{},
+	)
+	backend(
+		&pgproto3.ErrorResponse{Message: "canceling statement"},
+		&pgproto3.ReadyForQuery{},
+	)
+
+	// A simple query with multiple statements completes with ReadyForQuery.
+	frontend(&pgproto3.Query{String: "INSERT INTO users VALUES (1); INSERT INTO users VALUES (2)"})
+	backend(
+		&pgproto3.CommandComplete{CommandTag: []byte("INSERT 0 1")},
+		&pgproto3.CommandComplete{CommandTag: []byte("INSERT 0 1")},
+		&pgproto3.ReadyForQuery{},
+	)
+
+	type result struct {
+		Query string
+		Rows  int64
+		Err   string
+	}
+	var got []result
+	for _, q := range l.queries {
+		c.Assert(q.Database, qt.Equals, "users")
+		got = append(got, result{q.Query, q.Rows, q.Err})
+	}
+	c.Assert(got, qt.DeepEquals, []result{
+		{"SELECT id FROM users", 2, ""},
+		{"UPDATE users SET name = $1", 3, ""},
+		{"SELECT id FROM users", 0, "canceling statement"},
+		{"INSERT INTO users VALUES (1); INSERT INTO users VALUES (2)", 2, ""},
+	})
+	c.Assert(r.pending, qt.HasLen, 0)
+}
+
+func TestQueryLogLimit(t *testing.T) {
+	c := qt.New(t)
+	l := &queryLog{}
+	for i := range maxLoggedQueries + 10 {
+		l.add(&LoggedQuery{Rows: int64(i)})
+	}
+	c.Assert(l.queries, qt.HasLen, maxLoggedQueries)
+	c.Assert(l.queries[0].Rows, qt.Equals, int64(10))
+}
+
+func TestLinkQueries(t *testing.T) {
+	c := qt.New(t)
+	base := time.Now()
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+
+	logged := []*LoggedQuery{
+		{Time: at(1), Query: "SELECT 1"},
+		{Time: at(2), Query: "SELECT\n\t1"},
+		{Time: at(3), Query: "SELECT 2"},
+		{Time: at(20), Query: "SELECT 1"},
+	}
+	linkQueries(logged, []trace2.DBQuery{
+		// Two concurrent requests making the same query.
+		{TraceID: "b", SpanID: "b1", Query: "SELECT 1", Start: at(1), End: at(5)},
+		{TraceID: "a", SpanID: "a1", Query: "SELECT 1", Start: at(0), End: at(4)},
+		// The query text differs, so it isn't linked.
+		{TraceID: "a", SpanID: "a1", Query: "SELECT 3", Start: at(2), End: at(4)},
+		// The query was made after the traced one completed.
+		{TraceID: "c", SpanID: "c1", Query: "SELECT 1", Start: at(10), End: at(15)},
+	})
+
+	var traces []string
+	for _, q := range logged {
+		traces = append(traces, q.TraceID)
+	}
+	c.Assert(traces, qt.DeepEquals, []string{"a", "b", "", ""})
+}
+
+func TestParseRows(t *testing.T) {
+	c := qt.New(t)
+	for tag, want := range map[string]int64{"SELECT 5": 5, "INSERT 0 1": 1, "DELETE 12": 12} {
+		rows, ok := parseRows([]byte(tag))
+		c.Assert(ok, qt.IsTrue, qt.Commentf("tag %q", tag))
+		c.Assert(rows, qt.Equals, want)
+	}
+	_, ok := parseRows([]byte("BEGIN"))
+	c.Assert(ok, qt.IsFalse)
+}
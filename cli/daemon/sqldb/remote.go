@@ -3,24 +3,30 @@ package sqldb
 import (
 	"context"
 	"crypto/rand"
+	"database/sql"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
+	"path/filepath"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog/log"
 
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/cli/internal/platform"
+	"encr.dev/pkg/fns"
 	"encr.dev/pkg/pgproxy"
+	"encr.dev/pkg/schemadiff"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 // OneshotProxy listens on a random port for a single connection, and proxies that connection to a remote db.
 // It reports the one-time password and port to use.
 // Once a connection has been established, it stops listening.
 func OneshotProxy(appSlug, envSlug string, role RoleType) (port int, passwd string, err error) {
-	ln, err := net.Listen("tcp", "localhost:0")
+	ln, err := bindaddr.ListenLoopback(0)
 	if err != nil {
 		return 0, "", err
 	}
@@ -34,6 +40,77 @@ func OneshotProxy(appSlug, envSlug string, role RoleType) (port int, passwd stri
 	return ln.Addr().(*net.TCPAddr).Port, passwd, nil
 }
 
+// RemoteAppliedMigrations reports the migrations applied to the given database
+// of a cloud environment, as returned by LoadAppliedVersions.
+func RemoteAppliedMigrations(ctx context.Context, appSlug, envSlug, dbName string) (map[uint64]bool, error) {
+	port, passwd, err := OneshotProxy(appSlug, envSlug, RoleRead)
+	if err != nil {
+		return nil, err
+	}
+	uri := fmt.Sprintf("postgresql://encore:%s@%s/%s?sslmode=disable", passwd, bindaddr.LoopbackAddr(port), dbName)
+	pool, err := sql.Open("pgx", uri)
+	if err != nil {
+		return nil, err
+	}
+	defer fns.CloseIgnore(pool)
+
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer fns.CloseIgnore(conn)
+	return LoadAppliedVersions(ctx, conn, "public", "schema_migrations")
+}
+
+// RemoteSchema loads the schema of the given database of a cloud environment.
+func RemoteSchema(ctx context.Context, appSlug, envSlug, dbName string) (*schemadiff.Schema, error) {
+	port, passwd, err := OneshotProxy(appSlug, envSlug, RoleRead)
+	if err != nil {
+		return nil, err
+	}
+	uri := fmt.Sprintf("postgresql://encore:%s@%s/%s?sslmode=disable", passwd, bindaddr.LoopbackAddr(port), dbName)
+	pool, err := sql.Open("pgx", uri)
+	if err != nil {
+		return nil, err
+	}
+	defer fns.CloseIgnore(pool)
+
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer fns.CloseIgnore(conn)
+	return schemadiff.Load(ctx, conn)
+}
+
+// RemoteRollback rolls back migrations of the given database in a cloud
+// environment by running their down migrations, read from the migration
+// directory of the app at appRoot.
+// done is called after each migration has been rolled back.
+func RemoteRollback(ctx context.Context, appSlug, envSlug, appRoot string, dbMeta *meta.SQLDatabase, rollbacks []Rollback, done func(*meta.DBMigration)) error {
+	if dbMeta.MigrationRelPath == nil {
+		return errors.New("the database has no migrations")
+	}
+	port, passwd, err := OneshotProxy(appSlug, envSlug, RoleAdmin)
+	if err != nil {
+		return err
+	}
+	uri := fmt.Sprintf("postgresql://encore:%s@%s/%s?sslmode=disable", passwd, bindaddr.LoopbackAddr(port), dbMeta.Name)
+	pool, err := sql.Open("pgx", uri)
+	if err != nil {
+		return err
+	}
+	defer fns.CloseIgnore(pool)
+
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer fns.CloseIgnore(conn)
+	reader := NewOsMigrationReader(filepath.Join(appRoot, *dbMeta.MigrationRelPath))
+	return RunRollbacks(ctx, conn, dbMeta.AllowNonSequentialMigrations, reader, rollbacks, done)
+}
+
 func oneshotServer(ctx context.Context, ln net.Listener, passwd, appSlug, envSlug string, role RoleType) error {
 	proxy := &pgproxy.SingleBackendProxy{
 		RequirePassword: passwd != "",
@@ -0,0 +1,161 @@
+package sqldb
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/option"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// A Rollback is an applied migration to roll back by running its down migration.
+type Rollback struct {
+	Migration *meta.DBMigration
+
+	// Version is the migration version of the database after the rollback,
+	// or None if no migrations remain applied. It's only used for databases
+	// with sequential migrations, which only record the current version.
+	Version option.Option[uint64]
+}
+
+// Rollbacks returns the applied migrations of db to roll back, newest first:
+// the steps most recently applied migrations, or if to is set, the migrations
+// after version to, where version 0 rolls back all migrations.
+// applied are the applied versions as returned by LoadAppliedVersions.
+//
+// It reports an error if a migration to roll back has no down migration.
+func Rollbacks(db *meta.SQLDatabase, applied map[uint64]bool, steps int, to option.Option[uint64]) ([]Rollback, error) {
+	byNumber := make(map[uint64]*meta.DBMigration, len(db.Migrations))
+	for _, m := range db.Migrations {
+		byNumber[m.Number] = m
+	}
+
+	// versions are the applied versions, newest first.
+	var versions []uint64
+	if db.AllowNonSequentialMigrations {
+		for version, dirty := range applied {
+			if dirty {
+				return nil, errors.Newf("migration %d failed to apply: apply it successfully before rolling back", version)
+			}
+			versions = append(versions, version)
+		}
+	} else if len(applied) > 0 {
+		// Sequential migrations only record the current version,
+		// and the migrations up to it have all been applied.
+		var current uint64
+		currentDirty := false
+		for version, dirty := range applied {
+			if version >= current {
+				current, currentDirty = version, dirty
+			}
+		}
+		if currentDirty {
+			return nil, errors.Newf("migration %d failed to apply: apply it successfully before rolling back", current)
+		}
+		versions = append(versions, current)
+		for _, m := range db.Migrations {
+			if m.Number < current {
+				versions = append(versions, m.Number)
+			}
+		}
+	}
+	slices.SortFunc(versions, func(a, b uint64) int { return cmp.Compare(b, a) })
+
+	n := steps
+	if version, ok := to.Get(); ok {
+		if version != 0 && !slices.Contains(versions, version) {
+			return nil, errors.Newf("cannot roll back to migration %d: it's not applied", version)
+		}
+		n = slices.IndexFunc(versions, func(v uint64) bool { return v <= version })
+		if n < 0 {
+			n = len(versions)
+		}
+	} else if steps <= 0 {
+		return nil, errors.New("the number of migrations to roll back must be positive")
+	} else if steps > len(versions) {
+		return nil, errors.Newf("cannot roll back %d migration(s): only %d are applied", steps, len(versions))
+	}
+
+	var (
+		rollbacks []Rollback
+		missing   []string
+	)
+	for i, version := range versions[:n] {
+		m, ok := byNumber[version]
+		if !ok {
+			return nil, errors.Newf("cannot roll back migration %d: its migration file no longer exists", version)
+		} else if m.DownFilename == "" {
+			missing = append(missing, m.Filename)
+		}
+		r := Rollback{Migration: m}
+		if i+1 < len(versions) {
+			r.Version = option.Some(versions[i+1])
+		}
+		rollbacks = append(rollbacks, r)
+	}
+	if len(missing) > 0 {
+		return nil, errors.Newf("no down migration for %s: add a file with the same name ending in .down.sql",
+			strings.Join(missing, ", "))
+	}
+	return rollbacks, nil
+}
+
+// RunRollbacks rolls back migrations in order by running their down migrations,
+// read with reader, against the database conn is connected to. Each migration is
+// rolled back and removed from the schema_migrations table in a single transaction.
+// done is called after each migration has been rolled back.
+func RunRollbacks(ctx context.Context, conn *sql.Conn, nonSeq bool, reader MigrationReader, rollbacks []Rollback, done func(*meta.DBMigration)) error {
+	for _, r := range rollbacks {
+		if err := runRollback(ctx, conn, nonSeq, reader, r); err != nil {
+			return errors.Wrapf(err, "roll back migration %s", r.Migration.Filename)
+		}
+		if done != nil {
+			done(r.Migration)
+		}
+	}
+	return nil
+}
+
+func runRollback(ctx context.Context, conn *sql.Conn, nonSeq bool, reader MigrationReader, r Rollback) error {
+	down := &meta.DBMigration{
+		Filename:    r.Migration.DownFilename,
+		Number:      r.Migration.Number,
+		Description: r.Migration.Description,
+	}
+	rd, err := reader.Read(down)
+	if err != nil {
+		return errors.Wrap(err, "read down migration")
+	}
+	script, err := io.ReadAll(rd)
+	_ = rd.Close()
+	if err != nil {
+		return errors.Wrap(err, "read down migration")
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }() // committed explicitly on success
+	if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+		return err
+	}
+
+	if nonSeq {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", r.Migration.Number)
+	} else if _, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err == nil {
+		if version, ok := r.Version.Get(); ok {
+			_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", version)
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "update schema_migrations")
+	}
+	return tx.Commit()
+}
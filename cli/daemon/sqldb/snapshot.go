@@ -0,0 +1,240 @@
+package sqldb
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// snapshotVersion is the version of the snapshot archive format.
+const snapshotVersion = 1
+
+// snapshotManifestName is the name of the manifest in a snapshot archive.
+// It's the first entry, followed by a "<database>.dump" entry per database.
+const snapshotManifestName = "manifest.json"
+
+// SnapshotManifest describes the contents of a snapshot archive.
+type SnapshotManifest struct {
+	Version   int                `json:"version"`
+	AppID     string             `json:"app_id"`
+	Namespace string             `json:"namespace"`
+	CreatedAt time.Time          `json:"created_at"`
+	Databases []SnapshotDatabase `json:"databases"`
+}
+
+// SnapshotDatabase describes a database in a snapshot archive.
+type SnapshotDatabase struct {
+	Name string `json:"name"`
+
+	// MigrationVersion is the number of the last migration
+	// applied to the database, or 0 if none were.
+	MigrationVersion uint64 `json:"migration_version"`
+}
+
+// CreateSnapshot writes a snapshot archive of the given databases of the namespace to w,
+// starting the namespace's database cluster as necessary.
+// Databases that haven't been created yet are left out.
+func (cm *ClusterManager) CreateSnapshot(ctx context.Context, app *apps.Instance, ns *namespace.Namespace, dbs []*meta.SQLDatabase, w io.Writer) (*SnapshotManifest, error) {
+	c, err := cm.startRunCluster(ctx, app, ns)
+	if err != nil {
+		return nil, err
+	}
+	return c.writeSnapshot(ctx, app.PlatformOrLocalID(), dbs, w)
+}
+
+// RestoreSnapshot restores the databases in the snapshot archive read from r
+// into the namespace, starting its database cluster as necessary.
+// Databases of the same name are replaced. The restored databases aren't migrated,
+// so migrations newer than the snapshot are applied when they're next migrated.
+//
+// Only the databases in dbs are restored. It reports the names of the
+// databases in the archive that aren't, as the app doesn't declare them.
+func (cm *ClusterManager) RestoreSnapshot(ctx context.Context, app *apps.Instance, ns *namespace.Namespace, dbs []*meta.SQLDatabase, r io.Reader) (m *SnapshotManifest, skipped []string, err error) {
+	c, err := cm.startRunCluster(ctx, app, ns)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.restoreSnapshot(ctx, dbs, r)
+}
+
+func (cm *ClusterManager) startRunCluster(ctx context.Context, app *apps.Instance, ns *namespace.Namespace) (*Cluster, error) {
+	if err := cm.Ready(); err != nil {
+		return nil, err
+	}
+	c := cm.Create(ctx, &CreateParams{ClusterID: GetClusterID(app, Run, ns)})
+	if _, err := c.Start(ctx, nil); err != nil {
+		return nil, errors.Wrapf(err, "start cluster for namespace %s", ns.Name)
+	}
+	return c, nil
+}
+
+func (c *Cluster) writeSnapshot(ctx context.Context, appID string, dbs []*meta.SQLDatabase, w io.Writer) (*SnapshotManifest, error) {
+	m := &SnapshotManifest{
+		Version:   snapshotVersion,
+		AppID:     appID,
+		Namespace: string(c.ID.NS.Name),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Databases: []SnapshotDatabase{},
+	}
+
+	// Dump the databases to temporary files first,
+	// as the size of each entry must be known to write it.
+	dir, err := os.MkdirTemp("", "encore-snapshot")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	for _, dbMeta := range dbs {
+		if c.IsExternalDB(dbMeta.Name) {
+			continue
+		}
+		applied, exists, err := c.AppliedMigrations(ctx, dbMeta.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "dump database %s", dbMeta.Name)
+		} else if !exists {
+			continue
+		}
+		if err := c.dumpToFile(ctx, dbMeta.Name, filepath.Join(dir, dbMeta.Name+".dump")); err != nil {
+			return nil, errors.Wrapf(err, "dump database %s", dbMeta.Name)
+		}
+		var version uint64
+		for v := range applied {
+			version = max(version, v)
+		}
+		m.Databases = append(m.Databases, SnapshotDatabase{Name: dbMeta.Name, MigrationVersion: version})
+	}
+
+	tw := tar.NewWriter(w)
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name:    snapshotManifestName,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: m.CreatedAt,
+	})
+	if err == nil {
+		_, err = tw.Write(data)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "write manifest")
+	}
+	for _, db := range m.Databases {
+		if err := addSnapshotFile(tw, filepath.Join(dir, db.Name+".dump"), m.CreatedAt); err != nil {
+			return nil, errors.Wrapf(err, "write database %s", db.Name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return m, nil
+}
+
+// dumpToFile dumps the database name to the file at path.
+func (c *Cluster) dumpToFile(ctx context.Context, name, path string) error {
+	db, ok := c.GetDB(name)
+	if !ok {
+		db = c.newDB(name)
+		defer db.CloseConns()
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := c.driver.DumpDatabase(ctx, c.ID, db.ApplicationCloudName(), f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return errors.WithStack(f.Close())
+}
+
+// addSnapshotFile adds the file at path to tw, named after its base name.
+func addSnapshotFile(tw *tar.Writer, path string, modTime time.Time) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name:    filepath.Base(path),
+		Mode:    0644,
+		Size:    fi.Size(),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+func (c *Cluster) restoreSnapshot(ctx context.Context, dbs []*meta.SQLDatabase, r io.Reader) (m *SnapshotManifest, skipped []string, err error) {
+	tr := tar.NewReader(r)
+	m, err = readSnapshotManifest(tr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, errors.Wrap(err, "read snapshot")
+		}
+		name, ok := strings.CutSuffix(hdr.Name, ".dump")
+		if !ok || !slices.ContainsFunc(m.Databases, func(db SnapshotDatabase) bool { return db.Name == name }) {
+			return nil, nil, fmt.Errorf("invalid snapshot: unexpected entry %q", hdr.Name)
+		}
+		if !slices.ContainsFunc(dbs, func(db *meta.SQLDatabase) bool { return db.Name == name }) || c.IsExternalDB(name) {
+			skipped = append(skipped, name)
+			continue
+		}
+
+		c.mu.Lock()
+		db, ok := c.dbs[name]
+		if !ok {
+			db = c.initDB(name)
+		}
+		c.mu.Unlock()
+		if err := db.restoreFrom(ctx, tr); err != nil {
+			return nil, nil, errors.Wrapf(err, "restore database %s", name)
+		}
+	}
+	return m, skipped, nil
+}
+
+// readSnapshotManifest reads the manifest at the start of a snapshot archive.
+func readSnapshotManifest(tr *tar.Reader) (*SnapshotManifest, error) {
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != snapshotManifestName {
+		return nil, errors.New("not a database snapshot")
+	}
+	var m SnapshotManifest
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return nil, errors.Wrap(err, "invalid snapshot manifest")
+	} else if m.Version > snapshotVersion {
+		return nil, fmt.Errorf("the snapshot was created by a newer version of Encore (format version %d); upgrade Encore to restore it", m.Version)
+	}
+	return &m, nil
+}
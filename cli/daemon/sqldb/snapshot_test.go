@@ -0,0 +1,43 @@
+package sqldb
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestReadSnapshotManifest(t *testing.T) {
+	c := qt.New(t)
+	archive := func(name string, m *SnapshotManifest) *tar.Reader {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		data, err := json.Marshal(m)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}), qt.IsNil)
+		_, err = tw.Write(data)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tw.Close(), qt.IsNil)
+		return tar.NewReader(&buf)
+	}
+
+	want := &SnapshotManifest{
+		Version:   snapshotVersion,
+		AppID:     "app",
+		Namespace: "default",
+		Databases: []SnapshotDatabase{{Name: "users", MigrationVersion: 3}},
+	}
+	got, err := readSnapshotManifest(archive(snapshotManifestName, want))
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, want)
+
+	_, err = readSnapshotManifest(archive("users.dump", want))
+	c.Assert(err, qt.ErrorMatches, "not a database snapshot")
+
+	newer := *want
+	newer.Version = snapshotVersion + 1
+	_, err = readSnapshotManifest(archive(snapshotManifestName, &newer))
+	c.Assert(err, qt.ErrorMatches, "the snapshot was created by a newer version of Encore.*")
+}
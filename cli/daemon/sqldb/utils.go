@@ -3,6 +3,7 @@ package sqldb
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -32,7 +33,34 @@ func WaitForConn(ctx context.Context, uri string) error {
 	return fmt.Errorf("database did not come up: %v", err)
 }
 
-// IsUsed reports whether the application uses SQL databases at all.
+// SearchDatabase is the name of the database storing
+// the application's search indexes during local development.
+const SearchDatabase = "encore_search"
+
+// Databases returns the Postgres databases to provision for the application:
+// its own databases, and the database storing its search indexes if it has any.
+// Databases using the MySQL engine are provisioned separately; see MySQLDatabases.
+func Databases(md *meta.Data) []*meta.SQLDatabase {
+	dbs := slices.DeleteFunc(slices.Clone(md.SqlDatabases), IsMySQL)
+	if len(md.SearchIndexes) == 0 {
+		return dbs
+	}
+	return append(dbs, &meta.SQLDatabase{Name: SearchDatabase})
+}
+
+// MySQLDatabases returns the databases of the application using the MySQL engine.
+func MySQLDatabases(md *meta.Data) []*meta.SQLDatabase {
+	return slices.DeleteFunc(slices.Clone(md.SqlDatabases), func(db *meta.SQLDatabase) bool {
+		return !IsMySQL(db)
+	})
+}
+
+// IsMySQL reports whether db uses the MySQL engine.
+func IsMySQL(db *meta.SQLDatabase) bool {
+	return db.Engine == meta.SQLDatabase_MYSQL
+}
+
+// IsUsed reports whether the application uses Postgres databases at all.
 func IsUsed(md *meta.Data) bool {
-	return len(md.SqlDatabases) > 0
+	return len(Databases(md)) > 0
 }
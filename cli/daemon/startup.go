@@ -0,0 +1,78 @@
+package daemon
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/run"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// StartupReport reports where the time went when the processes of a running app started.
+func (s *Server) StartupReport(ctx context.Context, req *daemonpb.StartupReportRequest) (*daemonpb.StartupReportResponse, error) {
+	r, err := s.runningApp(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	pg := r.ProcGroup()
+	if pg == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the app is not running")
+	}
+
+	// Group the services and gateways by the process running them.
+	var procs []*run.Proc
+	names := make(map[*run.Proc][]string)
+	for _, byName := range []map[string]*run.Proc{pg.Gateways, pg.Services} {
+		for _, name := range slices.Sorted(maps.Keys(byName)) {
+			p := byName[name]
+			if !p.Started.Load() {
+				continue
+			}
+			if _, ok := names[p]; !ok {
+				procs = append(procs, p)
+			}
+			names[p] = append(names[p], name)
+		}
+	}
+
+	resp := &daemonpb.StartupReportResponse{
+		MigrationsWaitNs: pg.MigrationsWait().Nanoseconds(),
+		Processes:        make([]*daemonpb.ProcessStartupReport, len(procs)),
+	}
+	var eg errgroup.Group
+	for i, p := range procs {
+		out := &daemonpb.ProcessStartupReport{Services: names[p], Pid: int32(p.Pid)}
+		resp.Processes[i] = out
+		eg.Go(func() error {
+			rep, err := p.StartupReport(ctx)
+			if err != nil {
+				out.Error = err.Error()
+				return nil
+			}
+			out.Complete = rep.Complete
+			out.TotalNs = rep.Total.Nanoseconds()
+			out.PackageInitNs = rep.PackageInit.Nanoseconds()
+			out.ResourceSetupNs = rep.ResourceSetup.Nanoseconds()
+			out.RuntimeSetupNs = rep.RuntimeSetup.Nanoseconds()
+			out.ServiceInitNs = rep.ServiceInit.Nanoseconds()
+			out.Resources = startupTimings(rep.Resources)
+			out.ServicesInit = startupTimings(rep.Services)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	return resp, nil
+}
+
+func startupTimings(timings []run.StartupTiming) []*daemonpb.StartupTiming {
+	res := make([]*daemonpb.StartupTiming, len(timings))
+	for i, t := range timings {
+		res[i] = &daemonpb.StartupTiming{Kind: t.Kind, Name: t.Name, DurationNs: t.Duration.Nanoseconds()}
+	}
+	return res
+}
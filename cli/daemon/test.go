@@ -18,12 +18,21 @@ import (
 
 // Test runs tests.
 func (s *Server) Test(req *daemonpb.TestRequest, stream daemonpb.Daemon_TestServer) error {
+	if req.Resumable {
+		return s.runResumable(stream, func(stream commandServerStream) {
+			_ = s.runTests(req, stream)
+		})
+	}
+	return s.runTests(req, stream)
+}
+
+func (s *Server) runTests(req *daemonpb.TestRequest, stream daemonpb.Daemon_TestServer) error {
 	ctx := stream.Context()
 	slog := &streamLog{stream: stream, buffered: false}
 	stderr := slog.Stderr(false)
 	sendErr := func(err error) {
 		stderr.Write([]byte(err.Error() + "\n"))
-		streamExit(stream, 1)
+		streamError(stream, err)
 	}
 
 	ctx, tracer, err := s.beginTracing(ctx, req.AppRoot, req.WorkingDir, req.TraceFile)
@@ -39,6 +48,12 @@ func (s *Server) Test(req *daemonpb.TestRequest, stream daemonpb.Daemon_TestServ
 		return nil
 	}
 
+	isolationEnv, err := dbIsolationEnv(req.DbIsolation)
+	if err != nil {
+		sendErr(err)
+		return nil
+	}
+
 	ns, err := s.namespaceOrActive(ctx, app, nil /* tests don't support different namespaces */)
 	if err != nil {
 		sendErr(err)
@@ -67,7 +82,8 @@ func (s *Server) Test(req *daemonpb.TestRequest, stream daemonpb.Daemon_TestServ
 			}
 		}()
 
-		testEnv := append([]string{"ENCORE_RUNTIME_LOG=error"}, req.Environ...)
+		testEnv := append([]string{"ENCORE_RUNTIME_LOG=error"}, isolationEnv...)
+		testEnv = append(testEnv, req.Environ...)
 
 		tp := run.TestParams{
 			TestSpecParams: &run.TestSpecParams{
@@ -107,6 +123,11 @@ func (s *Server) TestSpec(ctx context.Context, req *daemonpb.TestSpecRequest) (r
 		return nil, errors.Wrap(err, "unable to track app")
 	}
 
+	isolationEnv, err := dbIsolationEnv(req.DbIsolation)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	ns, err := s.namespaceOrActive(ctx, app, nil /* tests don't support different namespaces */)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get namespace")
@@ -129,7 +150,8 @@ func (s *Server) TestSpec(ctx context.Context, req *daemonpb.TestSpecRequest) (r
 		}
 	}()
 
-	testEnv := append([]string{"ENCORE_RUNTIME_LOG=error"}, req.Environ...)
+	testEnv := append([]string{"ENCORE_RUNTIME_LOG=error"}, isolationEnv...)
+	testEnv = append(testEnv, req.Environ...)
 
 	spec, err := s.mgr.TestSpec(ctx, run.TestSpecParams{
 		App:        app,
@@ -152,3 +174,16 @@ func (s *Server) TestSpec(ctx context.Context, req *daemonpb.TestSpecRequest) (r
 		Environ: spec.Environ,
 	}, nil
 }
+
+// dbIsolationEnv returns the environment variables that configure
+// the given database isolation mode for the tests.
+func dbIsolationEnv(mode string) ([]string, error) {
+	switch mode {
+	case "", "none":
+		return nil, nil
+	case "test", "package":
+		return []string{"ENCORE_TEST_DB_ISOLATION=" + mode}, nil
+	default:
+		return nil, fmt.Errorf("invalid --db-isolation value %q: must be one of test, package or none", mode)
+	}
+}
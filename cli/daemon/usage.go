@@ -0,0 +1,76 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	daemonpb "encr.dev/proto/encore/daemon"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// UsageReport attributes the resource usage in recorded traces to requests,
+// aggregated by endpoint or user.
+func (s *Server) UsageReport(ctx context.Context, req *daemonpb.UsageReportRequest) (*daemonpb.UsageReportResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	appID := app.PlatformOrLocalID()
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 1000
+	}
+	var traceIDs []string
+	err = s.tr.List(ctx, &trace2.Query{AppID: appID, Limit: limit}, func(sp *tracepb2.SpanSummary) bool {
+		traceIDs = append(traceIDs, sp.TraceId)
+		return true
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to list traces: %v", err)
+	}
+
+	resp := &daemonpb.UsageReportResponse{}
+	var reqs []trace2.RequestUsage
+	for _, traceID := range traceIDs {
+		var events []*tracepb2.TraceEvent
+		err := s.tr.Get(ctx, appID, traceID, func(ev *tracepb2.TraceEvent) bool {
+			events = append(events, ev)
+			return true
+		})
+		if errors.Is(err, trace2.ErrNotFound) {
+			continue // deleted since it was listed
+		} else if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to get trace %s: %v", traceID, err)
+		}
+		resp.TracesAnalyzed++
+		reqs = append(reqs, trace2.AttributeUsage(events)...)
+	}
+
+	by := trace2.ByEndpoint
+	if req.GroupBy == daemonpb.UsageReportRequest_GROUP_BY_UID {
+		by = trace2.ByUID
+	}
+	for _, g := range trace2.AggregateUsage(reqs, by) {
+		resp.Groups = append(resp.Groups, &daemonpb.UsageGroup{
+			ServiceName:  g.Service,
+			EndpointName: g.Endpoint,
+			Uid:          g.UID,
+			Requests:     int32(g.Requests),
+			RequestNanos: uint64(g.RequestTime),
+			DbQueries:    int32(g.DBQueries),
+			DbNanos:      uint64(g.DBTime),
+			CacheOps:     int32(g.CacheOps),
+			CacheNanos:   uint64(g.CacheTime),
+			Publishes:    int32(g.Publishes),
+			PublishNanos: uint64(g.PublishTime),
+			HttpCalls:    int32(g.HTTPCalls),
+			HttpNanos:    uint64(g.HTTPTime),
+		})
+	}
+	return resp, nil
+}
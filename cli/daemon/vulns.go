@@ -0,0 +1,47 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// VulnScan reports known vulnerabilities in the dependencies of an app.
+func (s *Server) VulnScan(ctx context.Context, req *daemonpb.VulnScanRequest) (*daemonpb.VulnScanResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	rep, err := s.mgr.VulnReport(ctx, app, req.Refresh)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "vulnerability scan failed: %v", err)
+	}
+
+	resp := &daemonpb.VulnScanResponse{
+		Scanner:   rep.Scanner,
+		ScannedAt: rep.ScannedAt.Format(time.RFC3339),
+	}
+	for _, f := range rep.Findings {
+		if !req.IncludeUnreachable && !f.Reachable() {
+			resp.Unreachable++
+			continue
+		}
+		resp.Vulnerabilities = append(resp.Vulnerabilities, &daemonpb.Vulnerability{
+			Id:           f.ID,
+			Aliases:      f.Aliases,
+			Summary:      f.Summary,
+			Url:          f.URL,
+			Module:       f.Module,
+			Version:      f.Version,
+			FixedVersion: f.FixedVersion,
+			Severity:     f.Severity,
+			Reachability: daemonpb.Vulnerability_Reachability(f.Reachability),
+			Trace:        f.Trace,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,112 @@
+// Package bindaddr implements the policy governing which network interfaces
+// the listeners created by the daemon, like the app's and the dashboard's, bind to,
+// and helpers for binding to and rendering local addresses, IPv4 or IPv6.
+package bindaddr
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"encr.dev/internal/userconfig"
+)
+
+// Policy governs binding listeners to non-loopback addresses,
+// which exposes them to other devices on the network.
+type Policy string
+
+const (
+	// PolicyWarn allows non-loopback addresses but warns about them.
+	PolicyWarn Policy = "warn"
+	// PolicyLoopback refuses non-loopback addresses.
+	PolicyLoopback Policy = "loopback"
+	// PolicyAllow allows non-loopback addresses silently.
+	PolicyAllow Policy = "allow"
+)
+
+// Parse parses a policy. The empty string parses as PolicyWarn.
+func Parse(s string) (Policy, error) {
+	switch p := Policy(s); p {
+	case "":
+		return PolicyWarn, nil
+	case PolicyWarn, PolicyLoopback, PolicyAllow:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid bind policy %q (expected one of warn, loopback, allow)", s)
+	}
+}
+
+// Configured returns the policy set with the bind.policy config setting
+// for the app at appRoot, or globally if appRoot is empty.
+func Configured(appRoot string) Policy {
+	var (
+		cfg *userconfig.Config
+		err error
+	)
+	if appRoot != "" {
+		cfg, err = userconfig.ForApp(appRoot).Get()
+	} else {
+		cfg, err = userconfig.Global().Get()
+	}
+	if err != nil {
+		return PolicyWarn
+	}
+	p, err := Parse(cfg.BindPolicy)
+	if err != nil {
+		return PolicyWarn
+	}
+	return p
+}
+
+// ExposedError is reported when the policy refuses an address.
+type ExposedError struct {
+	Component string
+	Addr      string
+}
+
+func (e *ExposedError) Error() string {
+	return fmt.Sprintf("%s would listen on %s, which is reachable from other devices on the network; "+
+		"the bind policy only allows loopback addresses", e.Component, e.Addr)
+}
+
+// Check checks listening on addr, a host:port or host address, against the policy.
+// It returns an *ExposedError if the policy refuses it, and otherwise
+// a warning to report if the policy warns about it, or "" if there is none.
+func (p Policy) Check(component, addr string) (warning string, err error) {
+	if p == PolicyAllow || IsLoopback(addr) {
+		return "", nil
+	}
+	if p == PolicyLoopback {
+		return "", &ExposedError{Component: component, Addr: addr}
+	}
+	return fmt.Sprintf("%s is listening on %s, which is reachable from other devices on the network", component, addr), nil
+}
+
+// IsLoopback reports whether addr, a host:port or host address,
+// only accepts connections from the local machine.
+// An empty host listens on all interfaces, so it's not loopback.
+func IsLoopback(addr string) bool {
+	host := SplitHost(addr)
+	if host == "" {
+		return false
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return ip.IsLoopback()
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	// Resolve host names, which are loopback only if all their addresses are.
+	ips, err := net.LookupIP(host)
+	if err != nil || len(ips) == 0 {
+		return false
+	}
+	for _, ip := range ips {
+		if !ip.IsLoopback() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package bindaddr
+
+import (
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestIsLoopback(t *testing.T) {
+	c := qt.New(t)
+	for addr, want := range map[string]bool{
+		"127.0.0.1:4000": true,
+		"127.0.0.2":      true,
+		"[::1]:4000":     true,
+		"localhost:4000": true,
+		":4000":          false,
+		"0.0.0.0:4000":   false,
+		"[::]:4000":      false,
+		"192.168.1.2:80": false,
+	} {
+		c.Check(IsLoopback(addr), qt.Equals, want, qt.Commentf("addr %s", addr))
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := qt.New(t)
+
+	warning, err := PolicyWarn.Check("app", "127.0.0.1:4000")
+	c.Assert(err, qt.IsNil)
+	c.Assert(warning, qt.Equals, "")
+
+	warning, err = PolicyWarn.Check("app", "0.0.0.0:4000")
+	c.Assert(err, qt.IsNil)
+	c.Assert(warning, qt.Contains, "app is listening on 0.0.0.0:4000")
+
+	warning, err = PolicyAllow.Check("app", "0.0.0.0:4000")
+	c.Assert(err, qt.IsNil)
+	c.Assert(warning, qt.Equals, "")
+
+	_, err = PolicyLoopback.Check("app", ":4000")
+	var exposed *ExposedError
+	c.Assert(errors.As(err, &exposed), qt.IsTrue)
+	c.Assert(exposed.Addr, qt.Equals, ":4000")
+}
+
+func TestParse(t *testing.T) {
+	c := qt.New(t)
+	p, err := Parse("")
+	c.Assert(err, qt.IsNil)
+	c.Assert(p, qt.Equals, PolicyWarn)
+
+	p, err = Parse("loopback")
+	c.Assert(err, qt.IsNil)
+	c.Assert(p, qt.Equals, PolicyLoopback)
+
+	_, err = Parse("public")
+	c.Assert(err, qt.ErrorMatches, `invalid bind policy "public".*`)
+}
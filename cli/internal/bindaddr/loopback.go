@@ -0,0 +1,84 @@
+package bindaddr
+
+import (
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Loopback returns the loopback address to bind local listeners to:
+// 127.0.0.1, or ::1 on hosts without IPv4 loopback, like IPv6-only hosts.
+var Loopback = sync.OnceValue(func() string {
+	if ln, err := net.Listen("tcp4", "127.0.0.1:0"); err == nil {
+		_ = ln.Close()
+		return "127.0.0.1"
+	}
+	if ln, err := net.Listen("tcp6", "[::1]:0"); err == nil {
+		_ = ln.Close()
+		return "::1"
+	}
+	return "127.0.0.1"
+})
+
+// ListenLoopback listens for TCP connections on the loopback address
+// on the given port, or on a port picked by the OS if port is 0.
+func ListenLoopback(port int) (net.Listener, error) {
+	return net.Listen("tcp", LoopbackAddr(port))
+}
+
+// LoopbackAddr returns the host:port address of port on the loopback address.
+func LoopbackAddr(port int) string {
+	return net.JoinHostPort(Loopback(), strconv.Itoa(port))
+}
+
+// SplitHost returns the host of addr, a host:port or host address,
+// without the brackets around IPv6 addresses.
+func SplitHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+}
+
+// DisplayAddr renders the listen address addr for clients on this machine.
+// Addresses that listen on all interfaces, like ":4000", "0.0.0.0:4000"
+// and "[::]:4000", render as "localhost:4000". Other addresses are kept,
+// with IPv6 addresses in brackets.
+func DisplayAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if isUnspecified(host) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// DialAddr returns the address for clients on this machine to connect
+// to a listener on addr. Addresses that listen on all interfaces, and
+// "localhost", are connected to on the loopback address, or on ::1
+// for "[::]", which may only accept IPv6 connections.
+func DialAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if host == "::" {
+		host = "::1"
+	} else if isUnspecified(host) || strings.EqualFold(host, "localhost") {
+		host = Loopback()
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// isUnspecified reports whether host listens on all interfaces.
+func isUnspecified(host string) bool {
+	if host == "" {
+		return true
+	}
+	ip, err := netip.ParseAddr(host)
+	return err == nil && ip.IsUnspecified()
+}
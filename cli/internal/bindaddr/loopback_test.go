@@ -0,0 +1,51 @@
+package bindaddr
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDisplayAddr(t *testing.T) {
+	c := qt.New(t)
+	for addr, want := range map[string]string{
+		":4000":          "localhost:4000",
+		"0.0.0.0:4000":   "localhost:4000",
+		"[::]:4000":      "localhost:4000",
+		"127.0.0.1:4000": "127.0.0.1:4000",
+		"[::1]:4000":     "[::1]:4000",
+		"example.com:80": "example.com:80",
+		"no-port":        "no-port",
+	} {
+		c.Check(DisplayAddr(addr), qt.Equals, want, qt.Commentf("addr %s", addr))
+	}
+}
+
+func TestDialAddr(t *testing.T) {
+	c := qt.New(t)
+	loopback := LoopbackAddr(4000)
+	for addr, want := range map[string]string{
+		":4000":          loopback,
+		"0.0.0.0:4000":   loopback,
+		"localhost:4000": loopback,
+		"[::]:4000":      "[::1]:4000",
+		"[::1]:4000":     "[::1]:4000",
+		"10.0.0.1:4000":  "10.0.0.1:4000",
+	} {
+		c.Check(DialAddr(addr), qt.Equals, want, qt.Commentf("addr %s", addr))
+	}
+}
+
+func TestSplitHost(t *testing.T) {
+	c := qt.New(t)
+	for addr, want := range map[string]string{
+		"[::1]:4000":     "::1",
+		"[::1]":          "::1",
+		"::1":            "::1",
+		"127.0.0.1:4000": "127.0.0.1",
+		"localhost":      "localhost",
+		":4000":          "",
+	} {
+		c.Check(SplitHost(addr), qt.Equals, want, qt.Commentf("addr %s", addr))
+	}
+}
@@ -0,0 +1,198 @@
+// Package localauth authenticates requests to the HTTP servers the daemon
+// exposes locally, like the development dashboard and the MCP endpoint.
+//
+// By default they accept requests from anyone who can reach them, which on
+// shared machines or when listening on all interfaces exposes app internals
+// to other users. The "daemon.auth" config setting makes them require either
+// a token only the current user can read, or connections from processes
+// running as the current user.
+package localauth
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/internal/userconfig"
+)
+
+// Mode is how requests are authenticated.
+type Mode string
+
+const (
+	// ModeNone accepts all requests.
+	ModeNone Mode = "none"
+
+	// ModeToken requires requests to carry the local token,
+	// either as the TokenParam query parameter, a bearer token
+	// or the cookie set when a request carried the query parameter.
+	ModeToken Mode = "token"
+
+	// ModeUser only accepts connections from processes running
+	// as the same OS user as the daemon. On platforms where the owner
+	// of a connection can't be determined it requires the token instead.
+	ModeUser Mode = "user"
+)
+
+// TokenParam is the query parameter carrying the local token.
+const TokenParam = "token"
+
+// cookieName is the cookie remembering the local token for browsers,
+// so the requests the dashboard makes on its own are authenticated.
+const cookieName = "encore_local_token"
+
+// Authenticator authenticates requests according to its mode.
+type Authenticator struct {
+	mode  Mode
+	token string // set in ModeToken
+}
+
+// Configured returns an Authenticator for the mode
+// configured by the "daemon.auth" setting.
+func Configured() (*Authenticator, error) {
+	cfg, err := userconfig.Global().Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "read config")
+	}
+	return New(Mode(cfg.DaemonAuth))
+}
+
+// New returns an Authenticator for the given mode.
+func New(mode Mode) (*Authenticator, error) {
+	switch mode {
+	case "", ModeNone:
+		return &Authenticator{mode: ModeNone}, nil
+	case ModeUser:
+		if peerUIDSupported {
+			return &Authenticator{mode: ModeUser}, nil
+		}
+		// Fall back to requiring the token, which is also only
+		// readable by the current user.
+	case ModeToken:
+	default:
+		return nil, errors.Newf("unknown authentication mode %q", mode)
+	}
+
+	token, err := Token()
+	if err != nil {
+		return nil, err
+	}
+	return &Authenticator{mode: ModeToken, token: token}, nil
+}
+
+// Mode reports the mode requests are authenticated with.
+func (a *Authenticator) Mode() Mode {
+	return a.mode
+}
+
+// URL adds the local token to u if it's required,
+// for URLs opened in a browser or given to MCP clients.
+// A nil Authenticator returns u unchanged.
+func (a *Authenticator) URL(u string) string {
+	if a == nil || a.mode != ModeToken {
+		return u
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return u
+	}
+	q := parsed.Query()
+	q.Set(TokenParam, a.token)
+	parsed.RawQuery = q.Encode()
+	return parsed.String()
+}
+
+// Serve serves HTTP requests on ln with h,
+// rejecting the requests and connections that fail authentication.
+func (a *Authenticator) Serve(ln net.Listener, h http.Handler) error {
+	switch a.mode {
+	case ModeToken:
+		h = a.tokenHandler(h)
+	case ModeUser:
+		ln = &userListener{Listener: ln}
+	}
+	return http.Serve(ln, h)
+}
+
+func (a *Authenticator) tokenHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if tok := req.URL.Query().Get(TokenParam); a.validToken(tok) {
+			// Remember the token for the requests the browser makes on its own.
+			http.SetCookie(w, &http.Cookie{
+				Name:     cookieName,
+				Value:    tok,
+				Path:     "/",
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+			})
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		if tok, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); ok && a.validToken(tok) {
+			h.ServeHTTP(w, req)
+			return
+		}
+		if c, err := req.Cookie(cookieName); err == nil && a.validToken(c.Value) {
+			h.ServeHTTP(w, req)
+			return
+		}
+		http.Error(w, "unauthorized: open the URL printed by 'encore run', which includes the local token", http.StatusUnauthorized)
+	})
+}
+
+func (a *Authenticator) validToken(tok string) bool {
+	return tok != "" && subtle.ConstantTimeCompare([]byte(tok), []byte(a.token)) == 1
+}
+
+// userListener only accepts connections from processes
+// running as the same OS user as the daemon.
+type userListener struct {
+	net.Listener
+}
+
+func (ln *userListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := ln.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPeer(conn); err != nil {
+			log.Warn().Err(err).Stringer("remote_addr", conn.RemoteAddr()).Msg("rejected local connection")
+			_ = conn.Close()
+			continue
+		}
+		return conn, nil
+	}
+}
+
+// checkPeer reports an error unless conn is from a process
+// running as the same OS user as the daemon.
+func checkPeer(conn net.Conn) error {
+	local, err := netip.ParseAddrPort(conn.LocalAddr().String())
+	if err != nil {
+		return errors.Wrap(err, "parse local address")
+	}
+	remote, err := netip.ParseAddrPort(conn.RemoteAddr().String())
+	if err != nil {
+		return errors.Wrap(err, "parse remote address")
+	}
+	if !remote.Addr().Unmap().IsLoopback() {
+		return errors.New("connection is not from this machine")
+	}
+
+	uid, err := peerUID(remote, local)
+	if err != nil {
+		return err
+	} else if uid != os.Getuid() {
+		return errors.Newf("connection is from another user (uid %d)", uid)
+	}
+	return nil
+}
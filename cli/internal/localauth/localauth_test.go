@@ -0,0 +1,87 @@
+package localauth
+
+import (
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestToken(t *testing.T) {
+	c := qt.New(t)
+	t.Setenv("ENCORE_CONFIG_DIR", t.TempDir())
+
+	a, err := New(ModeToken)
+	c.Assert(err, qt.IsNil)
+	addr := serve(c, a)
+
+	get := func(client *http.Client, u string, header http.Header) int {
+		req, err := http.NewRequest("GET", u, nil)
+		c.Assert(err, qt.IsNil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := client.Do(req)
+		c.Assert(err, qt.IsNil)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	base := "http://" + addr + "/app"
+	c.Assert(get(http.DefaultClient, base, nil), qt.Equals, http.StatusUnauthorized)
+	c.Assert(get(http.DefaultClient, base+"?token=wrong", nil), qt.Equals, http.StatusUnauthorized)
+	c.Assert(get(http.DefaultClient, base, http.Header{"Authorization": {"Bearer " + a.token}}), qt.Equals, http.StatusOK)
+
+	// A browser opening the URL with the token is authenticated by the cookie afterwards.
+	jar, err := cookiejar.New(nil)
+	c.Assert(err, qt.IsNil)
+	browser := &http.Client{Jar: jar}
+	c.Assert(get(browser, a.URL(base), nil), qt.Equals, http.StatusOK)
+	c.Assert(get(browser, "http://"+addr+"/other", nil), qt.Equals, http.StatusOK)
+
+	// The token is stable.
+	again, err := Token()
+	c.Assert(err, qt.IsNil)
+	c.Assert(again, qt.Equals, a.token)
+}
+
+func TestURL(t *testing.T) {
+	c := qt.New(t)
+	none, err := New(ModeNone)
+	c.Assert(err, qt.IsNil)
+	c.Assert(none.URL("http://localhost:9400/app"), qt.Equals, "http://localhost:9400/app")
+
+	tok := &Authenticator{mode: ModeToken, token: "secret"}
+	u, err := url.Parse(tok.URL("http://localhost:9900/sse?app=my-app"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(u.Query().Get("app"), qt.Equals, "my-app")
+	c.Assert(u.Query().Get(TokenParam), qt.Equals, "secret")
+}
+
+func TestUser(t *testing.T) {
+	c := qt.New(t)
+	if !peerUIDSupported {
+		c.Skip("determining the owner of a connection is not supported on this platform")
+	}
+	a, err := New(ModeUser)
+	c.Assert(err, qt.IsNil)
+	addr := serve(c, a)
+
+	resp, err := http.Get("http://" + addr + "/")
+	c.Assert(err, qt.IsNil)
+	_ = resp.Body.Close()
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+}
+
+func serve(c *qt.C, a *Authenticator) (addr string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		_ = a.Serve(ln, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	}()
+	return ln.Addr().String()
+}
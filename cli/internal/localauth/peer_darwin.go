@@ -0,0 +1,46 @@
+package localauth
+
+import (
+	"bufio"
+	"bytes"
+	"net/netip"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+)
+
+const peerUIDSupported = true
+
+// peerUID returns the uid of the owner of the TCP socket with the local
+// address local connected to remote, by looking it up with lsof.
+//
+// Without root privileges lsof only lists the current user's processes,
+// so connections from other users' processes are not found at all.
+func peerUID(local, remote netip.AddrPort) (int, error) {
+	out, err := exec.Command("lsof", "-nP", "-iTCP@"+local.String(), "-Fpu").Output()
+	if err != nil && len(out) == 0 {
+		return 0, errors.Newf("no socket found for %s", local)
+	}
+
+	// The output has a "p<pid>" line for each process followed by a "u<uid>" line.
+	// Our own side of the connection matches too, so skip the daemon's process.
+	pid, self := 0, os.Getpid()
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := sc.Text()
+		if len(line) < 2 {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			pid, _ = strconv.Atoi(line[1:])
+		case 'u':
+			if pid != self {
+				return strconv.Atoi(line[1:])
+			}
+		}
+	}
+	return 0, errors.Newf("no socket found for %s", local)
+}
@@ -0,0 +1,81 @@
+package localauth
+
+import (
+	"bufio"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const peerUIDSupported = true
+
+// peerUID returns the uid of the owner of the TCP socket with the local
+// address local connected to remote, by looking it up in /proc/net/tcp{,6}.
+func peerUID(local, remote netip.AddrPort) (int, error) {
+	locals, remotes := procNetAddrs(local), procNetAddrs(remote)
+	for _, file := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		uid, ok, err := findSocketUID(file, locals, remotes)
+		if err != nil {
+			return 0, err
+		} else if ok {
+			return uid, nil
+		}
+	}
+	return 0, errors.Newf("no socket found for %s", local)
+}
+
+func findSocketUID(file string, locals, remotes []string) (uid int, ok bool, err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, false, err
+	}
+	defer func() { _ = f.Close() }()
+
+	sc := bufio.NewScanner(f)
+	sc.Scan() // skip the header
+	for sc.Scan() {
+		// Fields are: sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid ...
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 8 {
+			continue
+		}
+		if !slices.Contains(locals, fields[1]) || !slices.Contains(remotes, fields[2]) {
+			continue
+		}
+		uid, err := strconv.Atoi(fields[7])
+		if err != nil {
+			return 0, false, errors.Wrapf(err, "parse uid in %s", file)
+		}
+		return uid, true, nil
+	}
+	return 0, false, sc.Err()
+}
+
+// procNetAddrs returns the ways addr may be written in /proc/net/tcp{,6}:
+// as hex encoded 32-bit words in host (little endian) byte order and a hex
+// encoded port. IPv4 addresses are also written as IPv4-mapped IPv6 addresses.
+func procNetAddrs(addr netip.AddrPort) []string {
+	ip := addr.Addr().Unmap()
+	port := fmt.Sprintf(":%04X", addr.Port())
+
+	var res []string
+	if ip.Is4() {
+		b := ip.As4()
+		res = append(res, words(b[:])+port)
+	}
+	b := ip.As16()
+	return append(res, words(b[:])+port)
+}
+
+func words(b []byte) string {
+	var sb strings.Builder
+	for i := 0; i < len(b); i += 4 {
+		_, _ = fmt.Fprintf(&sb, "%02X%02X%02X%02X", b[i+3], b[i+2], b[i+1], b[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+
+package localauth
+
+import (
+	"net/netip"
+
+	"github.com/cockroachdb/errors"
+)
+
+const peerUIDSupported = false
+
+func peerUID(local, remote netip.AddrPort) (int, error) {
+	return 0, errors.New("determining the owner of a connection is not supported on this platform")
+}
@@ -0,0 +1,60 @@
+package localauth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"encr.dev/internal/conf"
+)
+
+// Token returns the local token, creating it if it doesn't exist yet.
+// It's stored in the Encore config directory, readable only by the current user.
+func Token() (string, error) {
+	dir, err := conf.Dir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "local-token")
+
+	if data, err := os.ReadFile(path); err == nil {
+		return strings.TrimSpace(string(data)), nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b[:])
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	// Write the token to a temporary file and link it into place, so processes
+	// creating it concurrently agree on a single, fully written token.
+	f, err := os.CreateTemp(dir, "local-token-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.WriteString(token); err != nil {
+		_ = f.Close()
+		return "", err
+	} else if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Link(f.Name(), path); errors.Is(err, fs.ErrExist) {
+		data, err := os.ReadFile(path)
+		return strings.TrimSpace(string(data)), err
+	} else if err != nil {
+		return "", err
+	}
+	return token, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/briandowns/spinner"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/internal/bindaddr"
 	"encr.dev/cli/internal/browser"
 	"encr.dev/cli/internal/platform"
 	"encr.dev/internal/conf"
@@ -43,7 +44,7 @@ func Interactive() (*conf.Config, error) {
 	challengeHash := sha256.Sum256([]byte(challenge))
 	encodedChallenge := base64.RawURLEncoding.EncodeToString(challengeHash[:])
 
-	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	ln, err := bindaddr.ListenLoopback(0)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,99 @@
+package sqlshell
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// printTable prints the rows of a query result as an aligned table
+// followed by the number of rows, like psql does. Nil values are NULL.
+func printTable(w io.Writer, fields []pgconn.FieldDescription, rows [][]*string) {
+	headers := make([]string, len(fields))
+	rightAlign := make([]bool, len(fields))
+	for i, f := range fields {
+		headers[i] = f.Name
+		rightAlign[i] = isNumeric(f.DataTypeOID)
+	}
+
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]string, len(row))
+		for j, v := range row {
+			if v != nil {
+				// Keep each row on a single line.
+				cells[i][j] = strings.NewReplacer("\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(*v)
+			}
+		}
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range cells {
+		for i, c := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+
+	var b strings.Builder
+	writeRow := func(cols []string, align []bool) {
+		var line strings.Builder
+		for i, c := range cols {
+			if i > 0 {
+				line.WriteString("|")
+			}
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c))
+			line.WriteString(" ")
+			if align != nil && align[i] {
+				line.WriteString(pad + c)
+			} else {
+				line.WriteString(c + pad)
+			}
+			line.WriteString(" ")
+		}
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+
+	// Headers are centered.
+	centered := make([]string, len(headers))
+	for i, h := range headers {
+		left := (widths[i] - utf8.RuneCountInString(h)) / 2
+		centered[i] = strings.Repeat(" ", left) + h
+	}
+	writeRow(centered, nil)
+	for i, width := range widths {
+		if i > 0 {
+			b.WriteString("+")
+		}
+		b.WriteString(strings.Repeat("-", width+2))
+	}
+	b.WriteString("\n")
+	for _, row := range cells {
+		writeRow(row, rightAlign)
+	}
+
+	if len(rows) == 1 {
+		b.WriteString("(1 row)\n")
+	} else {
+		fmt.Fprintf(&b, "(%d rows)\n", len(rows))
+	}
+	_, _ = io.WriteString(w, b.String())
+}
+
+// isNumeric reports whether values of the type with the given oid are numbers,
+// which are right-aligned.
+func isNumeric(oid uint32) bool {
+	switch oid {
+	case pgtype.Int2OID, pgtype.Int4OID, pgtype.Int8OID, pgtype.OIDOID,
+		pgtype.Float4OID, pgtype.Float8OID, pgtype.NumericOID:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,38 @@
+package sqlshell
+
+import (
+	"bytes"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestPrintTable(t *testing.T) {
+	c := qt.New(t)
+	str := func(s string) *string { return &s }
+	fields := []pgconn.FieldDescription{
+		{Name: "id", DataTypeOID: pgtype.Int8OID},
+		{Name: "name", DataTypeOID: pgtype.TextOID},
+	}
+
+	var buf bytes.Buffer
+	printTable(&buf, fields, [][]*string{
+		{str("1"), str("alice")},
+		{str("100"), nil},
+		{str("2"), str("multi\nline")},
+	})
+	c.Assert(buf.String(), qt.Equals, `
+ id  |    name
+-----+-------------
+   1 | alice
+ 100 |
+   2 | multi\nline
+(3 rows)
+`[1:])
+
+	buf.Reset()
+	printTable(&buf, fields[:1], [][]*string{{str("1")}})
+	c.Assert(buf.String(), qt.Equals, " id\n----\n  1\n(1 row)\n")
+}
@@ -0,0 +1,223 @@
+package sqlshell
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const helpText = `General
+  \q                 quit the shell
+  \?                 show this help
+  \conninfo          show information about the current connection
+  \timing            toggle reporting how long statements take
+  \r                 discard the statement being entered
+
+Informational
+  \d                 list tables, views and sequences
+  \d NAME            describe a table, view, sequence or index
+  \dt                list tables
+  \dv                list views
+  \di                list indexes
+  \ds                list sequences
+  \dn                list schemas
+  \l                 list databases
+  \du                list roles
+
+Statements may span multiple lines and are run once terminated by a semicolon.
+`
+
+// relationKinds maps the \d commands listing relations to the relation kinds they list.
+var relationKinds = map[string][]string{
+	`\d`:  {"r", "p", "v", "m", "S", "f"},
+	`\dt`: {"r", "p"},
+	`\dv`: {"v", "m"},
+	`\di`: {"i", "I"},
+	`\ds`: {"S"},
+}
+
+const listRelationsQuery = `
+SELECT n.nspname AS "Schema", c.relname AS "Name",
+	CASE c.relkind
+		WHEN 'r' THEN 'table'
+		WHEN 'p' THEN 'partitioned table'
+		WHEN 'v' THEN 'view'
+		WHEN 'm' THEN 'materialized view'
+		WHEN 'i' THEN 'index'
+		WHEN 'I' THEN 'partitioned index'
+		WHEN 'S' THEN 'sequence'
+		WHEN 'f' THEN 'foreign table'
+	END AS "Type",
+	pg_get_userbyid(c.relowner) AS "Owner"
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind::text = ANY($1)
+	AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+	AND n.nspname !~ '^pg_toast'
+	AND pg_table_is_visible(c.oid)
+ORDER BY 1, 2`
+
+const describeColumnsQuery = `
+SELECT a.attname AS "Column",
+	format_type(a.atttypid, a.atttypmod) AS "Type",
+	CASE WHEN a.attnotnull THEN 'not null' ELSE '' END AS "Nullable",
+	COALESCE(pg_get_expr(d.adbin, d.adrelid), '') AS "Default"
+FROM pg_attribute a
+LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`
+
+const describeIndexesQuery = `
+SELECT pg_get_indexdef(i.indexrelid)
+FROM pg_index i
+WHERE i.indrelid = $1::regclass
+ORDER BY 1`
+
+const describeConstraintsQuery = `
+SELECT conname, pg_get_constraintdef(oid)
+FROM pg_constraint
+WHERE conrelid = $1::regclass AND contype IN ('c', 'f')
+ORDER BY 1`
+
+// meta runs a meta-command, like \d. It reports whether the shell should quit.
+func (sh *Shell) meta(ctx context.Context, cmd string) (quit bool) {
+	name, arg, _ := strings.Cut(cmd, " ")
+	arg = strings.TrimSpace(arg)
+
+	switch name {
+	case `\q`:
+		return true
+	case `\?`:
+		fmt.Fprint(sh.out, helpText)
+	case `\r`:
+		sh.split.Reset()
+		fmt.Fprintln(sh.out, "Query buffer reset (cleared).")
+	case `\timing`:
+		sh.timing = !sh.timing
+		if sh.timing {
+			fmt.Fprintln(sh.out, "Timing is on.")
+		} else {
+			fmt.Fprintln(sh.out, "Timing is off.")
+		}
+	case `\conninfo`:
+		cfg := sh.conn.Config()
+		fmt.Fprintf(sh.out, "You are connected to database %q as user %q on host %q at port \"%d\".\n",
+			cfg.Database, cfg.User, cfg.Host, cfg.Port)
+	case `\d`, `\dt`, `\dv`, `\di`, `\ds`:
+		if name == `\d` && arg != "" {
+			sh.describe(ctx, arg)
+		} else {
+			sh.query(ctx, listRelationsQuery, relationKinds[name])
+		}
+	case `\dn`:
+		sh.query(ctx, `
+			SELECT nspname AS "Name", pg_get_userbyid(nspowner) AS "Owner"
+			FROM pg_namespace
+			WHERE nspname !~ '^pg_' AND nspname <> 'information_schema'
+			ORDER BY 1`)
+	case `\l`:
+		sh.query(ctx, `
+			SELECT datname AS "Name", pg_get_userbyid(datdba) AS "Owner",
+				pg_encoding_to_char(encoding) AS "Encoding"
+			FROM pg_database
+			WHERE NOT datistemplate
+			ORDER BY 1`)
+	case `\du`:
+		sh.query(ctx, `
+			SELECT rolname AS "Role name",
+				concat_ws(', ',
+					CASE WHEN rolsuper THEN 'Superuser' END,
+					CASE WHEN rolcreatedb THEN 'Create DB' END,
+					CASE WHEN rolcreaterole THEN 'Create role' END,
+					CASE WHEN NOT rolcanlogin THEN 'Cannot login' END
+				) AS "Attributes"
+			FROM pg_roles
+			WHERE rolname !~ '^pg_'
+			ORDER BY 1`)
+	default:
+		fmt.Fprintf(sh.out, "invalid command %s\nTry \\? for help.\n", name)
+	}
+	return false
+}
+
+// describe describes the relation with the given name, like \d NAME.
+func (sh *Shell) describe(ctx context.Context, name string) {
+	var exists bool
+	if err := sh.conn.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists); err != nil {
+		sh.printErr(err)
+		return
+	} else if !exists {
+		fmt.Fprintf(sh.out, "Did not find any relation named %q.\n", name)
+		return
+	}
+
+	fmt.Fprintf(sh.out, "Relation %q\n", name)
+	sh.query(ctx, describeColumnsQuery, name)
+
+	rows, _ := sh.conn.Query(ctx, describeIndexesQuery, name)
+	indexes, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		sh.printErr(err)
+		return
+	}
+	if len(indexes) > 0 {
+		fmt.Fprintln(sh.out, "Indexes:")
+		for _, idx := range indexes {
+			fmt.Fprintf(sh.out, "    %s\n", idx)
+		}
+	}
+
+	type constraint struct {
+		Name, Def string
+	}
+	rows, _ = sh.conn.Query(ctx, describeConstraintsQuery, name)
+	constraints, err := pgx.CollectRows(rows, pgx.RowToStructByPos[constraint])
+	if err != nil {
+		sh.printErr(err)
+		return
+	}
+	if len(constraints) > 0 {
+		fmt.Fprintln(sh.out, "Constraints:")
+		for _, c := range constraints {
+			fmt.Fprintf(sh.out, "    %q %s\n", c.Name, c.Def)
+		}
+	}
+	if len(indexes) > 0 || len(constraints) > 0 {
+		fmt.Fprintln(sh.out)
+	}
+}
+
+// query runs an introspection query and prints the results as a table.
+func (sh *Shell) query(ctx context.Context, query string, args ...any) {
+	rows, err := sh.conn.Query(ctx, query, args...)
+	if err != nil {
+		sh.printErr(err)
+		return
+	}
+	fields := rows.FieldDescriptions()
+	var data [][]*string
+	for rows.Next() {
+		vals, err := rows.Values()
+		if err != nil {
+			rows.Close()
+			sh.printErr(err)
+			return
+		}
+		row := make([]*string, len(vals))
+		for i, v := range vals {
+			if v != nil {
+				s := fmt.Sprint(v)
+				row[i] = &s
+			}
+		}
+		data = append(data, row)
+	}
+	if err := rows.Err(); err != nil {
+		sh.printErr(err)
+		return
+	}
+	printTable(sh.out, fields, data)
+	fmt.Fprintln(sh.out)
+}
@@ -0,0 +1,178 @@
+// Package sqlshell implements a minimal interactive SQL shell for PostgreSQL databases,
+// for use when psql isn't installed.
+package sqlshell
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"golang.org/x/term"
+)
+
+// Shell is an interactive SQL shell connected to a database.
+type Shell struct {
+	conn   *pgx.Conn
+	out    io.Writer
+	split  splitter
+	timing bool // whether to report how long statements take
+}
+
+// Run connects to the database at dsn and runs a shell reading from in and writing to out
+// until the input ends or the user quits. If in is a terminal the shell is interactive,
+// with line editing and history; otherwise statements are read from in as a script.
+func Run(ctx context.Context, dsn string, in *os.File, out io.Writer) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	sh := &Shell{conn: conn, out: out}
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return sh.runScript(ctx, in)
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("set up terminal: %w", err)
+	}
+	defer func() { _ = term.Restore(fd, oldState) }()
+
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, out}, "")
+	if w, h, err := term.GetSize(fd); err == nil {
+		_ = t.SetSize(w, h)
+	}
+	sh.out = t
+	return sh.runInteractive(ctx, t)
+}
+
+func (sh *Shell) runInteractive(ctx context.Context, t *term.Terminal) error {
+	dbName := sh.conn.Config().Database
+	fmt.Fprintf(sh.out, "Connected to database %q. Type \\? for help, \\q to quit.\n", dbName)
+	for {
+		switch {
+		case sh.split.InQuote():
+			t.SetPrompt(dbName + "'> ")
+		case sh.split.Pending() != "":
+			t.SetPrompt(dbName + "-> ")
+		default:
+			t.SetPrompt(dbName + "=> ")
+		}
+
+		line, err := t.ReadLine()
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(sh.out)
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if quit := sh.handleLine(ctx, line); quit {
+			return nil
+		}
+	}
+}
+
+func (sh *Shell) runScript(ctx context.Context, in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		if quit := sh.handleLine(ctx, scanner.Text()); quit {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// Run the final statement even if it lacks a terminating semicolon.
+	if stmt := sh.split.Pending(); stmt != "" {
+		sh.split.Reset()
+		sh.exec(ctx, stmt)
+	}
+	return nil
+}
+
+// handleLine handles a line of input, running the statements and meta-commands it completes.
+// It reports whether the shell should quit.
+func (sh *Shell) handleLine(ctx context.Context, line string) (quit bool) {
+	if !sh.split.InQuote() {
+		if cmd := strings.TrimSpace(line); strings.HasPrefix(cmd, `\`) {
+			return sh.meta(ctx, cmd)
+		}
+	}
+	for _, stmt := range sh.split.Feed(line) {
+		sh.exec(ctx, stmt)
+	}
+	return false
+}
+
+// exec runs a statement and prints its results.
+func (sh *Shell) exec(ctx context.Context, stmt string) {
+	start := time.Now()
+	// Use the simple protocol so values are returned in their text representation,
+	// like psql displays them.
+	rows, err := sh.conn.Query(ctx, stmt, pgx.QueryExecModeSimpleProtocol)
+	if err != nil {
+		sh.printErr(err)
+		return
+	}
+
+	var (
+		fields = rows.FieldDescriptions()
+		data   [][]*string
+	)
+	for rows.Next() {
+		raw := rows.RawValues()
+		row := make([]*string, len(raw))
+		for i, v := range raw {
+			if v != nil {
+				s := string(v)
+				row[i] = &s
+			}
+		}
+		data = append(data, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		sh.printErr(err)
+		return
+	}
+
+	if len(fields) > 0 {
+		printTable(sh.out, fields, data)
+	} else {
+		fmt.Fprintln(sh.out, rows.CommandTag().String())
+	}
+	if sh.timing {
+		fmt.Fprintf(sh.out, "Time: %.3f ms\n", float64(time.Since(start).Microseconds())/1000)
+	}
+	fmt.Fprintln(sh.out)
+}
+
+// printErr prints an error the way psql does.
+func (sh *Shell) printErr(err error) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		fmt.Fprintf(sh.out, "ERROR:  %v\n", err)
+		return
+	}
+	fmt.Fprintf(sh.out, "%s:  %s\n", pgErr.Severity, pgErr.Message)
+	if pgErr.Detail != "" {
+		fmt.Fprintf(sh.out, "DETAIL:  %s\n", pgErr.Detail)
+	}
+	if pgErr.Hint != "" {
+		fmt.Fprintf(sh.out, "HINT:  %s\n", pgErr.Hint)
+	}
+}
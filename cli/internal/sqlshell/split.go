@@ -0,0 +1,164 @@
+package sqlshell
+
+import (
+	"strings"
+	"unicode"
+)
+
+type scanState int
+
+const (
+	stateNormal scanState = iota
+	stateSingleQuote
+	stateDoubleQuote
+	stateLineComment
+	stateBlockComment
+	stateDollarQuote
+)
+
+// A splitter splits SQL input into statements terminated by semicolons.
+// Semicolons within quoted strings and identifiers, comments and
+// dollar-quoted strings don't terminate statements.
+type splitter struct {
+	buf    strings.Builder // input of the incomplete statement
+	state  scanState
+	escape bool   // whether the single-quoted string is an E'' escape string
+	depth  int    // nesting depth of block comments
+	tag    string // delimiter of the dollar-quoted string, like "$$" or "$fn$"
+}
+
+// Feed adds a line of input and returns the statements it completes,
+// without their terminating semicolons.
+func (s *splitter) Feed(line string) []string {
+	var stmts []string
+	rs := []rune(line + "\n")
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		next := rune(0)
+		if i+1 < len(rs) {
+			next = rs[i+1]
+		}
+
+		switch s.state {
+		case stateNormal:
+			switch {
+			case r == ';':
+				if stmt := strings.TrimSpace(s.buf.String()); stmt != "" {
+					stmts = append(stmts, stmt)
+				}
+				s.buf.Reset()
+				continue
+			case r == '\'':
+				s.state = stateSingleQuote
+				s.escape = i > 0 && (rs[i-1] == 'e' || rs[i-1] == 'E') && (i < 2 || !isIdentRune(rs[i-2]))
+			case r == '"':
+				s.state = stateDoubleQuote
+			case r == '-' && next == '-':
+				s.state = stateLineComment
+			case r == '/' && next == '*':
+				s.state, s.depth = stateBlockComment, 1
+				s.buf.WriteString("/*")
+				i++
+				continue
+			case r == '$' && (i == 0 || !isIdentRune(rs[i-1])):
+				if tag, ok := dollarTag(rs[i:]); ok {
+					s.state, s.tag = stateDollarQuote, tag
+					s.buf.WriteString(tag)
+					i += len([]rune(tag)) - 1
+					continue
+				}
+			}
+
+		case stateSingleQuote:
+			if r == '\\' && s.escape && next != 0 {
+				s.buf.WriteRune(r)
+				s.buf.WriteRune(next)
+				i++
+				continue
+			} else if r == '\'' {
+				// A doubled quote ends the string and immediately starts a new one,
+				// which is equivalent to treating it as an escaped quote.
+				s.state = stateNormal
+			}
+
+		case stateDoubleQuote:
+			if r == '"' {
+				s.state = stateNormal
+			}
+
+		case stateLineComment:
+			if r == '\n' {
+				s.state = stateNormal
+			}
+
+		case stateBlockComment:
+			if r == '/' && next == '*' {
+				s.depth++
+				s.buf.WriteString("/*")
+				i++
+				continue
+			} else if r == '*' && next == '/' {
+				s.depth--
+				if s.depth == 0 {
+					s.state = stateNormal
+				}
+				s.buf.WriteString("*/")
+				i++
+				continue
+			}
+
+		case stateDollarQuote:
+			if r == '$' && strings.HasPrefix(string(rs[i:]), s.tag) {
+				s.state = stateNormal
+				s.buf.WriteString(s.tag)
+				i += len([]rune(s.tag)) - 1
+				continue
+			}
+		}
+		s.buf.WriteRune(r)
+	}
+	return stmts
+}
+
+// Pending returns the input of the incomplete statement,
+// or the empty string if there is none.
+func (s *splitter) Pending() string {
+	return strings.TrimSpace(s.buf.String())
+}
+
+// InQuote reports whether the input ends inside a quoted string,
+// a quoted identifier or a dollar-quoted string.
+func (s *splitter) InQuote() bool {
+	switch s.state {
+	case stateSingleQuote, stateDoubleQuote, stateDollarQuote:
+		return true
+	default:
+		return false
+	}
+}
+
+// Reset discards the incomplete statement.
+func (s *splitter) Reset() {
+	*s = splitter{}
+}
+
+// dollarTag returns the dollar quote delimiter, like "$$" or "$body$",
+// that rs starts with.
+func dollarTag(rs []rune) (string, bool) {
+	for i := 1; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case r == '$':
+			return string(rs[:i+1]), true
+		case r == '_' || unicode.IsLetter(r) || (i > 1 && unicode.IsDigit(r)):
+			continue
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,102 @@
+package sqlshell
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSplitter(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		want    []string
+		pending string
+		inQuote bool
+	}{
+		{
+			name:  "single",
+			lines: []string{"SELECT 1;"},
+			want:  []string{"SELECT 1"},
+		},
+		{
+			name:  "multiple_on_one_line",
+			lines: []string{"SELECT 1; SELECT 2;"},
+			want:  []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:  "multi_line",
+			lines: []string{"SELECT", "  1", ";"},
+			want:  []string{"SELECT\n  1"},
+		},
+		{
+			name:    "incomplete",
+			lines:   []string{"SELECT 1; SELECT"},
+			want:    []string{"SELECT 1"},
+			pending: "SELECT",
+		},
+		{
+			name:  "empty_statements",
+			lines: []string{";;", "  ;"},
+			want:  nil,
+		},
+		{
+			name:  "semicolon_in_string",
+			lines: []string{"SELECT 'a;b', 'it''s;';"},
+			want:  []string{"SELECT 'a;b', 'it''s;'"},
+		},
+		{
+			name:  "escape_string",
+			lines: []string{`SELECT E'a\';b';`},
+			want:  []string{`SELECT E'a\';b'`},
+		},
+		{
+			name:  "semicolon_in_identifier",
+			lines: []string{`SELECT 1 AS "a;b";`},
+			want:  []string{`SELECT 1 AS "a;b"`},
+		},
+		{
+			name:  "line_comment",
+			lines: []string{"SELECT 1 -- done;", ";"},
+			want:  []string{"SELECT 1 -- done;"},
+		},
+		{
+			name:  "nested_block_comment",
+			lines: []string{"SELECT /* a /* b; */ c; */ 1;"},
+			want:  []string{"SELECT /* a /* b; */ c; */ 1"},
+		},
+		{
+			name: "dollar_quote",
+			lines: []string{
+				"CREATE FUNCTION f() RETURNS int AS $fn$",
+				"BEGIN RETURN 1; END;",
+				"$fn$ LANGUAGE plpgsql;",
+			},
+			want: []string{"CREATE FUNCTION f() RETURNS int AS $fn$\nBEGIN RETURN 1; END;\n$fn$ LANGUAGE plpgsql"},
+		},
+		{
+			name:  "positional_param",
+			lines: []string{"PREPARE p AS SELECT $1;"},
+			want:  []string{"PREPARE p AS SELECT $1"},
+		},
+		{
+			name:    "open_quote",
+			lines:   []string{"SELECT 'a;"},
+			pending: "SELECT 'a;",
+			inQuote: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := qt.New(t)
+			var s splitter
+			var got []string
+			for _, line := range test.lines {
+				got = append(got, s.Feed(line)...)
+			}
+			c.Assert(got, qt.DeepEquals, test.want)
+			c.Assert(s.Pending(), qt.Equals, test.pending)
+			c.Assert(s.InQuote(), qt.Equals, test.inQuote)
+		})
+	}
+}
@@ -266,6 +266,11 @@
 					text: "Raw Endpoints"
 					path: "/go/primitives/raw-endpoints"
 					file: "go/primitives/raw-endpoints"
+				}, {
+					kind: "basic"
+					text: "API Experiments"
+					path: "/go/primitives/api-experiments"
+					file: "go/primitives/api-experiments"
 				}, {
 					kind: "basic"
 					text: "Service Structs"
@@ -310,6 +315,11 @@
 					text: "PostgreSQL Extensions"
 					path: "/go/primitives/databases/extensions"
 					file: "go/primitives/database-extensions"
+				}, {
+					kind: "basic"
+					text: "Row-level security"
+					path: "/go/primitives/databases/row-level-security"
+					file: "go/primitives/database-row-level-security"
 				}, {
 					kind: "basic"
 					text: "Troubleshooting"
@@ -321,6 +331,31 @@
 				text: "Object Storage"
 				path: "/go/primitives/object-storage"
 				file: "go/primitives/object-storage"
+			}, {
+				kind: "basic"
+				text: "Search"
+				path: "/go/primitives/search"
+				file: "go/primitives/search"
+			}, {
+				kind: "basic"
+				text: "Encryption"
+				path: "/go/primitives/encryption"
+				file: "go/primitives/encryption"
+			}, {
+				kind: "basic"
+				text: "Data Retention"
+				path: "/go/primitives/data-retention"
+				file: "go/primitives/data-retention"
+			}, {
+				kind: "basic"
+				text: "Inbound Email"
+				path: "/go/primitives/inbound-email"
+				file: "go/primitives/inbound-email"
+			}, {
+				kind: "basic"
+				text: "Long-running Operations"
+				path: "/go/primitives/operations"
+				file: "go/primitives/operations"
 			}, {
 				kind: "basic"
 				text: "Cron Jobs"
@@ -341,6 +376,11 @@
 				text: "Secrets"
 				path: "/go/primitives/secrets"
 				file: "go/primitives/secrets"
+			}, {
+				kind: "basic"
+				text: "Environment Variables"
+				path: "/go/primitives/environment-variables"
+				file: "go/primitives/environment-variables"
 			}, {
 				kind: "basic"
 				text: "Code Snippets"
@@ -365,6 +405,36 @@
 				text: "CORS"
 				path: "/go/develop/cors"
 				file: "go/develop/cors"
+			}, {
+				kind: "basic"
+				text: "Gateway transforms"
+				path: "/go/develop/gateway-transforms"
+				file: "go/develop/gateway-transforms"
+			}, {
+				kind: "basic"
+				text: "Client metadata"
+				path: "/go/develop/client-metadata"
+				file: "go/develop/client-metadata"
+			}, {
+				kind: "basic"
+				text: "Simulating quotas"
+				path: "/go/develop/local-quotas"
+				file: "go/develop/local-quotas"
+			}, {
+				kind: "basic"
+				text: "Local hostnames"
+				path: "/go/develop/local-hosts"
+				file: "go/develop/local-hosts"
+			}, {
+				kind: "basic"
+				text: "File watching"
+				path: "/go/develop/watch"
+				file: "go/develop/watch"
+			}, {
+				kind: "basic"
+				text: "Resource limits"
+				path: "/go/develop/resource-limits"
+				file: "go/develop/resource-limits"
 			}, {
 				kind: "basic"
 				text: "Metadata"
@@ -502,6 +572,16 @@
 				text: "Break a monolith into microservices"
 				path: "/go/how-to/break-up-monolith"
 				file: "go/how-to/break-up-monolith"
+			}, {
+				kind: "basic"
+				text: "Run alongside docker-compose"
+				path: "/go/how-to/docker-compose"
+				file: "go/how-to/docker-compose"
+			}, {
+				kind: "basic"
+				text: "Receive files over SFTP"
+				path: "/go/how-to/sftp-bridge"
+				file: "go/how-to/sftp-bridge"
 			}, {
 				kind: "basic"
 				text: "Integrate with a web frontend"
@@ -1157,6 +1237,16 @@
 				text: "Use NestJS with Encore"
 				path: "/ts/how-to/nestjs"
 				file: "ts/how-to/nestjs"
+			}, {
+				kind: "basic"
+				text: "Run alongside docker-compose"
+				path: "/ts/how-to/docker-compose"
+				file: "ts/how-to/docker-compose"
+			}, {
+				kind: "basic"
+				text: "Receive files over SFTP"
+				path: "/ts/how-to/sftp-bridge"
+				file: "ts/how-to/sftp-bridge"
 			}]
 		}, {
 			kind: "section"
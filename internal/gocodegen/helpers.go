@@ -140,6 +140,8 @@ func ConvertBuiltInSchemaToJenType(builtin schema.Builtin) *Statement {
 		return Qual("encoding/json", "RawMessage")
 	case schema.Builtin_USER_ID:
 		return Qual("encore.dev/beta/auth", "UID")
+	case schema.Builtin_FILE:
+		return Qual("encore.dev/types/upload", "File")
 	case schema.Builtin_INT:
 		return Int()
 	case schema.Builtin_UINT:
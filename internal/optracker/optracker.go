@@ -35,6 +35,12 @@ func NewLineMode(w io.Writer) *OpTracker {
 	return &OpTracker{w: w, lineMode: true}
 }
 
+// NewEventMode creates a tracker that sends an OperationEvent per state change
+// on stream instead of writing any output, for clients requesting structured output.
+func NewEventMode(stream OutputStream) *OpTracker {
+	return &OpTracker{stream: stream, eventMode: true}
+}
+
 type OpTracker struct {
 	mu          sync.Mutex
 	ops         []*slowOp
@@ -44,6 +50,7 @@ type OpTracker struct {
 	savedCursor sync.Once
 	stream      OutputStream
 	lineMode    bool // when true, emit one line per state change instead of redrawing
+	eventMode   bool // when true, send an event per state change instead of writing output
 }
 
 type OperationID int
@@ -75,7 +82,7 @@ func (t *OpTracker) AllDone() {
 		}
 	}
 	t.quit = true
-	if t.lineMode {
+	if t.lineMode || t.eventMode {
 		// State transitions were already emitted as they happened; nothing
 		// further to render.
 		return
@@ -106,6 +113,9 @@ func (t *OpTracker) Add(msg string, minStart time.Time) OperationID {
 	if t.lineMode {
 		fmt.Fprintf(t.w, "encore: %s...\n", msg)
 		return id
+	} else if t.eventMode {
+		t.sendEvent(op, daemonpb.OperationEvent_STATE_STARTED)
+		return id
 	}
 
 	t.refresh()
@@ -139,6 +149,9 @@ func (t *OpTracker) Done(id OperationID, minDuration time.Duration) {
 	if t.lineMode {
 		fmt.Fprintf(t.w, "encore: %s done (%s)\n", o.msg, done.Sub(o.start).Round(time.Millisecond))
 		return
+	} else if t.eventMode {
+		t.sendEvent(o, daemonpb.OperationEvent_STATE_DONE)
+		return
 	}
 	t.refresh()
 }
@@ -169,6 +182,13 @@ func (t *OpTracker) Fail(id OperationID, err error) {
 			fmt.Fprintf(t.w, "encore: %s failed: %v\n", o.msg, err)
 		}
 		return
+	} else if t.eventMode {
+		if errors.Is(err, ErrCanceled) {
+			t.sendEvent(t.ops[id], daemonpb.OperationEvent_STATE_CANCELED)
+		} else {
+			t.sendEvent(t.ops[id], daemonpb.OperationEvent_STATE_FAILED)
+		}
+		return
 	}
 	t.refresh()
 }
@@ -179,6 +199,25 @@ func (t *OpTracker) Cancel(id OperationID) {
 	t.Fail(id, ErrCanceled)
 }
 
+// sendEvent sends an event reporting that o entered the given state.
+// The mutex must be held by the caller.
+func (t *OpTracker) sendEvent(o *slowOp, state daemonpb.OperationEvent_State) {
+	ev := &daemonpb.OperationEvent{Name: o.msg, State: state}
+	if state != daemonpb.OperationEvent_STATE_STARTED {
+		ev.DurationMs = o.done.Sub(o.start).Milliseconds()
+	}
+	if state == daemonpb.OperationEvent_STATE_FAILED && o.err != nil {
+		if list := errlist.Convert(o.err); list != nil && len(list.List) > 0 {
+			ev.Error = list.List[0].Title()
+		} else {
+			ev.Error = o.err.Error()
+		}
+	}
+	_ = t.stream.Send(&daemonpb.CommandMessage{Msg: &daemonpb.CommandMessage_Event{
+		Event: &daemonpb.CommandEvent{Event: &daemonpb.CommandEvent_Operation{Operation: ev}},
+	}})
+}
+
 // refresh refreshes the display by writing to t.w.
 // The mutex must be held by the caller.
 func (t *OpTracker) refresh() {
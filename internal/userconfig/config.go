@@ -6,6 +6,71 @@ type Config struct {
 	// If set to "auto", the browser will be opened if the dashboard is not already open.
 	RunBrowser string `koanf:"run.browser" oneof:"always,never,auto" default:"auto"`
 
+	// Comma-separated glob patterns of files whose changes don't reload the app
+	// on `encore run`, in addition to the app's "watch.ignore" setting in encore.app.
+	RunWatchIgnore string `koanf:"run.watch.ignore" default:""`
+
+	// Comma-separated glob patterns of files whose changes reload the app
+	// on `encore run`, in addition to the app's "watch.include" setting in encore.app.
+	RunWatchInclude string `koanf:"run.watch.include" default:""`
+
+	// How long to wait for further changes after a change before reloading the app
+	// on `encore run`, like "500ms". Overrides the app's "watch.debounce" setting in encore.app.
+	RunWatchDebounce string `koanf:"run.watch.debounce" default:""`
+
+	// CPU limit of each process started by `encore run`, in cores, like "1.5".
+	// Comma-separated "name=limit" entries override it for the processes of specific
+	// services and gateways, like "1,search=2". Requires cgroup v2 on Linux.
+	RunLimitsCPU string `koanf:"run.limits.cpu" default:""`
+
+	// Memory limit of each process started by `encore run`, like "512MiB".
+	// Comma-separated "name=limit" entries override it for the processes of specific
+	// services and gateways, like "1GiB,search=4GiB". Requires cgroup v2 on Linux.
+	RunLimitsMemory string `koanf:"run.limits.memory" default:""`
+
+	// How many times in a row `encore run` restarts the app when a process crashes,
+	// with exponential backoff, before giving up. Set to 0 to disable restarting.
+	RunRestartMax int `koanf:"run.restart.max" default:"5"`
+
+	// Whether `encore run` monitors the heap of each process for sustained growth,
+	// capturing heap profiles and reporting the allocation sites that grew when it
+	// exceeds "run.leaks.threshold". Only supported for Go apps.
+	RunLeaksDetect bool `koanf:"run.leaks.detect" default:"false"`
+
+	// How often `encore run` measures the heap of each process when "run.leaks.detect" is enabled.
+	RunLeaksInterval string `koanf:"run.leaks.interval" default:"5m"`
+
+	// How many percent the heap of a process must grow, compared to when it was
+	// first measured or last reported, to report a suspected memory leak.
+	RunLeaksThreshold int `koanf:"run.leaks.threshold" default:"50"`
+
+	// Command `encore run` executes before the app is first built,
+	// after the app's "run_hooks.prestart" command in encore.app.
+	RunHooksPreStart string `koanf:"run.hooks.prestart" default:""`
+
+	// Command `encore run` executes once the app is listening for requests,
+	// along with the app's "run_hooks.poststart" command in encore.app.
+	RunHooksPostStart string `koanf:"run.hooks.poststart" default:""`
+
+	// Whether to use a namespace named after the checked out git branch
+	// when running commands that use the active namespace, like `encore run`.
+	// A namespace created for a new branch starts with a copy of the
+	// databases of the previously active namespace.
+	NamespaceGitBranch bool `koanf:"namespace.git_branch" default:"false"`
+
+	// How the daemon authenticates requests to the Local Development Dashboard,
+	// the MCP endpoint and its other local HTTP servers. "token" requires a token
+	// stored in the Encore config directory, "user" only accepts connections
+	// from processes of the same OS user. Takes effect when the daemon restarts.
+	DaemonAuth string `koanf:"daemon.auth" oneof:"none,token,user" default:"none"`
+
+	// Which network interfaces the listeners Encore creates locally, like the app's,
+	// the dashboard's and the MCP endpoint's, may bind to. "warn" allows addresses
+	// reachable from other devices on the network but warns about them, "loopback"
+	// refuses them and "allow" allows them silently. Can be overridden for a single
+	// run with `encore run --bind-policy`.
+	BindPolicy string `koanf:"bind.policy" oneof:"warn,loopback,allow" default:"warn"`
+
 	// Always choose this tool when creating an app or when initializing llm tools
 	// for an existing app, unless overriden via --llm-rules flag on command line.
 	LLMRules string `koanf:"llm_rules" oneof:",cursor,claudcode,vscode,agentsmd,zed" default:""`
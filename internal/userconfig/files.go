@@ -79,6 +79,15 @@ var tomlParser = toml.Parser()
 func newInstance(paths ...string) (*Config, error) {
 	k := koanf.New(".")
 
+	// Start from the documented defaults, which the config files override.
+	for key, desc := range descs {
+		if def := desc.Type.Default; def != nil {
+			if err := k.Set(key, *def); err != nil {
+				return nil, errors.Wrapf(err, "unable to set default for %s", key)
+			}
+		}
+	}
+
 	for _, path := range paths {
 		f := file.Provider(path)
 		err := k.Load(f, tomlParser)
@@ -24,6 +24,7 @@ const (
 	Query     ParameterLocation = "query"     // Parameter is placed in the query string
 	Body      ParameterLocation = "body"      // Parameter is placed in the body
 	Cookie    ParameterLocation = "cookie"    // Parameter is placed in cookies
+	Form      ParameterLocation = "form"      // Parameter is placed in a form body
 )
 
 var (
@@ -47,6 +48,10 @@ var (
 		omitEmptyOption: "omitempty",
 		overrideDefault: true,
 	}
+	FormTag = tagDescription{
+		location:        Form,
+		overrideDefault: true,
+	}
 )
 
 // authTags is a description of tags used for auth
@@ -63,6 +68,7 @@ var requestTags = map[string]tagDescription{
 	"header": HeaderTag,
 	"cookie": CookieTag,
 	"json":   JSONTag,
+	"form":   FormTag,
 }
 
 // responseTags is a description of tags used for responses
@@ -180,17 +186,18 @@ type RequestEncoding struct {
 	QueryParameters  []*ParameterEncoding `json:"query_parameters"`
 	CookieParameters []*ParameterEncoding `json:"cookie_parameters"`
 	BodyParameters   []*ParameterEncoding `json:"body_parameters"`
+	FormParameters   []*ParameterEncoding `json:"form_parameters"`
 }
 
 // ParameterEncodingMap returns the parameter encodings as a map, keyed by SrcName.
 func (e *RequestEncoding) ParameterEncodingMap() map[string]*ParameterEncoding {
-	return toEncodingMap(srcNameKey, e.HeaderParameters, e.QueryParameters, e.BodyParameters, e.CookieParameters)
+	return toEncodingMap(srcNameKey, e.HeaderParameters, e.QueryParameters, e.BodyParameters, e.CookieParameters, e.FormParameters)
 }
 
 // ParameterEncodingMapByName returns the parameter encodings as a map, keyed by Name.
 // Conflicts result in an undefined encoding getting set.
 func (e *RequestEncoding) ParameterEncodingMapByName() map[string][]*ParameterEncoding {
-	return toEncodingMultiMap(nameKey, e.HeaderParameters, e.QueryParameters, e.BodyParameters, e.CookieParameters)
+	return toEncodingMultiMap(nameKey, e.HeaderParameters, e.QueryParameters, e.BodyParameters, e.CookieParameters, e.FormParameters)
 }
 
 // ParameterEncoding expresses how a parameter should be encoded on the wire
@@ -213,6 +220,8 @@ type ParameterEncoding struct {
 	WireFormat string `json:"wire_format"`
 	// Optional indicates whether the field is optional.
 	Optional bool `json:"optional"`
+	// Deprecated is the deprecation notice of the field, if it is deprecated.
+	Deprecated *string `json:"deprecated,omitempty"`
 }
 
 type Options struct {
@@ -647,8 +656,8 @@ func DescribeRequest(appMetaData *meta.Data, requestSchema *schema.Type, options
 			}
 		}
 
-		if keys := keyDiff(fields, Query, Header, Body, Cookie); len(keys) > 0 {
-			return nil, errors.Newf("request must only contain Query, Body, Header and Cookie parameters. Found: %v", keys)
+		if keys := keyDiff(fields, Query, Header, Body, Cookie, Form); len(keys) > 0 {
+			return nil, errors.Newf("request must only contain Query, Body, Header, Cookie and Form parameters. Found: %v", keys)
 		}
 		reqs = append(reqs, &RequestEncoding{
 			HTTPMethods:      methods,
@@ -656,6 +665,7 @@ func DescribeRequest(appMetaData *meta.Data, requestSchema *schema.Type, options
 			HeaderParameters: fields[Header],
 			CookieParameters: fields[Cookie],
 			BodyParameters:   fields[Body],
+			FormParameters:   fields[Form],
 		})
 	}
 
@@ -722,6 +732,7 @@ func describeParam(lang meta.Lang, encodingHints *encodingHints, field *schema.F
 		RawTag:     field.RawTag,
 		Optional:   field.Optional,
 		WireFormat: name,
+		Deprecated: field.Deprecated,
 	}
 
 	var usedOverrideTag string
@@ -803,3 +814,15 @@ func srcNameKey(e *ParameterEncoding) string {
 func nameKey(e *ParameterEncoding) string {
 	return e.Name
 }
+
+// FormFieldKind reports whether the form parameter type typ is an uploaded file
+// (possibly behind a pointer), and whether it is a list of values.
+func FormFieldKind(typ *schema.Type) (isFile, isList bool) {
+	if list := typ.GetList(); list != nil {
+		typ, isList = list.Elem, true
+	}
+	if ptr := typ.GetPointer(); ptr != nil {
+		typ = ptr.Base
+	}
+	return typ.GetBuiltin() == schema.Builtin_FILE, isList
+}
@@ -10,7 +10,9 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/tailscale/hujson"
 	"mvdan.cc/sh/v3/expand"
@@ -18,6 +20,7 @@ import (
 	"mvdan.cc/sh/v3/syntax"
 
 	"encore.dev/appruntime/exported/experiments"
+	"encr.dev/pkg/glob"
 )
 
 // Name is the name of the Encore app file.
@@ -53,9 +56,65 @@ type File struct {
 	// will be applied to all API gateways into the application.
 	GlobalCORS *CORS `json:"global_cors,omitempty"`
 
+	// GatewayTransforms configures transformations the API gateways apply
+	// to requests and responses, such as injecting headers or compressing
+	// response bodies. They are applied in order.
+	GatewayTransforms []GatewayTransform `json:"gateway_transforms,omitempty"`
+
+	// ClientMetadata enables enriching requests in the API gateway with
+	// metadata about the client making them, when running locally.
+	// If nil requests are not enriched.
+	ClientMetadata *ClientMetadata `json:"client_metadata,omitempty"`
+
+	// LocalQuotas simulates the request quotas of a cloud API gateway
+	// when running locally, so clients' handling of rate limiting can be tested.
+	// If nil no quotas are enforced.
+	LocalQuotas *LocalQuotas `json:"local_quotas,omitempty"`
+
+	// LocalHosts maps local hostnames, such as "admin.myapp.localhost",
+	// to the name of a service or gateway when running locally.
+	// Requests to a host mapped to a service can only reach that service's
+	// endpoints, and requests to a host mapped to a gateway are routed through it.
+	LocalHosts map[string]string `json:"local_hosts,omitempty"`
+
+	// Watch configures which file changes reload the app on 'encore run'.
+	// If nil the default configuration is used.
+	Watch *Watch `json:"watch,omitempty"`
+
+	// GracefulShutdown configures how long the app is given to shut down
+	// gracefully when 'encore run' stops or reloads it.
+	// If nil the default timings are used.
+	GracefulShutdown *GracefulShutdown `json:"graceful_shutdown,omitempty"`
+
+	// RunHooks configures commands 'encore run' executes
+	// around starting the app. If nil no commands are executed.
+	RunHooks *RunHooks `json:"run_hooks,omitempty"`
+
+	// Seeds configures the scripts that seed the app's local databases
+	// with data, using 'encore db seed'. If nil no seeds are declared.
+	Seeds *Seeds `json:"seeds,omitempty"`
+
 	// Build contains build settings for the application.
 	Build Build `json:"build,omitempty"`
 
+	// Protobuf configures generating API types from Protocol Buffers
+	// definitions as part of the build. If nil no types are generated.
+	Protobuf *Protobuf `json:"protobuf,omitempty"`
+
+	// SchemaExport configures exporting JSON Schema and Avro definitions
+	// of the app's API and Pub/Sub message types as part of the build.
+	// If nil no schemas are exported.
+	SchemaExport *SchemaExport `json:"schema_export,omitempty"`
+
+	// Compose configures running the app alongside an existing
+	// docker-compose stack with 'encore run'. If nil it is not used.
+	Compose *Compose `json:"compose,omitempty"`
+
+	// LocalSFTP runs an SFTP server with 'encore run' that maps directories
+	// to the app's buckets, for integrations that deliver files over SFTP.
+	// If nil no SFTP server is run.
+	LocalSFTP *LocalSFTP `json:"local_sftp,omitempty"`
+
 	// CgoEnabled enables building with cgo.
 	//
 	// Deprecated: Use build.cgo_enabled instead.
@@ -85,6 +144,22 @@ type Build struct {
 
 	// Hooks configures hooks for the build process.
 	Hooks Hooks `json:"hooks,omitempty"`
+
+	// Licenses configures checking the licenses of the app's dependencies
+	// when building, using the license report embedded in build artifacts.
+	Licenses Licenses `json:"licenses,omitempty"`
+}
+
+// Licenses configures checking the licenses of an app's dependencies.
+type Licenses struct {
+	// Deny lists SPDX license identifiers, or glob patterns such as "GPL-*",
+	// that dependencies must not be licensed under. Building fails if one is.
+	// Use "UNKNOWN" to deny dependencies whose license cannot be determined.
+	Deny []string `json:"deny,omitempty"`
+
+	// Ignore lists dependencies (module or package names, or glob patterns)
+	// exempt from the deny list.
+	Ignore []string `json:"ignore,omitempty"`
 }
 
 type Hooks struct {
@@ -212,6 +287,251 @@ type CORS struct {
 	AllowOriginsWithCredentials []string `json:"allow_origins_with_credentials,omitempty"`
 }
 
+// Protobuf configures generating types from .proto files.
+// The generated types can be used in API schemas and Pub/Sub messages,
+// and encode to the same JSON as the Protocol Buffers JSON mapping.
+type Protobuf struct {
+	// ImportPaths are the directories, relative to the app root, that .proto files
+	// and their imports are resolved relative to. If empty it defaults to ["proto"].
+	ImportPaths []string `json:"import_paths,omitempty"`
+
+	// Files are the .proto files to generate types for, relative to an import path.
+	// If empty types are generated for all .proto files in the import paths.
+	Files []string `json:"files,omitempty"`
+
+	// Output is the directory, relative to the app root, to write the
+	// generated types to. If empty it defaults to "protobuf".
+	Output string `json:"output,omitempty"`
+
+	// Protoc is the protoc command to run. If empty it defaults to "protoc".
+	Protoc string `json:"protoc,omitempty"`
+}
+
+// SchemaExport configures exporting schemas of the app's request, response
+// and Pub/Sub message types, for use with data pipelines and schema registries.
+type SchemaExport struct {
+	// Formats are the formats to export, "jsonschema" and/or "avro".
+	// If empty all formats are exported.
+	Formats []string `json:"formats,omitempty"`
+
+	// Output is the directory, relative to the app root, to write the
+	// schemas to. If empty it defaults to "schemas".
+	Output string `json:"output,omitempty"`
+}
+
+// Compose configures running the app alongside an existing docker-compose stack,
+// for developing Encore services within a larger system.
+type Compose struct {
+	// File is the compose file, relative to the app root.
+	// If empty docker compose looks for its default files in the app root.
+	File string `json:"file,omitempty"`
+
+	// Project is the compose project name.
+	// If empty it is determined by docker compose.
+	Project string `json:"project,omitempty"`
+
+	// Network is the compose network to make the app reachable on.
+	// If empty it defaults to the project's default network.
+	Network string `json:"network,omitempty"`
+
+	// Proxy, if set, registers the app with a reverse proxy in the stack.
+	Proxy *ComposeProxy `json:"proxy,omitempty"`
+}
+
+// ComposeProxy configures registering the app with a reverse proxy
+// by writing a configuration file the proxy loads.
+type ComposeProxy struct {
+	// Format is the configuration format: "traefik", "nginx" or "caddy".
+	Format string `json:"format"`
+
+	// Path is the configuration file to write, relative to the app root.
+	Path string `json:"path"`
+
+	// Host is the hostname the proxy routes to the app.
+	// If empty requests for all hostnames are routed.
+	Host string `json:"host,omitempty"`
+
+	// PathPrefix is the path prefix the proxy routes to the app.
+	// If empty all paths are routed.
+	PathPrefix string `json:"path_prefix,omitempty"`
+}
+
+// GatewayTransform describes a transformation the API gateways apply
+// to requests to, and responses from, a set of endpoints.
+type GatewayTransform struct {
+	// Endpoints are the endpoints the transform applies to, in the form
+	// "service.endpoint". The endpoint may be "*" to match all endpoints
+	// in the service. If empty the transform applies to all endpoints.
+	Endpoints []string `json:"endpoints,omitempty"`
+
+	// RequestHeaders are headers to set on requests before
+	// they are forwarded to the endpoint.
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+
+	// ResponseHeaders are headers to set on responses before
+	// they are returned to the client.
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+
+	// StripResponseFields are top-level fields to remove
+	// from JSON response bodies.
+	StripResponseFields []string `json:"strip_response_fields,omitempty"`
+
+	// Compression lists the compression algorithms to use for response
+	// bodies, in order of preference. Supported values are "gzip", "br"
+	// and "zstd". The first algorithm the client accepts is used.
+	Compression []string `json:"compression,omitempty"`
+}
+
+// ClientMetadata configures client metadata enrichment in the API gateway.
+type ClientMetadata struct {
+	// GeoIPDatabase is the path to a MaxMind DB file (such as GeoLite2 City),
+	// relative to the app root, to look up the location of clients in.
+	// If empty no location is determined.
+	GeoIPDatabase string `json:"geoip_database,omitempty"`
+
+	// ClientIPHeader is the header to read the client's IP address from.
+	// If empty the address of the connecting peer is used.
+	ClientIPHeader string `json:"client_ip_header,omitempty"`
+}
+
+// LocalQuotas configures simulated per-API-key request quotas.
+type LocalQuotas struct {
+	// KeyHeader is the header identifying the API key making a request.
+	// Quotas are tracked per API key, and requests without one share a quota.
+	// If empty it defaults to "X-API-Key".
+	KeyHeader string `json:"key_header,omitempty"`
+
+	// Default is the quota for endpoints without a quota in Endpoints.
+	// If nil such endpoints are not limited.
+	Default *Quota `json:"default,omitempty"`
+
+	// Endpoints are the quotas of individual endpoints,
+	// keyed by "service.endpoint".
+	Endpoints map[string]Quota `json:"endpoints,omitempty"`
+}
+
+// Quota is a request quota, enforced as a token bucket:
+// Requests are allowed per Period on average, in bursts of up to Burst.
+type Quota struct {
+	Requests int `json:"requests"`
+
+	// Period is the period Requests are allowed in, as a duration such as "1s" or "1m".
+	// If empty it defaults to "1s".
+	Period string `json:"period,omitempty"`
+
+	// Burst is the number of requests that may be made at once.
+	// If zero it defaults to Requests.
+	Burst int `json:"burst,omitempty"`
+}
+
+// LocalSFTP configures the local SFTP server mapping directories to buckets.
+// The server only listens on the loopback interface.
+type LocalSFTP struct {
+	// Port is the port the server listens on, on the loopback interface.
+	// If zero it listens on 2022 if available, and otherwise on another
+	// port that is reused by later runs of the app.
+	Port int `json:"port,omitempty"`
+
+	// User is the username clients log in with.
+	// If empty it defaults to "encore".
+	User string `json:"user,omitempty"`
+
+	// Password is the password clients log in with. It is stored in
+	// plain text in encore.app, so it should only be used for local testing.
+	// If both it and AuthorizedKeys are empty a password is generated
+	// each time the server starts, and shown in the server's URL.
+	Password string `json:"password,omitempty"`
+
+	// AuthorizedKeys is the path to a file of public keys clients can
+	// log in with, in the authorized_keys format, relative to the app root.
+	AuthorizedKeys string `json:"authorized_keys,omitempty"`
+
+	// Directories maps the names of top-level directories to the names
+	// of the buckets they contain. If empty every bucket is mapped
+	// to a directory of the same name.
+	Directories map[string]string `json:"directories,omitempty"`
+}
+
+// Watch configures the file watcher that reloads the app on 'encore run'.
+//
+// Patterns are globs matched against paths relative to the app root,
+// using "/" as the separator. "**" matches any number of directories,
+// and patterns without a "/" match files in any directory.
+type Watch struct {
+	// Ignore are patterns of files whose changes don't reload the app.
+	Ignore []string `json:"ignore,omitempty"`
+
+	// Include are patterns of files whose changes reload the app,
+	// even if they match Ignore or are ignored by default
+	// (like files that are not source code).
+	Include []string `json:"include,omitempty"`
+
+	// Debounce is how long to wait for further changes after a change
+	// before reloading, as a duration such as "500ms".
+	// If empty the app is reloaded right away.
+	Debounce string `json:"debounce,omitempty"`
+}
+
+// GracefulShutdown configures the graceful shutdown of the app when running locally,
+// mirroring the graceful_shutdown section of the infrastructure config.
+//
+// The timings are durations such as "30s". Empty timings use the defaults.
+type GracefulShutdown struct {
+	// Total is how long the app is given to shut down before it is killed.
+	// If empty it defaults to "10s".
+	Total string `json:"total,omitempty"`
+
+	// Handlers is how long before Total runs out that the contexts
+	// passed to running API and Pub/Sub handlers are canceled.
+	// If empty it defaults to "2s".
+	Handlers string `json:"handlers,omitempty"`
+
+	// ShutdownHooks is how long before Total runs out that the context
+	// passed to shutdown hooks is canceled.
+	// If empty it defaults to "4s".
+	ShutdownHooks string `json:"shutdown_hooks,omitempty"`
+}
+
+// RunHooks configures the commands 'encore run' executes around starting the app.
+// The commands are executed in the app root, and their output is shown
+// along with the app's output.
+type RunHooks struct {
+	// PreStart is executed before the app is first built,
+	// such as to generate code. The app isn't started if it fails.
+	PreStart Hook `json:"prestart,omitempty"`
+
+	// PostStart is executed once the app is listening for requests,
+	// such as to seed data or open a tunnel. It's stopped
+	// if it's still running when 'encore run' exits.
+	PostStart Hook `json:"poststart,omitempty"`
+}
+
+// Seeds configures the scripts that seed an app's local databases with data.
+type Seeds struct {
+	// AutoApply applies the seeds after 'encore db reset', and when
+	// 'encore run' creates the app's databases, such as in a new namespace.
+	AutoApply bool `json:"auto_apply,omitempty"`
+
+	// Databases maps the names of databases to their seeds,
+	// which are applied in order.
+	Databases map[string][]Seed `json:"databases,omitempty"`
+}
+
+// Seed is a script that seeds a database with data.
+// Exactly one of SQL and Go must be set.
+type Seed struct {
+	// Name identifies the seed among the seeds of its database.
+	Name string `json:"name"`
+
+	// SQL is the path of a SQL file to execute against the database,
+	// relative to the app root.
+	SQL string `json:"sql,omitempty"`
+
+	// Go is the path of a Go main package to execute, relative to the app root.
+	// It's executed as with 'encore exec', and can use the app's databases.
+	Go string `json:"go,omitempty"`
+}
+
 // Parse parses the app file data into a File.
 func Parse(data []byte) (*File, error) {
 	var f File
@@ -232,6 +552,75 @@ func Parse(data []byte) (*File, error) {
 		return nil, fmt.Errorf("appfile.Parse: invalid lang %q", f.Lang)
 	}
 
+	for i, t := range f.GatewayTransforms {
+		for _, c := range t.Compression {
+			switch c {
+			case "gzip", "br", "zstd":
+			default:
+				return nil, fmt.Errorf("appfile.Parse: gateway_transforms[%d]: unsupported compression %q", i, c)
+			}
+		}
+		for _, ep := range t.Endpoints {
+			if svc, name, ok := strings.Cut(ep, "."); !ok || svc == "" || name == "" {
+				return nil, fmt.Errorf("appfile.Parse: gateway_transforms[%d]: invalid endpoint %q (expected \"service.endpoint\")", i, ep)
+			}
+		}
+	}
+
+	if w := f.Watch; w != nil {
+		for _, p := range append(slices.Clone(w.Ignore), w.Include...) {
+			if !glob.Valid(p) {
+				return nil, fmt.Errorf("appfile.Parse: watch: invalid pattern %q", p)
+			}
+		}
+		if w.Debounce != "" {
+			if _, err := time.ParseDuration(w.Debounce); err != nil {
+				return nil, fmt.Errorf("appfile.Parse: watch: invalid debounce %q", w.Debounce)
+			}
+		}
+	}
+
+	if g := f.GracefulShutdown; g != nil {
+		for _, d := range []struct{ name, val string }{
+			{"total", g.Total}, {"handlers", g.Handlers}, {"shutdown_hooks", g.ShutdownHooks},
+		} {
+			if d.val == "" {
+				continue
+			}
+			if dur, err := time.ParseDuration(d.val); err != nil || dur < 0 {
+				return nil, fmt.Errorf("appfile.Parse: graceful_shutdown: invalid %s %q", d.name, d.val)
+			}
+		}
+	}
+
+	if sftp := f.LocalSFTP; sftp != nil {
+		if sftp.Port < 0 || sftp.Port > 65535 {
+			return nil, fmt.Errorf("appfile.Parse: local_sftp: invalid port %d", sftp.Port)
+		}
+		for dir := range sftp.Directories {
+			if dir == "" || dir == "." || dir == ".." || strings.ContainsAny(dir, "/\\") {
+				return nil, fmt.Errorf("appfile.Parse: local_sftp: invalid directory %q (expected a single path segment)", dir)
+			}
+		}
+	}
+
+	if seeds := f.Seeds; seeds != nil {
+		for dbName, dbSeeds := range seeds.Databases {
+			seen := make(map[string]bool, len(dbSeeds))
+			for i, seed := range dbSeeds {
+				switch {
+				case seed.Name == "":
+					return nil, fmt.Errorf("appfile.Parse: seeds: %s[%d]: missing name", dbName, i)
+				case seen[seed.Name]:
+					return nil, fmt.Errorf("appfile.Parse: seeds: %s: duplicate seed %q", dbName, seed.Name)
+				case (seed.SQL == "") == (seed.Go == ""):
+					return nil, fmt.Errorf("appfile.Parse: seeds: %s: seed %q must set exactly one of sql and go", dbName, seed.Name)
+				}
+				seen[seed.Name] = true
+			}
+		}
+	}
+
 	// Parse deprecated fields into the new Build struct.
 	f.Build.CgoEnabled = f.Build.CgoEnabled || f.CgoEnabled
 	if f.Build.Docker.BaseImage == "" {
@@ -291,6 +680,87 @@ func GlobalCORS(appRoot string) (*CORS, error) {
 	return f.GlobalCORS, nil
 }
 
+// GatewayTransforms returns the gateway transforms for the app located at appRoot.
+func GatewayTransforms(appRoot string) ([]GatewayTransform, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return nil, err
+	}
+	return f.GatewayTransforms, nil
+}
+
+// ProtobufConfig returns the Protocol Buffers settings for the app located at appRoot.
+func ProtobufConfig(appRoot string) (*Protobuf, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return nil, err
+	}
+	return f.Protobuf, nil
+}
+
+// SchemaExportConfig returns the schema export settings for the app located at appRoot.
+func SchemaExportConfig(appRoot string) (*SchemaExport, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return nil, err
+	}
+	return f.SchemaExport, nil
+}
+
+// ComposeConfig returns the docker-compose settings for the app located at appRoot.
+func ComposeConfig(appRoot string) (*Compose, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return nil, err
+	}
+	return f.Compose, nil
+}
+
+// LocalSFTPConfig returns the local SFTP server settings for the app located at appRoot.
+func LocalSFTPConfig(appRoot string) (*LocalSFTP, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return nil, err
+	}
+	return f.LocalSFTP, nil
+}
+
+// WatchConfig returns the file watcher settings for the app located at appRoot.
+func WatchConfig(appRoot string) (*Watch, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return nil, err
+	}
+	return f.Watch, nil
+}
+
+// GracefulShutdownConfig returns the graceful shutdown settings for the app located at appRoot.
+func GracefulShutdownConfig(appRoot string) (*GracefulShutdown, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return nil, err
+	}
+	return f.GracefulShutdown, nil
+}
+
+// RunHooksConfig returns the 'encore run' hooks for the app located at appRoot.
+func RunHooksConfig(appRoot string) (*RunHooks, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return nil, err
+	}
+	return f.RunHooks, nil
+}
+
+// SeedsConfig returns the database seeds for the app located at appRoot.
+func SeedsConfig(appRoot string) (*Seeds, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return nil, err
+	}
+	return f.Seeds, nil
+}
+
 // AppLang returns the language of the app located at appRoot.
 func AppLang(appRoot string) (Lang, error) {
 	f, err := ParseFile(filepath.Join(appRoot, Name))
@@ -0,0 +1,246 @@
+// Package appmeta provides a stable, programmatic API for parsing an Encore
+// application and obtaining its metadata graph.
+//
+// It is intended for tooling that wants to analyze Encore apps (for example
+// internal platform tooling, linters or documentation generators) without
+// shelling out to the Encore CLI. The exported API of this package follows
+// semantic versioning as described by APIVersion: breaking changes to the
+// package's exported identifiers are only made in a new major version.
+//
+// The metadata itself is described by the encore.parser.meta.v1 protobuf
+// package, which is versioned independently and only evolves in a
+// backwards-compatible way.
+package appmeta
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang/protobuf/jsonpb"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/builder"
+	"encr.dev/pkg/builder/builderimpl"
+	"encr.dev/pkg/fns"
+	"encr.dev/pkg/option"
+	"encr.dev/pkg/paths"
+	"encr.dev/pkg/vcs"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// APIVersion is the semantic version of the API exposed by this package.
+const APIVersion = "v1.0.0"
+
+// Options configures how an app is parsed.
+type Options struct {
+	// AppRoot is the filesystem path to the root of the Encore app,
+	// i.e. the directory containing the encore.app file. It is required.
+	AppRoot string
+
+	// WorkingDir is the directory, relative to AppRoot, used for formatting
+	// relative paths in error messages. It defaults to ".".
+	WorkingDir string
+
+	// ParseTests specifies whether to parse test files as well.
+	ParseTests bool
+
+	// Environ is the environment used when resolving experiments and
+	// build configuration, in the same format as os.Environ().
+	// If nil, os.Environ() is used.
+	Environ []string
+
+	// GoRoot and RuntimesPath override the paths to the Encore Go root and
+	// the Encore runtimes. If empty they are resolved the same way the
+	// Encore CLI resolves them (ENCORE_GOROOT and ENCORE_RUNTIMES_PATH).
+	GoRoot       string
+	RuntimesPath string
+}
+
+// Result is the result of parsing an app.
+type Result struct {
+	// Meta is the metadata graph describing the app.
+	Meta *meta.Data
+
+	// Lang is the language the app is written in.
+	Lang appfile.Lang
+}
+
+// Parse parses the Encore app described by opts and returns its metadata.
+//
+// Parse errors are returned as an error whose message is formatted the same
+// way as the errors reported by the Encore CLI.
+func Parse(ctx context.Context, opts Options) (*Result, error) {
+	if opts.AppRoot == "" {
+		return nil, errors.New("appmeta: AppRoot must be set")
+	}
+	root, err := filepath.Abs(opts.AppRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "appmeta: resolve app root")
+	}
+	if _, err := os.Stat(filepath.Join(root, appfile.Name)); err != nil {
+		return nil, errors.Wrapf(err, "appmeta: %s is not an Encore app", root)
+	}
+
+	workingDir := opts.WorkingDir
+	if workingDir == "" {
+		workingDir = "."
+	}
+	environ := opts.Environ
+	if environ == nil {
+		environ = os.Environ()
+	}
+
+	app := apps.NewInstance(root, "appmeta", "")
+	expSet, err := app.Experiments(environ)
+	if err != nil {
+		return nil, errors.Wrap(err, "appmeta: resolve experiments")
+	}
+
+	vcsRevision := vcs.GetRevision(root)
+	buildInfo := builder.DefaultBuildInfo()
+	buildInfo.Environ = environ
+	buildInfo.GOOS = runtime.GOOS
+	buildInfo.GOARCH = runtime.GOARCH
+	buildInfo.Revision = vcsRevision.Revision
+	buildInfo.UncommittedChanges = vcsRevision.Uncommitted
+	if opts.GoRoot != "" {
+		buildInfo.GoRoot = option.Some(paths.RootedFSPath(opts.GoRoot, "."))
+	}
+	if opts.RuntimesPath != "" {
+		buildInfo.EncoreRuntimes = option.Some(paths.RootedFSPath(opts.RuntimesPath, "."))
+	}
+
+	lang := app.Lang()
+	bld := builderimpl.Resolve(lang, expSet)
+	defer fns.CloseIgnore(bld)
+
+	prepareResult, err := bld.Prepare(ctx, builder.PrepareParams{
+		Build:      buildInfo,
+		App:        app,
+		WorkingDir: workingDir,
+	})
+	if err != nil {
+		return nil, err
+	}
+	parse, err := bld.Parse(ctx, builder.ParseParams{
+		Build:       buildInfo,
+		App:         app,
+		Experiments: expSet,
+		WorkingDir:  workingDir,
+		ParseTests:  opts.ParseTests,
+		Prepare:     prepareResult,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Meta: parse.Meta, Lang: lang}, nil
+}
+
+// Service returns the service with the given name, or nil if not found.
+func (r *Result) Service(name string) *meta.Service {
+	for _, svc := range r.Meta.Svcs {
+		if svc.Name == name {
+			return svc
+		}
+	}
+	return nil
+}
+
+// ServiceNames returns the names of all services in the app, sorted.
+func (r *Result) ServiceNames() []string {
+	names := fns.Map(r.Meta.Svcs, func(svc *meta.Service) string { return svc.Name })
+	sort.Strings(names)
+	return names
+}
+
+// Endpoint describes an API endpoint together with the service it belongs to.
+type Endpoint struct {
+	Service string
+	RPC     *meta.RPC
+}
+
+// Endpoints returns all endpoints in the app, sorted by service and endpoint name.
+func (r *Result) Endpoints() []Endpoint {
+	var eps []Endpoint
+	for _, svc := range r.Meta.Svcs {
+		for _, rpc := range svc.Rpcs {
+			eps = append(eps, Endpoint{Service: svc.Name, RPC: rpc})
+		}
+	}
+	sort.Slice(eps, func(i, j int) bool {
+		if eps[i].Service != eps[j].Service {
+			return eps[i].Service < eps[j].Service
+		}
+		return eps[i].RPC.Name < eps[j].RPC.Name
+	})
+	return eps
+}
+
+// Format describes a serialization format for metadata.
+type Format string
+
+const (
+	FormatProto Format = "proto"
+	FormatJSON  Format = "json"
+)
+
+// Marshal serializes md in the given format.
+func Marshal(md *meta.Data, format Format) ([]byte, error) {
+	switch format {
+	case FormatProto:
+		return proto.Marshal(md)
+	case FormatJSON:
+		var buf bytes.Buffer
+		m := &jsonpb.Marshaler{OrigName: true, EmitDefaults: true, Indent: "  "}
+		if err := m.Marshal(&buf, md); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, errors.Newf("appmeta: unknown format %q", format)
+	}
+}
+
+// Unmarshal parses metadata previously serialized with Marshal
+// (or by 'encore debug meta').
+func Unmarshal(data []byte, format Format) (*meta.Data, error) {
+	md := &meta.Data{}
+	switch format {
+	case FormatProto:
+		if err := proto.Unmarshal(data, md); err != nil {
+			return nil, err
+		}
+	case FormatJSON:
+		u := &jsonpb.Unmarshaler{AllowUnknownFields: true}
+		if err := u.Unmarshal(bytes.NewReader(data), md); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Newf("appmeta: unknown format %q", format)
+	}
+	return md, nil
+}
+
+// ReadFile reads metadata from the file at path.
+// The format is determined by the file extension: ".json" is parsed
+// as JSON and anything else as protobuf.
+func ReadFile(path string) (*meta.Data, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	format := FormatProto
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = FormatJSON
+	}
+	return Unmarshal(data, format)
+}
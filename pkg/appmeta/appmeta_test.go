@@ -0,0 +1,78 @@
+package appmeta
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		ModulePath: "encore.app",
+		Svcs: []*meta.Service{
+			{Name: "svc", Rpcs: []*meta.RPC{{Name: "Foo", ServiceName: "svc"}}},
+		},
+	}
+
+	for _, format := range []Format{FormatProto, FormatJSON} {
+		c.Run(string(format), func(c *qt.C) {
+			data, err := Marshal(md, format)
+			c.Assert(err, qt.IsNil)
+
+			got, err := Unmarshal(data, format)
+			c.Assert(err, qt.IsNil)
+			c.Assert(got.ModulePath, qt.Equals, "encore.app")
+			c.Assert(got.Svcs, qt.HasLen, 1)
+			c.Assert(got.Svcs[0].Rpcs[0].Name, qt.Equals, "Foo")
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{ModulePath: "encore.app"}
+	data, err := Marshal(md, FormatJSON)
+	c.Assert(err, qt.IsNil)
+
+	path := filepath.Join(t.TempDir(), "meta.json")
+	c.Assert(os.WriteFile(path, data, 0644), qt.IsNil)
+
+	got, err := ReadFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.ModulePath, qt.Equals, "encore.app")
+}
+
+func TestResultHelpers(t *testing.T) {
+	c := qt.New(t)
+	res := &Result{Meta: &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "b", Rpcs: []*meta.RPC{{Name: "Z"}, {Name: "A"}}},
+			{Name: "a", Rpcs: []*meta.RPC{{Name: "M"}}},
+		},
+	}}
+
+	c.Assert(res.ServiceNames(), qt.DeepEquals, []string{"a", "b"})
+	c.Assert(res.Service("b").Name, qt.Equals, "b")
+	c.Assert(res.Service("missing"), qt.IsNil)
+
+	var names []string
+	for _, ep := range res.Endpoints() {
+		names = append(names, ep.Service+"."+ep.RPC.Name)
+	}
+	c.Assert(names, qt.DeepEquals, []string{"a.M", "b.A", "b.Z"})
+}
+
+func TestParseRequiresApp(t *testing.T) {
+	c := qt.New(t)
+	_, err := Parse(context.Background(), Options{})
+	c.Assert(err, qt.ErrorMatches, ".*AppRoot must be set")
+
+	_, err = Parse(context.Background(), Options{AppRoot: t.TempDir()})
+	c.Assert(err, qt.ErrorMatches, ".*is not an Encore app.*")
+}
@@ -0,0 +1,220 @@
+// Package benchcmp parses the output of Go benchmarks and compares
+// the results against a baseline to detect performance regressions.
+package benchcmp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Result is the result of a benchmark, averaged over all its runs.
+type Result struct {
+	Package string `json:"package,omitempty"`
+	Name    string `json:"name"` // benchmark name without the GOMAXPROCS suffix
+	Runs    int    `json:"runs"`
+
+	// Metrics are the mean values of the benchmark's metrics, keyed by unit (like "ns/op").
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+func (r *Result) key() string {
+	return r.Package + " " + r.Name
+}
+
+// Parser collects benchmark results from the output of "go test -bench".
+// It is safe for concurrent use.
+type Parser struct {
+	mu      sync.Mutex
+	pkg     string // the most recently seen package, from "pkg:" lines
+	results map[string]*Result
+	sums    map[string]map[string]float64
+}
+
+// NewParser returns a new parser.
+func NewParser() *Parser {
+	return &Parser{
+		results: make(map[string]*Result),
+		sums:    make(map[string]map[string]float64),
+	}
+}
+
+// procsSuffix matches the GOMAXPROCS suffix go test adds to benchmark names.
+var procsSuffix = regexp.MustCompile(`-\d+$`)
+
+// ParseLine parses a single line of output. If pkg is empty, the package
+// is taken from the most recent "pkg:" line, as printed by go test.
+// Lines that are not benchmark results are ignored.
+func (p *Parser) ParseLine(pkg, line string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	line = strings.TrimSpace(line)
+	if rest, ok := strings.CutPrefix(line, "pkg: "); ok {
+		p.pkg = rest
+		return
+	}
+	if !strings.HasPrefix(line, "Benchmark") {
+		return
+	}
+
+	// A result line is of the form "BenchmarkName-8 <iterations> <value> <unit> [<value> <unit>...]".
+	fields := strings.Fields(line)
+	if len(fields) < 4 || len(fields)%2 != 0 {
+		return
+	}
+	if _, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+		return
+	}
+	metrics := make(map[string]float64)
+	for i := 2; i+1 < len(fields); i += 2 {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return
+		}
+		metrics[fields[i+1]] = v
+	}
+
+	if pkg == "" {
+		pkg = p.pkg
+	}
+	r := &Result{Package: pkg, Name: procsSuffix.ReplaceAllString(fields[0], "")}
+	if existing, ok := p.results[r.key()]; ok {
+		r = existing
+	} else {
+		p.results[r.key()] = r
+		p.sums[r.key()] = make(map[string]float64)
+	}
+
+	r.Runs++
+	sums := p.sums[r.key()]
+	r.Metrics = make(map[string]float64, len(sums))
+	for unit, v := range metrics {
+		sums[unit] += v
+	}
+	for unit, sum := range sums {
+		r.Metrics[unit] = sum / float64(r.Runs)
+	}
+}
+
+// Results returns the parsed results, sorted by package and name.
+func (p *Parser) Results() []*Result {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	results := make([]*Result, 0, len(p.results))
+	for _, r := range p.results {
+		results = append(results, r)
+	}
+	slices.SortFunc(results, func(a, b *Result) int {
+		return strings.Compare(a.key(), b.key())
+	})
+	return results
+}
+
+// baselineFile is the format of baseline files.
+type baselineFile struct {
+	Benchmarks []*Result `json:"benchmarks"`
+}
+
+// WriteBaseline writes the results to the baseline file at path.
+func WriteBaseline(path string, results []*Result) error {
+	data, err := json.MarshalIndent(baselineFile{Benchmarks: results}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// ReadBaseline reads the baseline file at path.
+func ReadBaseline(path string) ([]*Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f baselineFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %v", path, err)
+	}
+	return f.Benchmarks, nil
+}
+
+// Delta is the change of a benchmark metric compared to the baseline.
+type Delta struct {
+	Package string
+	Name    string
+	Unit    string
+	Old     float64
+	New     float64
+
+	// Change is the relative change from Old to New, like 0.1 for a 10% increase.
+	Change float64
+
+	// Regression reports whether the change is a regression exceeding the threshold.
+	Regression bool
+}
+
+// Compare compares the current results against the baseline.
+// A change is a regression if the metric got worse by more than threshold,
+// given as a fraction (like 0.1 for 10%).
+//
+// Benchmarks that are missing from either the baseline or the current results are ignored.
+func Compare(baseline, current []*Result, threshold float64) []Delta {
+	base := make(map[string]*Result, len(baseline))
+	for _, r := range baseline {
+		base[r.key()] = r
+	}
+
+	var deltas []Delta
+	for _, r := range current {
+		old, ok := base[r.key()]
+		if !ok {
+			continue
+		}
+		units := make([]string, 0, len(r.Metrics))
+		for unit := range r.Metrics {
+			if _, ok := old.Metrics[unit]; ok {
+				units = append(units, unit)
+			}
+		}
+		slices.Sort(units)
+
+		for _, unit := range units {
+			d := Delta{
+				Package: r.Package,
+				Name:    r.Name,
+				Unit:    unit,
+				Old:     old.Metrics[unit],
+				New:     r.Metrics[unit],
+			}
+			switch {
+			case d.Old == d.New:
+				d.Change = 0
+			case d.Old == 0:
+				d.Change = math.Inf(1)
+			default:
+				d.Change = (d.New - d.Old) / d.Old
+			}
+
+			if higherIsBetter(unit) {
+				d.Regression = d.Change < -threshold
+			} else {
+				d.Regression = d.Change > threshold
+			}
+			deltas = append(deltas, d)
+		}
+	}
+	return deltas
+}
+
+// higherIsBetter reports whether higher values of a metric with the given unit
+// are better, like throughput measured in "MB/s".
+func higherIsBetter(unit string) bool {
+	return strings.HasSuffix(unit, "/s")
+}
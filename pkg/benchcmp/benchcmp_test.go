@@ -0,0 +1,65 @@
+package benchcmp
+
+import (
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParser(t *testing.T) {
+	c := qt.New(t)
+	p := NewParser()
+	for _, line := range []string{
+		"goos: linux",
+		"pkg: encore.app/svc",
+		"BenchmarkList-8   \t    1000\t      1200 ns/op\t     64 B/op\t       2 allocs/op",
+		"BenchmarkList-8   \t    1000\t      1000 ns/op\t     64 B/op\t       2 allocs/op",
+		"BenchmarkList",
+		"--- FAIL: BenchmarkOther",
+		"PASS",
+	} {
+		p.ParseLine("", line)
+	}
+	p.ParseLine("encore.app/other", "BenchmarkCopy-4 \t 50 \t 20.5 MB/s")
+
+	results := p.Results()
+	c.Assert(results, qt.DeepEquals, []*Result{
+		{Package: "encore.app/other", Name: "BenchmarkCopy", Runs: 1, Metrics: map[string]float64{"MB/s": 20.5}},
+		{Package: "encore.app/svc", Name: "BenchmarkList", Runs: 2, Metrics: map[string]float64{
+			"ns/op": 1100, "B/op": 64, "allocs/op": 2,
+		}},
+	})
+}
+
+func TestCompare(t *testing.T) {
+	c := qt.New(t)
+	baseline := []*Result{
+		{Package: "p", Name: "BenchmarkA", Metrics: map[string]float64{"ns/op": 100, "allocs/op": 2}},
+		{Package: "p", Name: "BenchmarkB", Metrics: map[string]float64{"MB/s": 100}},
+		{Package: "p", Name: "BenchmarkRemoved", Metrics: map[string]float64{"ns/op": 100}},
+	}
+	current := []*Result{
+		{Package: "p", Name: "BenchmarkA", Metrics: map[string]float64{"ns/op": 105, "allocs/op": 3}},
+		{Package: "p", Name: "BenchmarkB", Metrics: map[string]float64{"MB/s": 80}},
+		{Package: "p", Name: "BenchmarkNew", Metrics: map[string]float64{"ns/op": 100}},
+	}
+
+	deltas := Compare(baseline, current, 0.1)
+	c.Assert(deltas, qt.DeepEquals, []Delta{
+		{Package: "p", Name: "BenchmarkA", Unit: "allocs/op", Old: 2, New: 3, Change: 0.5, Regression: true},
+		{Package: "p", Name: "BenchmarkA", Unit: "ns/op", Old: 100, New: 105, Change: 0.05},
+		{Package: "p", Name: "BenchmarkB", Unit: "MB/s", Old: 100, New: 80, Change: -0.2, Regression: true},
+	})
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	c := qt.New(t)
+	path := filepath.Join(t.TempDir(), "bench.json")
+	results := []*Result{{Package: "p", Name: "BenchmarkA", Runs: 3, Metrics: map[string]float64{"ns/op": 100}}}
+	c.Assert(WriteBaseline(path, results), qt.IsNil)
+
+	got, err := ReadBaseline(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, results)
+}
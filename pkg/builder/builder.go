@@ -67,6 +67,11 @@ type BuildInfo struct {
 	// DisableSensitiveScrubbing, if true, disables scrubbing of sensitive fields.
 	// Used for local development.
 	DisableSensitiveScrubbing bool
+
+	// Reproducible, if true, builds artifacts that are byte-identical
+	// when built from identical inputs, by omitting build paths and
+	// other host-specific details from them. Used for exported builds.
+	Reproducible bool
 }
 
 func (b *BuildInfo) IsCrossBuild() bool {
@@ -0,0 +1,217 @@
+// Package cdc generates change data capture (CDC) configuration for an app's
+// databases, for streaming their changes to data warehouses and pipelines.
+//
+// It supports generating Debezium connector configuration and logical replication
+// setup scripts for deployed databases, and a trigger-based local mode that
+// emits change events for testing pipelines during development.
+package cdc
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Publication is the name of the publication tables are added to.
+const Publication = "encore_cdc"
+
+// Table is a database table.
+type Table struct {
+	Schema string
+	Name   string
+}
+
+// String returns the qualified name of the table, like "public.orders".
+func (t Table) String() string {
+	return t.Schema + "." + t.Name
+}
+
+// quoted returns the qualified name of the table, quoted for use in SQL.
+func (t Table) quoted() string {
+	return quoteIdent(t.Schema) + "." + quoteIdent(t.Name)
+}
+
+var (
+	tableName = `((?:"[^"]+"|[A-Za-z_][\w$]*)(?:\s*\.\s*(?:"[^"]+"|[A-Za-z_][\w$]*))?)`
+
+	createTableRe = regexp.MustCompile(`(?is)^CREATE\s+(?:(?:GLOBAL|LOCAL)\s+)?((?:TEMP|TEMPORARY|UNLOGGED)\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?` + tableName)
+	dropTableRe   = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(.+?)(?:\s+(?:CASCADE|RESTRICT))?\s*$`)
+	renameTableRe = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?` + tableName + `\s+RENAME\s+TO\s+("[^"]+"|[A-Za-z_][\w$]*)\s*$`)
+	setSchemaRe   = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?` + tableName + `\s+SET\s+SCHEMA\s+("[^"]+"|[A-Za-z_][\w$]*)\s*$`)
+
+	qualifiedNameRe = regexp.MustCompile(`^("[^"]+"|[^."\s]+)\s*\.\s*("[^"]+"|[^."\s]+)$`)
+	lineCommentRe   = regexp.MustCompile(`--[^\n]*`)
+	blockCommentRe  = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	nonIdentRe      = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// Tables returns the tables of a database, as declared by its migrations.
+// The migrations are read from the database's migration directory
+// relative to appRoot, and applied in order.
+func Tables(appRoot string, db *meta.SQLDatabase) ([]Table, error) {
+	if db.MigrationRelPath == nil {
+		return nil, nil
+	}
+	dir := filepath.Join(appRoot, filepath.FromSlash(*db.MigrationRelPath))
+
+	migrations := slices.Clone(db.Migrations)
+	slices.SortFunc(migrations, func(a, b *meta.DBMigration) int {
+		return cmp.Compare(a.Number, b.Number)
+	})
+
+	var tables []Table
+	for _, m := range migrations {
+		data, err := os.ReadFile(filepath.Join(dir, m.Filename))
+		if err != nil {
+			return nil, errors.Wrapf(err, "read migration %s", m.Filename)
+		}
+		tables = applyMigration(tables, string(data))
+	}
+	return tables, nil
+}
+
+// applyMigration updates tables with the tables created, dropped
+// and renamed by the statements of a migration.
+func applyMigration(tables []Table, sql string) []Table {
+	sql = blockCommentRe.ReplaceAllString(sql, "")
+	sql = lineCommentRe.ReplaceAllString(sql, "")
+
+	for _, stmt := range strings.Split(sql, ";") {
+		stmt = strings.TrimSpace(stmt)
+		if m := createTableRe.FindStringSubmatch(stmt); m != nil {
+			// Temporary and unlogged tables are not replicated.
+			if m[1] != "" {
+				continue
+			}
+			if t := parseTable(m[2]); !slices.Contains(tables, t) {
+				tables = append(tables, t)
+			}
+		} else if m := dropTableRe.FindStringSubmatch(stmt); m != nil {
+			for _, name := range strings.Split(m[1], ",") {
+				t := parseTable(strings.TrimSpace(name))
+				tables = slices.DeleteFunc(tables, func(o Table) bool { return o == t })
+			}
+		} else if m := renameTableRe.FindStringSubmatch(stmt); m != nil {
+			from := parseTable(m[1])
+			for i, t := range tables {
+				if t == from {
+					tables[i].Name = parseIdent(m[2])
+				}
+			}
+		} else if m := setSchemaRe.FindStringSubmatch(stmt); m != nil {
+			from := parseTable(m[1])
+			for i, t := range tables {
+				if t == from {
+					tables[i].Schema = parseIdent(m[2])
+				}
+			}
+		}
+	}
+	return tables
+}
+
+// parseTable parses a possibly qualified table name.
+func parseTable(s string) Table {
+	if m := qualifiedNameRe.FindStringSubmatch(s); m != nil {
+		return Table{Schema: parseIdent(m[1]), Name: parseIdent(m[2])}
+	}
+	return Table{Schema: "public", Name: parseIdent(s)}
+}
+
+// parseIdent parses an SQL identifier. Unquoted identifiers are case-insensitive.
+func parseIdent(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return strings.ToLower(s)
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// SlotName returns the name of the replication slot for a database.
+func SlotName(dbName string) string {
+	return Publication + "_" + strings.ToLower(nonIdentRe.ReplaceAllString(dbName, "_"))
+}
+
+// DebeziumConnector returns the Kafka Connect configuration of a Debezium
+// PostgreSQL connector capturing the changes of the given tables.
+//
+// The connection parameters are read from environment variables named after
+// the database, like ORDERS_DB_HOST, using Kafka Connect's environment
+// variable config provider.
+func DebeziumConnector(appSlug string, db *meta.SQLDatabase, tables []Table) ([]byte, error) {
+	envPrefix := strings.ToUpper(nonIdentRe.ReplaceAllString(db.Name, "_")) + "_DB_"
+	env := func(name string) string {
+		return fmt.Sprintf("${env:%s%s}", envPrefix, name)
+	}
+
+	include := make([]string, len(tables))
+	for i, t := range tables {
+		include[i] = regexp.QuoteMeta(t.String())
+	}
+
+	prefix := db.Name
+	if appSlug != "" {
+		prefix = appSlug + "." + db.Name
+	}
+	connector := map[string]any{
+		"name": strings.ReplaceAll(prefix, ".", "-") + "-cdc",
+		"config": map[string]string{
+			"connector.class":             "io.debezium.connector.postgresql.PostgresConnector",
+			"plugin.name":                 "pgoutput",
+			"database.hostname":           env("HOST"),
+			"database.port":               env("PORT"),
+			"database.user":               env("USER"),
+			"database.password":           env("PASSWORD"),
+			"database.dbname":             db.Name,
+			"topic.prefix":                prefix,
+			"publication.name":            Publication,
+			"publication.autocreate.mode": "disabled",
+			"slot.name":                   SlotName(db.Name),
+			"table.include.list":          strings.Join(include, ","),
+			"snapshot.mode":               "initial",
+			"tombstones.on.delete":        "false",
+		},
+	}
+	data, err := json.MarshalIndent(connector, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// ReplicationSQL returns an SQL script that sets up logical replication
+// of the given tables, for use with Debezium or other CDC tools.
+func ReplicationSQL(db *meta.SQLDatabase, tables []Table) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- Logical replication setup for the %q database, generated by encore.\n", db.Name)
+	b.WriteString("-- Requires the server to be configured with wal_level = logical.\n")
+	if len(tables) == 0 {
+		b.WriteString("-- The database has no tables to replicate.\n")
+		return b.String()
+	}
+
+	b.WriteString("\n-- Include the previous values of rows in update and delete events.\n")
+	for _, t := range tables {
+		fmt.Fprintf(&b, "ALTER TABLE %s REPLICA IDENTITY FULL;\n", t.quoted())
+	}
+
+	quoted := make([]string, len(tables))
+	for i, t := range tables {
+		quoted[i] = t.quoted()
+	}
+	fmt.Fprintf(&b, "\nCREATE PUBLICATION %s FOR TABLE %s;\n", Publication, strings.Join(quoted, ", "))
+	fmt.Fprintf(&b, "SELECT pg_create_logical_replication_slot('%s', 'pgoutput');\n", SlotName(db.Name))
+	return b.String()
+}
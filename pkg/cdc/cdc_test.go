@@ -0,0 +1,83 @@
+package cdc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/golden"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func TestTables(t *testing.T) {
+	c := qt.New(t)
+	root := t.TempDir()
+	dir := filepath.Join(root, "orders", "migrations")
+	c.Assert(os.MkdirAll(dir, 0755), qt.IsNil)
+
+	migrations := map[string]string{
+		"1_init.up.sql": `
+			-- The orders table.
+			CREATE TABLE orders (id BIGSERIAL PRIMARY KEY, total INT NOT NULL);
+			CREATE TABLE IF NOT EXISTS "LineItems" (order_id BIGINT REFERENCES orders(id));
+			CREATE UNLOGGED TABLE cache (key TEXT);
+			CREATE TEMP TABLE scratch (x INT);
+			/* CREATE TABLE commented_out (x INT); */
+			CREATE TABLE audit.events (id BIGINT);
+		`,
+		"2_rename.up.sql": `
+			ALTER TABLE orders RENAME TO purchases;
+			DROP TABLE IF EXISTS cache, audit.events CASCADE;
+			CREATE TABLE Customers (id BIGINT);
+		`,
+	}
+	for name, sql := range migrations {
+		c.Assert(os.WriteFile(filepath.Join(dir, name), []byte(sql), 0644), qt.IsNil)
+	}
+
+	relPath := "orders/migrations"
+	db := &meta.SQLDatabase{
+		Name:             "orders",
+		MigrationRelPath: &relPath,
+		Migrations: []*meta.DBMigration{
+			{Filename: "2_rename.up.sql", Number: 2},
+			{Filename: "1_init.up.sql", Number: 1},
+		},
+	}
+	tables, err := Tables(root, db)
+	c.Assert(err, qt.IsNil)
+	c.Assert(tables, qt.DeepEquals, []Table{
+		{Schema: "public", Name: "purchases"},
+		{Schema: "public", Name: "LineItems"},
+		{Schema: "public", Name: "customers"},
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	db := &meta.SQLDatabase{Name: "orders"}
+	tables := []Table{{Schema: "public", Name: "orders"}, {Schema: "public", Name: "LineItems"}}
+
+	connector, err := DebeziumConnector("my-app", db, tables)
+	c.Assert(err, qt.IsNil)
+	golden.TestAgainst(c, "debezium.json", string(connector))
+	golden.TestAgainst(c, "replication.sql", ReplicationSQL(db, tables))
+	golden.TestAgainst(c, "local.sql", LocalSetupSQL(tables)+"\n"+LocalTeardownSQL(tables))
+}
+
+func TestParseEvent(t *testing.T) {
+	c := qt.New(t)
+	ev, err := parseEvent("orders", `{"schema":"public","table":"orders","op":"insert","before":null,"after":{"id":1},"time":"2026-01-02T03:04:05.123456+00:00"}`)
+	c.Assert(err, qt.IsNil)
+	c.Assert(ev.Database, qt.Equals, "orders")
+	c.Assert(ev.Op, qt.Equals, "insert")
+	c.Assert(ev.Before, qt.IsNil)
+	c.Assert(string(ev.After), qt.Equals, `{"id":1}`)
+	c.Assert(ev.Time.Year(), qt.Equals, 2026)
+}
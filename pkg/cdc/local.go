@@ -0,0 +1,167 @@
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgx/v5"
+)
+
+// notifyChannel is the channel the local triggers notify change events on.
+const notifyChannel = "encore_cdc"
+
+// maxNotifyPayload is the maximum size of a notification payload.
+// Postgres rejects payloads of 8000 bytes or more.
+const maxNotifyPayload = 7900
+
+// Event is a change event emitted in local mode.
+type Event struct {
+	// Database is the name of the database the change was made in.
+	Database string `json:"database"`
+
+	// Schema and Table identify the changed table.
+	Schema string `json:"schema"`
+	Table  string `json:"table"`
+
+	// Op is the operation: "insert", "update" or "delete".
+	Op string `json:"op"`
+
+	// Before is the row before an update or delete, and After is the row
+	// after an insert or update, as JSON objects keyed by column name.
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+
+	// Time is the time of the transaction that made the change.
+	Time time.Time `json:"time"`
+
+	// Truncated is set if the rows were too large to include in the event,
+	// in which case Before and After are omitted.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// LocalSetupSQL returns an SQL script that installs triggers that emit
+// change events for the given tables, for use with Stream.
+//
+// Unlike logical replication this works with the default configuration
+// of the local database cluster, at the cost of some write overhead.
+func LocalSetupSQL(tables []Table) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `CREATE OR REPLACE FUNCTION encore_cdc_notify() RETURNS trigger AS $$
+DECLARE
+	payload text;
+BEGIN
+	payload := json_build_object(
+		'schema', TG_TABLE_SCHEMA,
+		'table', TG_TABLE_NAME,
+		'op', lower(TG_OP),
+		'before', CASE WHEN TG_OP IN ('UPDATE', 'DELETE') THEN row_to_json(OLD) END,
+		'after', CASE WHEN TG_OP IN ('INSERT', 'UPDATE') THEN row_to_json(NEW) END,
+		'time', now()
+	)::text;
+	IF octet_length(payload) > %d THEN
+		payload := json_build_object(
+			'schema', TG_TABLE_SCHEMA,
+			'table', TG_TABLE_NAME,
+			'op', lower(TG_OP),
+			'time', now(),
+			'truncated', true
+		)::text;
+	END IF;
+	PERFORM pg_notify('%s', payload);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+`, maxNotifyPayload, notifyChannel)
+
+	for _, t := range tables {
+		fmt.Fprintf(&b, "DROP TRIGGER IF EXISTS encore_cdc ON %s;\n", t.quoted())
+		fmt.Fprintf(&b, "CREATE TRIGGER encore_cdc AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION encore_cdc_notify();\n", t.quoted())
+	}
+	return b.String()
+}
+
+// LocalTeardownSQL returns an SQL script that removes the triggers
+// installed by LocalSetupSQL.
+func LocalTeardownSQL(tables []Table) string {
+	var b strings.Builder
+	for _, t := range tables {
+		fmt.Fprintf(&b, "DROP TRIGGER IF EXISTS encore_cdc ON %s;\n", t.quoted())
+	}
+	b.WriteString("DROP FUNCTION IF EXISTS encore_cdc_notify();\n")
+	return b.String()
+}
+
+// Stream installs change event triggers for the given tables in the database
+// identified by connString, and calls fn for each change until ctx is canceled
+// or fn returns an error. The triggers are removed before Stream returns.
+func Stream(ctx context.Context, connString, database string, tables []Table, fn func(*Event) error) (err error) {
+	conn, err := pgx.Connect(ctx, connString)
+	if err != nil {
+		return errors.Wrap(err, "connect to database")
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	if _, err := conn.Exec(ctx, LocalSetupSQL(tables)); err != nil {
+		return errors.Wrap(err, "install change triggers")
+	}
+	defer func() {
+		// Use a new connection since canceling ctx closes conn.
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if cleanupErr := teardown(cleanupCtx, connString, tables); cleanupErr != nil && err == nil {
+			err = errors.Wrap(cleanupErr, "remove change triggers")
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return errors.Wrap(err, "listen for changes")
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "wait for changes")
+		}
+
+		ev, err := parseEvent(database, n.Payload)
+		if err != nil {
+			return err
+		}
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+}
+
+func teardown(ctx context.Context, connString string, tables []Table) error {
+	conn, err := pgx.Connect(ctx, connString)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close(ctx) }()
+	_, err = conn.Exec(ctx, LocalTeardownSQL(tables))
+	return err
+}
+
+func parseEvent(database, payload string) (*Event, error) {
+	ev := &Event{}
+	if err := json.Unmarshal([]byte(payload), ev); err != nil {
+		return nil, errors.Wrap(err, "parse change event")
+	}
+	ev.Database = database
+
+	// json_build_object encodes missing rows as null.
+	if string(ev.Before) == "null" {
+		ev.Before = nil
+	}
+	if string(ev.After) == "null" {
+		ev.After = nil
+	}
+	return ev, nil
+}
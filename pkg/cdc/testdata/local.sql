@@ -0,0 +1,33 @@
+CREATE OR REPLACE FUNCTION encore_cdc_notify() RETURNS trigger AS $$
+DECLARE
+	payload text;
+BEGIN
+	payload := json_build_object(
+		'schema', TG_TABLE_SCHEMA,
+		'table', TG_TABLE_NAME,
+		'op', lower(TG_OP),
+		'before', CASE WHEN TG_OP IN ('UPDATE', 'DELETE') THEN row_to_json(OLD) END,
+		'after', CASE WHEN TG_OP IN ('INSERT', 'UPDATE') THEN row_to_json(NEW) END,
+		'time', now()
+	)::text;
+	IF octet_length(payload) > 7900 THEN
+		payload := json_build_object(
+			'schema', TG_TABLE_SCHEMA,
+			'table', TG_TABLE_NAME,
+			'op', lower(TG_OP),
+			'time', now(),
+			'truncated', true
+		)::text;
+	END IF;
+	PERFORM pg_notify('encore_cdc', payload);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS encore_cdc ON "public"."orders";
+CREATE TRIGGER encore_cdc AFTER INSERT OR UPDATE OR DELETE ON "public"."orders" FOR EACH ROW EXECUTE FUNCTION encore_cdc_notify();
+DROP TRIGGER IF EXISTS encore_cdc ON "public"."LineItems";
+CREATE TRIGGER encore_cdc AFTER INSERT OR UPDATE OR DELETE ON "public"."LineItems" FOR EACH ROW EXECUTE FUNCTION encore_cdc_notify();
+
+DROP TRIGGER IF EXISTS encore_cdc ON "public"."orders";
+DROP TRIGGER IF EXISTS encore_cdc ON "public"."LineItems";
+DROP FUNCTION IF EXISTS encore_cdc_notify();
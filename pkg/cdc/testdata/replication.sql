@@ -0,0 +1,9 @@
+-- Logical replication setup for the "orders" database, generated by encore.
+-- Requires the server to be configured with wal_level = logical.
+
+-- Include the previous values of rows in update and delete events.
+ALTER TABLE "public"."orders" REPLICA IDENTITY FULL;
+ALTER TABLE "public"."LineItems" REPLICA IDENTITY FULL;
+
+CREATE PUBLICATION encore_cdc FOR TABLE "public"."orders", "public"."LineItems";
+SELECT pg_create_logical_replication_slot('encore_cdc_orders', 'pgoutput');
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"google.golang.org/protobuf/proto"
+
 	"encr.dev/pkg/clientgen/clientgentypes"
 	"encr.dev/pkg/clientgen/openapi"
 	"encr.dev/pkg/errinsrc/srcerrors"
@@ -34,6 +37,16 @@ type generator interface {
 // ErrUnknownLang is reported by Generate when the language is not known.
 var ErrUnknownLang = errors.New("unknown language")
 
+// ErrUnknownHooks is reported by Generate when the hooks library is not known.
+var ErrUnknownHooks = errors.New("unknown hooks library")
+
+// ErrUnknownSchemas is reported by Generate when the schema library is not known.
+var ErrUnknownSchemas = errors.New("unknown schema library")
+
+// ErrSchemasSharedTypes is reported by Generate when schemas are requested
+// for a client importing its types from the backend.
+var ErrSchemasSharedTypes = errors.New("schemas cannot be generated with shared types")
+
 // Detect attempts to detect the language from the given filename.
 func Detect(path string) (lang Lang, ok bool) {
 	suffix := strings.ToLower(filepath.Ext(path))
@@ -69,10 +82,23 @@ func Client(
 	var gen generator
 	switch lang {
 	case LangTypeScript:
+		switch opts.TSHooks {
+		case clientgentypes.TSHooksNone, clientgentypes.TSHooksReactQuery, clientgentypes.TSHooksSWR:
+		default:
+			return nil, ErrUnknownHooks
+		}
+		switch opts.TSSchemas {
+		case clientgentypes.TSSchemasNone, clientgentypes.TSSchemasZod:
+		default:
+			return nil, ErrUnknownSchemas
+		}
 		if opts.TSSharedTypes && md.Language == meta.Lang_TYPESCRIPT {
-			gen = &typescript{generatorVersion: typescriptGenLatestVersion, sharedTypes: true, clientTarget: opts.TSClientTarget}
+			if opts.TSSchemas != clientgentypes.TSSchemasNone {
+				return nil, ErrSchemasSharedTypes
+			}
+			gen = &typescript{generatorVersion: typescriptGenLatestVersion, sharedTypes: true, clientTarget: opts.TSClientTarget, hooks: opts.TSHooks}
 		} else {
-			gen = &typescript{generatorVersion: typescriptGenLatestVersion, sharedTypes: false}
+			gen = &typescript{generatorVersion: typescriptGenLatestVersion, sharedTypes: false, hooks: opts.TSHooks, schemas: opts.TSSchemas}
 		}
 	case LangJavascript:
 		gen = &javascript{generatorVersion: javascriptGenLatestVersion}
@@ -88,7 +114,7 @@ func Client(
 	params := clientgentypes.GenerateParams{
 		Buf:      &buf,
 		AppSlug:  appSlug,
-		Meta:     md,
+		Meta:     withoutExperiments(md),
 		Services: services,
 		Tags:     tags,
 		Options:  opts,
@@ -102,6 +128,24 @@ func Client(
 
 // getServiceDoc returns the documentation for a service by looking up the
 // root package matching the service's rel_path
+// withoutExperiments returns md without the endpoints that are experiments,
+// as they're alternate implementations of other endpoints that clients
+// call through the regular endpoint.
+func withoutExperiments(md *meta.Data) *meta.Data {
+	hasExperiments := slices.ContainsFunc(md.Svcs, func(svc *meta.Service) bool {
+		return slices.ContainsFunc(svc.Rpcs, func(rpc *meta.RPC) bool { return rpc.Experiment != nil })
+	})
+	if !hasExperiments {
+		return md
+	}
+
+	md = proto.Clone(md).(*meta.Data)
+	for _, svc := range md.Svcs {
+		svc.Rpcs = slices.DeleteFunc(svc.Rpcs, func(rpc *meta.RPC) bool { return rpc.Experiment != nil })
+	}
+	return md
+}
+
 func getServiceDoc(md *meta.Data, svc *meta.Service) string {
 	for _, pkg := range md.Pkgs {
 		if pkg.RelPath == svc.RelPath {
@@ -78,6 +78,15 @@ func TestClientCodeGenerationFromGoApp(t *testing.T) {
 							language, ok = LangOpenAPI, true
 						}
 						c.Assert(ok, qt.IsTrue, qt.Commentf("Unable to detect language type for %s", file.Name()))
+						options := clientgentypes.Options{}
+						if strings.Contains(file.Name(), "reactquery") {
+							options.TSHooks = clientgentypes.TSHooksReactQuery
+						} else if strings.Contains(file.Name(), "swr") {
+							options.TSHooks = clientgentypes.TSHooksSWR
+						}
+						if strings.Contains(file.Name(), "zod") {
+							options.TSSchemas = clientgentypes.TSSchemasZod
+						}
 
 						services := clientgentypes.AllServices(res.Meta)
 
@@ -87,7 +96,7 @@ func TestClientCodeGenerationFromGoApp(t *testing.T) {
 							res.Meta,
 							services,
 							clientgentypes.TagSet{},
-							clientgentypes.Options{},
+							options,
 						)
 						c.Assert(err, qt.IsNil)
 
@@ -12,8 +12,33 @@ type Options struct {
 	OpenAPIExcludePrivateEndpoints bool
 	TSSharedTypes                  bool
 	TSClientTarget                 string
+
+	// TSHooks is the data fetching library to generate React hooks
+	// for in TypeScript clients, if any.
+	TSHooks TSHooks
+
+	// TSSchemas is the validation library to generate schemas
+	// for the request types with in TypeScript clients, if any.
+	TSSchemas TSSchemas
 }
 
+// TSHooks is a data fetching library the TypeScript client can generate React hooks for.
+type TSHooks string
+
+const (
+	TSHooksNone       TSHooks = ""
+	TSHooksReactQuery TSHooks = "react-query"
+	TSHooksSWR        TSHooks = "swr"
+)
+
+// TSSchemas is a validation library the TypeScript client can generate request schemas for.
+type TSSchemas string
+
+const (
+	TSSchemasNone TSSchemas = ""
+	TSSchemasZod  TSSchemas = "zod"
+)
+
 type GenerateParams struct {
 	Buf      *bytes.Buffer
 	AppSlug  string
@@ -350,13 +350,13 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 		}
 
 		// Add the documentation for the API to the interface method
-		if rpc.Doc != nil && !g.skipDocs {
+		if (rpc.Doc != nil && !g.skipDocs) || rpc.Deprecated != nil {
 			// Add a newline if this is not the first method
 			if len(interfaceMethods) > 0 {
 				interfaceMethods = append(interfaceMethods, Line())
 			}
 
-			for _, line := range strings.Split(strings.TrimSpace(*rpc.Doc), "\n") {
+			for _, line := range g.docLines(rpc.GetDoc(), rpc.Deprecated) {
 				interfaceMethods = append(interfaceMethods, Comment(line))
 			}
 		}
@@ -386,8 +386,8 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 			continue
 		}
 
-		if rpc.Doc != nil && *rpc.Doc != "" && !g.skipDocs {
-			for _, line := range strings.Split(strings.TrimSpace(*rpc.Doc), "\n") {
+		if (rpc.Doc != nil && *rpc.Doc != "" && !g.skipDocs) || rpc.Deprecated != nil {
+			for _, line := range g.docLines(rpc.GetDoc(), rpc.Deprecated) {
 				if line != "" {
 					file.Comment(line)
 				}
@@ -941,10 +941,12 @@ func (g *golang) getType(typ *schema.Type) Code {
 			}
 
 			// Add the docs for the field
-			if field.Doc != "" && !g.skipDocs {
-				lines := strings.Split(strings.TrimSpace(field.Doc), "\n")
+			if (field.Doc != "" && !g.skipDocs) || field.Deprecated != nil {
+				lines := g.docLines(field.Doc, field.Deprecated)
 
-				if len(lines) == 1 {
+				// Deprecation notices must be in a doc comment above the field
+				// for tools to recognize them, so never use a line comment for them.
+				if len(lines) == 1 && field.Deprecated == nil {
 					fieldTyp = fieldTyp.Comment(lines[0])
 				} else {
 					fields = append(fields, Line())
@@ -1578,3 +1580,30 @@ func (g *golang) addAuthData(grp *Group) (err error) {
 
 	return
 }
+
+// docLines returns the lines of the Go doc comment for the given doc string.
+// If deprecated is non-nil a "Deprecated:" paragraph is added unless the doc
+// already contains one, so editors and linters warn about uses of the deprecated API.
+// The deprecation notice is included even if docs are skipped.
+func (g *golang) docLines(doc string, deprecated *string) []string {
+	doc = strings.TrimSpace(doc)
+	if g.skipDocs {
+		doc = ""
+	}
+
+	var lines []string
+	if doc != "" || deprecated == nil {
+		lines = strings.Split(doc, "\n")
+	}
+	if deprecated != nil && !strings.HasPrefix(doc, "Deprecated:") && !strings.Contains(doc, "\n\nDeprecated:") {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		notice := "Deprecated: " + *deprecated
+		if *deprecated == "" {
+			notice = "Deprecated: this API is deprecated."
+		}
+		lines = append(lines, notice)
+	}
+	return lines
+}
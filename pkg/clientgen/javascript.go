@@ -196,14 +196,13 @@ func (js *javascript) writeService(svc *meta.Service, set clientgentypes.Service
 		js.WriteByte('\n')
 
 		// Doc string
-		if rpc.Doc != nil && *rpc.Doc != "" {
-			scanner := bufio.NewScanner(strings.NewReader(*rpc.Doc))
+		if lines := jsDocLines(rpc.GetDoc(), rpc.Deprecated); len(lines) > 0 {
 			indent()
 			js.WriteString("/**\n")
-			for scanner.Scan() {
+			for _, line := range lines {
 				indent()
 				js.WriteString(" * ")
-				js.WriteString(scanner.Text())
+				js.WriteString(line)
 				js.WriteByte('\n')
 			}
 			indent()
@@ -142,6 +142,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 		Description: desc,
 		OperationID: method + ":" + rpc.ServiceName + "." + rpc.Name,
 		Responses:   make(openapi3.Responses),
+		Deprecated:  rpc.Deprecated != nil,
 	}
 
 	// Add path parameters
@@ -180,7 +181,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 				Explode:         ptr(true),
 				AllowEmptyValue: true,
 				AllowReserved:   false,
-				Deprecated:      false,
+				Deprecated:      param.Deprecated != nil,
 				Required:        !param.Optional,
 				Schema:          g.schemaType(param.Type),
 				Example:         nil,
@@ -201,7 +202,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 				Explode:         ptr(true),
 				AllowEmptyValue: true,
 				AllowReserved:   false,
-				Deprecated:      false,
+				Deprecated:      param.Deprecated != nil,
 				Required:        !param.Optional,
 				Schema:          g.schemaType(param.Type),
 				Example:         nil,
@@ -220,6 +221,14 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 				Content:     g.bodyContent(reqEnc.BodyParameters),
 			},
 		}
+	} else if len(reqEnc.FormParameters) > 0 {
+		op.RequestBody = &openapi3.RequestBodyRef{
+			Value: &openapi3.RequestBody{
+				Description: "",
+				Required:    false,
+				Content:     g.formContent(reqEnc.FormParameters),
+			},
+		}
 	}
 
 	// Encode the response
@@ -239,7 +248,7 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 						Explode:         ptr(true),
 						AllowEmptyValue: true,
 						AllowReserved:   false,
-						Deprecated:      false,
+						Deprecated:      param.Deprecated != nil,
 						Required:        !param.Optional,
 						Schema:          g.schemaType(param.Type),
 						Example:         nil,
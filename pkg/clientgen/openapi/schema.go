@@ -14,6 +14,16 @@ import (
 )
 
 func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.Content {
+	return g.content("application/json", params)
+}
+
+// formContent is like bodyContent but for form parameters,
+// which are sent as multipart/form-data.
+func (g *Generator) formContent(params []*encoding.ParameterEncoding) openapi3.Content {
+	return g.content("multipart/form-data", params)
+}
+
+func (g *Generator) content(mediaType string, params []*encoding.ParameterEncoding) openapi3.Content {
 	if len(params) == 0 {
 		return nil
 	}
@@ -25,7 +35,7 @@ func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.C
 		if vv := val.Value; vv != nil {
 			vv.Title, vv.Description = splitDoc(p.Doc)
 		}
-		props[p.WireFormat] = val
+		props[p.WireFormat] = deprecatedSchema(val, p.Deprecated != nil)
 		if !p.Optional {
 			required = append(required, p.WireFormat)
 		}
@@ -36,7 +46,7 @@ func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.C
 	s.Required = required
 
 	return openapi3.Content{
-		"application/json": &openapi3.MediaType{
+		mediaType: &openapi3.MediaType{
 			Schema:   s.NewRef(),
 			Example:  nil,
 			Examples: nil,
@@ -45,6 +55,18 @@ func (g *Generator) bodyContent(params []*encoding.ParameterEncoding) openapi3.C
 	}
 }
 
+// deprecatedSchema marks the schema as deprecated if deprecated is true.
+// References to named types are wrapped in an allOf schema, like for descriptions.
+func deprecatedSchema(val *openapi3.SchemaRef, deprecated bool) *openapi3.SchemaRef {
+	if !deprecated {
+		return val
+	} else if val.Value == nil {
+		val = &openapi3.SchemaRef{Value: &openapi3.Schema{AllOf: []*openapi3.SchemaRef{val}}}
+	}
+	val.Value.Deprecated = true
+	return val
+}
+
 func (g *Generator) schemaType(typ *schema.Type) *openapi3.SchemaRef {
 	switch t := typ.Typ.(type) {
 	// A type switch for all the different schema types we support
@@ -85,7 +107,7 @@ func (g *Generator) schemaType(typ *schema.Type) *openapi3.SchemaRef {
 					},
 				}
 			}
-			props[jsonName] = val
+			props[jsonName] = deprecatedSchema(val, f.Deprecated != nil)
 		}
 
 		s := openapi3.NewObjectSchema()
@@ -254,6 +276,8 @@ func (g *Generator) builtinSchemaType(t schema.Builtin) *openapi3.Schema {
 		return openapi3.NewStringSchema()
 	case schema.Builtin_DECIMAL:
 		return openapi3.NewStringSchema()
+	case schema.Builtin_FILE:
+		return openapi3.NewStringSchema().WithFormat("binary")
 	default:
 		doBailout(errors.Newf("unknown builtin type %v", t))
 		panic("unreachable")
@@ -0,0 +1,67 @@
+-- go.mod --
+module app
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import (
+    "context"
+    "net/http"
+)
+
+type ListParams struct {
+    Limit int `query:"limit"`
+}
+
+type Item struct {
+    ID   string
+    Name string
+}
+
+type ListResponse struct {
+    Items []Item
+}
+
+type CreateParams struct {
+    Name string
+}
+
+// List lists the items.
+//encore:api public method=GET path=/items
+func List(ctx context.Context, p *ListParams) (*ListResponse, error) {
+    return nil, nil
+}
+
+//encore:api public method=GET path=/items/:id
+func Get(ctx context.Context, id string) (*Item, error) {
+    return nil, nil
+}
+
+// Count counts the items.
+//
+// Deprecated: use List instead.
+//encore:api public method=GET path=/count
+func Count(ctx context.Context) (*ListResponse, error) {
+    return nil, nil
+}
+
+//encore:api public method=POST path=/items
+func Create(ctx context.Context, p *CreateParams) (*Item, error) {
+    return nil, nil
+}
+
+//encore:api public method=PUT path=/items/:id
+func Update(ctx context.Context, id string, p *CreateParams) (*Item, error) {
+    return nil, nil
+}
+
+//encore:api public method=POST path=/reset
+func Reset(ctx context.Context) error {
+    return nil
+}
+
+//encore:api public raw path=/webhook
+func Webhook(w http.ResponseWriter, req *http.Request) {}
@@ -0,0 +1,34 @@
+-- go.mod --
+module app
+
+-- encore.app --
+{"id": ""}
+
+-- svc/migrations/1_create_table.up.sql --
+CREATE TABLE exports (id TEXT PRIMARY KEY);
+
+-- svc/svc.go --
+package svc
+
+import (
+    "context"
+
+    "encore.dev/operations"
+    "encore.dev/storage/sqldb"
+)
+
+var db = sqldb.NewDatabase("svc", sqldb.DatabaseConfig{Migrations: "./migrations"})
+
+var Exports = operations.NewTracker("exports", operations.TrackerConfig{DB: db})
+
+var Reindexes = operations.NewTracker("reindexes", operations.TrackerConfig{DB: db, Private: true})
+
+type Response struct {
+    OperationID string
+}
+
+// StartExport starts an export.
+//encore:api public
+func StartExport(ctx context.Context) (*Response, error) {
+    return nil, nil
+}
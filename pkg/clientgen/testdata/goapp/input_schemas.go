@@ -0,0 +1,81 @@
+-- go.mod --
+module app
+
+require encore.dev v1.52.1
+
+-- encore.app --
+{"id": ""}
+
+-- svc/svc.go --
+package svc
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "encore.dev/types/option"
+    "encore.dev/types/uuid"
+
+    "app/shared"
+)
+
+type Node struct {
+    Name     string
+    Children []*Node
+}
+
+type CreateParams struct {
+    Name     string            `json:"name"`
+    Nickname string            `json:"nickname,omitempty"`
+    Age      uint8             `json:"age"`
+    Score    float64           `json:"score"`
+    Tags     []string          `json:"tags"`
+    Labels   map[string]int    `json:"labels"`
+    Owner    uuid.UUID         `json:"owner"`
+    Due      *time.Time        `json:"due"`
+    Note     option.Option[string] `json:"note"`
+    Extra    json.RawMessage   `json:"extra"`
+    Tree     *Node             `json:"tree"`
+    Address  shared.Address    `json:"address"`
+    Trace    string            `header:"X-Trace"`
+    Session  string            `cookie:"session"`
+    Internal string            `json:"-"`
+}
+
+type Page[T any] struct {
+    Cursor string `query:"cursor"`
+    Filter T      `query:"filter"`
+}
+
+type Item struct {
+    ID string
+}
+
+//encore:api public method=POST path=/items
+func Create(ctx context.Context, p *CreateParams) (*Item, error) {
+    return nil, nil
+}
+
+//encore:api public method=GET path=/items
+func List(ctx context.Context, p *Page[string]) (*Item, error) {
+    return nil, nil
+}
+
+//encore:api public method=GET path=/items/:id
+func Get(ctx context.Context, id string) (*Item, error) {
+    return nil, nil
+}
+
+//encore:api private method=POST path=/internal
+func Internal(ctx context.Context, p *Item) error {
+    return nil
+}
+
+-- shared/shared.go --
+package shared
+
+type Address struct {
+    Street string
+    Zip    string
+}
@@ -65,6 +65,10 @@ type typescript struct {
 	generatorVersion tsGenVersion
 	sharedTypes      bool
 	clientTarget     string
+	hooks            clientgentypes.TSHooks
+	schemas          clientgentypes.TSSchemas
+
+	hookEndpoints []*tsHookEndpoint // endpoints to generate hooks for, in order
 
 	seenJSON           bool // true if a JSON type was seen
 	seenStream         bool // true if a stream endpoint was seen
@@ -120,10 +124,13 @@ func (ts *typescript) Generate(p clientgentypes.GenerateParams) (err error) {
 	}
 	ts.writeExtraTypes()
 	ts.writeStreamClasses()
+	ts.writeOperationsClient()
 	if err := ts.writeBaseClient(p.AppSlug); err != nil {
 		return err
 	}
 	ts.writeCustomErrorType()
+	ts.writeHooks()
+	ts.writeSchemas(p.Services, p.Tags)
 
 	if ts.clientTarget != "" {
 		fmt.Fprintf(ts, `
@@ -312,14 +319,13 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 		ts.WriteByte('\n')
 
 		// Doc string
-		if rpc.Doc != nil && *rpc.Doc != "" {
-			scanner := bufio.NewScanner(strings.NewReader(*rpc.Doc))
+		if lines := jsDocLines(rpc.GetDoc(), rpc.Deprecated); len(lines) > 0 {
 			indent()
 			ts.WriteString("/**\n")
-			for scanner.Scan() {
+			for _, line := range lines {
 				indent()
 				ts.WriteString(" * ")
-				ts.WriteString(scanner.Text())
+				ts.WriteString(line)
 				ts.WriteByte('\n')
 			}
 			indent()
@@ -329,8 +335,10 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 		// Signature
 		indent()
 		fmt.Fprintf(ts, "public async %s(", ts.memberName(rpc.Name))
+		paramsStart := ts.Len()
 
 		isRaw := rpc.Proto == meta.RPC_RAW
+		var args []string // the names of the parameters
 
 		if isRaw && !ts.sharedTypes {
 			fmt.Fprintf(ts, "method: %s, ", getMethodType(rpc))
@@ -348,6 +356,7 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 		var inlinePathParams = (isRaw || (rpc.RequestSchema == nil && !hasHandshake)) && hasPathParams(rpc) && ts.sharedTypes
 		if inlinePathParams {
 			ts.WriteString(payloadName + ": { ")
+			args = append(args, payloadName)
 		}
 		var rpcPath strings.Builder
 		for _, s := range rpc.Path.Segments {
@@ -360,6 +369,9 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 
 					ts.WriteString(ts.nonReservedId(s.Value))
 					ts.WriteString(": ")
+					if !inlinePathParams {
+						args = append(args, ts.nonReservedId(s.Value))
+					}
 					switch s.ValueType {
 					case meta.PathSegment_STRING, meta.PathSegment_UUID:
 						ts.WriteString("string")
@@ -394,6 +406,7 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 				ts.WriteString(", ")
 			}
 			ts.WriteString(payloadName + ": ")
+			args = append(args, payloadName)
 			if ts.sharedTypes {
 				fmt.Fprintf(ts, "RequestType<typeof %s>", rpcImportName(rpc))
 			} else if isStream {
@@ -447,7 +460,9 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 			}
 		}
 
+		params := string(ts.Bytes()[paramsStart:])
 		ts.WriteString("): Promise<")
+		resultStart := ts.Len()
 
 		if isStream {
 			ts.seenStream = true
@@ -480,6 +495,15 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 		} else {
 			ts.WriteString("void")
 		}
+		if ts.hooks != clientgentypes.TSHooksNone && !isRaw {
+			ts.hookEndpoints = append(ts.hookEndpoints, &tsHookEndpoint{
+				rpc:       rpc,
+				params:    params,
+				args:      args,
+				result:    string(ts.Bytes()[resultStart:]),
+				direction: direction,
+			})
+		}
 		ts.WriteString("> {\n")
 
 		if isStream {
@@ -640,6 +664,7 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 	headers := ""
 	query := ""
 	body := ""
+	isForm := false
 
 	if rpc.RequestSchema != nil {
 		reqEnc := rpcEncoding.DefaultRequestEncoding
@@ -718,10 +743,40 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 				w.WriteString("\n\n")
 			}
 		}
+
+		// Generate the form body
+		if len(reqEnc.FormParameters) > 0 {
+			body = "form"
+			isForm = true
+
+			w.WriteString("// Construct the form with the fields sent in the request body\nconst form = new FormData()\n")
+			for _, field := range reqEnc.FormParameters {
+				ref := ts.Dot("params", field.SrcName)
+				isFile, isList := encoding.FormFieldKind(field.Type)
+				if isList {
+					val := "v"
+					if !isFile {
+						val = ts.convertBuiltinToString(field.Type.GetList().Elem.GetBuiltin(), "v", false)
+					}
+					w.WriteStringf("%s?.forEach((v) => form.append(%q, %s))\n", ref, field.WireFormat, val)
+				} else {
+					val := ref
+					if !isFile {
+						val = ts.convertBuiltinToString(field.Type.GetBuiltin(), ref, false)
+					}
+					w.WriteStringf("if (%s !== undefined) form.append(%q, %s)\n", ref, field.WireFormat, val)
+				}
+			}
+			w.WriteString("\n")
+		}
 	}
 
-	// Build the call to callTypedAPI
+	// Build the call to callTypedAPI, or callAPI for form requests
+	// (so the content type, including the multipart boundary, is set by fetch).
 	callAPI := "this.baseClient.callTypedAPI("
+	if isForm {
+		callAPI = "this.baseClient.callAPI("
+	}
 	if !ts.sharedTypes {
 		callAPI += fmt.Sprintf("\"%s\", ", rpcEncoding.DefaultMethod)
 	}
@@ -1135,6 +1190,9 @@ export %sclass Client {
 				w.WriteStringf("public readonly %s: %s.ServiceClient\n", ts.memberName(svc.Name), ts.typeName(svc.Name))
 			}
 		}
+		if len(ts.operationTrackers()) > 0 {
+			w.WriteString("public readonly operations: OperationsClient\n")
+		}
 		w.WriteString("private readonly options: ClientOptions\n")
 		w.WriteString("private readonly target: string\n")
 		w.WriteString("\n")
@@ -1190,6 +1248,9 @@ if (typeof options === "string") {
 					w.WriteStringf("this.%s = new %s.ServiceClient(base)\n", ts.memberName(svc.Name), ts.typeName(svc.Name))
 				}
 			}
+			if len(ts.operationTrackers()) > 0 {
+				w.WriteString("this.operations = new OperationsClient(base)\n")
+			}
 		}
 		w.WriteString("}\n")
 	}
@@ -1598,6 +1659,530 @@ class BaseClient {
 	return nil
 }
 
+// operationTrackers returns the operation trackers
+// whose operations are served to clients.
+func (ts *typescript) operationTrackers() []*meta.OperationTracker {
+	var trackers []*meta.OperationTracker
+	for _, t := range ts.md.OperationTrackers {
+		if !t.Private {
+			trackers = append(trackers, t)
+		}
+	}
+	return trackers
+}
+
+// writeOperationsClient writes the client polling the status of
+// long-running operations, if the app has operation trackers.
+func (ts *typescript) writeOperationsClient() {
+	trackers := ts.operationTrackers()
+	if len(trackers) == 0 {
+		return
+	}
+
+	names := make([]string, len(trackers))
+	for i, t := range trackers {
+		names[i] = ts.Quote(t.Name)
+	}
+	callAPI := "this.baseClient.callTypedAPI(\"GET\", path)"
+	if ts.sharedTypes {
+		callAPI = "this.baseClient.callTypedAPI(path, { method: \"GET\" })"
+	}
+
+	fmt.Fprintf(ts, `
+/**
+ * OperationTracker is the name of an operation tracker of the application.
+ */
+export type OperationTracker = %s
+
+/**
+ * OperationState is the state of a long-running operation.
+ */
+export type OperationState = "pending" | "running" | "succeeded" | "failed"
+
+/**
+ * OperationStatus is the status of a long-running operation.
+ */
+export interface OperationStatus<Metadata = unknown, Result = unknown> {
+    id: string
+    tracker: OperationTracker
+    state: OperationState
+    progress: {
+        done: number
+        total: number
+        message?: string
+    }
+    metadata?: Metadata
+    result?: Result
+    error?: string
+    created_at: string
+    updated_at: string
+}
+
+/**
+ * WaitOptions configures how OperationsClient.wait polls an operation.
+ */
+export interface WaitOptions<Metadata = unknown, Result = unknown> {
+    /** How often to poll the status of the operation, in milliseconds. Defaults to 1000. */
+    interval?: number
+
+    /** Called with the status of the operation every time it's polled. */
+    onProgress?: (status: OperationStatus<Metadata, Result>) => void
+
+    /** Stops waiting for the operation when aborted. */
+    signal?: AbortSignal
+}
+
+/**
+ * OperationsClient fetches the status of long-running operations.
+ */
+export class OperationsClient {
+    private baseClient: BaseClient
+
+    constructor(baseClient: BaseClient) {
+        this.baseClient = baseClient
+    }
+
+    /**
+     * Returns the status of an operation.
+     */
+    public async get<Metadata = unknown, Result = unknown>(tracker: OperationTracker, id: string): Promise<OperationStatus<Metadata, Result>> {
+        const path = `+"`/__encore/operations/${encodeURIComponent(tracker)}/${encodeURIComponent(id)}`"+`
+        const resp = await %s
+        return await resp.json() as OperationStatus<Metadata, Result>
+    }
+
+    /**
+     * Polls the status of an operation until it has succeeded or failed,
+     * and returns its final status.
+     */
+    public async wait<Metadata = unknown, Result = unknown>(tracker: OperationTracker, id: string, options?: WaitOptions<Metadata, Result>): Promise<OperationStatus<Metadata, Result>> {
+        const interval = options?.interval ?? 1000
+        for (;;) {
+            options?.signal?.throwIfAborted()
+            const status = await this.get<Metadata, Result>(tracker, id)
+            options?.onProgress?.(status)
+            if (status.state === "succeeded" || status.state === "failed") {
+                return status
+            }
+            await new Promise((resolve) => setTimeout(resolve, interval))
+        }
+    }
+}
+`, strings.Join(names, " | "), callAPI)
+}
+
+// tsHookEndpoint describes an endpoint to generate a hook for.
+type tsHookEndpoint struct {
+	rpc       *meta.RPC
+	params    string   // the parameter list of the client method
+	args      []string // the names of the client method's parameters
+	result    string   // the type the client method's promise resolves to
+	direction streamDirection
+}
+
+// kind reports what kind of hook to generate for the endpoint.
+func (e *tsHookEndpoint) kind() (query, mutation, subscription bool) {
+	rpc := e.rpc
+	switch {
+	case rpc.StreamingRequest || rpc.StreamingResponse:
+		// Only streams from the server can be subscribed to.
+		return false, false, e.direction == In
+	case rpc.ResponseSchema == nil || len(rpc.HttpMethods) == 0:
+		return false, true, false
+	}
+	for _, m := range rpc.HttpMethods {
+		if m != "GET" && m != "HEAD" {
+			return false, true, false
+		}
+	}
+	return true, false, false
+}
+
+// hookName is the name of the hook calling the endpoint.
+func (ts *typescript) hookName(rpc *meta.RPC) string {
+	name := []rune(ts.memberName(rpc.Name))
+	name[0] = unicode.ToUpper(name[0])
+	return "use" + string(name)
+}
+
+// writeHooks writes React hooks calling the endpoints with
+// the data fetching library selected by ts.hooks, if any.
+func (ts *typescript) writeHooks() {
+	if ts.hooks == clientgentypes.TSHooksNone || len(ts.hookEndpoints) == 0 {
+		return
+	}
+
+	hasSubscription := false
+	for _, e := range ts.hookEndpoints {
+		if _, _, sub := e.kind(); sub {
+			hasSubscription = true
+		}
+	}
+
+	w := ts.newIdentWriter(0)
+	w.WriteString("\n")
+	if hasSubscription {
+		w.WriteString("import { createContext, useContext, useEffect, useRef, useState } from \"react\"\n")
+	} else {
+		w.WriteString("import { createContext, useContext } from \"react\"\n")
+	}
+
+	switch ts.hooks {
+	case clientgentypes.TSHooksReactQuery:
+		w.WriteString(`import {
+    useMutation,
+    useQuery,
+    type UseMutationOptions,
+    type UseMutationResult,
+    type UseQueryOptions,
+    type UseQueryResult,
+} from "@tanstack/react-query"
+`)
+	case clientgentypes.TSHooksSWR:
+		w.WriteString(`import useSWR, { type SWRConfiguration, type SWRResponse } from "swr"
+import useSWRMutation, { type SWRMutationConfiguration, type SWRMutationResponse } from "swr/mutation"
+`)
+	}
+
+	w.WriteString(`
+const ClientContext = createContext<Client | undefined>(undefined)
+
+/**
+ * ClientProvider provides the client the hooks call the API with
+ * to the components it's rendered around.
+ */
+export const ClientProvider = ClientContext.Provider
+
+/**
+ * useClient returns the client provided by the nearest ClientProvider.
+ */
+export function useClient(): Client {
+    const client = useContext(ClientContext)
+    if (client === undefined) {
+        throw new Error("useClient must be used within a ClientProvider")
+    }
+    return client
+}
+`)
+
+	switch ts.hooks {
+	case clientgentypes.TSHooksReactQuery:
+		w.WriteString(`
+/**
+ * QueryOptions are the options of the hooks fetching data, such as enabled and staleTime.
+ */
+export type QueryOptions<Response> = Omit<UseQueryOptions<Response, APIError>, "queryKey" | "queryFn">
+
+/**
+ * MutationOptions are the options of the hooks changing data, such as onSuccess.
+ */
+export type MutationOptions<Response, Variables> = Omit<UseMutationOptions<Response, APIError, Variables>, "mutationFn">
+`)
+	case clientgentypes.TSHooksSWR:
+		w.WriteString(`
+/**
+ * QueryOptions are the options of the hooks fetching data.
+ */
+export type QueryOptions<Response> = SWRConfiguration<Response, APIError> & {
+    /** Whether to fetch the data. Defaults to true. */
+    enabled?: boolean
+}
+
+/**
+ * MutationKey is the cache key of the hooks changing data, of the form [service, endpoint].
+ */
+export type MutationKey = readonly [string, string]
+
+/**
+ * MutationOptions are the options of the hooks changing data, such as onSuccess.
+ */
+export type MutationOptions<Response, Variables> = SWRMutationConfiguration<Response, APIError, MutationKey, Variables>
+`)
+	}
+
+	if hasSubscription {
+		ts.writeSubscriptionHook(w)
+	}
+
+	// Group the endpoints by service, in the order of the services.
+	bySvc := make(map[string][]*tsHookEndpoint)
+	for _, e := range ts.hookEndpoints {
+		bySvc[e.rpc.ServiceName] = append(bySvc[e.rpc.ServiceName], e)
+	}
+	var svcs []string
+	for _, svc := range ts.md.Svcs {
+		if len(bySvc[svc.Name]) > 0 {
+			svcs = append(svcs, svc.Name)
+		}
+	}
+
+	// Cache keys.
+	w.WriteString(`
+/**
+ * queryKeys are the cache keys of the hooks fetching data,
+ * of the form [service, endpoint, ...parameters].
+`)
+	switch ts.hooks {
+	case clientgentypes.TSHooksReactQuery:
+		w.WriteString(` * Keys match the queries they're a prefix of, so invalidating
+ * queryKeys.<service>.all invalidates all queries of the service.
+`)
+	case clientgentypes.TSHooksSWR:
+		w.WriteString(` * To revalidate all data of a service, call mutate with a filter
+ * matching the keys starting with queryKeys.<service>.all.
+`)
+	}
+	w.WriteString(" */\nexport const queryKeys = {\n")
+	{
+		w := w.Indent()
+		for _, svc := range svcs {
+			svcName := ts.memberName(svc)
+			w.WriteStringf("%s: {\n", svcName)
+			{
+				w := w.Indent()
+				w.WriteStringf("all: [%s] as const,\n", ts.Quote(svc))
+				for _, e := range bySvc[svc] {
+					if query, _, sub := e.kind(); !query && !sub {
+						continue
+					}
+					name := ts.memberName(e.rpc.Name)
+					w.WriteStringf("%s: (...params: Parameters<%s.ServiceClient[%s]>) => [%s, %s, ...params] as const,\n",
+						name, ts.typeName(svc), ts.Quote(name), ts.Quote(svc), ts.Quote(e.rpc.Name))
+				}
+			}
+			w.WriteString("},\n")
+		}
+	}
+	w.WriteString("}\n")
+
+	// Hooks, in the namespaces of their services.
+	for _, svc := range svcs {
+		w.WriteStringf("\nexport namespace %s {\n", ts.typeName(svc))
+		for i, e := range bySvc[svc] {
+			if i > 0 {
+				w.WriteString("\n")
+			}
+			ts.writeHook(w.Indent(), e)
+		}
+		w.WriteString("}\n")
+	}
+}
+
+// writeHook writes the hook calling the endpoint e.
+func (ts *typescript) writeHook(w *indentWriter, e *tsHookEndpoint) {
+	rpc := e.rpc
+	name := ts.memberName(rpc.Name)
+	call := fmt.Sprintf("client.%s.%s", ts.memberName(rpc.ServiceName), name)
+	key := fmt.Sprintf("queryKeys.%s.%s", ts.memberName(rpc.ServiceName), name)
+	args := strings.Join(e.args, ", ")
+	params := e.params
+	if params != "" {
+		params += ", "
+	}
+
+	query, _, sub := e.kind()
+
+	var doc []string
+	switch {
+	case query:
+		doc = append(doc, fmt.Sprintf("%s fetches the response of %s, cached under %s.", ts.hookName(rpc), name, key))
+	case sub:
+		doc = append(doc, fmt.Sprintf("%s subscribes to the messages streamed by %s while mounted.", ts.hookName(rpc), name))
+	default:
+		doc = append(doc, fmt.Sprintf("%s returns a mutation calling %s.", ts.hookName(rpc), name))
+	}
+	if rpc.Deprecated != nil {
+		doc = append(doc, "", strings.TrimSpace("@deprecated "+*rpc.Deprecated))
+	}
+	w.WriteString("/**\n")
+	for _, line := range doc {
+		w.WriteString(strings.TrimRight(" * "+line, " ") + "\n")
+	}
+	w.WriteString(" */\n")
+
+	switch {
+	case sub:
+		msg := strings.TrimSuffix(strings.TrimPrefix(e.result, "StreamIn<"), ">")
+		w.WriteStringf("export function %s(%soptions?: SubscriptionOptions<%s>): Subscription<%s> {\n", ts.hookName(rpc), params, msg, msg)
+		{
+			w := w.Indent()
+			w.WriteString("const client = useClient()\n")
+			w.WriteStringf("return useSubscription(%s(%s), () => %s(%s), options)\n", key, args, call, args)
+		}
+		w.WriteString("}\n")
+
+	case query:
+		switch ts.hooks {
+		case clientgentypes.TSHooksReactQuery:
+			w.WriteStringf("export function %s(%soptions?: QueryOptions<%s>): UseQueryResult<%s, APIError> {\n", ts.hookName(rpc), params, e.result, e.result)
+			{
+				w := w.Indent()
+				w.WriteString("const client = useClient()\n")
+				w.WriteString("return useQuery({\n")
+				{
+					w := w.Indent()
+					w.WriteString("...options,\n")
+					w.WriteStringf("queryKey: %s(%s),\n", key, args)
+					w.WriteStringf("queryFn: () => %s(%s),\n", call, args)
+				}
+				w.WriteString("})\n")
+			}
+			w.WriteString("}\n")
+		case clientgentypes.TSHooksSWR:
+			w.WriteStringf("export function %s(%soptions?: QueryOptions<%s>): SWRResponse<%s, APIError> {\n", ts.hookName(rpc), params, e.result, e.result)
+			{
+				w := w.Indent()
+				w.WriteString("const client = useClient()\n")
+				w.WriteString("const enabled = options?.enabled ?? true\n")
+				w.WriteStringf("return useSWR(enabled ? %s(%s) : null, () => %s(%s), options)\n", key, args, call, args)
+			}
+			w.WriteString("}\n")
+		}
+
+	default:
+		// The variables of the mutation are the parameters of the endpoint:
+		// nothing, the single parameter, or a tuple of them.
+		var vars, fn string
+		switch len(e.args) {
+		case 0:
+			vars = "void"
+			fn = fmt.Sprintf("%s()", call)
+		case 1:
+			vars = fmt.Sprintf("Parameters<ServiceClient[%s]>[0]", ts.Quote(name))
+			fn = fmt.Sprintf("%s(%s)", call, e.args[0])
+		default:
+			vars = fmt.Sprintf("Parameters<ServiceClient[%s]>", ts.Quote(name))
+			fn = fmt.Sprintf("%s(...args)", call)
+		}
+
+		switch ts.hooks {
+		case clientgentypes.TSHooksReactQuery:
+			w.WriteStringf("export function %s(options?: MutationOptions<%s, %s>): UseMutationResult<%s, APIError, %s> {\n", ts.hookName(rpc), e.result, vars, e.result, vars)
+			{
+				w := w.Indent()
+				w.WriteString("const client = useClient()\n")
+				w.WriteStringf("return useMutation<%s, APIError, %s>({\n", e.result, vars)
+				{
+					w := w.Indent()
+					w.WriteString("...options,\n")
+					switch len(e.args) {
+					case 0:
+						w.WriteStringf("mutationFn: () => %s,\n", fn)
+					case 1:
+						w.WriteStringf("mutationFn: (%s) => %s,\n", e.args[0], fn)
+					default:
+						w.WriteStringf("mutationFn: (args) => %s,\n", fn)
+					}
+				}
+				w.WriteString("})\n")
+			}
+			w.WriteString("}\n")
+		case clientgentypes.TSHooksSWR:
+			w.WriteStringf("export function %s(options?: MutationOptions<%s, %s>): SWRMutationResponse<%s, APIError, MutationKey, %s> {\n", ts.hookName(rpc), e.result, vars, e.result, vars)
+			{
+				w := w.Indent()
+				w.WriteString("const client = useClient()\n")
+				w.WriteStringf("const key: MutationKey = [%s, %s]\n", ts.Quote(rpc.ServiceName), ts.Quote(rpc.Name))
+				switch len(e.args) {
+				case 0:
+					w.WriteStringf("return useSWRMutation<%s, APIError, MutationKey, %s>(key, () => %s, options)\n", e.result, vars, fn)
+				case 1:
+					w.WriteStringf("return useSWRMutation<%s, APIError, MutationKey, %s>(key, (_key: MutationKey, { arg: %s }: { arg: %s }) => %s, options)\n", e.result, vars, e.args[0], vars, fn)
+				default:
+					w.WriteStringf("return useSWRMutation<%s, APIError, MutationKey, %s>(key, (_key: MutationKey, { arg: args }: { arg: %s }) => %s, options)\n", e.result, vars, vars, fn)
+				}
+			}
+			w.WriteString("}\n")
+		}
+	}
+}
+
+// writeSubscriptionHook writes the hook the hooks subscribing to streams are built on.
+func (ts *typescript) writeSubscriptionHook(w *indentWriter) {
+	w.WriteString(`
+/**
+ * SubscriptionOptions are the options of the hooks subscribing to streams.
+ */
+export interface SubscriptionOptions<Message> {
+    /** Whether to open the stream. Defaults to true. */
+    enabled?: boolean
+
+    /** Called with each message received on the stream. */
+    onMessage?: (msg: Message) => void
+}
+
+/**
+ * Subscription is the state of a subscription to a stream.
+ */
+export interface Subscription<Message> {
+    /** The last message received on the stream, if any. */
+    data: Message | undefined
+
+    /** The error the stream failed with, if any. */
+    error: Error | undefined
+
+    /** Whether the stream is closed. */
+    done: boolean
+}
+
+/**
+ * useSubscription opens the stream returned by open while mounted,
+ * and reopens it when the key changes.
+ */
+function useSubscription<Message>(key: readonly unknown[], open: () => Promise<StreamIn<Message>>, options?: SubscriptionOptions<Message>): Subscription<Message> {
+    const [state, setState] = useState<Subscription<Message>>({ data: undefined, error: undefined, done: false })
+    const enabled = options?.enabled ?? true
+
+    // Call the latest callback without reopening the stream when it changes.
+    const onMessage = useRef(options?.onMessage)
+    onMessage.current = options?.onMessage
+
+    const keyHash = JSON.stringify(key)
+    useEffect(() => {
+        if (!enabled) {
+            return
+        }
+
+        let closed = false
+        let stream: StreamIn<Message> | undefined
+        setState({ data: undefined, error: undefined, done: false })
+
+        const receive = async () => {
+            try {
+                stream = await open()
+                if (closed) {
+                    stream.close()
+                    return
+                }
+                for await (const msg of stream) {
+                    if (closed) {
+                        return
+                    }
+                    onMessage.current?.(msg)
+                    setState((s) => ({ ...s, data: msg }))
+                }
+                if (!closed) {
+                    setState((s) => ({ ...s, done: true }))
+                }
+            } catch (err) {
+                if (!closed) {
+                    setState((s) => ({ ...s, error: err as Error, done: true }))
+                }
+            }
+        }
+        receive()
+
+        return () => {
+            closed = true
+            stream?.close()
+        }
+    }, [enabled, keyHash])
+
+    return state
+}
+`)
+}
+
 func (ts *typescript) writeExtraTypes() {
 	if ts.seenJSON {
 		ts.WriteString(`// JSONValue represents an arbitrary JSON value.
@@ -1722,6 +2307,8 @@ func (ts *typescript) builtinType(typ schema.Builtin) string {
 		return "string"
 	case schema.Builtin_DECIMAL:
 		return "string"
+	case schema.Builtin_FILE:
+		return "Blob"
 	default:
 		ts.errorf("unknown builtin type %v", typ)
 		return "any"
@@ -1880,14 +2467,14 @@ func (ts *typescript) renderTyp(buf *bytes.Buffer, ns string, tt *schema.Type, n
 
 		buf.WriteString("{\n")
 		for i, field := range fields {
-			if field.Doc != "" {
-				scanner := bufio.NewScanner(strings.NewReader(field.Doc))
+			lines := jsDocLines(field.Doc, field.Deprecated)
+			if len(lines) > 0 {
 				indent()
 				buf.WriteString("/**\n")
-				for scanner.Scan() {
+				for _, line := range lines {
 					indent()
 					buf.WriteString(" * ")
-					buf.WriteString(scanner.Text())
+					buf.WriteString(line)
 					buf.WriteByte('\n')
 				}
 				indent()
@@ -1906,7 +2493,7 @@ func (ts *typescript) renderTyp(buf *bytes.Buffer, ns string, tt *schema.Type, n
 
 			// Add another empty line if we have a doc comment
 			// and this was not the last field.
-			if field.Doc != "" && i < len(fields)-1 {
+			if len(lines) > 0 && i < len(fields)-1 {
 				buf.WriteByte('\n')
 			}
 		}
@@ -2389,3 +2976,25 @@ func stringIsOnly(str string, predicate func(r rune) bool) bool {
 	}
 	return true
 }
+
+// jsDocLines returns the lines of a JSDoc comment for the given doc string.
+// If deprecated is non-nil a @deprecated tag is added unless the doc already
+// has one, so editors and the TypeScript compiler warn about uses of the deprecated API.
+func jsDocLines(doc string, deprecated *string) []string {
+	var lines []string
+	hasTag := false
+	if doc != "" {
+		scanner := bufio.NewScanner(strings.NewReader(doc))
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+			hasTag = hasTag || strings.HasPrefix(strings.TrimSpace(scanner.Text()), "@deprecated")
+		}
+	}
+	if deprecated != nil && !hasTag {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, strings.TrimSpace("@deprecated "+*deprecated))
+	}
+	return lines
+}
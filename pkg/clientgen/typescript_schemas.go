@@ -0,0 +1,382 @@
+package clientgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/clientgen/clientgentypes"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// schemaName is the name of the schema validating the given declaration.
+func (ts *typescript) schemaName(decl *schema.Decl) string {
+	return ts.typeName(decl.Name) + "Schema"
+}
+
+// requestDecls returns the declarations used by the request types of the
+// endpoints included in the client, ordered so that declarations come after
+// those they reference, except for recursive references.
+func (ts *typescript) requestDecls(set clientgentypes.ServiceSet, tags clientgentypes.TagSet) []*schema.Decl {
+	var (
+		result []*schema.Decl
+		seen   = make(map[uint32]bool)
+		visit  func(typ *schema.Type)
+	)
+	visit = func(typ *schema.Type) {
+		if typ == nil {
+			return
+		}
+		switch t := typ.Typ.(type) {
+		case *schema.Type_Named:
+			for _, arg := range t.Named.TypeArguments {
+				visit(arg)
+			}
+			if !seen[t.Named.Id] {
+				seen[t.Named.Id] = true
+				decl := ts.md.Decls[t.Named.Id]
+				visit(decl.Type)
+				result = append(result, decl)
+			}
+		case *schema.Type_List:
+			visit(t.List.Elem)
+		case *schema.Type_Map:
+			visit(t.Map.Key)
+			visit(t.Map.Value)
+		case *schema.Type_Struct:
+			for _, f := range t.Struct.Fields {
+				visit(f.Typ)
+			}
+		case *schema.Type_Pointer:
+			visit(t.Pointer.Base)
+		case *schema.Type_Option:
+			visit(t.Option.Value)
+		case *schema.Type_Config:
+			visit(t.Config.Elem)
+		case *schema.Type_Union:
+			for _, tt := range t.Union.Types {
+				visit(tt)
+			}
+		}
+	}
+
+	for _, svc := range ts.md.Svcs {
+		if !set.Has(svc.Name) {
+			continue
+		}
+		for _, rpc := range svc.Rpcs {
+			if rpc.AccessType == meta.RPC_PRIVATE || !tags.IsRPCIncluded(rpc) || rpc.Proto == meta.RPC_RAW {
+				continue
+			}
+			visit(rpc.RequestSchema)
+		}
+	}
+	return result
+}
+
+// writeSchemas writes schemas for the request types of the endpoints
+// with the validation library selected by ts.schemas, if any,
+// so frontends can validate input with the same constraints as the backend.
+func (ts *typescript) writeSchemas(set clientgentypes.ServiceSet, tags clientgentypes.TagSet) {
+	if ts.schemas == clientgentypes.TSSchemasNone {
+		return
+	}
+	decls := ts.requestDecls(set, tags)
+	if len(decls) == 0 {
+		return
+	}
+
+	w := ts.newIdentWriter(0)
+	w.WriteString("\nimport { z } from \"zod\"\n")
+
+	// Group the declarations by namespace, keeping them ordered by their
+	// references within each. Schemas of declarations that haven't been
+	// written yet are referenced lazily.
+	sort.SliceStable(decls, func(i, j int) bool {
+		return decls[i].Loc.PkgName < decls[j].Loc.PkgName
+	})
+	written := make(map[uint32]bool)
+	ns := ""
+	for i, decl := range decls {
+		if i == 0 || decl.Loc.PkgName != ns {
+			if i > 0 {
+				w.WriteString("}\n")
+			}
+			ns = decl.Loc.PkgName
+			w.WriteStringf("\nexport namespace %s {\n", ts.typeName(ns))
+		} else {
+			w.WriteString("\n")
+		}
+
+		var buf bytes.Buffer
+		prev := ts.currDecl
+		ts.currDecl = decl
+		ts.renderSchema(&buf, ns, decl.Type, written, 0)
+		ts.currDecl = prev
+
+		iw := w.Indent()
+		name := ts.schemaName(decl)
+		if len(decl.TypeParams) == 0 {
+			iw.WriteStringf("export const %s: z.ZodType<%s> = %s\n", name, ts.typeName(decl.Name), buf.String())
+		} else {
+			// Generic declarations are functions taking the schemas of the
+			// type arguments, with the schema type inferred from them.
+			typeParams := make([]string, len(decl.TypeParams))
+			params := make([]string, len(decl.TypeParams))
+			for i, p := range decl.TypeParams {
+				typeParams[i] = p.Name + " extends z.ZodTypeAny"
+				params[i] = p.Name + ": " + p.Name
+			}
+			iw.WriteStringf("export function %s<%s>(%s) {\n", name, strings.Join(typeParams, ", "), strings.Join(params, ", "))
+			iw.Indent().WriteStringf("return %s\n", buf.String())
+			iw.WriteString("}\n")
+		}
+		written[decl.Id] = true
+	}
+	w.WriteString("}\n")
+}
+
+// renderSchema renders the schema validating typ.
+func (ts *typescript) renderSchema(buf *bytes.Buffer, ns string, tt *schema.Type, written map[uint32]bool, numIndents int) {
+	switch typ := tt.Typ.(type) {
+	case *schema.Type_Named:
+		decl := ts.md.Decls[typ.Named.Id]
+		var ref bytes.Buffer
+		if decl.Loc.PkgName != ns {
+			ref.WriteString(ts.typeName(decl.Loc.PkgName) + ".")
+		}
+		ref.WriteString(ts.schemaName(decl))
+		if len(typ.Named.TypeArguments) > 0 {
+			ref.WriteByte('(')
+			for i, arg := range typ.Named.TypeArguments {
+				if i > 0 {
+					ref.WriteString(", ")
+				}
+				ts.renderSchema(&ref, ns, arg, written, numIndents)
+			}
+			ref.WriteByte(')')
+		}
+
+		if written[decl.Id] {
+			buf.Write(ref.Bytes())
+		} else {
+			fmt.Fprintf(buf, "z.lazy(() => %s)", ref.String())
+		}
+
+	case *schema.Type_List:
+		buf.WriteString("z.array(")
+		ts.renderSchema(buf, ns, typ.List.Elem, written, numIndents)
+		buf.WriteString(")")
+
+	case *schema.Type_Map:
+		// Object keys are always strings in JSON.
+		buf.WriteString("z.record(z.string(), ")
+		ts.renderSchema(buf, ns, typ.Map.Value, written, numIndents)
+		buf.WriteString(")")
+
+	case *schema.Type_Builtin:
+		buf.WriteString(ts.builtinSchema(typ.Builtin))
+
+	case *schema.Type_Literal:
+		switch lit := typ.Literal.Value.(type) {
+		case *schema.Literal_Str:
+			fmt.Fprintf(buf, "z.literal(%s)", ts.Quote(lit.Str))
+		case *schema.Literal_Int:
+			fmt.Fprintf(buf, "z.literal(%d)", lit.Int)
+		case *schema.Literal_Float:
+			fmt.Fprintf(buf, "z.literal(%s)", strconv.FormatFloat(lit.Float, 'f', -1, 64))
+		case *schema.Literal_Boolean:
+			fmt.Fprintf(buf, "z.literal(%t)", lit.Boolean)
+		case *schema.Literal_Null:
+			buf.WriteString("z.null()")
+		default:
+			ts.errorf("unknown literal type %T", lit)
+		}
+
+	case *schema.Type_Pointer, *schema.Type_Option, *schema.Type_Union:
+		var cases []string
+		seen := make(map[string]bool)
+		for _, c := range ts.getUnionCases(tt) {
+			var caseBuf bytes.Buffer
+			ts.renderSchema(&caseBuf, ns, c, written, numIndents)
+			if s := caseBuf.String(); !seen[s] {
+				seen[s] = true
+				cases = append(cases, s)
+			}
+		}
+		if len(cases) == 1 {
+			buf.WriteString(cases[0])
+		} else {
+			fmt.Fprintf(buf, "z.union([%s])", strings.Join(cases, ", "))
+		}
+
+	case *schema.Type_Struct:
+		fields := make([]*schema.Field, 0, len(typ.Struct.Fields))
+		for _, f := range typ.Struct.Fields {
+			// Cookies are set by the browser, not the caller.
+			if f.Wire.GetCookie() != nil || encoding.IgnoreField(f) {
+				continue
+			}
+			fields = append(fields, f)
+		}
+		if len(fields) == 0 {
+			buf.WriteString("z.object({})")
+			break
+		}
+
+		buf.WriteString("z.object({\n")
+		for _, field := range fields {
+			buf.WriteString(strings.Repeat("    ", numIndents+1))
+			buf.WriteString(ts.QuoteIfRequired(ts.fieldNameInStruct(field)))
+			buf.WriteString(": ")
+			ts.renderSchema(buf, ns, field.Typ, written, numIndents+1)
+			if field.Optional || ts.isRecursive(field.Typ) {
+				buf.WriteString(".optional()")
+			}
+			buf.WriteString(",\n")
+		}
+		buf.WriteString(strings.Repeat("    ", numIndents))
+		buf.WriteString("})")
+
+	case *schema.Type_TypeParameter:
+		decl := ts.md.Decls[typ.TypeParameter.DeclId]
+		buf.WriteString(decl.TypeParams[typ.TypeParameter.ParamIdx].Name)
+
+	case *schema.Type_Config:
+		ts.renderSchema(buf, ns, typ.Config.Elem, written, numIndents)
+
+	default:
+		ts.errorf("unknown type %T", typ)
+	}
+
+	if tt.Validation != nil {
+		isDecimal := tt.GetBuiltin() == schema.Builtin_DECIMAL
+		for _, expr := range validationTerms(tt.Validation) {
+			cond, msg := ts.validationCond(expr, isDecimal)
+			fmt.Fprintf(buf, ".refine((v) => %s, { message: %s })", cond, jsString(msg))
+		}
+	}
+}
+
+// builtinSchema returns the schema validating a builtin type,
+// matching the constraints the backend applies when decoding it.
+func (ts *typescript) builtinSchema(typ schema.Builtin) string {
+	switch typ {
+	case schema.Builtin_ANY:
+		return "z.any()"
+	case schema.Builtin_BOOL:
+		return "z.boolean()"
+	case schema.Builtin_INT8:
+		return "z.number().int().min(-128).max(127)"
+	case schema.Builtin_INT16:
+		return "z.number().int().min(-32768).max(32767)"
+	case schema.Builtin_INT32:
+		return "z.number().int().min(-2147483648).max(2147483647)"
+	case schema.Builtin_INT, schema.Builtin_INT64:
+		return "z.number().int()"
+	case schema.Builtin_UINT8:
+		return "z.number().int().min(0).max(255)"
+	case schema.Builtin_UINT16:
+		return "z.number().int().min(0).max(65535)"
+	case schema.Builtin_UINT32:
+		return "z.number().int().min(0).max(4294967295)"
+	case schema.Builtin_UINT, schema.Builtin_UINT64:
+		return "z.number().int().min(0)"
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return "z.number()"
+	case schema.Builtin_STRING, schema.Builtin_BYTES, schema.Builtin_USER_ID, schema.Builtin_DECIMAL:
+		return "z.string()"
+	case schema.Builtin_TIME:
+		return "z.string().datetime({ offset: true })"
+	case schema.Builtin_UUID:
+		return "z.string().uuid()"
+	case schema.Builtin_JSON:
+		return "z.any()"
+	case schema.Builtin_FILE:
+		return "z.instanceof(Blob)"
+	default:
+		ts.errorf("unknown builtin type %v", typ)
+		return "z.any()"
+	}
+}
+
+// validationTerms splits a validation expression into the expressions
+// that must all hold, so each can be reported with its own message.
+func validationTerms(expr *schema.ValidationExpr) []*schema.ValidationExpr {
+	if and := expr.GetAnd(); and != nil {
+		var terms []*schema.ValidationExpr
+		for _, e := range and.Exprs {
+			terms = append(terms, validationTerms(e)...)
+		}
+		return terms
+	}
+	return []*schema.ValidationExpr{expr}
+}
+
+// validationCond returns a JavaScript condition on the value v that holds
+// if v satisfies expr, along with a message describing the constraint.
+// The messages match those of the errors reported by the backend.
+func (ts *typescript) validationCond(expr *schema.ValidationExpr, isDecimal bool) (cond, msg string) {
+	join := func(exprs []*schema.ValidationExpr, op, word string) (string, string) {
+		conds := make([]string, len(exprs))
+		msgs := make([]string, len(exprs))
+		for i, e := range exprs {
+			conds[i], msgs[i] = ts.validationCond(e, isDecimal)
+		}
+		return "(" + strings.Join(conds, " "+op+" ") + ")", strings.Join(msgs, " "+word+" ")
+	}
+
+	num := "v"
+	if isDecimal {
+		num = "Number(v)"
+	}
+
+	switch e := expr.Expr.(type) {
+	case *schema.ValidationExpr_And_:
+		return join(e.And.Exprs, "&&", "and")
+	case *schema.ValidationExpr_Or_:
+		return join(e.Or.Exprs, "||", "or")
+	case *schema.ValidationExpr_Rule:
+		switch r := e.Rule.Rule.(type) {
+		case *schema.ValidationRule_MinLen:
+			return fmt.Sprintf("v.length >= %d", r.MinLen), fmt.Sprintf("length too short (expected at least %d)", r.MinLen)
+		case *schema.ValidationRule_MaxLen:
+			return fmt.Sprintf("v.length <= %d", r.MaxLen), fmt.Sprintf("length too long (expected at most %d)", r.MaxLen)
+		case *schema.ValidationRule_MinVal:
+			n := strconv.FormatFloat(r.MinVal, 'f', -1, 64)
+			return fmt.Sprintf("%s >= %s", num, n), "value must be at least " + n
+		case *schema.ValidationRule_MaxVal:
+			n := strconv.FormatFloat(r.MaxVal, 'f', -1, 64)
+			return fmt.Sprintf("%s <= %s", num, n), "value must be at most " + n
+		case *schema.ValidationRule_StartsWith:
+			s := jsString(r.StartsWith)
+			return fmt.Sprintf("v.startsWith(%s)", s), "value does not start with " + s
+		case *schema.ValidationRule_EndsWith:
+			s := jsString(r.EndsWith)
+			return fmt.Sprintf("v.endsWith(%s)", s), "value does not end with " + s
+		case *schema.ValidationRule_MatchesRegexp:
+			s := jsString(r.MatchesRegexp)
+			return fmt.Sprintf("new RegExp(%s).test(v)", s), "value does not match the regexp " + s
+		case *schema.ValidationRule_Is_:
+			switch r.Is {
+			case schema.ValidationRule_EMAIL:
+				return "z.string().email().safeParse(v).success", "value is not an email"
+			case schema.ValidationRule_URL:
+				return "z.string().url().safeParse(v).success", "value is not a url"
+			}
+		}
+	}
+	ts.errorf("unknown validation expression %v", expr)
+	return "", ""
+}
+
+// jsString returns s as a JavaScript string literal.
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,94 @@
+package clientgen
+
+import (
+	"bytes"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func TestTypeScriptSchemaValidation(t *testing.T) {
+	rule := func(r *schema.ValidationRule) *schema.ValidationExpr {
+		return &schema.ValidationExpr{Expr: &schema.ValidationExpr_Rule{Rule: r}}
+	}
+	and := func(exprs ...*schema.ValidationExpr) *schema.ValidationExpr {
+		return &schema.ValidationExpr{Expr: &schema.ValidationExpr_And_{And: &schema.ValidationExpr_And{Exprs: exprs}}}
+	}
+	or := func(exprs ...*schema.ValidationExpr) *schema.ValidationExpr {
+		return &schema.ValidationExpr{Expr: &schema.ValidationExpr_Or_{Or: &schema.ValidationExpr_Or{Exprs: exprs}}}
+	}
+	builtin := func(b schema.Builtin, v *schema.ValidationExpr) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Builtin{Builtin: b}, Validation: v}
+	}
+
+	tests := []struct {
+		name string
+		typ  *schema.Type
+		want string
+	}{
+		{
+			name: "length",
+			typ: builtin(schema.Builtin_STRING, and(
+				rule(&schema.ValidationRule{Rule: &schema.ValidationRule_MinLen{MinLen: 3}}),
+				rule(&schema.ValidationRule{Rule: &schema.ValidationRule_MaxLen{MaxLen: 10}}),
+			)),
+			want: `z.string()` +
+				`.refine((v) => v.length >= 3, { message: "length too short (expected at least 3)" })` +
+				`.refine((v) => v.length <= 10, { message: "length too long (expected at most 10)" })`,
+		},
+		{
+			name: "range",
+			typ: builtin(schema.Builtin_FLOAT64, and(
+				rule(&schema.ValidationRule{Rule: &schema.ValidationRule_MinVal{MinVal: 0.5}}),
+				rule(&schema.ValidationRule{Rule: &schema.ValidationRule_MaxVal{MaxVal: 100}}),
+			)),
+			want: `z.number()` +
+				`.refine((v) => v >= 0.5, { message: "value must be at least 0.5" })` +
+				`.refine((v) => v <= 100, { message: "value must be at most 100" })`,
+		},
+		{
+			name: "decimal",
+			typ:  builtin(schema.Builtin_DECIMAL, rule(&schema.ValidationRule{Rule: &schema.ValidationRule_MinVal{MinVal: 1}})),
+			want: `z.string().refine((v) => Number(v) >= 1, { message: "value must be at least 1" })`,
+		},
+		{
+			name: "or",
+			typ: builtin(schema.Builtin_STRING, or(
+				rule(&schema.ValidationRule{Rule: &schema.ValidationRule_Is_{Is: schema.ValidationRule_EMAIL}}),
+				and(
+					rule(&schema.ValidationRule{Rule: &schema.ValidationRule_StartsWith{StartsWith: "user-"}}),
+					rule(&schema.ValidationRule{Rule: &schema.ValidationRule_EndsWith{EndsWith: `"x"`}}),
+				),
+			)),
+			want: `z.string().refine((v) => (z.string().email().safeParse(v).success || (v.startsWith("user-") && v.endsWith("\"x\""))), ` +
+				`{ message: "value is not an email or value does not start with \"user-\" and value does not end with \"\\\"x\\\"\"" })`,
+		},
+		{
+			name: "regexp",
+			typ:  builtin(schema.Builtin_STRING, rule(&schema.ValidationRule{Rule: &schema.ValidationRule_MatchesRegexp{MatchesRegexp: `^\d+$`}})),
+			want: `z.string().refine((v) => new RegExp("^\\d+$").test(v), { message: "value does not match the regexp \"^\\\\d+$\"" })`,
+		},
+		{
+			name: "list",
+			typ: &schema.Type{
+				Typ: &schema.Type_List{List: &schema.List{
+					Elem: builtin(schema.Builtin_STRING, rule(&schema.ValidationRule{Rule: &schema.ValidationRule_Is_{Is: schema.ValidationRule_URL}})),
+				}},
+				Validation: rule(&schema.ValidationRule{Rule: &schema.ValidationRule_MinLen{MinLen: 1}}),
+			},
+			want: `z.array(z.string().refine((v) => z.string().url().safeParse(v).success, { message: "value is not a url" }))` +
+				`.refine((v) => v.length >= 1, { message: "length too short (expected at least 1)" })`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			ts := &typescript{generatorVersion: typescriptGenLatestVersion}
+			var buf bytes.Buffer
+			ts.renderSchema(&buf, "svc", tt.typ, nil, 0)
+			c.Assert(buf.String(), qt.Equals, tt.want)
+		})
+	}
+}
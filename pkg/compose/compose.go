@@ -0,0 +1,231 @@
+// Package compose integrates 'encore run' with an existing docker-compose stack,
+// so Encore services can be developed within a larger non-Encore system.
+//
+// It resolves the services of the stack to the addresses they are published on,
+// determines the address the stack's containers reach the host at, and generates
+// reverse proxy configuration that routes requests to the app.
+package compose
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/appfile"
+)
+
+// Stack describes a running docker-compose stack.
+type Stack struct {
+	// Project is the compose project name.
+	Project string
+
+	// Services are the running services of the stack, ordered by name.
+	Services []Service
+
+	// Network is the compose network the app is made reachable on.
+	Network string
+
+	// HostAddr is the address containers on Network reach the host at.
+	// It is invalid if the network has no gateway, such as with Docker Desktop,
+	// where containers reach the host at "host.docker.internal" instead.
+	HostAddr netip.Addr
+}
+
+// Service is a service of a compose stack.
+type Service struct {
+	// Name is the name of the service, which is its hostname within the stack.
+	Name string
+
+	// Ports are the ports the service publishes on the host.
+	Ports []Port
+}
+
+// Port is a port a service publishes on the host.
+type Port struct {
+	Target    uint16 // the port within the container
+	Published uint16 // the port on the host
+}
+
+// Load loads the running services of the stack configured by cfg,
+// for the app located at appRoot.
+func Load(ctx context.Context, appRoot string, cfg *appfile.Compose) (*Stack, error) {
+	args := []string{"compose"}
+	if cfg.File != "" {
+		args = append(args, "--file", filepath.Join(appRoot, cfg.File))
+	}
+	if cfg.Project != "" {
+		args = append(args, "--project-name", cfg.Project)
+	}
+	args = append(args, "ps", "--format", "json")
+
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Dir = appRoot
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, errors.New("docker not found: is it installed and in your PATH?")
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "docker compose ps failed: %s", bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	containers, err := parsePS(out)
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return nil, errors.New("no running docker-compose services found: start the stack with 'docker compose up'")
+	}
+
+	stack := &Stack{Project: cfg.Project, Services: servicesOf(containers)}
+	if stack.Project == "" {
+		stack.Project = containers[0].Project
+	}
+	stack.Network = cfg.Network
+	if stack.Network == "" {
+		stack.Network = stack.Project + "_default"
+	}
+	stack.HostAddr, err = networkGateway(ctx, stack.Network)
+	if err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
+
+// container is a container as reported by 'docker compose ps --format json'.
+type container struct {
+	Project    string
+	Service    string
+	Publishers []struct {
+		TargetPort    uint16
+		PublishedPort uint16
+		Protocol      string
+	}
+}
+
+// parsePS parses the output of 'docker compose ps --format json', which is
+// a JSON array in older versions of docker compose and JSON lines in newer ones.
+func parsePS(out []byte) ([]container, error) {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return nil, nil
+	}
+
+	var containers []container
+	if out[0] == '[' {
+		if err := json.Unmarshal(out, &containers); err != nil {
+			return nil, errors.Wrap(err, "parse docker compose ps output")
+		}
+		return containers, nil
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(nil, 1024*1024)
+	for sc.Scan() {
+		if line := bytes.TrimSpace(sc.Bytes()); len(line) > 0 {
+			var c container
+			if err := json.Unmarshal(line, &c); err != nil {
+				return nil, errors.Wrap(err, "parse docker compose ps output")
+			}
+			containers = append(containers, c)
+		}
+	}
+	return containers, sc.Err()
+}
+
+// servicesOf returns the services of the given containers
+// with the TCP ports they publish, ordered by name.
+func servicesOf(containers []container) []Service {
+	var services []Service
+	for _, c := range containers {
+		idx := slices.IndexFunc(services, func(s Service) bool { return s.Name == c.Service })
+		if idx < 0 {
+			services = append(services, Service{Name: c.Service})
+			idx = len(services) - 1
+		}
+		svc := &services[idx]
+		for _, p := range c.Publishers {
+			if p.PublishedPort == 0 || (p.Protocol != "" && p.Protocol != "tcp") {
+				continue
+			}
+			// Ports published on both IPv4 and IPv6 are reported twice.
+			port := Port{Target: p.TargetPort, Published: p.PublishedPort}
+			if !slices.Contains(svc.Ports, port) {
+				svc.Ports = append(svc.Ports, port)
+			}
+		}
+		slices.SortFunc(svc.Ports, func(a, b Port) int { return int(a.Target) - int(b.Target) })
+	}
+	slices.SortFunc(services, func(a, b Service) int { return strings.Compare(a.Name, b.Name) })
+	return services
+}
+
+// networkGateway returns the gateway address of a docker network,
+// which is the address of the host on that network.
+func networkGateway(ctx context.Context, network string) (netip.Addr, error) {
+	out, err := exec.CommandContext(ctx, "docker", "network", "inspect",
+		"--format", "{{range .IPAM.Config}}{{.Gateway}} {{end}}", network).CombinedOutput()
+	if err != nil {
+		return netip.Addr{}, errors.Wrapf(err, "docker network inspect %s failed: %s", network, bytes.TrimSpace(out))
+	}
+	for _, field := range strings.Fields(string(out)) {
+		if addr, err := netip.ParseAddr(field); err == nil && addr.Is4() {
+			return addr, nil
+		}
+	}
+	return netip.Addr{}, nil
+}
+
+// Environ returns environment variables that resolve the services of the stack
+// to the addresses they are published on, for use by the app.
+//
+// For each service publishing ports, COMPOSE_<SERVICE>_HOST is set to the host
+// and COMPOSE_<SERVICE>_PORT_<TARGET> to the port each target port is published on.
+// COMPOSE_<SERVICE>_PORT is set to the first published port.
+func (s *Stack) Environ() []string {
+	var env []string
+	for _, svc := range s.Services {
+		if len(svc.Ports) == 0 {
+			continue
+		}
+		prefix := "COMPOSE_" + envName(svc.Name) + "_"
+		env = append(env,
+			prefix+"HOST=127.0.0.1",
+			prefix+"PORT="+strconv.Itoa(int(svc.Ports[0].Published)),
+		)
+		for _, p := range svc.Ports {
+			env = append(env, fmt.Sprintf("%sPORT_%d=%d", prefix, p.Target, p.Published))
+		}
+	}
+	return env
+}
+
+// GatewayHost returns the host the stack's containers reach the app at.
+func (s *Stack) GatewayHost() string {
+	// Containers on Linux reach the host on the network's gateway.
+	// Docker Desktop instead forwards host.docker.internal to the host.
+	if runtime.GOOS == "linux" && s.HostAddr.IsValid() {
+		return s.HostAddr.String()
+	}
+	return "host.docker.internal"
+}
+
+func envName(s string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, s))
+}
@@ -0,0 +1,75 @@
+package compose
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/golden"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func TestParsePS(t *testing.T) {
+	c := qt.New(t)
+
+	lines := `{"Project":"shop","Service":"redis","Publishers":[{"URL":"0.0.0.0","TargetPort":6379,"PublishedPort":16379,"Protocol":"tcp"},{"URL":"::","TargetPort":6379,"PublishedPort":16379,"Protocol":"tcp"}]}
+{"Project":"shop","Service":"legacy-api","Publishers":[{"TargetPort":9090,"PublishedPort":0,"Protocol":"tcp"},{"TargetPort":8080,"PublishedPort":8081,"Protocol":"tcp"},{"TargetPort":53,"PublishedPort":5353,"Protocol":"udp"}]}
+`
+	array := `[{"Project":"shop","Service":"redis","Publishers":[{"TargetPort":6379,"PublishedPort":16379,"Protocol":"tcp"}]},
+{"Project":"shop","Service":"legacy-api","Publishers":[{"TargetPort":8080,"PublishedPort":8081,"Protocol":"tcp"}]}]`
+
+	want := []Service{
+		{Name: "legacy-api", Ports: []Port{{Target: 8080, Published: 8081}}},
+		{Name: "redis", Ports: []Port{{Target: 6379, Published: 16379}}},
+	}
+	for _, out := range []string{lines, array} {
+		containers, err := parsePS([]byte(out))
+		c.Assert(err, qt.IsNil)
+		c.Assert(containers[0].Project, qt.Equals, "shop")
+		c.Assert(servicesOf(containers), qt.DeepEquals, want)
+	}
+
+	containers, err := parsePS([]byte("\n"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(containers, qt.HasLen, 0)
+}
+
+func TestEnviron(t *testing.T) {
+	c := qt.New(t)
+	stack := &Stack{Services: []Service{
+		{Name: "legacy-api", Ports: []Port{{Target: 8080, Published: 8081}, {Target: 9090, Published: 9091}}},
+		{Name: "worker"},
+	}}
+	c.Assert(stack.Environ(), qt.DeepEquals, []string{
+		"COMPOSE_LEGACY_API_HOST=127.0.0.1",
+		"COMPOSE_LEGACY_API_PORT=8081",
+		"COMPOSE_LEGACY_API_PORT_8080=8081",
+		"COMPOSE_LEGACY_API_PORT_9090=9091",
+	})
+}
+
+func TestProxyConfig(t *testing.T) {
+	c := qt.New(t)
+	for _, format := range ProxyFormats {
+		for _, tc := range []struct {
+			name string
+			cfg  appfile.ComposeProxy
+		}{
+			{"all", appfile.ComposeProxy{Format: format}},
+			{"host_prefix", appfile.ComposeProxy{Format: format, Host: "shop.localhost", PathPrefix: "/api/"}},
+		} {
+			out, err := ProxyConfig(&tc.cfg, "encore-shop", "172.18.0.1:4000")
+			c.Assert(err, qt.IsNil)
+			golden.TestAgainst(c, format+"_"+tc.name+".conf", string(out))
+		}
+	}
+
+	_, err := ProxyConfig(&appfile.ComposeProxy{Format: "haproxy"}, "encore-shop", "172.18.0.1:4000")
+	c.Assert(err, qt.ErrorMatches, `unknown proxy format "haproxy".*`)
+	_, err = ProxyConfig(&appfile.ComposeProxy{Format: "nginx", PathPrefix: "api"}, "encore-shop", "172.18.0.1:4000")
+	c.Assert(err, qt.ErrorMatches, `invalid path prefix.*`)
+}
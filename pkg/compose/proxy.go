@@ -0,0 +1,106 @@
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/appfile"
+)
+
+// ProxyFormats are the supported reverse proxy configuration formats.
+var ProxyFormats = []string{"traefik", "nginx", "caddy"}
+
+const proxyHeader = "Generated by 'encore run'. Do not edit."
+
+// ProxyConfig returns reverse proxy configuration that routes the requests
+// matched by cfg to the app listening at addr (a "host:port" pair).
+// The path prefix, if any, is removed from the requests.
+func ProxyConfig(cfg *appfile.ComposeProxy, name, addr string) ([]byte, error) {
+	prefix := strings.TrimSuffix(cfg.PathPrefix, "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		return nil, errors.Newf("invalid path prefix %q: must start with '/'", cfg.PathPrefix)
+	}
+
+	var b strings.Builder
+	switch cfg.Format {
+	case "traefik":
+		var rules []string
+		if cfg.Host != "" {
+			rules = append(rules, fmt.Sprintf("Host(`%s`)", cfg.Host))
+		}
+		rules = append(rules, fmt.Sprintf("PathPrefix(`%s/`)", prefix))
+
+		fmt.Fprintf(&b, "# %s\n", proxyHeader)
+		fmt.Fprintf(&b, "http:\n  routers:\n    %s:\n", name)
+		fmt.Fprintf(&b, "      rule: %q\n", strings.Join(rules, " && "))
+		fmt.Fprintf(&b, "      service: %s\n", name)
+		if prefix != "" {
+			fmt.Fprintf(&b, "      middlewares:\n        - %s-strip\n", name)
+			fmt.Fprintf(&b, "  middlewares:\n    %s-strip:\n      stripPrefix:\n        prefixes:\n          - %q\n", name, prefix)
+		}
+		fmt.Fprintf(&b, "  services:\n    %s:\n      loadBalancer:\n        servers:\n          - url: %q\n", name, "http://"+addr)
+
+	case "nginx":
+		serverName := cfg.Host
+		if serverName == "" {
+			serverName = "_"
+		}
+		fmt.Fprintf(&b, "# %s\n", proxyHeader)
+		fmt.Fprintf(&b, "server {\n    listen 80;\n    server_name %s;\n\n", serverName)
+		fmt.Fprintf(&b, "    location %s/ {\n", prefix)
+		fmt.Fprintf(&b, "        proxy_pass http://%s/;\n", addr)
+		b.WriteString("        proxy_http_version 1.1;\n")
+		b.WriteString("        proxy_set_header Host $host;\n")
+		b.WriteString("        proxy_set_header Upgrade $http_upgrade;\n")
+		b.WriteString("        proxy_set_header Connection $http_connection;\n")
+		b.WriteString("        proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;\n")
+		if prefix != "" {
+			fmt.Fprintf(&b, "        proxy_set_header X-Forwarded-Prefix %s;\n", prefix)
+		}
+		b.WriteString("    }\n}\n")
+
+	case "caddy":
+		site := "http://" + cfg.Host
+		if cfg.Host == "" {
+			site = ":80"
+		}
+		fmt.Fprintf(&b, "# %s\n", proxyHeader)
+		fmt.Fprintf(&b, "%s {\n", site)
+		if prefix != "" {
+			fmt.Fprintf(&b, "\thandle_path %s/* {\n\t\treverse_proxy %s\n\t}\n", prefix, addr)
+		} else {
+			fmt.Fprintf(&b, "\treverse_proxy %s\n", addr)
+		}
+		b.WriteString("}\n")
+
+	default:
+		return nil, errors.Newf("unknown proxy format %q: must be one of %s", cfg.Format, strings.Join(ProxyFormats, ", "))
+	}
+	return []byte(b.String()), nil
+}
+
+// WriteProxyConfig writes the reverse proxy configuration for the app
+// to the file configured by cfg, relative to appRoot. It returns a function
+// that removes the file, deregistering the app from the proxy.
+func WriteProxyConfig(appRoot string, cfg *appfile.ComposeProxy, name, addr string) (remove func(), err error) {
+	if cfg.Path == "" {
+		return nil, errors.New("no proxy configuration path given")
+	}
+	data, err := ProxyConfig(cfg, name, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := filepath.Join(appRoot, cfg.Path)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return nil, errors.Wrap(err, "create proxy configuration directory")
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return nil, errors.Wrap(err, "write proxy configuration")
+	}
+	return func() { _ = os.Remove(dst) }, nil
+}
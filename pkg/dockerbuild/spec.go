@@ -95,6 +95,13 @@ type BuildInfo struct {
 
 	// AppCommit describes the commit of the app.
 	AppCommit CommitInfo
+
+	// Toolchains are the versions of the toolchains the app was built with,
+	// keyed by name, such as "go".
+	Toolchains map[string]string `json:",omitempty"`
+
+	// InfraHash is a hash of the infrastructure the app requires.
+	InfraHash string `json:",omitempty"`
 }
 
 type CommitInfo struct {
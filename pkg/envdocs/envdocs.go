@@ -0,0 +1,100 @@
+// Package envdocs generates documentation of the environment variables
+// an app declares, for the people operating it.
+package envdocs
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Var is an environment variable declared by an app,
+// merging the declarations of the services reading it.
+type Var struct {
+	Name        string
+	Description string
+	Required    bool
+	Format      string
+	Services    []string // the services declaring the variable, sorted
+}
+
+// Vars returns the environment variables declared in md, sorted by name.
+func Vars(md *meta.Data) []*Var {
+	byName := make(map[string]*Var)
+	var vars []*Var
+	for _, ev := range md.EnvVars {
+		v, ok := byName[ev.Name]
+		if !ok {
+			v = &Var{Name: ev.Name, Required: ev.Required, Format: ev.Format}
+			byName[ev.Name] = v
+			vars = append(vars, v)
+		}
+		if v.Description == "" {
+			v.Description = ev.Description
+		}
+		if svc := ev.GetServiceName(); svc != "" && !slices.Contains(v.Services, svc) {
+			v.Services = append(v.Services, svc)
+		}
+	}
+
+	slices.SortFunc(vars, func(a, b *Var) int { return strings.Compare(a.Name, b.Name) })
+	for _, v := range vars {
+		slices.Sort(v.Services)
+	}
+	return vars
+}
+
+// Markdown documents the environment variables declared in md as a Markdown table.
+func Markdown(md *meta.Data) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# Environment variables\n\n")
+	vars := Vars(md)
+	if len(vars) == 0 {
+		buf.WriteString("The application declares no environment variables.\n")
+		return buf.Bytes()
+	}
+
+	buf.WriteString("| Name | Description | Required | Format | Used by |\n")
+	buf.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, v := range vars {
+		required := "No"
+		if v.Required {
+			required = "Yes"
+		}
+		fmt.Fprintf(&buf, "| `%s` | %s | %s | %s | %s |\n", v.Name, markdownCell(v.Description),
+			required, v.Format, strings.Join(v.Services, ", "))
+	}
+	return buf.Bytes()
+}
+
+// Dotenv documents the environment variables declared in md as a dotenv file,
+// with each variable's description in a comment, for use as a template.
+func Dotenv(md *meta.Data) []byte {
+	var buf bytes.Buffer
+	for i, v := range Vars(md) {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		for _, line := range strings.Split(v.Description, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(&buf, "# %s\n", line)
+			}
+		}
+		note := v.Format
+		if v.Required {
+			note += ", required"
+		}
+		fmt.Fprintf(&buf, "# (%s)\n", note)
+		fmt.Fprintf(&buf, "%s=\n", v.Name)
+	}
+	return buf.Bytes()
+}
+
+// markdownCell escapes s for use in a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
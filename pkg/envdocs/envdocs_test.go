@@ -0,0 +1,42 @@
+package envdocs
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func svc(name string) *string { return &name }
+
+var testMeta = &meta.Data{EnvVars: []*meta.EnvVar{
+	{Name: "STRIPE_URL", Description: "Base URL of the Stripe API", Required: true, Format: "url", ServiceName: svc("billing")},
+	{Name: "WORKERS", Description: "Number of workers | per instance", Format: "int", ServiceName: svc("jobs")},
+	{Name: "STRIPE_URL", Required: true, Format: "url", ServiceName: svc("accounts")},
+}}
+
+func TestMarkdown(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(string(Markdown(testMeta)), qt.Equals, `# Environment variables
+
+| Name | Description | Required | Format | Used by |
+| --- | --- | --- | --- | --- |
+| `+"`STRIPE_URL`"+` | Base URL of the Stripe API | Yes | url | accounts, billing |
+| `+"`WORKERS`"+` | Number of workers \| per instance | No | int | jobs |
+`)
+	c.Assert(string(Markdown(&meta.Data{})), qt.Equals,
+		"# Environment variables\n\nThe application declares no environment variables.\n")
+}
+
+func TestDotenv(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(string(Dotenv(testMeta)), qt.Equals, `# Base URL of the Stripe API
+# (url, required)
+STRIPE_URL=
+
+# Number of workers | per instance
+# (int)
+WORKERS=
+`)
+}
@@ -0,0 +1,125 @@
+package environ
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadDotenvFile reads the KEY=VALUE pairs in the dotenv file at path.
+func ReadDotenvFile(path string) (Environ, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	env, err := ParseDotenv(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return env, nil
+}
+
+// ParseDotenv parses KEY=VALUE pairs in the dotenv format:
+//
+//   - Blank lines and lines starting with '#' are ignored.
+//   - Lines may be prefixed with "export ".
+//   - Unquoted values are trimmed, and a " #" starts a comment.
+//   - Values in single quotes are taken literally.
+//   - Values in double quotes may span multiple lines and
+//     support the escape sequences \n, \r, \t, \" and \\.
+//
+// Variables are not expanded.
+func ParseDotenv(r io.Reader) (Environ, error) {
+	var env Environ
+	sc := bufio.NewScanner(r)
+	lineNum := 0
+	for sc.Scan() {
+		lineNum++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, "'"):
+			end := strings.Index(value[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated single-quoted value", lineNum)
+			}
+			value = value[1 : end+1]
+
+		case strings.HasPrefix(value, `"`):
+			// Double-quoted values may continue onto the following lines.
+			raw := value[1:]
+			startLine := lineNum
+			for {
+				if v, ok := unquoteDouble(raw); ok {
+					value = v
+					break
+				}
+				if !sc.Scan() {
+					return nil, fmt.Errorf("line %d: unterminated double-quoted value", startLine)
+				}
+				lineNum++
+				raw += "\n" + sc.Text()
+			}
+
+		default:
+			if idx := strings.Index(value, " #"); idx >= 0 {
+				value = strings.TrimSpace(value[:idx])
+			}
+		}
+
+		env = append(env, key+"="+value)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// unquoteDouble unescapes s up to its closing double quote.
+// It reports false if s has no closing quote.
+func unquoteDouble(s string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), true
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				case '"', '\\':
+					b.WriteByte(s[i])
+				default:
+					b.WriteByte('\\')
+					b.WriteByte(s[i])
+				}
+				continue
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", false
+}
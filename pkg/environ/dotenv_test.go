@@ -0,0 +1,89 @@
+package environ
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Environ
+		wantErr string
+	}{
+		{name: "empty", in: "", want: nil},
+		{name: "plain", in: "FOO=bar\nBAZ=qux", want: Environ{"FOO=bar", "BAZ=qux"}},
+		{name: "empty_value", in: "FOO=", want: Environ{"FOO="}},
+		{name: "trimmed", in: "  FOO  =  bar baz  ", want: Environ{"FOO=bar baz"}},
+		{name: "equals_in_value", in: "URL=postgres://h/db?sslmode=disable", want: Environ{"URL=postgres://h/db?sslmode=disable"}},
+		{name: "blank_lines_and_comments", in: "\n# comment\n  # indented comment\nFOO=bar\n\n", want: Environ{"FOO=bar"}},
+		{name: "trailing_comment", in: "FOO=bar # comment", want: Environ{"FOO=bar"}},
+		{name: "hash_without_space", in: "FOO=bar#baz", want: Environ{"FOO=bar#baz"}},
+		{name: "export", in: "export FOO=bar", want: Environ{"FOO=bar"}},
+		{name: "export_quoted", in: `export FOO="bar"`, want: Environ{"FOO=bar"}},
+		{name: "no_expansion", in: "FOO=${BAR}", want: Environ{"FOO=${BAR}"}},
+
+		{name: "single_quoted", in: `FOO='bar # baz'`, want: Environ{"FOO=bar # baz"}},
+		{name: "single_quoted_literal", in: `FOO='a\nb "c"'`, want: Environ{`FOO=a\nb "c"`}},
+		{name: "single_quoted_trailing", in: `FOO='bar' # comment`, want: Environ{"FOO=bar"}},
+		{name: "single_quoted_unterminated", in: `FOO='bar`, wantErr: `line 1: unterminated single-quoted value`},
+
+		{name: "double_quoted", in: `FOO="bar # baz"`, want: Environ{"FOO=bar # baz"}},
+		{name: "double_quoted_empty", in: `FOO=""`, want: Environ{"FOO="}},
+		{name: "double_quoted_escapes", in: `FOO="a\nb\rc\td\"e\\f"`, want: Environ{"FOO=a\nb\rc\td\"e\\f"}},
+		{name: "double_quoted_unknown_escape", in: `FOO="a\zb"`, want: Environ{`FOO=a\zb`}},
+		{name: "double_quoted_escaped_backslash_before_quote", in: `FOO="a\\" # comment`, want: Environ{`FOO=a\`}},
+		{name: "double_quoted_trailing", in: `FOO="bar" # comment`, want: Environ{"FOO=bar"}},
+		{name: "multiline", in: "KEY=\"-----BEGIN KEY-----\nabc\n-----END KEY-----\"\nNEXT=1", want: Environ{"KEY=-----BEGIN KEY-----\nabc\n-----END KEY-----", "NEXT=1"}},
+		{name: "multiline_escaped_quote", in: "FOO=\"a\\\"\nb\"", want: Environ{"FOO=a\"\nb"}},
+		{name: "multiline_unterminated", in: "A=1\nFOO=\"bar\nbaz", wantErr: `line 2: unterminated double-quoted value`},
+
+		{name: "missing_equals", in: "FOO=bar\nBAZ", wantErr: `line 2: expected KEY=VALUE`},
+		{name: "empty_key", in: "=bar", wantErr: `line 1: expected KEY=VALUE`},
+		{name: "space_in_key", in: "FOO BAR=baz", wantErr: `line 1: expected KEY=VALUE`},
+		{name: "line_after_multiline", in: "FOO=\"a\nb\"\nBAD", wantErr: `line 3: expected KEY=VALUE`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			got, err := ParseDotenv(strings.NewReader(tt.in))
+			if tt.wantErr != "" {
+				c.Assert(err, qt.ErrorMatches, regexpQuote(tt.wantErr))
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(got, qt.DeepEquals, tt.want)
+		})
+	}
+}
+
+func TestUnquoteDouble(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{in: `"`, want: "", wantOK: true},
+		{in: `abc" rest`, want: "abc", wantOK: true},
+		{in: `a\"b"`, want: `a"b`, wantOK: true},
+		{in: `a\\"`, want: `a\`, wantOK: true},
+		{in: `\n\r\t"`, want: "\n\r\t", wantOK: true},
+		{in: `\x"`, want: `\x`, wantOK: true},
+		{in: `abc`, wantOK: false},
+		{in: `abc\"`, wantOK: false},
+		{in: `abc\`, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			c := qt.New(t)
+			got, ok := unquoteDouble(tt.in)
+			c.Assert(ok, qt.Equals, tt.wantOK)
+			if ok {
+				c.Assert(got, qt.Equals, tt.want)
+			}
+		})
+	}
+}
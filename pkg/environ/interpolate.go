@@ -0,0 +1,128 @@
+package environ
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resolver resolves a reference with a prefix, such as "secret:Name".
+// It reports whether the referenced value exists.
+type Resolver func(ref string) (value string, ok bool, err error)
+
+// Expand expands the references in value:
+//
+//   - ${REF} is replaced by the value of REF, as returned by lookup.
+//   - ${REF:-default} is replaced by default if REF doesn't exist.
+//   - $$ is replaced by a literal $.
+//
+// Any other $ is kept as-is. It's an error to reference a value
+// that doesn't exist without a default.
+func Expand(value string, lookup Resolver) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for {
+		idx := strings.IndexByte(value, '$')
+		if idx < 0 || idx == len(value)-1 {
+			b.WriteString(value)
+			return b.String(), nil
+		}
+		b.WriteString(value[:idx])
+		value = value[idx:]
+
+		switch value[1] {
+		case '$':
+			b.WriteByte('$')
+			value = value[2:]
+		case '{':
+			end := strings.IndexByte(value, '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated reference %q", value)
+			}
+			ref, def, hasDef := strings.Cut(value[2:end], ":-")
+			if ref == "" {
+				return "", fmt.Errorf("empty reference %q", value[:end+1])
+			}
+			val, ok, err := lookup(ref)
+			if err != nil {
+				return "", fmt.Errorf("${%s}: %w", ref, err)
+			} else if !ok && !hasDef {
+				return "", fmt.Errorf("${%s}: not found", ref)
+			} else if !ok {
+				val = def
+			}
+			b.WriteString(val)
+			value = value[end+1:]
+		default:
+			b.WriteByte('$')
+			value = value[1:]
+		}
+	}
+}
+
+// Interpolate returns a copy of e with the references in the values of the
+// variables named by keys expanded, as described by Expand.
+//
+// References without a prefix, like ${HOME}, resolve to the values of the
+// variables in e, themselves expanded if they're named by keys.
+// References with a prefix, like ${secret:Name}, are resolved with resolve.
+func (e Environ) Interpolate(keys []string, resolve Resolver) (Environ, error) {
+	interpolate := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		interpolate[k] = true
+	}
+
+	// Later values take precedence, as they do for processes.
+	values := make(map[string]string, len(e))
+	for _, kv := range e {
+		key, val, _ := strings.Cut(kv, "=")
+		values[key] = val
+	}
+
+	expanded := make(map[string]string)
+	expanding := make(map[string]bool) // to detect cycles
+
+	var expandVar func(key string) (string, bool, error)
+	expandVar = func(key string) (string, bool, error) {
+		if val, ok := expanded[key]; ok {
+			return val, true, nil
+		}
+		val, ok := values[key]
+		if !ok || !interpolate[key] {
+			return val, ok, nil
+		}
+		if expanding[key] {
+			return "", false, fmt.Errorf("variable %s references itself", key)
+		}
+
+		expanding[key] = true
+		val, err := Expand(val, func(ref string) (string, bool, error) {
+			if strings.Contains(ref, ":") {
+				return resolve(ref)
+			}
+			return expandVar(ref)
+		})
+		delete(expanding, key)
+		if err != nil {
+			return "", false, err
+		}
+		expanded[key] = val
+		return val, true, nil
+	}
+
+	result := make(Environ, len(e))
+	for i, kv := range e {
+		key, _, _ := strings.Cut(kv, "=")
+		if interpolate[key] {
+			val, _, err := expandVar(key)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			kv = key + "=" + val
+		}
+		result[i] = kv
+	}
+	return result, nil
+}
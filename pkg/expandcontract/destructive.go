@@ -0,0 +1,53 @@
+package expandcontract
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var (
+	dropSchemaRe = regexp.MustCompile(`(?is)^DROP\s+SCHEMA\s+(?:IF\s+EXISTS\s+)?(.+?)(?:\s+(?:CASCADE|RESTRICT))?$`)
+	truncateRe   = regexp.MustCompile(`(?is)^TRUNCATE\s+(?:TABLE\s+)?(.+?)(?:\s+(?:RESTART|CONTINUE)\s+IDENTITY)?(?:\s+(?:CASCADE|RESTRICT))?$`)
+	deleteRe     = regexp.MustCompile(`(?is)^DELETE\s+FROM\s+(?:ONLY\s+)?` + tableNamePattern + `(.*)$`)
+	whereRe      = regexp.MustCompile(`(?i)\bWHERE\b`)
+	onlyRe       = regexp.MustCompile(`(?i)^ONLY\s+`)
+)
+
+// Destructive describes the data a migration statement destroys or may lose,
+// like "drops table orders and all its rows". It returns nil for statements
+// that don't destroy data, or that are not understood.
+func Destructive(stmt string) []string {
+	if m := dropSchemaRe.FindStringSubmatch(stmt); m != nil {
+		var msgs []string
+		for _, name := range splitTopLevel(m[1]) {
+			msgs = append(msgs, fmt.Sprintf("drops schema %s and all tables in it", parseIdent(name)))
+		}
+		return msgs
+	}
+	if m := truncateRe.FindStringSubmatch(stmt); m != nil {
+		var msgs []string
+		for _, name := range splitTopLevel(m[1]) {
+			msgs = append(msgs, fmt.Sprintf("deletes all rows of table %s", parseTable(onlyRe.ReplaceAllString(name, ""))))
+		}
+		return msgs
+	}
+	if m := deleteRe.FindStringSubmatch(stmt); m != nil {
+		if whereRe.MatchString(m[2]) {
+			return []string{fmt.Sprintf("deletes rows of table %s", parseTable(m[1]))}
+		}
+		return []string{fmt.Sprintf("deletes all rows of table %s", parseTable(m[1]))}
+	}
+
+	var msgs []string
+	for _, a := range parseStatement(stmt) {
+		switch a.kind {
+		case actDropTable:
+			msgs = append(msgs, fmt.Sprintf("drops table %s and all its rows", a.table))
+		case actDropColumn:
+			msgs = append(msgs, fmt.Sprintf("drops column %s.%s and its values", a.table, a.column))
+		case actAlterType:
+			msgs = append(msgs, fmt.Sprintf("converts column %s.%s to %s, which may lose data or fail for existing values", a.table, a.column, a.typ))
+		}
+	}
+	return msgs
+}
@@ -0,0 +1,237 @@
+package expandcontract
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+)
+
+// ChangeKind is a kind of schema change that breaks queries written
+// against the schema from before the change.
+type ChangeKind string
+
+const (
+	DropTable         ChangeKind = "drop table"
+	RenameTable       ChangeKind = "rename table"
+	DropColumn        ChangeKind = "drop column"
+	RenameColumn      ChangeKind = "rename column"
+	ChangeColumnType  ChangeKind = "change column type"
+	AddRequiredColumn ChangeKind = "add required column"
+	SetNotNull        ChangeKind = "set not null"
+)
+
+// Change is a schema change that breaks the queries of the previous
+// version of an app, which keeps running while a new version is rolled out.
+type Change struct {
+	Kind    ChangeKind
+	Table   string
+	Column  string // for column changes
+	NewName string // for renames
+	Type    string // the new column type, for ChangeColumnType
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case DropTable:
+		return fmt.Sprintf("drop table %s", c.Table)
+	case RenameTable:
+		return fmt.Sprintf("rename table %s to %s", c.Table, c.NewName)
+	case RenameColumn:
+		return fmt.Sprintf("rename column %s.%s to %s", c.Table, c.Column, c.NewName)
+	case ChangeColumnType:
+		return fmt.Sprintf("change type of column %s.%s to %s", c.Table, c.Column, c.Type)
+	default:
+		return fmt.Sprintf("%s %s.%s", c.Kind, c.Table, c.Column)
+	}
+}
+
+// Risk describes how the change breaks queries while a new version of the app is rolled out.
+func (c Change) Risk() string {
+	switch c.Kind {
+	case DropTable, RenameTable:
+		return fmt.Sprintf("queries of the previous version using table %s fail once the migration is applied", c.Table)
+	case DropColumn, RenameColumn:
+		return fmt.Sprintf("queries of the previous version using column %s.%s fail once the migration is applied", c.Table, c.Column)
+	case ChangeColumnType:
+		return fmt.Sprintf("queries of the previous version may not handle the new type of column %s.%s", c.Table, c.Column)
+	case AddRequiredColumn, SetNotNull:
+		return fmt.Sprintf("inserts into %s by the previous version without a value for %s fail once the migration is applied", c.Table, c.Column)
+	default:
+		return ""
+	}
+}
+
+// Plan is a migration split into expand and contract phases.
+//
+// The expand phase is compatible with both the previous and the new version
+// of the app, and is applied before the new version is rolled out.
+// The contract phase removes what the previous version depended on,
+// and is applied once no instances of the previous version remain.
+type Plan struct {
+	// Changes are the breaking changes made by the migration.
+	Changes []Change
+
+	// Expand and Contract are the statements of each phase.
+	Expand   []string
+	Contract []string
+}
+
+// ExpandSQL returns the statements of the expand phase as a migration.
+func (p *Plan) ExpandSQL() string { return joinStatements(p.Expand) }
+
+// ContractSQL returns the statements of the contract phase as a migration.
+func (p *Plan) ContractSQL() string { return joinStatements(p.Contract) }
+
+func joinStatements(stmts []string) string {
+	var b strings.Builder
+	for i, stmt := range stmts {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(stmt)
+		b.WriteString(";\n")
+	}
+	return b.String()
+}
+
+// NewPlan splits the migration sql into expand and contract phases.
+// before is the schema before the migration is applied.
+//
+// Statements that don't break the previous version of the app are kept
+// in the expand phase as is.
+func NewPlan(before Schema, sql string) *Plan {
+	schema := make(Schema, len(before))
+	for table, cols := range before {
+		schema[table] = maps.Clone(cols)
+	}
+	// Tables created by the migration are unknown to the previous version,
+	// so changing them is safe.
+	created := make(map[string]bool)
+
+	p := &Plan{}
+	for _, stmt := range SplitStatements(sql) {
+		for _, a := range parseStatement(stmt) {
+			if a.kind == actCreateTable {
+				created[a.table] = true
+			}
+			if created[a.table] {
+				if a.kind == actRenameTable {
+					created[a.newName] = true
+				}
+				p.Expand = append(p.Expand, a.stmt)
+			} else {
+				p.add(schema, a)
+			}
+			schema.apply(a)
+		}
+	}
+	return p
+}
+
+func (p *Plan) add(schema Schema, a action) {
+	table := quoteTable(a.table)
+	col := quoteIdent(a.column)
+
+	switch a.kind {
+	case actDropTable:
+		p.Changes = append(p.Changes, Change{Kind: DropTable, Table: a.table})
+		p.Contract = append(p.Contract, a.stmt)
+
+	case actRenameTable:
+		// Keep the table available under its previous name with a view,
+		// which Postgres supports writing to like the table itself.
+		p.Changes = append(p.Changes, Change{Kind: RenameTable, Table: a.table, NewName: a.newName})
+		p.Expand = append(p.Expand,
+			a.stmt,
+			fmt.Sprintf("CREATE VIEW %s AS SELECT * FROM %s", table, quoteTable(a.newName)))
+		p.Contract = append(p.Contract, fmt.Sprintf("DROP VIEW %s", table))
+
+	case actDropColumn:
+		p.Changes = append(p.Changes, Change{Kind: DropColumn, Table: a.table, Column: a.column})
+		p.Contract = append(p.Contract, a.stmt)
+
+	case actRenameColumn:
+		// Add the new column and keep both columns in sync until the contract phase.
+		p.Changes = append(p.Changes, Change{Kind: RenameColumn, Table: a.table, Column: a.column, NewName: a.newName})
+		typ := schema.ColumnType(a.table, a.column)
+		if typ == "" {
+			typ = fmt.Sprintf("TEXT /* TODO: the type of %s */", a.column)
+		}
+		newCol := quoteIdent(a.newName)
+		fn := quoteIdent(syncFuncName(a.table, a.column, a.newName))
+		p.Expand = append(p.Expand,
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, newCol, typ),
+			fmt.Sprintf("UPDATE %s SET %s = %s", table, newCol, col),
+			fmt.Sprintf(`CREATE FUNCTION %s() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        NEW.%[2]s := COALESCE(NEW.%[2]s, NEW.%[3]s);
+        NEW.%[3]s := COALESCE(NEW.%[3]s, NEW.%[2]s);
+    ELSIF NEW.%[3]s IS DISTINCT FROM OLD.%[3]s THEN
+        NEW.%[2]s := NEW.%[3]s;
+    ELSIF NEW.%[2]s IS DISTINCT FROM OLD.%[2]s THEN
+        NEW.%[3]s := NEW.%[2]s;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql`, fn, newCol, col),
+			fmt.Sprintf("CREATE TRIGGER %s BEFORE INSERT OR UPDATE ON %s FOR EACH ROW EXECUTE FUNCTION %s()", fn, table, fn))
+		p.Contract = append(p.Contract,
+			fmt.Sprintf("DROP TRIGGER %s ON %s", fn, table),
+			fmt.Sprintf("DROP FUNCTION %s()", fn),
+			fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, col))
+
+	case actAlterType:
+		// Converting a column in place can't be made compatible with both
+		// versions, so it's deferred until the previous version is gone.
+		p.Changes = append(p.Changes, Change{Kind: ChangeColumnType, Table: a.table, Column: a.column, Type: a.typ})
+		p.Contract = append(p.Contract, a.stmt)
+
+	case actAddColumn:
+		if !a.notNull || a.hasDefault {
+			p.Expand = append(p.Expand, a.stmt)
+			return
+		}
+		// Add the column as nullable, and require it once
+		// the previous version no longer inserts rows without it.
+		p.Changes = append(p.Changes, Change{Kind: AddRequiredColumn, Table: a.table, Column: a.column})
+		p.Expand = append(p.Expand, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s",
+			table, col, strings.TrimSpace(notNullOnlyRe.ReplaceAllString(a.colDef, ""))))
+		p.Contract = append(p.Contract, setNotNull(a.table, a.column,
+			fmt.Sprintf("-- TODO: backfill %s.%s for existing rows before applying this.\n", a.table, a.column))...)
+
+	case actSetNotNull:
+		p.Changes = append(p.Changes, Change{Kind: SetNotNull, Table: a.table, Column: a.column})
+		p.Contract = append(p.Contract, setNotNull(a.table, a.column, "")...)
+
+	default:
+		p.Expand = append(p.Expand, a.stmt)
+	}
+}
+
+var notNullOnlyRe = regexp.MustCompile(`(?i)\s*\bNOT\s+NULL\b`)
+
+// setNotNull returns the statements making a column NOT NULL.
+// Validating a check constraint first avoids holding
+// an exclusive lock on the table while scanning it.
+func setNotNull(table, column, comment string) []string {
+	t, col := quoteTable(table), quoteIdent(column)
+	constraint := quoteIdent(fmt.Sprintf("%s_%s_not_null", strings.ReplaceAll(table, ".", "_"), column))
+	return []string{
+		fmt.Sprintf("%sALTER TABLE %s ADD CONSTRAINT %s CHECK (%s IS NOT NULL) NOT VALID", comment, t, constraint, col),
+		fmt.Sprintf("ALTER TABLE %s VALIDATE CONSTRAINT %s", t, constraint),
+		fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL", t, col),
+		fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", t, constraint),
+	}
+}
+
+var nonIdentRe = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// syncFuncName returns the name of the trigger function keeping
+// the columns from and to of table in sync.
+func syncFuncName(table, from, to string) string {
+	name := nonIdentRe.ReplaceAllString(strings.ToLower(fmt.Sprintf("sync_%s_%s_%s", table, from, to)), "_")
+	// Postgres truncates identifiers to 63 bytes.
+	return name[:min(len(name), 63)]
+}
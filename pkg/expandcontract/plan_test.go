@@ -0,0 +1,194 @@
+package expandcontract
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestBuildSchema(t *testing.T) {
+	c := qt.New(t)
+	s := BuildSchema([]Migration{
+		{Number: 1, SQL: `
+			CREATE TABLE orders (
+				id BIGSERIAL PRIMARY KEY,
+				total INT NOT NULL,
+				"Note" TEXT DEFAULT 'x;y',
+				CONSTRAINT positive CHECK (total > 0)
+			);
+			CREATE TABLE audit.events (id BIGINT);
+		`},
+		{Number: 2, SQL: `
+			ALTER TABLE orders ADD COLUMN customer_id BIGINT, DROP COLUMN "Note";
+			ALTER TABLE public.orders RENAME COLUMN total TO amount;
+			ALTER TABLE orders ALTER COLUMN amount TYPE NUMERIC(10, 2);
+			ALTER TABLE audit.events RENAME TO log;
+		`},
+	})
+	c.Assert(s, qt.DeepEquals, Schema{
+		"orders": {
+			"id":          "BIGSERIAL",
+			"amount":      "NUMERIC(10, 2)",
+			"customer_id": "BIGINT",
+		},
+		"audit.log": {"id": "BIGINT"},
+	})
+}
+
+func TestNewPlan(t *testing.T) {
+	c := qt.New(t)
+	before := Schema{
+		"orders": {"id": "BIGINT", "total": "INT", "note": "TEXT"},
+		"legacy": {"id": "BIGINT"},
+	}
+
+	p := NewPlan(before, `
+		CREATE TABLE customers (id BIGINT);
+		ALTER TABLE customers RENAME TO clients;
+		ALTER TABLE orders RENAME COLUMN total TO amount, ADD COLUMN customer_id BIGINT NOT NULL;
+		ALTER TABLE orders ADD COLUMN status TEXT NOT NULL DEFAULT 'new';
+		ALTER TABLE orders DROP COLUMN note;
+		DROP TABLE legacy;
+	`)
+
+	c.Assert(p.Changes, qt.DeepEquals, []Change{
+		{Kind: RenameColumn, Table: "orders", Column: "total", NewName: "amount"},
+		{Kind: AddRequiredColumn, Table: "orders", Column: "customer_id"},
+		{Kind: DropColumn, Table: "orders", Column: "note"},
+		{Kind: DropTable, Table: "legacy"},
+	})
+
+	c.Assert(p.Expand, qt.HasLen, 8)
+	c.Assert(p.Expand[:3], qt.DeepEquals, []string{
+		"CREATE TABLE customers (id BIGINT)",
+		"ALTER TABLE customers RENAME TO clients",
+		"ALTER TABLE orders ADD COLUMN amount INT",
+	})
+	c.Assert(p.Expand[3], qt.Equals, "UPDATE orders SET amount = total")
+	c.Assert(p.Expand[6:], qt.DeepEquals, []string{
+		"ALTER TABLE orders ADD COLUMN customer_id BIGINT",
+		"ALTER TABLE orders ADD COLUMN status TEXT NOT NULL DEFAULT 'new'",
+	})
+
+	c.Assert(p.Contract, qt.DeepEquals, []string{
+		"DROP TRIGGER sync_orders_total_amount ON orders",
+		"DROP FUNCTION sync_orders_total_amount()",
+		"ALTER TABLE orders DROP COLUMN total",
+		"-- TODO: backfill orders.customer_id for existing rows before applying this.\n" +
+			"ALTER TABLE orders ADD CONSTRAINT orders_customer_id_not_null CHECK (customer_id IS NOT NULL) NOT VALID",
+		"ALTER TABLE orders VALIDATE CONSTRAINT orders_customer_id_not_null",
+		"ALTER TABLE orders ALTER COLUMN customer_id SET NOT NULL",
+		"ALTER TABLE orders DROP CONSTRAINT orders_customer_id_not_null",
+		"ALTER TABLE orders DROP COLUMN note",
+		"DROP TABLE legacy",
+	})
+}
+
+func TestNewPlan_RenameTable(t *testing.T) {
+	c := qt.New(t)
+	p := NewPlan(Schema{"orders": {"id": "BIGINT"}}, `ALTER TABLE orders RENAME TO purchases`)
+	c.Assert(p.Changes, qt.DeepEquals, []Change{{Kind: RenameTable, Table: "orders", NewName: "purchases"}})
+	c.Assert(p.ExpandSQL(), qt.Equals, "ALTER TABLE orders RENAME TO purchases;\n\nCREATE VIEW orders AS SELECT * FROM purchases;\n")
+	c.Assert(p.ContractSQL(), qt.Equals, "DROP VIEW orders;\n")
+}
+
+func TestAffects(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		change Change
+		sql    string
+		want   bool
+	}{
+		{Change{Kind: DropTable, Table: "legacy"}, "SELECT id FROM legacy", true},
+		{Change{Kind: DropTable, Table: "legacy"}, "SELECT legacy_id FROM orders", false},
+		{Change{Kind: RenameColumn, Table: "orders", Column: "total"}, "SELECT total FROM orders WHERE id = $1", true},
+		{Change{Kind: RenameColumn, Table: "orders", Column: "total"}, "SELECT subtotal FROM orders", false},
+		{Change{Kind: RenameColumn, Table: "orders", Column: "total"}, "SELECT total FROM invoices", false},
+		{Change{Kind: DropColumn, Table: "audit.events", Column: "data"}, `SELECT "data" FROM events`, true},
+		{Change{Kind: AddRequiredColumn, Table: "orders", Column: "customer_id"}, "INSERT INTO orders (total) VALUES ($1)", true},
+		{Change{Kind: AddRequiredColumn, Table: "orders", Column: "customer_id"}, "INSERT INTO orders (total, customer_id) VALUES ($1, $2)", false},
+		{Change{Kind: AddRequiredColumn, Table: "orders", Column: "customer_id"}, "SELECT total FROM orders", false},
+	}
+	for _, test := range tests {
+		got := test.change.Affects(Query{SQL: test.sql})
+		c.Check(got, qt.Equals, test.want, qt.Commentf("%s: %s", test.change, test.sql))
+	}
+}
+
+func TestDestructive(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		stmt string
+		want []string
+	}{
+		{"CREATE TABLE orders (id BIGINT)", nil},
+		{"ALTER TABLE orders ADD COLUMN note TEXT", nil},
+		{"DROP TABLE IF EXISTS legacy, audit.events CASCADE", []string{
+			"drops table legacy and all its rows",
+			"drops table audit.events and all its rows",
+		}},
+		{"ALTER TABLE orders DROP COLUMN note, ALTER COLUMN total TYPE BIGINT", []string{
+			"drops column orders.note and its values",
+			"converts column orders.total to BIGINT, which may lose data or fail for existing values",
+		}},
+		{"ALTER TABLE orders DROP CONSTRAINT positive", nil},
+		{"DROP SCHEMA audit CASCADE", []string{"drops schema audit and all tables in it"}},
+		{"TRUNCATE ONLY orders RESTART IDENTITY", []string{"deletes all rows of table orders"}},
+		{"DELETE FROM orders", []string{"deletes all rows of table orders"}},
+		{"delete from public.orders where total = 0", []string{"deletes rows of table orders"}},
+	}
+	for _, test := range tests {
+		c.Check(Destructive(test.stmt), qt.DeepEquals, test.want, qt.Commentf("%s", test.stmt))
+	}
+}
+
+func TestFindQueries(t *testing.T) {
+	c := qt.New(t)
+	root := t.TempDir()
+	files := map[string]string{
+		"orders/orders.go": "package orders\n\n" +
+			"// SELECT in a comment\n" +
+			"func get() {\n" +
+			"\tdb.QueryRow(ctx, `\n\t\tSELECT total\n\t\tFROM orders\n\t`)\n" +
+			"\tdb.Exec(ctx, \"DELETE FROM orders\")\n" +
+			"\tlog.Info(\"selected order\")\n" +
+			"}\n",
+		"users/users.ts":           "const row = await db.queryRow`SELECT * FROM users`;\n",
+		"node_modules/pg/index.js": "query('SELECT 1')\n",
+		".encore/x.go":             "const q = \"SELECT 1\"\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0755), qt.IsNil)
+		c.Assert(os.WriteFile(path, []byte(content), 0644), qt.IsNil)
+	}
+
+	queries, err := FindQueries(root)
+	c.Assert(err, qt.IsNil)
+	c.Assert(queries, qt.DeepEquals, []Query{
+		{File: "orders/orders.go", Line: 5, SQL: "\n\t\tSELECT total\n\t\tFROM orders\n\t"},
+		{File: "orders/orders.go", Line: 9, SQL: "DELETE FROM orders"},
+		{File: "users/users.ts", Line: 1, SQL: "SELECT * FROM users"},
+	})
+}
+
+func TestState(t *testing.T) {
+	c := qt.New(t)
+	dir := t.TempDir()
+
+	s, err := LoadState(dir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Rollouts, qt.HasLen, 0)
+
+	r := &Rollout{ContractFile: "3_rename.up.sql"}
+	r.SetPhase("staging", Contract)
+	r.SetPhase("prod", Expand)
+	s.Rollouts["3_rename.up.sql"] = r
+	c.Assert(s.Save(dir), qt.IsNil)
+
+	got, err := LoadState(dir)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, s)
+}
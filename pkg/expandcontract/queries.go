@@ -0,0 +1,161 @@
+package expandcontract
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Query is an SQL query in the source code of an app.
+type Query struct {
+	File string // relative to the app root
+	Line int
+	SQL  string
+}
+
+// sourceExts are the extensions of the source files searched for queries.
+var sourceExts = []string{".go", ".ts", ".tsx", ".js", ".mjs", ".cjs", ".mts", ".cts"}
+
+var queryRe = regexp.MustCompile(`(?is)^\s*(SELECT|INSERT|UPDATE|DELETE|WITH)\s`)
+
+// FindQueries finds the SQL queries in the source code of the app at appRoot.
+// Queries are string literals starting with SELECT, INSERT, UPDATE, DELETE or WITH.
+func FindQueries(appRoot string) ([]Query, error) {
+	var queries []Query
+	err := filepath.WalkDir(appRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != appRoot && (strings.HasPrefix(name, ".") || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !slices.Contains(sourceExts, filepath.Ext(name)) || strings.HasPrefix(name, "encore.gen.") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(appRoot, path)
+		if err != nil {
+			return err
+		}
+		for _, lit := range stringLiterals(string(data)) {
+			if queryRe.MatchString(lit.value) {
+				queries = append(queries, Query{File: filepath.ToSlash(rel), Line: lit.line, SQL: lit.value})
+			}
+		}
+		return nil
+	})
+	return queries, err
+}
+
+type literal struct {
+	value string
+	line  int
+}
+
+// stringLiterals returns the string literals in Go or JavaScript source code,
+// delimited by double quotes, single quotes or backticks.
+func stringLiterals(src string) []literal {
+	var lits []literal
+	line := 1
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+		case c == '/' && strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				return lits
+			}
+			i += end - 1
+		case c == '/' && strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return lits
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 3
+		case c == '"' || c == '\'' || c == '`':
+			start := i + 1
+			end := start
+			for end < len(src) && src[end] != c {
+				if src[end] == '\\' && c != '`' {
+					end++
+				} else if src[end] == '\n' && c != '`' {
+					break
+				}
+				end++
+			}
+			if end >= len(src) {
+				return lits
+			}
+			lits = append(lits, literal{value: src[start:end], line: line})
+			line += strings.Count(src[start:end], "\n")
+			i = end
+		}
+	}
+	return lits
+}
+
+var identRe = regexp.MustCompile(`"(?:[^"]|"")+"|[A-Za-z_][\w$]*`)
+
+// Affects reports whether q is broken by the change
+// if run by the previous version of the app.
+func (c Change) Affects(q Query) bool {
+	idents := make(map[string]bool)
+	for _, id := range identRe.FindAllString(q.SQL, -1) {
+		idents[parseIdent(id)] = true
+	}
+
+	// Match on the table name without the schema,
+	// as queries often rely on the search path.
+	table := c.Table
+	if _, name, ok := strings.Cut(table, "."); ok {
+		table = name
+	}
+	if !idents[table] {
+		return false
+	}
+
+	switch c.Kind {
+	case DropTable, RenameTable:
+		return true
+	case DropColumn, RenameColumn, ChangeColumnType:
+		return idents[c.Column]
+	case AddRequiredColumn, SetNotNull:
+		m := insertRe.FindStringSubmatch(q.SQL)
+		if m == nil {
+			return false
+		}
+		if into := parseTable(m[1]); into != c.Table && into != table {
+			return false
+		}
+		return !idents[c.Column]
+	default:
+		return false
+	}
+}
+
+var insertRe = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+` + tableNamePattern)
+
+// AffectedQueries returns the queries broken by the change.
+func (c Change) AffectedQueries(queries []Query) []Query {
+	var affected []Query
+	for _, q := range queries {
+		if c.Affects(q) {
+			affected = append(affected, q)
+		}
+	}
+	return affected
+}
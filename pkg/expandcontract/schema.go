@@ -0,0 +1,241 @@
+package expandcontract
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Migration is a database migration.
+type Migration struct {
+	Number   uint64
+	Filename string
+	SQL      string
+}
+
+// Migrations reads the migrations of db from its migration directory
+// relative to appRoot, ordered by their number.
+func Migrations(appRoot string, db *meta.SQLDatabase) ([]Migration, error) {
+	if db.MigrationRelPath == nil {
+		return nil, nil
+	}
+	dir := MigrationDir(appRoot, db)
+
+	var migrations []Migration
+	for _, m := range db.Migrations {
+		data, err := os.ReadFile(filepath.Join(dir, m.Filename))
+		if err != nil {
+			return nil, errors.Wrapf(err, "read migration %s", m.Filename)
+		}
+		migrations = append(migrations, Migration{Number: m.Number, Filename: m.Filename, SQL: string(data)})
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Number, b.Number) })
+	return migrations, nil
+}
+
+// MigrationDir returns the absolute path to the migration directory of db.
+func MigrationDir(appRoot string, db *meta.SQLDatabase) string {
+	return filepath.Join(appRoot, filepath.FromSlash(db.GetMigrationRelPath()))
+}
+
+// Schema describes the tables of a database, as declared by its migrations.
+// It maps table names, as returned by parseTable, to their columns
+// and the columns to their types.
+type Schema map[string]map[string]string
+
+// BuildSchema computes the schema declared by applying migrations in order.
+func BuildSchema(migrations []Migration) Schema {
+	s := make(Schema)
+	for _, m := range migrations {
+		for _, stmt := range SplitStatements(m.SQL) {
+			for _, a := range parseStatement(stmt) {
+				s.apply(a)
+			}
+		}
+	}
+	return s
+}
+
+// ColumnType returns the type of the given column, or "" if it's unknown.
+func (s Schema) ColumnType(table, column string) string {
+	return s[table][column]
+}
+
+func (s Schema) apply(a action) {
+	switch a.kind {
+	case actCreateTable:
+		cols := make(map[string]string, len(a.columns))
+		for _, c := range a.columns {
+			cols[c.name] = c.typ
+		}
+		s[a.table] = cols
+	case actDropTable:
+		delete(s, a.table)
+	case actRenameTable:
+		if cols, ok := s[a.table]; ok {
+			delete(s, a.table)
+			s[a.newName] = cols
+		}
+	case actAddColumn:
+		if cols, ok := s[a.table]; ok {
+			cols[a.column] = a.typ
+		}
+	case actDropColumn:
+		delete(s[a.table], a.column)
+	case actRenameColumn:
+		if cols, ok := s[a.table]; ok {
+			if typ, ok := cols[a.column]; ok {
+				delete(cols, a.column)
+				cols[a.newName] = typ
+			}
+		}
+	case actAlterType:
+		if cols, ok := s[a.table]; ok {
+			if _, ok := cols[a.column]; ok {
+				cols[a.column] = a.typ
+			}
+		}
+	}
+}
+
+type actionKind int
+
+const (
+	actOther actionKind = iota
+	actCreateTable
+	actDropTable
+	actRenameTable
+	actAddColumn
+	actDropColumn
+	actRenameColumn
+	actAlterType
+	actSetNotNull
+)
+
+// action is a change made by (part of) a migration statement.
+type action struct {
+	kind    actionKind
+	stmt    string // the SQL statement making the change
+	table   string
+	column  string
+	newName string // for renames
+	typ     string // the column type, for actAddColumn and actAlterType
+
+	notNull    bool // for actAddColumn: whether the column is NOT NULL
+	hasDefault bool // for actAddColumn: whether the column has a default value
+	colDef     string
+
+	columns []column // for actCreateTable
+}
+
+type column struct {
+	name string
+	typ  string
+}
+
+var (
+	tableNamePattern = `(` + identPattern + `(?:\s*\.\s*` + identPattern + `)?)`
+
+	createTableRe = regexp.MustCompile(`(?is)^CREATE\s+(?:(?:GLOBAL|LOCAL)\s+)?(?:(?:TEMP|TEMPORARY|UNLOGGED)\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?` + tableNamePattern + `\s*\(`)
+	dropTableRe   = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(.+?)(?:\s+(?:CASCADE|RESTRICT))?$`)
+	alterTableRe  = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?` + tableNamePattern + `\s+(.+)$`)
+
+	renameTableRe  = regexp.MustCompile(`(?is)^RENAME\s+TO\s+(` + identPattern + `)$`)
+	renameColumnRe = regexp.MustCompile(`(?is)^RENAME\s+(?:COLUMN\s+)?(` + identPattern + `)\s+TO\s+(` + identPattern + `)$`)
+	addColumnRe    = regexp.MustCompile(`(?is)^ADD\s+(COLUMN\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(` + identPattern + `)\s+(.+)$`)
+	dropColumnRe   = regexp.MustCompile(`(?is)^DROP\s+(?:COLUMN\s+)?(?:IF\s+EXISTS\s+)?(` + identPattern + `)(?:\s+(?:CASCADE|RESTRICT))?$`)
+	alterTypeRe    = regexp.MustCompile(`(?is)^ALTER\s+(?:COLUMN\s+)?(` + identPattern + `)\s+(?:SET\s+DATA\s+)?TYPE\s+(.+?)(?:\s+USING\s+.+)?$`)
+	setNotNullRe   = regexp.MustCompile(`(?is)^ALTER\s+(?:COLUMN\s+)?(` + identPattern + `)\s+SET\s+NOT\s+NULL$`)
+
+	columnDefRe = regexp.MustCompile(`(?s)^(` + identPattern + `)\s+(.+)$`)
+
+	// tableConstraintRe matches table constraints, which are not columns.
+	tableConstraintRe = regexp.MustCompile(`(?i)^(CONSTRAINT|PRIMARY|UNIQUE|CHECK|FOREIGN|EXCLUDE|LIKE)\b`)
+	// columnConstraintRe matches the start of the constraints following a column's type.
+	columnConstraintRe = regexp.MustCompile(`(?i)\s+(NOT\s+NULL|NULL|DEFAULT|PRIMARY\s+KEY|REFERENCES|UNIQUE|CHECK|CONSTRAINT|GENERATED|COLLATE)\b`)
+	notNullRe          = regexp.MustCompile(`(?i)\b(NOT\s+NULL|PRIMARY\s+KEY)\b`)
+	defaultRe          = regexp.MustCompile(`(?i)\b(DEFAULT|GENERATED)\b|^(SMALL|BIG)?SERIAL\b`)
+)
+
+// parseStatement parses the changes made by a migration statement.
+// Statements that are not understood are returned as a single actOther.
+func parseStatement(stmt string) []action {
+	if m := createTableRe.FindStringSubmatchIndex(stmt); m != nil {
+		a := action{kind: actCreateTable, stmt: stmt, table: parseTable(stmt[m[2]:m[3]])}
+		open := m[1] - 1
+		if end := matchingParen(stmt, open); end > 0 {
+			for _, def := range splitTopLevel(stmt[open+1 : end]) {
+				if tableConstraintRe.MatchString(def) {
+					continue
+				}
+				if m := columnDefRe.FindStringSubmatch(def); m != nil {
+					typ, _, _ := parseColumnDef(m[2])
+					a.columns = append(a.columns, column{name: parseIdent(m[1]), typ: typ})
+				}
+			}
+		}
+		return []action{a}
+	}
+
+	if m := dropTableRe.FindStringSubmatch(stmt); m != nil {
+		var actions []action
+		for _, name := range splitTopLevel(m[1]) {
+			table := parseTable(name)
+			actions = append(actions, action{
+				kind:  actDropTable,
+				stmt:  "DROP TABLE " + quoteTable(table),
+				table: table,
+			})
+		}
+		return actions
+	}
+
+	m := alterTableRe.FindStringSubmatch(stmt)
+	if m == nil {
+		return []action{{kind: actOther, stmt: stmt}}
+	}
+	table := parseTable(m[1])
+	prefix := "ALTER TABLE " + quoteTable(table) + " "
+
+	var actions []action
+	for _, part := range splitTopLevel(m[2]) {
+		a := action{kind: actOther, stmt: prefix + part, table: table}
+		if m := renameTableRe.FindStringSubmatch(part); m != nil {
+			// Renamed tables stay in the same schema.
+			a.kind, a.newName = actRenameTable, parseIdent(m[1])
+			if schema, _, ok := strings.Cut(table, "."); ok {
+				a.newName = schema + "." + a.newName
+			}
+		} else if m := renameColumnRe.FindStringSubmatch(part); m != nil {
+			a.kind, a.column, a.newName = actRenameColumn, parseIdent(m[1]), parseIdent(m[2])
+		} else if m := addColumnRe.FindStringSubmatch(part); m != nil && (m[1] != "" || !tableConstraintRe.MatchString(m[2])) {
+			a.kind, a.column, a.colDef = actAddColumn, parseIdent(m[2]), m[3]
+			a.typ, a.notNull, a.hasDefault = parseColumnDef(m[3])
+		} else if m := dropColumnRe.FindStringSubmatch(part); m != nil && !strings.EqualFold(m[1], "CONSTRAINT") {
+			a.kind, a.column = actDropColumn, parseIdent(m[1])
+		} else if m := setNotNullRe.FindStringSubmatch(part); m != nil {
+			a.kind, a.column = actSetNotNull, parseIdent(m[1])
+		} else if m := alterTypeRe.FindStringSubmatch(part); m != nil {
+			a.kind, a.column, a.typ = actAlterType, parseIdent(m[1]), strings.TrimSpace(m[2])
+		}
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+// parseColumnDef parses a column definition following the column name.
+func parseColumnDef(def string) (typ string, notNull, hasDefault bool) {
+	def = strings.TrimSpace(def)
+	typ = def
+	if loc := columnConstraintRe.FindStringIndex(def); loc != nil {
+		typ = def[:loc[0]]
+	}
+	return strings.TrimSpace(typ), notNullRe.MatchString(def), defaultRe.MatchString(def)
+}
@@ -0,0 +1,185 @@
+package expandcontract
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SplitStatements splits SQL into its statements, removing comments.
+// Semicolons in string literals, quoted identifiers and dollar-quoted
+// bodies (like those of functions) don't end statements.
+func SplitStatements(sql string) []string {
+	var (
+		stmts []string
+		cur   strings.Builder
+	)
+	flush := func() {
+		if s := strings.TrimSpace(cur.String()); s != "" {
+			stmts = append(stmts, s)
+		}
+		cur.Reset()
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end
+				cur.WriteByte('\n')
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 3
+				cur.WriteByte(' ')
+			}
+		case c == '\'' || c == '"':
+			end := i + 1
+			for end < len(sql) {
+				if sql[end] == c {
+					// A doubled quote is an escaped quote.
+					if end+1 < len(sql) && sql[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			end = min(end, len(sql)-1)
+			cur.WriteString(sql[i : end+1])
+			i = end
+		case c == '$':
+			if tag := dollarTagRe.FindString(sql[i:]); tag != "" {
+				end := strings.Index(sql[i+len(tag):], tag)
+				if end < 0 {
+					end = len(sql)
+				} else {
+					end = i + len(tag) + end + len(tag)
+				}
+				cur.WriteString(sql[i:end])
+				i = end - 1
+				continue
+			}
+			cur.WriteByte(c)
+		case c == ';':
+			flush()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+	return stmts
+}
+
+var dollarTagRe = regexp.MustCompile(`^\$(?:[A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// splitTopLevel splits s on commas that are not nested in parentheses or quotes.
+func splitTopLevel(s string) []string {
+	var (
+		parts []string
+		depth int
+		quote byte
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(s[start:]); rest != "" {
+		parts = append(parts, rest)
+	}
+	return parts
+}
+
+// matchingParen returns the index of the parenthesis closing
+// the one at s[open], or -1 if there is none.
+func matchingParen(s string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+const identPattern = `(?:"(?:[^"]|"")+"|[A-Za-z_][\w$]*)`
+
+var qualifiedNameRe = regexp.MustCompile(`^(` + identPattern + `)\s*\.\s*(` + identPattern + `)$`)
+
+// parseTable parses a possibly qualified table name.
+// Tables in the public schema are returned unqualified, like "orders",
+// and tables in other schemas qualified, like "audit.events".
+func parseTable(s string) string {
+	s = strings.TrimSpace(s)
+	if m := qualifiedNameRe.FindStringSubmatch(s); m != nil {
+		schema, name := parseIdent(m[1]), parseIdent(m[2])
+		if schema == "public" {
+			return name
+		}
+		return schema + "." + name
+	}
+	return parseIdent(s)
+}
+
+// parseIdent parses an SQL identifier. Unquoted identifiers are case-insensitive.
+func parseIdent(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return strings.ToLower(s)
+}
+
+var plainIdentRe = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+
+// quoteIdent quotes an identifier for use in SQL, if necessary.
+func quoteIdent(s string) string {
+	if plainIdentRe.MatchString(s) {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// quoteTable quotes a table name returned by parseTable for use in SQL.
+func quoteTable(s string) string {
+	if schema, name, ok := strings.Cut(s, "."); ok {
+		return quoteIdent(schema) + "." + quoteIdent(name)
+	}
+	return quoteIdent(s)
+}
@@ -0,0 +1,91 @@
+package expandcontract
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Phase is the phase of an expand/contract rollout.
+type Phase string
+
+const (
+	// Expand means the expand migration is applied and the
+	// previous version of the app may still be running.
+	Expand Phase = "expand"
+	// Contract means the previous version of the app is gone
+	// and the contract migration is applied.
+	Contract Phase = "contract"
+)
+
+// ContractDir is the directory, relative to a migration directory,
+// containing the contract migrations that are not yet applied
+// and the state of the rollouts.
+//
+// Encore ignores subdirectories of migration directories,
+// so it's never applied as migrations.
+const ContractDir = "contract"
+
+const stateFile = "rollouts.json"
+
+// State is the state of the expand/contract rollouts of a database.
+type State struct {
+	// Rollouts are the rollouts, keyed by the filename of their expand migration.
+	Rollouts map[string]*Rollout `json:"rollouts"`
+}
+
+// Rollout is a migration split into expand and contract phases.
+type Rollout struct {
+	// ContractFile is the filename of the contract migration, in ContractDir
+	// until it's promoted to a migration.
+	ContractFile string `json:"contract_file"`
+
+	// Promoted is the filename of the contract migration in the migration
+	// directory, if it has been promoted.
+	Promoted string `json:"promoted,omitempty"`
+
+	// Environments are the phases of each environment.
+	Environments map[string]Phase `json:"environments,omitempty"`
+}
+
+// LoadState loads the rollout state from the migration directory dir.
+// If there is no state it returns an empty one.
+func LoadState(dir string) (*State, error) {
+	s := &State{Rollouts: make(map[string]*Rollout)}
+	data, err := os.ReadFile(filepath.Join(dir, ContractDir, stateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if s.Rollouts == nil {
+		s.Rollouts = make(map[string]*Rollout)
+	}
+	return s, nil
+}
+
+// Save writes the rollout state to the migration directory dir.
+func (s *State) Save(dir string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ContractDir), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ContractDir, stateFile), append(data, '\n'), 0644)
+}
+
+// SetPhase records that env is in the given phase of the rollout.
+func (r *Rollout) SetPhase(env string, phase Phase) {
+	if r.Environments == nil {
+		r.Environments = make(map[string]Phase)
+	}
+	r.Environments[env] = phase
+}
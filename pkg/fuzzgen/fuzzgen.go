@@ -0,0 +1,828 @@
+// Package fuzzgen generates API requests from an application's metadata,
+// for fuzz testing its endpoints.
+package fuzzgen
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"encr.dev/pkg/schemautil"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// Mode describes what kind of values to generate.
+type Mode int
+
+const (
+	// Valid generates values that conform to the request schema.
+	Valid Mode = iota
+
+	// Boundary generates values that conform to the request schema
+	// but are at the edges of what it allows, like empty strings,
+	// empty lists and the minimum and maximum values of numeric types.
+	Boundary
+
+	// Invalid generates values that violate the request schema,
+	// like values of the wrong type, out of range numbers,
+	// missing required fields and malformed request bodies.
+	Invalid
+)
+
+// Modes are all the supported modes.
+var Modes = []Mode{Valid, Boundary, Invalid}
+
+func (m Mode) String() string {
+	switch m {
+	case Valid:
+		return "valid"
+	case Boundary:
+		return "boundary"
+	case Invalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseMode parses a mode name, as returned by Mode.String.
+func ParseMode(s string) (Mode, error) {
+	for _, m := range Modes {
+		if m.String() == s {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown fuzz mode %q", s)
+}
+
+// Request is a generated HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"` // the request path, including the query string
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Generator generates requests for the endpoints of an application.
+// The generated requests are fully determined by the source of randomness,
+// so a generator seeded with the same value generates the same requests.
+//
+// It is not safe for concurrent use.
+type Generator struct {
+	md  *meta.Data
+	rnd *rand.Rand
+}
+
+// New returns a generator for the endpoints in md,
+// using src as its source of randomness.
+func New(md *meta.Data, src rand.Source) *Generator {
+	return &Generator{md: md, rnd: rand.New(src)}
+}
+
+const (
+	// maxDepth is the maximum nesting depth of generated values,
+	// to guarantee termination for recursive types.
+	maxDepth = 6
+
+	// maxLen is the maximum length of generated strings, lists and maps,
+	// except for boundary values.
+	maxLen = 8
+
+	// boundaryLen is the length of long strings, lists and maps
+	// generated as boundary values.
+	boundaryLen = 1024
+)
+
+// Request generates a request for the given endpoint.
+func (g *Generator) Request(rpc *meta.RPC, mode Mode) *Request {
+	req := &Request{
+		Method: g.method(rpc),
+		Header: make(http.Header),
+	}
+	path := g.path(rpc.Path, mode)
+
+	var (
+		query url.Values
+		body  map[string]any
+	)
+	if st, args := g.requestStruct(rpc.RequestSchema); st != nil {
+		fields := schemautil.RequestFieldsByLocation(st, req.Method)
+		for _, loc := range []schemautil.FieldLocation{
+			schemautil.FieldLocationHeader,
+			schemautil.FieldLocationQuery,
+			schemautil.FieldLocationCookie,
+			schemautil.FieldLocationBody,
+		} {
+			for _, f := range fields[loc] {
+				if g.omitField(f.Field, mode) {
+					continue
+				}
+				switch loc {
+				case schemautil.FieldLocationHeader:
+					for _, v := range g.strings(f.Typ, args, mode) {
+						req.Header.Add(f.Name, v)
+					}
+				case schemautil.FieldLocationQuery:
+					if query == nil {
+						query = make(url.Values)
+					}
+					for _, v := range g.strings(f.Typ, args, mode) {
+						query.Add(f.Name, v)
+					}
+				case schemautil.FieldLocationCookie:
+					for _, v := range g.strings(f.Typ, args, mode) {
+						req.Header.Add("Cookie", (&http.Cookie{Name: f.Name, Value: v}).String())
+					}
+				case schemautil.FieldLocationBody:
+					if body == nil {
+						body = make(map[string]any)
+					}
+					body[f.Name] = g.value(f.Typ, args, 0, mode)
+				}
+			}
+		}
+	} else if rpc.Proto == meta.RPC_RAW && hasBody(req.Method) {
+		// Raw endpoints have no schema; send an arbitrary JSON object.
+		body = g.object(0)
+	}
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			// Generated values are always marshallable.
+			panic(fmt.Sprintf("fuzzgen: marshal body: %v", err))
+		}
+		req.Body = string(data)
+		if mode == Invalid && g.chance(8) {
+			req.Body = g.malformed(req.Body)
+		}
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	req.Path = path
+	return req
+}
+
+var wildcardMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+func (g *Generator) method(rpc *meta.RPC) string {
+	methods := rpc.HttpMethods
+	if len(methods) == 0 || methods[0] == "*" {
+		methods = wildcardMethods
+	}
+	return methods[g.rnd.Intn(len(methods))]
+}
+
+func hasBody(method string) bool {
+	return method != "GET" && method != "HEAD" && method != "DELETE"
+}
+
+func (g *Generator) path(p *meta.Path, mode Mode) string {
+	var b strings.Builder
+	for _, seg := range p.GetSegments() {
+		b.WriteByte('/')
+		switch seg.Type {
+		case meta.PathSegment_LITERAL:
+			b.WriteString(seg.Value)
+		case meta.PathSegment_PARAM:
+			b.WriteString(url.PathEscape(g.pathParam(seg.ValueType, mode)))
+		case meta.PathSegment_WILDCARD, meta.PathSegment_FALLBACK:
+			n := 1 + g.rnd.Intn(3)
+			for i := 0; i < n; i++ {
+				if i > 0 {
+					b.WriteByte('/')
+				}
+				b.WriteString(url.PathEscape(g.str(limits{}, Valid)))
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
+
+func (g *Generator) pathParam(typ meta.PathSegment_ParamType, mode Mode) string {
+	if mode == Invalid && typ != meta.PathSegment_STRING && g.chance(2) {
+		return pick(g, "not-a-value", "-", "1.5", "99999999999999999999999")
+	}
+
+	var b schema.Builtin
+	switch typ {
+	case meta.PathSegment_BOOL:
+		b = schema.Builtin_BOOL
+	case meta.PathSegment_INT8:
+		b = schema.Builtin_INT8
+	case meta.PathSegment_INT16:
+		b = schema.Builtin_INT16
+	case meta.PathSegment_INT32:
+		b = schema.Builtin_INT32
+	case meta.PathSegment_INT64:
+		b = schema.Builtin_INT64
+	case meta.PathSegment_INT:
+		b = schema.Builtin_INT
+	case meta.PathSegment_UINT8:
+		b = schema.Builtin_UINT8
+	case meta.PathSegment_UINT16:
+		b = schema.Builtin_UINT16
+	case meta.PathSegment_UINT32:
+		b = schema.Builtin_UINT32
+	case meta.PathSegment_UINT64:
+		b = schema.Builtin_UINT64
+	case meta.PathSegment_UINT:
+		b = schema.Builtin_UINT
+	case meta.PathSegment_UUID:
+		b = schema.Builtin_UUID
+	default:
+		s := g.str(limits{}, mode)
+		if s == "" {
+			// Path parameters can't be empty.
+			s = "x"
+		}
+		return s
+	}
+	if mode == Invalid {
+		mode = Valid
+	}
+	return toString(g.builtin(b, nil, mode))
+}
+
+// requestStruct resolves the request schema to a struct,
+// returning it together with its type arguments.
+func (g *Generator) requestStruct(typ *schema.Type) (*schema.Struct, []*schema.Type) {
+	if ptr := typ.GetPointer(); ptr != nil {
+		typ = ptr.Base
+	}
+	if named := typ.GetNamed(); named != nil && int(named.Id) < len(g.md.Decls) {
+		if st := g.md.Decls[named.Id].GetType().GetStruct(); st != nil {
+			return st, named.TypeArguments
+		}
+		return nil, nil
+	}
+	return typ.GetStruct(), nil
+}
+
+// omitField reports whether to leave out the field f from the request.
+func (g *Generator) omitField(f *schema.Field, mode Mode) bool {
+	switch {
+	case f.Optional:
+		return g.chance(3)
+	case mode == Invalid:
+		return g.chance(6)
+	default:
+		return false
+	}
+}
+
+// strings generates the string representations of a header, query string
+// or cookie value of the given type.
+func (g *Generator) strings(typ *schema.Type, args []*schema.Type, mode Mode) []string {
+	if list := typ.GetList(); list != nil {
+		n := g.rnd.Intn(4)
+		vals := make([]string, n)
+		for i := range vals {
+			vals[i] = toString(g.value(list.Elem, args, 1, mode))
+		}
+		return vals
+	}
+	return []string{toString(g.value(typ, args, 1, mode))}
+}
+
+// value generates a JSON-marshallable value of the given type.
+func (g *Generator) value(typ *schema.Type, args []*schema.Type, depth int, mode Mode) any {
+	if typ == nil || depth > maxDepth {
+		return nil
+	}
+	if mode == Invalid && g.chance(4) {
+		return g.wrongType(typ)
+	}
+
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Builtin:
+		return g.builtin(t.Builtin, typ.Validation, mode)
+
+	case *schema.Type_Named:
+		if int(t.Named.Id) >= len(g.md.Decls) {
+			return nil
+		}
+		decl := g.md.Decls[t.Named.Id]
+		declArgs := make([]*schema.Type, len(t.Named.TypeArguments))
+		for i, arg := range t.Named.TypeArguments {
+			declArgs[i] = resolveParam(arg, args)
+		}
+		return g.value(decl.Type, declArgs, depth+1, mode)
+
+	case *schema.Type_TypeParameter:
+		if idx := int(t.TypeParameter.ParamIdx); idx < len(args) {
+			return g.value(args[idx], nil, depth, mode)
+		}
+		return nil
+
+	case *schema.Type_Struct:
+		obj := make(map[string]any, len(t.Struct.Fields))
+		for _, f := range t.Struct.Fields {
+			name := f.JsonName
+			if name == "-" {
+				continue
+			} else if name == "" {
+				name = f.Name
+			}
+			if !g.omitField(f, mode) {
+				obj[name] = g.value(f.Typ, args, depth+1, mode)
+			}
+		}
+		return obj
+
+	case *schema.Type_List:
+		lim := limitsOf(typ.Validation)
+		n := g.length(lim, mode)
+		if mode == Boundary && n > maxLen*4 {
+			// Keep boundary lists of complex types reasonably sized.
+			n = maxLen * 4
+		}
+		list := make([]any, n)
+		for i := range list {
+			list[i] = g.value(t.List.Elem, args, depth+1, mode)
+		}
+		return list
+
+	case *schema.Type_Map:
+		n := g.rnd.Intn(maxLen)
+		m := make(map[string]any, n)
+		for i := 0; i < n; i++ {
+			key := toString(g.value(t.Map.Key, args, depth+1, mode))
+			m[key] = g.value(t.Map.Value, args, depth+1, mode)
+		}
+		return m
+
+	case *schema.Type_Pointer:
+		if g.chance(4) {
+			return nil
+		}
+		return g.value(t.Pointer.Base, args, depth, mode)
+
+	case *schema.Type_Option:
+		if g.chance(4) {
+			return nil
+		}
+		return g.value(t.Option.Value, args, depth, mode)
+
+	case *schema.Type_Union:
+		if len(t.Union.Types) == 0 {
+			return nil
+		}
+		return g.value(t.Union.Types[g.rnd.Intn(len(t.Union.Types))], args, depth, mode)
+
+	case *schema.Type_Literal:
+		return literalValue(t.Literal)
+
+	default:
+		return nil
+	}
+}
+
+// resolveParam resolves typ if it is a reference to one of args.
+func resolveParam(typ *schema.Type, args []*schema.Type) *schema.Type {
+	if ref := typ.GetTypeParameter(); ref != nil && int(ref.ParamIdx) < len(args) {
+		return args[ref.ParamIdx]
+	}
+	return typ
+}
+
+func literalValue(lit *schema.Literal) any {
+	switch v := lit.Value.(type) {
+	case *schema.Literal_Str:
+		return v.Str
+	case *schema.Literal_Boolean:
+		return v.Boolean
+	case *schema.Literal_Int:
+		return v.Int
+	case *schema.Literal_Float:
+		return v.Float
+	default:
+		return nil
+	}
+}
+
+// builtin generates a value of the builtin type b, honoring the validation rules in v.
+func (g *Generator) builtin(b schema.Builtin, v *schema.ValidationExpr, mode Mode) any {
+	lim := limitsOf(v)
+	switch b {
+	case schema.Builtin_BOOL:
+		if mode == Invalid {
+			return pick(g, "yes", "1", "")
+		}
+		return g.chance(2)
+
+	case schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32, schema.Builtin_INT64, schema.Builtin_INT:
+		return g.signed(intBits(b), lim, mode)
+
+	case schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32, schema.Builtin_UINT64, schema.Builtin_UINT:
+		return g.unsigned(intBits(b), lim, mode)
+
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return g.float(b, lim, mode)
+
+	case schema.Builtin_STRING, schema.Builtin_USER_ID:
+		return g.str(lim, mode)
+
+	case schema.Builtin_BYTES:
+		switch mode {
+		case Boundary:
+			return base64.StdEncoding.EncodeToString(g.bytes(pick(g, 0, boundaryLen)))
+		case Invalid:
+			return "!not base64!"
+		default:
+			return base64.StdEncoding.EncodeToString(g.bytes(g.rnd.Intn(maxLen * 4)))
+		}
+
+	case schema.Builtin_TIME:
+		switch mode {
+		case Boundary:
+			return pick(g, "0001-01-01T00:00:00Z", "9999-12-31T23:59:59.999999999Z", "1970-01-01T00:00:00Z", "2024-02-29T12:00:00+14:00")
+		case Invalid:
+			return pick(g, "not-a-time", "2024-13-45T25:61:61Z", "1700000000")
+		default:
+			sec := g.rnd.Int63n(4102444800) // 1970 to 2100
+			return time.Unix(sec, 0).UTC().Format(time.RFC3339)
+		}
+
+	case schema.Builtin_UUID:
+		switch mode {
+		case Boundary:
+			return pick(g, "00000000-0000-0000-0000-000000000000", "ffffffff-ffff-ffff-ffff-ffffffffffff")
+		case Invalid:
+			return pick(g, "not-a-uuid", "00000000-0000-0000-0000", "")
+		default:
+			u := g.bytes(16)
+			u[6] = (u[6] & 0x0f) | 0x40 // version 4
+			u[8] = (u[8] & 0x3f) | 0x80 // variant 10
+			return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+		}
+
+	case schema.Builtin_DECIMAL:
+		switch mode {
+		case Boundary:
+			return pick(g, "0", "-0.000000000000000001", "99999999999999999999999999999999.99")
+		case Invalid:
+			return pick(g, "abc", "1.2.3", "")
+		default:
+			return strconv.FormatFloat(g.rnd.Float64()*2000-1000, 'f', 2, 64)
+		}
+
+	case schema.Builtin_JSON, schema.Builtin_ANY:
+		return g.anyValue(0)
+
+	case schema.Builtin_FILE:
+		if mode == Invalid {
+			return "not a file"
+		}
+		return map[string]any{
+			"filename":     g.str(limits{}, mode) + ".bin",
+			"content_type": "application/octet-stream",
+			"data":         base64.StdEncoding.EncodeToString(g.bytes(g.rnd.Intn(maxLen * 4))),
+		}
+
+	default:
+		return nil
+	}
+}
+
+func intBits(b schema.Builtin) int {
+	switch b {
+	case schema.Builtin_INT8, schema.Builtin_UINT8:
+		return 8
+	case schema.Builtin_INT16, schema.Builtin_UINT16:
+		return 16
+	case schema.Builtin_INT32, schema.Builtin_UINT32:
+		return 32
+	default:
+		return 64
+	}
+}
+
+func (g *Generator) signed(bits int, lim limits, mode Mode) any {
+	lo, hi := int64(-1)<<(bits-1), int64(1<<(bits-1)-1)
+	if lim.minVal != nil && *lim.minVal > float64(lo) {
+		lo = int64(math.Ceil(*lim.minVal))
+	}
+	if lim.maxVal != nil && *lim.maxVal < float64(hi) {
+		hi = int64(math.Floor(*lim.maxVal))
+	}
+	if lo > hi {
+		return json.Number(strconv.FormatInt(lo, 10))
+	}
+
+	var n int64
+	switch mode {
+	case Boundary:
+		n = pick(g, lo, hi, max(lo, min(hi, 0)), max(lo, min(hi, -1)), max(lo, min(hi, 1)))
+	case Invalid:
+		if bits < 64 || lim.minVal != nil || lim.maxVal != nil {
+			return json.Number(pick(g,
+				strconv.FormatInt(lo-1, 10),
+				strconv.FormatInt(hi+1, 10),
+				"1.5",
+			))
+		}
+		return json.Number(pick(g, "9223372036854775808", "-9223372036854775809", "1.5"))
+	default:
+		// Prefer small numbers, but stay within range.
+		n = max(lo, min(hi, g.rnd.Int63n(2001)-1000))
+	}
+	return json.Number(strconv.FormatInt(n, 10))
+}
+
+func (g *Generator) unsigned(bits int, lim limits, mode Mode) any {
+	lo, hi := uint64(0), uint64(1<<bits-1)
+	if bits == 64 {
+		hi = math.MaxUint64
+	}
+	if lim.minVal != nil && *lim.minVal > 0 {
+		lo = uint64(math.Ceil(*lim.minVal))
+	}
+	if lim.maxVal != nil && *lim.maxVal < float64(hi) {
+		hi = uint64(math.Max(0, math.Floor(*lim.maxVal)))
+	}
+	if lo > hi {
+		return json.Number(strconv.FormatUint(lo, 10))
+	}
+
+	var n uint64
+	switch mode {
+	case Boundary:
+		n = pick(g, lo, hi, max(lo, min(hi, 1)))
+	case Invalid:
+		over := "18446744073709551616"
+		if hi < math.MaxUint64 {
+			over = strconv.FormatUint(hi+1, 10)
+		}
+		return json.Number(pick(g, "-1", over, "1.5"))
+	default:
+		n = max(lo, min(hi, uint64(g.rnd.Int63n(1001))))
+	}
+	return json.Number(strconv.FormatUint(n, 10))
+}
+
+func (g *Generator) float(b schema.Builtin, lim limits, mode Mode) any {
+	lo, hi := -math.MaxFloat64, math.MaxFloat64
+	if b == schema.Builtin_FLOAT32 {
+		lo, hi = -math.MaxFloat32, math.MaxFloat32
+	}
+	if lim.minVal != nil {
+		lo = math.Max(lo, *lim.minVal)
+	}
+	if lim.maxVal != nil {
+		hi = math.Min(hi, *lim.maxVal)
+	}
+
+	switch mode {
+	case Boundary:
+		return pick(g, lo, hi, math.Max(lo, math.Min(hi, 0)), math.Max(lo, math.Min(hi, math.SmallestNonzeroFloat32)))
+	case Invalid:
+		return json.Number(pick(g, "1e400", "-1e400", "0x10"))
+	default:
+		return math.Max(lo, math.Min(hi, g.rnd.Float64()*2000-1000))
+	}
+}
+
+// strChars are the characters used in generated strings.
+var strChars = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 -_.~/%?&=+é日本語🙂")
+
+// boundaryStrings are strings that commonly trip up input handling.
+var boundaryStrings = []string{
+	"\x00",
+	"\u202e", // right-to-left override
+	"\ufeff", // byte order mark
+	"🙂🙂🙂",
+	"' OR '1'='1",
+	"<script>alert(1)</script>",
+	"../../etc/passwd",
+	"%s%s%s%n",
+	"null",
+}
+
+func (g *Generator) str(lim limits, mode Mode) string {
+	switch lim.is {
+	case schema.ValidationRule_EMAIL:
+		if mode != Invalid {
+			return g.word() + "@example.com"
+		}
+	case schema.ValidationRule_URL:
+		if mode != Invalid {
+			return "https://example.com/" + g.word()
+		}
+	}
+
+	var s string
+	switch {
+	case mode == Boundary && lim.minLen == nil && lim.maxLen == nil && g.chance(2):
+		s = pick(g, boundaryStrings...)
+	case mode == Invalid && (lim.minLen != nil || lim.maxLen != nil) && g.chance(2):
+		// Violate the length limits.
+		if lim.maxLen != nil {
+			s = g.runes(int(*lim.maxLen) + 1)
+		} else if *lim.minLen > 0 {
+			s = g.runes(int(*lim.minLen) - 1)
+		}
+	case mode == Invalid && lim.is != schema.ValidationRule_UNKNOWN:
+		s = g.word()
+	default:
+		s = g.runes(g.length(lim, mode))
+	}
+
+	if mode != Invalid {
+		s = lim.prefix + s + lim.suffix
+	}
+	return s
+}
+
+// length returns the length of a string or list, honoring the limits.
+func (g *Generator) length(lim limits, mode Mode) int {
+	lo, hi := 0, maxLen
+	if mode == Boundary {
+		hi = boundaryLen
+	}
+	if lim.minLen != nil {
+		lo = int(*lim.minLen)
+		hi = max(hi, lo)
+	}
+	if lim.maxLen != nil {
+		hi = min(hi, int(*lim.maxLen))
+	}
+	if hi < lo {
+		hi = lo
+	}
+	if mode == Boundary {
+		return pick(g, lo, hi)
+	}
+	return lo + g.rnd.Intn(hi-lo+1)
+}
+
+func (g *Generator) runes(n int) string {
+	r := make([]rune, n)
+	for i := range r {
+		r[i] = strChars[g.rnd.Intn(len(strChars))]
+	}
+	return string(r)
+}
+
+func (g *Generator) word() string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, 3+g.rnd.Intn(6))
+	for i := range b {
+		b[i] = letters[g.rnd.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+func (g *Generator) bytes(n int) []byte {
+	b := make([]byte, n)
+	_, _ = g.rnd.Read(b)
+	return b
+}
+
+// anyValue generates an arbitrary JSON value.
+func (g *Generator) anyValue(depth int) any {
+	k := g.rnd.Intn(6)
+	if depth >= 2 {
+		k = g.rnd.Intn(4)
+	}
+	switch k {
+	case 0:
+		return nil
+	case 1:
+		return g.chance(2)
+	case 2:
+		return g.rnd.Intn(2001) - 1000
+	case 3:
+		return g.word()
+	case 4:
+		list := make([]any, g.rnd.Intn(4))
+		for i := range list {
+			list[i] = g.anyValue(depth + 1)
+		}
+		return list
+	default:
+		return g.object(depth + 1)
+	}
+}
+
+func (g *Generator) object(depth int) map[string]any {
+	obj := make(map[string]any)
+	for i, n := 0, g.rnd.Intn(4); i < n; i++ {
+		obj[g.word()] = g.anyValue(depth + 1)
+	}
+	return obj
+}
+
+// wrongType returns a JSON value whose type differs from typ.
+func (g *Generator) wrongType(typ *schema.Type) any {
+	switch {
+	case typ.GetStruct() != nil, typ.GetMap() != nil, typ.GetNamed() != nil:
+		return pick[any](g, []any{}, "not an object", 42)
+	case typ.GetList() != nil:
+		return pick[any](g, map[string]any{}, "not a list", 42)
+	case typ.GetBuiltin() == schema.Builtin_BOOL:
+		return pick[any](g, "true", 1, []any{})
+	case typ.GetBuiltin() == schema.Builtin_STRING, typ.GetBuiltin() == schema.Builtin_USER_ID:
+		return pick[any](g, 42, true, map[string]any{})
+	default:
+		return pick[any](g, "not a number", true, []any{})
+	}
+}
+
+// malformed returns a malformed version of the JSON body.
+func (g *Generator) malformed(body string) string {
+	switch g.rnd.Intn(3) {
+	case 0:
+		return body[:g.rnd.Intn(len(body))]
+	case 1:
+		return body + "}"
+	default:
+		return string(g.bytes(1 + g.rnd.Intn(64)))
+	}
+}
+
+// chance reports true with a probability of 1/n.
+func (g *Generator) chance(n int) bool {
+	return g.rnd.Intn(n) == 0
+}
+
+// pick returns one of the given values at random.
+func pick[T any](g *Generator, vals ...T) T {
+	return vals[g.rnd.Intn(len(vals))]
+}
+
+// toString formats a generated scalar value as a string,
+// for use in headers, query strings, cookies and map keys.
+func toString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// limits are the validation rules that apply to a value.
+type limits struct {
+	minLen, maxLen *uint64
+	minVal, maxVal *float64
+	prefix, suffix string
+	is             schema.ValidationRule_Is
+}
+
+// limitsOf returns the limits described by v.
+// Rules combined with "or" are ignored, as they can't be honored in general.
+func limitsOf(v *schema.ValidationExpr) limits {
+	var lim limits
+	var walk func(v *schema.ValidationExpr)
+	walk = func(v *schema.ValidationExpr) {
+		switch e := v.GetExpr().(type) {
+		case *schema.ValidationExpr_And_:
+			for _, expr := range e.And.Exprs {
+				walk(expr)
+			}
+		case *schema.ValidationExpr_Rule:
+			switch r := e.Rule.Rule.(type) {
+			case *schema.ValidationRule_MinLen:
+				lim.minLen = &r.MinLen
+			case *schema.ValidationRule_MaxLen:
+				lim.maxLen = &r.MaxLen
+			case *schema.ValidationRule_MinVal:
+				lim.minVal = &r.MinVal
+			case *schema.ValidationRule_MaxVal:
+				lim.maxVal = &r.MaxVal
+			case *schema.ValidationRule_StartsWith:
+				lim.prefix = r.StartsWith
+			case *schema.ValidationRule_EndsWith:
+				lim.suffix = r.EndsWith
+			case *schema.ValidationRule_Is_:
+				lim.is = r.Is
+			}
+		}
+	}
+	walk(v)
+	return lim
+}
@@ -0,0 +1,138 @@
+package fuzzgen
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func builtin(b schema.Builtin) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Builtin{Builtin: b}}
+}
+
+func testMeta() (*meta.Data, *meta.RPC) {
+	params := &schema.Decl{
+		Id:   0,
+		Name: "Params",
+		Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{
+			Fields: []*schema.Field{
+				{Name: "Name", Typ: &schema.Type{
+					Typ: &schema.Type_Builtin{Builtin: schema.Builtin_STRING},
+					Validation: &schema.ValidationExpr{Expr: &schema.ValidationExpr_Rule{
+						Rule: &schema.ValidationRule{Rule: &schema.ValidationRule_MaxLen{MaxLen: 5}},
+					}},
+				}},
+				{Name: "Count", Typ: builtin(schema.Builtin_UINT8)},
+				{Name: "Tags", Typ: &schema.Type{Typ: &schema.Type_List{List: &schema.List{Elem: builtin(schema.Builtin_STRING)}}}},
+				{Name: "Trace", Typ: builtin(schema.Builtin_STRING), Wire: &schema.WireSpec{
+					Location: &schema.WireSpec_Header_{Header: &schema.WireSpec_Header{}},
+				}},
+			},
+		}}},
+	}
+	rpc := &meta.RPC{
+		Name:        "Create",
+		ServiceName: "svc",
+		HttpMethods: []string{"POST"},
+		Path: &meta.Path{Segments: []*meta.PathSegment{
+			{Type: meta.PathSegment_LITERAL, Value: "items"},
+			{Type: meta.PathSegment_PARAM, Value: "id", ValueType: meta.PathSegment_INT64},
+		}},
+		RequestSchema: &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: 0}}},
+	}
+	md := &meta.Data{
+		Decls: []*schema.Decl{params},
+		Svcs:  []*meta.Service{{Name: "svc", Rpcs: []*meta.RPC{rpc}}},
+	}
+	return md, rpc
+}
+
+func TestValid(t *testing.T) {
+	c := qt.New(t)
+	md, rpc := testMeta()
+	g := New(md, rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		req := g.Request(rpc, Valid)
+		c.Assert(req.Method, qt.Equals, "POST")
+
+		id, found := strings.CutPrefix(req.Path, "/items/")
+		c.Assert(found, qt.IsTrue, qt.Commentf("path %q", req.Path))
+		_, err := strconv.ParseInt(id, 10, 64)
+		c.Assert(err, qt.IsNil)
+
+		var body struct {
+			Name  string
+			Count uint8
+			Tags  []string
+		}
+		c.Assert(json.Unmarshal([]byte(req.Body), &body), qt.IsNil, qt.Commentf("body %s", req.Body))
+		c.Assert(len([]rune(body.Name)) <= 5, qt.IsTrue, qt.Commentf("name %q", body.Name))
+		c.Assert(req.Header.Values("Trace"), qt.HasLen, 1)
+		c.Assert(req.Header.Get("Content-Type"), qt.Equals, "application/json")
+	}
+}
+
+func TestBoundary(t *testing.T) {
+	c := qt.New(t)
+	md, rpc := testMeta()
+	g := New(md, rand.NewSource(1))
+
+	counts := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		req := g.Request(rpc, Boundary)
+		var body struct {
+			Name  string
+			Count json.Number
+		}
+		c.Assert(json.Unmarshal([]byte(req.Body), &body), qt.IsNil, qt.Commentf("body %s", req.Body))
+		c.Assert(len([]rune(body.Name)) <= 5, qt.IsTrue, qt.Commentf("name %q", body.Name))
+		counts[body.Count.String()] = true
+	}
+	c.Assert(counts["0"], qt.IsTrue)
+	c.Assert(counts["255"], qt.IsTrue)
+}
+
+func TestQueryParams(t *testing.T) {
+	c := qt.New(t)
+	md, rpc := testMeta()
+	rpc.HttpMethods = []string{"GET"}
+	g := New(md, rand.NewSource(1))
+
+	req := g.Request(rpc, Valid)
+	c.Assert(req.Body, qt.Equals, "")
+	u, err := url.Parse(req.Path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(u.Query().Has("Count"), qt.IsTrue)
+}
+
+func TestDeterministic(t *testing.T) {
+	c := qt.New(t)
+	md, rpc := testMeta()
+	for _, mode := range Modes {
+		g1 := New(md, rand.NewSource(42))
+		g2 := New(md, rand.NewSource(42))
+		for i := 0; i < 20; i++ {
+			c.Assert(g1.Request(rpc, mode), qt.DeepEquals, g2.Request(rpc, mode))
+		}
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	c := qt.New(t)
+	for _, mode := range Modes {
+		got, err := ParseMode(mode.String())
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, mode)
+	}
+	_, err := ParseMode("bogus")
+	c.Assert(err, qt.ErrorMatches, `unknown fuzz mode "bogus"`)
+}
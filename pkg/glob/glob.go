@@ -0,0 +1,71 @@
+// Package glob matches slash-separated paths against glob patterns.
+//
+// Patterns use the syntax of path.Match for each path segment,
+// with the addition that a "**" segment matches any number of segments.
+// Patterns without a "/" match the last segment of a path,
+// so "*.png" matches "assets/logo.png", and patterns ending with a "/"
+// match everything within a directory.
+package glob
+
+import (
+	"path"
+	"strings"
+)
+
+// Valid reports whether pattern is a valid glob pattern.
+func Valid(pattern string) bool {
+	if pattern == "" {
+		return false
+	}
+	for _, seg := range strings.Split(pattern, "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Match reports whether the slash-separated path name matches pattern.
+// Invalid patterns match nothing.
+func Match(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	name = strings.TrimPrefix(name, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	} else if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// MatchAny reports whether name matches any of patterns.
+func MatchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if Match(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Try matching the rest of the pattern at every remaining position.
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,42 @@
+package glob
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestMatch(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.png", "logo.png", true},
+		{"*.png", "assets/img/logo.png", true},
+		{"*.png", "logo.png.go", false},
+		{"frontend/**", "frontend/src/app.ts", true},
+		{"frontend/**", "backend/app.go", false},
+		{"frontend/", "frontend/dist/index.js", true},
+		{"/frontend/", "frontend/dist/index.js", true},
+		{"**/gen/*.go", "gen/types.go", true},
+		{"**/gen/*.go", "svc/gen/types.go", true},
+		{"**/gen/*.go", "svc/gen/sub/types.go", false},
+		{"svc/*.go", "svc/api.go", true},
+		{"svc/*.go", "other/svc/api.go", false},
+		{"svc/**/*_test.go", "svc/api_test.go", true},
+		{"svc/**/*_test.go", "svc/a/b/api_test.go", true},
+		{"[", "[", false},
+	}
+	for _, test := range tests {
+		c.Check(Match(test.pattern, test.name), qt.Equals, test.want, qt.Commentf("%s, %s", test.pattern, test.name))
+	}
+}
+
+func TestValid(t *testing.T) {
+	c := qt.New(t)
+	c.Check(Valid("frontend/**/*.ts"), qt.IsTrue)
+	c.Check(Valid("[a-z]*.sql"), qt.IsTrue)
+	c.Check(Valid("["), qt.IsFalse)
+	c.Check(Valid(""), qt.IsFalse)
+}
@@ -0,0 +1,297 @@
+// Package licenses collects the licenses of an app's dependencies
+// and checks them against a deny list.
+package licenses
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/appfile"
+)
+
+// Unknown is the license of dependencies whose license could not be determined.
+const Unknown = "UNKNOWN"
+
+// Report lists the licenses of an app's dependencies.
+type Report struct {
+	GeneratedAt  time.Time    `json:"generated_at"`
+	Dependencies []Dependency `json:"dependencies"` // sorted by name
+}
+
+// Dependency is a dependency of the app.
+type Dependency struct {
+	Name    string `json:"name"` // module path or package name
+	Version string `json:"version,omitempty"`
+
+	// License is an SPDX license expression, such as "MIT" or
+	// "(MIT OR Apache-2.0)", or Unknown.
+	License string `json:"license"`
+
+	// LicenseFile is the name of the file the license was determined from, if any.
+	LicenseFile string `json:"license_file,omitempty"`
+}
+
+// Violation is a dependency licensed under a denied license.
+type Violation struct {
+	Dependency Dependency
+	Denied     []string // the denied licenses
+}
+
+func (v Violation) String() string {
+	name := v.Dependency.Name
+	if v.Dependency.Version != "" {
+		name += "@" + v.Dependency.Version
+	}
+	return name + " is licensed under " + v.Dependency.License + " (denied: " + strings.Join(v.Denied, ", ") + ")"
+}
+
+// Collect collects the licenses of the dependencies of the app at appRoot.
+// goBin is the go binary used to list the dependencies of Go apps;
+// if empty, the one on PATH is used.
+func Collect(ctx context.Context, appRoot string, lang appfile.Lang, goBin string) (*Report, error) {
+	var (
+		deps []Dependency
+		err  error
+	)
+	if lang == appfile.LangTS {
+		deps, err = collectNpm(appRoot)
+	} else {
+		deps, err = collectGo(ctx, appRoot, cmp.Or(goBin, "go"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(deps, func(a, b Dependency) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Version, b.Version))
+	})
+	return &Report{GeneratedAt: time.Now().UTC(), Dependencies: deps}, nil
+}
+
+// collectGo collects the licenses of the modules providing
+// packages the app's packages depend on.
+func collectGo(ctx context.Context, appRoot, goBin string) ([]Dependency, error) {
+	const format = "{{with .Module}}{{if not .Main}}{{.Path}}\t{{.Version}}\t{{.Dir}}{{end}}{{end}}"
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, goBin, "list", "-e", "-deps", "-f", format, "./...")
+	cmd.Dir = appRoot
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Newf("go list failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	seen := make(map[string]bool)
+	var deps []Dependency
+	sc := bufio.NewScanner(&stdout)
+	for sc.Scan() {
+		mod, rest, ok := strings.Cut(sc.Text(), "\t")
+		if !ok || seen[mod] {
+			continue
+		}
+		seen[mod] = true
+		version, dir, _ := strings.Cut(rest, "\t")
+
+		dep := Dependency{Name: mod, Version: version, License: Unknown}
+		if dir != "" {
+			dep.License, dep.LicenseFile = detectDir(dir)
+		}
+		deps = append(deps, dep)
+	}
+	return deps, sc.Err()
+}
+
+// collectNpm collects the licenses of the production dependencies
+// listed in package-lock.json.
+func collectNpm(appRoot string) ([]Dependency, error) {
+	data, err := os.ReadFile(filepath.Join(appRoot, "package-lock.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "read package-lock.json")
+	}
+	var lock struct {
+		Packages map[string]struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			License any    `json:"license"` // a string, or an object in legacy packages
+			Dev     bool   `json:"dev"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, errors.Wrap(err, "parse package-lock.json")
+	} else if lock.Packages == nil {
+		return nil, errors.New("package-lock.json has no packages; it must be lockfile version 2 or later")
+	}
+
+	seen := make(map[string]bool)
+	var deps []Dependency
+	for key, pkg := range lock.Packages {
+		// The root package has an empty key; linked workspace packages are part of the app.
+		if key == "" || pkg.Dev || pkg.Link {
+			continue
+		}
+		name := pkg.Name
+		if name == "" {
+			_, name, _ = strings.Cut(key[strings.LastIndex(key, "node_modules/"):], "/")
+		}
+		if seen[name+"@"+pkg.Version] {
+			continue
+		}
+		seen[name+"@"+pkg.Version] = true
+
+		dep := Dependency{Name: name, Version: pkg.Version, License: Unknown}
+		switch l := pkg.License.(type) {
+		case string:
+			if l != "" {
+				dep.License = l
+			}
+		case map[string]any:
+			if t, ok := l["type"].(string); ok && t != "" {
+				dep.License = t
+			}
+		}
+		if dep.License == Unknown {
+			// Fall back to the license file of the installed package.
+			if lic, file := detectDir(filepath.Join(appRoot, filepath.FromSlash(key))); lic != Unknown {
+				dep.License, dep.LicenseFile = lic, file
+			}
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// detectDir determines the license of the package in dir from its license files.
+func detectDir(dir string) (license, file string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Unknown, ""
+	}
+	var ids []string
+	for _, e := range entries {
+		name := strings.ToUpper(e.Name())
+		if e.IsDir() || !(strings.HasPrefix(name, "LICENSE") || strings.HasPrefix(name, "LICENCE") || strings.HasPrefix(name, "COPYING")) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		if id := Detect(data); id != Unknown && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+			if file == "" {
+				file = e.Name()
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return Unknown, ""
+	} else if len(ids) == 1 {
+		return ids[0], file
+	}
+	return "(" + strings.Join(ids, " AND ") + ")", file
+}
+
+// licenseMatchers identify licenses by phrases in their text, most specific first.
+var licenseMatchers = []struct {
+	id      string
+	phrases []string // all of which must be present
+}{
+	{"AGPL-3.0", []string{"GNU AFFERO GENERAL PUBLIC LICENSE"}},
+	{"LGPL-2.1", []string{"GNU LESSER GENERAL PUBLIC LICENSE", "Version 2.1"}},
+	{"LGPL-3.0", []string{"GNU LESSER GENERAL PUBLIC LICENSE"}},
+	{"GPL-2.0", []string{"GNU GENERAL PUBLIC LICENSE", "Version 2,"}},
+	{"GPL-3.0", []string{"GNU GENERAL PUBLIC LICENSE"}},
+	{"MPL-2.0", []string{"Mozilla Public License", "2.0"}},
+	{"EPL-2.0", []string{"Eclipse Public License", "2.0"}},
+	{"Apache-2.0", []string{"Apache License", "Version 2.0"}},
+	{"BSD-3-Clause", []string{"Redistribution and use in source and binary forms", "Neither the name"}},
+	{"BSD-2-Clause", []string{"Redistribution and use in source and binary forms"}},
+	{"MIT", []string{"Permission is hereby granted, free of charge"}},
+	{"ISC", []string{"Permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{"Unlicense", []string{"This is free and unencumbered software released into the public domain"}},
+	{"CC0-1.0", []string{"CC0 1.0 Universal"}},
+}
+
+// Detect returns the SPDX identifier of the license text, or Unknown.
+func Detect(text []byte) string {
+	// Normalize whitespace so phrases match across line breaks.
+	s := strings.Join(strings.Fields(string(text)), " ")
+	for _, m := range licenseMatchers {
+		if !slices.ContainsFunc(m.phrases, func(p string) bool { return !strings.Contains(s, p) }) {
+			return m.id
+		}
+	}
+	return Unknown
+}
+
+// Check returns the dependencies licensed under a license matching deny,
+// excluding dependencies matching ignore. Both are lists of glob patterns.
+//
+// A dependency offering a choice of licenses ("MIT OR GPL-3.0") is only
+// a violation if all choices are denied.
+func Check(r *Report, deny, ignore []string) []Violation {
+	if len(deny) == 0 {
+		return nil
+	}
+	var violations []Violation
+	for _, dep := range r.Dependencies {
+		if matchAny(ignore, dep.Name) {
+			continue
+		}
+		if denied := deniedLicenses(dep.License, deny); len(denied) > 0 {
+			violations = append(violations, Violation{Dependency: dep, Denied: denied})
+		}
+	}
+	return violations
+}
+
+// deniedLicenses returns the denied licenses of the SPDX expression expr,
+// or nil if the expression can be satisfied without a denied license.
+func deniedLicenses(expr string, deny []string) []string {
+	expr = strings.NewReplacer("(", " ", ")", " ").Replace(expr)
+	var denied []string
+	for _, choice := range splitOp(expr, "OR") {
+		var choiceDenied []string
+		for _, id := range splitOp(choice, "AND") {
+			id = strings.TrimSuffix(id, "+")
+			if matchAny(deny, id) {
+				choiceDenied = append(choiceDenied, id)
+			}
+		}
+		if len(choiceDenied) == 0 {
+			return nil
+		}
+		denied = append(denied, choiceDenied...)
+	}
+	return denied
+}
+
+// splitOp splits an SPDX expression by the operator op.
+func splitOp(expr, op string) []string {
+	var parts []string
+	for _, p := range strings.Split(" "+expr+" ", " "+op+" ") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+func matchAny(patterns []string, s string) bool {
+	return slices.ContainsFunc(patterns, func(p string) bool {
+		ok, _ := path.Match(p, s)
+		return ok || p == s
+	})
+}
@@ -0,0 +1,86 @@
+package licenses
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/appfile"
+)
+
+func TestDetect(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"MIT License\n\nPermission is hereby granted, free of charge, to any person\nobtaining a copy", "MIT"},
+		{"Apache License\n   Version 2.0, January 2004", "Apache-2.0"},
+		{"Redistribution and use in source and binary forms, with or without\nmodification, are permitted. Neither the name of Google Inc. nor", "BSD-3-Clause"},
+		{"Redistribution and use in source and\nbinary forms, with or without modification", "BSD-2-Clause"},
+		{"GNU LESSER GENERAL PUBLIC LICENSE\nVersion 2.1, February 1999", "LGPL-2.1"},
+		{"GNU GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007", "GPL-3.0"},
+		{"GNU GENERAL PUBLIC LICENSE\nVersion 2, June 1991", "GPL-2.0"},
+		{"GNU AFFERO GENERAL PUBLIC LICENSE\nVersion 3", "AGPL-3.0"},
+		{"Mozilla Public License Version 2.0", "MPL-2.0"},
+		{"All rights reserved.", Unknown},
+	}
+	for _, test := range tests {
+		c.Check(Detect([]byte(test.text)), qt.Equals, test.want, qt.Commentf("text %q", test.text))
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := qt.New(t)
+	rep := &Report{Dependencies: []Dependency{
+		{Name: "a", License: "MIT"},
+		{Name: "b", License: "GPL-3.0"},
+		{Name: "c", License: "(MIT OR GPL-3.0)"},
+		{Name: "d", License: "(MIT AND GPL-2.0+)"},
+		{Name: "e", License: "AGPL-3.0 OR GPL-3.0"},
+		{Name: "f", License: Unknown},
+		{Name: "github.com/ignored/g", License: "GPL-3.0"},
+	}}
+
+	got := Check(rep, []string{"*GPL-*", Unknown}, []string{"github.com/ignored/*"})
+	var names []string
+	for _, v := range got {
+		names = append(names, v.Dependency.Name)
+	}
+	c.Assert(names, qt.DeepEquals, []string{"b", "d", "e", "f"})
+	c.Assert(got[1].Denied, qt.DeepEquals, []string{"GPL-2.0"})
+	c.Assert(got[2].Denied, qt.DeepEquals, []string{"AGPL-3.0", "GPL-3.0"})
+	c.Assert(got[1].String(), qt.Equals, "d is licensed under (MIT AND GPL-2.0+) (denied: GPL-2.0)")
+
+	c.Assert(Check(rep, nil, nil), qt.IsNil)
+}
+
+func TestCollectNpm(t *testing.T) {
+	c := qt.New(t)
+	dir := t.TempDir()
+	const lock = `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/left-pad": {"version": "1.3.0", "license": "WTFPL"},
+    "node_modules/@scope/pkg": {"version": "2.0.0", "license": {"type": "ISC"}},
+    "node_modules/typescript": {"version": "5.0.0", "license": "Apache-2.0", "dev": true},
+    "node_modules/nolicense": {"version": "0.1.0"},
+    "node_modules/a/node_modules/left-pad": {"version": "1.3.0", "license": "WTFPL"}
+  }
+}`
+	c.Assert(os.WriteFile(filepath.Join(dir, "package-lock.json"), []byte(lock), 0o644), qt.IsNil)
+	c.Assert(os.MkdirAll(filepath.Join(dir, "node_modules", "nolicense"), 0o755), qt.IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dir, "node_modules", "nolicense", "LICENSE.md"),
+		[]byte("Permission is hereby granted, free of charge, to any person"), 0o644), qt.IsNil)
+
+	rep, err := Collect(t.Context(), dir, appfile.LangTS, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(rep.Dependencies, qt.DeepEquals, []Dependency{
+		{Name: "@scope/pkg", Version: "2.0.0", License: "ISC"},
+		{Name: "left-pad", Version: "1.3.0", License: "WTFPL"},
+		{Name: "nolicense", Version: "0.1.0", License: "MIT", LicenseFile: "LICENSE.md"},
+	})
+}
@@ -21,6 +21,9 @@ type Route struct {
 	Path    string      // "/path/:param/*wildcard"
 	Dest    ServiceName // where to route the request
 
+	// Endpoint is the name of the endpoint, as "service.endpoint".
+	Endpoint string
+
 	// RequiresAuth specifies whether the route requires authentication.
 	RequiresAuth bool
 }
@@ -139,7 +142,7 @@ func (g *Gateway) handleRequest(r *httputil.ProxyRequest) {
 		r.Out = out.WithContext(ctx)
 	}
 
-	route, ok := g.lookupRoute(r.In)
+	route, ok := g.LookupRoute(r.In)
 	if !ok {
 		setErrResp(errRouteNotFound)
 		return
@@ -157,9 +160,9 @@ func (g *Gateway) handleRequest(r *httputil.ProxyRequest) {
 	r.SetXForwarded()
 }
 
-// lookupRoute looks up the route the request is for.
+// LookupRoute looks up the route the request is for.
 // If no route is found, it reports (nil, false).
-func (g *Gateway) lookupRoute(req *http.Request) (route *Route, ok bool) {
+func (g *Gateway) LookupRoute(req *http.Request) (route *Route, ok bool) {
 	handle, _, tsr := g.routeLookup.Lookup(req.Method, req.URL.Path)
 
 	// Handle trailing slash redirects.
@@ -43,6 +43,7 @@ func Describe(md *meta.Data, serviceDiscovery map[noopgateway.ServiceName]string
 			desc.Routes = append(desc.Routes, &noopgateway.Route{
 				Methods:      methods,
 				Dest:         svcName,
+				Endpoint:     svc.Name + "." + ep.Name,
 				RequiresAuth: ep.AccessType == meta.RPC_AUTH,
 				Path:         pathToString(ep.Path),
 			})
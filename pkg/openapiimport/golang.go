@@ -0,0 +1,523 @@
+package openapiimport
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+
+	"encr.dev/pkg/idents"
+)
+
+const (
+	errsPkg = "encore.dev/beta/errs"
+	uuidPkg = "encore.dev/types/uuid"
+)
+
+func generateGo(svc *service) ([]File, error) {
+	apiFile := jen.NewFile(svc.name)
+	apiFile.HeaderComment("Code generated by encore gen service from an OpenAPI specification.")
+	apiFile.HeaderComment("This file is meant to be edited: implement the endpoints and remove the TODOs.")
+	apiFile.PackageComment(fmt.Sprintf("Package %s implements the %s.", svc.name, apiDescription(svc)))
+	apiFile.ImportName(errsPkg, "errs")
+	for i, ep := range svc.endpoints {
+		if i > 0 {
+			apiFile.Line()
+		}
+		goEndpoint(apiFile, ep)
+	}
+
+	typesFile := jen.NewFile(svc.name)
+	typesFile.HeaderComment("Code generated by encore gen service from an OpenAPI specification.")
+	typesFile.ImportName(uuidPkg, "uuid")
+	for i, nt := range svc.types {
+		if i > 0 {
+			typesFile.Line()
+		}
+		goNamedType(typesFile, nt)
+	}
+
+	files := []File{{Name: svc.name + ".go"}, {Name: "types.go"}}
+	for i, f := range []*jen.File{apiFile, typesFile} {
+		var buf bytes.Buffer
+		if err := f.Render(&buf); err != nil {
+			return nil, fmt.Errorf("render %s: %v", files[i].Name, err)
+		}
+		files[i].Content = buf.Bytes()
+	}
+	return files, nil
+}
+
+func goEndpoint(file *jen.File, ep *endpoint) {
+	goDoc(file, ep.doc)
+	if len(ep.todos) > 0 {
+		if ep.doc != "" {
+			file.Comment("")
+		}
+		for _, todo := range ep.todos {
+			file.Comment("TODO: " + todo + ".")
+		}
+	}
+
+	access := "public"
+	if ep.auth {
+		access = "auth"
+	}
+	file.Comment(fmt.Sprintf("//encore:api %s method=%s path=%s", access, ep.method, ep.path))
+
+	params := []jen.Code{jen.Id("ctx").Qual("context", "Context")}
+	for _, p := range ep.pathParams {
+		params = append(params, jen.Id(p.name).Add(goType(p.typ)))
+	}
+	if ep.request != nil {
+		params = append(params, jen.Id("p").Op("*").Id(ep.request.name))
+	}
+
+	unimplemented := jen.Op("&").Qual(errsPkg, "Error").Values(jen.Dict{
+		jen.Id("Code"):    jen.Qual(errsPkg, "Unimplemented"),
+		jen.Id("Message"): jen.Lit("not implemented"),
+	})
+	if ep.response != nil {
+		file.Func().Id(ep.name).Params(params...).Params(jen.Op("*").Id(ep.response.name), jen.Error()).Block(
+			jen.Comment("TODO: implement"),
+			jen.Return(jen.Nil(), unimplemented),
+		)
+	} else {
+		file.Func().Id(ep.name).Params(params...).Error().Block(
+			jen.Comment("TODO: implement"),
+			jen.Return(unimplemented),
+		)
+	}
+}
+
+func goNamedType(file *jen.File, nt *namedType) {
+	goDoc(file, nt.doc)
+	file.Type().Id(nt.name).Add(goType(nt.typ))
+
+	// Add constants for the allowed values of enums.
+	if nt.typ.kind == kindBuiltin && nt.typ.builtin == builtinString && len(nt.typ.enum) > 0 {
+		file.Line()
+		var defs []jen.Code
+		for _, v := range nt.typ.enum {
+			defs = append(defs, jen.Id(nt.name+typeName(v)).Id(nt.name).Op("=").Lit(v))
+		}
+		file.Const().Defs(defs...)
+	}
+}
+
+func goType(t *typ) jen.Code {
+	switch t.kind {
+	case kindNamed:
+		return jen.Id(t.name)
+	case kindList:
+		return jen.Index().Add(goType(t.elem))
+	case kindMap:
+		return jen.Map(jen.String()).Add(goType(t.elem))
+	case kindStruct:
+		return goStruct(t.fields)
+	}
+
+	switch t.builtin {
+	case builtinString:
+		return jen.String()
+	case builtinBool:
+		return jen.Bool()
+	case builtinInt:
+		return jen.Int()
+	case builtinInt32:
+		return jen.Int32()
+	case builtinInt64:
+		return jen.Int64()
+	case builtinFloat32:
+		return jen.Float32()
+	case builtinFloat64:
+		return jen.Float64()
+	case builtinTime:
+		return jen.Qual("time", "Time")
+	case builtinUUID:
+		return jen.Qual(uuidPkg, "UUID")
+	case builtinBytes:
+		return jen.Index().Byte()
+	default:
+		return jen.Qual("encoding/json", "RawMessage")
+	}
+}
+
+func goStruct(fields []*field) jen.Code {
+	var defs []jen.Code
+	for i, f := range fields {
+		if f.doc != "" {
+			if i > 0 {
+				defs = append(defs, jen.Line())
+			}
+			for _, line := range strings.Split(f.doc, "\n") {
+				defs = append(defs, jen.Comment(line))
+			}
+		}
+
+		typ := goType(f.typ)
+		if f.nullable && f.typ.kind != kindList && f.typ.kind != kindMap {
+			typ = jen.Op("*").Add(typ)
+		}
+
+		tags := make(map[string]string)
+		switch f.loc {
+		case locQuery:
+			tags["query"] = f.wireName
+		case locHeader:
+			tags["header"] = f.wireName
+		default:
+			tags["json"] = f.wireName
+			if f.optional {
+				tags["json"] += ",omitempty"
+			}
+		}
+		if f.optional {
+			tags["encore"] = "optional"
+		}
+		if f.attr != "" {
+			tags["pubsub-attr"] = f.attr
+		}
+		defs = append(defs, jen.Id(goFieldName(f)).Add(typ).Tag(tags))
+	}
+	return jen.Struct(defs...)
+}
+
+// goFieldName returns the name of the Go struct field for f.
+func goFieldName(f *field) string {
+	return initialisms.ReplaceAllStringFunc(idents.Convert(f.name, idents.PascalCase), strings.ToUpper)
+}
+
+func goDoc(file *jen.File, doc string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		file.Comment(line)
+	}
+}
+
+// apiDescription describes the API the service implements, for use in doc comments.
+func apiDescription(svc *service) string {
+	if svc.title != "" {
+		return svc.title + " API"
+	}
+	return "API"
+}
+
+const pubsubPkg = "encore.dev/pubsub"
+
+func generateIngestGo(in *ingest) ([]File, error) {
+	file := jen.NewFile(in.opts.Service)
+	file.HeaderComment("Code generated by encore gen ingest from a JSON Schema.")
+	if slices.ContainsFunc(in.checks, func(ch *fieldCheck) bool { return ch.todo != "" }) {
+		file.HeaderComment("This file is meant to be edited: complete the TODOs.")
+	}
+	file.ImportName(errsPkg, "errs")
+	file.ImportName(pubsubPkg, "pubsub")
+	file.ImportName(uuidPkg, "uuid")
+
+	goNamedType(file, in.event)
+	for _, nt := range in.types {
+		file.Line()
+		goNamedType(file, nt)
+	}
+
+	file.Line()
+	goValidate(file, in)
+
+	file.Line()
+	file.Comment(fmt.Sprintf("%s is the topic the %s events received by %s are published to.", in.topicVar, in.event.name, in.endpoint))
+	file.Var().Id(in.topicVar).Op("=").Qual(pubsubPkg, "NewTopic").Types(jen.Op("*").Id(in.event.name)).Call(
+		jen.Lit(in.opts.Topic),
+		jen.Qual(pubsubPkg, "TopicConfig").Values(jen.Dict{
+			jen.Id("DeliveryGuarantee"): jen.Qual(pubsubPkg, "AtLeastOnce"),
+		}),
+	)
+
+	file.Line()
+	if in.opts.Auth == IngestAuthHandler {
+		goIngestEndpoint(file, in)
+	} else {
+		goRawIngestEndpoint(file, in)
+	}
+
+	name := "ingest_" + idents.Convert(in.event.name, idents.SnakeCase) + ".go"
+	var buf bytes.Buffer
+	if err := file.Render(&buf); err != nil {
+		return nil, fmt.Errorf("render %s: %v", name, err)
+	}
+	return []File{{Name: name, Content: buf.Bytes()}}, nil
+}
+
+// goValidate generates the Validate method of the event, which Encore calls
+// to validate requests before they're passed to the endpoint.
+func goValidate(file *jen.File, in *ingest) {
+	recv := strings.ToLower(in.event.name[:1])
+	var body []jen.Code
+	for _, ch := range in.checks {
+		f := ch.field
+		ref := jen.Id(recv).Dot(goFieldName(f))
+		isPtr := f.nullable && f.typ.kind != kindList && f.typ.kind != kindMap
+		val := ref.Clone()
+		if isPtr {
+			val = jen.Op("*").Add(ref.Clone())
+		}
+
+		if ch.todo != "" {
+			body = append(body, jen.Comment("TODO: "+ch.todo+"."))
+		}
+		if ch.required {
+			missing := goZeroCheck(ch, ref.Clone())
+			if isPtr {
+				missing = ref.Clone().Op("==").Nil()
+			}
+			if missing != nil {
+				body = append(body, jen.If(missing).Block(goValidationErr(fmt.Sprintf("missing required field %q", f.wireName))))
+			}
+		}
+
+		var checks []jen.Code
+		if enum := ch.enum(); len(enum) > 0 {
+			var values []jen.Code
+			for _, v := range enum {
+				values = append(values, jen.Lit(v))
+			}
+			checks = append(checks, jen.Switch(val.Clone()).Block(
+				jen.Case(values...),
+				jen.Default().Block(goValidationErr(ch.enumMessage())),
+			))
+		}
+
+		str := val.Clone()
+		if f.typ.kind == kindNamed {
+			str = jen.String().Call(val.Clone())
+		}
+		length, numeric, items := ch.hasBounds()
+		isString := ch.kind.kind == kindBuiltin && ch.kind.builtin == builtinString
+		if isString && length {
+			count := jen.Qual("unicode/utf8", "RuneCountInString").Call(str.Clone())
+			if n := ch.schema.MinLength; n > 0 {
+				checks = append(checks, jen.If(count.Clone().Op("<").Lit(int(n))).Block(
+					goValidationErr(fmt.Sprintf("field %q must be at least %d characters", f.wireName, n))))
+			}
+			if n := ch.schema.MaxLength; n != nil {
+				checks = append(checks, jen.If(count.Clone().Op(">").Lit(int(*n))).Block(
+					goValidationErr(fmt.Sprintf("field %q must be at most %d characters", f.wireName, *n))))
+			}
+		}
+		if isString && ch.pattern != "" {
+			pattern := idents.Convert(in.event.name, idents.CamelCase) + goFieldName(f) + "Pattern"
+			file.Var().Id(pattern).Op("=").Qual("regexp", "MustCompile").Call(goRawString(ch.pattern))
+			file.Line()
+			checks = append(checks, jen.If(jen.Op("!").Id(pattern).Dot("MatchString").Call(str.Clone())).Block(
+				goValidationErr(fmt.Sprintf("field %q must match the pattern %s", f.wireName, ch.pattern))))
+		}
+		if numeric && ch.kind.kind == kindBuiltin {
+			minMsg, maxMsg := ch.boundMessages()
+			if s := ch.schema; s.Min != nil {
+				v, bound := goNumericBound(ch, val, *s.Min)
+				op := "<"
+				if s.ExclusiveMin {
+					op = "<="
+				}
+				checks = append(checks, jen.If(v.Op(op).Add(bound)).Block(goValidationErr(minMsg)))
+			}
+			if s := ch.schema; s.Max != nil {
+				v, bound := goNumericBound(ch, val, *s.Max)
+				op := ">"
+				if s.ExclusiveMax {
+					op = ">="
+				}
+				checks = append(checks, jen.If(v.Op(op).Add(bound)).Block(goValidationErr(maxMsg)))
+			}
+		}
+		if items && ch.kind.kind == kindList {
+			if n := ch.schema.MinItems; n > 0 {
+				checks = append(checks, jen.If(jen.Len(val.Clone()).Op("<").Lit(int(n))).Block(
+					goValidationErr(fmt.Sprintf("field %q must have at least %d items", f.wireName, n))))
+			}
+			if n := ch.schema.MaxItems; n != nil {
+				checks = append(checks, jen.If(jen.Len(val.Clone()).Op(">").Lit(int(*n))).Block(
+					goValidationErr(fmt.Sprintf("field %q must have at most %d items", f.wireName, *n))))
+			}
+		}
+		if len(checks) == 0 {
+			continue
+		}
+
+		// Optional fields are only checked when present.
+		switch {
+		case ch.required:
+			body = append(body, checks...)
+		case isPtr || ch.kind.kind == kindList || ch.kind.kind == kindMap:
+			body = append(body, jen.If(ref.Clone().Op("!=").Nil()).Block(checks...))
+		case isString:
+			body = append(body, jen.If(ref.Clone().Op("!=").Lit("")).Block(checks...))
+		default:
+			body = append(body, checks...)
+		}
+	}
+	body = append(body, jen.Return(jen.Nil()))
+
+	file.Comment(fmt.Sprintf("Validate reports whether the %s matches its schema.", in.event.name))
+	file.Func().Params(jen.Id(recv).Op("*").Id(in.event.name)).Id("Validate").Params().Error().Block(body...)
+}
+
+// goZeroCheck returns the condition for the field being absent from the event,
+// or nil if an absent field can't be told apart from its zero value.
+func goZeroCheck(ch *fieldCheck, ref *jen.Statement) jen.Code {
+	switch ch.kind.kind {
+	case kindList, kindMap:
+		return ref.Op("==").Nil()
+	case kindBuiltin:
+		switch ch.kind.builtin {
+		case builtinString:
+			return ref.Op("==").Lit("")
+		case builtinBytes, builtinAny:
+			return ref.Op("==").Nil()
+		case builtinTime:
+			return ref.Dot("IsZero").Call()
+		case builtinUUID:
+			return ref.Op("==").Qual(uuidPkg, "Nil")
+		}
+	}
+	return nil
+}
+
+// goNumericBound returns the value and bound to compare for a numeric bound check.
+func goNumericBound(ch *fieldCheck, val *jen.Statement, bound float64) (*jen.Statement, jen.Code) {
+	if ch.isInteger() && bound == float64(int64(bound)) {
+		return val.Clone(), jen.Lit(int(bound))
+	}
+	if ch.isInteger() {
+		return jen.Float64().Call(val.Clone()), jen.Lit(bound)
+	}
+	return val.Clone(), jen.Lit(bound)
+}
+
+func goValidationErr(msg string) jen.Code {
+	return jen.Return(jen.Qual("errors", "New").Call(jen.Lit(msg)))
+}
+
+// goRawString returns s as a raw string literal, if possible.
+func goRawString(s string) jen.Code {
+	if strings.Contains(s, "`") {
+		return jen.Lit(s)
+	}
+	return jen.Op("`" + s + "`")
+}
+
+// goIngestEndpoint generates an endpoint that receives events authenticated by the app's auth handler.
+func goIngestEndpoint(file *jen.File, in *ingest) {
+	resp := in.endpoint + "Response"
+	file.Comment(fmt.Sprintf("%s receives %s events from external systems and publishes them to %s.", in.endpoint, in.event.name, in.topicVar))
+	file.Comment("Requests are authenticated by the app's auth handler, and the events")
+	file.Comment("are validated against their schema before the endpoint is called.")
+	file.Comment("")
+	file.Comment(fmt.Sprintf("//encore:api auth method=POST path=%s", in.opts.Path))
+	file.Func().Id(in.endpoint).Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("event").Op("*").Id(in.event.name),
+	).Params(jen.Op("*").Id(resp), jen.Error()).Block(
+		jen.List(jen.Id("id"), jen.Err()).Op(":=").Id(in.topicVar).Dot("Publish").Call(jen.Id("ctx"), jen.Id("event")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())),
+		jen.Return(jen.Op("&").Id(resp).Values(jen.Id("ID").Op(":").Id("id")), jen.Nil()),
+	)
+
+	file.Line()
+	file.Type().Id(resp).Struct(
+		jen.Comment("ID is the ID of the published message."),
+		jen.Id("ID").String().Tag(map[string]string{"json": "id"}),
+	)
+}
+
+// goRawIngestEndpoint generates a raw endpoint that receives events authenticated by a shared secret.
+func goRawIngestEndpoint(file *jen.File, in *ingest) {
+	secret := jen.Id("secrets").Dot(in.secret)
+
+	file.Comment("If the service already declares its secrets, move this secret there.")
+	file.Var().Id("secrets").Struct(
+		jen.Comment(fmt.Sprintf("%s is the secret shared with the systems sending %s events.", in.secret, in.event.name)),
+		jen.Id(in.secret).String(),
+	)
+	file.Line()
+
+	file.Comment(fmt.Sprintf("%s receives %s events from external systems and publishes them to %s.", in.endpoint, in.event.name, in.topicVar))
+	file.Comment("")
+	var auth []jen.Code
+	switch in.opts.Auth {
+	case IngestHMAC:
+		file.Comment(fmt.Sprintf("Requests must be signed with the %s secret: the X-Signature header must hold", in.secret))
+		file.Comment(`the hex-encoded HMAC-SHA256 of the request body, optionally prefixed by "sha256=".`)
+		auth = []jen.Code{
+			jen.Id("mac").Op(":=").Qual("crypto/hmac", "New").Call(jen.Qual("crypto/sha256", "New"), jen.Index().Byte().Call(secret.Clone())),
+			jen.Id("mac").Dot("Write").Call(jen.Id("body")),
+			jen.List(jen.Id("signature"), jen.Err()).Op(":=").Qual("encoding/hex", "DecodeString").Call(
+				jen.Qual("strings", "TrimPrefix").Call(jen.Id("req").Dot("Header").Dot("Get").Call(jen.Lit("X-Signature")), jen.Lit("sha256="))),
+			jen.If(jen.Err().Op("!=").Nil().Op("||").Add(secret.Clone()).Op("==").Lit("").Op("||").Op("!").Qual("crypto/hmac", "Equal").Call(
+				jen.Id("signature"), jen.Id("mac").Dot("Sum").Call(jen.Nil()))).Block(
+				goHTTPError("Unauthenticated", "invalid signature"),
+				jen.Return(),
+			),
+		}
+	case IngestToken:
+		file.Comment(fmt.Sprintf("Requests must have an Authorization header holding the %s secret as a bearer token.", in.secret))
+		auth = []jen.Code{
+			jen.List(jen.Id("token"), jen.Id("ok")).Op(":=").Qual("strings", "CutPrefix").Call(
+				jen.Id("req").Dot("Header").Dot("Get").Call(jen.Lit("Authorization")), jen.Lit("Bearer ")),
+			jen.If(jen.Op("!").Id("ok").Op("||").Add(secret.Clone()).Op("==").Lit("").Op("||").Qual("crypto/subtle", "ConstantTimeCompare").Call(
+				jen.Index().Byte().Call(jen.Id("token")), jen.Index().Byte().Call(secret.Clone())).Op("!=").Lit(1)).Block(
+				goHTTPError("Unauthenticated", "invalid token"),
+				jen.Return(),
+			),
+		}
+	}
+	file.Comment("")
+	file.Comment(fmt.Sprintf("//encore:api public raw method=POST path=%s", in.opts.Path))
+
+	body := []jen.Code{
+		jen.List(jen.Id("body"), jen.Err()).Op(":=").Qual("io", "ReadAll").Call(
+			jen.Qual("net/http", "MaxBytesReader").Call(jen.Id("w"), jen.Id("req").Dot("Body"), jen.Lit(1).Op("<<").Lit(20))),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			goHTTPError("InvalidArgument", "could not read request body"),
+			jen.Return(),
+		),
+		jen.Line(),
+	}
+	body = append(body, auth...)
+	body = append(body,
+		jen.Line(),
+		jen.Var().Id("event").Id(in.event.name),
+		jen.If(jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("body"), jen.Op("&").Id("event")), jen.Err().Op("!=").Nil()).Block(
+			goHTTPError("InvalidArgument", "invalid event"),
+			jen.Return(),
+		),
+		jen.If(jen.Err().Op(":=").Id("event").Dot("Validate").Call(), jen.Err().Op("!=").Nil()).Block(
+			jen.Qual(errsPkg, "HTTPError").Call(jen.Id("w"), jen.Qual(errsPkg, "B").Call().Dot("Code").Call(jen.Qual(errsPkg, "InvalidArgument")).Dot("Msg").Call(jen.Err().Dot("Error").Call()).Dot("Err").Call()),
+			jen.Return(),
+		),
+		jen.Line(),
+		jen.List(jen.Id("id"), jen.Err()).Op(":=").Id(in.topicVar).Dot("Publish").Call(jen.Id("req").Dot("Context").Call(), jen.Op("&").Id("event")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Qual(errsPkg, "HTTPError").Call(jen.Id("w"), jen.Err()),
+			jen.Return(),
+		),
+		jen.Id("w").Dot("Header").Call().Dot("Set").Call(jen.Lit("Content-Type"), jen.Lit("application/json")),
+		jen.Id("w").Dot("WriteHeader").Call(jen.Qual("net/http", "StatusAccepted")),
+		jen.Qual("encoding/json", "NewEncoder").Call(jen.Id("w")).Dot("Encode").Call(
+			jen.Map(jen.String()).String().Values(jen.Dict{jen.Lit("id"): jen.Id("id")})),
+	)
+
+	file.Func().Id(in.endpoint).Params(
+		jen.Id("w").Qual("net/http", "ResponseWriter"),
+		jen.Id("req").Op("*").Qual("net/http", "Request"),
+	).Block(body...)
+}
+
+func goHTTPError(code, msg string) jen.Code {
+	return jen.Qual(errsPkg, "HTTPError").Call(jen.Id("w"),
+		jen.Qual(errsPkg, "B").Call().Dot("Code").Call(jen.Qual(errsPkg, code)).Dot("Msg").Call(jen.Lit(msg)).Dot("Err").Call())
+}
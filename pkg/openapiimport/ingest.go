@@ -0,0 +1,560 @@
+package openapiimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+	"sigs.k8s.io/yaml"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/idents"
+)
+
+// Schema is a schema describing the events received by an ingestion endpoint.
+type Schema struct {
+	doc  *openapi3.T // the OpenAPI specification the schema is part of, if any
+	ref  *openapi3.SchemaRef
+	name string // the name of the schema, if known
+}
+
+// LoadSchema loads the JSON Schema at the given file path.
+// Both JSON and YAML schemas are supported.
+//
+// A schema in an OpenAPI specification is loaded by adding a fragment
+// referring to it, like "spec.yaml#/components/schemas/Event".
+// The specification can then also be an http(s) URL.
+func LoadSchema(ctx context.Context, location string) (*Schema, error) {
+	if location, fragment, ok := strings.Cut(location, "#"); ok {
+		name, ok := strings.CutPrefix(fragment, "/components/schemas/")
+		if !ok {
+			return nil, errors.Newf("unsupported schema reference #%s: must refer to a component schema, like #/components/schemas/Event", fragment)
+		}
+		doc, err := Load(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+		var ref *openapi3.SchemaRef
+		if doc.Components != nil {
+			ref = doc.Components.Schemas[name]
+		}
+		if ref == nil || ref.Value == nil {
+			return nil, errors.Newf("schema %q not found in %s", name, location)
+		}
+		return &Schema{doc: doc, ref: ref, name: name}, nil
+	}
+
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, errors.Wrap(err, "read schema")
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse schema")
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, errors.Wrap(err, "parse schema")
+	}
+
+	// Convert the JSON Schema to the OpenAPI dialect.
+	normalized, err := normalizeSchema(root, root, make(map[string]bool))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid schema")
+	}
+	data, err = json.Marshal(normalized)
+	if err != nil {
+		return nil, err
+	}
+	s := openapi3.NewSchema()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrap(err, "invalid schema")
+	}
+	return &Schema{doc: &openapi3.T{}, ref: &openapi3.SchemaRef{Value: s}, name: s.Title}, nil
+}
+
+// normalizeSchema converts a JSON Schema to the dialect of OpenAPI 3.0 schemas:
+// local references are inlined, null types become nullable schemas,
+// numeric exclusive bounds become boolean ones and constants become enums.
+func normalizeSchema(node, root any, resolving map[string]bool) (any, error) {
+	s, ok := node.(map[string]any)
+	if !ok {
+		return node, nil
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		if resolving[ref] {
+			return nil, errors.Newf("recursive reference %s is not supported", ref)
+		}
+		target, err := resolvePointer(root, ref)
+		if err != nil {
+			return nil, err
+		}
+		resolving[ref] = true
+		defer delete(resolving, ref)
+		return normalizeSchema(target, root, resolving)
+	}
+
+	out := make(map[string]any, len(s))
+	for k, v := range s {
+		switch k {
+		case "$schema", "$id", "$defs", "definitions":
+			// Not part of OpenAPI schemas, and definitions are inlined where they're used.
+			continue
+
+		case "type":
+			types, ok := v.([]any)
+			if !ok {
+				out[k] = v
+				continue
+			}
+			types = slices.DeleteFunc(slices.Clone(types), func(t any) bool { return t == "null" })
+			if len(types) < len(v.([]any)) {
+				out["nullable"] = true
+			}
+			if len(types) == 1 {
+				out[k] = types[0]
+			}
+
+		case "exclusiveMinimum", "exclusiveMaximum":
+			if n, ok := v.(float64); ok {
+				bound := "minimum"
+				if k == "exclusiveMaximum" {
+					bound = "maximum"
+				}
+				out[bound] = n
+				out[k] = true
+			} else {
+				out[k] = v
+			}
+
+		case "const":
+			out["enum"] = []any{v}
+
+		case "properties", "patternProperties":
+			props, _ := v.(map[string]any)
+			normalized := make(map[string]any, len(props))
+			for name, prop := range props {
+				p, err := normalizeSchema(prop, root, resolving)
+				if err != nil {
+					return nil, err
+				}
+				normalized[name] = p
+			}
+			out[k] = normalized
+
+		case "items", "additionalProperties", "not":
+			n, err := normalizeSchema(v, root, resolving)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = n
+
+		case "allOf", "anyOf", "oneOf":
+			list, _ := v.([]any)
+			normalized := make([]any, len(list))
+			for i, elem := range list {
+				n, err := normalizeSchema(elem, root, resolving)
+				if err != nil {
+					return nil, err
+				}
+				normalized[i] = n
+			}
+			out[k] = normalized
+
+		default:
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+// resolvePointer resolves a JSON pointer reference like "#/$defs/Location" within root.
+func resolvePointer(root any, ref string) (any, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, errors.Newf("unsupported reference %s: only references within the schema are supported", ref)
+	}
+	node := root
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, errors.Newf("reference %s not found", ref)
+		}
+		if node, ok = m[token]; !ok {
+			return nil, errors.Newf("reference %s not found", ref)
+		}
+	}
+	return node, nil
+}
+
+// IngestAuth is how an ingestion endpoint authenticates the senders of events.
+type IngestAuth string
+
+const (
+	// IngestHMAC authenticates requests by the HMAC-SHA256 signature of their body,
+	// computed with a shared secret and sent in the X-Signature header, as is common for webhooks.
+	IngestHMAC IngestAuth = "hmac"
+
+	// IngestToken authenticates requests by a shared secret sent as a bearer token.
+	IngestToken IngestAuth = "token"
+
+	// IngestAuthHandler authenticates requests with the app's auth handler.
+	IngestAuthHandler IngestAuth = "auth"
+)
+
+// IngestOptions are the options for generating an ingestion endpoint.
+type IngestOptions struct {
+	// Service is the name of the service to generate the endpoint in.
+	Service string
+
+	// Lang is the language to generate the endpoint in.
+	Lang appfile.Lang
+
+	// Event is the name of the event type.
+	// It defaults to the name or title of the schema.
+	Event string
+
+	// Topic is the name of the Pub/Sub topic to publish the events to.
+	// It defaults to the event name in kebab-case.
+	Topic string
+
+	// Path is the path of the endpoint. It defaults to "/ingest/<topic>".
+	Path string
+
+	// Auth is how the endpoint authenticates requests. It defaults to IngestHMAC.
+	Auth IngestAuth
+
+	// Attrs maps top-level properties of the event to the names of the message
+	// attributes they are published as. An empty name uses the property name.
+	Attrs map[string]string
+}
+
+// ingest is the language-independent description of the ingestion endpoint to generate.
+type ingest struct {
+	opts     IngestOptions // with the defaults applied
+	event    *namedType
+	types    []*namedType // the types the event uses
+	checks   []*fieldCheck
+	topicVar string // the name of the topic variable
+	endpoint string // the name of the endpoint, in PascalCase
+	secret   string // the name of the secret authenticating requests, if any
+}
+
+// fieldCheck describes the validation of a top-level field of the event against its schema.
+type fieldCheck struct {
+	field    *field
+	kind     typ // the type of the field, with named types resolved
+	schema   *openapi3.Schema
+	required bool
+	pattern  string // the pattern the field must match, if any
+	todo     string // a check that must be done by hand, if any
+}
+
+// GenerateIngest generates the source files of an endpoint that receives events
+// described by schema from external systems, validates them against the schema
+// and publishes them to a Pub/Sub topic.
+func GenerateIngest(schema *Schema, opts IngestOptions) ([]File, error) {
+	if !isIdent(opts.Service) || strings.ToLower(opts.Service) != opts.Service {
+		return nil, errors.Newf("invalid service name %q: must be a lowercase identifier", opts.Service)
+	}
+
+	in, err := newConverter(schema.doc, opts.Lang).ingest(schema, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch opts.Lang {
+	case appfile.LangGo:
+		return generateIngestGo(in)
+	case appfile.LangTS:
+		return generateIngestTS(in), nil
+	default:
+		return nil, errors.Newf("unsupported language %q", opts.Lang)
+	}
+}
+
+func (c *converter) ingest(schema *Schema, opts IngestOptions) (*ingest, error) {
+	if opts.Event == "" {
+		opts.Event = schema.name
+	}
+	opts.Event = c.typeName(opts.Event)
+	if opts.Event == "" {
+		return nil, errors.New("the schema has no title; specify the name of the event")
+	}
+	if opts.Topic == "" {
+		opts.Topic = idents.Convert(opts.Event, idents.KebabCase)
+	}
+	if !topicName.MatchString(opts.Topic) {
+		return nil, errors.Newf("invalid topic name %q: must be in kebab-case", opts.Topic)
+	}
+	if opts.Path == "" {
+		opts.Path = "/ingest/" + opts.Topic
+	}
+	if !strings.HasPrefix(opts.Path, "/") || strings.ContainsAny(opts.Path, ":*{} ") {
+		return nil, errors.Newf("invalid path %q: must start with a slash and have no parameters", opts.Path)
+	}
+	switch opts.Auth {
+	case "":
+		opts.Auth = IngestHMAC
+	case IngestHMAC, IngestToken, IngestAuthHandler:
+	default:
+		return nil, errors.Newf("unknown auth mode %q: must be one of hmac, token or auth", opts.Auth)
+	}
+
+	in := &ingest{
+		opts:     opts,
+		topicVar: c.typeName(opts.Topic) + "Topic",
+		endpoint: "Ingest" + opts.Event,
+	}
+	switch opts.Auth {
+	case IngestHMAC:
+		in.secret = opts.Event + "IngestSecret"
+	case IngestToken:
+		in.secret = opts.Event + "IngestToken"
+	}
+
+	// Add the event type first, so it gets its preferred name.
+	event := c.addType(opts.Event, "", nil)
+	if event.name != opts.Event {
+		return nil, errors.Newf("the event name %q is already taken", opts.Event)
+	}
+	if schema.ref.Value != nil {
+		c.names[schema.ref.Value] = event.name
+	}
+	fields, ok := c.objectFields(event.name, &openapi3.SchemaRef{Value: schema.ref.Value})
+	if !ok || len(fields) == 0 {
+		return nil, errors.New("the event schema must be an object with properties")
+	}
+	in.event = c.types[event.name]
+	in.event.doc = schema.ref.Value.Description
+	if title := schema.ref.Value.Title; title != "" && c.typeName(title) != event.name {
+		in.event.doc = joinDoc(title, in.event.doc)
+	}
+	in.event.typ = &typ{kind: kindStruct, fields: fields}
+
+	if err := c.ingestAttrs(in, fields); err != nil {
+		return nil, err
+	}
+	props, required := make(map[string]*openapi3.Schema), make(map[string]bool)
+	objectProperties(schema.ref, props, required)
+	for _, f := range fields {
+		in.checks = append(in.checks, c.fieldCheck(f, props[f.wireName], required[f.wireName]))
+	}
+
+	if c.lang == appfile.LangTS {
+		// JSON has no dates, and the events are published as they're received.
+		for _, nt := range c.types {
+			stringTimes(nt.typ)
+		}
+	}
+
+	for _, name := range sortedKeys(c.types) {
+		if name != event.name {
+			in.types = append(in.types, c.types[name])
+		}
+	}
+	return in, nil
+}
+
+// topicName matches valid topic names.
+var topicName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// ingestAttrs marks the event fields that are published as message attributes.
+func (c *converter) ingestAttrs(in *ingest, fields []*field) error {
+	for _, prop := range sortedKeys(in.opts.Attrs) {
+		attr := in.opts.Attrs[prop]
+		if attr == "" {
+			attr = prop
+		}
+		idx := slices.IndexFunc(fields, func(f *field) bool { return f.wireName == prop })
+		if idx < 0 {
+			return errors.Newf("cannot publish %q as a message attribute: the event has no such property", prop)
+		}
+		f := fields[idx]
+		if t := c.resolve(f.typ); t.kind != kindBuiltin || !isAttrType(t.builtin) {
+			return errors.Newf("cannot publish %q as a message attribute: must be a string, number or boolean", prop)
+		}
+		if strings.HasPrefix(attr, "encore") {
+			return errors.Newf("invalid message attribute %q: names starting with \"encore\" are reserved", attr)
+		}
+		if c.lang == appfile.LangTS && attr != prop {
+			return errors.Newf("cannot publish %q as message attribute %q: TypeScript attributes are named after their property", prop, attr)
+		}
+		f.attr = attr
+	}
+	return nil
+}
+
+func isAttrType(b builtin) bool {
+	switch b {
+	case builtinString, builtinBool, builtinInt, builtinInt32, builtinInt64, builtinFloat32, builtinFloat64, builtinUUID:
+		return true
+	}
+	return false
+}
+
+// resolve returns t, with named types resolved to their underlying type.
+func (c *converter) resolve(t *typ) *typ {
+	for seen := 0; t.kind == kindNamed && seen < len(c.types); seen++ {
+		nt := c.types[t.name]
+		if nt == nil || nt.typ == nil {
+			break
+		}
+		t = nt.typ
+	}
+	return t
+}
+
+// objectProperties adds the property schemas of an object schema to props,
+// merging the properties of allOf schemas like objectFields does.
+func objectProperties(ref *openapi3.SchemaRef, props map[string]*openapi3.Schema, required map[string]bool) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	for _, part := range ref.Value.AllOf {
+		objectProperties(part, props, required)
+	}
+	for name, prop := range ref.Value.Properties {
+		if prop != nil && prop.Value != nil {
+			props[name] = prop.Value
+			required[name] = slices.Contains(ref.Value.Required, name)
+		}
+	}
+}
+
+// fieldCheck describes how to validate the event field f with the given schema.
+func (c *converter) fieldCheck(f *field, s *openapi3.Schema, required bool) *fieldCheck {
+	if s == nil {
+		s = openapi3.NewSchema()
+	}
+	check := &fieldCheck{field: f, kind: *c.resolve(f.typ), schema: s, required: required}
+
+	if c.lang == appfile.LangGo && !required && check.kind.kind == kindBuiltin {
+		// Use pointers for optional values that can't be told apart from their zero value.
+		switch check.kind.builtin {
+		case builtinBool, builtinInt, builtinInt32, builtinInt64, builtinFloat32, builtinFloat64, builtinTime, builtinUUID:
+			f.nullable = true
+		}
+	}
+	if required && c.lang == appfile.LangGo && !f.nullable && !hasAbsentValue(check.kind) {
+		check.todo = fmt.Sprintf("check that %q is present; it can't be told apart from its zero value", f.wireName)
+	}
+
+	if s.Pattern != "" {
+		// The pattern must work the same in Go and JavaScript regular expressions.
+		if _, err := regexp.Compile(s.Pattern); err != nil {
+			check.todo = joinTodo(check.todo, fmt.Sprintf("check that %q matches the pattern %s", f.wireName, strconv.Quote(s.Pattern)))
+		} else {
+			check.pattern = s.Pattern
+		}
+	}
+	return check
+}
+
+// hasAbsentValue reports whether absent values of type t can be told apart
+// from valid ones in Go, as their zero value is not valid.
+func hasAbsentValue(t typ) bool {
+	switch t.kind {
+	case kindList, kindMap:
+		return true
+	case kindBuiltin:
+		switch t.builtin {
+		case builtinString, builtinBytes, builtinAny, builtinTime, builtinUUID:
+			return true
+		}
+	}
+	return false
+}
+
+func joinTodo(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + ", and " + b
+}
+
+// stringTimes replaces the times in t with strings.
+func stringTimes(t *typ) {
+	if t == nil {
+		return
+	}
+	if t.kind == kindBuiltin && t.builtin == builtinTime {
+		t.builtin = builtinString
+	}
+	stringTimes(t.elem)
+	for _, f := range t.fields {
+		stringTimes(f.typ)
+	}
+	for _, v := range t.variants {
+		stringTimes(v)
+	}
+}
+
+// hasBounds reports whether the schema has bounds of the given kind.
+func (ch *fieldCheck) hasBounds() (length, numeric, items bool) {
+	s := ch.schema
+	length = s.MinLength > 0 || s.MaxLength != nil
+	numeric = s.Min != nil || s.Max != nil
+	items = s.MinItems > 0 || s.MaxItems != nil
+	return length, numeric, items
+}
+
+// enum returns the allowed values of the field, if restricted.
+func (ch *fieldCheck) enum() []string {
+	if ch.kind.kind == kindBuiltin && ch.kind.builtin == builtinString {
+		return ch.kind.enum
+	}
+	return nil
+}
+
+// isInteger reports whether the field is an integer.
+func (ch *fieldCheck) isInteger() bool {
+	switch ch.kind.builtin {
+	case builtinInt, builtinInt32, builtinInt64:
+		return ch.kind.kind == kindBuiltin
+	}
+	return false
+}
+
+// boundMessages returns the error messages for values out of the numeric bounds of the field.
+func (ch *fieldCheck) boundMessages() (min, max string) {
+	s, name := ch.schema, ch.field.wireName
+	if s.Min != nil {
+		min = fmt.Sprintf("field %q must be at least %s", name, formatNumber(*s.Min))
+		if s.ExclusiveMin {
+			min = fmt.Sprintf("field %q must be greater than %s", name, formatNumber(*s.Min))
+		}
+	}
+	if s.Max != nil {
+		max = fmt.Sprintf("field %q must be at most %s", name, formatNumber(*s.Max))
+		if s.ExclusiveMax {
+			max = fmt.Sprintf("field %q must be less than %s", name, formatNumber(*s.Max))
+		}
+	}
+	return min, max
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// enumMessage returns the error message for values not in the allowed values.
+func (ch *fieldCheck) enumMessage() string {
+	var values []string
+	for _, v := range ch.enum() {
+		values = append(values, strconv.Quote(v))
+	}
+	return fmt.Sprintf("field %q must be one of %s", ch.field.wireName, strings.Join(values, ", "))
+}
@@ -0,0 +1,714 @@
+// Package openapiimport generates Encore service stubs from OpenAPI specifications.
+//
+// Each operation in the specification becomes an API endpoint with a handler that
+// returns an "unimplemented" error, and the schemas it uses become request and response types.
+// Constructs that cannot be represented in an Encore API are described in TODO comments
+// on the generated endpoints, so they can be completed by hand.
+//
+// It also generates endpoints that ingest external events described by a schema
+// into Pub/Sub topics; see GenerateIngest.
+package openapiimport
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/idents"
+)
+
+// Load loads the OpenAPI specification at the given file path or URL.
+// Both JSON and YAML specifications are supported.
+func Load(ctx context.Context, location string) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	loader.Context = ctx
+	loader.IsExternalRefsAllowed = true
+
+	var (
+		doc *openapi3.T
+		err error
+	)
+	if u, parseErr := url.Parse(location); parseErr == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		doc, err = loader.LoadFromURI(u)
+	} else {
+		doc, err = loader.LoadFromFile(location)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "load openapi spec")
+	}
+	if err := doc.Validate(ctx); err != nil {
+		return nil, errors.Wrap(err, "invalid openapi spec")
+	}
+	return doc, nil
+}
+
+// Options are the options for generating a service.
+type Options struct {
+	// Service is the name of the service to generate.
+	Service string
+
+	// Lang is the language to generate the service in.
+	Lang appfile.Lang
+}
+
+// File is a generated source file.
+type File struct {
+	Name    string // file name, relative to the service directory
+	Content []byte
+}
+
+// Generate generates the source files of a service implementing the API described by doc.
+func Generate(doc *openapi3.T, opts Options) ([]File, error) {
+	if !isIdent(opts.Service) || strings.ToLower(opts.Service) != opts.Service {
+		return nil, errors.Newf("invalid service name %q: must be a lowercase identifier", opts.Service)
+	}
+
+	svc, err := newConverter(doc, opts.Lang).convert(opts.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	switch opts.Lang {
+	case appfile.LangGo:
+		return generateGo(svc)
+	case appfile.LangTS:
+		return generateTS(svc), nil
+	default:
+		return nil, errors.Newf("unsupported language %q", opts.Lang)
+	}
+}
+
+// service is the language-independent description of the service to generate.
+type service struct {
+	name      string
+	title     string // the title of the API specification
+	types     []*namedType
+	endpoints []*endpoint
+}
+
+type namedType struct {
+	name string
+	doc  string
+	typ  *typ
+}
+
+type typeKind int
+
+const (
+	kindBuiltin typeKind = iota
+	kindNamed
+	kindList
+	kindMap
+	kindStruct
+	kindUnion
+)
+
+type builtin string
+
+const (
+	builtinString  builtin = "string"
+	builtinBool    builtin = "bool"
+	builtinInt     builtin = "int"
+	builtinInt32   builtin = "int32"
+	builtinInt64   builtin = "int64"
+	builtinFloat32 builtin = "float32"
+	builtinFloat64 builtin = "float64"
+	builtinTime    builtin = "time"
+	builtinUUID    builtin = "uuid"
+	builtinBytes   builtin = "bytes"
+	builtinAny     builtin = "any"
+)
+
+// typ is a type in the generated code.
+type typ struct {
+	kind     typeKind
+	builtin  builtin  // for kindBuiltin
+	name     string   // for kindNamed
+	elem     *typ     // for kindList and kindMap
+	fields   []*field // for kindStruct
+	variants []*typ   // for kindUnion
+	enum     []string // the allowed values of a string builtin, if restricted
+}
+
+type paramLocation int
+
+const (
+	locBody paramLocation = iota
+	locQuery
+	locHeader
+	locPath
+)
+
+type field struct {
+	name     string // identifier in the generated code, in camelCase
+	wireName string
+	doc      string
+	typ      *typ
+	loc      paramLocation
+	optional bool
+	nullable bool
+	attr     string // the Pub/Sub message attribute the field is published as, if any
+}
+
+type endpoint struct {
+	name       string // in PascalCase
+	doc        string
+	method     string
+	path       string // in Encore syntax, like "/users/:id"
+	auth       bool
+	pathParams []*field
+	request    *typ // named type, or nil
+	response   *typ // named type, or nil
+	todos      []string
+}
+
+type converter struct {
+	doc   *openapi3.T
+	lang  appfile.Lang
+	types map[string]*namedType
+	names map[*openapi3.Schema]string // component schemas that have been converted
+}
+
+func newConverter(doc *openapi3.T, lang appfile.Lang) *converter {
+	return &converter{
+		doc:   doc,
+		lang:  lang,
+		types: make(map[string]*namedType),
+		names: make(map[*openapi3.Schema]string),
+	}
+}
+
+func (c *converter) convert(svcName string) (*service, error) {
+	svc := &service{name: svcName}
+	if c.doc.Info != nil {
+		svc.title = c.doc.Info.Title
+	}
+
+	// Convert the component schemas first, so they get their preferred names.
+	if c.doc.Components != nil {
+		for _, name := range sortedKeys(c.doc.Components.Schemas) {
+			c.componentType(name, c.doc.Components.Schemas[name])
+		}
+	}
+
+	epNames := make(map[string]bool)
+	for _, path := range sortedKeys(c.doc.Paths) {
+		item := c.doc.Paths[path]
+		ops := item.Operations()
+		for _, method := range sortedKeys(ops) {
+			ep, err := c.endpoint(path, method, item, ops[method])
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s %s", method, path)
+			}
+			ep.name = uniqueName(ep.name, func(n string) bool { return epNames[n] || c.types[n] != nil })
+			epNames[ep.name] = true
+			svc.endpoints = append(svc.endpoints, ep)
+		}
+	}
+
+	for _, name := range sortedKeys(c.types) {
+		svc.types = append(svc.types, c.types[name])
+	}
+	return svc, nil
+}
+
+func (c *converter) endpoint(path, method string, item *openapi3.PathItem, op *openapi3.Operation) (*endpoint, error) {
+	ep := &endpoint{
+		name:   c.goIdent(endpointName(method, path, op.OperationID)),
+		doc:    joinDoc(op.Summary, op.Description),
+		method: method,
+	}
+	if op.Deprecated {
+		ep.doc = joinDoc(ep.doc, "Deprecated: This endpoint is deprecated.")
+	}
+	if op.Security != nil {
+		ep.auth = len(*op.Security) > 0
+	} else {
+		ep.auth = len(c.doc.Security) > 0
+	}
+
+	// Operation parameters override path item parameters with the same name and location.
+	params := make(map[string]*openapi3.Parameter)
+	var paramKeys []string
+	for _, list := range []openapi3.Parameters{item.Parameters, op.Parameters} {
+		for _, ref := range list {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			key := ref.Value.In + ":" + ref.Value.Name
+			if _, ok := params[key]; !ok {
+				paramKeys = append(paramKeys, key)
+			}
+			params[key] = ref.Value
+		}
+	}
+
+	reqName := ep.name + "Request"
+	var reqFields []*field
+	pathNames := make(map[string]string) // wire name -> identifier
+	for _, key := range paramKeys {
+		p := params[key]
+		f := &field{
+			name:     c.fieldName(p.Name),
+			wireName: p.Name,
+			doc:      p.Description,
+			optional: !p.Required,
+		}
+		if p.Schema != nil {
+			f.typ = c.paramType(p.Schema)
+		} else {
+			f.typ = &typ{kind: kindBuiltin, builtin: builtinString}
+		}
+
+		switch p.In {
+		case openapi3.ParameterInPath:
+			f.loc = locPath
+			f.optional = false
+			if !isPathParamType(f.typ) {
+				ep.todos = append(ep.todos, fmt.Sprintf("path parameter %q has an unsupported type; it is treated as a string", p.Name))
+				f.typ = &typ{kind: kindBuiltin, builtin: builtinString}
+			}
+			pathNames[p.Name] = f.name
+			ep.pathParams = append(ep.pathParams, f)
+		case openapi3.ParameterInQuery:
+			f.loc = locQuery
+			reqFields = append(reqFields, f)
+		case openapi3.ParameterInHeader:
+			f.loc = locHeader
+			reqFields = append(reqFields, f)
+		default:
+			ep.todos = append(ep.todos, fmt.Sprintf("the %s parameter %q is not supported and must be handled manually", p.In, p.Name))
+		}
+	}
+
+	encorePath, err := convertPath(path, pathNames)
+	if err != nil {
+		return nil, err
+	}
+	ep.path = encorePath
+	// Order the path parameters as they appear in the path, as Encore requires.
+	slices.SortStableFunc(ep.pathParams, func(a, b *field) int {
+		return strings.Index(ep.path, ":"+a.name) - strings.Index(ep.path, ":"+b.name)
+	})
+
+	// Request body
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		body := op.RequestBody.Value
+		if media := jsonContent(body.Content); media == nil || media.Schema == nil {
+			ep.todos = append(ep.todos, fmt.Sprintf("the request body has content type %s, which is not supported; use a raw endpoint to handle it", contentTypes(body.Content)))
+		} else if named, ok := c.refName(media.Schema); ok && len(reqFields) == 0 && c.isStruct(named) {
+			// Use the referenced type directly.
+			ep.request = &typ{kind: kindNamed, name: named}
+		} else if fields, ok := c.objectFields(reqName, media.Schema); ok {
+			for _, f := range fields {
+				f.optional = f.optional || !body.Required
+			}
+			reqFields = append(reqFields, fields...)
+		} else {
+			ep.todos = append(ep.todos, "the request body is not an object, which is not supported; use a raw endpoint to handle it")
+		}
+	}
+	if ep.request == nil && len(reqFields) > 0 {
+		ep.request = c.addType(reqName, "", &typ{kind: kindStruct, fields: reqFields})
+	}
+
+	// Response body
+	resp, status := successResponse(op.Responses)
+	switch {
+	case resp == nil:
+	case len(resp.Content) == 0:
+		// No response body.
+	default:
+		respName := ep.name + "Response"
+		if media := jsonContent(resp.Content); media == nil || media.Schema == nil {
+			ep.todos = append(ep.todos, fmt.Sprintf("the %s response has content type %s, which is not supported; use a raw endpoint to handle it", status, contentTypes(resp.Content)))
+		} else if named, ok := c.refName(media.Schema); ok && c.isStruct(named) {
+			ep.response = &typ{kind: kindNamed, name: named}
+		} else if fields, ok := c.objectFields(respName, media.Schema); ok {
+			ep.response = c.addType(respName, "", &typ{kind: kindStruct, fields: fields})
+		} else {
+			ep.todos = append(ep.todos, `the response body is not an object, so it is wrapped in a "data" field`)
+			ep.response = c.addType(respName, "", &typ{kind: kindStruct, fields: []*field{{
+				name:     "data",
+				wireName: "data",
+				typ:      c.schemaType(respName+"Data", media.Schema),
+			}}})
+		}
+	}
+	if status != "" && status != "200" && status != "2XX" {
+		ep.todos = append(ep.todos, fmt.Sprintf("the endpoint responds with status %s", status))
+	}
+
+	return ep, nil
+}
+
+// componentType converts the component schema with the given name.
+func (c *converter) componentType(name string, ref *openapi3.SchemaRef) string {
+	if ref == nil || ref.Value == nil {
+		return ""
+	}
+	if existing, ok := c.names[ref.Value]; ok {
+		return existing
+	}
+
+	nt := c.addType(c.typeName(name), "", nil)
+	c.names[ref.Value] = nt.name
+	t := c.types[nt.name]
+	t.doc = joinDoc(ref.Value.Title, ref.Value.Description)
+	if ref.Value.Deprecated {
+		t.doc = joinDoc(t.doc, "Deprecated: This type is deprecated.")
+	}
+	t.typ = c.rawSchemaType(nt.name, &openapi3.SchemaRef{Value: ref.Value})
+	return nt.name
+}
+
+// refName reports the name of the component type the schema refers to, if any.
+func (c *converter) refName(ref *openapi3.SchemaRef) (string, bool) {
+	if ref == nil || ref.Ref == "" {
+		return "", false
+	}
+	_, component, ok := strings.Cut(ref.Ref, "#/components/schemas/")
+	if !ok {
+		return "", false
+	}
+	return c.componentType(component, ref), true
+}
+
+// isStruct reports whether the named type is a struct.
+func (c *converter) isStruct(name string) bool {
+	t := c.types[name]
+	return t != nil && t.typ != nil && t.typ.kind == kindStruct
+}
+
+// addType adds a named type with a name based on the given one.
+// It returns a reference to the type.
+func (c *converter) addType(name, doc string, t *typ) *typ {
+	name = uniqueName(name, func(n string) bool { return c.types[n] != nil })
+	c.types[name] = &namedType{name: name, doc: doc, typ: t}
+	return &typ{kind: kindNamed, name: name}
+}
+
+// schemaType converts a schema to a type. Inline object schemas are converted
+// to named types, with names derived from the given name.
+func (c *converter) schemaType(name string, ref *openapi3.SchemaRef) *typ {
+	if ref != nil && ref.Value != nil {
+		// Reuse the type of inline schemas that have already been converted,
+		// like the properties of objects included in several allOf schemas.
+		if existing, ok := c.names[ref.Value]; ok {
+			return &typ{kind: kindNamed, name: existing}
+		}
+	}
+
+	t := c.rawSchemaType(name, ref)
+	if t.kind == kindStruct {
+		t = c.addType(name, "", t)
+		c.names[ref.Value] = t.name
+	}
+	return t
+}
+
+// rawSchemaType is like schemaType, but returns inline object schemas as structs.
+func (c *converter) rawSchemaType(name string, ref *openapi3.SchemaRef) *typ {
+	if named, ok := c.refName(ref); ok {
+		return &typ{kind: kindNamed, name: named}
+	}
+	if ref == nil || ref.Value == nil {
+		return &typ{kind: kindBuiltin, builtin: builtinAny}
+	}
+
+	s := ref.Value
+	switch {
+	case len(s.AllOf) > 0 || s.Type == openapi3.TypeObject || len(s.Properties) > 0:
+		if fields, ok := c.objectFields(name, ref); ok && len(fields) > 0 {
+			return &typ{kind: kindStruct, fields: fields}
+		}
+		if ap := s.AdditionalProperties.Schema; ap != nil {
+			return &typ{kind: kindMap, elem: c.schemaType(name+"Value", ap)}
+		}
+		return &typ{kind: kindBuiltin, builtin: builtinAny}
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		if c.lang != appfile.LangTS {
+			// Go has no union types.
+			return &typ{kind: kindBuiltin, builtin: builtinAny}
+		}
+		u := &typ{kind: kindUnion}
+		for i, v := range append(slices.Clone(s.OneOf), s.AnyOf...) {
+			u.variants = append(u.variants, c.schemaType(fmt.Sprintf("%s%d", name, i+1), v))
+		}
+		return u
+	case s.Type == openapi3.TypeArray:
+		return &typ{kind: kindList, elem: c.schemaType(name+"Item", s.Items)}
+	case s.Type == openapi3.TypeString:
+		t := &typ{kind: kindBuiltin, builtin: builtinString}
+		switch s.Format {
+		case "date-time":
+			t.builtin = builtinTime
+		case "uuid":
+			t.builtin = builtinUUID
+		case "byte", "binary":
+			t.builtin = builtinBytes
+		}
+		for _, v := range s.Enum {
+			if str, ok := v.(string); ok {
+				t.enum = append(t.enum, str)
+			}
+		}
+		return t
+	case s.Type == openapi3.TypeInteger:
+		switch s.Format {
+		case "int32":
+			return &typ{kind: kindBuiltin, builtin: builtinInt32}
+		case "int64":
+			return &typ{kind: kindBuiltin, builtin: builtinInt64}
+		}
+		return &typ{kind: kindBuiltin, builtin: builtinInt}
+	case s.Type == openapi3.TypeNumber:
+		if s.Format == "float" {
+			return &typ{kind: kindBuiltin, builtin: builtinFloat32}
+		}
+		return &typ{kind: kindBuiltin, builtin: builtinFloat64}
+	case s.Type == openapi3.TypeBoolean:
+		return &typ{kind: kindBuiltin, builtin: builtinBool}
+	default:
+		return &typ{kind: kindBuiltin, builtin: builtinAny}
+	}
+}
+
+// objectFields returns the fields of an object schema, merging the properties of allOf schemas.
+// It reports false if the schema is not an object.
+func (c *converter) objectFields(name string, ref *openapi3.SchemaRef) ([]*field, bool) {
+	if ref == nil || ref.Value == nil {
+		return nil, false
+	}
+	s := ref.Value
+	if s.Type != "" && s.Type != openapi3.TypeObject {
+		return nil, false
+	}
+	if s.Type == "" && len(s.Properties) == 0 && len(s.AllOf) == 0 {
+		return nil, false
+	}
+
+	var fields []*field
+	for _, part := range s.AllOf {
+		partFields, ok := c.objectFields(name, part)
+		if !ok {
+			return nil, false
+		}
+		fields = append(fields, partFields...)
+	}
+
+	for _, prop := range sortedKeys(s.Properties) {
+		propRef := s.Properties[prop]
+		f := &field{
+			name:     c.fieldName(prop),
+			wireName: prop,
+			typ:      c.schemaType(name+c.typeName(prop), propRef),
+			optional: !slices.Contains(s.Required, prop),
+		}
+		if v := propRef.Value; v != nil {
+			f.nullable = v.Nullable
+			if propRef.Ref == "" {
+				f.doc = joinDoc(v.Title, v.Description)
+			}
+			if v.Deprecated {
+				f.doc = joinDoc(f.doc, "Deprecated: This field is deprecated.")
+			}
+		}
+
+		// Properties redefined by a later allOf schema replace earlier ones.
+		fields = slices.DeleteFunc(fields, func(other *field) bool { return other.wireName == prop })
+		fields = append(fields, f)
+	}
+	return fields, true
+}
+
+// paramType converts the schema of a query, header or path parameter.
+func (c *converter) paramType(ref *openapi3.SchemaRef) *typ {
+	t := c.schemaType("", ref)
+	if t.kind == kindNamed {
+		// Resolve aliases of builtins, as parameters must be builtins or lists of them.
+		if nt := c.types[t.name]; nt != nil && nt.typ != nil && nt.typ.kind == kindBuiltin {
+			return nt.typ
+		}
+	}
+	return t
+}
+
+func isPathParamType(t *typ) bool {
+	if t.kind != kindBuiltin {
+		return false
+	}
+	switch t.builtin {
+	case builtinBytes, builtinAny, builtinTime:
+		return false
+	}
+	return true
+}
+
+// successResponse returns the response for the first success status code of the operation.
+func successResponse(responses openapi3.Responses) (*openapi3.Response, string) {
+	var codes []string
+	for code := range responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+	for _, code := range codes {
+		if ref := responses[code]; ref != nil && ref.Value != nil {
+			return ref.Value, code
+		}
+	}
+	return nil, ""
+}
+
+// jsonContent returns the JSON media type of the content, if any.
+func jsonContent(content openapi3.Content) *openapi3.MediaType {
+	for _, ct := range sortedKeys(content) {
+		base, _, _ := strings.Cut(ct, ";")
+		base = strings.TrimSpace(base)
+		if base == "application/json" || strings.HasSuffix(base, "+json") {
+			return content[ct]
+		}
+	}
+	return nil
+}
+
+func contentTypes(content openapi3.Content) string {
+	return strings.Join(sortedKeys(content), ", ")
+}
+
+// convertPath converts an OpenAPI path like "/users/{id}" to Encore syntax,
+// using the given identifiers for the path parameters.
+func convertPath(path string, params map[string]string) (string, error) {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if !strings.Contains(seg, "{") {
+			continue
+		}
+		if !strings.HasPrefix(seg, "{") || !strings.HasSuffix(seg, "}") {
+			return "", errors.Newf("path segment %q must consist of a single parameter", seg)
+		}
+		name := seg[1 : len(seg)-1]
+		ident, ok := params[name]
+		if !ok {
+			return "", errors.Newf("path parameter %q is not defined", name)
+		}
+		segments[i] = ":" + ident
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// endpointName returns the name of an endpoint, in PascalCase.
+func endpointName(method, path, operationID string) string {
+	if name := typeName(operationID); operationID != "" && name != "" {
+		return name
+	}
+
+	// Derive the name from the method and path, like "GetUsersByID" for "GET /users/{id}".
+	var b strings.Builder
+	b.WriteString(typeName(strings.ToLower(method)))
+	for _, seg := range strings.Split(path, "/") {
+		if strings.HasPrefix(seg, "{") {
+			b.WriteString("By")
+			seg = strings.Trim(seg, "{}")
+		}
+		b.WriteString(typeName(seg))
+	}
+	return b.String()
+}
+
+// typeName converts s to a type name, using the conventions of the target language.
+func (c *converter) typeName(s string) string {
+	return c.goIdent(typeName(s))
+}
+
+// fieldName converts s to a field name, using the conventions of the target language.
+func (c *converter) fieldName(s string) string {
+	return c.goIdent(fieldName(s))
+}
+
+// initialisms matches the common initialisms that are written in upper case in Go identifiers.
+var initialisms = regexp.MustCompile(`(Api|Http|Id|Json|Uri|Url|Uuid)([A-Z0-9]|$)`)
+
+// goIdent returns ident with initialisms in upper case if the target language is Go.
+func (c *converter) goIdent(ident string) string {
+	if c.lang != appfile.LangGo {
+		return ident
+	}
+	return initialisms.ReplaceAllStringFunc(ident, strings.ToUpper)
+}
+
+// typeName converts s to an identifier in PascalCase.
+func typeName(s string) string {
+	name := idents.Convert(sanitize(s), idents.PascalCase)
+	if name != "" && !unicode.IsLetter([]rune(name)[0]) {
+		name = "T" + name
+	}
+	return name
+}
+
+// fieldName converts s to an identifier in camelCase.
+func fieldName(s string) string {
+	name := idents.Convert(sanitize(s), idents.CamelCase)
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "f" + typeName(name)
+	}
+	return name
+}
+
+// sanitize replaces the characters of s that are not valid in identifiers with underscores.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+func isIdent(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// uniqueName returns name, with a numeric suffix added if it is already taken.
+func uniqueName(name string, taken func(string) bool) string {
+	candidate := name
+	for i := 2; taken(candidate); i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	return candidate
+}
+
+// joinDoc joins the non-empty doc paragraphs.
+func joinDoc(paragraphs ...string) string {
+	var parts []string
+	for _, p := range paragraphs {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
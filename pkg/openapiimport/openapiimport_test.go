@@ -0,0 +1,111 @@
+package openapiimport
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/golden"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	doc, err := Load(context.Background(), "testdata/petstore.yaml")
+	c.Assert(err, qt.IsNil)
+
+	for _, lang := range []appfile.Lang{appfile.LangGo, appfile.LangTS} {
+		c.Run(string(lang), func(c *qt.C) {
+			files, err := Generate(doc, Options{Service: "petstore", Lang: lang})
+			c.Assert(err, qt.IsNil)
+			for _, f := range files {
+				golden.TestAgainst(c, string(lang)+"/"+f.Name, string(f.Content))
+			}
+		})
+	}
+}
+
+func TestGenerateInvalidService(t *testing.T) {
+	c := qt.New(t)
+	doc, err := Load(context.Background(), "testdata/petstore.yaml")
+	c.Assert(err, qt.IsNil)
+
+	_, err = Generate(doc, Options{Service: "Pet-Store", Lang: appfile.LangGo})
+	c.Assert(err, qt.ErrorMatches, `invalid service name "Pet-Store".*`)
+}
+
+func TestConvertPath(t *testing.T) {
+	c := qt.New(t)
+	path, err := convertPath("/users/{user_id}/posts/{id}", map[string]string{"user_id": "userID", "id": "id"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(path, qt.Equals, "/users/:userID/posts/:id")
+
+	_, err = convertPath("/files/{name}.txt", map[string]string{"name": "name"})
+	c.Assert(err, qt.ErrorMatches, `path segment "{name}.txt" must consist of a single parameter`)
+}
+
+func TestGenerateIngest(t *testing.T) {
+	c := qt.New(t)
+	schema, err := LoadSchema(context.Background(), "testdata/ingest/reading.schema.json")
+	c.Assert(err, qt.IsNil)
+
+	tests := []struct {
+		lang appfile.Lang
+		auth IngestAuth
+	}{
+		{appfile.LangGo, IngestHMAC},
+		{appfile.LangGo, IngestAuthHandler},
+		{appfile.LangTS, IngestToken},
+		{appfile.LangTS, IngestAuthHandler},
+	}
+	for _, test := range tests {
+		c.Run(string(test.lang)+"_"+string(test.auth), func(c *qt.C) {
+			files, err := GenerateIngest(schema, IngestOptions{
+				Service: "telemetry",
+				Lang:    test.lang,
+				Auth:    test.auth,
+				Attrs:   map[string]string{"device_id": "", "kind": ""},
+			})
+			c.Assert(err, qt.IsNil)
+			for _, f := range files {
+				golden.TestAgainst(c, "ingest/"+string(test.lang)+"_"+string(test.auth)+"/"+f.Name, string(f.Content))
+			}
+		})
+	}
+}
+
+func TestGenerateIngestFromOpenAPI(t *testing.T) {
+	c := qt.New(t)
+	schema, err := LoadSchema(context.Background(), "testdata/petstore.yaml#/components/schemas/Pet")
+	c.Assert(err, qt.IsNil)
+
+	files, err := GenerateIngest(schema, IngestOptions{
+		Service: "petstore",
+		Lang:    appfile.LangGo,
+		Topic:   "pet-updates",
+		Attrs:   map[string]string{"status": "pet-status"},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 1)
+	c.Assert(files[0].Name, qt.Equals, "ingest_pet.go")
+	golden.TestAgainst(c, "ingest/go_openapi/ingest_pet.go", string(files[0].Content))
+}
+
+func TestGenerateIngestInvalidAttr(t *testing.T) {
+	c := qt.New(t)
+	schema, err := LoadSchema(context.Background(), "testdata/ingest/reading.schema.json")
+	c.Assert(err, qt.IsNil)
+
+	opts := IngestOptions{Service: "telemetry", Lang: appfile.LangGo, Attrs: map[string]string{"location": ""}}
+	_, err = GenerateIngest(schema, opts)
+	c.Assert(err, qt.ErrorMatches, `cannot publish "location" as a message attribute: must be a string, number or boolean`)
+
+	opts.Attrs = map[string]string{"kind": "encore-kind"}
+	_, err = GenerateIngest(schema, opts)
+	c.Assert(err, qt.ErrorMatches, `invalid message attribute "encore-kind".*`)
+}
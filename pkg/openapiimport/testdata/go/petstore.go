@@ -0,0 +1,70 @@
+// Code generated by encore gen service from an OpenAPI specification.
+// This file is meant to be edited: implement the endpoints and remove the TODOs.
+
+// Package petstore implements the Petstore API.
+package petstore
+
+import (
+	"context"
+	"encore.dev/beta/errs"
+)
+
+// Lists the pets in the store.
+//
+//encore:api public method=GET path=/pets
+func ListPets(ctx context.Context, p *ListPetsRequest) (*ListPetsResponse, error) {
+	// TODO: implement
+	return nil, &errs.Error{
+		Code:    errs.Unimplemented,
+		Message: "not implemented",
+	}
+}
+
+// Adds a pet to the store.
+//
+// TODO: the endpoint responds with status 201.
+//
+//encore:api auth method=POST path=/pets
+func CreatePet(ctx context.Context, p *NewPet) (*Pet, error) {
+	// TODO: implement
+	return nil, &errs.Error{
+		Code:    errs.Unimplemented,
+		Message: "not implemented",
+	}
+}
+
+// Deprecated: This endpoint is deprecated.
+//
+// TODO: the endpoint responds with status 204.
+//
+//encore:api auth method=DELETE path=/pets/:petID
+func DeletePet(ctx context.Context, petID int64) error {
+	// TODO: implement
+	return &errs.Error{
+		Code:    errs.Unimplemented,
+		Message: "not implemented",
+	}
+}
+
+// Returns a pet.
+//
+//encore:api auth method=GET path=/pets/:petID
+func GetPetsByPetID(ctx context.Context, petID int64) (*Pet, error) {
+	// TODO: implement
+	return nil, &errs.Error{
+		Code:    errs.Unimplemented,
+		Message: "not implemented",
+	}
+}
+
+// TODO: the request body has content type image/png, which is not supported; use a raw endpoint to handle it.
+// TODO: the response body is not an object, so it is wrapped in a "data" field.
+//
+//encore:api auth method=PUT path=/pets/:petID/photo
+func UploadPhoto(ctx context.Context, petID int64) (*UploadPhotoResponse, error) {
+	// TODO: implement
+	return nil, &errs.Error{
+		Code:    errs.Unimplemented,
+		Message: "not implemented",
+	}
+}
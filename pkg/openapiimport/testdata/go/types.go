@@ -0,0 +1,52 @@
+// Code generated by encore gen service from an OpenAPI specification.
+
+package petstore
+
+import "time"
+
+type ListPetsRequest struct {
+	// The maximum number of pets to return.
+	Limit      int32  `encore:"optional" query:"limit"`
+	XRequestID string `encore:"optional" header:"X-Request-ID"`
+}
+
+type ListPetsResponse struct {
+	NextCursor *string `encore:"optional" json:"next_cursor,omitempty"`
+	Pets       []Pet   `json:"pets"`
+}
+
+type NewPet struct {
+	// The name of the pet.
+	Name   string      `json:"name"`
+	Owner  NewPetOwner `encore:"optional" json:"owner,omitempty"`
+	Status Status      `encore:"optional" json:"status,omitempty"`
+	Tag    string      `encore:"optional" json:"tag,omitempty"`
+}
+
+type NewPetOwner struct {
+	Email string `encore:"optional" json:"email,omitempty"`
+}
+
+// A pet in the store.
+type Pet struct {
+	// The name of the pet.
+	Name       string            `json:"name"`
+	Owner      NewPetOwner       `encore:"optional" json:"owner,omitempty"`
+	Status     Status            `encore:"optional" json:"status,omitempty"`
+	Tag        string            `encore:"optional" json:"tag,omitempty"`
+	Attributes map[string]string `encore:"optional" json:"attributes,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	ID         int64             `json:"id"`
+}
+
+type Status string
+
+const (
+	StatusAvailable Status = "available"
+	StatusPending   Status = "pending"
+	StatusSold      Status = "sold"
+)
+
+type UploadPhotoResponse struct {
+	Data []string `json:"data"`
+}
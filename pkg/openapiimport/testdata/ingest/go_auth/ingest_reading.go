@@ -0,0 +1,103 @@
+// Code generated by encore gen ingest from a JSON Schema.
+// This file is meant to be edited: complete the TODOs.
+
+package telemetry
+
+import (
+	"context"
+	"encore.dev/pubsub"
+	"errors"
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+// A sensor reading sent by a device.
+type Reading struct {
+	Battery *int `encore:"optional" json:"battery,omitempty"`
+
+	// The ID of the device.
+	DeviceID   string          `json:"device_id" pubsub-attr:"device_id"`
+	Firmware   *string         `encore:"optional" json:"firmware,omitempty"`
+	Kind       string          `json:"kind" pubsub-attr:"kind"`
+	Location   ReadingLocation `encore:"optional" json:"location,omitempty"`
+	RecordedAt time.Time       `json:"recorded_at"`
+	Tags       []string        `encore:"optional" json:"tags,omitempty"`
+	Value      float64         `json:"value"`
+}
+
+type ReadingLocation struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+var readingDeviceIDPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// Validate reports whether the Reading matches its schema.
+func (r *Reading) Validate() error {
+	if r.Battery != nil {
+		if *r.Battery < 0 {
+			return errors.New("field \"battery\" must be at least 0")
+		}
+		if *r.Battery > 100 {
+			return errors.New("field \"battery\" must be at most 100")
+		}
+	}
+	if r.DeviceID == "" {
+		return errors.New("missing required field \"device_id\"")
+	}
+	if utf8.RuneCountInString(r.DeviceID) < 3 {
+		return errors.New("field \"device_id\" must be at least 3 characters")
+	}
+	if utf8.RuneCountInString(r.DeviceID) > 64 {
+		return errors.New("field \"device_id\" must be at most 64 characters")
+	}
+	if !readingDeviceIDPattern.MatchString(r.DeviceID) {
+		return errors.New("field \"device_id\" must match the pattern ^[a-z0-9-]+$")
+	}
+	if r.Kind == "" {
+		return errors.New("missing required field \"kind\"")
+	}
+	switch r.Kind {
+	case "temperature", "humidity":
+	default:
+		return errors.New("field \"kind\" must be one of \"temperature\", \"humidity\"")
+	}
+	if r.RecordedAt.IsZero() {
+		return errors.New("missing required field \"recorded_at\"")
+	}
+	if r.Tags != nil {
+		if len(r.Tags) > 10 {
+			return errors.New("field \"tags\" must have at most 10 items")
+		}
+	}
+	// TODO: check that "value" is present; it can't be told apart from its zero value.
+	if r.Value < -100.0 {
+		return errors.New("field \"value\" must be at least -100")
+	}
+	if r.Value >= 1000.0 {
+		return errors.New("field \"value\" must be less than 1000")
+	}
+	return nil
+}
+
+// ReadingTopic is the topic the Reading events received by IngestReading are published to.
+var ReadingTopic = pubsub.NewTopic[*Reading]("reading", pubsub.TopicConfig{DeliveryGuarantee: pubsub.AtLeastOnce})
+
+// IngestReading receives Reading events from external systems and publishes them to ReadingTopic.
+// Requests are authenticated by the app's auth handler, and the events
+// are validated against their schema before the endpoint is called.
+//
+//encore:api auth method=POST path=/ingest/reading
+func IngestReading(ctx context.Context, event *Reading) (*IngestReadingResponse, error) {
+	id, err := ReadingTopic.Publish(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	return &IngestReadingResponse{ID: id}, nil
+}
+
+type IngestReadingResponse struct {
+	// ID is the ID of the published message.
+	ID string `json:"id"`
+}
@@ -0,0 +1,139 @@
+// Code generated by encore gen ingest from a JSON Schema.
+// This file is meant to be edited: complete the TODOs.
+
+package telemetry
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encore.dev/beta/errs"
+	"encore.dev/pubsub"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// A sensor reading sent by a device.
+type Reading struct {
+	Battery *int `encore:"optional" json:"battery,omitempty"`
+
+	// The ID of the device.
+	DeviceID   string          `json:"device_id" pubsub-attr:"device_id"`
+	Firmware   *string         `encore:"optional" json:"firmware,omitempty"`
+	Kind       string          `json:"kind" pubsub-attr:"kind"`
+	Location   ReadingLocation `encore:"optional" json:"location,omitempty"`
+	RecordedAt time.Time       `json:"recorded_at"`
+	Tags       []string        `encore:"optional" json:"tags,omitempty"`
+	Value      float64         `json:"value"`
+}
+
+type ReadingLocation struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+var readingDeviceIDPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// Validate reports whether the Reading matches its schema.
+func (r *Reading) Validate() error {
+	if r.Battery != nil {
+		if *r.Battery < 0 {
+			return errors.New("field \"battery\" must be at least 0")
+		}
+		if *r.Battery > 100 {
+			return errors.New("field \"battery\" must be at most 100")
+		}
+	}
+	if r.DeviceID == "" {
+		return errors.New("missing required field \"device_id\"")
+	}
+	if utf8.RuneCountInString(r.DeviceID) < 3 {
+		return errors.New("field \"device_id\" must be at least 3 characters")
+	}
+	if utf8.RuneCountInString(r.DeviceID) > 64 {
+		return errors.New("field \"device_id\" must be at most 64 characters")
+	}
+	if !readingDeviceIDPattern.MatchString(r.DeviceID) {
+		return errors.New("field \"device_id\" must match the pattern ^[a-z0-9-]+$")
+	}
+	if r.Kind == "" {
+		return errors.New("missing required field \"kind\"")
+	}
+	switch r.Kind {
+	case "temperature", "humidity":
+	default:
+		return errors.New("field \"kind\" must be one of \"temperature\", \"humidity\"")
+	}
+	if r.RecordedAt.IsZero() {
+		return errors.New("missing required field \"recorded_at\"")
+	}
+	if r.Tags != nil {
+		if len(r.Tags) > 10 {
+			return errors.New("field \"tags\" must have at most 10 items")
+		}
+	}
+	// TODO: check that "value" is present; it can't be told apart from its zero value.
+	if r.Value < -100.0 {
+		return errors.New("field \"value\" must be at least -100")
+	}
+	if r.Value >= 1000.0 {
+		return errors.New("field \"value\" must be less than 1000")
+	}
+	return nil
+}
+
+// ReadingTopic is the topic the Reading events received by IngestReading are published to.
+var ReadingTopic = pubsub.NewTopic[*Reading]("reading", pubsub.TopicConfig{DeliveryGuarantee: pubsub.AtLeastOnce})
+
+// If the service already declares its secrets, move this secret there.
+var secrets struct {
+	// ReadingIngestSecret is the secret shared with the systems sending Reading events.
+	ReadingIngestSecret string
+}
+
+// IngestReading receives Reading events from external systems and publishes them to ReadingTopic.
+//
+// Requests must be signed with the ReadingIngestSecret secret: the X-Signature header must hold
+// the hex-encoded HMAC-SHA256 of the request body, optionally prefixed by "sha256=".
+//
+//encore:api public raw method=POST path=/ingest/reading
+func IngestReading(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, 1<<20))
+	if err != nil {
+		errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Msg("could not read request body").Err())
+		return
+	}
+
+	mac := hmac.New(sha256.New, []byte(secrets.ReadingIngestSecret))
+	mac.Write(body)
+	signature, err := hex.DecodeString(strings.TrimPrefix(req.Header.Get("X-Signature"), "sha256="))
+	if err != nil || secrets.ReadingIngestSecret == "" || !hmac.Equal(signature, mac.Sum(nil)) {
+		errs.HTTPError(w, errs.B().Code(errs.Unauthenticated).Msg("invalid signature").Err())
+		return
+	}
+
+	var event Reading
+	if err := json.Unmarshal(body, &event); err != nil {
+		errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Msg("invalid event").Err())
+		return
+	}
+	if err := event.Validate(); err != nil {
+		errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Msg(err.Error()).Err())
+		return
+	}
+
+	id, err := ReadingTopic.Publish(req.Context(), &event)
+	if err != nil {
+		errs.HTTPError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"id": id})
+}
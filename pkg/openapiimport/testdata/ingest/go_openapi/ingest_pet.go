@@ -0,0 +1,111 @@
+// Code generated by encore gen ingest from a JSON Schema.
+// This file is meant to be edited: complete the TODOs.
+
+package petstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encore.dev/beta/errs"
+	"encore.dev/pubsub"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// A pet in the store.
+type Pet struct {
+	// The name of the pet.
+	Name       string            `json:"name"`
+	Owner      PetOwner          `encore:"optional" json:"owner,omitempty"`
+	Status     Status            `encore:"optional" json:"status,omitempty" pubsub-attr:"pet-status"`
+	Tag        string            `encore:"optional" json:"tag,omitempty"`
+	Attributes map[string]string `encore:"optional" json:"attributes,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	ID         int64             `json:"id"`
+}
+
+type PetOwner struct {
+	Email string `encore:"optional" json:"email,omitempty"`
+}
+
+type Status string
+
+const (
+	StatusAvailable Status = "available"
+	StatusPending   Status = "pending"
+	StatusSold      Status = "sold"
+)
+
+// Validate reports whether the Pet matches its schema.
+func (p *Pet) Validate() error {
+	if p.Name == "" {
+		return errors.New("missing required field \"name\"")
+	}
+	if p.Status != "" {
+		switch p.Status {
+		case "available", "pending", "sold":
+		default:
+			return errors.New("field \"status\" must be one of \"available\", \"pending\", \"sold\"")
+		}
+	}
+	if p.CreatedAt.IsZero() {
+		return errors.New("missing required field \"created_at\"")
+	}
+	// TODO: check that "id" is present; it can't be told apart from its zero value.
+	return nil
+}
+
+// PetUpdatesTopic is the topic the Pet events received by IngestPet are published to.
+var PetUpdatesTopic = pubsub.NewTopic[*Pet]("pet-updates", pubsub.TopicConfig{DeliveryGuarantee: pubsub.AtLeastOnce})
+
+// If the service already declares its secrets, move this secret there.
+var secrets struct {
+	// PetIngestSecret is the secret shared with the systems sending Pet events.
+	PetIngestSecret string
+}
+
+// IngestPet receives Pet events from external systems and publishes them to PetUpdatesTopic.
+//
+// Requests must be signed with the PetIngestSecret secret: the X-Signature header must hold
+// the hex-encoded HMAC-SHA256 of the request body, optionally prefixed by "sha256=".
+//
+//encore:api public raw method=POST path=/ingest/pet-updates
+func IngestPet(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, 1<<20))
+	if err != nil {
+		errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Msg("could not read request body").Err())
+		return
+	}
+
+	mac := hmac.New(sha256.New, []byte(secrets.PetIngestSecret))
+	mac.Write(body)
+	signature, err := hex.DecodeString(strings.TrimPrefix(req.Header.Get("X-Signature"), "sha256="))
+	if err != nil || secrets.PetIngestSecret == "" || !hmac.Equal(signature, mac.Sum(nil)) {
+		errs.HTTPError(w, errs.B().Code(errs.Unauthenticated).Msg("invalid signature").Err())
+		return
+	}
+
+	var event Pet
+	if err := json.Unmarshal(body, &event); err != nil {
+		errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Msg("invalid event").Err())
+		return
+	}
+	if err := event.Validate(); err != nil {
+		errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Msg(err.Error()).Err())
+		return
+	}
+
+	id, err := PetUpdatesTopic.Publish(req.Context(), &event)
+	if err != nil {
+		errs.HTTPError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"id": id})
+}
@@ -0,0 +1,137 @@
+// Code generated by encore gen ingest from a JSON Schema.
+import { api, APIError } from "encore.dev/api";
+import { Attribute, Topic } from "encore.dev/pubsub";
+
+/**
+ * A sensor reading sent by a device.
+ */
+export interface Reading {
+  battery?: number;
+
+  /**
+   * The ID of the device.
+   */
+  device_id: Attribute<string>;
+  firmware?: string | null;
+  kind: Attribute<"temperature" | "humidity">;
+  location?: ReadingLocation;
+  recorded_at: string;
+  tags?: string[];
+  value: number;
+}
+
+export interface ReadingLocation {
+  lat: number;
+  lng: number;
+}
+
+/**
+ * readingTopic is the topic the Reading events received by ingestReading are published to.
+ */
+export const readingTopic = new Topic<Reading>("reading", {
+  deliveryGuarantee: "at-least-once",
+});
+
+export interface IngestReadingResponse {
+  /**
+   * The ID of the published message.
+   */
+  id: string;
+}
+
+/**
+ * ingestReading receives Reading events from external systems and publishes them to readingTopic.
+ * Requests are authenticated by the app's auth handler.
+ */
+export const ingestReading = api(
+  { expose: true, auth: true, method: "POST", path: "/ingest/reading" },
+  async (event: Reading): Promise<IngestReadingResponse> => {
+    const invalid = validateReading(event);
+    if (invalid) {
+      throw APIError.invalidArgument(invalid);
+    }
+    const id = await readingTopic.publish(event);
+    return { id };
+  },
+);
+
+/**
+ * validateReading reports why the event does not match its schema, if it doesn't.
+ */
+function validateReading(e: Reading): string | undefined {
+  if (typeof e !== "object" || e === null || Array.isArray(e)) {
+    return "the event must be a JSON object";
+  }
+  if (e.battery !== undefined && e.battery !== null) {
+    if (!Number.isInteger(e.battery)) {
+      return "field \"battery\" must be an integer";
+    }
+    if (e.battery < 0) {
+      return "field \"battery\" must be at least 0";
+    }
+    if (e.battery > 100) {
+      return "field \"battery\" must be at most 100";
+    }
+  }
+  if (e.device_id === undefined || e.device_id === null) {
+    return "missing required field \"device_id\"";
+  }
+  if (typeof e.device_id !== "string") {
+    return "field \"device_id\" must be a string";
+  }
+  if ([...e.device_id].length < 3) {
+    return "field \"device_id\" must be at least 3 characters";
+  }
+  if ([...e.device_id].length > 64) {
+    return "field \"device_id\" must be at most 64 characters";
+  }
+  if (!new RegExp("^[a-z0-9-]+$").test(e.device_id)) {
+    return "field \"device_id\" must match the pattern ^[a-z0-9-]+$";
+  }
+  if (e.firmware !== undefined && e.firmware !== null) {
+    if (typeof e.firmware !== "string") {
+      return "field \"firmware\" must be a string";
+    }
+  }
+  if (e.kind === undefined || e.kind === null) {
+    return "missing required field \"kind\"";
+  }
+  if (typeof e.kind !== "string") {
+    return "field \"kind\" must be a string";
+  }
+  if (!["temperature", "humidity"].includes(e.kind)) {
+    return "field \"kind\" must be one of \"temperature\", \"humidity\"";
+  }
+  if (e.location !== undefined && e.location !== null) {
+    if (typeof e.location !== "object" || Array.isArray(e.location)) {
+      return "field \"location\" must be an object";
+    }
+  }
+  if (e.recorded_at === undefined || e.recorded_at === null) {
+    return "missing required field \"recorded_at\"";
+  }
+  if (typeof e.recorded_at !== "string" || isNaN(Date.parse(e.recorded_at))) {
+    return "field \"recorded_at\" must be a date-time";
+  }
+  if (e.tags !== undefined && e.tags !== null) {
+    if (!Array.isArray(e.tags)) {
+      return "field \"tags\" must be an array";
+    }
+    if (e.tags.length > 10) {
+      return "field \"tags\" must have at most 10 items";
+    }
+  }
+  if (e.value === undefined || e.value === null) {
+    return "missing required field \"value\"";
+  }
+  if (typeof e.value !== "number") {
+    return "field \"value\" must be a number";
+  }
+  if (e.value < -100) {
+    return "field \"value\" must be at least -100";
+  }
+  if (e.value >= 1000) {
+    return "field \"value\" must be less than 1000";
+  }
+  return undefined;
+}
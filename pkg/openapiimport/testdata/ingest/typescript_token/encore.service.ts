@@ -0,0 +1,4 @@
+// Code generated by encore gen ingest from a JSON Schema.
+import { Service } from "encore.dev/service";
+
+export default new Service("telemetry");
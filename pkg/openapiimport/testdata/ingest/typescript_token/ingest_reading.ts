@@ -0,0 +1,189 @@
+// Code generated by encore gen ingest from a JSON Schema.
+import { api } from "encore.dev/api";
+import { secret } from "encore.dev/config";
+import { Attribute, Topic } from "encore.dev/pubsub";
+import { timingSafeEqual } from "node:crypto";
+import type { IncomingMessage, ServerResponse } from "node:http";
+
+/**
+ * A sensor reading sent by a device.
+ */
+export interface Reading {
+  battery?: number;
+
+  /**
+   * The ID of the device.
+   */
+  device_id: Attribute<string>;
+  firmware?: string | null;
+  kind: Attribute<"temperature" | "humidity">;
+  location?: ReadingLocation;
+  recorded_at: string;
+  tags?: string[];
+  value: number;
+}
+
+export interface ReadingLocation {
+  lat: number;
+  lng: number;
+}
+
+/**
+ * readingTopic is the topic the Reading events received by ingestReading are published to.
+ */
+export const readingTopic = new Topic<Reading>("reading", {
+  deliveryGuarantee: "at-least-once",
+});
+
+/**
+ * ReadingIngestToken is the secret shared with the systems sending Reading events.
+ */
+const readingIngestToken = secret("ReadingIngestToken");
+
+/**
+ * ingestReading receives Reading events from external systems and publishes them to readingTopic.
+ *
+ * Requests must have an Authorization header holding the ReadingIngestToken secret as a bearer token.
+ */
+export const ingestReading = api.raw(
+  { expose: true, method: "POST", path: "/ingest/reading" },
+  async (req, resp) => {
+    const body = await readBody(req, 1 << 20);
+    if (body === undefined) {
+      return reply(resp, 413, { code: "invalid_argument", message: "request body too large" });
+    }
+
+    const auth = String(req.headers["authorization"] ?? "");
+    if (!readingIngestToken() || !auth.startsWith("Bearer ") || !safeEqual(auth.slice("Bearer ".length), readingIngestToken())) {
+      return reply(resp, 401, { code: "unauthenticated", message: "invalid token" });
+    }
+
+    let event: Reading;
+    try {
+      event = JSON.parse(body.toString("utf8"));
+    } catch {
+      return reply(resp, 400, { code: "invalid_argument", message: "invalid event" });
+    }
+    const invalid = validateReading(event);
+    if (invalid) {
+      return reply(resp, 400, { code: "invalid_argument", message: invalid });
+    }
+
+    const id = await readingTopic.publish(event);
+    reply(resp, 202, { id });
+  },
+);
+
+/**
+ * validateReading reports why the event does not match its schema, if it doesn't.
+ */
+function validateReading(e: Reading): string | undefined {
+  if (typeof e !== "object" || e === null || Array.isArray(e)) {
+    return "the event must be a JSON object";
+  }
+  if (e.battery !== undefined && e.battery !== null) {
+    if (!Number.isInteger(e.battery)) {
+      return "field \"battery\" must be an integer";
+    }
+    if (e.battery < 0) {
+      return "field \"battery\" must be at least 0";
+    }
+    if (e.battery > 100) {
+      return "field \"battery\" must be at most 100";
+    }
+  }
+  if (e.device_id === undefined || e.device_id === null) {
+    return "missing required field \"device_id\"";
+  }
+  if (typeof e.device_id !== "string") {
+    return "field \"device_id\" must be a string";
+  }
+  if ([...e.device_id].length < 3) {
+    return "field \"device_id\" must be at least 3 characters";
+  }
+  if ([...e.device_id].length > 64) {
+    return "field \"device_id\" must be at most 64 characters";
+  }
+  if (!new RegExp("^[a-z0-9-]+$").test(e.device_id)) {
+    return "field \"device_id\" must match the pattern ^[a-z0-9-]+$";
+  }
+  if (e.firmware !== undefined && e.firmware !== null) {
+    if (typeof e.firmware !== "string") {
+      return "field \"firmware\" must be a string";
+    }
+  }
+  if (e.kind === undefined || e.kind === null) {
+    return "missing required field \"kind\"";
+  }
+  if (typeof e.kind !== "string") {
+    return "field \"kind\" must be a string";
+  }
+  if (!["temperature", "humidity"].includes(e.kind)) {
+    return "field \"kind\" must be one of \"temperature\", \"humidity\"";
+  }
+  if (e.location !== undefined && e.location !== null) {
+    if (typeof e.location !== "object" || Array.isArray(e.location)) {
+      return "field \"location\" must be an object";
+    }
+  }
+  if (e.recorded_at === undefined || e.recorded_at === null) {
+    return "missing required field \"recorded_at\"";
+  }
+  if (typeof e.recorded_at !== "string" || isNaN(Date.parse(e.recorded_at))) {
+    return "field \"recorded_at\" must be a date-time";
+  }
+  if (e.tags !== undefined && e.tags !== null) {
+    if (!Array.isArray(e.tags)) {
+      return "field \"tags\" must be an array";
+    }
+    if (e.tags.length > 10) {
+      return "field \"tags\" must have at most 10 items";
+    }
+  }
+  if (e.value === undefined || e.value === null) {
+    return "missing required field \"value\"";
+  }
+  if (typeof e.value !== "number") {
+    return "field \"value\" must be a number";
+  }
+  if (e.value < -100) {
+    return "field \"value\" must be at least -100";
+  }
+  if (e.value >= 1000) {
+    return "field \"value\" must be less than 1000";
+  }
+  return undefined;
+}
+
+/**
+ * readBody reads the request body, or returns undefined if it's larger than limit bytes.
+ */
+async function readBody(req: IncomingMessage, limit: number): Promise<Buffer | undefined> {
+  const chunks: Buffer[] = [];
+  let size = 0;
+  for await (const chunk of req) {
+    size += chunk.length;
+    if (size > limit) {
+      return undefined;
+    }
+    chunks.push(chunk);
+  }
+  return Buffer.concat(chunks);
+}
+
+/**
+ * safeEqual reports whether a and b are equal, in constant time.
+ */
+function safeEqual(a: string, b: string): boolean {
+  const x = Buffer.from(a);
+  const y = Buffer.from(b);
+  return x.length === y.length && timingSafeEqual(x, y);
+}
+
+/**
+ * reply writes a JSON response.
+ */
+function reply(resp: ServerResponse, status: number, body: unknown) {
+  resp.writeHead(status, { "Content-Type": "application/json" });
+  resp.end(JSON.stringify(body));
+}
@@ -0,0 +1,5 @@
+// Code generated by encore gen service from an OpenAPI specification.
+import { Service } from "encore.dev/service";
+
+// The petstore service implements the Petstore API.
+export default new Service("petstore");
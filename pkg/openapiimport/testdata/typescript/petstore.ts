@@ -0,0 +1,113 @@
+// Code generated by encore gen service from an OpenAPI specification.
+// This file is meant to be edited: implement the endpoints and remove the TODOs.
+import { api, APIError, Header, Query } from "encore.dev/api";
+
+export interface ListPetsRequest {
+  /**
+   * The maximum number of pets to return.
+   */
+  limit?: Query<number, "limit">;
+  xRequestID?: Header<"X-Request-ID">;
+}
+
+export interface ListPetsResponse {
+  next_cursor?: string | null;
+  pets: Pet[];
+}
+
+export interface NewPet {
+  /**
+   * The name of the pet.
+   */
+  name: string;
+  owner?: NewPetOwner;
+  status?: Status;
+  tag?: string;
+}
+
+export interface NewPetOwner {
+  email?: string;
+}
+
+/**
+ * A pet in the store.
+ */
+export interface Pet {
+  /**
+   * The name of the pet.
+   */
+  name: string;
+  owner?: NewPetOwner;
+  status?: Status;
+  tag?: string;
+  attributes?: Record<string, string>;
+  created_at: Date;
+  id: number;
+}
+
+export type Status = "available" | "pending" | "sold";
+
+export interface UploadPhotoResponse {
+  data: string[];
+}
+
+/**
+ * Lists the pets in the store.
+ */
+export const listPets = api(
+  { expose: true, method: "GET", path: "/pets" },
+  async (req: ListPetsRequest): Promise<ListPetsResponse> => {
+    // TODO: implement
+    throw APIError.unimplemented("not implemented");
+  },
+);
+
+/**
+ * Adds a pet to the store.
+ *
+ * TODO: the endpoint responds with status 201.
+ */
+export const createPet = api(
+  { expose: true, auth: true, method: "POST", path: "/pets" },
+  async (req: NewPet): Promise<Pet> => {
+    // TODO: implement
+    throw APIError.unimplemented("not implemented");
+  },
+);
+
+/**
+ * @deprecated This endpoint is deprecated.
+ *
+ * TODO: the endpoint responds with status 204.
+ */
+export const deletePet = api(
+  { expose: true, auth: true, method: "DELETE", path: "/pets/:petId" },
+  async (req: { petId: number }): Promise<void> => {
+    // TODO: implement
+    throw APIError.unimplemented("not implemented");
+  },
+);
+
+/**
+ * Returns a pet.
+ */
+export const getPetsByPetId = api(
+  { expose: true, auth: true, method: "GET", path: "/pets/:petId" },
+  async (req: { petId: number }): Promise<Pet> => {
+    // TODO: implement
+    throw APIError.unimplemented("not implemented");
+  },
+);
+
+/**
+ * TODO: the request body has content type image/png, which is not supported; use a raw endpoint to handle it.
+ *
+ * TODO: the response body is not an object, so it is wrapped in a "data" field.
+ */
+export const uploadPhoto = api(
+  { expose: true, auth: true, method: "PUT", path: "/pets/:petId/photo" },
+  async (req: { petId: number }): Promise<UploadPhotoResponse> => {
+    // TODO: implement
+    throw APIError.unimplemented("not implemented");
+  },
+);
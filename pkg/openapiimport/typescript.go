@@ -0,0 +1,533 @@
+package openapiimport
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"encr.dev/pkg/idents"
+)
+
+func generateTS(svc *service) []File {
+	var svcFile strings.Builder
+	svcFile.WriteString(tsHeader)
+	svcFile.WriteString("import { Service } from \"encore.dev/service\";\n\n")
+	fmt.Fprintf(&svcFile, "// The %s service implements the %s.\n", svc.name, apiDescription(svc))
+	fmt.Fprintf(&svcFile, "export default new Service(%q);\n", svc.name)
+
+	var api strings.Builder
+	api.WriteString(tsHeader)
+	api.WriteString("// This file is meant to be edited: implement the endpoints and remove the TODOs.\n")
+
+	var imports []string
+	if len(svc.endpoints) > 0 {
+		imports = append(imports, "api", "APIError")
+	}
+	if usesLocation(svc, locHeader) {
+		imports = append(imports, "Header")
+	}
+	if usesLocation(svc, locQuery) {
+		imports = append(imports, "Query")
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(&api, "import { %s } from \"encore.dev/api\";\n", strings.Join(imports, ", "))
+	}
+
+	for _, nt := range svc.types {
+		api.WriteByte('\n')
+		tsDoc(&api, "", nt.doc)
+		if nt.typ.kind == kindStruct {
+			fmt.Fprintf(&api, "export interface %s ", nt.name)
+		} else {
+			fmt.Fprintf(&api, "export type %s = ", nt.name)
+		}
+		api.WriteString(tsType(nt.typ, ""))
+		if nt.typ.kind != kindStruct {
+			api.WriteByte(';')
+		}
+		api.WriteByte('\n')
+	}
+
+	for _, ep := range svc.endpoints {
+		api.WriteByte('\n')
+		tsEndpoint(&api, ep)
+	}
+
+	return []File{
+		{Name: "encore.service.ts", Content: []byte(svcFile.String())},
+		{Name: svc.name + ".ts", Content: []byte(api.String())},
+	}
+}
+
+const tsHeader = "// Code generated by encore gen service from an OpenAPI specification.\n"
+
+func tsEndpoint(b *strings.Builder, ep *endpoint) {
+	doc := ep.doc
+	for _, todo := range ep.todos {
+		doc = joinDoc(doc, "TODO: "+todo+".")
+	}
+	tsDoc(b, "", tsDeprecation(doc))
+
+	opts := []string{"expose: true"}
+	if ep.auth {
+		opts = append(opts, "auth: true")
+	}
+	opts = append(opts, fmt.Sprintf("method: %q", ep.method), fmt.Sprintf("path: %q", ep.path))
+
+	// Path parameters are part of the request type in TypeScript.
+	var param string
+	switch {
+	case len(ep.pathParams) > 0:
+		var fields []string
+		for _, p := range ep.pathParams {
+			fields = append(fields, fmt.Sprintf("%s: %s", p.name, tsType(p.typ, "")))
+		}
+		param = "req: { " + strings.Join(fields, "; ") + " }"
+		if ep.request != nil {
+			param += " & " + ep.request.name
+		}
+	case ep.request != nil:
+		param = "req: " + ep.request.name
+	}
+
+	resp := "void"
+	if ep.response != nil {
+		resp = ep.response.name
+	}
+
+	fmt.Fprintf(b, "export const %s = api(\n", idents.Convert(ep.name, idents.CamelCase))
+	fmt.Fprintf(b, "  { %s },\n", strings.Join(opts, ", "))
+	fmt.Fprintf(b, "  async (%s): Promise<%s> => {\n", param, resp)
+	b.WriteString("    // TODO: implement\n")
+	b.WriteString("    throw APIError.unimplemented(\"not implemented\");\n")
+	b.WriteString("  },\n")
+	b.WriteString(");\n")
+}
+
+func tsType(t *typ, indent string) string {
+	switch t.kind {
+	case kindNamed:
+		return t.name
+	case kindList:
+		elem := tsType(t.elem, indent)
+		if t.elem.kind == kindUnion {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case kindMap:
+		return "Record<string, " + tsType(t.elem, indent) + ">"
+	case kindUnion:
+		var variants []string
+		for _, v := range t.variants {
+			variants = append(variants, tsType(v, indent))
+		}
+		return strings.Join(variants, " | ")
+	case kindStruct:
+		return tsStruct(t.fields, indent)
+	}
+
+	switch t.builtin {
+	case builtinString, builtinUUID, builtinBytes:
+		if len(t.enum) > 0 {
+			var values []string
+			for _, v := range t.enum {
+				values = append(values, strconv.Quote(v))
+			}
+			return strings.Join(values, " | ")
+		}
+		return "string"
+	case builtinBool:
+		return "boolean"
+	case builtinInt, builtinInt32, builtinInt64, builtinFloat32, builtinFloat64:
+		return "number"
+	case builtinTime:
+		return "Date"
+	default:
+		return "any"
+	}
+}
+
+func tsStruct(fields []*field, indent string) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	inner := indent + "  "
+	for i, f := range fields {
+		if f.doc != "" && i > 0 {
+			b.WriteByte('\n')
+		}
+		tsDoc(&b, inner, tsDeprecation(f.doc))
+
+		name := f.wireName
+		if f.loc == locBody && !isIdent(name) {
+			name = strconv.Quote(name)
+		} else if f.loc != locBody {
+			name = f.name
+		}
+		b.WriteString(inner)
+		b.WriteString(name)
+		if f.optional {
+			b.WriteByte('?')
+		}
+		b.WriteString(": ")
+
+		typ := tsType(f.typ, inner)
+		switch f.loc {
+		case locHeader:
+			typ = fmt.Sprintf("Header<%q>", f.wireName)
+			if f.typ.kind != kindBuiltin || f.typ.builtin != builtinString || len(f.typ.enum) > 0 {
+				typ = fmt.Sprintf("Header<%s, %q>", tsType(f.typ, inner), f.wireName)
+			}
+		case locQuery:
+			typ = fmt.Sprintf("Query<%q>", f.wireName)
+			if f.typ.kind != kindBuiltin || f.typ.builtin != builtinString || len(f.typ.enum) > 0 {
+				typ = fmt.Sprintf("Query<%s, %q>", tsType(f.typ, inner), f.wireName)
+			}
+		}
+		if f.attr != "" {
+			typ = "Attribute<" + typ + ">"
+		}
+		if f.nullable {
+			typ += " | null"
+		}
+		b.WriteString(typ)
+		b.WriteString(";\n")
+	}
+	b.WriteString(indent)
+	b.WriteByte('}')
+	return b.String()
+}
+
+// tsDeprecation converts a Go style deprecation paragraph to a JSDoc @deprecated tag.
+func tsDeprecation(doc string) string {
+	paragraphs := strings.Split(doc, "\n\n")
+	for i, p := range paragraphs {
+		if rest, ok := strings.CutPrefix(p, "Deprecated: "); ok {
+			paragraphs[i] = "@deprecated " + rest
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+func tsDoc(b *strings.Builder, indent, doc string) {
+	if doc == "" {
+		return
+	}
+	b.WriteString(indent + "/**\n")
+	for _, line := range strings.Split(doc, "\n") {
+		b.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+	}
+	b.WriteString(indent + " */\n")
+}
+
+// usesLocation reports whether any request type of the service has a field in the given location.
+func usesLocation(svc *service, loc paramLocation) bool {
+	for _, nt := range svc.types {
+		if nt.typ.kind != kindStruct {
+			continue
+		}
+		for _, f := range nt.typ.fields {
+			if f.loc == loc {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+const tsIngestHeader = "// Code generated by encore gen ingest from a JSON Schema.\n"
+
+func generateIngestTS(in *ingest) []File {
+	var b strings.Builder
+	b.WriteString(tsIngestHeader)
+	if slices.ContainsFunc(in.checks, func(ch *fieldCheck) bool { return ch.todo != "" }) {
+		b.WriteString("// This file is meant to be edited: complete the TODOs.\n")
+	}
+
+	raw := in.opts.Auth != IngestAuthHandler
+	if raw {
+		b.WriteString("import { api } from \"encore.dev/api\";\n")
+		b.WriteString("import { secret } from \"encore.dev/config\";\n")
+	} else {
+		b.WriteString("import { api, APIError } from \"encore.dev/api\";\n")
+	}
+	if slices.ContainsFunc(in.event.typ.fields, func(f *field) bool { return f.attr != "" }) {
+		b.WriteString("import { Attribute, Topic } from \"encore.dev/pubsub\";\n")
+	} else {
+		b.WriteString("import { Topic } from \"encore.dev/pubsub\";\n")
+	}
+	switch in.opts.Auth {
+	case IngestHMAC:
+		b.WriteString("import { createHmac, timingSafeEqual } from \"node:crypto\";\n")
+	case IngestToken:
+		b.WriteString("import { timingSafeEqual } from \"node:crypto\";\n")
+	}
+	if raw {
+		b.WriteString("import type { IncomingMessage, ServerResponse } from \"node:http\";\n")
+	}
+
+	for _, nt := range append([]*namedType{in.event}, in.types...) {
+		b.WriteByte('\n')
+		tsDoc(&b, "", nt.doc)
+		if nt.typ.kind == kindStruct {
+			fmt.Fprintf(&b, "export interface %s %s\n", nt.name, tsType(nt.typ, ""))
+		} else {
+			fmt.Fprintf(&b, "export type %s = %s;\n", nt.name, tsType(nt.typ, ""))
+		}
+	}
+
+	topic := idents.Convert(in.topicVar, idents.CamelCase)
+	endpoint := idents.Convert(in.endpoint, idents.CamelCase)
+	validate := "validate" + in.event.name
+
+	b.WriteByte('\n')
+	tsDoc(&b, "", fmt.Sprintf("%s is the topic the %s events received by %s are published to.", topic, in.event.name, endpoint))
+	fmt.Fprintf(&b, "export const %s = new Topic<%s>(%q, {\n", topic, in.event.name, in.opts.Topic)
+	b.WriteString("  deliveryGuarantee: \"at-least-once\",\n")
+	b.WriteString("});\n")
+
+	if raw {
+		secretVar := idents.Convert(in.secret, idents.CamelCase)
+		b.WriteByte('\n')
+		tsDoc(&b, "", fmt.Sprintf("%s is the secret shared with the systems sending %s events.", in.secret, in.event.name))
+		fmt.Fprintf(&b, "const %s = secret(%q);\n", secretVar, in.secret)
+
+		doc := fmt.Sprintf("%s receives %s events from external systems and publishes them to %s.", endpoint, in.event.name, topic)
+		var check string
+		switch in.opts.Auth {
+		case IngestHMAC:
+			doc = joinDoc(doc, fmt.Sprintf("Requests must be signed with the %s secret: the X-Signature header must hold\n"+
+				"the hex-encoded HMAC-SHA256 of the request body, optionally prefixed by \"sha256=\".", in.secret))
+			check = fmt.Sprintf(`    const signature = String(req.headers["x-signature"] ?? "").replace(/^sha256=/, "").toLowerCase();
+    const expected = createHmac("sha256", %[1]s()).update(body).digest("hex");
+    if (!%[1]s() || !safeEqual(signature, expected)) {
+      return reply(resp, 401, { code: "unauthenticated", message: "invalid signature" });
+    }
+`, secretVar)
+		case IngestToken:
+			doc = joinDoc(doc, fmt.Sprintf("Requests must have an Authorization header holding the %s secret as a bearer token.", in.secret))
+			check = fmt.Sprintf(`    const auth = String(req.headers["authorization"] ?? "");
+    if (!%[1]s() || !auth.startsWith("Bearer ") || !safeEqual(auth.slice("Bearer ".length), %[1]s())) {
+      return reply(resp, 401, { code: "unauthenticated", message: "invalid token" });
+    }
+`, secretVar)
+		}
+
+		b.WriteByte('\n')
+		tsDoc(&b, "", doc)
+		fmt.Fprintf(&b, "export const %s = api.raw(\n", endpoint)
+		fmt.Fprintf(&b, "  { expose: true, method: \"POST\", path: %q },\n", in.opts.Path)
+		b.WriteString("  async (req, resp) => {\n")
+		b.WriteString("    const body = await readBody(req, 1 << 20);\n")
+		b.WriteString("    if (body === undefined) {\n")
+		b.WriteString("      return reply(resp, 413, { code: \"invalid_argument\", message: \"request body too large\" });\n")
+		b.WriteString("    }\n\n")
+		b.WriteString(check)
+		fmt.Fprintf(&b, "\n    let event: %s;\n", in.event.name)
+		b.WriteString("    try {\n")
+		b.WriteString("      event = JSON.parse(body.toString(\"utf8\"));\n")
+		b.WriteString("    } catch {\n")
+		b.WriteString("      return reply(resp, 400, { code: \"invalid_argument\", message: \"invalid event\" });\n")
+		b.WriteString("    }\n")
+		fmt.Fprintf(&b, "    const invalid = %s(event);\n", validate)
+		b.WriteString("    if (invalid) {\n")
+		b.WriteString("      return reply(resp, 400, { code: \"invalid_argument\", message: invalid });\n")
+		b.WriteString("    }\n\n")
+		fmt.Fprintf(&b, "    const id = await %s.publish(event);\n", topic)
+		b.WriteString("    reply(resp, 202, { id });\n")
+		b.WriteString("  },\n")
+		b.WriteString(");\n")
+	} else {
+		resp := in.endpoint + "Response"
+		b.WriteByte('\n')
+		fmt.Fprintf(&b, "export interface %s {\n", resp)
+		b.WriteString("  /**\n   * The ID of the published message.\n   */\n")
+		b.WriteString("  id: string;\n")
+		b.WriteString("}\n\n")
+		tsDoc(&b, "", fmt.Sprintf("%s receives %s events from external systems and publishes them to %s.\n"+
+			"Requests are authenticated by the app's auth handler.", endpoint, in.event.name, topic))
+		fmt.Fprintf(&b, "export const %s = api(\n", endpoint)
+		fmt.Fprintf(&b, "  { expose: true, auth: true, method: \"POST\", path: %q },\n", in.opts.Path)
+		fmt.Fprintf(&b, "  async (event: %s): Promise<%s> => {\n", in.event.name, resp)
+		fmt.Fprintf(&b, "    const invalid = %s(event);\n", validate)
+		b.WriteString("    if (invalid) {\n")
+		b.WriteString("      throw APIError.invalidArgument(invalid);\n")
+		b.WriteString("    }\n")
+		fmt.Fprintf(&b, "    const id = await %s.publish(event);\n", topic)
+		b.WriteString("    return { id };\n")
+		b.WriteString("  },\n")
+		b.WriteString(");\n")
+	}
+
+	b.WriteByte('\n')
+	tsValidate(&b, in, validate)
+
+	if raw {
+		b.WriteString(tsIngestHelpers)
+	}
+
+	name := "ingest_" + idents.Convert(in.event.name, idents.SnakeCase) + ".ts"
+	return []File{
+		{Name: "encore.service.ts", Content: []byte(tsIngestHeader + "import { Service } from \"encore.dev/service\";\n\n" +
+			fmt.Sprintf("export default new Service(%q);\n", in.opts.Service))},
+		{Name: name, Content: []byte(b.String())},
+	}
+}
+
+// tsValidate generates a function reporting why an event does not match its schema,
+// as events are not validated when they're received by a raw endpoint.
+func tsValidate(b *strings.Builder, in *ingest, name string) {
+	tsDoc(b, "", fmt.Sprintf("%s reports why the event does not match its schema, if it doesn't.", name))
+	fmt.Fprintf(b, "function %s(e: %s): string | undefined {\n", name, in.event.name)
+	b.WriteString("  if (typeof e !== \"object\" || e === null || Array.isArray(e)) {\n")
+	b.WriteString("    return \"the event must be a JSON object\";\n")
+	b.WriteString("  }\n")
+
+	for _, ch := range in.checks {
+		f := ch.field
+		v := "e." + f.wireName
+		if !isIdent(f.wireName) {
+			v = "e[" + strconv.Quote(f.wireName) + "]"
+		}
+		ret := func(format string, args ...any) string {
+			return "return " + strconv.Quote(fmt.Sprintf(format, args...)) + ";"
+		}
+
+		if ch.todo != "" {
+			fmt.Fprintf(b, "  // TODO: %s.\n", ch.todo)
+		}
+		if ch.required {
+			cond := v + " === undefined"
+			if !f.nullable {
+				cond += " || " + v + " === null"
+			}
+			fmt.Fprintf(b, "  if (%s) {\n    %s\n  }\n", cond, ret("missing required field %q", f.wireName))
+		}
+
+		var checks []string
+		check := func(cond, ret string) {
+			checks = append(checks, fmt.Sprintf("if (%s) {\n  %s\n}", cond, ret))
+		}
+
+		isString := false
+		switch ch.kind.kind {
+		case kindList:
+			check("!Array.isArray("+v+")", ret("field %q must be an array", f.wireName))
+		case kindMap, kindStruct:
+			check("typeof "+v+" !== \"object\" || Array.isArray("+v+")", ret("field %q must be an object", f.wireName))
+		case kindBuiltin:
+			switch ch.kind.builtin {
+			case builtinString, builtinUUID, builtinBytes:
+				isString = ch.kind.builtin == builtinString
+				check("typeof "+v+" !== \"string\"", ret("field %q must be a string", f.wireName))
+			case builtinTime:
+				check("typeof "+v+" !== \"string\" || isNaN(Date.parse("+v+"))", ret("field %q must be a date-time", f.wireName))
+			case builtinBool:
+				check("typeof "+v+" !== \"boolean\"", ret("field %q must be a boolean", f.wireName))
+			case builtinInt, builtinInt32, builtinInt64:
+				check("!Number.isInteger("+v+")", ret("field %q must be an integer", f.wireName))
+			case builtinFloat32, builtinFloat64:
+				check("typeof "+v+" !== \"number\"", ret("field %q must be a number", f.wireName))
+			}
+		}
+
+		if enum := ch.enum(); len(enum) > 0 {
+			var values []string
+			for _, e := range enum {
+				values = append(values, strconv.Quote(e))
+			}
+			check(fmt.Sprintf("![%s].includes(%s)", strings.Join(values, ", "), v), "return "+strconv.Quote(ch.enumMessage())+";")
+		}
+		length, numeric, items := ch.hasBounds()
+		if isString && length {
+			if n := ch.schema.MinLength; n > 0 {
+				check(fmt.Sprintf("[...%s].length < %d", v, n), ret("field %q must be at least %d characters", f.wireName, n))
+			}
+			if n := ch.schema.MaxLength; n != nil {
+				check(fmt.Sprintf("[...%s].length > %d", v, *n), ret("field %q must be at most %d characters", f.wireName, *n))
+			}
+		}
+		if isString && ch.pattern != "" {
+			check(fmt.Sprintf("!new RegExp(%s).test(%s)", strconv.Quote(ch.pattern), v), ret("field %q must match the pattern %s", f.wireName, ch.pattern))
+		}
+		if numeric && ch.kind.kind == kindBuiltin {
+			minMsg, maxMsg := ch.boundMessages()
+			if s := ch.schema; s.Min != nil {
+				op := "<"
+				if s.ExclusiveMin {
+					op = "<="
+				}
+				check(fmt.Sprintf("%s %s %s", v, op, formatNumber(*s.Min)), "return "+strconv.Quote(minMsg)+";")
+			}
+			if s := ch.schema; s.Max != nil {
+				op := ">"
+				if s.ExclusiveMax {
+					op = ">="
+				}
+				check(fmt.Sprintf("%s %s %s", v, op, formatNumber(*s.Max)), "return "+strconv.Quote(maxMsg)+";")
+			}
+		}
+		if items && ch.kind.kind == kindList {
+			if n := ch.schema.MinItems; n > 0 {
+				check(fmt.Sprintf("%s.length < %d", v, n), ret("field %q must have at least %d items", f.wireName, n))
+			}
+			if n := ch.schema.MaxItems; n != nil {
+				check(fmt.Sprintf("%s.length > %d", v, *n), ret("field %q must have at most %d items", f.wireName, *n))
+			}
+		}
+		if len(checks) == 0 {
+			continue
+		}
+
+		// Fields that may be absent are only checked when present.
+		indent := "  "
+		guarded := !ch.required || f.nullable
+		if guarded {
+			fmt.Fprintf(b, "  if (%s !== undefined && %s !== null) {\n", v, v)
+			indent = "    "
+		}
+		for _, c := range checks {
+			b.WriteString(indent + strings.ReplaceAll(c, "\n", "\n"+indent) + "\n")
+		}
+		if guarded {
+			b.WriteString("  }\n")
+		}
+	}
+	b.WriteString("  return undefined;\n")
+	b.WriteString("}\n")
+}
+
+const tsIngestHelpers = `
+/**
+ * readBody reads the request body, or returns undefined if it's larger than limit bytes.
+ */
+async function readBody(req: IncomingMessage, limit: number): Promise<Buffer | undefined> {
+  const chunks: Buffer[] = [];
+  let size = 0;
+  for await (const chunk of req) {
+    size += chunk.length;
+    if (size > limit) {
+      return undefined;
+    }
+    chunks.push(chunk);
+  }
+  return Buffer.concat(chunks);
+}
+
+/**
+ * safeEqual reports whether a and b are equal, in constant time.
+ */
+function safeEqual(a: string, b: string): boolean {
+  const x = Buffer.from(a);
+  const y = Buffer.from(b);
+  return x.length === y.length && timingSafeEqual(x, y);
+}
+
+/**
+ * reply writes a JSON response.
+ */
+function reply(resp: ServerResponse, status: number, body: unknown) {
+  resp.writeHead(status, { "Content-Type": "application/json" });
+  resp.end(JSON.stringify(body));
+}
+`
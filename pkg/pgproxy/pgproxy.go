@@ -493,6 +493,24 @@ func FinalizeInitialHandshake(client *pgproto3.Backend, server *pgproto3.Fronten
 
 // CopySteadyState copies messages back and forth after the initial handshake.
 func CopySteadyState(client *pgproto3.Backend, server *pgproto3.Frontend) error {
+	return CopySteadyStateObserved(client, server, nil)
+}
+
+// Observer observes the messages copied by CopySteadyStateObserved.
+//
+// The methods are called from different goroutines, before the message
+// is forwarded. Messages may be reused once the method returns,
+// so implementations must copy any data they retain.
+type Observer interface {
+	// FrontendMessage is called for each message sent by the client.
+	FrontendMessage(msg pgproto3.FrontendMessage)
+	// BackendMessage is called for each message sent by the server.
+	BackendMessage(msg pgproto3.BackendMessage)
+}
+
+// CopySteadyStateObserved is like CopySteadyState but reports
+// the copied messages to obs, if it's non-nil.
+func CopySteadyStateObserved(client *pgproto3.Backend, server *pgproto3.Frontend, obs Observer) error {
 	errChan := make(chan error, 2)
 	msgAck := make(chan struct{})
 	done := make(chan struct{})
@@ -514,6 +532,9 @@ func CopySteadyState(client *pgproto3.Backend, server *pgproto3.Frontend) error
 			} else if err != nil {
 				return err
 			}
+			if obs != nil {
+				obs.BackendMessage(msg)
+			}
 			if err := client.Send(msg); err != nil {
 				return err
 			}
@@ -531,6 +552,9 @@ func CopySteadyState(client *pgproto3.Backend, server *pgproto3.Frontend) error
 			if err != nil {
 				return err
 			}
+			if obs != nil {
+				obs.FrontendMessage(msg)
+			}
 			select {
 			case <-done:
 				return nil
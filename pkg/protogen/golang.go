@@ -0,0 +1,194 @@
+package protogen
+
+import (
+	"bytes"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// generateGo generates a Go file for each .proto file, in a package per protobuf package.
+// importPath is the import path of the output directory.
+func generateGo(files []protoreflect.FileDescriptor, importPath string) (map[string][]byte, error) {
+	g := &goGen{importPath: importPath}
+	result := make(map[string][]byte)
+	for _, fd := range files {
+		f := jen.NewFilePathName(g.pkgPath(fd), goPackageName(fd))
+		f.HeaderComment(generatedPrefix + fd.Path() + ". DO NOT EDIT.")
+
+		for _, e := range enums(fd) {
+			g.enum(f, e)
+		}
+		for _, m := range messages(fd) {
+			g.message(f, m)
+		}
+
+		var buf bytes.Buffer
+		if err := f.Render(&buf); err != nil {
+			return nil, errors.Wrapf(err, "generate %s", fd.Path())
+		}
+		result[path.Join(packageDir(fd), baseName(fd)+".pb.go")] = buf.Bytes()
+	}
+	return result, nil
+}
+
+type goGen struct {
+	importPath string
+}
+
+func (g *goGen) pkgPath(fd protoreflect.FileDescriptor) string {
+	return path.Join(g.importPath, packageDir(fd))
+}
+
+// goPackageName returns the name of the Go package for a file:
+// the last component of its protobuf package.
+func goPackageName(fd protoreflect.FileDescriptor) string {
+	pkg := string(fd.Package())
+	if pkg == "" {
+		return "protobuf"
+	}
+	if i := strings.LastIndexByte(pkg, '.'); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	return strings.ToLower(strings.ReplaceAll(pkg, "_", ""))
+}
+
+func (g *goGen) enum(f *jen.File, e protoreflect.EnumDescriptor) {
+	name := typeName(e)
+	goComment(f, comment(e))
+	f.Type().Id(name).String()
+	f.Line()
+
+	var defs []jen.Code
+	values := e.Values()
+	for i := 0; i < values.Len(); i++ {
+		v := values.Get(i)
+		if c := comment(v); c != "" {
+			for _, line := range strings.Split(c, "\n") {
+				defs = append(defs, jen.Comment(line))
+			}
+		}
+		// Enum values are scoped to the enum's parent, so prefix them like protoc-gen-go does.
+		prefix := name
+		if e.Parent() != e.ParentFile() {
+			prefix = typeName(e.Parent())
+		}
+		defs = append(defs, jen.Id(prefix+"_"+string(v.Name())).Id(name).Op("=").Lit(string(v.Name())))
+	}
+	f.Const().Defs(defs...)
+	f.Line()
+}
+
+func (g *goGen) message(f *jen.File, m protoreflect.MessageDescriptor) {
+	goComment(f, comment(m))
+
+	var defs []jen.Code
+	for i, fld := range fields(m) {
+		if c := comment(fld); c != "" {
+			if i > 0 {
+				defs = append(defs, jen.Line())
+			}
+			for _, line := range strings.Split(c, "\n") {
+				defs = append(defs, jen.Comment(line))
+			}
+		}
+
+		typ := g.fieldType(fld)
+		if hasPresence(fld) && !(fld.Message() != nil && wellKnown(fld.Message()) == wktJSON) {
+			typ = jen.Op("*").Add(typ)
+		}
+
+		tags := map[string]string{"json": fld.JSONName()}
+		if isOptional(fld) {
+			tags["json"] += ",omitempty"
+			tags["encore"] = "optional"
+		}
+		defs = append(defs, jen.Id(goFieldName(fld)).Add(typ).Tag(tags))
+	}
+	f.Type().Id(typeName(m)).Struct(defs...)
+	f.Line()
+}
+
+func (g *goGen) fieldType(fld protoreflect.FieldDescriptor) jen.Code {
+	if fld.IsMap() {
+		return jen.Map(g.singularType(fld.MapKey())).Add(g.singularType(fld.MapValue()))
+	} else if fld.IsList() {
+		return jen.Index().Add(g.singularType(fld))
+	}
+	return g.singularType(fld)
+}
+
+func (g *goGen) singularType(fld protoreflect.FieldDescriptor) jen.Code {
+	switch fld.Kind() {
+	case protoreflect.BoolKind:
+		return jen.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return jen.Int32()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return jen.Uint32()
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return jen.Int64()
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return jen.Uint64()
+	case protoreflect.FloatKind:
+		return jen.Float32()
+	case protoreflect.DoubleKind:
+		return jen.Float64()
+	case protoreflect.StringKind:
+		return jen.String()
+	case protoreflect.BytesKind:
+		return jen.Index().Byte()
+	case protoreflect.EnumKind:
+		return g.ref(fld.Enum())
+	}
+
+	msg := fld.Message()
+	switch wellKnown(msg) {
+	case wktTimestamp:
+		return jen.Qual("time", "Time")
+	case wktString:
+		return jen.String()
+	case wktJSON:
+		return jen.Qual("encoding/json", "RawMessage")
+	case wktEmpty:
+		return jen.Struct()
+	case wktWrapper:
+		return g.singularType(msg.Fields().ByName("value"))
+	}
+	return g.ref(msg)
+}
+
+// ref returns a reference to the type generated for a message or enum.
+func (g *goGen) ref(d protoreflect.Descriptor) jen.Code {
+	return jen.Qual(g.pkgPath(d.ParentFile()), typeName(d))
+}
+
+// goFieldName returns the Go name of a field, like protoc-gen-go.
+func goFieldName(fld protoreflect.FieldDescriptor) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range string(fld.Name()) {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func goComment(f *jen.File, c string) {
+	if c == "" {
+		return
+	}
+	for _, line := range strings.Split(c, "\n") {
+		f.Comment(line)
+	}
+}
@@ -0,0 +1,337 @@
+// Package protogen generates Encore API types from Protocol Buffers definitions.
+//
+// The .proto files are compiled with protoc, and each message and enum becomes
+// a type that can be used in API schemas and Pub/Sub messages. The generated types
+// encode to the same JSON as the Protocol Buffers JSON mapping, so the same
+// definitions can be shared with systems that use protobuf directly.
+package protogen
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/mod/modfile"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"encr.dev/pkg/appfile"
+)
+
+const (
+	defaultImportPath = "proto"
+	defaultOutput     = "protobuf"
+
+	// generatedPrefix is the first line of generated files,
+	// used to find generated files that are no longer needed.
+	generatedPrefix = "// Code generated by encore from "
+)
+
+// Generate generates types for the .proto files configured in the app file
+// of the app at appRoot, in the given language.
+// It does nothing if the app does not configure Protocol Buffers.
+func Generate(ctx context.Context, appRoot string, lang appfile.Lang) error {
+	cfg, err := appfile.ProtobufConfig(appRoot)
+	if err != nil {
+		return err
+	} else if cfg == nil {
+		return nil
+	}
+
+	files, err := compile(ctx, appRoot, cfg)
+	if err != nil {
+		return err
+	}
+
+	output := cfg.Output
+	if output == "" {
+		output = defaultOutput
+	}
+
+	var generated map[string][]byte
+	switch lang {
+	case appfile.LangTS:
+		generated = generateTS(files)
+	default:
+		modPath, err := modulePath(appRoot)
+		if err != nil {
+			return err
+		}
+		generated, err = generateGo(files, path.Join(modPath, filepath.ToSlash(output)))
+		if err != nil {
+			return err
+		}
+	}
+	return writeFiles(filepath.Join(appRoot, output), generated)
+}
+
+// compile compiles the configured .proto files with protoc and returns
+// the files to generate types for, including their imports.
+func compile(ctx context.Context, appRoot string, cfg *appfile.Protobuf) ([]protoreflect.FileDescriptor, error) {
+	importPaths := cfg.ImportPaths
+	if len(importPaths) == 0 {
+		importPaths = []string{defaultImportPath}
+	}
+
+	sources := cfg.Files
+	if len(sources) == 0 {
+		for _, dir := range importPaths {
+			root := filepath.Join(appRoot, dir)
+			err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() || filepath.Ext(p) != ".proto" {
+					return err
+				}
+				rel, err := filepath.Rel(root, p)
+				sources = append(sources, filepath.ToSlash(rel))
+				return err
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "find .proto files in %s", dir)
+			}
+		}
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	tmp, err := os.CreateTemp("", "encore-protoc-*.pb")
+	if err != nil {
+		return nil, err
+	}
+	_ = tmp.Close()
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	protoc := cfg.Protoc
+	if protoc == "" {
+		protoc = "protoc"
+	}
+	args := []string{"--include_imports", "--include_source_info", "--descriptor_set_out=" + tmp.Name()}
+	for _, dir := range importPaths {
+		args = append(args, "--proto_path="+filepath.Join(appRoot, dir))
+	}
+	args = append(args, sources...)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, protoc, args...)
+	cmd.Dir = appRoot
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, errors.Newf("protoc: command not found; install the Protocol Buffers compiler to generate types from .proto files")
+		}
+		return nil, errors.Newf("protoc: %v\n%s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	data, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return nil, err
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, errors.Wrap(err, "parse protoc output")
+	}
+	return resolve(&set)
+}
+
+// resolve resolves the files of the descriptor set, excluding the well-known types
+// which map to builtin types.
+func resolve(set *descriptorpb.FileDescriptorSet) ([]protoreflect.FileDescriptor, error) {
+	reg, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve .proto files")
+	}
+
+	var files []protoreflect.FileDescriptor
+	for _, f := range set.File {
+		if strings.HasPrefix(f.GetName(), "google/protobuf/") {
+			continue
+		}
+		fd, err := reg.FindFileByPath(f.GetName())
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fd)
+	}
+	return files, nil
+}
+
+// modulePath returns the Go module path of the app.
+func modulePath(appRoot string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(appRoot, "go.mod"))
+	if err != nil {
+		return "", errors.Wrap(err, "read go.mod")
+	}
+	if p := modfile.ModulePath(data); p != "" {
+		return p, nil
+	}
+	return "", errors.New("go.mod: missing module path")
+}
+
+// writeFiles writes the generated files to dir, leaving unchanged files as is
+// to avoid triggering rebuilds, and removes previously generated files that are
+// no longer needed.
+func writeFiles(dir string, files map[string][]byte) error {
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if _, ok := files[filepath.ToSlash(rel)]; ok {
+			return nil
+		}
+		if data, err := os.ReadFile(p); err == nil && bytes.HasPrefix(data, []byte(generatedPrefix)) {
+			return os.Remove(p)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "remove stale generated files")
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		dst := filepath.Join(dir, filepath.FromSlash(name))
+		if existing, err := os.ReadFile(dst); err == nil && bytes.Equal(existing, files[name]) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, files[name], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packageDir returns the output directory of the types of the given file,
+// based on its package name.
+func packageDir(fd protoreflect.FileDescriptor) string {
+	return strings.ReplaceAll(string(fd.Package()), ".", "/")
+}
+
+// baseName returns the name of the file without directory and extension.
+func baseName(fd protoreflect.FileDescriptor) string {
+	return strings.TrimSuffix(path.Base(fd.Path()), ".proto")
+}
+
+// typeName returns the name of the generated type for a message or enum.
+// Nested types are named like Outer_Inner, as with protoc-gen-go.
+func typeName(d protoreflect.Descriptor) string {
+	name := strings.TrimPrefix(string(d.FullName()), string(d.ParentFile().Package())+".")
+	return strings.ReplaceAll(name, ".", "_")
+}
+
+// comment returns the leading comment of a descriptor.
+func comment(d protoreflect.Descriptor) string {
+	loc := d.ParentFile().SourceLocations().ByDescriptor(d)
+	lines := strings.Split(strings.TrimSpace(loc.LeadingComments), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// messages returns all messages of a file, including nested ones.
+func messages(fd protoreflect.FileDescriptor) []protoreflect.MessageDescriptor {
+	var msgs []protoreflect.MessageDescriptor
+	var walk func(ms protoreflect.MessageDescriptors)
+	walk = func(ms protoreflect.MessageDescriptors) {
+		for i := 0; i < ms.Len(); i++ {
+			m := ms.Get(i)
+			if m.IsMapEntry() {
+				continue
+			}
+			msgs = append(msgs, m)
+			walk(m.Messages())
+		}
+	}
+	walk(fd.Messages())
+	return msgs
+}
+
+// enums returns all enums of a file, including nested ones.
+func enums(fd protoreflect.FileDescriptor) []protoreflect.EnumDescriptor {
+	var result []protoreflect.EnumDescriptor
+	add := func(es protoreflect.EnumDescriptors) {
+		for i := 0; i < es.Len(); i++ {
+			result = append(result, es.Get(i))
+		}
+	}
+	add(fd.Enums())
+	for _, m := range messages(fd) {
+		add(m.Enums())
+	}
+	return result
+}
+
+// fields returns the fields of a message.
+func fields(m protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	fs := make([]protoreflect.FieldDescriptor, m.Fields().Len())
+	for i := range fs {
+		fs[i] = m.Fields().Get(i)
+	}
+	return fs
+}
+
+// isOptional reports whether the field can be omitted from messages.
+// The JSON mapping omits fields with default values, so only proto2
+// required fields must always be present.
+func isOptional(f protoreflect.FieldDescriptor) bool {
+	return f.Cardinality() != protoreflect.Required
+}
+
+// hasPresence reports whether the generated field should be a pointer,
+// to distinguish unset fields from fields set to the default value.
+func hasPresence(f protoreflect.FieldDescriptor) bool {
+	return !f.IsList() && !f.IsMap() && f.HasPresence()
+}
+
+// wellKnownType describes how a well-known type is represented.
+type wellKnownType int
+
+const (
+	wktNone wellKnownType = iota
+	wktTimestamp
+	wktString // Duration and FieldMask, which are strings in JSON
+	wktJSON   // Struct, Value, ListValue and Any
+	wktEmpty
+	wktWrapper
+)
+
+func wellKnown(m protoreflect.MessageDescriptor) wellKnownType {
+	if m.ParentFile().Package() != "google.protobuf" {
+		return wktNone
+	}
+	switch m.Name() {
+	case "Timestamp":
+		return wktTimestamp
+	case "Duration", "FieldMask":
+		return wktString
+	case "Struct", "Value", "ListValue", "Any":
+		return wktJSON
+	case "Empty":
+		return wktEmpty
+	case "DoubleValue", "FloatValue", "Int64Value", "UInt64Value", "Int32Value", "UInt32Value", "BoolValue", "StringValue", "BytesValue":
+		return wktWrapper
+	}
+	return wktJSON
+}
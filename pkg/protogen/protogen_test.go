@@ -0,0 +1,161 @@
+package protogen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encr.dev/pkg/golden"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func testFiles() []*descriptorpb.FileDescriptorProto {
+	common := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("shop/common/status.proto"),
+		Package: proto.String("shop.common"),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("STATUS_ACTIVE"), Number: proto.Int32(1)},
+			},
+		}},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{5, 0}, Span: []int32{0, 0, 0}, LeadingComments: proto.String(" Status is the status of an order.\n")},
+		}},
+	}
+
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(jsonName(name)),
+			Number:   proto.Int32(num),
+			Type:     typ.Enum(),
+			Label:    label.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	const (
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	)
+
+	orders := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("shop/orders.proto"),
+		Package:    proto.String("shop"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"shop/common/status.proto", "google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("order_id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+				field("status", 2, descriptorpb.FieldDescriptorProto_TYPE_ENUM, optional, ".shop.common.Status"),
+				field("items", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated, ".shop.Order.Item"),
+				field("created_at", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional, ".google.protobuf.Timestamp"),
+				field("labels", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated, ".shop.Order.LabelsEntry"),
+				field("total_cents", 6, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional, ""),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{
+				{
+					Name: proto.String("Item"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("sku", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+						field("quantity", 2, descriptorpb.FieldDescriptorProto_TYPE_UINT32, optional, ""),
+					},
+				},
+				{
+					Name:    proto.String("LabelsEntry"),
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+						field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+					},
+				},
+			},
+		}},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{4, 0}, Span: []int32{0, 0, 0}, LeadingComments: proto.String(" Order is an order in the shop.\n")},
+			{Path: []int32{4, 0, 2, 0}, Span: []int32{0, 0, 0}, LeadingComments: proto.String(" The unique ID of the order.\n")},
+		}},
+	}
+
+	return []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+		common,
+		orders,
+	}
+}
+
+func jsonName(name string) string {
+	var b []byte
+	upper := false
+	for i := 0; i < len(name); i++ {
+		switch {
+		case name[i] == '_':
+			upper = true
+		case upper:
+			b = append(b, name[i]-'a'+'A')
+			upper = false
+		default:
+			b = append(b, name[i])
+		}
+	}
+	return string(b)
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	files, err := resolve(&descriptorpb.FileDescriptorSet{File: testFiles()})
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 2)
+
+	c.Run("go", func(c *qt.C) {
+		generated, err := generateGo(files, "example.com/app/protobuf")
+		c.Assert(err, qt.IsNil)
+		c.Assert(generated, qt.HasLen, 2)
+		for name, data := range generated {
+			golden.TestAgainst(c, "go/"+name, string(data))
+		}
+	})
+
+	c.Run("typescript", func(c *qt.C) {
+		generated := generateTS(files)
+		c.Assert(generated, qt.HasLen, 2)
+		for name, data := range generated {
+			golden.TestAgainst(c, "ts/"+name, string(data))
+		}
+	})
+}
+
+func TestWriteFiles(t *testing.T) {
+	c := qt.New(t)
+	dir := t.TempDir()
+
+	c.Assert(writeFiles(dir, map[string][]byte{
+		"a/one.pb.go": []byte(generatedPrefix + "a/one.proto. DO NOT EDIT.\n"),
+		"a/two.pb.go": []byte(generatedPrefix + "a/two.proto. DO NOT EDIT.\n"),
+	}), qt.IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dir, "a", "user.go"), []byte("package a\n"), 0644), qt.IsNil)
+
+	// Generated files that are no longer needed are removed, other files are kept.
+	c.Assert(writeFiles(dir, map[string][]byte{
+		"a/one.pb.go": []byte(generatedPrefix + "a/one.proto. DO NOT EDIT.\n"),
+	}), qt.IsNil)
+	_, err := os.Stat(filepath.Join(dir, "a", "two.pb.go"))
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+	_, err = os.Stat(filepath.Join(dir, "a", "user.go"))
+	c.Assert(err, qt.IsNil)
+}
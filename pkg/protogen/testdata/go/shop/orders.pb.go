@@ -0,0 +1,24 @@
+// Code generated by encore from shop/orders.proto. DO NOT EDIT.
+
+package shop
+
+import (
+	common "example.com/app/protobuf/shop/common"
+	"time"
+)
+
+// Order is an order in the shop.
+type Order struct {
+	// The unique ID of the order.
+	OrderId    string            `encore:"optional" json:"orderId,omitempty"`
+	Status     common.Status     `encore:"optional" json:"status,omitempty"`
+	Items      []Order_Item      `encore:"optional" json:"items,omitempty"`
+	CreatedAt  *time.Time        `encore:"optional" json:"createdAt,omitempty"`
+	Labels     map[string]string `encore:"optional" json:"labels,omitempty"`
+	TotalCents int64             `encore:"optional" json:"totalCents,omitempty"`
+}
+
+type Order_Item struct {
+	Sku      string `encore:"optional" json:"sku,omitempty"`
+	Quantity uint32 `encore:"optional" json:"quantity,omitempty"`
+}
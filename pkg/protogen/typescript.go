@@ -0,0 +1,167 @@
+package protogen
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// generateTS generates a TypeScript module for each .proto file.
+func generateTS(files []protoreflect.FileDescriptor) map[string][]byte {
+	result := make(map[string][]byte)
+	for _, fd := range files {
+		g := &tsGen{file: fd, imports: make(map[string][]string)}
+
+		var body strings.Builder
+		for _, e := range enums(fd) {
+			body.WriteByte('\n')
+			g.enum(&body, e)
+		}
+		for _, m := range messages(fd) {
+			body.WriteByte('\n')
+			g.message(&body, m)
+		}
+
+		var out strings.Builder
+		out.WriteString(generatedPrefix + fd.Path() + ". DO NOT EDIT.\n")
+		if len(g.imports) > 0 {
+			out.WriteByte('\n')
+		}
+		modules := make([]string, 0, len(g.imports))
+		for mod := range g.imports {
+			modules = append(modules, mod)
+		}
+		slices.Sort(modules)
+		for _, mod := range modules {
+			names := g.imports[mod]
+			slices.Sort(names)
+			fmt.Fprintf(&out, "import { %s } from %q;\n", strings.Join(names, ", "), mod)
+		}
+		out.WriteString(body.String())
+
+		result[tsModulePath(fd)+".ts"] = []byte(out.String())
+	}
+	return result
+}
+
+type tsGen struct {
+	file    protoreflect.FileDescriptor
+	imports map[string][]string // module -> imported names
+}
+
+// tsModulePath returns the path of the module generated for a file,
+// relative to the output directory and without extension.
+func tsModulePath(fd protoreflect.FileDescriptor) string {
+	return path.Join(packageDir(fd), baseName(fd))
+}
+
+func (g *tsGen) enum(b *strings.Builder, e protoreflect.EnumDescriptor) {
+	tsComment(b, "", comment(e))
+	var values []string
+	for i := 0; i < e.Values().Len(); i++ {
+		values = append(values, strconv.Quote(string(e.Values().Get(i).Name())))
+	}
+	fmt.Fprintf(b, "export type %s = %s;\n", typeName(e), strings.Join(values, " | "))
+}
+
+func (g *tsGen) message(b *strings.Builder, m protoreflect.MessageDescriptor) {
+	tsComment(b, "", comment(m))
+	fmt.Fprintf(b, "export interface %s {\n", typeName(m))
+	for i, fld := range fields(m) {
+		c := comment(fld)
+		if c != "" && i > 0 {
+			b.WriteByte('\n')
+		}
+		tsComment(b, "  ", c)
+		b.WriteString("  " + fld.JSONName())
+		if isOptional(fld) {
+			b.WriteByte('?')
+		}
+		fmt.Fprintf(b, ": %s;\n", g.fieldType(fld))
+	}
+	b.WriteString("}\n")
+}
+
+func (g *tsGen) fieldType(fld protoreflect.FieldDescriptor) string {
+	if fld.IsMap() {
+		return fmt.Sprintf("Record<string, %s>", g.singularType(fld.MapValue()))
+	} else if fld.IsList() {
+		return g.singularType(fld) + "[]"
+	}
+	return g.singularType(fld)
+}
+
+func (g *tsGen) singularType(fld protoreflect.FieldDescriptor) string {
+	switch fld.Kind() {
+	case protoreflect.BoolKind:
+		return "boolean"
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return "string"
+	case protoreflect.EnumKind:
+		return g.ref(fld.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := fld.Message()
+		switch wellKnown(msg) {
+		case wktTimestamp:
+			return "Date"
+		case wktString:
+			return "string"
+		case wktJSON:
+			return "any"
+		case wktEmpty:
+			return "Record<string, never>"
+		case wktWrapper:
+			return g.singularType(msg.Fields().ByName("value"))
+		}
+		return g.ref(msg)
+	default:
+		return "number"
+	}
+}
+
+// ref returns a reference to the type generated for a message or enum,
+// importing it if it's defined in another file.
+func (g *tsGen) ref(d protoreflect.Descriptor) string {
+	name := typeName(d)
+	if fd := d.ParentFile(); fd.Path() != g.file.Path() {
+		mod, _ := relPath(path.Dir(tsModulePath(g.file)), tsModulePath(fd))
+		if !slices.Contains(g.imports[mod], name) {
+			g.imports[mod] = append(g.imports[mod], name)
+		}
+	}
+	return name
+}
+
+// relPath returns the relative module path from the directory dir to the module target.
+func relPath(dir, target string) (string, bool) {
+	dirParts := strings.Split(path.Clean(dir), "/")
+	if dir == "." || dir == "" {
+		dirParts = nil
+	}
+	targetParts := strings.Split(target, "/")
+
+	common := 0
+	for common < len(dirParts) && common < len(targetParts)-1 && dirParts[common] == targetParts[common] {
+		common++
+	}
+	rel := strings.Repeat("../", len(dirParts)-common) + strings.Join(targetParts[common:], "/")
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	return rel, true
+}
+
+func tsComment(b *strings.Builder, indent, c string) {
+	if c == "" {
+		return
+	}
+	b.WriteString(indent + "/**\n")
+	for _, line := range strings.Split(c, "\n") {
+		b.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+	}
+	b.WriteString(indent + " */\n")
+}
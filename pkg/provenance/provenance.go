@@ -0,0 +1,124 @@
+// Package provenance describes the inputs a build of an app was produced
+// from, and normalizes the parts of a build that would otherwise vary
+// between builds of the same inputs.
+package provenance
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"encr.dev/pkg/vcs"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// InfraHash returns a hash of the infrastructure an app requires:
+// its services, gateways, databases, Pub/Sub topics and subscriptions,
+// caches, buckets, cron jobs, metrics and secrets.
+//
+// Only the requirements themselves contribute to the hash,
+// so changing documentation or code that uses the infrastructure
+// does not change it.
+func InfraHash(md *meta.Data) string {
+	if md == nil {
+		return ""
+	}
+	var reqs []string
+	add := func(format string, args ...any) {
+		reqs = append(reqs, fmt.Sprintf(format, args...))
+	}
+
+	for _, svc := range md.Svcs {
+		add("service %s", svc.Name)
+		for _, db := range svc.Databases {
+			add("service %s uses database %s", svc.Name, db)
+		}
+		for _, b := range svc.Buckets {
+			add("service %s uses bucket %s %v", svc.Name, b.Bucket, b.Operations)
+		}
+	}
+	for _, gw := range md.Gateways {
+		add("gateway %s", gw.EncoreName)
+	}
+	for _, db := range md.SqlDatabases {
+		add("database %s", db.Name)
+		for _, m := range db.Migrations {
+			add("database %s migration %d %s", db.Name, m.Number, m.Filename)
+		}
+	}
+	for _, t := range md.PubsubTopics {
+		add("topic %s %s ordering=%q", t.Name, t.DeliveryGuarantee, t.OrderingKey)
+		for _, s := range t.Subscriptions {
+			add("topic %s subscription %s service=%s", t.Name, s.Name, s.ServiceName)
+		}
+	}
+	for _, c := range md.CacheClusters {
+		add("cache %s eviction=%s", c.Name, c.EvictionPolicy)
+	}
+	for _, b := range md.Buckets {
+		add("bucket %s versioned=%t public=%t", b.Name, b.Versioned, b.Public)
+	}
+	for _, j := range md.CronJobs {
+		add("cron %s %s", j.Id, j.Schedule)
+	}
+	for _, m := range md.Metrics {
+		add("metric %s %s", m.Name, m.Kind)
+	}
+	for _, pkg := range md.Pkgs {
+		for _, s := range pkg.Secrets {
+			add("secret %s", s)
+		}
+	}
+
+	slices.Sort(reqs)
+	reqs = slices.Compact(reqs)
+	h := sha256.New()
+	for _, r := range reqs {
+		_, _ = h.Write([]byte(r + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// GoVersion returns the version of the Go toolchain in goroot,
+// such as "go1.23.4", or "" if it cannot be determined.
+func GoVersion(goroot string) string {
+	f, err := os.Open(filepath.Join(goroot, "VERSION"))
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+	sc := bufio.NewScanner(f)
+	if sc.Scan() {
+		return strings.TrimSpace(sc.Text())
+	}
+	return ""
+}
+
+// BuildTime returns the time to record in build artifacts, so that
+// building the same inputs twice produces identical artifacts.
+//
+// It is the time in the SOURCE_DATE_EPOCH environment variable if set
+// (see https://reproducible-builds.org/specs/source-date-epoch/),
+// or otherwise the commit time of the source if it has no uncommitted changes.
+// If neither is available the build is not reproducible and it reports false.
+func BuildTime(environ []string, rev vcs.Status) (t time.Time, ok bool) {
+	for i := len(environ) - 1; i >= 0; i-- {
+		if val, found := strings.CutPrefix(environ[i], "SOURCE_DATE_EPOCH="); found {
+			if secs, err := strconv.ParseInt(val, 10, 64); err == nil {
+				return time.Unix(secs, 0).UTC(), true
+			}
+			break
+		}
+	}
+	if !rev.Uncommitted && !rev.CommitTime.IsZero() {
+		return rev.CommitTime.UTC(), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,84 @@
+package provenance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/pkg/vcs"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestInfraHash(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "users", Databases: []string{"users"}},
+			{Name: "email"},
+		},
+		SqlDatabases: []*meta.SQLDatabase{{
+			Name:       "users",
+			Migrations: []*meta.DBMigration{{Number: 1, Filename: "1_init.up.sql"}},
+		}},
+		PubsubTopics: []*meta.PubSubTopic{{
+			Name:          "signups",
+			Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "welcome", ServiceName: "email"}},
+		}},
+		Pkgs: []*meta.Package{{RelPath: "users", Secrets: []string{"StripeKey"}}},
+	}
+	hash := InfraHash(md)
+	c.Assert(hash, qt.HasLen, 64)
+
+	// Documentation and ordering does not affect the hash.
+	md2 := proto.Clone(md).(*meta.Data)
+	md2.SqlDatabases[0].Doc = proto.String("The users database.")
+	md2.Svcs[0], md2.Svcs[1] = md2.Svcs[1], md2.Svcs[0]
+	c.Assert(InfraHash(md2), qt.Equals, hash)
+
+	// Requirements do.
+	md3 := proto.Clone(md).(*meta.Data)
+	md3.SqlDatabases[0].Migrations = append(md3.SqlDatabases[0].Migrations,
+		&meta.DBMigration{Number: 2, Filename: "2_add_email.up.sql"})
+	c.Assert(InfraHash(md3), qt.Not(qt.Equals), hash)
+
+	md4 := proto.Clone(md).(*meta.Data)
+	md4.Pkgs[0].Secrets = nil
+	c.Assert(InfraHash(md4), qt.Not(qt.Equals), hash)
+}
+
+func TestGoVersion(t *testing.T) {
+	c := qt.New(t)
+	dir := t.TempDir()
+	c.Assert(GoVersion(dir), qt.Equals, "")
+	c.Assert(os.WriteFile(filepath.Join(dir, "VERSION"), []byte("go1.23.4\ntime 2024-12-03T00:00:00Z\n"), 0o644), qt.IsNil)
+	c.Assert(GoVersion(dir), qt.Equals, "go1.23.4")
+}
+
+func TestBuildTime(t *testing.T) {
+	c := qt.New(t)
+	commit := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+	clean := vcs.Status{Revision: "abc", CommitTime: commit}
+	dirty := vcs.Status{Revision: "abc", CommitTime: commit, Uncommitted: true}
+
+	tests := []struct {
+		environ []string
+		rev     vcs.Status
+		want    time.Time
+		wantOK  bool
+	}{
+		{[]string{"SOURCE_DATE_EPOCH=1700000000"}, dirty, time.Unix(1700000000, 0).UTC(), true},
+		{nil, clean, commit, true},
+		{nil, dirty, time.Time{}, false},
+		{[]string{"SOURCE_DATE_EPOCH=invalid"}, dirty, time.Time{}, false},
+	}
+	for _, test := range tests {
+		got, ok := BuildTime(test.environ, test.rev)
+		c.Check(got, qt.Equals, test.want)
+		c.Check(ok, qt.Equals, test.wantOK)
+	}
+}
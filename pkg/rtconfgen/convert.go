@@ -53,6 +53,7 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 		Gateways:           []config.Gateway{},
 		PubsubTopics:       make(map[string]*config.PubsubTopic),
 		Buckets:            make(map[string]*config.Bucket),
+		SearchIndexes:      make(map[string]*config.SearchIndex),
 		CORS:               &config.CORS{},
 	}
 
@@ -128,6 +129,15 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 						AllowPrivateNetworkAccess:      gw.Cors.AllowPrivateNetworkAccess,
 					}
 				}
+				for _, t := range gw.Transforms {
+					cfg.GatewayTransforms = append(cfg.GatewayTransforms, convertGatewayTransform(t))
+				}
+				if m := gw.ClientMetadata; m != nil {
+					cfg.ClientMetadata = &config.ClientMetadata{
+						GeoIPDatabase:  m.GeoipDatabase,
+						ClientIPHeader: m.ClientIpHeader,
+					}
+				}
 				cfg.Gateways = append(cfg.Gateways, config.Gateway{
 					Name: gw.EncoreName,
 					Host: gw.Hostnames[0],
@@ -200,22 +210,44 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 						ClientCert: clientCert,
 						ClientKey:  clientKey,
 					}
+					if cluster.Engine == runtimev1.SQLEngine_SQL_ENGINE_MYSQL {
+						candidateServer.Engine = "mysql"
+					}
 					if primary.TlsConfig != nil {
 						candidateServer.ServerCACert = primary.TlsConfig.GetServerCaCert()
 					}
+					for _, srv := range cluster.Servers {
+						if srv.Kind != runtimev1.ServerKind_SERVER_KIND_READ_REPLICA {
+							continue
+						}
+						replica := &config.SQLServer{
+							Host:       srv.Host,
+							ClientCert: clientCert,
+							ClientKey:  clientKey,
+						}
+						if srv.TlsConfig != nil {
+							replica.ServerCACert = srv.TlsConfig.GetServerCaCert()
+						}
+						candidateServer.ReadReplicas = append(candidateServer.ReadReplicas, replica)
+					}
 
+					sameServer := func(a, b *config.SQLServer) bool {
+						return a.Host == b.Host &&
+							a.Engine == b.Engine &&
+							a.ServerCACert == b.ServerCACert &&
+							a.ClientCert == b.ClientCert &&
+							a.ClientKey == b.ClientKey
+					}
 					serverIdx := slices.IndexFunc(cfg.SQLServers, func(s *config.SQLServer) bool {
-						return s.Host == candidateServer.Host &&
-							s.ServerCACert == candidateServer.ServerCACert &&
-							s.ClientCert == candidateServer.ClientCert &&
-							s.ClientKey == candidateServer.ClientKey
+						return sameServer(s, candidateServer) &&
+							slices.EqualFunc(s.ReadReplicas, candidateServer.ReadReplicas, sameServer)
 					})
 					if serverIdx == -1 {
 						serverIdx = len(cfg.SQLServers)
 						cfg.SQLServers = append(cfg.SQLServers, candidateServer)
 					}
 
-					cfg.SQLDatabases = append(cfg.SQLDatabases, &config.SQLDatabase{
+					dbCfg := &config.SQLDatabase{
 						ServerID:       serverIdx,
 						EncoreName:     db.EncoreName,
 						DatabaseName:   db.CloudName,
@@ -223,7 +255,21 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 						Password:       c.secretString(role.Password),
 						MinConnections: int(pool.MinConnections),
 						MaxConnections: int(pool.MaxConnections),
-					})
+					}
+
+					// Services granted read access to the database connect with the role
+					// of its read-only connection pool, if it has one.
+					if roPool, ok := fns.Find(db.ConnPools, func(pool *runtimev1.SQLConnectionPool) bool {
+						return pool.IsReadonly
+					}); ok && roPool.RoleRid != pool.RoleRid {
+						if roRole, ok := findRID(roPool.RoleRid, c.in.Infra.Credentials.SqlRoles); ok {
+							dbCfg.ReadOnlyUser = roRole.Username
+							dbCfg.ReadOnlyPassword = c.secretString(roRole.Password)
+						} else {
+							c.setErrf("unable to find sql role %q", roPool.RoleRid)
+						}
+					}
+					cfg.SQLDatabases = append(cfg.SQLDatabases, dbCfg)
 				}
 			}
 		}
@@ -454,6 +500,64 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 
 			}
 		}
+
+		// Search
+		{
+			for _, cluster := range c.in.Infra.Resources.SearchClusters {
+				p := &config.SearchProvider{}
+				switch prov := cluster.Provider.(type) {
+				case *runtimev1.SearchCluster_Postgres_:
+					p.Postgres = &config.PostgresSearchProvider{
+						Database: prov.Postgres.Database,
+					}
+				case *runtimev1.SearchCluster_Opensearch:
+					p.OpenSearch = &config.OpenSearchProvider{
+						URL:      prov.Opensearch.Url,
+						Username: prov.Opensearch.GetUsername(),
+						Password: c.secretString(prov.Opensearch.Password),
+					}
+				case *runtimev1.SearchCluster_Meilisearch_:
+					p.Meilisearch = &config.MeilisearchProvider{
+						URL:    prov.Meilisearch.Url,
+						APIKey: c.secretString(prov.Meilisearch.ApiKey),
+					}
+				default:
+					c.setErrf("unknown search provider type %T", prov)
+					continue
+				}
+
+				providerID := len(cfg.SearchProviders)
+				cfg.SearchProviders = append(cfg.SearchProviders, p)
+				for _, idx := range cluster.Indexes {
+					cfg.SearchIndexes[idx.EncoreName] = &config.SearchIndex{
+						ProviderID: providerID,
+						EncoreName: idx.EncoreName,
+						CloudName:  idx.CloudName,
+					}
+				}
+			}
+		}
+
+		// Encryption keys
+		{
+			for _, key := range c.in.Infra.Resources.EncryptionKeys {
+				k := &config.EncryptionKey{}
+				switch prov := key.Provider.(type) {
+				case *runtimev1.EncryptionKey_Local_:
+					k.Local = &config.LocalEncryptionKey{
+						Key: base64.StdEncoding.EncodeToString(c.secretBytes(prov.Local.Key)),
+					}
+				case *runtimev1.EncryptionKey_AwsKms:
+					k.AWSKMS = &config.AWSKMSKey{KeyARN: prov.AwsKms.KeyArn}
+				case *runtimev1.EncryptionKey_GcpKms:
+					k.GCPKMS = &config.GCPKMSKey{KeyName: prov.GcpKms.KeyName}
+				default:
+					c.setErrf("unknown encryption key provider type %T", prov)
+					continue
+				}
+				cfg.EncryptionKeys = append(cfg.EncryptionKeys, k)
+			}
+		}
 	}
 
 	// Observability.
@@ -729,3 +833,23 @@ func randomMapValue[K comparable, V any](m map[K]V) (V, bool) {
 	var zero V
 	return zero, false
 }
+
+func convertGatewayTransform(t *runtimev1.Gateway_Transform) config.GatewayTransform {
+	out := config.GatewayTransform{
+		Endpoints:           t.Endpoints,
+		RequestHeaders:      t.RequestHeaders,
+		ResponseHeaders:     t.ResponseHeaders,
+		StripResponseFields: t.StripResponseFields,
+	}
+	for _, c := range t.Compression {
+		switch c {
+		case runtimev1.Gateway_COMPRESSION_GZIP:
+			out.Compression = append(out.Compression, "gzip")
+		case runtimev1.Gateway_COMPRESSION_BROTLI:
+			out.Compression = append(out.Compression, "br")
+		case runtimev1.Gateway_COMPRESSION_ZSTD:
+			out.Compression = append(out.Compression, "zstd")
+		}
+	}
+	return out
+}
@@ -223,6 +223,48 @@ type Bucket struct {
 	b   *InfraBuilder
 }
 
+func (b *InfraBuilder) SearchCluster(p *runtimev1.SearchCluster) *SearchCluster {
+	return b.SearchClusterFn(p.Rid, tofn(p))
+}
+
+func (b *InfraBuilder) SearchClusterFn(rid string, fn func() *runtimev1.SearchCluster) *SearchCluster {
+	val := addResFunc(&b.infra.Resources.SearchClusters, b.rs, rid, fn)
+	return &SearchCluster{Val: val, b: b}
+}
+
+type SearchCluster struct {
+	Val *runtimev1.SearchCluster
+	b   *InfraBuilder
+}
+
+func (c *SearchCluster) SearchIndex(p *runtimev1.SearchIndex) *SearchIndex {
+	return c.SearchIndexFn(p.Rid, tofn(p))
+}
+
+func (c *SearchCluster) SearchIndexFn(rid string, fn func() *runtimev1.SearchIndex) *SearchIndex {
+	val := addResFunc(&c.Val.Indexes, c.b.rs, rid, fn)
+	return &SearchIndex{Val: val, b: c.b}
+}
+
+type SearchIndex struct {
+	Val *runtimev1.SearchIndex
+	b   *InfraBuilder
+}
+
+func (b *InfraBuilder) EncryptionKey(p *runtimev1.EncryptionKey) *EncryptionKey {
+	return b.EncryptionKeyFn(p.Rid, tofn(p))
+}
+
+func (b *InfraBuilder) EncryptionKeyFn(rid string, fn func() *runtimev1.EncryptionKey) *EncryptionKey {
+	val := addResFunc(&b.infra.Resources.EncryptionKeys, b.rs, rid, fn)
+	return &EncryptionKey{Val: val, b: b}
+}
+
+type EncryptionKey struct {
+	Val *runtimev1.EncryptionKey
+	b   *InfraBuilder
+}
+
 func (b *InfraBuilder) Gateway(gw *runtimev1.Gateway) *Gateway {
 	return b.GatewayFn(gw.Rid, tofn(gw))
 }
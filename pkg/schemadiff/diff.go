@@ -0,0 +1,296 @@
+package schemadiff
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Diff returns the statements that turn the schema from into the schema to,
+// in the order they must be run. It also returns notes about differences
+// it can't express as statements, which need to be migrated by hand.
+func Diff(from, to *Schema) (stmts, notes []string) {
+	d := &differ{from: from, to: to}
+	d.diff()
+	return d.stmts, d.notes
+}
+
+type differ struct {
+	from, to *Schema
+	stmts    []string
+	notes    []string
+}
+
+func (d *differ) add(format string, args ...any) {
+	d.stmts = append(d.stmts, fmt.Sprintf(format, args...))
+}
+
+func (d *differ) note(format string, args ...any) {
+	d.notes = append(d.notes, fmt.Sprintf(format, args...))
+}
+
+func (d *differ) diff() {
+	from, to := d.from, d.to
+
+	// Drop the views first, as they may depend on the columns that change.
+	for _, name := range sortedKeys(from.Views) {
+		if def, ok := to.Views[name]; !ok || def != from.Views[name] {
+			d.add("DROP VIEW %s", name)
+		}
+	}
+
+	for _, name := range sortedKeys(to.Extensions) {
+		if !from.Extensions[name] {
+			d.add("CREATE EXTENSION IF NOT EXISTS %s", name)
+		}
+	}
+	d.diffEnums()
+
+	// Drop the constraints that are removed or changed, starting with the foreign keys
+	// as they may reference the primary key and unique constraints that are dropped.
+	kept := d.keptTables()
+	for _, fk := range []bool{true, false} {
+		for _, name := range kept {
+			ft, tt := from.Tables[name], to.Tables[name]
+			for _, conName := range sortedKeys(ft.Constraints) {
+				con := ft.Constraints[conName]
+				if con.ForeignKey != fk {
+					continue
+				}
+				if c, ok := tt.Constraints[conName]; !ok || c.Definition != con.Definition {
+					d.add("ALTER TABLE %s DROP CONSTRAINT %s", name, conName)
+				}
+			}
+		}
+	}
+	for _, name := range kept {
+		ft, tt := from.Tables[name], to.Tables[name]
+		for _, idx := range sortedKeys(ft.Indexes) {
+			if def, ok := tt.Indexes[idx]; !ok || def != ft.Indexes[idx] {
+				d.add("DROP INDEX %s", idx)
+			}
+		}
+	}
+
+	var created []string
+	for _, name := range sortedKeys(to.Tables) {
+		if _, ok := from.Tables[name]; !ok {
+			d.createTable(to.Tables[name])
+			created = append(created, name)
+		}
+	}
+	for _, name := range kept {
+		d.diffColumns(from.Tables[name], to.Tables[name])
+	}
+
+	// Add the constraints and indexes of the kept tables that are new or changed,
+	// and those of the created tables that weren't created with the table.
+	changedTables := append(slices.Clone(kept), created...)
+	slices.Sort(changedTables)
+	addedConstraints := func(name string) []*Constraint {
+		var res []*Constraint
+		tt := to.Tables[name]
+		ft, existed := from.Tables[name]
+		for _, conName := range sortedKeys(tt.Constraints) {
+			con := tt.Constraints[conName]
+			if !existed {
+				res = append(res, con)
+			} else if c, ok := ft.Constraints[conName]; !ok || c.Definition != con.Definition {
+				res = append(res, con)
+			}
+		}
+		return res
+	}
+	for _, name := range kept {
+		for _, con := range addedConstraints(name) {
+			if !con.ForeignKey {
+				d.add("ALTER TABLE %s ADD CONSTRAINT %s %s", name, con.Name, con.Definition)
+			}
+		}
+	}
+	for _, name := range changedTables {
+		tt := to.Tables[name]
+		ft, existed := from.Tables[name]
+		for _, idx := range sortedKeys(tt.Indexes) {
+			if !existed || ft.Indexes[idx] != tt.Indexes[idx] {
+				d.stmts = append(d.stmts, tt.Indexes[idx])
+			}
+		}
+	}
+	for _, name := range changedTables {
+		for _, con := range addedConstraints(name) {
+			if con.ForeignKey {
+				d.add("ALTER TABLE %s ADD CONSTRAINT %s %s", name, con.Name, con.Definition)
+			}
+		}
+	}
+
+	// Drop the removed tables in a single statement,
+	// as they may reference each other.
+	var dropped []string
+	for _, name := range sortedKeys(from.Tables) {
+		if _, ok := to.Tables[name]; !ok {
+			dropped = append(dropped, name)
+		}
+	}
+	if len(dropped) > 0 {
+		d.add("DROP TABLE %s", strings.Join(dropped, ", "))
+	}
+
+	for _, name := range sortedKeys(from.Enums) {
+		if _, ok := to.Enums[name]; !ok {
+			d.add("DROP TYPE %s", name)
+		}
+	}
+	for _, name := range sortedKeys(from.Extensions) {
+		if !to.Extensions[name] {
+			d.add("DROP EXTENSION %s", name)
+		}
+	}
+
+	for _, name := range sortedKeys(to.Views) {
+		if def, ok := from.Views[name]; !ok || def != to.Views[name] {
+			d.add("CREATE VIEW %s AS\n%s", name, strings.TrimSuffix(strings.TrimSpace(to.Views[name]), ";"))
+		}
+	}
+}
+
+// keptTables returns the names of the tables in both schemas.
+func (d *differ) keptTables() []string {
+	var names []string
+	for _, name := range sortedKeys(d.from.Tables) {
+		if _, ok := d.to.Tables[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (d *differ) diffEnums() {
+	for _, name := range sortedKeys(d.to.Enums) {
+		labels := d.to.Enums[name]
+		prev, ok := d.from.Enums[name]
+		if !ok {
+			quoted := make([]string, len(labels))
+			for i, l := range labels {
+				quoted[i] = quoteLiteral(l)
+			}
+			d.add("CREATE TYPE %s AS ENUM (%s)", name, strings.Join(quoted, ", "))
+			continue
+		}
+
+		for i, l := range labels {
+			if slices.Contains(prev, l) {
+				continue
+			}
+			switch {
+			case i > 0:
+				d.add("ALTER TYPE %s ADD VALUE %s AFTER %s", name, quoteLiteral(l), quoteLiteral(labels[i-1]))
+			case len(labels) > 1:
+				d.add("ALTER TYPE %s ADD VALUE %s BEFORE %s", name, quoteLiteral(l), quoteLiteral(labels[1]))
+			default:
+				d.add("ALTER TYPE %s ADD VALUE %s", name, quoteLiteral(l))
+			}
+		}
+		for _, l := range prev {
+			if !slices.Contains(labels, l) {
+				d.note("value %s was removed from enum type %s, which requires recreating the type", quoteLiteral(l), name)
+			}
+		}
+	}
+}
+
+func (d *differ) createTable(t *Table) {
+	var defs []string
+	for _, c := range t.Columns {
+		defs = append(defs, columnDef(c))
+	}
+	for _, name := range sortedKeys(t.Constraints) {
+		if con := t.Constraints[name]; !con.ForeignKey {
+			defs = append(defs, fmt.Sprintf("CONSTRAINT %s %s", con.Name, con.Definition))
+		}
+	}
+	d.add("CREATE TABLE %s (\n    %s\n)", t.Name, strings.Join(defs, ",\n    "))
+}
+
+func (d *differ) diffColumns(from, to *Table) {
+	for _, c := range to.Columns {
+		prev := from.Column(c.Name)
+		if prev == nil {
+			d.add("ALTER TABLE %s ADD COLUMN %s", to.Name, columnDef(c))
+			continue
+		}
+
+		if prev.Identity != c.Identity || prev.Generated != c.Generated {
+			d.note("column %s.%s changed whether or how it's generated, which requires recreating the column", to.Name, c.Name)
+			continue
+		}
+		if prev.Type != c.Type {
+			d.add("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s", to.Name, c.Name, c.Type, c.Name, c.Type)
+		}
+		if prev.Default != c.Default {
+			if c.Default == "" {
+				d.add("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT", to.Name, c.Name)
+			} else {
+				d.add("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", to.Name, c.Name, c.Default)
+			}
+		}
+		if prev.NotNull != c.NotNull && c.Identity == "" {
+			if c.NotNull {
+				d.add("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL", to.Name, c.Name)
+			} else {
+				d.add("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL", to.Name, c.Name)
+			}
+		}
+	}
+	for _, c := range from.Columns {
+		if to.Column(c.Name) == nil {
+			d.add("ALTER TABLE %s DROP COLUMN %s", to.Name, c.Name)
+		}
+	}
+}
+
+// serialDefault matches the default of serial columns.
+var serialDefault = regexp.MustCompile(`^nextval\('[^']+_seq'::regclass\)$`)
+
+// serialTypes maps integer types to their serial type.
+var serialTypes = map[string]string{
+	"smallint": "smallserial",
+	"integer":  "serial",
+	"bigint":   "bigserial",
+}
+
+// columnDef returns the definition of c in a CREATE TABLE or ADD COLUMN statement.
+func columnDef(c *Column) string {
+	typ, def := c.Type, c.Default
+	if serial, ok := serialTypes[typ]; ok && serialDefault.MatchString(def) {
+		// Create the sequence along with the column.
+		typ, def = serial, ""
+	}
+
+	parts := []string{c.Name, typ}
+	switch {
+	case c.Identity == "a":
+		parts = append(parts, "GENERATED ALWAYS AS IDENTITY")
+	case c.Identity == "d":
+		parts = append(parts, "GENERATED BY DEFAULT AS IDENTITY")
+	case c.Generated != "":
+		parts = append(parts, fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", c.Generated))
+	case def != "":
+		parts = append(parts, "DEFAULT "+def)
+	}
+	if c.NotNull && c.Identity == "" {
+		parts = append(parts, "NOT NULL")
+	}
+	return strings.Join(parts, " ")
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
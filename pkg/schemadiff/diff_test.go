@@ -0,0 +1,147 @@
+package schemadiff
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func newSchema(tables ...*Table) *Schema {
+	s := &Schema{
+		Extensions: map[string]bool{},
+		Enums:      map[string][]string{},
+		Tables:     map[string]*Table{},
+		Views:      map[string]string{},
+	}
+	for _, t := range tables {
+		if t.Constraints == nil {
+			t.Constraints = map[string]*Constraint{}
+		}
+		if t.Indexes == nil {
+			t.Indexes = map[string]string{}
+		}
+		s.Tables[t.Name] = t
+	}
+	return s
+}
+
+func usersTable() *Table {
+	return &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "id", Type: "bigint", NotNull: true, Default: "nextval('users_id_seq'::regclass)"},
+			{Name: "email", Type: "text", NotNull: true},
+		},
+		Constraints: map[string]*Constraint{
+			"users_pkey": {Name: "users_pkey", Definition: "PRIMARY KEY (id)"},
+		},
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	c := qt.New(t)
+	stmts, notes := Diff(newSchema(usersTable()), newSchema(usersTable()))
+	c.Assert(stmts, qt.HasLen, 0)
+	c.Assert(notes, qt.HasLen, 0)
+}
+
+func TestDiff_CreateTable(t *testing.T) {
+	c := qt.New(t)
+	orders := &Table{
+		Name: "orders",
+		Columns: []*Column{
+			{Name: "id", Type: "integer", NotNull: true, Identity: "a"},
+			{Name: "user_id", Type: "bigint", NotNull: true},
+			{Name: "status", Type: "order_status", NotNull: true, Default: "'pending'::order_status"},
+		},
+		Constraints: map[string]*Constraint{
+			"orders_pkey":         {Name: "orders_pkey", Definition: "PRIMARY KEY (id)"},
+			"orders_user_id_fkey": {Name: "orders_user_id_fkey", Definition: "FOREIGN KEY (user_id) REFERENCES users(id)", ForeignKey: true},
+		},
+		Indexes: map[string]string{
+			"orders_user_id_idx": "CREATE INDEX orders_user_id_idx ON public.orders USING btree (user_id)",
+		},
+	}
+	to := newSchema(usersTable(), orders)
+	to.Enums["order_status"] = []string{"pending", "shipped"}
+
+	stmts, notes := Diff(newSchema(usersTable()), to)
+	c.Assert(notes, qt.HasLen, 0)
+	c.Assert(stmts, qt.DeepEquals, []string{
+		"CREATE TYPE order_status AS ENUM ('pending', 'shipped')",
+		"CREATE TABLE orders (\n" +
+			"    id integer GENERATED ALWAYS AS IDENTITY,\n" +
+			"    user_id bigint NOT NULL,\n" +
+			"    status order_status DEFAULT 'pending'::order_status NOT NULL,\n" +
+			"    CONSTRAINT orders_pkey PRIMARY KEY (id)\n" +
+			")",
+		"CREATE INDEX orders_user_id_idx ON public.orders USING btree (user_id)",
+		"ALTER TABLE orders ADD CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)",
+	})
+}
+
+func TestDiff_AlterTable(t *testing.T) {
+	c := qt.New(t)
+	from := newSchema(usersTable())
+	from.Tables["users"].Columns = append(from.Tables["users"].Columns, &Column{Name: "nickname", Type: "text"})
+
+	users := usersTable()
+	users.Columns[1] = &Column{Name: "email", Type: "character varying(320)", NotNull: false, Default: "''::character varying"}
+	users.Columns = append(users.Columns, &Column{Name: "id2", Type: "integer", NotNull: true, Default: "nextval('users_id2_seq'::regclass)"})
+	users.Constraints["users_email_key"] = &Constraint{Name: "users_email_key", Definition: "UNIQUE (email)"}
+	to := newSchema(users)
+
+	stmts, notes := Diff(from, to)
+	c.Assert(notes, qt.HasLen, 0)
+	c.Assert(stmts, qt.DeepEquals, []string{
+		"ALTER TABLE users ALTER COLUMN email TYPE character varying(320) USING email::character varying(320)",
+		"ALTER TABLE users ALTER COLUMN email SET DEFAULT ''::character varying",
+		"ALTER TABLE users ALTER COLUMN email DROP NOT NULL",
+		"ALTER TABLE users ADD COLUMN id2 serial NOT NULL",
+		"ALTER TABLE users DROP COLUMN nickname",
+		"ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email)",
+	})
+}
+
+func TestDiff_Drop(t *testing.T) {
+	c := qt.New(t)
+	posts := &Table{
+		Name:    "posts",
+		Columns: []*Column{{Name: "author_id", Type: "bigint"}},
+		Constraints: map[string]*Constraint{
+			"posts_author_id_fkey": {Name: "posts_author_id_fkey", Definition: "FOREIGN KEY (author_id) REFERENCES users(id)", ForeignKey: true},
+		},
+	}
+	from := newSchema(usersTable(), posts)
+	from.Enums["mood"] = []string{"happy", "sad"}
+	from.Views["active_users"] = "SELECT id FROM users;"
+
+	users := usersTable()
+	delete(users.Constraints, "users_pkey")
+	to := newSchema(users)
+
+	stmts, notes := Diff(from, to)
+	c.Assert(notes, qt.HasLen, 0)
+	c.Assert(stmts, qt.DeepEquals, []string{
+		"DROP VIEW active_users",
+		"ALTER TABLE users DROP CONSTRAINT users_pkey",
+		"DROP TABLE posts",
+		"DROP TYPE mood",
+	})
+}
+
+func TestDiff_Enums(t *testing.T) {
+	c := qt.New(t)
+	from, to := newSchema(), newSchema()
+	from.Enums["status"] = []string{"open", "closed"}
+	to.Enums["status"] = []string{"draft", "open", "won't fix"}
+
+	stmts, notes := Diff(from, to)
+	c.Assert(stmts, qt.DeepEquals, []string{
+		"ALTER TYPE status ADD VALUE 'draft' BEFORE 'open'",
+		"ALTER TYPE status ADD VALUE 'won''t fix' AFTER 'open'",
+	})
+	c.Assert(notes, qt.DeepEquals, []string{
+		"value 'closed' was removed from enum type status, which requires recreating the type",
+	})
+}
@@ -0,0 +1,254 @@
+// Package schemadiff compares the schemas of PostgreSQL databases,
+// computing the statements that turn one schema into the other.
+package schemadiff
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Schema describes the objects of a database that migrations create.
+// Names are quoted as needed, and qualified with their schema
+// unless they're in the public schema.
+type Schema struct {
+	Extensions map[string]bool     // extension name -> true
+	Enums      map[string][]string // type name -> labels, in order
+	Tables     map[string]*Table   // table name -> table
+	Views      map[string]string   // view name -> query
+}
+
+// Table describes a table.
+type Table struct {
+	Name        string
+	Columns     []*Column              // in order
+	Constraints map[string]*Constraint // constraint name -> constraint
+	Indexes     map[string]string      // index name -> CREATE INDEX statement
+}
+
+// Column returns the column with the given name, or nil if there is none.
+func (t *Table) Column(name string) *Column {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Column describes a column of a table.
+type Column struct {
+	Name    string
+	Type    string
+	NotNull bool
+	Default string // the default expression, or "" for none
+
+	// Identity is "a" for GENERATED ALWAYS AS IDENTITY columns,
+	// "d" for GENERATED BY DEFAULT AS IDENTITY columns and "" otherwise.
+	Identity string
+
+	// Generated is the expression of a generated column, or "" for other columns.
+	Generated string
+}
+
+// Constraint describes a table constraint.
+type Constraint struct {
+	Name       string
+	Definition string // as returned by pg_get_constraintdef
+	ForeignKey bool
+}
+
+// Queryer is implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// userObject filters pg_class, pg_type and similar catalogs joined with
+// pg_namespace n to the objects in user schemas not owned by an extension.
+const userObject = `
+	n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg\_%%'
+	AND NOT EXISTS (SELECT 1 FROM pg_depend dep WHERE dep.objid = %s.oid AND dep.deptype = 'e')`
+
+// qualifiedName is the expression for the quoted name column of an object,
+// qualified with its schema in pg_namespace n unless it's public.
+const qualifiedName = `
+	CASE WHEN n.nspname = 'public' THEN '' ELSE quote_ident(n.nspname) || '.' END || quote_ident(%s)`
+
+// MigrationsTable is the table recording the applied migrations,
+// which is left out of the schema.
+const MigrationsTable = "schema_migrations"
+
+// Load loads the schema of the database q is connected to.
+func Load(ctx context.Context, q Queryer) (*Schema, error) {
+	s := &Schema{
+		Extensions: make(map[string]bool),
+		Enums:      make(map[string][]string),
+		Tables:     make(map[string]*Table),
+		Views:      make(map[string]string),
+	}
+
+	err := query(ctx, q, `SELECT quote_ident(extname) FROM pg_extension WHERE extname <> 'plpgsql'`,
+		func(rows *sql.Rows) error {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			s.Extensions[name] = true
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "load extensions")
+	}
+
+	err = query(ctx, q, `
+		SELECT `+fmt.Sprintf(qualifiedName, "t.typname")+`, e.enumlabel
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		JOIN pg_enum e ON e.enumtypid = t.oid
+		WHERE `+fmt.Sprintf(userObject, "t")+`
+		ORDER BY t.oid, e.enumsortorder`,
+		func(rows *sql.Rows) error {
+			var name, label string
+			if err := rows.Scan(&name, &label); err != nil {
+				return err
+			}
+			s.Enums[name] = append(s.Enums[name], label)
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "load enums")
+	}
+
+	tables := make(map[int64]*Table) // oid -> table
+	err = query(ctx, q, `
+		SELECT c.oid::int8, `+fmt.Sprintf(qualifiedName, "c.relname")+`, c.relkind::text,
+			CASE WHEN c.relkind = 'v' THEN pg_get_viewdef(c.oid, true) ELSE '' END
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p', 'v') AND NOT c.relispartition
+		AND NOT (n.nspname = 'public' AND c.relname = '`+MigrationsTable+`')
+		AND `+fmt.Sprintf(userObject, "c"),
+		func(rows *sql.Rows) error {
+			var (
+				oid              int64
+				name, kind, view string
+			)
+			if err := rows.Scan(&oid, &name, &kind, &view); err != nil {
+				return err
+			}
+			if kind == "v" {
+				s.Views[name] = view
+				return nil
+			}
+			t := &Table{Name: name, Constraints: make(map[string]*Constraint), Indexes: make(map[string]string)}
+			s.Tables[name] = t
+			tables[oid] = t
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "load tables")
+	}
+
+	err = query(ctx, q, `
+		SELECT a.attrelid::int8, quote_ident(a.attname), format_type(a.atttypid, a.atttypmod), a.attnotnull,
+			coalesce(pg_get_expr(d.adbin, d.adrelid), ''), a.attidentity::text, a.attgenerated::text
+		FROM pg_attribute a
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		JOIN pg_class c ON c.oid = a.attrelid
+		WHERE c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attrelid, a.attnum`,
+		func(rows *sql.Rows) error {
+			var (
+				oid                int64
+				col                Column
+				expr, ident, genrt string
+			)
+			if err := rows.Scan(&oid, &col.Name, &col.Type, &col.NotNull, &expr, &ident, &genrt); err != nil {
+				return err
+			}
+			t, ok := tables[oid]
+			if !ok {
+				return nil
+			}
+			col.Identity = ident
+			if genrt != "" {
+				col.Generated = expr
+			} else {
+				col.Default = expr
+			}
+			t.Columns = append(t.Columns, &col)
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "load columns")
+	}
+
+	err = query(ctx, q, `
+		SELECT conrelid::int8, quote_ident(conname), pg_get_constraintdef(oid, true), contype::text
+		FROM pg_constraint
+		WHERE conrelid <> 0 AND contype IN ('p', 'u', 'f', 'c', 'x')`,
+		func(rows *sql.Rows) error {
+			var (
+				oid     int64
+				con     Constraint
+				conType string
+			)
+			if err := rows.Scan(&oid, &con.Name, &con.Definition, &conType); err != nil {
+				return err
+			}
+			if t, ok := tables[oid]; ok {
+				con.ForeignKey = conType == "f"
+				t.Constraints[con.Name] = &con
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "load constraints")
+	}
+
+	// Leave out the indexes backing constraints, which are created with them.
+	err = query(ctx, q, `
+		SELECT i.indrelid::int8, `+fmt.Sprintf(qualifiedName, "c.relname")+`, pg_get_indexdef(i.indexrelid)
+		FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indexrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE NOT EXISTS (
+			SELECT 1 FROM pg_constraint con
+			WHERE con.conindid = i.indexrelid AND con.contype IN ('p', 'u', 'x')
+		)`,
+		func(rows *sql.Rows) error {
+			var (
+				oid       int64
+				name, def string
+			)
+			if err := rows.Scan(&oid, &name, &def); err != nil {
+				return err
+			}
+			if t, ok := tables[oid]; ok {
+				t.Indexes[name] = def
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "load indexes")
+	}
+
+	return s, nil
+}
+
+// query runs the query, calling fn for each row.
+func query(ctx context.Context, q Queryer, stmt string, fn func(*sql.Rows) error) error {
+	rows, err := q.QueryContext(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,320 @@
+package schemaexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+type avroGen struct {
+	*namer
+
+	// defined are the full names of the records and enums defined so far.
+	// Avro requires each named type to be defined once per schema,
+	// and subsequently referenced by its full name.
+	defined map[string]bool
+
+	// inProgress are the named types whose definitions are being computed,
+	// to detect recursive types that can't be represented.
+	inProgress map[string]bool
+}
+
+func newAvroGen(md *meta.Data) *avroGen {
+	return &avroGen{namer: newNamer(md), defined: make(map[string]bool), inProgress: make(map[string]bool)}
+}
+
+// document returns an Avro schema describing typ. Anonymous records
+// are named after typeName.
+func (g *avroGen) document(typ *schema.Type, typeName, doc string) (any, error) {
+	s, err := g.schema(typ, avroName(typeName))
+	if err != nil {
+		return nil, err
+	}
+	if rec, ok := s.(object); ok && doc != "" && rec["doc"] == nil && isNamedAvroType(rec) {
+		rec["doc"] = doc
+	}
+	return s, nil
+}
+
+// schema returns the Avro schema of a type.
+// The name is used for anonymous records and enums.
+func (g *avroGen) schema(typ *schema.Type, name string) (any, error) {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		return g.named(t.Named)
+
+	case *schema.Type_Struct:
+		name = g.uniqueName(name)
+		rec := object{"type": "record", "name": name}
+		fields := []any{}
+		for _, f := range t.Struct.Fields {
+			fieldName := fieldName(f)
+			if fieldName == "" {
+				continue
+			}
+			ident := avroIdent(fieldName)
+			s, err := g.schema(f.Typ, name+"_"+pascalCase(ident))
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %s", f.Name)
+			}
+
+			field := object{"name": ident}
+			if f.Optional {
+				// Avro has no notion of absent fields, so optional fields are nullable with a null default.
+				field["type"] = nullable(s)
+				field["default"] = nil
+			} else {
+				field["type"] = s
+			}
+			if f.Doc != "" {
+				field["doc"] = strings.TrimSpace(f.Doc)
+			}
+			if ident != fieldName {
+				// Keep track of the JSON name, which is not a valid Avro name.
+				field["jsonName"] = fieldName
+			}
+			if f.Deprecated != nil {
+				field["deprecated"] = *f.Deprecated
+			}
+			fields = append(fields, field)
+		}
+		rec["fields"] = fields
+		return rec, nil
+
+	case *schema.Type_Map:
+		v, err := g.schema(t.Map.Value, name+"Value")
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "map", "values": v}, nil
+
+	case *schema.Type_List:
+		elem, err := g.schema(t.List.Elem, name+"Item")
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "array", "items": elem}, nil
+
+	case *schema.Type_Pointer:
+		s, err := g.schema(t.Pointer.Base, name)
+		if err != nil {
+			return nil, err
+		}
+		return nullable(s), nil
+
+	case *schema.Type_Option:
+		s, err := g.schema(t.Option.Value, name)
+		if err != nil {
+			return nil, err
+		}
+		return nullable(s), nil
+
+	case *schema.Type_Union:
+		if values, ok := literalValues(t.Union.Types); ok && validSymbols(values) {
+			symbols := make([]string, len(values))
+			for i, v := range values {
+				symbols[i] = v.(string)
+			}
+			return object{"type": "enum", "name": g.uniqueName(name), "symbols": symbols}, nil
+		}
+		var variants []any
+		for i, v := range t.Union.Types {
+			s, err := g.schema(v, fmt.Sprintf("%s%d", name, i+1))
+			if err != nil {
+				return nil, err
+			}
+			variants = append(variants, s)
+		}
+		return union(variants...), nil
+
+	case *schema.Type_Literal:
+		// Avro has no literal types, so use the type of the literal.
+		switch t.Literal.Value.(type) {
+		case *schema.Literal_Str:
+			return "string", nil
+		case *schema.Literal_Int:
+			return "long", nil
+		case *schema.Literal_Float:
+			return "double", nil
+		case *schema.Literal_Boolean:
+			return "boolean", nil
+		default:
+			return "null", nil
+		}
+
+	case *schema.Type_Builtin:
+		return avroBuiltin(t.Builtin)
+
+	case *schema.Type_Config:
+		return g.schema(t.Config.Elem, name)
+
+	default:
+		return nil, errors.Newf("unsupported type %T", t)
+	}
+}
+
+// named returns the Avro schema of a named type. Records and enums
+// are defined the first time they are used and referenced by name afterwards.
+// Other types, which Avro can't name, are inlined.
+func (g *avroGen) named(named *schema.Named) (any, error) {
+	defName, _ := g.name(named)
+	fullName := avroName(defName)
+	if g.defined[fullName] {
+		return fullName, nil
+	} else if g.inProgress[fullName] {
+		return nil, errors.Newf("recursive type %s cannot be represented in Avro", defName)
+	}
+
+	concrete, err := g.concrete(named)
+	if err != nil {
+		return nil, err
+	}
+	// Records are defined before their fields are computed, which handles recursive
+	// record types. Other recursive types can't be represented.
+	g.inProgress[fullName] = true
+	defer delete(g.inProgress, fullName)
+
+	s, err := g.schema(concrete, fullName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "type %s", defName)
+	}
+	if def, ok := s.(object); ok && isNamedAvroType(def) {
+		g.defined[fullName] = true
+		def["name"] = fullName
+		if doc := g.doc(named); doc != "" {
+			def["doc"] = doc
+		}
+	}
+	return s, nil
+}
+
+// uniqueName returns name, or name with a numeric suffix if it's already defined.
+func (g *avroGen) uniqueName(name string) string {
+	candidate := name
+	for idx := 2; g.defined[candidate]; idx++ {
+		candidate = fmt.Sprintf("%s_%d", name, idx)
+	}
+	g.defined[candidate] = true
+	return candidate
+}
+
+func avroBuiltin(b schema.Builtin) (any, error) {
+	switch b {
+	case schema.Builtin_ANY, schema.Builtin_JSON:
+		// Avro has no dynamic type, so arbitrary JSON values are encoded as strings.
+		return "string", nil
+	case schema.Builtin_BOOL:
+		return "boolean", nil
+	case schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32,
+		schema.Builtin_UINT8, schema.Builtin_UINT16:
+		return "int", nil
+	case schema.Builtin_INT64, schema.Builtin_INT,
+		schema.Builtin_UINT32, schema.Builtin_UINT64, schema.Builtin_UINT:
+		return "long", nil
+	case schema.Builtin_FLOAT32:
+		return "float", nil
+	case schema.Builtin_FLOAT64:
+		return "double", nil
+	case schema.Builtin_STRING, schema.Builtin_USER_ID, schema.Builtin_DECIMAL:
+		return "string", nil
+	case schema.Builtin_BYTES, schema.Builtin_FILE:
+		return "bytes", nil
+	case schema.Builtin_TIME:
+		return object{"type": "long", "logicalType": "timestamp-micros"}, nil
+	case schema.Builtin_UUID:
+		return object{"type": "string", "logicalType": "uuid"}, nil
+	default:
+		return nil, errors.Newf("unsupported builtin type %v", b)
+	}
+}
+
+// nullable returns a union of null and s.
+func nullable(s any) any {
+	return union("null", s)
+}
+
+// union returns a union of the given schemas. Nested unions are flattened
+// and duplicate members removed, as required by Avro.
+func union(members ...any) any {
+	var result []any
+	seen := make(map[string]bool)
+	var add func(s any)
+	add = func(s any) {
+		if u, ok := s.([]any); ok {
+			for _, m := range u {
+				add(m)
+			}
+			return
+		}
+		key, _ := json.Marshal(unionKey(s))
+		if !seen[string(key)] {
+			seen[string(key)] = true
+			result = append(result, s)
+		}
+	}
+	for _, m := range members {
+		add(m)
+	}
+	if len(result) == 1 {
+		return result[0]
+	}
+	return result
+}
+
+// unionKey returns the key Avro uses to tell apart union members:
+// the full name of named types, and the type of other types.
+func unionKey(s any) any {
+	if def, ok := s.(object); ok {
+		if isNamedAvroType(def) {
+			return def["name"]
+		}
+		return def["type"]
+	}
+	return s
+}
+
+func isNamedAvroType(def object) bool {
+	switch def["type"] {
+	case "record", "enum", "fixed":
+		return true
+	}
+	return false
+}
+
+var (
+	invalidAvroChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+	avroSymbol       = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// avroIdent converts s to a valid Avro name.
+func avroIdent(s string) string {
+	s = invalidAvroChars.ReplaceAllString(s, "_")
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		s = "_" + s
+	}
+	return s
+}
+
+// avroName converts a dotted name to a valid Avro full name.
+func avroName(s string) string {
+	parts := strings.Split(s, ".")
+	for i, p := range parts {
+		parts[i] = avroIdent(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+func validSymbols(values []any) bool {
+	for _, v := range values {
+		if s, ok := v.(string); !ok || !avroSymbol.MatchString(s) {
+			return false
+		}
+	}
+	return len(values) > 0
+}
@@ -0,0 +1,236 @@
+package schemaexport
+
+import (
+	"math"
+
+	"github.com/cockroachdb/errors"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// object is a JSON object in a schema definition.
+type object = map[string]any
+
+type jsonSchemaGen struct {
+	*namer
+	defs object
+}
+
+func newJSONSchemaGen(md *meta.Data) *jsonSchemaGen {
+	return &jsonSchemaGen{namer: newNamer(md), defs: make(object)}
+}
+
+// document returns a JSON Schema document describing typ,
+// with the named types it references in "$defs".
+func (g *jsonSchemaGen) document(typ *schema.Type, title, doc string) (object, error) {
+	s, err := g.schema(typ)
+	if err != nil {
+		return nil, err
+	}
+	s["$schema"] = jsonSchemaDialect
+	s["title"] = title
+	if doc != "" {
+		s["description"] = doc
+	}
+	if len(g.defs) > 0 {
+		s["$defs"] = g.defs
+	}
+	return s, nil
+}
+
+func (g *jsonSchemaGen) schema(typ *schema.Type) (object, error) {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		return g.named(t.Named)
+
+	case *schema.Type_Struct:
+		props := make(object)
+		required := []string{}
+		for _, f := range t.Struct.Fields {
+			name := fieldName(f)
+			if name == "" {
+				continue
+			}
+			s, err := g.schema(f.Typ)
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %s", f.Name)
+			}
+			if f.Doc != "" {
+				s["description"] = f.Doc
+			}
+			if f.Deprecated != nil {
+				s["deprecated"] = true
+			}
+			props[name] = s
+			if !f.Optional {
+				required = append(required, name)
+			}
+		}
+		s := object{"type": "object", "properties": props}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s, nil
+
+	case *schema.Type_Map:
+		v, err := g.schema(t.Map.Value)
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "object", "additionalProperties": v}, nil
+
+	case *schema.Type_List:
+		elem, err := g.schema(t.List.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return object{"type": "array", "items": elem}, nil
+
+	case *schema.Type_Pointer:
+		return g.nullable(t.Pointer.Base)
+
+	case *schema.Type_Option:
+		return g.nullable(t.Option.Value)
+
+	case *schema.Type_Union:
+		if values, ok := literalValues(t.Union.Types); ok {
+			return object{"enum": values}, nil
+		}
+		var variants []any
+		for _, v := range t.Union.Types {
+			s, err := g.schema(v)
+			if err != nil {
+				return nil, err
+			}
+			variants = append(variants, s)
+		}
+		return object{"anyOf": variants}, nil
+
+	case *schema.Type_Literal:
+		if _, ok := t.Literal.Value.(*schema.Literal_Null); ok {
+			return object{"type": "null"}, nil
+		}
+		return object{"const": literalValue(t.Literal)}, nil
+
+	case *schema.Type_Builtin:
+		return jsonSchemaBuiltin(t.Builtin)
+
+	case *schema.Type_Config:
+		return g.schema(t.Config.Elem)
+
+	default:
+		return nil, errors.Newf("unsupported type %T", t)
+	}
+}
+
+func (g *jsonSchemaGen) nullable(typ *schema.Type) (object, error) {
+	s, err := g.schema(typ)
+	if err != nil {
+		return nil, err
+	}
+	return object{"anyOf": []any{s, object{"type": "null"}}}, nil
+}
+
+// named returns a reference to the definition of a named type,
+// adding it to the definitions if needed.
+func (g *jsonSchemaGen) named(named *schema.Named) (object, error) {
+	name, seen := g.name(named)
+	ref := object{"$ref": "#/$defs/" + name}
+	if seen {
+		return ref, nil
+	}
+
+	// Add a placeholder before computing the definition to handle recursive types.
+	g.defs[name] = nil
+	concrete, err := g.concrete(named)
+	if err != nil {
+		return nil, err
+	}
+	def, err := g.schema(concrete)
+	if err != nil {
+		return nil, errors.Wrapf(err, "type %s", name)
+	}
+	if doc := g.doc(named); doc != "" {
+		def["description"] = doc
+	}
+	g.defs[name] = def
+	return ref, nil
+}
+
+func jsonSchemaBuiltin(b schema.Builtin) (object, error) {
+	integer := func(min, max float64) object {
+		s := object{"type": "integer", "minimum": min}
+		if max > 0 {
+			s["maximum"] = max
+		}
+		return s
+	}
+
+	switch b {
+	case schema.Builtin_ANY, schema.Builtin_JSON:
+		return object{}, nil
+	case schema.Builtin_BOOL:
+		return object{"type": "boolean"}, nil
+	case schema.Builtin_INT8:
+		return integer(math.MinInt8, math.MaxInt8), nil
+	case schema.Builtin_INT16:
+		return integer(math.MinInt16, math.MaxInt16), nil
+	case schema.Builtin_INT32:
+		return integer(math.MinInt32, math.MaxInt32), nil
+	case schema.Builtin_INT64, schema.Builtin_INT:
+		return object{"type": "integer"}, nil
+	case schema.Builtin_UINT8:
+		return integer(0, math.MaxUint8), nil
+	case schema.Builtin_UINT16:
+		return integer(0, math.MaxUint16), nil
+	case schema.Builtin_UINT32:
+		return integer(0, math.MaxUint32), nil
+	case schema.Builtin_UINT64, schema.Builtin_UINT:
+		return integer(0, 0), nil
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return object{"type": "number"}, nil
+	case schema.Builtin_STRING, schema.Builtin_USER_ID, schema.Builtin_DECIMAL:
+		return object{"type": "string"}, nil
+	case schema.Builtin_BYTES, schema.Builtin_FILE:
+		return object{"type": "string", "contentEncoding": "base64"}, nil
+	case schema.Builtin_TIME:
+		return object{"type": "string", "format": "date-time"}, nil
+	case schema.Builtin_UUID:
+		return object{"type": "string", "format": "uuid"}, nil
+	default:
+		return nil, errors.Newf("unsupported builtin type %v", b)
+	}
+}
+
+// literalValues returns the values of a union of non-null literals.
+func literalValues(types []*schema.Type) ([]any, bool) {
+	var values []any
+	for _, t := range types {
+		lit := t.GetLiteral()
+		if lit == nil || lit.GetValue() == nil {
+			return nil, false
+		} else if _, ok := lit.Value.(*schema.Literal_Null); ok {
+			return nil, false
+		}
+		values = append(values, literalValue(lit))
+	}
+	return values, true
+}
+
+func literalValue(lit *schema.Literal) any {
+	switch v := lit.Value.(type) {
+	case *schema.Literal_Str:
+		return v.Str
+	case *schema.Literal_Int:
+		return v.Int
+	case *schema.Literal_Float:
+		return v.Float
+	case *schema.Literal_Boolean:
+		return v.Boolean
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,308 @@
+// Package schemaexport exports the request, response and Pub/Sub message types
+// of an Encore app as JSON Schema and Avro definitions, for integration with
+// data pipelines and schema registries.
+package schemaexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/appfile"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// Format is a schema format.
+type Format string
+
+const (
+	JSONSchema Format = "jsonschema"
+	Avro       Format = "avro"
+)
+
+// Formats are the supported formats.
+var Formats = []Format{JSONSchema, Avro}
+
+// ParseFormat parses a format name.
+func ParseFormat(s string) (Format, error) {
+	if f := Format(s); slices.Contains(Formats, f) {
+		return f, nil
+	}
+	return "", errors.Newf("unknown schema format %q (supported formats are %q and %q)", s, JSONSchema, Avro)
+}
+
+// Ext returns the file extension of schemas in the format.
+func (f Format) Ext() string {
+	if f == Avro {
+		return ".avsc"
+	}
+	return ".json"
+}
+
+// Schema is an exported schema.
+type Schema struct {
+	// Name is the name of the schema, in the form "service/Endpoint.request",
+	// "service/Endpoint.response" or "pubsub/topic".
+	Name string
+
+	// Content is the schema definition.
+	Content []byte
+}
+
+// Export exports a schema for the request and response type of each endpoint,
+// and the message type of each Pub/Sub topic, in the given format.
+// Each schema is self-contained, including the definitions of all types it references.
+func Export(md *meta.Data, format Format) ([]*Schema, error) {
+	var result []*Schema
+	add := func(name, typeName string, doc string, typ *schema.Type) error {
+		var (
+			def any
+			err error
+		)
+		switch format {
+		case JSONSchema:
+			def, err = newJSONSchemaGen(md).document(typ, typeName, doc)
+		case Avro:
+			def, err = newAvroGen(md).document(typ, typeName, doc)
+		default:
+			return errors.Newf("unknown schema format %q", format)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "export schema %s", name)
+		}
+
+		data, err := json.MarshalIndent(def, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "export schema %s", name)
+		}
+		result = append(result, &Schema{Name: name, Content: append(data, '\n')})
+		return nil
+	}
+
+	for _, svc := range md.Svcs {
+		for _, rpc := range svc.Rpcs {
+			if rpc.RequestSchema != nil {
+				name := svc.Name + "/" + rpc.Name + ".request"
+				if err := add(name, svc.Name+"."+rpc.Name+"Request", rpc.GetDoc(), rpc.RequestSchema); err != nil {
+					return nil, err
+				}
+			}
+			if rpc.ResponseSchema != nil {
+				name := svc.Name + "/" + rpc.Name + ".response"
+				if err := add(name, svc.Name+"."+rpc.Name+"Response", rpc.GetDoc(), rpc.ResponseSchema); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	for _, topic := range md.PubsubTopics {
+		if topic.MessageType == nil {
+			continue
+		}
+		typeName := "pubsub." + pascalCase(topic.Name) + "Message"
+		if err := add("pubsub/"+topic.Name, typeName, topic.GetDoc(), topic.MessageType); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Write regenerates the schemas of the app at appRoot in the formats
+// configured in its app file. It does nothing if the app does not
+// configure schema exports.
+func Write(appRoot string, md *meta.Data) error {
+	cfg, err := appfile.SchemaExportConfig(appRoot)
+	if err != nil {
+		return err
+	} else if cfg == nil {
+		return nil
+	}
+
+	formats := Formats
+	if len(cfg.Formats) > 0 {
+		formats = nil
+		for _, s := range cfg.Formats {
+			f, err := ParseFormat(s)
+			if err != nil {
+				return err
+			}
+			formats = append(formats, f)
+		}
+	}
+
+	output := cfg.Output
+	if output == "" {
+		output = "schemas"
+	}
+	for _, format := range formats {
+		schemas, err := Export(md, format)
+		if err != nil {
+			return err
+		}
+		dir := filepath.Join(appRoot, output, string(format))
+		if err := writeSchemas(dir, format, schemas); err != nil {
+			return errors.Wrapf(err, "write %s schemas", format)
+		}
+	}
+	return nil
+}
+
+// writeSchemas writes the schemas to dir, leaving unchanged files as is
+// and removing schemas of types that no longer exist.
+func writeSchemas(dir string, format Format, schemas []*Schema) error {
+	files := make(map[string][]byte, len(schemas))
+	for _, s := range schemas {
+		files[filepath.FromSlash(s.Name)+format.Ext()] = s.Content
+	}
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil || d.IsDir() || filepath.Ext(p) != format.Ext() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if _, ok := files[rel]; !ok {
+			return os.Remove(p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for name, data := range files {
+		dst := filepath.Join(dir, name)
+		if existing, err := os.ReadFile(dst); err == nil && bytes.Equal(existing, data) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namer allocates unique definition names for named types.
+type namer struct {
+	md    *meta.Data
+	names map[string]string // definition name -> type key
+}
+
+func newNamer(md *meta.Data) *namer {
+	return &namer{md: md, names: make(map[string]string)}
+}
+
+// name returns the definition name of a named type, and whether
+// it has been allocated before.
+func (n *namer) name(named *schema.Named) (string, bool) {
+	key := typeKey(n.md, &schema.Type{Typ: &schema.Type_Named{Named: named}})
+	orig := key
+	for idx := 1; ; idx++ {
+		candidate := orig
+		if idx > 1 {
+			candidate += fmt.Sprintf("_%d", idx)
+		}
+		// The key includes the package name but not the package path,
+		// so different declarations may end up with the same name.
+		if existing, ok := n.names[candidate]; ok {
+			if existing == declKey(n.md, named) {
+				return candidate, true
+			}
+			continue
+		}
+		n.names[candidate] = declKey(n.md, named)
+		return candidate, false
+	}
+}
+
+// concrete returns the underlying type of a named type, with type arguments resolved.
+func (n *namer) concrete(named *schema.Named) (*schema.Type, error) {
+	return encoding.GetConcreteType(n.md.Decls, &schema.Type{Typ: &schema.Type_Named{Named: named}}, nil)
+}
+
+// doc returns the documentation of a named type.
+func (n *namer) doc(named *schema.Named) string {
+	return strings.TrimSpace(n.md.Decls[named.Id].Doc)
+}
+
+// declKey uniquely identifies an instantiation of a declaration.
+func declKey(md *meta.Data, named *schema.Named) string {
+	key := fmt.Sprintf("%d", named.Id)
+	for _, arg := range named.TypeArguments {
+		key += "," + typeKey(md, arg)
+	}
+	return key
+}
+
+// typeKey returns a readable name for a type, like "users.Page_users_User".
+func typeKey(md *meta.Data, typ *schema.Type) string {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl := md.Decls[t.Named.Id]
+		name := decl.Loc.PkgName + "." + decl.Name
+		for _, arg := range t.Named.TypeArguments {
+			// Keep the package name of the type as the only dotted part of the name.
+			name += "_" + strings.ReplaceAll(typeKey(md, arg), ".", "_")
+		}
+		return name
+	case *schema.Type_List:
+		return "List_" + typeKey(md, t.List.Elem)
+	case *schema.Type_Map:
+		return "Map_" + typeKey(md, t.Map.Key) + "_" + typeKey(md, t.Map.Value)
+	case *schema.Type_Pointer:
+		return typeKey(md, t.Pointer.Base)
+	case *schema.Type_Option:
+		return "Option_" + typeKey(md, t.Option.Value)
+	case *schema.Type_Config:
+		return typeKey(md, t.Config.Elem)
+	case *schema.Type_Builtin:
+		return strings.ToLower(t.Builtin.String())
+	default:
+		return "Anonymous"
+	}
+}
+
+// fieldName returns the name of a field when encoded as JSON,
+// or "" if the field is not encoded.
+func fieldName(f *schema.Field) string {
+	if f.JsonName == "-" || encoding.IgnoreField(f) {
+		return ""
+	} else if f.JsonName != "" {
+		return f.JsonName
+	}
+	return f.Name
+}
+
+// pascalCase converts a name like "order-created" to "OrderCreated".
+func pascalCase(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		switch {
+		case r == '-' || r == '_' || r == '.' || r == ' ':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
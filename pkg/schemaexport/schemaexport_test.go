@@ -0,0 +1,146 @@
+package schemaexport
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/golden"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func builtin(b schema.Builtin) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Builtin{Builtin: b}}
+}
+
+func named(id uint32, args ...*schema.Type) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: id, TypeArguments: args}}}
+}
+
+func list(elem *schema.Type) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_List{List: &schema.List{Elem: elem}}}
+}
+
+func strLit(s string) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Literal{Literal: &schema.Literal{Value: &schema.Literal_Str{Str: s}}}}
+}
+
+func structType(fields ...*schema.Field) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: fields}}}
+}
+
+func testMeta() *meta.Data {
+	loc := &schema.Loc{PkgPath: "users", PkgName: "users"}
+	deprecated := "Use Emails instead."
+	decls := []*schema.Decl{
+		{
+			Id: 0, Name: "User", Loc: loc, Doc: "User is a user of the system.\n",
+			Type: structType(
+				&schema.Field{Name: "ID", JsonName: "id", Typ: builtin(schema.Builtin_UUID)},
+				&schema.Field{Name: "Email", JsonName: "email", Typ: &schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{Base: builtin(schema.Builtin_STRING)}}}, Optional: true, Deprecated: &deprecated},
+				&schema.Field{Name: "Emails", JsonName: "emails", Typ: list(builtin(schema.Builtin_STRING))},
+				&schema.Field{Name: "Role", JsonName: "role", Doc: "The role of the user.", Typ: &schema.Type{Typ: &schema.Type_Union{Union: &schema.Union{Types: []*schema.Type{strLit("admin"), strLit("member")}}}}},
+				&schema.Field{Name: "CreatedAt", JsonName: "created-at", Typ: builtin(schema.Builtin_TIME)},
+				&schema.Field{Name: "Attrs", JsonName: "attrs", Typ: &schema.Type{Typ: &schema.Type_Map{Map: &schema.Map{Key: builtin(schema.Builtin_STRING), Value: builtin(schema.Builtin_INT32)}}}},
+				&schema.Field{Name: "Manager", JsonName: "manager", Typ: &schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{Base: named(0)}}}, Optional: true},
+				&schema.Field{Name: "Internal", JsonName: "-", Typ: builtin(schema.Builtin_STRING)},
+			),
+		},
+		{
+			Id: 1, Name: "Page", Loc: loc, TypeParams: []*schema.TypeParameter{{Name: "T"}},
+			Type: structType(
+				&schema.Field{Name: "Items", JsonName: "items", Typ: list(&schema.Type{Typ: &schema.Type_TypeParameter{TypeParameter: &schema.TypeParameterRef{DeclId: 1, ParamIdx: 0}}})},
+				&schema.Field{Name: "Next", JsonName: "next", Typ: builtin(schema.Builtin_STRING), Optional: true},
+			),
+		},
+		{
+			Id: 2, Name: "ListParams", Loc: loc,
+			Type: structType(
+				&schema.Field{Name: "Limit", JsonName: "limit", Typ: builtin(schema.Builtin_UINT16)},
+			),
+		},
+	}
+
+	doc := "List lists users."
+	topicDoc := "Signups are published when a user signs up."
+	return &meta.Data{
+		Decls: decls,
+		Svcs: []*meta.Service{{
+			Name: "users",
+			Rpcs: []*meta.RPC{{
+				Name:           "List",
+				ServiceName:    "users",
+				Doc:            &doc,
+				RequestSchema:  named(2),
+				ResponseSchema: named(1, named(0)),
+			}},
+		}},
+		PubsubTopics: []*meta.PubSubTopic{{
+			Name: "user-signups",
+			Doc:  &topicDoc,
+			MessageType: structType(
+				&schema.Field{Name: "User", JsonName: "user", Typ: named(0)},
+				&schema.Field{Name: "Source", JsonName: "source", Typ: structType(
+					&schema.Field{Name: "Referrer", JsonName: "referrer", Typ: builtin(schema.Builtin_STRING)},
+				)},
+			),
+		}},
+	}
+}
+
+func TestExport(t *testing.T) {
+	c := qt.New(t)
+	md := testMeta()
+	for _, format := range Formats {
+		schemas, err := Export(md, format)
+		c.Assert(err, qt.IsNil)
+		c.Assert(schemas, qt.HasLen, 3)
+		for _, s := range schemas {
+			golden.TestAgainst(c, filepath.Join(string(format), s.Name+format.Ext()), string(s.Content))
+		}
+	}
+}
+
+func TestAvroRecursiveNonRecord(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		Decls: []*schema.Decl{{Id: 0, Name: "Tree", Loc: &schema.Loc{PkgName: "svc"}, Type: list(named(0))}},
+		PubsubTopics: []*meta.PubSubTopic{{
+			Name:        "trees",
+			MessageType: named(0),
+		}},
+	}
+	_, err := Export(md, Avro)
+	c.Assert(err, qt.ErrorMatches, `.*recursive type svc.Tree cannot be represented in Avro`)
+
+	_, err = Export(md, JSONSchema)
+	c.Assert(err, qt.IsNil)
+}
+
+func TestWrite(t *testing.T) {
+	c := qt.New(t)
+	root := t.TempDir()
+	c.Assert(os.WriteFile(filepath.Join(root, "encore.app"), []byte(`{"schema_export": {"formats": ["avro"], "output": "out"}}`), 0644), qt.IsNil)
+
+	stale := filepath.Join(root, "out", "avro", "users", "Removed.request.avsc")
+	c.Assert(os.MkdirAll(filepath.Dir(stale), 0755), qt.IsNil)
+	c.Assert(os.WriteFile(stale, []byte("{}"), 0644), qt.IsNil)
+
+	c.Assert(Write(root, testMeta()), qt.IsNil)
+
+	_, err := os.Stat(stale)
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+	for _, name := range []string{"users/List.request.avsc", "users/List.response.avsc", "pubsub/user-signups.avsc"} {
+		_, err := os.Stat(filepath.Join(root, "out", "avro", filepath.FromSlash(name)))
+		c.Assert(err, qt.IsNil)
+	}
+	_, err = os.Stat(filepath.Join(root, "out", "jsonschema"))
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+}
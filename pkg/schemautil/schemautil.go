@@ -212,6 +212,8 @@ func renderBuiltinValue(buf *bytes.Buffer, b schema.Builtin, urlEncode bool) {
 		value = "\"userID\""
 	case schema.Builtin_DECIMAL:
 		value = "\"0.0\""
+	case schema.Builtin_FILE:
+		value = "\"\" /* file */"
 	default:
 		value = "<unknown>"
 	}
@@ -265,6 +267,12 @@ func renderFieldValueAsQueryParam(typ *schema.Type) string {
 	return buf.String()
 }
 
+// RequestFieldsByLocation categorizes the fields of a request struct
+// by their HTTP location, for a request made with the given method.
+func RequestFieldsByLocation(s *schema.Struct, method string) map[FieldLocation][]DescribedField {
+	return splitFieldsByLocation(s, method, false)
+}
+
 // splitFieldsByLocation categorizes struct fields by their HTTP location
 func splitFieldsByLocation(s *schema.Struct, method string, asResponse bool) map[FieldLocation][]DescribedField {
 	result := make(map[FieldLocation][]DescribedField)
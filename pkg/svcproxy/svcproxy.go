@@ -2,11 +2,13 @@ package svcproxy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/netip"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -24,7 +26,7 @@ type SvcProxy struct {
 
 	mu       sync.RWMutex
 	gateways map[string]*httputil.ReverseProxy // Map of the gateway name to address and port it's listening on
-	services map[string]*httputil.ReverseProxy // Map of service name to address and port it's listening on
+	services map[string]http.Handler           // Map of service name to the handler proxying requests to it
 }
 
 var (
@@ -45,7 +47,7 @@ func New(ctx context.Context, logger zerolog.Logger) (*SvcProxy, error) {
 		listener: ln,
 		logger:   logger,
 		gateways: make(map[string]*httputil.ReverseProxy),
-		services: make(map[string]*httputil.ReverseProxy),
+		services: make(map[string]http.Handler),
 	}
 
 	proxy.httpServer = &http.Server{
@@ -89,6 +91,53 @@ func (p *SvcProxy) RegisterService(name string, addr netip.AddrPort) string {
 	return fmt.Sprintf("http://%s/service/%s", p.listener.Addr().String(), name)
 }
 
+// RegisterUpstream registers a service hosted by another environment, reached at the
+// upstream base URL, and returns the BaseURL to be used to access the service.
+//
+// Requests are forwarded as external requests: the authentication and metadata
+// of internal calls, which the upstream environment can't verify, are removed.
+func (p *SvcProxy) RegisterUpstream(name string, upstream *url.URL) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	prefix := fmt.Sprintf("/service/%s", name)
+	p.services[name] = &httputil.ReverseProxy{
+		Rewrite: func(request *httputil.ProxyRequest) {
+			request.Out.URL.Path = strings.TrimPrefix(request.In.URL.Path, prefix)
+			request.Out.URL.RawPath = ""
+			request.SetURL(upstream)
+			request.Out.Header.Del("X-Encore-Auth")
+			for key := range request.Out.Header {
+				if strings.HasPrefix(key, "X-Encore-Meta-") {
+					request.Out.Header.Del(key)
+				}
+			}
+		},
+		ErrorLog: logging.NewZeroLogAdapter(p.logger.With().Str("service", name).Logger(), zerolog.ErrorLevel),
+	}
+
+	return fmt.Sprintf("http://%s%s", p.listener.Addr().String(), prefix)
+}
+
+// RegisterStub registers a service that isn't running, whose calls fail
+// with an "unavailable" error, and returns the BaseURL to be used to access the service.
+func (p *SvcProxy) RegisterStub(name string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.services[name] = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code":    "unavailable",
+			"message": fmt.Sprintf("service %s is not running locally; use --upstream to proxy calls to it", name),
+			"details": nil,
+		})
+	})
+
+	return fmt.Sprintf("http://%s/service/%s", p.listener.Addr().String(), name)
+}
+
 func (p *SvcProxy) createReverseProxy(what, name string, listener netip.AddrPort) *httputil.ReverseProxy {
 	return &httputil.ReverseProxy{
 		// This transport is copied from the default transport in the http package just with the dial context
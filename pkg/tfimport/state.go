@@ -0,0 +1,115 @@
+package tfimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// state is the subset of the Terraform state format we use,
+// as written by Terraform and output by `terraform state pull`.
+type state struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   any            `json:"index_key"`
+			Attributes map[string]any `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// object is an instance of a managed resource in the state.
+type object struct {
+	Address string // the resource address, like `module.db.aws_db_instance.main["a"]`
+	Type    string // the resource type, like "aws_db_instance"
+	Attrs   map[string]any
+}
+
+// parseState parses the objects in the Terraform state data.
+func parseState(data []byte) ([]object, error) {
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("invalid terraform state: %v", err)
+	}
+	if st.Version != 4 {
+		return nil, fmt.Errorf("unsupported terraform state version %d, expected 4", st.Version)
+	}
+
+	var objs []object
+	for _, r := range st.Resources {
+		if r.Mode != "managed" {
+			continue
+		}
+		addr := r.Type + "." + r.Name
+		if r.Module != "" {
+			addr = r.Module + "." + addr
+		}
+		for _, inst := range r.Instances {
+			a := addr
+			switch key := inst.IndexKey.(type) {
+			case float64:
+				a += "[" + strconv.Itoa(int(key)) + "]"
+			case string:
+				a += "[" + strconv.Quote(key) + "]"
+			}
+			objs = append(objs, object{Address: a, Type: r.Type, Attrs: inst.Attributes})
+		}
+	}
+	return objs, nil
+}
+
+// str returns the string attribute at the given path of attribute names,
+// where nested blocks are lists whose first element is used.
+func (o object) str(path ...string) string {
+	v, _ := o.attr(path...).(string)
+	return v
+}
+
+// num returns the number attribute at the given path.
+func (o object) num(path ...string) int {
+	v, _ := o.attr(path...).(float64)
+	return int(v)
+}
+
+// bool returns the bool attribute at the given path.
+func (o object) bool(path ...string) bool {
+	v, _ := o.attr(path...).(bool)
+	return v
+}
+
+func (o object) attr(path ...string) any {
+	var v any = o.Attrs
+	for _, name := range path {
+		if list, ok := v.([]any); ok && len(list) > 0 {
+			v = list[0]
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[name]
+	}
+	return v
+}
+
+// tag returns the value of the given AWS tag or GCP label.
+func (o object) tag(key string) string {
+	if v := o.str("tags", key); v != "" {
+		return v
+	}
+	return o.str("labels", key)
+}
+
+// cloud returns the cloud provider of the object.
+func (o object) cloud() string {
+	provider, _, _ := strings.Cut(o.Type, "_")
+	if provider == "google" {
+		return "gcp"
+	}
+	return provider
+}
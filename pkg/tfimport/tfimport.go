@@ -0,0 +1,455 @@
+// Package tfimport generates infra configs for self-hosting Encore apps on
+// existing cloud resources, by mapping the infrastructure resources declared
+// by an app to the resources recorded in Terraform state.
+//
+// An Encore resource is mapped to the Terraform resource explicitly mapped
+// to it, tagged (or labeled) with its name using [TagKey], named like it,
+// or with a name ending with it, in that order of preference.
+package tfimport
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"encore.dev/appruntime/exported/config/infra"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// TagKey is the AWS tag or GCP label naming the Encore resource a cloud
+// resource is for, for cloud resources not named like the Encore resource.
+const TagKey = "encore-name"
+
+// Options are the options of an import.
+type Options struct {
+	// Mappings maps Encore resource names to the addresses of the Terraform
+	// resources to use for them, like "aws_db_instance.main".
+	Mappings map[string]string
+}
+
+// Result is the result of an import.
+type Result struct {
+	// Config is the infra config using the matched resources.
+	// Credentials are never copied from the state, but referenced
+	// from environment variables.
+	Config *infra.InfraConfig
+
+	Matches   []Match     // the Encore resources mapped to Terraform resources
+	Unmatched []Unmatched // the Encore resources that couldn't be mapped
+}
+
+// Match is an Encore resource mapped to a Terraform resource.
+type Match struct {
+	Kind    string // the kind of resource, like "database"
+	Name    string // the Encore name; "topic/subscription" for subscriptions
+	Address string // the address of the Terraform resource
+}
+
+// Unmatched is an Encore resource that couldn't be mapped to a Terraform resource.
+type Unmatched struct {
+	Kind   string
+	Name   string
+	Reason string
+}
+
+var errNotFound = errors.New("no matching terraform resource")
+
+// Import maps the resources declared in md to the resources
+// in the Terraform state data.
+func Import(data []byte, md *meta.Data, opts Options) (*Result, error) {
+	objs, err := parseState(data)
+	if err != nil {
+		return nil, err
+	}
+	for name, addr := range opts.Mappings {
+		if !slices.ContainsFunc(objs, func(o object) bool { return o.Address == addr }) {
+			return nil, fmt.Errorf("invalid mapping of %s: no terraform resource %s", name, addr)
+		}
+	}
+
+	im := &importer{
+		objs:   objs,
+		opts:   opts,
+		res:    &Result{Config: &infra.InfraConfig{}},
+		clouds: make(map[string]bool),
+	}
+	im.databases(md)
+	im.caches(md)
+	im.pubsub(md)
+	im.buckets(md)
+
+	if len(im.clouds) == 1 {
+		for cloud := range im.clouds {
+			im.res.Config.Metadata.Cloud = cloud
+		}
+	}
+	return im.res, nil
+}
+
+type importer struct {
+	objs   []object
+	opts   Options
+	res    *Result
+	clouds map[string]bool // the clouds of the matched objects
+}
+
+func (im *importer) matched(kind, name string, o object) {
+	im.res.Matches = append(im.res.Matches, Match{Kind: kind, Name: name, Address: o.Address})
+	im.clouds[o.cloud()] = true
+}
+
+func (im *importer) unmatched(kind, name string, err error) {
+	im.res.Unmatched = append(im.res.Unmatched, Unmatched{Kind: kind, Name: name, Reason: err.Error()})
+}
+
+// ofType returns the objects of the given types.
+func (im *importer) ofType(types ...string) []object {
+	var objs []object
+	for _, o := range im.objs {
+		if slices.Contains(types, o.Type) {
+			objs = append(objs, o)
+		}
+	}
+	return objs
+}
+
+// find returns the object among candidates to use for the Encore resource
+// with the given name, where names returns the cloud names of an object.
+func (im *importer) find(name string, candidates []object, names func(o object) []string) (object, error) {
+	if addr, ok := im.opts.Mappings[name]; ok {
+		for _, o := range candidates {
+			if o.Address == addr {
+				return o, nil
+			}
+		}
+		return object{}, fmt.Errorf("mapped to %s, which is not a supported resource of this kind", addr)
+	}
+
+	want := normalize(name)
+	levels := []func(o object) bool{
+		func(o object) bool { return o.tag(TagKey) == name },
+		func(o object) bool {
+			return slices.ContainsFunc(names(o), func(n string) bool { return normalize(n) == want })
+		},
+		func(o object) bool {
+			return slices.ContainsFunc(names(o), func(n string) bool { return strings.HasSuffix(normalize(n), "-"+want) })
+		},
+	}
+	for _, matches := range levels {
+		var found []object
+		for _, o := range candidates {
+			if matches(o) {
+				found = append(found, o)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			addrs := make([]string, len(found))
+			for i, o := range found {
+				addrs[i] = o.Address
+			}
+			return object{}, fmt.Errorf("ambiguous, matching %s; map it explicitly", strings.Join(addrs, ", "))
+		}
+	}
+	return object{}, errNotFound
+}
+
+// normalize normalizes a resource name for comparison,
+// as cloud names often use hyphens where Encore names use underscores.
+func normalize(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// names returns a function returning the given string attributes of an object.
+func names(attrs ...string) func(o object) []string {
+	return func(o object) []string {
+		var names []string
+		for _, a := range attrs {
+			if v := o.str(a); v != "" {
+				names = append(names, v)
+			}
+		}
+		return names
+	}
+}
+
+// envName returns the name of the environment variable holding
+// the given credential of an Encore resource, like "USERS_DB_PASSWORD".
+func envName(name, suffix string) string {
+	name = strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+	return name + "_" + suffix
+}
+
+func envRef(name string) infra.EnvString {
+	return infra.EnvString{Env: &infra.EnvRef{Env: name}}
+}
+
+func hostPort(host string, port int) string {
+	if port == 0 {
+		return host
+	}
+	return host + ":" + strconv.Itoa(port)
+}
+
+var sqlServerTypes = []string{"aws_db_instance", "aws_rds_cluster", "google_sql_database_instance"}
+
+func (im *importer) databases(md *meta.Data) {
+	servers := make(map[string]*infra.SQLServer) // by address
+	serverObjs := im.ofType(sqlServerTypes...)
+	for _, db := range md.SqlDatabases {
+		server, dbName, err := im.findDatabase(db.Name, serverObjs)
+		if err != nil {
+			im.unmatched("database", db.Name, err)
+			continue
+		}
+		im.matched("database", db.Name, server)
+
+		s, ok := servers[server.Address]
+		if !ok {
+			s = &infra.SQLServer{Databases: make(map[string]*infra.SQLDatabase)}
+			switch server.Type {
+			case "aws_db_instance":
+				s.Host = hostPort(server.str("address"), server.num("port"))
+			case "aws_rds_cluster":
+				s.Host = hostPort(server.str("endpoint"), server.num("port"))
+			case "google_sql_database_instance":
+				host := server.str("private_ip_address")
+				if host == "" {
+					host = server.str("public_ip_address")
+				}
+				s.Host = hostPort(host, 5432)
+			}
+			servers[server.Address] = s
+			im.res.Config.SQLServers = append(im.res.Config.SQLServers, s)
+		}
+
+		username := envRef(envName(db.Name, "DB_USERNAME"))
+		if user := server.str("username"); user != "" {
+			username = infra.EnvString{Str: user}
+		} else if user := server.str("master_username"); user != "" {
+			username = infra.EnvString{Str: user}
+		}
+		s.Databases[db.Name] = &infra.SQLDatabase{
+			Name:     dbName,
+			Username: username,
+			Password: envRef(envName(db.Name, "DB_PASSWORD")),
+		}
+	}
+}
+
+// findDatabase returns the server object to use for the database
+// with the given name, and the cloud name of the database.
+func (im *importer) findDatabase(name string, servers []object) (server object, dbName string, err error) {
+	// GCP databases are resources of their own.
+	if db, err := im.find(name, im.ofType("google_sql_database"), names("name")); err == nil {
+		for _, s := range servers {
+			if s.Type == "google_sql_database_instance" && s.str("name") == db.str("instance") {
+				return s, db.str("name"), nil
+			}
+		}
+		return object{}, "", fmt.Errorf("instance %s of %s not found", db.str("instance"), db.Address)
+	} else if !errors.Is(err, errNotFound) {
+		return object{}, "", err
+	}
+
+	server, err = im.find(name, servers, names("identifier", "cluster_identifier", "db_name", "database_name", "name"))
+	if errors.Is(err, errNotFound) && len(servers) == 1 {
+		// Apps commonly keep all their databases on a single server.
+		server, err = servers[0], nil
+	}
+	if err != nil {
+		return object{}, "", err
+	}
+
+	dbName = name
+	for _, attr := range []string{"db_name", "database_name"} {
+		if n := server.str(attr); n != "" && normalize(n) == normalize(name) {
+			dbName = n
+		}
+	}
+	return server, dbName, nil
+}
+
+func (im *importer) caches(md *meta.Data) {
+	objs := im.ofType("aws_elasticache_replication_group", "aws_elasticache_cluster", "google_redis_instance")
+	for _, cluster := range md.CacheClusters {
+		o, err := im.find(cluster.Name, objs, names("replication_group_id", "cluster_id", "name"))
+		if err != nil {
+			im.unmatched("cache", cluster.Name, err)
+			continue
+		}
+		im.matched("cache", cluster.Name, o)
+
+		r := &infra.Redis{}
+		var tls, auth bool
+		switch o.Type {
+		case "aws_elasticache_replication_group":
+			host := o.str("primary_endpoint_address")
+			if host == "" {
+				host = o.str("configuration_endpoint_address")
+			}
+			r.Host = hostPort(host, o.num("port"))
+			tls = o.bool("transit_encryption_enabled")
+			auth = o.str("auth_token") != ""
+		case "aws_elasticache_cluster":
+			r.Host = hostPort(o.str("cache_nodes", "address"), o.num("port"))
+			tls = o.bool("transit_encryption_enabled")
+		case "google_redis_instance":
+			r.Host = hostPort(o.str("host"), o.num("port"))
+			tls = o.str("transit_encryption_mode") == "SERVER_AUTHENTICATION"
+			auth = o.bool("auth_enabled")
+		}
+		if tls {
+			r.TLSConfig = &infra.TLSConfig{}
+		} else {
+			r.TLSConfig = &infra.TLSConfig{Disabled: true}
+		}
+		if auth {
+			ref := envRef(envName(cluster.Name, "REDIS_AUTH"))
+			r.Auth = &infra.RedisAuth{Type: "auth_string", AuthString: &ref}
+		}
+
+		if im.res.Config.Redis == nil {
+			im.res.Config.Redis = make(map[string]*infra.Redis)
+		}
+		im.res.Config.Redis[cluster.Name] = r
+	}
+}
+
+func (im *importer) pubsub(md *meta.Data) {
+	var (
+		aws *infra.AWSSNS_SQS
+		gcp *infra.GCPPubsub
+	)
+	topicObjs := im.ofType("aws_sns_topic", "google_pubsub_topic")
+	for _, topic := range md.PubsubTopics {
+		o, err := im.find(topic.Name, topicObjs, names("name"))
+		if err != nil {
+			im.unmatched("topic", topic.Name, err)
+			continue
+		}
+		im.matched("topic", topic.Name, o)
+
+		switch o.Type {
+		case "aws_sns_topic":
+			if aws == nil {
+				aws = &infra.AWSSNS_SQS{Topics: make(map[string]*infra.AWSTopic)}
+				im.res.Config.PubSub = append(im.res.Config.PubSub, &infra.PubSub{Type: "aws_sns_sqs", AWS: aws})
+			}
+			t := &infra.AWSTopic{ARN: o.str("arn"), Subscriptions: make(map[string]*infra.AWSSub)}
+			aws.Topics[topic.Name] = t
+
+			// Only consider the queues subscribed to the topic.
+			var queues []object
+			for _, sub := range im.ofType("aws_sns_topic_subscription") {
+				if sub.str("topic_arn") != t.ARN || sub.str("protocol") != "sqs" {
+					continue
+				}
+				for _, q := range im.ofType("aws_sqs_queue") {
+					if q.str("arn") == sub.str("endpoint") {
+						queues = append(queues, q)
+					}
+				}
+			}
+			for _, sub := range topic.Subscriptions {
+				q, ok := im.findSubscription(topic, sub, queues, names("name"))
+				if ok {
+					t.Subscriptions[sub.Name] = &infra.AWSSub{URL: q.str("url")}
+				}
+			}
+
+		case "google_pubsub_topic":
+			if gcp == nil {
+				gcp = &infra.GCPPubsub{Topics: make(map[string]*infra.GCPTopic)}
+				im.res.Config.PubSub = append(im.res.Config.PubSub, &infra.PubSub{Type: "gcp_pubsub", GCP: gcp})
+			}
+			t := &infra.GCPTopic{Name: o.str("name"), ProjectID: o.str("project"), Subscriptions: make(map[string]*infra.GCPSub)}
+			gcp.Topics[topic.Name] = t
+
+			// Only consider the subscriptions of the topic, which is referenced
+			// either by name or by its full resource name.
+			var subs []object
+			for _, s := range im.ofType("google_pubsub_subscription") {
+				ref := s.str("topic")
+				if ref == t.Name || ref == o.str("id") || strings.HasSuffix(ref, "/topics/"+t.Name) {
+					subs = append(subs, s)
+				}
+			}
+			for _, sub := range topic.Subscriptions {
+				s, ok := im.findSubscription(topic, sub, subs, names("name"))
+				if !ok {
+					continue
+				}
+				gs := &infra.GCPSub{Name: s.str("name"), ProjectID: s.str("project")}
+				if endpoint := s.str("push_config", "push_endpoint"); endpoint != "" {
+					_, id, _ := strings.Cut(endpoint, "/__encore/pubsub/push/")
+					audience := s.str("push_config", "oidc_token", "audience")
+					if audience == "" {
+						// The audience defaults to the push endpoint.
+						audience = endpoint
+					}
+					gs.PushConfig = &infra.PushConfig{
+						ID:             id,
+						ServiceAccount: s.str("push_config", "oidc_token", "service_account_email"),
+						JWTAudience:    audience,
+					}
+				}
+				t.Subscriptions[sub.Name] = gs
+			}
+		}
+	}
+}
+
+// findSubscription finds the object among candidates, which are the objects
+// subscribed to the topic, to use for the subscription sub.
+func (im *importer) findSubscription(topic *meta.PubSubTopic, sub *meta.PubSubTopic_Subscription, candidates []object, names func(o object) []string) (object, bool) {
+	name := topic.Name + "/" + sub.Name
+	o, err := im.find(sub.Name, candidates, names)
+	if errors.Is(err, errNotFound) && len(candidates) == 1 && len(topic.Subscriptions) == 1 {
+		o, err = candidates[0], nil
+	}
+	if err != nil {
+		im.unmatched("subscription", name, err)
+		return object{}, false
+	}
+	im.matched("subscription", name, o)
+	return o, true
+}
+
+func (im *importer) buckets(md *meta.Data) {
+	var gcs *infra.GCS
+	s3 := make(map[string]*infra.S3) // by region
+	objs := im.ofType("aws_s3_bucket", "google_storage_bucket")
+	for _, bkt := range md.Buckets {
+		o, err := im.find(bkt.Name, objs, names("bucket", "name"))
+		if err != nil {
+			im.unmatched("bucket", bkt.Name, err)
+			continue
+		}
+		im.matched("bucket", bkt.Name, o)
+
+		switch o.Type {
+		case "aws_s3_bucket":
+			region := o.str("region")
+			s, ok := s3[region]
+			if !ok {
+				s = &infra.S3{Region: region, Buckets: make(map[string]*infra.Bucket)}
+				s3[region] = s
+				im.res.Config.ObjectStorage = append(im.res.Config.ObjectStorage, &infra.ObjectStorage{Type: "s3", S3: s})
+			}
+			s.Buckets[bkt.Name] = &infra.Bucket{Name: o.str("bucket")}
+		case "google_storage_bucket":
+			if gcs == nil {
+				gcs = &infra.GCS{Buckets: make(map[string]*infra.Bucket)}
+				im.res.Config.ObjectStorage = append(im.res.Config.ObjectStorage, &infra.ObjectStorage{Type: "gcs", GCS: gcs})
+			}
+			gcs.Buckets[bkt.Name] = &infra.Bucket{Name: o.str("name")}
+		}
+	}
+}
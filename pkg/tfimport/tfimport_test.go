@@ -0,0 +1,179 @@
+package tfimport
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func testMeta() *meta.Data {
+	return &meta.Data{
+		SqlDatabases:  []*meta.SQLDatabase{{Name: "users"}, {Name: "analytics"}},
+		CacheClusters: []*meta.CacheCluster{{Name: "sessions"}},
+		PubsubTopics: []*meta.PubSubTopic{
+			{Name: "signups", Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "welcome-email"}}},
+			{Name: "orders", Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "fulfil"}}},
+		},
+		Buckets: []*meta.Bucket{{Name: "avatars"}},
+	}
+}
+
+func TestImportAWS(t *testing.T) {
+	c := qt.New(t)
+	data, err := os.ReadFile("testdata/aws.tfstate")
+	c.Assert(err, qt.IsNil)
+
+	res, err := Import(data, testMeta(), Options{})
+	c.Assert(err, qt.IsNil)
+
+	got, err := json.MarshalIndent(res.Config, "", "  ")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(got), qt.JSONEquals, json.RawMessage(`{
+		"metadata": {"cloud": "aws"},
+		"sql_servers": [{
+			"host": "myapp-prod.abc123.eu-west-1.rds.amazonaws.com:5432",
+			"databases": {
+				"users": {"name": "users", "username": "encore", "password": {"$env": "USERS_DB_PASSWORD"}},
+				"analytics": {"name": "analytics", "username": "encore", "password": {"$env": "ANALYTICS_DB_PASSWORD"}}
+			}
+		}],
+		"redis": {
+			"sessions": {
+				"host": "master.myapp-prod-sessions.abc123.euw1.cache.amazonaws.com:6379",
+				"database_index": 0,
+				"auth": {"type": "auth_string", "auth_string": {"$env": "SESSIONS_REDIS_AUTH"}},
+				"tls_config": {},
+				"in_memory": false
+			}
+		},
+		"pubsub": [{
+			"type": "aws_sns_sqs",
+			"topics": {
+				"signups": {
+					"arn": "arn:aws:sns:eu-west-1:123456789012:myapp-prod-signups",
+					"subscriptions": {
+						"welcome-email": {"url": "https://sqs.eu-west-1.amazonaws.com/123456789012/welcome-emails"}
+					}
+				},
+				"orders": {"arn": "arn:aws:sns:eu-west-1:123456789012:orders-v2"}
+			}
+		}],
+		"secrets": null,
+		"object_storage": [{
+			"type": "s3",
+			"region": "eu-west-1",
+			"secret_access_key": "",
+			"buckets": {"avatars": {"name": "myapp-prod-avatars"}}
+		}]
+	}`))
+
+	c.Assert(res.Matches, qt.DeepEquals, []Match{
+		{Kind: "database", Name: "users", Address: "aws_db_instance.main"},
+		{Kind: "database", Name: "analytics", Address: "aws_db_instance.main"},
+		{Kind: "cache", Name: "sessions", Address: "module.cache.aws_elasticache_replication_group.this"},
+		{Kind: "topic", Name: "signups", Address: `aws_sns_topic.topics["signups"]`},
+		{Kind: "subscription", Name: "signups/welcome-email", Address: "aws_sqs_queue.welcome"},
+		{Kind: "topic", Name: "orders", Address: `aws_sns_topic.topics["orders"]`},
+		{Kind: "bucket", Name: "avatars", Address: "aws_s3_bucket.avatars[0]"},
+	})
+	c.Assert(res.Unmatched, qt.DeepEquals, []Unmatched{
+		{Kind: "subscription", Name: "orders/fulfil", Reason: "no matching terraform resource"},
+	})
+}
+
+func TestImportGCP(t *testing.T) {
+	c := qt.New(t)
+	data, err := os.ReadFile("testdata/gcp.tfstate")
+	c.Assert(err, qt.IsNil)
+
+	md := testMeta()
+	md.SqlDatabases = md.SqlDatabases[:1]
+	md.PubsubTopics = md.PubsubTopics[:1]
+	res, err := Import(data, md, Options{})
+	c.Assert(err, qt.IsNil)
+
+	got, err := json.MarshalIndent(res.Config, "", "  ")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(got), qt.JSONEquals, json.RawMessage(`{
+		"metadata": {"cloud": "gcp"},
+		"sql_servers": [{
+			"host": "10.0.0.5:5432",
+			"databases": {
+				"users": {"name": "users", "username": {"$env": "USERS_DB_USERNAME"}, "password": {"$env": "USERS_DB_PASSWORD"}}
+			}
+		}],
+		"pubsub": [{
+			"type": "gcp_pubsub",
+			"topics": {
+				"signups": {
+					"name": "signups",
+					"project_id": "myapp-prod",
+					"subscriptions": {
+						"welcome-email": {
+							"name": "signups-welcome-email",
+							"project_id": "myapp-prod",
+							"push_config": {
+								"id": "welcome-email",
+								"service_account": "pubsub-push@myapp-prod.iam.gserviceaccount.com",
+								"jwt_audience": "https://api.example.com/__encore/pubsub/push/welcome-email"
+							}
+						}
+					}
+				}
+			}
+		}],
+		"secrets": null,
+		"object_storage": [{
+			"type": "gcs",
+			"buckets": {"avatars": {"name": "myapp-prod-avatars"}}
+		}]
+	}`))
+	c.Assert(res.Unmatched, qt.DeepEquals, []Unmatched{
+		{Kind: "cache", Name: "sessions", Reason: "no matching terraform resource"},
+	})
+}
+
+func TestImportMappings(t *testing.T) {
+	c := qt.New(t)
+	data, err := os.ReadFile("testdata/aws.tfstate")
+	c.Assert(err, qt.IsNil)
+	md := &meta.Data{Buckets: []*meta.Bucket{{Name: "uploads"}}}
+
+	res, err := Import(data, md, Options{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Unmatched, qt.HasLen, 1)
+
+	res, err = Import(data, md, Options{Mappings: map[string]string{"uploads": "aws_s3_bucket.avatars[0]"}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Unmatched, qt.HasLen, 0)
+	c.Assert(res.Config.ObjectStorage[0].S3.Buckets["uploads"].Name, qt.Equals, "myapp-prod-avatars")
+
+	res, err = Import(data, md, Options{Mappings: map[string]string{"uploads": "aws_db_instance.main"}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Unmatched, qt.DeepEquals, []Unmatched{
+		{Kind: "bucket", Name: "uploads", Reason: "mapped to aws_db_instance.main, which is not a supported resource of this kind"},
+	})
+
+	_, err = Import(data, md, Options{Mappings: map[string]string{"uploads": "aws_s3_bucket.missing"}})
+	c.Assert(err, qt.ErrorMatches, "invalid mapping of uploads: no terraform resource aws_s3_bucket.missing")
+}
+
+func TestImportAmbiguous(t *testing.T) {
+	c := qt.New(t)
+	data := []byte(`{"version": 4, "resources": [
+		{"mode": "managed", "type": "aws_s3_bucket", "name": "a", "instances": [{"attributes": {"bucket": "staging-avatars", "region": "eu-west-1"}}]},
+		{"mode": "managed", "type": "aws_s3_bucket", "name": "b", "instances": [{"attributes": {"bucket": "prod-avatars", "region": "eu-west-1"}}]}
+	]}`)
+	res, err := Import(data, &meta.Data{Buckets: []*meta.Bucket{{Name: "avatars"}}}, Options{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Unmatched, qt.DeepEquals, []Unmatched{
+		{Kind: "bucket", Name: "avatars", Reason: "ambiguous, matching aws_s3_bucket.a, aws_s3_bucket.b; map it explicitly"},
+	})
+
+	_, err = Import([]byte(`{"version": 3}`), &meta.Data{}, Options{})
+	c.Assert(err, qt.ErrorMatches, "unsupported terraform state version 3, expected 4")
+}
@@ -316,6 +316,29 @@ func (tp *traceParser) requestSpanStart() *tracepb2.SpanStart {
 				ExtCorrelationId: ptrOrNil(tp.String()),
 				Uid:              ptrOrNil(tp.String()),
 				Mocked:           tp.FromVer(15).Bool(false),
+				DeadlineRemainingNanos: (func() *int64 {
+					if tp.version >= 18 && tp.Bool() {
+						d := int64(tp.Duration())
+						return &d
+					}
+					return nil
+				})(),
+				Client: (func() *tracepb2.ClientInfo {
+					if tp.version >= 19 && tp.Bool() {
+						return &tracepb2.ClientInfo{
+							Ip:             tp.String(),
+							CountryCode:    tp.String(),
+							Country:        tp.String(),
+							Region:         tp.String(),
+							City:           tp.String(),
+							Browser:        tp.String(),
+							BrowserVersion: tp.String(),
+							Os:             tp.String(),
+							Device:         tp.String(),
+						}
+					}
+					return nil
+				})(),
 			},
 		},
 	}
@@ -352,6 +375,13 @@ func (tp *traceParser) requestSpanEnd() *tracepb2.SpanEnd {
 					}
 					return nil
 				})(),
+				CancelCause: (func() *string {
+					if tp.version >= 18 {
+						return tp.OptString()
+					}
+					return nil
+				})(),
+				DeadlineExceeded: tp.FromVer(18).Bool(false),
 			},
 		},
 	}
@@ -3,8 +3,10 @@ package traceparser
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"net/http"
+	"net/netip"
 	"testing"
 	"time"
 
@@ -137,6 +139,134 @@ func TestParse(t *testing.T) {
 			},
 		},
 
+		{
+			Name: "RequestSpanStart/Deadline",
+			Emit: func(l *trace2.Log) {
+				l.RequestSpanStart(&model.Request{
+					Type:     model.RPCCall,
+					TraceID:  traceID,
+					SpanID:   spanID,
+					Start:    now,
+					Deadline: now.Add(2 * time.Second),
+					Traced:   true,
+					DefLoc:   defLoc,
+					RPCData: &model.RPCData{
+						Desc:       &model.RPCDesc{Service: "service", Endpoint: "endpoint"},
+						HTTPMethod: "GET",
+						Path:       "/path",
+					},
+				}, goid)
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+					DefLoc: &udefLoc,
+					Goid:   goid,
+					Data: &tracepb2.SpanStart_Request{
+						Request: &tracepb2.RequestSpanStart{
+							ServiceName:            "service",
+							EndpointName:           "endpoint",
+							HttpMethod:             "GET",
+							Path:                   "/path",
+							RequestHeaders:         map[string]string{},
+							DeadlineRemainingNanos: ptr(int64(2 * time.Second)),
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "RequestSpanStart/Client",
+			Emit: func(l *trace2.Log) {
+				l.RequestSpanStart(&model.Request{
+					Type:    model.RPCCall,
+					TraceID: traceID,
+					SpanID:  spanID,
+					Start:   now,
+					Traced:  true,
+					DefLoc:  defLoc,
+					RPCData: &model.RPCData{
+						Desc:       &model.RPCDesc{Service: "service", Endpoint: "endpoint"},
+						HTTPMethod: "GET",
+						Path:       "/path",
+						Client: &model.ClientInfo{
+							IP:        netip.MustParseAddr("81.2.69.142"),
+							Geo:       &model.GeoInfo{CountryCode: "GB", Country: "United Kingdom", City: "London"},
+							UserAgent: model.UserAgent{Browser: "Firefox", BrowserVersion: "125.0", OS: "Linux", Device: "desktop"},
+						},
+					},
+				}, goid)
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+					DefLoc: &udefLoc,
+					Goid:   goid,
+					Data: &tracepb2.SpanStart_Request{
+						Request: &tracepb2.RequestSpanStart{
+							ServiceName:    "service",
+							EndpointName:   "endpoint",
+							HttpMethod:     "GET",
+							Path:           "/path",
+							RequestHeaders: map[string]string{},
+							Client: &tracepb2.ClientInfo{
+								Ip:             "81.2.69.142",
+								CountryCode:    "GB",
+								Country:        "United Kingdom",
+								City:           "London",
+								Browser:        "Firefox",
+								BrowserVersion: "125.0",
+								Os:             "Linux",
+								Device:         "desktop",
+							},
+						},
+					},
+				}},
+			},
+		},
+
+		{
+			Name: "RequestSpanEnd/DeadlineExceeded",
+			Emit: func(l *trace2.Log) {
+				l.RequestSpanEnd(trace2.RequestSpanEndParams{
+					EventParams: ep,
+					Req: &model.Request{
+						Start:       now,
+						Deadline:    now.Add(time.Second),
+						CancelCause: func() error { return context.DeadlineExceeded },
+						RPCData: &model.RPCData{
+							Desc: &model.RPCDesc{Service: "service", Endpoint: "endpoint"},
+						},
+					},
+					Resp: &model.Response{
+						HTTPStatus: 504,
+						Duration:   2 * time.Second,
+					},
+				})
+			},
+			Want: &tracepb2.TraceEvent{
+				TraceId: pbTraceID,
+				SpanId:  pbSpanID,
+				Event: &tracepb2.TraceEvent_SpanEnd{SpanEnd: &tracepb2.SpanEnd{
+					DurationNanos: uint64(2 * time.Second),
+					Data: &tracepb2.SpanEnd_Request{
+						Request: &tracepb2.RequestSpanEnd{
+							ServiceName:      "service",
+							EndpointName:     "endpoint",
+							HttpStatusCode:   504,
+							ResponseHeaders:  map[string]string{},
+							CallerEventId:    ptr(uint64(0)),
+							CancelCause:      ptr("context deadline exceeded"),
+							DeadlineExceeded: true,
+						},
+					},
+				}},
+			},
+		},
+
 		{
 			Name: "AuthSpanStart",
 			Emit: func(l *trace2.Log) {
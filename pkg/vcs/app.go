@@ -1,6 +1,9 @@
 package vcs
 
 import (
+	"os/exec"
+	"strings"
+
 	"github.com/rs/zerolog/log"
 )
 
@@ -30,3 +33,17 @@ func GetRevision(appRoot string) Status {
 
 	return status
 }
+
+// GitBranch returns the name of the git branch checked out in the repository
+// containing appRoot. It reports false if appRoot isn't in a git repository,
+// or if no branch is checked out, such as when HEAD is detached.
+func GitBranch(appRoot string) (branch string, ok bool) {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD")
+	cmd.Dir = appRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	branch = strings.TrimSpace(string(out))
+	return branch, branch != ""
+}
@@ -0,0 +1,377 @@
+// Package vulnscan scans the dependencies of an app for known vulnerabilities,
+// using govulncheck for Go apps and npm audit for TypeScript apps.
+package vulnscan
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Reachability describes how much of a vulnerable dependency an app uses.
+type Reachability int
+
+const (
+	// ReachabilityUnknown means the scanner does not analyze reachability.
+	ReachabilityUnknown Reachability = iota
+	// ReachabilityRequired means the vulnerable module is a dependency,
+	// but the vulnerable package is not imported.
+	ReachabilityRequired
+	// ReachabilityImported means the vulnerable package is imported,
+	// but the vulnerable code is not called.
+	ReachabilityImported
+	// ReachabilityCalled means the app calls the vulnerable code.
+	ReachabilityCalled
+)
+
+func (r Reachability) String() string {
+	switch r {
+	case ReachabilityRequired:
+		return "required"
+	case ReachabilityImported:
+		return "imported"
+	case ReachabilityCalled:
+		return "called"
+	default:
+		return "unknown"
+	}
+}
+
+// Finding is a vulnerability affecting a dependency of the app.
+type Finding struct {
+	ID           string   // advisory id, such as "GO-2024-2687" or "GHSA-xvch-5gv4-984h"
+	Aliases      []string // other ids of the advisory, such as CVEs
+	Summary      string
+	URL          string
+	Module       string // the affected module or package
+	Version      string // the version in use, if known
+	FixedVersion string // the first version with a fix, if any
+	Severity     string // the severity, if known
+	Reachability Reachability
+
+	// Trace is the call stack from the app's code to the vulnerable
+	// function, outermost first, for findings reachable through calls.
+	Trace []string
+}
+
+// Reachable reports whether the app can reach the vulnerable code,
+// as far as the scanner can tell.
+func (f *Finding) Reachable() bool {
+	return f.Reachability == ReachabilityCalled || f.Reachability == ReachabilityUnknown
+}
+
+// Report is the result of a scan.
+type Report struct {
+	Scanner   string // "govulncheck" or "npm audit"
+	ScannedAt time.Time
+	Findings  []*Finding // sorted by reachability, most reachable first, then by id
+
+	// Hash is the hash of the dependency manifests that were scanned.
+	Hash string
+}
+
+// Reachable returns the findings reachable by the app.
+func (r *Report) Reachable() []*Finding {
+	var fs []*Finding
+	for _, f := range r.Findings {
+		if f.Reachable() {
+			fs = append(fs, f)
+		}
+	}
+	return fs
+}
+
+// Lang is the language of an app.
+type Lang string
+
+const (
+	Go         Lang = "go"
+	TypeScript Lang = "ts"
+)
+
+// manifests returns the files describing the dependencies of an app.
+func manifests(lang Lang) []string {
+	if lang == TypeScript {
+		return []string{"package.json", "package-lock.json"}
+	}
+	return []string{"go.mod", "go.sum"}
+}
+
+// ManifestHash returns a hash of the dependency manifests of the app at appRoot,
+// which changes when its dependencies do.
+func ManifestHash(appRoot string, lang Lang) (string, error) {
+	h := sha256.New()
+	for _, name := range manifests(lang) {
+		data, err := os.ReadFile(filepath.Join(appRoot, name))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		_, _ = io.WriteString(h, name+"\x00")
+		_, _ = h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Scan scans the dependencies of the app at appRoot.
+func Scan(ctx context.Context, appRoot string, lang Lang) (*Report, error) {
+	hash, err := ManifestHash(appRoot, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	var rep *Report
+	if lang == TypeScript {
+		rep, err = scanNpm(ctx, appRoot)
+	} else {
+		rep, err = scanGo(ctx, appRoot)
+	}
+	if err != nil {
+		return nil, err
+	}
+	rep.ScannedAt = time.Now()
+	rep.Hash = hash
+	slices.SortFunc(rep.Findings, func(a, b *Finding) int {
+		return cmp.Or(cmp.Compare(b.Reachability, a.Reachability), cmp.Compare(a.ID, b.ID), cmp.Compare(a.Module, b.Module))
+	})
+	return rep, nil
+}
+
+func scanGo(ctx context.Context, appRoot string) (*Report, error) {
+	bin, err := findGovulncheck()
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, bin, "-json", "./...")
+	cmd.Dir = appRoot
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Newf("govulncheck failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	findings, err := parseGovulncheck(&stdout)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse govulncheck output")
+	}
+	return &Report{Scanner: "govulncheck", Findings: findings}, nil
+}
+
+func scanNpm(ctx context.Context, appRoot string) (*Report, error) {
+	if _, err := os.Stat(filepath.Join(appRoot, "package-lock.json")); err != nil {
+		return nil, errors.New("npm audit requires a package-lock.json file")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "npm", "audit", "--json")
+	cmd.Dir = appRoot
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// npm audit exits with a non-zero status when it finds vulnerabilities,
+	// so only treat it as a failure if it didn't report any results.
+	if err := cmd.Run(); err != nil && stdout.Len() == 0 {
+		return nil, errors.Newf("npm audit failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	findings, err := parseNpmAudit(stdout.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "parse npm audit output")
+	}
+	return &Report{Scanner: "npm audit", Findings: findings}, nil
+}
+
+// findGovulncheck finds the govulncheck binary on PATH or in the Go bin directory.
+func findGovulncheck() (string, error) {
+	if path, err := exec.LookPath("govulncheck"); err == nil {
+		return path, nil
+	}
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			gopath = filepath.Join(home, "go")
+		}
+	}
+	if gopath != "" {
+		path := filepath.Join(gopath, "bin", "govulncheck")
+		if runtime.GOOS == "windows" {
+			path += ".exe"
+		}
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("govulncheck not found: install it with 'go install golang.org/x/vuln/cmd/govulncheck@latest'")
+}
+
+// govulncheckMessage is a message in the JSON output of govulncheck.
+type govulncheckMessage struct {
+	OSV *struct {
+		ID               string   `json:"id"`
+		Aliases          []string `json:"aliases"`
+		Summary          string   `json:"summary"`
+		Details          string   `json:"details"`
+		DatabaseSpecific *struct {
+			URL string `json:"url"`
+		} `json:"database_specific"`
+	} `json:"osv"`
+	Finding *struct {
+		OSV          string `json:"osv"`
+		FixedVersion string `json:"fixed_version"`
+		Trace        []struct {
+			Module   string `json:"module"`
+			Version  string `json:"version"`
+			Package  string `json:"package"`
+			Function string `json:"function"`
+			Receiver string `json:"receiver"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// parseGovulncheck parses the output of 'govulncheck -json'.
+// It reports a finding per vulnerability and module, at its highest reachability.
+func parseGovulncheck(r io.Reader) ([]*Finding, error) {
+	type key struct{ id, module string }
+	var (
+		dec      = json.NewDecoder(r)
+		findings = make(map[key]*Finding)
+		order    []key
+		osvs     = make(map[string]*Finding) // id -> advisory details
+	)
+	for {
+		var msg govulncheckMessage
+		if err := dec.Decode(&msg); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if osv := msg.OSV; osv != nil {
+			f := &Finding{ID: osv.ID, Aliases: osv.Aliases, Summary: osv.Summary}
+			if f.Summary == "" {
+				f.Summary, _, _ = strings.Cut(osv.Details, "\n")
+			}
+			if osv.DatabaseSpecific != nil {
+				f.URL = osv.DatabaseSpecific.URL
+			}
+			osvs[osv.ID] = f
+			continue
+		}
+
+		fd := msg.Finding
+		if fd == nil || len(fd.Trace) == 0 {
+			continue
+		}
+		vuln := fd.Trace[0]
+		reach := ReachabilityRequired
+		switch {
+		case vuln.Function != "":
+			reach = ReachabilityCalled
+		case vuln.Package != "":
+			reach = ReachabilityImported
+		}
+
+		k := key{fd.OSV, vuln.Module}
+		f := findings[k]
+		if f == nil {
+			f = &Finding{ID: fd.OSV, Module: vuln.Module, Version: vuln.Version, FixedVersion: fd.FixedVersion}
+			findings[k] = f
+			order = append(order, k)
+		}
+		if reach > f.Reachability {
+			f.Reachability = reach
+			f.Trace = nil
+			if reach == ReachabilityCalled {
+				// The trace starts at the vulnerable function; report it outermost first.
+				for i := len(fd.Trace) - 1; i >= 0; i-- {
+					fr := fd.Trace[i]
+					name := fr.Package + "."
+					if fr.Receiver != "" {
+						name += strings.TrimPrefix(fr.Receiver, "*") + "."
+					}
+					f.Trace = append(f.Trace, name+fr.Function)
+				}
+			}
+		}
+	}
+
+	result := make([]*Finding, 0, len(order))
+	for _, k := range order {
+		f := findings[k]
+		if osv := osvs[f.ID]; osv != nil {
+			f.Aliases, f.Summary, f.URL = osv.Aliases, osv.Summary, osv.URL
+		}
+		if f.URL == "" {
+			f.URL = "https://pkg.go.dev/vuln/" + f.ID
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// parseNpmAudit parses the output of 'npm audit --json' (report version 2).
+// npm audit does not analyze reachability.
+func parseNpmAudit(data []byte) ([]*Finding, error) {
+	var report struct {
+		Error *struct {
+			Summary string `json:"summary"`
+		} `json:"error"`
+		Vulnerabilities map[string]struct {
+			Name         string            `json:"name"`
+			Severity     string            `json:"severity"`
+			Via          []json.RawMessage `json:"via"`
+			FixAvailable json.RawMessage   `json:"fixAvailable"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	} else if report.Error != nil {
+		return nil, errors.New(report.Error.Summary)
+	}
+
+	var findings []*Finding
+	for _, name := range slices.Sorted(maps.Keys(report.Vulnerabilities)) {
+		v := report.Vulnerabilities[name]
+
+		var fix struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		}
+		_ = json.Unmarshal(v.FixAvailable, &fix)
+
+		for _, raw := range v.Via {
+			// Entries that are strings name vulnerable dependencies,
+			// which are reported on their own.
+			var adv struct {
+				Title    string `json:"title"`
+				URL      string `json:"url"`
+				Severity string `json:"severity"`
+			}
+			if json.Unmarshal(raw, &adv) != nil || adv.URL == "" {
+				continue
+			}
+			f := &Finding{
+				ID:       adv.URL[strings.LastIndexByte(adv.URL, '/')+1:],
+				Summary:  adv.Title,
+				URL:      adv.URL,
+				Module:   v.Name,
+				Severity: cmp.Or(adv.Severity, v.Severity),
+			}
+			if fix.Name == v.Name {
+				f.FixedVersion = fix.Version
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
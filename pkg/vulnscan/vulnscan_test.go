@@ -0,0 +1,83 @@
+package vulnscan
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseGovulncheck(t *testing.T) {
+	c := qt.New(t)
+	const out = `{"config": {"protocol_version": "v1.0.0", "scanner_name": "govulncheck", "scan_level": "symbol"}}
+{"progress": {"message": "Scanning your code and 120 packages across 20 dependent modules for known vulnerabilities..."}}
+{"osv": {"id": "GO-2024-2687", "aliases": ["CVE-2023-45288"], "summary": "HTTP/2 CONTINUATION flood in net/http", "database_specific": {"url": "https://pkg.go.dev/vuln/GO-2024-2687"}}}
+{"osv": {"id": "GO-2022-1059", "aliases": ["CVE-2022-32149"], "details": "Denial of service via crafted Accept-Language header.\nMore details."}}
+{"finding": {"osv": "GO-2024-2687", "fixed_version": "v0.23.0", "trace": [{"module": "golang.org/x/net", "version": "v0.20.0"}]}}
+{"finding": {"osv": "GO-2024-2687", "fixed_version": "v0.23.0", "trace": [{"module": "golang.org/x/net", "version": "v0.20.0", "package": "golang.org/x/net/http2"}]}}
+{"finding": {"osv": "GO-2024-2687", "fixed_version": "v0.23.0", "trace": [
+	{"module": "golang.org/x/net", "version": "v0.20.0", "package": "golang.org/x/net/http2", "function": "ReadFrame", "receiver": "*Framer"},
+	{"module": "encore.app", "package": "encore.app/users", "function": "Get"}]}}
+{"finding": {"osv": "GO-2022-1059", "fixed_version": "v0.3.8", "trace": [{"module": "golang.org/x/text", "version": "v0.3.7", "package": "golang.org/x/text/language"}]}}
+`
+	findings, err := parseGovulncheck(strings.NewReader(out))
+	c.Assert(err, qt.IsNil)
+	c.Assert(findings, qt.DeepEquals, []*Finding{
+		{
+			ID:           "GO-2024-2687",
+			Aliases:      []string{"CVE-2023-45288"},
+			Summary:      "HTTP/2 CONTINUATION flood in net/http",
+			URL:          "https://pkg.go.dev/vuln/GO-2024-2687",
+			Module:       "golang.org/x/net",
+			Version:      "v0.20.0",
+			FixedVersion: "v0.23.0",
+			Reachability: ReachabilityCalled,
+			Trace:        []string{"encore.app/users.Get", "golang.org/x/net/http2.Framer.ReadFrame"},
+		},
+		{
+			ID:           "GO-2022-1059",
+			Aliases:      []string{"CVE-2022-32149"},
+			Summary:      "Denial of service via crafted Accept-Language header.",
+			URL:          "https://pkg.go.dev/vuln/GO-2022-1059",
+			Module:       "golang.org/x/text",
+			Version:      "v0.3.7",
+			FixedVersion: "v0.3.8",
+			Reachability: ReachabilityImported,
+		},
+	})
+	c.Assert(findings[0].Reachable(), qt.IsTrue)
+	c.Assert(findings[1].Reachable(), qt.IsFalse)
+}
+
+func TestParseNpmAudit(t *testing.T) {
+	c := qt.New(t)
+	const out = `{
+  "auditReportVersion": 2,
+  "vulnerabilities": {
+    "minimist": {
+      "name": "minimist", "severity": "critical", "isDirect": false,
+      "via": [{"source": 1096, "name": "minimist", "title": "Prototype Pollution in minimist",
+        "url": "https://github.com/advisories/GHSA-xvch-5gv4-984h", "severity": "critical", "range": "<0.2.4"}],
+      "fixAvailable": {"name": "minimist", "version": "1.2.8", "isSemVerMajor": false}
+    },
+    "mkdirp": {
+      "name": "mkdirp", "severity": "critical", "isDirect": true,
+      "via": ["minimist"], "fixAvailable": true
+    }
+  }
+}`
+	findings, err := parseNpmAudit([]byte(out))
+	c.Assert(err, qt.IsNil)
+	c.Assert(findings, qt.DeepEquals, []*Finding{{
+		ID:           "GHSA-xvch-5gv4-984h",
+		Summary:      "Prototype Pollution in minimist",
+		URL:          "https://github.com/advisories/GHSA-xvch-5gv4-984h",
+		Module:       "minimist",
+		FixedVersion: "1.2.8",
+		Severity:     "critical",
+	}})
+	c.Assert(findings[0].Reachable(), qt.IsTrue)
+
+	_, err = parseNpmAudit([]byte(`{"error": {"code": "ENOLOCK", "summary": "This command requires an existing lockfile."}}`))
+	c.Assert(err, qt.ErrorMatches, "This command requires an existing lockfile.")
+}
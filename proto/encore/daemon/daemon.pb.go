@@ -22,6 +22,118 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type OutputCompression int32
+
+const (
+	OutputCompression_OUTPUT_COMPRESSION_NONE OutputCompression = 0
+	OutputCompression_OUTPUT_COMPRESSION_GZIP OutputCompression = 1
+)
+
+// Enum value maps for OutputCompression.
+var (
+	OutputCompression_name = map[int32]string{
+		0: "OUTPUT_COMPRESSION_NONE",
+		1: "OUTPUT_COMPRESSION_GZIP",
+	}
+	OutputCompression_value = map[string]int32{
+		"OUTPUT_COMPRESSION_NONE": 0,
+		"OUTPUT_COMPRESSION_GZIP": 1,
+	}
+)
+
+func (x OutputCompression) Enum() *OutputCompression {
+	p := new(OutputCompression)
+	*p = x
+	return p
+}
+
+func (x OutputCompression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputCompression) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[0].Descriptor()
+}
+
+func (OutputCompression) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[0]
+}
+
+func (x OutputCompression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputCompression.Descriptor instead.
+func (OutputCompression) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{0}
+}
+
+// ExitCategory categorizes command failures, so scripts can tell them apart.
+// Each category has its own exit code.
+type ExitCategory int32
+
+const (
+	ExitCategory_EXIT_CATEGORY_UNSPECIFIED      ExitCategory = 0 // success or uncategorized failure (exit code 1)
+	ExitCategory_EXIT_CATEGORY_TEST_FAILURE     ExitCategory = 1 // tests failed (exit code 1)
+	ExitCategory_EXIT_CATEGORY_BUILD            ExitCategory = 2 // the app failed to parse or compile (exit code 10)
+	ExitCategory_EXIT_CATEGORY_INFRA            ExitCategory = 3 // local infrastructure failed to start (exit code 11)
+	ExitCategory_EXIT_CATEGORY_PORT_CONFLICT    ExitCategory = 4 // the address to listen on is in use (exit code 12)
+	ExitCategory_EXIT_CATEGORY_AUTH_REQUIRED    ExitCategory = 5 // the command requires logging in (exit code 13)
+	ExitCategory_EXIT_CATEGORY_VERSION_MISMATCH ExitCategory = 6 // Encore must be updated (exit code 14)
+	ExitCategory_EXIT_CATEGORY_ALREADY_RUNNING  ExitCategory = 7 // the app is already running (exit code 15)
+)
+
+// Enum value maps for ExitCategory.
+var (
+	ExitCategory_name = map[int32]string{
+		0: "EXIT_CATEGORY_UNSPECIFIED",
+		1: "EXIT_CATEGORY_TEST_FAILURE",
+		2: "EXIT_CATEGORY_BUILD",
+		3: "EXIT_CATEGORY_INFRA",
+		4: "EXIT_CATEGORY_PORT_CONFLICT",
+		5: "EXIT_CATEGORY_AUTH_REQUIRED",
+		6: "EXIT_CATEGORY_VERSION_MISMATCH",
+		7: "EXIT_CATEGORY_ALREADY_RUNNING",
+	}
+	ExitCategory_value = map[string]int32{
+		"EXIT_CATEGORY_UNSPECIFIED":      0,
+		"EXIT_CATEGORY_TEST_FAILURE":     1,
+		"EXIT_CATEGORY_BUILD":            2,
+		"EXIT_CATEGORY_INFRA":            3,
+		"EXIT_CATEGORY_PORT_CONFLICT":    4,
+		"EXIT_CATEGORY_AUTH_REQUIRED":    5,
+		"EXIT_CATEGORY_VERSION_MISMATCH": 6,
+		"EXIT_CATEGORY_ALREADY_RUNNING":  7,
+	}
+)
+
+func (x ExitCategory) Enum() *ExitCategory {
+	p := new(ExitCategory)
+	*p = x
+	return p
+}
+
+func (x ExitCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExitCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[1].Descriptor()
+}
+
+func (ExitCategory) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[1]
+}
+
+func (x ExitCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExitCategory.Descriptor instead.
+func (ExitCategory) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{1}
+}
+
 type DBRole int32
 
 const (
@@ -61,11 +173,11 @@ func (x DBRole) String() string {
 }
 
 func (DBRole) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_daemon_daemon_proto_enumTypes[0].Descriptor()
+	return file_encore_daemon_daemon_proto_enumTypes[2].Descriptor()
 }
 
 func (DBRole) Type() protoreflect.EnumType {
-	return &file_encore_daemon_daemon_proto_enumTypes[0]
+	return &file_encore_daemon_daemon_proto_enumTypes[2]
 }
 
 func (x DBRole) Number() protoreflect.EnumNumber {
@@ -74,7 +186,7 @@ func (x DBRole) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DBRole.Descriptor instead.
 func (DBRole) EnumDescriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{0}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{2}
 }
 
 type DBClusterType int32
@@ -113,11 +225,11 @@ func (x DBClusterType) String() string {
 }
 
 func (DBClusterType) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_daemon_daemon_proto_enumTypes[1].Descriptor()
+	return file_encore_daemon_daemon_proto_enumTypes[3].Descriptor()
 }
 
 func (DBClusterType) Type() protoreflect.EnumType {
-	return &file_encore_daemon_daemon_proto_enumTypes[1]
+	return &file_encore_daemon_daemon_proto_enumTypes[3]
 }
 
 func (x DBClusterType) Number() protoreflect.EnumNumber {
@@ -126,7 +238,116 @@ func (x DBClusterType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DBClusterType.Descriptor instead.
 func (DBClusterType) EnumDescriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{1}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{3}
+}
+
+type OperationEvent_State int32
+
+const (
+	OperationEvent_STATE_UNSPECIFIED OperationEvent_State = 0
+	OperationEvent_STATE_STARTED     OperationEvent_State = 1
+	OperationEvent_STATE_DONE        OperationEvent_State = 2
+	OperationEvent_STATE_FAILED      OperationEvent_State = 3
+	OperationEvent_STATE_CANCELED    OperationEvent_State = 4
+)
+
+// Enum value maps for OperationEvent_State.
+var (
+	OperationEvent_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_STARTED",
+		2: "STATE_DONE",
+		3: "STATE_FAILED",
+		4: "STATE_CANCELED",
+	}
+	OperationEvent_State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"STATE_STARTED":     1,
+		"STATE_DONE":        2,
+		"STATE_FAILED":      3,
+		"STATE_CANCELED":    4,
+	}
+)
+
+func (x OperationEvent_State) Enum() *OperationEvent_State {
+	p := new(OperationEvent_State)
+	*p = x
+	return p
+}
+
+func (x OperationEvent_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationEvent_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[4].Descriptor()
+}
+
+func (OperationEvent_State) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[4]
+}
+
+func (x OperationEvent_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationEvent_State.Descriptor instead.
+func (OperationEvent_State) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type ReloadEvent_State int32
+
+const (
+	ReloadEvent_STATE_UNSPECIFIED ReloadEvent_State = 0
+	ReloadEvent_STATE_STARTED     ReloadEvent_State = 1
+	ReloadEvent_STATE_DONE        ReloadEvent_State = 2
+	// STATE_STALE reports that the build failed, and the previous build
+	// keeps serving requests until the next build that succeeds.
+	ReloadEvent_STATE_STALE ReloadEvent_State = 3
+)
+
+// Enum value maps for ReloadEvent_State.
+var (
+	ReloadEvent_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_STARTED",
+		2: "STATE_DONE",
+		3: "STATE_STALE",
+	}
+	ReloadEvent_State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"STATE_STARTED":     1,
+		"STATE_DONE":        2,
+		"STATE_STALE":       3,
+	}
+)
+
+func (x ReloadEvent_State) Enum() *ReloadEvent_State {
+	p := new(ReloadEvent_State)
+	*p = x
+	return p
+}
+
+func (x ReloadEvent_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReloadEvent_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[5].Descriptor()
+}
+
+func (ReloadEvent_State) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[5]
+}
+
+func (x ReloadEvent_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReloadEvent_State.Descriptor instead.
+func (ReloadEvent_State) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{5, 0}
 }
 
 type RunRequest_BrowserMode int32
@@ -162,11 +383,11 @@ func (x RunRequest_BrowserMode) String() string {
 }
 
 func (RunRequest_BrowserMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_daemon_daemon_proto_enumTypes[2].Descriptor()
+	return file_encore_daemon_daemon_proto_enumTypes[6].Descriptor()
 }
 
 func (RunRequest_BrowserMode) Type() protoreflect.EnumType {
-	return &file_encore_daemon_daemon_proto_enumTypes[2]
+	return &file_encore_daemon_daemon_proto_enumTypes[6]
 }
 
 func (x RunRequest_BrowserMode) Number() protoreflect.EnumNumber {
@@ -175,7 +396,7 @@ func (x RunRequest_BrowserMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RunRequest_BrowserMode.Descriptor instead.
 func (RunRequest_BrowserMode) EnumDescriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{6, 0}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{15, 0}
 }
 
 type RunRequest_DebugMode int32
@@ -211,11 +432,11 @@ func (x RunRequest_DebugMode) String() string {
 }
 
 func (RunRequest_DebugMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_daemon_daemon_proto_enumTypes[3].Descriptor()
+	return file_encore_daemon_daemon_proto_enumTypes[7].Descriptor()
 }
 
 func (RunRequest_DebugMode) Type() protoreflect.EnumType {
-	return &file_encore_daemon_daemon_proto_enumTypes[3]
+	return &file_encore_daemon_daemon_proto_enumTypes[7]
 }
 
 func (x RunRequest_DebugMode) Number() protoreflect.EnumNumber {
@@ -224,179 +445,5913 @@ func (x RunRequest_DebugMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RunRequest_DebugMode.Descriptor instead.
 func (RunRequest_DebugMode) EnumDescriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{6, 1}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{15, 1}
 }
 
-type DumpMetaRequest_Format int32
+type RunRequest_EmulationProfile int32
 
 const (
-	DumpMetaRequest_FORMAT_UNSPECIFIED DumpMetaRequest_Format = 0
-	DumpMetaRequest_FORMAT_JSON        DumpMetaRequest_Format = 1
-	DumpMetaRequest_FORMAT_PROTO       DumpMetaRequest_Format = 2
+	// EMULATION_DEVELOPMENT runs the app with the regular,
+	// developer-friendly local settings.
+	RunRequest_EMULATION_DEVELOPMENT RunRequest_EmulationProfile = 0
+	// EMULATION_PRODUCTION runs the app with production-like settings:
+	// production log format, strict CORS, no dev-only access,
+	// sampled tracing and resource limits.
+	RunRequest_EMULATION_PRODUCTION RunRequest_EmulationProfile = 1
 )
 
-// Enum value maps for DumpMetaRequest_Format.
+// Enum value maps for RunRequest_EmulationProfile.
 var (
-	DumpMetaRequest_Format_name = map[int32]string{
-		0: "FORMAT_UNSPECIFIED",
-		1: "FORMAT_JSON",
-		2: "FORMAT_PROTO",
+	RunRequest_EmulationProfile_name = map[int32]string{
+		0: "EMULATION_DEVELOPMENT",
+		1: "EMULATION_PRODUCTION",
 	}
-	DumpMetaRequest_Format_value = map[string]int32{
-		"FORMAT_UNSPECIFIED": 0,
-		"FORMAT_JSON":        1,
-		"FORMAT_PROTO":       2,
+	RunRequest_EmulationProfile_value = map[string]int32{
+		"EMULATION_DEVELOPMENT": 0,
+		"EMULATION_PRODUCTION":  1,
 	}
 )
 
-func (x DumpMetaRequest_Format) Enum() *DumpMetaRequest_Format {
-	p := new(DumpMetaRequest_Format)
+func (x RunRequest_EmulationProfile) Enum() *RunRequest_EmulationProfile {
+	p := new(RunRequest_EmulationProfile)
 	*p = x
 	return p
 }
 
-func (x DumpMetaRequest_Format) String() string {
+func (x RunRequest_EmulationProfile) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (DumpMetaRequest_Format) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_daemon_daemon_proto_enumTypes[4].Descriptor()
+func (RunRequest_EmulationProfile) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[8].Descriptor()
 }
 
-func (DumpMetaRequest_Format) Type() protoreflect.EnumType {
-	return &file_encore_daemon_daemon_proto_enumTypes[4]
+func (RunRequest_EmulationProfile) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[8]
 }
 
-func (x DumpMetaRequest_Format) Number() protoreflect.EnumNumber {
+func (x RunRequest_EmulationProfile) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use DumpMetaRequest_Format.Descriptor instead.
-func (DumpMetaRequest_Format) EnumDescriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{41, 0}
+// Deprecated: Use RunRequest_EmulationProfile.Descriptor instead.
+func (RunRequest_EmulationProfile) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{15, 2}
 }
 
-type CommandMessage struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*CommandMessage_Output
-	//	*CommandMessage_Exit
-	//	*CommandMessage_Errors
-	Msg           isCommandMessage_Msg `protobuf_oneof:"msg"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
+type RunRequest_IfRunning int32
 
-func (x *CommandMessage) Reset() {
-	*x = CommandMessage{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
+const (
+	// IF_RUNNING_FAIL fails the run, reporting where the app is running.
+	RunRequest_IF_RUNNING_FAIL RunRequest_IfRunning = 0
+	// IF_RUNNING_ATTACH streams the output of the running app instead.
+	RunRequest_IF_RUNNING_ATTACH RunRequest_IfRunning = 1
+	// IF_RUNNING_TAKEOVER gracefully stops the running app
+	// and runs the app in its place.
+	RunRequest_IF_RUNNING_TAKEOVER RunRequest_IfRunning = 2
+)
 
-func (x *CommandMessage) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Enum value maps for RunRequest_IfRunning.
+var (
+	RunRequest_IfRunning_name = map[int32]string{
+		0: "IF_RUNNING_FAIL",
+		1: "IF_RUNNING_ATTACH",
+		2: "IF_RUNNING_TAKEOVER",
+	}
+	RunRequest_IfRunning_value = map[string]int32{
+		"IF_RUNNING_FAIL":     0,
+		"IF_RUNNING_ATTACH":   1,
+		"IF_RUNNING_TAKEOVER": 2,
+	}
+)
+
+func (x RunRequest_IfRunning) Enum() *RunRequest_IfRunning {
+	p := new(RunRequest_IfRunning)
+	*p = x
+	return p
 }
 
-func (*CommandMessage) ProtoMessage() {}
+func (x RunRequest_IfRunning) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
 
-func (x *CommandMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (RunRequest_IfRunning) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[9].Descriptor()
 }
 
-// Deprecated: Use CommandMessage.ProtoReflect.Descriptor instead.
-func (*CommandMessage) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{0}
+func (RunRequest_IfRunning) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[9]
 }
 
-func (x *CommandMessage) GetMsg() isCommandMessage_Msg {
-	if x != nil {
-		return x.Msg
-	}
-	return nil
+func (x RunRequest_IfRunning) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *CommandMessage) GetOutput() *CommandOutput {
-	if x != nil {
-		if x, ok := x.Msg.(*CommandMessage_Output); ok {
-			return x.Output
-		}
-	}
-	return nil
+// Deprecated: Use RunRequest_IfRunning.Descriptor instead.
+func (RunRequest_IfRunning) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{15, 3}
 }
 
-func (x *CommandMessage) GetExit() *CommandExit {
-	if x != nil {
-		if x, ok := x.Msg.(*CommandMessage_Exit); ok {
-			return x.Exit
-		}
+type ControlRunRequest_Action int32
+
+const (
+	ControlRunRequest_ACTION_UNSPECIFIED ControlRunRequest_Action = 0
+	// ACTION_RESTART rebuilds and restarts the app.
+	ControlRunRequest_ACTION_RESTART ControlRunRequest_Action = 1
+	// ACTION_TOGGLE_TRACE_SAMPLING turns the sampling of traces off,
+	// or back on if it's off.
+	ControlRunRequest_ACTION_TOGGLE_TRACE_SAMPLING ControlRunRequest_Action = 2
+)
+
+// Enum value maps for ControlRunRequest_Action.
+var (
+	ControlRunRequest_Action_name = map[int32]string{
+		0: "ACTION_UNSPECIFIED",
+		1: "ACTION_RESTART",
+		2: "ACTION_TOGGLE_TRACE_SAMPLING",
 	}
-	return nil
+	ControlRunRequest_Action_value = map[string]int32{
+		"ACTION_UNSPECIFIED":           0,
+		"ACTION_RESTART":               1,
+		"ACTION_TOGGLE_TRACE_SAMPLING": 2,
+	}
+)
+
+func (x ControlRunRequest_Action) Enum() *ControlRunRequest_Action {
+	p := new(ControlRunRequest_Action)
+	*p = x
+	return p
 }
 
-func (x *CommandMessage) GetErrors() *CommandDisplayErrors {
-	if x != nil {
-		if x, ok := x.Msg.(*CommandMessage_Errors); ok {
-			return x.Errors
-		}
-	}
-	return nil
+func (x ControlRunRequest_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type isCommandMessage_Msg interface {
-	isCommandMessage_Msg()
+func (ControlRunRequest_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[10].Descriptor()
 }
 
-type CommandMessage_Output struct {
-	Output *CommandOutput `protobuf:"bytes,1,opt,name=output,proto3,oneof"`
+func (ControlRunRequest_Action) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[10]
 }
 
-type CommandMessage_Exit struct {
-	Exit *CommandExit `protobuf:"bytes,2,opt,name=exit,proto3,oneof"`
+func (x ControlRunRequest_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-type CommandMessage_Errors struct {
-	Errors *CommandDisplayErrors `protobuf:"bytes,3,opt,name=errors,proto3,oneof"`
+// Deprecated: Use ControlRunRequest_Action.Descriptor instead.
+func (ControlRunRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{18, 0}
 }
 
-func (*CommandMessage_Output) isCommandMessage_Msg() {}
+type DBCDCConfigRequest_Format int32
 
-func (*CommandMessage_Exit) isCommandMessage_Msg() {}
+const (
+	DBCDCConfigRequest_FORMAT_UNSPECIFIED DBCDCConfigRequest_Format = 0
+	// FORMAT_DEBEZIUM generates Debezium PostgreSQL connector configuration.
+	DBCDCConfigRequest_FORMAT_DEBEZIUM DBCDCConfigRequest_Format = 1
+	// FORMAT_SQL generates logical replication setup scripts.
+	DBCDCConfigRequest_FORMAT_SQL DBCDCConfigRequest_Format = 2
+)
 
-func (*CommandMessage_Errors) isCommandMessage_Msg() {}
+// Enum value maps for DBCDCConfigRequest_Format.
+var (
+	DBCDCConfigRequest_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "FORMAT_DEBEZIUM",
+		2: "FORMAT_SQL",
+	}
+	DBCDCConfigRequest_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"FORMAT_DEBEZIUM":    1,
+		"FORMAT_SQL":         2,
+	}
+)
 
-type CommandOutput struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Stdout        []byte                 `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
-	Stderr        []byte                 `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x DBCDCConfigRequest_Format) Enum() *DBCDCConfigRequest_Format {
+	p := new(DBCDCConfigRequest_Format)
+	*p = x
+	return p
 }
 
-func (x *CommandOutput) Reset() {
-	*x = CommandOutput{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[1]
+func (x DBCDCConfigRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DBCDCConfigRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[11].Descriptor()
+}
+
+func (DBCDCConfigRequest_Format) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[11]
+}
+
+func (x DBCDCConfigRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DBCDCConfigRequest_Format.Descriptor instead.
+func (DBCDCConfigRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{61, 0}
+}
+
+type DumpMetaRequest_Format int32
+
+const (
+	DumpMetaRequest_FORMAT_UNSPECIFIED DumpMetaRequest_Format = 0
+	DumpMetaRequest_FORMAT_JSON        DumpMetaRequest_Format = 1
+	DumpMetaRequest_FORMAT_PROTO       DumpMetaRequest_Format = 2
+)
+
+// Enum value maps for DumpMetaRequest_Format.
+var (
+	DumpMetaRequest_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "FORMAT_JSON",
+		2: "FORMAT_PROTO",
+	}
+	DumpMetaRequest_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"FORMAT_JSON":        1,
+		"FORMAT_PROTO":       2,
+	}
+)
+
+func (x DumpMetaRequest_Format) Enum() *DumpMetaRequest_Format {
+	p := new(DumpMetaRequest_Format)
+	*p = x
+	return p
+}
+
+func (x DumpMetaRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DumpMetaRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[12].Descriptor()
+}
+
+func (DumpMetaRequest_Format) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[12]
+}
+
+func (x DumpMetaRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DumpMetaRequest_Format.Descriptor instead.
+func (DumpMetaRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{79, 0}
+}
+
+type Vulnerability_Reachability int32
+
+const (
+	// REACHABILITY_UNKNOWN means the scanner does not analyze reachability.
+	Vulnerability_REACHABILITY_UNKNOWN Vulnerability_Reachability = 0
+	// REACHABILITY_REQUIRED means the vulnerable package is not imported.
+	Vulnerability_REACHABILITY_REQUIRED Vulnerability_Reachability = 1
+	// REACHABILITY_IMPORTED means the vulnerable code is not called.
+	Vulnerability_REACHABILITY_IMPORTED Vulnerability_Reachability = 2
+	// REACHABILITY_CALLED means the app calls the vulnerable code.
+	Vulnerability_REACHABILITY_CALLED Vulnerability_Reachability = 3
+)
+
+// Enum value maps for Vulnerability_Reachability.
+var (
+	Vulnerability_Reachability_name = map[int32]string{
+		0: "REACHABILITY_UNKNOWN",
+		1: "REACHABILITY_REQUIRED",
+		2: "REACHABILITY_IMPORTED",
+		3: "REACHABILITY_CALLED",
+	}
+	Vulnerability_Reachability_value = map[string]int32{
+		"REACHABILITY_UNKNOWN":  0,
+		"REACHABILITY_REQUIRED": 1,
+		"REACHABILITY_IMPORTED": 2,
+		"REACHABILITY_CALLED":   3,
+	}
+)
+
+func (x Vulnerability_Reachability) Enum() *Vulnerability_Reachability {
+	p := new(Vulnerability_Reachability)
+	*p = x
+	return p
+}
+
+func (x Vulnerability_Reachability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Vulnerability_Reachability) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[13].Descriptor()
+}
+
+func (Vulnerability_Reachability) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[13]
+}
+
+func (x Vulnerability_Reachability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Vulnerability_Reachability.Descriptor instead.
+func (Vulnerability_Reachability) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{102, 0}
+}
+
+type DeadlineFinding_Issue int32
+
+const (
+	DeadlineFinding_ISSUE_UNSPECIFIED             DeadlineFinding_Issue = 0
+	DeadlineFinding_ISSUE_DEADLINE_EXCEEDED       DeadlineFinding_Issue = 1
+	DeadlineFinding_ISSUE_DEADLINE_NOT_PROPAGATED DeadlineFinding_Issue = 2
+	DeadlineFinding_ISSUE_CANCELED                DeadlineFinding_Issue = 3
+)
+
+// Enum value maps for DeadlineFinding_Issue.
+var (
+	DeadlineFinding_Issue_name = map[int32]string{
+		0: "ISSUE_UNSPECIFIED",
+		1: "ISSUE_DEADLINE_EXCEEDED",
+		2: "ISSUE_DEADLINE_NOT_PROPAGATED",
+		3: "ISSUE_CANCELED",
+	}
+	DeadlineFinding_Issue_value = map[string]int32{
+		"ISSUE_UNSPECIFIED":             0,
+		"ISSUE_DEADLINE_EXCEEDED":       1,
+		"ISSUE_DEADLINE_NOT_PROPAGATED": 2,
+		"ISSUE_CANCELED":                3,
+	}
+)
+
+func (x DeadlineFinding_Issue) Enum() *DeadlineFinding_Issue {
+	p := new(DeadlineFinding_Issue)
+	*p = x
+	return p
+}
+
+func (x DeadlineFinding_Issue) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeadlineFinding_Issue) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[14].Descriptor()
+}
+
+func (DeadlineFinding_Issue) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[14]
+}
+
+func (x DeadlineFinding_Issue) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeadlineFinding_Issue.Descriptor instead.
+func (DeadlineFinding_Issue) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{111, 0}
+}
+
+type UsageReportRequest_GroupBy int32
+
+const (
+	UsageReportRequest_GROUP_BY_ENDPOINT UsageReportRequest_GroupBy = 0
+	UsageReportRequest_GROUP_BY_UID      UsageReportRequest_GroupBy = 1
+)
+
+// Enum value maps for UsageReportRequest_GroupBy.
+var (
+	UsageReportRequest_GroupBy_name = map[int32]string{
+		0: "GROUP_BY_ENDPOINT",
+		1: "GROUP_BY_UID",
+	}
+	UsageReportRequest_GroupBy_value = map[string]int32{
+		"GROUP_BY_ENDPOINT": 0,
+		"GROUP_BY_UID":      1,
+	}
+)
+
+func (x UsageReportRequest_GroupBy) Enum() *UsageReportRequest_GroupBy {
+	p := new(UsageReportRequest_GroupBy)
+	*p = x
+	return p
+}
+
+func (x UsageReportRequest_GroupBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UsageReportRequest_GroupBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_daemon_daemon_proto_enumTypes[15].Descriptor()
+}
+
+func (UsageReportRequest_GroupBy) Type() protoreflect.EnumType {
+	return &file_encore_daemon_daemon_proto_enumTypes[15]
+}
+
+func (x UsageReportRequest_GroupBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UsageReportRequest_GroupBy.Descriptor instead.
+func (UsageReportRequest_GroupBy) EnumDescriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{112, 0}
+}
+
+type CommandMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*CommandMessage_Output
+	//	*CommandMessage_Exit
+	//	*CommandMessage_Errors
+	//	*CommandMessage_Session
+	//	*CommandMessage_OutputBatch
+	//	*CommandMessage_Event
+	Msg           isCommandMessage_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandMessage) Reset() {
+	*x = CommandMessage{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandMessage) ProtoMessage() {}
+
+func (x *CommandMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandMessage.ProtoReflect.Descriptor instead.
+func (*CommandMessage) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CommandMessage) GetMsg() isCommandMessage_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *CommandMessage) GetOutput() *CommandOutput {
+	if x != nil {
+		if x, ok := x.Msg.(*CommandMessage_Output); ok {
+			return x.Output
+		}
+	}
+	return nil
+}
+
+func (x *CommandMessage) GetExit() *CommandExit {
+	if x != nil {
+		if x, ok := x.Msg.(*CommandMessage_Exit); ok {
+			return x.Exit
+		}
+	}
+	return nil
+}
+
+func (x *CommandMessage) GetErrors() *CommandDisplayErrors {
+	if x != nil {
+		if x, ok := x.Msg.(*CommandMessage_Errors); ok {
+			return x.Errors
+		}
+	}
+	return nil
+}
+
+func (x *CommandMessage) GetSession() *CommandSession {
+	if x != nil {
+		if x, ok := x.Msg.(*CommandMessage_Session); ok {
+			return x.Session
+		}
+	}
+	return nil
+}
+
+func (x *CommandMessage) GetOutputBatch() *CommandOutputBatch {
+	if x != nil {
+		if x, ok := x.Msg.(*CommandMessage_OutputBatch); ok {
+			return x.OutputBatch
+		}
+	}
+	return nil
+}
+
+func (x *CommandMessage) GetEvent() *CommandEvent {
+	if x != nil {
+		if x, ok := x.Msg.(*CommandMessage_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+type isCommandMessage_Msg interface {
+	isCommandMessage_Msg()
+}
+
+type CommandMessage_Output struct {
+	Output *CommandOutput `protobuf:"bytes,1,opt,name=output,proto3,oneof"`
+}
+
+type CommandMessage_Exit struct {
+	Exit *CommandExit `protobuf:"bytes,2,opt,name=exit,proto3,oneof"`
+}
+
+type CommandMessage_Errors struct {
+	Errors *CommandDisplayErrors `protobuf:"bytes,3,opt,name=errors,proto3,oneof"`
+}
+
+type CommandMessage_Session struct {
+	Session *CommandSession `protobuf:"bytes,4,opt,name=session,proto3,oneof"`
+}
+
+type CommandMessage_OutputBatch struct {
+	OutputBatch *CommandOutputBatch `protobuf:"bytes,5,opt,name=output_batch,json=outputBatch,proto3,oneof"`
+}
+
+type CommandMessage_Event struct {
+	Event *CommandEvent `protobuf:"bytes,6,opt,name=event,proto3,oneof"`
+}
+
+func (*CommandMessage_Output) isCommandMessage_Msg() {}
+
+func (*CommandMessage_Exit) isCommandMessage_Msg() {}
+
+func (*CommandMessage_Errors) isCommandMessage_Msg() {}
+
+func (*CommandMessage_Session) isCommandMessage_Msg() {}
+
+func (*CommandMessage_OutputBatch) isCommandMessage_Msg() {}
+
+func (*CommandMessage_Event) isCommandMessage_Msg() {}
+
+// CommandEvent is a structured event about the progress of a command,
+// sent instead of the corresponding human-readable output when requested.
+type CommandEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*CommandEvent_Operation
+	//	*CommandEvent_RunStarted
+	//	*CommandEvent_Reload
+	//	*CommandEvent_Listen
+	Event         isCommandEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandEvent) Reset() {
+	*x = CommandEvent{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandEvent) ProtoMessage() {}
+
+func (x *CommandEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandEvent.ProtoReflect.Descriptor instead.
+func (*CommandEvent) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CommandEvent) GetEvent() isCommandEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *CommandEvent) GetOperation() *OperationEvent {
+	if x != nil {
+		if x, ok := x.Event.(*CommandEvent_Operation); ok {
+			return x.Operation
+		}
+	}
+	return nil
+}
+
+func (x *CommandEvent) GetRunStarted() *RunStartedEvent {
+	if x != nil {
+		if x, ok := x.Event.(*CommandEvent_RunStarted); ok {
+			return x.RunStarted
+		}
+	}
+	return nil
+}
+
+func (x *CommandEvent) GetReload() *ReloadEvent {
+	if x != nil {
+		if x, ok := x.Event.(*CommandEvent_Reload); ok {
+			return x.Reload
+		}
+	}
+	return nil
+}
+
+func (x *CommandEvent) GetListen() *ListenEvent {
+	if x != nil {
+		if x, ok := x.Event.(*CommandEvent_Listen); ok {
+			return x.Listen
+		}
+	}
+	return nil
+}
+
+type isCommandEvent_Event interface {
+	isCommandEvent_Event()
+}
+
+type CommandEvent_Operation struct {
+	Operation *OperationEvent `protobuf:"bytes,1,opt,name=operation,proto3,oneof"`
+}
+
+type CommandEvent_RunStarted struct {
+	RunStarted *RunStartedEvent `protobuf:"bytes,2,opt,name=run_started,json=runStarted,proto3,oneof"`
+}
+
+type CommandEvent_Reload struct {
+	Reload *ReloadEvent `protobuf:"bytes,3,opt,name=reload,proto3,oneof"`
+}
+
+type CommandEvent_Listen struct {
+	Listen *ListenEvent `protobuf:"bytes,4,opt,name=listen,proto3,oneof"`
+}
+
+func (*CommandEvent_Operation) isCommandEvent_Event() {}
+
+func (*CommandEvent_RunStarted) isCommandEvent_Event() {}
+
+func (*CommandEvent_Reload) isCommandEvent_Event() {}
+
+func (*CommandEvent_Listen) isCommandEvent_Event() {}
+
+// ListenEvent reports the address the app listens on, as soon as it's bound.
+// It may differ from the requested address, such as when requesting port 0
+// or with auto_port.
+type ListenEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// listen_addr is the address the app listens on, such as "127.0.0.1:53214".
+	ListenAddr    string `protobuf:"bytes,1,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenEvent) Reset() {
+	*x = ListenEvent{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenEvent) ProtoMessage() {}
+
+func (x *ListenEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenEvent.ProtoReflect.Descriptor instead.
+func (*ListenEvent) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListenEvent) GetListenAddr() string {
+	if x != nil {
+		return x.ListenAddr
+	}
+	return ""
+}
+
+// OperationEvent reports a change of the state of an operation,
+// such as a build step.
+type OperationEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name describes the operation, such as "Compiling application source code".
+	Name  string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State OperationEvent_State `protobuf:"varint,2,opt,name=state,proto3,enum=encore.daemon.OperationEvent_State" json:"state,omitempty"`
+	// error is the error of failed operations.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// duration_ms is how long the operation took, once it's no longer started.
+	DurationMs    int64 `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationEvent) Reset() {
+	*x = OperationEvent{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationEvent) ProtoMessage() {}
+
+func (x *OperationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationEvent.ProtoReflect.Descriptor instead.
+func (*OperationEvent) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OperationEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OperationEvent) GetState() OperationEvent_State {
+	if x != nil {
+		return x.State
+	}
+	return OperationEvent_STATE_UNSPECIFIED
+}
+
+func (x *OperationEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *OperationEvent) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// RunStartedEvent reports that the app is running.
+type RunStartedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// base_url is the base URL the app's API is served at.
+	BaseUrl string `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// listen_addr is the address the app listens on.
+	ListenAddr string `protobuf:"bytes,2,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
+	// dashboard_url is the URL of the local development dashboard for the app.
+	DashboardUrl string `protobuf:"bytes,3,opt,name=dashboard_url,json=dashboardUrl,proto3" json:"dashboard_url,omitempty"`
+	// namespace is the name of the infrastructure namespace the app runs in.
+	Namespace     string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunStartedEvent) Reset() {
+	*x = RunStartedEvent{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunStartedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunStartedEvent) ProtoMessage() {}
+
+func (x *RunStartedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunStartedEvent.ProtoReflect.Descriptor instead.
+func (*RunStartedEvent) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RunStartedEvent) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *RunStartedEvent) GetListenAddr() string {
+	if x != nil {
+		return x.ListenAddr
+	}
+	return ""
+}
+
+func (x *RunStartedEvent) GetDashboardUrl() string {
+	if x != nil {
+		return x.DashboardUrl
+	}
+	return ""
+}
+
+func (x *RunStartedEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ReloadEvent reports that the app reloads after a change.
+// Builds that fail are reported with CommandDisplayErrors.
+type ReloadEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         ReloadEvent_State      `protobuf:"varint,1,opt,name=state,proto3,enum=encore.daemon.ReloadEvent_State" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadEvent) Reset() {
+	*x = ReloadEvent{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadEvent) ProtoMessage() {}
+
+func (x *ReloadEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadEvent.ProtoReflect.Descriptor instead.
+func (*ReloadEvent) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReloadEvent) GetState() ReloadEvent_State {
+	if x != nil {
+		return x.State
+	}
+	return ReloadEvent_STATE_UNSPECIFIED
+}
+
+// CommandOutputBatch is a batch of consecutive output frames,
+// sent instead of individual CommandOutput messages when requested.
+type CommandOutputBatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// frames are the output frames, in order. It is empty if compressed.
+	Frames []*CommandOutput `protobuf:"bytes,1,rep,name=frames,proto3" json:"frames,omitempty"`
+	// compression is the algorithm the frames are compressed with, if any.
+	Compression OutputCompression `protobuf:"varint,2,opt,name=compression,proto3,enum=encore.daemon.OutputCompression" json:"compression,omitempty"`
+	// compressed is the compressed encoding of a CommandOutputBatch
+	// holding the frames, if compression is set.
+	Compressed    []byte `protobuf:"bytes,3,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandOutputBatch) Reset() {
+	*x = CommandOutputBatch{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandOutputBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandOutputBatch) ProtoMessage() {}
+
+func (x *CommandOutputBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandOutputBatch.ProtoReflect.Descriptor instead.
+func (*CommandOutputBatch) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CommandOutputBatch) GetFrames() []*CommandOutput {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+func (x *CommandOutputBatch) GetCompression() OutputCompression {
+	if x != nil {
+		return x.Compression
+	}
+	return OutputCompression_OUTPUT_COMPRESSION_NONE
+}
+
+func (x *CommandOutputBatch) GetCompressed() []byte {
+	if x != nil {
+		return x.Compressed
+	}
+	return nil
+}
+
+// CommandSession is sent first on the stream of a resumable command.
+type CommandSession struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token identifies the command, for resuming its stream.
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// lost is the number of messages a resuming client missed because
+	// they were dropped from the buffer while it was disconnected.
+	// The messages sent after it follow the lost ones.
+	Lost          uint64 `protobuf:"varint,2,opt,name=lost,proto3" json:"lost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandSession) Reset() {
+	*x = CommandSession{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandSession) ProtoMessage() {}
+
+func (x *CommandSession) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandSession.ProtoReflect.Descriptor instead.
+func (*CommandSession) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CommandSession) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CommandSession) GetLost() uint64 {
+	if x != nil {
+		return x.Lost
+	}
+	return 0
+}
+
+type ResumeStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token is the token of the command to resume, from its CommandSession.
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// received is the number of messages already received, excluding
+	// CommandSession messages. Only later messages are sent.
+	Received      uint64 `protobuf:"varint,2,opt,name=received,proto3" json:"received,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeStreamRequest) Reset() {
+	*x = ResumeStreamRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeStreamRequest) ProtoMessage() {}
+
+func (x *ResumeStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeStreamRequest.ProtoReflect.Descriptor instead.
+func (*ResumeStreamRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ResumeStreamRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResumeStreamRequest) GetReceived() uint64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+type CancelStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// token is the token of the command to cancel, from its CommandSession.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelStreamRequest) Reset() {
+	*x = CancelStreamRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelStreamRequest) ProtoMessage() {}
+
+func (x *CancelStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelStreamRequest.ProtoReflect.Descriptor instead.
+func (*CancelStreamRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelStreamRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type CommandOutput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stdout        []byte                 `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr        []byte                 `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandOutput) Reset() {
+	*x = CommandOutput{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandOutput) ProtoMessage() {}
+
+func (x *CommandOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandOutput.ProtoReflect.Descriptor instead.
+func (*CommandOutput) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CommandOutput) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *CommandOutput) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+type CommandExit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Code  int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // exit code
+	// category is why the command failed, if it failed.
+	Category      ExitCategory `protobuf:"varint,2,opt,name=category,proto3,enum=encore.daemon.ExitCategory" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandExit) Reset() {
+	*x = CommandExit{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandExit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandExit) ProtoMessage() {}
+
+func (x *CommandExit) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandExit.ProtoReflect.Descriptor instead.
+func (*CommandExit) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CommandExit) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *CommandExit) GetCategory() ExitCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ExitCategory_EXIT_CATEGORY_UNSPECIFIED
+}
+
+type CommandDisplayErrors struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Errinsrc      []byte                 `protobuf:"bytes,1,opt,name=errinsrc,proto3" json:"errinsrc,omitempty"` // error messages in source code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandDisplayErrors) Reset() {
+	*x = CommandDisplayErrors{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandDisplayErrors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandDisplayErrors) ProtoMessage() {}
+
+func (x *CommandDisplayErrors) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandDisplayErrors.ProtoReflect.Descriptor instead.
+func (*CommandDisplayErrors) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CommandDisplayErrors) GetErrinsrc() []byte {
+	if x != nil {
+		return x.Errinsrc
+	}
+	return nil
+}
+
+type CreateAppRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_root is the absolute filesystem path to the Encore app root.
+	AppRoot string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// template is the template used to create the app
+	Template string `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	// tutorial is a flag to indicate if the app is a tutorial app
+	Tutorial      bool `protobuf:"varint,3,opt,name=tutorial,proto3" json:"tutorial,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAppRequest) Reset() {
+	*x = CreateAppRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAppRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAppRequest) ProtoMessage() {}
+
+func (x *CreateAppRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAppRequest.ProtoReflect.Descriptor instead.
+func (*CreateAppRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateAppRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *CreateAppRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *CreateAppRequest) GetTutorial() bool {
+	if x != nil {
+		return x.Tutorial
+	}
+	return false
+}
+
+type CreateAppResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAppResponse) Reset() {
+	*x = CreateAppResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAppResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAppResponse) ProtoMessage() {}
+
+func (x *CreateAppResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAppResponse.ProtoReflect.Descriptor instead.
+func (*CreateAppResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateAppResponse) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type RunRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_root is the absolute filesystem path to the Encore app root.
+	AppRoot string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// working_dir is the working directory relative to the app_root,
+	// for formatting relative paths in error messages.
+	WorkingDir string `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	// watch, if true, enables live reloading of the app whenever the source changes.
+	Watch bool `protobuf:"varint,5,opt,name=watch,proto3" json:"watch,omitempty"`
+	// listen_addr is the address to listen on.
+	ListenAddr string `protobuf:"bytes,6,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
+	// environ is the environment to set for the running command.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ []string `protobuf:"bytes,7,rep,name=environ,proto3" json:"environ,omitempty"`
+	// trace_file, if set specifies a trace file to write trace information
+	// about the parse and compilation process to.
+	TraceFile *string `protobuf:"bytes,8,opt,name=trace_file,json=traceFile,proto3,oneof" json:"trace_file,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,9,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// browser specifies whether and how to open the browser on startup.
+	Browser RunRequest_BrowserMode `protobuf:"varint,10,opt,name=browser,proto3,enum=encore.daemon.RunRequest_BrowserMode" json:"browser,omitempty"`
+	// debug_mode specifies the debug mode to use.
+	DebugMode RunRequest_DebugMode `protobuf:"varint,11,opt,name=debug_mode,json=debugMode,proto3,enum=encore.daemon.RunRequest_DebugMode" json:"debug_mode,omitempty"`
+	// Log level override.
+	LogLevel *string `protobuf:"bytes,12,opt,name=log_level,json=logLevel,proto3,oneof" json:"log_level,omitempty"`
+	// scrub_sensitive_data, if true, scrubs sensitive data from local traces.
+	ScrubSensitiveData bool `protobuf:"varint,13,opt,name=scrub_sensitive_data,json=scrubSensitiveData,proto3" json:"scrub_sensitive_data,omitempty"`
+	// non_interactive, if true, signals that the CLI is not connected to an
+	// interactive terminal. The build progress UI is rendered as plain
+	// one-line-per-event output instead of the spinner.
+	NonInteractive bool `protobuf:"varint,14,opt,name=non_interactive,json=nonInteractive,proto3" json:"non_interactive,omitempty"`
+	// emulation specifies which kind of environment the local run
+	// should emulate. It defaults to regular local development.
+	Emulation RunRequest_EmulationProfile `protobuf:"varint,15,opt,name=emulation,proto3,enum=encore.daemon.RunRequest_EmulationProfile" json:"emulation,omitempty"`
+	// resumable, if true, keeps the app running for a while if the connection
+	// to the daemon is interrupted, so the stream can be resumed with ResumeStream.
+	Resumable bool `protobuf:"varint,16,opt,name=resumable,proto3" json:"resumable,omitempty"`
+	// batch_output, if true, batches the app's output into CommandOutputBatch
+	// messages instead of sending each write as a CommandOutput message.
+	BatchOutput bool `protobuf:"varint,17,opt,name=batch_output,json=batchOutput,proto3" json:"batch_output,omitempty"`
+	// output_compression, if set, compresses the batched output.
+	// It implies batch_output.
+	OutputCompression OutputCompression `protobuf:"varint,18,opt,name=output_compression,json=outputCompression,proto3,enum=encore.daemon.OutputCompression" json:"output_compression,omitempty"`
+	// debug_bundles, if true, captures the request payload, database rows read
+	// and Pub/Sub messages published by failed requests into debug bundles.
+	DebugBundles bool `protobuf:"varint,19,opt,name=debug_bundles,json=debugBundles,proto3" json:"debug_bundles,omitempty"`
+	// dap_listen_addr is the address to serve the Debug Adapter Protocol proxy on
+	// when debug_mode is DEBUG_ENABLED, for debugging the app's processes with Delve
+	// from editors. If empty, no proxy is served.
+	DapListenAddr string `protobuf:"bytes,20,opt,name=dap_listen_addr,json=dapListenAddr,proto3" json:"dap_listen_addr,omitempty"`
+	// vuln_scan, if true, scans the app's dependencies for known vulnerabilities
+	// after each build and reports the reachable ones in the output.
+	VulnScan bool `protobuf:"varint,21,opt,name=vuln_scan,json=vulnScan,proto3" json:"vuln_scan,omitempty"`
+	// auto_port, if true, runs the app on the next available port
+	// if the port in listen_addr is already in use, such as by another app.
+	AutoPort bool `protobuf:"varint,22,opt,name=auto_port,json=autoPort,proto3" json:"auto_port,omitempty"`
+	// skip_ready_check, if true, announces the app as running as soon as
+	// it has started, without waiting for its services to finish initializing.
+	SkipReadyCheck bool `protobuf:"varint,23,opt,name=skip_ready_check,json=skipReadyCheck,proto3" json:"skip_ready_check,omitempty"`
+	// tls, if true, also serves the app over HTTPS on listen_addr,
+	// with a certificate issued by a locally-trusted certificate authority.
+	Tls bool `protobuf:"varint,24,opt,name=tls,proto3" json:"tls,omitempty"`
+	// profile, if true, serves the net/http/pprof endpoints of each
+	// of the app's processes on a dedicated port.
+	Profile bool `protobuf:"varint,25,opt,name=profile,proto3" json:"profile,omitempty"`
+	// detach, if true, runs the app in the background: the daemon keeps
+	// it running after the client disconnects, and the stream ends once
+	// the app is running. Use AttachRun to attach to it again and
+	// StopRun to stop it. It implies resumable.
+	Detach bool `protobuf:"varint,26,opt,name=detach,proto3" json:"detach,omitempty"`
+	// services, if non-empty, are the only services to start, along with the gateways.
+	// Calls to other services are proxied to upstream, or fail if it's empty.
+	Services []string `protobuf:"bytes,27,rep,name=services,proto3" json:"services,omitempty"`
+	// upstream is the base URL of an environment to proxy calls to the services
+	// not started to, such as "https://staging-my-app.encr.app".
+	Upstream string `protobuf:"bytes,28,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	// structured_output, if true, reports the build progress and the app
+	// starting and reloading as CommandEvent messages, instead of as output.
+	StructuredOutput bool `protobuf:"varint,29,opt,name=structured_output,json=structuredOutput,proto3" json:"structured_output,omitempty"`
+	// interpolated_env_keys are the names of the variables in environ
+	// set with --env or env files. Their values may contain ${VAR} references
+	// to other variables, secrets and infrastructure, resolved before starting the app.
+	InterpolatedEnvKeys []string `protobuf:"bytes,30,rep,name=interpolated_env_keys,json=interpolatedEnvKeys,proto3" json:"interpolated_env_keys,omitempty"`
+	// interactive_controls, if true, indicates the client handles
+	// keyboard controls, calling ControlRun for the actions it requests.
+	InteractiveControls bool `protobuf:"varint,31,opt,name=interactive_controls,json=interactiveControls,proto3" json:"interactive_controls,omitempty"`
+	// offline, if true, runs the app without network access: the check for
+	// updates is skipped, and the secrets last synced are used instead of fetching them.
+	Offline bool `protobuf:"varint,32,opt,name=offline,proto3" json:"offline,omitempty"`
+	// listen_external, if true, indicates listen_addr listens on all interfaces
+	// for other devices on the local network to reach the app at the machine's
+	// network addresses, which are reported in the output.
+	ListenExternal bool `protobuf:"varint,33,opt,name=listen_external,json=listenExternal,proto3" json:"listen_external,omitempty"`
+	// session_name, if set, names the run session recorded for the run,
+	// so it can be looked up by name with GetRunSession.
+	SessionName string `protobuf:"bytes,34,opt,name=session_name,json=sessionName,proto3" json:"session_name,omitempty"`
+	// bind_policy, if set, overrides the bind.policy config setting for the run:
+	// "warn", "loopback" or "allow". It governs listening on listen_addr and
+	// dap_listen_addr when they're reachable from other devices on the network.
+	BindPolicy *string `protobuf:"bytes,35,opt,name=bind_policy,json=bindPolicy,proto3,oneof" json:"bind_policy,omitempty"`
+	// if_running decides what to do if the app is already running
+	// in the same namespace.
+	IfRunning     RunRequest_IfRunning `protobuf:"varint,36,opt,name=if_running,json=ifRunning,proto3,enum=encore.daemon.RunRequest_IfRunning" json:"if_running,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunRequest) Reset() {
+	*x = RunRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunRequest) ProtoMessage() {}
+
+func (x *RunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunRequest.ProtoReflect.Descriptor instead.
+func (*RunRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RunRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *RunRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *RunRequest) GetWatch() bool {
+	if x != nil {
+		return x.Watch
+	}
+	return false
+}
+
+func (x *RunRequest) GetListenAddr() string {
+	if x != nil {
+		return x.ListenAddr
+	}
+	return ""
+}
+
+func (x *RunRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *RunRequest) GetTraceFile() string {
+	if x != nil && x.TraceFile != nil {
+		return *x.TraceFile
+	}
+	return ""
+}
+
+func (x *RunRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *RunRequest) GetBrowser() RunRequest_BrowserMode {
+	if x != nil {
+		return x.Browser
+	}
+	return RunRequest_BROWSER_AUTO
+}
+
+func (x *RunRequest) GetDebugMode() RunRequest_DebugMode {
+	if x != nil {
+		return x.DebugMode
+	}
+	return RunRequest_DEBUG_DISABLED
+}
+
+func (x *RunRequest) GetLogLevel() string {
+	if x != nil && x.LogLevel != nil {
+		return *x.LogLevel
+	}
+	return ""
+}
+
+func (x *RunRequest) GetScrubSensitiveData() bool {
+	if x != nil {
+		return x.ScrubSensitiveData
+	}
+	return false
+}
+
+func (x *RunRequest) GetNonInteractive() bool {
+	if x != nil {
+		return x.NonInteractive
+	}
+	return false
+}
+
+func (x *RunRequest) GetEmulation() RunRequest_EmulationProfile {
+	if x != nil {
+		return x.Emulation
+	}
+	return RunRequest_EMULATION_DEVELOPMENT
+}
+
+func (x *RunRequest) GetResumable() bool {
+	if x != nil {
+		return x.Resumable
+	}
+	return false
+}
+
+func (x *RunRequest) GetBatchOutput() bool {
+	if x != nil {
+		return x.BatchOutput
+	}
+	return false
+}
+
+func (x *RunRequest) GetOutputCompression() OutputCompression {
+	if x != nil {
+		return x.OutputCompression
+	}
+	return OutputCompression_OUTPUT_COMPRESSION_NONE
+}
+
+func (x *RunRequest) GetDebugBundles() bool {
+	if x != nil {
+		return x.DebugBundles
+	}
+	return false
+}
+
+func (x *RunRequest) GetDapListenAddr() string {
+	if x != nil {
+		return x.DapListenAddr
+	}
+	return ""
+}
+
+func (x *RunRequest) GetVulnScan() bool {
+	if x != nil {
+		return x.VulnScan
+	}
+	return false
+}
+
+func (x *RunRequest) GetAutoPort() bool {
+	if x != nil {
+		return x.AutoPort
+	}
+	return false
+}
+
+func (x *RunRequest) GetSkipReadyCheck() bool {
+	if x != nil {
+		return x.SkipReadyCheck
+	}
+	return false
+}
+
+func (x *RunRequest) GetTls() bool {
+	if x != nil {
+		return x.Tls
+	}
+	return false
+}
+
+func (x *RunRequest) GetProfile() bool {
+	if x != nil {
+		return x.Profile
+	}
+	return false
+}
+
+func (x *RunRequest) GetDetach() bool {
+	if x != nil {
+		return x.Detach
+	}
+	return false
+}
+
+func (x *RunRequest) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *RunRequest) GetUpstream() string {
+	if x != nil {
+		return x.Upstream
+	}
+	return ""
+}
+
+func (x *RunRequest) GetStructuredOutput() bool {
+	if x != nil {
+		return x.StructuredOutput
+	}
+	return false
+}
+
+func (x *RunRequest) GetInterpolatedEnvKeys() []string {
+	if x != nil {
+		return x.InterpolatedEnvKeys
+	}
+	return nil
+}
+
+func (x *RunRequest) GetInteractiveControls() bool {
+	if x != nil {
+		return x.InteractiveControls
+	}
+	return false
+}
+
+func (x *RunRequest) GetOffline() bool {
+	if x != nil {
+		return x.Offline
+	}
+	return false
+}
+
+func (x *RunRequest) GetListenExternal() bool {
+	if x != nil {
+		return x.ListenExternal
+	}
+	return false
+}
+
+func (x *RunRequest) GetSessionName() string {
+	if x != nil {
+		return x.SessionName
+	}
+	return ""
+}
+
+func (x *RunRequest) GetBindPolicy() string {
+	if x != nil && x.BindPolicy != nil {
+		return *x.BindPolicy
+	}
+	return ""
+}
+
+func (x *RunRequest) GetIfRunning() RunRequest_IfRunning {
+	if x != nil {
+		return x.IfRunning
+	}
+	return RunRequest_IF_RUNNING_FAIL
+}
+
+type AttachRunRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_root is the absolute filesystem path to the Encore app root.
+	AppRoot       string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachRunRequest) Reset() {
+	*x = AttachRunRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachRunRequest) ProtoMessage() {}
+
+func (x *AttachRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachRunRequest.ProtoReflect.Descriptor instead.
+func (*AttachRunRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AttachRunRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+type StopRunRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_root is the absolute filesystem path to the Encore app root.
+	AppRoot       string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopRunRequest) Reset() {
+	*x = StopRunRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRunRequest) ProtoMessage() {}
+
+func (x *StopRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRunRequest.ProtoReflect.Descriptor instead.
+func (*StopRunRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StopRunRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+type ControlRunRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_root is the absolute filesystem path to the Encore app root.
+	AppRoot       string                   `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Action        ControlRunRequest_Action `protobuf:"varint,2,opt,name=action,proto3,enum=encore.daemon.ControlRunRequest_Action" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ControlRunRequest) Reset() {
+	*x = ControlRunRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ControlRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlRunRequest) ProtoMessage() {}
+
+func (x *ControlRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlRunRequest.ProtoReflect.Descriptor instead.
+func (*ControlRunRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ControlRunRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *ControlRunRequest) GetAction() ControlRunRequest_Action {
+	if x != nil {
+		return x.Action
+	}
+	return ControlRunRequest_ACTION_UNSPECIFIED
+}
+
+type ListRunSessionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_root is the absolute filesystem path to the Encore app root.
+	AppRoot string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// limit is the maximum number of sessions to list. If zero, all are listed.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunSessionsRequest) Reset() {
+	*x = ListRunSessionsRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunSessionsRequest) ProtoMessage() {}
+
+func (x *ListRunSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRunSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListRunSessionsRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *ListRunSessionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRunSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*RunSession          `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunSessionsResponse) Reset() {
+	*x = ListRunSessionsResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunSessionsResponse) ProtoMessage() {}
+
+func (x *ListRunSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRunSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListRunSessionsResponse) GetSessions() []*RunSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type GetRunSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_root is the absolute filesystem path to the Encore app root.
+	AppRoot string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// session is the id of the session, or the name of it
+	// to get the most recent session with that name.
+	Session       string `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRunSessionRequest) Reset() {
+	*x = GetRunSessionRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRunSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRunSessionRequest) ProtoMessage() {}
+
+func (x *GetRunSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRunSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetRunSessionRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetRunSessionRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *GetRunSessionRequest) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+type GetRunSessionResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Session *RunSession            `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	// output is the output of the app during the session.
+	Output []byte `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	// traces are the traces recorded during the session, most recent first.
+	Traces        []*RunSessionTrace `protobuf:"bytes,3,rep,name=traces,proto3" json:"traces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRunSessionResponse) Reset() {
+	*x = GetRunSessionResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRunSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRunSessionResponse) ProtoMessage() {}
+
+func (x *GetRunSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRunSessionResponse.ProtoReflect.Descriptor instead.
+func (*GetRunSessionResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetRunSessionResponse) GetSession() *RunSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *GetRunSessionResponse) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *GetRunSessionResponse) GetTraces() []*RunSessionTrace {
+	if x != nil {
+		return x.Traces
+	}
+	return nil
+}
+
+// RunSession describes a run of an app recorded by the daemon.
+type RunSession struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// name is the name given to the session, if any.
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// git_commit is the git commit the app was at when started,
+	// suffixed with "-dirty" if there were uncommitted changes.
+	GitCommit string `protobuf:"bytes,4,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
+	// started_at is when the session started, in RFC 3339 format.
+	StartedAt string `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// ended_at is when the session ended, in RFC 3339 format.
+	// It's unset if the app is still running.
+	EndedAt *string `protobuf:"bytes,6,opt,name=ended_at,json=endedAt,proto3,oneof" json:"ended_at,omitempty"`
+	// exit_reason describes why the session ended.
+	ExitReason    string `protobuf:"bytes,7,opt,name=exit_reason,json=exitReason,proto3" json:"exit_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSession) Reset() {
+	*x = RunSession{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSession) ProtoMessage() {}
+
+func (x *RunSession) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSession.ProtoReflect.Descriptor instead.
+func (*RunSession) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RunSession) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RunSession) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RunSession) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RunSession) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *RunSession) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *RunSession) GetEndedAt() string {
+	if x != nil && x.EndedAt != nil {
+		return *x.EndedAt
+	}
+	return ""
+}
+
+func (x *RunSession) GetExitReason() string {
+	if x != nil {
+		return x.ExitReason
+	}
+	return ""
+}
+
+type RunSessionTrace struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TraceId  string                 `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	Service  string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Endpoint *string                `protobuf:"bytes,3,opt,name=endpoint,proto3,oneof" json:"endpoint,omitempty"`
+	// started_at is when the trace started, in RFC 3339 format.
+	StartedAt     string `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	DurationNanos uint64 `protobuf:"varint,5,opt,name=duration_nanos,json=durationNanos,proto3" json:"duration_nanos,omitempty"`
+	IsError       bool   `protobuf:"varint,6,opt,name=is_error,json=isError,proto3" json:"is_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSessionTrace) Reset() {
+	*x = RunSessionTrace{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSessionTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSessionTrace) ProtoMessage() {}
+
+func (x *RunSessionTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSessionTrace.ProtoReflect.Descriptor instead.
+func (*RunSessionTrace) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RunSessionTrace) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *RunSessionTrace) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *RunSessionTrace) GetEndpoint() string {
+	if x != nil && x.Endpoint != nil {
+		return *x.Endpoint
+	}
+	return ""
+}
+
+func (x *RunSessionTrace) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *RunSessionTrace) GetDurationNanos() uint64 {
+	if x != nil {
+		return x.DurationNanos
+	}
+	return 0
+}
+
+func (x *RunSessionTrace) GetIsError() bool {
+	if x != nil {
+		return x.IsError
+	}
+	return false
+}
+
+type ListRunsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_root, if set, only lists the runs of the app at this root.
+	AppRoot       string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunsRequest) Reset() {
+	*x = ListRunsRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunsRequest) ProtoMessage() {}
+
+func (x *ListRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListRunsRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListRunsRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+type ListRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*RunInfo             `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunsResponse) Reset() {
+	*x = ListRunsResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunsResponse) ProtoMessage() {}
+
+func (x *ListRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListRunsResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListRunsResponse) GetRuns() []*RunInfo {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type RunInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the unique id of the run.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// app_id is the platform or local id of the app.
+	AppId string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// app_root is the absolute filesystem path to the app root.
+	AppRoot string `protobuf:"bytes,3,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// listen_addr is the address the app's API is served on.
+	ListenAddr string `protobuf:"bytes,4,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
+	// namespace is the infrastructure namespace the app runs with.
+	Namespace string `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// dashboard_url is the URL of the app's development dashboard.
+	DashboardUrl  string `protobuf:"bytes,6,opt,name=dashboard_url,json=dashboardUrl,proto3" json:"dashboard_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunInfo) Reset() {
+	*x = RunInfo{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunInfo) ProtoMessage() {}
+
+func (x *RunInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunInfo.ProtoReflect.Descriptor instead.
+func (*RunInfo) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RunInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RunInfo) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *RunInfo) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *RunInfo) GetListenAddr() string {
+	if x != nil {
+		return x.ListenAddr
+	}
+	return ""
+}
+
+func (x *RunInfo) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RunInfo) GetDashboardUrl() string {
+	if x != nil {
+		return x.DashboardUrl
+	}
+	return ""
+}
+
+type RunSpecRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_root is the absolute filesystem path to the Encore app root.
+	AppRoot string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// working_dir is the working directory relative to the app_root,
+	// for formatting relative paths in error messages.
+	WorkingDir string `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	// environ is the environment to set for the running app,
+	// each entry in "KEY=VALUE" format.
+	Environ []string `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
+	// commands is the ordered list of spec commands to execute against the app.
+	Commands []*SpecCommand `protobuf:"bytes,4,rep,name=commands,proto3" json:"commands,omitempty"`
+	// ready_timeout_seconds is the budget for waiting on /__encore/healthz.
+	// If <= 0, the daemon picks a sensible default.
+	ReadyTimeoutSeconds int32 `protobuf:"varint,5,opt,name=ready_timeout_seconds,json=readyTimeoutSeconds,proto3" json:"ready_timeout_seconds,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace     *string `protobuf:"bytes,6,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSpecRequest) Reset() {
+	*x = RunSpecRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSpecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSpecRequest) ProtoMessage() {}
+
+func (x *RunSpecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSpecRequest.ProtoReflect.Descriptor instead.
+func (*RunSpecRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RunSpecRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *RunSpecRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *RunSpecRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *RunSpecRequest) GetCommands() []*SpecCommand {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *RunSpecRequest) GetReadyTimeoutSeconds() int32 {
+	if x != nil {
+		return x.ReadyTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RunSpecRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type SpecCommand struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Cmd:
+	//
+	//	*SpecCommand_Curl
+	Cmd           isSpecCommand_Cmd `protobuf_oneof:"cmd"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpecCommand) Reset() {
+	*x = SpecCommand{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpecCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpecCommand) ProtoMessage() {}
+
+func (x *SpecCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpecCommand.ProtoReflect.Descriptor instead.
+func (*SpecCommand) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SpecCommand) GetCmd() isSpecCommand_Cmd {
+	if x != nil {
+		return x.Cmd
+	}
+	return nil
+}
+
+func (x *SpecCommand) GetCurl() *CurlCommand {
+	if x != nil {
+		if x, ok := x.Cmd.(*SpecCommand_Curl); ok {
+			return x.Curl
+		}
+	}
+	return nil
+}
+
+type isSpecCommand_Cmd interface {
+	isSpecCommand_Cmd()
+}
+
+type SpecCommand_Curl struct {
+	Curl *CurlCommand `protobuf:"bytes,1,opt,name=curl,proto3,oneof"`
+}
+
+func (*SpecCommand_Curl) isSpecCommand_Cmd() {}
+
+type CurlCommand struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// path must start with "/" and is appended to http://<bound-addr> by the daemon.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// args are extra flags passed verbatim to the curl subprocess.
+	Args          []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurlCommand) Reset() {
+	*x = CurlCommand{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurlCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurlCommand) ProtoMessage() {}
+
+func (x *CurlCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurlCommand.ProtoReflect.Descriptor instead.
+func (*CurlCommand) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CurlCommand) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CurlCommand) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type RunSpecMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*RunSpecMessage_Output
+	//	*RunSpecMessage_Result
+	//	*RunSpecMessage_Complete
+	Msg           isRunSpecMessage_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSpecMessage) Reset() {
+	*x = RunSpecMessage{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSpecMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSpecMessage) ProtoMessage() {}
+
+func (x *RunSpecMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSpecMessage.ProtoReflect.Descriptor instead.
+func (*RunSpecMessage) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RunSpecMessage) GetMsg() isRunSpecMessage_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *RunSpecMessage) GetOutput() *CommandOutput {
+	if x != nil {
+		if x, ok := x.Msg.(*RunSpecMessage_Output); ok {
+			return x.Output
+		}
+	}
+	return nil
+}
+
+func (x *RunSpecMessage) GetResult() *SpecCommandResult {
+	if x != nil {
+		if x, ok := x.Msg.(*RunSpecMessage_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *RunSpecMessage) GetComplete() *SpecComplete {
+	if x != nil {
+		if x, ok := x.Msg.(*RunSpecMessage_Complete); ok {
+			return x.Complete
+		}
+	}
+	return nil
+}
+
+type isRunSpecMessage_Msg interface {
+	isRunSpecMessage_Msg()
+}
+
+type RunSpecMessage_Output struct {
+	// output forwards stdout/stderr from the app or daemon (compile noise,
+	// runtime logs). Clients should render this on stderr.
+	Output *CommandOutput `protobuf:"bytes,1,opt,name=output,proto3,oneof"`
+}
+
+type RunSpecMessage_Result struct {
+	// result is one finished spec command.
+	Result *SpecCommandResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+type RunSpecMessage_Complete struct {
+	// complete is the terminal message for the stream.
+	Complete *SpecComplete `protobuf:"bytes,3,opt,name=complete,proto3,oneof"`
+}
+
+func (*RunSpecMessage_Output) isRunSpecMessage_Msg() {}
+
+func (*RunSpecMessage_Result) isRunSpecMessage_Msg() {}
+
+func (*RunSpecMessage_Complete) isRunSpecMessage_Msg() {}
+
+type SpecCommandResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // 1-based
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Display       string                 `protobuf:"bytes,3,opt,name=display,proto3" json:"display,omitempty"` // human-readable, e.g. "curl /api/foo -i"
+	Stdout        []byte                 `protobuf:"bytes,4,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr        []byte                 `protobuf:"bytes,5,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpecCommandResult) Reset() {
+	*x = SpecCommandResult{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpecCommandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpecCommandResult) ProtoMessage() {}
+
+func (x *SpecCommandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpecCommandResult.ProtoReflect.Descriptor instead.
+func (*SpecCommandResult) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SpecCommandResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SpecCommandResult) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SpecCommandResult) GetDisplay() string {
+	if x != nil {
+		return x.Display
+	}
+	return ""
+}
+
+func (x *SpecCommandResult) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *SpecCommandResult) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *SpecCommandResult) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+type SpecComplete struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Succeeded int32                  `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Total     int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// error, if non-empty, indicates the run never reached the command-running
+	// phase (compile failure, healthz timeout, etc.). When set, succeeded/total
+	// reflect commands that did execute (typically zero).
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpecComplete) Reset() {
+	*x = SpecComplete{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpecComplete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpecComplete) ProtoMessage() {}
+
+func (x *SpecComplete) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpecComplete.ProtoReflect.Descriptor instead.
+func (*SpecComplete) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SpecComplete) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *SpecComplete) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SpecComplete) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type TestRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	Args       []string               `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	// environ is the environment to set for the running command.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ []string `protobuf:"bytes,4,rep,name=environ,proto3" json:"environ,omitempty"`
+	// trace_file, if set specifies a trace file to write trace information
+	// about the parse and compilation process to.
+	TraceFile *string `protobuf:"bytes,6,opt,name=trace_file,json=traceFile,proto3,oneof" json:"trace_file,omitempty"`
+	// codegen_debug, if true, dumps the generated code and prints where it is located.
+	CodegenDebug bool `protobuf:"varint,7,opt,name=codegen_debug,json=codegenDebug,proto3" json:"codegen_debug,omitempty"`
+	// temp_dir is a temp dir that will be cleaned up after tests have been executed
+	// to write things like app meta and runtime config etc.
+	TempDir string `protobuf:"bytes,8,opt,name=temp_dir,json=tempDir,proto3" json:"temp_dir,omitempty"`
+	// db_isolation, if set, isolates the app's SQL databases to each test ("test")
+	// or to each test binary ("package") by cloning them from the migrated template database.
+	DbIsolation string `protobuf:"bytes,9,opt,name=db_isolation,json=dbIsolation,proto3" json:"db_isolation,omitempty"`
+	// resumable, if true, keeps the tests running for a while if the connection
+	// to the daemon is interrupted, so the stream can be resumed with ResumeStream.
+	Resumable     bool `protobuf:"varint,10,opt,name=resumable,proto3" json:"resumable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestRequest) Reset() {
+	*x = TestRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestRequest) ProtoMessage() {}
+
+func (x *TestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestRequest.ProtoReflect.Descriptor instead.
+func (*TestRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TestRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *TestRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *TestRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *TestRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *TestRequest) GetTraceFile() string {
+	if x != nil && x.TraceFile != nil {
+		return *x.TraceFile
+	}
+	return ""
+}
+
+func (x *TestRequest) GetCodegenDebug() bool {
+	if x != nil {
+		return x.CodegenDebug
+	}
+	return false
+}
+
+func (x *TestRequest) GetTempDir() string {
+	if x != nil {
+		return x.TempDir
+	}
+	return ""
+}
+
+func (x *TestRequest) GetDbIsolation() string {
+	if x != nil {
+		return x.DbIsolation
+	}
+	return ""
+}
+
+func (x *TestRequest) GetResumable() bool {
+	if x != nil {
+		return x.Resumable
+	}
+	return false
+}
+
+type TestSpecRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	Args       []string               `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	// environ is the environment to set for the running command.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ []string `protobuf:"bytes,4,rep,name=environ,proto3" json:"environ,omitempty"`
+	// temp_dir is a temp dir that will be cleaned up after tests have been executed
+	// to write things like app meta and runtime config etc.
+	TempDir string `protobuf:"bytes,5,opt,name=temp_dir,json=tempDir,proto3" json:"temp_dir,omitempty"`
+	// db_isolation, if set, isolates the app's SQL databases to each test ("test")
+	// or to each test binary ("package") by cloning them from the migrated template database.
+	DbIsolation   string `protobuf:"bytes,6,opt,name=db_isolation,json=dbIsolation,proto3" json:"db_isolation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestSpecRequest) Reset() {
+	*x = TestSpecRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestSpecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestSpecRequest) ProtoMessage() {}
+
+func (x *TestSpecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestSpecRequest.ProtoReflect.Descriptor instead.
+func (*TestSpecRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TestSpecRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *TestSpecRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *TestSpecRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *TestSpecRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *TestSpecRequest) GetTempDir() string {
+	if x != nil {
+		return x.TempDir
+	}
+	return ""
+}
+
+func (x *TestSpecRequest) GetDbIsolation() string {
+	if x != nil {
+		return x.DbIsolation
+	}
+	return ""
+}
+
+type TestSpecResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args          []string               `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	Environ       []string               `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestSpecResponse) Reset() {
+	*x = TestSpecResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestSpecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestSpecResponse) ProtoMessage() {}
+
+func (x *TestSpecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestSpecResponse.ProtoReflect.Descriptor instead.
+func (*TestSpecResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TestSpecResponse) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *TestSpecResponse) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *TestSpecResponse) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+type ExecScriptRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	ScriptArgs []string               `protobuf:"bytes,4,rep,name=script_args,json=scriptArgs,proto3" json:"script_args,omitempty"`
+	// environ is the environment to set for the running command.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ []string `protobuf:"bytes,5,rep,name=environ,proto3" json:"environ,omitempty"`
+	// trace_file, if set specifies a trace file to write trace information
+	// about the parse and compilation process to.
+	TraceFile *string `protobuf:"bytes,6,opt,name=trace_file,json=traceFile,proto3,oneof" json:"trace_file,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,7,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// non_interactive, if true, signals that the CLI is not connected to an
+	// interactive terminal. See RunRequest.non_interactive for details.
+	NonInteractive bool `protobuf:"varint,8,opt,name=non_interactive,json=nonInteractive,proto3" json:"non_interactive,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExecScriptRequest) Reset() {
+	*x = ExecScriptRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecScriptRequest) ProtoMessage() {}
+
+func (x *ExecScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecScriptRequest.ProtoReflect.Descriptor instead.
+func (*ExecScriptRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ExecScriptRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *ExecScriptRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *ExecScriptRequest) GetScriptArgs() []string {
+	if x != nil {
+		return x.ScriptArgs
+	}
+	return nil
+}
+
+func (x *ExecScriptRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *ExecScriptRequest) GetTraceFile() string {
+	if x != nil && x.TraceFile != nil {
+		return *x.TraceFile
+	}
+	return ""
+}
+
+func (x *ExecScriptRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *ExecScriptRequest) GetNonInteractive() bool {
+	if x != nil {
+		return x.NonInteractive
+	}
+	return false
+}
+
+type ExecSpecRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	ScriptArgs []string               `protobuf:"bytes,4,rep,name=script_args,json=scriptArgs,proto3" json:"script_args,omitempty"`
+	// environ is the environment to set for the running command.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ []string `protobuf:"bytes,5,rep,name=environ,proto3" json:"environ,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,7,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// temp_dir is a temp dir that will be cleaned up by the CLI after the command
+	// has been executed, to write things like app meta and runtime config etc.
+	TempDir string `protobuf:"bytes,8,opt,name=temp_dir,json=tempDir,proto3" json:"temp_dir,omitempty"`
+	// non_interactive, if true, signals that the CLI is not connected to an
+	// interactive terminal. See RunRequest.non_interactive for details.
+	NonInteractive bool `protobuf:"varint,9,opt,name=non_interactive,json=nonInteractive,proto3" json:"non_interactive,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExecSpecRequest) Reset() {
+	*x = ExecSpecRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecSpecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecSpecRequest) ProtoMessage() {}
+
+func (x *ExecSpecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecSpecRequest.ProtoReflect.Descriptor instead.
+func (*ExecSpecRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ExecSpecRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *ExecSpecRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *ExecSpecRequest) GetScriptArgs() []string {
+	if x != nil {
+		return x.ScriptArgs
+	}
+	return nil
+}
+
+func (x *ExecSpecRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *ExecSpecRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *ExecSpecRequest) GetTempDir() string {
+	if x != nil {
+		return x.TempDir
+	}
+	return ""
+}
+
+func (x *ExecSpecRequest) GetNonInteractive() bool {
+	if x != nil {
+		return x.NonInteractive
+	}
+	return false
+}
+
+type ExecSpecMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*ExecSpecMessage_Output
+	//	*ExecSpecMessage_Spec
+	Msg           isExecSpecMessage_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecSpecMessage) Reset() {
+	*x = ExecSpecMessage{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecSpecMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecSpecMessage) ProtoMessage() {}
+
+func (x *ExecSpecMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecSpecMessage.ProtoReflect.Descriptor instead.
+func (*ExecSpecMessage) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ExecSpecMessage) GetMsg() isExecSpecMessage_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *ExecSpecMessage) GetOutput() *CommandOutput {
+	if x != nil {
+		if x, ok := x.Msg.(*ExecSpecMessage_Output); ok {
+			return x.Output
+		}
+	}
+	return nil
+}
+
+func (x *ExecSpecMessage) GetSpec() *ExecSpecResponse {
+	if x != nil {
+		if x, ok := x.Msg.(*ExecSpecMessage_Spec); ok {
+			return x.Spec
+		}
+	}
+	return nil
+}
+
+type isExecSpecMessage_Msg interface {
+	isExecSpecMessage_Msg()
+}
+
+type ExecSpecMessage_Output struct {
+	Output *CommandOutput `protobuf:"bytes,1,opt,name=output,proto3,oneof"`
+}
+
+type ExecSpecMessage_Spec struct {
+	Spec *ExecSpecResponse `protobuf:"bytes,2,opt,name=spec,proto3,oneof"`
+}
+
+func (*ExecSpecMessage_Output) isExecSpecMessage_Msg() {}
+
+func (*ExecSpecMessage_Spec) isExecSpecMessage_Msg() {}
+
+type ExecSpecResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args          []string               `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	Environ       []string               `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecSpecResponse) Reset() {
+	*x = ExecSpecResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecSpecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecSpecResponse) ProtoMessage() {}
+
+func (x *ExecSpecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecSpecResponse.ProtoReflect.Descriptor instead.
+func (*ExecSpecResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ExecSpecResponse) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ExecSpecResponse) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ExecSpecResponse) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+type CheckRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	// codegen_debug, if true, dumps the generated code and prints where it is located.
+	CodegenDebug bool `protobuf:"varint,3,opt,name=codegen_debug,json=codegenDebug,proto3" json:"codegen_debug,omitempty"`
+	// parse_tests, if true, exercises test parsing and codegen as well.
+	ParseTests bool `protobuf:"varint,4,opt,name=parse_tests,json=parseTests,proto3" json:"parse_tests,omitempty"`
+	// environ is the environment to set for the running command.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ       []string `protobuf:"bytes,5,rep,name=environ,proto3" json:"environ,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRequest) Reset() {
+	*x = CheckRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRequest) ProtoMessage() {}
+
+func (x *CheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CheckRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *CheckRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *CheckRequest) GetCodegenDebug() bool {
+	if x != nil {
+		return x.CodegenDebug
+	}
+	return false
+}
+
+func (x *CheckRequest) GetParseTests() bool {
+	if x != nil {
+		return x.ParseTests
+	}
+	return false
+}
+
+func (x *CheckRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+type ExportRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// goos and goarch specify the platform configuration to compile
+	// the application for. The values must be valid GOOS/GOARCH values.
+	Goos   string `protobuf:"bytes,2,opt,name=goos,proto3" json:"goos,omitempty"`
+	Goarch string `protobuf:"bytes,3,opt,name=goarch,proto3" json:"goarch,omitempty"`
+	// cgo_enabled specifies whether to build with cgo enabled.
+	// The host must have a valid C compiler for the target platform
+	// if true.
+	CgoEnabled bool `protobuf:"varint,4,opt,name=cgo_enabled,json=cgoEnabled,proto3" json:"cgo_enabled,omitempty"`
+	// environ is the environment to set for the running command.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ []string `protobuf:"bytes,5,rep,name=environ,proto3" json:"environ,omitempty"`
+	// Types that are valid to be assigned to Format:
+	//
+	//	*ExportRequest_Docker
+	Format        isExportRequest_Format `protobuf_oneof:"format"`
+	InfraConfPath string                 `protobuf:"bytes,7,opt,name=infra_conf_path,json=infraConfPath,proto3" json:"infra_conf_path,omitempty"`
+	Services      []string               `protobuf:"bytes,8,rep,name=services,proto3" json:"services,omitempty"`
+	Gateways      []string               `protobuf:"bytes,9,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	SkipInfraConf bool                   `protobuf:"varint,10,opt,name=skip_infra_conf,json=skipInfraConf,proto3" json:"skip_infra_conf,omitempty"`
+	// A parent path to app_root containing the .git, or the same as app_root
+	WorkspaceRoot string `protobuf:"bytes,11,opt,name=workspace_root,json=workspaceRoot,proto3" json:"workspace_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ExportRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetGoos() string {
+	if x != nil {
+		return x.Goos
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetGoarch() string {
+	if x != nil {
+		return x.Goarch
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetCgoEnabled() bool {
+	if x != nil {
+		return x.CgoEnabled
+	}
+	return false
+}
+
+func (x *ExportRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetFormat() isExportRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetDocker() *DockerExportParams {
+	if x != nil {
+		if x, ok := x.Format.(*ExportRequest_Docker); ok {
+			return x.Docker
+		}
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetInfraConfPath() string {
+	if x != nil {
+		return x.InfraConfPath
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetGateways() []string {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetSkipInfraConf() bool {
+	if x != nil {
+		return x.SkipInfraConf
+	}
+	return false
+}
+
+func (x *ExportRequest) GetWorkspaceRoot() string {
+	if x != nil {
+		return x.WorkspaceRoot
+	}
+	return ""
+}
+
+type isExportRequest_Format interface {
+	isExportRequest_Format()
+}
+
+type ExportRequest_Docker struct {
+	// docker specifies to export the app as a docker image.
+	Docker *DockerExportParams `protobuf:"bytes,6,opt,name=docker,proto3,oneof"`
+}
+
+func (*ExportRequest_Docker) isExportRequest_Format() {}
+
+type DockerExportParams struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// local_daemon_tag specifies what to tag the image as
+	// in the local Docker daemon. If empty the export does not
+	// interact with (or require) the local docker daemon at all.
+	LocalDaemonTag string `protobuf:"bytes,1,opt,name=local_daemon_tag,json=localDaemonTag,proto3" json:"local_daemon_tag,omitempty"`
+	// push_destination_tag specifies the remote registry tag
+	// to push the exported image to. If empty the built image
+	// is not pushed anywhere.
+	PushDestinationTag string `protobuf:"bytes,2,opt,name=push_destination_tag,json=pushDestinationTag,proto3" json:"push_destination_tag,omitempty"`
+	// base_image_tag is the base image to build the image from.
+	BaseImageTag  string `protobuf:"bytes,3,opt,name=base_image_tag,json=baseImageTag,proto3" json:"base_image_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DockerExportParams) Reset() {
+	*x = DockerExportParams{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DockerExportParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DockerExportParams) ProtoMessage() {}
+
+func (x *DockerExportParams) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DockerExportParams.ProtoReflect.Descriptor instead.
+func (*DockerExportParams) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DockerExportParams) GetLocalDaemonTag() string {
+	if x != nil {
+		return x.LocalDaemonTag
+	}
+	return ""
+}
+
+func (x *DockerExportParams) GetPushDestinationTag() string {
+	if x != nil {
+		return x.PushDestinationTag
+	}
+	return ""
+}
+
+func (x *DockerExportParams) GetBaseImageTag() string {
+	if x != nil {
+		return x.BaseImageTag
+	}
+	return ""
+}
+
+type DBConnectRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot     string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	DbName      string                 `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	EnvName     string                 `protobuf:"bytes,3,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"` // optional
+	ClusterType DBClusterType          `protobuf:"varint,4,opt,name=cluster_type,json=clusterType,proto3,enum=encore.daemon.DBClusterType" json:"cluster_type,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace     *string `protobuf:"bytes,5,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Role          DBRole  `protobuf:"varint,6,opt,name=role,proto3,enum=encore.daemon.DBRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBConnectRequest) Reset() {
+	*x = DBConnectRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBConnectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBConnectRequest) ProtoMessage() {}
+
+func (x *DBConnectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBConnectRequest.ProtoReflect.Descriptor instead.
+func (*DBConnectRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DBConnectRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBConnectRequest) GetDbName() string {
+	if x != nil {
+		return x.DbName
+	}
+	return ""
+}
+
+func (x *DBConnectRequest) GetEnvName() string {
+	if x != nil {
+		return x.EnvName
+	}
+	return ""
+}
+
+func (x *DBConnectRequest) GetClusterType() DBClusterType {
+	if x != nil {
+		return x.ClusterType
+	}
+	return DBClusterType_DB_CLUSTER_TYPE_UNSPECIFIED
+}
+
+func (x *DBConnectRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DBConnectRequest) GetRole() DBRole {
+	if x != nil {
+		return x.Role
+	}
+	return DBRole_DB_ROLE_UNSPECIFIED
+}
+
+type DBConnectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dsn           string                 `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBConnectResponse) Reset() {
+	*x = DBConnectResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBConnectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBConnectResponse) ProtoMessage() {}
+
+func (x *DBConnectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBConnectResponse.ProtoReflect.Descriptor instead.
+func (*DBConnectResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DBConnectResponse) GetDsn() string {
+	if x != nil {
+		return x.Dsn
+	}
+	return ""
+}
+
+type DBProxyRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot     string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	EnvName     string                 `protobuf:"bytes,2,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"` // optional
+	Port        int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`                     // optional
+	ClusterType DBClusterType          `protobuf:"varint,4,opt,name=cluster_type,json=clusterType,proto3,enum=encore.daemon.DBClusterType" json:"cluster_type,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace     *string `protobuf:"bytes,5,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	Role          DBRole  `protobuf:"varint,6,opt,name=role,proto3,enum=encore.daemon.DBRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBProxyRequest) Reset() {
+	*x = DBProxyRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBProxyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBProxyRequest) ProtoMessage() {}
+
+func (x *DBProxyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBProxyRequest.ProtoReflect.Descriptor instead.
+func (*DBProxyRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DBProxyRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBProxyRequest) GetEnvName() string {
+	if x != nil {
+		return x.EnvName
+	}
+	return ""
+}
+
+func (x *DBProxyRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *DBProxyRequest) GetClusterType() DBClusterType {
+	if x != nil {
+		return x.ClusterType
+	}
+	return DBClusterType_DB_CLUSTER_TYPE_UNSPECIFIED
+}
+
+func (x *DBProxyRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DBProxyRequest) GetRole() DBRole {
+	if x != nil {
+		return x.Role
+	}
+	return DBRole_DB_ROLE_UNSPECIFIED
+}
+
+type DBResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	DatabaseNames []string               `protobuf:"bytes,2,rep,name=database_names,json=databaseNames,proto3" json:"database_names,omitempty"` // database names to reset
+	ClusterType   DBClusterType          `protobuf:"varint,3,opt,name=cluster_type,json=clusterType,proto3,enum=encore.daemon.DBClusterType" json:"cluster_type,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// seed applies the seeds of the databases after resetting them,
+	// even if encore.app doesn't enable applying them automatically.
+	Seed          bool `protobuf:"varint,5,opt,name=seed,proto3" json:"seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBResetRequest) Reset() {
+	*x = DBResetRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBResetRequest) ProtoMessage() {}
+
+func (x *DBResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBResetRequest.ProtoReflect.Descriptor instead.
+func (*DBResetRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DBResetRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBResetRequest) GetDatabaseNames() []string {
+	if x != nil {
+		return x.DatabaseNames
+	}
+	return nil
+}
+
+func (x *DBResetRequest) GetClusterType() DBClusterType {
+	if x != nil {
+		return x.ClusterType
+	}
+	return DBClusterType_DB_CLUSTER_TYPE_UNSPECIFIED
+}
+
+func (x *DBResetRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DBResetRequest) GetSeed() bool {
+	if x != nil {
+		return x.Seed
+	}
+	return false
+}
+
+type DBSeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	DatabaseNames []string               `protobuf:"bytes,2,rep,name=database_names,json=databaseNames,proto3" json:"database_names,omitempty"` // databases to seed; all if empty
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// force applies the seeds that have already been applied again.
+	Force bool `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
+	// environ is the environment to execute Go seeds with.
+	Environ       []string `protobuf:"bytes,5,rep,name=environ,proto3" json:"environ,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBSeedRequest) Reset() {
+	*x = DBSeedRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBSeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBSeedRequest) ProtoMessage() {}
+
+func (x *DBSeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBSeedRequest.ProtoReflect.Descriptor instead.
+func (*DBSeedRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DBSeedRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBSeedRequest) GetDatabaseNames() []string {
+	if x != nil {
+		return x.DatabaseNames
+	}
+	return nil
+}
+
+func (x *DBSeedRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DBSeedRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *DBSeedRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+type DBSeedStatusRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace     *string `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBSeedStatusRequest) Reset() {
+	*x = DBSeedStatusRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBSeedStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBSeedStatusRequest) ProtoMessage() {}
+
+func (x *DBSeedStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBSeedStatusRequest.ProtoReflect.Descriptor instead.
+func (*DBSeedStatusRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DBSeedStatusRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBSeedStatusRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type DBSeedStatusResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Seeds         []*DBSeedStatusResponse_Seed `protobuf:"bytes,1,rep,name=seeds,proto3" json:"seeds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBSeedStatusResponse) Reset() {
+	*x = DBSeedStatusResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBSeedStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBSeedStatusResponse) ProtoMessage() {}
+
+func (x *DBSeedStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBSeedStatusResponse.ProtoReflect.Descriptor instead.
+func (*DBSeedStatusResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DBSeedStatusResponse) GetSeeds() []*DBSeedStatusResponse_Seed {
+	if x != nil {
+		return x.Seeds
+	}
+	return nil
+}
+
+type DBMigrationPlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	DatabaseNames []string               `protobuf:"bytes,2,rep,name=database_names,json=databaseNames,proto3" json:"database_names,omitempty"` // databases to plan; all if empty
+	// env_name is the environment to plan the migrations for,
+	// or "local" for a local namespace.
+	EnvName string `protobuf:"bytes,3,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"`
+	// namespace is the infrastructure namespace to use, for env_name "local".
+	// If empty the active namespace is used.
+	Namespace     *string `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBMigrationPlanRequest) Reset() {
+	*x = DBMigrationPlanRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBMigrationPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBMigrationPlanRequest) ProtoMessage() {}
+
+func (x *DBMigrationPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBMigrationPlanRequest.ProtoReflect.Descriptor instead.
+func (*DBMigrationPlanRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DBMigrationPlanRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBMigrationPlanRequest) GetDatabaseNames() []string {
+	if x != nil {
+		return x.DatabaseNames
+	}
+	return nil
+}
+
+func (x *DBMigrationPlanRequest) GetEnvName() string {
+	if x != nil {
+		return x.EnvName
+	}
+	return ""
+}
+
+func (x *DBMigrationPlanRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+type DBMigrationPlanResponse struct {
+	state         protoimpl.MessageState              `protogen:"open.v1"`
+	Databases     []*DBMigrationPlanResponse_Database `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBMigrationPlanResponse) Reset() {
+	*x = DBMigrationPlanResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBMigrationPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBMigrationPlanResponse) ProtoMessage() {}
+
+func (x *DBMigrationPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBMigrationPlanResponse.ProtoReflect.Descriptor instead.
+func (*DBMigrationPlanResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DBMigrationPlanResponse) GetDatabases() []*DBMigrationPlanResponse_Database {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+type DBRollbackRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot      string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	DatabaseName string                 `protobuf:"bytes,2,opt,name=database_name,json=databaseName,proto3" json:"database_name,omitempty"`
+	// steps is the number of most recently applied migrations to roll back.
+	// It's ignored if to_version is set.
+	Steps int32 `protobuf:"varint,3,opt,name=steps,proto3" json:"steps,omitempty"`
+	// to_version rolls back the migrations applied after the given version.
+	// Version 0 rolls back all migrations.
+	ToVersion *uint64 `protobuf:"varint,4,opt,name=to_version,json=toVersion,proto3,oneof" json:"to_version,omitempty"`
+	// env_name is the environment to roll back the migrations of,
+	// or "local" for a local namespace.
+	EnvName string `protobuf:"bytes,5,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"`
+	// namespace is the infrastructure namespace to use, for env_name "local".
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,6,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// dry_run reports the migrations that would be rolled back
+	// and the statements they run, without rolling them back.
+	DryRun bool `protobuf:"varint,7,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// confirm_env must equal env_name to roll back the migrations
+	// of a cloud environment, confirming the user's intent.
+	ConfirmEnv string `protobuf:"bytes,8,opt,name=confirm_env,json=confirmEnv,proto3" json:"confirm_env,omitempty"`
+	// allow_production allows rolling back the migrations
+	// of production environments.
+	AllowProduction bool `protobuf:"varint,9,opt,name=allow_production,json=allowProduction,proto3" json:"allow_production,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DBRollbackRequest) Reset() {
+	*x = DBRollbackRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBRollbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBRollbackRequest) ProtoMessage() {}
+
+func (x *DBRollbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBRollbackRequest.ProtoReflect.Descriptor instead.
+func (*DBRollbackRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DBRollbackRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBRollbackRequest) GetDatabaseName() string {
+	if x != nil {
+		return x.DatabaseName
+	}
+	return ""
+}
+
+func (x *DBRollbackRequest) GetSteps() int32 {
+	if x != nil {
+		return x.Steps
+	}
+	return 0
+}
+
+func (x *DBRollbackRequest) GetToVersion() uint64 {
+	if x != nil && x.ToVersion != nil {
+		return *x.ToVersion
+	}
+	return 0
+}
+
+func (x *DBRollbackRequest) GetEnvName() string {
+	if x != nil {
+		return x.EnvName
+	}
+	return ""
+}
+
+func (x *DBRollbackRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DBRollbackRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *DBRollbackRequest) GetConfirmEnv() string {
+	if x != nil {
+		return x.ConfirmEnv
+	}
+	return ""
+}
+
+func (x *DBRollbackRequest) GetAllowProduction() bool {
+	if x != nil {
+		return x.AllowProduction
+	}
+	return false
+}
+
+type DBDiffRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot      string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	DatabaseName string                 `protobuf:"bytes,2,opt,name=database_name,json=databaseName,proto3" json:"database_name,omitempty"`
+	// env_name is the environment with the live schema to compare,
+	// or "local" for a local namespace.
+	EnvName string `protobuf:"bytes,3,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"`
+	// namespace is the infrastructure namespace to use for "local" environments.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// against_env is the environment to compare against, or "local" for
+	// the local namespace. If empty the live schema is compared against
+	// the schema produced by applying all the database's migrations.
+	AgainstEnv    string `protobuf:"bytes,5,opt,name=against_env,json=againstEnv,proto3" json:"against_env,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBDiffRequest) Reset() {
+	*x = DBDiffRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBDiffRequest) ProtoMessage() {}
+
+func (x *DBDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBDiffRequest.ProtoReflect.Descriptor instead.
+func (*DBDiffRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DBDiffRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBDiffRequest) GetDatabaseName() string {
+	if x != nil {
+		return x.DatabaseName
+	}
+	return ""
+}
+
+func (x *DBDiffRequest) GetEnvName() string {
+	if x != nil {
+		return x.EnvName
+	}
+	return ""
+}
+
+func (x *DBDiffRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DBDiffRequest) GetAgainstEnv() string {
+	if x != nil {
+		return x.AgainstEnv
+	}
+	return ""
+}
+
+type DBDiffResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// from and to describe the compared schemas, like "the migrations"
+	// and "namespace default". The statements turn from into to.
+	From       string                      `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To         string                      `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Statements []*DBDiffResponse_Statement `protobuf:"bytes,3,rep,name=statements,proto3" json:"statements,omitempty"`
+	// notes describe differences that can't be expressed as
+	// statements and need to be migrated by hand.
+	Notes []string `protobuf:"bytes,4,rep,name=notes,proto3" json:"notes,omitempty"`
+	// migration_dir is the absolute path to the database's migration directory,
+	// and next_version the number to give a new migration.
+	MigrationDir  string `protobuf:"bytes,5,opt,name=migration_dir,json=migrationDir,proto3" json:"migration_dir,omitempty"`
+	NextVersion   uint64 `protobuf:"varint,6,opt,name=next_version,json=nextVersion,proto3" json:"next_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBDiffResponse) Reset() {
+	*x = DBDiffResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBDiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBDiffResponse) ProtoMessage() {}
+
+func (x *DBDiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBDiffResponse.ProtoReflect.Descriptor instead.
+func (*DBDiffResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DBDiffResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *DBDiffResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *DBDiffResponse) GetStatements() []*DBDiffResponse_Statement {
+	if x != nil {
+		return x.Statements
+	}
+	return nil
+}
+
+func (x *DBDiffResponse) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *DBDiffResponse) GetMigrationDir() string {
+	if x != nil {
+		return x.MigrationDir
+	}
+	return ""
+}
+
+func (x *DBDiffResponse) GetNextVersion() uint64 {
+	if x != nil {
+		return x.NextVersion
+	}
+	return 0
+}
+
+type DBSnapshotCreateRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// namespace is the infrastructure namespace to snapshot.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// database_names are the databases to snapshot.
+	// If empty all the app's databases are snapshotted.
+	DatabaseNames []string `protobuf:"bytes,3,rep,name=database_names,json=databaseNames,proto3" json:"database_names,omitempty"`
+	// path is the absolute path to write the snapshot archive to.
+	Path          string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBSnapshotCreateRequest) Reset() {
+	*x = DBSnapshotCreateRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBSnapshotCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBSnapshotCreateRequest) ProtoMessage() {}
+
+func (x *DBSnapshotCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBSnapshotCreateRequest.ProtoReflect.Descriptor instead.
+func (*DBSnapshotCreateRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DBSnapshotCreateRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBSnapshotCreateRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DBSnapshotCreateRequest) GetDatabaseNames() []string {
+	if x != nil {
+		return x.DatabaseNames
+	}
+	return nil
+}
+
+func (x *DBSnapshotCreateRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type DBSnapshotRestoreRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// namespace is the infrastructure namespace to restore into.
+	// If empty the active namespace is used.
+	Namespace *string `protobuf:"bytes,2,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+	// database_names are the databases to restore.
+	// If empty all the databases in the snapshot are restored.
+	DatabaseNames []string `protobuf:"bytes,3,rep,name=database_names,json=databaseNames,proto3" json:"database_names,omitempty"`
+	// path is the absolute path of the snapshot archive to restore.
+	Path          string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBSnapshotRestoreRequest) Reset() {
+	*x = DBSnapshotRestoreRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBSnapshotRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBSnapshotRestoreRequest) ProtoMessage() {}
+
+func (x *DBSnapshotRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBSnapshotRestoreRequest.ProtoReflect.Descriptor instead.
+func (*DBSnapshotRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DBSnapshotRestoreRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBSnapshotRestoreRequest) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *DBSnapshotRestoreRequest) GetDatabaseNames() []string {
+	if x != nil {
+		return x.DatabaseNames
+	}
+	return nil
+}
+
+func (x *DBSnapshotRestoreRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type DBSnapshotResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// app_id and namespace describe where the snapshot was taken,
+	// and created_at when, in RFC 3339 format.
+	AppId     string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	CreatedAt string `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// databases are the databases that were snapshotted or restored.
+	Databases []string `protobuf:"bytes,4,rep,name=databases,proto3" json:"databases,omitempty"`
+	// skipped are the databases in the snapshot that weren't restored,
+	// as the app doesn't declare them or they weren't requested.
+	Skipped       []string `protobuf:"bytes,5,rep,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBSnapshotResponse) Reset() {
+	*x = DBSnapshotResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBSnapshotResponse) ProtoMessage() {}
+
+func (x *DBSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*DBSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *DBSnapshotResponse) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *DBSnapshotResponse) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DBSnapshotResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *DBSnapshotResponse) GetDatabases() []string {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+func (x *DBSnapshotResponse) GetSkipped() []string {
+	if x != nil {
+		return x.Skipped
+	}
+	return nil
+}
+
+type DBQueryLogRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// trace_id, if set, returns only the queries made by the request
+	// with the given trace.
+	TraceId *string `protobuf:"bytes,2,opt,name=trace_id,json=traceId,proto3,oneof" json:"trace_id,omitempty"`
+	// limit is the maximum number of queries to return, keeping the most recent ones.
+	// If 0 all the logged queries are returned.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBQueryLogRequest) Reset() {
+	*x = DBQueryLogRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBQueryLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBQueryLogRequest) ProtoMessage() {}
+
+func (x *DBQueryLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBQueryLogRequest.ProtoReflect.Descriptor instead.
+func (*DBQueryLogRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DBQueryLogRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBQueryLogRequest) GetTraceId() string {
+	if x != nil && x.TraceId != nil {
+		return *x.TraceId
+	}
+	return ""
+}
+
+func (x *DBQueryLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type DBQueryLogResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// queries are the logged queries, oldest first.
+	Queries       []*DBQueryLogResponse_Query `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBQueryLogResponse) Reset() {
+	*x = DBQueryLogResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBQueryLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBQueryLogResponse) ProtoMessage() {}
+
+func (x *DBQueryLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBQueryLogResponse.ProtoReflect.Descriptor instead.
+func (*DBQueryLogResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DBQueryLogResponse) GetQueries() []*DBQueryLogResponse_Query {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+type DBCDCConfigRequest struct {
+	state   protoimpl.MessageState    `protogen:"open.v1"`
+	AppRoot string                    `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Format  DBCDCConfigRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=encore.daemon.DBCDCConfigRequest_Format" json:"format,omitempty"`
+	// database_names are the databases to generate configuration for.
+	// If empty configuration is generated for all databases.
+	DatabaseNames []string `protobuf:"bytes,3,rep,name=database_names,json=databaseNames,proto3" json:"database_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBCDCConfigRequest) Reset() {
+	*x = DBCDCConfigRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBCDCConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBCDCConfigRequest) ProtoMessage() {}
+
+func (x *DBCDCConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBCDCConfigRequest.ProtoReflect.Descriptor instead.
+func (*DBCDCConfigRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DBCDCConfigRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBCDCConfigRequest) GetFormat() DBCDCConfigRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return DBCDCConfigRequest_FORMAT_UNSPECIFIED
+}
+
+func (x *DBCDCConfigRequest) GetDatabaseNames() []string {
+	if x != nil {
+		return x.DatabaseNames
+	}
+	return nil
+}
+
+type DBCDCConfigResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Files         []*DBCDCConfigResponse_File `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBCDCConfigResponse) Reset() {
+	*x = DBCDCConfigResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBCDCConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBCDCConfigResponse) ProtoMessage() {}
+
+func (x *DBCDCConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBCDCConfigResponse.ProtoReflect.Descriptor instead.
+func (*DBCDCConfigResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DBCDCConfigResponse) GetFiles() []*DBCDCConfigResponse_File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type DBCDCStreamRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot      string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	DatabaseName string                 `protobuf:"bytes,2,opt,name=database_name,json=databaseName,proto3" json:"database_name,omitempty"`
+	// topic is the Pub/Sub topic to publish change events to.
+	// If unset change events are only written to the output.
+	Topic         *string `protobuf:"bytes,3,opt,name=topic,proto3,oneof" json:"topic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DBCDCStreamRequest) Reset() {
+	*x = DBCDCStreamRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DBCDCStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DBCDCStreamRequest) ProtoMessage() {}
+
+func (x *DBCDCStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBCDCStreamRequest.ProtoReflect.Descriptor instead.
+func (*DBCDCStreamRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DBCDCStreamRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DBCDCStreamRequest) GetDatabaseName() string {
+	if x != nil {
+		return x.DatabaseName
+	}
+	return ""
+}
+
+func (x *DBCDCStreamRequest) GetTopic() string {
+	if x != nil && x.Topic != nil {
+		return *x.Topic
+	}
+	return ""
+}
+
+type AttachLogsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// services, if set, only streams the structured logs of the given services.
+	// Other output is not streamed.
+	Services      []string `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachLogsRequest) Reset() {
+	*x = AttachLogsRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachLogsRequest) ProtoMessage() {}
+
+func (x *AttachLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachLogsRequest.ProtoReflect.Descriptor instead.
+func (*AttachLogsRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *AttachLogsRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *AttachLogsRequest) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type GenClientRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	AppId    string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	EnvName  string                 `protobuf:"bytes,2,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"`
+	Lang     string                 `protobuf:"bytes,3,opt,name=lang,proto3" json:"lang,omitempty"`
+	Filepath string                 `protobuf:"bytes,4,opt,name=filepath,proto3" json:"filepath,omitempty"`
+	// Services to include in the output.
+	// If the string "*" is present all services are included.
+	Services []string `protobuf:"bytes,5,rep,name=services,proto3" json:"services,omitempty"`
+	// Services to exclude from the output.
+	// Takes precedence over 'services' above.
+	ExcludedServices []string `protobuf:"bytes,6,rep,name=excluded_services,json=excludedServices,proto3" json:"excluded_services,omitempty"`
+	// Tags of endpoints to include in the output.
+	// Only includes endpoints from services included in 'services' above.
+	EndpointTags []string `protobuf:"bytes,7,rep,name=endpoint_tags,json=endpointTags,proto3" json:"endpoint_tags,omitempty"`
+	// Tags of endpoints to exclude from the output.
+	// Takes precedence over 'endpoint_tags' above.
+	ExcludedEndpointTags []string `protobuf:"bytes,8,rep,name=excluded_endpoint_tags,json=excludedEndpointTags,proto3" json:"excluded_endpoint_tags,omitempty"`
+	// The OpenAPI spec generator by default includes private endpoints.
+	// If this is set to `true`, private endpoints will not be included
+	// in the generated OpenAPI spec.
+	OpenapiExcludePrivateEndpoints *bool `protobuf:"varint,9,opt,name=openapi_exclude_private_endpoints,json=openapiExcludePrivateEndpoints,proto3,oneof" json:"openapi_exclude_private_endpoints,omitempty"`
+	// The TS generator by default re-declares the api types in the client.
+	// If this is set to `true`, the types will be imported and shared between
+	// the client and the server. It assumes "~backend" is available in the
+	// import path.
+	TsSharedTypes *bool `protobuf:"varint,10,opt,name=ts_shared_types,json=tsSharedTypes,proto3,oneof" json:"ts_shared_types,omitempty"`
+	// If set, the default export of the generate TypeScript client will be
+	// an instantiated client with the given target. The target can be e.g.
+	// a variable, e.g. "import.meta.env.VITE_CLIENT_TARGET" or a string literal.
+	TsClientTarget *string `protobuf:"bytes,11,opt,name=ts_client_target,json=tsClientTarget,proto3,oneof" json:"ts_client_target,omitempty"`
+	// The root directory of the app to generate a client for.
+	// Included to be able to handle multi clone scenarios.
+	AppRoot string `protobuf:"bytes,12,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// If set, the TypeScript client includes React hooks calling the endpoints
+	// with the given data fetching library ("react-query" or "swr").
+	TsHooks *string `protobuf:"bytes,13,opt,name=ts_hooks,json=tsHooks,proto3,oneof" json:"ts_hooks,omitempty"`
+	// If set, the TypeScript client includes schemas for validating the
+	// request types with the given validation library ("zod").
+	TsSchemas     *string `protobuf:"bytes,14,opt,name=ts_schemas,json=tsSchemas,proto3,oneof" json:"ts_schemas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenClientRequest) Reset() {
+	*x = GenClientRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenClientRequest) ProtoMessage() {}
+
+func (x *GenClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenClientRequest.ProtoReflect.Descriptor instead.
+func (*GenClientRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GenClientRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetEnvName() string {
+	if x != nil {
+		return x.EnvName
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetFilepath() string {
+	if x != nil {
+		return x.Filepath
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *GenClientRequest) GetExcludedServices() []string {
+	if x != nil {
+		return x.ExcludedServices
+	}
+	return nil
+}
+
+func (x *GenClientRequest) GetEndpointTags() []string {
+	if x != nil {
+		return x.EndpointTags
+	}
+	return nil
+}
+
+func (x *GenClientRequest) GetExcludedEndpointTags() []string {
+	if x != nil {
+		return x.ExcludedEndpointTags
+	}
+	return nil
+}
+
+func (x *GenClientRequest) GetOpenapiExcludePrivateEndpoints() bool {
+	if x != nil && x.OpenapiExcludePrivateEndpoints != nil {
+		return *x.OpenapiExcludePrivateEndpoints
+	}
+	return false
+}
+
+func (x *GenClientRequest) GetTsSharedTypes() bool {
+	if x != nil && x.TsSharedTypes != nil {
+		return *x.TsSharedTypes
+	}
+	return false
+}
+
+func (x *GenClientRequest) GetTsClientTarget() string {
+	if x != nil && x.TsClientTarget != nil {
+		return *x.TsClientTarget
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetTsHooks() string {
+	if x != nil && x.TsHooks != nil {
+		return *x.TsHooks
+	}
+	return ""
+}
+
+func (x *GenClientRequest) GetTsSchemas() string {
+	if x != nil && x.TsSchemas != nil {
+		return *x.TsSchemas
+	}
+	return ""
+}
+
+type GenClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          []byte                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenClientResponse) Reset() {
+	*x = GenClientResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenClientResponse) ProtoMessage() {}
+
+func (x *GenClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenClientResponse.ProtoReflect.Descriptor instead.
+func (*GenClientResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *GenClientResponse) GetCode() []byte {
+	if x != nil {
+		return x.Code
+	}
+	return nil
+}
+
+type GenWrappersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenWrappersRequest) Reset() {
+	*x = GenWrappersRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenWrappersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenWrappersRequest) ProtoMessage() {}
+
+func (x *GenWrappersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenWrappersRequest.ProtoReflect.Descriptor instead.
+func (*GenWrappersRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GenWrappersRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+type GenWrappersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenWrappersResponse) Reset() {
+	*x = GenWrappersResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenWrappersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenWrappersResponse) ProtoMessage() {}
+
+func (x *GenWrappersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenWrappersResponse.ProtoReflect.Descriptor instead.
+func (*GenWrappersResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{68}
+}
+
+type SecretsRefreshRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretsRefreshRequest) Reset() {
+	*x = SecretsRefreshRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretsRefreshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretsRefreshRequest) ProtoMessage() {}
+
+func (x *SecretsRefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretsRefreshRequest.ProtoReflect.Descriptor instead.
+func (*SecretsRefreshRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SecretsRefreshRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *SecretsRefreshRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SecretsRefreshRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SecretsRefreshResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretsRefreshResponse) Reset() {
+	*x = SecretsRefreshResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretsRefreshResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretsRefreshResponse) ProtoMessage() {}
+
+func (x *SecretsRefreshResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretsRefreshResponse.ProtoReflect.Descriptor instead.
+func (*SecretsRefreshResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{70}
+}
+
+type VersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	ConfigHash    string                 `protobuf:"bytes,2,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *VersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetConfigHash() string {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return ""
+}
+
+type Namespace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastActiveAt  *string                `protobuf:"bytes,5,opt,name=last_active_at,json=lastActiveAt,proto3,oneof" json:"last_active_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Namespace) Reset() {
+	*x = Namespace{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Namespace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Namespace) ProtoMessage() {}
+
+func (x *Namespace) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Namespace.ProtoReflect.Descriptor instead.
+func (*Namespace) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *Namespace) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Namespace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Namespace) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Namespace) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Namespace) GetLastActiveAt() string {
+	if x != nil && x.LastActiveAt != nil {
+		return *x.LastActiveAt
+	}
+	return ""
+}
+
+type CreateNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNamespaceRequest) Reset() {
+	*x = CreateNamespaceRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNamespaceRequest) ProtoMessage() {}
+
+func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CreateNamespaceRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *CreateNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SwitchNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Create        bool                   `protobuf:"varint,3,opt,name=create,proto3" json:"create,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwitchNamespaceRequest) Reset() {
+	*x = SwitchNamespaceRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CommandOutput) String() string {
+func (x *SwitchNamespaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommandOutput) ProtoMessage() {}
+func (*SwitchNamespaceRequest) ProtoMessage() {}
 
-func (x *CommandOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[1]
+func (x *SwitchNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -407,47 +6362,261 @@ func (x *CommandOutput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommandOutput.ProtoReflect.Descriptor instead.
-func (*CommandOutput) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SwitchNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*SwitchNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *CommandOutput) GetStdout() []byte {
+func (x *SwitchNamespaceRequest) GetAppRoot() string {
 	if x != nil {
-		return x.Stdout
+		return x.AppRoot
 	}
-	return nil
+	return ""
 }
 
-func (x *CommandOutput) GetStderr() []byte {
+func (x *SwitchNamespaceRequest) GetName() string {
 	if x != nil {
-		return x.Stderr
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SwitchNamespaceRequest) GetCreate() bool {
+	if x != nil {
+		return x.Create
+	}
+	return false
+}
+
+type ListNamespacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesRequest) Reset() {
+	*x = ListNamespacesRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesRequest) ProtoMessage() {}
+
+func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ListNamespacesRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+type DeleteNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNamespaceRequest) Reset() {
+	*x = DeleteNamespaceRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNamespaceRequest) ProtoMessage() {}
+
+func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *DeleteNamespaceRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
+}
+
+func (x *DeleteNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListNamespacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespaces    []*Namespace           `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesResponse) ProtoMessage() {}
+
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListNamespacesResponse) GetNamespaces() []*Namespace {
+	if x != nil {
+		return x.Namespaces
 	}
 	return nil
 }
 
-type CommandExit struct {
+type TelemetryConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // exit code
+	AnonId        string                 `protobuf:"bytes,1,opt,name=anon_id,json=anonId,proto3" json:"anon_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Debug         bool                   `protobuf:"varint,3,opt,name=debug,proto3" json:"debug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TelemetryConfig) Reset() {
+	*x = TelemetryConfig{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryConfig) ProtoMessage() {}
+
+func (x *TelemetryConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryConfig.ProtoReflect.Descriptor instead.
+func (*TelemetryConfig) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *TelemetryConfig) GetAnonId() string {
+	if x != nil {
+		return x.AnonId
+	}
+	return ""
+}
+
+func (x *TelemetryConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *TelemetryConfig) GetDebug() bool {
+	if x != nil {
+		return x.Debug
+	}
+	return false
+}
+
+type DumpMetaRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"` // for error reporting
+	// environ is the environment to set for the running command.
+	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
+	Environ []string `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
+	// Whether or not to parse tests.
+	ParseTests    bool                   `protobuf:"varint,4,opt,name=parse_tests,json=parseTests,proto3" json:"parse_tests,omitempty"`
+	Format        DumpMetaRequest_Format `protobuf:"varint,5,opt,name=format,proto3,enum=encore.daemon.DumpMetaRequest_Format" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CommandExit) Reset() {
-	*x = CommandExit{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[2]
+func (x *DumpMetaRequest) Reset() {
+	*x = DumpMetaRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CommandExit) String() string {
+func (x *DumpMetaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommandExit) ProtoMessage() {}
+func (*DumpMetaRequest) ProtoMessage() {}
 
-func (x *CommandExit) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[2]
+func (x *DumpMetaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,40 +6627,68 @@ func (x *CommandExit) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommandExit.ProtoReflect.Descriptor instead.
-func (*CommandExit) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use DumpMetaRequest.ProtoReflect.Descriptor instead.
+func (*DumpMetaRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *CommandExit) GetCode() int32 {
+func (x *DumpMetaRequest) GetAppRoot() string {
 	if x != nil {
-		return x.Code
+		return x.AppRoot
 	}
-	return 0
+	return ""
 }
 
-type CommandDisplayErrors struct {
+func (x *DumpMetaRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *DumpMetaRequest) GetEnviron() []string {
+	if x != nil {
+		return x.Environ
+	}
+	return nil
+}
+
+func (x *DumpMetaRequest) GetParseTests() bool {
+	if x != nil {
+		return x.ParseTests
+	}
+	return false
+}
+
+func (x *DumpMetaRequest) GetFormat() DumpMetaRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return DumpMetaRequest_FORMAT_UNSPECIFIED
+}
+
+type DumpMetaResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Errinsrc      []byte                 `protobuf:"bytes,1,opt,name=errinsrc,proto3" json:"errinsrc,omitempty"` // error messages in source code
+	Meta          []byte                 `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CommandDisplayErrors) Reset() {
-	*x = CommandDisplayErrors{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[3]
+func (x *DumpMetaResponse) Reset() {
+	*x = DumpMetaResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CommandDisplayErrors) String() string {
+func (x *DumpMetaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommandDisplayErrors) ProtoMessage() {}
+func (*DumpMetaResponse) ProtoMessage() {}
 
-func (x *CommandDisplayErrors) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[3]
+func (x *DumpMetaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -502,45 +6699,42 @@ func (x *CommandDisplayErrors) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommandDisplayErrors.ProtoReflect.Descriptor instead.
-func (*CommandDisplayErrors) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use DumpMetaResponse.ProtoReflect.Descriptor instead.
+func (*DumpMetaResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *CommandDisplayErrors) GetErrinsrc() []byte {
+func (x *DumpMetaResponse) GetMeta() []byte {
 	if x != nil {
-		return x.Errinsrc
+		return x.Meta
 	}
 	return nil
 }
 
-type CreateAppRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// app_root is the absolute filesystem path to the Encore app root.
-	AppRoot string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	// template is the template used to create the app
-	Template string `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
-	// tutorial is a flag to indicate if the app is a tutorial app
-	Tutorial      bool `protobuf:"varint,3,opt,name=tutorial,proto3" json:"tutorial,omitempty"`
+type DebugBundlesRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// trace_id, if set, restricts the bundles to those of the given trace.
+	TraceId       *string `protobuf:"bytes,2,opt,name=trace_id,json=traceId,proto3,oneof" json:"trace_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAppRequest) Reset() {
-	*x = CreateAppRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[4]
+func (x *DebugBundlesRequest) Reset() {
+	*x = DebugBundlesRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAppRequest) String() string {
+func (x *DebugBundlesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAppRequest) ProtoMessage() {}
+func (*DebugBundlesRequest) ProtoMessage() {}
 
-func (x *CreateAppRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[4]
+func (x *DebugBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,54 +6745,48 @@ func (x *CreateAppRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAppRequest.ProtoReflect.Descriptor instead.
-func (*CreateAppRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use DebugBundlesRequest.ProtoReflect.Descriptor instead.
+func (*DebugBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *CreateAppRequest) GetAppRoot() string {
+func (x *DebugBundlesRequest) GetAppRoot() string {
 	if x != nil {
 		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *CreateAppRequest) GetTemplate() string {
-	if x != nil {
-		return x.Template
+func (x *DebugBundlesRequest) GetTraceId() string {
+	if x != nil && x.TraceId != nil {
+		return *x.TraceId
 	}
 	return ""
 }
 
-func (x *CreateAppRequest) GetTutorial() bool {
-	if x != nil {
-		return x.Tutorial
-	}
-	return false
-}
-
-type CreateAppResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+type DebugBundlesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// bundles are the debug bundles, most recent first.
+	Bundles       []*DebugBundle `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAppResponse) Reset() {
-	*x = CreateAppResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[5]
+func (x *DebugBundlesResponse) Reset() {
+	*x = DebugBundlesResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAppResponse) String() string {
+func (x *DebugBundlesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAppResponse) ProtoMessage() {}
+func (*DebugBundlesResponse) ProtoMessage() {}
 
-func (x *CreateAppResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[5]
+func (x *DebugBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,69 +6797,50 @@ func (x *CreateAppResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAppResponse.ProtoReflect.Descriptor instead.
-func (*CreateAppResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use DebugBundlesResponse.ProtoReflect.Descriptor instead.
+func (*DebugBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *CreateAppResponse) GetAppId() string {
+func (x *DebugBundlesResponse) GetBundles() []*DebugBundle {
 	if x != nil {
-		return x.AppId
+		return x.Bundles
 	}
-	return ""
+	return nil
 }
 
-type RunRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// app_root is the absolute filesystem path to the Encore app root.
-	AppRoot string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	// working_dir is the working directory relative to the app_root,
-	// for formatting relative paths in error messages.
-	WorkingDir string `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
-	// watch, if true, enables live reloading of the app whenever the source changes.
-	Watch bool `protobuf:"varint,5,opt,name=watch,proto3" json:"watch,omitempty"`
-	// listen_addr is the address to listen on.
-	ListenAddr string `protobuf:"bytes,6,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
-	// environ is the environment to set for the running command.
-	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
-	Environ []string `protobuf:"bytes,7,rep,name=environ,proto3" json:"environ,omitempty"`
-	// trace_file, if set specifies a trace file to write trace information
-	// about the parse and compilation process to.
-	TraceFile *string `protobuf:"bytes,8,opt,name=trace_file,json=traceFile,proto3,oneof" json:"trace_file,omitempty"`
-	// namespace is the infrastructure namespace to use.
-	// If empty the active namespace is used.
-	Namespace *string `protobuf:"bytes,9,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
-	// browser specifies whether and how to open the browser on startup.
-	Browser RunRequest_BrowserMode `protobuf:"varint,10,opt,name=browser,proto3,enum=encore.daemon.RunRequest_BrowserMode" json:"browser,omitempty"`
-	// debug_mode specifies the debug mode to use.
-	DebugMode RunRequest_DebugMode `protobuf:"varint,11,opt,name=debug_mode,json=debugMode,proto3,enum=encore.daemon.RunRequest_DebugMode" json:"debug_mode,omitempty"`
-	// Log level override.
-	LogLevel *string `protobuf:"bytes,12,opt,name=log_level,json=logLevel,proto3,oneof" json:"log_level,omitempty"`
-	// scrub_sensitive_data, if true, scrubs sensitive data from local traces.
-	ScrubSensitiveData bool `protobuf:"varint,13,opt,name=scrub_sensitive_data,json=scrubSensitiveData,proto3" json:"scrub_sensitive_data,omitempty"`
-	// non_interactive, if true, signals that the CLI is not connected to an
-	// interactive terminal. The build progress UI is rendered as plain
-	// one-line-per-event output instead of the spinner.
-	NonInteractive bool `protobuf:"varint,14,opt,name=non_interactive,json=nonInteractive,proto3" json:"non_interactive,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type DebugBundle struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TraceId string                 `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	SpanId  string                 `protobuf:"bytes,2,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	Service string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	// endpoint is the failed endpoint, or the subscription for Pub/Sub messages.
+	Endpoint string `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Code     string `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	Error    string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// time is when the request started, in RFC 3339 format.
+	Time string `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`
+	// data is the JSON-encoded bundle.
+	Data          []byte `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunRequest) Reset() {
-	*x = RunRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[6]
+func (x *DebugBundle) Reset() {
+	*x = DebugBundle{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunRequest) String() string {
+func (x *DebugBundle) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunRequest) ProtoMessage() {}
+func (*DebugBundle) ProtoMessage() {}
 
-func (x *RunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[6]
+func (x *DebugBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -682,132 +6851,99 @@ func (x *RunRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunRequest.ProtoReflect.Descriptor instead.
-func (*RunRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use DebugBundle.ProtoReflect.Descriptor instead.
+func (*DebugBundle) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *RunRequest) GetAppRoot() string {
+func (x *DebugBundle) GetTraceId() string {
 	if x != nil {
-		return x.AppRoot
+		return x.TraceId
 	}
 	return ""
 }
 
-func (x *RunRequest) GetWorkingDir() string {
+func (x *DebugBundle) GetSpanId() string {
 	if x != nil {
-		return x.WorkingDir
+		return x.SpanId
 	}
 	return ""
 }
 
-func (x *RunRequest) GetWatch() bool {
-	if x != nil {
-		return x.Watch
-	}
-	return false
-}
-
-func (x *RunRequest) GetListenAddr() string {
+func (x *DebugBundle) GetService() string {
 	if x != nil {
-		return x.ListenAddr
+		return x.Service
 	}
 	return ""
 }
 
-func (x *RunRequest) GetEnviron() []string {
+func (x *DebugBundle) GetEndpoint() string {
 	if x != nil {
-		return x.Environ
-	}
-	return nil
-}
-
-func (x *RunRequest) GetTraceFile() string {
-	if x != nil && x.TraceFile != nil {
-		return *x.TraceFile
-	}
-	return ""
-}
-
-func (x *RunRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+		return x.Endpoint
 	}
 	return ""
 }
 
-func (x *RunRequest) GetBrowser() RunRequest_BrowserMode {
-	if x != nil {
-		return x.Browser
-	}
-	return RunRequest_BROWSER_AUTO
-}
-
-func (x *RunRequest) GetDebugMode() RunRequest_DebugMode {
+func (x *DebugBundle) GetCode() string {
 	if x != nil {
-		return x.DebugMode
-	}
-	return RunRequest_DEBUG_DISABLED
-}
-
-func (x *RunRequest) GetLogLevel() string {
-	if x != nil && x.LogLevel != nil {
-		return *x.LogLevel
+		return x.Code
 	}
 	return ""
 }
-
-func (x *RunRequest) GetScrubSensitiveData() bool {
-	if x != nil {
-		return x.ScrubSensitiveData
-	}
-	return false
-}
-
-func (x *RunRequest) GetNonInteractive() bool {
-	if x != nil {
-		return x.NonInteractive
-	}
-	return false
-}
-
-type RunSpecRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// app_root is the absolute filesystem path to the Encore app root.
-	AppRoot string `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	// working_dir is the working directory relative to the app_root,
-	// for formatting relative paths in error messages.
-	WorkingDir string `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
-	// environ is the environment to set for the running app,
-	// each entry in "KEY=VALUE" format.
-	Environ []string `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
-	// commands is the ordered list of spec commands to execute against the app.
-	Commands []*SpecCommand `protobuf:"bytes,4,rep,name=commands,proto3" json:"commands,omitempty"`
-	// ready_timeout_seconds is the budget for waiting on /__encore/healthz.
-	// If <= 0, the daemon picks a sensible default.
-	ReadyTimeoutSeconds int32 `protobuf:"varint,5,opt,name=ready_timeout_seconds,json=readyTimeoutSeconds,proto3" json:"ready_timeout_seconds,omitempty"`
-	// namespace is the infrastructure namespace to use.
-	// If empty the active namespace is used.
-	Namespace     *string `protobuf:"bytes,6,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
+
+func (x *DebugBundle) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DebugBundle) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *DebugBundle) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AddLogpointRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// service is the service or gateway in whose process to add the logpoint.
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// location is where to add the logpoint: a "file.go:line" or
+	// a function such as "users.Get" or "users.(*Service).Get".
+	Location string `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	// exprs are the Go expressions to evaluate when the location is reached.
+	Exprs []string `protobuf:"bytes,4,rep,name=exprs,proto3" json:"exprs,omitempty"`
+	// condition, if set, is a Go expression that must be true
+	// for the expressions to be evaluated.
+	Condition     *string `protobuf:"bytes,5,opt,name=condition,proto3,oneof" json:"condition,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunSpecRequest) Reset() {
-	*x = RunSpecRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[7]
+func (x *AddLogpointRequest) Reset() {
+	*x = AddLogpointRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunSpecRequest) String() string {
+func (x *AddLogpointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunSpecRequest) ProtoMessage() {}
+func (*AddLogpointRequest) ProtoMessage() {}
 
-func (x *RunSpecRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[7]
+func (x *AddLogpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -818,78 +6954,72 @@ func (x *RunSpecRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunSpecRequest.ProtoReflect.Descriptor instead.
-func (*RunSpecRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AddLogpointRequest.ProtoReflect.Descriptor instead.
+func (*AddLogpointRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *RunSpecRequest) GetAppRoot() string {
+func (x *AddLogpointRequest) GetAppRoot() string {
 	if x != nil {
 		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *RunSpecRequest) GetWorkingDir() string {
+func (x *AddLogpointRequest) GetService() string {
 	if x != nil {
-		return x.WorkingDir
+		return x.Service
 	}
 	return ""
 }
 
-func (x *RunSpecRequest) GetEnviron() []string {
+func (x *AddLogpointRequest) GetLocation() string {
 	if x != nil {
-		return x.Environ
+		return x.Location
 	}
-	return nil
+	return ""
 }
 
-func (x *RunSpecRequest) GetCommands() []*SpecCommand {
+func (x *AddLogpointRequest) GetExprs() []string {
 	if x != nil {
-		return x.Commands
+		return x.Exprs
 	}
 	return nil
 }
 
-func (x *RunSpecRequest) GetReadyTimeoutSeconds() int32 {
-	if x != nil {
-		return x.ReadyTimeoutSeconds
-	}
-	return 0
-}
-
-func (x *RunSpecRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+func (x *AddLogpointRequest) GetCondition() string {
+	if x != nil && x.Condition != nil {
+		return *x.Condition
 	}
 	return ""
 }
 
-type SpecCommand struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Cmd:
-	//
-	//	*SpecCommand_Curl
-	Cmd           isSpecCommand_Cmd `protobuf_oneof:"cmd"`
+type Logpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Service       string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Exprs         []string               `protobuf:"bytes,4,rep,name=exprs,proto3" json:"exprs,omitempty"`
+	Condition     *string                `protobuf:"bytes,5,opt,name=condition,proto3,oneof" json:"condition,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SpecCommand) Reset() {
-	*x = SpecCommand{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[8]
+func (x *Logpoint) Reset() {
+	*x = Logpoint{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SpecCommand) String() string {
+func (x *Logpoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpecCommand) ProtoMessage() {}
+func (*Logpoint) ProtoMessage() {}
 
-func (x *SpecCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[8]
+func (x *Logpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -900,62 +7030,68 @@ func (x *SpecCommand) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpecCommand.ProtoReflect.Descriptor instead.
-func (*SpecCommand) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Logpoint.ProtoReflect.Descriptor instead.
+func (*Logpoint) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *SpecCommand) GetCmd() isSpecCommand_Cmd {
+func (x *Logpoint) GetId() int32 {
 	if x != nil {
-		return x.Cmd
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-func (x *SpecCommand) GetCurl() *CurlCommand {
+func (x *Logpoint) GetService() string {
 	if x != nil {
-		if x, ok := x.Cmd.(*SpecCommand_Curl); ok {
-			return x.Curl
-		}
+		return x.Service
 	}
-	return nil
+	return ""
 }
 
-type isSpecCommand_Cmd interface {
-	isSpecCommand_Cmd()
+func (x *Logpoint) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
 }
 
-type SpecCommand_Curl struct {
-	Curl *CurlCommand `protobuf:"bytes,1,opt,name=curl,proto3,oneof"`
+func (x *Logpoint) GetExprs() []string {
+	if x != nil {
+		return x.Exprs
+	}
+	return nil
 }
 
-func (*SpecCommand_Curl) isSpecCommand_Cmd() {}
+func (x *Logpoint) GetCondition() string {
+	if x != nil && x.Condition != nil {
+		return *x.Condition
+	}
+	return ""
+}
 
-type CurlCommand struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// path must start with "/" and is appended to http://<bound-addr> by the daemon.
-	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	// args are extra flags passed verbatim to the curl subprocess.
-	Args          []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+type ListLogpointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CurlCommand) Reset() {
-	*x = CurlCommand{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[9]
+func (x *ListLogpointsRequest) Reset() {
+	*x = ListLogpointsRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CurlCommand) String() string {
+func (x *ListLogpointsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CurlCommand) ProtoMessage() {}
+func (*ListLogpointsRequest) ProtoMessage() {}
 
-func (x *CurlCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[9]
+func (x *ListLogpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -966,52 +7102,40 @@ func (x *CurlCommand) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CurlCommand.ProtoReflect.Descriptor instead.
-func (*CurlCommand) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListLogpointsRequest.ProtoReflect.Descriptor instead.
+func (*ListLogpointsRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *CurlCommand) GetPath() string {
+func (x *ListLogpointsRequest) GetAppRoot() string {
 	if x != nil {
-		return x.Path
+		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *CurlCommand) GetArgs() []string {
-	if x != nil {
-		return x.Args
-	}
-	return nil
-}
-
-type RunSpecMessage struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*RunSpecMessage_Output
-	//	*RunSpecMessage_Result
-	//	*RunSpecMessage_Complete
-	Msg           isRunSpecMessage_Msg `protobuf_oneof:"msg"`
+type ListLogpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logpoints     []*Logpoint            `protobuf:"bytes,1,rep,name=logpoints,proto3" json:"logpoints,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunSpecMessage) Reset() {
-	*x = RunSpecMessage{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[10]
+func (x *ListLogpointsResponse) Reset() {
+	*x = ListLogpointsResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunSpecMessage) String() string {
+func (x *ListLogpointsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunSpecMessage) ProtoMessage() {}
+func (*ListLogpointsResponse) ProtoMessage() {}
 
-func (x *RunSpecMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[10]
+func (x *ListLogpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1022,98 +7146,98 @@ func (x *RunSpecMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunSpecMessage.ProtoReflect.Descriptor instead.
-func (*RunSpecMessage) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListLogpointsResponse.ProtoReflect.Descriptor instead.
+func (*ListLogpointsResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *RunSpecMessage) GetMsg() isRunSpecMessage_Msg {
+func (x *ListLogpointsResponse) GetLogpoints() []*Logpoint {
 	if x != nil {
-		return x.Msg
+		return x.Logpoints
 	}
 	return nil
 }
 
-func (x *RunSpecMessage) GetOutput() *CommandOutput {
-	if x != nil {
-		if x, ok := x.Msg.(*RunSpecMessage_Output); ok {
-			return x.Output
-		}
-	}
-	return nil
+type RemoveLogpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunSpecMessage) GetResult() *SpecCommandResult {
-	if x != nil {
-		if x, ok := x.Msg.(*RunSpecMessage_Result); ok {
-			return x.Result
-		}
-	}
-	return nil
+func (x *RemoveLogpointRequest) Reset() {
+	*x = RemoveLogpointRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunSpecMessage) GetComplete() *SpecComplete {
+func (x *RemoveLogpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLogpointRequest) ProtoMessage() {}
+
+func (x *RemoveLogpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[88]
 	if x != nil {
-		if x, ok := x.Msg.(*RunSpecMessage_Complete); ok {
-			return x.Complete
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
-}
-
-type isRunSpecMessage_Msg interface {
-	isRunSpecMessage_Msg()
+	return mi.MessageOf(x)
 }
 
-type RunSpecMessage_Output struct {
-	// output forwards stdout/stderr from the app or daemon (compile noise,
-	// runtime logs). Clients should render this on stderr.
-	Output *CommandOutput `protobuf:"bytes,1,opt,name=output,proto3,oneof"`
+// Deprecated: Use RemoveLogpointRequest.ProtoReflect.Descriptor instead.
+func (*RemoveLogpointRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{88}
 }
 
-type RunSpecMessage_Result struct {
-	// result is one finished spec command.
-	Result *SpecCommandResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+func (x *RemoveLogpointRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
+	}
+	return ""
 }
 
-type RunSpecMessage_Complete struct {
-	// complete is the terminal message for the stream.
-	Complete *SpecComplete `protobuf:"bytes,3,opt,name=complete,proto3,oneof"`
+func (x *RemoveLogpointRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
 }
 
-func (*RunSpecMessage_Output) isRunSpecMessage_Msg() {}
-
-func (*RunSpecMessage_Result) isRunSpecMessage_Msg() {}
-
-func (*RunSpecMessage_Complete) isRunSpecMessage_Msg() {}
-
-type SpecCommandResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // 1-based
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Display       string                 `protobuf:"bytes,3,opt,name=display,proto3" json:"display,omitempty"` // human-readable, e.g. "curl /api/foo -i"
-	Stdout        []byte                 `protobuf:"bytes,4,opt,name=stdout,proto3" json:"stdout,omitempty"`
-	Stderr        []byte                 `protobuf:"bytes,5,opt,name=stderr,proto3" json:"stderr,omitempty"`
-	ExitCode      int32                  `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GoroutineDumpRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// services, if set, restricts the dumps to the processes running
+	// the given services or gateways.
+	Services []string `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	// min_wait_minutes is how long goroutines must have been blocked
+	// to be suspected deadlocked. Defaults to 1 minute.
+	MinWaitMinutes int32 `protobuf:"varint,3,opt,name=min_wait_minutes,json=minWaitMinutes,proto3" json:"min_wait_minutes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *SpecCommandResult) Reset() {
-	*x = SpecCommandResult{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[11]
+func (x *GoroutineDumpRequest) Reset() {
+	*x = GoroutineDumpRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SpecCommandResult) String() string {
+func (x *GoroutineDumpRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpecCommandResult) ProtoMessage() {}
+func (*GoroutineDumpRequest) ProtoMessage() {}
 
-func (x *SpecCommandResult) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[11]
+func (x *GoroutineDumpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1124,80 +7248,109 @@ func (x *SpecCommandResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpecCommandResult.ProtoReflect.Descriptor instead.
-func (*SpecCommandResult) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GoroutineDumpRequest.ProtoReflect.Descriptor instead.
+func (*GoroutineDumpRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *SpecCommandResult) GetIndex() int32 {
+func (x *GoroutineDumpRequest) GetAppRoot() string {
 	if x != nil {
-		return x.Index
+		return x.AppRoot
 	}
-	return 0
+	return ""
 }
 
-func (x *SpecCommandResult) GetTotal() int32 {
+func (x *GoroutineDumpRequest) GetServices() []string {
 	if x != nil {
-		return x.Total
+		return x.Services
 	}
-	return 0
+	return nil
 }
 
-func (x *SpecCommandResult) GetDisplay() string {
+func (x *GoroutineDumpRequest) GetMinWaitMinutes() int32 {
 	if x != nil {
-		return x.Display
+		return x.MinWaitMinutes
 	}
-	return ""
+	return 0
 }
 
-func (x *SpecCommandResult) GetStdout() []byte {
+type GoroutineDumpResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Processes     []*ProcessGoroutineDump `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoroutineDumpResponse) Reset() {
+	*x = GoroutineDumpResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoroutineDumpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoroutineDumpResponse) ProtoMessage() {}
+
+func (x *GoroutineDumpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[90]
 	if x != nil {
-		return x.Stdout
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *SpecCommandResult) GetStderr() []byte {
-	if x != nil {
-		return x.Stderr
-	}
-	return nil
+// Deprecated: Use GoroutineDumpResponse.ProtoReflect.Descriptor instead.
+func (*GoroutineDumpResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *SpecCommandResult) GetExitCode() int32 {
+func (x *GoroutineDumpResponse) GetProcesses() []*ProcessGoroutineDump {
 	if x != nil {
-		return x.ExitCode
+		return x.Processes
 	}
-	return 0
+	return nil
 }
 
-type SpecComplete struct {
-	state     protoimpl.MessageState `protogen:"open.v1"`
-	Succeeded int32                  `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
-	Total     int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	// error, if non-empty, indicates the run never reached the command-running
-	// phase (compile failure, healthz timeout, etc.). When set, succeeded/total
-	// reflect commands that did execute (typically zero).
-	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+type ProcessGoroutineDump struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// services are the services and gateways the process runs.
+	Services []string `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	Pid      int32    `protobuf:"varint,2,opt,name=pid,proto3" json:"pid,omitempty"`
+	// dump is the full goroutine dump, in the format of an unrecovered panic.
+	Dump string `protobuf:"bytes,3,opt,name=dump,proto3" json:"dump,omitempty"`
+	// report groups identical goroutines and annotates
+	// those suspected deadlocked, in human-readable form.
+	Report string `protobuf:"bytes,4,opt,name=report,proto3" json:"report,omitempty"`
+	// suspected_deadlocks is the number of goroutines suspected deadlocked.
+	SuspectedDeadlocks int32 `protobuf:"varint,5,opt,name=suspected_deadlocks,json=suspectedDeadlocks,proto3" json:"suspected_deadlocks,omitempty"`
+	// error is why the dump could not be captured, if it failed.
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SpecComplete) Reset() {
-	*x = SpecComplete{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[12]
+func (x *ProcessGoroutineDump) Reset() {
+	*x = ProcessGoroutineDump{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SpecComplete) String() string {
+func (x *ProcessGoroutineDump) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SpecComplete) ProtoMessage() {}
+func (*ProcessGoroutineDump) ProtoMessage() {}
 
-func (x *SpecComplete) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[12]
+func (x *ProcessGoroutineDump) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1208,67 +7361,77 @@ func (x *SpecComplete) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SpecComplete.ProtoReflect.Descriptor instead.
-func (*SpecComplete) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ProcessGoroutineDump.ProtoReflect.Descriptor instead.
+func (*ProcessGoroutineDump) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *SpecComplete) GetSucceeded() int32 {
+func (x *ProcessGoroutineDump) GetServices() []string {
 	if x != nil {
-		return x.Succeeded
+		return x.Services
+	}
+	return nil
+}
+
+func (x *ProcessGoroutineDump) GetPid() int32 {
+	if x != nil {
+		return x.Pid
 	}
 	return 0
 }
 
-func (x *SpecComplete) GetTotal() int32 {
+func (x *ProcessGoroutineDump) GetDump() string {
 	if x != nil {
-		return x.Total
+		return x.Dump
+	}
+	return ""
+}
+
+func (x *ProcessGoroutineDump) GetReport() string {
+	if x != nil {
+		return x.Report
+	}
+	return ""
+}
+
+func (x *ProcessGoroutineDump) GetSuspectedDeadlocks() int32 {
+	if x != nil {
+		return x.SuspectedDeadlocks
 	}
 	return 0
 }
 
-func (x *SpecComplete) GetError() string {
+func (x *ProcessGoroutineDump) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type TestRequest struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
-	Args       []string               `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
-	// environ is the environment to set for the running command.
-	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
-	Environ []string `protobuf:"bytes,4,rep,name=environ,proto3" json:"environ,omitempty"`
-	// trace_file, if set specifies a trace file to write trace information
-	// about the parse and compilation process to.
-	TraceFile *string `protobuf:"bytes,6,opt,name=trace_file,json=traceFile,proto3,oneof" json:"trace_file,omitempty"`
-	// codegen_debug, if true, dumps the generated code and prints where it is located.
-	CodegenDebug bool `protobuf:"varint,7,opt,name=codegen_debug,json=codegenDebug,proto3" json:"codegen_debug,omitempty"`
-	// temp_dir is a temp dir that will be cleaned up after tests have been executed
-	// to write things like app meta and runtime config etc.
-	TempDir       string `protobuf:"bytes,8,opt,name=temp_dir,json=tempDir,proto3" json:"temp_dir,omitempty"`
+type RetentionDryRunRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// subject_id, if set, is the subject whose deletion to dry run.
+	SubjectId     *string `protobuf:"bytes,2,opt,name=subject_id,json=subjectId,proto3,oneof" json:"subject_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TestRequest) Reset() {
-	*x = TestRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[13]
+func (x *RetentionDryRunRequest) Reset() {
+	*x = RetentionDryRunRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TestRequest) String() string {
+func (x *RetentionDryRunRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestRequest) ProtoMessage() {}
+func (*RetentionDryRunRequest) ProtoMessage() {}
 
-func (x *TestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[13]
+func (x *RetentionDryRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1279,90 +7442,107 @@ func (x *TestRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestRequest.ProtoReflect.Descriptor instead.
-func (*TestRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use RetentionDryRunRequest.ProtoReflect.Descriptor instead.
+func (*RetentionDryRunRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *TestRequest) GetAppRoot() string {
+func (x *RetentionDryRunRequest) GetAppRoot() string {
 	if x != nil {
 		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *TestRequest) GetWorkingDir() string {
-	if x != nil {
-		return x.WorkingDir
+func (x *RetentionDryRunRequest) GetSubjectId() string {
+	if x != nil && x.SubjectId != nil {
+		return *x.SubjectId
 	}
 	return ""
 }
 
-func (x *TestRequest) GetArgs() []string {
-	if x != nil {
-		return x.Args
-	}
-	return nil
+type RetentionDryRunResponse struct {
+	state    protoimpl.MessageState   `protogen:"open.v1"`
+	Policies []*RetentionPolicyDryRun `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	// subjects are the results of the subject deletion handlers,
+	// if a subject_id was given.
+	Subjects      []*RetentionSubjectDryRun `protobuf:"bytes,2,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TestRequest) GetEnviron() []string {
+func (x *RetentionDryRunResponse) Reset() {
+	*x = RetentionDryRunResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionDryRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionDryRunResponse) ProtoMessage() {}
+
+func (x *RetentionDryRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[93]
 	if x != nil {
-		return x.Environ
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *TestRequest) GetTraceFile() string {
-	if x != nil && x.TraceFile != nil {
-		return *x.TraceFile
-	}
-	return ""
+// Deprecated: Use RetentionDryRunResponse.ProtoReflect.Descriptor instead.
+func (*RetentionDryRunResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *TestRequest) GetCodegenDebug() bool {
+func (x *RetentionDryRunResponse) GetPolicies() []*RetentionPolicyDryRun {
 	if x != nil {
-		return x.CodegenDebug
+		return x.Policies
 	}
-	return false
+	return nil
 }
 
-func (x *TestRequest) GetTempDir() string {
+func (x *RetentionDryRunResponse) GetSubjects() []*RetentionSubjectDryRun {
 	if x != nil {
-		return x.TempDir
+		return x.Subjects
 	}
-	return ""
+	return nil
 }
 
-type TestSpecRequest struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
-	Args       []string               `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
-	// environ is the environment to set for the running command.
-	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
-	Environ []string `protobuf:"bytes,4,rep,name=environ,proto3" json:"environ,omitempty"`
-	// temp_dir is a temp dir that will be cleaned up after tests have been executed
-	// to write things like app meta and runtime config etc.
-	TempDir       string `protobuf:"bytes,5,opt,name=temp_dir,json=tempDir,proto3" json:"temp_dir,omitempty"`
+type RetentionPolicyDryRun struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Table  string                 `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	MaxAge string                 `protobuf:"bytes,3,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// would_delete is the number of rows the policy would delete.
+	WouldDelete int64 `protobuf:"varint,4,opt,name=would_delete,json=wouldDelete,proto3" json:"would_delete,omitempty"`
+	// error is why the dry run failed, if it did.
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TestSpecRequest) Reset() {
-	*x = TestSpecRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[14]
+func (x *RetentionPolicyDryRun) Reset() {
+	*x = RetentionPolicyDryRun{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TestSpecRequest) String() string {
+func (x *RetentionPolicyDryRun) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestSpecRequest) ProtoMessage() {}
+func (*RetentionPolicyDryRun) ProtoMessage() {}
 
-func (x *TestSpecRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[14]
+func (x *RetentionPolicyDryRun) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1373,70 +7553,72 @@ func (x *TestSpecRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestSpecRequest.ProtoReflect.Descriptor instead.
-func (*TestSpecRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use RetentionPolicyDryRun.ProtoReflect.Descriptor instead.
+func (*RetentionPolicyDryRun) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *TestSpecRequest) GetAppRoot() string {
+func (x *RetentionPolicyDryRun) GetName() string {
 	if x != nil {
-		return x.AppRoot
+		return x.Name
 	}
 	return ""
 }
 
-func (x *TestSpecRequest) GetWorkingDir() string {
+func (x *RetentionPolicyDryRun) GetTable() string {
 	if x != nil {
-		return x.WorkingDir
+		return x.Table
 	}
 	return ""
 }
 
-func (x *TestSpecRequest) GetArgs() []string {
+func (x *RetentionPolicyDryRun) GetMaxAge() string {
 	if x != nil {
-		return x.Args
+		return x.MaxAge
 	}
-	return nil
+	return ""
 }
 
-func (x *TestSpecRequest) GetEnviron() []string {
+func (x *RetentionPolicyDryRun) GetWouldDelete() int64 {
 	if x != nil {
-		return x.Environ
+		return x.WouldDelete
 	}
-	return nil
+	return 0
 }
 
-func (x *TestSpecRequest) GetTempDir() string {
+func (x *RetentionPolicyDryRun) GetError() string {
 	if x != nil {
-		return x.TempDir
+		return x.Error
 	}
 	return ""
 }
 
-type TestSpecResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
-	Args          []string               `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
-	Environ       []string               `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
+type RetentionSubjectDryRun struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Handler string                 `protobuf:"bytes,1,opt,name=handler,proto3" json:"handler,omitempty"`
+	// would_delete is the number of rows the handler would delete.
+	WouldDelete int64 `protobuf:"varint,2,opt,name=would_delete,json=wouldDelete,proto3" json:"would_delete,omitempty"`
+	// error is why the dry run failed, if it did.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TestSpecResponse) Reset() {
-	*x = TestSpecResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[15]
+func (x *RetentionSubjectDryRun) Reset() {
+	*x = RetentionSubjectDryRun{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TestSpecResponse) String() string {
+func (x *RetentionSubjectDryRun) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestSpecResponse) ProtoMessage() {}
+func (*RetentionSubjectDryRun) ProtoMessage() {}
 
-func (x *TestSpecResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[15]
+func (x *RetentionSubjectDryRun) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1447,68 +7629,54 @@ func (x *TestSpecResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestSpecResponse.ProtoReflect.Descriptor instead.
-func (*TestSpecResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use RetentionSubjectDryRun.ProtoReflect.Descriptor instead.
+func (*RetentionSubjectDryRun) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *TestSpecResponse) GetCommand() string {
+func (x *RetentionSubjectDryRun) GetHandler() string {
 	if x != nil {
-		return x.Command
+		return x.Handler
 	}
 	return ""
 }
 
-func (x *TestSpecResponse) GetArgs() []string {
+func (x *RetentionSubjectDryRun) GetWouldDelete() int64 {
 	if x != nil {
-		return x.Args
+		return x.WouldDelete
 	}
-	return nil
+	return 0
 }
 
-func (x *TestSpecResponse) GetEnviron() []string {
+func (x *RetentionSubjectDryRun) GetError() string {
 	if x != nil {
-		return x.Environ
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-type ExecScriptRequest struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
-	ScriptArgs []string               `protobuf:"bytes,4,rep,name=script_args,json=scriptArgs,proto3" json:"script_args,omitempty"`
-	// environ is the environment to set for the running command.
-	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
-	Environ []string `protobuf:"bytes,5,rep,name=environ,proto3" json:"environ,omitempty"`
-	// trace_file, if set specifies a trace file to write trace information
-	// about the parse and compilation process to.
-	TraceFile *string `protobuf:"bytes,6,opt,name=trace_file,json=traceFile,proto3,oneof" json:"trace_file,omitempty"`
-	// namespace is the infrastructure namespace to use.
-	// If empty the active namespace is used.
-	Namespace *string `protobuf:"bytes,7,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
-	// non_interactive, if true, signals that the CLI is not connected to an
-	// interactive terminal. See RunRequest.non_interactive for details.
-	NonInteractive bool `protobuf:"varint,8,opt,name=non_interactive,json=nonInteractive,proto3" json:"non_interactive,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type StartupReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecScriptRequest) Reset() {
-	*x = ExecScriptRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[16]
+func (x *StartupReportRequest) Reset() {
+	*x = StartupReportRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecScriptRequest) String() string {
+func (x *StartupReportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecScriptRequest) ProtoMessage() {}
+func (*StartupReportRequest) ProtoMessage() {}
 
-func (x *ExecScriptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[16]
+func (x *StartupReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1519,96 +7687,115 @@ func (x *ExecScriptRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecScriptRequest.ProtoReflect.Descriptor instead.
-func (*ExecScriptRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use StartupReportRequest.ProtoReflect.Descriptor instead.
+func (*StartupReportRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *ExecScriptRequest) GetAppRoot() string {
+func (x *StartupReportRequest) GetAppRoot() string {
 	if x != nil {
 		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *ExecScriptRequest) GetWorkingDir() string {
-	if x != nil {
-		return x.WorkingDir
-	}
-	return ""
+type StartupReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// migrations_wait_ns is how long starting the app waited for database
+	// migrations to complete after it was compiled.
+	MigrationsWaitNs int64                   `protobuf:"varint,1,opt,name=migrations_wait_ns,json=migrationsWaitNs,proto3" json:"migrations_wait_ns,omitempty"`
+	Processes        []*ProcessStartupReport `protobuf:"bytes,2,rep,name=processes,proto3" json:"processes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *ExecScriptRequest) GetScriptArgs() []string {
-	if x != nil {
-		return x.ScriptArgs
-	}
-	return nil
+func (x *StartupReportResponse) Reset() {
+	*x = StartupReportResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecScriptRequest) GetEnviron() []string {
+func (x *StartupReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartupReportResponse) ProtoMessage() {}
+
+func (x *StartupReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[97]
 	if x != nil {
-		return x.Environ
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *ExecScriptRequest) GetTraceFile() string {
-	if x != nil && x.TraceFile != nil {
-		return *x.TraceFile
-	}
-	return ""
+// Deprecated: Use StartupReportResponse.ProtoReflect.Descriptor instead.
+func (*StartupReportResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *ExecScriptRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+func (x *StartupReportResponse) GetMigrationsWaitNs() int64 {
+	if x != nil {
+		return x.MigrationsWaitNs
 	}
-	return ""
+	return 0
 }
 
-func (x *ExecScriptRequest) GetNonInteractive() bool {
+func (x *StartupReportResponse) GetProcesses() []*ProcessStartupReport {
 	if x != nil {
-		return x.NonInteractive
+		return x.Processes
 	}
-	return false
+	return nil
 }
 
-type ExecSpecRequest struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
-	ScriptArgs []string               `protobuf:"bytes,4,rep,name=script_args,json=scriptArgs,proto3" json:"script_args,omitempty"`
-	// environ is the environment to set for the running command.
-	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
-	Environ []string `protobuf:"bytes,5,rep,name=environ,proto3" json:"environ,omitempty"`
-	// namespace is the infrastructure namespace to use.
-	// If empty the active namespace is used.
-	Namespace *string `protobuf:"bytes,7,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
-	// temp_dir is a temp dir that will be cleaned up by the CLI after the command
-	// has been executed, to write things like app meta and runtime config etc.
-	TempDir string `protobuf:"bytes,8,opt,name=temp_dir,json=tempDir,proto3" json:"temp_dir,omitempty"`
-	// non_interactive, if true, signals that the CLI is not connected to an
-	// interactive terminal. See RunRequest.non_interactive for details.
-	NonInteractive bool `protobuf:"varint,9,opt,name=non_interactive,json=nonInteractive,proto3" json:"non_interactive,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type ProcessStartupReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// services are the services and gateways the process runs.
+	Services []string `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	Pid      int32    `protobuf:"varint,2,opt,name=pid,proto3" json:"pid,omitempty"`
+	// complete is whether all services of the process have been initialized.
+	Complete bool `protobuf:"varint,3,opt,name=complete,proto3" json:"complete,omitempty"`
+	// total_ns is the time from the process starting
+	// until all its services were initialized.
+	TotalNs int64 `protobuf:"varint,4,opt,name=total_ns,json=totalNs,proto3" json:"total_ns,omitempty"`
+	// package_init_ns is the time from the process starting until main was
+	// called, which includes the time spent setting up resources.
+	PackageInitNs   int64 `protobuf:"varint,5,opt,name=package_init_ns,json=packageInitNs,proto3" json:"package_init_ns,omitempty"`
+	ResourceSetupNs int64 `protobuf:"varint,6,opt,name=resource_setup_ns,json=resourceSetupNs,proto3" json:"resource_setup_ns,omitempty"`
+	// runtime_setup_ns is the time from main being called until
+	// the initialization of the services started.
+	RuntimeSetupNs int64 `protobuf:"varint,7,opt,name=runtime_setup_ns,json=runtimeSetupNs,proto3" json:"runtime_setup_ns,omitempty"`
+	ServiceInitNs  int64 `protobuf:"varint,8,opt,name=service_init_ns,json=serviceInitNs,proto3" json:"service_init_ns,omitempty"`
+	// resources and services are the time it took to set up each resource
+	// and to initialize each service, slowest first.
+	Resources    []*StartupTiming `protobuf:"bytes,9,rep,name=resources,proto3" json:"resources,omitempty"`
+	ServicesInit []*StartupTiming `protobuf:"bytes,10,rep,name=services_init,json=servicesInit,proto3" json:"services_init,omitempty"`
+	// error is why the report could not be retrieved, if it failed.
+	Error         string `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecSpecRequest) Reset() {
-	*x = ExecSpecRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[17]
+func (x *ProcessStartupReport) Reset() {
+	*x = ProcessStartupReport{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecSpecRequest) String() string {
+func (x *ProcessStartupReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecSpecRequest) ProtoMessage() {}
+func (*ProcessStartupReport) ProtoMessage() {}
 
-func (x *ExecSpecRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[17]
+func (x *ProcessStartupReport) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1619,86 +7806,113 @@ func (x *ExecSpecRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecSpecRequest.ProtoReflect.Descriptor instead.
-func (*ExecSpecRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ProcessStartupReport.ProtoReflect.Descriptor instead.
+func (*ProcessStartupReport) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *ExecSpecRequest) GetAppRoot() string {
+func (x *ProcessStartupReport) GetServices() []string {
 	if x != nil {
-		return x.AppRoot
+		return x.Services
 	}
-	return ""
+	return nil
 }
 
-func (x *ExecSpecRequest) GetWorkingDir() string {
+func (x *ProcessStartupReport) GetPid() int32 {
 	if x != nil {
-		return x.WorkingDir
+		return x.Pid
 	}
-	return ""
+	return 0
 }
 
-func (x *ExecSpecRequest) GetScriptArgs() []string {
+func (x *ProcessStartupReport) GetComplete() bool {
 	if x != nil {
-		return x.ScriptArgs
+		return x.Complete
 	}
-	return nil
+	return false
 }
 
-func (x *ExecSpecRequest) GetEnviron() []string {
+func (x *ProcessStartupReport) GetTotalNs() int64 {
 	if x != nil {
-		return x.Environ
+		return x.TotalNs
 	}
-	return nil
+	return 0
 }
 
-func (x *ExecSpecRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+func (x *ProcessStartupReport) GetPackageInitNs() int64 {
+	if x != nil {
+		return x.PackageInitNs
 	}
-	return ""
+	return 0
 }
 
-func (x *ExecSpecRequest) GetTempDir() string {
+func (x *ProcessStartupReport) GetResourceSetupNs() int64 {
 	if x != nil {
-		return x.TempDir
+		return x.ResourceSetupNs
 	}
-	return ""
+	return 0
 }
 
-func (x *ExecSpecRequest) GetNonInteractive() bool {
+func (x *ProcessStartupReport) GetRuntimeSetupNs() int64 {
 	if x != nil {
-		return x.NonInteractive
+		return x.RuntimeSetupNs
 	}
-	return false
+	return 0
 }
 
-type ExecSpecMessage struct {
+func (x *ProcessStartupReport) GetServiceInitNs() int64 {
+	if x != nil {
+		return x.ServiceInitNs
+	}
+	return 0
+}
+
+func (x *ProcessStartupReport) GetResources() []*StartupTiming {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ProcessStartupReport) GetServicesInit() []*StartupTiming {
+	if x != nil {
+		return x.ServicesInit
+	}
+	return nil
+}
+
+func (x *ProcessStartupReport) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type StartupTiming struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*ExecSpecMessage_Output
-	//	*ExecSpecMessage_Spec
-	Msg           isExecSpecMessage_Msg `protobuf_oneof:"msg"`
+	// kind is the kind of resource, like "database"; empty for services.
+	Kind          string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DurationNs    int64  `protobuf:"varint,3,opt,name=duration_ns,json=durationNs,proto3" json:"duration_ns,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecSpecMessage) Reset() {
-	*x = ExecSpecMessage{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[18]
+func (x *StartupTiming) Reset() {
+	*x = StartupTiming{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecSpecMessage) String() string {
+func (x *StartupTiming) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecSpecMessage) ProtoMessage() {}
+func (*StartupTiming) ProtoMessage() {}
 
-func (x *ExecSpecMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[18]
+func (x *StartupTiming) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1709,76 +7923,59 @@ func (x *ExecSpecMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecSpecMessage.ProtoReflect.Descriptor instead.
-func (*ExecSpecMessage) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use StartupTiming.ProtoReflect.Descriptor instead.
+func (*StartupTiming) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *ExecSpecMessage) GetMsg() isExecSpecMessage_Msg {
+func (x *StartupTiming) GetKind() string {
 	if x != nil {
-		return x.Msg
+		return x.Kind
 	}
-	return nil
+	return ""
 }
 
-func (x *ExecSpecMessage) GetOutput() *CommandOutput {
+func (x *StartupTiming) GetName() string {
 	if x != nil {
-		if x, ok := x.Msg.(*ExecSpecMessage_Output); ok {
-			return x.Output
-		}
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *ExecSpecMessage) GetSpec() *ExecSpecResponse {
+func (x *StartupTiming) GetDurationNs() int64 {
 	if x != nil {
-		if x, ok := x.Msg.(*ExecSpecMessage_Spec); ok {
-			return x.Spec
-		}
+		return x.DurationNs
 	}
-	return nil
-}
-
-type isExecSpecMessage_Msg interface {
-	isExecSpecMessage_Msg()
-}
-
-type ExecSpecMessage_Output struct {
-	Output *CommandOutput `protobuf:"bytes,1,opt,name=output,proto3,oneof"`
-}
-
-type ExecSpecMessage_Spec struct {
-	Spec *ExecSpecResponse `protobuf:"bytes,2,opt,name=spec,proto3,oneof"`
+	return 0
 }
 
-func (*ExecSpecMessage_Output) isExecSpecMessage_Msg() {}
-
-func (*ExecSpecMessage_Spec) isExecSpecMessage_Msg() {}
-
-type ExecSpecResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
-	Args          []string               `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
-	Environ       []string               `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
+type VulnScanRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// include_unreachable, if true, also reports vulnerabilities
+	// in dependencies whose vulnerable code the app does not call.
+	IncludeUnreachable bool `protobuf:"varint,2,opt,name=include_unreachable,json=includeUnreachable,proto3" json:"include_unreachable,omitempty"`
+	// refresh, if true, scans the dependencies even if they did not change.
+	Refresh       bool `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecSpecResponse) Reset() {
-	*x = ExecSpecResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[19]
+func (x *VulnScanRequest) Reset() {
+	*x = VulnScanRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecSpecResponse) String() string {
+func (x *VulnScanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecSpecResponse) ProtoMessage() {}
+func (*VulnScanRequest) ProtoMessage() {}
 
-func (x *ExecSpecResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[19]
+func (x *VulnScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1789,62 +7986,61 @@ func (x *ExecSpecResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecSpecResponse.ProtoReflect.Descriptor instead.
-func (*ExecSpecResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use VulnScanRequest.ProtoReflect.Descriptor instead.
+func (*VulnScanRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *ExecSpecResponse) GetCommand() string {
+func (x *VulnScanRequest) GetAppRoot() string {
 	if x != nil {
-		return x.Command
+		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *ExecSpecResponse) GetArgs() []string {
+func (x *VulnScanRequest) GetIncludeUnreachable() bool {
 	if x != nil {
-		return x.Args
+		return x.IncludeUnreachable
 	}
-	return nil
+	return false
 }
 
-func (x *ExecSpecResponse) GetEnviron() []string {
+func (x *VulnScanRequest) GetRefresh() bool {
 	if x != nil {
-		return x.Environ
+		return x.Refresh
 	}
-	return nil
+	return false
 }
 
-type CheckRequest struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
-	// codegen_debug, if true, dumps the generated code and prints where it is located.
-	CodegenDebug bool `protobuf:"varint,3,opt,name=codegen_debug,json=codegenDebug,proto3" json:"codegen_debug,omitempty"`
-	// parse_tests, if true, exercises test parsing and codegen as well.
-	ParseTests bool `protobuf:"varint,4,opt,name=parse_tests,json=parseTests,proto3" json:"parse_tests,omitempty"`
-	// environ is the environment to set for the running command.
-	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
-	Environ       []string `protobuf:"bytes,5,rep,name=environ,proto3" json:"environ,omitempty"`
+type VulnScanResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// scanner is the scanner used, such as "govulncheck" or "npm audit".
+	Scanner string `protobuf:"bytes,1,opt,name=scanner,proto3" json:"scanner,omitempty"`
+	// scanned_at is when the dependencies were scanned, in RFC 3339 format.
+	ScannedAt       string           `protobuf:"bytes,2,opt,name=scanned_at,json=scannedAt,proto3" json:"scanned_at,omitempty"`
+	Vulnerabilities []*Vulnerability `protobuf:"bytes,3,rep,name=vulnerabilities,proto3" json:"vulnerabilities,omitempty"`
+	// unreachable is the number of vulnerabilities whose vulnerable code
+	// the app does not call, if not included.
+	Unreachable   int32 `protobuf:"varint,4,opt,name=unreachable,proto3" json:"unreachable,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckRequest) Reset() {
-	*x = CheckRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[20]
+func (x *VulnScanResponse) Reset() {
+	*x = VulnScanResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckRequest) String() string {
+func (x *VulnScanResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckRequest) ProtoMessage() {}
+func (*VulnScanResponse) ProtoMessage() {}
 
-func (x *CheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[20]
+func (x *VulnScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1855,89 +8051,77 @@ func (x *CheckRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
-func (*CheckRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use VulnScanResponse.ProtoReflect.Descriptor instead.
+func (*VulnScanResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *CheckRequest) GetAppRoot() string {
+func (x *VulnScanResponse) GetScanner() string {
 	if x != nil {
-		return x.AppRoot
+		return x.Scanner
 	}
 	return ""
 }
 
-func (x *CheckRequest) GetWorkingDir() string {
+func (x *VulnScanResponse) GetScannedAt() string {
 	if x != nil {
-		return x.WorkingDir
+		return x.ScannedAt
 	}
 	return ""
 }
 
-func (x *CheckRequest) GetCodegenDebug() bool {
-	if x != nil {
-		return x.CodegenDebug
-	}
-	return false
-}
-
-func (x *CheckRequest) GetParseTests() bool {
+func (x *VulnScanResponse) GetVulnerabilities() []*Vulnerability {
 	if x != nil {
-		return x.ParseTests
+		return x.Vulnerabilities
 	}
-	return false
+	return nil
 }
 
-func (x *CheckRequest) GetEnviron() []string {
+func (x *VulnScanResponse) GetUnreachable() int32 {
 	if x != nil {
-		return x.Environ
+		return x.Unreachable
 	}
-	return nil
+	return 0
 }
 
-type ExportRequest struct {
-	state   protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	// goos and goarch specify the platform configuration to compile
-	// the application for. The values must be valid GOOS/GOARCH values.
-	Goos   string `protobuf:"bytes,2,opt,name=goos,proto3" json:"goos,omitempty"`
-	Goarch string `protobuf:"bytes,3,opt,name=goarch,proto3" json:"goarch,omitempty"`
-	// cgo_enabled specifies whether to build with cgo enabled.
-	// The host must have a valid C compiler for the target platform
-	// if true.
-	CgoEnabled bool `protobuf:"varint,4,opt,name=cgo_enabled,json=cgoEnabled,proto3" json:"cgo_enabled,omitempty"`
-	// environ is the environment to set for the running command.
-	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
-	Environ []string `protobuf:"bytes,5,rep,name=environ,proto3" json:"environ,omitempty"`
-	// Types that are valid to be assigned to Format:
-	//
-	//	*ExportRequest_Docker
-	Format        isExportRequest_Format `protobuf_oneof:"format"`
-	InfraConfPath string                 `protobuf:"bytes,7,opt,name=infra_conf_path,json=infraConfPath,proto3" json:"infra_conf_path,omitempty"`
-	Services      []string               `protobuf:"bytes,8,rep,name=services,proto3" json:"services,omitempty"`
-	Gateways      []string               `protobuf:"bytes,9,rep,name=gateways,proto3" json:"gateways,omitempty"`
-	SkipInfraConf bool                   `protobuf:"varint,10,opt,name=skip_infra_conf,json=skipInfraConf,proto3" json:"skip_infra_conf,omitempty"`
-	// A parent path to app_root containing the .git, or the same as app_root
-	WorkspaceRoot string `protobuf:"bytes,11,opt,name=workspace_root,json=workspaceRoot,proto3" json:"workspace_root,omitempty"`
+type Vulnerability struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the advisory id, such as "GO-2024-2687" or "GHSA-xvch-5gv4-984h".
+	Id      string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Aliases []string `protobuf:"bytes,2,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Summary string   `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	Url     string   `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	// module is the affected module or package.
+	Module string `protobuf:"bytes,5,opt,name=module,proto3" json:"module,omitempty"`
+	// version is the version in use, if known.
+	Version string `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	// fixed_version is the first version with a fix, if any.
+	FixedVersion string `protobuf:"bytes,7,opt,name=fixed_version,json=fixedVersion,proto3" json:"fixed_version,omitempty"`
+	// severity is the severity of the vulnerability, if known.
+	Severity     string                     `protobuf:"bytes,8,opt,name=severity,proto3" json:"severity,omitempty"`
+	Reachability Vulnerability_Reachability `protobuf:"varint,9,opt,name=reachability,proto3,enum=encore.daemon.Vulnerability_Reachability" json:"reachability,omitempty"`
+	// trace is the call stack from the app's code to the vulnerable function,
+	// outermost first, if the app calls it.
+	Trace         []string `protobuf:"bytes,10,rep,name=trace,proto3" json:"trace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExportRequest) Reset() {
-	*x = ExportRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[21]
+func (x *Vulnerability) Reset() {
+	*x = Vulnerability{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExportRequest) String() string {
+func (x *Vulnerability) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExportRequest) ProtoMessage() {}
+func (*Vulnerability) ProtoMessage() {}
 
-func (x *ExportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[21]
+func (x *Vulnerability) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1945,142 +8129,218 @@ func (x *ExportRequest) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vulnerability.ProtoReflect.Descriptor instead.
+func (*Vulnerability) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *Vulnerability) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
 }
 
-// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
-func (*ExportRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{21}
+func (x *Vulnerability) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
 }
 
-func (x *ExportRequest) GetAppRoot() string {
+func (x *Vulnerability) GetModule() string {
 	if x != nil {
-		return x.AppRoot
+		return x.Module
 	}
 	return ""
 }
 
-func (x *ExportRequest) GetGoos() string {
+func (x *Vulnerability) GetVersion() string {
 	if x != nil {
-		return x.Goos
+		return x.Version
 	}
 	return ""
 }
 
-func (x *ExportRequest) GetGoarch() string {
+func (x *Vulnerability) GetFixedVersion() string {
 	if x != nil {
-		return x.Goarch
+		return x.FixedVersion
 	}
 	return ""
 }
 
-func (x *ExportRequest) GetCgoEnabled() bool {
+func (x *Vulnerability) GetSeverity() string {
 	if x != nil {
-		return x.CgoEnabled
+		return x.Severity
 	}
-	return false
+	return ""
 }
 
-func (x *ExportRequest) GetEnviron() []string {
+func (x *Vulnerability) GetReachability() Vulnerability_Reachability {
 	if x != nil {
-		return x.Environ
+		return x.Reachability
 	}
-	return nil
+	return Vulnerability_REACHABILITY_UNKNOWN
 }
 
-func (x *ExportRequest) GetFormat() isExportRequest_Format {
+func (x *Vulnerability) GetTrace() []string {
 	if x != nil {
-		return x.Format
+		return x.Trace
 	}
 	return nil
 }
 
-func (x *ExportRequest) GetDocker() *DockerExportParams {
+type LicenseReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LicenseReportRequest) Reset() {
+	*x = LicenseReportRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LicenseReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LicenseReportRequest) ProtoMessage() {}
+
+func (x *LicenseReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[103]
 	if x != nil {
-		if x, ok := x.Format.(*ExportRequest_Docker); ok {
-			return x.Docker
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *ExportRequest) GetInfraConfPath() string {
+// Deprecated: Use LicenseReportRequest.ProtoReflect.Descriptor instead.
+func (*LicenseReportRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *LicenseReportRequest) GetAppRoot() string {
 	if x != nil {
-		return x.InfraConfPath
+		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *ExportRequest) GetServices() []string {
-	if x != nil {
-		return x.Services
-	}
-	return nil
+type LicenseReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// generated_at is when the report was generated, in RFC 3339 format.
+	GeneratedAt  string               `protobuf:"bytes,1,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	Dependencies []*DependencyLicense `protobuf:"bytes,2,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	// violations are the dependencies licensed under a denied license.
+	Violations    []*LicenseViolation `protobuf:"bytes,3,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExportRequest) GetGateways() []string {
-	if x != nil {
-		return x.Gateways
-	}
-	return nil
+func (x *LicenseReportResponse) Reset() {
+	*x = LicenseReportResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExportRequest) GetSkipInfraConf() bool {
+func (x *LicenseReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LicenseReportResponse) ProtoMessage() {}
+
+func (x *LicenseReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[104]
 	if x != nil {
-		return x.SkipInfraConf
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *ExportRequest) GetWorkspaceRoot() string {
+// Deprecated: Use LicenseReportResponse.ProtoReflect.Descriptor instead.
+func (*LicenseReportResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *LicenseReportResponse) GetGeneratedAt() string {
 	if x != nil {
-		return x.WorkspaceRoot
+		return x.GeneratedAt
 	}
 	return ""
 }
 
-type isExportRequest_Format interface {
-	isExportRequest_Format()
+func (x *LicenseReportResponse) GetDependencies() []*DependencyLicense {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
 }
 
-type ExportRequest_Docker struct {
-	// docker specifies to export the app as a docker image.
-	Docker *DockerExportParams `protobuf:"bytes,6,opt,name=docker,proto3,oneof"`
+func (x *LicenseReportResponse) GetViolations() []*LicenseViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
 }
 
-func (*ExportRequest_Docker) isExportRequest_Format() {}
-
-type DockerExportParams struct {
+type DependencyLicense struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// local_daemon_tag specifies what to tag the image as
-	// in the local Docker daemon. If empty the export does not
-	// interact with (or require) the local docker daemon at all.
-	LocalDaemonTag string `protobuf:"bytes,1,opt,name=local_daemon_tag,json=localDaemonTag,proto3" json:"local_daemon_tag,omitempty"`
-	// push_destination_tag specifies the remote registry tag
-	// to push the exported image to. If empty the built image
-	// is not pushed anywhere.
-	PushDestinationTag string `protobuf:"bytes,2,opt,name=push_destination_tag,json=pushDestinationTag,proto3" json:"push_destination_tag,omitempty"`
-	// base_image_tag is the base image to build the image from.
-	BaseImageTag  string `protobuf:"bytes,3,opt,name=base_image_tag,json=baseImageTag,proto3" json:"base_image_tag,omitempty"`
+	// name is the module path or package name of the dependency.
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// license is an SPDX license expression, or "UNKNOWN".
+	License string `protobuf:"bytes,3,opt,name=license,proto3" json:"license,omitempty"`
+	// license_file is the file the license was determined from, if any.
+	LicenseFile   string `protobuf:"bytes,4,opt,name=license_file,json=licenseFile,proto3" json:"license_file,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DockerExportParams) Reset() {
-	*x = DockerExportParams{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[22]
+func (x *DependencyLicense) Reset() {
+	*x = DependencyLicense{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DockerExportParams) String() string {
+func (x *DependencyLicense) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DockerExportParams) ProtoMessage() {}
+func (*DependencyLicense) ProtoMessage() {}
 
-func (x *DockerExportParams) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[22]
+func (x *DependencyLicense) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2091,61 +8351,63 @@ func (x *DockerExportParams) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DockerExportParams.ProtoReflect.Descriptor instead.
-func (*DockerExportParams) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use DependencyLicense.ProtoReflect.Descriptor instead.
+func (*DependencyLicense) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *DockerExportParams) GetLocalDaemonTag() string {
+func (x *DependencyLicense) GetName() string {
 	if x != nil {
-		return x.LocalDaemonTag
+		return x.Name
 	}
 	return ""
 }
 
-func (x *DockerExportParams) GetPushDestinationTag() string {
+func (x *DependencyLicense) GetVersion() string {
 	if x != nil {
-		return x.PushDestinationTag
+		return x.Version
 	}
 	return ""
 }
 
-func (x *DockerExportParams) GetBaseImageTag() string {
+func (x *DependencyLicense) GetLicense() string {
 	if x != nil {
-		return x.BaseImageTag
+		return x.License
 	}
 	return ""
 }
 
-type DBConnectRequest struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot     string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	DbName      string                 `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	EnvName     string                 `protobuf:"bytes,3,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"` // optional
-	ClusterType DBClusterType          `protobuf:"varint,4,opt,name=cluster_type,json=clusterType,proto3,enum=encore.daemon.DBClusterType" json:"cluster_type,omitempty"`
-	// namespace is the infrastructure namespace to use.
-	// If empty the active namespace is used.
-	Namespace     *string `protobuf:"bytes,5,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
-	Role          DBRole  `protobuf:"varint,6,opt,name=role,proto3,enum=encore.daemon.DBRole" json:"role,omitempty"`
+func (x *DependencyLicense) GetLicenseFile() string {
+	if x != nil {
+		return x.LicenseFile
+	}
+	return ""
+}
+
+type LicenseViolation struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Dependency *DependencyLicense     `protobuf:"bytes,1,opt,name=dependency,proto3" json:"dependency,omitempty"`
+	// denied are the denied licenses the dependency is licensed under.
+	Denied        []string `protobuf:"bytes,2,rep,name=denied,proto3" json:"denied,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DBConnectRequest) Reset() {
-	*x = DBConnectRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[23]
+func (x *LicenseViolation) Reset() {
+	*x = LicenseViolation{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DBConnectRequest) String() string {
+func (x *LicenseViolation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DBConnectRequest) ProtoMessage() {}
+func (*LicenseViolation) ProtoMessage() {}
 
-func (x *DBConnectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[23]
+func (x *LicenseViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2156,75 +8418,112 @@ func (x *DBConnectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DBConnectRequest.ProtoReflect.Descriptor instead.
-func (*DBConnectRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use LicenseViolation.ProtoReflect.Descriptor instead.
+func (*LicenseViolation) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *DBConnectRequest) GetAppRoot() string {
+func (x *LicenseViolation) GetDependency() *DependencyLicense {
 	if x != nil {
-		return x.AppRoot
+		return x.Dependency
 	}
-	return ""
+	return nil
 }
 
-func (x *DBConnectRequest) GetDbName() string {
+func (x *LicenseViolation) GetDenied() []string {
 	if x != nil {
-		return x.DbName
+		return x.Denied
 	}
-	return ""
+	return nil
 }
 
-func (x *DBConnectRequest) GetEnvName() string {
-	if x != nil {
-		return x.EnvName
-	}
-	return ""
+type BuildProvenanceRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// environ is the environment of the build, for SOURCE_DATE_EPOCH.
+	Environ       []string `protobuf:"bytes,2,rep,name=environ,proto3" json:"environ,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DBConnectRequest) GetClusterType() DBClusterType {
+func (x *BuildProvenanceRequest) Reset() {
+	*x = BuildProvenanceRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildProvenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildProvenanceRequest) ProtoMessage() {}
+
+func (x *BuildProvenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[107]
 	if x != nil {
-		return x.ClusterType
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return DBClusterType_DB_CLUSTER_TYPE_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-func (x *DBConnectRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+// Deprecated: Use BuildProvenanceRequest.ProtoReflect.Descriptor instead.
+func (*BuildProvenanceRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *BuildProvenanceRequest) GetAppRoot() string {
+	if x != nil {
+		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *DBConnectRequest) GetRole() DBRole {
+func (x *BuildProvenanceRequest) GetEnviron() []string {
 	if x != nil {
-		return x.Role
+		return x.Environ
 	}
-	return DBRole_DB_ROLE_UNSPECIFIED
+	return nil
 }
 
-type DBConnectResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Dsn           string                 `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
+type BuildProvenanceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// encore_compiler is the version of Encore the app is built with.
+	EncoreCompiler string `protobuf:"bytes,1,opt,name=encore_compiler,json=encoreCompiler,proto3" json:"encore_compiler,omitempty"`
+	// revision is the source commit, if known.
+	Revision           string `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	UncommittedChanges bool   `protobuf:"varint,3,opt,name=uncommitted_changes,json=uncommittedChanges,proto3" json:"uncommitted_changes,omitempty"`
+	// toolchains are the toolchain versions, keyed by name, such as "go".
+	Toolchains map[string]string `protobuf:"bytes,4,rep,name=toolchains,proto3" json:"toolchains,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// infra_hash is a hash of the infrastructure the app requires.
+	InfraHash string `protobuf:"bytes,5,opt,name=infra_hash,json=infraHash,proto3" json:"infra_hash,omitempty"`
+	// build_time is the time recorded in build artifacts, in RFC 3339 format.
+	// It is empty if builds are not reproducible, because the app has
+	// uncommitted changes and SOURCE_DATE_EPOCH is not set.
+	BuildTime     string `protobuf:"bytes,6,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DBConnectResponse) Reset() {
-	*x = DBConnectResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[24]
+func (x *BuildProvenanceResponse) Reset() {
+	*x = BuildProvenanceResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DBConnectResponse) String() string {
+func (x *BuildProvenanceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DBConnectResponse) ProtoMessage() {}
+func (*BuildProvenanceResponse) ProtoMessage() {}
 
-func (x *DBConnectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[24]
+func (x *BuildProvenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2235,47 +8534,80 @@ func (x *DBConnectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DBConnectResponse.ProtoReflect.Descriptor instead.
-func (*DBConnectResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use BuildProvenanceResponse.ProtoReflect.Descriptor instead.
+func (*BuildProvenanceResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *DBConnectResponse) GetDsn() string {
+func (x *BuildProvenanceResponse) GetEncoreCompiler() string {
 	if x != nil {
-		return x.Dsn
+		return x.EncoreCompiler
 	}
 	return ""
 }
 
-type DBProxyRequest struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot     string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	EnvName     string                 `protobuf:"bytes,2,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"` // optional
-	Port        int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`                     // optional
-	ClusterType DBClusterType          `protobuf:"varint,4,opt,name=cluster_type,json=clusterType,proto3,enum=encore.daemon.DBClusterType" json:"cluster_type,omitempty"`
-	// namespace is the infrastructure namespace to use.
-	// If empty the active namespace is used.
-	Namespace     *string `protobuf:"bytes,5,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
-	Role          DBRole  `protobuf:"varint,6,opt,name=role,proto3,enum=encore.daemon.DBRole" json:"role,omitempty"`
+func (x *BuildProvenanceResponse) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *BuildProvenanceResponse) GetUncommittedChanges() bool {
+	if x != nil {
+		return x.UncommittedChanges
+	}
+	return false
+}
+
+func (x *BuildProvenanceResponse) GetToolchains() map[string]string {
+	if x != nil {
+		return x.Toolchains
+	}
+	return nil
+}
+
+func (x *BuildProvenanceResponse) GetInfraHash() string {
+	if x != nil {
+		return x.InfraHash
+	}
+	return ""
+}
+
+func (x *BuildProvenanceResponse) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+type AnalyzeDeadlinesRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// trace_id, if set, restricts the analysis to the given trace.
+	TraceId *string `protobuf:"bytes,2,opt,name=trace_id,json=traceId,proto3,oneof" json:"trace_id,omitempty"`
+	// limit is the maximum number of recent traces to analyze.
+	// If 0 it defaults to 100.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DBProxyRequest) Reset() {
-	*x = DBProxyRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[25]
+func (x *AnalyzeDeadlinesRequest) Reset() {
+	*x = AnalyzeDeadlinesRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DBProxyRequest) String() string {
+func (x *AnalyzeDeadlinesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DBProxyRequest) ProtoMessage() {}
+func (*AnalyzeDeadlinesRequest) ProtoMessage() {}
 
-func (x *DBProxyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[25]
+func (x *AnalyzeDeadlinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2286,80 +8618,56 @@ func (x *DBProxyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DBProxyRequest.ProtoReflect.Descriptor instead.
-func (*DBProxyRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use AnalyzeDeadlinesRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeDeadlinesRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{109}
 }
 
-func (x *DBProxyRequest) GetAppRoot() string {
+func (x *AnalyzeDeadlinesRequest) GetAppRoot() string {
 	if x != nil {
 		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *DBProxyRequest) GetEnvName() string {
-	if x != nil {
-		return x.EnvName
+func (x *AnalyzeDeadlinesRequest) GetTraceId() string {
+	if x != nil && x.TraceId != nil {
+		return *x.TraceId
 	}
 	return ""
 }
 
-func (x *DBProxyRequest) GetPort() int32 {
+func (x *AnalyzeDeadlinesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Port
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *DBProxyRequest) GetClusterType() DBClusterType {
-	if x != nil {
-		return x.ClusterType
-	}
-	return DBClusterType_DB_CLUSTER_TYPE_UNSPECIFIED
-}
-
-func (x *DBProxyRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
-	}
-	return ""
-}
-
-func (x *DBProxyRequest) GetRole() DBRole {
-	if x != nil {
-		return x.Role
-	}
-	return DBRole_DB_ROLE_UNSPECIFIED
-}
-
-type DBResetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	DatabaseNames []string               `protobuf:"bytes,2,rep,name=database_names,json=databaseNames,proto3" json:"database_names,omitempty"` // database names to reset
-	ClusterType   DBClusterType          `protobuf:"varint,3,opt,name=cluster_type,json=clusterType,proto3,enum=encore.daemon.DBClusterType" json:"cluster_type,omitempty"`
-	// namespace is the infrastructure namespace to use.
-	// If empty the active namespace is used.
-	Namespace     *string `protobuf:"bytes,4,opt,name=namespace,proto3,oneof" json:"namespace,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type AnalyzeDeadlinesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Findings []*DeadlineFinding     `protobuf:"bytes,1,rep,name=findings,proto3" json:"findings,omitempty"`
+	// traces_analyzed is the number of traces that were analyzed.
+	TracesAnalyzed int32 `protobuf:"varint,2,opt,name=traces_analyzed,json=tracesAnalyzed,proto3" json:"traces_analyzed,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *DBResetRequest) Reset() {
-	*x = DBResetRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[26]
+func (x *AnalyzeDeadlinesResponse) Reset() {
+	*x = AnalyzeDeadlinesResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DBResetRequest) String() string {
+func (x *AnalyzeDeadlinesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DBResetRequest) ProtoMessage() {}
+func (*AnalyzeDeadlinesResponse) ProtoMessage() {}
 
-func (x *DBResetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[26]
+func (x *AnalyzeDeadlinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2370,92 +8678,60 @@ func (x *DBResetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DBResetRequest.ProtoReflect.Descriptor instead.
-func (*DBResetRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{26}
-}
-
-func (x *DBResetRequest) GetAppRoot() string {
-	if x != nil {
-		return x.AppRoot
-	}
-	return ""
+// Deprecated: Use AnalyzeDeadlinesResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeDeadlinesResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *DBResetRequest) GetDatabaseNames() []string {
+func (x *AnalyzeDeadlinesResponse) GetFindings() []*DeadlineFinding {
 	if x != nil {
-		return x.DatabaseNames
+		return x.Findings
 	}
 	return nil
 }
 
-func (x *DBResetRequest) GetClusterType() DBClusterType {
+func (x *AnalyzeDeadlinesResponse) GetTracesAnalyzed() int32 {
 	if x != nil {
-		return x.ClusterType
-	}
-	return DBClusterType_DB_CLUSTER_TYPE_UNSPECIFIED
-}
-
-func (x *DBResetRequest) GetNamespace() string {
-	if x != nil && x.Namespace != nil {
-		return *x.Namespace
+		return x.TracesAnalyzed
 	}
-	return ""
+	return 0
 }
 
-type GenClientRequest struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	AppId    string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	EnvName  string                 `protobuf:"bytes,2,opt,name=env_name,json=envName,proto3" json:"env_name,omitempty"`
-	Lang     string                 `protobuf:"bytes,3,opt,name=lang,proto3" json:"lang,omitempty"`
-	Filepath string                 `protobuf:"bytes,4,opt,name=filepath,proto3" json:"filepath,omitempty"`
-	// Services to include in the output.
-	// If the string "*" is present all services are included.
-	Services []string `protobuf:"bytes,5,rep,name=services,proto3" json:"services,omitempty"`
-	// Services to exclude from the output.
-	// Takes precedence over 'services' above.
-	ExcludedServices []string `protobuf:"bytes,6,rep,name=excluded_services,json=excludedServices,proto3" json:"excluded_services,omitempty"`
-	// Tags of endpoints to include in the output.
-	// Only includes endpoints from services included in 'services' above.
-	EndpointTags []string `protobuf:"bytes,7,rep,name=endpoint_tags,json=endpointTags,proto3" json:"endpoint_tags,omitempty"`
-	// Tags of endpoints to exclude from the output.
-	// Takes precedence over 'endpoint_tags' above.
-	ExcludedEndpointTags []string `protobuf:"bytes,8,rep,name=excluded_endpoint_tags,json=excludedEndpointTags,proto3" json:"excluded_endpoint_tags,omitempty"`
-	// The OpenAPI spec generator by default includes private endpoints.
-	// If this is set to `true`, private endpoints will not be included
-	// in the generated OpenAPI spec.
-	OpenapiExcludePrivateEndpoints *bool `protobuf:"varint,9,opt,name=openapi_exclude_private_endpoints,json=openapiExcludePrivateEndpoints,proto3,oneof" json:"openapi_exclude_private_endpoints,omitempty"`
-	// The TS generator by default re-declares the api types in the client.
-	// If this is set to `true`, the types will be imported and shared between
-	// the client and the server. It assumes "~backend" is available in the
-	// import path.
-	TsSharedTypes *bool `protobuf:"varint,10,opt,name=ts_shared_types,json=tsSharedTypes,proto3,oneof" json:"ts_shared_types,omitempty"`
-	// If set, the default export of the generate TypeScript client will be
-	// an instantiated client with the given target. The target can be e.g.
-	// a variable, e.g. "import.meta.env.VITE_CLIENT_TARGET" or a string literal.
-	TsClientTarget *string `protobuf:"bytes,11,opt,name=ts_client_target,json=tsClientTarget,proto3,oneof" json:"ts_client_target,omitempty"`
-	// The root directory of the app to generate a client for.
-	// Included to be able to handle multi clone scenarios.
-	AppRoot       string `protobuf:"bytes,12,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+type DeadlineFinding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Issue         DeadlineFinding_Issue  `protobuf:"varint,1,opt,name=issue,proto3,enum=encore.daemon.DeadlineFinding_Issue" json:"issue,omitempty"`
+	TraceId       string                 `protobuf:"bytes,2,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	SpanId        string                 `protobuf:"bytes,3,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	ParentSpanId  *string                `protobuf:"bytes,4,opt,name=parent_span_id,json=parentSpanId,proto3,oneof" json:"parent_span_id,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,5,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	EndpointName  string                 `protobuf:"bytes,6,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
+	DurationNanos uint64                 `protobuf:"varint,7,opt,name=duration_nanos,json=durationNanos,proto3" json:"duration_nanos,omitempty"`
+	// deadline_remaining_nanos is the time remaining until the request's
+	// deadline when it started. Unset if the request had no deadline.
+	DeadlineRemainingNanos *int64 `protobuf:"varint,8,opt,name=deadline_remaining_nanos,json=deadlineRemainingNanos,proto3,oneof" json:"deadline_remaining_nanos,omitempty"`
+	// parent_deadline_remaining_nanos is the same for the calling request.
+	ParentDeadlineRemainingNanos *int64 `protobuf:"varint,9,opt,name=parent_deadline_remaining_nanos,json=parentDeadlineRemainingNanos,proto3,oneof" json:"parent_deadline_remaining_nanos,omitempty"`
+	// cancel_cause is the cause of the context cancellation, if any.
+	CancelCause   *string `protobuf:"bytes,10,opt,name=cancel_cause,json=cancelCause,proto3,oneof" json:"cancel_cause,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenClientRequest) Reset() {
-	*x = GenClientRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[27]
+func (x *DeadlineFinding) Reset() {
+	*x = DeadlineFinding{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenClientRequest) String() string {
+func (x *DeadlineFinding) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenClientRequest) ProtoMessage() {}
+func (*DeadlineFinding) ProtoMessage() {}
 
-func (x *GenClientRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[27]
+func (x *DeadlineFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2466,117 +8742,107 @@ func (x *GenClientRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenClientRequest.ProtoReflect.Descriptor instead.
-func (*GenClientRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use DeadlineFinding.ProtoReflect.Descriptor instead.
+func (*DeadlineFinding) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *GenClientRequest) GetAppId() string {
+func (x *DeadlineFinding) GetIssue() DeadlineFinding_Issue {
 	if x != nil {
-		return x.AppId
+		return x.Issue
 	}
-	return ""
+	return DeadlineFinding_ISSUE_UNSPECIFIED
 }
 
-func (x *GenClientRequest) GetEnvName() string {
+func (x *DeadlineFinding) GetTraceId() string {
 	if x != nil {
-		return x.EnvName
+		return x.TraceId
 	}
 	return ""
 }
 
-func (x *GenClientRequest) GetLang() string {
+func (x *DeadlineFinding) GetSpanId() string {
 	if x != nil {
-		return x.Lang
+		return x.SpanId
 	}
 	return ""
 }
 
-func (x *GenClientRequest) GetFilepath() string {
-	if x != nil {
-		return x.Filepath
+func (x *DeadlineFinding) GetParentSpanId() string {
+	if x != nil && x.ParentSpanId != nil {
+		return *x.ParentSpanId
 	}
 	return ""
 }
 
-func (x *GenClientRequest) GetServices() []string {
-	if x != nil {
-		return x.Services
-	}
-	return nil
-}
-
-func (x *GenClientRequest) GetExcludedServices() []string {
+func (x *DeadlineFinding) GetServiceName() string {
 	if x != nil {
-		return x.ExcludedServices
+		return x.ServiceName
 	}
-	return nil
+	return ""
 }
 
-func (x *GenClientRequest) GetEndpointTags() []string {
+func (x *DeadlineFinding) GetEndpointName() string {
 	if x != nil {
-		return x.EndpointTags
+		return x.EndpointName
 	}
-	return nil
+	return ""
 }
 
-func (x *GenClientRequest) GetExcludedEndpointTags() []string {
+func (x *DeadlineFinding) GetDurationNanos() uint64 {
 	if x != nil {
-		return x.ExcludedEndpointTags
-	}
-	return nil
-}
-
-func (x *GenClientRequest) GetOpenapiExcludePrivateEndpoints() bool {
-	if x != nil && x.OpenapiExcludePrivateEndpoints != nil {
-		return *x.OpenapiExcludePrivateEndpoints
+		return x.DurationNanos
 	}
-	return false
+	return 0
 }
 
-func (x *GenClientRequest) GetTsSharedTypes() bool {
-	if x != nil && x.TsSharedTypes != nil {
-		return *x.TsSharedTypes
+func (x *DeadlineFinding) GetDeadlineRemainingNanos() int64 {
+	if x != nil && x.DeadlineRemainingNanos != nil {
+		return *x.DeadlineRemainingNanos
 	}
-	return false
+	return 0
 }
 
-func (x *GenClientRequest) GetTsClientTarget() string {
-	if x != nil && x.TsClientTarget != nil {
-		return *x.TsClientTarget
+func (x *DeadlineFinding) GetParentDeadlineRemainingNanos() int64 {
+	if x != nil && x.ParentDeadlineRemainingNanos != nil {
+		return *x.ParentDeadlineRemainingNanos
 	}
-	return ""
+	return 0
 }
 
-func (x *GenClientRequest) GetAppRoot() string {
-	if x != nil {
-		return x.AppRoot
+func (x *DeadlineFinding) GetCancelCause() string {
+	if x != nil && x.CancelCause != nil {
+		return *x.CancelCause
 	}
 	return ""
 }
 
-type GenClientResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          []byte                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+type UsageReportRequest struct {
+	state   protoimpl.MessageState     `protogen:"open.v1"`
+	AppRoot string                     `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	GroupBy UsageReportRequest_GroupBy `protobuf:"varint,2,opt,name=group_by,json=groupBy,proto3,enum=encore.daemon.UsageReportRequest_GroupBy" json:"group_by,omitempty"`
+	// limit is the maximum number of recent traces to analyze.
+	// If 0 it defaults to 1000.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenClientResponse) Reset() {
-	*x = GenClientResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[28]
+func (x *UsageReportRequest) Reset() {
+	*x = UsageReportRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenClientResponse) String() string {
+func (x *UsageReportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenClientResponse) ProtoMessage() {}
+func (*UsageReportRequest) ProtoMessage() {}
 
-func (x *GenClientResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[28]
+func (x *UsageReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2587,83 +8853,56 @@ func (x *GenClientResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenClientResponse.ProtoReflect.Descriptor instead.
-func (*GenClientResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use UsageReportRequest.ProtoReflect.Descriptor instead.
+func (*UsageReportRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{112}
 }
 
-func (x *GenClientResponse) GetCode() []byte {
+func (x *UsageReportRequest) GetAppRoot() string {
 	if x != nil {
-		return x.Code
+		return x.AppRoot
 	}
-	return nil
-}
-
-type GenWrappersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GenWrappersRequest) Reset() {
-	*x = GenWrappersRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[29]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GenWrappersRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*GenWrappersRequest) ProtoMessage() {}
-
-func (x *GenWrappersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[29]
+func (x *UsageReportRequest) GetGroupBy() UsageReportRequest_GroupBy {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.GroupBy
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GenWrappersRequest.ProtoReflect.Descriptor instead.
-func (*GenWrappersRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{29}
+	return UsageReportRequest_GROUP_BY_ENDPOINT
 }
 
-func (x *GenWrappersRequest) GetAppRoot() string {
+func (x *UsageReportRequest) GetLimit() int32 {
 	if x != nil {
-		return x.AppRoot
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type GenWrappersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type UsageReportResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TracesAnalyzed int32                  `protobuf:"varint,1,opt,name=traces_analyzed,json=tracesAnalyzed,proto3" json:"traces_analyzed,omitempty"`
+	// groups are sorted by the time spent on resources, most first.
+	Groups        []*UsageGroup `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenWrappersResponse) Reset() {
-	*x = GenWrappersResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[30]
+func (x *UsageReportResponse) Reset() {
+	*x = UsageReportResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenWrappersResponse) String() string {
+func (x *UsageReportResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenWrappersResponse) ProtoMessage() {}
+func (*UsageReportResponse) ProtoMessage() {}
 
-func (x *GenWrappersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[30]
+func (x *UsageReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2674,35 +8913,61 @@ func (x *GenWrappersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenWrappersResponse.ProtoReflect.Descriptor instead.
-func (*GenWrappersResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use UsageReportResponse.ProtoReflect.Descriptor instead.
+func (*UsageReportResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{113}
 }
 
-type SecretsRefreshRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+func (x *UsageReportResponse) GetTracesAnalyzed() int32 {
+	if x != nil {
+		return x.TracesAnalyzed
+	}
+	return 0
+}
+
+func (x *UsageReportResponse) GetGroups() []*UsageGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type UsageGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// service_name and endpoint_name are set when grouping by endpoint.
+	ServiceName  string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	EndpointName string `protobuf:"bytes,2,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
+	// uid is set when grouping by user. It is empty for unauthenticated requests.
+	Uid           string `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Requests      int32  `protobuf:"varint,4,opt,name=requests,proto3" json:"requests,omitempty"`
+	RequestNanos  uint64 `protobuf:"varint,5,opt,name=request_nanos,json=requestNanos,proto3" json:"request_nanos,omitempty"`
+	DbQueries     int32  `protobuf:"varint,6,opt,name=db_queries,json=dbQueries,proto3" json:"db_queries,omitempty"`
+	DbNanos       uint64 `protobuf:"varint,7,opt,name=db_nanos,json=dbNanos,proto3" json:"db_nanos,omitempty"`
+	CacheOps      int32  `protobuf:"varint,8,opt,name=cache_ops,json=cacheOps,proto3" json:"cache_ops,omitempty"`
+	CacheNanos    uint64 `protobuf:"varint,9,opt,name=cache_nanos,json=cacheNanos,proto3" json:"cache_nanos,omitempty"`
+	Publishes     int32  `protobuf:"varint,10,opt,name=publishes,proto3" json:"publishes,omitempty"`
+	PublishNanos  uint64 `protobuf:"varint,11,opt,name=publish_nanos,json=publishNanos,proto3" json:"publish_nanos,omitempty"`
+	HttpCalls     int32  `protobuf:"varint,12,opt,name=http_calls,json=httpCalls,proto3" json:"http_calls,omitempty"`
+	HttpNanos     uint64 `protobuf:"varint,13,opt,name=http_nanos,json=httpNanos,proto3" json:"http_nanos,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SecretsRefreshRequest) Reset() {
-	*x = SecretsRefreshRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[31]
+func (x *UsageGroup) Reset() {
+	*x = UsageGroup{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SecretsRefreshRequest) String() string {
+func (x *UsageGroup) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SecretsRefreshRequest) ProtoMessage() {}
+func (*UsageGroup) ProtoMessage() {}
 
-func (x *SecretsRefreshRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[31]
+func (x *UsageGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2713,91 +8978,126 @@ func (x *SecretsRefreshRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SecretsRefreshRequest.ProtoReflect.Descriptor instead.
-func (*SecretsRefreshRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use UsageGroup.ProtoReflect.Descriptor instead.
+func (*UsageGroup) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *SecretsRefreshRequest) GetAppRoot() string {
+func (x *UsageGroup) GetServiceName() string {
 	if x != nil {
-		return x.AppRoot
+		return x.ServiceName
 	}
 	return ""
 }
 
-func (x *SecretsRefreshRequest) GetKey() string {
+func (x *UsageGroup) GetEndpointName() string {
 	if x != nil {
-		return x.Key
+		return x.EndpointName
 	}
 	return ""
 }
 
-func (x *SecretsRefreshRequest) GetValue() string {
+func (x *UsageGroup) GetUid() string {
 	if x != nil {
-		return x.Value
+		return x.Uid
 	}
 	return ""
 }
 
-type SecretsRefreshResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UsageGroup) GetRequests() int32 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
 }
 
-func (x *SecretsRefreshResponse) Reset() {
-	*x = SecretsRefreshResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[32]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UsageGroup) GetRequestNanos() uint64 {
+	if x != nil {
+		return x.RequestNanos
+	}
+	return 0
 }
 
-func (x *SecretsRefreshResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UsageGroup) GetDbQueries() int32 {
+	if x != nil {
+		return x.DbQueries
+	}
+	return 0
 }
 
-func (*SecretsRefreshResponse) ProtoMessage() {}
+func (x *UsageGroup) GetDbNanos() uint64 {
+	if x != nil {
+		return x.DbNanos
+	}
+	return 0
+}
 
-func (x *SecretsRefreshResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[32]
+func (x *UsageGroup) GetCacheOps() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CacheOps
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use SecretsRefreshResponse.ProtoReflect.Descriptor instead.
-func (*SecretsRefreshResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{32}
+func (x *UsageGroup) GetCacheNanos() uint64 {
+	if x != nil {
+		return x.CacheNanos
+	}
+	return 0
 }
 
-type VersionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	ConfigHash    string                 `protobuf:"bytes,2,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+func (x *UsageGroup) GetPublishes() int32 {
+	if x != nil {
+		return x.Publishes
+	}
+	return 0
+}
+
+func (x *UsageGroup) GetPublishNanos() uint64 {
+	if x != nil {
+		return x.PublishNanos
+	}
+	return 0
+}
+
+func (x *UsageGroup) GetHttpCalls() int32 {
+	if x != nil {
+		return x.HttpCalls
+	}
+	return 0
+}
+
+func (x *UsageGroup) GetHttpNanos() uint64 {
+	if x != nil {
+		return x.HttpNanos
+	}
+	return 0
+}
+
+type ExportSchemasRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppRoot string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+	// format is the schema format, "jsonschema" or "avro".
+	Format        string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VersionResponse) Reset() {
-	*x = VersionResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[33]
+func (x *ExportSchemasRequest) Reset() {
+	*x = ExportSchemasRequest{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VersionResponse) String() string {
+func (x *ExportSchemasRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VersionResponse) ProtoMessage() {}
+func (*ExportSchemasRequest) ProtoMessage() {}
 
-func (x *VersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[33]
+func (x *ExportSchemasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2808,51 +9108,47 @@ func (x *VersionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
-func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use ExportSchemasRequest.ProtoReflect.Descriptor instead.
+func (*ExportSchemasRequest) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{115}
 }
 
-func (x *VersionResponse) GetVersion() string {
+func (x *ExportSchemasRequest) GetAppRoot() string {
 	if x != nil {
-		return x.Version
+		return x.AppRoot
 	}
 	return ""
 }
 
-func (x *VersionResponse) GetConfigHash() string {
+func (x *ExportSchemasRequest) GetFormat() string {
 	if x != nil {
-		return x.ConfigHash
+		return x.Format
 	}
 	return ""
 }
 
-type Namespace struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	LastActiveAt  *string                `protobuf:"bytes,5,opt,name=last_active_at,json=lastActiveAt,proto3,oneof" json:"last_active_at,omitempty"`
+type ExportSchemasResponse struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Schemas       []*ExportSchemasResponse_Schema `protobuf:"bytes,1,rep,name=schemas,proto3" json:"schemas,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Namespace) Reset() {
-	*x = Namespace{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[34]
+func (x *ExportSchemasResponse) Reset() {
+	*x = ExportSchemasResponse{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Namespace) String() string {
+func (x *ExportSchemasResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Namespace) ProtoMessage() {}
+func (*ExportSchemasResponse) ProtoMessage() {}
 
-func (x *Namespace) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[34]
+func (x *ExportSchemasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2863,69 +9159,40 @@ func (x *Namespace) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Namespace.ProtoReflect.Descriptor instead.
-func (*Namespace) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{34}
-}
-
-func (x *Namespace) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Namespace) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Namespace) GetActive() bool {
-	if x != nil {
-		return x.Active
-	}
-	return false
+// Deprecated: Use ExportSchemasResponse.ProtoReflect.Descriptor instead.
+func (*ExportSchemasResponse) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{116}
 }
 
-func (x *Namespace) GetCreatedAt() string {
+func (x *ExportSchemasResponse) GetSchemas() []*ExportSchemasResponse_Schema {
 	if x != nil {
-		return x.CreatedAt
-	}
-	return ""
-}
-
-func (x *Namespace) GetLastActiveAt() string {
-	if x != nil && x.LastActiveAt != nil {
-		return *x.LastActiveAt
+		return x.Schemas
 	}
-	return ""
+	return nil
 }
 
-type CreateNamespaceRequest struct {
+// The following messages are used for sqlc plugin integration.
+type SQLCPlugin struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateNamespaceRequest) Reset() {
-	*x = CreateNamespaceRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[35]
+func (x *SQLCPlugin) Reset() {
+	*x = SQLCPlugin{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateNamespaceRequest) String() string {
+func (x *SQLCPlugin) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateNamespaceRequest) ProtoMessage() {}
+func (*SQLCPlugin) ProtoMessage() {}
 
-func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[35]
+func (x *SQLCPlugin) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2936,49 +9203,41 @@ func (x *CreateNamespaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*CreateNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{35}
-}
-
-func (x *CreateNamespaceRequest) GetAppRoot() string {
-	if x != nil {
-		return x.AppRoot
-	}
-	return ""
-}
-
-func (x *CreateNamespaceRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use SQLCPlugin.ProtoReflect.Descriptor instead.
+func (*SQLCPlugin) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117}
 }
 
-type SwitchNamespaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Create        bool                   `protobuf:"varint,3,opt,name=create,proto3" json:"create,omitempty"`
+type DBSeedStatusResponse_Seed struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Database string                 `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind     string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"` // "sql" or "go"
+	Path     string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"` // relative to the app root
+	// applied_at is when the seed was applied, in RFC 3339 format.
+	// It's unset if it hasn't been applied.
+	AppliedAt *string `protobuf:"bytes,5,opt,name=applied_at,json=appliedAt,proto3,oneof" json:"applied_at,omitempty"`
+	// changed reports whether the seed's SQL file has changed since it was applied.
+	Changed       bool `protobuf:"varint,6,opt,name=changed,proto3" json:"changed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SwitchNamespaceRequest) Reset() {
-	*x = SwitchNamespaceRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[36]
+func (x *DBSeedStatusResponse_Seed) Reset() {
+	*x = DBSeedStatusResponse_Seed{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SwitchNamespaceRequest) String() string {
+func (x *DBSeedStatusResponse_Seed) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SwitchNamespaceRequest) ProtoMessage() {}
+func (*DBSeedStatusResponse_Seed) ProtoMessage() {}
 
-func (x *SwitchNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[36]
+func (x *DBSeedStatusResponse_Seed) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2989,54 +9248,77 @@ func (x *SwitchNamespaceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SwitchNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*SwitchNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use DBSeedStatusResponse_Seed.ProtoReflect.Descriptor instead.
+func (*DBSeedStatusResponse_Seed) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{50, 0}
 }
 
-func (x *SwitchNamespaceRequest) GetAppRoot() string {
+func (x *DBSeedStatusResponse_Seed) GetDatabase() string {
 	if x != nil {
-		return x.AppRoot
+		return x.Database
 	}
 	return ""
 }
 
-func (x *SwitchNamespaceRequest) GetName() string {
+func (x *DBSeedStatusResponse_Seed) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *SwitchNamespaceRequest) GetCreate() bool {
+func (x *DBSeedStatusResponse_Seed) GetKind() string {
 	if x != nil {
-		return x.Create
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DBSeedStatusResponse_Seed) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DBSeedStatusResponse_Seed) GetAppliedAt() string {
+	if x != nil && x.AppliedAt != nil {
+		return *x.AppliedAt
+	}
+	return ""
+}
+
+func (x *DBSeedStatusResponse_Seed) GetChanged() bool {
+	if x != nil {
+		return x.Changed
 	}
 	return false
 }
 
-type ListNamespacesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
+type DBMigrationPlanResponse_Statement struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sql   string                 `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	// warnings describe the data the statement destroys or may lose.
+	Warnings      []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNamespacesRequest) Reset() {
-	*x = ListNamespacesRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[37]
+func (x *DBMigrationPlanResponse_Statement) Reset() {
+	*x = DBMigrationPlanResponse_Statement{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNamespacesRequest) String() string {
+func (x *DBMigrationPlanResponse_Statement) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNamespacesRequest) ProtoMessage() {}
+func (*DBMigrationPlanResponse_Statement) ProtoMessage() {}
 
-func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[37]
+func (x *DBMigrationPlanResponse_Statement) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3047,41 +9329,51 @@ func (x *ListNamespacesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNamespacesRequest.ProtoReflect.Descriptor instead.
-func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use DBMigrationPlanResponse_Statement.ProtoReflect.Descriptor instead.
+func (*DBMigrationPlanResponse_Statement) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{52, 0}
 }
 
-func (x *ListNamespacesRequest) GetAppRoot() string {
+func (x *DBMigrationPlanResponse_Statement) GetSql() string {
 	if x != nil {
-		return x.AppRoot
+		return x.Sql
 	}
 	return ""
 }
 
-type DeleteNamespaceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot       string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+func (x *DBMigrationPlanResponse_Statement) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type DBMigrationPlanResponse_Migration struct {
+	state      protoimpl.MessageState               `protogen:"open.v1"`
+	Number     uint64                               `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Filename   string                               `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Statements []*DBMigrationPlanResponse_Statement `protobuf:"bytes,3,rep,name=statements,proto3" json:"statements,omitempty"`
+	// dirty reports whether a previous attempt to apply the migration failed.
+	Dirty         bool `protobuf:"varint,4,opt,name=dirty,proto3" json:"dirty,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteNamespaceRequest) Reset() {
-	*x = DeleteNamespaceRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[38]
+func (x *DBMigrationPlanResponse_Migration) Reset() {
+	*x = DBMigrationPlanResponse_Migration{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteNamespaceRequest) String() string {
+func (x *DBMigrationPlanResponse_Migration) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteNamespaceRequest) ProtoMessage() {}
+func (*DBMigrationPlanResponse_Migration) ProtoMessage() {}
 
-func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[38]
+func (x *DBMigrationPlanResponse_Migration) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3089,50 +9381,69 @@ func (x *DeleteNamespaceRequest) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DBMigrationPlanResponse_Migration.ProtoReflect.Descriptor instead.
+func (*DBMigrationPlanResponse_Migration) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{52, 1}
+}
+
+func (x *DBMigrationPlanResponse_Migration) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
 }
 
-// Deprecated: Use DeleteNamespaceRequest.ProtoReflect.Descriptor instead.
-func (*DeleteNamespaceRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{38}
+func (x *DBMigrationPlanResponse_Migration) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
 }
 
-func (x *DeleteNamespaceRequest) GetAppRoot() string {
+func (x *DBMigrationPlanResponse_Migration) GetStatements() []*DBMigrationPlanResponse_Statement {
 	if x != nil {
-		return x.AppRoot
+		return x.Statements
 	}
-	return ""
+	return nil
 }
 
-func (x *DeleteNamespaceRequest) GetName() string {
+func (x *DBMigrationPlanResponse_Migration) GetDirty() bool {
 	if x != nil {
-		return x.Name
+		return x.Dirty
 	}
-	return ""
+	return false
 }
 
-type ListNamespacesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespaces    []*Namespace           `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+type DBMigrationPlanResponse_Database struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// exists reports whether the database has been created.
+	// Databases that don't exist yet have all their migrations pending.
+	Exists bool `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	// pending are the migrations that would be applied, in order.
+	Pending       []*DBMigrationPlanResponse_Migration `protobuf:"bytes,3,rep,name=pending,proto3" json:"pending,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNamespacesResponse) Reset() {
-	*x = ListNamespacesResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[39]
+func (x *DBMigrationPlanResponse_Database) Reset() {
+	*x = DBMigrationPlanResponse_Database{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNamespacesResponse) String() string {
+func (x *DBMigrationPlanResponse_Database) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNamespacesResponse) ProtoMessage() {}
+func (*DBMigrationPlanResponse_Database) ProtoMessage() {}
 
-func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[39]
+func (x *DBMigrationPlanResponse_Database) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3143,42 +9454,56 @@ func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
-func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use DBMigrationPlanResponse_Database.ProtoReflect.Descriptor instead.
+func (*DBMigrationPlanResponse_Database) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{52, 2}
 }
 
-func (x *ListNamespacesResponse) GetNamespaces() []*Namespace {
+func (x *DBMigrationPlanResponse_Database) GetName() string {
 	if x != nil {
-		return x.Namespaces
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DBMigrationPlanResponse_Database) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *DBMigrationPlanResponse_Database) GetPending() []*DBMigrationPlanResponse_Migration {
+	if x != nil {
+		return x.Pending
 	}
 	return nil
 }
 
-type TelemetryConfig struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AnonId        string                 `protobuf:"bytes,1,opt,name=anon_id,json=anonId,proto3" json:"anon_id,omitempty"`
-	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Debug         bool                   `protobuf:"varint,3,opt,name=debug,proto3" json:"debug,omitempty"`
+type DBDiffResponse_Statement struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sql   string                 `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	// warnings describe the data the statement destroys or may lose.
+	Warnings      []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TelemetryConfig) Reset() {
-	*x = TelemetryConfig{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[40]
+func (x *DBDiffResponse_Statement) Reset() {
+	*x = DBDiffResponse_Statement{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TelemetryConfig) String() string {
+func (x *DBDiffResponse_Statement) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TelemetryConfig) ProtoMessage() {}
+func (*DBDiffResponse_Statement) ProtoMessage() {}
 
-func (x *TelemetryConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[40]
+func (x *DBDiffResponse_Statement) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3189,61 +9514,57 @@ func (x *TelemetryConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TelemetryConfig.ProtoReflect.Descriptor instead.
-func (*TelemetryConfig) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use DBDiffResponse_Statement.ProtoReflect.Descriptor instead.
+func (*DBDiffResponse_Statement) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{55, 0}
 }
 
-func (x *TelemetryConfig) GetAnonId() string {
+func (x *DBDiffResponse_Statement) GetSql() string {
 	if x != nil {
-		return x.AnonId
+		return x.Sql
 	}
 	return ""
 }
 
-func (x *TelemetryConfig) GetEnabled() bool {
-	if x != nil {
-		return x.Enabled
-	}
-	return false
-}
-
-func (x *TelemetryConfig) GetDebug() bool {
+func (x *DBDiffResponse_Statement) GetWarnings() []string {
 	if x != nil {
-		return x.Debug
+		return x.Warnings
 	}
-	return false
+	return nil
 }
 
-type DumpMetaRequest struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	AppRoot    string                 `protobuf:"bytes,1,opt,name=app_root,json=appRoot,proto3" json:"app_root,omitempty"`
-	WorkingDir string                 `protobuf:"bytes,2,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"` // for error reporting
-	// environ is the environment to set for the running command.
-	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
-	Environ []string `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
-	// Whether or not to parse tests.
-	ParseTests    bool                   `protobuf:"varint,4,opt,name=parse_tests,json=parseTests,proto3" json:"parse_tests,omitempty"`
-	Format        DumpMetaRequest_Format `protobuf:"varint,5,opt,name=format,proto3,enum=encore.daemon.DumpMetaRequest_Format" json:"format,omitempty"`
+type DBQueryLogResponse_Query struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// time is when the query was sent to the database, in RFC 3339 format.
+	Time          string `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Database      string `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	Query         string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	DurationNanos int64  `protobuf:"varint,4,opt,name=duration_nanos,json=durationNanos,proto3" json:"duration_nanos,omitempty"`
+	// rows is the number of rows the query returned or affected.
+	Rows  int64   `protobuf:"varint,5,opt,name=rows,proto3" json:"rows,omitempty"`
+	Error *string `protobuf:"bytes,6,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	// trace_id and span_id identify the request that made the query, if known.
+	TraceId       *string `protobuf:"bytes,7,opt,name=trace_id,json=traceId,proto3,oneof" json:"trace_id,omitempty"`
+	SpanId        *string `protobuf:"bytes,8,opt,name=span_id,json=spanId,proto3,oneof" json:"span_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DumpMetaRequest) Reset() {
-	*x = DumpMetaRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[41]
+func (x *DBQueryLogResponse_Query) Reset() {
+	*x = DBQueryLogResponse_Query{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DumpMetaRequest) String() string {
+func (x *DBQueryLogResponse_Query) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpMetaRequest) ProtoMessage() {}
+func (*DBQueryLogResponse_Query) ProtoMessage() {}
 
-func (x *DumpMetaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[41]
+func (x *DBQueryLogResponse_Query) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3254,68 +9575,90 @@ func (x *DumpMetaRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpMetaRequest.ProtoReflect.Descriptor instead.
-func (*DumpMetaRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use DBQueryLogResponse_Query.ProtoReflect.Descriptor instead.
+func (*DBQueryLogResponse_Query) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{60, 0}
 }
 
-func (x *DumpMetaRequest) GetAppRoot() string {
+func (x *DBQueryLogResponse_Query) GetTime() string {
 	if x != nil {
-		return x.AppRoot
+		return x.Time
 	}
 	return ""
 }
 
-func (x *DumpMetaRequest) GetWorkingDir() string {
+func (x *DBQueryLogResponse_Query) GetDatabase() string {
 	if x != nil {
-		return x.WorkingDir
+		return x.Database
 	}
 	return ""
 }
 
-func (x *DumpMetaRequest) GetEnviron() []string {
+func (x *DBQueryLogResponse_Query) GetQuery() string {
 	if x != nil {
-		return x.Environ
+		return x.Query
 	}
-	return nil
+	return ""
 }
 
-func (x *DumpMetaRequest) GetParseTests() bool {
+func (x *DBQueryLogResponse_Query) GetDurationNanos() int64 {
 	if x != nil {
-		return x.ParseTests
+		return x.DurationNanos
 	}
-	return false
+	return 0
 }
 
-func (x *DumpMetaRequest) GetFormat() DumpMetaRequest_Format {
+func (x *DBQueryLogResponse_Query) GetRows() int64 {
 	if x != nil {
-		return x.Format
+		return x.Rows
 	}
-	return DumpMetaRequest_FORMAT_UNSPECIFIED
+	return 0
 }
 
-type DumpMetaResponse struct {
+func (x *DBQueryLogResponse_Query) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *DBQueryLogResponse_Query) GetTraceId() string {
+	if x != nil && x.TraceId != nil {
+		return *x.TraceId
+	}
+	return ""
+}
+
+func (x *DBQueryLogResponse_Query) GetSpanId() string {
+	if x != nil && x.SpanId != nil {
+		return *x.SpanId
+	}
+	return ""
+}
+
+type DBCDCConfigResponse_File struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Meta          []byte                 `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DumpMetaResponse) Reset() {
-	*x = DumpMetaResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[42]
+func (x *DBCDCConfigResponse_File) Reset() {
+	*x = DBCDCConfigResponse_File{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DumpMetaResponse) String() string {
+func (x *DBCDCConfigResponse_File) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpMetaResponse) ProtoMessage() {}
+func (*DBCDCConfigResponse_File) ProtoMessage() {}
 
-func (x *DumpMetaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[42]
+func (x *DBCDCConfigResponse_File) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3326,40 +9669,50 @@ func (x *DumpMetaResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpMetaResponse.ProtoReflect.Descriptor instead.
-func (*DumpMetaResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use DBCDCConfigResponse_File.ProtoReflect.Descriptor instead.
+func (*DBCDCConfigResponse_File) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{62, 0}
 }
 
-func (x *DumpMetaResponse) GetMeta() []byte {
+func (x *DBCDCConfigResponse_File) GetName() string {
 	if x != nil {
-		return x.Meta
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DBCDCConfigResponse_File) GetContent() []byte {
+	if x != nil {
+		return x.Content
 	}
 	return nil
 }
 
-// The following messages are used for sqlc plugin integration.
-type SQLCPlugin struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type ExportSchemasResponse_Schema struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the schema, in the form "service/Endpoint.request",
+	// "service/Endpoint.response" or "pubsub/topic".
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content       []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SQLCPlugin) Reset() {
-	*x = SQLCPlugin{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+func (x *ExportSchemasResponse_Schema) Reset() {
+	*x = ExportSchemasResponse_Schema{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SQLCPlugin) String() string {
+func (x *ExportSchemasResponse_Schema) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLCPlugin) ProtoMessage() {}
+func (*ExportSchemasResponse_Schema) ProtoMessage() {}
 
-func (x *SQLCPlugin) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+func (x *ExportSchemasResponse_Schema) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3370,9 +9723,23 @@ func (x *SQLCPlugin) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLCPlugin.ProtoReflect.Descriptor instead.
-func (*SQLCPlugin) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use ExportSchemasResponse_Schema.ProtoReflect.Descriptor instead.
+func (*ExportSchemasResponse_Schema) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{116, 0}
+}
+
+func (x *ExportSchemasResponse_Schema) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportSchemasResponse_Schema) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
 }
 
 type SQLCPlugin_File struct {
@@ -3385,7 +9752,7 @@ type SQLCPlugin_File struct {
 
 func (x *SQLCPlugin_File) Reset() {
 	*x = SQLCPlugin_File{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[127]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3397,7 +9764,7 @@ func (x *SQLCPlugin_File) String() string {
 func (*SQLCPlugin_File) ProtoMessage() {}
 
 func (x *SQLCPlugin_File) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[127]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3410,7 +9777,7 @@ func (x *SQLCPlugin_File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_File.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_File) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 0}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 0}
 }
 
 func (x *SQLCPlugin_File) GetName() string {
@@ -3440,7 +9807,7 @@ type SQLCPlugin_Settings struct {
 
 func (x *SQLCPlugin_Settings) Reset() {
 	*x = SQLCPlugin_Settings{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[128]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3452,7 +9819,7 @@ func (x *SQLCPlugin_Settings) String() string {
 func (*SQLCPlugin_Settings) ProtoMessage() {}
 
 func (x *SQLCPlugin_Settings) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[128]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3465,7 +9832,7 @@ func (x *SQLCPlugin_Settings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Settings.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Settings) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 1}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 1}
 }
 
 func (x *SQLCPlugin_Settings) GetVersion() string {
@@ -3517,7 +9884,7 @@ type SQLCPlugin_Codegen struct {
 
 func (x *SQLCPlugin_Codegen) Reset() {
 	*x = SQLCPlugin_Codegen{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[129]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3529,7 +9896,7 @@ func (x *SQLCPlugin_Codegen) String() string {
 func (*SQLCPlugin_Codegen) ProtoMessage() {}
 
 func (x *SQLCPlugin_Codegen) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3542,7 +9909,7 @@ func (x *SQLCPlugin_Codegen) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Codegen.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Codegen) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 2}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 2}
 }
 
 func (x *SQLCPlugin_Codegen) GetOut() string {
@@ -3599,7 +9966,7 @@ type SQLCPlugin_Catalog struct {
 
 func (x *SQLCPlugin_Catalog) Reset() {
 	*x = SQLCPlugin_Catalog{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3611,7 +9978,7 @@ func (x *SQLCPlugin_Catalog) String() string {
 func (*SQLCPlugin_Catalog) ProtoMessage() {}
 
 func (x *SQLCPlugin_Catalog) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3624,7 +9991,7 @@ func (x *SQLCPlugin_Catalog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Catalog.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Catalog) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 3}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 3}
 }
 
 func (x *SQLCPlugin_Catalog) GetComment() string {
@@ -3668,7 +10035,7 @@ type SQLCPlugin_Schema struct {
 
 func (x *SQLCPlugin_Schema) Reset() {
 	*x = SQLCPlugin_Schema{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3680,7 +10047,7 @@ func (x *SQLCPlugin_Schema) String() string {
 func (*SQLCPlugin_Schema) ProtoMessage() {}
 
 func (x *SQLCPlugin_Schema) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3693,7 +10060,7 @@ func (x *SQLCPlugin_Schema) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Schema.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Schema) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 4}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 4}
 }
 
 func (x *SQLCPlugin_Schema) GetComment() string {
@@ -3741,7 +10108,7 @@ type SQLCPlugin_CompositeType struct {
 
 func (x *SQLCPlugin_CompositeType) Reset() {
 	*x = SQLCPlugin_CompositeType{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[132]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3753,7 +10120,7 @@ func (x *SQLCPlugin_CompositeType) String() string {
 func (*SQLCPlugin_CompositeType) ProtoMessage() {}
 
 func (x *SQLCPlugin_CompositeType) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[132]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3766,7 +10133,7 @@ func (x *SQLCPlugin_CompositeType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_CompositeType.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_CompositeType) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 5}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 5}
 }
 
 func (x *SQLCPlugin_CompositeType) GetName() string {
@@ -3794,7 +10161,7 @@ type SQLCPlugin_Enum struct {
 
 func (x *SQLCPlugin_Enum) Reset() {
 	*x = SQLCPlugin_Enum{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3806,7 +10173,7 @@ func (x *SQLCPlugin_Enum) String() string {
 func (*SQLCPlugin_Enum) ProtoMessage() {}
 
 func (x *SQLCPlugin_Enum) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3819,7 +10186,7 @@ func (x *SQLCPlugin_Enum) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Enum.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Enum) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 6}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 6}
 }
 
 func (x *SQLCPlugin_Enum) GetName() string {
@@ -3854,7 +10221,7 @@ type SQLCPlugin_Table struct {
 
 func (x *SQLCPlugin_Table) Reset() {
 	*x = SQLCPlugin_Table{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[51]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3866,7 +10233,7 @@ func (x *SQLCPlugin_Table) String() string {
 func (*SQLCPlugin_Table) ProtoMessage() {}
 
 func (x *SQLCPlugin_Table) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[51]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3879,7 +10246,7 @@ func (x *SQLCPlugin_Table) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Table.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Table) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 7}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 7}
 }
 
 func (x *SQLCPlugin_Table) GetRel() *SQLCPlugin_Identifier {
@@ -3914,7 +10281,7 @@ type SQLCPlugin_Identifier struct {
 
 func (x *SQLCPlugin_Identifier) Reset() {
 	*x = SQLCPlugin_Identifier{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[52]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3926,7 +10293,7 @@ func (x *SQLCPlugin_Identifier) String() string {
 func (*SQLCPlugin_Identifier) ProtoMessage() {}
 
 func (x *SQLCPlugin_Identifier) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[52]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3939,7 +10306,7 @@ func (x *SQLCPlugin_Identifier) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Identifier.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Identifier) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 8}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 8}
 }
 
 func (x *SQLCPlugin_Identifier) GetCatalog() string {
@@ -3988,7 +10355,7 @@ type SQLCPlugin_Column struct {
 
 func (x *SQLCPlugin_Column) Reset() {
 	*x = SQLCPlugin_Column{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4000,7 +10367,7 @@ func (x *SQLCPlugin_Column) String() string {
 func (*SQLCPlugin_Column) ProtoMessage() {}
 
 func (x *SQLCPlugin_Column) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4013,7 +10380,7 @@ func (x *SQLCPlugin_Column) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Column.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Column) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 9}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 9}
 }
 
 func (x *SQLCPlugin_Column) GetName() string {
@@ -4144,7 +10511,7 @@ type SQLCPlugin_Query struct {
 
 func (x *SQLCPlugin_Query) Reset() {
 	*x = SQLCPlugin_Query{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4156,7 +10523,7 @@ func (x *SQLCPlugin_Query) String() string {
 func (*SQLCPlugin_Query) ProtoMessage() {}
 
 func (x *SQLCPlugin_Query) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4169,7 +10536,7 @@ func (x *SQLCPlugin_Query) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Query.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Query) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 10}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 10}
 }
 
 func (x *SQLCPlugin_Query) GetText() string {
@@ -4238,7 +10605,7 @@ type SQLCPlugin_Parameter struct {
 
 func (x *SQLCPlugin_Parameter) Reset() {
 	*x = SQLCPlugin_Parameter{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4250,7 +10617,7 @@ func (x *SQLCPlugin_Parameter) String() string {
 func (*SQLCPlugin_Parameter) ProtoMessage() {}
 
 func (x *SQLCPlugin_Parameter) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4263,7 +10630,7 @@ func (x *SQLCPlugin_Parameter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Parameter.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Parameter) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 11}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 11}
 }
 
 func (x *SQLCPlugin_Parameter) GetNumber() int32 {
@@ -4294,7 +10661,7 @@ type SQLCPlugin_GenerateRequest struct {
 
 func (x *SQLCPlugin_GenerateRequest) Reset() {
 	*x = SQLCPlugin_GenerateRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[139]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4306,7 +10673,7 @@ func (x *SQLCPlugin_GenerateRequest) String() string {
 func (*SQLCPlugin_GenerateRequest) ProtoMessage() {}
 
 func (x *SQLCPlugin_GenerateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[139]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4319,7 +10686,7 @@ func (x *SQLCPlugin_GenerateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_GenerateRequest.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_GenerateRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 12}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 12}
 }
 
 func (x *SQLCPlugin_GenerateRequest) GetSettings() *SQLCPlugin_Settings {
@@ -4373,7 +10740,7 @@ type SQLCPlugin_GenerateResponse struct {
 
 func (x *SQLCPlugin_GenerateResponse) Reset() {
 	*x = SQLCPlugin_GenerateResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[140]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4385,7 +10752,7 @@ func (x *SQLCPlugin_GenerateResponse) String() string {
 func (*SQLCPlugin_GenerateResponse) ProtoMessage() {}
 
 func (x *SQLCPlugin_GenerateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[140]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4398,7 +10765,7 @@ func (x *SQLCPlugin_GenerateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_GenerateResponse.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_GenerateResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 13}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 13}
 }
 
 func (x *SQLCPlugin_GenerateResponse) GetFiles() []*SQLCPlugin_File {
@@ -4417,7 +10784,7 @@ type SQLCPlugin_Codegen_Process struct {
 
 func (x *SQLCPlugin_Codegen_Process) Reset() {
 	*x = SQLCPlugin_Codegen_Process{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[141]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4429,7 +10796,7 @@ func (x *SQLCPlugin_Codegen_Process) String() string {
 func (*SQLCPlugin_Codegen_Process) ProtoMessage() {}
 
 func (x *SQLCPlugin_Codegen_Process) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[141]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4442,7 +10809,7 @@ func (x *SQLCPlugin_Codegen_Process) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Codegen_Process.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Codegen_Process) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 2, 0}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 2, 0}
 }
 
 func (x *SQLCPlugin_Codegen_Process) GetCmd() string {
@@ -4462,7 +10829,7 @@ type SQLCPlugin_Codegen_WASM struct {
 
 func (x *SQLCPlugin_Codegen_WASM) Reset() {
 	*x = SQLCPlugin_Codegen_WASM{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[142]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4474,7 +10841,7 @@ func (x *SQLCPlugin_Codegen_WASM) String() string {
 func (*SQLCPlugin_Codegen_WASM) ProtoMessage() {}
 
 func (x *SQLCPlugin_Codegen_WASM) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[142]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4487,7 +10854,7 @@ func (x *SQLCPlugin_Codegen_WASM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Codegen_WASM.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Codegen_WASM) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 2, 1}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{117, 2, 1}
 }
 
 func (x *SQLCPlugin_Codegen_WASM) GetUrl() string {
@@ -4508,17 +10875,72 @@ var File_encore_daemon_daemon_proto protoreflect.FileDescriptor
 
 const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\n" +
-	"\x1aencore/daemon/daemon.proto\x12\rencore.daemon\x1a\x1bgoogle/protobuf/empty.proto\"\xc0\x01\n" +
+	"\x1aencore/daemon/daemon.proto\x12\rencore.daemon\x1a\x1bgoogle/protobuf/empty.proto\"\xf8\x02\n" +
 	"\x0eCommandMessage\x126\n" +
 	"\x06output\x18\x01 \x01(\v2\x1c.encore.daemon.CommandOutputH\x00R\x06output\x120\n" +
 	"\x04exit\x18\x02 \x01(\v2\x1a.encore.daemon.CommandExitH\x00R\x04exit\x12=\n" +
-	"\x06errors\x18\x03 \x01(\v2#.encore.daemon.CommandDisplayErrorsH\x00R\x06errorsB\x05\n" +
-	"\x03msg\"?\n" +
+	"\x06errors\x18\x03 \x01(\v2#.encore.daemon.CommandDisplayErrorsH\x00R\x06errors\x129\n" +
+	"\asession\x18\x04 \x01(\v2\x1d.encore.daemon.CommandSessionH\x00R\asession\x12F\n" +
+	"\foutput_batch\x18\x05 \x01(\v2!.encore.daemon.CommandOutputBatchH\x00R\voutputBatch\x123\n" +
+	"\x05event\x18\x06 \x01(\v2\x1b.encore.daemon.CommandEventH\x00R\x05eventB\x05\n" +
+	"\x03msg\"\x85\x02\n" +
+	"\fCommandEvent\x12=\n" +
+	"\toperation\x18\x01 \x01(\v2\x1d.encore.daemon.OperationEventH\x00R\toperation\x12A\n" +
+	"\vrun_started\x18\x02 \x01(\v2\x1e.encore.daemon.RunStartedEventH\x00R\n" +
+	"runStarted\x124\n" +
+	"\x06reload\x18\x03 \x01(\v2\x1a.encore.daemon.ReloadEventH\x00R\x06reload\x124\n" +
+	"\x06listen\x18\x04 \x01(\v2\x1a.encore.daemon.ListenEventH\x00R\x06listenB\a\n" +
+	"\x05event\".\n" +
+	"\vListenEvent\x12\x1f\n" +
+	"\vlisten_addr\x18\x01 \x01(\tR\n" +
+	"listenAddr\"\xff\x01\n" +
+	"\x0eOperationEvent\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
+	"\x05state\x18\x02 \x01(\x0e2#.encore.daemon.OperationEvent.StateR\x05state\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\"g\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATE_STARTED\x10\x01\x12\x0e\n" +
+	"\n" +
+	"STATE_DONE\x10\x02\x12\x10\n" +
+	"\fSTATE_FAILED\x10\x03\x12\x12\n" +
+	"\x0eSTATE_CANCELED\x10\x04\"\x90\x01\n" +
+	"\x0fRunStartedEvent\x12\x19\n" +
+	"\bbase_url\x18\x01 \x01(\tR\abaseUrl\x12\x1f\n" +
+	"\vlisten_addr\x18\x02 \x01(\tR\n" +
+	"listenAddr\x12#\n" +
+	"\rdashboard_url\x18\x03 \x01(\tR\fdashboardUrl\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\x99\x01\n" +
+	"\vReloadEvent\x126\n" +
+	"\x05state\x18\x01 \x01(\x0e2 .encore.daemon.ReloadEvent.StateR\x05state\"R\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATE_STARTED\x10\x01\x12\x0e\n" +
+	"\n" +
+	"STATE_DONE\x10\x02\x12\x0f\n" +
+	"\vSTATE_STALE\x10\x03\"\xae\x01\n" +
+	"\x12CommandOutputBatch\x124\n" +
+	"\x06frames\x18\x01 \x03(\v2\x1c.encore.daemon.CommandOutputR\x06frames\x12B\n" +
+	"\vcompression\x18\x02 \x01(\x0e2 .encore.daemon.OutputCompressionR\vcompression\x12\x1e\n" +
+	"\n" +
+	"compressed\x18\x03 \x01(\fR\n" +
+	"compressed\":\n" +
+	"\x0eCommandSession\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04lost\x18\x02 \x01(\x04R\x04lost\"G\n" +
+	"\x13ResumeStreamRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\breceived\x18\x02 \x01(\x04R\breceived\"+\n" +
+	"\x13CancelStreamRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"?\n" +
 	"\rCommandOutput\x12\x16\n" +
 	"\x06stdout\x18\x01 \x01(\fR\x06stdout\x12\x16\n" +
-	"\x06stderr\x18\x02 \x01(\fR\x06stderr\"!\n" +
+	"\x06stderr\x18\x02 \x01(\fR\x06stderr\"Z\n" +
 	"\vCommandExit\x12\x12\n" +
-	"\x04code\x18\x01 \x01(\x05R\x04code\"2\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x127\n" +
+	"\bcategory\x18\x02 \x01(\x0e2\x1b.encore.daemon.ExitCategoryR\bcategory\"2\n" +
 	"\x14CommandDisplayErrors\x12\x1a\n" +
 	"\berrinsrc\x18\x01 \x01(\fR\berrinsrc\"e\n" +
 	"\x10CreateAppRequest\x12\x19\n" +
@@ -4526,7 +10948,7 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\btemplate\x18\x02 \x01(\tR\btemplate\x12\x1a\n" +
 	"\btutorial\x18\x03 \x01(\bR\btutorial\"*\n" +
 	"\x11CreateAppResponse\x12\x15\n" +
-	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\x9a\x05\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\xb2\r\n" +
 	"\n" +
 	"RunRequest\x12\x19\n" +
 	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1f\n" +
@@ -4545,7 +10967,31 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"debug_mode\x18\v \x01(\x0e2#.encore.daemon.RunRequest.DebugModeR\tdebugMode\x12 \n" +
 	"\tlog_level\x18\f \x01(\tH\x02R\blogLevel\x88\x01\x01\x120\n" +
 	"\x14scrub_sensitive_data\x18\r \x01(\bR\x12scrubSensitiveData\x12'\n" +
-	"\x0fnon_interactive\x18\x0e \x01(\bR\x0enonInteractive\"F\n" +
+	"\x0fnon_interactive\x18\x0e \x01(\bR\x0enonInteractive\x12H\n" +
+	"\temulation\x18\x0f \x01(\x0e2*.encore.daemon.RunRequest.EmulationProfileR\temulation\x12\x1c\n" +
+	"\tresumable\x18\x10 \x01(\bR\tresumable\x12!\n" +
+	"\fbatch_output\x18\x11 \x01(\bR\vbatchOutput\x12O\n" +
+	"\x12output_compression\x18\x12 \x01(\x0e2 .encore.daemon.OutputCompressionR\x11outputCompression\x12#\n" +
+	"\rdebug_bundles\x18\x13 \x01(\bR\fdebugBundles\x12&\n" +
+	"\x0fdap_listen_addr\x18\x14 \x01(\tR\rdapListenAddr\x12\x1b\n" +
+	"\tvuln_scan\x18\x15 \x01(\bR\bvulnScan\x12\x1b\n" +
+	"\tauto_port\x18\x16 \x01(\bR\bautoPort\x12(\n" +
+	"\x10skip_ready_check\x18\x17 \x01(\bR\x0eskipReadyCheck\x12\x10\n" +
+	"\x03tls\x18\x18 \x01(\bR\x03tls\x12\x18\n" +
+	"\aprofile\x18\x19 \x01(\bR\aprofile\x12\x16\n" +
+	"\x06detach\x18\x1a \x01(\bR\x06detach\x12\x1a\n" +
+	"\bservices\x18\x1b \x03(\tR\bservices\x12\x1a\n" +
+	"\bupstream\x18\x1c \x01(\tR\bupstream\x12+\n" +
+	"\x11structured_output\x18\x1d \x01(\bR\x10structuredOutput\x122\n" +
+	"\x15interpolated_env_keys\x18\x1e \x03(\tR\x13interpolatedEnvKeys\x121\n" +
+	"\x14interactive_controls\x18\x1f \x01(\bR\x13interactiveControls\x12\x18\n" +
+	"\aoffline\x18  \x01(\bR\aoffline\x12'\n" +
+	"\x0flisten_external\x18! \x01(\bR\x0elistenExternal\x12!\n" +
+	"\fsession_name\x18\" \x01(\tR\vsessionName\x12$\n" +
+	"\vbind_policy\x18# \x01(\tH\x03R\n" +
+	"bindPolicy\x88\x01\x01\x12B\n" +
+	"\n" +
+	"if_running\x18$ \x01(\x0e2#.encore.daemon.RunRequest.IfRunningR\tifRunning\"F\n" +
 	"\vBrowserMode\x12\x10\n" +
 	"\fBROWSER_AUTO\x10\x00\x12\x11\n" +
 	"\rBROWSER_NEVER\x10\x01\x12\x12\n" +
@@ -4553,12 +10999,77 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\tDebugMode\x12\x12\n" +
 	"\x0eDEBUG_DISABLED\x10\x00\x12\x11\n" +
 	"\rDEBUG_ENABLED\x10\x01\x12\x0f\n" +
-	"\vDEBUG_BREAK\x10\x02B\r\n" +
+	"\vDEBUG_BREAK\x10\x02\"G\n" +
+	"\x10EmulationProfile\x12\x19\n" +
+	"\x15EMULATION_DEVELOPMENT\x10\x00\x12\x18\n" +
+	"\x14EMULATION_PRODUCTION\x10\x01\"P\n" +
+	"\tIfRunning\x12\x13\n" +
+	"\x0fIF_RUNNING_FAIL\x10\x00\x12\x15\n" +
+	"\x11IF_RUNNING_ATTACH\x10\x01\x12\x17\n" +
+	"\x13IF_RUNNING_TAKEOVER\x10\x02B\r\n" +
 	"\v_trace_fileB\f\n" +
 	"\n" +
 	"_namespaceB\f\n" +
 	"\n" +
-	"_log_level\"\x83\x02\n" +
+	"_log_levelB\x0e\n" +
+	"\f_bind_policy\"-\n" +
+	"\x10AttachRunRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\"+\n" +
+	"\x0eStopRunRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\"\xc7\x01\n" +
+	"\x11ControlRunRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12?\n" +
+	"\x06action\x18\x02 \x01(\x0e2'.encore.daemon.ControlRunRequest.ActionR\x06action\"V\n" +
+	"\x06Action\x12\x16\n" +
+	"\x12ACTION_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eACTION_RESTART\x10\x01\x12 \n" +
+	"\x1cACTION_TOGGLE_TRACE_SAMPLING\x10\x02\"I\n" +
+	"\x16ListRunSessionsRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"P\n" +
+	"\x17ListRunSessionsResponse\x125\n" +
+	"\bsessions\x18\x01 \x03(\v2\x19.encore.daemon.RunSessionR\bsessions\"K\n" +
+	"\x14GetRunSessionRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x18\n" +
+	"\asession\x18\x02 \x01(\tR\asession\"\x9c\x01\n" +
+	"\x15GetRunSessionResponse\x123\n" +
+	"\asession\x18\x01 \x01(\v2\x19.encore.daemon.RunSessionR\asession\x12\x16\n" +
+	"\x06output\x18\x02 \x01(\fR\x06output\x126\n" +
+	"\x06traces\x18\x03 \x03(\v2\x1e.encore.daemon.RunSessionTraceR\x06traces\"\xda\x01\n" +
+	"\n" +
+	"RunSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x1d\n" +
+	"\n" +
+	"git_commit\x18\x04 \x01(\tR\tgitCommit\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\tR\tstartedAt\x12\x1e\n" +
+	"\bended_at\x18\x06 \x01(\tH\x00R\aendedAt\x88\x01\x01\x12\x1f\n" +
+	"\vexit_reason\x18\a \x01(\tR\n" +
+	"exitReasonB\v\n" +
+	"\t_ended_at\"\xd5\x01\n" +
+	"\x0fRunSessionTrace\x12\x19\n" +
+	"\btrace_id\x18\x01 \x01(\tR\atraceId\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x1f\n" +
+	"\bendpoint\x18\x03 \x01(\tH\x00R\bendpoint\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\tR\tstartedAt\x12%\n" +
+	"\x0eduration_nanos\x18\x05 \x01(\x04R\rdurationNanos\x12\x19\n" +
+	"\bis_error\x18\x06 \x01(\bR\aisErrorB\v\n" +
+	"\t_endpoint\",\n" +
+	"\x0fListRunsRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\">\n" +
+	"\x10ListRunsResponse\x12*\n" +
+	"\x04runs\x18\x01 \x03(\v2\x16.encore.daemon.RunInfoR\x04runs\"\xaf\x01\n" +
+	"\aRunInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x19\n" +
+	"\bapp_root\x18\x03 \x01(\tR\aappRoot\x12\x1f\n" +
+	"\vlisten_addr\x18\x04 \x01(\tR\n" +
+	"listenAddr\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12#\n" +
+	"\rdashboard_url\x18\x06 \x01(\tR\fdashboardUrl\"\x83\x02\n" +
 	"\x0eRunSpecRequest\x12\x19\n" +
 	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1f\n" +
 	"\vworking_dir\x18\x02 \x01(\tR\n" +
@@ -4590,7 +11101,7 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\fSpecComplete\x12\x1c\n" +
 	"\tsucceeded\x18\x01 \x01(\x05R\tsucceeded\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"\xf0\x01\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xb1\x02\n" +
 	"\vTestRequest\x12\x19\n" +
 	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1f\n" +
 	"\vworking_dir\x18\x02 \x01(\tR\n" +
@@ -4600,15 +11111,19 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\n" +
 	"trace_file\x18\x06 \x01(\tH\x00R\ttraceFile\x88\x01\x01\x12#\n" +
 	"\rcodegen_debug\x18\a \x01(\bR\fcodegenDebug\x12\x19\n" +
-	"\btemp_dir\x18\b \x01(\tR\atempDirB\r\n" +
-	"\v_trace_fileJ\x04\b\x05\x10\x06\"\x96\x01\n" +
+	"\btemp_dir\x18\b \x01(\tR\atempDir\x12!\n" +
+	"\fdb_isolation\x18\t \x01(\tR\vdbIsolation\x12\x1c\n" +
+	"\tresumable\x18\n" +
+	" \x01(\bR\tresumableB\r\n" +
+	"\v_trace_fileJ\x04\b\x05\x10\x06\"\xb9\x01\n" +
 	"\x0fTestSpecRequest\x12\x19\n" +
 	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1f\n" +
 	"\vworking_dir\x18\x02 \x01(\tR\n" +
 	"workingDir\x12\x12\n" +
 	"\x04args\x18\x03 \x03(\tR\x04args\x12\x18\n" +
 	"\aenviron\x18\x04 \x03(\tR\aenviron\x12\x19\n" +
-	"\btemp_dir\x18\x05 \x01(\tR\atempDir\"Z\n" +
+	"\btemp_dir\x18\x05 \x01(\tR\atempDir\x12!\n" +
+	"\fdb_isolation\x18\x06 \x01(\tR\vdbIsolation\"Z\n" +
 	"\x10TestSpecResponse\x12\x18\n" +
 	"\acommand\x18\x01 \x01(\tR\acommand\x12\x12\n" +
 	"\x04args\x18\x02 \x03(\tR\x04args\x12\x18\n" +
@@ -4693,14 +11208,161 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\tnamespace\x18\x05 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12)\n" +
 	"\x04role\x18\x06 \x01(\x0e2\x15.encore.daemon.DBRoleR\x04roleB\f\n" +
 	"\n" +
-	"_namespace\"\xc4\x01\n" +
+	"_namespace\"\xd8\x01\n" +
 	"\x0eDBResetRequest\x12\x19\n" +
 	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12%\n" +
 	"\x0edatabase_names\x18\x02 \x03(\tR\rdatabaseNames\x12?\n" +
 	"\fcluster_type\x18\x03 \x01(\x0e2\x1c.encore.daemon.DBClusterTypeR\vclusterType\x12!\n" +
+	"\tnamespace\x18\x04 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12\x12\n" +
+	"\x04seed\x18\x05 \x01(\bR\x04seedB\f\n" +
+	"\n" +
+	"_namespace\"\xb2\x01\n" +
+	"\rDBSeedRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12%\n" +
+	"\x0edatabase_names\x18\x02 \x03(\tR\rdatabaseNames\x12!\n" +
+	"\tnamespace\x18\x03 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12\x14\n" +
+	"\x05force\x18\x04 \x01(\bR\x05force\x12\x18\n" +
+	"\aenviron\x18\x05 \x03(\tR\aenvironB\f\n" +
+	"\n" +
+	"_namespace\"a\n" +
+	"\x13DBSeedStatusRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
+	"\n" +
+	"_namespace\"\x84\x02\n" +
+	"\x14DBSeedStatusResponse\x12>\n" +
+	"\x05seeds\x18\x01 \x03(\v2(.encore.daemon.DBSeedStatusResponse.SeedR\x05seeds\x1a\xab\x01\n" +
+	"\x04Seed\x12\x1a\n" +
+	"\bdatabase\x18\x01 \x01(\tR\bdatabase\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\"\n" +
+	"\n" +
+	"applied_at\x18\x05 \x01(\tH\x00R\tappliedAt\x88\x01\x01\x12\x18\n" +
+	"\achanged\x18\x06 \x01(\bR\achangedB\r\n" +
+	"\v_applied_at\"\xa6\x01\n" +
+	"\x16DBMigrationPlanRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12%\n" +
+	"\x0edatabase_names\x18\x02 \x03(\tR\rdatabaseNames\x12\x19\n" +
+	"\benv_name\x18\x03 \x01(\tR\aenvName\x12!\n" +
 	"\tnamespace\x18\x04 \x01(\tH\x00R\tnamespace\x88\x01\x01B\f\n" +
 	"\n" +
-	"_namespace\"\xae\x04\n" +
+	"_namespace\"\xd2\x03\n" +
+	"\x17DBMigrationPlanResponse\x12M\n" +
+	"\tdatabases\x18\x01 \x03(\v2/.encore.daemon.DBMigrationPlanResponse.DatabaseR\tdatabases\x1a9\n" +
+	"\tStatement\x12\x10\n" +
+	"\x03sql\x18\x01 \x01(\tR\x03sql\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings\x1a\xa7\x01\n" +
+	"\tMigration\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x04R\x06number\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12P\n" +
+	"\n" +
+	"statements\x18\x03 \x03(\v20.encore.daemon.DBMigrationPlanResponse.StatementR\n" +
+	"statements\x12\x14\n" +
+	"\x05dirty\x18\x04 \x01(\bR\x05dirty\x1a\x82\x01\n" +
+	"\bDatabase\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06exists\x18\x02 \x01(\bR\x06exists\x12J\n" +
+	"\apending\x18\x03 \x03(\v20.encore.daemon.DBMigrationPlanResponse.MigrationR\apending\"\xcd\x02\n" +
+	"\x11DBRollbackRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12#\n" +
+	"\rdatabase_name\x18\x02 \x01(\tR\fdatabaseName\x12\x14\n" +
+	"\x05steps\x18\x03 \x01(\x05R\x05steps\x12\"\n" +
+	"\n" +
+	"to_version\x18\x04 \x01(\x04H\x00R\ttoVersion\x88\x01\x01\x12\x19\n" +
+	"\benv_name\x18\x05 \x01(\tR\aenvName\x12!\n" +
+	"\tnamespace\x18\x06 \x01(\tH\x01R\tnamespace\x88\x01\x01\x12\x17\n" +
+	"\adry_run\x18\a \x01(\bR\x06dryRun\x12\x1f\n" +
+	"\vconfirm_env\x18\b \x01(\tR\n" +
+	"confirmEnv\x12)\n" +
+	"\x10allow_production\x18\t \x01(\bR\x0fallowProductionB\r\n" +
+	"\v_to_versionB\f\n" +
+	"\n" +
+	"_namespace\"\xbc\x01\n" +
+	"\rDBDiffRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12#\n" +
+	"\rdatabase_name\x18\x02 \x01(\tR\fdatabaseName\x12\x19\n" +
+	"\benv_name\x18\x03 \x01(\tR\aenvName\x12!\n" +
+	"\tnamespace\x18\x04 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12\x1f\n" +
+	"\vagainst_env\x18\x05 \x01(\tR\n" +
+	"againstEnvB\f\n" +
+	"\n" +
+	"_namespace\"\x96\x02\n" +
+	"\x0eDBDiffResponse\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12G\n" +
+	"\n" +
+	"statements\x18\x03 \x03(\v2'.encore.daemon.DBDiffResponse.StatementR\n" +
+	"statements\x12\x14\n" +
+	"\x05notes\x18\x04 \x03(\tR\x05notes\x12#\n" +
+	"\rmigration_dir\x18\x05 \x01(\tR\fmigrationDir\x12!\n" +
+	"\fnext_version\x18\x06 \x01(\x04R\vnextVersion\x1a9\n" +
+	"\tStatement\x12\x10\n" +
+	"\x03sql\x18\x01 \x01(\tR\x03sql\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings\"\xa0\x01\n" +
+	"\x17DBSnapshotCreateRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12%\n" +
+	"\x0edatabase_names\x18\x03 \x03(\tR\rdatabaseNames\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04pathB\f\n" +
+	"\n" +
+	"_namespace\"\xa1\x01\n" +
+	"\x18DBSnapshotRestoreRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12!\n" +
+	"\tnamespace\x18\x02 \x01(\tH\x00R\tnamespace\x88\x01\x01\x12%\n" +
+	"\x0edatabase_names\x18\x03 \x03(\tR\rdatabaseNames\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04pathB\f\n" +
+	"\n" +
+	"_namespace\"\xa0\x01\n" +
+	"\x12DBSnapshotResponse\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12\x1c\n" +
+	"\tdatabases\x18\x04 \x03(\tR\tdatabases\x12\x18\n" +
+	"\askipped\x18\x05 \x03(\tR\askipped\"q\n" +
+	"\x11DBQueryLogRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1e\n" +
+	"\btrace_id\x18\x02 \x01(\tH\x00R\atraceId\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limitB\v\n" +
+	"\t_trace_id\"\xde\x02\n" +
+	"\x12DBQueryLogResponse\x12A\n" +
+	"\aqueries\x18\x01 \x03(\v2'.encore.daemon.DBQueryLogResponse.QueryR\aqueries\x1a\x84\x02\n" +
+	"\x05Query\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\tR\x04time\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12%\n" +
+	"\x0eduration_nanos\x18\x04 \x01(\x03R\rdurationNanos\x12\x12\n" +
+	"\x04rows\x18\x05 \x01(\x03R\x04rows\x12\x19\n" +
+	"\x05error\x18\x06 \x01(\tH\x00R\x05error\x88\x01\x01\x12\x1e\n" +
+	"\btrace_id\x18\a \x01(\tH\x01R\atraceId\x88\x01\x01\x12\x1c\n" +
+	"\aspan_id\x18\b \x01(\tH\x02R\x06spanId\x88\x01\x01B\b\n" +
+	"\x06_errorB\v\n" +
+	"\t_trace_idB\n" +
+	"\n" +
+	"\b_span_id\"\xdf\x01\n" +
+	"\x12DBCDCConfigRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12@\n" +
+	"\x06format\x18\x02 \x01(\x0e2(.encore.daemon.DBCDCConfigRequest.FormatR\x06format\x12%\n" +
+	"\x0edatabase_names\x18\x03 \x03(\tR\rdatabaseNames\"E\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fFORMAT_DEBEZIUM\x10\x01\x12\x0e\n" +
+	"\n" +
+	"FORMAT_SQL\x10\x02\"\x8a\x01\n" +
+	"\x13DBCDCConfigResponse\x12=\n" +
+	"\x05files\x18\x01 \x03(\v2'.encore.daemon.DBCDCConfigResponse.FileR\x05files\x1a4\n" +
+	"\x04File\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"y\n" +
+	"\x12DBCDCStreamRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12#\n" +
+	"\rdatabase_name\x18\x02 \x01(\tR\fdatabaseName\x12\x19\n" +
+	"\x05topic\x18\x03 \x01(\tH\x00R\x05topic\x88\x01\x01B\b\n" +
+	"\x06_topic\"J\n" +
+	"\x11AttachLogsRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1a\n" +
+	"\bservices\x18\x02 \x03(\tR\bservices\"\x8e\x05\n" +
 	"\x10GenClientRequest\x12\x15\n" +
 	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x19\n" +
 	"\benv_name\x18\x02 \x01(\tR\aenvName\x12\x12\n" +
@@ -4714,10 +11376,15 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x0fts_shared_types\x18\n" +
 	" \x01(\bH\x01R\rtsSharedTypes\x88\x01\x01\x12-\n" +
 	"\x10ts_client_target\x18\v \x01(\tH\x02R\x0etsClientTarget\x88\x01\x01\x12\x19\n" +
-	"\bapp_root\x18\f \x01(\tR\aappRootB$\n" +
+	"\bapp_root\x18\f \x01(\tR\aappRoot\x12\x1e\n" +
+	"\bts_hooks\x18\r \x01(\tH\x03R\atsHooks\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"ts_schemas\x18\x0e \x01(\tH\x04R\ttsSchemas\x88\x01\x01B$\n" +
 	"\"_openapi_exclude_private_endpointsB\x12\n" +
 	"\x10_ts_shared_typesB\x13\n" +
-	"\x11_ts_client_target\"'\n" +
+	"\x11_ts_client_targetB\v\n" +
+	"\t_ts_hooksB\r\n" +
+	"\v_ts_schemas\"'\n" +
 	"\x11GenClientResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\fR\x04code\"/\n" +
 	"\x12GenWrappersRequest\x12\x19\n" +
@@ -4773,7 +11440,228 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\vFORMAT_JSON\x10\x01\x12\x10\n" +
 	"\fFORMAT_PROTO\x10\x02\"&\n" +
 	"\x10DumpMetaResponse\x12\x12\n" +
-	"\x04meta\x18\x01 \x01(\fR\x04meta\"\xcb\x15\n" +
+	"\x04meta\x18\x01 \x01(\fR\x04meta\"]\n" +
+	"\x13DebugBundlesRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1e\n" +
+	"\btrace_id\x18\x02 \x01(\tH\x00R\atraceId\x88\x01\x01B\v\n" +
+	"\t_trace_id\"L\n" +
+	"\x14DebugBundlesResponse\x124\n" +
+	"\abundles\x18\x01 \x03(\v2\x1a.encore.daemon.DebugBundleR\abundles\"\xc9\x01\n" +
+	"\vDebugBundle\x12\x19\n" +
+	"\btrace_id\x18\x01 \x01(\tR\atraceId\x12\x17\n" +
+	"\aspan_id\x18\x02 \x01(\tR\x06spanId\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12\x1a\n" +
+	"\bendpoint\x18\x04 \x01(\tR\bendpoint\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x12\n" +
+	"\x04time\x18\a \x01(\tR\x04time\x12\x12\n" +
+	"\x04data\x18\b \x01(\fR\x04data\"\xac\x01\n" +
+	"\x12AddLogpointRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12\x14\n" +
+	"\x05exprs\x18\x04 \x03(\tR\x05exprs\x12!\n" +
+	"\tcondition\x18\x05 \x01(\tH\x00R\tcondition\x88\x01\x01B\f\n" +
+	"\n" +
+	"_condition\"\x97\x01\n" +
+	"\bLogpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12\x14\n" +
+	"\x05exprs\x18\x04 \x03(\tR\x05exprs\x12!\n" +
+	"\tcondition\x18\x05 \x01(\tH\x00R\tcondition\x88\x01\x01B\f\n" +
+	"\n" +
+	"_condition\"1\n" +
+	"\x14ListLogpointsRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\"N\n" +
+	"\x15ListLogpointsResponse\x125\n" +
+	"\tlogpoints\x18\x01 \x03(\v2\x17.encore.daemon.LogpointR\tlogpoints\"B\n" +
+	"\x15RemoveLogpointRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"w\n" +
+	"\x14GoroutineDumpRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1a\n" +
+	"\bservices\x18\x02 \x03(\tR\bservices\x12(\n" +
+	"\x10min_wait_minutes\x18\x03 \x01(\x05R\x0eminWaitMinutes\"Z\n" +
+	"\x15GoroutineDumpResponse\x12A\n" +
+	"\tprocesses\x18\x01 \x03(\v2#.encore.daemon.ProcessGoroutineDumpR\tprocesses\"\xb7\x01\n" +
+	"\x14ProcessGoroutineDump\x12\x1a\n" +
+	"\bservices\x18\x01 \x03(\tR\bservices\x12\x10\n" +
+	"\x03pid\x18\x02 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04dump\x18\x03 \x01(\tR\x04dump\x12\x16\n" +
+	"\x06report\x18\x04 \x01(\tR\x06report\x12/\n" +
+	"\x13suspected_deadlocks\x18\x05 \x01(\x05R\x12suspectedDeadlocks\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"f\n" +
+	"\x16RetentionDryRunRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\"\n" +
+	"\n" +
+	"subject_id\x18\x02 \x01(\tH\x00R\tsubjectId\x88\x01\x01B\r\n" +
+	"\v_subject_id\"\x9e\x01\n" +
+	"\x17RetentionDryRunResponse\x12@\n" +
+	"\bpolicies\x18\x01 \x03(\v2$.encore.daemon.RetentionPolicyDryRunR\bpolicies\x12A\n" +
+	"\bsubjects\x18\x02 \x03(\v2%.encore.daemon.RetentionSubjectDryRunR\bsubjects\"\x93\x01\n" +
+	"\x15RetentionPolicyDryRun\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05table\x18\x02 \x01(\tR\x05table\x12\x17\n" +
+	"\amax_age\x18\x03 \x01(\tR\x06maxAge\x12!\n" +
+	"\fwould_delete\x18\x04 \x01(\x03R\vwouldDelete\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"k\n" +
+	"\x16RetentionSubjectDryRun\x12\x18\n" +
+	"\ahandler\x18\x01 \x01(\tR\ahandler\x12!\n" +
+	"\fwould_delete\x18\x02 \x01(\x03R\vwouldDelete\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"1\n" +
+	"\x14StartupReportRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\"\x88\x01\n" +
+	"\x15StartupReportResponse\x12,\n" +
+	"\x12migrations_wait_ns\x18\x01 \x01(\x03R\x10migrationsWaitNs\x12A\n" +
+	"\tprocesses\x18\x02 \x03(\v2#.encore.daemon.ProcessStartupReportR\tprocesses\"\xb6\x03\n" +
+	"\x14ProcessStartupReport\x12\x1a\n" +
+	"\bservices\x18\x01 \x03(\tR\bservices\x12\x10\n" +
+	"\x03pid\x18\x02 \x01(\x05R\x03pid\x12\x1a\n" +
+	"\bcomplete\x18\x03 \x01(\bR\bcomplete\x12\x19\n" +
+	"\btotal_ns\x18\x04 \x01(\x03R\atotalNs\x12&\n" +
+	"\x0fpackage_init_ns\x18\x05 \x01(\x03R\rpackageInitNs\x12*\n" +
+	"\x11resource_setup_ns\x18\x06 \x01(\x03R\x0fresourceSetupNs\x12(\n" +
+	"\x10runtime_setup_ns\x18\a \x01(\x03R\x0eruntimeSetupNs\x12&\n" +
+	"\x0fservice_init_ns\x18\b \x01(\x03R\rserviceInitNs\x12:\n" +
+	"\tresources\x18\t \x03(\v2\x1c.encore.daemon.StartupTimingR\tresources\x12A\n" +
+	"\rservices_init\x18\n" +
+	" \x03(\v2\x1c.encore.daemon.StartupTimingR\fservicesInit\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\"X\n" +
+	"\rStartupTiming\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vduration_ns\x18\x03 \x01(\x03R\n" +
+	"durationNs\"w\n" +
+	"\x0fVulnScanRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12/\n" +
+	"\x13include_unreachable\x18\x02 \x01(\bR\x12includeUnreachable\x12\x18\n" +
+	"\arefresh\x18\x03 \x01(\bR\arefresh\"\xb5\x01\n" +
+	"\x10VulnScanResponse\x12\x18\n" +
+	"\ascanner\x18\x01 \x01(\tR\ascanner\x12\x1d\n" +
+	"\n" +
+	"scanned_at\x18\x02 \x01(\tR\tscannedAt\x12F\n" +
+	"\x0fvulnerabilities\x18\x03 \x03(\v2\x1c.encore.daemon.VulnerabilityR\x0fvulnerabilities\x12 \n" +
+	"\vunreachable\x18\x04 \x01(\x05R\vunreachable\"\xb6\x03\n" +
+	"\rVulnerability\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aaliases\x18\x02 \x03(\tR\aaliases\x12\x18\n" +
+	"\asummary\x18\x03 \x01(\tR\asummary\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x16\n" +
+	"\x06module\x18\x05 \x01(\tR\x06module\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\tR\aversion\x12#\n" +
+	"\rfixed_version\x18\a \x01(\tR\ffixedVersion\x12\x1a\n" +
+	"\bseverity\x18\b \x01(\tR\bseverity\x12M\n" +
+	"\freachability\x18\t \x01(\x0e2).encore.daemon.Vulnerability.ReachabilityR\freachability\x12\x14\n" +
+	"\x05trace\x18\n" +
+	" \x03(\tR\x05trace\"w\n" +
+	"\fReachability\x12\x18\n" +
+	"\x14REACHABILITY_UNKNOWN\x10\x00\x12\x19\n" +
+	"\x15REACHABILITY_REQUIRED\x10\x01\x12\x19\n" +
+	"\x15REACHABILITY_IMPORTED\x10\x02\x12\x17\n" +
+	"\x13REACHABILITY_CALLED\x10\x03\"1\n" +
+	"\x14LicenseReportRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\"\xc1\x01\n" +
+	"\x15LicenseReportResponse\x12!\n" +
+	"\fgenerated_at\x18\x01 \x01(\tR\vgeneratedAt\x12D\n" +
+	"\fdependencies\x18\x02 \x03(\v2 .encore.daemon.DependencyLicenseR\fdependencies\x12?\n" +
+	"\n" +
+	"violations\x18\x03 \x03(\v2\x1f.encore.daemon.LicenseViolationR\n" +
+	"violations\"~\n" +
+	"\x11DependencyLicense\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x18\n" +
+	"\alicense\x18\x03 \x01(\tR\alicense\x12!\n" +
+	"\flicense_file\x18\x04 \x01(\tR\vlicenseFile\"l\n" +
+	"\x10LicenseViolation\x12@\n" +
+	"\n" +
+	"dependency\x18\x01 \x01(\v2 .encore.daemon.DependencyLicenseR\n" +
+	"dependency\x12\x16\n" +
+	"\x06denied\x18\x02 \x03(\tR\x06denied\"M\n" +
+	"\x16BuildProvenanceRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x18\n" +
+	"\aenviron\x18\x02 \x03(\tR\aenviron\"\xe4\x02\n" +
+	"\x17BuildProvenanceResponse\x12'\n" +
+	"\x0fencore_compiler\x18\x01 \x01(\tR\x0eencoreCompiler\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\tR\brevision\x12/\n" +
+	"\x13uncommitted_changes\x18\x03 \x01(\bR\x12uncommittedChanges\x12V\n" +
+	"\n" +
+	"toolchains\x18\x04 \x03(\v26.encore.daemon.BuildProvenanceResponse.ToolchainsEntryR\n" +
+	"toolchains\x12\x1d\n" +
+	"\n" +
+	"infra_hash\x18\x05 \x01(\tR\tinfraHash\x12\x1d\n" +
+	"\n" +
+	"build_time\x18\x06 \x01(\tR\tbuildTime\x1a=\n" +
+	"\x0fToolchainsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"w\n" +
+	"\x17AnalyzeDeadlinesRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1e\n" +
+	"\btrace_id\x18\x02 \x01(\tH\x00R\atraceId\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limitB\v\n" +
+	"\t_trace_id\"\x7f\n" +
+	"\x18AnalyzeDeadlinesResponse\x12:\n" +
+	"\bfindings\x18\x01 \x03(\v2\x1e.encore.daemon.DeadlineFindingR\bfindings\x12'\n" +
+	"\x0ftraces_analyzed\x18\x02 \x01(\x05R\x0etracesAnalyzed\"\xa7\x05\n" +
+	"\x0fDeadlineFinding\x12:\n" +
+	"\x05issue\x18\x01 \x01(\x0e2$.encore.daemon.DeadlineFinding.IssueR\x05issue\x12\x19\n" +
+	"\btrace_id\x18\x02 \x01(\tR\atraceId\x12\x17\n" +
+	"\aspan_id\x18\x03 \x01(\tR\x06spanId\x12)\n" +
+	"\x0eparent_span_id\x18\x04 \x01(\tH\x00R\fparentSpanId\x88\x01\x01\x12!\n" +
+	"\fservice_name\x18\x05 \x01(\tR\vserviceName\x12#\n" +
+	"\rendpoint_name\x18\x06 \x01(\tR\fendpointName\x12%\n" +
+	"\x0eduration_nanos\x18\a \x01(\x04R\rdurationNanos\x12=\n" +
+	"\x18deadline_remaining_nanos\x18\b \x01(\x03H\x01R\x16deadlineRemainingNanos\x88\x01\x01\x12J\n" +
+	"\x1fparent_deadline_remaining_nanos\x18\t \x01(\x03H\x02R\x1cparentDeadlineRemainingNanos\x88\x01\x01\x12&\n" +
+	"\fcancel_cause\x18\n" +
+	" \x01(\tH\x03R\vcancelCause\x88\x01\x01\"r\n" +
+	"\x05Issue\x12\x15\n" +
+	"\x11ISSUE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ISSUE_DEADLINE_EXCEEDED\x10\x01\x12!\n" +
+	"\x1dISSUE_DEADLINE_NOT_PROPAGATED\x10\x02\x12\x12\n" +
+	"\x0eISSUE_CANCELED\x10\x03B\x11\n" +
+	"\x0f_parent_span_idB\x1b\n" +
+	"\x19_deadline_remaining_nanosB\"\n" +
+	" _parent_deadline_remaining_nanosB\x0f\n" +
+	"\r_cancel_cause\"\xbf\x01\n" +
+	"\x12UsageReportRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12D\n" +
+	"\bgroup_by\x18\x02 \x01(\x0e2).encore.daemon.UsageReportRequest.GroupByR\agroupBy\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"2\n" +
+	"\aGroupBy\x12\x15\n" +
+	"\x11GROUP_BY_ENDPOINT\x10\x00\x12\x10\n" +
+	"\fGROUP_BY_UID\x10\x01\"q\n" +
+	"\x13UsageReportResponse\x12'\n" +
+	"\x0ftraces_analyzed\x18\x01 \x01(\x05R\x0etracesAnalyzed\x121\n" +
+	"\x06groups\x18\x02 \x03(\v2\x19.encore.daemon.UsageGroupR\x06groups\"\xa0\x03\n" +
+	"\n" +
+	"UsageGroup\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12#\n" +
+	"\rendpoint_name\x18\x02 \x01(\tR\fendpointName\x12\x10\n" +
+	"\x03uid\x18\x03 \x01(\tR\x03uid\x12\x1a\n" +
+	"\brequests\x18\x04 \x01(\x05R\brequests\x12#\n" +
+	"\rrequest_nanos\x18\x05 \x01(\x04R\frequestNanos\x12\x1d\n" +
+	"\n" +
+	"db_queries\x18\x06 \x01(\x05R\tdbQueries\x12\x19\n" +
+	"\bdb_nanos\x18\a \x01(\x04R\adbNanos\x12\x1b\n" +
+	"\tcache_ops\x18\b \x01(\x05R\bcacheOps\x12\x1f\n" +
+	"\vcache_nanos\x18\t \x01(\x04R\n" +
+	"cacheNanos\x12\x1c\n" +
+	"\tpublishes\x18\n" +
+	" \x01(\x05R\tpublishes\x12#\n" +
+	"\rpublish_nanos\x18\v \x01(\x04R\fpublishNanos\x12\x1d\n" +
+	"\n" +
+	"http_calls\x18\f \x01(\x05R\thttpCalls\x12\x1d\n" +
+	"\n" +
+	"http_nanos\x18\r \x01(\x04R\thttpNanos\"I\n" +
+	"\x14ExportSchemasRequest\x12\x19\n" +
+	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"\x96\x01\n" +
+	"\x15ExportSchemasResponse\x12E\n" +
+	"\aschemas\x18\x01 \x03(\v2+.encore.daemon.ExportSchemasResponse.SchemaR\aschemas\x1a6\n" +
+	"\x06Schema\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"\xcb\x15\n" +
 	"\n" +
 	"SQLCPlugin\x1a6\n" +
 	"\x04File\x12\x12\n" +
@@ -4869,7 +11757,19 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x0eplugin_options\x18\x05 \x01(\fR\x0eplugin_options\x12&\n" +
 	"\x0eglobal_options\x18\x06 \x01(\fR\x0eglobal_options\x1aH\n" +
 	"\x10GenerateResponse\x124\n" +
-	"\x05files\x18\x01 \x03(\v2\x1e.encore.daemon.SQLCPlugin.FileR\x05files*p\n" +
+	"\x05files\x18\x01 \x03(\v2\x1e.encore.daemon.SQLCPlugin.FileR\x05files*M\n" +
+	"\x11OutputCompression\x12\x1b\n" +
+	"\x17OUTPUT_COMPRESSION_NONE\x10\x00\x12\x1b\n" +
+	"\x17OUTPUT_COMPRESSION_GZIP\x10\x01*\x88\x02\n" +
+	"\fExitCategory\x12\x1d\n" +
+	"\x19EXIT_CATEGORY_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aEXIT_CATEGORY_TEST_FAILURE\x10\x01\x12\x17\n" +
+	"\x13EXIT_CATEGORY_BUILD\x10\x02\x12\x17\n" +
+	"\x13EXIT_CATEGORY_INFRA\x10\x03\x12\x1f\n" +
+	"\x1bEXIT_CATEGORY_PORT_CONFLICT\x10\x04\x12\x1f\n" +
+	"\x1bEXIT_CATEGORY_AUTH_REQUIRED\x10\x05\x12\"\n" +
+	"\x1eEXIT_CATEGORY_VERSION_MISMATCH\x10\x06\x12!\n" +
+	"\x1dEXIT_CATEGORY_ALREADY_RUNNING\x10\a*p\n" +
 	"\x06DBRole\x12\x17\n" +
 	"\x13DB_ROLE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11DB_ROLE_SUPERUSER\x10\x01\x12\x11\n" +
@@ -4880,9 +11780,16 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x1bDB_CLUSTER_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13DB_CLUSTER_TYPE_RUN\x10\x01\x12\x18\n" +
 	"\x14DB_CLUSTER_TYPE_TEST\x10\x02\x12\x1a\n" +
-	"\x16DB_CLUSTER_TYPE_SHADOW\x10\x032\xc0\r\n" +
+	"\x16DB_CLUSTER_TYPE_SHADOW\x10\x032\xfa\"\n" +
 	"\x06Daemon\x12A\n" +
-	"\x03Run\x12\x19.encore.daemon.RunRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12I\n" +
+	"\x03Run\x12\x19.encore.daemon.RunRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12K\n" +
+	"\bListRuns\x12\x1e.encore.daemon.ListRunsRequest\x1a\x1f.encore.daemon.ListRunsResponse\x12M\n" +
+	"\tAttachRun\x12\x1f.encore.daemon.AttachRunRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12@\n" +
+	"\aStopRun\x12\x1d.encore.daemon.StopRunRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\n" +
+	"ControlRun\x12 .encore.daemon.ControlRunRequest\x1a\x16.google.protobuf.Empty\x12`\n" +
+	"\x0fListRunSessions\x12%.encore.daemon.ListRunSessionsRequest\x1a&.encore.daemon.ListRunSessionsResponse\x12Z\n" +
+	"\rGetRunSession\x12#.encore.daemon.GetRunSessionRequest\x1a$.encore.daemon.GetRunSessionResponse\x12I\n" +
 	"\aRunSpec\x12\x1d.encore.daemon.RunSpecRequest\x1a\x1d.encore.daemon.RunSpecMessage0\x01\x12C\n" +
 	"\x04Test\x12\x1a.encore.daemon.TestRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12K\n" +
 	"\bTestSpec\x12\x1e.encore.daemon.TestSpecRequest\x1a\x1f.encore.daemon.TestSpecResponse\x12O\n" +
@@ -4893,7 +11800,17 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x06Export\x12\x1c.encore.daemon.ExportRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12N\n" +
 	"\tDBConnect\x12\x1f.encore.daemon.DBConnectRequest\x1a .encore.daemon.DBConnectResponse\x12I\n" +
 	"\aDBProxy\x12\x1d.encore.daemon.DBProxyRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12I\n" +
-	"\aDBReset\x12\x1d.encore.daemon.DBResetRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12N\n" +
+	"\aDBReset\x12\x1d.encore.daemon.DBResetRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12G\n" +
+	"\x06DBSeed\x12\x1c.encore.daemon.DBSeedRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12W\n" +
+	"\fDBSeedStatus\x12\".encore.daemon.DBSeedStatusRequest\x1a#.encore.daemon.DBSeedStatusResponse\x12`\n" +
+	"\x0fDBMigrationPlan\x12%.encore.daemon.DBMigrationPlanRequest\x1a&.encore.daemon.DBMigrationPlanResponse\x12O\n" +
+	"\n" +
+	"DBRollback\x12 .encore.daemon.DBRollbackRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12E\n" +
+	"\x06DBDiff\x12\x1c.encore.daemon.DBDiffRequest\x1a\x1d.encore.daemon.DBDiffResponse\x12]\n" +
+	"\x10DBSnapshotCreate\x12&.encore.daemon.DBSnapshotCreateRequest\x1a!.encore.daemon.DBSnapshotResponse\x12_\n" +
+	"\x11DBSnapshotRestore\x12'.encore.daemon.DBSnapshotRestoreRequest\x1a!.encore.daemon.DBSnapshotResponse\x12Q\n" +
+	"\n" +
+	"DBQueryLog\x12 .encore.daemon.DBQueryLogRequest\x1a!.encore.daemon.DBQueryLogResponse\x12N\n" +
 	"\tGenClient\x12\x1f.encore.daemon.GenClientRequest\x1a .encore.daemon.GenClientResponse\x12T\n" +
 	"\vGenWrappers\x12!.encore.daemon.GenWrappersRequest\x1a\".encore.daemon.GenWrappersResponse\x12]\n" +
 	"\x0eSecretsRefresh\x12$.encore.daemon.SecretsRefreshRequest\x1a%.encore.daemon.SecretsRefreshResponse\x12A\n" +
@@ -4904,7 +11821,26 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x0fDeleteNamespace\x12%.encore.daemon.DeleteNamespaceRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
 	"\bDumpMeta\x12\x1e.encore.daemon.DumpMetaRequest\x1a\x1f.encore.daemon.DumpMetaResponse\x12C\n" +
 	"\tTelemetry\x12\x1e.encore.daemon.TelemetryConfig\x1a\x16.google.protobuf.Empty\x12N\n" +
-	"\tCreateApp\x12\x1f.encore.daemon.CreateAppRequest\x1a .encore.daemon.CreateAppResponseB\x1eZ\x1cencr.dev/proto/encore/daemonb\x06proto3"
+	"\tCreateApp\x12\x1f.encore.daemon.CreateAppRequest\x1a .encore.daemon.CreateAppResponse\x12c\n" +
+	"\x10AnalyzeDeadlines\x12&.encore.daemon.AnalyzeDeadlinesRequest\x1a'.encore.daemon.AnalyzeDeadlinesResponse\x12T\n" +
+	"\vUsageReport\x12!.encore.daemon.UsageReportRequest\x1a\".encore.daemon.UsageReportResponse\x12W\n" +
+	"\fDebugBundles\x12\".encore.daemon.DebugBundlesRequest\x1a#.encore.daemon.DebugBundlesResponse\x12I\n" +
+	"\vAddLogpoint\x12!.encore.daemon.AddLogpointRequest\x1a\x17.encore.daemon.Logpoint\x12Z\n" +
+	"\rListLogpoints\x12#.encore.daemon.ListLogpointsRequest\x1a$.encore.daemon.ListLogpointsResponse\x12N\n" +
+	"\x0eRemoveLogpoint\x12$.encore.daemon.RemoveLogpointRequest\x1a\x16.google.protobuf.Empty\x12Z\n" +
+	"\rGoroutineDump\x12#.encore.daemon.GoroutineDumpRequest\x1a$.encore.daemon.GoroutineDumpResponse\x12`\n" +
+	"\x0fRetentionDryRun\x12%.encore.daemon.RetentionDryRunRequest\x1a&.encore.daemon.RetentionDryRunResponse\x12Z\n" +
+	"\rStartupReport\x12#.encore.daemon.StartupReportRequest\x1a$.encore.daemon.StartupReportResponse\x12K\n" +
+	"\bVulnScan\x12\x1e.encore.daemon.VulnScanRequest\x1a\x1f.encore.daemon.VulnScanResponse\x12Z\n" +
+	"\rLicenseReport\x12#.encore.daemon.LicenseReportRequest\x1a$.encore.daemon.LicenseReportResponse\x12`\n" +
+	"\x0fBuildProvenance\x12%.encore.daemon.BuildProvenanceRequest\x1a&.encore.daemon.BuildProvenanceResponse\x12Z\n" +
+	"\rExportSchemas\x12#.encore.daemon.ExportSchemasRequest\x1a$.encore.daemon.ExportSchemasResponse\x12T\n" +
+	"\vDBCDCConfig\x12!.encore.daemon.DBCDCConfigRequest\x1a\".encore.daemon.DBCDCConfigResponse\x12Q\n" +
+	"\vDBCDCStream\x12!.encore.daemon.DBCDCStreamRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12O\n" +
+	"\n" +
+	"AttachLogs\x12 .encore.daemon.AttachLogsRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12S\n" +
+	"\fResumeStream\x12\".encore.daemon.ResumeStreamRequest\x1a\x1d.encore.daemon.CommandMessage0\x01\x12J\n" +
+	"\fCancelStream\x12\".encore.daemon.CancelStreamRequest\x1a\x16.google.protobuf.EmptyB\x1eZ\x1cencr.dev/proto/encore/daemonb\x06proto3"
 
 var (
 	file_encore_daemon_daemon_proto_rawDescOnce sync.Once
@@ -4918,166 +11854,371 @@ func file_encore_daemon_daemon_proto_rawDescGZIP() []byte {
 	return file_encore_daemon_daemon_proto_rawDescData
 }
 
-var file_encore_daemon_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_encore_daemon_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
+var file_encore_daemon_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 16)
+var file_encore_daemon_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 143)
 var file_encore_daemon_daemon_proto_goTypes = []any{
-	(DBRole)(0),                         // 0: encore.daemon.DBRole
-	(DBClusterType)(0),                  // 1: encore.daemon.DBClusterType
-	(RunRequest_BrowserMode)(0),         // 2: encore.daemon.RunRequest.BrowserMode
-	(RunRequest_DebugMode)(0),           // 3: encore.daemon.RunRequest.DebugMode
-	(DumpMetaRequest_Format)(0),         // 4: encore.daemon.DumpMetaRequest.Format
-	(*CommandMessage)(nil),              // 5: encore.daemon.CommandMessage
-	(*CommandOutput)(nil),               // 6: encore.daemon.CommandOutput
-	(*CommandExit)(nil),                 // 7: encore.daemon.CommandExit
-	(*CommandDisplayErrors)(nil),        // 8: encore.daemon.CommandDisplayErrors
-	(*CreateAppRequest)(nil),            // 9: encore.daemon.CreateAppRequest
-	(*CreateAppResponse)(nil),           // 10: encore.daemon.CreateAppResponse
-	(*RunRequest)(nil),                  // 11: encore.daemon.RunRequest
-	(*RunSpecRequest)(nil),              // 12: encore.daemon.RunSpecRequest
-	(*SpecCommand)(nil),                 // 13: encore.daemon.SpecCommand
-	(*CurlCommand)(nil),                 // 14: encore.daemon.CurlCommand
-	(*RunSpecMessage)(nil),              // 15: encore.daemon.RunSpecMessage
-	(*SpecCommandResult)(nil),           // 16: encore.daemon.SpecCommandResult
-	(*SpecComplete)(nil),                // 17: encore.daemon.SpecComplete
-	(*TestRequest)(nil),                 // 18: encore.daemon.TestRequest
-	(*TestSpecRequest)(nil),             // 19: encore.daemon.TestSpecRequest
-	(*TestSpecResponse)(nil),            // 20: encore.daemon.TestSpecResponse
-	(*ExecScriptRequest)(nil),           // 21: encore.daemon.ExecScriptRequest
-	(*ExecSpecRequest)(nil),             // 22: encore.daemon.ExecSpecRequest
-	(*ExecSpecMessage)(nil),             // 23: encore.daemon.ExecSpecMessage
-	(*ExecSpecResponse)(nil),            // 24: encore.daemon.ExecSpecResponse
-	(*CheckRequest)(nil),                // 25: encore.daemon.CheckRequest
-	(*ExportRequest)(nil),               // 26: encore.daemon.ExportRequest
-	(*DockerExportParams)(nil),          // 27: encore.daemon.DockerExportParams
-	(*DBConnectRequest)(nil),            // 28: encore.daemon.DBConnectRequest
-	(*DBConnectResponse)(nil),           // 29: encore.daemon.DBConnectResponse
-	(*DBProxyRequest)(nil),              // 30: encore.daemon.DBProxyRequest
-	(*DBResetRequest)(nil),              // 31: encore.daemon.DBResetRequest
-	(*GenClientRequest)(nil),            // 32: encore.daemon.GenClientRequest
-	(*GenClientResponse)(nil),           // 33: encore.daemon.GenClientResponse
-	(*GenWrappersRequest)(nil),          // 34: encore.daemon.GenWrappersRequest
-	(*GenWrappersResponse)(nil),         // 35: encore.daemon.GenWrappersResponse
-	(*SecretsRefreshRequest)(nil),       // 36: encore.daemon.SecretsRefreshRequest
-	(*SecretsRefreshResponse)(nil),      // 37: encore.daemon.SecretsRefreshResponse
-	(*VersionResponse)(nil),             // 38: encore.daemon.VersionResponse
-	(*Namespace)(nil),                   // 39: encore.daemon.Namespace
-	(*CreateNamespaceRequest)(nil),      // 40: encore.daemon.CreateNamespaceRequest
-	(*SwitchNamespaceRequest)(nil),      // 41: encore.daemon.SwitchNamespaceRequest
-	(*ListNamespacesRequest)(nil),       // 42: encore.daemon.ListNamespacesRequest
-	(*DeleteNamespaceRequest)(nil),      // 43: encore.daemon.DeleteNamespaceRequest
-	(*ListNamespacesResponse)(nil),      // 44: encore.daemon.ListNamespacesResponse
-	(*TelemetryConfig)(nil),             // 45: encore.daemon.TelemetryConfig
-	(*DumpMetaRequest)(nil),             // 46: encore.daemon.DumpMetaRequest
-	(*DumpMetaResponse)(nil),            // 47: encore.daemon.DumpMetaResponse
-	(*SQLCPlugin)(nil),                  // 48: encore.daemon.SQLCPlugin
-	(*SQLCPlugin_File)(nil),             // 49: encore.daemon.SQLCPlugin.File
-	(*SQLCPlugin_Settings)(nil),         // 50: encore.daemon.SQLCPlugin.Settings
-	(*SQLCPlugin_Codegen)(nil),          // 51: encore.daemon.SQLCPlugin.Codegen
-	(*SQLCPlugin_Catalog)(nil),          // 52: encore.daemon.SQLCPlugin.Catalog
-	(*SQLCPlugin_Schema)(nil),           // 53: encore.daemon.SQLCPlugin.Schema
-	(*SQLCPlugin_CompositeType)(nil),    // 54: encore.daemon.SQLCPlugin.CompositeType
-	(*SQLCPlugin_Enum)(nil),             // 55: encore.daemon.SQLCPlugin.Enum
-	(*SQLCPlugin_Table)(nil),            // 56: encore.daemon.SQLCPlugin.Table
-	(*SQLCPlugin_Identifier)(nil),       // 57: encore.daemon.SQLCPlugin.Identifier
-	(*SQLCPlugin_Column)(nil),           // 58: encore.daemon.SQLCPlugin.Column
-	(*SQLCPlugin_Query)(nil),            // 59: encore.daemon.SQLCPlugin.Query
-	(*SQLCPlugin_Parameter)(nil),        // 60: encore.daemon.SQLCPlugin.Parameter
-	(*SQLCPlugin_GenerateRequest)(nil),  // 61: encore.daemon.SQLCPlugin.GenerateRequest
-	(*SQLCPlugin_GenerateResponse)(nil), // 62: encore.daemon.SQLCPlugin.GenerateResponse
-	(*SQLCPlugin_Codegen_Process)(nil),  // 63: encore.daemon.SQLCPlugin.Codegen.Process
-	(*SQLCPlugin_Codegen_WASM)(nil),     // 64: encore.daemon.SQLCPlugin.Codegen.WASM
-	(*emptypb.Empty)(nil),               // 65: google.protobuf.Empty
+	(OutputCompression)(0),                    // 0: encore.daemon.OutputCompression
+	(ExitCategory)(0),                         // 1: encore.daemon.ExitCategory
+	(DBRole)(0),                               // 2: encore.daemon.DBRole
+	(DBClusterType)(0),                        // 3: encore.daemon.DBClusterType
+	(OperationEvent_State)(0),                 // 4: encore.daemon.OperationEvent.State
+	(ReloadEvent_State)(0),                    // 5: encore.daemon.ReloadEvent.State
+	(RunRequest_BrowserMode)(0),               // 6: encore.daemon.RunRequest.BrowserMode
+	(RunRequest_DebugMode)(0),                 // 7: encore.daemon.RunRequest.DebugMode
+	(RunRequest_EmulationProfile)(0),          // 8: encore.daemon.RunRequest.EmulationProfile
+	(RunRequest_IfRunning)(0),                 // 9: encore.daemon.RunRequest.IfRunning
+	(ControlRunRequest_Action)(0),             // 10: encore.daemon.ControlRunRequest.Action
+	(DBCDCConfigRequest_Format)(0),            // 11: encore.daemon.DBCDCConfigRequest.Format
+	(DumpMetaRequest_Format)(0),               // 12: encore.daemon.DumpMetaRequest.Format
+	(Vulnerability_Reachability)(0),           // 13: encore.daemon.Vulnerability.Reachability
+	(DeadlineFinding_Issue)(0),                // 14: encore.daemon.DeadlineFinding.Issue
+	(UsageReportRequest_GroupBy)(0),           // 15: encore.daemon.UsageReportRequest.GroupBy
+	(*CommandMessage)(nil),                    // 16: encore.daemon.CommandMessage
+	(*CommandEvent)(nil),                      // 17: encore.daemon.CommandEvent
+	(*ListenEvent)(nil),                       // 18: encore.daemon.ListenEvent
+	(*OperationEvent)(nil),                    // 19: encore.daemon.OperationEvent
+	(*RunStartedEvent)(nil),                   // 20: encore.daemon.RunStartedEvent
+	(*ReloadEvent)(nil),                       // 21: encore.daemon.ReloadEvent
+	(*CommandOutputBatch)(nil),                // 22: encore.daemon.CommandOutputBatch
+	(*CommandSession)(nil),                    // 23: encore.daemon.CommandSession
+	(*ResumeStreamRequest)(nil),               // 24: encore.daemon.ResumeStreamRequest
+	(*CancelStreamRequest)(nil),               // 25: encore.daemon.CancelStreamRequest
+	(*CommandOutput)(nil),                     // 26: encore.daemon.CommandOutput
+	(*CommandExit)(nil),                       // 27: encore.daemon.CommandExit
+	(*CommandDisplayErrors)(nil),              // 28: encore.daemon.CommandDisplayErrors
+	(*CreateAppRequest)(nil),                  // 29: encore.daemon.CreateAppRequest
+	(*CreateAppResponse)(nil),                 // 30: encore.daemon.CreateAppResponse
+	(*RunRequest)(nil),                        // 31: encore.daemon.RunRequest
+	(*AttachRunRequest)(nil),                  // 32: encore.daemon.AttachRunRequest
+	(*StopRunRequest)(nil),                    // 33: encore.daemon.StopRunRequest
+	(*ControlRunRequest)(nil),                 // 34: encore.daemon.ControlRunRequest
+	(*ListRunSessionsRequest)(nil),            // 35: encore.daemon.ListRunSessionsRequest
+	(*ListRunSessionsResponse)(nil),           // 36: encore.daemon.ListRunSessionsResponse
+	(*GetRunSessionRequest)(nil),              // 37: encore.daemon.GetRunSessionRequest
+	(*GetRunSessionResponse)(nil),             // 38: encore.daemon.GetRunSessionResponse
+	(*RunSession)(nil),                        // 39: encore.daemon.RunSession
+	(*RunSessionTrace)(nil),                   // 40: encore.daemon.RunSessionTrace
+	(*ListRunsRequest)(nil),                   // 41: encore.daemon.ListRunsRequest
+	(*ListRunsResponse)(nil),                  // 42: encore.daemon.ListRunsResponse
+	(*RunInfo)(nil),                           // 43: encore.daemon.RunInfo
+	(*RunSpecRequest)(nil),                    // 44: encore.daemon.RunSpecRequest
+	(*SpecCommand)(nil),                       // 45: encore.daemon.SpecCommand
+	(*CurlCommand)(nil),                       // 46: encore.daemon.CurlCommand
+	(*RunSpecMessage)(nil),                    // 47: encore.daemon.RunSpecMessage
+	(*SpecCommandResult)(nil),                 // 48: encore.daemon.SpecCommandResult
+	(*SpecComplete)(nil),                      // 49: encore.daemon.SpecComplete
+	(*TestRequest)(nil),                       // 50: encore.daemon.TestRequest
+	(*TestSpecRequest)(nil),                   // 51: encore.daemon.TestSpecRequest
+	(*TestSpecResponse)(nil),                  // 52: encore.daemon.TestSpecResponse
+	(*ExecScriptRequest)(nil),                 // 53: encore.daemon.ExecScriptRequest
+	(*ExecSpecRequest)(nil),                   // 54: encore.daemon.ExecSpecRequest
+	(*ExecSpecMessage)(nil),                   // 55: encore.daemon.ExecSpecMessage
+	(*ExecSpecResponse)(nil),                  // 56: encore.daemon.ExecSpecResponse
+	(*CheckRequest)(nil),                      // 57: encore.daemon.CheckRequest
+	(*ExportRequest)(nil),                     // 58: encore.daemon.ExportRequest
+	(*DockerExportParams)(nil),                // 59: encore.daemon.DockerExportParams
+	(*DBConnectRequest)(nil),                  // 60: encore.daemon.DBConnectRequest
+	(*DBConnectResponse)(nil),                 // 61: encore.daemon.DBConnectResponse
+	(*DBProxyRequest)(nil),                    // 62: encore.daemon.DBProxyRequest
+	(*DBResetRequest)(nil),                    // 63: encore.daemon.DBResetRequest
+	(*DBSeedRequest)(nil),                     // 64: encore.daemon.DBSeedRequest
+	(*DBSeedStatusRequest)(nil),               // 65: encore.daemon.DBSeedStatusRequest
+	(*DBSeedStatusResponse)(nil),              // 66: encore.daemon.DBSeedStatusResponse
+	(*DBMigrationPlanRequest)(nil),            // 67: encore.daemon.DBMigrationPlanRequest
+	(*DBMigrationPlanResponse)(nil),           // 68: encore.daemon.DBMigrationPlanResponse
+	(*DBRollbackRequest)(nil),                 // 69: encore.daemon.DBRollbackRequest
+	(*DBDiffRequest)(nil),                     // 70: encore.daemon.DBDiffRequest
+	(*DBDiffResponse)(nil),                    // 71: encore.daemon.DBDiffResponse
+	(*DBSnapshotCreateRequest)(nil),           // 72: encore.daemon.DBSnapshotCreateRequest
+	(*DBSnapshotRestoreRequest)(nil),          // 73: encore.daemon.DBSnapshotRestoreRequest
+	(*DBSnapshotResponse)(nil),                // 74: encore.daemon.DBSnapshotResponse
+	(*DBQueryLogRequest)(nil),                 // 75: encore.daemon.DBQueryLogRequest
+	(*DBQueryLogResponse)(nil),                // 76: encore.daemon.DBQueryLogResponse
+	(*DBCDCConfigRequest)(nil),                // 77: encore.daemon.DBCDCConfigRequest
+	(*DBCDCConfigResponse)(nil),               // 78: encore.daemon.DBCDCConfigResponse
+	(*DBCDCStreamRequest)(nil),                // 79: encore.daemon.DBCDCStreamRequest
+	(*AttachLogsRequest)(nil),                 // 80: encore.daemon.AttachLogsRequest
+	(*GenClientRequest)(nil),                  // 81: encore.daemon.GenClientRequest
+	(*GenClientResponse)(nil),                 // 82: encore.daemon.GenClientResponse
+	(*GenWrappersRequest)(nil),                // 83: encore.daemon.GenWrappersRequest
+	(*GenWrappersResponse)(nil),               // 84: encore.daemon.GenWrappersResponse
+	(*SecretsRefreshRequest)(nil),             // 85: encore.daemon.SecretsRefreshRequest
+	(*SecretsRefreshResponse)(nil),            // 86: encore.daemon.SecretsRefreshResponse
+	(*VersionResponse)(nil),                   // 87: encore.daemon.VersionResponse
+	(*Namespace)(nil),                         // 88: encore.daemon.Namespace
+	(*CreateNamespaceRequest)(nil),            // 89: encore.daemon.CreateNamespaceRequest
+	(*SwitchNamespaceRequest)(nil),            // 90: encore.daemon.SwitchNamespaceRequest
+	(*ListNamespacesRequest)(nil),             // 91: encore.daemon.ListNamespacesRequest
+	(*DeleteNamespaceRequest)(nil),            // 92: encore.daemon.DeleteNamespaceRequest
+	(*ListNamespacesResponse)(nil),            // 93: encore.daemon.ListNamespacesResponse
+	(*TelemetryConfig)(nil),                   // 94: encore.daemon.TelemetryConfig
+	(*DumpMetaRequest)(nil),                   // 95: encore.daemon.DumpMetaRequest
+	(*DumpMetaResponse)(nil),                  // 96: encore.daemon.DumpMetaResponse
+	(*DebugBundlesRequest)(nil),               // 97: encore.daemon.DebugBundlesRequest
+	(*DebugBundlesResponse)(nil),              // 98: encore.daemon.DebugBundlesResponse
+	(*DebugBundle)(nil),                       // 99: encore.daemon.DebugBundle
+	(*AddLogpointRequest)(nil),                // 100: encore.daemon.AddLogpointRequest
+	(*Logpoint)(nil),                          // 101: encore.daemon.Logpoint
+	(*ListLogpointsRequest)(nil),              // 102: encore.daemon.ListLogpointsRequest
+	(*ListLogpointsResponse)(nil),             // 103: encore.daemon.ListLogpointsResponse
+	(*RemoveLogpointRequest)(nil),             // 104: encore.daemon.RemoveLogpointRequest
+	(*GoroutineDumpRequest)(nil),              // 105: encore.daemon.GoroutineDumpRequest
+	(*GoroutineDumpResponse)(nil),             // 106: encore.daemon.GoroutineDumpResponse
+	(*ProcessGoroutineDump)(nil),              // 107: encore.daemon.ProcessGoroutineDump
+	(*RetentionDryRunRequest)(nil),            // 108: encore.daemon.RetentionDryRunRequest
+	(*RetentionDryRunResponse)(nil),           // 109: encore.daemon.RetentionDryRunResponse
+	(*RetentionPolicyDryRun)(nil),             // 110: encore.daemon.RetentionPolicyDryRun
+	(*RetentionSubjectDryRun)(nil),            // 111: encore.daemon.RetentionSubjectDryRun
+	(*StartupReportRequest)(nil),              // 112: encore.daemon.StartupReportRequest
+	(*StartupReportResponse)(nil),             // 113: encore.daemon.StartupReportResponse
+	(*ProcessStartupReport)(nil),              // 114: encore.daemon.ProcessStartupReport
+	(*StartupTiming)(nil),                     // 115: encore.daemon.StartupTiming
+	(*VulnScanRequest)(nil),                   // 116: encore.daemon.VulnScanRequest
+	(*VulnScanResponse)(nil),                  // 117: encore.daemon.VulnScanResponse
+	(*Vulnerability)(nil),                     // 118: encore.daemon.Vulnerability
+	(*LicenseReportRequest)(nil),              // 119: encore.daemon.LicenseReportRequest
+	(*LicenseReportResponse)(nil),             // 120: encore.daemon.LicenseReportResponse
+	(*DependencyLicense)(nil),                 // 121: encore.daemon.DependencyLicense
+	(*LicenseViolation)(nil),                  // 122: encore.daemon.LicenseViolation
+	(*BuildProvenanceRequest)(nil),            // 123: encore.daemon.BuildProvenanceRequest
+	(*BuildProvenanceResponse)(nil),           // 124: encore.daemon.BuildProvenanceResponse
+	(*AnalyzeDeadlinesRequest)(nil),           // 125: encore.daemon.AnalyzeDeadlinesRequest
+	(*AnalyzeDeadlinesResponse)(nil),          // 126: encore.daemon.AnalyzeDeadlinesResponse
+	(*DeadlineFinding)(nil),                   // 127: encore.daemon.DeadlineFinding
+	(*UsageReportRequest)(nil),                // 128: encore.daemon.UsageReportRequest
+	(*UsageReportResponse)(nil),               // 129: encore.daemon.UsageReportResponse
+	(*UsageGroup)(nil),                        // 130: encore.daemon.UsageGroup
+	(*ExportSchemasRequest)(nil),              // 131: encore.daemon.ExportSchemasRequest
+	(*ExportSchemasResponse)(nil),             // 132: encore.daemon.ExportSchemasResponse
+	(*SQLCPlugin)(nil),                        // 133: encore.daemon.SQLCPlugin
+	(*DBSeedStatusResponse_Seed)(nil),         // 134: encore.daemon.DBSeedStatusResponse.Seed
+	(*DBMigrationPlanResponse_Statement)(nil), // 135: encore.daemon.DBMigrationPlanResponse.Statement
+	(*DBMigrationPlanResponse_Migration)(nil), // 136: encore.daemon.DBMigrationPlanResponse.Migration
+	(*DBMigrationPlanResponse_Database)(nil),  // 137: encore.daemon.DBMigrationPlanResponse.Database
+	(*DBDiffResponse_Statement)(nil),          // 138: encore.daemon.DBDiffResponse.Statement
+	(*DBQueryLogResponse_Query)(nil),          // 139: encore.daemon.DBQueryLogResponse.Query
+	(*DBCDCConfigResponse_File)(nil),          // 140: encore.daemon.DBCDCConfigResponse.File
+	nil,                                       // 141: encore.daemon.BuildProvenanceResponse.ToolchainsEntry
+	(*ExportSchemasResponse_Schema)(nil),      // 142: encore.daemon.ExportSchemasResponse.Schema
+	(*SQLCPlugin_File)(nil),                   // 143: encore.daemon.SQLCPlugin.File
+	(*SQLCPlugin_Settings)(nil),               // 144: encore.daemon.SQLCPlugin.Settings
+	(*SQLCPlugin_Codegen)(nil),                // 145: encore.daemon.SQLCPlugin.Codegen
+	(*SQLCPlugin_Catalog)(nil),                // 146: encore.daemon.SQLCPlugin.Catalog
+	(*SQLCPlugin_Schema)(nil),                 // 147: encore.daemon.SQLCPlugin.Schema
+	(*SQLCPlugin_CompositeType)(nil),          // 148: encore.daemon.SQLCPlugin.CompositeType
+	(*SQLCPlugin_Enum)(nil),                   // 149: encore.daemon.SQLCPlugin.Enum
+	(*SQLCPlugin_Table)(nil),                  // 150: encore.daemon.SQLCPlugin.Table
+	(*SQLCPlugin_Identifier)(nil),             // 151: encore.daemon.SQLCPlugin.Identifier
+	(*SQLCPlugin_Column)(nil),                 // 152: encore.daemon.SQLCPlugin.Column
+	(*SQLCPlugin_Query)(nil),                  // 153: encore.daemon.SQLCPlugin.Query
+	(*SQLCPlugin_Parameter)(nil),              // 154: encore.daemon.SQLCPlugin.Parameter
+	(*SQLCPlugin_GenerateRequest)(nil),        // 155: encore.daemon.SQLCPlugin.GenerateRequest
+	(*SQLCPlugin_GenerateResponse)(nil),       // 156: encore.daemon.SQLCPlugin.GenerateResponse
+	(*SQLCPlugin_Codegen_Process)(nil),        // 157: encore.daemon.SQLCPlugin.Codegen.Process
+	(*SQLCPlugin_Codegen_WASM)(nil),           // 158: encore.daemon.SQLCPlugin.Codegen.WASM
+	(*emptypb.Empty)(nil),                     // 159: google.protobuf.Empty
 }
 var file_encore_daemon_daemon_proto_depIdxs = []int32{
-	6,  // 0: encore.daemon.CommandMessage.output:type_name -> encore.daemon.CommandOutput
-	7,  // 1: encore.daemon.CommandMessage.exit:type_name -> encore.daemon.CommandExit
-	8,  // 2: encore.daemon.CommandMessage.errors:type_name -> encore.daemon.CommandDisplayErrors
-	2,  // 3: encore.daemon.RunRequest.browser:type_name -> encore.daemon.RunRequest.BrowserMode
-	3,  // 4: encore.daemon.RunRequest.debug_mode:type_name -> encore.daemon.RunRequest.DebugMode
-	13, // 5: encore.daemon.RunSpecRequest.commands:type_name -> encore.daemon.SpecCommand
-	14, // 6: encore.daemon.SpecCommand.curl:type_name -> encore.daemon.CurlCommand
-	6,  // 7: encore.daemon.RunSpecMessage.output:type_name -> encore.daemon.CommandOutput
-	16, // 8: encore.daemon.RunSpecMessage.result:type_name -> encore.daemon.SpecCommandResult
-	17, // 9: encore.daemon.RunSpecMessage.complete:type_name -> encore.daemon.SpecComplete
-	6,  // 10: encore.daemon.ExecSpecMessage.output:type_name -> encore.daemon.CommandOutput
-	24, // 11: encore.daemon.ExecSpecMessage.spec:type_name -> encore.daemon.ExecSpecResponse
-	27, // 12: encore.daemon.ExportRequest.docker:type_name -> encore.daemon.DockerExportParams
-	1,  // 13: encore.daemon.DBConnectRequest.cluster_type:type_name -> encore.daemon.DBClusterType
-	0,  // 14: encore.daemon.DBConnectRequest.role:type_name -> encore.daemon.DBRole
-	1,  // 15: encore.daemon.DBProxyRequest.cluster_type:type_name -> encore.daemon.DBClusterType
-	0,  // 16: encore.daemon.DBProxyRequest.role:type_name -> encore.daemon.DBRole
-	1,  // 17: encore.daemon.DBResetRequest.cluster_type:type_name -> encore.daemon.DBClusterType
-	39, // 18: encore.daemon.ListNamespacesResponse.namespaces:type_name -> encore.daemon.Namespace
-	4,  // 19: encore.daemon.DumpMetaRequest.format:type_name -> encore.daemon.DumpMetaRequest.Format
-	51, // 20: encore.daemon.SQLCPlugin.Settings.codegen:type_name -> encore.daemon.SQLCPlugin.Codegen
-	63, // 21: encore.daemon.SQLCPlugin.Codegen.process:type_name -> encore.daemon.SQLCPlugin.Codegen.Process
-	64, // 22: encore.daemon.SQLCPlugin.Codegen.wasm:type_name -> encore.daemon.SQLCPlugin.Codegen.WASM
-	53, // 23: encore.daemon.SQLCPlugin.Catalog.schemas:type_name -> encore.daemon.SQLCPlugin.Schema
-	56, // 24: encore.daemon.SQLCPlugin.Schema.tables:type_name -> encore.daemon.SQLCPlugin.Table
-	55, // 25: encore.daemon.SQLCPlugin.Schema.enums:type_name -> encore.daemon.SQLCPlugin.Enum
-	54, // 26: encore.daemon.SQLCPlugin.Schema.composite_types:type_name -> encore.daemon.SQLCPlugin.CompositeType
-	57, // 27: encore.daemon.SQLCPlugin.Table.rel:type_name -> encore.daemon.SQLCPlugin.Identifier
-	58, // 28: encore.daemon.SQLCPlugin.Table.columns:type_name -> encore.daemon.SQLCPlugin.Column
-	57, // 29: encore.daemon.SQLCPlugin.Column.table:type_name -> encore.daemon.SQLCPlugin.Identifier
-	57, // 30: encore.daemon.SQLCPlugin.Column.type:type_name -> encore.daemon.SQLCPlugin.Identifier
-	57, // 31: encore.daemon.SQLCPlugin.Column.embed_table:type_name -> encore.daemon.SQLCPlugin.Identifier
-	58, // 32: encore.daemon.SQLCPlugin.Query.columns:type_name -> encore.daemon.SQLCPlugin.Column
-	60, // 33: encore.daemon.SQLCPlugin.Query.params:type_name -> encore.daemon.SQLCPlugin.Parameter
-	57, // 34: encore.daemon.SQLCPlugin.Query.insert_into_table:type_name -> encore.daemon.SQLCPlugin.Identifier
-	58, // 35: encore.daemon.SQLCPlugin.Parameter.column:type_name -> encore.daemon.SQLCPlugin.Column
-	50, // 36: encore.daemon.SQLCPlugin.GenerateRequest.settings:type_name -> encore.daemon.SQLCPlugin.Settings
-	52, // 37: encore.daemon.SQLCPlugin.GenerateRequest.catalog:type_name -> encore.daemon.SQLCPlugin.Catalog
-	59, // 38: encore.daemon.SQLCPlugin.GenerateRequest.queries:type_name -> encore.daemon.SQLCPlugin.Query
-	49, // 39: encore.daemon.SQLCPlugin.GenerateResponse.files:type_name -> encore.daemon.SQLCPlugin.File
-	11, // 40: encore.daemon.Daemon.Run:input_type -> encore.daemon.RunRequest
-	12, // 41: encore.daemon.Daemon.RunSpec:input_type -> encore.daemon.RunSpecRequest
-	18, // 42: encore.daemon.Daemon.Test:input_type -> encore.daemon.TestRequest
-	19, // 43: encore.daemon.Daemon.TestSpec:input_type -> encore.daemon.TestSpecRequest
-	21, // 44: encore.daemon.Daemon.ExecScript:input_type -> encore.daemon.ExecScriptRequest
-	22, // 45: encore.daemon.Daemon.ExecSpec:input_type -> encore.daemon.ExecSpecRequest
-	25, // 46: encore.daemon.Daemon.Check:input_type -> encore.daemon.CheckRequest
-	26, // 47: encore.daemon.Daemon.Export:input_type -> encore.daemon.ExportRequest
-	28, // 48: encore.daemon.Daemon.DBConnect:input_type -> encore.daemon.DBConnectRequest
-	30, // 49: encore.daemon.Daemon.DBProxy:input_type -> encore.daemon.DBProxyRequest
-	31, // 50: encore.daemon.Daemon.DBReset:input_type -> encore.daemon.DBResetRequest
-	32, // 51: encore.daemon.Daemon.GenClient:input_type -> encore.daemon.GenClientRequest
-	34, // 52: encore.daemon.Daemon.GenWrappers:input_type -> encore.daemon.GenWrappersRequest
-	36, // 53: encore.daemon.Daemon.SecretsRefresh:input_type -> encore.daemon.SecretsRefreshRequest
-	65, // 54: encore.daemon.Daemon.Version:input_type -> google.protobuf.Empty
-	40, // 55: encore.daemon.Daemon.CreateNamespace:input_type -> encore.daemon.CreateNamespaceRequest
-	41, // 56: encore.daemon.Daemon.SwitchNamespace:input_type -> encore.daemon.SwitchNamespaceRequest
-	42, // 57: encore.daemon.Daemon.ListNamespaces:input_type -> encore.daemon.ListNamespacesRequest
-	43, // 58: encore.daemon.Daemon.DeleteNamespace:input_type -> encore.daemon.DeleteNamespaceRequest
-	46, // 59: encore.daemon.Daemon.DumpMeta:input_type -> encore.daemon.DumpMetaRequest
-	45, // 60: encore.daemon.Daemon.Telemetry:input_type -> encore.daemon.TelemetryConfig
-	9,  // 61: encore.daemon.Daemon.CreateApp:input_type -> encore.daemon.CreateAppRequest
-	5,  // 62: encore.daemon.Daemon.Run:output_type -> encore.daemon.CommandMessage
-	15, // 63: encore.daemon.Daemon.RunSpec:output_type -> encore.daemon.RunSpecMessage
-	5,  // 64: encore.daemon.Daemon.Test:output_type -> encore.daemon.CommandMessage
-	20, // 65: encore.daemon.Daemon.TestSpec:output_type -> encore.daemon.TestSpecResponse
-	5,  // 66: encore.daemon.Daemon.ExecScript:output_type -> encore.daemon.CommandMessage
-	23, // 67: encore.daemon.Daemon.ExecSpec:output_type -> encore.daemon.ExecSpecMessage
-	5,  // 68: encore.daemon.Daemon.Check:output_type -> encore.daemon.CommandMessage
-	5,  // 69: encore.daemon.Daemon.Export:output_type -> encore.daemon.CommandMessage
-	29, // 70: encore.daemon.Daemon.DBConnect:output_type -> encore.daemon.DBConnectResponse
-	5,  // 71: encore.daemon.Daemon.DBProxy:output_type -> encore.daemon.CommandMessage
-	5,  // 72: encore.daemon.Daemon.DBReset:output_type -> encore.daemon.CommandMessage
-	33, // 73: encore.daemon.Daemon.GenClient:output_type -> encore.daemon.GenClientResponse
-	35, // 74: encore.daemon.Daemon.GenWrappers:output_type -> encore.daemon.GenWrappersResponse
-	37, // 75: encore.daemon.Daemon.SecretsRefresh:output_type -> encore.daemon.SecretsRefreshResponse
-	38, // 76: encore.daemon.Daemon.Version:output_type -> encore.daemon.VersionResponse
-	39, // 77: encore.daemon.Daemon.CreateNamespace:output_type -> encore.daemon.Namespace
-	39, // 78: encore.daemon.Daemon.SwitchNamespace:output_type -> encore.daemon.Namespace
-	44, // 79: encore.daemon.Daemon.ListNamespaces:output_type -> encore.daemon.ListNamespacesResponse
-	65, // 80: encore.daemon.Daemon.DeleteNamespace:output_type -> google.protobuf.Empty
-	47, // 81: encore.daemon.Daemon.DumpMeta:output_type -> encore.daemon.DumpMetaResponse
-	65, // 82: encore.daemon.Daemon.Telemetry:output_type -> google.protobuf.Empty
-	10, // 83: encore.daemon.Daemon.CreateApp:output_type -> encore.daemon.CreateAppResponse
-	62, // [62:84] is the sub-list for method output_type
-	40, // [40:62] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	26,  // 0: encore.daemon.CommandMessage.output:type_name -> encore.daemon.CommandOutput
+	27,  // 1: encore.daemon.CommandMessage.exit:type_name -> encore.daemon.CommandExit
+	28,  // 2: encore.daemon.CommandMessage.errors:type_name -> encore.daemon.CommandDisplayErrors
+	23,  // 3: encore.daemon.CommandMessage.session:type_name -> encore.daemon.CommandSession
+	22,  // 4: encore.daemon.CommandMessage.output_batch:type_name -> encore.daemon.CommandOutputBatch
+	17,  // 5: encore.daemon.CommandMessage.event:type_name -> encore.daemon.CommandEvent
+	19,  // 6: encore.daemon.CommandEvent.operation:type_name -> encore.daemon.OperationEvent
+	20,  // 7: encore.daemon.CommandEvent.run_started:type_name -> encore.daemon.RunStartedEvent
+	21,  // 8: encore.daemon.CommandEvent.reload:type_name -> encore.daemon.ReloadEvent
+	18,  // 9: encore.daemon.CommandEvent.listen:type_name -> encore.daemon.ListenEvent
+	4,   // 10: encore.daemon.OperationEvent.state:type_name -> encore.daemon.OperationEvent.State
+	5,   // 11: encore.daemon.ReloadEvent.state:type_name -> encore.daemon.ReloadEvent.State
+	26,  // 12: encore.daemon.CommandOutputBatch.frames:type_name -> encore.daemon.CommandOutput
+	0,   // 13: encore.daemon.CommandOutputBatch.compression:type_name -> encore.daemon.OutputCompression
+	1,   // 14: encore.daemon.CommandExit.category:type_name -> encore.daemon.ExitCategory
+	6,   // 15: encore.daemon.RunRequest.browser:type_name -> encore.daemon.RunRequest.BrowserMode
+	7,   // 16: encore.daemon.RunRequest.debug_mode:type_name -> encore.daemon.RunRequest.DebugMode
+	8,   // 17: encore.daemon.RunRequest.emulation:type_name -> encore.daemon.RunRequest.EmulationProfile
+	0,   // 18: encore.daemon.RunRequest.output_compression:type_name -> encore.daemon.OutputCompression
+	9,   // 19: encore.daemon.RunRequest.if_running:type_name -> encore.daemon.RunRequest.IfRunning
+	10,  // 20: encore.daemon.ControlRunRequest.action:type_name -> encore.daemon.ControlRunRequest.Action
+	39,  // 21: encore.daemon.ListRunSessionsResponse.sessions:type_name -> encore.daemon.RunSession
+	39,  // 22: encore.daemon.GetRunSessionResponse.session:type_name -> encore.daemon.RunSession
+	40,  // 23: encore.daemon.GetRunSessionResponse.traces:type_name -> encore.daemon.RunSessionTrace
+	43,  // 24: encore.daemon.ListRunsResponse.runs:type_name -> encore.daemon.RunInfo
+	45,  // 25: encore.daemon.RunSpecRequest.commands:type_name -> encore.daemon.SpecCommand
+	46,  // 26: encore.daemon.SpecCommand.curl:type_name -> encore.daemon.CurlCommand
+	26,  // 27: encore.daemon.RunSpecMessage.output:type_name -> encore.daemon.CommandOutput
+	48,  // 28: encore.daemon.RunSpecMessage.result:type_name -> encore.daemon.SpecCommandResult
+	49,  // 29: encore.daemon.RunSpecMessage.complete:type_name -> encore.daemon.SpecComplete
+	26,  // 30: encore.daemon.ExecSpecMessage.output:type_name -> encore.daemon.CommandOutput
+	56,  // 31: encore.daemon.ExecSpecMessage.spec:type_name -> encore.daemon.ExecSpecResponse
+	59,  // 32: encore.daemon.ExportRequest.docker:type_name -> encore.daemon.DockerExportParams
+	3,   // 33: encore.daemon.DBConnectRequest.cluster_type:type_name -> encore.daemon.DBClusterType
+	2,   // 34: encore.daemon.DBConnectRequest.role:type_name -> encore.daemon.DBRole
+	3,   // 35: encore.daemon.DBProxyRequest.cluster_type:type_name -> encore.daemon.DBClusterType
+	2,   // 36: encore.daemon.DBProxyRequest.role:type_name -> encore.daemon.DBRole
+	3,   // 37: encore.daemon.DBResetRequest.cluster_type:type_name -> encore.daemon.DBClusterType
+	134, // 38: encore.daemon.DBSeedStatusResponse.seeds:type_name -> encore.daemon.DBSeedStatusResponse.Seed
+	137, // 39: encore.daemon.DBMigrationPlanResponse.databases:type_name -> encore.daemon.DBMigrationPlanResponse.Database
+	138, // 40: encore.daemon.DBDiffResponse.statements:type_name -> encore.daemon.DBDiffResponse.Statement
+	139, // 41: encore.daemon.DBQueryLogResponse.queries:type_name -> encore.daemon.DBQueryLogResponse.Query
+	11,  // 42: encore.daemon.DBCDCConfigRequest.format:type_name -> encore.daemon.DBCDCConfigRequest.Format
+	140, // 43: encore.daemon.DBCDCConfigResponse.files:type_name -> encore.daemon.DBCDCConfigResponse.File
+	88,  // 44: encore.daemon.ListNamespacesResponse.namespaces:type_name -> encore.daemon.Namespace
+	12,  // 45: encore.daemon.DumpMetaRequest.format:type_name -> encore.daemon.DumpMetaRequest.Format
+	99,  // 46: encore.daemon.DebugBundlesResponse.bundles:type_name -> encore.daemon.DebugBundle
+	101, // 47: encore.daemon.ListLogpointsResponse.logpoints:type_name -> encore.daemon.Logpoint
+	107, // 48: encore.daemon.GoroutineDumpResponse.processes:type_name -> encore.daemon.ProcessGoroutineDump
+	110, // 49: encore.daemon.RetentionDryRunResponse.policies:type_name -> encore.daemon.RetentionPolicyDryRun
+	111, // 50: encore.daemon.RetentionDryRunResponse.subjects:type_name -> encore.daemon.RetentionSubjectDryRun
+	114, // 51: encore.daemon.StartupReportResponse.processes:type_name -> encore.daemon.ProcessStartupReport
+	115, // 52: encore.daemon.ProcessStartupReport.resources:type_name -> encore.daemon.StartupTiming
+	115, // 53: encore.daemon.ProcessStartupReport.services_init:type_name -> encore.daemon.StartupTiming
+	118, // 54: encore.daemon.VulnScanResponse.vulnerabilities:type_name -> encore.daemon.Vulnerability
+	13,  // 55: encore.daemon.Vulnerability.reachability:type_name -> encore.daemon.Vulnerability.Reachability
+	121, // 56: encore.daemon.LicenseReportResponse.dependencies:type_name -> encore.daemon.DependencyLicense
+	122, // 57: encore.daemon.LicenseReportResponse.violations:type_name -> encore.daemon.LicenseViolation
+	121, // 58: encore.daemon.LicenseViolation.dependency:type_name -> encore.daemon.DependencyLicense
+	141, // 59: encore.daemon.BuildProvenanceResponse.toolchains:type_name -> encore.daemon.BuildProvenanceResponse.ToolchainsEntry
+	127, // 60: encore.daemon.AnalyzeDeadlinesResponse.findings:type_name -> encore.daemon.DeadlineFinding
+	14,  // 61: encore.daemon.DeadlineFinding.issue:type_name -> encore.daemon.DeadlineFinding.Issue
+	15,  // 62: encore.daemon.UsageReportRequest.group_by:type_name -> encore.daemon.UsageReportRequest.GroupBy
+	130, // 63: encore.daemon.UsageReportResponse.groups:type_name -> encore.daemon.UsageGroup
+	142, // 64: encore.daemon.ExportSchemasResponse.schemas:type_name -> encore.daemon.ExportSchemasResponse.Schema
+	135, // 65: encore.daemon.DBMigrationPlanResponse.Migration.statements:type_name -> encore.daemon.DBMigrationPlanResponse.Statement
+	136, // 66: encore.daemon.DBMigrationPlanResponse.Database.pending:type_name -> encore.daemon.DBMigrationPlanResponse.Migration
+	145, // 67: encore.daemon.SQLCPlugin.Settings.codegen:type_name -> encore.daemon.SQLCPlugin.Codegen
+	157, // 68: encore.daemon.SQLCPlugin.Codegen.process:type_name -> encore.daemon.SQLCPlugin.Codegen.Process
+	158, // 69: encore.daemon.SQLCPlugin.Codegen.wasm:type_name -> encore.daemon.SQLCPlugin.Codegen.WASM
+	147, // 70: encore.daemon.SQLCPlugin.Catalog.schemas:type_name -> encore.daemon.SQLCPlugin.Schema
+	150, // 71: encore.daemon.SQLCPlugin.Schema.tables:type_name -> encore.daemon.SQLCPlugin.Table
+	149, // 72: encore.daemon.SQLCPlugin.Schema.enums:type_name -> encore.daemon.SQLCPlugin.Enum
+	148, // 73: encore.daemon.SQLCPlugin.Schema.composite_types:type_name -> encore.daemon.SQLCPlugin.CompositeType
+	151, // 74: encore.daemon.SQLCPlugin.Table.rel:type_name -> encore.daemon.SQLCPlugin.Identifier
+	152, // 75: encore.daemon.SQLCPlugin.Table.columns:type_name -> encore.daemon.SQLCPlugin.Column
+	151, // 76: encore.daemon.SQLCPlugin.Column.table:type_name -> encore.daemon.SQLCPlugin.Identifier
+	151, // 77: encore.daemon.SQLCPlugin.Column.type:type_name -> encore.daemon.SQLCPlugin.Identifier
+	151, // 78: encore.daemon.SQLCPlugin.Column.embed_table:type_name -> encore.daemon.SQLCPlugin.Identifier
+	152, // 79: encore.daemon.SQLCPlugin.Query.columns:type_name -> encore.daemon.SQLCPlugin.Column
+	154, // 80: encore.daemon.SQLCPlugin.Query.params:type_name -> encore.daemon.SQLCPlugin.Parameter
+	151, // 81: encore.daemon.SQLCPlugin.Query.insert_into_table:type_name -> encore.daemon.SQLCPlugin.Identifier
+	152, // 82: encore.daemon.SQLCPlugin.Parameter.column:type_name -> encore.daemon.SQLCPlugin.Column
+	144, // 83: encore.daemon.SQLCPlugin.GenerateRequest.settings:type_name -> encore.daemon.SQLCPlugin.Settings
+	146, // 84: encore.daemon.SQLCPlugin.GenerateRequest.catalog:type_name -> encore.daemon.SQLCPlugin.Catalog
+	153, // 85: encore.daemon.SQLCPlugin.GenerateRequest.queries:type_name -> encore.daemon.SQLCPlugin.Query
+	143, // 86: encore.daemon.SQLCPlugin.GenerateResponse.files:type_name -> encore.daemon.SQLCPlugin.File
+	31,  // 87: encore.daemon.Daemon.Run:input_type -> encore.daemon.RunRequest
+	41,  // 88: encore.daemon.Daemon.ListRuns:input_type -> encore.daemon.ListRunsRequest
+	32,  // 89: encore.daemon.Daemon.AttachRun:input_type -> encore.daemon.AttachRunRequest
+	33,  // 90: encore.daemon.Daemon.StopRun:input_type -> encore.daemon.StopRunRequest
+	34,  // 91: encore.daemon.Daemon.ControlRun:input_type -> encore.daemon.ControlRunRequest
+	35,  // 92: encore.daemon.Daemon.ListRunSessions:input_type -> encore.daemon.ListRunSessionsRequest
+	37,  // 93: encore.daemon.Daemon.GetRunSession:input_type -> encore.daemon.GetRunSessionRequest
+	44,  // 94: encore.daemon.Daemon.RunSpec:input_type -> encore.daemon.RunSpecRequest
+	50,  // 95: encore.daemon.Daemon.Test:input_type -> encore.daemon.TestRequest
+	51,  // 96: encore.daemon.Daemon.TestSpec:input_type -> encore.daemon.TestSpecRequest
+	53,  // 97: encore.daemon.Daemon.ExecScript:input_type -> encore.daemon.ExecScriptRequest
+	54,  // 98: encore.daemon.Daemon.ExecSpec:input_type -> encore.daemon.ExecSpecRequest
+	57,  // 99: encore.daemon.Daemon.Check:input_type -> encore.daemon.CheckRequest
+	58,  // 100: encore.daemon.Daemon.Export:input_type -> encore.daemon.ExportRequest
+	60,  // 101: encore.daemon.Daemon.DBConnect:input_type -> encore.daemon.DBConnectRequest
+	62,  // 102: encore.daemon.Daemon.DBProxy:input_type -> encore.daemon.DBProxyRequest
+	63,  // 103: encore.daemon.Daemon.DBReset:input_type -> encore.daemon.DBResetRequest
+	64,  // 104: encore.daemon.Daemon.DBSeed:input_type -> encore.daemon.DBSeedRequest
+	65,  // 105: encore.daemon.Daemon.DBSeedStatus:input_type -> encore.daemon.DBSeedStatusRequest
+	67,  // 106: encore.daemon.Daemon.DBMigrationPlan:input_type -> encore.daemon.DBMigrationPlanRequest
+	69,  // 107: encore.daemon.Daemon.DBRollback:input_type -> encore.daemon.DBRollbackRequest
+	70,  // 108: encore.daemon.Daemon.DBDiff:input_type -> encore.daemon.DBDiffRequest
+	72,  // 109: encore.daemon.Daemon.DBSnapshotCreate:input_type -> encore.daemon.DBSnapshotCreateRequest
+	73,  // 110: encore.daemon.Daemon.DBSnapshotRestore:input_type -> encore.daemon.DBSnapshotRestoreRequest
+	75,  // 111: encore.daemon.Daemon.DBQueryLog:input_type -> encore.daemon.DBQueryLogRequest
+	81,  // 112: encore.daemon.Daemon.GenClient:input_type -> encore.daemon.GenClientRequest
+	83,  // 113: encore.daemon.Daemon.GenWrappers:input_type -> encore.daemon.GenWrappersRequest
+	85,  // 114: encore.daemon.Daemon.SecretsRefresh:input_type -> encore.daemon.SecretsRefreshRequest
+	159, // 115: encore.daemon.Daemon.Version:input_type -> google.protobuf.Empty
+	89,  // 116: encore.daemon.Daemon.CreateNamespace:input_type -> encore.daemon.CreateNamespaceRequest
+	90,  // 117: encore.daemon.Daemon.SwitchNamespace:input_type -> encore.daemon.SwitchNamespaceRequest
+	91,  // 118: encore.daemon.Daemon.ListNamespaces:input_type -> encore.daemon.ListNamespacesRequest
+	92,  // 119: encore.daemon.Daemon.DeleteNamespace:input_type -> encore.daemon.DeleteNamespaceRequest
+	95,  // 120: encore.daemon.Daemon.DumpMeta:input_type -> encore.daemon.DumpMetaRequest
+	94,  // 121: encore.daemon.Daemon.Telemetry:input_type -> encore.daemon.TelemetryConfig
+	29,  // 122: encore.daemon.Daemon.CreateApp:input_type -> encore.daemon.CreateAppRequest
+	125, // 123: encore.daemon.Daemon.AnalyzeDeadlines:input_type -> encore.daemon.AnalyzeDeadlinesRequest
+	128, // 124: encore.daemon.Daemon.UsageReport:input_type -> encore.daemon.UsageReportRequest
+	97,  // 125: encore.daemon.Daemon.DebugBundles:input_type -> encore.daemon.DebugBundlesRequest
+	100, // 126: encore.daemon.Daemon.AddLogpoint:input_type -> encore.daemon.AddLogpointRequest
+	102, // 127: encore.daemon.Daemon.ListLogpoints:input_type -> encore.daemon.ListLogpointsRequest
+	104, // 128: encore.daemon.Daemon.RemoveLogpoint:input_type -> encore.daemon.RemoveLogpointRequest
+	105, // 129: encore.daemon.Daemon.GoroutineDump:input_type -> encore.daemon.GoroutineDumpRequest
+	108, // 130: encore.daemon.Daemon.RetentionDryRun:input_type -> encore.daemon.RetentionDryRunRequest
+	112, // 131: encore.daemon.Daemon.StartupReport:input_type -> encore.daemon.StartupReportRequest
+	116, // 132: encore.daemon.Daemon.VulnScan:input_type -> encore.daemon.VulnScanRequest
+	119, // 133: encore.daemon.Daemon.LicenseReport:input_type -> encore.daemon.LicenseReportRequest
+	123, // 134: encore.daemon.Daemon.BuildProvenance:input_type -> encore.daemon.BuildProvenanceRequest
+	131, // 135: encore.daemon.Daemon.ExportSchemas:input_type -> encore.daemon.ExportSchemasRequest
+	77,  // 136: encore.daemon.Daemon.DBCDCConfig:input_type -> encore.daemon.DBCDCConfigRequest
+	79,  // 137: encore.daemon.Daemon.DBCDCStream:input_type -> encore.daemon.DBCDCStreamRequest
+	80,  // 138: encore.daemon.Daemon.AttachLogs:input_type -> encore.daemon.AttachLogsRequest
+	24,  // 139: encore.daemon.Daemon.ResumeStream:input_type -> encore.daemon.ResumeStreamRequest
+	25,  // 140: encore.daemon.Daemon.CancelStream:input_type -> encore.daemon.CancelStreamRequest
+	16,  // 141: encore.daemon.Daemon.Run:output_type -> encore.daemon.CommandMessage
+	42,  // 142: encore.daemon.Daemon.ListRuns:output_type -> encore.daemon.ListRunsResponse
+	16,  // 143: encore.daemon.Daemon.AttachRun:output_type -> encore.daemon.CommandMessage
+	159, // 144: encore.daemon.Daemon.StopRun:output_type -> google.protobuf.Empty
+	159, // 145: encore.daemon.Daemon.ControlRun:output_type -> google.protobuf.Empty
+	36,  // 146: encore.daemon.Daemon.ListRunSessions:output_type -> encore.daemon.ListRunSessionsResponse
+	38,  // 147: encore.daemon.Daemon.GetRunSession:output_type -> encore.daemon.GetRunSessionResponse
+	47,  // 148: encore.daemon.Daemon.RunSpec:output_type -> encore.daemon.RunSpecMessage
+	16,  // 149: encore.daemon.Daemon.Test:output_type -> encore.daemon.CommandMessage
+	52,  // 150: encore.daemon.Daemon.TestSpec:output_type -> encore.daemon.TestSpecResponse
+	16,  // 151: encore.daemon.Daemon.ExecScript:output_type -> encore.daemon.CommandMessage
+	55,  // 152: encore.daemon.Daemon.ExecSpec:output_type -> encore.daemon.ExecSpecMessage
+	16,  // 153: encore.daemon.Daemon.Check:output_type -> encore.daemon.CommandMessage
+	16,  // 154: encore.daemon.Daemon.Export:output_type -> encore.daemon.CommandMessage
+	61,  // 155: encore.daemon.Daemon.DBConnect:output_type -> encore.daemon.DBConnectResponse
+	16,  // 156: encore.daemon.Daemon.DBProxy:output_type -> encore.daemon.CommandMessage
+	16,  // 157: encore.daemon.Daemon.DBReset:output_type -> encore.daemon.CommandMessage
+	16,  // 158: encore.daemon.Daemon.DBSeed:output_type -> encore.daemon.CommandMessage
+	66,  // 159: encore.daemon.Daemon.DBSeedStatus:output_type -> encore.daemon.DBSeedStatusResponse
+	68,  // 160: encore.daemon.Daemon.DBMigrationPlan:output_type -> encore.daemon.DBMigrationPlanResponse
+	16,  // 161: encore.daemon.Daemon.DBRollback:output_type -> encore.daemon.CommandMessage
+	71,  // 162: encore.daemon.Daemon.DBDiff:output_type -> encore.daemon.DBDiffResponse
+	74,  // 163: encore.daemon.Daemon.DBSnapshotCreate:output_type -> encore.daemon.DBSnapshotResponse
+	74,  // 164: encore.daemon.Daemon.DBSnapshotRestore:output_type -> encore.daemon.DBSnapshotResponse
+	76,  // 165: encore.daemon.Daemon.DBQueryLog:output_type -> encore.daemon.DBQueryLogResponse
+	82,  // 166: encore.daemon.Daemon.GenClient:output_type -> encore.daemon.GenClientResponse
+	84,  // 167: encore.daemon.Daemon.GenWrappers:output_type -> encore.daemon.GenWrappersResponse
+	86,  // 168: encore.daemon.Daemon.SecretsRefresh:output_type -> encore.daemon.SecretsRefreshResponse
+	87,  // 169: encore.daemon.Daemon.Version:output_type -> encore.daemon.VersionResponse
+	88,  // 170: encore.daemon.Daemon.CreateNamespace:output_type -> encore.daemon.Namespace
+	88,  // 171: encore.daemon.Daemon.SwitchNamespace:output_type -> encore.daemon.Namespace
+	93,  // 172: encore.daemon.Daemon.ListNamespaces:output_type -> encore.daemon.ListNamespacesResponse
+	159, // 173: encore.daemon.Daemon.DeleteNamespace:output_type -> google.protobuf.Empty
+	96,  // 174: encore.daemon.Daemon.DumpMeta:output_type -> encore.daemon.DumpMetaResponse
+	159, // 175: encore.daemon.Daemon.Telemetry:output_type -> google.protobuf.Empty
+	30,  // 176: encore.daemon.Daemon.CreateApp:output_type -> encore.daemon.CreateAppResponse
+	126, // 177: encore.daemon.Daemon.AnalyzeDeadlines:output_type -> encore.daemon.AnalyzeDeadlinesResponse
+	129, // 178: encore.daemon.Daemon.UsageReport:output_type -> encore.daemon.UsageReportResponse
+	98,  // 179: encore.daemon.Daemon.DebugBundles:output_type -> encore.daemon.DebugBundlesResponse
+	101, // 180: encore.daemon.Daemon.AddLogpoint:output_type -> encore.daemon.Logpoint
+	103, // 181: encore.daemon.Daemon.ListLogpoints:output_type -> encore.daemon.ListLogpointsResponse
+	159, // 182: encore.daemon.Daemon.RemoveLogpoint:output_type -> google.protobuf.Empty
+	106, // 183: encore.daemon.Daemon.GoroutineDump:output_type -> encore.daemon.GoroutineDumpResponse
+	109, // 184: encore.daemon.Daemon.RetentionDryRun:output_type -> encore.daemon.RetentionDryRunResponse
+	113, // 185: encore.daemon.Daemon.StartupReport:output_type -> encore.daemon.StartupReportResponse
+	117, // 186: encore.daemon.Daemon.VulnScan:output_type -> encore.daemon.VulnScanResponse
+	120, // 187: encore.daemon.Daemon.LicenseReport:output_type -> encore.daemon.LicenseReportResponse
+	124, // 188: encore.daemon.Daemon.BuildProvenance:output_type -> encore.daemon.BuildProvenanceResponse
+	132, // 189: encore.daemon.Daemon.ExportSchemas:output_type -> encore.daemon.ExportSchemasResponse
+	78,  // 190: encore.daemon.Daemon.DBCDCConfig:output_type -> encore.daemon.DBCDCConfigResponse
+	16,  // 191: encore.daemon.Daemon.DBCDCStream:output_type -> encore.daemon.CommandMessage
+	16,  // 192: encore.daemon.Daemon.AttachLogs:output_type -> encore.daemon.CommandMessage
+	16,  // 193: encore.daemon.Daemon.ResumeStream:output_type -> encore.daemon.CommandMessage
+	159, // 194: encore.daemon.Daemon.CancelStream:output_type -> google.protobuf.Empty
+	141, // [141:195] is the sub-list for method output_type
+	87,  // [87:141] is the sub-list for method input_type
+	87,  // [87:87] is the sub-list for extension type_name
+	87,  // [87:87] is the sub-list for extension extendee
+	0,   // [0:87] is the sub-list for field type_name
 }
 
 func init() { file_encore_daemon_daemon_proto_init() }
@@ -5089,39 +12230,67 @@ func file_encore_daemon_daemon_proto_init() {
 		(*CommandMessage_Output)(nil),
 		(*CommandMessage_Exit)(nil),
 		(*CommandMessage_Errors)(nil),
+		(*CommandMessage_Session)(nil),
+		(*CommandMessage_OutputBatch)(nil),
+		(*CommandMessage_Event)(nil),
+	}
+	file_encore_daemon_daemon_proto_msgTypes[1].OneofWrappers = []any{
+		(*CommandEvent_Operation)(nil),
+		(*CommandEvent_RunStarted)(nil),
+		(*CommandEvent_Reload)(nil),
+		(*CommandEvent_Listen)(nil),
 	}
-	file_encore_daemon_daemon_proto_msgTypes[6].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[7].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[8].OneofWrappers = []any{
+	file_encore_daemon_daemon_proto_msgTypes[15].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[23].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[24].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[28].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[29].OneofWrappers = []any{
 		(*SpecCommand_Curl)(nil),
 	}
-	file_encore_daemon_daemon_proto_msgTypes[10].OneofWrappers = []any{
+	file_encore_daemon_daemon_proto_msgTypes[31].OneofWrappers = []any{
 		(*RunSpecMessage_Output)(nil),
 		(*RunSpecMessage_Result)(nil),
 		(*RunSpecMessage_Complete)(nil),
 	}
-	file_encore_daemon_daemon_proto_msgTypes[13].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[16].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[17].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[18].OneofWrappers = []any{
+	file_encore_daemon_daemon_proto_msgTypes[34].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[37].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[38].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[39].OneofWrappers = []any{
 		(*ExecSpecMessage_Output)(nil),
 		(*ExecSpecMessage_Spec)(nil),
 	}
-	file_encore_daemon_daemon_proto_msgTypes[21].OneofWrappers = []any{
+	file_encore_daemon_daemon_proto_msgTypes[42].OneofWrappers = []any{
 		(*ExportRequest_Docker)(nil),
 	}
-	file_encore_daemon_daemon_proto_msgTypes[23].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[25].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[26].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[27].OneofWrappers = []any{}
-	file_encore_daemon_daemon_proto_msgTypes[34].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[44].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[46].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[47].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[48].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[49].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[51].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[53].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[54].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[56].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[57].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[59].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[63].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[65].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[72].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[81].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[84].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[85].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[92].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[109].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[111].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[118].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[123].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_daemon_daemon_proto_rawDesc), len(file_encore_daemon_daemon_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   60,
+			NumEnums:      16,
+			NumMessages:   143,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
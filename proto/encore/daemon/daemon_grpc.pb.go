@@ -20,28 +20,60 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Daemon_Run_FullMethodName             = "/encore.daemon.Daemon/Run"
-	Daemon_RunSpec_FullMethodName         = "/encore.daemon.Daemon/RunSpec"
-	Daemon_Test_FullMethodName            = "/encore.daemon.Daemon/Test"
-	Daemon_TestSpec_FullMethodName        = "/encore.daemon.Daemon/TestSpec"
-	Daemon_ExecScript_FullMethodName      = "/encore.daemon.Daemon/ExecScript"
-	Daemon_ExecSpec_FullMethodName        = "/encore.daemon.Daemon/ExecSpec"
-	Daemon_Check_FullMethodName           = "/encore.daemon.Daemon/Check"
-	Daemon_Export_FullMethodName          = "/encore.daemon.Daemon/Export"
-	Daemon_DBConnect_FullMethodName       = "/encore.daemon.Daemon/DBConnect"
-	Daemon_DBProxy_FullMethodName         = "/encore.daemon.Daemon/DBProxy"
-	Daemon_DBReset_FullMethodName         = "/encore.daemon.Daemon/DBReset"
-	Daemon_GenClient_FullMethodName       = "/encore.daemon.Daemon/GenClient"
-	Daemon_GenWrappers_FullMethodName     = "/encore.daemon.Daemon/GenWrappers"
-	Daemon_SecretsRefresh_FullMethodName  = "/encore.daemon.Daemon/SecretsRefresh"
-	Daemon_Version_FullMethodName         = "/encore.daemon.Daemon/Version"
-	Daemon_CreateNamespace_FullMethodName = "/encore.daemon.Daemon/CreateNamespace"
-	Daemon_SwitchNamespace_FullMethodName = "/encore.daemon.Daemon/SwitchNamespace"
-	Daemon_ListNamespaces_FullMethodName  = "/encore.daemon.Daemon/ListNamespaces"
-	Daemon_DeleteNamespace_FullMethodName = "/encore.daemon.Daemon/DeleteNamespace"
-	Daemon_DumpMeta_FullMethodName        = "/encore.daemon.Daemon/DumpMeta"
-	Daemon_Telemetry_FullMethodName       = "/encore.daemon.Daemon/Telemetry"
-	Daemon_CreateApp_FullMethodName       = "/encore.daemon.Daemon/CreateApp"
+	Daemon_Run_FullMethodName               = "/encore.daemon.Daemon/Run"
+	Daemon_ListRuns_FullMethodName          = "/encore.daemon.Daemon/ListRuns"
+	Daemon_AttachRun_FullMethodName         = "/encore.daemon.Daemon/AttachRun"
+	Daemon_StopRun_FullMethodName           = "/encore.daemon.Daemon/StopRun"
+	Daemon_ControlRun_FullMethodName        = "/encore.daemon.Daemon/ControlRun"
+	Daemon_ListRunSessions_FullMethodName   = "/encore.daemon.Daemon/ListRunSessions"
+	Daemon_GetRunSession_FullMethodName     = "/encore.daemon.Daemon/GetRunSession"
+	Daemon_RunSpec_FullMethodName           = "/encore.daemon.Daemon/RunSpec"
+	Daemon_Test_FullMethodName              = "/encore.daemon.Daemon/Test"
+	Daemon_TestSpec_FullMethodName          = "/encore.daemon.Daemon/TestSpec"
+	Daemon_ExecScript_FullMethodName        = "/encore.daemon.Daemon/ExecScript"
+	Daemon_ExecSpec_FullMethodName          = "/encore.daemon.Daemon/ExecSpec"
+	Daemon_Check_FullMethodName             = "/encore.daemon.Daemon/Check"
+	Daemon_Export_FullMethodName            = "/encore.daemon.Daemon/Export"
+	Daemon_DBConnect_FullMethodName         = "/encore.daemon.Daemon/DBConnect"
+	Daemon_DBProxy_FullMethodName           = "/encore.daemon.Daemon/DBProxy"
+	Daemon_DBReset_FullMethodName           = "/encore.daemon.Daemon/DBReset"
+	Daemon_DBSeed_FullMethodName            = "/encore.daemon.Daemon/DBSeed"
+	Daemon_DBSeedStatus_FullMethodName      = "/encore.daemon.Daemon/DBSeedStatus"
+	Daemon_DBMigrationPlan_FullMethodName   = "/encore.daemon.Daemon/DBMigrationPlan"
+	Daemon_DBRollback_FullMethodName        = "/encore.daemon.Daemon/DBRollback"
+	Daemon_DBDiff_FullMethodName            = "/encore.daemon.Daemon/DBDiff"
+	Daemon_DBSnapshotCreate_FullMethodName  = "/encore.daemon.Daemon/DBSnapshotCreate"
+	Daemon_DBSnapshotRestore_FullMethodName = "/encore.daemon.Daemon/DBSnapshotRestore"
+	Daemon_DBQueryLog_FullMethodName        = "/encore.daemon.Daemon/DBQueryLog"
+	Daemon_GenClient_FullMethodName         = "/encore.daemon.Daemon/GenClient"
+	Daemon_GenWrappers_FullMethodName       = "/encore.daemon.Daemon/GenWrappers"
+	Daemon_SecretsRefresh_FullMethodName    = "/encore.daemon.Daemon/SecretsRefresh"
+	Daemon_Version_FullMethodName           = "/encore.daemon.Daemon/Version"
+	Daemon_CreateNamespace_FullMethodName   = "/encore.daemon.Daemon/CreateNamespace"
+	Daemon_SwitchNamespace_FullMethodName   = "/encore.daemon.Daemon/SwitchNamespace"
+	Daemon_ListNamespaces_FullMethodName    = "/encore.daemon.Daemon/ListNamespaces"
+	Daemon_DeleteNamespace_FullMethodName   = "/encore.daemon.Daemon/DeleteNamespace"
+	Daemon_DumpMeta_FullMethodName          = "/encore.daemon.Daemon/DumpMeta"
+	Daemon_Telemetry_FullMethodName         = "/encore.daemon.Daemon/Telemetry"
+	Daemon_CreateApp_FullMethodName         = "/encore.daemon.Daemon/CreateApp"
+	Daemon_AnalyzeDeadlines_FullMethodName  = "/encore.daemon.Daemon/AnalyzeDeadlines"
+	Daemon_UsageReport_FullMethodName       = "/encore.daemon.Daemon/UsageReport"
+	Daemon_DebugBundles_FullMethodName      = "/encore.daemon.Daemon/DebugBundles"
+	Daemon_AddLogpoint_FullMethodName       = "/encore.daemon.Daemon/AddLogpoint"
+	Daemon_ListLogpoints_FullMethodName     = "/encore.daemon.Daemon/ListLogpoints"
+	Daemon_RemoveLogpoint_FullMethodName    = "/encore.daemon.Daemon/RemoveLogpoint"
+	Daemon_GoroutineDump_FullMethodName     = "/encore.daemon.Daemon/GoroutineDump"
+	Daemon_RetentionDryRun_FullMethodName   = "/encore.daemon.Daemon/RetentionDryRun"
+	Daemon_StartupReport_FullMethodName     = "/encore.daemon.Daemon/StartupReport"
+	Daemon_VulnScan_FullMethodName          = "/encore.daemon.Daemon/VulnScan"
+	Daemon_LicenseReport_FullMethodName     = "/encore.daemon.Daemon/LicenseReport"
+	Daemon_BuildProvenance_FullMethodName   = "/encore.daemon.Daemon/BuildProvenance"
+	Daemon_ExportSchemas_FullMethodName     = "/encore.daemon.Daemon/ExportSchemas"
+	Daemon_DBCDCConfig_FullMethodName       = "/encore.daemon.Daemon/DBCDCConfig"
+	Daemon_DBCDCStream_FullMethodName       = "/encore.daemon.Daemon/DBCDCStream"
+	Daemon_AttachLogs_FullMethodName        = "/encore.daemon.Daemon/AttachLogs"
+	Daemon_ResumeStream_FullMethodName      = "/encore.daemon.Daemon/ResumeStream"
+	Daemon_CancelStream_FullMethodName      = "/encore.daemon.Daemon/CancelStream"
 )
 
 // DaemonClient is the client API for Daemon service.
@@ -50,6 +82,19 @@ const (
 type DaemonClient interface {
 	// Run runs the application.
 	Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
+	// ListRuns lists the apps currently running and the addresses they listen on.
+	ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error)
+	// AttachRun attaches to the output of an app started with detach set.
+	AttachRun(ctx context.Context, in *AttachRunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
+	// StopRun stops a running app.
+	StopRun(ctx context.Context, in *StopRunRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ControlRun controls a running app, such as restarting it,
+	// for the interactive keyboard controls of 'encore run'.
+	ControlRun(ctx context.Context, in *ControlRunRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListRunSessions lists the recorded run sessions of an app, most recent first.
+	ListRunSessions(ctx context.Context, in *ListRunSessionsRequest, opts ...grpc.CallOption) (*ListRunSessionsResponse, error)
+	// GetRunSession returns a recorded run session along with its logs and traces.
+	GetRunSession(ctx context.Context, in *GetRunSessionRequest, opts ...grpc.CallOption) (*GetRunSessionResponse, error)
 	// RunSpec runs a list of HTTP requests against a freshly-started app and
 	// streams the results. Designed for non-interactive agents.
 	RunSpec(ctx context.Context, in *RunSpecRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RunSpecMessage], error)
@@ -73,6 +118,28 @@ type DaemonClient interface {
 	DBProxy(ctx context.Context, in *DBProxyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
 	// DBReset resets the given databases, recreating them from scratch.
 	DBReset(ctx context.Context, in *DBResetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
+	// DBSeed applies the seeds declared for the app's databases
+	// that haven't been applied in the namespace.
+	DBSeed(ctx context.Context, in *DBSeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
+	// DBSeedStatus lists the seeds declared for the app's databases,
+	// and whether they've been applied in the namespace.
+	DBSeedStatus(ctx context.Context, in *DBSeedStatusRequest, opts ...grpc.CallOption) (*DBSeedStatusResponse, error)
+	// DBMigrationPlan reports the migrations that would be applied to the app's
+	// databases in a namespace or cloud environment, without applying them.
+	DBMigrationPlan(ctx context.Context, in *DBMigrationPlanRequest, opts ...grpc.CallOption) (*DBMigrationPlanResponse, error)
+	// DBRollback rolls back the most recently applied migrations of a database
+	// in a namespace or cloud environment by running their down migrations.
+	DBRollback(ctx context.Context, in *DBRollbackRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
+	// DBDiff compares the live schema of a database against the schema its
+	// migrations produce, or another environment, and drafts a migration for the difference.
+	DBDiff(ctx context.Context, in *DBDiffRequest, opts ...grpc.CallOption) (*DBDiffResponse, error)
+	// DBSnapshotCreate dumps the app's databases in a namespace to a snapshot archive.
+	DBSnapshotCreate(ctx context.Context, in *DBSnapshotCreateRequest, opts ...grpc.CallOption) (*DBSnapshotResponse, error)
+	// DBSnapshotRestore restores the databases in a snapshot archive into a namespace.
+	DBSnapshotRestore(ctx context.Context, in *DBSnapshotRestoreRequest, opts ...grpc.CallOption) (*DBSnapshotResponse, error)
+	// DBQueryLog returns the queries an app made to its local databases,
+	// linked with the traces of the requests that made them.
+	DBQueryLog(ctx context.Context, in *DBQueryLogRequest, opts ...grpc.CallOption) (*DBQueryLogResponse, error)
 	// GenClient generates a client based on the app's API.
 	GenClient(ctx context.Context, in *GenClientRequest, opts ...grpc.CallOption) (*GenClientResponse, error)
 	// GenWrappers generates user-facing wrapper code.
@@ -95,6 +162,57 @@ type DaemonClient interface {
 	Telemetry(ctx context.Context, in *TelemetryConfig, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// InitTutorial sets the tutorial flag of the app
 	CreateApp(ctx context.Context, in *CreateAppRequest, opts ...grpc.CallOption) (*CreateAppResponse, error)
+	// AnalyzeDeadlines analyzes recorded traces for requests whose
+	// deadline was exceeded, whose context was canceled, or that
+	// did not receive the deadline of their caller.
+	AnalyzeDeadlines(ctx context.Context, in *AnalyzeDeadlinesRequest, opts ...grpc.CallOption) (*AnalyzeDeadlinesResponse, error)
+	// UsageReport attributes the database, cache, Pub/Sub and external HTTP
+	// usage in recorded traces to requests, aggregated by endpoint or user.
+	UsageReport(ctx context.Context, in *UsageReportRequest, opts ...grpc.CallOption) (*UsageReportResponse, error)
+	// DebugBundles returns the debug bundles captured for failed requests
+	// of an app run with debug bundles enabled.
+	DebugBundles(ctx context.Context, in *DebugBundlesRequest, opts ...grpc.CallOption) (*DebugBundlesResponse, error)
+	// AddLogpoint adds a logpoint to a service of a running app: a location at
+	// which expressions are evaluated and written to the run's output, without
+	// changing the code or restarting the service.
+	AddLogpoint(ctx context.Context, in *AddLogpointRequest, opts ...grpc.CallOption) (*Logpoint, error)
+	// ListLogpoints lists the logpoints of a running app.
+	ListLogpoints(ctx context.Context, in *ListLogpointsRequest, opts ...grpc.CallOption) (*ListLogpointsResponse, error)
+	// RemoveLogpoint removes a logpoint of a running app.
+	RemoveLogpoint(ctx context.Context, in *RemoveLogpointRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GoroutineDump captures goroutine dumps of the processes of a running app,
+	// annotated with the goroutines that look deadlocked.
+	GoroutineDump(ctx context.Context, in *GoroutineDumpRequest, opts ...grpc.CallOption) (*GoroutineDumpResponse, error)
+	// RetentionDryRun reports what the data retention policies of a running app
+	// would delete if enforced now, and what deleting a subject would delete.
+	RetentionDryRun(ctx context.Context, in *RetentionDryRunRequest, opts ...grpc.CallOption) (*RetentionDryRunResponse, error)
+	// StartupReport reports where the time went when the processes
+	// of a running app started.
+	StartupReport(ctx context.Context, in *StartupReportRequest, opts ...grpc.CallOption) (*StartupReportResponse, error)
+	// VulnScan reports known vulnerabilities in the dependencies of an app,
+	// scanning them if they changed since the last scan.
+	VulnScan(ctx context.Context, in *VulnScanRequest, opts ...grpc.CallOption) (*VulnScanResponse, error)
+	// LicenseReport reports the licenses of the dependencies of an app
+	// and the dependencies licensed under licenses the app denies.
+	LicenseReport(ctx context.Context, in *LicenseReportRequest, opts ...grpc.CallOption) (*LicenseReportResponse, error)
+	// BuildProvenance reports the provenance metadata that building
+	// the app in its current state would embed in its artifacts.
+	BuildProvenance(ctx context.Context, in *BuildProvenanceRequest, opts ...grpc.CallOption) (*BuildProvenanceResponse, error)
+	// ExportSchemas exports JSON Schema or Avro definitions of the
+	// request, response and Pub/Sub message types of an app.
+	ExportSchemas(ctx context.Context, in *ExportSchemasRequest, opts ...grpc.CallOption) (*ExportSchemasResponse, error)
+	// DBCDCConfig generates change data capture configuration for an app's databases.
+	DBCDCConfig(ctx context.Context, in *DBCDCConfigRequest, opts ...grpc.CallOption) (*DBCDCConfigResponse, error)
+	// DBCDCStream streams change events from a local database to a Pub/Sub topic
+	// of the running app, for testing data pipelines.
+	DBCDCStream(ctx context.Context, in *DBCDCStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
+	// AttachLogs streams the output of an app started with 'encore run'.
+	AttachLogs(ctx context.Context, in *AttachLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
+	// ResumeStream reattaches to the output stream of a resumable command
+	// after the connection to the daemon was interrupted.
+	ResumeStream(ctx context.Context, in *ResumeStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error)
+	// CancelStream cancels a resumable command.
+	CancelStream(ctx context.Context, in *CancelStreamRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type daemonClient struct {
@@ -124,9 +242,78 @@ func (c *daemonClient) Run(ctx context.Context, in *RunRequest, opts ...grpc.Cal
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Daemon_RunClient = grpc.ServerStreamingClient[CommandMessage]
 
+func (c *daemonClient) ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRunsResponse)
+	err := c.cc.Invoke(ctx, Daemon_ListRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) AttachRun(ctx context.Context, in *AttachRunRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[1], Daemon_AttachRun_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AttachRunRequest, CommandMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_AttachRunClient = grpc.ServerStreamingClient[CommandMessage]
+
+func (c *daemonClient) StopRun(ctx context.Context, in *StopRunRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Daemon_StopRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ControlRun(ctx context.Context, in *ControlRunRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Daemon_ControlRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ListRunSessions(ctx context.Context, in *ListRunSessionsRequest, opts ...grpc.CallOption) (*ListRunSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRunSessionsResponse)
+	err := c.cc.Invoke(ctx, Daemon_ListRunSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) GetRunSession(ctx context.Context, in *GetRunSessionRequest, opts ...grpc.CallOption) (*GetRunSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRunSessionResponse)
+	err := c.cc.Invoke(ctx, Daemon_GetRunSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonClient) RunSpec(ctx context.Context, in *RunSpecRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RunSpecMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[1], Daemon_RunSpec_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[2], Daemon_RunSpec_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +332,7 @@ type Daemon_RunSpecClient = grpc.ServerStreamingClient[RunSpecMessage]
 
 func (c *daemonClient) Test(ctx context.Context, in *TestRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[2], Daemon_Test_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[3], Daemon_Test_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +361,7 @@ func (c *daemonClient) TestSpec(ctx context.Context, in *TestSpecRequest, opts .
 
 func (c *daemonClient) ExecScript(ctx context.Context, in *ExecScriptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[3], Daemon_ExecScript_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[4], Daemon_ExecScript_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +380,7 @@ type Daemon_ExecScriptClient = grpc.ServerStreamingClient[CommandMessage]
 
 func (c *daemonClient) ExecSpec(ctx context.Context, in *ExecSpecRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecSpecMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[4], Daemon_ExecSpec_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[5], Daemon_ExecSpec_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +399,7 @@ type Daemon_ExecSpecClient = grpc.ServerStreamingClient[ExecSpecMessage]
 
 func (c *daemonClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[5], Daemon_Check_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[6], Daemon_Check_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +418,7 @@ type Daemon_CheckClient = grpc.ServerStreamingClient[CommandMessage]
 
 func (c *daemonClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[6], Daemon_Export_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[7], Daemon_Export_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -260,7 +447,7 @@ func (c *daemonClient) DBConnect(ctx context.Context, in *DBConnectRequest, opts
 
 func (c *daemonClient) DBProxy(ctx context.Context, in *DBProxyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[7], Daemon_DBProxy_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[8], Daemon_DBProxy_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -279,7 +466,7 @@ type Daemon_DBProxyClient = grpc.ServerStreamingClient[CommandMessage]
 
 func (c *daemonClient) DBReset(ctx context.Context, in *DBResetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[8], Daemon_DBReset_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[9], Daemon_DBReset_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -296,6 +483,104 @@ func (c *daemonClient) DBReset(ctx context.Context, in *DBResetRequest, opts ...
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Daemon_DBResetClient = grpc.ServerStreamingClient[CommandMessage]
 
+func (c *daemonClient) DBSeed(ctx context.Context, in *DBSeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[10], Daemon_DBSeed_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DBSeedRequest, CommandMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBSeedClient = grpc.ServerStreamingClient[CommandMessage]
+
+func (c *daemonClient) DBSeedStatus(ctx context.Context, in *DBSeedStatusRequest, opts ...grpc.CallOption) (*DBSeedStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DBSeedStatusResponse)
+	err := c.cc.Invoke(ctx, Daemon_DBSeedStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) DBMigrationPlan(ctx context.Context, in *DBMigrationPlanRequest, opts ...grpc.CallOption) (*DBMigrationPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DBMigrationPlanResponse)
+	err := c.cc.Invoke(ctx, Daemon_DBMigrationPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) DBRollback(ctx context.Context, in *DBRollbackRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[11], Daemon_DBRollback_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DBRollbackRequest, CommandMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBRollbackClient = grpc.ServerStreamingClient[CommandMessage]
+
+func (c *daemonClient) DBDiff(ctx context.Context, in *DBDiffRequest, opts ...grpc.CallOption) (*DBDiffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DBDiffResponse)
+	err := c.cc.Invoke(ctx, Daemon_DBDiff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) DBSnapshotCreate(ctx context.Context, in *DBSnapshotCreateRequest, opts ...grpc.CallOption) (*DBSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DBSnapshotResponse)
+	err := c.cc.Invoke(ctx, Daemon_DBSnapshotCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) DBSnapshotRestore(ctx context.Context, in *DBSnapshotRestoreRequest, opts ...grpc.CallOption) (*DBSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DBSnapshotResponse)
+	err := c.cc.Invoke(ctx, Daemon_DBSnapshotRestore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) DBQueryLog(ctx context.Context, in *DBQueryLogRequest, opts ...grpc.CallOption) (*DBQueryLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DBQueryLogResponse)
+	err := c.cc.Invoke(ctx, Daemon_DBQueryLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonClient) GenClient(ctx context.Context, in *GenClientRequest, opts ...grpc.CallOption) (*GenClientResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GenClientResponse)
@@ -406,100 +691,435 @@ func (c *daemonClient) CreateApp(ctx context.Context, in *CreateAppRequest, opts
 	return out, nil
 }
 
-// DaemonServer is the server API for Daemon service.
-// All implementations must embed UnimplementedDaemonServer
-// for forward compatibility.
-type DaemonServer interface {
-	// Run runs the application.
-	Run(*RunRequest, grpc.ServerStreamingServer[CommandMessage]) error
-	// RunSpec runs a list of HTTP requests against a freshly-started app and
-	// streams the results. Designed for non-interactive agents.
-	RunSpec(*RunSpecRequest, grpc.ServerStreamingServer[RunSpecMessage]) error
-	// Test runs tests.
-	Test(*TestRequest, grpc.ServerStreamingServer[CommandMessage]) error
-	// TestSpec returns the specification for how to run tests.
-	TestSpec(context.Context, *TestSpecRequest) (*TestSpecResponse, error)
-	// ExecScript executes a one-off script.
-	ExecScript(*ExecScriptRequest, grpc.ServerStreamingServer[CommandMessage]) error
-	// ExecSpec returns the specification for how to run an exec command.
-	// It streams progress messages during setup, then sends the spec as the final message.
-	ExecSpec(*ExecSpecRequest, grpc.ServerStreamingServer[ExecSpecMessage]) error
-	// Check checks the app for compilation errors.
-	Check(*CheckRequest, grpc.ServerStreamingServer[CommandMessage]) error
-	// Export exports the app in various formats.
-	Export(*ExportRequest, grpc.ServerStreamingServer[CommandMessage]) error
-	// DBConnect starts the database and returns the DSN for connecting to it.
-	DBConnect(context.Context, *DBConnectRequest) (*DBConnectResponse, error)
-	// DBProxy starts a local database proxy for connecting to remote databases
-	// on the encore.dev platform.
-	DBProxy(*DBProxyRequest, grpc.ServerStreamingServer[CommandMessage]) error
-	// DBReset resets the given databases, recreating them from scratch.
-	DBReset(*DBResetRequest, grpc.ServerStreamingServer[CommandMessage]) error
-	// GenClient generates a client based on the app's API.
-	GenClient(context.Context, *GenClientRequest) (*GenClientResponse, error)
-	// GenWrappers generates user-facing wrapper code.
-	GenWrappers(context.Context, *GenWrappersRequest) (*GenWrappersResponse, error)
-	// SecretsRefresh tells the daemon to refresh the local development secrets
-	// for the given application.
-	SecretsRefresh(context.Context, *SecretsRefreshRequest) (*SecretsRefreshResponse, error)
-	// Version reports the daemon version.
-	Version(context.Context, *emptypb.Empty) (*VersionResponse, error)
-	// CreateNamespace creates a new infra namespace.
-	CreateNamespace(context.Context, *CreateNamespaceRequest) (*Namespace, error)
-	// SwitchNamespace switches the active infra namespace.
-	SwitchNamespace(context.Context, *SwitchNamespaceRequest) (*Namespace, error)
-	// ListNamespaces lists all namespaces for the given app.
-	ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
-	// DeleteNamespace deletes an infra namespace.
-	DeleteNamespace(context.Context, *DeleteNamespaceRequest) (*emptypb.Empty, error)
-	DumpMeta(context.Context, *DumpMetaRequest) (*DumpMetaResponse, error)
-	// Telemetry enables or disables telemetry.
-	Telemetry(context.Context, *TelemetryConfig) (*emptypb.Empty, error)
-	// InitTutorial sets the tutorial flag of the app
-	CreateApp(context.Context, *CreateAppRequest) (*CreateAppResponse, error)
-	mustEmbedUnimplementedDaemonServer()
+func (c *daemonClient) AnalyzeDeadlines(ctx context.Context, in *AnalyzeDeadlinesRequest, opts ...grpc.CallOption) (*AnalyzeDeadlinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyzeDeadlinesResponse)
+	err := c.cc.Invoke(ctx, Daemon_AnalyzeDeadlines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedDaemonServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedDaemonServer struct{}
-
-func (UnimplementedDaemonServer) Run(*RunRequest, grpc.ServerStreamingServer[CommandMessage]) error {
-	return status.Errorf(codes.Unimplemented, "method Run not implemented")
-}
-func (UnimplementedDaemonServer) RunSpec(*RunSpecRequest, grpc.ServerStreamingServer[RunSpecMessage]) error {
-	return status.Errorf(codes.Unimplemented, "method RunSpec not implemented")
+func (c *daemonClient) UsageReport(ctx context.Context, in *UsageReportRequest, opts ...grpc.CallOption) (*UsageReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageReportResponse)
+	err := c.cc.Invoke(ctx, Daemon_UsageReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDaemonServer) Test(*TestRequest, grpc.ServerStreamingServer[CommandMessage]) error {
-	return status.Errorf(codes.Unimplemented, "method Test not implemented")
+
+func (c *daemonClient) DebugBundles(ctx context.Context, in *DebugBundlesRequest, opts ...grpc.CallOption) (*DebugBundlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DebugBundlesResponse)
+	err := c.cc.Invoke(ctx, Daemon_DebugBundles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDaemonServer) TestSpec(context.Context, *TestSpecRequest) (*TestSpecResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TestSpec not implemented")
+
+func (c *daemonClient) AddLogpoint(ctx context.Context, in *AddLogpointRequest, opts ...grpc.CallOption) (*Logpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Logpoint)
+	err := c.cc.Invoke(ctx, Daemon_AddLogpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDaemonServer) ExecScript(*ExecScriptRequest, grpc.ServerStreamingServer[CommandMessage]) error {
-	return status.Errorf(codes.Unimplemented, "method ExecScript not implemented")
+
+func (c *daemonClient) ListLogpoints(ctx context.Context, in *ListLogpointsRequest, opts ...grpc.CallOption) (*ListLogpointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLogpointsResponse)
+	err := c.cc.Invoke(ctx, Daemon_ListLogpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDaemonServer) ExecSpec(*ExecSpecRequest, grpc.ServerStreamingServer[ExecSpecMessage]) error {
-	return status.Errorf(codes.Unimplemented, "method ExecSpec not implemented")
+
+func (c *daemonClient) RemoveLogpoint(ctx context.Context, in *RemoveLogpointRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Daemon_RemoveLogpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDaemonServer) Check(*CheckRequest, grpc.ServerStreamingServer[CommandMessage]) error {
-	return status.Errorf(codes.Unimplemented, "method Check not implemented")
+
+func (c *daemonClient) GoroutineDump(ctx context.Context, in *GoroutineDumpRequest, opts ...grpc.CallOption) (*GoroutineDumpResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoroutineDumpResponse)
+	err := c.cc.Invoke(ctx, Daemon_GoroutineDump_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDaemonServer) Export(*ExportRequest, grpc.ServerStreamingServer[CommandMessage]) error {
-	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+
+func (c *daemonClient) RetentionDryRun(ctx context.Context, in *RetentionDryRunRequest, opts ...grpc.CallOption) (*RetentionDryRunResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetentionDryRunResponse)
+	err := c.cc.Invoke(ctx, Daemon_RetentionDryRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDaemonServer) DBConnect(context.Context, *DBConnectRequest) (*DBConnectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DBConnect not implemented")
+
+func (c *daemonClient) StartupReport(ctx context.Context, in *StartupReportRequest, opts ...grpc.CallOption) (*StartupReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartupReportResponse)
+	err := c.cc.Invoke(ctx, Daemon_StartupReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDaemonServer) DBProxy(*DBProxyRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+
+func (c *daemonClient) VulnScan(ctx context.Context, in *VulnScanRequest, opts ...grpc.CallOption) (*VulnScanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VulnScanResponse)
+	err := c.cc.Invoke(ctx, Daemon_VulnScan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) LicenseReport(ctx context.Context, in *LicenseReportRequest, opts ...grpc.CallOption) (*LicenseReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LicenseReportResponse)
+	err := c.cc.Invoke(ctx, Daemon_LicenseReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) BuildProvenance(ctx context.Context, in *BuildProvenanceRequest, opts ...grpc.CallOption) (*BuildProvenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuildProvenanceResponse)
+	err := c.cc.Invoke(ctx, Daemon_BuildProvenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ExportSchemas(ctx context.Context, in *ExportSchemasRequest, opts ...grpc.CallOption) (*ExportSchemasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportSchemasResponse)
+	err := c.cc.Invoke(ctx, Daemon_ExportSchemas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) DBCDCConfig(ctx context.Context, in *DBCDCConfigRequest, opts ...grpc.CallOption) (*DBCDCConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DBCDCConfigResponse)
+	err := c.cc.Invoke(ctx, Daemon_DBCDCConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) DBCDCStream(ctx context.Context, in *DBCDCStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[12], Daemon_DBCDCStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DBCDCStreamRequest, CommandMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBCDCStreamClient = grpc.ServerStreamingClient[CommandMessage]
+
+func (c *daemonClient) AttachLogs(ctx context.Context, in *AttachLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[13], Daemon_AttachLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AttachLogsRequest, CommandMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_AttachLogsClient = grpc.ServerStreamingClient[CommandMessage]
+
+func (c *daemonClient) ResumeStream(ctx context.Context, in *ResumeStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CommandMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[14], Daemon_ResumeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ResumeStreamRequest, CommandMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_ResumeStreamClient = grpc.ServerStreamingClient[CommandMessage]
+
+func (c *daemonClient) CancelStream(ctx context.Context, in *CancelStreamRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Daemon_CancelStream_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaemonServer is the server API for Daemon service.
+// All implementations must embed UnimplementedDaemonServer
+// for forward compatibility.
+type DaemonServer interface {
+	// Run runs the application.
+	Run(*RunRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// ListRuns lists the apps currently running and the addresses they listen on.
+	ListRuns(context.Context, *ListRunsRequest) (*ListRunsResponse, error)
+	// AttachRun attaches to the output of an app started with detach set.
+	AttachRun(*AttachRunRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// StopRun stops a running app.
+	StopRun(context.Context, *StopRunRequest) (*emptypb.Empty, error)
+	// ControlRun controls a running app, such as restarting it,
+	// for the interactive keyboard controls of 'encore run'.
+	ControlRun(context.Context, *ControlRunRequest) (*emptypb.Empty, error)
+	// ListRunSessions lists the recorded run sessions of an app, most recent first.
+	ListRunSessions(context.Context, *ListRunSessionsRequest) (*ListRunSessionsResponse, error)
+	// GetRunSession returns a recorded run session along with its logs and traces.
+	GetRunSession(context.Context, *GetRunSessionRequest) (*GetRunSessionResponse, error)
+	// RunSpec runs a list of HTTP requests against a freshly-started app and
+	// streams the results. Designed for non-interactive agents.
+	RunSpec(*RunSpecRequest, grpc.ServerStreamingServer[RunSpecMessage]) error
+	// Test runs tests.
+	Test(*TestRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// TestSpec returns the specification for how to run tests.
+	TestSpec(context.Context, *TestSpecRequest) (*TestSpecResponse, error)
+	// ExecScript executes a one-off script.
+	ExecScript(*ExecScriptRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// ExecSpec returns the specification for how to run an exec command.
+	// It streams progress messages during setup, then sends the spec as the final message.
+	ExecSpec(*ExecSpecRequest, grpc.ServerStreamingServer[ExecSpecMessage]) error
+	// Check checks the app for compilation errors.
+	Check(*CheckRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// Export exports the app in various formats.
+	Export(*ExportRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// DBConnect starts the database and returns the DSN for connecting to it.
+	DBConnect(context.Context, *DBConnectRequest) (*DBConnectResponse, error)
+	// DBProxy starts a local database proxy for connecting to remote databases
+	// on the encore.dev platform.
+	DBProxy(*DBProxyRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// DBReset resets the given databases, recreating them from scratch.
+	DBReset(*DBResetRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// DBSeed applies the seeds declared for the app's databases
+	// that haven't been applied in the namespace.
+	DBSeed(*DBSeedRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// DBSeedStatus lists the seeds declared for the app's databases,
+	// and whether they've been applied in the namespace.
+	DBSeedStatus(context.Context, *DBSeedStatusRequest) (*DBSeedStatusResponse, error)
+	// DBMigrationPlan reports the migrations that would be applied to the app's
+	// databases in a namespace or cloud environment, without applying them.
+	DBMigrationPlan(context.Context, *DBMigrationPlanRequest) (*DBMigrationPlanResponse, error)
+	// DBRollback rolls back the most recently applied migrations of a database
+	// in a namespace or cloud environment by running their down migrations.
+	DBRollback(*DBRollbackRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// DBDiff compares the live schema of a database against the schema its
+	// migrations produce, or another environment, and drafts a migration for the difference.
+	DBDiff(context.Context, *DBDiffRequest) (*DBDiffResponse, error)
+	// DBSnapshotCreate dumps the app's databases in a namespace to a snapshot archive.
+	DBSnapshotCreate(context.Context, *DBSnapshotCreateRequest) (*DBSnapshotResponse, error)
+	// DBSnapshotRestore restores the databases in a snapshot archive into a namespace.
+	DBSnapshotRestore(context.Context, *DBSnapshotRestoreRequest) (*DBSnapshotResponse, error)
+	// DBQueryLog returns the queries an app made to its local databases,
+	// linked with the traces of the requests that made them.
+	DBQueryLog(context.Context, *DBQueryLogRequest) (*DBQueryLogResponse, error)
+	// GenClient generates a client based on the app's API.
+	GenClient(context.Context, *GenClientRequest) (*GenClientResponse, error)
+	// GenWrappers generates user-facing wrapper code.
+	GenWrappers(context.Context, *GenWrappersRequest) (*GenWrappersResponse, error)
+	// SecretsRefresh tells the daemon to refresh the local development secrets
+	// for the given application.
+	SecretsRefresh(context.Context, *SecretsRefreshRequest) (*SecretsRefreshResponse, error)
+	// Version reports the daemon version.
+	Version(context.Context, *emptypb.Empty) (*VersionResponse, error)
+	// CreateNamespace creates a new infra namespace.
+	CreateNamespace(context.Context, *CreateNamespaceRequest) (*Namespace, error)
+	// SwitchNamespace switches the active infra namespace.
+	SwitchNamespace(context.Context, *SwitchNamespaceRequest) (*Namespace, error)
+	// ListNamespaces lists all namespaces for the given app.
+	ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
+	// DeleteNamespace deletes an infra namespace.
+	DeleteNamespace(context.Context, *DeleteNamespaceRequest) (*emptypb.Empty, error)
+	DumpMeta(context.Context, *DumpMetaRequest) (*DumpMetaResponse, error)
+	// Telemetry enables or disables telemetry.
+	Telemetry(context.Context, *TelemetryConfig) (*emptypb.Empty, error)
+	// InitTutorial sets the tutorial flag of the app
+	CreateApp(context.Context, *CreateAppRequest) (*CreateAppResponse, error)
+	// AnalyzeDeadlines analyzes recorded traces for requests whose
+	// deadline was exceeded, whose context was canceled, or that
+	// did not receive the deadline of their caller.
+	AnalyzeDeadlines(context.Context, *AnalyzeDeadlinesRequest) (*AnalyzeDeadlinesResponse, error)
+	// UsageReport attributes the database, cache, Pub/Sub and external HTTP
+	// usage in recorded traces to requests, aggregated by endpoint or user.
+	UsageReport(context.Context, *UsageReportRequest) (*UsageReportResponse, error)
+	// DebugBundles returns the debug bundles captured for failed requests
+	// of an app run with debug bundles enabled.
+	DebugBundles(context.Context, *DebugBundlesRequest) (*DebugBundlesResponse, error)
+	// AddLogpoint adds a logpoint to a service of a running app: a location at
+	// which expressions are evaluated and written to the run's output, without
+	// changing the code or restarting the service.
+	AddLogpoint(context.Context, *AddLogpointRequest) (*Logpoint, error)
+	// ListLogpoints lists the logpoints of a running app.
+	ListLogpoints(context.Context, *ListLogpointsRequest) (*ListLogpointsResponse, error)
+	// RemoveLogpoint removes a logpoint of a running app.
+	RemoveLogpoint(context.Context, *RemoveLogpointRequest) (*emptypb.Empty, error)
+	// GoroutineDump captures goroutine dumps of the processes of a running app,
+	// annotated with the goroutines that look deadlocked.
+	GoroutineDump(context.Context, *GoroutineDumpRequest) (*GoroutineDumpResponse, error)
+	// RetentionDryRun reports what the data retention policies of a running app
+	// would delete if enforced now, and what deleting a subject would delete.
+	RetentionDryRun(context.Context, *RetentionDryRunRequest) (*RetentionDryRunResponse, error)
+	// StartupReport reports where the time went when the processes
+	// of a running app started.
+	StartupReport(context.Context, *StartupReportRequest) (*StartupReportResponse, error)
+	// VulnScan reports known vulnerabilities in the dependencies of an app,
+	// scanning them if they changed since the last scan.
+	VulnScan(context.Context, *VulnScanRequest) (*VulnScanResponse, error)
+	// LicenseReport reports the licenses of the dependencies of an app
+	// and the dependencies licensed under licenses the app denies.
+	LicenseReport(context.Context, *LicenseReportRequest) (*LicenseReportResponse, error)
+	// BuildProvenance reports the provenance metadata that building
+	// the app in its current state would embed in its artifacts.
+	BuildProvenance(context.Context, *BuildProvenanceRequest) (*BuildProvenanceResponse, error)
+	// ExportSchemas exports JSON Schema or Avro definitions of the
+	// request, response and Pub/Sub message types of an app.
+	ExportSchemas(context.Context, *ExportSchemasRequest) (*ExportSchemasResponse, error)
+	// DBCDCConfig generates change data capture configuration for an app's databases.
+	DBCDCConfig(context.Context, *DBCDCConfigRequest) (*DBCDCConfigResponse, error)
+	// DBCDCStream streams change events from a local database to a Pub/Sub topic
+	// of the running app, for testing data pipelines.
+	DBCDCStream(*DBCDCStreamRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// AttachLogs streams the output of an app started with 'encore run'.
+	AttachLogs(*AttachLogsRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// ResumeStream reattaches to the output stream of a resumable command
+	// after the connection to the daemon was interrupted.
+	ResumeStream(*ResumeStreamRequest, grpc.ServerStreamingServer[CommandMessage]) error
+	// CancelStream cancels a resumable command.
+	CancelStream(context.Context, *CancelStreamRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedDaemonServer()
+}
+
+// UnimplementedDaemonServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDaemonServer struct{}
+
+func (UnimplementedDaemonServer) Run(*RunRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Run not implemented")
+}
+func (UnimplementedDaemonServer) ListRuns(context.Context, *ListRunsRequest) (*ListRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRuns not implemented")
+}
+func (UnimplementedDaemonServer) AttachRun(*AttachRunRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method AttachRun not implemented")
+}
+func (UnimplementedDaemonServer) StopRun(context.Context, *StopRunRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopRun not implemented")
+}
+func (UnimplementedDaemonServer) ControlRun(context.Context, *ControlRunRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ControlRun not implemented")
+}
+func (UnimplementedDaemonServer) ListRunSessions(context.Context, *ListRunSessionsRequest) (*ListRunSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRunSessions not implemented")
+}
+func (UnimplementedDaemonServer) GetRunSession(context.Context, *GetRunSessionRequest) (*GetRunSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunSession not implemented")
+}
+func (UnimplementedDaemonServer) RunSpec(*RunSpecRequest, grpc.ServerStreamingServer[RunSpecMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method RunSpec not implemented")
+}
+func (UnimplementedDaemonServer) Test(*TestRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Test not implemented")
+}
+func (UnimplementedDaemonServer) TestSpec(context.Context, *TestSpecRequest) (*TestSpecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestSpec not implemented")
+}
+func (UnimplementedDaemonServer) ExecScript(*ExecScriptRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecScript not implemented")
+}
+func (UnimplementedDaemonServer) ExecSpec(*ExecSpecRequest, grpc.ServerStreamingServer[ExecSpecMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecSpec not implemented")
+}
+func (UnimplementedDaemonServer) Check(*CheckRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedDaemonServer) Export(*ExportRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedDaemonServer) DBConnect(context.Context, *DBConnectRequest) (*DBConnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DBConnect not implemented")
+}
+func (UnimplementedDaemonServer) DBProxy(*DBProxyRequest, grpc.ServerStreamingServer[CommandMessage]) error {
 	return status.Errorf(codes.Unimplemented, "method DBProxy not implemented")
 }
 func (UnimplementedDaemonServer) DBReset(*DBResetRequest, grpc.ServerStreamingServer[CommandMessage]) error {
 	return status.Errorf(codes.Unimplemented, "method DBReset not implemented")
 }
+func (UnimplementedDaemonServer) DBSeed(*DBSeedRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method DBSeed not implemented")
+}
+func (UnimplementedDaemonServer) DBSeedStatus(context.Context, *DBSeedStatusRequest) (*DBSeedStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DBSeedStatus not implemented")
+}
+func (UnimplementedDaemonServer) DBMigrationPlan(context.Context, *DBMigrationPlanRequest) (*DBMigrationPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DBMigrationPlan not implemented")
+}
+func (UnimplementedDaemonServer) DBRollback(*DBRollbackRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method DBRollback not implemented")
+}
+func (UnimplementedDaemonServer) DBDiff(context.Context, *DBDiffRequest) (*DBDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DBDiff not implemented")
+}
+func (UnimplementedDaemonServer) DBSnapshotCreate(context.Context, *DBSnapshotCreateRequest) (*DBSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DBSnapshotCreate not implemented")
+}
+func (UnimplementedDaemonServer) DBSnapshotRestore(context.Context, *DBSnapshotRestoreRequest) (*DBSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DBSnapshotRestore not implemented")
+}
+func (UnimplementedDaemonServer) DBQueryLog(context.Context, *DBQueryLogRequest) (*DBQueryLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DBQueryLog not implemented")
+}
 func (UnimplementedDaemonServer) GenClient(context.Context, *GenClientRequest) (*GenClientResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenClient not implemented")
 }
@@ -533,6 +1153,60 @@ func (UnimplementedDaemonServer) Telemetry(context.Context, *TelemetryConfig) (*
 func (UnimplementedDaemonServer) CreateApp(context.Context, *CreateAppRequest) (*CreateAppResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateApp not implemented")
 }
+func (UnimplementedDaemonServer) AnalyzeDeadlines(context.Context, *AnalyzeDeadlinesRequest) (*AnalyzeDeadlinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeDeadlines not implemented")
+}
+func (UnimplementedDaemonServer) UsageReport(context.Context, *UsageReportRequest) (*UsageReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UsageReport not implemented")
+}
+func (UnimplementedDaemonServer) DebugBundles(context.Context, *DebugBundlesRequest) (*DebugBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DebugBundles not implemented")
+}
+func (UnimplementedDaemonServer) AddLogpoint(context.Context, *AddLogpointRequest) (*Logpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddLogpoint not implemented")
+}
+func (UnimplementedDaemonServer) ListLogpoints(context.Context, *ListLogpointsRequest) (*ListLogpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLogpoints not implemented")
+}
+func (UnimplementedDaemonServer) RemoveLogpoint(context.Context, *RemoveLogpointRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveLogpoint not implemented")
+}
+func (UnimplementedDaemonServer) GoroutineDump(context.Context, *GoroutineDumpRequest) (*GoroutineDumpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GoroutineDump not implemented")
+}
+func (UnimplementedDaemonServer) RetentionDryRun(context.Context, *RetentionDryRunRequest) (*RetentionDryRunResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetentionDryRun not implemented")
+}
+func (UnimplementedDaemonServer) StartupReport(context.Context, *StartupReportRequest) (*StartupReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartupReport not implemented")
+}
+func (UnimplementedDaemonServer) VulnScan(context.Context, *VulnScanRequest) (*VulnScanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VulnScan not implemented")
+}
+func (UnimplementedDaemonServer) LicenseReport(context.Context, *LicenseReportRequest) (*LicenseReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LicenseReport not implemented")
+}
+func (UnimplementedDaemonServer) BuildProvenance(context.Context, *BuildProvenanceRequest) (*BuildProvenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildProvenance not implemented")
+}
+func (UnimplementedDaemonServer) ExportSchemas(context.Context, *ExportSchemasRequest) (*ExportSchemasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSchemas not implemented")
+}
+func (UnimplementedDaemonServer) DBCDCConfig(context.Context, *DBCDCConfigRequest) (*DBCDCConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DBCDCConfig not implemented")
+}
+func (UnimplementedDaemonServer) DBCDCStream(*DBCDCStreamRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method DBCDCStream not implemented")
+}
+func (UnimplementedDaemonServer) AttachLogs(*AttachLogsRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method AttachLogs not implemented")
+}
+func (UnimplementedDaemonServer) ResumeStream(*ResumeStreamRequest, grpc.ServerStreamingServer[CommandMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method ResumeStream not implemented")
+}
+func (UnimplementedDaemonServer) CancelStream(context.Context, *CancelStreamRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelStream not implemented")
+}
 func (UnimplementedDaemonServer) mustEmbedUnimplementedDaemonServer() {}
 func (UnimplementedDaemonServer) testEmbeddedByValue()                {}
 
@@ -565,324 +1239,858 @@ func _Daemon_Run_Handler(srv interface{}, stream grpc.ServerStream) error {
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Daemon_RunServer = grpc.ServerStreamingServer[CommandMessage]
 
-func _Daemon_RunSpec_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(RunSpecRequest)
+func _Daemon_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ListRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListRuns(ctx, req.(*ListRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_AttachRun_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AttachRunRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(DaemonServer).RunSpec(m, &grpc.GenericServerStream[RunSpecRequest, RunSpecMessage]{ServerStream: stream})
+	return srv.(DaemonServer).AttachRun(m, &grpc.GenericServerStream[AttachRunRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_AttachRunServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_StopRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).StopRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_StopRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).StopRun(ctx, req.(*StopRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ControlRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ControlRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ControlRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ControlRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ControlRun(ctx, req.(*ControlRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ListRunSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListRunSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ListRunSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListRunSessions(ctx, req.(*ListRunSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_GetRunSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).GetRunSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_GetRunSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).GetRunSession(ctx, req.(*GetRunSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_RunSpec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunSpecRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).RunSpec(m, &grpc.GenericServerStream[RunSpecRequest, RunSpecMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_RunSpecServer = grpc.ServerStreamingServer[RunSpecMessage]
+
+func _Daemon_Test_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).Test(m, &grpc.GenericServerStream[TestRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_TestServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_TestSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestSpecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).TestSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_TestSpec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).TestSpec(ctx, req.(*TestSpecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ExecScript_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecScriptRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).ExecScript(m, &grpc.GenericServerStream[ExecScriptRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_ExecScriptServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_ExecSpec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecSpecRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).ExecSpec(m, &grpc.GenericServerStream[ExecSpecRequest, ExecSpecMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_ExecSpecServer = grpc.ServerStreamingServer[ExecSpecMessage]
+
+func _Daemon_Check_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CheckRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).Check(m, &grpc.GenericServerStream[CheckRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_CheckServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).Export(m, &grpc.GenericServerStream[ExportRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_ExportServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_DBConnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DBConnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DBConnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DBConnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DBConnect(ctx, req.(*DBConnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DBProxy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DBProxyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).DBProxy(m, &grpc.GenericServerStream[DBProxyRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBProxyServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_DBReset_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DBResetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).DBReset(m, &grpc.GenericServerStream[DBResetRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBResetServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_DBSeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DBSeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).DBSeed(m, &grpc.GenericServerStream[DBSeedRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBSeedServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_DBSeedStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DBSeedStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DBSeedStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DBSeedStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DBSeedStatus(ctx, req.(*DBSeedStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DBMigrationPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DBMigrationPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DBMigrationPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DBMigrationPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DBMigrationPlan(ctx, req.(*DBMigrationPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DBRollback_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DBRollbackRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).DBRollback(m, &grpc.GenericServerStream[DBRollbackRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBRollbackServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_DBDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DBDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DBDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DBDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DBDiff(ctx, req.(*DBDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DBSnapshotCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DBSnapshotCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DBSnapshotCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DBSnapshotCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DBSnapshotCreate(ctx, req.(*DBSnapshotCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DBSnapshotRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DBSnapshotRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DBSnapshotRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DBSnapshotRestore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DBSnapshotRestore(ctx, req.(*DBSnapshotRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DBQueryLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DBQueryLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DBQueryLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DBQueryLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DBQueryLog(ctx, req.(*DBQueryLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_GenClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).GenClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_GenClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).GenClient(ctx, req.(*GenClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_GenWrappers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenWrappersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).GenWrappers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_GenWrappers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).GenWrappers(ctx, req.(*GenWrappersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SecretsRefresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretsRefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SecretsRefresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_SecretsRefresh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SecretsRefresh(ctx, req.(*SecretsRefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_Version_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).Version(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_CreateNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).CreateNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_CreateNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).CreateNamespace(ctx, req.(*CreateNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_SwitchNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).SwitchNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_SwitchNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).SwitchNamespace(ctx, req.(*SwitchNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ListNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ListNamespaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListNamespaces(ctx, req.(*ListNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DeleteNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DeleteNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DeleteNamespace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DeleteNamespace(ctx, req.(*DeleteNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DumpMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DumpMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DumpMeta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DumpMeta(ctx, req.(*DumpMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_Telemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TelemetryConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).Telemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_Telemetry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).Telemetry(ctx, req.(*TelemetryConfig))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Daemon_RunSpecServer = grpc.ServerStreamingServer[RunSpecMessage]
-
-func _Daemon_Test_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(TestRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Daemon_CreateApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAppRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(DaemonServer).Test(m, &grpc.GenericServerStream[TestRequest, CommandMessage]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(DaemonServer).CreateApp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_CreateApp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).CreateApp(ctx, req.(*CreateAppRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Daemon_TestServer = grpc.ServerStreamingServer[CommandMessage]
-
-func _Daemon_TestSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TestSpecRequest)
+func _Daemon_AnalyzeDeadlines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeDeadlinesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).TestSpec(ctx, in)
+		return srv.(DaemonServer).AnalyzeDeadlines(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_TestSpec_FullMethodName,
+		FullMethod: Daemon_AnalyzeDeadlines_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).TestSpec(ctx, req.(*TestSpecRequest))
+		return srv.(DaemonServer).AnalyzeDeadlines(ctx, req.(*AnalyzeDeadlinesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_ExecScript_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ExecScriptRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Daemon_UsageReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(DaemonServer).ExecScript(m, &grpc.GenericServerStream[ExecScriptRequest, CommandMessage]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Daemon_ExecScriptServer = grpc.ServerStreamingServer[CommandMessage]
-
-func _Daemon_ExecSpec_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ExecSpecRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	if interceptor == nil {
+		return srv.(DaemonServer).UsageReport(ctx, in)
 	}
-	return srv.(DaemonServer).ExecSpec(m, &grpc.GenericServerStream[ExecSpecRequest, ExecSpecMessage]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Daemon_ExecSpecServer = grpc.ServerStreamingServer[ExecSpecMessage]
-
-func _Daemon_Check_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(CheckRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_UsageReport_FullMethodName,
 	}
-	return srv.(DaemonServer).Check(m, &grpc.GenericServerStream[CheckRequest, CommandMessage]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Daemon_CheckServer = grpc.ServerStreamingServer[CommandMessage]
-
-func _Daemon_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ExportRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).UsageReport(ctx, req.(*UsageReportRequest))
 	}
-	return srv.(DaemonServer).Export(m, &grpc.GenericServerStream[ExportRequest, CommandMessage]{ServerStream: stream})
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Daemon_ExportServer = grpc.ServerStreamingServer[CommandMessage]
-
-func _Daemon_DBConnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DBConnectRequest)
+func _Daemon_DebugBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugBundlesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).DBConnect(ctx, in)
+		return srv.(DaemonServer).DebugBundles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_DBConnect_FullMethodName,
+		FullMethod: Daemon_DebugBundles_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).DBConnect(ctx, req.(*DBConnectRequest))
+		return srv.(DaemonServer).DebugBundles(ctx, req.(*DebugBundlesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_DBProxy_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(DBProxyRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Daemon_AddLogpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddLogpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(DaemonServer).DBProxy(m, &grpc.GenericServerStream[DBProxyRequest, CommandMessage]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Daemon_DBProxyServer = grpc.ServerStreamingServer[CommandMessage]
-
-func _Daemon_DBReset_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(DBResetRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	if interceptor == nil {
+		return srv.(DaemonServer).AddLogpoint(ctx, in)
 	}
-	return srv.(DaemonServer).DBReset(m, &grpc.GenericServerStream[DBResetRequest, CommandMessage]{ServerStream: stream})
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_AddLogpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).AddLogpoint(ctx, req.(*AddLogpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type Daemon_DBResetServer = grpc.ServerStreamingServer[CommandMessage]
-
-func _Daemon_GenClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GenClientRequest)
+func _Daemon_ListLogpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLogpointsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).GenClient(ctx, in)
+		return srv.(DaemonServer).ListLogpoints(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_GenClient_FullMethodName,
+		FullMethod: Daemon_ListLogpoints_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).GenClient(ctx, req.(*GenClientRequest))
+		return srv.(DaemonServer).ListLogpoints(ctx, req.(*ListLogpointsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_GenWrappers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GenWrappersRequest)
+func _Daemon_RemoveLogpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveLogpointRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).GenWrappers(ctx, in)
+		return srv.(DaemonServer).RemoveLogpoint(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_GenWrappers_FullMethodName,
+		FullMethod: Daemon_RemoveLogpoint_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).GenWrappers(ctx, req.(*GenWrappersRequest))
+		return srv.(DaemonServer).RemoveLogpoint(ctx, req.(*RemoveLogpointRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_SecretsRefresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SecretsRefreshRequest)
+func _Daemon_GoroutineDump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoroutineDumpRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).SecretsRefresh(ctx, in)
+		return srv.(DaemonServer).GoroutineDump(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_SecretsRefresh_FullMethodName,
+		FullMethod: Daemon_GoroutineDump_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).SecretsRefresh(ctx, req.(*SecretsRefreshRequest))
+		return srv.(DaemonServer).GoroutineDump(ctx, req.(*GoroutineDumpRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Daemon_RetentionDryRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetentionDryRunRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).Version(ctx, in)
+		return srv.(DaemonServer).RetentionDryRun(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_Version_FullMethodName,
+		FullMethod: Daemon_RetentionDryRun_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).Version(ctx, req.(*emptypb.Empty))
+		return srv.(DaemonServer).RetentionDryRun(ctx, req.(*RetentionDryRunRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_CreateNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateNamespaceRequest)
+func _Daemon_StartupReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartupReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).CreateNamespace(ctx, in)
+		return srv.(DaemonServer).StartupReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_CreateNamespace_FullMethodName,
+		FullMethod: Daemon_StartupReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).CreateNamespace(ctx, req.(*CreateNamespaceRequest))
+		return srv.(DaemonServer).StartupReport(ctx, req.(*StartupReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_SwitchNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SwitchNamespaceRequest)
+func _Daemon_VulnScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VulnScanRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).SwitchNamespace(ctx, in)
+		return srv.(DaemonServer).VulnScan(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_SwitchNamespace_FullMethodName,
+		FullMethod: Daemon_VulnScan_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).SwitchNamespace(ctx, req.(*SwitchNamespaceRequest))
+		return srv.(DaemonServer).VulnScan(ctx, req.(*VulnScanRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_ListNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListNamespacesRequest)
+func _Daemon_LicenseReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LicenseReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).ListNamespaces(ctx, in)
+		return srv.(DaemonServer).LicenseReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_ListNamespaces_FullMethodName,
+		FullMethod: Daemon_LicenseReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).ListNamespaces(ctx, req.(*ListNamespacesRequest))
+		return srv.(DaemonServer).LicenseReport(ctx, req.(*LicenseReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_DeleteNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteNamespaceRequest)
+func _Daemon_BuildProvenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildProvenanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).DeleteNamespace(ctx, in)
+		return srv.(DaemonServer).BuildProvenance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_DeleteNamespace_FullMethodName,
+		FullMethod: Daemon_BuildProvenance_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).DeleteNamespace(ctx, req.(*DeleteNamespaceRequest))
+		return srv.(DaemonServer).BuildProvenance(ctx, req.(*BuildProvenanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_DumpMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DumpMetaRequest)
+func _Daemon_ExportSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSchemasRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).DumpMeta(ctx, in)
+		return srv.(DaemonServer).ExportSchemas(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_DumpMeta_FullMethodName,
+		FullMethod: Daemon_ExportSchemas_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).DumpMeta(ctx, req.(*DumpMetaRequest))
+		return srv.(DaemonServer).ExportSchemas(ctx, req.(*ExportSchemasRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_Telemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TelemetryConfig)
+func _Daemon_DBCDCConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DBCDCConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).Telemetry(ctx, in)
+		return srv.(DaemonServer).DBCDCConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_Telemetry_FullMethodName,
+		FullMethod: Daemon_DBCDCConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).Telemetry(ctx, req.(*TelemetryConfig))
+		return srv.(DaemonServer).DBCDCConfig(ctx, req.(*DBCDCConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Daemon_CreateApp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateAppRequest)
+func _Daemon_DBCDCStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DBCDCStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).DBCDCStream(m, &grpc.GenericServerStream[DBCDCStreamRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_DBCDCStreamServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_AttachLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AttachLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).AttachLogs(m, &grpc.GenericServerStream[AttachLogsRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_AttachLogsServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_ResumeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResumeStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).ResumeStream(m, &grpc.GenericServerStream[ResumeStreamRequest, CommandMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daemon_ResumeStreamServer = grpc.ServerStreamingServer[CommandMessage]
+
+func _Daemon_CancelStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelStreamRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DaemonServer).CreateApp(ctx, in)
+		return srv.(DaemonServer).CancelStream(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Daemon_CreateApp_FullMethodName,
+		FullMethod: Daemon_CancelStream_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServer).CreateApp(ctx, req.(*CreateAppRequest))
+		return srv.(DaemonServer).CancelStream(ctx, req.(*CancelStreamRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -894,6 +2102,26 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "encore.daemon.Daemon",
 	HandlerType: (*DaemonServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRuns",
+			Handler:    _Daemon_ListRuns_Handler,
+		},
+		{
+			MethodName: "StopRun",
+			Handler:    _Daemon_StopRun_Handler,
+		},
+		{
+			MethodName: "ControlRun",
+			Handler:    _Daemon_ControlRun_Handler,
+		},
+		{
+			MethodName: "ListRunSessions",
+			Handler:    _Daemon_ListRunSessions_Handler,
+		},
+		{
+			MethodName: "GetRunSession",
+			Handler:    _Daemon_GetRunSession_Handler,
+		},
 		{
 			MethodName: "TestSpec",
 			Handler:    _Daemon_TestSpec_Handler,
@@ -902,6 +2130,30 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DBConnect",
 			Handler:    _Daemon_DBConnect_Handler,
 		},
+		{
+			MethodName: "DBSeedStatus",
+			Handler:    _Daemon_DBSeedStatus_Handler,
+		},
+		{
+			MethodName: "DBMigrationPlan",
+			Handler:    _Daemon_DBMigrationPlan_Handler,
+		},
+		{
+			MethodName: "DBDiff",
+			Handler:    _Daemon_DBDiff_Handler,
+		},
+		{
+			MethodName: "DBSnapshotCreate",
+			Handler:    _Daemon_DBSnapshotCreate_Handler,
+		},
+		{
+			MethodName: "DBSnapshotRestore",
+			Handler:    _Daemon_DBSnapshotRestore_Handler,
+		},
+		{
+			MethodName: "DBQueryLog",
+			Handler:    _Daemon_DBQueryLog_Handler,
+		},
 		{
 			MethodName: "GenClient",
 			Handler:    _Daemon_GenClient_Handler,
@@ -946,6 +2198,66 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateApp",
 			Handler:    _Daemon_CreateApp_Handler,
 		},
+		{
+			MethodName: "AnalyzeDeadlines",
+			Handler:    _Daemon_AnalyzeDeadlines_Handler,
+		},
+		{
+			MethodName: "UsageReport",
+			Handler:    _Daemon_UsageReport_Handler,
+		},
+		{
+			MethodName: "DebugBundles",
+			Handler:    _Daemon_DebugBundles_Handler,
+		},
+		{
+			MethodName: "AddLogpoint",
+			Handler:    _Daemon_AddLogpoint_Handler,
+		},
+		{
+			MethodName: "ListLogpoints",
+			Handler:    _Daemon_ListLogpoints_Handler,
+		},
+		{
+			MethodName: "RemoveLogpoint",
+			Handler:    _Daemon_RemoveLogpoint_Handler,
+		},
+		{
+			MethodName: "GoroutineDump",
+			Handler:    _Daemon_GoroutineDump_Handler,
+		},
+		{
+			MethodName: "RetentionDryRun",
+			Handler:    _Daemon_RetentionDryRun_Handler,
+		},
+		{
+			MethodName: "StartupReport",
+			Handler:    _Daemon_StartupReport_Handler,
+		},
+		{
+			MethodName: "VulnScan",
+			Handler:    _Daemon_VulnScan_Handler,
+		},
+		{
+			MethodName: "LicenseReport",
+			Handler:    _Daemon_LicenseReport_Handler,
+		},
+		{
+			MethodName: "BuildProvenance",
+			Handler:    _Daemon_BuildProvenance_Handler,
+		},
+		{
+			MethodName: "ExportSchemas",
+			Handler:    _Daemon_ExportSchemas_Handler,
+		},
+		{
+			MethodName: "DBCDCConfig",
+			Handler:    _Daemon_DBCDCConfig_Handler,
+		},
+		{
+			MethodName: "CancelStream",
+			Handler:    _Daemon_CancelStream_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -953,6 +2265,11 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Daemon_Run_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "AttachRun",
+			Handler:       _Daemon_AttachRun_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "RunSpec",
 			Handler:       _Daemon_RunSpec_Handler,
@@ -993,6 +2310,31 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Daemon_DBReset_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DBSeed",
+			Handler:       _Daemon_DBSeed_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DBRollback",
+			Handler:       _Daemon_DBRollback_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DBCDCStream",
+			Handler:       _Daemon_DBCDCStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AttachLogs",
+			Handler:       _Daemon_AttachLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ResumeStream",
+			Handler:       _Daemon_ResumeStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "encore/daemon/daemon.proto",
 }
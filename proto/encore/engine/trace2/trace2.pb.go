@@ -296,7 +296,7 @@ func (x DBTransactionEnd_CompletionType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DBTransactionEnd_CompletionType.Descriptor instead.
 func (DBTransactionEnd_CompletionType) EnumDescriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{20, 0}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{21, 0}
 }
 
 type CacheCallEnd_Result int32
@@ -351,7 +351,7 @@ func (x CacheCallEnd_Result) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CacheCallEnd_Result.Descriptor instead.
 func (CacheCallEnd_Result) EnumDescriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{28, 0}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{29, 0}
 }
 
 // Note: These values don't match the values used by the binary trace protocol,
@@ -410,7 +410,7 @@ func (x LogMessage_Level) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LogMessage_Level.Descriptor instead.
 func (LogMessage_Level) EnumDescriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{60, 0}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{61, 0}
 }
 
 // SpanSummary summarizes a span for display purposes.
@@ -1157,7 +1157,13 @@ type RequestSpanStart struct {
 	ExtCorrelationId *string                `protobuf:"bytes,8,opt,name=ext_correlation_id,json=extCorrelationId,proto3,oneof" json:"ext_correlation_id,omitempty"`
 	Uid              *string                `protobuf:"bytes,9,opt,name=uid,proto3,oneof" json:"uid,omitempty"`
 	// mocked is true if the request was handled by a mock
-	Mocked        bool `protobuf:"varint,10,opt,name=mocked,proto3" json:"mocked,omitempty"`
+	Mocked bool `protobuf:"varint,10,opt,name=mocked,proto3" json:"mocked,omitempty"`
+	// deadline_remaining_nanos is the time remaining until the request
+	// context's deadline when the request started. Unset if it had no deadline.
+	DeadlineRemainingNanos *int64 `protobuf:"varint,11,opt,name=deadline_remaining_nanos,json=deadlineRemainingNanos,proto3,oneof" json:"deadline_remaining_nanos,omitempty"`
+	// client is the metadata about the client that made the request,
+	// if the gateway enriches requests with client metadata.
+	Client        *ClientInfo `protobuf:"bytes,12,opt,name=client,proto3,oneof" json:"client,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1262,6 +1268,128 @@ func (x *RequestSpanStart) GetMocked() bool {
 	return false
 }
 
+func (x *RequestSpanStart) GetDeadlineRemainingNanos() int64 {
+	if x != nil && x.DeadlineRemainingNanos != nil {
+		return *x.DeadlineRemainingNanos
+	}
+	return 0
+}
+
+func (x *RequestSpanStart) GetClient() *ClientInfo {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type ClientInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ip             string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	CountryCode    string                 `protobuf:"bytes,2,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Country        string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Region         string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	City           string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	Browser        string                 `protobuf:"bytes,6,opt,name=browser,proto3" json:"browser,omitempty"`
+	BrowserVersion string                 `protobuf:"bytes,7,opt,name=browser_version,json=browserVersion,proto3" json:"browser_version,omitempty"`
+	Os             string                 `protobuf:"bytes,8,opt,name=os,proto3" json:"os,omitempty"`
+	Device         string                 `protobuf:"bytes,9,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClientInfo) Reset() {
+	*x = ClientInfo{}
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientInfo) ProtoMessage() {}
+
+func (x *ClientInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientInfo.ProtoReflect.Descriptor instead.
+func (*ClientInfo) Descriptor() ([]byte, []int) {
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ClientInfo) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetBrowser() string {
+	if x != nil {
+		return x.Browser
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetBrowserVersion() string {
+	if x != nil {
+		return x.BrowserVersion
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
 type RequestSpanEnd struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Repeat service/endpoint name here to make it possible
@@ -1273,13 +1401,19 @@ type RequestSpanEnd struct {
 	ResponsePayload []byte            `protobuf:"bytes,5,opt,name=response_payload,json=responsePayload,proto3,oneof" json:"response_payload,omitempty"`
 	CallerEventId   *uint64           `protobuf:"varint,6,opt,name=caller_event_id,json=callerEventId,proto3,oneof" json:"caller_event_id,omitempty"`
 	Uid             *string           `protobuf:"bytes,7,opt,name=uid,proto3,oneof" json:"uid,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// cancel_cause is the cause of the request context's cancellation,
+	// if it was canceled before the request completed.
+	CancelCause *string `protobuf:"bytes,8,opt,name=cancel_cause,json=cancelCause,proto3,oneof" json:"cancel_cause,omitempty"`
+	// deadline_exceeded is true if the request completed after
+	// its context's deadline.
+	DeadlineExceeded bool `protobuf:"varint,9,opt,name=deadline_exceeded,json=deadlineExceeded,proto3" json:"deadline_exceeded,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *RequestSpanEnd) Reset() {
 	*x = RequestSpanEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[7]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1291,7 +1425,7 @@ func (x *RequestSpanEnd) String() string {
 func (*RequestSpanEnd) ProtoMessage() {}
 
 func (x *RequestSpanEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[7]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1304,7 +1438,7 @@ func (x *RequestSpanEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestSpanEnd.ProtoReflect.Descriptor instead.
 func (*RequestSpanEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{7}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RequestSpanEnd) GetServiceName() string {
@@ -1356,6 +1490,20 @@ func (x *RequestSpanEnd) GetUid() string {
 	return ""
 }
 
+func (x *RequestSpanEnd) GetCancelCause() string {
+	if x != nil && x.CancelCause != nil {
+		return *x.CancelCause
+	}
+	return ""
+}
+
+func (x *RequestSpanEnd) GetDeadlineExceeded() bool {
+	if x != nil {
+		return x.DeadlineExceeded
+	}
+	return false
+}
+
 type AuthSpanStart struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
@@ -1367,7 +1515,7 @@ type AuthSpanStart struct {
 
 func (x *AuthSpanStart) Reset() {
 	*x = AuthSpanStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[8]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1379,7 +1527,7 @@ func (x *AuthSpanStart) String() string {
 func (*AuthSpanStart) ProtoMessage() {}
 
 func (x *AuthSpanStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[8]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1392,7 +1540,7 @@ func (x *AuthSpanStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthSpanStart.ProtoReflect.Descriptor instead.
 func (*AuthSpanStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{8}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AuthSpanStart) GetServiceName() string {
@@ -1430,7 +1578,7 @@ type AuthSpanEnd struct {
 
 func (x *AuthSpanEnd) Reset() {
 	*x = AuthSpanEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[9]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1442,7 +1590,7 @@ func (x *AuthSpanEnd) String() string {
 func (*AuthSpanEnd) ProtoMessage() {}
 
 func (x *AuthSpanEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[9]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1455,7 +1603,7 @@ func (x *AuthSpanEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthSpanEnd.ProtoReflect.Descriptor instead.
 func (*AuthSpanEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{9}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AuthSpanEnd) GetServiceName() string {
@@ -1501,7 +1649,7 @@ type PubsubMessageSpanStart struct {
 
 func (x *PubsubMessageSpanStart) Reset() {
 	*x = PubsubMessageSpanStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[10]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1513,7 +1661,7 @@ func (x *PubsubMessageSpanStart) String() string {
 func (*PubsubMessageSpanStart) ProtoMessage() {}
 
 func (x *PubsubMessageSpanStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[10]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1526,7 +1674,7 @@ func (x *PubsubMessageSpanStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubsubMessageSpanStart.ProtoReflect.Descriptor instead.
 func (*PubsubMessageSpanStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{10}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PubsubMessageSpanStart) GetServiceName() string {
@@ -1592,7 +1740,7 @@ type PubsubMessageSpanEnd struct {
 
 func (x *PubsubMessageSpanEnd) Reset() {
 	*x = PubsubMessageSpanEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[11]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1604,7 +1752,7 @@ func (x *PubsubMessageSpanEnd) String() string {
 func (*PubsubMessageSpanEnd) ProtoMessage() {}
 
 func (x *PubsubMessageSpanEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[11]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1617,7 +1765,7 @@ func (x *PubsubMessageSpanEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubsubMessageSpanEnd.ProtoReflect.Descriptor instead.
 func (*PubsubMessageSpanEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{11}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PubsubMessageSpanEnd) GetServiceName() string {
@@ -1661,7 +1809,7 @@ type TestSpanStart struct {
 
 func (x *TestSpanStart) Reset() {
 	*x = TestSpanStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[12]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1673,7 +1821,7 @@ func (x *TestSpanStart) String() string {
 func (*TestSpanStart) ProtoMessage() {}
 
 func (x *TestSpanStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[12]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1686,7 +1834,7 @@ func (x *TestSpanStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestSpanStart.ProtoReflect.Descriptor instead.
 func (*TestSpanStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{12}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TestSpanStart) GetServiceName() string {
@@ -1737,7 +1885,7 @@ type TestSpanEnd struct {
 
 func (x *TestSpanEnd) Reset() {
 	*x = TestSpanEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[13]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1749,7 +1897,7 @@ func (x *TestSpanEnd) String() string {
 func (*TestSpanEnd) ProtoMessage() {}
 
 func (x *TestSpanEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[13]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1762,7 +1910,7 @@ func (x *TestSpanEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestSpanEnd.ProtoReflect.Descriptor instead.
 func (*TestSpanEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{13}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *TestSpanEnd) GetServiceName() string {
@@ -1842,7 +1990,7 @@ type SpanEvent struct {
 
 func (x *SpanEvent) Reset() {
 	*x = SpanEvent{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[14]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1854,7 +2002,7 @@ func (x *SpanEvent) String() string {
 func (*SpanEvent) ProtoMessage() {}
 
 func (x *SpanEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[14]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1867,7 +2015,7 @@ func (x *SpanEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpanEvent.ProtoReflect.Descriptor instead.
 func (*SpanEvent) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{14}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *SpanEvent) GetGoid() uint32 {
@@ -2303,7 +2451,7 @@ type RPCCallStart struct {
 
 func (x *RPCCallStart) Reset() {
 	*x = RPCCallStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[15]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2315,7 +2463,7 @@ func (x *RPCCallStart) String() string {
 func (*RPCCallStart) ProtoMessage() {}
 
 func (x *RPCCallStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[15]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2328,7 +2476,7 @@ func (x *RPCCallStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPCCallStart.ProtoReflect.Descriptor instead.
 func (*RPCCallStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{15}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *RPCCallStart) GetTargetServiceName() string {
@@ -2361,7 +2509,7 @@ type RPCCallEnd struct {
 
 func (x *RPCCallEnd) Reset() {
 	*x = RPCCallEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[16]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2373,7 +2521,7 @@ func (x *RPCCallEnd) String() string {
 func (*RPCCallEnd) ProtoMessage() {}
 
 func (x *RPCCallEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[16]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2386,7 +2534,7 @@ func (x *RPCCallEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPCCallEnd.ProtoReflect.Descriptor instead.
 func (*RPCCallEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{16}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RPCCallEnd) GetErr() *Error {
@@ -2404,7 +2552,7 @@ type GoroutineStart struct {
 
 func (x *GoroutineStart) Reset() {
 	*x = GoroutineStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[17]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2416,7 +2564,7 @@ func (x *GoroutineStart) String() string {
 func (*GoroutineStart) ProtoMessage() {}
 
 func (x *GoroutineStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[17]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2429,7 +2577,7 @@ func (x *GoroutineStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoroutineStart.ProtoReflect.Descriptor instead.
 func (*GoroutineStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{17}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{18}
 }
 
 type GoroutineEnd struct {
@@ -2440,7 +2588,7 @@ type GoroutineEnd struct {
 
 func (x *GoroutineEnd) Reset() {
 	*x = GoroutineEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[18]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2452,7 +2600,7 @@ func (x *GoroutineEnd) String() string {
 func (*GoroutineEnd) ProtoMessage() {}
 
 func (x *GoroutineEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[18]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2465,7 +2613,7 @@ func (x *GoroutineEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoroutineEnd.ProtoReflect.Descriptor instead.
 func (*GoroutineEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{18}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{19}
 }
 
 type DBTransactionStart struct {
@@ -2477,7 +2625,7 @@ type DBTransactionStart struct {
 
 func (x *DBTransactionStart) Reset() {
 	*x = DBTransactionStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[19]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2489,7 +2637,7 @@ func (x *DBTransactionStart) String() string {
 func (*DBTransactionStart) ProtoMessage() {}
 
 func (x *DBTransactionStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[19]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2502,7 +2650,7 @@ func (x *DBTransactionStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DBTransactionStart.ProtoReflect.Descriptor instead.
 func (*DBTransactionStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{19}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DBTransactionStart) GetStack() *StackTrace {
@@ -2523,7 +2671,7 @@ type DBTransactionEnd struct {
 
 func (x *DBTransactionEnd) Reset() {
 	*x = DBTransactionEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[20]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2535,7 +2683,7 @@ func (x *DBTransactionEnd) String() string {
 func (*DBTransactionEnd) ProtoMessage() {}
 
 func (x *DBTransactionEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[20]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2548,7 +2696,7 @@ func (x *DBTransactionEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DBTransactionEnd.ProtoReflect.Descriptor instead.
 func (*DBTransactionEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{20}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *DBTransactionEnd) GetCompletion() DBTransactionEnd_CompletionType {
@@ -2582,7 +2730,7 @@ type DBQueryStart struct {
 
 func (x *DBQueryStart) Reset() {
 	*x = DBQueryStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[21]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2594,7 +2742,7 @@ func (x *DBQueryStart) String() string {
 func (*DBQueryStart) ProtoMessage() {}
 
 func (x *DBQueryStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[21]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2607,7 +2755,7 @@ func (x *DBQueryStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DBQueryStart.ProtoReflect.Descriptor instead.
 func (*DBQueryStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{21}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *DBQueryStart) GetQuery() string {
@@ -2633,7 +2781,7 @@ type DBQueryEnd struct {
 
 func (x *DBQueryEnd) Reset() {
 	*x = DBQueryEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[22]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2645,7 +2793,7 @@ func (x *DBQueryEnd) String() string {
 func (*DBQueryEnd) ProtoMessage() {}
 
 func (x *DBQueryEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[22]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2658,7 +2806,7 @@ func (x *DBQueryEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DBQueryEnd.ProtoReflect.Descriptor instead.
 func (*DBQueryEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{22}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *DBQueryEnd) GetErr() *Error {
@@ -2679,7 +2827,7 @@ type PubsubPublishStart struct {
 
 func (x *PubsubPublishStart) Reset() {
 	*x = PubsubPublishStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[23]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2691,7 +2839,7 @@ func (x *PubsubPublishStart) String() string {
 func (*PubsubPublishStart) ProtoMessage() {}
 
 func (x *PubsubPublishStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[23]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2704,7 +2852,7 @@ func (x *PubsubPublishStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubsubPublishStart.ProtoReflect.Descriptor instead.
 func (*PubsubPublishStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{23}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *PubsubPublishStart) GetTopic() string {
@@ -2738,7 +2886,7 @@ type PubsubPublishEnd struct {
 
 func (x *PubsubPublishEnd) Reset() {
 	*x = PubsubPublishEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[24]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2750,7 +2898,7 @@ func (x *PubsubPublishEnd) String() string {
 func (*PubsubPublishEnd) ProtoMessage() {}
 
 func (x *PubsubPublishEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[24]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2763,7 +2911,7 @@ func (x *PubsubPublishEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubsubPublishEnd.ProtoReflect.Descriptor instead.
 func (*PubsubPublishEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{24}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *PubsubPublishEnd) GetMessageId() string {
@@ -2789,7 +2937,7 @@ type ServiceInitStart struct {
 
 func (x *ServiceInitStart) Reset() {
 	*x = ServiceInitStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[25]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2801,7 +2949,7 @@ func (x *ServiceInitStart) String() string {
 func (*ServiceInitStart) ProtoMessage() {}
 
 func (x *ServiceInitStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[25]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2814,7 +2962,7 @@ func (x *ServiceInitStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceInitStart.ProtoReflect.Descriptor instead.
 func (*ServiceInitStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{25}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ServiceInitStart) GetService() string {
@@ -2833,7 +2981,7 @@ type ServiceInitEnd struct {
 
 func (x *ServiceInitEnd) Reset() {
 	*x = ServiceInitEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[26]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2845,7 +2993,7 @@ func (x *ServiceInitEnd) String() string {
 func (*ServiceInitEnd) ProtoMessage() {}
 
 func (x *ServiceInitEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[26]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2858,7 +3006,7 @@ func (x *ServiceInitEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceInitEnd.ProtoReflect.Descriptor instead.
 func (*ServiceInitEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{26}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ServiceInitEnd) GetErr() *Error {
@@ -2880,7 +3028,7 @@ type CacheCallStart struct {
 
 func (x *CacheCallStart) Reset() {
 	*x = CacheCallStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[27]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2892,7 +3040,7 @@ func (x *CacheCallStart) String() string {
 func (*CacheCallStart) ProtoMessage() {}
 
 func (x *CacheCallStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[27]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2905,7 +3053,7 @@ func (x *CacheCallStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheCallStart.ProtoReflect.Descriptor instead.
 func (*CacheCallStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{27}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *CacheCallStart) GetOperation() string {
@@ -2946,7 +3094,7 @@ type CacheCallEnd struct {
 
 func (x *CacheCallEnd) Reset() {
 	*x = CacheCallEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[28]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2958,7 +3106,7 @@ func (x *CacheCallEnd) String() string {
 func (*CacheCallEnd) ProtoMessage() {}
 
 func (x *CacheCallEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[28]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2971,7 +3119,7 @@ func (x *CacheCallEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheCallEnd.ProtoReflect.Descriptor instead.
 func (*CacheCallEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{28}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *CacheCallEnd) GetResult() CacheCallEnd_Result {
@@ -3000,7 +3148,7 @@ type BucketObjectUploadStart struct {
 
 func (x *BucketObjectUploadStart) Reset() {
 	*x = BucketObjectUploadStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[29]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3012,7 +3160,7 @@ func (x *BucketObjectUploadStart) String() string {
 func (*BucketObjectUploadStart) ProtoMessage() {}
 
 func (x *BucketObjectUploadStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[29]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3025,7 +3173,7 @@ func (x *BucketObjectUploadStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectUploadStart.ProtoReflect.Descriptor instead.
 func (*BucketObjectUploadStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{29}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *BucketObjectUploadStart) GetBucket() string {
@@ -3067,7 +3215,7 @@ type BucketObjectUploadEnd struct {
 
 func (x *BucketObjectUploadEnd) Reset() {
 	*x = BucketObjectUploadEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[30]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3079,7 +3227,7 @@ func (x *BucketObjectUploadEnd) String() string {
 func (*BucketObjectUploadEnd) ProtoMessage() {}
 
 func (x *BucketObjectUploadEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[30]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3092,7 +3240,7 @@ func (x *BucketObjectUploadEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectUploadEnd.ProtoReflect.Descriptor instead.
 func (*BucketObjectUploadEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{30}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *BucketObjectUploadEnd) GetErr() *Error {
@@ -3128,7 +3276,7 @@ type BucketObjectDownloadStart struct {
 
 func (x *BucketObjectDownloadStart) Reset() {
 	*x = BucketObjectDownloadStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[31]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3140,7 +3288,7 @@ func (x *BucketObjectDownloadStart) String() string {
 func (*BucketObjectDownloadStart) ProtoMessage() {}
 
 func (x *BucketObjectDownloadStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[31]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3153,7 +3301,7 @@ func (x *BucketObjectDownloadStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectDownloadStart.ProtoReflect.Descriptor instead.
 func (*BucketObjectDownloadStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{31}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *BucketObjectDownloadStart) GetBucket() string {
@@ -3194,7 +3342,7 @@ type BucketObjectDownloadEnd struct {
 
 func (x *BucketObjectDownloadEnd) Reset() {
 	*x = BucketObjectDownloadEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[32]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3206,7 +3354,7 @@ func (x *BucketObjectDownloadEnd) String() string {
 func (*BucketObjectDownloadEnd) ProtoMessage() {}
 
 func (x *BucketObjectDownloadEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[32]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3219,7 +3367,7 @@ func (x *BucketObjectDownloadEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectDownloadEnd.ProtoReflect.Descriptor instead.
 func (*BucketObjectDownloadEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{32}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *BucketObjectDownloadEnd) GetErr() *Error {
@@ -3248,7 +3396,7 @@ type BucketObjectGetAttrsStart struct {
 
 func (x *BucketObjectGetAttrsStart) Reset() {
 	*x = BucketObjectGetAttrsStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[33]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3260,7 +3408,7 @@ func (x *BucketObjectGetAttrsStart) String() string {
 func (*BucketObjectGetAttrsStart) ProtoMessage() {}
 
 func (x *BucketObjectGetAttrsStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[33]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3273,7 +3421,7 @@ func (x *BucketObjectGetAttrsStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectGetAttrsStart.ProtoReflect.Descriptor instead.
 func (*BucketObjectGetAttrsStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{33}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *BucketObjectGetAttrsStart) GetBucket() string {
@@ -3314,7 +3462,7 @@ type BucketObjectGetAttrsEnd struct {
 
 func (x *BucketObjectGetAttrsEnd) Reset() {
 	*x = BucketObjectGetAttrsEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[34]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3326,7 +3474,7 @@ func (x *BucketObjectGetAttrsEnd) String() string {
 func (*BucketObjectGetAttrsEnd) ProtoMessage() {}
 
 func (x *BucketObjectGetAttrsEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[34]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3339,7 +3487,7 @@ func (x *BucketObjectGetAttrsEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectGetAttrsEnd.ProtoReflect.Descriptor instead.
 func (*BucketObjectGetAttrsEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{34}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *BucketObjectGetAttrsEnd) GetErr() *Error {
@@ -3367,7 +3515,7 @@ type BucketListObjectsStart struct {
 
 func (x *BucketListObjectsStart) Reset() {
 	*x = BucketListObjectsStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[35]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3379,7 +3527,7 @@ func (x *BucketListObjectsStart) String() string {
 func (*BucketListObjectsStart) ProtoMessage() {}
 
 func (x *BucketListObjectsStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[35]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3392,7 +3540,7 @@ func (x *BucketListObjectsStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketListObjectsStart.ProtoReflect.Descriptor instead.
 func (*BucketListObjectsStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{35}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *BucketListObjectsStart) GetBucket() string {
@@ -3427,7 +3575,7 @@ type BucketListObjectsEnd struct {
 
 func (x *BucketListObjectsEnd) Reset() {
 	*x = BucketListObjectsEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[36]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3439,7 +3587,7 @@ func (x *BucketListObjectsEnd) String() string {
 func (*BucketListObjectsEnd) ProtoMessage() {}
 
 func (x *BucketListObjectsEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[36]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3452,7 +3600,7 @@ func (x *BucketListObjectsEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketListObjectsEnd.ProtoReflect.Descriptor instead.
 func (*BucketListObjectsEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{36}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *BucketListObjectsEnd) GetErr() *Error {
@@ -3487,7 +3635,7 @@ type BucketDeleteObjectsStart struct {
 
 func (x *BucketDeleteObjectsStart) Reset() {
 	*x = BucketDeleteObjectsStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[37]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3499,7 +3647,7 @@ func (x *BucketDeleteObjectsStart) String() string {
 func (*BucketDeleteObjectsStart) ProtoMessage() {}
 
 func (x *BucketDeleteObjectsStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[37]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3512,7 +3660,7 @@ func (x *BucketDeleteObjectsStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketDeleteObjectsStart.ProtoReflect.Descriptor instead.
 func (*BucketDeleteObjectsStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{37}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *BucketDeleteObjectsStart) GetBucket() string {
@@ -3546,7 +3694,7 @@ type BucketDeleteObjectEntry struct {
 
 func (x *BucketDeleteObjectEntry) Reset() {
 	*x = BucketDeleteObjectEntry{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[38]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3558,7 +3706,7 @@ func (x *BucketDeleteObjectEntry) String() string {
 func (*BucketDeleteObjectEntry) ProtoMessage() {}
 
 func (x *BucketDeleteObjectEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[38]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3571,7 +3719,7 @@ func (x *BucketDeleteObjectEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketDeleteObjectEntry.ProtoReflect.Descriptor instead.
 func (*BucketDeleteObjectEntry) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{38}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *BucketDeleteObjectEntry) GetObject() string {
@@ -3597,7 +3745,7 @@ type BucketDeleteObjectsEnd struct {
 
 func (x *BucketDeleteObjectsEnd) Reset() {
 	*x = BucketDeleteObjectsEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[39]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3609,7 +3757,7 @@ func (x *BucketDeleteObjectsEnd) String() string {
 func (*BucketDeleteObjectsEnd) ProtoMessage() {}
 
 func (x *BucketDeleteObjectsEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[39]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3622,7 +3770,7 @@ func (x *BucketDeleteObjectsEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketDeleteObjectsEnd.ProtoReflect.Descriptor instead.
 func (*BucketDeleteObjectsEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{39}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *BucketDeleteObjectsEnd) GetErr() *Error {
@@ -3644,7 +3792,7 @@ type BucketObjectAttributes struct {
 
 func (x *BucketObjectAttributes) Reset() {
 	*x = BucketObjectAttributes{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[40]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3656,7 +3804,7 @@ func (x *BucketObjectAttributes) String() string {
 func (*BucketObjectAttributes) ProtoMessage() {}
 
 func (x *BucketObjectAttributes) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[40]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3669,7 +3817,7 @@ func (x *BucketObjectAttributes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketObjectAttributes.ProtoReflect.Descriptor instead.
 func (*BucketObjectAttributes) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{40}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *BucketObjectAttributes) GetSize() uint64 {
@@ -3711,7 +3859,7 @@ type BodyStream struct {
 
 func (x *BodyStream) Reset() {
 	*x = BodyStream{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[41]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3723,7 +3871,7 @@ func (x *BodyStream) String() string {
 func (*BodyStream) ProtoMessage() {}
 
 func (x *BodyStream) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[41]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3736,7 +3884,7 @@ func (x *BodyStream) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BodyStream.ProtoReflect.Descriptor instead.
 func (*BodyStream) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{41}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *BodyStream) GetIsResponse() bool {
@@ -3775,7 +3923,7 @@ type HTTPCallStart struct {
 
 func (x *HTTPCallStart) Reset() {
 	*x = HTTPCallStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[42]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3787,7 +3935,7 @@ func (x *HTTPCallStart) String() string {
 func (*HTTPCallStart) ProtoMessage() {}
 
 func (x *HTTPCallStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[42]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3800,7 +3948,7 @@ func (x *HTTPCallStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPCallStart.ProtoReflect.Descriptor instead.
 func (*HTTPCallStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{42}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *HTTPCallStart) GetCorrelationParentSpanId() uint64 {
@@ -3852,7 +4000,7 @@ type HTTPCallEnd struct {
 
 func (x *HTTPCallEnd) Reset() {
 	*x = HTTPCallEnd{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[43]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3864,7 +4012,7 @@ func (x *HTTPCallEnd) String() string {
 func (*HTTPCallEnd) ProtoMessage() {}
 
 func (x *HTTPCallEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[43]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3877,7 +4025,7 @@ func (x *HTTPCallEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPCallEnd.ProtoReflect.Descriptor instead.
 func (*HTTPCallEnd) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{43}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *HTTPCallEnd) GetStatusCode() uint32 {
@@ -3927,7 +4075,7 @@ type HTTPTraceEvent struct {
 
 func (x *HTTPTraceEvent) Reset() {
 	*x = HTTPTraceEvent{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[44]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3939,7 +4087,7 @@ func (x *HTTPTraceEvent) String() string {
 func (*HTTPTraceEvent) ProtoMessage() {}
 
 func (x *HTTPTraceEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[44]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3952,7 +4100,7 @@ func (x *HTTPTraceEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPTraceEvent.ProtoReflect.Descriptor instead.
 func (*HTTPTraceEvent) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{44}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *HTTPTraceEvent) GetNanotime() int64 {
@@ -4192,7 +4340,7 @@ type HTTPGetConn struct {
 
 func (x *HTTPGetConn) Reset() {
 	*x = HTTPGetConn{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[45]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4204,7 +4352,7 @@ func (x *HTTPGetConn) String() string {
 func (*HTTPGetConn) ProtoMessage() {}
 
 func (x *HTTPGetConn) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[45]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4217,7 +4365,7 @@ func (x *HTTPGetConn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPGetConn.ProtoReflect.Descriptor instead.
 func (*HTTPGetConn) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{45}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *HTTPGetConn) GetHostPort() string {
@@ -4238,7 +4386,7 @@ type HTTPGotConn struct {
 
 func (x *HTTPGotConn) Reset() {
 	*x = HTTPGotConn{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[46]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4250,7 +4398,7 @@ func (x *HTTPGotConn) String() string {
 func (*HTTPGotConn) ProtoMessage() {}
 
 func (x *HTTPGotConn) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[46]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4263,7 +4411,7 @@ func (x *HTTPGotConn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPGotConn.ProtoReflect.Descriptor instead.
 func (*HTTPGotConn) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{46}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *HTTPGotConn) GetReused() bool {
@@ -4295,7 +4443,7 @@ type HTTPGotFirstResponseByte struct {
 
 func (x *HTTPGotFirstResponseByte) Reset() {
 	*x = HTTPGotFirstResponseByte{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[47]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4307,7 +4455,7 @@ func (x *HTTPGotFirstResponseByte) String() string {
 func (*HTTPGotFirstResponseByte) ProtoMessage() {}
 
 func (x *HTTPGotFirstResponseByte) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[47]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4320,7 +4468,7 @@ func (x *HTTPGotFirstResponseByte) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPGotFirstResponseByte.ProtoReflect.Descriptor instead.
 func (*HTTPGotFirstResponseByte) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{47}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{48}
 }
 
 type HTTPGot1XxResponse struct {
@@ -4332,7 +4480,7 @@ type HTTPGot1XxResponse struct {
 
 func (x *HTTPGot1XxResponse) Reset() {
 	*x = HTTPGot1XxResponse{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[48]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4344,7 +4492,7 @@ func (x *HTTPGot1XxResponse) String() string {
 func (*HTTPGot1XxResponse) ProtoMessage() {}
 
 func (x *HTTPGot1XxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[48]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4357,7 +4505,7 @@ func (x *HTTPGot1XxResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPGot1XxResponse.ProtoReflect.Descriptor instead.
 func (*HTTPGot1XxResponse) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{48}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *HTTPGot1XxResponse) GetCode() int32 {
@@ -4376,7 +4524,7 @@ type HTTPDNSStart struct {
 
 func (x *HTTPDNSStart) Reset() {
 	*x = HTTPDNSStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[49]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4388,7 +4536,7 @@ func (x *HTTPDNSStart) String() string {
 func (*HTTPDNSStart) ProtoMessage() {}
 
 func (x *HTTPDNSStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[49]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4401,7 +4549,7 @@ func (x *HTTPDNSStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPDNSStart.ProtoReflect.Descriptor instead.
 func (*HTTPDNSStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{49}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *HTTPDNSStart) GetHost() string {
@@ -4421,7 +4569,7 @@ type HTTPDNSDone struct {
 
 func (x *HTTPDNSDone) Reset() {
 	*x = HTTPDNSDone{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[50]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4433,7 +4581,7 @@ func (x *HTTPDNSDone) String() string {
 func (*HTTPDNSDone) ProtoMessage() {}
 
 func (x *HTTPDNSDone) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[50]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4446,7 +4594,7 @@ func (x *HTTPDNSDone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPDNSDone.ProtoReflect.Descriptor instead.
 func (*HTTPDNSDone) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{50}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *HTTPDNSDone) GetErr() []byte {
@@ -4472,7 +4620,7 @@ type DNSAddr struct {
 
 func (x *DNSAddr) Reset() {
 	*x = DNSAddr{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[51]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4484,7 +4632,7 @@ func (x *DNSAddr) String() string {
 func (*DNSAddr) ProtoMessage() {}
 
 func (x *DNSAddr) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[51]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4497,7 +4645,7 @@ func (x *DNSAddr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DNSAddr.ProtoReflect.Descriptor instead.
 func (*DNSAddr) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{51}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *DNSAddr) GetIp() []byte {
@@ -4517,7 +4665,7 @@ type HTTPConnectStart struct {
 
 func (x *HTTPConnectStart) Reset() {
 	*x = HTTPConnectStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[52]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4529,7 +4677,7 @@ func (x *HTTPConnectStart) String() string {
 func (*HTTPConnectStart) ProtoMessage() {}
 
 func (x *HTTPConnectStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[52]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4542,7 +4690,7 @@ func (x *HTTPConnectStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPConnectStart.ProtoReflect.Descriptor instead.
 func (*HTTPConnectStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{52}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *HTTPConnectStart) GetNetwork() string {
@@ -4570,7 +4718,7 @@ type HTTPConnectDone struct {
 
 func (x *HTTPConnectDone) Reset() {
 	*x = HTTPConnectDone{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[53]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4582,7 +4730,7 @@ func (x *HTTPConnectDone) String() string {
 func (*HTTPConnectDone) ProtoMessage() {}
 
 func (x *HTTPConnectDone) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[53]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4595,7 +4743,7 @@ func (x *HTTPConnectDone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPConnectDone.ProtoReflect.Descriptor instead.
 func (*HTTPConnectDone) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{53}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *HTTPConnectDone) GetNetwork() string {
@@ -4627,7 +4775,7 @@ type HTTPTLSHandshakeStart struct {
 
 func (x *HTTPTLSHandshakeStart) Reset() {
 	*x = HTTPTLSHandshakeStart{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[54]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4639,7 +4787,7 @@ func (x *HTTPTLSHandshakeStart) String() string {
 func (*HTTPTLSHandshakeStart) ProtoMessage() {}
 
 func (x *HTTPTLSHandshakeStart) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[54]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4652,7 +4800,7 @@ func (x *HTTPTLSHandshakeStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPTLSHandshakeStart.ProtoReflect.Descriptor instead.
 func (*HTTPTLSHandshakeStart) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{54}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{55}
 }
 
 type HTTPTLSHandshakeDone struct {
@@ -4668,7 +4816,7 @@ type HTTPTLSHandshakeDone struct {
 
 func (x *HTTPTLSHandshakeDone) Reset() {
 	*x = HTTPTLSHandshakeDone{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[55]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4680,7 +4828,7 @@ func (x *HTTPTLSHandshakeDone) String() string {
 func (*HTTPTLSHandshakeDone) ProtoMessage() {}
 
 func (x *HTTPTLSHandshakeDone) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[55]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4693,7 +4841,7 @@ func (x *HTTPTLSHandshakeDone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPTLSHandshakeDone.ProtoReflect.Descriptor instead.
 func (*HTTPTLSHandshakeDone) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{55}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *HTTPTLSHandshakeDone) GetErr() []byte {
@@ -4739,7 +4887,7 @@ type HTTPWroteHeaders struct {
 
 func (x *HTTPWroteHeaders) Reset() {
 	*x = HTTPWroteHeaders{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[56]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4751,7 +4899,7 @@ func (x *HTTPWroteHeaders) String() string {
 func (*HTTPWroteHeaders) ProtoMessage() {}
 
 func (x *HTTPWroteHeaders) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[56]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4764,7 +4912,7 @@ func (x *HTTPWroteHeaders) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPWroteHeaders.ProtoReflect.Descriptor instead.
 func (*HTTPWroteHeaders) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{56}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{57}
 }
 
 type HTTPWroteRequest struct {
@@ -4776,7 +4924,7 @@ type HTTPWroteRequest struct {
 
 func (x *HTTPWroteRequest) Reset() {
 	*x = HTTPWroteRequest{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[57]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4788,7 +4936,7 @@ func (x *HTTPWroteRequest) String() string {
 func (*HTTPWroteRequest) ProtoMessage() {}
 
 func (x *HTTPWroteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[57]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4801,7 +4949,7 @@ func (x *HTTPWroteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPWroteRequest.ProtoReflect.Descriptor instead.
 func (*HTTPWroteRequest) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{57}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *HTTPWroteRequest) GetErr() []byte {
@@ -4819,7 +4967,7 @@ type HTTPWait100Continue struct {
 
 func (x *HTTPWait100Continue) Reset() {
 	*x = HTTPWait100Continue{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[58]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4831,7 +4979,7 @@ func (x *HTTPWait100Continue) String() string {
 func (*HTTPWait100Continue) ProtoMessage() {}
 
 func (x *HTTPWait100Continue) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[58]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4844,7 +4992,7 @@ func (x *HTTPWait100Continue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPWait100Continue.ProtoReflect.Descriptor instead.
 func (*HTTPWait100Continue) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{58}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{59}
 }
 
 type HTTPClosedBodyData struct {
@@ -4856,7 +5004,7 @@ type HTTPClosedBodyData struct {
 
 func (x *HTTPClosedBodyData) Reset() {
 	*x = HTTPClosedBodyData{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[59]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4868,7 +5016,7 @@ func (x *HTTPClosedBodyData) String() string {
 func (*HTTPClosedBodyData) ProtoMessage() {}
 
 func (x *HTTPClosedBodyData) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[59]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4881,7 +5029,7 @@ func (x *HTTPClosedBodyData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPClosedBodyData.ProtoReflect.Descriptor instead.
 func (*HTTPClosedBodyData) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{59}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *HTTPClosedBodyData) GetErr() []byte {
@@ -4903,7 +5051,7 @@ type LogMessage struct {
 
 func (x *LogMessage) Reset() {
 	*x = LogMessage{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[60]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4915,7 +5063,7 @@ func (x *LogMessage) String() string {
 func (*LogMessage) ProtoMessage() {}
 
 func (x *LogMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[60]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4928,7 +5076,7 @@ func (x *LogMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogMessage.ProtoReflect.Descriptor instead.
 func (*LogMessage) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{60}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *LogMessage) GetLevel() LogMessage_Level {
@@ -4982,7 +5130,7 @@ type LogField struct {
 
 func (x *LogField) Reset() {
 	*x = LogField{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[61]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4994,7 +5142,7 @@ func (x *LogField) String() string {
 func (*LogField) ProtoMessage() {}
 
 func (x *LogField) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[61]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5007,7 +5155,7 @@ func (x *LogField) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogField.ProtoReflect.Descriptor instead.
 func (*LogField) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{61}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *LogField) GetKey() string {
@@ -5203,7 +5351,7 @@ type StackTrace struct {
 
 func (x *StackTrace) Reset() {
 	*x = StackTrace{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[62]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5215,7 +5363,7 @@ func (x *StackTrace) String() string {
 func (*StackTrace) ProtoMessage() {}
 
 func (x *StackTrace) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[62]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5228,7 +5376,7 @@ func (x *StackTrace) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StackTrace.ProtoReflect.Descriptor instead.
 func (*StackTrace) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{62}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *StackTrace) GetPcs() []int64 {
@@ -5256,7 +5404,7 @@ type StackFrame struct {
 
 func (x *StackFrame) Reset() {
 	*x = StackFrame{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[63]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5268,7 +5416,7 @@ func (x *StackFrame) String() string {
 func (*StackFrame) ProtoMessage() {}
 
 func (x *StackFrame) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[63]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5281,7 +5429,7 @@ func (x *StackFrame) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StackFrame.ProtoReflect.Descriptor instead.
 func (*StackFrame) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{63}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *StackFrame) GetFilename() string {
@@ -5315,7 +5463,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[64]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5327,7 +5475,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[64]
+	mi := &file_encore_engine_trace2_trace2_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5340,7 +5488,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{64}
+	return file_encore_engine_trace2_trace2_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *Error) GetMsg() string {
@@ -5456,7 +5604,7 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\x06_errorB\x0e\n" +
 	"\f_panic_stackB\x12\n" +
 	"\x10_parent_trace_idB\x11\n" +
-	"\x0f_parent_span_id\"\x9b\x04\n" +
+	"\x0f_parent_span_id\"\xc1\x05\n" +
 	"\x10RequestSpanStart\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12#\n" +
 	"\rendpoint_name\x18\x02 \x01(\tR\fendpointName\x12\x1f\n" +
@@ -5470,13 +5618,28 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\x12ext_correlation_id\x18\b \x01(\tH\x01R\x10extCorrelationId\x88\x01\x01\x12\x15\n" +
 	"\x03uid\x18\t \x01(\tH\x02R\x03uid\x88\x01\x01\x12\x16\n" +
 	"\x06mocked\x18\n" +
-	" \x01(\bR\x06mocked\x1aA\n" +
+	" \x01(\bR\x06mocked\x12=\n" +
+	"\x18deadline_remaining_nanos\x18\v \x01(\x03H\x03R\x16deadlineRemainingNanos\x88\x01\x01\x12=\n" +
+	"\x06client\x18\f \x01(\v2 .encore.engine.trace2.ClientInfoH\x04R\x06client\x88\x01\x01\x1aA\n" +
 	"\x13RequestHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x12\n" +
 	"\x10_request_payloadB\x15\n" +
 	"\x13_ext_correlation_idB\x06\n" +
-	"\x04_uid\"\xd1\x03\n" +
+	"\x04_uidB\x1b\n" +
+	"\x19_deadline_remaining_nanosB\t\n" +
+	"\a_client\"\xf0\x01\n" +
+	"\n" +
+	"ClientInfo\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12!\n" +
+	"\fcountry_code\x18\x02 \x01(\tR\vcountryCode\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x18\n" +
+	"\abrowser\x18\x06 \x01(\tR\abrowser\x12'\n" +
+	"\x0fbrowser_version\x18\a \x01(\tR\x0ebrowserVersion\x12\x0e\n" +
+	"\x02os\x18\b \x01(\tR\x02os\x12\x16\n" +
+	"\x06device\x18\t \x01(\tR\x06device\"\xb7\x04\n" +
 	"\x0eRequestSpanEnd\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12#\n" +
 	"\rendpoint_name\x18\x02 \x01(\tR\fendpointName\x12(\n" +
@@ -5484,13 +5647,16 @@ const file_encore_engine_trace2_trace2_proto_rawDesc = "" +
 	"\x10response_headers\x18\x04 \x03(\v29.encore.engine.trace2.RequestSpanEnd.ResponseHeadersEntryR\x0fresponseHeaders\x12.\n" +
 	"\x10response_payload\x18\x05 \x01(\fH\x00R\x0fresponsePayload\x88\x01\x01\x12+\n" +
 	"\x0fcaller_event_id\x18\x06 \x01(\x04H\x01R\rcallerEventId\x88\x01\x01\x12\x15\n" +
-	"\x03uid\x18\a \x01(\tH\x02R\x03uid\x88\x01\x01\x1aB\n" +
+	"\x03uid\x18\a \x01(\tH\x02R\x03uid\x88\x01\x01\x12&\n" +
+	"\fcancel_cause\x18\b \x01(\tH\x03R\vcancelCause\x88\x01\x01\x12+\n" +
+	"\x11deadline_exceeded\x18\t \x01(\bR\x10deadlineExceeded\x1aB\n" +
 	"\x14ResponseHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x13\n" +
 	"\x11_response_payloadB\x12\n" +
 	"\x10_caller_event_idB\x06\n" +
-	"\x04_uid\"\x90\x01\n" +
+	"\x04_uidB\x0f\n" +
+	"\r_cancel_cause\"\x90\x01\n" +
 	"\rAuthSpanStart\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12#\n" +
 	"\rendpoint_name\x18\x02 \x01(\tR\fendpointName\x12&\n" +
@@ -5875,7 +6041,7 @@ func file_encore_engine_trace2_trace2_proto_rawDescGZIP() []byte {
 }
 
 var file_encore_engine_trace2_trace2_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_encore_engine_trace2_trace2_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
+var file_encore_engine_trace2_trace2_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_encore_engine_trace2_trace2_proto_goTypes = []any{
 	(HTTPTraceEventCode)(0),              // 0: encore.engine.trace2.HTTPTraceEventCode
 	(StatusCode)(0),                      // 1: encore.engine.trace2.StatusCode
@@ -5890,176 +6056,178 @@ var file_encore_engine_trace2_trace2_proto_goTypes = []any{
 	(*SpanStart)(nil),                    // 10: encore.engine.trace2.SpanStart
 	(*SpanEnd)(nil),                      // 11: encore.engine.trace2.SpanEnd
 	(*RequestSpanStart)(nil),             // 12: encore.engine.trace2.RequestSpanStart
-	(*RequestSpanEnd)(nil),               // 13: encore.engine.trace2.RequestSpanEnd
-	(*AuthSpanStart)(nil),                // 14: encore.engine.trace2.AuthSpanStart
-	(*AuthSpanEnd)(nil),                  // 15: encore.engine.trace2.AuthSpanEnd
-	(*PubsubMessageSpanStart)(nil),       // 16: encore.engine.trace2.PubsubMessageSpanStart
-	(*PubsubMessageSpanEnd)(nil),         // 17: encore.engine.trace2.PubsubMessageSpanEnd
-	(*TestSpanStart)(nil),                // 18: encore.engine.trace2.TestSpanStart
-	(*TestSpanEnd)(nil),                  // 19: encore.engine.trace2.TestSpanEnd
-	(*SpanEvent)(nil),                    // 20: encore.engine.trace2.SpanEvent
-	(*RPCCallStart)(nil),                 // 21: encore.engine.trace2.RPCCallStart
-	(*RPCCallEnd)(nil),                   // 22: encore.engine.trace2.RPCCallEnd
-	(*GoroutineStart)(nil),               // 23: encore.engine.trace2.GoroutineStart
-	(*GoroutineEnd)(nil),                 // 24: encore.engine.trace2.GoroutineEnd
-	(*DBTransactionStart)(nil),           // 25: encore.engine.trace2.DBTransactionStart
-	(*DBTransactionEnd)(nil),             // 26: encore.engine.trace2.DBTransactionEnd
-	(*DBQueryStart)(nil),                 // 27: encore.engine.trace2.DBQueryStart
-	(*DBQueryEnd)(nil),                   // 28: encore.engine.trace2.DBQueryEnd
-	(*PubsubPublishStart)(nil),           // 29: encore.engine.trace2.PubsubPublishStart
-	(*PubsubPublishEnd)(nil),             // 30: encore.engine.trace2.PubsubPublishEnd
-	(*ServiceInitStart)(nil),             // 31: encore.engine.trace2.ServiceInitStart
-	(*ServiceInitEnd)(nil),               // 32: encore.engine.trace2.ServiceInitEnd
-	(*CacheCallStart)(nil),               // 33: encore.engine.trace2.CacheCallStart
-	(*CacheCallEnd)(nil),                 // 34: encore.engine.trace2.CacheCallEnd
-	(*BucketObjectUploadStart)(nil),      // 35: encore.engine.trace2.BucketObjectUploadStart
-	(*BucketObjectUploadEnd)(nil),        // 36: encore.engine.trace2.BucketObjectUploadEnd
-	(*BucketObjectDownloadStart)(nil),    // 37: encore.engine.trace2.BucketObjectDownloadStart
-	(*BucketObjectDownloadEnd)(nil),      // 38: encore.engine.trace2.BucketObjectDownloadEnd
-	(*BucketObjectGetAttrsStart)(nil),    // 39: encore.engine.trace2.BucketObjectGetAttrsStart
-	(*BucketObjectGetAttrsEnd)(nil),      // 40: encore.engine.trace2.BucketObjectGetAttrsEnd
-	(*BucketListObjectsStart)(nil),       // 41: encore.engine.trace2.BucketListObjectsStart
-	(*BucketListObjectsEnd)(nil),         // 42: encore.engine.trace2.BucketListObjectsEnd
-	(*BucketDeleteObjectsStart)(nil),     // 43: encore.engine.trace2.BucketDeleteObjectsStart
-	(*BucketDeleteObjectEntry)(nil),      // 44: encore.engine.trace2.BucketDeleteObjectEntry
-	(*BucketDeleteObjectsEnd)(nil),       // 45: encore.engine.trace2.BucketDeleteObjectsEnd
-	(*BucketObjectAttributes)(nil),       // 46: encore.engine.trace2.BucketObjectAttributes
-	(*BodyStream)(nil),                   // 47: encore.engine.trace2.BodyStream
-	(*HTTPCallStart)(nil),                // 48: encore.engine.trace2.HTTPCallStart
-	(*HTTPCallEnd)(nil),                  // 49: encore.engine.trace2.HTTPCallEnd
-	(*HTTPTraceEvent)(nil),               // 50: encore.engine.trace2.HTTPTraceEvent
-	(*HTTPGetConn)(nil),                  // 51: encore.engine.trace2.HTTPGetConn
-	(*HTTPGotConn)(nil),                  // 52: encore.engine.trace2.HTTPGotConn
-	(*HTTPGotFirstResponseByte)(nil),     // 53: encore.engine.trace2.HTTPGotFirstResponseByte
-	(*HTTPGot1XxResponse)(nil),           // 54: encore.engine.trace2.HTTPGot1xxResponse
-	(*HTTPDNSStart)(nil),                 // 55: encore.engine.trace2.HTTPDNSStart
-	(*HTTPDNSDone)(nil),                  // 56: encore.engine.trace2.HTTPDNSDone
-	(*DNSAddr)(nil),                      // 57: encore.engine.trace2.DNSAddr
-	(*HTTPConnectStart)(nil),             // 58: encore.engine.trace2.HTTPConnectStart
-	(*HTTPConnectDone)(nil),              // 59: encore.engine.trace2.HTTPConnectDone
-	(*HTTPTLSHandshakeStart)(nil),        // 60: encore.engine.trace2.HTTPTLSHandshakeStart
-	(*HTTPTLSHandshakeDone)(nil),         // 61: encore.engine.trace2.HTTPTLSHandshakeDone
-	(*HTTPWroteHeaders)(nil),             // 62: encore.engine.trace2.HTTPWroteHeaders
-	(*HTTPWroteRequest)(nil),             // 63: encore.engine.trace2.HTTPWroteRequest
-	(*HTTPWait100Continue)(nil),          // 64: encore.engine.trace2.HTTPWait100Continue
-	(*HTTPClosedBodyData)(nil),           // 65: encore.engine.trace2.HTTPClosedBodyData
-	(*LogMessage)(nil),                   // 66: encore.engine.trace2.LogMessage
-	(*LogField)(nil),                     // 67: encore.engine.trace2.LogField
-	(*StackTrace)(nil),                   // 68: encore.engine.trace2.StackTrace
-	(*StackFrame)(nil),                   // 69: encore.engine.trace2.StackFrame
-	(*Error)(nil),                        // 70: encore.engine.trace2.Error
-	nil,                                  // 71: encore.engine.trace2.RequestSpanStart.RequestHeadersEntry
-	nil,                                  // 72: encore.engine.trace2.RequestSpanEnd.ResponseHeadersEntry
-	(*timestamppb.Timestamp)(nil),        // 73: google.protobuf.Timestamp
+	(*ClientInfo)(nil),                   // 13: encore.engine.trace2.ClientInfo
+	(*RequestSpanEnd)(nil),               // 14: encore.engine.trace2.RequestSpanEnd
+	(*AuthSpanStart)(nil),                // 15: encore.engine.trace2.AuthSpanStart
+	(*AuthSpanEnd)(nil),                  // 16: encore.engine.trace2.AuthSpanEnd
+	(*PubsubMessageSpanStart)(nil),       // 17: encore.engine.trace2.PubsubMessageSpanStart
+	(*PubsubMessageSpanEnd)(nil),         // 18: encore.engine.trace2.PubsubMessageSpanEnd
+	(*TestSpanStart)(nil),                // 19: encore.engine.trace2.TestSpanStart
+	(*TestSpanEnd)(nil),                  // 20: encore.engine.trace2.TestSpanEnd
+	(*SpanEvent)(nil),                    // 21: encore.engine.trace2.SpanEvent
+	(*RPCCallStart)(nil),                 // 22: encore.engine.trace2.RPCCallStart
+	(*RPCCallEnd)(nil),                   // 23: encore.engine.trace2.RPCCallEnd
+	(*GoroutineStart)(nil),               // 24: encore.engine.trace2.GoroutineStart
+	(*GoroutineEnd)(nil),                 // 25: encore.engine.trace2.GoroutineEnd
+	(*DBTransactionStart)(nil),           // 26: encore.engine.trace2.DBTransactionStart
+	(*DBTransactionEnd)(nil),             // 27: encore.engine.trace2.DBTransactionEnd
+	(*DBQueryStart)(nil),                 // 28: encore.engine.trace2.DBQueryStart
+	(*DBQueryEnd)(nil),                   // 29: encore.engine.trace2.DBQueryEnd
+	(*PubsubPublishStart)(nil),           // 30: encore.engine.trace2.PubsubPublishStart
+	(*PubsubPublishEnd)(nil),             // 31: encore.engine.trace2.PubsubPublishEnd
+	(*ServiceInitStart)(nil),             // 32: encore.engine.trace2.ServiceInitStart
+	(*ServiceInitEnd)(nil),               // 33: encore.engine.trace2.ServiceInitEnd
+	(*CacheCallStart)(nil),               // 34: encore.engine.trace2.CacheCallStart
+	(*CacheCallEnd)(nil),                 // 35: encore.engine.trace2.CacheCallEnd
+	(*BucketObjectUploadStart)(nil),      // 36: encore.engine.trace2.BucketObjectUploadStart
+	(*BucketObjectUploadEnd)(nil),        // 37: encore.engine.trace2.BucketObjectUploadEnd
+	(*BucketObjectDownloadStart)(nil),    // 38: encore.engine.trace2.BucketObjectDownloadStart
+	(*BucketObjectDownloadEnd)(nil),      // 39: encore.engine.trace2.BucketObjectDownloadEnd
+	(*BucketObjectGetAttrsStart)(nil),    // 40: encore.engine.trace2.BucketObjectGetAttrsStart
+	(*BucketObjectGetAttrsEnd)(nil),      // 41: encore.engine.trace2.BucketObjectGetAttrsEnd
+	(*BucketListObjectsStart)(nil),       // 42: encore.engine.trace2.BucketListObjectsStart
+	(*BucketListObjectsEnd)(nil),         // 43: encore.engine.trace2.BucketListObjectsEnd
+	(*BucketDeleteObjectsStart)(nil),     // 44: encore.engine.trace2.BucketDeleteObjectsStart
+	(*BucketDeleteObjectEntry)(nil),      // 45: encore.engine.trace2.BucketDeleteObjectEntry
+	(*BucketDeleteObjectsEnd)(nil),       // 46: encore.engine.trace2.BucketDeleteObjectsEnd
+	(*BucketObjectAttributes)(nil),       // 47: encore.engine.trace2.BucketObjectAttributes
+	(*BodyStream)(nil),                   // 48: encore.engine.trace2.BodyStream
+	(*HTTPCallStart)(nil),                // 49: encore.engine.trace2.HTTPCallStart
+	(*HTTPCallEnd)(nil),                  // 50: encore.engine.trace2.HTTPCallEnd
+	(*HTTPTraceEvent)(nil),               // 51: encore.engine.trace2.HTTPTraceEvent
+	(*HTTPGetConn)(nil),                  // 52: encore.engine.trace2.HTTPGetConn
+	(*HTTPGotConn)(nil),                  // 53: encore.engine.trace2.HTTPGotConn
+	(*HTTPGotFirstResponseByte)(nil),     // 54: encore.engine.trace2.HTTPGotFirstResponseByte
+	(*HTTPGot1XxResponse)(nil),           // 55: encore.engine.trace2.HTTPGot1xxResponse
+	(*HTTPDNSStart)(nil),                 // 56: encore.engine.trace2.HTTPDNSStart
+	(*HTTPDNSDone)(nil),                  // 57: encore.engine.trace2.HTTPDNSDone
+	(*DNSAddr)(nil),                      // 58: encore.engine.trace2.DNSAddr
+	(*HTTPConnectStart)(nil),             // 59: encore.engine.trace2.HTTPConnectStart
+	(*HTTPConnectDone)(nil),              // 60: encore.engine.trace2.HTTPConnectDone
+	(*HTTPTLSHandshakeStart)(nil),        // 61: encore.engine.trace2.HTTPTLSHandshakeStart
+	(*HTTPTLSHandshakeDone)(nil),         // 62: encore.engine.trace2.HTTPTLSHandshakeDone
+	(*HTTPWroteHeaders)(nil),             // 63: encore.engine.trace2.HTTPWroteHeaders
+	(*HTTPWroteRequest)(nil),             // 64: encore.engine.trace2.HTTPWroteRequest
+	(*HTTPWait100Continue)(nil),          // 65: encore.engine.trace2.HTTPWait100Continue
+	(*HTTPClosedBodyData)(nil),           // 66: encore.engine.trace2.HTTPClosedBodyData
+	(*LogMessage)(nil),                   // 67: encore.engine.trace2.LogMessage
+	(*LogField)(nil),                     // 68: encore.engine.trace2.LogField
+	(*StackTrace)(nil),                   // 69: encore.engine.trace2.StackTrace
+	(*StackFrame)(nil),                   // 70: encore.engine.trace2.StackFrame
+	(*Error)(nil),                        // 71: encore.engine.trace2.Error
+	nil,                                  // 72: encore.engine.trace2.RequestSpanStart.RequestHeadersEntry
+	nil,                                  // 73: encore.engine.trace2.RequestSpanEnd.ResponseHeadersEntry
+	(*timestamppb.Timestamp)(nil),        // 74: google.protobuf.Timestamp
 }
 var file_encore_engine_trace2_trace2_proto_depIdxs = []int32{
 	2,   // 0: encore.engine.trace2.SpanSummary.type:type_name -> encore.engine.trace2.SpanSummary.SpanType
-	73,  // 1: encore.engine.trace2.SpanSummary.started_at:type_name -> google.protobuf.Timestamp
+	74,  // 1: encore.engine.trace2.SpanSummary.started_at:type_name -> google.protobuf.Timestamp
 	9,   // 2: encore.engine.trace2.EventList.events:type_name -> encore.engine.trace2.TraceEvent
 	7,   // 3: encore.engine.trace2.TraceEvent.trace_id:type_name -> encore.engine.trace2.TraceID
-	73,  // 4: encore.engine.trace2.TraceEvent.event_time:type_name -> google.protobuf.Timestamp
+	74,  // 4: encore.engine.trace2.TraceEvent.event_time:type_name -> google.protobuf.Timestamp
 	10,  // 5: encore.engine.trace2.TraceEvent.span_start:type_name -> encore.engine.trace2.SpanStart
 	11,  // 6: encore.engine.trace2.TraceEvent.span_end:type_name -> encore.engine.trace2.SpanEnd
-	20,  // 7: encore.engine.trace2.TraceEvent.span_event:type_name -> encore.engine.trace2.SpanEvent
+	21,  // 7: encore.engine.trace2.TraceEvent.span_event:type_name -> encore.engine.trace2.SpanEvent
 	7,   // 8: encore.engine.trace2.SpanStart.parent_trace_id:type_name -> encore.engine.trace2.TraceID
 	12,  // 9: encore.engine.trace2.SpanStart.request:type_name -> encore.engine.trace2.RequestSpanStart
-	14,  // 10: encore.engine.trace2.SpanStart.auth:type_name -> encore.engine.trace2.AuthSpanStart
-	16,  // 11: encore.engine.trace2.SpanStart.pubsub_message:type_name -> encore.engine.trace2.PubsubMessageSpanStart
-	18,  // 12: encore.engine.trace2.SpanStart.test:type_name -> encore.engine.trace2.TestSpanStart
-	70,  // 13: encore.engine.trace2.SpanEnd.error:type_name -> encore.engine.trace2.Error
-	68,  // 14: encore.engine.trace2.SpanEnd.panic_stack:type_name -> encore.engine.trace2.StackTrace
+	15,  // 10: encore.engine.trace2.SpanStart.auth:type_name -> encore.engine.trace2.AuthSpanStart
+	17,  // 11: encore.engine.trace2.SpanStart.pubsub_message:type_name -> encore.engine.trace2.PubsubMessageSpanStart
+	19,  // 12: encore.engine.trace2.SpanStart.test:type_name -> encore.engine.trace2.TestSpanStart
+	71,  // 13: encore.engine.trace2.SpanEnd.error:type_name -> encore.engine.trace2.Error
+	69,  // 14: encore.engine.trace2.SpanEnd.panic_stack:type_name -> encore.engine.trace2.StackTrace
 	7,   // 15: encore.engine.trace2.SpanEnd.parent_trace_id:type_name -> encore.engine.trace2.TraceID
 	1,   // 16: encore.engine.trace2.SpanEnd.status_code:type_name -> encore.engine.trace2.StatusCode
-	13,  // 17: encore.engine.trace2.SpanEnd.request:type_name -> encore.engine.trace2.RequestSpanEnd
-	15,  // 18: encore.engine.trace2.SpanEnd.auth:type_name -> encore.engine.trace2.AuthSpanEnd
-	17,  // 19: encore.engine.trace2.SpanEnd.pubsub_message:type_name -> encore.engine.trace2.PubsubMessageSpanEnd
-	19,  // 20: encore.engine.trace2.SpanEnd.test:type_name -> encore.engine.trace2.TestSpanEnd
-	71,  // 21: encore.engine.trace2.RequestSpanStart.request_headers:type_name -> encore.engine.trace2.RequestSpanStart.RequestHeadersEntry
-	72,  // 22: encore.engine.trace2.RequestSpanEnd.response_headers:type_name -> encore.engine.trace2.RequestSpanEnd.ResponseHeadersEntry
-	73,  // 23: encore.engine.trace2.PubsubMessageSpanStart.publish_time:type_name -> google.protobuf.Timestamp
-	66,  // 24: encore.engine.trace2.SpanEvent.log_message:type_name -> encore.engine.trace2.LogMessage
-	47,  // 25: encore.engine.trace2.SpanEvent.body_stream:type_name -> encore.engine.trace2.BodyStream
-	21,  // 26: encore.engine.trace2.SpanEvent.rpc_call_start:type_name -> encore.engine.trace2.RPCCallStart
-	22,  // 27: encore.engine.trace2.SpanEvent.rpc_call_end:type_name -> encore.engine.trace2.RPCCallEnd
-	25,  // 28: encore.engine.trace2.SpanEvent.db_transaction_start:type_name -> encore.engine.trace2.DBTransactionStart
-	26,  // 29: encore.engine.trace2.SpanEvent.db_transaction_end:type_name -> encore.engine.trace2.DBTransactionEnd
-	27,  // 30: encore.engine.trace2.SpanEvent.db_query_start:type_name -> encore.engine.trace2.DBQueryStart
-	28,  // 31: encore.engine.trace2.SpanEvent.db_query_end:type_name -> encore.engine.trace2.DBQueryEnd
-	48,  // 32: encore.engine.trace2.SpanEvent.http_call_start:type_name -> encore.engine.trace2.HTTPCallStart
-	49,  // 33: encore.engine.trace2.SpanEvent.http_call_end:type_name -> encore.engine.trace2.HTTPCallEnd
-	29,  // 34: encore.engine.trace2.SpanEvent.pubsub_publish_start:type_name -> encore.engine.trace2.PubsubPublishStart
-	30,  // 35: encore.engine.trace2.SpanEvent.pubsub_publish_end:type_name -> encore.engine.trace2.PubsubPublishEnd
-	33,  // 36: encore.engine.trace2.SpanEvent.cache_call_start:type_name -> encore.engine.trace2.CacheCallStart
-	34,  // 37: encore.engine.trace2.SpanEvent.cache_call_end:type_name -> encore.engine.trace2.CacheCallEnd
-	31,  // 38: encore.engine.trace2.SpanEvent.service_init_start:type_name -> encore.engine.trace2.ServiceInitStart
-	32,  // 39: encore.engine.trace2.SpanEvent.service_init_end:type_name -> encore.engine.trace2.ServiceInitEnd
-	35,  // 40: encore.engine.trace2.SpanEvent.bucket_object_upload_start:type_name -> encore.engine.trace2.BucketObjectUploadStart
-	36,  // 41: encore.engine.trace2.SpanEvent.bucket_object_upload_end:type_name -> encore.engine.trace2.BucketObjectUploadEnd
-	37,  // 42: encore.engine.trace2.SpanEvent.bucket_object_download_start:type_name -> encore.engine.trace2.BucketObjectDownloadStart
-	38,  // 43: encore.engine.trace2.SpanEvent.bucket_object_download_end:type_name -> encore.engine.trace2.BucketObjectDownloadEnd
-	39,  // 44: encore.engine.trace2.SpanEvent.bucket_object_get_attrs_start:type_name -> encore.engine.trace2.BucketObjectGetAttrsStart
-	40,  // 45: encore.engine.trace2.SpanEvent.bucket_object_get_attrs_end:type_name -> encore.engine.trace2.BucketObjectGetAttrsEnd
-	41,  // 46: encore.engine.trace2.SpanEvent.bucket_list_objects_start:type_name -> encore.engine.trace2.BucketListObjectsStart
-	42,  // 47: encore.engine.trace2.SpanEvent.bucket_list_objects_end:type_name -> encore.engine.trace2.BucketListObjectsEnd
-	43,  // 48: encore.engine.trace2.SpanEvent.bucket_delete_objects_start:type_name -> encore.engine.trace2.BucketDeleteObjectsStart
-	45,  // 49: encore.engine.trace2.SpanEvent.bucket_delete_objects_end:type_name -> encore.engine.trace2.BucketDeleteObjectsEnd
-	68,  // 50: encore.engine.trace2.RPCCallStart.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 51: encore.engine.trace2.RPCCallEnd.err:type_name -> encore.engine.trace2.Error
-	68,  // 52: encore.engine.trace2.DBTransactionStart.stack:type_name -> encore.engine.trace2.StackTrace
-	3,   // 53: encore.engine.trace2.DBTransactionEnd.completion:type_name -> encore.engine.trace2.DBTransactionEnd.CompletionType
-	68,  // 54: encore.engine.trace2.DBTransactionEnd.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 55: encore.engine.trace2.DBTransactionEnd.err:type_name -> encore.engine.trace2.Error
-	68,  // 56: encore.engine.trace2.DBQueryStart.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 57: encore.engine.trace2.DBQueryEnd.err:type_name -> encore.engine.trace2.Error
-	68,  // 58: encore.engine.trace2.PubsubPublishStart.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 59: encore.engine.trace2.PubsubPublishEnd.err:type_name -> encore.engine.trace2.Error
-	70,  // 60: encore.engine.trace2.ServiceInitEnd.err:type_name -> encore.engine.trace2.Error
-	68,  // 61: encore.engine.trace2.CacheCallStart.stack:type_name -> encore.engine.trace2.StackTrace
-	4,   // 62: encore.engine.trace2.CacheCallEnd.result:type_name -> encore.engine.trace2.CacheCallEnd.Result
-	70,  // 63: encore.engine.trace2.CacheCallEnd.err:type_name -> encore.engine.trace2.Error
-	46,  // 64: encore.engine.trace2.BucketObjectUploadStart.attrs:type_name -> encore.engine.trace2.BucketObjectAttributes
-	68,  // 65: encore.engine.trace2.BucketObjectUploadStart.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 66: encore.engine.trace2.BucketObjectUploadEnd.err:type_name -> encore.engine.trace2.Error
-	68,  // 67: encore.engine.trace2.BucketObjectDownloadStart.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 68: encore.engine.trace2.BucketObjectDownloadEnd.err:type_name -> encore.engine.trace2.Error
-	68,  // 69: encore.engine.trace2.BucketObjectGetAttrsStart.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 70: encore.engine.trace2.BucketObjectGetAttrsEnd.err:type_name -> encore.engine.trace2.Error
-	46,  // 71: encore.engine.trace2.BucketObjectGetAttrsEnd.attrs:type_name -> encore.engine.trace2.BucketObjectAttributes
-	68,  // 72: encore.engine.trace2.BucketListObjectsStart.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 73: encore.engine.trace2.BucketListObjectsEnd.err:type_name -> encore.engine.trace2.Error
-	68,  // 74: encore.engine.trace2.BucketDeleteObjectsStart.stack:type_name -> encore.engine.trace2.StackTrace
-	44,  // 75: encore.engine.trace2.BucketDeleteObjectsStart.entries:type_name -> encore.engine.trace2.BucketDeleteObjectEntry
-	70,  // 76: encore.engine.trace2.BucketDeleteObjectsEnd.err:type_name -> encore.engine.trace2.Error
-	68,  // 77: encore.engine.trace2.HTTPCallStart.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 78: encore.engine.trace2.HTTPCallEnd.err:type_name -> encore.engine.trace2.Error
-	50,  // 79: encore.engine.trace2.HTTPCallEnd.trace_events:type_name -> encore.engine.trace2.HTTPTraceEvent
-	51,  // 80: encore.engine.trace2.HTTPTraceEvent.get_conn:type_name -> encore.engine.trace2.HTTPGetConn
-	52,  // 81: encore.engine.trace2.HTTPTraceEvent.got_conn:type_name -> encore.engine.trace2.HTTPGotConn
-	53,  // 82: encore.engine.trace2.HTTPTraceEvent.got_first_response_byte:type_name -> encore.engine.trace2.HTTPGotFirstResponseByte
-	54,  // 83: encore.engine.trace2.HTTPTraceEvent.got_1xx_response:type_name -> encore.engine.trace2.HTTPGot1xxResponse
-	55,  // 84: encore.engine.trace2.HTTPTraceEvent.dns_start:type_name -> encore.engine.trace2.HTTPDNSStart
-	56,  // 85: encore.engine.trace2.HTTPTraceEvent.dns_done:type_name -> encore.engine.trace2.HTTPDNSDone
-	58,  // 86: encore.engine.trace2.HTTPTraceEvent.connect_start:type_name -> encore.engine.trace2.HTTPConnectStart
-	59,  // 87: encore.engine.trace2.HTTPTraceEvent.connect_done:type_name -> encore.engine.trace2.HTTPConnectDone
-	60,  // 88: encore.engine.trace2.HTTPTraceEvent.tls_handshake_start:type_name -> encore.engine.trace2.HTTPTLSHandshakeStart
-	61,  // 89: encore.engine.trace2.HTTPTraceEvent.tls_handshake_done:type_name -> encore.engine.trace2.HTTPTLSHandshakeDone
-	62,  // 90: encore.engine.trace2.HTTPTraceEvent.wrote_headers:type_name -> encore.engine.trace2.HTTPWroteHeaders
-	63,  // 91: encore.engine.trace2.HTTPTraceEvent.wrote_request:type_name -> encore.engine.trace2.HTTPWroteRequest
-	64,  // 92: encore.engine.trace2.HTTPTraceEvent.wait_100_continue:type_name -> encore.engine.trace2.HTTPWait100Continue
-	65,  // 93: encore.engine.trace2.HTTPTraceEvent.closed_body:type_name -> encore.engine.trace2.HTTPClosedBodyData
-	57,  // 94: encore.engine.trace2.HTTPDNSDone.addrs:type_name -> encore.engine.trace2.DNSAddr
-	5,   // 95: encore.engine.trace2.LogMessage.level:type_name -> encore.engine.trace2.LogMessage.Level
-	67,  // 96: encore.engine.trace2.LogMessage.fields:type_name -> encore.engine.trace2.LogField
-	68,  // 97: encore.engine.trace2.LogMessage.stack:type_name -> encore.engine.trace2.StackTrace
-	70,  // 98: encore.engine.trace2.LogField.error:type_name -> encore.engine.trace2.Error
-	73,  // 99: encore.engine.trace2.LogField.time:type_name -> google.protobuf.Timestamp
-	69,  // 100: encore.engine.trace2.StackTrace.frames:type_name -> encore.engine.trace2.StackFrame
-	68,  // 101: encore.engine.trace2.Error.stack:type_name -> encore.engine.trace2.StackTrace
-	102, // [102:102] is the sub-list for method output_type
-	102, // [102:102] is the sub-list for method input_type
-	102, // [102:102] is the sub-list for extension type_name
-	102, // [102:102] is the sub-list for extension extendee
-	0,   // [0:102] is the sub-list for field type_name
+	14,  // 17: encore.engine.trace2.SpanEnd.request:type_name -> encore.engine.trace2.RequestSpanEnd
+	16,  // 18: encore.engine.trace2.SpanEnd.auth:type_name -> encore.engine.trace2.AuthSpanEnd
+	18,  // 19: encore.engine.trace2.SpanEnd.pubsub_message:type_name -> encore.engine.trace2.PubsubMessageSpanEnd
+	20,  // 20: encore.engine.trace2.SpanEnd.test:type_name -> encore.engine.trace2.TestSpanEnd
+	72,  // 21: encore.engine.trace2.RequestSpanStart.request_headers:type_name -> encore.engine.trace2.RequestSpanStart.RequestHeadersEntry
+	13,  // 22: encore.engine.trace2.RequestSpanStart.client:type_name -> encore.engine.trace2.ClientInfo
+	73,  // 23: encore.engine.trace2.RequestSpanEnd.response_headers:type_name -> encore.engine.trace2.RequestSpanEnd.ResponseHeadersEntry
+	74,  // 24: encore.engine.trace2.PubsubMessageSpanStart.publish_time:type_name -> google.protobuf.Timestamp
+	67,  // 25: encore.engine.trace2.SpanEvent.log_message:type_name -> encore.engine.trace2.LogMessage
+	48,  // 26: encore.engine.trace2.SpanEvent.body_stream:type_name -> encore.engine.trace2.BodyStream
+	22,  // 27: encore.engine.trace2.SpanEvent.rpc_call_start:type_name -> encore.engine.trace2.RPCCallStart
+	23,  // 28: encore.engine.trace2.SpanEvent.rpc_call_end:type_name -> encore.engine.trace2.RPCCallEnd
+	26,  // 29: encore.engine.trace2.SpanEvent.db_transaction_start:type_name -> encore.engine.trace2.DBTransactionStart
+	27,  // 30: encore.engine.trace2.SpanEvent.db_transaction_end:type_name -> encore.engine.trace2.DBTransactionEnd
+	28,  // 31: encore.engine.trace2.SpanEvent.db_query_start:type_name -> encore.engine.trace2.DBQueryStart
+	29,  // 32: encore.engine.trace2.SpanEvent.db_query_end:type_name -> encore.engine.trace2.DBQueryEnd
+	49,  // 33: encore.engine.trace2.SpanEvent.http_call_start:type_name -> encore.engine.trace2.HTTPCallStart
+	50,  // 34: encore.engine.trace2.SpanEvent.http_call_end:type_name -> encore.engine.trace2.HTTPCallEnd
+	30,  // 35: encore.engine.trace2.SpanEvent.pubsub_publish_start:type_name -> encore.engine.trace2.PubsubPublishStart
+	31,  // 36: encore.engine.trace2.SpanEvent.pubsub_publish_end:type_name -> encore.engine.trace2.PubsubPublishEnd
+	34,  // 37: encore.engine.trace2.SpanEvent.cache_call_start:type_name -> encore.engine.trace2.CacheCallStart
+	35,  // 38: encore.engine.trace2.SpanEvent.cache_call_end:type_name -> encore.engine.trace2.CacheCallEnd
+	32,  // 39: encore.engine.trace2.SpanEvent.service_init_start:type_name -> encore.engine.trace2.ServiceInitStart
+	33,  // 40: encore.engine.trace2.SpanEvent.service_init_end:type_name -> encore.engine.trace2.ServiceInitEnd
+	36,  // 41: encore.engine.trace2.SpanEvent.bucket_object_upload_start:type_name -> encore.engine.trace2.BucketObjectUploadStart
+	37,  // 42: encore.engine.trace2.SpanEvent.bucket_object_upload_end:type_name -> encore.engine.trace2.BucketObjectUploadEnd
+	38,  // 43: encore.engine.trace2.SpanEvent.bucket_object_download_start:type_name -> encore.engine.trace2.BucketObjectDownloadStart
+	39,  // 44: encore.engine.trace2.SpanEvent.bucket_object_download_end:type_name -> encore.engine.trace2.BucketObjectDownloadEnd
+	40,  // 45: encore.engine.trace2.SpanEvent.bucket_object_get_attrs_start:type_name -> encore.engine.trace2.BucketObjectGetAttrsStart
+	41,  // 46: encore.engine.trace2.SpanEvent.bucket_object_get_attrs_end:type_name -> encore.engine.trace2.BucketObjectGetAttrsEnd
+	42,  // 47: encore.engine.trace2.SpanEvent.bucket_list_objects_start:type_name -> encore.engine.trace2.BucketListObjectsStart
+	43,  // 48: encore.engine.trace2.SpanEvent.bucket_list_objects_end:type_name -> encore.engine.trace2.BucketListObjectsEnd
+	44,  // 49: encore.engine.trace2.SpanEvent.bucket_delete_objects_start:type_name -> encore.engine.trace2.BucketDeleteObjectsStart
+	46,  // 50: encore.engine.trace2.SpanEvent.bucket_delete_objects_end:type_name -> encore.engine.trace2.BucketDeleteObjectsEnd
+	69,  // 51: encore.engine.trace2.RPCCallStart.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 52: encore.engine.trace2.RPCCallEnd.err:type_name -> encore.engine.trace2.Error
+	69,  // 53: encore.engine.trace2.DBTransactionStart.stack:type_name -> encore.engine.trace2.StackTrace
+	3,   // 54: encore.engine.trace2.DBTransactionEnd.completion:type_name -> encore.engine.trace2.DBTransactionEnd.CompletionType
+	69,  // 55: encore.engine.trace2.DBTransactionEnd.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 56: encore.engine.trace2.DBTransactionEnd.err:type_name -> encore.engine.trace2.Error
+	69,  // 57: encore.engine.trace2.DBQueryStart.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 58: encore.engine.trace2.DBQueryEnd.err:type_name -> encore.engine.trace2.Error
+	69,  // 59: encore.engine.trace2.PubsubPublishStart.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 60: encore.engine.trace2.PubsubPublishEnd.err:type_name -> encore.engine.trace2.Error
+	71,  // 61: encore.engine.trace2.ServiceInitEnd.err:type_name -> encore.engine.trace2.Error
+	69,  // 62: encore.engine.trace2.CacheCallStart.stack:type_name -> encore.engine.trace2.StackTrace
+	4,   // 63: encore.engine.trace2.CacheCallEnd.result:type_name -> encore.engine.trace2.CacheCallEnd.Result
+	71,  // 64: encore.engine.trace2.CacheCallEnd.err:type_name -> encore.engine.trace2.Error
+	47,  // 65: encore.engine.trace2.BucketObjectUploadStart.attrs:type_name -> encore.engine.trace2.BucketObjectAttributes
+	69,  // 66: encore.engine.trace2.BucketObjectUploadStart.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 67: encore.engine.trace2.BucketObjectUploadEnd.err:type_name -> encore.engine.trace2.Error
+	69,  // 68: encore.engine.trace2.BucketObjectDownloadStart.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 69: encore.engine.trace2.BucketObjectDownloadEnd.err:type_name -> encore.engine.trace2.Error
+	69,  // 70: encore.engine.trace2.BucketObjectGetAttrsStart.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 71: encore.engine.trace2.BucketObjectGetAttrsEnd.err:type_name -> encore.engine.trace2.Error
+	47,  // 72: encore.engine.trace2.BucketObjectGetAttrsEnd.attrs:type_name -> encore.engine.trace2.BucketObjectAttributes
+	69,  // 73: encore.engine.trace2.BucketListObjectsStart.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 74: encore.engine.trace2.BucketListObjectsEnd.err:type_name -> encore.engine.trace2.Error
+	69,  // 75: encore.engine.trace2.BucketDeleteObjectsStart.stack:type_name -> encore.engine.trace2.StackTrace
+	45,  // 76: encore.engine.trace2.BucketDeleteObjectsStart.entries:type_name -> encore.engine.trace2.BucketDeleteObjectEntry
+	71,  // 77: encore.engine.trace2.BucketDeleteObjectsEnd.err:type_name -> encore.engine.trace2.Error
+	69,  // 78: encore.engine.trace2.HTTPCallStart.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 79: encore.engine.trace2.HTTPCallEnd.err:type_name -> encore.engine.trace2.Error
+	51,  // 80: encore.engine.trace2.HTTPCallEnd.trace_events:type_name -> encore.engine.trace2.HTTPTraceEvent
+	52,  // 81: encore.engine.trace2.HTTPTraceEvent.get_conn:type_name -> encore.engine.trace2.HTTPGetConn
+	53,  // 82: encore.engine.trace2.HTTPTraceEvent.got_conn:type_name -> encore.engine.trace2.HTTPGotConn
+	54,  // 83: encore.engine.trace2.HTTPTraceEvent.got_first_response_byte:type_name -> encore.engine.trace2.HTTPGotFirstResponseByte
+	55,  // 84: encore.engine.trace2.HTTPTraceEvent.got_1xx_response:type_name -> encore.engine.trace2.HTTPGot1xxResponse
+	56,  // 85: encore.engine.trace2.HTTPTraceEvent.dns_start:type_name -> encore.engine.trace2.HTTPDNSStart
+	57,  // 86: encore.engine.trace2.HTTPTraceEvent.dns_done:type_name -> encore.engine.trace2.HTTPDNSDone
+	59,  // 87: encore.engine.trace2.HTTPTraceEvent.connect_start:type_name -> encore.engine.trace2.HTTPConnectStart
+	60,  // 88: encore.engine.trace2.HTTPTraceEvent.connect_done:type_name -> encore.engine.trace2.HTTPConnectDone
+	61,  // 89: encore.engine.trace2.HTTPTraceEvent.tls_handshake_start:type_name -> encore.engine.trace2.HTTPTLSHandshakeStart
+	62,  // 90: encore.engine.trace2.HTTPTraceEvent.tls_handshake_done:type_name -> encore.engine.trace2.HTTPTLSHandshakeDone
+	63,  // 91: encore.engine.trace2.HTTPTraceEvent.wrote_headers:type_name -> encore.engine.trace2.HTTPWroteHeaders
+	64,  // 92: encore.engine.trace2.HTTPTraceEvent.wrote_request:type_name -> encore.engine.trace2.HTTPWroteRequest
+	65,  // 93: encore.engine.trace2.HTTPTraceEvent.wait_100_continue:type_name -> encore.engine.trace2.HTTPWait100Continue
+	66,  // 94: encore.engine.trace2.HTTPTraceEvent.closed_body:type_name -> encore.engine.trace2.HTTPClosedBodyData
+	58,  // 95: encore.engine.trace2.HTTPDNSDone.addrs:type_name -> encore.engine.trace2.DNSAddr
+	5,   // 96: encore.engine.trace2.LogMessage.level:type_name -> encore.engine.trace2.LogMessage.Level
+	68,  // 97: encore.engine.trace2.LogMessage.fields:type_name -> encore.engine.trace2.LogField
+	69,  // 98: encore.engine.trace2.LogMessage.stack:type_name -> encore.engine.trace2.StackTrace
+	71,  // 99: encore.engine.trace2.LogField.error:type_name -> encore.engine.trace2.Error
+	74,  // 100: encore.engine.trace2.LogField.time:type_name -> google.protobuf.Timestamp
+	70,  // 101: encore.engine.trace2.StackTrace.frames:type_name -> encore.engine.trace2.StackFrame
+	69,  // 102: encore.engine.trace2.Error.stack:type_name -> encore.engine.trace2.StackTrace
+	103, // [103:103] is the sub-list for method output_type
+	103, // [103:103] is the sub-list for method input_type
+	103, // [103:103] is the sub-list for extension type_name
+	103, // [103:103] is the sub-list for extension extendee
+	0,   // [0:103] is the sub-list for field type_name
 }
 
 func init() { file_encore_engine_trace2_trace2_proto_init() }
@@ -6086,12 +6254,12 @@ func file_encore_engine_trace2_trace2_proto_init() {
 		(*SpanEnd_Test)(nil),
 	}
 	file_encore_engine_trace2_trace2_proto_msgTypes[6].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[7].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[8].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[9].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[10].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[13].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[14].OneofWrappers = []any{
+	file_encore_engine_trace2_trace2_proto_msgTypes[11].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[14].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[15].OneofWrappers = []any{
 		(*SpanEvent_LogMessage)(nil),
 		(*SpanEvent_BodyStream)(nil),
 		(*SpanEvent_RpcCallStart)(nil),
@@ -6119,24 +6287,24 @@ func file_encore_engine_trace2_trace2_proto_init() {
 		(*SpanEvent_BucketDeleteObjectsStart)(nil),
 		(*SpanEvent_BucketDeleteObjectsEnd)(nil),
 	}
-	file_encore_engine_trace2_trace2_proto_msgTypes[16].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[20].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[22].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[24].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[26].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[28].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[30].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[17].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[21].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[23].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[25].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[27].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[29].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[31].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[32].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[33].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[34].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[35].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[36].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[38].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[37].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[39].OneofWrappers = []any{}
 	file_encore_engine_trace2_trace2_proto_msgTypes[40].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[43].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[44].OneofWrappers = []any{
+	file_encore_engine_trace2_trace2_proto_msgTypes[41].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[44].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[45].OneofWrappers = []any{
 		(*HTTPTraceEvent_GetConn)(nil),
 		(*HTTPTraceEvent_GotConn)(nil),
 		(*HTTPTraceEvent_GotFirstResponseByte)(nil),
@@ -6152,11 +6320,11 @@ func file_encore_engine_trace2_trace2_proto_init() {
 		(*HTTPTraceEvent_Wait_100Continue)(nil),
 		(*HTTPTraceEvent_ClosedBody)(nil),
 	}
-	file_encore_engine_trace2_trace2_proto_msgTypes[50].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[55].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[57].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[59].OneofWrappers = []any{}
-	file_encore_engine_trace2_trace2_proto_msgTypes[61].OneofWrappers = []any{
+	file_encore_engine_trace2_trace2_proto_msgTypes[51].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[56].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[58].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[60].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[62].OneofWrappers = []any{
 		(*LogField_Error)(nil),
 		(*LogField_Str)(nil),
 		(*LogField_Bool)(nil),
@@ -6169,14 +6337,14 @@ func file_encore_engine_trace2_trace2_proto_init() {
 		(*LogField_Float32)(nil),
 		(*LogField_Float64)(nil),
 	}
-	file_encore_engine_trace2_trace2_proto_msgTypes[64].OneofWrappers = []any{}
+	file_encore_engine_trace2_trace2_proto_msgTypes[65].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_engine_trace2_trace2_proto_rawDesc), len(file_encore_engine_trace2_trace2_proto_rawDesc)),
 			NumEnums:      6,
-			NumMessages:   67,
+			NumMessages:   68,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -147,7 +147,7 @@ func (x BucketUsage_Operation) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BucketUsage_Operation.Descriptor instead.
 func (BucketUsage_Operation) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{4, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{5, 0}
 }
 
 type Selector_Type int32
@@ -196,7 +196,7 @@ func (x Selector_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Selector_Type.Descriptor instead.
 func (Selector_Type) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{5, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{6, 0}
 }
 
 type RPC_AccessType int32
@@ -245,7 +245,7 @@ func (x RPC_AccessType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RPC_AccessType.Descriptor instead.
 func (RPC_AccessType) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{6, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7, 0}
 }
 
 type RPC_Protocol int32
@@ -291,7 +291,53 @@ func (x RPC_Protocol) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RPC_Protocol.Descriptor instead.
 func (RPC_Protocol) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{6, 1}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7, 1}
+}
+
+type RPC_RoutingCondition_Source int32
+
+const (
+	RPC_RoutingCondition_HEADER RPC_RoutingCondition_Source = 0
+	RPC_RoutingCondition_QUERY  RPC_RoutingCondition_Source = 1
+)
+
+// Enum value maps for RPC_RoutingCondition_Source.
+var (
+	RPC_RoutingCondition_Source_name = map[int32]string{
+		0: "HEADER",
+		1: "QUERY",
+	}
+	RPC_RoutingCondition_Source_value = map[string]int32{
+		"HEADER": 0,
+		"QUERY":  1,
+	}
+)
+
+func (x RPC_RoutingCondition_Source) Enum() *RPC_RoutingCondition_Source {
+	p := new(RPC_RoutingCondition_Source)
+	*p = x
+	return p
+}
+
+func (x RPC_RoutingCondition_Source) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RPC_RoutingCondition_Source) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[5].Descriptor()
+}
+
+func (RPC_RoutingCondition_Source) Type() protoreflect.EnumType {
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[5]
+}
+
+func (x RPC_RoutingCondition_Source) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RPC_RoutingCondition_Source.Descriptor instead.
+func (RPC_RoutingCondition_Source) EnumDescriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7, 2, 0}
 }
 
 type StaticCallNode_Package int32
@@ -327,11 +373,11 @@ func (x StaticCallNode_Package) String() string {
 }
 
 func (StaticCallNode_Package) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_parser_meta_v1_meta_proto_enumTypes[5].Descriptor()
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[6].Descriptor()
 }
 
 func (StaticCallNode_Package) Type() protoreflect.EnumType {
-	return &file_encore_parser_meta_v1_meta_proto_enumTypes[5]
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[6]
 }
 
 func (x StaticCallNode_Package) Number() protoreflect.EnumNumber {
@@ -340,7 +386,7 @@ func (x StaticCallNode_Package) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use StaticCallNode_Package.Descriptor instead.
 func (StaticCallNode_Package) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{12, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{13, 0}
 }
 
 type Path_Type int32
@@ -373,11 +419,11 @@ func (x Path_Type) String() string {
 }
 
 func (Path_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_parser_meta_v1_meta_proto_enumTypes[6].Descriptor()
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[7].Descriptor()
 }
 
 func (Path_Type) Type() protoreflect.EnumType {
-	return &file_encore_parser_meta_v1_meta_proto_enumTypes[6]
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[7]
 }
 
 func (x Path_Type) Number() protoreflect.EnumNumber {
@@ -386,7 +432,7 @@ func (x Path_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Path_Type.Descriptor instead.
 func (Path_Type) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{20, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{21, 0}
 }
 
 type PathSegment_SegmentType int32
@@ -425,11 +471,11 @@ func (x PathSegment_SegmentType) String() string {
 }
 
 func (PathSegment_SegmentType) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_parser_meta_v1_meta_proto_enumTypes[7].Descriptor()
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[8].Descriptor()
 }
 
 func (PathSegment_SegmentType) Type() protoreflect.EnumType {
-	return &file_encore_parser_meta_v1_meta_proto_enumTypes[7]
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[8]
 }
 
 func (x PathSegment_SegmentType) Number() protoreflect.EnumNumber {
@@ -438,7 +484,7 @@ func (x PathSegment_SegmentType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PathSegment_SegmentType.Descriptor instead.
 func (PathSegment_SegmentType) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{21, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{22, 0}
 }
 
 type PathSegment_ParamType int32
@@ -504,11 +550,11 @@ func (x PathSegment_ParamType) String() string {
 }
 
 func (PathSegment_ParamType) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_parser_meta_v1_meta_proto_enumTypes[8].Descriptor()
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[9].Descriptor()
 }
 
 func (PathSegment_ParamType) Type() protoreflect.EnumType {
-	return &file_encore_parser_meta_v1_meta_proto_enumTypes[8]
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[9]
 }
 
 func (x PathSegment_ParamType) Number() protoreflect.EnumNumber {
@@ -517,7 +563,53 @@ func (x PathSegment_ParamType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PathSegment_ParamType.Descriptor instead.
 func (PathSegment_ParamType) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{21, 1}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{22, 1}
+}
+
+type SQLDatabase_Engine int32
+
+const (
+	SQLDatabase_POSTGRES SQLDatabase_Engine = 0
+	SQLDatabase_MYSQL    SQLDatabase_Engine = 1
+)
+
+// Enum value maps for SQLDatabase_Engine.
+var (
+	SQLDatabase_Engine_name = map[int32]string{
+		0: "POSTGRES",
+		1: "MYSQL",
+	}
+	SQLDatabase_Engine_value = map[string]int32{
+		"POSTGRES": 0,
+		"MYSQL":    1,
+	}
+)
+
+func (x SQLDatabase_Engine) Enum() *SQLDatabase_Engine {
+	p := new(SQLDatabase_Engine)
+	*p = x
+	return p
+}
+
+func (x SQLDatabase_Engine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SQLDatabase_Engine) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[10].Descriptor()
+}
+
+func (SQLDatabase_Engine) Type() protoreflect.EnumType {
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[10]
+}
+
+func (x SQLDatabase_Engine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SQLDatabase_Engine.Descriptor instead.
+func (SQLDatabase_Engine) EnumDescriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{25, 0}
 }
 
 type PubSubTopic_DeliveryGuarantee int32
@@ -550,11 +642,11 @@ func (x PubSubTopic_DeliveryGuarantee) String() string {
 }
 
 func (PubSubTopic_DeliveryGuarantee) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_parser_meta_v1_meta_proto_enumTypes[9].Descriptor()
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[11].Descriptor()
 }
 
 func (PubSubTopic_DeliveryGuarantee) Type() protoreflect.EnumType {
-	return &file_encore_parser_meta_v1_meta_proto_enumTypes[9]
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[11]
 }
 
 func (x PubSubTopic_DeliveryGuarantee) Number() protoreflect.EnumNumber {
@@ -563,7 +655,7 @@ func (x PubSubTopic_DeliveryGuarantee) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PubSubTopic_DeliveryGuarantee.Descriptor instead.
 func (PubSubTopic_DeliveryGuarantee) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{32, 0}
 }
 
 type Metric_MetricKind int32
@@ -599,11 +691,11 @@ func (x Metric_MetricKind) String() string {
 }
 
 func (Metric_MetricKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_parser_meta_v1_meta_proto_enumTypes[10].Descriptor()
+	return file_encore_parser_meta_v1_meta_proto_enumTypes[12].Descriptor()
 }
 
 func (Metric_MetricKind) Type() protoreflect.EnumType {
-	return &file_encore_parser_meta_v1_meta_proto_enumTypes[10]
+	return &file_encore_parser_meta_v1_meta_proto_enumTypes[12]
 }
 
 func (x Metric_MetricKind) Number() protoreflect.EnumNumber {
@@ -612,7 +704,7 @@ func (x Metric_MetricKind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Metric_MetricKind.Descriptor instead.
 func (Metric_MetricKind) EnumDescriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{29, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{34, 0}
 }
 
 // Data is the metadata associated with an app version.
@@ -635,6 +727,10 @@ type Data struct {
 	Gateways           []*Gateway             `protobuf:"bytes,15,rep,name=gateways,proto3" json:"gateways,omitempty"`
 	Language           Lang                   `protobuf:"varint,16,opt,name=language,proto3,enum=encore.parser.meta.v1.Lang" json:"language,omitempty"`
 	Buckets            []*Bucket              `protobuf:"bytes,17,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	SearchIndexes      []*SearchIndex         `protobuf:"bytes,18,rep,name=search_indexes,json=searchIndexes,proto3" json:"search_indexes,omitempty"`
+	InboundEmails      []*InboundEmail        `protobuf:"bytes,19,rep,name=inbound_emails,json=inboundEmails,proto3" json:"inbound_emails,omitempty"`
+	OperationTrackers  []*OperationTracker    `protobuf:"bytes,20,rep,name=operation_trackers,json=operationTrackers,proto3" json:"operation_trackers,omitempty"`
+	EnvVars            []*EnvVar              `protobuf:"bytes,21,rep,name=env_vars,json=envVars,proto3" json:"env_vars,omitempty"` // the environment variables declared by the application
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -788,6 +884,34 @@ func (x *Data) GetBuckets() []*Bucket {
 	return nil
 }
 
+func (x *Data) GetSearchIndexes() []*SearchIndex {
+	if x != nil {
+		return x.SearchIndexes
+	}
+	return nil
+}
+
+func (x *Data) GetInboundEmails() []*InboundEmail {
+	if x != nil {
+		return x.InboundEmails
+	}
+	return nil
+}
+
+func (x *Data) GetOperationTrackers() []*OperationTracker {
+	if x != nil {
+		return x.OperationTrackers
+	}
+	return nil
+}
+
+func (x *Data) GetEnvVars() []*EnvVar {
+	if x != nil {
+		return x.EnvVars
+	}
+	return nil
+}
+
 // QualifiedName is a name of an object in a specific package.
 // It is never an unqualified name, even in circumstances
 // where a package may refer to its own objects.
@@ -945,6 +1069,7 @@ type Service struct {
 	HasConfig     bool                   `protobuf:"varint,6,opt,name=has_config,json=hasConfig,proto3" json:"has_config,omitempty"` // true if the service has uses config
 	Buckets       []*BucketUsage         `protobuf:"bytes,7,rep,name=buckets,proto3" json:"buckets,omitempty"`                       // buckets this service uses
 	Metrics       []string               `protobuf:"bytes,8,rep,name=metrics,proto3" json:"metrics,omitempty"`                       // metrics this service uses
+	Owner         *ServiceOwner          `protobuf:"bytes,9,opt,name=owner,proto3" json:"owner,omitempty"`                           // the team owning the service, if declared
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1035,6 +1160,74 @@ func (x *Service) GetMetrics() []string {
 	return nil
 }
 
+func (x *Service) GetOwner() *ServiceOwner {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+// ServiceOwner describes the team owning a service.
+type ServiceOwner struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          string                 `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	SlackChannel  string                 `protobuf:"bytes,2,opt,name=slack_channel,json=slackChannel,proto3" json:"slack_channel,omitempty"` // the team's Slack channel, like "#payments", if any
+	OnCall        string                 `protobuf:"bytes,3,opt,name=on_call,json=onCall,proto3" json:"on_call,omitempty"`                   // how to reach the team's on-call, like a rotation name, if any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceOwner) Reset() {
+	*x = ServiceOwner{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceOwner) ProtoMessage() {}
+
+func (x *ServiceOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceOwner.ProtoReflect.Descriptor instead.
+func (*ServiceOwner) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ServiceOwner) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *ServiceOwner) GetSlackChannel() string {
+	if x != nil {
+		return x.SlackChannel
+	}
+	return ""
+}
+
+func (x *ServiceOwner) GetOnCall() string {
+	if x != nil {
+		return x.OnCall
+	}
+	return ""
+}
+
 type BucketUsage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The encore name of the bucket.
@@ -1047,7 +1240,7 @@ type BucketUsage struct {
 
 func (x *BucketUsage) Reset() {
 	*x = BucketUsage{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[4]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1059,7 +1252,7 @@ func (x *BucketUsage) String() string {
 func (*BucketUsage) ProtoMessage() {}
 
 func (x *BucketUsage) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[4]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1072,7 +1265,7 @@ func (x *BucketUsage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketUsage.ProtoReflect.Descriptor instead.
 func (*BucketUsage) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{4}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *BucketUsage) GetBucket() string {
@@ -1099,7 +1292,7 @@ type Selector struct {
 
 func (x *Selector) Reset() {
 	*x = Selector{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[5]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1111,7 +1304,7 @@ func (x *Selector) String() string {
 func (*Selector) ProtoMessage() {}
 
 func (x *Selector) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[5]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1124,7 +1317,7 @@ func (x *Selector) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Selector.ProtoReflect.Descriptor instead.
 func (*Selector) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{5}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Selector) GetType() Selector_Type {
@@ -1169,14 +1362,24 @@ type RPC struct {
 	StreamingResponse bool     `protobuf:"varint,17,opt,name=streaming_response,json=streamingResponse,proto3" json:"streaming_response,omitempty"`
 	HandshakeSchema   *v1.Type `protobuf:"bytes,18,opt,name=handshake_schema,json=handshakeSchema,proto3,oneof" json:"handshake_schema,omitempty"` // handshake schema, or nil
 	// If the endpoint serves static assets.
-	StaticAssets  *RPC_StaticAssets `protobuf:"bytes,19,opt,name=static_assets,json=staticAssets,proto3,oneof" json:"static_assets,omitempty"`
+	StaticAssets *RPC_StaticAssets `protobuf:"bytes,19,opt,name=static_assets,json=staticAssets,proto3,oneof" json:"static_assets,omitempty"`
+	// Additional conditions a request must match, besides the path and
+	// HTTP method, to be routed to this endpoint. Used to let multiple raw
+	// endpoints share the same path.
+	RoutingConditions []*RPC_RoutingCondition `protobuf:"bytes,20,rep,name=routing_conditions,json=routingConditions,proto3" json:"routing_conditions,omitempty"`
+	// The deprecation notice of the endpoint, if it is deprecated.
+	// It is empty if the endpoint is deprecated without a notice.
+	Deprecated *string `protobuf:"bytes,21,opt,name=deprecated,proto3,oneof" json:"deprecated,omitempty"`
+	// The experiment the endpoint is an alternate implementation for,
+	// of the endpoint with the same path and HTTP methods.
+	Experiment    *RPC_Experiment `protobuf:"bytes,22,opt,name=experiment,proto3,oneof" json:"experiment,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RPC) Reset() {
 	*x = RPC{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[6]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1188,7 +1391,7 @@ func (x *RPC) String() string {
 func (*RPC) ProtoMessage() {}
 
 func (x *RPC) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[6]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1201,7 +1404,7 @@ func (x *RPC) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPC.ProtoReflect.Descriptor instead.
 func (*RPC) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{6}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RPC) GetName() string {
@@ -1337,6 +1540,27 @@ func (x *RPC) GetStaticAssets() *RPC_StaticAssets {
 	return nil
 }
 
+func (x *RPC) GetRoutingConditions() []*RPC_RoutingCondition {
+	if x != nil {
+		return x.RoutingConditions
+	}
+	return nil
+}
+
+func (x *RPC) GetDeprecated() string {
+	if x != nil && x.Deprecated != nil {
+		return *x.Deprecated
+	}
+	return ""
+}
+
+func (x *RPC) GetExperiment() *RPC_Experiment {
+	if x != nil {
+		return x.Experiment
+	}
+	return nil
+}
+
 type AuthHandler struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -1353,7 +1577,7 @@ type AuthHandler struct {
 
 func (x *AuthHandler) Reset() {
 	*x = AuthHandler{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[7]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1365,7 +1589,7 @@ func (x *AuthHandler) String() string {
 func (*AuthHandler) ProtoMessage() {}
 
 func (x *AuthHandler) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[7]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1378,7 +1602,7 @@ func (x *AuthHandler) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthHandler.ProtoReflect.Descriptor instead.
 func (*AuthHandler) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AuthHandler) GetName() string {
@@ -1451,7 +1675,7 @@ type Middleware struct {
 
 func (x *Middleware) Reset() {
 	*x = Middleware{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[8]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1463,7 +1687,7 @@ func (x *Middleware) String() string {
 func (*Middleware) ProtoMessage() {}
 
 func (x *Middleware) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[8]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1476,7 +1700,7 @@ func (x *Middleware) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Middleware.ProtoReflect.Descriptor instead.
 func (*Middleware) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{8}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Middleware) GetName() *QualifiedName {
@@ -1550,7 +1774,7 @@ type TraceNode struct {
 
 func (x *TraceNode) Reset() {
 	*x = TraceNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[9]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1562,7 +1786,7 @@ func (x *TraceNode) String() string {
 func (*TraceNode) ProtoMessage() {}
 
 func (x *TraceNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[9]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1575,7 +1799,7 @@ func (x *TraceNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TraceNode.ProtoReflect.Descriptor instead.
 func (*TraceNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{9}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *TraceNode) GetId() int32 {
@@ -1806,7 +2030,7 @@ type RPCDefNode struct {
 
 func (x *RPCDefNode) Reset() {
 	*x = RPCDefNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[10]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1818,7 +2042,7 @@ func (x *RPCDefNode) String() string {
 func (*RPCDefNode) ProtoMessage() {}
 
 func (x *RPCDefNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[10]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1831,7 +2055,7 @@ func (x *RPCDefNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPCDefNode.ProtoReflect.Descriptor instead.
 func (*RPCDefNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{10}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RPCDefNode) GetServiceName() string {
@@ -1866,7 +2090,7 @@ type RPCCallNode struct {
 
 func (x *RPCCallNode) Reset() {
 	*x = RPCCallNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[11]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1878,7 +2102,7 @@ func (x *RPCCallNode) String() string {
 func (*RPCCallNode) ProtoMessage() {}
 
 func (x *RPCCallNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[11]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1891,7 +2115,7 @@ func (x *RPCCallNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPCCallNode.ProtoReflect.Descriptor instead.
 func (*RPCCallNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{11}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RPCCallNode) GetServiceName() string {
@@ -1926,7 +2150,7 @@ type StaticCallNode struct {
 
 func (x *StaticCallNode) Reset() {
 	*x = StaticCallNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[12]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1938,7 +2162,7 @@ func (x *StaticCallNode) String() string {
 func (*StaticCallNode) ProtoMessage() {}
 
 func (x *StaticCallNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[12]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1951,7 +2175,7 @@ func (x *StaticCallNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StaticCallNode.ProtoReflect.Descriptor instead.
 func (*StaticCallNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{12}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *StaticCallNode) GetPackage() StaticCallNode_Package {
@@ -1986,7 +2210,7 @@ type AuthHandlerDefNode struct {
 
 func (x *AuthHandlerDefNode) Reset() {
 	*x = AuthHandlerDefNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[13]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1998,7 +2222,7 @@ func (x *AuthHandlerDefNode) String() string {
 func (*AuthHandlerDefNode) ProtoMessage() {}
 
 func (x *AuthHandlerDefNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[13]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2011,7 +2235,7 @@ func (x *AuthHandlerDefNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthHandlerDefNode.ProtoReflect.Descriptor instead.
 func (*AuthHandlerDefNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{13}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AuthHandlerDefNode) GetServiceName() string {
@@ -2045,7 +2269,7 @@ type PubSubTopicDefNode struct {
 
 func (x *PubSubTopicDefNode) Reset() {
 	*x = PubSubTopicDefNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[14]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2057,7 +2281,7 @@ func (x *PubSubTopicDefNode) String() string {
 func (*PubSubTopicDefNode) ProtoMessage() {}
 
 func (x *PubSubTopicDefNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[14]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2070,7 +2294,7 @@ func (x *PubSubTopicDefNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopicDefNode.ProtoReflect.Descriptor instead.
 func (*PubSubTopicDefNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{14}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *PubSubTopicDefNode) GetTopicName() string {
@@ -2097,7 +2321,7 @@ type PubSubPublishNode struct {
 
 func (x *PubSubPublishNode) Reset() {
 	*x = PubSubPublishNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[15]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2109,7 +2333,7 @@ func (x *PubSubPublishNode) String() string {
 func (*PubSubPublishNode) ProtoMessage() {}
 
 func (x *PubSubPublishNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[15]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2122,7 +2346,7 @@ func (x *PubSubPublishNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubPublishNode.ProtoReflect.Descriptor instead.
 func (*PubSubPublishNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{15}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *PubSubPublishNode) GetTopicName() string {
@@ -2151,7 +2375,7 @@ type PubSubSubscriberNode struct {
 
 func (x *PubSubSubscriberNode) Reset() {
 	*x = PubSubSubscriberNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[16]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2163,7 +2387,7 @@ func (x *PubSubSubscriberNode) String() string {
 func (*PubSubSubscriberNode) ProtoMessage() {}
 
 func (x *PubSubSubscriberNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[16]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2176,7 +2400,7 @@ func (x *PubSubSubscriberNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubSubscriberNode.ProtoReflect.Descriptor instead.
 func (*PubSubSubscriberNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{16}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PubSubSubscriberNode) GetTopicName() string {
@@ -2218,7 +2442,7 @@ type ServiceInitNode struct {
 
 func (x *ServiceInitNode) Reset() {
 	*x = ServiceInitNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[17]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2230,7 +2454,7 @@ func (x *ServiceInitNode) String() string {
 func (*ServiceInitNode) ProtoMessage() {}
 
 func (x *ServiceInitNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[17]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2243,7 +2467,7 @@ func (x *ServiceInitNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceInitNode.ProtoReflect.Descriptor instead.
 func (*ServiceInitNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{17}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ServiceInitNode) GetServiceName() string {
@@ -2279,7 +2503,7 @@ type MiddlewareDefNode struct {
 
 func (x *MiddlewareDefNode) Reset() {
 	*x = MiddlewareDefNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[18]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2291,7 +2515,7 @@ func (x *MiddlewareDefNode) String() string {
 func (*MiddlewareDefNode) ProtoMessage() {}
 
 func (x *MiddlewareDefNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[18]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2304,7 +2528,7 @@ func (x *MiddlewareDefNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MiddlewareDefNode.ProtoReflect.Descriptor instead.
 func (*MiddlewareDefNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{18}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *MiddlewareDefNode) GetPkgRelPath() string {
@@ -2347,7 +2571,7 @@ type CacheKeyspaceDefNode struct {
 
 func (x *CacheKeyspaceDefNode) Reset() {
 	*x = CacheKeyspaceDefNode{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[19]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2359,7 +2583,7 @@ func (x *CacheKeyspaceDefNode) String() string {
 func (*CacheKeyspaceDefNode) ProtoMessage() {}
 
 func (x *CacheKeyspaceDefNode) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[19]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2372,7 +2596,7 @@ func (x *CacheKeyspaceDefNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheKeyspaceDefNode.ProtoReflect.Descriptor instead.
 func (*CacheKeyspaceDefNode) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{19}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CacheKeyspaceDefNode) GetPkgRelPath() string {
@@ -2413,7 +2637,7 @@ type Path struct {
 
 func (x *Path) Reset() {
 	*x = Path{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[20]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2425,7 +2649,7 @@ func (x *Path) String() string {
 func (*Path) ProtoMessage() {}
 
 func (x *Path) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[20]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2438,7 +2662,7 @@ func (x *Path) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Path.ProtoReflect.Descriptor instead.
 func (*Path) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{20}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Path) GetSegments() []*PathSegment {
@@ -2467,7 +2691,7 @@ type PathSegment struct {
 
 func (x *PathSegment) Reset() {
 	*x = PathSegment{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[21]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2479,7 +2703,7 @@ func (x *PathSegment) String() string {
 func (*PathSegment) ProtoMessage() {}
 
 func (x *PathSegment) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[21]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2492,7 +2716,7 @@ func (x *PathSegment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PathSegment.ProtoReflect.Descriptor instead.
 func (*PathSegment) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{21}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *PathSegment) GetType() PathSegment_SegmentType {
@@ -2534,7 +2758,7 @@ type Gateway struct {
 
 func (x *Gateway) Reset() {
 	*x = Gateway{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[22]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2546,7 +2770,7 @@ func (x *Gateway) String() string {
 func (*Gateway) ProtoMessage() {}
 
 func (x *Gateway) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[22]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2559,7 +2783,7 @@ func (x *Gateway) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway.ProtoReflect.Descriptor instead.
 func (*Gateway) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{22}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Gateway) GetEncoreName() string {
@@ -2589,7 +2813,7 @@ type CronJob struct {
 
 func (x *CronJob) Reset() {
 	*x = CronJob{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[23]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2601,7 +2825,7 @@ func (x *CronJob) String() string {
 func (*CronJob) ProtoMessage() {}
 
 func (x *CronJob) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[23]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2614,7 +2838,7 @@ func (x *CronJob) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CronJob.ProtoReflect.Descriptor instead.
 func (*CronJob) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{23}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CronJob) GetId() string {
@@ -2658,16 +2882,19 @@ type SQLDatabase struct {
 	Doc   *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
 	// migration_rel_path is the slash-separated path to the migrations,
 	// relative to the main module's root directory.
-	MigrationRelPath             *string        `protobuf:"bytes,3,opt,name=migration_rel_path,json=migrationRelPath,proto3,oneof" json:"migration_rel_path,omitempty"`
-	Migrations                   []*DBMigration `protobuf:"bytes,4,rep,name=migrations,proto3" json:"migrations,omitempty"`
-	AllowNonSequentialMigrations bool           `protobuf:"varint,5,opt,name=allow_non_sequential_migrations,json=allowNonSequentialMigrations,proto3" json:"allow_non_sequential_migrations,omitempty"`
-	unknownFields                protoimpl.UnknownFields
-	sizeCache                    protoimpl.SizeCache
+	MigrationRelPath             *string            `protobuf:"bytes,3,opt,name=migration_rel_path,json=migrationRelPath,proto3,oneof" json:"migration_rel_path,omitempty"`
+	Migrations                   []*DBMigration     `protobuf:"bytes,4,rep,name=migrations,proto3" json:"migrations,omitempty"`
+	AllowNonSequentialMigrations bool               `protobuf:"varint,5,opt,name=allow_non_sequential_migrations,json=allowNonSequentialMigrations,proto3" json:"allow_non_sequential_migrations,omitempty"`
+	Engine                       SQLDatabase_Engine `protobuf:"varint,6,opt,name=engine,proto3,enum=encore.parser.meta.v1.SQLDatabase_Engine" json:"engine,omitempty"` // the database engine
+	// read_access are the services granted read-only access to the database.
+	ReadAccess    []string `protobuf:"bytes,7,rep,name=read_access,json=readAccess,proto3" json:"read_access,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SQLDatabase) Reset() {
 	*x = SQLDatabase{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[24]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2679,7 +2906,7 @@ func (x *SQLDatabase) String() string {
 func (*SQLDatabase) ProtoMessage() {}
 
 func (x *SQLDatabase) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[24]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2692,7 +2919,7 @@ func (x *SQLDatabase) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLDatabase.ProtoReflect.Descriptor instead.
 func (*SQLDatabase) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{24}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SQLDatabase) GetName() string {
@@ -2730,18 +2957,33 @@ func (x *SQLDatabase) GetAllowNonSequentialMigrations() bool {
 	return false
 }
 
+func (x *SQLDatabase) GetEngine() SQLDatabase_Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return SQLDatabase_POSTGRES
+}
+
+func (x *SQLDatabase) GetReadAccess() []string {
+	if x != nil {
+		return x.ReadAccess
+	}
+	return nil
+}
+
 type DBMigration struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`       // filename
-	Number        uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`          // migration number
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"` // descriptive name
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`                             // filename
+	Number        uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`                                // migration number
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`                       // descriptive name
+	DownFilename  string                 `protobuf:"bytes,4,opt,name=down_filename,json=downFilename,proto3" json:"down_filename,omitempty"` // filename of the down migration, or "" if there is none
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DBMigration) Reset() {
 	*x = DBMigration{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[25]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2753,7 +2995,7 @@ func (x *DBMigration) String() string {
 func (*DBMigration) ProtoMessage() {}
 
 func (x *DBMigration) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[25]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2766,7 +3008,7 @@ func (x *DBMigration) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DBMigration.ProtoReflect.Descriptor instead.
 func (*DBMigration) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{25}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DBMigration) GetFilename() string {
@@ -2790,6 +3032,13 @@ func (x *DBMigration) GetDescription() string {
 	return ""
 }
 
+func (x *DBMigration) GetDownFilename() string {
+	if x != nil {
+		return x.DownFilename
+	}
+	return ""
+}
+
 type Bucket struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -2802,7 +3051,7 @@ type Bucket struct {
 
 func (x *Bucket) Reset() {
 	*x = Bucket{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[26]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2814,7 +3063,7 @@ func (x *Bucket) String() string {
 func (*Bucket) ProtoMessage() {}
 
 func (x *Bucket) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[26]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2827,7 +3076,7 @@ func (x *Bucket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
 func (*Bucket) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{26}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *Bucket) GetName() string {
@@ -2858,6 +3107,312 @@ func (x *Bucket) GetPublic() bool {
 	return false
 }
 
+type SearchIndex struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Doc              *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	SearchableFields []string               `protobuf:"bytes,3,rep,name=searchable_fields,json=searchableFields,proto3" json:"searchable_fields,omitempty"` // the document fields queries match against
+	FilterableFields []string               `protobuf:"bytes,4,rep,name=filterable_fields,json=filterableFields,proto3" json:"filterable_fields,omitempty"` // the document fields queries can filter on
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SearchIndex) Reset() {
+	*x = SearchIndex{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchIndex) ProtoMessage() {}
+
+func (x *SearchIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchIndex.ProtoReflect.Descriptor instead.
+func (*SearchIndex) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SearchIndex) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchIndex) GetDoc() string {
+	if x != nil && x.Doc != nil {
+		return *x.Doc
+	}
+	return ""
+}
+
+func (x *SearchIndex) GetSearchableFields() []string {
+	if x != nil {
+		return x.SearchableFields
+	}
+	return nil
+}
+
+func (x *SearchIndex) GetFilterableFields() []string {
+	if x != nil {
+		return x.FilterableFields
+	}
+	return nil
+}
+
+type InboundEmail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Doc           *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"` // the service handling the received messages
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InboundEmail) Reset() {
+	*x = InboundEmail{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboundEmail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboundEmail) ProtoMessage() {}
+
+func (x *InboundEmail) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboundEmail.ProtoReflect.Descriptor instead.
+func (*InboundEmail) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *InboundEmail) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InboundEmail) GetDoc() string {
+	if x != nil && x.Doc != nil {
+		return *x.Doc
+	}
+	return ""
+}
+
+func (x *InboundEmail) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+type OperationTracker struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Doc           *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"` // the service declaring the tracker
+	Private       bool                   `protobuf:"varint,4,opt,name=private,proto3" json:"private,omitempty"`                           // whether the status of operations isn't served to clients
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationTracker) Reset() {
+	*x = OperationTracker{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationTracker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationTracker) ProtoMessage() {}
+
+func (x *OperationTracker) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationTracker.ProtoReflect.Descriptor instead.
+func (*OperationTracker) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *OperationTracker) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OperationTracker) GetDoc() string {
+	if x != nil && x.Doc != nil {
+		return *x.Doc
+	}
+	return ""
+}
+
+func (x *OperationTracker) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *OperationTracker) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+// EnvVar is an environment variable declared by the application.
+// A variable declared by several services is listed once for each declaration.
+type EnvVar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Doc           *string                `protobuf:"bytes,2,opt,name=doc,proto3,oneof" json:"doc,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Required      bool                   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	Format        string                 `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`                                    // the format of the value: "string", "int", "bool", "url" or "duration"
+	ServiceName   *string                `protobuf:"bytes,6,opt,name=service_name,json=serviceName,proto3,oneof" json:"service_name,omitempty"` // the service declaring the variable, if any
+	Filepath      string                 `protobuf:"bytes,7,opt,name=filepath,proto3" json:"filepath,omitempty"`                                // the file declaring the variable, relative to the app root
+	SrcLine       int32                  `protobuf:"varint,8,opt,name=src_line,json=srcLine,proto3" json:"src_line,omitempty"`                  // the line of the declaration
+	SrcCol        int32                  `protobuf:"varint,9,opt,name=src_col,json=srcCol,proto3" json:"src_col,omitempty"`                     // the column of the declaration
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnvVar) Reset() {
+	*x = EnvVar{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvVar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvVar) ProtoMessage() {}
+
+func (x *EnvVar) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvVar.ProtoReflect.Descriptor instead.
+func (*EnvVar) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *EnvVar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EnvVar) GetDoc() string {
+	if x != nil && x.Doc != nil {
+		return *x.Doc
+	}
+	return ""
+}
+
+func (x *EnvVar) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EnvVar) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *EnvVar) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *EnvVar) GetServiceName() string {
+	if x != nil && x.ServiceName != nil {
+		return *x.ServiceName
+	}
+	return ""
+}
+
+func (x *EnvVar) GetFilepath() string {
+	if x != nil {
+		return x.Filepath
+	}
+	return ""
+}
+
+func (x *EnvVar) GetSrcLine() int32 {
+	if x != nil {
+		return x.SrcLine
+	}
+	return 0
+}
+
+func (x *EnvVar) GetSrcCol() int32 {
+	if x != nil {
+		return x.SrcCol
+	}
+	return 0
+}
+
 type PubSubTopic struct {
 	state             protoimpl.MessageState        `protogen:"open.v1"`
 	Name              string                        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                                                              // The pub sub topic name (unique per application)
@@ -2873,7 +3428,7 @@ type PubSubTopic struct {
 
 func (x *PubSubTopic) Reset() {
 	*x = PubSubTopic{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[27]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2885,7 +3440,7 @@ func (x *PubSubTopic) String() string {
 func (*PubSubTopic) ProtoMessage() {}
 
 func (x *PubSubTopic) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[27]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2898,7 +3453,7 @@ func (x *PubSubTopic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic.ProtoReflect.Descriptor instead.
 func (*PubSubTopic) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *PubSubTopic) GetName() string {
@@ -2962,7 +3517,7 @@ type CacheCluster struct {
 
 func (x *CacheCluster) Reset() {
 	*x = CacheCluster{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[28]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2974,7 +3529,7 @@ func (x *CacheCluster) String() string {
 func (*CacheCluster) ProtoMessage() {}
 
 func (x *CacheCluster) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[28]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2987,7 +3542,7 @@ func (x *CacheCluster) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheCluster.ProtoReflect.Descriptor instead.
 func (*CacheCluster) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{28}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *CacheCluster) GetName() string {
@@ -3032,7 +3587,7 @@ type Metric struct {
 
 func (x *Metric) Reset() {
 	*x = Metric{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[29]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3044,7 +3599,7 @@ func (x *Metric) String() string {
 func (*Metric) ProtoMessage() {}
 
 func (x *Metric) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[29]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3057,7 +3612,7 @@ func (x *Metric) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metric.ProtoReflect.Descriptor instead.
 func (*Metric) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{29}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *Metric) GetName() string {
@@ -3103,14 +3658,17 @@ func (x *Metric) GetLabels() []*Metric_Label {
 }
 
 type RPC_ExposeOptions struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// internal_only restricts access through the gateway to
+	// requests originating from internal (private) networks.
+	InternalOnly  bool `protobuf:"varint,1,opt,name=internal_only,json=internalOnly,proto3" json:"internal_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RPC_ExposeOptions) Reset() {
 	*x = RPC_ExposeOptions{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[31]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3122,7 +3680,7 @@ func (x *RPC_ExposeOptions) String() string {
 func (*RPC_ExposeOptions) ProtoMessage() {}
 
 func (x *RPC_ExposeOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[31]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3135,7 +3693,132 @@ func (x *RPC_ExposeOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPC_ExposeOptions.ProtoReflect.Descriptor instead.
 func (*RPC_ExposeOptions) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{6, 1}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7, 1}
+}
+
+func (x *RPC_ExposeOptions) GetInternalOnly() bool {
+	if x != nil {
+		return x.InternalOnly
+	}
+	return false
+}
+
+type RPC_RoutingCondition struct {
+	state  protoimpl.MessageState      `protogen:"open.v1"`
+	Source RPC_RoutingCondition_Source `protobuf:"varint,1,opt,name=source,proto3,enum=encore.parser.meta.v1.RPC_RoutingCondition_Source" json:"source,omitempty"`
+	// name is the header or query parameter name.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// value is the value to match. If empty, the header
+	// or query parameter only needs to be present.
+	Value         string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RPC_RoutingCondition) Reset() {
+	*x = RPC_RoutingCondition{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RPC_RoutingCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPC_RoutingCondition) ProtoMessage() {}
+
+func (x *RPC_RoutingCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPC_RoutingCondition.ProtoReflect.Descriptor instead.
+func (*RPC_RoutingCondition) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7, 2}
+}
+
+func (x *RPC_RoutingCondition) GetSource() RPC_RoutingCondition_Source {
+	if x != nil {
+		return x.Source
+	}
+	return RPC_RoutingCondition_HEADER
+}
+
+func (x *RPC_RoutingCondition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RPC_RoutingCondition) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type RPC_Experiment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the experiment.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// percent is the percentage of requests the API gateway
+	// routes to the experiment instead of the regular endpoint.
+	Percent       int32 `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RPC_Experiment) Reset() {
+	*x = RPC_Experiment{}
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RPC_Experiment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPC_Experiment) ProtoMessage() {}
+
+func (x *RPC_Experiment) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPC_Experiment.ProtoReflect.Descriptor instead.
+func (*RPC_Experiment) Descriptor() ([]byte, []int) {
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7, 3}
+}
+
+func (x *RPC_Experiment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RPC_Experiment) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
 }
 
 type RPC_StaticAssets struct {
@@ -3154,7 +3837,7 @@ type RPC_StaticAssets struct {
 
 func (x *RPC_StaticAssets) Reset() {
 	*x = RPC_StaticAssets{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[32]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3166,7 +3849,7 @@ func (x *RPC_StaticAssets) String() string {
 func (*RPC_StaticAssets) ProtoMessage() {}
 
 func (x *RPC_StaticAssets) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[32]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3179,7 +3862,7 @@ func (x *RPC_StaticAssets) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPC_StaticAssets.ProtoReflect.Descriptor instead.
 func (*RPC_StaticAssets) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{6, 2}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7, 4}
 }
 
 func (x *RPC_StaticAssets) GetDirRelPath() string {
@@ -3221,7 +3904,7 @@ type RPC_StaticAssets_HeaderValues struct {
 
 func (x *RPC_StaticAssets_HeaderValues) Reset() {
 	*x = RPC_StaticAssets_HeaderValues{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[33]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3233,7 +3916,7 @@ func (x *RPC_StaticAssets_HeaderValues) String() string {
 func (*RPC_StaticAssets_HeaderValues) ProtoMessage() {}
 
 func (x *RPC_StaticAssets_HeaderValues) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[33]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3246,7 +3929,7 @@ func (x *RPC_StaticAssets_HeaderValues) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RPC_StaticAssets_HeaderValues.ProtoReflect.Descriptor instead.
 func (*RPC_StaticAssets_HeaderValues) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{6, 2, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{7, 4, 0}
 }
 
 func (x *RPC_StaticAssets_HeaderValues) GetValues() []string {
@@ -3267,7 +3950,7 @@ type Gateway_Explicit struct {
 
 func (x *Gateway_Explicit) Reset() {
 	*x = Gateway_Explicit{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[35]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3279,7 +3962,7 @@ func (x *Gateway_Explicit) String() string {
 func (*Gateway_Explicit) ProtoMessage() {}
 
 func (x *Gateway_Explicit) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[35]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3292,7 +3975,7 @@ func (x *Gateway_Explicit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway_Explicit.ProtoReflect.Descriptor instead.
 func (*Gateway_Explicit) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{22, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{23, 0}
 }
 
 func (x *Gateway_Explicit) GetServiceName() string {
@@ -3318,7 +4001,7 @@ type PubSubTopic_Publisher struct {
 
 func (x *PubSubTopic_Publisher) Reset() {
 	*x = PubSubTopic_Publisher{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[36]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3330,7 +4013,7 @@ func (x *PubSubTopic_Publisher) String() string {
 func (*PubSubTopic_Publisher) ProtoMessage() {}
 
 func (x *PubSubTopic_Publisher) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[36]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3343,7 +4026,7 @@ func (x *PubSubTopic_Publisher) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic_Publisher.ProtoReflect.Descriptor instead.
 func (*PubSubTopic_Publisher) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{32, 0}
 }
 
 func (x *PubSubTopic_Publisher) GetServiceName() string {
@@ -3369,7 +4052,7 @@ type PubSubTopic_Subscription struct {
 
 func (x *PubSubTopic_Subscription) Reset() {
 	*x = PubSubTopic_Subscription{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[37]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3381,7 +4064,7 @@ func (x *PubSubTopic_Subscription) String() string {
 func (*PubSubTopic_Subscription) ProtoMessage() {}
 
 func (x *PubSubTopic_Subscription) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[37]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3394,7 +4077,7 @@ func (x *PubSubTopic_Subscription) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic_Subscription.ProtoReflect.Descriptor instead.
 func (*PubSubTopic_Subscription) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27, 1}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{32, 1}
 }
 
 func (x *PubSubTopic_Subscription) GetName() string {
@@ -3450,7 +4133,7 @@ type PubSubTopic_RetryPolicy struct {
 
 func (x *PubSubTopic_RetryPolicy) Reset() {
 	*x = PubSubTopic_RetryPolicy{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[38]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3462,7 +4145,7 @@ func (x *PubSubTopic_RetryPolicy) String() string {
 func (*PubSubTopic_RetryPolicy) ProtoMessage() {}
 
 func (x *PubSubTopic_RetryPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[38]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3475,7 +4158,7 @@ func (x *PubSubTopic_RetryPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic_RetryPolicy.ProtoReflect.Descriptor instead.
 func (*PubSubTopic_RetryPolicy) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{27, 2}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{32, 2}
 }
 
 func (x *PubSubTopic_RetryPolicy) GetMinBackoff() int64 {
@@ -3512,7 +4195,7 @@ type CacheCluster_Keyspace struct {
 
 func (x *CacheCluster_Keyspace) Reset() {
 	*x = CacheCluster_Keyspace{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[39]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3524,7 +4207,7 @@ func (x *CacheCluster_Keyspace) String() string {
 func (*CacheCluster_Keyspace) ProtoMessage() {}
 
 func (x *CacheCluster_Keyspace) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[39]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3537,7 +4220,7 @@ func (x *CacheCluster_Keyspace) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheCluster_Keyspace.ProtoReflect.Descriptor instead.
 func (*CacheCluster_Keyspace) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{28, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{33, 0}
 }
 
 func (x *CacheCluster_Keyspace) GetKeyType() *v1.Type {
@@ -3586,7 +4269,7 @@ type Metric_Label struct {
 
 func (x *Metric_Label) Reset() {
 	*x = Metric_Label{}
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[40]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3598,7 +4281,7 @@ func (x *Metric_Label) String() string {
 func (*Metric_Label) ProtoMessage() {}
 
 func (x *Metric_Label) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[40]
+	mi := &file_encore_parser_meta_v1_meta_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3611,7 +4294,7 @@ func (x *Metric_Label) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metric_Label.ProtoReflect.Descriptor instead.
 func (*Metric_Label) Descriptor() ([]byte, []int) {
-	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{29, 0}
+	return file_encore_parser_meta_v1_meta_proto_rawDescGZIP(), []int{34, 0}
 }
 
 func (x *Metric_Label) GetKey() string {
@@ -3639,7 +4322,8 @@ var File_encore_parser_meta_v1_meta_proto protoreflect.FileDescriptor
 
 const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\n" +
-	" encore/parser/meta/v1/meta.proto\x12\x15encore.parser.meta.v1\x1a$encore/parser/schema/v1/schema.proto\"\xdc\a\n" +
+	" encore/parser/meta/v1/meta.proto\x12\x15encore.parser.meta.v1\x1a$encore/parser/schema/v1/schema.proto\"\x85\n" +
+	"\n" +
 	"\x04Data\x12\x1f\n" +
 	"\vmodule_path\x18\x01 \x01(\tR\n" +
 	"modulePath\x12!\n" +
@@ -3661,7 +4345,11 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\rsql_databases\x18\x0e \x03(\v2\".encore.parser.meta.v1.SQLDatabaseR\fsqlDatabases\x12:\n" +
 	"\bgateways\x18\x0f \x03(\v2\x1e.encore.parser.meta.v1.GatewayR\bgateways\x127\n" +
 	"\blanguage\x18\x10 \x01(\x0e2\x1b.encore.parser.meta.v1.LangR\blanguage\x127\n" +
-	"\abuckets\x18\x11 \x03(\v2\x1d.encore.parser.meta.v1.BucketR\abucketsB\x0f\n" +
+	"\abuckets\x18\x11 \x03(\v2\x1d.encore.parser.meta.v1.BucketR\abuckets\x12I\n" +
+	"\x0esearch_indexes\x18\x12 \x03(\v2\".encore.parser.meta.v1.SearchIndexR\rsearchIndexes\x12J\n" +
+	"\x0einbound_emails\x18\x13 \x03(\v2#.encore.parser.meta.v1.InboundEmailR\rinboundEmails\x12V\n" +
+	"\x12operation_trackers\x18\x14 \x03(\v2'.encore.parser.meta.v1.OperationTrackerR\x11operationTrackers\x128\n" +
+	"\benv_vars\x18\x15 \x03(\v2\x1d.encore.parser.meta.v1.EnvVarR\aenvVarsB\x0f\n" +
 	"\r_auth_handler\"5\n" +
 	"\rQualifiedName\x12\x10\n" +
 	"\x03pkg\x18\x01 \x01(\tR\x03pkg\x12\x12\n" +
@@ -3674,7 +4362,7 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\asecrets\x18\x05 \x03(\tR\asecrets\x12A\n" +
 	"\trpc_calls\x18\x06 \x03(\v2$.encore.parser.meta.v1.QualifiedNameR\brpcCalls\x12A\n" +
 	"\vtrace_nodes\x18\a \x03(\v2 .encore.parser.meta.v1.TraceNodeR\n" +
-	"traceNodes\"\xc1\x02\n" +
+	"traceNodes\"\xfc\x02\n" +
 	"\aService\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
 	"\brel_path\x18\x02 \x01(\tR\arelPath\x12.\n" +
@@ -3686,7 +4374,12 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\n" +
 	"has_config\x18\x06 \x01(\bR\thasConfig\x12<\n" +
 	"\abuckets\x18\a \x03(\v2\".encore.parser.meta.v1.BucketUsageR\abuckets\x12\x18\n" +
-	"\ametrics\x18\b \x03(\tR\ametrics\"\xd8\x02\n" +
+	"\ametrics\x18\b \x03(\tR\ametrics\x129\n" +
+	"\x05owner\x18\t \x01(\v2#.encore.parser.meta.v1.ServiceOwnerR\x05owner\"`\n" +
+	"\fServiceOwner\x12\x12\n" +
+	"\x04team\x18\x01 \x01(\tR\x04team\x12#\n" +
+	"\rslack_channel\x18\x02 \x01(\tR\fslackChannel\x12\x17\n" +
+	"\aon_call\x18\x03 \x01(\tR\x06onCall\"\xd8\x02\n" +
 	"\vBucketUsage\x12\x16\n" +
 	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12L\n" +
 	"\n" +
@@ -3709,7 +4402,7 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\x04Type\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\a\n" +
 	"\x03ALL\x10\x01\x12\a\n" +
-	"\x03TAG\x10\x02\"\xb4\r\n" +
+	"\x03TAG\x10\x02\"\xac\x11\n" +
 	"\x03RPC\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
 	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12!\n" +
@@ -3732,11 +4425,31 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\x11streaming_request\x18\x10 \x01(\bR\x10streamingRequest\x12-\n" +
 	"\x12streaming_response\x18\x11 \x01(\bR\x11streamingResponse\x12M\n" +
 	"\x10handshake_schema\x18\x12 \x01(\v2\x1d.encore.parser.schema.v1.TypeH\x04R\x0fhandshakeSchema\x88\x01\x01\x12Q\n" +
-	"\rstatic_assets\x18\x13 \x01(\v2'.encore.parser.meta.v1.RPC.StaticAssetsH\x05R\fstaticAssets\x88\x01\x01\x1ac\n" +
+	"\rstatic_assets\x18\x13 \x01(\v2'.encore.parser.meta.v1.RPC.StaticAssetsH\x05R\fstaticAssets\x88\x01\x01\x12Z\n" +
+	"\x12routing_conditions\x18\x14 \x03(\v2+.encore.parser.meta.v1.RPC.RoutingConditionR\x11routingConditions\x12#\n" +
+	"\n" +
+	"deprecated\x18\x15 \x01(\tH\x06R\n" +
+	"deprecated\x88\x01\x01\x12J\n" +
+	"\n" +
+	"experiment\x18\x16 \x01(\v2%.encore.parser.meta.v1.RPC.ExperimentH\aR\n" +
+	"experiment\x88\x01\x01\x1ac\n" +
 	"\vExposeEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12>\n" +
-	"\x05value\x18\x02 \x01(\v2(.encore.parser.meta.v1.RPC.ExposeOptionsR\x05value:\x028\x01\x1a\x0f\n" +
-	"\rExposeOptions\x1a\xa7\x03\n" +
+	"\x05value\x18\x02 \x01(\v2(.encore.parser.meta.v1.RPC.ExposeOptionsR\x05value:\x028\x01\x1a4\n" +
+	"\rExposeOptions\x12#\n" +
+	"\rinternal_only\x18\x01 \x01(\bR\finternalOnly\x1a\xa9\x01\n" +
+	"\x10RoutingCondition\x12J\n" +
+	"\x06source\x18\x01 \x01(\x0e22.encore.parser.meta.v1.RPC.RoutingCondition.SourceR\x06source\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"\x1f\n" +
+	"\x06Source\x12\n" +
+	"\n" +
+	"\x06HEADER\x10\x00\x12\t\n" +
+	"\x05QUERY\x10\x01\x1a:\n" +
+	"\n" +
+	"Experiment\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x05R\apercent\x1a\xa7\x03\n" +
 	"\fStaticAssets\x12 \n" +
 	"\fdir_rel_path\x18\x01 \x01(\tR\n" +
 	"dirRelPath\x120\n" +
@@ -3764,7 +4477,9 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\x10_response_schemaB\r\n" +
 	"\v_body_limitB\x13\n" +
 	"\x11_handshake_schemaB\x10\n" +
-	"\x0e_static_assets\"\xd2\x02\n" +
+	"\x0e_static_assetsB\r\n" +
+	"\v_deprecatedB\r\n" +
+	"\v_experiment\"\xd2\x02\n" +
 	"\vAuthHandler\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
 	"\x03doc\x18\x02 \x01(\tR\x03doc\x12\x19\n" +
@@ -3914,7 +4629,7 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\x03doc\x18\x03 \x01(\tH\x00R\x03doc\x88\x01\x01\x12\x1a\n" +
 	"\bschedule\x18\x04 \x01(\tR\bschedule\x12@\n" +
 	"\bendpoint\x18\x05 \x01(\v2$.encore.parser.meta.v1.QualifiedNameR\bendpointB\x06\n" +
-	"\x04_doc\"\x95\x02\n" +
+	"\x04_doc\"\x9c\x03\n" +
 	"\vSQLDatabase\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
 	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x121\n" +
@@ -3922,19 +4637,55 @@ const file_encore_parser_meta_v1_meta_proto_rawDesc = "" +
 	"\n" +
 	"migrations\x18\x04 \x03(\v2\".encore.parser.meta.v1.DBMigrationR\n" +
 	"migrations\x12E\n" +
-	"\x1fallow_non_sequential_migrations\x18\x05 \x01(\bR\x1callowNonSequentialMigrationsB\x06\n" +
+	"\x1fallow_non_sequential_migrations\x18\x05 \x01(\bR\x1callowNonSequentialMigrations\x12A\n" +
+	"\x06engine\x18\x06 \x01(\x0e2).encore.parser.meta.v1.SQLDatabase.EngineR\x06engine\x12\x1f\n" +
+	"\vread_access\x18\a \x03(\tR\n" +
+	"readAccess\"!\n" +
+	"\x06Engine\x12\f\n" +
+	"\bPOSTGRES\x10\x00\x12\t\n" +
+	"\x05MYSQL\x10\x01B\x06\n" +
 	"\x04_docB\x15\n" +
-	"\x13_migration_rel_path\"c\n" +
+	"\x13_migration_rel_path\"\x88\x01\n" +
 	"\vDBMigration\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x16\n" +
 	"\x06number\x18\x02 \x01(\x04R\x06number\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\"q\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12#\n" +
+	"\rdown_filename\x18\x04 \x01(\tR\fdownFilename\"q\n" +
 	"\x06Bucket\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
 	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12\x1c\n" +
 	"\tversioned\x18\x03 \x01(\bR\tversioned\x12\x16\n" +
 	"\x06public\x18\x04 \x01(\bR\x06publicB\x06\n" +
-	"\x04_doc\"\xb8\a\n" +
+	"\x04_doc\"\x9a\x01\n" +
+	"\vSearchIndex\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12+\n" +
+	"\x11searchable_fields\x18\x03 \x03(\tR\x10searchableFields\x12+\n" +
+	"\x11filterable_fields\x18\x04 \x03(\tR\x10filterableFieldsB\x06\n" +
+	"\x04_doc\"d\n" +
+	"\fInboundEmail\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceNameB\x06\n" +
+	"\x04_doc\"\x82\x01\n" +
+	"\x10OperationTracker\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x18\n" +
+	"\aprivate\x18\x04 \x01(\bR\aprivateB\x06\n" +
+	"\x04_doc\"\x9a\x02\n" +
+	"\x06EnvVar\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\x12\x16\n" +
+	"\x06format\x18\x05 \x01(\tR\x06format\x12&\n" +
+	"\fservice_name\x18\x06 \x01(\tH\x01R\vserviceName\x88\x01\x01\x12\x1a\n" +
+	"\bfilepath\x18\a \x01(\tR\bfilepath\x12\x19\n" +
+	"\bsrc_line\x18\b \x01(\x05R\asrcLine\x12\x17\n" +
+	"\asrc_col\x18\t \x01(\x05R\x06srcColB\x06\n" +
+	"\x04_docB\x0f\n" +
+	"\r_service_name\"\xb8\a\n" +
 	"\vPubSubTopic\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
 	"\x03doc\x18\x02 \x01(\tH\x00R\x03doc\x88\x01\x01\x12@\n" +
@@ -4013,146 +4764,164 @@ func file_encore_parser_meta_v1_meta_proto_rawDescGZIP() []byte {
 	return file_encore_parser_meta_v1_meta_proto_rawDescData
 }
 
-var file_encore_parser_meta_v1_meta_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
-var file_encore_parser_meta_v1_meta_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
+var file_encore_parser_meta_v1_meta_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_encore_parser_meta_v1_meta_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
 var file_encore_parser_meta_v1_meta_proto_goTypes = []any{
 	(Lang)(0),                             // 0: encore.parser.meta.v1.Lang
 	(BucketUsage_Operation)(0),            // 1: encore.parser.meta.v1.BucketUsage.Operation
 	(Selector_Type)(0),                    // 2: encore.parser.meta.v1.Selector.Type
 	(RPC_AccessType)(0),                   // 3: encore.parser.meta.v1.RPC.AccessType
 	(RPC_Protocol)(0),                     // 4: encore.parser.meta.v1.RPC.Protocol
-	(StaticCallNode_Package)(0),           // 5: encore.parser.meta.v1.StaticCallNode.Package
-	(Path_Type)(0),                        // 6: encore.parser.meta.v1.Path.Type
-	(PathSegment_SegmentType)(0),          // 7: encore.parser.meta.v1.PathSegment.SegmentType
-	(PathSegment_ParamType)(0),            // 8: encore.parser.meta.v1.PathSegment.ParamType
-	(PubSubTopic_DeliveryGuarantee)(0),    // 9: encore.parser.meta.v1.PubSubTopic.DeliveryGuarantee
-	(Metric_MetricKind)(0),                // 10: encore.parser.meta.v1.Metric.MetricKind
-	(*Data)(nil),                          // 11: encore.parser.meta.v1.Data
-	(*QualifiedName)(nil),                 // 12: encore.parser.meta.v1.QualifiedName
-	(*Package)(nil),                       // 13: encore.parser.meta.v1.Package
-	(*Service)(nil),                       // 14: encore.parser.meta.v1.Service
-	(*BucketUsage)(nil),                   // 15: encore.parser.meta.v1.BucketUsage
-	(*Selector)(nil),                      // 16: encore.parser.meta.v1.Selector
-	(*RPC)(nil),                           // 17: encore.parser.meta.v1.RPC
-	(*AuthHandler)(nil),                   // 18: encore.parser.meta.v1.AuthHandler
-	(*Middleware)(nil),                    // 19: encore.parser.meta.v1.Middleware
-	(*TraceNode)(nil),                     // 20: encore.parser.meta.v1.TraceNode
-	(*RPCDefNode)(nil),                    // 21: encore.parser.meta.v1.RPCDefNode
-	(*RPCCallNode)(nil),                   // 22: encore.parser.meta.v1.RPCCallNode
-	(*StaticCallNode)(nil),                // 23: encore.parser.meta.v1.StaticCallNode
-	(*AuthHandlerDefNode)(nil),            // 24: encore.parser.meta.v1.AuthHandlerDefNode
-	(*PubSubTopicDefNode)(nil),            // 25: encore.parser.meta.v1.PubSubTopicDefNode
-	(*PubSubPublishNode)(nil),             // 26: encore.parser.meta.v1.PubSubPublishNode
-	(*PubSubSubscriberNode)(nil),          // 27: encore.parser.meta.v1.PubSubSubscriberNode
-	(*ServiceInitNode)(nil),               // 28: encore.parser.meta.v1.ServiceInitNode
-	(*MiddlewareDefNode)(nil),             // 29: encore.parser.meta.v1.MiddlewareDefNode
-	(*CacheKeyspaceDefNode)(nil),          // 30: encore.parser.meta.v1.CacheKeyspaceDefNode
-	(*Path)(nil),                          // 31: encore.parser.meta.v1.Path
-	(*PathSegment)(nil),                   // 32: encore.parser.meta.v1.PathSegment
-	(*Gateway)(nil),                       // 33: encore.parser.meta.v1.Gateway
-	(*CronJob)(nil),                       // 34: encore.parser.meta.v1.CronJob
-	(*SQLDatabase)(nil),                   // 35: encore.parser.meta.v1.SQLDatabase
-	(*DBMigration)(nil),                   // 36: encore.parser.meta.v1.DBMigration
-	(*Bucket)(nil),                        // 37: encore.parser.meta.v1.Bucket
-	(*PubSubTopic)(nil),                   // 38: encore.parser.meta.v1.PubSubTopic
-	(*CacheCluster)(nil),                  // 39: encore.parser.meta.v1.CacheCluster
-	(*Metric)(nil),                        // 40: encore.parser.meta.v1.Metric
-	nil,                                   // 41: encore.parser.meta.v1.RPC.ExposeEntry
-	(*RPC_ExposeOptions)(nil),             // 42: encore.parser.meta.v1.RPC.ExposeOptions
-	(*RPC_StaticAssets)(nil),              // 43: encore.parser.meta.v1.RPC.StaticAssets
-	(*RPC_StaticAssets_HeaderValues)(nil), // 44: encore.parser.meta.v1.RPC.StaticAssets.HeaderValues
-	nil,                                   // 45: encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry
-	(*Gateway_Explicit)(nil),              // 46: encore.parser.meta.v1.Gateway.Explicit
-	(*PubSubTopic_Publisher)(nil),         // 47: encore.parser.meta.v1.PubSubTopic.Publisher
-	(*PubSubTopic_Subscription)(nil),      // 48: encore.parser.meta.v1.PubSubTopic.Subscription
-	(*PubSubTopic_RetryPolicy)(nil),       // 49: encore.parser.meta.v1.PubSubTopic.RetryPolicy
-	(*CacheCluster_Keyspace)(nil),         // 50: encore.parser.meta.v1.CacheCluster.Keyspace
-	(*Metric_Label)(nil),                  // 51: encore.parser.meta.v1.Metric.Label
-	(*v1.Decl)(nil),                       // 52: encore.parser.schema.v1.Decl
-	(*v1.Type)(nil),                       // 53: encore.parser.schema.v1.Type
-	(*v1.Loc)(nil),                        // 54: encore.parser.schema.v1.Loc
-	(*v1.ValidationExpr)(nil),             // 55: encore.parser.schema.v1.ValidationExpr
-	(v1.Builtin)(0),                       // 56: encore.parser.schema.v1.Builtin
+	(RPC_RoutingCondition_Source)(0),      // 5: encore.parser.meta.v1.RPC.RoutingCondition.Source
+	(StaticCallNode_Package)(0),           // 6: encore.parser.meta.v1.StaticCallNode.Package
+	(Path_Type)(0),                        // 7: encore.parser.meta.v1.Path.Type
+	(PathSegment_SegmentType)(0),          // 8: encore.parser.meta.v1.PathSegment.SegmentType
+	(PathSegment_ParamType)(0),            // 9: encore.parser.meta.v1.PathSegment.ParamType
+	(SQLDatabase_Engine)(0),               // 10: encore.parser.meta.v1.SQLDatabase.Engine
+	(PubSubTopic_DeliveryGuarantee)(0),    // 11: encore.parser.meta.v1.PubSubTopic.DeliveryGuarantee
+	(Metric_MetricKind)(0),                // 12: encore.parser.meta.v1.Metric.MetricKind
+	(*Data)(nil),                          // 13: encore.parser.meta.v1.Data
+	(*QualifiedName)(nil),                 // 14: encore.parser.meta.v1.QualifiedName
+	(*Package)(nil),                       // 15: encore.parser.meta.v1.Package
+	(*Service)(nil),                       // 16: encore.parser.meta.v1.Service
+	(*ServiceOwner)(nil),                  // 17: encore.parser.meta.v1.ServiceOwner
+	(*BucketUsage)(nil),                   // 18: encore.parser.meta.v1.BucketUsage
+	(*Selector)(nil),                      // 19: encore.parser.meta.v1.Selector
+	(*RPC)(nil),                           // 20: encore.parser.meta.v1.RPC
+	(*AuthHandler)(nil),                   // 21: encore.parser.meta.v1.AuthHandler
+	(*Middleware)(nil),                    // 22: encore.parser.meta.v1.Middleware
+	(*TraceNode)(nil),                     // 23: encore.parser.meta.v1.TraceNode
+	(*RPCDefNode)(nil),                    // 24: encore.parser.meta.v1.RPCDefNode
+	(*RPCCallNode)(nil),                   // 25: encore.parser.meta.v1.RPCCallNode
+	(*StaticCallNode)(nil),                // 26: encore.parser.meta.v1.StaticCallNode
+	(*AuthHandlerDefNode)(nil),            // 27: encore.parser.meta.v1.AuthHandlerDefNode
+	(*PubSubTopicDefNode)(nil),            // 28: encore.parser.meta.v1.PubSubTopicDefNode
+	(*PubSubPublishNode)(nil),             // 29: encore.parser.meta.v1.PubSubPublishNode
+	(*PubSubSubscriberNode)(nil),          // 30: encore.parser.meta.v1.PubSubSubscriberNode
+	(*ServiceInitNode)(nil),               // 31: encore.parser.meta.v1.ServiceInitNode
+	(*MiddlewareDefNode)(nil),             // 32: encore.parser.meta.v1.MiddlewareDefNode
+	(*CacheKeyspaceDefNode)(nil),          // 33: encore.parser.meta.v1.CacheKeyspaceDefNode
+	(*Path)(nil),                          // 34: encore.parser.meta.v1.Path
+	(*PathSegment)(nil),                   // 35: encore.parser.meta.v1.PathSegment
+	(*Gateway)(nil),                       // 36: encore.parser.meta.v1.Gateway
+	(*CronJob)(nil),                       // 37: encore.parser.meta.v1.CronJob
+	(*SQLDatabase)(nil),                   // 38: encore.parser.meta.v1.SQLDatabase
+	(*DBMigration)(nil),                   // 39: encore.parser.meta.v1.DBMigration
+	(*Bucket)(nil),                        // 40: encore.parser.meta.v1.Bucket
+	(*SearchIndex)(nil),                   // 41: encore.parser.meta.v1.SearchIndex
+	(*InboundEmail)(nil),                  // 42: encore.parser.meta.v1.InboundEmail
+	(*OperationTracker)(nil),              // 43: encore.parser.meta.v1.OperationTracker
+	(*EnvVar)(nil),                        // 44: encore.parser.meta.v1.EnvVar
+	(*PubSubTopic)(nil),                   // 45: encore.parser.meta.v1.PubSubTopic
+	(*CacheCluster)(nil),                  // 46: encore.parser.meta.v1.CacheCluster
+	(*Metric)(nil),                        // 47: encore.parser.meta.v1.Metric
+	nil,                                   // 48: encore.parser.meta.v1.RPC.ExposeEntry
+	(*RPC_ExposeOptions)(nil),             // 49: encore.parser.meta.v1.RPC.ExposeOptions
+	(*RPC_RoutingCondition)(nil),          // 50: encore.parser.meta.v1.RPC.RoutingCondition
+	(*RPC_Experiment)(nil),                // 51: encore.parser.meta.v1.RPC.Experiment
+	(*RPC_StaticAssets)(nil),              // 52: encore.parser.meta.v1.RPC.StaticAssets
+	(*RPC_StaticAssets_HeaderValues)(nil), // 53: encore.parser.meta.v1.RPC.StaticAssets.HeaderValues
+	nil,                                   // 54: encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry
+	(*Gateway_Explicit)(nil),              // 55: encore.parser.meta.v1.Gateway.Explicit
+	(*PubSubTopic_Publisher)(nil),         // 56: encore.parser.meta.v1.PubSubTopic.Publisher
+	(*PubSubTopic_Subscription)(nil),      // 57: encore.parser.meta.v1.PubSubTopic.Subscription
+	(*PubSubTopic_RetryPolicy)(nil),       // 58: encore.parser.meta.v1.PubSubTopic.RetryPolicy
+	(*CacheCluster_Keyspace)(nil),         // 59: encore.parser.meta.v1.CacheCluster.Keyspace
+	(*Metric_Label)(nil),                  // 60: encore.parser.meta.v1.Metric.Label
+	(*v1.Decl)(nil),                       // 61: encore.parser.schema.v1.Decl
+	(*v1.Type)(nil),                       // 62: encore.parser.schema.v1.Type
+	(*v1.Loc)(nil),                        // 63: encore.parser.schema.v1.Loc
+	(*v1.ValidationExpr)(nil),             // 64: encore.parser.schema.v1.ValidationExpr
+	(v1.Builtin)(0),                       // 65: encore.parser.schema.v1.Builtin
 }
 var file_encore_parser_meta_v1_meta_proto_depIdxs = []int32{
-	52, // 0: encore.parser.meta.v1.Data.decls:type_name -> encore.parser.schema.v1.Decl
-	13, // 1: encore.parser.meta.v1.Data.pkgs:type_name -> encore.parser.meta.v1.Package
-	14, // 2: encore.parser.meta.v1.Data.svcs:type_name -> encore.parser.meta.v1.Service
-	18, // 3: encore.parser.meta.v1.Data.auth_handler:type_name -> encore.parser.meta.v1.AuthHandler
-	34, // 4: encore.parser.meta.v1.Data.cron_jobs:type_name -> encore.parser.meta.v1.CronJob
-	38, // 5: encore.parser.meta.v1.Data.pubsub_topics:type_name -> encore.parser.meta.v1.PubSubTopic
-	19, // 6: encore.parser.meta.v1.Data.middleware:type_name -> encore.parser.meta.v1.Middleware
-	39, // 7: encore.parser.meta.v1.Data.cache_clusters:type_name -> encore.parser.meta.v1.CacheCluster
-	40, // 8: encore.parser.meta.v1.Data.metrics:type_name -> encore.parser.meta.v1.Metric
-	35, // 9: encore.parser.meta.v1.Data.sql_databases:type_name -> encore.parser.meta.v1.SQLDatabase
-	33, // 10: encore.parser.meta.v1.Data.gateways:type_name -> encore.parser.meta.v1.Gateway
+	61, // 0: encore.parser.meta.v1.Data.decls:type_name -> encore.parser.schema.v1.Decl
+	15, // 1: encore.parser.meta.v1.Data.pkgs:type_name -> encore.parser.meta.v1.Package
+	16, // 2: encore.parser.meta.v1.Data.svcs:type_name -> encore.parser.meta.v1.Service
+	21, // 3: encore.parser.meta.v1.Data.auth_handler:type_name -> encore.parser.meta.v1.AuthHandler
+	37, // 4: encore.parser.meta.v1.Data.cron_jobs:type_name -> encore.parser.meta.v1.CronJob
+	45, // 5: encore.parser.meta.v1.Data.pubsub_topics:type_name -> encore.parser.meta.v1.PubSubTopic
+	22, // 6: encore.parser.meta.v1.Data.middleware:type_name -> encore.parser.meta.v1.Middleware
+	46, // 7: encore.parser.meta.v1.Data.cache_clusters:type_name -> encore.parser.meta.v1.CacheCluster
+	47, // 8: encore.parser.meta.v1.Data.metrics:type_name -> encore.parser.meta.v1.Metric
+	38, // 9: encore.parser.meta.v1.Data.sql_databases:type_name -> encore.parser.meta.v1.SQLDatabase
+	36, // 10: encore.parser.meta.v1.Data.gateways:type_name -> encore.parser.meta.v1.Gateway
 	0,  // 11: encore.parser.meta.v1.Data.language:type_name -> encore.parser.meta.v1.Lang
-	37, // 12: encore.parser.meta.v1.Data.buckets:type_name -> encore.parser.meta.v1.Bucket
-	12, // 13: encore.parser.meta.v1.Package.rpc_calls:type_name -> encore.parser.meta.v1.QualifiedName
-	20, // 14: encore.parser.meta.v1.Package.trace_nodes:type_name -> encore.parser.meta.v1.TraceNode
-	17, // 15: encore.parser.meta.v1.Service.rpcs:type_name -> encore.parser.meta.v1.RPC
-	36, // 16: encore.parser.meta.v1.Service.migrations:type_name -> encore.parser.meta.v1.DBMigration
-	15, // 17: encore.parser.meta.v1.Service.buckets:type_name -> encore.parser.meta.v1.BucketUsage
-	1,  // 18: encore.parser.meta.v1.BucketUsage.operations:type_name -> encore.parser.meta.v1.BucketUsage.Operation
-	2,  // 19: encore.parser.meta.v1.Selector.type:type_name -> encore.parser.meta.v1.Selector.Type
-	3,  // 20: encore.parser.meta.v1.RPC.access_type:type_name -> encore.parser.meta.v1.RPC.AccessType
-	53, // 21: encore.parser.meta.v1.RPC.request_schema:type_name -> encore.parser.schema.v1.Type
-	53, // 22: encore.parser.meta.v1.RPC.response_schema:type_name -> encore.parser.schema.v1.Type
-	4,  // 23: encore.parser.meta.v1.RPC.proto:type_name -> encore.parser.meta.v1.RPC.Protocol
-	54, // 24: encore.parser.meta.v1.RPC.loc:type_name -> encore.parser.schema.v1.Loc
-	31, // 25: encore.parser.meta.v1.RPC.path:type_name -> encore.parser.meta.v1.Path
-	16, // 26: encore.parser.meta.v1.RPC.tags:type_name -> encore.parser.meta.v1.Selector
-	41, // 27: encore.parser.meta.v1.RPC.expose:type_name -> encore.parser.meta.v1.RPC.ExposeEntry
-	53, // 28: encore.parser.meta.v1.RPC.handshake_schema:type_name -> encore.parser.schema.v1.Type
-	43, // 29: encore.parser.meta.v1.RPC.static_assets:type_name -> encore.parser.meta.v1.RPC.StaticAssets
-	54, // 30: encore.parser.meta.v1.AuthHandler.loc:type_name -> encore.parser.schema.v1.Loc
-	53, // 31: encore.parser.meta.v1.AuthHandler.auth_data:type_name -> encore.parser.schema.v1.Type
-	53, // 32: encore.parser.meta.v1.AuthHandler.params:type_name -> encore.parser.schema.v1.Type
-	12, // 33: encore.parser.meta.v1.Middleware.name:type_name -> encore.parser.meta.v1.QualifiedName
-	54, // 34: encore.parser.meta.v1.Middleware.loc:type_name -> encore.parser.schema.v1.Loc
-	16, // 35: encore.parser.meta.v1.Middleware.target:type_name -> encore.parser.meta.v1.Selector
-	21, // 36: encore.parser.meta.v1.TraceNode.rpc_def:type_name -> encore.parser.meta.v1.RPCDefNode
-	22, // 37: encore.parser.meta.v1.TraceNode.rpc_call:type_name -> encore.parser.meta.v1.RPCCallNode
-	23, // 38: encore.parser.meta.v1.TraceNode.static_call:type_name -> encore.parser.meta.v1.StaticCallNode
-	24, // 39: encore.parser.meta.v1.TraceNode.auth_handler_def:type_name -> encore.parser.meta.v1.AuthHandlerDefNode
-	25, // 40: encore.parser.meta.v1.TraceNode.pubsub_topic_def:type_name -> encore.parser.meta.v1.PubSubTopicDefNode
-	26, // 41: encore.parser.meta.v1.TraceNode.pubsub_publish:type_name -> encore.parser.meta.v1.PubSubPublishNode
-	27, // 42: encore.parser.meta.v1.TraceNode.pubsub_subscriber:type_name -> encore.parser.meta.v1.PubSubSubscriberNode
-	28, // 43: encore.parser.meta.v1.TraceNode.service_init:type_name -> encore.parser.meta.v1.ServiceInitNode
-	29, // 44: encore.parser.meta.v1.TraceNode.middleware_def:type_name -> encore.parser.meta.v1.MiddlewareDefNode
-	30, // 45: encore.parser.meta.v1.TraceNode.cache_keyspace:type_name -> encore.parser.meta.v1.CacheKeyspaceDefNode
-	5,  // 46: encore.parser.meta.v1.StaticCallNode.package:type_name -> encore.parser.meta.v1.StaticCallNode.Package
-	16, // 47: encore.parser.meta.v1.MiddlewareDefNode.target:type_name -> encore.parser.meta.v1.Selector
-	32, // 48: encore.parser.meta.v1.Path.segments:type_name -> encore.parser.meta.v1.PathSegment
-	6,  // 49: encore.parser.meta.v1.Path.type:type_name -> encore.parser.meta.v1.Path.Type
-	7,  // 50: encore.parser.meta.v1.PathSegment.type:type_name -> encore.parser.meta.v1.PathSegment.SegmentType
-	8,  // 51: encore.parser.meta.v1.PathSegment.value_type:type_name -> encore.parser.meta.v1.PathSegment.ParamType
-	55, // 52: encore.parser.meta.v1.PathSegment.validation:type_name -> encore.parser.schema.v1.ValidationExpr
-	46, // 53: encore.parser.meta.v1.Gateway.explicit:type_name -> encore.parser.meta.v1.Gateway.Explicit
-	12, // 54: encore.parser.meta.v1.CronJob.endpoint:type_name -> encore.parser.meta.v1.QualifiedName
-	36, // 55: encore.parser.meta.v1.SQLDatabase.migrations:type_name -> encore.parser.meta.v1.DBMigration
-	53, // 56: encore.parser.meta.v1.PubSubTopic.message_type:type_name -> encore.parser.schema.v1.Type
-	9,  // 57: encore.parser.meta.v1.PubSubTopic.delivery_guarantee:type_name -> encore.parser.meta.v1.PubSubTopic.DeliveryGuarantee
-	47, // 58: encore.parser.meta.v1.PubSubTopic.publishers:type_name -> encore.parser.meta.v1.PubSubTopic.Publisher
-	48, // 59: encore.parser.meta.v1.PubSubTopic.subscriptions:type_name -> encore.parser.meta.v1.PubSubTopic.Subscription
-	50, // 60: encore.parser.meta.v1.CacheCluster.keyspaces:type_name -> encore.parser.meta.v1.CacheCluster.Keyspace
-	56, // 61: encore.parser.meta.v1.Metric.value_type:type_name -> encore.parser.schema.v1.Builtin
-	10, // 62: encore.parser.meta.v1.Metric.kind:type_name -> encore.parser.meta.v1.Metric.MetricKind
-	51, // 63: encore.parser.meta.v1.Metric.labels:type_name -> encore.parser.meta.v1.Metric.Label
-	42, // 64: encore.parser.meta.v1.RPC.ExposeEntry.value:type_name -> encore.parser.meta.v1.RPC.ExposeOptions
-	45, // 65: encore.parser.meta.v1.RPC.StaticAssets.headers:type_name -> encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry
-	44, // 66: encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry.value:type_name -> encore.parser.meta.v1.RPC.StaticAssets.HeaderValues
-	18, // 67: encore.parser.meta.v1.Gateway.Explicit.auth_handler:type_name -> encore.parser.meta.v1.AuthHandler
-	49, // 68: encore.parser.meta.v1.PubSubTopic.Subscription.retry_policy:type_name -> encore.parser.meta.v1.PubSubTopic.RetryPolicy
-	53, // 69: encore.parser.meta.v1.CacheCluster.Keyspace.key_type:type_name -> encore.parser.schema.v1.Type
-	53, // 70: encore.parser.meta.v1.CacheCluster.Keyspace.value_type:type_name -> encore.parser.schema.v1.Type
-	31, // 71: encore.parser.meta.v1.CacheCluster.Keyspace.path_pattern:type_name -> encore.parser.meta.v1.Path
-	56, // 72: encore.parser.meta.v1.Metric.Label.type:type_name -> encore.parser.schema.v1.Builtin
-	73, // [73:73] is the sub-list for method output_type
-	73, // [73:73] is the sub-list for method input_type
-	73, // [73:73] is the sub-list for extension type_name
-	73, // [73:73] is the sub-list for extension extendee
-	0,  // [0:73] is the sub-list for field type_name
+	40, // 12: encore.parser.meta.v1.Data.buckets:type_name -> encore.parser.meta.v1.Bucket
+	41, // 13: encore.parser.meta.v1.Data.search_indexes:type_name -> encore.parser.meta.v1.SearchIndex
+	42, // 14: encore.parser.meta.v1.Data.inbound_emails:type_name -> encore.parser.meta.v1.InboundEmail
+	43, // 15: encore.parser.meta.v1.Data.operation_trackers:type_name -> encore.parser.meta.v1.OperationTracker
+	44, // 16: encore.parser.meta.v1.Data.env_vars:type_name -> encore.parser.meta.v1.EnvVar
+	14, // 17: encore.parser.meta.v1.Package.rpc_calls:type_name -> encore.parser.meta.v1.QualifiedName
+	23, // 18: encore.parser.meta.v1.Package.trace_nodes:type_name -> encore.parser.meta.v1.TraceNode
+	20, // 19: encore.parser.meta.v1.Service.rpcs:type_name -> encore.parser.meta.v1.RPC
+	39, // 20: encore.parser.meta.v1.Service.migrations:type_name -> encore.parser.meta.v1.DBMigration
+	18, // 21: encore.parser.meta.v1.Service.buckets:type_name -> encore.parser.meta.v1.BucketUsage
+	17, // 22: encore.parser.meta.v1.Service.owner:type_name -> encore.parser.meta.v1.ServiceOwner
+	1,  // 23: encore.parser.meta.v1.BucketUsage.operations:type_name -> encore.parser.meta.v1.BucketUsage.Operation
+	2,  // 24: encore.parser.meta.v1.Selector.type:type_name -> encore.parser.meta.v1.Selector.Type
+	3,  // 25: encore.parser.meta.v1.RPC.access_type:type_name -> encore.parser.meta.v1.RPC.AccessType
+	62, // 26: encore.parser.meta.v1.RPC.request_schema:type_name -> encore.parser.schema.v1.Type
+	62, // 27: encore.parser.meta.v1.RPC.response_schema:type_name -> encore.parser.schema.v1.Type
+	4,  // 28: encore.parser.meta.v1.RPC.proto:type_name -> encore.parser.meta.v1.RPC.Protocol
+	63, // 29: encore.parser.meta.v1.RPC.loc:type_name -> encore.parser.schema.v1.Loc
+	34, // 30: encore.parser.meta.v1.RPC.path:type_name -> encore.parser.meta.v1.Path
+	19, // 31: encore.parser.meta.v1.RPC.tags:type_name -> encore.parser.meta.v1.Selector
+	48, // 32: encore.parser.meta.v1.RPC.expose:type_name -> encore.parser.meta.v1.RPC.ExposeEntry
+	62, // 33: encore.parser.meta.v1.RPC.handshake_schema:type_name -> encore.parser.schema.v1.Type
+	52, // 34: encore.parser.meta.v1.RPC.static_assets:type_name -> encore.parser.meta.v1.RPC.StaticAssets
+	50, // 35: encore.parser.meta.v1.RPC.routing_conditions:type_name -> encore.parser.meta.v1.RPC.RoutingCondition
+	51, // 36: encore.parser.meta.v1.RPC.experiment:type_name -> encore.parser.meta.v1.RPC.Experiment
+	63, // 37: encore.parser.meta.v1.AuthHandler.loc:type_name -> encore.parser.schema.v1.Loc
+	62, // 38: encore.parser.meta.v1.AuthHandler.auth_data:type_name -> encore.parser.schema.v1.Type
+	62, // 39: encore.parser.meta.v1.AuthHandler.params:type_name -> encore.parser.schema.v1.Type
+	14, // 40: encore.parser.meta.v1.Middleware.name:type_name -> encore.parser.meta.v1.QualifiedName
+	63, // 41: encore.parser.meta.v1.Middleware.loc:type_name -> encore.parser.schema.v1.Loc
+	19, // 42: encore.parser.meta.v1.Middleware.target:type_name -> encore.parser.meta.v1.Selector
+	24, // 43: encore.parser.meta.v1.TraceNode.rpc_def:type_name -> encore.parser.meta.v1.RPCDefNode
+	25, // 44: encore.parser.meta.v1.TraceNode.rpc_call:type_name -> encore.parser.meta.v1.RPCCallNode
+	26, // 45: encore.parser.meta.v1.TraceNode.static_call:type_name -> encore.parser.meta.v1.StaticCallNode
+	27, // 46: encore.parser.meta.v1.TraceNode.auth_handler_def:type_name -> encore.parser.meta.v1.AuthHandlerDefNode
+	28, // 47: encore.parser.meta.v1.TraceNode.pubsub_topic_def:type_name -> encore.parser.meta.v1.PubSubTopicDefNode
+	29, // 48: encore.parser.meta.v1.TraceNode.pubsub_publish:type_name -> encore.parser.meta.v1.PubSubPublishNode
+	30, // 49: encore.parser.meta.v1.TraceNode.pubsub_subscriber:type_name -> encore.parser.meta.v1.PubSubSubscriberNode
+	31, // 50: encore.parser.meta.v1.TraceNode.service_init:type_name -> encore.parser.meta.v1.ServiceInitNode
+	32, // 51: encore.parser.meta.v1.TraceNode.middleware_def:type_name -> encore.parser.meta.v1.MiddlewareDefNode
+	33, // 52: encore.parser.meta.v1.TraceNode.cache_keyspace:type_name -> encore.parser.meta.v1.CacheKeyspaceDefNode
+	6,  // 53: encore.parser.meta.v1.StaticCallNode.package:type_name -> encore.parser.meta.v1.StaticCallNode.Package
+	19, // 54: encore.parser.meta.v1.MiddlewareDefNode.target:type_name -> encore.parser.meta.v1.Selector
+	35, // 55: encore.parser.meta.v1.Path.segments:type_name -> encore.parser.meta.v1.PathSegment
+	7,  // 56: encore.parser.meta.v1.Path.type:type_name -> encore.parser.meta.v1.Path.Type
+	8,  // 57: encore.parser.meta.v1.PathSegment.type:type_name -> encore.parser.meta.v1.PathSegment.SegmentType
+	9,  // 58: encore.parser.meta.v1.PathSegment.value_type:type_name -> encore.parser.meta.v1.PathSegment.ParamType
+	64, // 59: encore.parser.meta.v1.PathSegment.validation:type_name -> encore.parser.schema.v1.ValidationExpr
+	55, // 60: encore.parser.meta.v1.Gateway.explicit:type_name -> encore.parser.meta.v1.Gateway.Explicit
+	14, // 61: encore.parser.meta.v1.CronJob.endpoint:type_name -> encore.parser.meta.v1.QualifiedName
+	39, // 62: encore.parser.meta.v1.SQLDatabase.migrations:type_name -> encore.parser.meta.v1.DBMigration
+	10, // 63: encore.parser.meta.v1.SQLDatabase.engine:type_name -> encore.parser.meta.v1.SQLDatabase.Engine
+	62, // 64: encore.parser.meta.v1.PubSubTopic.message_type:type_name -> encore.parser.schema.v1.Type
+	11, // 65: encore.parser.meta.v1.PubSubTopic.delivery_guarantee:type_name -> encore.parser.meta.v1.PubSubTopic.DeliveryGuarantee
+	56, // 66: encore.parser.meta.v1.PubSubTopic.publishers:type_name -> encore.parser.meta.v1.PubSubTopic.Publisher
+	57, // 67: encore.parser.meta.v1.PubSubTopic.subscriptions:type_name -> encore.parser.meta.v1.PubSubTopic.Subscription
+	59, // 68: encore.parser.meta.v1.CacheCluster.keyspaces:type_name -> encore.parser.meta.v1.CacheCluster.Keyspace
+	65, // 69: encore.parser.meta.v1.Metric.value_type:type_name -> encore.parser.schema.v1.Builtin
+	12, // 70: encore.parser.meta.v1.Metric.kind:type_name -> encore.parser.meta.v1.Metric.MetricKind
+	60, // 71: encore.parser.meta.v1.Metric.labels:type_name -> encore.parser.meta.v1.Metric.Label
+	49, // 72: encore.parser.meta.v1.RPC.ExposeEntry.value:type_name -> encore.parser.meta.v1.RPC.ExposeOptions
+	5,  // 73: encore.parser.meta.v1.RPC.RoutingCondition.source:type_name -> encore.parser.meta.v1.RPC.RoutingCondition.Source
+	54, // 74: encore.parser.meta.v1.RPC.StaticAssets.headers:type_name -> encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry
+	53, // 75: encore.parser.meta.v1.RPC.StaticAssets.HeadersEntry.value:type_name -> encore.parser.meta.v1.RPC.StaticAssets.HeaderValues
+	21, // 76: encore.parser.meta.v1.Gateway.Explicit.auth_handler:type_name -> encore.parser.meta.v1.AuthHandler
+	58, // 77: encore.parser.meta.v1.PubSubTopic.Subscription.retry_policy:type_name -> encore.parser.meta.v1.PubSubTopic.RetryPolicy
+	62, // 78: encore.parser.meta.v1.CacheCluster.Keyspace.key_type:type_name -> encore.parser.schema.v1.Type
+	62, // 79: encore.parser.meta.v1.CacheCluster.Keyspace.value_type:type_name -> encore.parser.schema.v1.Type
+	34, // 80: encore.parser.meta.v1.CacheCluster.Keyspace.path_pattern:type_name -> encore.parser.meta.v1.Path
+	65, // 81: encore.parser.meta.v1.Metric.Label.type:type_name -> encore.parser.schema.v1.Builtin
+	82, // [82:82] is the sub-list for method output_type
+	82, // [82:82] is the sub-list for method input_type
+	82, // [82:82] is the sub-list for extension type_name
+	82, // [82:82] is the sub-list for extension extendee
+	0,  // [0:82] is the sub-list for field type_name
 }
 
 func init() { file_encore_parser_meta_v1_meta_proto_init() }
@@ -4161,10 +4930,10 @@ func file_encore_parser_meta_v1_meta_proto_init() {
 		return
 	}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[0].OneofWrappers = []any{}
-	file_encore_parser_meta_v1_meta_proto_msgTypes[6].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[7].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[8].OneofWrappers = []any{}
-	file_encore_parser_meta_v1_meta_proto_msgTypes[9].OneofWrappers = []any{
+	file_encore_parser_meta_v1_meta_proto_msgTypes[9].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[10].OneofWrappers = []any{
 		(*TraceNode_RpcDef)(nil),
 		(*TraceNode_RpcCall)(nil),
 		(*TraceNode_StaticCall)(nil),
@@ -4176,23 +4945,27 @@ func file_encore_parser_meta_v1_meta_proto_init() {
 		(*TraceNode_MiddlewareDef)(nil),
 		(*TraceNode_CacheKeyspace)(nil),
 	}
-	file_encore_parser_meta_v1_meta_proto_msgTypes[21].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[22].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[23].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[24].OneofWrappers = []any{}
-	file_encore_parser_meta_v1_meta_proto_msgTypes[26].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[25].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[27].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[28].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[29].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[30].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[31].OneofWrappers = []any{}
 	file_encore_parser_meta_v1_meta_proto_msgTypes[32].OneofWrappers = []any{}
-	file_encore_parser_meta_v1_meta_proto_msgTypes[35].OneofWrappers = []any{}
-	file_encore_parser_meta_v1_meta_proto_msgTypes[37].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[34].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[39].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[42].OneofWrappers = []any{}
+	file_encore_parser_meta_v1_meta_proto_msgTypes[44].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_parser_meta_v1_meta_proto_rawDesc), len(file_encore_parser_meta_v1_meta_proto_rawDesc)),
-			NumEnums:      11,
-			NumMessages:   41,
+			NumEnums:      13,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -49,6 +49,8 @@ const (
 	Builtin_INT     Builtin = 18
 	Builtin_UINT    Builtin = 19
 	Builtin_DECIMAL Builtin = 20
+	// FILE is a file uploaded in a multipart/form-data request.
+	Builtin_FILE Builtin = 21
 )
 
 // Enum value maps for Builtin.
@@ -75,6 +77,7 @@ var (
 		18: "INT",
 		19: "UINT",
 		20: "DECIMAL",
+		21: "FILE",
 	}
 	Builtin_value = map[string]int32{
 		"ANY":     0,
@@ -98,6 +101,7 @@ var (
 		"INT":     18,
 		"UINT":    19,
 		"DECIMAL": 20,
+		"FILE":    21,
 	}
 )
 
@@ -1124,6 +1128,7 @@ type Field struct {
 	RawTag          string                 `protobuf:"bytes,7,opt,name=raw_tag,json=rawTag,proto3" json:"raw_tag,omitempty"`                              // The original Go struct tag; should not be parsed individually
 	Tags            []*Tag                 `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`                                                // Parsed go struct tags. Used for marshalling hints
 	Wire            *WireSpec              `protobuf:"bytes,9,opt,name=wire,proto3,oneof" json:"wire,omitempty"`                                          // The explicitly set wire location of the field.
+	Deprecated      *string                `protobuf:"bytes,10,opt,name=deprecated,proto3,oneof" json:"deprecated,omitempty"`                             // The deprecation notice of the field, if it is deprecated.
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -1221,6 +1226,13 @@ func (x *Field) GetWire() *WireSpec {
 	return nil
 }
 
+func (x *Field) GetDeprecated() string {
+	if x != nil && x.Deprecated != nil {
+		return *x.Deprecated
+	}
+	return ""
+}
+
 // WireLocation provides information about how a field should be encoded on the wire.
 type WireSpec struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1230,6 +1242,7 @@ type WireSpec struct {
 	//	*WireSpec_Query_
 	//	*WireSpec_Cookie_
 	//	*WireSpec_HttpStatus_
+	//	*WireSpec_Form_
 	Location      isWireSpec_Location `protobuf_oneof:"location"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1308,6 +1321,15 @@ func (x *WireSpec) GetHttpStatus() *WireSpec_HttpStatus {
 	return nil
 }
 
+func (x *WireSpec) GetForm() *WireSpec_Form {
+	if x != nil {
+		if x, ok := x.Location.(*WireSpec_Form_); ok {
+			return x.Form
+		}
+	}
+	return nil
+}
+
 type isWireSpec_Location interface {
 	isWireSpec_Location()
 }
@@ -1328,6 +1350,10 @@ type WireSpec_HttpStatus_ struct {
 	HttpStatus *WireSpec_HttpStatus `protobuf:"bytes,4,opt,name=http_status,json=httpStatus,proto3,oneof"`
 }
 
+type WireSpec_Form_ struct {
+	Form *WireSpec_Form `protobuf:"bytes,5,opt,name=form,proto3,oneof"`
+}
+
 func (*WireSpec_Header_) isWireSpec_Location() {}
 
 func (*WireSpec_Query_) isWireSpec_Location() {}
@@ -1336,6 +1362,8 @@ func (*WireSpec_Cookie_) isWireSpec_Location() {}
 
 func (*WireSpec_HttpStatus_) isWireSpec_Location() {}
 
+func (*WireSpec_Form_) isWireSpec_Location() {}
+
 type Tag struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`         // The tag key (e.g. json, query, header ...)
@@ -2075,6 +2103,62 @@ func (*WireSpec_HttpStatus) Descriptor() ([]byte, []int) {
 	return file_encore_parser_schema_v1_schema_proto_rawDescGZIP(), []int{10, 3}
 }
 
+type WireSpec_Form struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The explicitly specified form field name.
+	// If empty, the name of the field is used.
+	Name *string `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// The maximum size of uploaded files, in bytes.
+	// Only set for file fields with a size limit.
+	MaxSize       *int64 `protobuf:"varint,2,opt,name=max_size,json=maxSize,proto3,oneof" json:"max_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WireSpec_Form) Reset() {
+	*x = WireSpec_Form{}
+	mi := &file_encore_parser_schema_v1_schema_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WireSpec_Form) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WireSpec_Form) ProtoMessage() {}
+
+func (x *WireSpec_Form) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_parser_schema_v1_schema_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WireSpec_Form.ProtoReflect.Descriptor instead.
+func (*WireSpec_Form) Descriptor() ([]byte, []int) {
+	return file_encore_parser_schema_v1_schema_proto_rawDescGZIP(), []int{10, 4}
+}
+
+func (x *WireSpec_Form) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *WireSpec_Form) GetMaxSize() int64 {
+	if x != nil && x.MaxSize != nil {
+		return *x.MaxSize
+	}
+	return 0
+}
+
 var File_encore_parser_schema_v1_schema_proto protoreflect.FileDescriptor
 
 const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
@@ -2150,7 +2234,7 @@ const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12D\n" +
 	"\x0etype_arguments\x18\x02 \x03(\v2\x1d.encore.parser.schema.v1.TypeR\rtypeArguments\"@\n" +
 	"\x06Struct\x126\n" +
-	"\x06fields\x18\x01 \x03(\v2\x1e.encore.parser.schema.v1.FieldR\x06fields\"\xd3\x02\n" +
+	"\x06fields\x18\x01 \x03(\v2\x1e.encore.parser.schema.v1.FieldR\x06fields\"\x87\x03\n" +
 	"\x05Field\x12/\n" +
 	"\x03typ\x18\x01 \x01(\v2\x1d.encore.parser.schema.v1.TypeR\x03typ\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
@@ -2160,14 +2244,20 @@ const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
 	"\x11query_string_name\x18\x06 \x01(\tR\x0fqueryStringName\x12\x17\n" +
 	"\araw_tag\x18\a \x01(\tR\x06rawTag\x120\n" +
 	"\x04tags\x18\b \x03(\v2\x1c.encore.parser.schema.v1.TagR\x04tags\x12:\n" +
-	"\x04wire\x18\t \x01(\v2!.encore.parser.schema.v1.WireSpecH\x00R\x04wire\x88\x01\x01B\a\n" +
-	"\x05_wire\"\xc1\x03\n" +
+	"\x04wire\x18\t \x01(\v2!.encore.parser.schema.v1.WireSpecH\x00R\x04wire\x88\x01\x01\x12#\n" +
+	"\n" +
+	"deprecated\x18\n" +
+	" \x01(\tH\x01R\n" +
+	"deprecated\x88\x01\x01B\a\n" +
+	"\x05_wireB\r\n" +
+	"\v_deprecated\"\xd6\x04\n" +
 	"\bWireSpec\x12B\n" +
 	"\x06header\x18\x01 \x01(\v2(.encore.parser.schema.v1.WireSpec.HeaderH\x00R\x06header\x12?\n" +
 	"\x05query\x18\x02 \x01(\v2'.encore.parser.schema.v1.WireSpec.QueryH\x00R\x05query\x12B\n" +
 	"\x06cookie\x18\x03 \x01(\v2(.encore.parser.schema.v1.WireSpec.CookieH\x00R\x06cookie\x12O\n" +
 	"\vhttp_status\x18\x04 \x01(\v2,.encore.parser.schema.v1.WireSpec.HttpStatusH\x00R\n" +
-	"httpStatus\x1a*\n" +
+	"httpStatus\x12<\n" +
+	"\x04form\x18\x05 \x01(\v2&.encore.parser.schema.v1.WireSpec.FormH\x00R\x04form\x1a*\n" +
 	"\x06Header\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01B\a\n" +
 	"\x05_name\x1a)\n" +
@@ -2178,7 +2268,12 @@ const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01B\a\n" +
 	"\x05_name\x1a\f\n" +
 	"\n" +
-	"HttpStatusB\n" +
+	"HttpStatus\x1aU\n" +
+	"\x04Form\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1e\n" +
+	"\bmax_size\x18\x02 \x01(\x03H\x01R\amaxSize\x88\x01\x01B\a\n" +
+	"\x05_nameB\v\n" +
+	"\t_max_sizeB\n" +
 	"\n" +
 	"\blocation\"E\n" +
 	"\x03Tag\x12\x10\n" +
@@ -2205,7 +2300,7 @@ const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
 	"\x05value\"d\n" +
 	"\vConfigValue\x121\n" +
 	"\x04elem\x18\x01 \x01(\v2\x1d.encore.parser.schema.v1.TypeR\x04elem\x12\"\n" +
-	"\fIsValuesList\x18\x02 \x01(\bR\fIsValuesList*\xf2\x01\n" +
+	"\fIsValuesList\x18\x02 \x01(\bR\fIsValuesList*\xfc\x01\n" +
 	"\aBuiltin\x12\a\n" +
 	"\x03ANY\x10\x00\x12\b\n" +
 	"\x04BOOL\x10\x01\x12\b\n" +
@@ -2232,7 +2327,8 @@ const file_encore_parser_schema_v1_schema_proto_rawDesc = "" +
 	"\aUSER_ID\x10\x11\x12\a\n" +
 	"\x03INT\x10\x12\x12\b\n" +
 	"\x04UINT\x10\x13\x12\v\n" +
-	"\aDECIMAL\x10\x14B(Z&encr.dev/proto/encore/parser/schema/v1b\x06proto3"
+	"\aDECIMAL\x10\x14\x12\b\n" +
+	"\x04FILE\x10\x15B(Z&encr.dev/proto/encore/parser/schema/v1b\x06proto3"
 
 var (
 	file_encore_parser_schema_v1_schema_proto_rawDescOnce sync.Once
@@ -2247,7 +2343,7 @@ func file_encore_parser_schema_v1_schema_proto_rawDescGZIP() []byte {
 }
 
 var file_encore_parser_schema_v1_schema_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_encore_parser_schema_v1_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_encore_parser_schema_v1_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_encore_parser_schema_v1_schema_proto_goTypes = []any{
 	(Builtin)(0),                // 0: encore.parser.schema.v1.Builtin
 	(ValidationRule_Is)(0),      // 1: encore.parser.schema.v1.ValidationRule.Is
@@ -2276,6 +2372,7 @@ var file_encore_parser_schema_v1_schema_proto_goTypes = []any{
 	(*WireSpec_Query)(nil),      // 24: encore.parser.schema.v1.WireSpec.Query
 	(*WireSpec_Cookie)(nil),     // 25: encore.parser.schema.v1.WireSpec.Cookie
 	(*WireSpec_HttpStatus)(nil), // 26: encore.parser.schema.v1.WireSpec.HttpStatus
+	(*WireSpec_Form)(nil),       // 27: encore.parser.schema.v1.WireSpec.Form
 }
 var file_encore_parser_schema_v1_schema_proto_depIdxs = []int32{
 	9,  // 0: encore.parser.schema.v1.Type.named:type_name -> encore.parser.schema.v1.Named
@@ -2306,20 +2403,21 @@ var file_encore_parser_schema_v1_schema_proto_depIdxs = []int32{
 	24, // 25: encore.parser.schema.v1.WireSpec.query:type_name -> encore.parser.schema.v1.WireSpec.Query
 	25, // 26: encore.parser.schema.v1.WireSpec.cookie:type_name -> encore.parser.schema.v1.WireSpec.Cookie
 	26, // 27: encore.parser.schema.v1.WireSpec.http_status:type_name -> encore.parser.schema.v1.WireSpec.HttpStatus
-	2,  // 28: encore.parser.schema.v1.Map.key:type_name -> encore.parser.schema.v1.Type
-	2,  // 29: encore.parser.schema.v1.Map.value:type_name -> encore.parser.schema.v1.Type
-	2,  // 30: encore.parser.schema.v1.List.elem:type_name -> encore.parser.schema.v1.Type
-	2,  // 31: encore.parser.schema.v1.Pointer.base:type_name -> encore.parser.schema.v1.Type
-	2,  // 32: encore.parser.schema.v1.Option.value:type_name -> encore.parser.schema.v1.Type
-	2,  // 33: encore.parser.schema.v1.Union.types:type_name -> encore.parser.schema.v1.Type
-	2,  // 34: encore.parser.schema.v1.ConfigValue.elem:type_name -> encore.parser.schema.v1.Type
-	4,  // 35: encore.parser.schema.v1.ValidationExpr.And.exprs:type_name -> encore.parser.schema.v1.ValidationExpr
-	4,  // 36: encore.parser.schema.v1.ValidationExpr.Or.exprs:type_name -> encore.parser.schema.v1.ValidationExpr
-	37, // [37:37] is the sub-list for method output_type
-	37, // [37:37] is the sub-list for method input_type
-	37, // [37:37] is the sub-list for extension type_name
-	37, // [37:37] is the sub-list for extension extendee
-	0,  // [0:37] is the sub-list for field type_name
+	27, // 28: encore.parser.schema.v1.WireSpec.form:type_name -> encore.parser.schema.v1.WireSpec.Form
+	2,  // 29: encore.parser.schema.v1.Map.key:type_name -> encore.parser.schema.v1.Type
+	2,  // 30: encore.parser.schema.v1.Map.value:type_name -> encore.parser.schema.v1.Type
+	2,  // 31: encore.parser.schema.v1.List.elem:type_name -> encore.parser.schema.v1.Type
+	2,  // 32: encore.parser.schema.v1.Pointer.base:type_name -> encore.parser.schema.v1.Type
+	2,  // 33: encore.parser.schema.v1.Option.value:type_name -> encore.parser.schema.v1.Type
+	2,  // 34: encore.parser.schema.v1.Union.types:type_name -> encore.parser.schema.v1.Type
+	2,  // 35: encore.parser.schema.v1.ConfigValue.elem:type_name -> encore.parser.schema.v1.Type
+	4,  // 36: encore.parser.schema.v1.ValidationExpr.And.exprs:type_name -> encore.parser.schema.v1.ValidationExpr
+	4,  // 37: encore.parser.schema.v1.ValidationExpr.Or.exprs:type_name -> encore.parser.schema.v1.ValidationExpr
+	38, // [38:38] is the sub-list for method output_type
+	38, // [38:38] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
 }
 
 func init() { file_encore_parser_schema_v1_schema_proto_init() }
@@ -2361,6 +2459,7 @@ func file_encore_parser_schema_v1_schema_proto_init() {
 		(*WireSpec_Query_)(nil),
 		(*WireSpec_Cookie_)(nil),
 		(*WireSpec_HttpStatus_)(nil),
+		(*WireSpec_Form_)(nil),
 	}
 	file_encore_parser_schema_v1_schema_proto_msgTypes[17].OneofWrappers = []any{
 		(*Literal_Str)(nil),
@@ -2372,13 +2471,14 @@ func file_encore_parser_schema_v1_schema_proto_init() {
 	file_encore_parser_schema_v1_schema_proto_msgTypes[21].OneofWrappers = []any{}
 	file_encore_parser_schema_v1_schema_proto_msgTypes[22].OneofWrappers = []any{}
 	file_encore_parser_schema_v1_schema_proto_msgTypes[23].OneofWrappers = []any{}
+	file_encore_parser_schema_v1_schema_proto_msgTypes[25].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_parser_schema_v1_schema_proto_rawDesc), len(file_encore_parser_schema_v1_schema_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   25,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
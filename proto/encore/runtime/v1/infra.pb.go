@@ -21,6 +21,52 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SQLEngine int32
+
+const (
+	SQLEngine_SQL_ENGINE_POSTGRES SQLEngine = 0
+	SQLEngine_SQL_ENGINE_MYSQL    SQLEngine = 1
+)
+
+// Enum value maps for SQLEngine.
+var (
+	SQLEngine_name = map[int32]string{
+		0: "SQL_ENGINE_POSTGRES",
+		1: "SQL_ENGINE_MYSQL",
+	}
+	SQLEngine_value = map[string]int32{
+		"SQL_ENGINE_POSTGRES": 0,
+		"SQL_ENGINE_MYSQL":    1,
+	}
+)
+
+func (x SQLEngine) Enum() *SQLEngine {
+	p := new(SQLEngine)
+	*p = x
+	return p
+}
+
+func (x SQLEngine) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SQLEngine) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_runtime_v1_infra_proto_enumTypes[0].Descriptor()
+}
+
+func (SQLEngine) Type() protoreflect.EnumType {
+	return &file_encore_runtime_v1_infra_proto_enumTypes[0]
+}
+
+func (x SQLEngine) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SQLEngine.Descriptor instead.
+func (SQLEngine) EnumDescriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{0}
+}
+
 type ServerKind int32
 
 const (
@@ -60,11 +106,11 @@ func (x ServerKind) String() string {
 }
 
 func (ServerKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_runtime_v1_infra_proto_enumTypes[0].Descriptor()
+	return file_encore_runtime_v1_infra_proto_enumTypes[1].Descriptor()
 }
 
 func (ServerKind) Type() protoreflect.EnumType {
-	return &file_encore_runtime_v1_infra_proto_enumTypes[0]
+	return &file_encore_runtime_v1_infra_proto_enumTypes[1]
 }
 
 func (x ServerKind) Number() protoreflect.EnumNumber {
@@ -73,7 +119,7 @@ func (x ServerKind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ServerKind.Descriptor instead.
 func (ServerKind) EnumDescriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{1}
 }
 
 type PubSubTopic_DeliveryGuarantee int32
@@ -109,11 +155,11 @@ func (x PubSubTopic_DeliveryGuarantee) String() string {
 }
 
 func (PubSubTopic_DeliveryGuarantee) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_runtime_v1_infra_proto_enumTypes[1].Descriptor()
+	return file_encore_runtime_v1_infra_proto_enumTypes[2].Descriptor()
 }
 
 func (PubSubTopic_DeliveryGuarantee) Type() protoreflect.EnumType {
-	return &file_encore_runtime_v1_infra_proto_enumTypes[1]
+	return &file_encore_runtime_v1_infra_proto_enumTypes[2]
 }
 
 func (x PubSubTopic_DeliveryGuarantee) Number() protoreflect.EnumNumber {
@@ -125,6 +171,58 @@ func (PubSubTopic_DeliveryGuarantee) EnumDescriptor() ([]byte, []int) {
 	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 0}
 }
 
+type Gateway_Compression int32
+
+const (
+	Gateway_COMPRESSION_UNSPECIFIED Gateway_Compression = 0
+	Gateway_COMPRESSION_GZIP        Gateway_Compression = 1
+	Gateway_COMPRESSION_BROTLI      Gateway_Compression = 2
+	Gateway_COMPRESSION_ZSTD        Gateway_Compression = 3
+)
+
+// Enum value maps for Gateway_Compression.
+var (
+	Gateway_Compression_name = map[int32]string{
+		0: "COMPRESSION_UNSPECIFIED",
+		1: "COMPRESSION_GZIP",
+		2: "COMPRESSION_BROTLI",
+		3: "COMPRESSION_ZSTD",
+	}
+	Gateway_Compression_value = map[string]int32{
+		"COMPRESSION_UNSPECIFIED": 0,
+		"COMPRESSION_GZIP":        1,
+		"COMPRESSION_BROTLI":      2,
+		"COMPRESSION_ZSTD":        3,
+	}
+)
+
+func (x Gateway_Compression) Enum() *Gateway_Compression {
+	p := new(Gateway_Compression)
+	*p = x
+	return p
+}
+
+func (x Gateway_Compression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Gateway_Compression) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_runtime_v1_infra_proto_enumTypes[3].Descriptor()
+}
+
+func (Gateway_Compression) Type() protoreflect.EnumType {
+	return &file_encore_runtime_v1_infra_proto_enumTypes[3]
+}
+
+func (x Gateway_Compression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Gateway_Compression.Descriptor instead.
+func (Gateway_Compression) EnumDescriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{23, 0}
+}
+
 type Infrastructure struct {
 	state         protoimpl.MessageState      `protogen:"open.v1"`
 	Resources     *Infrastructure_Resources   `protobuf:"bytes,1,opt,name=resources,proto3" json:"resources,omitempty"`
@@ -269,9 +367,11 @@ func (*SecretProvider_GcpSm) isSecretProvider_Provider() {}
 type SQLCluster struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this cluster.
-	Rid           string         `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
-	Servers       []*SQLServer   `protobuf:"bytes,2,rep,name=servers,proto3" json:"servers,omitempty"`
-	Databases     []*SQLDatabase `protobuf:"bytes,3,rep,name=databases,proto3" json:"databases,omitempty"`
+	Rid       string         `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
+	Servers   []*SQLServer   `protobuf:"bytes,2,rep,name=servers,proto3" json:"servers,omitempty"`
+	Databases []*SQLDatabase `protobuf:"bytes,3,rep,name=databases,proto3" json:"databases,omitempty"`
+	// The database engine the servers run.
+	Engine        SQLEngine `protobuf:"varint,4,opt,name=engine,proto3,enum=encore.runtime.v1.SQLEngine" json:"engine,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -327,6 +427,13 @@ func (x *SQLCluster) GetDatabases() []*SQLDatabase {
 	return nil
 }
 
+func (x *SQLCluster) GetEngine() SQLEngine {
+	if x != nil {
+		return x.Engine
+	}
+	return SQLEngine_SQL_ENGINE_POSTGRES
+}
+
 type TLSConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Server CA Cert PEM to use for verifying the server's certificate.
@@ -1783,37 +1890,35 @@ func (x *Bucket) GetPublicBaseUrl() string {
 	return ""
 }
 
-type Gateway struct {
+type SearchCluster struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The unique id for this resource.
-	Rid string `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
-	// The encore name of the gateway.
-	EncoreName string `protobuf:"bytes,2,opt,name=encore_name,json=encoreName,proto3" json:"encore_name,omitempty"`
-	// The base url for reaching this gateway, for returning to the application
-	// via e.g. the metadata APIs.
-	BaseUrl string `protobuf:"bytes,3,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
-	// The hostnames this gateway accepts requests for.
-	Hostnames []string `protobuf:"bytes,4,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
-	// CORS is the CORS configuration for this gateway.
-	Cors          *Gateway_CORS `protobuf:"bytes,5,opt,name=cors,proto3" json:"cors,omitempty"`
+	// The unique resource id for this cluster.
+	Rid     string         `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
+	Indexes []*SearchIndex `protobuf:"bytes,2,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	// Types that are valid to be assigned to Provider:
+	//
+	//	*SearchCluster_Postgres_
+	//	*SearchCluster_Opensearch
+	//	*SearchCluster_Meilisearch_
+	Provider      isSearchCluster_Provider `protobuf_oneof:"provider"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Gateway) Reset() {
-	*x = Gateway{}
+func (x *SearchCluster) Reset() {
+	*x = SearchCluster{}
 	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Gateway) String() string {
+func (x *SearchCluster) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Gateway) ProtoMessage() {}
+func (*SearchCluster) ProtoMessage() {}
 
-func (x *Gateway) ProtoReflect() protoreflect.Message {
+func (x *SearchCluster) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1825,69 +1930,107 @@ func (x *Gateway) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Gateway.ProtoReflect.Descriptor instead.
-func (*Gateway) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchCluster.ProtoReflect.Descriptor instead.
+func (*SearchCluster) Descriptor() ([]byte, []int) {
 	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *Gateway) GetRid() string {
+func (x *SearchCluster) GetRid() string {
 	if x != nil {
 		return x.Rid
 	}
 	return ""
 }
 
-func (x *Gateway) GetEncoreName() string {
+func (x *SearchCluster) GetIndexes() []*SearchIndex {
 	if x != nil {
-		return x.EncoreName
+		return x.Indexes
 	}
-	return ""
+	return nil
 }
 
-func (x *Gateway) GetBaseUrl() string {
+func (x *SearchCluster) GetProvider() isSearchCluster_Provider {
 	if x != nil {
-		return x.BaseUrl
+		return x.Provider
 	}
-	return ""
+	return nil
 }
 
-func (x *Gateway) GetHostnames() []string {
+func (x *SearchCluster) GetPostgres() *SearchCluster_Postgres {
 	if x != nil {
-		return x.Hostnames
+		if x, ok := x.Provider.(*SearchCluster_Postgres_); ok {
+			return x.Postgres
+		}
 	}
 	return nil
 }
 
-func (x *Gateway) GetCors() *Gateway_CORS {
+func (x *SearchCluster) GetOpensearch() *SearchCluster_OpenSearch {
 	if x != nil {
-		return x.Cors
+		if x, ok := x.Provider.(*SearchCluster_Opensearch); ok {
+			return x.Opensearch
+		}
 	}
 	return nil
 }
 
-type Infrastructure_Credentials struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientCerts   []*ClientCert          `protobuf:"bytes,1,rep,name=client_certs,json=clientCerts,proto3" json:"client_certs,omitempty"`
-	SqlRoles      []*SQLRole             `protobuf:"bytes,2,rep,name=sql_roles,json=sqlRoles,proto3" json:"sql_roles,omitempty"`
-	RedisRoles    []*RedisRole           `protobuf:"bytes,3,rep,name=redis_roles,json=redisRoles,proto3" json:"redis_roles,omitempty"`
+func (x *SearchCluster) GetMeilisearch() *SearchCluster_Meilisearch {
+	if x != nil {
+		if x, ok := x.Provider.(*SearchCluster_Meilisearch_); ok {
+			return x.Meilisearch
+		}
+	}
+	return nil
+}
+
+type isSearchCluster_Provider interface {
+	isSearchCluster_Provider()
+}
+
+type SearchCluster_Postgres_ struct {
+	Postgres *SearchCluster_Postgres `protobuf:"bytes,10,opt,name=postgres,proto3,oneof"`
+}
+
+type SearchCluster_Opensearch struct {
+	Opensearch *SearchCluster_OpenSearch `protobuf:"bytes,11,opt,name=opensearch,proto3,oneof"`
+}
+
+type SearchCluster_Meilisearch_ struct {
+	Meilisearch *SearchCluster_Meilisearch `protobuf:"bytes,12,opt,name=meilisearch,proto3,oneof"`
+}
+
+func (*SearchCluster_Postgres_) isSearchCluster_Provider() {}
+
+func (*SearchCluster_Opensearch) isSearchCluster_Provider() {}
+
+func (*SearchCluster_Meilisearch_) isSearchCluster_Provider() {}
+
+type SearchIndex struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The unique resource id for this index.
+	Rid string `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
+	// The encore name of the index.
+	EncoreName string `protobuf:"bytes,2,opt,name=encore_name,json=encoreName,proto3" json:"encore_name,omitempty"`
+	// The cloud name of the index.
+	CloudName     string `protobuf:"bytes,3,opt,name=cloud_name,json=cloudName,proto3" json:"cloud_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Infrastructure_Credentials) Reset() {
-	*x = Infrastructure_Credentials{}
+func (x *SearchIndex) Reset() {
+	*x = SearchIndex{}
 	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Infrastructure_Credentials) String() string {
+func (x *SearchIndex) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Infrastructure_Credentials) ProtoMessage() {}
+func (*SearchIndex) ProtoMessage() {}
 
-func (x *Infrastructure_Credentials) ProtoReflect() protoreflect.Message {
+func (x *SearchIndex) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1899,59 +2042,60 @@ func (x *Infrastructure_Credentials) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Infrastructure_Credentials.ProtoReflect.Descriptor instead.
-func (*Infrastructure_Credentials) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{0, 0}
+// Deprecated: Use SearchIndex.ProtoReflect.Descriptor instead.
+func (*SearchIndex) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *Infrastructure_Credentials) GetClientCerts() []*ClientCert {
+func (x *SearchIndex) GetRid() string {
 	if x != nil {
-		return x.ClientCerts
+		return x.Rid
 	}
-	return nil
+	return ""
 }
 
-func (x *Infrastructure_Credentials) GetSqlRoles() []*SQLRole {
+func (x *SearchIndex) GetEncoreName() string {
 	if x != nil {
-		return x.SqlRoles
+		return x.EncoreName
 	}
-	return nil
+	return ""
 }
 
-func (x *Infrastructure_Credentials) GetRedisRoles() []*RedisRole {
+func (x *SearchIndex) GetCloudName() string {
 	if x != nil {
-		return x.RedisRoles
+		return x.CloudName
 	}
-	return nil
+	return ""
 }
 
-type Infrastructure_Resources struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Gateways        []*Gateway             `protobuf:"bytes,1,rep,name=gateways,proto3" json:"gateways,omitempty"`
-	SqlClusters     []*SQLCluster          `protobuf:"bytes,2,rep,name=sql_clusters,json=sqlClusters,proto3" json:"sql_clusters,omitempty"`
-	PubsubClusters  []*PubSubCluster       `protobuf:"bytes,3,rep,name=pubsub_clusters,json=pubsubClusters,proto3" json:"pubsub_clusters,omitempty"`
-	RedisClusters   []*RedisCluster        `protobuf:"bytes,4,rep,name=redis_clusters,json=redisClusters,proto3" json:"redis_clusters,omitempty"`
-	AppSecrets      []*AppSecret           `protobuf:"bytes,5,rep,name=app_secrets,json=appSecrets,proto3" json:"app_secrets,omitempty"`
-	BucketClusters  []*BucketCluster       `protobuf:"bytes,6,rep,name=bucket_clusters,json=bucketClusters,proto3" json:"bucket_clusters,omitempty"`
-	SecretProviders []*SecretProvider      `protobuf:"bytes,7,rep,name=secret_providers,json=secretProviders,proto3" json:"secret_providers,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type EncryptionKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The unique resource id for this key.
+	Rid string `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
+	// Types that are valid to be assigned to Provider:
+	//
+	//	*EncryptionKey_Local_
+	//	*EncryptionKey_AwsKms
+	//	*EncryptionKey_GcpKms
+	Provider      isEncryptionKey_Provider `protobuf_oneof:"provider"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Infrastructure_Resources) Reset() {
-	*x = Infrastructure_Resources{}
+func (x *EncryptionKey) Reset() {
+	*x = EncryptionKey{}
 	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Infrastructure_Resources) String() string {
+func (x *EncryptionKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Infrastructure_Resources) ProtoMessage() {}
+func (*EncryptionKey) ProtoMessage() {}
 
-func (x *Infrastructure_Resources) ProtoReflect() protoreflect.Message {
+func (x *EncryptionKey) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1963,82 +2107,111 @@ func (x *Infrastructure_Resources) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Infrastructure_Resources.ProtoReflect.Descriptor instead.
-func (*Infrastructure_Resources) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{0, 1}
+// Deprecated: Use EncryptionKey.ProtoReflect.Descriptor instead.
+func (*EncryptionKey) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Infrastructure_Resources) GetGateways() []*Gateway {
+func (x *EncryptionKey) GetRid() string {
 	if x != nil {
-		return x.Gateways
+		return x.Rid
 	}
-	return nil
+	return ""
 }
 
-func (x *Infrastructure_Resources) GetSqlClusters() []*SQLCluster {
+func (x *EncryptionKey) GetProvider() isEncryptionKey_Provider {
 	if x != nil {
-		return x.SqlClusters
+		return x.Provider
 	}
 	return nil
 }
 
-func (x *Infrastructure_Resources) GetPubsubClusters() []*PubSubCluster {
+func (x *EncryptionKey) GetLocal() *EncryptionKey_Local {
 	if x != nil {
-		return x.PubsubClusters
+		if x, ok := x.Provider.(*EncryptionKey_Local_); ok {
+			return x.Local
+		}
 	}
 	return nil
 }
 
-func (x *Infrastructure_Resources) GetRedisClusters() []*RedisCluster {
+func (x *EncryptionKey) GetAwsKms() *EncryptionKey_AWSKMS {
 	if x != nil {
-		return x.RedisClusters
+		if x, ok := x.Provider.(*EncryptionKey_AwsKms); ok {
+			return x.AwsKms
+		}
 	}
 	return nil
 }
 
-func (x *Infrastructure_Resources) GetAppSecrets() []*AppSecret {
+func (x *EncryptionKey) GetGcpKms() *EncryptionKey_GCPKMS {
 	if x != nil {
-		return x.AppSecrets
+		if x, ok := x.Provider.(*EncryptionKey_GcpKms); ok {
+			return x.GcpKms
+		}
 	}
 	return nil
 }
 
-func (x *Infrastructure_Resources) GetBucketClusters() []*BucketCluster {
-	if x != nil {
-		return x.BucketClusters
-	}
-	return nil
+type isEncryptionKey_Provider interface {
+	isEncryptionKey_Provider()
 }
 
-func (x *Infrastructure_Resources) GetSecretProviders() []*SecretProvider {
-	if x != nil {
-		return x.SecretProviders
-	}
-	return nil
+type EncryptionKey_Local_ struct {
+	Local *EncryptionKey_Local `protobuf:"bytes,10,opt,name=local,proto3,oneof"`
 }
 
-type SecretProvider_GCPSecretManager struct {
+type EncryptionKey_AwsKms struct {
+	AwsKms *EncryptionKey_AWSKMS `protobuf:"bytes,11,opt,name=aws_kms,json=awsKms,proto3,oneof"`
+}
+
+type EncryptionKey_GcpKms struct {
+	GcpKms *EncryptionKey_GCPKMS `protobuf:"bytes,12,opt,name=gcp_kms,json=gcpKms,proto3,oneof"`
+}
+
+func (*EncryptionKey_Local_) isEncryptionKey_Provider() {}
+
+func (*EncryptionKey_AwsKms) isEncryptionKey_Provider() {}
+
+func (*EncryptionKey_GcpKms) isEncryptionKey_Provider() {}
+
+type Gateway struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The GCP project that owns the secrets.
-	ProjectId     string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// The unique id for this resource.
+	Rid string `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
+	//  The encore name of the gateway.
+	EncoreName string `protobuf:"bytes,2,opt,name=encore_name,json=encoreName,proto3" json:"encore_name,omitempty"`
+	// The base url for reaching this gateway, for returning to the application
+	// via e.g. the metadata APIs.
+	BaseUrl string `protobuf:"bytes,3,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// The hostnames this gateway accepts requests for.
+	Hostnames []string `protobuf:"bytes,4,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	// CORS is the CORS configuration for this gateway.
+	Cors *Gateway_CORS `protobuf:"bytes,5,opt,name=cors,proto3" json:"cors,omitempty"`
+	// Transformations to apply to requests and responses
+	// passing through this gateway, in order.
+	Transforms []*Gateway_Transform `protobuf:"bytes,6,rep,name=transforms,proto3" json:"transforms,omitempty"`
+	// Client metadata enrichment of incoming requests.
+	// If unset, requests are not enriched.
+	ClientMetadata *Gateway_ClientMetadata `protobuf:"bytes,7,opt,name=client_metadata,json=clientMetadata,proto3" json:"client_metadata,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *SecretProvider_GCPSecretManager) Reset() {
-	*x = SecretProvider_GCPSecretManager{}
+func (x *Gateway) Reset() {
+	*x = Gateway{}
 	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SecretProvider_GCPSecretManager) String() string {
+func (x *Gateway) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SecretProvider_GCPSecretManager) ProtoMessage() {}
+func (*Gateway) ProtoMessage() {}
 
-func (x *SecretProvider_GCPSecretManager) ProtoReflect() protoreflect.Message {
+func (x *Gateway) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2050,29 +2223,286 @@ func (x *SecretProvider_GCPSecretManager) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SecretProvider_GCPSecretManager.ProtoReflect.Descriptor instead.
-func (*SecretProvider_GCPSecretManager) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use Gateway.ProtoReflect.Descriptor instead.
+func (*Gateway) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *SecretProvider_GCPSecretManager) GetProjectId() string {
+func (x *Gateway) GetRid() string {
 	if x != nil {
-		return x.ProjectId
+		return x.Rid
 	}
 	return ""
 }
 
-type RedisRole_AuthACL struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      *SecretData            `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Gateway) GetEncoreName() string {
+	if x != nil {
+		return x.EncoreName
+	}
+	return ""
 }
 
-func (x *RedisRole_AuthACL) Reset() {
-	*x = RedisRole_AuthACL{}
+func (x *Gateway) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *Gateway) GetHostnames() []string {
+	if x != nil {
+		return x.Hostnames
+	}
+	return nil
+}
+
+func (x *Gateway) GetCors() *Gateway_CORS {
+	if x != nil {
+		return x.Cors
+	}
+	return nil
+}
+
+func (x *Gateway) GetTransforms() []*Gateway_Transform {
+	if x != nil {
+		return x.Transforms
+	}
+	return nil
+}
+
+func (x *Gateway) GetClientMetadata() *Gateway_ClientMetadata {
+	if x != nil {
+		return x.ClientMetadata
+	}
+	return nil
+}
+
+type Infrastructure_Credentials struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientCerts   []*ClientCert          `protobuf:"bytes,1,rep,name=client_certs,json=clientCerts,proto3" json:"client_certs,omitempty"`
+	SqlRoles      []*SQLRole             `protobuf:"bytes,2,rep,name=sql_roles,json=sqlRoles,proto3" json:"sql_roles,omitempty"`
+	RedisRoles    []*RedisRole           `protobuf:"bytes,3,rep,name=redis_roles,json=redisRoles,proto3" json:"redis_roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Infrastructure_Credentials) Reset() {
+	*x = Infrastructure_Credentials{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Infrastructure_Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Infrastructure_Credentials) ProtoMessage() {}
+
+func (x *Infrastructure_Credentials) ProtoReflect() protoreflect.Message {
 	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Infrastructure_Credentials.ProtoReflect.Descriptor instead.
+func (*Infrastructure_Credentials) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Infrastructure_Credentials) GetClientCerts() []*ClientCert {
+	if x != nil {
+		return x.ClientCerts
+	}
+	return nil
+}
+
+func (x *Infrastructure_Credentials) GetSqlRoles() []*SQLRole {
+	if x != nil {
+		return x.SqlRoles
+	}
+	return nil
+}
+
+func (x *Infrastructure_Credentials) GetRedisRoles() []*RedisRole {
+	if x != nil {
+		return x.RedisRoles
+	}
+	return nil
+}
+
+type Infrastructure_Resources struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Gateways        []*Gateway             `protobuf:"bytes,1,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	SqlClusters     []*SQLCluster          `protobuf:"bytes,2,rep,name=sql_clusters,json=sqlClusters,proto3" json:"sql_clusters,omitempty"`
+	PubsubClusters  []*PubSubCluster       `protobuf:"bytes,3,rep,name=pubsub_clusters,json=pubsubClusters,proto3" json:"pubsub_clusters,omitempty"`
+	RedisClusters   []*RedisCluster        `protobuf:"bytes,4,rep,name=redis_clusters,json=redisClusters,proto3" json:"redis_clusters,omitempty"`
+	AppSecrets      []*AppSecret           `protobuf:"bytes,5,rep,name=app_secrets,json=appSecrets,proto3" json:"app_secrets,omitempty"`
+	BucketClusters  []*BucketCluster       `protobuf:"bytes,6,rep,name=bucket_clusters,json=bucketClusters,proto3" json:"bucket_clusters,omitempty"`
+	SecretProviders []*SecretProvider      `protobuf:"bytes,7,rep,name=secret_providers,json=secretProviders,proto3" json:"secret_providers,omitempty"`
+	SearchClusters  []*SearchCluster       `protobuf:"bytes,8,rep,name=search_clusters,json=searchClusters,proto3" json:"search_clusters,omitempty"`
+	// The keys encrypting the data keys of encrypted data.
+	// The first key encrypts new data; the others only decrypt existing data.
+	EncryptionKeys []*EncryptionKey `protobuf:"bytes,9,rep,name=encryption_keys,json=encryptionKeys,proto3" json:"encryption_keys,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Infrastructure_Resources) Reset() {
+	*x = Infrastructure_Resources{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Infrastructure_Resources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Infrastructure_Resources) ProtoMessage() {}
+
+func (x *Infrastructure_Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Infrastructure_Resources.ProtoReflect.Descriptor instead.
+func (*Infrastructure_Resources) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *Infrastructure_Resources) GetGateways() []*Gateway {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+func (x *Infrastructure_Resources) GetSqlClusters() []*SQLCluster {
+	if x != nil {
+		return x.SqlClusters
+	}
+	return nil
+}
+
+func (x *Infrastructure_Resources) GetPubsubClusters() []*PubSubCluster {
+	if x != nil {
+		return x.PubsubClusters
+	}
+	return nil
+}
+
+func (x *Infrastructure_Resources) GetRedisClusters() []*RedisCluster {
+	if x != nil {
+		return x.RedisClusters
+	}
+	return nil
+}
+
+func (x *Infrastructure_Resources) GetAppSecrets() []*AppSecret {
+	if x != nil {
+		return x.AppSecrets
+	}
+	return nil
+}
+
+func (x *Infrastructure_Resources) GetBucketClusters() []*BucketCluster {
+	if x != nil {
+		return x.BucketClusters
+	}
+	return nil
+}
+
+func (x *Infrastructure_Resources) GetSecretProviders() []*SecretProvider {
+	if x != nil {
+		return x.SecretProviders
+	}
+	return nil
+}
+
+func (x *Infrastructure_Resources) GetSearchClusters() []*SearchCluster {
+	if x != nil {
+		return x.SearchClusters
+	}
+	return nil
+}
+
+func (x *Infrastructure_Resources) GetEncryptionKeys() []*EncryptionKey {
+	if x != nil {
+		return x.EncryptionKeys
+	}
+	return nil
+}
+
+type SecretProvider_GCPSecretManager struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The GCP project that owns the secrets.
+	ProjectId     string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretProvider_GCPSecretManager) Reset() {
+	*x = SecretProvider_GCPSecretManager{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretProvider_GCPSecretManager) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretProvider_GCPSecretManager) ProtoMessage() {}
+
+func (x *SecretProvider_GCPSecretManager) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretProvider_GCPSecretManager.ProtoReflect.Descriptor instead.
+func (*SecretProvider_GCPSecretManager) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *SecretProvider_GCPSecretManager) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type RedisRole_AuthACL struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      *SecretData            `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedisRole_AuthACL) Reset() {
+	*x = RedisRole_AuthACL{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2084,7 +2514,7 @@ func (x *RedisRole_AuthACL) String() string {
 func (*RedisRole_AuthACL) ProtoMessage() {}
 
 func (x *RedisRole_AuthACL) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2122,7 +2552,7 @@ type PubSubCluster_EncoreCloud struct {
 
 func (x *PubSubCluster_EncoreCloud) Reset() {
 	*x = PubSubCluster_EncoreCloud{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2134,7 +2564,7 @@ func (x *PubSubCluster_EncoreCloud) String() string {
 func (*PubSubCluster_EncoreCloud) ProtoMessage() {}
 
 func (x *PubSubCluster_EncoreCloud) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2158,7 +2588,7 @@ type PubSubCluster_AWSSqsSns struct {
 
 func (x *PubSubCluster_AWSSqsSns) Reset() {
 	*x = PubSubCluster_AWSSqsSns{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2170,7 +2600,7 @@ func (x *PubSubCluster_AWSSqsSns) String() string {
 func (*PubSubCluster_AWSSqsSns) ProtoMessage() {}
 
 func (x *PubSubCluster_AWSSqsSns) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2194,19 +2624,456 @@ type PubSubCluster_GCPPubSub struct {
 
 func (x *PubSubCluster_GCPPubSub) Reset() {
 	*x = PubSubCluster_GCPPubSub{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubCluster_GCPPubSub) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubCluster_GCPPubSub) ProtoMessage() {}
+
+func (x *PubSubCluster_GCPPubSub) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubCluster_GCPPubSub.ProtoReflect.Descriptor instead.
+func (*PubSubCluster_GCPPubSub) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 2}
+}
+
+type PubSubCluster_NSQ struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The hosts to connect to NSQ. Must be non-empty.
+	Hosts         []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubCluster_NSQ) Reset() {
+	*x = PubSubCluster_NSQ{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubCluster_NSQ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubCluster_NSQ) ProtoMessage() {}
+
+func (x *PubSubCluster_NSQ) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubCluster_NSQ.ProtoReflect.Descriptor instead.
+func (*PubSubCluster_NSQ) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 3}
+}
+
+func (x *PubSubCluster_NSQ) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type PubSubCluster_AzureServiceBus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubCluster_AzureServiceBus) Reset() {
+	*x = PubSubCluster_AzureServiceBus{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubCluster_AzureServiceBus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubCluster_AzureServiceBus) ProtoMessage() {}
+
+func (x *PubSubCluster_AzureServiceBus) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubCluster_AzureServiceBus.ProtoReflect.Descriptor instead.
+func (*PubSubCluster_AzureServiceBus) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 4}
+}
+
+func (x *PubSubCluster_AzureServiceBus) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type PubSubTopic_GCPConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The GCP project id where the topic exists.
+	ProjectId     string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubTopic_GCPConfig) Reset() {
+	*x = PubSubTopic_GCPConfig{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubTopic_GCPConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubTopic_GCPConfig) ProtoMessage() {}
+
+func (x *PubSubTopic_GCPConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubTopic_GCPConfig.ProtoReflect.Descriptor instead.
+func (*PubSubTopic_GCPConfig) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 0}
+}
+
+func (x *PubSubTopic_GCPConfig) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type PubSubSubscription_GCPConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The GCP project id where the subscription exists.
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// The service account used to authenticate messages being delivered over push.
+	// If unset, pushes are rejected.
+	PushServiceAccount *string `protobuf:"bytes,2,opt,name=push_service_account,json=pushServiceAccount,proto3,oneof" json:"push_service_account,omitempty"`
+	// The audience to use when validating JWTs delivered over push.
+	// If set, the JWT audience claim must match. If unset, any JWT audience is allowed.
+	PushJwtAudience *string `protobuf:"bytes,3,opt,name=push_jwt_audience,json=pushJwtAudience,proto3,oneof" json:"push_jwt_audience,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PubSubSubscription_GCPConfig) Reset() {
+	*x = PubSubSubscription_GCPConfig{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubSubscription_GCPConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubSubscription_GCPConfig) ProtoMessage() {}
+
+func (x *PubSubSubscription_GCPConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubSubscription_GCPConfig.ProtoReflect.Descriptor instead.
+func (*PubSubSubscription_GCPConfig) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{17, 0}
+}
+
+func (x *PubSubSubscription_GCPConfig) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *PubSubSubscription_GCPConfig) GetPushServiceAccount() string {
+	if x != nil && x.PushServiceAccount != nil {
+		return *x.PushServiceAccount
+	}
+	return ""
+}
+
+func (x *PubSubSubscription_GCPConfig) GetPushJwtAudience() string {
+	if x != nil && x.PushJwtAudience != nil {
+		return *x.PushJwtAudience
+	}
+	return ""
+}
+
+type BucketCluster_S3 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Region to connect to.
+	Region string `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	// Endpoint override, if any. Must be specified if using a non-standard AWS region.
+	Endpoint *string `protobuf:"bytes,2,opt,name=endpoint,proto3,oneof" json:"endpoint,omitempty"`
+	// Set these to use explicit credentials for this bucket,
+	// as opposed to resolving using AWS's default credential chain.
+	AccessKeyId     *string     `protobuf:"bytes,3,opt,name=access_key_id,json=accessKeyId,proto3,oneof" json:"access_key_id,omitempty"`
+	SecretAccessKey *SecretData `protobuf:"bytes,4,opt,name=secret_access_key,json=secretAccessKey,proto3,oneof" json:"secret_access_key,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BucketCluster_S3) Reset() {
+	*x = BucketCluster_S3{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketCluster_S3) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketCluster_S3) ProtoMessage() {}
+
+func (x *BucketCluster_S3) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketCluster_S3.ProtoReflect.Descriptor instead.
+func (*BucketCluster_S3) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 0}
+}
+
+func (x *BucketCluster_S3) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *BucketCluster_S3) GetEndpoint() string {
+	if x != nil && x.Endpoint != nil {
+		return *x.Endpoint
+	}
+	return ""
+}
+
+func (x *BucketCluster_S3) GetAccessKeyId() string {
+	if x != nil && x.AccessKeyId != nil {
+		return *x.AccessKeyId
+	}
+	return ""
+}
+
+func (x *BucketCluster_S3) GetSecretAccessKey() *SecretData {
+	if x != nil {
+		return x.SecretAccessKey
+	}
+	return nil
+}
+
+type BucketCluster_GCS struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Endpoint override, if any. Defaults to https://storage.googleapis.com if unset.
+	Endpoint *string `protobuf:"bytes,1,opt,name=endpoint,proto3,oneof" json:"endpoint,omitempty"`
+	// Whether to connect anonymously or if a service account should be resolved.
+	Anonymous bool `protobuf:"varint,2,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	// Additional options for signed URLs when running in local dev mode.
+	// Only use with anonymous mode.
+	LocalSign     *BucketCluster_GCS_LocalSignOptions `protobuf:"bytes,3,opt,name=local_sign,json=localSign,proto3,oneof" json:"local_sign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketCluster_GCS) Reset() {
+	*x = BucketCluster_GCS{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketCluster_GCS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketCluster_GCS) ProtoMessage() {}
+
+func (x *BucketCluster_GCS) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketCluster_GCS.ProtoReflect.Descriptor instead.
+func (*BucketCluster_GCS) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 1}
+}
+
+func (x *BucketCluster_GCS) GetEndpoint() string {
+	if x != nil && x.Endpoint != nil {
+		return *x.Endpoint
+	}
+	return ""
+}
+
+func (x *BucketCluster_GCS) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
+func (x *BucketCluster_GCS) GetLocalSign() *BucketCluster_GCS_LocalSignOptions {
+	if x != nil {
+		return x.LocalSign
+	}
+	return nil
+}
+
+type BucketCluster_GCS_LocalSignOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base prefix to use for presigned URLs.
+	BaseUrl string `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// Use these credentials to sign local URLs. Only pass dummy credentials
+	// here, no actual secrets.
+	AccessId      string `protobuf:"bytes,2,opt,name=access_id,json=accessId,proto3" json:"access_id,omitempty"`
+	PrivateKey    string `protobuf:"bytes,3,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketCluster_GCS_LocalSignOptions) Reset() {
+	*x = BucketCluster_GCS_LocalSignOptions{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketCluster_GCS_LocalSignOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketCluster_GCS_LocalSignOptions) ProtoMessage() {}
+
+func (x *BucketCluster_GCS_LocalSignOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketCluster_GCS_LocalSignOptions.ProtoReflect.Descriptor instead.
+func (*BucketCluster_GCS_LocalSignOptions) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 1, 0}
+}
+
+func (x *BucketCluster_GCS_LocalSignOptions) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *BucketCluster_GCS_LocalSignOptions) GetAccessId() string {
+	if x != nil {
+		return x.AccessId
+	}
+	return ""
+}
+
+func (x *BucketCluster_GCS_LocalSignOptions) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+// Postgres stores the indexes in a SQL database, using Postgres full-text search.
+type SearchCluster_Postgres struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The encore name of the SQL database storing the indexes.
+	Database      string `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCluster_Postgres) Reset() {
+	*x = SearchCluster_Postgres{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PubSubCluster_GCPPubSub) String() string {
+func (x *SearchCluster_Postgres) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PubSubCluster_GCPPubSub) ProtoMessage() {}
+func (*SearchCluster_Postgres) ProtoMessage() {}
 
-func (x *PubSubCluster_GCPPubSub) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
+func (x *SearchCluster_Postgres) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2217,34 +3084,44 @@ func (x *PubSubCluster_GCPPubSub) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PubSubCluster_GCPPubSub.ProtoReflect.Descriptor instead.
-func (*PubSubCluster_GCPPubSub) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 2}
+// Deprecated: Use SearchCluster_Postgres.ProtoReflect.Descriptor instead.
+func (*SearchCluster_Postgres) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 0}
 }
 
-type PubSubCluster_NSQ struct {
+func (x *SearchCluster_Postgres) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+type SearchCluster_OpenSearch struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The hosts to connect to NSQ. Must be non-empty.
-	Hosts         []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	// The base URL of the OpenSearch (or Elasticsearch) cluster.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Set these to use basic authentication.
+	Username      *string     `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Password      *SecretData `protobuf:"bytes,3,opt,name=password,proto3,oneof" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PubSubCluster_NSQ) Reset() {
-	*x = PubSubCluster_NSQ{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
+func (x *SearchCluster_OpenSearch) Reset() {
+	*x = SearchCluster_OpenSearch{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PubSubCluster_NSQ) String() string {
+func (x *SearchCluster_OpenSearch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PubSubCluster_NSQ) ProtoMessage() {}
+func (*SearchCluster_OpenSearch) ProtoMessage() {}
 
-func (x *PubSubCluster_NSQ) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
+func (x *SearchCluster_OpenSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2255,40 +3132,57 @@ func (x *PubSubCluster_NSQ) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PubSubCluster_NSQ.ProtoReflect.Descriptor instead.
-func (*PubSubCluster_NSQ) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 3}
+// Deprecated: Use SearchCluster_OpenSearch.ProtoReflect.Descriptor instead.
+func (*SearchCluster_OpenSearch) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 1}
 }
 
-func (x *PubSubCluster_NSQ) GetHosts() []string {
+func (x *SearchCluster_OpenSearch) GetUrl() string {
 	if x != nil {
-		return x.Hosts
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SearchCluster_OpenSearch) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *SearchCluster_OpenSearch) GetPassword() *SecretData {
+	if x != nil {
+		return x.Password
 	}
 	return nil
 }
 
-type PubSubCluster_AzureServiceBus struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+type SearchCluster_Meilisearch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The base URL of the Meilisearch server.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The API key to authenticate with, if any.
+	ApiKey        *SecretData `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3,oneof" json:"api_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PubSubCluster_AzureServiceBus) Reset() {
-	*x = PubSubCluster_AzureServiceBus{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
+func (x *SearchCluster_Meilisearch) Reset() {
+	*x = SearchCluster_Meilisearch{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PubSubCluster_AzureServiceBus) String() string {
+func (x *SearchCluster_Meilisearch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PubSubCluster_AzureServiceBus) ProtoMessage() {}
+func (*SearchCluster_Meilisearch) ProtoMessage() {}
 
-func (x *PubSubCluster_AzureServiceBus) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
+func (x *SearchCluster_Meilisearch) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2299,41 +3193,49 @@ func (x *PubSubCluster_AzureServiceBus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PubSubCluster_AzureServiceBus.ProtoReflect.Descriptor instead.
-func (*PubSubCluster_AzureServiceBus) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 4}
+// Deprecated: Use SearchCluster_Meilisearch.ProtoReflect.Descriptor instead.
+func (*SearchCluster_Meilisearch) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 2}
 }
 
-func (x *PubSubCluster_AzureServiceBus) GetNamespace() string {
+func (x *SearchCluster_Meilisearch) GetUrl() string {
 	if x != nil {
-		return x.Namespace
+		return x.Url
 	}
 	return ""
 }
 
-type PubSubTopic_GCPConfig struct {
+func (x *SearchCluster_Meilisearch) GetApiKey() *SecretData {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+// Local is a key held by the application itself.
+type EncryptionKey_Local struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The GCP project id where the topic exists.
-	ProjectId     string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// The 256-bit AES key.
+	Key           *SecretData `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PubSubTopic_GCPConfig) Reset() {
-	*x = PubSubTopic_GCPConfig{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
+func (x *EncryptionKey_Local) Reset() {
+	*x = EncryptionKey_Local{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PubSubTopic_GCPConfig) String() string {
+func (x *EncryptionKey_Local) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PubSubTopic_GCPConfig) ProtoMessage() {}
+func (*EncryptionKey_Local) ProtoMessage() {}
 
-func (x *PubSubTopic_GCPConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
+func (x *EncryptionKey_Local) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2344,47 +3246,41 @@ func (x *PubSubTopic_GCPConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PubSubTopic_GCPConfig.ProtoReflect.Descriptor instead.
-func (*PubSubTopic_GCPConfig) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 0}
+// Deprecated: Use EncryptionKey_Local.ProtoReflect.Descriptor instead.
+func (*EncryptionKey_Local) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{22, 0}
 }
 
-func (x *PubSubTopic_GCPConfig) GetProjectId() string {
+func (x *EncryptionKey_Local) GetKey() *SecretData {
 	if x != nil {
-		return x.ProjectId
+		return x.Key
 	}
-	return ""
+	return nil
 }
 
-type PubSubSubscription_GCPConfig struct {
+type EncryptionKey_AWSKMS struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The GCP project id where the subscription exists.
-	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	// The service account used to authenticate messages being delivered over push.
-	// If unset, pushes are rejected.
-	PushServiceAccount *string `protobuf:"bytes,2,opt,name=push_service_account,json=pushServiceAccount,proto3,oneof" json:"push_service_account,omitempty"`
-	// The audience to use when validating JWTs delivered over push.
-	// If set, the JWT audience claim must match. If unset, any JWT audience is allowed.
-	PushJwtAudience *string `protobuf:"bytes,3,opt,name=push_jwt_audience,json=pushJwtAudience,proto3,oneof" json:"push_jwt_audience,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// The ARN of the symmetric KMS key.
+	KeyArn        string `protobuf:"bytes,1,opt,name=key_arn,json=keyArn,proto3" json:"key_arn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PubSubSubscription_GCPConfig) Reset() {
-	*x = PubSubSubscription_GCPConfig{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
+func (x *EncryptionKey_AWSKMS) Reset() {
+	*x = EncryptionKey_AWSKMS{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PubSubSubscription_GCPConfig) String() string {
+func (x *EncryptionKey_AWSKMS) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PubSubSubscription_GCPConfig) ProtoMessage() {}
+func (*EncryptionKey_AWSKMS) ProtoMessage() {}
 
-func (x *PubSubSubscription_GCPConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
+func (x *EncryptionKey_AWSKMS) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2395,61 +3291,41 @@ func (x *PubSubSubscription_GCPConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PubSubSubscription_GCPConfig.ProtoReflect.Descriptor instead.
-func (*PubSubSubscription_GCPConfig) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{17, 0}
+// Deprecated: Use EncryptionKey_AWSKMS.ProtoReflect.Descriptor instead.
+func (*EncryptionKey_AWSKMS) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{22, 1}
 }
 
-func (x *PubSubSubscription_GCPConfig) GetProjectId() string {
+func (x *EncryptionKey_AWSKMS) GetKeyArn() string {
 	if x != nil {
-		return x.ProjectId
-	}
-	return ""
-}
-
-func (x *PubSubSubscription_GCPConfig) GetPushServiceAccount() string {
-	if x != nil && x.PushServiceAccount != nil {
-		return *x.PushServiceAccount
-	}
-	return ""
-}
-
-func (x *PubSubSubscription_GCPConfig) GetPushJwtAudience() string {
-	if x != nil && x.PushJwtAudience != nil {
-		return *x.PushJwtAudience
+		return x.KeyArn
 	}
 	return ""
 }
 
-type BucketCluster_S3 struct {
+type EncryptionKey_GCPKMS struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Region to connect to.
-	Region string `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
-	// Endpoint override, if any. Must be specified if using a non-standard AWS region.
-	Endpoint *string `protobuf:"bytes,2,opt,name=endpoint,proto3,oneof" json:"endpoint,omitempty"`
-	// Set these to use explicit credentials for this bucket,
-	// as opposed to resolving using AWS's default credential chain.
-	AccessKeyId     *string     `protobuf:"bytes,3,opt,name=access_key_id,json=accessKeyId,proto3,oneof" json:"access_key_id,omitempty"`
-	SecretAccessKey *SecretData `protobuf:"bytes,4,opt,name=secret_access_key,json=secretAccessKey,proto3,oneof" json:"secret_access_key,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// The resource name of the symmetric Cloud KMS key.
+	KeyName       string `protobuf:"bytes,1,opt,name=key_name,json=keyName,proto3" json:"key_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BucketCluster_S3) Reset() {
-	*x = BucketCluster_S3{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+func (x *EncryptionKey_GCPKMS) Reset() {
+	*x = EncryptionKey_GCPKMS{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BucketCluster_S3) String() string {
+func (x *EncryptionKey_GCPKMS) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BucketCluster_S3) ProtoMessage() {}
+func (*EncryptionKey_GCPKMS) ProtoMessage() {}
 
-func (x *BucketCluster_S3) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+func (x *EncryptionKey_GCPKMS) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2460,67 +3336,47 @@ func (x *BucketCluster_S3) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BucketCluster_S3.ProtoReflect.Descriptor instead.
-func (*BucketCluster_S3) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 0}
+// Deprecated: Use EncryptionKey_GCPKMS.ProtoReflect.Descriptor instead.
+func (*EncryptionKey_GCPKMS) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{22, 2}
 }
 
-func (x *BucketCluster_S3) GetRegion() string {
+func (x *EncryptionKey_GCPKMS) GetKeyName() string {
 	if x != nil {
-		return x.Region
-	}
-	return ""
-}
-
-func (x *BucketCluster_S3) GetEndpoint() string {
-	if x != nil && x.Endpoint != nil {
-		return *x.Endpoint
-	}
-	return ""
-}
-
-func (x *BucketCluster_S3) GetAccessKeyId() string {
-	if x != nil && x.AccessKeyId != nil {
-		return *x.AccessKeyId
+		return x.KeyName
 	}
 	return ""
 }
 
-func (x *BucketCluster_S3) GetSecretAccessKey() *SecretData {
-	if x != nil {
-		return x.SecretAccessKey
-	}
-	return nil
-}
-
-type BucketCluster_GCS struct {
+// ClientMetadata describes how the gateway determines
+// metadata about the clients making requests.
+type Gateway_ClientMetadata struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Endpoint override, if any. Defaults to https://storage.googleapis.com if unset.
-	Endpoint *string `protobuf:"bytes,1,opt,name=endpoint,proto3,oneof" json:"endpoint,omitempty"`
-	// Whether to connect anonymously or if a service account should be resolved.
-	Anonymous bool `protobuf:"varint,2,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
-	// Additional options for signed URLs when running in local dev mode.
-	// Only use with anonymous mode.
-	LocalSign     *BucketCluster_GCS_LocalSignOptions `protobuf:"bytes,3,opt,name=local_sign,json=localSign,proto3,oneof" json:"local_sign,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Path to a MaxMind DB file to look up client locations in.
+	// If empty no location is determined.
+	GeoipDatabase string `protobuf:"bytes,1,opt,name=geoip_database,json=geoipDatabase,proto3" json:"geoip_database,omitempty"`
+	// The header to read the client's IP address from.
+	// If empty the address of the connecting peer is used.
+	ClientIpHeader string `protobuf:"bytes,2,opt,name=client_ip_header,json=clientIpHeader,proto3" json:"client_ip_header,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *BucketCluster_GCS) Reset() {
-	*x = BucketCluster_GCS{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+func (x *Gateway_ClientMetadata) Reset() {
+	*x = Gateway_ClientMetadata{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BucketCluster_GCS) String() string {
+func (x *Gateway_ClientMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BucketCluster_GCS) ProtoMessage() {}
+func (*Gateway_ClientMetadata) ProtoMessage() {}
 
-func (x *BucketCluster_GCS) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+func (x *Gateway_ClientMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2531,59 +3387,62 @@ func (x *BucketCluster_GCS) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BucketCluster_GCS.ProtoReflect.Descriptor instead.
-func (*BucketCluster_GCS) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 1}
-}
-
-func (x *BucketCluster_GCS) GetEndpoint() string {
-	if x != nil && x.Endpoint != nil {
-		return *x.Endpoint
-	}
-	return ""
+// Deprecated: Use Gateway_ClientMetadata.ProtoReflect.Descriptor instead.
+func (*Gateway_ClientMetadata) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{23, 0}
 }
 
-func (x *BucketCluster_GCS) GetAnonymous() bool {
+func (x *Gateway_ClientMetadata) GetGeoipDatabase() string {
 	if x != nil {
-		return x.Anonymous
+		return x.GeoipDatabase
 	}
-	return false
+	return ""
 }
 
-func (x *BucketCluster_GCS) GetLocalSign() *BucketCluster_GCS_LocalSignOptions {
+func (x *Gateway_ClientMetadata) GetClientIpHeader() string {
 	if x != nil {
-		return x.LocalSign
+		return x.ClientIpHeader
 	}
-	return nil
+	return ""
 }
 
-type BucketCluster_GCS_LocalSignOptions struct {
+// Transform describes a transformation applied to requests to,
+// and responses from, a set of endpoints.
+type Gateway_Transform struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Base prefix to use for presigned URLs.
-	BaseUrl string `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
-	// Use these credentials to sign local URLs. Only pass dummy credentials
-	// here, no actual secrets.
-	AccessId      string `protobuf:"bytes,2,opt,name=access_id,json=accessId,proto3" json:"access_id,omitempty"`
-	PrivateKey    string `protobuf:"bytes,3,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	// The endpoints the transform applies to, in the form "service.endpoint".
+	// The endpoint may be "*" to match all endpoints in the service.
+	// If empty the transform applies to all endpoints.
+	Endpoints []string `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// Headers to set on requests before they are forwarded to the endpoint.
+	RequestHeaders map[string]string `protobuf:"bytes,2,rep,name=request_headers,json=requestHeaders,proto3" json:"request_headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Headers to set on responses before they are returned to the client.
+	ResponseHeaders map[string]string `protobuf:"bytes,3,rep,name=response_headers,json=responseHeaders,proto3" json:"response_headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Top-level fields to remove from JSON response bodies.
+	StripResponseFields []string `protobuf:"bytes,4,rep,name=strip_response_fields,json=stripResponseFields,proto3" json:"strip_response_fields,omitempty"`
+	// The compression algorithms to use for response bodies,
+	// in order of preference. The first algorithm the client
+	// accepts (per the Accept-Encoding header) is used.
+	Compression   []Gateway_Compression `protobuf:"varint,5,rep,packed,name=compression,proto3,enum=encore.runtime.v1.Gateway_Compression" json:"compression,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BucketCluster_GCS_LocalSignOptions) Reset() {
-	*x = BucketCluster_GCS_LocalSignOptions{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
+func (x *Gateway_Transform) Reset() {
+	*x = Gateway_Transform{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BucketCluster_GCS_LocalSignOptions) String() string {
+func (x *Gateway_Transform) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BucketCluster_GCS_LocalSignOptions) ProtoMessage() {}
+func (*Gateway_Transform) ProtoMessage() {}
 
-func (x *BucketCluster_GCS_LocalSignOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
+func (x *Gateway_Transform) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2594,30 +3453,44 @@ func (x *BucketCluster_GCS_LocalSignOptions) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BucketCluster_GCS_LocalSignOptions.ProtoReflect.Descriptor instead.
-func (*BucketCluster_GCS_LocalSignOptions) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 1, 0}
+// Deprecated: Use Gateway_Transform.ProtoReflect.Descriptor instead.
+func (*Gateway_Transform) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{23, 1}
 }
 
-func (x *BucketCluster_GCS_LocalSignOptions) GetBaseUrl() string {
+func (x *Gateway_Transform) GetEndpoints() []string {
 	if x != nil {
-		return x.BaseUrl
+		return x.Endpoints
 	}
-	return ""
+	return nil
 }
 
-func (x *BucketCluster_GCS_LocalSignOptions) GetAccessId() string {
+func (x *Gateway_Transform) GetRequestHeaders() map[string]string {
 	if x != nil {
-		return x.AccessId
+		return x.RequestHeaders
 	}
-	return ""
+	return nil
 }
 
-func (x *BucketCluster_GCS_LocalSignOptions) GetPrivateKey() string {
+func (x *Gateway_Transform) GetResponseHeaders() map[string]string {
 	if x != nil {
-		return x.PrivateKey
+		return x.ResponseHeaders
 	}
-	return ""
+	return nil
+}
+
+func (x *Gateway_Transform) GetStripResponseFields() []string {
+	if x != nil {
+		return x.StripResponseFields
+	}
+	return nil
+}
+
+func (x *Gateway_Transform) GetCompression() []Gateway_Compression {
+	if x != nil {
+		return x.Compression
+	}
+	return nil
 }
 
 // CORS describes the CORS configuration for a gateway.
@@ -2662,7 +3535,7 @@ type Gateway_CORS struct {
 
 func (x *Gateway_CORS) Reset() {
 	*x = Gateway_CORS{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2674,7 +3547,7 @@ func (x *Gateway_CORS) String() string {
 func (*Gateway_CORS) ProtoMessage() {}
 
 func (x *Gateway_CORS) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2687,7 +3560,7 @@ func (x *Gateway_CORS) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway_CORS.ProtoReflect.Descriptor instead.
 func (*Gateway_CORS) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{23, 2}
 }
 
 func (x *Gateway_CORS) GetDebug() bool {
@@ -2789,7 +3662,7 @@ type Gateway_CORSAllowedOrigins struct {
 
 func (x *Gateway_CORSAllowedOrigins) Reset() {
 	*x = Gateway_CORSAllowedOrigins{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[36]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2801,7 +3674,7 @@ func (x *Gateway_CORSAllowedOrigins) String() string {
 func (*Gateway_CORSAllowedOrigins) ProtoMessage() {}
 
 func (x *Gateway_CORSAllowedOrigins) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[36]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2814,7 +3687,7 @@ func (x *Gateway_CORSAllowedOrigins) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway_CORSAllowedOrigins.ProtoReflect.Descriptor instead.
 func (*Gateway_CORSAllowedOrigins) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 1}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{23, 3}
 }
 
 func (x *Gateway_CORSAllowedOrigins) GetAllowedOrigins() []string {
@@ -2828,7 +3701,7 @@ var File_encore_runtime_v1_infra_proto protoreflect.FileDescriptor
 
 const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\n" +
-	"\x1dencore/runtime/v1/infra.proto\x12\x11encore.runtime.v1\x1a\"encore/runtime/v1/secretdata.proto\"\xe9\x06\n" +
+	"\x1dencore/runtime/v1/infra.proto\x12\x11encore.runtime.v1\x1a\"encore/runtime/v1/secretdata.proto\"\xff\a\n" +
 	"\x0eInfrastructure\x12I\n" +
 	"\tresources\x18\x01 \x01(\v2+.encore.runtime.v1.Infrastructure.ResourcesR\tresources\x12O\n" +
 	"\vcredentials\x18\x02 \x01(\v2-.encore.runtime.v1.Infrastructure.CredentialsR\vcredentials\x1a\xc7\x01\n" +
@@ -2836,7 +3709,7 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\fclient_certs\x18\x01 \x03(\v2\x1d.encore.runtime.v1.ClientCertR\vclientCerts\x127\n" +
 	"\tsql_roles\x18\x02 \x03(\v2\x1a.encore.runtime.v1.SQLRoleR\bsqlRoles\x12=\n" +
 	"\vredis_roles\x18\x03 \x03(\v2\x1c.encore.runtime.v1.RedisRoleR\n" +
-	"redisRoles\x1a\xf0\x03\n" +
+	"redisRoles\x1a\x86\x05\n" +
 	"\tResources\x126\n" +
 	"\bgateways\x18\x01 \x03(\v2\x1a.encore.runtime.v1.GatewayR\bgateways\x12@\n" +
 	"\fsql_clusters\x18\x02 \x03(\v2\x1d.encore.runtime.v1.SQLClusterR\vsqlClusters\x12I\n" +
@@ -2845,7 +3718,9 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\vapp_secrets\x18\x05 \x03(\v2\x1c.encore.runtime.v1.AppSecretR\n" +
 	"appSecrets\x12I\n" +
 	"\x0fbucket_clusters\x18\x06 \x03(\v2 .encore.runtime.v1.BucketClusterR\x0ebucketClusters\x12L\n" +
-	"\x10secret_providers\x18\a \x03(\v2!.encore.runtime.v1.SecretProviderR\x0fsecretProviders\"\xcf\x01\n" +
+	"\x10secret_providers\x18\a \x03(\v2!.encore.runtime.v1.SecretProviderR\x0fsecretProviders\x12I\n" +
+	"\x0fsearch_clusters\x18\b \x03(\v2 .encore.runtime.v1.SearchClusterR\x0esearchClusters\x12I\n" +
+	"\x0fencryption_keys\x18\t \x03(\v2 .encore.runtime.v1.EncryptionKeyR\x0eencryptionKeys\"\xcf\x01\n" +
 	"\x0eSecretProvider\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
 	"\vencore_name\x18\x02 \x01(\tR\n" +
@@ -2856,12 +3731,13 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tR\tprojectIdB\n" +
 	"\n" +
-	"\bprovider\"\x94\x01\n" +
+	"\bprovider\"\xca\x01\n" +
 	"\n" +
 	"SQLCluster\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x126\n" +
 	"\aservers\x18\x02 \x03(\v2\x1c.encore.runtime.v1.SQLServerR\aservers\x12<\n" +
-	"\tdatabases\x18\x03 \x03(\v2\x1e.encore.runtime.v1.SQLDatabaseR\tdatabases\"\xc8\x01\n" +
+	"\tdatabases\x18\x03 \x03(\v2\x1e.encore.runtime.v1.SQLDatabaseR\tdatabases\x124\n" +
+	"\x06engine\x18\x04 \x01(\x0e2\x1c.encore.runtime.v1.SQLEngineR\x06engine\"\xc8\x01\n" +
 	"\tTLSConfig\x12)\n" +
 	"\x0eserver_ca_cert\x18\x01 \x01(\tH\x00R\fserverCaCert\x88\x01\x01\x12I\n" +
 	"!disable_tls_hostname_verification\x18\x02 \x01(\bR\x1edisableTlsHostnameVerification\x122\n" +
@@ -3038,14 +3914,78 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"key_prefix\x18\x04 \x01(\tH\x00R\tkeyPrefix\x88\x01\x01\x12+\n" +
 	"\x0fpublic_base_url\x18\x05 \x01(\tH\x01R\rpublicBaseUrl\x88\x01\x01B\r\n" +
 	"\v_key_prefixB\x12\n" +
-	"\x10_public_base_url\"\xb9\x06\n" +
+	"\x10_public_base_url\"\xff\x04\n" +
+	"\rSearchCluster\x12\x10\n" +
+	"\x03rid\x18\x01 \x01(\tR\x03rid\x128\n" +
+	"\aindexes\x18\x02 \x03(\v2\x1e.encore.runtime.v1.SearchIndexR\aindexes\x12G\n" +
+	"\bpostgres\x18\n" +
+	" \x01(\v2).encore.runtime.v1.SearchCluster.PostgresH\x00R\bpostgres\x12M\n" +
+	"\n" +
+	"opensearch\x18\v \x01(\v2+.encore.runtime.v1.SearchCluster.OpenSearchH\x00R\n" +
+	"opensearch\x12P\n" +
+	"\vmeilisearch\x18\f \x01(\v2,.encore.runtime.v1.SearchCluster.MeilisearchH\x00R\vmeilisearch\x1a&\n" +
+	"\bPostgres\x12\x1a\n" +
+	"\bdatabase\x18\x01 \x01(\tR\bdatabase\x1a\x99\x01\n" +
+	"\n" +
+	"OpenSearch\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12>\n" +
+	"\bpassword\x18\x03 \x01(\v2\x1d.encore.runtime.v1.SecretDataH\x01R\bpassword\x88\x01\x01B\v\n" +
+	"\t_usernameB\v\n" +
+	"\t_password\x1ah\n" +
+	"\vMeilisearch\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12;\n" +
+	"\aapi_key\x18\x02 \x01(\v2\x1d.encore.runtime.v1.SecretDataH\x00R\x06apiKey\x88\x01\x01B\n" +
+	"\n" +
+	"\b_api_keyB\n" +
+	"\n" +
+	"\bprovider\"_\n" +
+	"\vSearchIndex\x12\x10\n" +
+	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
+	"\vencore_name\x18\x02 \x01(\tR\n" +
+	"encoreName\x12\x1d\n" +
+	"\n" +
+	"cloud_name\x18\x03 \x01(\tR\tcloudName\"\xf7\x02\n" +
+	"\rEncryptionKey\x12\x10\n" +
+	"\x03rid\x18\x01 \x01(\tR\x03rid\x12>\n" +
+	"\x05local\x18\n" +
+	" \x01(\v2&.encore.runtime.v1.EncryptionKey.LocalH\x00R\x05local\x12B\n" +
+	"\aaws_kms\x18\v \x01(\v2'.encore.runtime.v1.EncryptionKey.AWSKMSH\x00R\x06awsKms\x12B\n" +
+	"\agcp_kms\x18\f \x01(\v2'.encore.runtime.v1.EncryptionKey.GCPKMSH\x00R\x06gcpKms\x1a8\n" +
+	"\x05Local\x12/\n" +
+	"\x03key\x18\x01 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\x03key\x1a!\n" +
+	"\x06AWSKMS\x12\x17\n" +
+	"\akey_arn\x18\x01 \x01(\tR\x06keyArn\x1a#\n" +
+	"\x06GCPKMS\x12\x19\n" +
+	"\bkey_name\x18\x01 \x01(\tR\akeyNameB\n" +
+	"\n" +
+	"\bprovider\"\xa0\r\n" +
 	"\aGateway\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
 	"\vencore_name\x18\x02 \x01(\tR\n" +
 	"encoreName\x12\x19\n" +
 	"\bbase_url\x18\x03 \x01(\tR\abaseUrl\x12\x1c\n" +
 	"\thostnames\x18\x04 \x03(\tR\thostnames\x123\n" +
-	"\x04cors\x18\x05 \x01(\v2\x1f.encore.runtime.v1.Gateway.CORSR\x04cors\x1a\xcd\x04\n" +
+	"\x04cors\x18\x05 \x01(\v2\x1f.encore.runtime.v1.Gateway.CORSR\x04cors\x12D\n" +
+	"\n" +
+	"transforms\x18\x06 \x03(\v2$.encore.runtime.v1.Gateway.TransformR\n" +
+	"transforms\x12R\n" +
+	"\x0fclient_metadata\x18\a \x01(\v2).encore.runtime.v1.Gateway.ClientMetadataR\x0eclientMetadata\x1aa\n" +
+	"\x0eClientMetadata\x12%\n" +
+	"\x0egeoip_database\x18\x01 \x01(\tR\rgeoipDatabase\x12(\n" +
+	"\x10client_ip_header\x18\x02 \x01(\tR\x0eclientIpHeader\x1a\xf7\x03\n" +
+	"\tTransform\x12\x1c\n" +
+	"\tendpoints\x18\x01 \x03(\tR\tendpoints\x12a\n" +
+	"\x0frequest_headers\x18\x02 \x03(\v28.encore.runtime.v1.Gateway.Transform.RequestHeadersEntryR\x0erequestHeaders\x12d\n" +
+	"\x10response_headers\x18\x03 \x03(\v29.encore.runtime.v1.Gateway.Transform.ResponseHeadersEntryR\x0fresponseHeaders\x122\n" +
+	"\x15strip_response_fields\x18\x04 \x03(\tR\x13stripResponseFields\x12H\n" +
+	"\vcompression\x18\x05 \x03(\x0e2&.encore.runtime.v1.Gateway.CompressionR\vcompression\x1aA\n" +
+	"\x13RequestHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aB\n" +
+	"\x14ResponseHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a\xcd\x04\n" +
 	"\x04CORS\x12\x14\n" +
 	"\x05debug\x18\x01 \x01(\bR\x05debug\x12/\n" +
 	"\x13disable_credentials\x18\x02 \x01(\bR\x12disableCredentials\x12X\n" +
@@ -3057,7 +3997,15 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\x1callow_private_network_access\x18\b \x01(\bR\x19allowPrivateNetworkAccessB\"\n" +
 	" allowed_origins_with_credentials\x1a=\n" +
 	"\x12CORSAllowedOrigins\x12'\n" +
-	"\x0fallowed_origins\x18\x01 \x03(\tR\x0eallowedOrigins*}\n" +
+	"\x0fallowed_origins\x18\x01 \x03(\tR\x0eallowedOrigins\"n\n" +
+	"\vCompression\x12\x1b\n" +
+	"\x17COMPRESSION_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10COMPRESSION_GZIP\x10\x01\x12\x16\n" +
+	"\x12COMPRESSION_BROTLI\x10\x02\x12\x14\n" +
+	"\x10COMPRESSION_ZSTD\x10\x03*:\n" +
+	"\tSQLEngine\x12\x17\n" +
+	"\x13SQL_ENGINE_POSTGRES\x10\x00\x12\x14\n" +
+	"\x10SQL_ENGINE_MYSQL\x10\x01*}\n" +
 	"\n" +
 	"ServerKind\x12\x1b\n" +
 	"\x17SERVER_KIND_UNSPECIFIED\x10\x00\x12\x17\n" +
@@ -3077,103 +4025,136 @@ func file_encore_runtime_v1_infra_proto_rawDescGZIP() []byte {
 	return file_encore_runtime_v1_infra_proto_rawDescData
 }
 
-var file_encore_runtime_v1_infra_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_encore_runtime_v1_infra_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_encore_runtime_v1_infra_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_encore_runtime_v1_infra_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_encore_runtime_v1_infra_proto_goTypes = []any{
-	(ServerKind)(0),                            // 0: encore.runtime.v1.ServerKind
-	(PubSubTopic_DeliveryGuarantee)(0),         // 1: encore.runtime.v1.PubSubTopic.DeliveryGuarantee
-	(*Infrastructure)(nil),                     // 2: encore.runtime.v1.Infrastructure
-	(*SecretProvider)(nil),                     // 3: encore.runtime.v1.SecretProvider
-	(*SQLCluster)(nil),                         // 4: encore.runtime.v1.SQLCluster
-	(*TLSConfig)(nil),                          // 5: encore.runtime.v1.TLSConfig
-	(*SQLServer)(nil),                          // 6: encore.runtime.v1.SQLServer
-	(*ClientCert)(nil),                         // 7: encore.runtime.v1.ClientCert
-	(*SQLRole)(nil),                            // 8: encore.runtime.v1.SQLRole
-	(*SQLDatabase)(nil),                        // 9: encore.runtime.v1.SQLDatabase
-	(*SQLConnectionPool)(nil),                  // 10: encore.runtime.v1.SQLConnectionPool
-	(*RedisCluster)(nil),                       // 11: encore.runtime.v1.RedisCluster
-	(*RedisServer)(nil),                        // 12: encore.runtime.v1.RedisServer
-	(*RedisConnectionPool)(nil),                // 13: encore.runtime.v1.RedisConnectionPool
-	(*RedisRole)(nil),                          // 14: encore.runtime.v1.RedisRole
-	(*RedisDatabase)(nil),                      // 15: encore.runtime.v1.RedisDatabase
-	(*AppSecret)(nil),                          // 16: encore.runtime.v1.AppSecret
-	(*PubSubCluster)(nil),                      // 17: encore.runtime.v1.PubSubCluster
-	(*PubSubTopic)(nil),                        // 18: encore.runtime.v1.PubSubTopic
-	(*PubSubSubscription)(nil),                 // 19: encore.runtime.v1.PubSubSubscription
-	(*BucketCluster)(nil),                      // 20: encore.runtime.v1.BucketCluster
-	(*Bucket)(nil),                             // 21: encore.runtime.v1.Bucket
-	(*Gateway)(nil),                            // 22: encore.runtime.v1.Gateway
-	(*Infrastructure_Credentials)(nil),         // 23: encore.runtime.v1.Infrastructure.Credentials
-	(*Infrastructure_Resources)(nil),           // 24: encore.runtime.v1.Infrastructure.Resources
-	(*SecretProvider_GCPSecretManager)(nil),    // 25: encore.runtime.v1.SecretProvider.GCPSecretManager
-	(*RedisRole_AuthACL)(nil),                  // 26: encore.runtime.v1.RedisRole.AuthACL
-	(*PubSubCluster_EncoreCloud)(nil),          // 27: encore.runtime.v1.PubSubCluster.EncoreCloud
-	(*PubSubCluster_AWSSqsSns)(nil),            // 28: encore.runtime.v1.PubSubCluster.AWSSqsSns
-	(*PubSubCluster_GCPPubSub)(nil),            // 29: encore.runtime.v1.PubSubCluster.GCPPubSub
-	(*PubSubCluster_NSQ)(nil),                  // 30: encore.runtime.v1.PubSubCluster.NSQ
-	(*PubSubCluster_AzureServiceBus)(nil),      // 31: encore.runtime.v1.PubSubCluster.AzureServiceBus
-	(*PubSubTopic_GCPConfig)(nil),              // 32: encore.runtime.v1.PubSubTopic.GCPConfig
-	(*PubSubSubscription_GCPConfig)(nil),       // 33: encore.runtime.v1.PubSubSubscription.GCPConfig
-	(*BucketCluster_S3)(nil),                   // 34: encore.runtime.v1.BucketCluster.S3
-	(*BucketCluster_GCS)(nil),                  // 35: encore.runtime.v1.BucketCluster.GCS
-	(*BucketCluster_GCS_LocalSignOptions)(nil), // 36: encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
-	(*Gateway_CORS)(nil),                       // 37: encore.runtime.v1.Gateway.CORS
-	(*Gateway_CORSAllowedOrigins)(nil),         // 38: encore.runtime.v1.Gateway.CORSAllowedOrigins
-	(*SecretData)(nil),                         // 39: encore.runtime.v1.SecretData
+	(SQLEngine)(0),                             // 0: encore.runtime.v1.SQLEngine
+	(ServerKind)(0),                            // 1: encore.runtime.v1.ServerKind
+	(PubSubTopic_DeliveryGuarantee)(0),         // 2: encore.runtime.v1.PubSubTopic.DeliveryGuarantee
+	(Gateway_Compression)(0),                   // 3: encore.runtime.v1.Gateway.Compression
+	(*Infrastructure)(nil),                     // 4: encore.runtime.v1.Infrastructure
+	(*SecretProvider)(nil),                     // 5: encore.runtime.v1.SecretProvider
+	(*SQLCluster)(nil),                         // 6: encore.runtime.v1.SQLCluster
+	(*TLSConfig)(nil),                          // 7: encore.runtime.v1.TLSConfig
+	(*SQLServer)(nil),                          // 8: encore.runtime.v1.SQLServer
+	(*ClientCert)(nil),                         // 9: encore.runtime.v1.ClientCert
+	(*SQLRole)(nil),                            // 10: encore.runtime.v1.SQLRole
+	(*SQLDatabase)(nil),                        // 11: encore.runtime.v1.SQLDatabase
+	(*SQLConnectionPool)(nil),                  // 12: encore.runtime.v1.SQLConnectionPool
+	(*RedisCluster)(nil),                       // 13: encore.runtime.v1.RedisCluster
+	(*RedisServer)(nil),                        // 14: encore.runtime.v1.RedisServer
+	(*RedisConnectionPool)(nil),                // 15: encore.runtime.v1.RedisConnectionPool
+	(*RedisRole)(nil),                          // 16: encore.runtime.v1.RedisRole
+	(*RedisDatabase)(nil),                      // 17: encore.runtime.v1.RedisDatabase
+	(*AppSecret)(nil),                          // 18: encore.runtime.v1.AppSecret
+	(*PubSubCluster)(nil),                      // 19: encore.runtime.v1.PubSubCluster
+	(*PubSubTopic)(nil),                        // 20: encore.runtime.v1.PubSubTopic
+	(*PubSubSubscription)(nil),                 // 21: encore.runtime.v1.PubSubSubscription
+	(*BucketCluster)(nil),                      // 22: encore.runtime.v1.BucketCluster
+	(*Bucket)(nil),                             // 23: encore.runtime.v1.Bucket
+	(*SearchCluster)(nil),                      // 24: encore.runtime.v1.SearchCluster
+	(*SearchIndex)(nil),                        // 25: encore.runtime.v1.SearchIndex
+	(*EncryptionKey)(nil),                      // 26: encore.runtime.v1.EncryptionKey
+	(*Gateway)(nil),                            // 27: encore.runtime.v1.Gateway
+	(*Infrastructure_Credentials)(nil),         // 28: encore.runtime.v1.Infrastructure.Credentials
+	(*Infrastructure_Resources)(nil),           // 29: encore.runtime.v1.Infrastructure.Resources
+	(*SecretProvider_GCPSecretManager)(nil),    // 30: encore.runtime.v1.SecretProvider.GCPSecretManager
+	(*RedisRole_AuthACL)(nil),                  // 31: encore.runtime.v1.RedisRole.AuthACL
+	(*PubSubCluster_EncoreCloud)(nil),          // 32: encore.runtime.v1.PubSubCluster.EncoreCloud
+	(*PubSubCluster_AWSSqsSns)(nil),            // 33: encore.runtime.v1.PubSubCluster.AWSSqsSns
+	(*PubSubCluster_GCPPubSub)(nil),            // 34: encore.runtime.v1.PubSubCluster.GCPPubSub
+	(*PubSubCluster_NSQ)(nil),                  // 35: encore.runtime.v1.PubSubCluster.NSQ
+	(*PubSubCluster_AzureServiceBus)(nil),      // 36: encore.runtime.v1.PubSubCluster.AzureServiceBus
+	(*PubSubTopic_GCPConfig)(nil),              // 37: encore.runtime.v1.PubSubTopic.GCPConfig
+	(*PubSubSubscription_GCPConfig)(nil),       // 38: encore.runtime.v1.PubSubSubscription.GCPConfig
+	(*BucketCluster_S3)(nil),                   // 39: encore.runtime.v1.BucketCluster.S3
+	(*BucketCluster_GCS)(nil),                  // 40: encore.runtime.v1.BucketCluster.GCS
+	(*BucketCluster_GCS_LocalSignOptions)(nil), // 41: encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
+	(*SearchCluster_Postgres)(nil),             // 42: encore.runtime.v1.SearchCluster.Postgres
+	(*SearchCluster_OpenSearch)(nil),           // 43: encore.runtime.v1.SearchCluster.OpenSearch
+	(*SearchCluster_Meilisearch)(nil),          // 44: encore.runtime.v1.SearchCluster.Meilisearch
+	(*EncryptionKey_Local)(nil),                // 45: encore.runtime.v1.EncryptionKey.Local
+	(*EncryptionKey_AWSKMS)(nil),               // 46: encore.runtime.v1.EncryptionKey.AWSKMS
+	(*EncryptionKey_GCPKMS)(nil),               // 47: encore.runtime.v1.EncryptionKey.GCPKMS
+	(*Gateway_ClientMetadata)(nil),             // 48: encore.runtime.v1.Gateway.ClientMetadata
+	(*Gateway_Transform)(nil),                  // 49: encore.runtime.v1.Gateway.Transform
+	(*Gateway_CORS)(nil),                       // 50: encore.runtime.v1.Gateway.CORS
+	(*Gateway_CORSAllowedOrigins)(nil),         // 51: encore.runtime.v1.Gateway.CORSAllowedOrigins
+	nil,                                        // 52: encore.runtime.v1.Gateway.Transform.RequestHeadersEntry
+	nil,                                        // 53: encore.runtime.v1.Gateway.Transform.ResponseHeadersEntry
+	(*SecretData)(nil),                         // 54: encore.runtime.v1.SecretData
 }
 var file_encore_runtime_v1_infra_proto_depIdxs = []int32{
-	24, // 0: encore.runtime.v1.Infrastructure.resources:type_name -> encore.runtime.v1.Infrastructure.Resources
-	23, // 1: encore.runtime.v1.Infrastructure.credentials:type_name -> encore.runtime.v1.Infrastructure.Credentials
-	25, // 2: encore.runtime.v1.SecretProvider.gcp_sm:type_name -> encore.runtime.v1.SecretProvider.GCPSecretManager
-	6,  // 3: encore.runtime.v1.SQLCluster.servers:type_name -> encore.runtime.v1.SQLServer
-	9,  // 4: encore.runtime.v1.SQLCluster.databases:type_name -> encore.runtime.v1.SQLDatabase
-	0,  // 5: encore.runtime.v1.SQLServer.kind:type_name -> encore.runtime.v1.ServerKind
-	5,  // 6: encore.runtime.v1.SQLServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
-	39, // 7: encore.runtime.v1.ClientCert.key:type_name -> encore.runtime.v1.SecretData
-	39, // 8: encore.runtime.v1.SQLRole.password:type_name -> encore.runtime.v1.SecretData
-	10, // 9: encore.runtime.v1.SQLDatabase.conn_pools:type_name -> encore.runtime.v1.SQLConnectionPool
-	12, // 10: encore.runtime.v1.RedisCluster.servers:type_name -> encore.runtime.v1.RedisServer
-	15, // 11: encore.runtime.v1.RedisCluster.databases:type_name -> encore.runtime.v1.RedisDatabase
-	0,  // 12: encore.runtime.v1.RedisServer.kind:type_name -> encore.runtime.v1.ServerKind
-	5,  // 13: encore.runtime.v1.RedisServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
-	26, // 14: encore.runtime.v1.RedisRole.acl:type_name -> encore.runtime.v1.RedisRole.AuthACL
-	39, // 15: encore.runtime.v1.RedisRole.auth_string:type_name -> encore.runtime.v1.SecretData
-	13, // 16: encore.runtime.v1.RedisDatabase.conn_pools:type_name -> encore.runtime.v1.RedisConnectionPool
-	39, // 17: encore.runtime.v1.AppSecret.data:type_name -> encore.runtime.v1.SecretData
-	18, // 18: encore.runtime.v1.PubSubCluster.topics:type_name -> encore.runtime.v1.PubSubTopic
-	19, // 19: encore.runtime.v1.PubSubCluster.subscriptions:type_name -> encore.runtime.v1.PubSubSubscription
-	27, // 20: encore.runtime.v1.PubSubCluster.encore:type_name -> encore.runtime.v1.PubSubCluster.EncoreCloud
-	28, // 21: encore.runtime.v1.PubSubCluster.aws:type_name -> encore.runtime.v1.PubSubCluster.AWSSqsSns
-	29, // 22: encore.runtime.v1.PubSubCluster.gcp:type_name -> encore.runtime.v1.PubSubCluster.GCPPubSub
-	31, // 23: encore.runtime.v1.PubSubCluster.azure:type_name -> encore.runtime.v1.PubSubCluster.AzureServiceBus
-	30, // 24: encore.runtime.v1.PubSubCluster.nsq:type_name -> encore.runtime.v1.PubSubCluster.NSQ
-	1,  // 25: encore.runtime.v1.PubSubTopic.delivery_guarantee:type_name -> encore.runtime.v1.PubSubTopic.DeliveryGuarantee
-	32, // 26: encore.runtime.v1.PubSubTopic.gcp_config:type_name -> encore.runtime.v1.PubSubTopic.GCPConfig
-	33, // 27: encore.runtime.v1.PubSubSubscription.gcp_config:type_name -> encore.runtime.v1.PubSubSubscription.GCPConfig
-	21, // 28: encore.runtime.v1.BucketCluster.buckets:type_name -> encore.runtime.v1.Bucket
-	34, // 29: encore.runtime.v1.BucketCluster.s3:type_name -> encore.runtime.v1.BucketCluster.S3
-	35, // 30: encore.runtime.v1.BucketCluster.gcs:type_name -> encore.runtime.v1.BucketCluster.GCS
-	37, // 31: encore.runtime.v1.Gateway.cors:type_name -> encore.runtime.v1.Gateway.CORS
-	7,  // 32: encore.runtime.v1.Infrastructure.Credentials.client_certs:type_name -> encore.runtime.v1.ClientCert
-	8,  // 33: encore.runtime.v1.Infrastructure.Credentials.sql_roles:type_name -> encore.runtime.v1.SQLRole
-	14, // 34: encore.runtime.v1.Infrastructure.Credentials.redis_roles:type_name -> encore.runtime.v1.RedisRole
-	22, // 35: encore.runtime.v1.Infrastructure.Resources.gateways:type_name -> encore.runtime.v1.Gateway
-	4,  // 36: encore.runtime.v1.Infrastructure.Resources.sql_clusters:type_name -> encore.runtime.v1.SQLCluster
-	17, // 37: encore.runtime.v1.Infrastructure.Resources.pubsub_clusters:type_name -> encore.runtime.v1.PubSubCluster
-	11, // 38: encore.runtime.v1.Infrastructure.Resources.redis_clusters:type_name -> encore.runtime.v1.RedisCluster
-	16, // 39: encore.runtime.v1.Infrastructure.Resources.app_secrets:type_name -> encore.runtime.v1.AppSecret
-	20, // 40: encore.runtime.v1.Infrastructure.Resources.bucket_clusters:type_name -> encore.runtime.v1.BucketCluster
-	3,  // 41: encore.runtime.v1.Infrastructure.Resources.secret_providers:type_name -> encore.runtime.v1.SecretProvider
-	39, // 42: encore.runtime.v1.RedisRole.AuthACL.password:type_name -> encore.runtime.v1.SecretData
-	39, // 43: encore.runtime.v1.BucketCluster.S3.secret_access_key:type_name -> encore.runtime.v1.SecretData
-	36, // 44: encore.runtime.v1.BucketCluster.GCS.local_sign:type_name -> encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
-	38, // 45: encore.runtime.v1.Gateway.CORS.allowed_origins:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
-	38, // 46: encore.runtime.v1.Gateway.CORS.allowed_origins_without_credentials:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
-	47, // [47:47] is the sub-list for method output_type
-	47, // [47:47] is the sub-list for method input_type
-	47, // [47:47] is the sub-list for extension type_name
-	47, // [47:47] is the sub-list for extension extendee
-	0,  // [0:47] is the sub-list for field type_name
+	29, // 0: encore.runtime.v1.Infrastructure.resources:type_name -> encore.runtime.v1.Infrastructure.Resources
+	28, // 1: encore.runtime.v1.Infrastructure.credentials:type_name -> encore.runtime.v1.Infrastructure.Credentials
+	30, // 2: encore.runtime.v1.SecretProvider.gcp_sm:type_name -> encore.runtime.v1.SecretProvider.GCPSecretManager
+	8,  // 3: encore.runtime.v1.SQLCluster.servers:type_name -> encore.runtime.v1.SQLServer
+	11, // 4: encore.runtime.v1.SQLCluster.databases:type_name -> encore.runtime.v1.SQLDatabase
+	0,  // 5: encore.runtime.v1.SQLCluster.engine:type_name -> encore.runtime.v1.SQLEngine
+	1,  // 6: encore.runtime.v1.SQLServer.kind:type_name -> encore.runtime.v1.ServerKind
+	7,  // 7: encore.runtime.v1.SQLServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
+	54, // 8: encore.runtime.v1.ClientCert.key:type_name -> encore.runtime.v1.SecretData
+	54, // 9: encore.runtime.v1.SQLRole.password:type_name -> encore.runtime.v1.SecretData
+	12, // 10: encore.runtime.v1.SQLDatabase.conn_pools:type_name -> encore.runtime.v1.SQLConnectionPool
+	14, // 11: encore.runtime.v1.RedisCluster.servers:type_name -> encore.runtime.v1.RedisServer
+	17, // 12: encore.runtime.v1.RedisCluster.databases:type_name -> encore.runtime.v1.RedisDatabase
+	1,  // 13: encore.runtime.v1.RedisServer.kind:type_name -> encore.runtime.v1.ServerKind
+	7,  // 14: encore.runtime.v1.RedisServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
+	31, // 15: encore.runtime.v1.RedisRole.acl:type_name -> encore.runtime.v1.RedisRole.AuthACL
+	54, // 16: encore.runtime.v1.RedisRole.auth_string:type_name -> encore.runtime.v1.SecretData
+	15, // 17: encore.runtime.v1.RedisDatabase.conn_pools:type_name -> encore.runtime.v1.RedisConnectionPool
+	54, // 18: encore.runtime.v1.AppSecret.data:type_name -> encore.runtime.v1.SecretData
+	20, // 19: encore.runtime.v1.PubSubCluster.topics:type_name -> encore.runtime.v1.PubSubTopic
+	21, // 20: encore.runtime.v1.PubSubCluster.subscriptions:type_name -> encore.runtime.v1.PubSubSubscription
+	32, // 21: encore.runtime.v1.PubSubCluster.encore:type_name -> encore.runtime.v1.PubSubCluster.EncoreCloud
+	33, // 22: encore.runtime.v1.PubSubCluster.aws:type_name -> encore.runtime.v1.PubSubCluster.AWSSqsSns
+	34, // 23: encore.runtime.v1.PubSubCluster.gcp:type_name -> encore.runtime.v1.PubSubCluster.GCPPubSub
+	36, // 24: encore.runtime.v1.PubSubCluster.azure:type_name -> encore.runtime.v1.PubSubCluster.AzureServiceBus
+	35, // 25: encore.runtime.v1.PubSubCluster.nsq:type_name -> encore.runtime.v1.PubSubCluster.NSQ
+	2,  // 26: encore.runtime.v1.PubSubTopic.delivery_guarantee:type_name -> encore.runtime.v1.PubSubTopic.DeliveryGuarantee
+	37, // 27: encore.runtime.v1.PubSubTopic.gcp_config:type_name -> encore.runtime.v1.PubSubTopic.GCPConfig
+	38, // 28: encore.runtime.v1.PubSubSubscription.gcp_config:type_name -> encore.runtime.v1.PubSubSubscription.GCPConfig
+	23, // 29: encore.runtime.v1.BucketCluster.buckets:type_name -> encore.runtime.v1.Bucket
+	39, // 30: encore.runtime.v1.BucketCluster.s3:type_name -> encore.runtime.v1.BucketCluster.S3
+	40, // 31: encore.runtime.v1.BucketCluster.gcs:type_name -> encore.runtime.v1.BucketCluster.GCS
+	25, // 32: encore.runtime.v1.SearchCluster.indexes:type_name -> encore.runtime.v1.SearchIndex
+	42, // 33: encore.runtime.v1.SearchCluster.postgres:type_name -> encore.runtime.v1.SearchCluster.Postgres
+	43, // 34: encore.runtime.v1.SearchCluster.opensearch:type_name -> encore.runtime.v1.SearchCluster.OpenSearch
+	44, // 35: encore.runtime.v1.SearchCluster.meilisearch:type_name -> encore.runtime.v1.SearchCluster.Meilisearch
+	45, // 36: encore.runtime.v1.EncryptionKey.local:type_name -> encore.runtime.v1.EncryptionKey.Local
+	46, // 37: encore.runtime.v1.EncryptionKey.aws_kms:type_name -> encore.runtime.v1.EncryptionKey.AWSKMS
+	47, // 38: encore.runtime.v1.EncryptionKey.gcp_kms:type_name -> encore.runtime.v1.EncryptionKey.GCPKMS
+	50, // 39: encore.runtime.v1.Gateway.cors:type_name -> encore.runtime.v1.Gateway.CORS
+	49, // 40: encore.runtime.v1.Gateway.transforms:type_name -> encore.runtime.v1.Gateway.Transform
+	48, // 41: encore.runtime.v1.Gateway.client_metadata:type_name -> encore.runtime.v1.Gateway.ClientMetadata
+	9,  // 42: encore.runtime.v1.Infrastructure.Credentials.client_certs:type_name -> encore.runtime.v1.ClientCert
+	10, // 43: encore.runtime.v1.Infrastructure.Credentials.sql_roles:type_name -> encore.runtime.v1.SQLRole
+	16, // 44: encore.runtime.v1.Infrastructure.Credentials.redis_roles:type_name -> encore.runtime.v1.RedisRole
+	27, // 45: encore.runtime.v1.Infrastructure.Resources.gateways:type_name -> encore.runtime.v1.Gateway
+	6,  // 46: encore.runtime.v1.Infrastructure.Resources.sql_clusters:type_name -> encore.runtime.v1.SQLCluster
+	19, // 47: encore.runtime.v1.Infrastructure.Resources.pubsub_clusters:type_name -> encore.runtime.v1.PubSubCluster
+	13, // 48: encore.runtime.v1.Infrastructure.Resources.redis_clusters:type_name -> encore.runtime.v1.RedisCluster
+	18, // 49: encore.runtime.v1.Infrastructure.Resources.app_secrets:type_name -> encore.runtime.v1.AppSecret
+	22, // 50: encore.runtime.v1.Infrastructure.Resources.bucket_clusters:type_name -> encore.runtime.v1.BucketCluster
+	5,  // 51: encore.runtime.v1.Infrastructure.Resources.secret_providers:type_name -> encore.runtime.v1.SecretProvider
+	24, // 52: encore.runtime.v1.Infrastructure.Resources.search_clusters:type_name -> encore.runtime.v1.SearchCluster
+	26, // 53: encore.runtime.v1.Infrastructure.Resources.encryption_keys:type_name -> encore.runtime.v1.EncryptionKey
+	54, // 54: encore.runtime.v1.RedisRole.AuthACL.password:type_name -> encore.runtime.v1.SecretData
+	54, // 55: encore.runtime.v1.BucketCluster.S3.secret_access_key:type_name -> encore.runtime.v1.SecretData
+	41, // 56: encore.runtime.v1.BucketCluster.GCS.local_sign:type_name -> encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
+	54, // 57: encore.runtime.v1.SearchCluster.OpenSearch.password:type_name -> encore.runtime.v1.SecretData
+	54, // 58: encore.runtime.v1.SearchCluster.Meilisearch.api_key:type_name -> encore.runtime.v1.SecretData
+	54, // 59: encore.runtime.v1.EncryptionKey.Local.key:type_name -> encore.runtime.v1.SecretData
+	52, // 60: encore.runtime.v1.Gateway.Transform.request_headers:type_name -> encore.runtime.v1.Gateway.Transform.RequestHeadersEntry
+	53, // 61: encore.runtime.v1.Gateway.Transform.response_headers:type_name -> encore.runtime.v1.Gateway.Transform.ResponseHeadersEntry
+	3,  // 62: encore.runtime.v1.Gateway.Transform.compression:type_name -> encore.runtime.v1.Gateway.Compression
+	51, // 63: encore.runtime.v1.Gateway.CORS.allowed_origins:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
+	51, // 64: encore.runtime.v1.Gateway.CORS.allowed_origins_without_credentials:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
+	65, // [65:65] is the sub-list for method output_type
+	65, // [65:65] is the sub-list for method input_type
+	65, // [65:65] is the sub-list for extension type_name
+	65, // [65:65] is the sub-list for extension extendee
+	0,  // [0:65] is the sub-list for field type_name
 }
 
 func init() { file_encore_runtime_v1_infra_proto_init() }
@@ -3212,10 +4193,22 @@ func file_encore_runtime_v1_infra_proto_init() {
 		(*BucketCluster_Gcs)(nil),
 	}
 	file_encore_runtime_v1_infra_proto_msgTypes[19].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[31].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[32].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[33].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[35].OneofWrappers = []any{
+	file_encore_runtime_v1_infra_proto_msgTypes[20].OneofWrappers = []any{
+		(*SearchCluster_Postgres_)(nil),
+		(*SearchCluster_Opensearch)(nil),
+		(*SearchCluster_Meilisearch_)(nil),
+	}
+	file_encore_runtime_v1_infra_proto_msgTypes[22].OneofWrappers = []any{
+		(*EncryptionKey_Local_)(nil),
+		(*EncryptionKey_AwsKms)(nil),
+		(*EncryptionKey_GcpKms)(nil),
+	}
+	file_encore_runtime_v1_infra_proto_msgTypes[34].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[35].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[36].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[39].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[40].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[46].OneofWrappers = []any{
 		(*Gateway_CORS_AllowedOrigins)(nil),
 		(*Gateway_CORS_UnsafeAllowAllOriginsWithCredentials)(nil),
 	}
@@ -3224,8 +4217,8 @@ func file_encore_runtime_v1_infra_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_runtime_v1_infra_proto_rawDesc), len(file_encore_runtime_v1_infra_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   37,
+			NumEnums:      4,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -212,7 +212,7 @@ impl BuilderCtx<'_, '_> {
     fn builtin(&mut self, b: schema::Builtin) -> Value {
         use schema::Builtin;
         Value::Basic(match b {
-            Builtin::Any | Builtin::Json => Basic::Any,
+            Builtin::Any | Builtin::Json | Builtin::File => Basic::Any,
             Builtin::Bool => Basic::Bool,
             Builtin::String | Builtin::Bytes | Builtin::Uuid | Builtin::UserId => Basic::String,
             Builtin::Time => Basic::DateTime,
@@ -144,6 +144,7 @@ impl EncodingConfig<'_, '_> {
                     WireLoc::Header(hdr.name.as_ref().unwrap_or(&f.name).clone())
                 }
                 Some(schema::wire_spec::Location::Query(_)) => WireLoc::Query,
+                Some(schema::wire_spec::Location::Form(_)) => WireLoc::Body,
                 Some(schema::wire_spec::Location::Cookie(c)) => {
                     WireLoc::Cookie(c.name.as_ref().unwrap_or(&f.name).clone())
                 }
@@ -28,6 +28,24 @@ pub struct InfraConfig {
     pub object_storage: Option<Vec<ObjectStorage>>,
     pub worker_threads: Option<i32>,
     pub log_config: Option<String>,
+
+    // Resources the TypeScript runtime doesn't support, which are rejected.
+    pub search: Option<serde_json::Value>,
+    pub encryption_keys: Option<serde_json::Value>,
+}
+
+impl InfraConfig {
+    /// Returns the name of a configured resource the TypeScript runtime
+    /// doesn't support, if any.
+    pub fn unsupported_resource(&self) -> Option<&'static str> {
+        if self.search.is_some() {
+            return Some("search");
+        }
+        if self.encryption_keys.is_some() {
+            return Some("encryption_keys");
+        }
+        None
+    }
 }
 
 #[derive(Debug, Serialize, Deserialize)]
@@ -583,6 +601,8 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                     base_url: metadata.base_url.clone().unwrap_or_default(),
                     hostnames: vec![],
                     cors: cors.clone(),
+                    transforms: vec![],
+                    client_metadata: None,
                 })
                 .collect::<Vec<_>>()
         })
@@ -707,6 +727,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                         ),
                     }],
                     databases,
+                    engine: pbruntime::SqlEngine::Postgres as i32,
                 }
             })
             .collect()
@@ -1015,6 +1036,8 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
         app_secrets,
         bucket_clusters: buckets.unwrap_or_default(),
         secret_providers: Vec::new(),
+        search_clusters: Vec::new(),
+        encryption_keys: Vec::new(),
     });
 
     let infra_struct = Some(Infrastructure {
@@ -683,6 +683,12 @@ fn infra_config_from_env() -> Result<Option<runtimepb::RuntimeConfig>, ParseErro
     let file_content = std::fs::read_to_string(cfg_path).map_err(ParseError::IO)?;
     let infra_config: infracfg::InfraConfig = serde_json::from_str(&file_content)
         .map_err(|e| ParseError::IO(std::io::Error::new(std::io::ErrorKind::InvalidData, e)))?;
+    if let Some(resource) = infra_config.unsupported_resource() {
+        return Err(ParseError::IO(std::io::Error::new(
+            std::io::ErrorKind::InvalidData,
+            format!("{resource} is not supported by the Encore TypeScript runtime"),
+        )));
+    }
     let runtime_config = infracfg::map_infra_to_runtime(infra_config);
     Ok(Some(runtime_config))
 }
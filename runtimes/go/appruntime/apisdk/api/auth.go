@@ -77,6 +77,7 @@ func (d *AuthHandlerDesc[Params]) Authenticate(c IncomingContext) (model.AuthInf
 				TypedPayload:       param,
 				RequestHeaders:     c.req.Header,
 				FromEncorePlatform: platformauth.IsEncorePlatformRequest(c.req.Context()),
+				Client:             c.callMeta.Client,
 			},
 			ExtCorrelationID:    clampTo64Chars(c.req.Header.Get("X-Correlation-ID")),
 			AdditionalLogFields: cloudtrace.StructuredLogFields(c.req),
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -49,6 +50,10 @@ type CallMeta struct {
 	CorrelationID string             // The correlation ID of the calling request
 	TraceSampled  bool               // Whether the caller sampled trace info
 
+	// Client is the metadata about the client that made the originating request,
+	// set by the gateway's client metadata enrichment and propagated on internal calls.
+	Client *model.ClientInfo
+
 	// Internal meta data which gets populated by Encore on service to service calls
 	//
 	// If set, the values can be trusted as they would have been authenticated to be correct
@@ -87,6 +92,9 @@ func (s *Server) metaFromAPICall(call *model.APICall) (meta CallMeta, err error)
 		meta.ParentEventID = call.StartEventID
 		meta.CorrelationID = call.Source.ExtCorrelationID
 		meta.TraceSampled = call.Source.Traced
+		if call.Source.RPCData != nil {
+			meta.Client = call.Source.RPCData.Client
+		}
 
 		if call.Source.RPCData != nil && call.Source.RPCData.Desc != nil {
 			// If we're processing an API call, let's update the caller
@@ -178,6 +186,15 @@ func (meta CallMeta) AddToRequest(server *Server, targetService config.Service,
 			}
 		}
 
+		// Propagate the metadata of the client that made the originating request.
+		if meta.Client != nil {
+			clientInfo, err := json.Marshal(meta.Client)
+			if err != nil {
+				return errs.B().Cause(err).Msg("failed to marshal client info").Err()
+			}
+			req.SetMeta("ClientInfo", string(clientInfo))
+		}
+
 		// If we're making an internal call, sign the request
 		targetAuth := server.outboundSvcAuth[targetService.ServiceAuth.Method]
 		if targetAuth == nil {
@@ -238,6 +255,14 @@ func (s *Server) MetaFromRequest(req transport.Transport) (meta CallMeta, err er
 				}
 			}
 		}
+
+		// Only trust client metadata from authenticated internal calls.
+		if data, found := req.ReadMeta("ClientInfo"); found && data != "" {
+			meta.Client = &model.ClientInfo{}
+			if err := json.Unmarshal([]byte(data), meta.Client); err != nil {
+				return CallMeta{}, errs.B().Cause(err).Msg("failed to unmarshal client info").Err()
+			}
+		}
 	}
 
 	// If we were tracing read the trace ID, span ID
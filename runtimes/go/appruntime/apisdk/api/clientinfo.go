@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/clientinfo"
+)
+
+// newClientInfoEnricher creates the enricher for the gateway's client metadata stage.
+// If the GeoIP database cannot be opened, requests are enriched without locations.
+func newClientInfoEnricher(cfg *config.ClientMetadata, logger zerolog.Logger) *clientinfo.Enricher {
+	e, err := clientinfo.New(cfg)
+	if err != nil {
+		logger.Error().Err(err).Str("path", cfg.GeoIPDatabase).Msg("failed to open GeoIP database, client locations will not be determined")
+		e, _ = clientinfo.New(&config.ClientMetadata{ClientIPHeader: cfg.ClientIPHeader})
+	}
+	return e
+}
+
+// enrichClientInfo wraps h to attach metadata about the client making
+// the request to its call metadata, replacing any existing metadata.
+func (s *Server) enrichClientInfo(h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		meta := CallMetaFromContext(req.Context())
+		meta.Client = s.clientInfo.Lookup(req)
+		h(w, req.WithContext(SetCallMetaInContext(req.Context(), meta)), ps)
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/beta/httpcache"
+)
+
+// handleConditionalRequests wraps h to respond with 304 Not Modified to
+// conditional GET and HEAD requests, when the ETag and Last-Modified headers
+// of the response show that the client's cached copy is still fresh.
+func handleConditionalRequests(h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			h(w, req, ps)
+			return
+		}
+		if req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+			h(w, req, ps)
+			return
+		}
+		h(&conditionalWriter{ResponseWriter: w, req: req}, req, ps)
+	}
+}
+
+// conditionalWriter is a http.ResponseWriter that turns
+// responses into 304 Not Modified when appropriate.
+type conditionalWriter struct {
+	http.ResponseWriter
+	req *http.Request
+
+	wroteHeader bool
+	notModified bool
+}
+
+func (w *conditionalWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	hdr := w.Header()
+	if code == http.StatusOK {
+		lastModified, _ := http.ParseTime(hdr.Get("Last-Modified"))
+		if httpcache.NotModified(w.req.Header, hdr.Get("ETag"), lastModified) {
+			w.notModified = true
+			code = http.StatusNotModified
+
+			// Same as net/http: remove headers describing the (omitted) body.
+			hdr.Del("Content-Type")
+			hdr.Del("Content-Length")
+			hdr.Del("Content-Encoding")
+			if hdr.Get("ETag") != "" {
+				hdr.Del("Last-Modified")
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *conditionalWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.notModified {
+		// Discard the body.
+		return len(p), nil
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *conditionalWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
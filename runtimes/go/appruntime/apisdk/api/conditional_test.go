@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestHandleConditionalRequests(t *testing.T) {
+	h := handleConditionalRequests(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version":1}`))
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		ifNoneMatch string
+		wantCode    int
+		wantBody    string
+	}{
+		{"unconditional", "GET", "", http.StatusOK, `{"version":1}`},
+		{"fresh", "GET", `"v1"`, http.StatusNotModified, ""},
+		{"stale", "GET", `"v0"`, http.StatusOK, `{"version":1}`},
+		{"post", "POST", `"v1"`, http.StatusOK, `{"version":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			h(w, req, nil)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("got code %d, want %d", w.Code, tt.wantCode)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("got body %q, want %q", got, tt.wantBody)
+			}
+			if got := w.Header().Get("ETag"); got != `"v1"` {
+				t.Errorf("got ETag %q, want %q", got, `"v1"`)
+			}
+		})
+	}
+}
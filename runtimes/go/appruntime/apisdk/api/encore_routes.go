@@ -1,18 +1,38 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
+	"runtime"
+	"runtime/pprof"
 
 	"github.com/julienschmidt/httprouter"
 
 	"encore.dev/appruntime/shared/jsonapi"
+	"encore.dev/appruntime/shared/startup"
 	"encore.dev/beta/errs"
+	"encore.dev/internal/platformauth"
 )
 
 func (s *Server) registerEncoreRoutes() {
 	s.encore.HandlerFunc(wildcardMethod, "/healthz", s.handleHealthz)
 	s.encore.Handle("POST", "/pubsub/push/:subscription_id", s.handlePubsubPush)
 	s.encore.Handle("POST", "/authhandler", s.handleRemoteAuthCall)
+	s.encore.HandlerFunc("GET", "/debug/goroutines", s.handleGoroutineDump)
+	s.encore.HandlerFunc("GET", "/debug/heap", s.handleHeapProfile)
+	s.registerPlatformRoute("GET", "/debug/startup", s.handleStartupReport)
+}
+
+// registerPlatformRoute registers h for an internal route
+// that is only available to the Encore platform.
+func (s *Server) registerPlatformRoute(method, path string, h http.HandlerFunc) {
+	s.encore.HandlerFunc(method, path, func(w http.ResponseWriter, req *http.Request) {
+		if !platformauth.IsEncorePlatformRequest(req.Context()) {
+			errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("endpoint not found").Err())
+			return
+		}
+		h(w, req)
+	})
 }
 
 // handleHealthz returns the current health and deployment details of the running Encore application
@@ -94,3 +114,38 @@ func (s *Server) handlePubsubPush(w http.ResponseWriter, req *http.Request, ps h
 
 	s.pubsubMgr.HandlePubSubPush(w, req, subscriptionID)
 }
+
+// handleGoroutineDump writes a dump of all goroutines, in the same format as
+// an unrecovered panic. It is only available to the Encore platform (and the
+// local development daemon) as the stacks reveal the inner workings of the app.
+func (s *Server) handleGoroutineDump(w http.ResponseWriter, req *http.Request) {
+	if !platformauth.IsEncorePlatformRequest(req.Context()) {
+		errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("endpoint not found").Err())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_ = pprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+// handleHeapProfile writes a heap profile in the legacy text format, after
+// running a garbage collection so it reflects the live heap. Like goroutine
+// dumps it is only available to the Encore platform (and the local development daemon).
+func (s *Server) handleHeapProfile(w http.ResponseWriter, req *http.Request) {
+	if !platformauth.IsEncorePlatformRequest(req.Context()) {
+		errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("endpoint not found").Err())
+		return
+	}
+
+	runtime.GC()
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_ = pprof.Lookup("heap").WriteTo(w, 1)
+}
+
+// handleStartupReport writes the breakdown of the startup of the process as JSON.
+func (s *Server) handleStartupReport(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(startup.Singleton.Report())
+}
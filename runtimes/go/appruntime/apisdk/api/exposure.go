@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/beta/errs"
+)
+
+// requireInternalNetwork wraps h to only serve requests originating
+// from internal networks. Other requests are reported as not found,
+// the same way as requests for private endpoints.
+func requireInternalNetwork(h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		if !isInternalNetworkRequest(req) {
+			errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("endpoint not found").Err())
+			return
+		}
+		h(w, req, ps)
+	}
+}
+
+// isInternalNetworkRequest reports whether req originates from an internal network.
+//
+// Since proxies append to X-Forwarded-For, every address in it must be internal
+// as well: a client can add addresses to the header, but not remove its own.
+func isInternalNetworkRequest(req *http.Request) bool {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	if !isInternalAddr(host) {
+		return false
+	}
+
+	for _, v := range req.Header.Values("X-Forwarded-For") {
+		for _, addr := range strings.Split(v, ",") {
+			if !isInternalAddr(strings.TrimSpace(addr)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isInternalAddr(addr string) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast()
+}
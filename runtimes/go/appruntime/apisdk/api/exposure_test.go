@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsInternalNetworkRequest(t *testing.T) {
+	tests := []struct {
+		remoteAddr string
+		fwdFor     string
+		want       bool
+	}{
+		{"127.0.0.1:1234", "", true},
+		{"[::1]:1234", "", true},
+		{"10.1.2.3:1234", "192.168.1.1", true},
+		{"[fd00::1]:1234", "", true},
+		{"8.8.8.8:1234", "", false},
+		{"10.1.2.3:1234", "8.8.8.8", false},
+		{"10.1.2.3:1234", "10.0.0.1, 8.8.8.8", false},
+		{"[::ffff:10.0.0.1]:1234", "", true},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = tt.remoteAddr
+		if tt.fwdFor != "" {
+			req.Header.Set("X-Forwarded-For", tt.fwdFor)
+		}
+		if got := isInternalNetworkRequest(req); got != tt.want {
+			t.Errorf("isInternalNetworkRequest(%q, %q) = %v, want %v", tt.remoteAddr, tt.fwdFor, got, tt.want)
+		}
+	}
+}
@@ -85,6 +85,18 @@ type Desc[Req, Resp any] struct {
 	// for when other routes don't match.
 	Fallback bool
 
+	// InternalOnly, if true, means the API gateway only serves the endpoint
+	// to requests originating from internal (private) networks.
+	InternalOnly bool
+
+	// RouteConditions are additional conditions a request must match,
+	// besides the path and method, to be routed to this endpoint.
+	RouteConditions []RouteCondition
+
+	// Experiment, if set, makes the endpoint an alternate implementation
+	// of the endpoint with the same path and method.
+	Experiment *Experiment
+
 	DecodeReq      func(*http.Request, UnnamedParams, jsoniter.API) (Req, UnnamedParams, error)
 	CloneReq       func(Req) (Req, error)
 	ReqPath        func(Req) (path string, params UnnamedParams, err error)
@@ -131,6 +143,11 @@ func (d *Desc[Req, Resp]) HTTPMethods() []string  { return d.Methods }
 func (d *Desc[Req, Resp]) SemanticPath() string   { return d.Path }
 func (d *Desc[Req, Resp]) HTTPRouterPath() string { return d.RawPath }
 func (d *Desc[Req, Resp]) IsFallback() bool       { return d.Fallback }
+func (d *Desc[Req, Resp]) IsInternalOnly() bool   { return d.InternalOnly }
+func (d *Desc[Req, Resp]) HTTPRouteConditions() []RouteCondition {
+	return d.RouteConditions
+}
+func (d *Desc[Req, Resp]) HTTPExperiment() *Experiment { return d.Experiment }
 
 func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
 	if d.Raw {
@@ -226,6 +243,12 @@ func returnError(c IncomingContext, err error, statusCodeToUse int, headers http
 		_, _ = c.w.Write(errBytes)
 
 	} else {
+		// Localize the error message for external clients, if the error has a localized message.
+		if localized, lang := errs.Localize(err, c.req.Header.Get("Accept-Language")); lang != "" {
+			err = localized
+			c.w.Header().Set("Content-Language", lang)
+			c.w.Header().Add("Vary", "Accept-Language")
+		}
 		errs.HTTPErrorWithCode(c.w, err, statusCodeToUse)
 	}
 }
@@ -273,6 +296,7 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 			RequestHeaders:       headersWithHost(c.req),
 			FromEncorePlatform:   platformauth.IsEncorePlatformRequest(c.req.Context()),
 			ServiceToServiceCall: c.callMeta.IsServiceToService(),
+			Client:               c.callMeta.Client,
 		},
 
 		ExtRequestID:            clampTo64Chars(c.req.Header.Get("X-Request-ID")),
@@ -676,6 +700,7 @@ func (d *Desc[Req, Resp]) runCall(c CallContext, req Req, mocked bool, executor
 				RequestHeaders:       nil, // not set right now for internal requests
 				ServiceToServiceCall: true,
 				Mocked:               mocked,
+				Client:               meta.Client,
 			},
 		})
 
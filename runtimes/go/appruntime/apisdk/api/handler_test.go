@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -283,16 +284,24 @@ func TestDescGeneratesTrace(t *testing.T) {
 	}
 
 	opts := []cmp.Option{
-		cmpopts.IgnoreFields(model.Request{}, "Logger"),
+		// The deadline and cancel cause are checked separately below.
+		cmpopts.IgnoreFields(model.Request{}, "Logger", "Deadline", "CancelCause"),
 		cmp.Comparer(func(a, b reflect.Type) bool { return a == b }),
 	}
+	errCanceled := errors.New("client went away")
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			server, traceMock, _ := testServer(t, klock, true)
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("POST", "/path/hello", strings.NewReader(test.reqBody))
+			deadline := time.Now().Add(time.Hour)
+			ctx, cancel := context.WithCancelCause(context.Background())
+			defer cancel(nil)
+			ctx, cancelDeadline := context.WithDeadline(ctx, deadline)
+			defer cancelDeadline()
+
+			req := httptest.NewRequest("POST", "/path/hello", strings.NewReader(test.reqBody)).WithContext(ctx)
 			req.Header = test.reqHeaders
 			ps := api.UnnamedParams{"hello"}
 
@@ -326,6 +335,23 @@ func TestDescGeneratesTrace(t *testing.T) {
 			if diff := cmp.Diff(test.want, beginReq, opts...); diff != "" {
 				t.Errorf("beginReq mismatch (-want +got):\n%s", diff)
 			}
+			if beginReq == nil {
+				return
+			}
+
+			if !beginReq.Deadline.Equal(deadline) {
+				t.Errorf("got deadline %v, want %v", beginReq.Deadline, deadline)
+			}
+			if beginReq.CancelCause == nil {
+				t.Fatal("got nil CancelCause")
+			}
+			if err := beginReq.CancelCause(); err != nil {
+				t.Errorf("got cancel cause %v before cancel, want nil", err)
+			}
+			cancel(errCanceled)
+			if err := beginReq.CancelCause(); !errors.Is(err, errCanceled) {
+				t.Errorf("got cancel cause %v after cancel, want %v", err, errCanceled)
+			}
 		})
 	}
 }
@@ -2,7 +2,10 @@
 
 package api
 
-import "reflect"
+import (
+	"net/http"
+	"reflect"
+)
 
 func RegisterEndpoint(handler Handler, function any) {
 	Singleton.registerEndpoint(handler, function)
@@ -25,3 +28,16 @@ func RegisterAuthDataType[T any]() {
 func RegisterGlobalMiddleware(mw *Middleware) {
 	Singleton.registerGlobalMiddleware(mw)
 }
+
+// RegisterPlatformRoute registers a handler for an internal route under /__encore,
+// which is only available to the Encore platform and the local development daemon.
+func RegisterPlatformRoute(method, path string, h http.HandlerFunc) {
+	Singleton.registerPlatformRoute(method, path, h)
+}
+
+// RegisterWebhookRoute registers a handler for a route under /__encore
+// that is called by third parties, such as email providers, or by clients.
+// The handler is responsible for authenticating the requests.
+func RegisterWebhookRoute(method, path string, h http.HandlerFunc) {
+	Singleton.encore.HandlerFunc(method, path, h)
+}
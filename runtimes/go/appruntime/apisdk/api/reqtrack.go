@@ -131,6 +131,15 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 		Start:            s.clock.Now(),
 		Traced:           traced,
 		RPCData:          p.Data,
+		CancelCause: func() error {
+			if ctx.Err() == nil {
+				return nil
+			}
+			return context.Cause(ctx)
+		},
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		req.Deadline = deadline
 	}
 
 	data := req.RPCData
@@ -305,6 +314,10 @@ func (s *Server) finishRequest(resp *model.Response) {
 		}
 	}
 
+	if req.Type != model.AuthHandler {
+		s.rt.ReportFailure(req, resp)
+	}
+
 	s.requestsTotal.With(requestsTotalLabels{
 		endpoint: req.RPCData.Desc.Endpoint,
 		code:     Code(resp.Err, resp.HTTPStatus),
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/appruntime/apisdk/api/transport"
+	"encore.dev/beta/errs"
+)
+
+// RouteCondition is a condition a request must match to be routed
+// to an endpoint, in addition to the endpoint's path and method.
+type RouteCondition struct {
+	// Query, if true, matches a query parameter instead of a header.
+	Query bool
+
+	// Name is the name of the header or query parameter.
+	Name string
+
+	// Value is the value to match.
+	// If empty, the header or query parameter need only be present.
+	Value string
+}
+
+// Matches reports whether req matches the condition.
+func (c RouteCondition) Matches(req *http.Request) bool {
+	var vals []string
+	var present bool
+	if c.Query {
+		vals, present = req.URL.Query()[c.Name]
+	} else {
+		vals = req.Header.Values(c.Name)
+		present = len(vals) > 0
+	}
+
+	if c.Value == "" {
+		return present
+	}
+	return slices.Contains(vals, c.Value)
+}
+
+// Experiment describes an endpoint that is an alternate implementation
+// of the endpoint with the same path and method, that a share of the
+// requests are routed to.
+type Experiment struct {
+	// Name is the name of the experiment.
+	Name string
+
+	// Percent is the percentage of requests routed to the experiment.
+	Percent int
+}
+
+const (
+	// experimentHeader is the request header and cookie listing the experiments
+	// a request opts in to, overriding the percentage based routing.
+	// It's also set on responses served by an experiment.
+	experimentHeader = "X-Encore-Experiment"
+	experimentCookie = "encore-experiment"
+)
+
+// routeKey identifies a route registered with a router.
+type routeKey struct {
+	router *httprouter.Router
+	method string
+	path   string
+}
+
+// conditionalRoute dispatches requests for a single method and path
+// to one of several handlers, based on their route conditions.
+type conditionalRoute struct {
+	conditional   []conditionalHandler
+	experiments   []experimentHandler
+	unconditional httprouter.Handle // nil if there is none
+}
+
+type conditionalHandler struct {
+	conds  []RouteCondition
+	handle httprouter.Handle
+}
+
+type experimentHandler struct {
+	exp      *Experiment
+	endpoint string // the "service.endpoint" name of the experiment
+	handle   httprouter.Handle
+}
+
+// handleRoute registers handle for the given method and path on router.
+// Multiple handlers may be registered for the same method and path
+// as long as at most one of them has no route conditions.
+func (s *Server) handleRoute(router *httprouter.Router, method, path string, conds []RouteCondition, handle httprouter.Handle) {
+	key := routeKey{router: router, method: method, path: path}
+	route, ok := s.routes[key]
+	if !ok {
+		route = &conditionalRoute{}
+		s.routes[key] = route
+		router.Handle(method, path, route.serve)
+	}
+
+	if len(conds) == 0 {
+		route.unconditional = handle
+		return
+	}
+
+	route.conditional = append(route.conditional, conditionalHandler{conds: conds, handle: handle})
+
+	// Try the most specific handlers first.
+	sort.SliceStable(route.conditional, func(i, j int) bool {
+		return len(route.conditional[i].conds) > len(route.conditional[j].conds)
+	})
+}
+
+// handleExperimentRoute registers handle for the given method and path on router,
+// as the experiment exp of the endpoint registered with handleRoute for them.
+func (s *Server) handleExperimentRoute(router *httprouter.Router, method, path, endpoint string, exp *Experiment, handle httprouter.Handle) {
+	key := routeKey{router: router, method: method, path: path}
+	route, ok := s.routes[key]
+	if !ok {
+		route = &conditionalRoute{}
+		s.routes[key] = route
+		router.Handle(method, path, route.serve)
+	}
+
+	route.experiments = append(route.experiments, experimentHandler{exp: exp, endpoint: endpoint, handle: handle})
+}
+
+func (r *conditionalRoute) serve(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+HandlerLoop:
+	for _, h := range r.conditional {
+		for _, c := range h.conds {
+			if !c.Matches(req) {
+				continue HandlerLoop
+			}
+		}
+		h.handle(w, req, ps)
+		return
+	}
+
+	if e, named := r.experiment(req); e != nil {
+		if !named {
+			// Tell which experiment served the request. Requests naming the endpoint are
+			// proxied or called internally, and the caller has already been told.
+			w.Header().Set(experimentHeader, e.exp.Name)
+		}
+		e.handle(w, req, ps)
+		return
+	}
+
+	if r.unconditional != nil {
+		r.unconditional(w, req, ps)
+		return
+	}
+	errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("endpoint not found").Err())
+}
+
+// experiment returns the experiment req is routed to, or nil if it's
+// routed to the regular endpoint. It reports whether req named the
+// endpoint it's for, rather than being routed by the experiment rules.
+func (r *conditionalRoute) experiment(req *http.Request) (e *experimentHandler, named bool) {
+	if len(r.experiments) == 0 {
+		return nil, false
+	}
+
+	// Calls from other services, and requests proxied by the API gateway,
+	// name the endpoint they're for.
+	if callee, ok := transport.HTTPRequest(req).ReadMeta(calleeMetaName); ok {
+		for i := range r.experiments {
+			if r.experiments[i].endpoint == callee {
+				return &r.experiments[i], true
+			}
+		}
+		return nil, true
+	}
+
+	// Requests can opt in to experiments, which makes them testable
+	// regardless of their share of the traffic.
+	if names, ok := requestedExperiments(req); ok {
+		for i := range r.experiments {
+			if slices.Contains(names, r.experiments[i].exp.Name) {
+				return &r.experiments[i], false
+			}
+		}
+		return nil, false
+	}
+
+	n := rand.IntN(100)
+	for i := range r.experiments {
+		if n < r.experiments[i].exp.Percent {
+			return &r.experiments[i], false
+		}
+		n -= r.experiments[i].exp.Percent
+	}
+	return nil, false
+}
+
+// requestedExperiments returns the experiments req opts in to with
+// the experiment header or cookie, and whether it specifies any.
+// A request specifying "none" opts out of all experiments.
+func requestedExperiments(req *http.Request) (names []string, ok bool) {
+	values := req.Header.Values(experimentHeader)
+	if c, err := req.Cookie(experimentCookie); err == nil {
+		values = append(values, c.Value)
+	}
+	for _, v := range values {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, len(names) > 0
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestConditionalRoute(t *testing.T) {
+	router := httprouter.New()
+	s := &Server{routes: make(map[routeKey]*conditionalRoute)}
+
+	respond := func(name string) httprouter.Handle {
+		return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+			_, _ = w.Write([]byte(name))
+		}
+	}
+	s.handleRoute(router, "POST", "/webhook", nil, respond("default"))
+	s.handleRoute(router, "POST", "/webhook", []RouteCondition{{Name: "X-Event", Value: "push"}}, respond("push"))
+	s.handleRoute(router, "POST", "/webhook", []RouteCondition{
+		{Name: "X-Event", Value: "push"},
+		{Query: true, Name: "v"},
+	}, respond("push-versioned"))
+
+	tests := []struct {
+		name   string
+		url    string
+		header http.Header
+		want   string
+	}{
+		{"no_match", "/webhook", nil, "default"},
+		{"header", "/webhook", http.Header{"X-Event": {"push"}}, "push"},
+		{"other_header_value", "/webhook", http.Header{"X-Event": {"pull"}}, "default"},
+		{"most_specific", "/webhook?v=2", http.Header{"X-Event": {"push"}}, "push-versioned"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.url, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExperimentRoute(t *testing.T) {
+	router := httprouter.New()
+	s := &Server{routes: make(map[routeKey]*conditionalRoute)}
+
+	respond := func(name string) httprouter.Handle {
+		return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+			_, _ = w.Write([]byte(name))
+		}
+	}
+	s.handleRoute(router, "POST", "/checkout", nil, respond("regular"))
+	s.handleExperimentRoute(router, "POST", "/checkout", "checkout.CheckoutV2", &Experiment{Name: "checkout-v2"}, respond("v2"))
+	s.handleExperimentRoute(router, "POST", "/checkout", "checkout.CheckoutV3", &Experiment{Name: "checkout-v3", Percent: 100}, respond("v3"))
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		cookie  string
+		want    string
+		wantExp string
+	}{
+		{"percent", nil, "", "v3", "checkout-v3"},
+		{"header", http.Header{"X-Encore-Experiment": {"checkout-v2"}}, "", "v2", "checkout-v2"},
+		{"cookie", nil, "other, checkout-v2", "v2", "checkout-v2"},
+		{"opt_out", http.Header{"X-Encore-Experiment": {"none"}}, "", "regular", ""},
+		{"callee", http.Header{"X-Encore-Meta-Callee": {"checkout.CheckoutV2"}}, "", "v2", ""},
+		{"callee_regular", http.Header{"X-Encore-Meta-Callee": {"checkout.Checkout"}}, "", "regular", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/checkout", nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "encore-experiment", Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if got := w.Header().Get("X-Encore-Experiment"); got != tt.wantExp {
+				t.Errorf("got experiment header %q, want %q", got, tt.wantExp)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"encore.dev/appruntime/exported/experiments"
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/shared/cfgutil"
+	"encore.dev/appruntime/shared/clientinfo"
 	"encore.dev/appruntime/shared/cloudtrace"
 	"encore.dev/appruntime/shared/health"
 	"encore.dev/appruntime/shared/platform"
@@ -96,7 +97,10 @@ type Handler interface {
 	SemanticPath() string
 	HTTPRouterPath() string
 	HTTPMethods() []string
+	HTTPRouteConditions() []RouteCondition
+	HTTPExperiment() *Experiment
 	IsFallback() bool
+	IsInternalOnly() bool
 	Handle(c IncomingContext)
 }
 
@@ -131,6 +135,7 @@ type Server struct {
 	private          *httprouter.Router
 	privateFallback  *httprouter.Router
 	encore           *httprouter.Router
+	routes           map[routeKey]*conditionalRoute
 	inboundSvcAuth   map[string]svcauth.ServiceAuth // auth methods used to accept inbound service-to-service calls
 	outboundSvcAuth  map[string]svcauth.ServiceAuth // auth methods used to make outbound service-to-service calls
 	httpsrv          *http.Server
@@ -138,6 +143,7 @@ type Server struct {
 	httpCtxCancel    context.CancelFunc
 	runningHandlers  sync.WaitGroup
 	remotePubSubPush map[string]*httputil.ReverseProxy
+	clientInfo       *clientinfo.Enricher // if nil, requests are not enriched with client metadata
 
 	callCtr uint64
 
@@ -192,11 +198,16 @@ func NewServer(static *config.Static, runtime *config.Runtime, rt *reqtrack.Requ
 		private:          newRouter(),
 		privateFallback:  newRouter(),
 		encore:           newRouter(),
+		routes:           make(map[routeKey]*conditionalRoute),
 		inboundSvcAuth:   inboundSvcAuth,
 		outboundSvcAuth:  outboundSvcAuth,
 		remotePubSubPush: make(map[string]*httputil.ReverseProxy),
 	}
 
+	if s.IsGateway() && runtime.ClientMetadata != nil {
+		s.clientInfo = newClientInfoEnricher(runtime.ClientMetadata, rootLogger)
+	}
+
 	// Create our HTTP server handler chain
 
 	// Start with the underlying router
@@ -350,15 +361,37 @@ func (s *Server) registerEndpoint(h Handler, function any) {
 
 	s.registeredHandlers = append(s.registeredHandlers, h)
 
+	route := func(router *httprouter.Router, method string, handle httprouter.Handle) {
+		if exp := h.HTTPExperiment(); exp != nil {
+			endpoint := h.ServiceName() + "." + h.EndpointName()
+			s.handleExperimentRoute(router, method, routerPath, endpoint, exp, handle)
+		} else {
+			s.handleRoute(router, method, routerPath, h.HTTPRouteConditions(), handle)
+		}
+	}
+
 	// Register the adapter
 	for _, m := range h.HTTPMethods() {
 		if m == "*" {
 			m = wildcardMethod
 		}
 
-		private.Handle(m, routerPath, adapter)
+		route(private, m, adapter)
 		if access := h.AccessType(); access == Public || access == RequiresAuth {
-			public.Handle(m, routerPath, adapter)
+			publicAdapter := adapter
+			if h.IsInternalOnly() {
+				publicAdapter = requireInternalNetwork(adapter)
+			}
+			if s.IsGateway() {
+				publicAdapter = handleConditionalRequests(publicAdapter)
+				if s.clientInfo != nil {
+					publicAdapter = s.enrichClientInfo(publicAdapter)
+				}
+				if t, ok := gatewayTransformFor(s.runtime.GatewayTransforms, h.ServiceName(), h.EndpointName()); ok {
+					publicAdapter = t.wrap(publicAdapter)
+				}
+			}
+			route(public, m, publicAdapter)
 		}
 	}
 
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/julienschmidt/httprouter"
+	"github.com/klauspost/compress/zstd"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+// gatewayTransform is the combination of all gateway transforms
+// that apply to a single endpoint.
+type gatewayTransform struct {
+	requestHeaders  map[string]string
+	responseHeaders map[string]string
+	stripFields     []string
+	compression     []string
+}
+
+// gatewayTransformFor computes the gateway transform for the endpoint
+// svc.endpoint from the given transforms, applied in order.
+// It reports false if no transform applies to the endpoint.
+func gatewayTransformFor(transforms []config.GatewayTransform, svc, endpoint string) (*gatewayTransform, bool) {
+	var (
+		t     gatewayTransform
+		found bool
+	)
+	for _, cfg := range transforms {
+		if !transformMatches(cfg.Endpoints, svc, endpoint) {
+			continue
+		}
+		found = true
+
+		for k, v := range cfg.RequestHeaders {
+			if t.requestHeaders == nil {
+				t.requestHeaders = make(map[string]string)
+			}
+			t.requestHeaders[k] = v
+		}
+		for k, v := range cfg.ResponseHeaders {
+			if t.responseHeaders == nil {
+				t.responseHeaders = make(map[string]string)
+			}
+			t.responseHeaders[k] = v
+		}
+		for _, f := range cfg.StripResponseFields {
+			if !slices.Contains(t.stripFields, f) {
+				t.stripFields = append(t.stripFields, f)
+			}
+		}
+		if len(cfg.Compression) > 0 {
+			t.compression = cfg.Compression
+		}
+	}
+	return &t, found
+}
+
+func transformMatches(endpoints []string, svc, endpoint string) bool {
+	if len(endpoints) == 0 {
+		return true
+	}
+	for _, ep := range endpoints {
+		s, e, _ := strings.Cut(ep, ".")
+		if s == svc && (e == "*" || e == endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrap returns a handler that applies the transform to requests
+// and responses handled by h.
+func (t *gatewayTransform) wrap(h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		for k, v := range t.requestHeaders {
+			req.Header.Set(k, v)
+		}
+
+		// Responses to upgraded connections (websockets) are not
+		// transformed, as the connection is hijacked by the handler.
+		if req.Header.Get("Upgrade") != "" {
+			h(w, req, ps)
+			return
+		}
+
+		tw := &transformWriter{
+			ResponseWriter: w,
+			t:              t,
+			encoding:       negotiateEncoding(req.Header.Get("Accept-Encoding"), t.compression),
+			head:           req.Method == http.MethodHead,
+		}
+		defer tw.finish()
+		h(tw, req, ps)
+	}
+}
+
+// transformWriter is a http.ResponseWriter that applies
+// a gatewayTransform to the response.
+type transformWriter struct {
+	http.ResponseWriter
+	t        *gatewayTransform
+	encoding string // negotiated content encoding, or "" for none
+	head     bool   // whether the request is a HEAD request
+
+	wroteHeader bool
+	code        int
+	buffering   bool         // whether the body is buffered to strip fields
+	buf         bytes.Buffer // buffered body, if buffering
+	enc         io.WriteCloser
+}
+
+func (w *transformWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.code = code
+
+	hdr := w.Header()
+	for k, v := range w.t.responseHeaders {
+		hdr.Set(k, v)
+	}
+
+	if len(w.t.stripFields) > 0 && isJSONContentType(hdr.Get("Content-Type")) {
+		// Defer writing the header until the body has been rewritten.
+		w.buffering = true
+		hdr.Del("Content-Length")
+		return
+	}
+	w.writeHeader()
+}
+
+// writeHeader sets up compression and writes the response header.
+func (w *transformWriter) writeHeader() {
+	hdr := w.Header()
+	// Partial content can't be compressed without invalidating its Content-Range.
+	if w.encoding != "" && !w.head && bodyAllowed(w.code) && w.code != http.StatusPartialContent && hdr.Get("Content-Encoding") == "" {
+		hdr.Set("Content-Encoding", w.encoding)
+		hdr.Add("Vary", "Accept-Encoding")
+		hdr.Del("Content-Length")
+		w.enc = newEncoder(w.encoding, w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.code)
+}
+
+func (w *transformWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	switch {
+	case w.buffering:
+		return w.buf.Write(p)
+	case w.enc != nil:
+		return w.enc.Write(p)
+	default:
+		return w.ResponseWriter.Write(p)
+	}
+}
+
+func (w *transformWriter) Flush() {
+	// Buffered responses can't be flushed until the body is complete.
+	if w.buffering {
+		return
+	}
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.enc.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// finish completes the response, writing any buffered body
+// and closing the encoder.
+func (w *transformWriter) finish() {
+	if !w.wroteHeader {
+		return
+	}
+	if w.buffering {
+		body := stripJSONFields(w.buf.Bytes(), w.t.stripFields)
+		if w.encoding == "" {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		w.buffering = false
+		w.writeHeader()
+		if !w.head {
+			_, _ = w.Write(body)
+		}
+	}
+	if w.enc != nil {
+		_ = w.enc.Close()
+	}
+}
+
+// stripJSONFields removes the given top-level fields from body.
+// If body is not a JSON object it is returned unchanged.
+func stripJSONFields(body []byte, fields []string) []byte {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return body
+	}
+	for _, f := range fields {
+		delete(obj, f)
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func isJSONContentType(ct string) bool {
+	mt, _, err := mime.ParseMediaType(ct)
+	return err == nil && (mt == "application/json" || strings.HasSuffix(mt, "+json"))
+}
+
+func bodyAllowed(code int) bool {
+	return !(code >= 100 && code <= 199) && code != http.StatusNoContent && code != http.StatusNotModified
+}
+
+// negotiateEncoding returns the first of the supported encodings
+// accepted by the given Accept-Encoding header, or "" if none are.
+func negotiateEncoding(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" || len(supported) == 0 {
+		return ""
+	}
+
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		ok := true
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				ok = false
+			}
+		}
+		accepted[name] = ok
+	}
+
+	for _, enc := range supported {
+		if ok, found := accepted[enc]; found {
+			if ok {
+				return enc
+			}
+			continue
+		}
+		if accepted["*"] {
+			return enc
+		}
+	}
+	return ""
+}
+
+func newEncoder(encoding string, w io.Writer) io.WriteCloser {
+	switch encoding {
+	case "gzip":
+		return gzip.NewWriter(w)
+	case "br":
+		return brotli.NewWriter(w)
+	case "zstd":
+		enc, err := zstd.NewWriter(w)
+		if err != nil {
+			// Only returned for invalid options.
+			panic(err)
+		}
+		return enc
+	default:
+		panic("unsupported encoding: " + encoding)
+	}
+}
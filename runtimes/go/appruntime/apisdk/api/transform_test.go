@@ -0,0 +1,102 @@
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestGatewayTransformFor(t *testing.T) {
+	transforms := []config.GatewayTransform{
+		{ResponseHeaders: map[string]string{"X-A": "all"}},
+		{Endpoints: []string{"svc.*"}, ResponseHeaders: map[string]string{"X-A": "svc"}, Compression: []string{"gzip"}},
+		{Endpoints: []string{"other.Foo"}, StripResponseFields: []string{"secret"}},
+	}
+
+	tr, ok := gatewayTransformFor(transforms, "svc", "Foo")
+	if !ok {
+		t.Fatal("expected transform for svc.Foo")
+	}
+	if got := tr.responseHeaders["X-A"]; got != "svc" {
+		t.Errorf("got X-A=%q, want %q", got, "svc")
+	}
+	if len(tr.stripFields) != 0 {
+		t.Errorf("got strip fields %v, want none", tr.stripFields)
+	}
+
+	if _, ok := gatewayTransformFor(transforms[2:], "svc", "Foo"); ok {
+		t.Error("expected no transform for svc.Foo")
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		accept    string
+		supported []string
+		want      string
+	}{
+		{"", []string{"gzip"}, ""},
+		{"gzip, br", []string{"zstd", "br", "gzip"}, "br"},
+		{"gzip;q=0, zstd", []string{"gzip", "zstd"}, "zstd"},
+		{"*", []string{"br"}, "br"},
+		{"gzip;q=0, *", []string{"gzip"}, ""},
+		{"identity", []string{"gzip"}, ""},
+	}
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.accept, tt.supported); got != tt.want {
+			t.Errorf("negotiateEncoding(%q, %v) = %q, want %q", tt.accept, tt.supported, got, tt.want)
+		}
+	}
+}
+
+func TestGatewayTransformWrap(t *testing.T) {
+	tr := &gatewayTransform{
+		requestHeaders:  map[string]string{"X-Injected": "yes"},
+		responseHeaders: map[string]string{"X-Served-By": "gateway"},
+		stripFields:     []string{"secret"},
+		compression:     []string{"gzip"},
+	}
+	var gotHeader string
+	h := tr.wrap(func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		gotHeader = req.Header.Get("X-Injected")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", "31")
+		_, _ = w.Write([]byte(`{"name":"foo","secret":"hunter2"}`))
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h(w, req, nil)
+
+	if gotHeader != "yes" {
+		t.Errorf("got request header %q, want %q", gotHeader, "yes")
+	}
+	resp := w.Result()
+	if got := resp.Header.Get("X-Served-By"); got != "gateway" {
+		t.Errorf("got response header %q, want %q", got, "gateway")
+	}
+	if got := resp.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("got Content-Encoding %q, want gzip", got)
+	}
+	if got := resp.Header.Get("Content-Length"); got != "" {
+		t.Errorf("got Content-Length %q, want none", got)
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(body), `{"name":"foo"}`; got != want {
+		t.Errorf("got body %s, want %s", got, want)
+	}
+}
@@ -2,12 +2,12 @@ package app
 
 import (
 	"github.com/rs/zerolog"
-	"go.uber.org/automaxprocs/maxprocs"
 
 	"encore.dev/appruntime/apisdk/api"
 	"encore.dev/appruntime/apisdk/service"
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/appruntime/shared/startup"
 
 	// Initialize the metric subsystem
 	_ "encore.dev/appruntime/infrasdk/metrics"
@@ -34,24 +34,9 @@ func New(runtime *config.Runtime, service *service.Manager, api *api.Server, shu
 }
 
 func (app *App) Run() error {
-	if app.runtime.EnvCloud != "local" {
-		// Set the maximum number of processes to use based on the enviroment we're running inside
-		// and what we can detect. Note this is required because the default value of GOMAXPROCS is
-		// the number of logical CPUs on the machine, which inside a kubernetes environment is not
-		// the same as the number of CPUs allocated to the container. This can lead to CPU throttling
-		// by the kernel and high tail latencies.
-		//
-		// We only do this when the app starts up, rather than using the automaxprocs magic import
-		// so it does not impact anything else which imports the Encore runtime (such as the CLI tooling).
-		undoMaxProcs, err := maxprocs.Set(maxprocs.Logger(func(s string, args ...interface{}) {
-			app.logger.Debug().Msgf(s, args...)
-		}))
-		if err != nil {
-			app.logger.Err(err).Msg("failed to set GOMAXPROCS")
-		} else {
-			defer undoMaxProcs()
-		}
-	}
+	startup.Singleton.Main()
+	undoTune := app.tuneRuntime()
+	defer undoTune()
 
 	ln, err := Listen()
 	if err != nil {
@@ -59,6 +44,7 @@ func (app *App) Run() error {
 	}
 	defer func() { _ = ln.Close() }()
 
+	app.servePprof()
 	app.Start()
 
 	// Begin serving requests.
@@ -67,10 +53,12 @@ func (app *App) Run() error {
 		serveCh <- app.api.Serve(ln)
 	}()
 
+	startup.Singleton.ServicesStarting()
 	if err := app.service.InitializeServices(); err != nil {
 		app.shutdown.Shutdown(nil, err)
 		return err
 	}
+	app.logStartupReport(startup.Singleton.Ready())
 
 	// Wait for the Serve to return before triggering shutdown.
 	serveErr := <-serveCh
@@ -114,3 +102,40 @@ func (app *App) logStartupInfo() {
 		}
 	}
 }
+
+// slowStartupItems is the number of resources and services
+// included in the startup report, slowest first.
+const slowStartupItems = 5
+
+// logStartupReport logs how long the startup took and where the time went,
+// so slow cold starts can be attributed.
+func (app *App) logStartupReport(rep startup.Report) {
+	if app.runtime.EnvType == "test" {
+		return
+	}
+	ev := app.logger.Info().
+		Dur("total", rep.Total).
+		Dur("package_init", rep.PackageInit).
+		Dur("resource_setup", rep.ResourceSetup).
+		Dur("runtime_setup", rep.RuntimeSetup).
+		Dur("service_init", rep.ServiceInit)
+
+	slowest := func(timings []startup.Timing) *zerolog.Event {
+		dict := zerolog.Dict()
+		for _, t := range timings[:min(len(timings), slowStartupItems)] {
+			key := t.Name
+			if t.Kind != "" {
+				key = t.Kind + " " + t.Name
+			}
+			dict.Dur(key, t.Duration)
+		}
+		return dict
+	}
+	if len(rep.Resources) > 0 {
+		ev = ev.Dict("slowest_resources", slowest(rep.Resources))
+	}
+	if len(rep.Services) > 0 {
+		ev = ev.Dict("slowest_services", slowest(rep.Services))
+	}
+	ev.Msg("application started")
+}
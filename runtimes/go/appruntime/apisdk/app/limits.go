@@ -0,0 +1,165 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"go.uber.org/automaxprocs/maxprocs"
+
+	"encore.dev/appruntime/shared/encoreenv"
+)
+
+// memLimitRatio is the share of the memory limit of the container the Go
+// runtime's soft memory limit is set to, leaving headroom for memory not
+// managed by the Go runtime, such as goroutine stacks and cgo allocations.
+const memLimitRatio = 0.9
+
+// tuneRuntime sets GOMAXPROCS and the soft memory limit of the Go runtime
+// from the CPU and memory limits the app runs with, unless they're set
+// explicitly with the GOMAXPROCS and GOMEMLIMIT environment variables.
+//
+// The default value of GOMAXPROCS is the number of logical CPUs on the machine,
+// which inside a Kubernetes environment is not the same as the number of CPUs
+// allocated to the container. This leads to CPU throttling by the kernel and
+// high tail latencies. Without a soft memory limit the garbage collector doesn't
+// know about the container's memory limit, and the container is OOM-killed
+// instead of the garbage collector working harder as the limit approaches.
+//
+// In the cloud the limits are read from the container's cgroup. When running
+// locally they're provided by `encore run`, so the processes are tuned the
+// same way even on platforms where the limits can't be enforced.
+//
+// It returns a function undoing the changes.
+func (app *App) tuneRuntime() (undo func()) {
+	var undoFuncs []func()
+	undo = func() {
+		for _, f := range undoFuncs {
+			f()
+		}
+	}
+
+	if app.runtime.EnvCloud == "local" {
+		if cpu, err := strconv.ParseFloat(encoreenv.Get("ENCORE_CPU_LIMIT"), 64); err == nil && cpu > 0 && os.Getenv("GOMAXPROCS") == "" {
+			procs := max(int(math.Floor(cpu)), 1)
+			prev := runtime.GOMAXPROCS(procs)
+			undoFuncs = append(undoFuncs, func() { runtime.GOMAXPROCS(prev) })
+			app.logger.Debug().Int("gomaxprocs", procs).Msg("set GOMAXPROCS from the CPU limit")
+		}
+		if mem, err := strconv.ParseInt(encoreenv.Get("ENCORE_MEMORY_LIMIT"), 10, 64); err == nil && mem > 0 {
+			undoFuncs = append(undoFuncs, app.setMemoryLimit(mem))
+		}
+		return undo
+	}
+
+	// We only do this when the app starts up, rather than using the automaxprocs magic import
+	// so it does not impact anything else which imports the Encore runtime (such as the CLI tooling).
+	undoMaxProcs, err := maxprocs.Set(maxprocs.Logger(func(s string, args ...interface{}) {
+		app.logger.Debug().Msgf(s, args...)
+	}))
+	if err != nil {
+		app.logger.Err(err).Msg("failed to set GOMAXPROCS")
+	} else {
+		undoFuncs = append(undoFuncs, undoMaxProcs)
+	}
+
+	if mem, ok := cgroupMemoryLimit(os.DirFS("/")); ok {
+		undoFuncs = append(undoFuncs, app.setMemoryLimit(mem))
+	}
+	return undo
+}
+
+// setMemoryLimit sets the soft memory limit of the Go runtime for a process
+// limited to mem bytes of memory, unless GOMEMLIMIT is set.
+// It returns a function undoing the change.
+func (app *App) setMemoryLimit(mem int64) (undo func()) {
+	if os.Getenv("GOMEMLIMIT") != "" {
+		return func() {}
+	}
+	limit := int64(float64(mem) * memLimitRatio)
+	prev := debug.SetMemoryLimit(limit)
+	app.logger.Debug().Int64("gomemlimit", limit).Msg("set GOMEMLIMIT from the memory limit")
+	return func() { debug.SetMemoryLimit(prev) }
+}
+
+// cgroupMemoryLimit returns the memory limit of the cgroup the process
+// belongs to, read from fsys mounted at "/". It reports false if the
+// process isn't memory limited, or the limit can't be determined.
+func cgroupMemoryLimit(fsys fs.FS) (limit int64, ok bool) {
+	data, err := fs.ReadFile(fsys, "proc/self/cgroup")
+	if err != nil {
+		return 0, false
+	}
+
+	// Each line is "hierarchy-ID:controller-list:cgroup-path".
+	// cgroup v2 has a single hierarchy with ID 0 and no controllers.
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		parts := strings.SplitN(sc.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		var candidates []string
+		switch {
+		case parts[0] == "0" && parts[1] == "":
+			candidates = cgroupPaths("sys/fs/cgroup", parts[2], "memory.max")
+		case containsController(parts[1], "memory"):
+			candidates = cgroupPaths("sys/fs/cgroup/memory", parts[2], "memory.limit_in_bytes")
+		default:
+			continue
+		}
+		for _, file := range candidates {
+			if limit, ok := readMemoryLimit(fsys, file); ok {
+				return limit, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// cgroupPaths returns the paths of the file of the cgroup at cgroupPath
+// within the hierarchy mounted at mount, and of the file at the root of the
+// mount. Within a container with its own cgroup namespace the cgroup path
+// isn't relative to the mount, in which case the root is the container's cgroup.
+func cgroupPaths(mount, cgroupPath, file string) []string {
+	paths := []string{path.Join(mount, cgroupPath, file)}
+	if cgroupPath != "/" {
+		paths = append(paths, path.Join(mount, file))
+	}
+	return paths
+}
+
+func containsController(list, controller string) bool {
+	for _, c := range strings.Split(list, ",") {
+		if c == controller {
+			return true
+		}
+	}
+	return false
+}
+
+// readMemoryLimit reads a cgroup memory limit file, reporting false if it
+// doesn't exist or doesn't limit the memory.
+func readMemoryLimit(fsys fs.FS, file string) (int64, bool) {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return 0, false
+	}
+	val := strings.TrimSpace(string(data))
+	if val == "max" {
+		return 0, false
+	}
+	limit, err := strconv.ParseInt(val, 10, 64)
+	// cgroup v1 reports a huge number rounded down to the page size when there's no limit.
+	if err != nil || limit <= 0 || limit >= math.MaxInt64&^0xfff {
+		return 0, false
+	}
+	return limit, true
+}
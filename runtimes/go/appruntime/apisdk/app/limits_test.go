@@ -0,0 +1,81 @@
+package app
+
+import (
+	"testing"
+	"testing/fstest"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCgroupMemoryLimit(t *testing.T) {
+	file := func(data string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(data)} }
+	tests := []struct {
+		name   string
+		fsys   fstest.MapFS
+		want   int64
+		wantOK bool
+	}{
+		{
+			name: "v2_namespaced",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":         file("0::/\n"),
+				"sys/fs/cgroup/memory.max": file("536870912\n"),
+			},
+			want:   512 << 20,
+			wantOK: true,
+		},
+		{
+			name: "v2_nested",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":                           file("0::/kubepods/pod1/ctr\n"),
+				"sys/fs/cgroup/kubepods/pod1/ctr/memory.max": file("1073741824\n"),
+			},
+			want:   1 << 30,
+			wantOK: true,
+		},
+		{
+			name: "v2_host_path_not_mounted",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":         file("0::/kubepods/pod1/ctr\n"),
+				"sys/fs/cgroup/memory.max": file("268435456\n"),
+			},
+			want:   256 << 20,
+			wantOK: true,
+		},
+		{
+			name: "v2_unlimited",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":         file("0::/\n"),
+				"sys/fs/cgroup/memory.max": file("max\n"),
+			},
+		},
+		{
+			name: "v1",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":                           file("12:cpu,cpuacct:/docker/abc\n11:memory:/docker/abc\n"),
+				"sys/fs/cgroup/memory/memory.limit_in_bytes": file("2147483648\n"),
+			},
+			want:   2 << 30,
+			wantOK: true,
+		},
+		{
+			name: "v1_unlimited",
+			fsys: fstest.MapFS{
+				"proc/self/cgroup":                           file("11:memory:/\n"),
+				"sys/fs/cgroup/memory/memory.limit_in_bytes": file("9223372036854771712\n"),
+			},
+		},
+		{
+			name: "no_cgroups",
+			fsys: fstest.MapFS{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := qt.New(t)
+			got, ok := cgroupMemoryLimit(test.fsys)
+			c.Assert(ok, qt.Equals, test.wantOK)
+			c.Assert(got, qt.Equals, test.want)
+		})
+	}
+}
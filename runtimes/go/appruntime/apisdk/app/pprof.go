@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"strings"
+	"time"
+
+	"encore.dev/appruntime/shared/encoreenv"
+)
+
+// servePprof serves pprof endpoints on the address set by
+// `encore run --profile`, if any. It's a no-op outside local development.
+//
+// The endpoints are compatible with net/http/pprof, which isn't used
+// as it registers its handlers on http.DefaultServeMux when imported.
+func (app *App) servePprof() {
+	addr := encoreenv.Get("ENCORE_PPROF_ADDR")
+	if addr == "" || app.runtime.EnvCloud != "local" {
+		return
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		app.logger.Err(err).Msg("failed to serve pprof endpoints")
+		return
+	}
+
+	// Enable the block and mutex profiles, which are disabled by default.
+	runtime.SetBlockProfileRate(10000) // sample blocking events lasting 10µs or more
+	runtime.SetMutexProfileFraction(10)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprofIndex)
+	mux.HandleFunc("/debug/pprof/profile", pprofCPU)
+	mux.HandleFunc("/debug/pprof/trace", pprofTrace)
+	go func() { _ = http.Serve(ln, mux) }()
+}
+
+// pprofIndex serves the profile named by the request path,
+// or lists the available profiles.
+func pprofIndex(w http.ResponseWriter, req *http.Request) {
+	name := strings.TrimPrefix(req.URL.Path, "/debug/pprof/")
+	if name == "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, p := range pprof.Profiles() {
+			_, _ = fmt.Fprintf(w, "%d\t/debug/pprof/%s\n", p.Count(), p.Name())
+		}
+		_, _ = fmt.Fprintln(w, "\t/debug/pprof/profile?seconds=30")
+		_, _ = fmt.Fprintln(w, "\t/debug/pprof/trace?seconds=1")
+		return
+	}
+
+	p := pprof.Lookup(name)
+	if p == nil {
+		http.Error(w, "unknown profile "+name, http.StatusNotFound)
+		return
+	}
+	debug, _ := strconv.Atoi(req.FormValue("debug"))
+	if name == "heap" && req.FormValue("gc") != "" {
+		runtime.GC()
+	}
+	if debug != 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	}
+	_ = p.WriteTo(w, debug)
+}
+
+// pprofCPU serves a CPU profile of the given number of seconds.
+func pprofCPU(w http.ResponseWriter, req *http.Request) {
+	d := profileDuration(req, 30*time.Second)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="profile"`)
+	if err := pprof.StartCPUProfile(w); err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w, "could not enable CPU profiling: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sleep(req, d)
+	pprof.StopCPUProfile()
+}
+
+// pprofTrace serves an execution trace of the given number of seconds.
+func pprofTrace(w http.ResponseWriter, req *http.Request) {
+	d := profileDuration(req, time.Second)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="trace"`)
+	if err := trace.Start(w); err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w, "could not enable tracing: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sleep(req, d)
+	trace.Stop()
+}
+
+func profileDuration(req *http.Request, def time.Duration) time.Duration {
+	if secs, err := strconv.ParseFloat(req.FormValue("seconds"), 64); err == nil && secs > 0 {
+		return time.Duration(secs * float64(time.Second))
+	}
+	return def
+}
+
+// sleep sleeps for d, or until the request is canceled.
+func sleep(req *http.Request, d time.Duration) {
+	select {
+	case <-time.After(d):
+	case <-req.Context().Done():
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 
@@ -15,6 +16,7 @@ import (
 	"encore.dev/appruntime/shared/health"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/appruntime/shared/startup"
 	"encore.dev/appruntime/shared/syncutil"
 	"encore.dev/appruntime/shared/testsupport"
 	"encore.dev/beta/errs"
@@ -167,7 +169,9 @@ func (mgr *Manager) InitializeServices() error {
 	for _, svc := range mgr.svcInit {
 		svc := svc
 		go func() {
+			start := time.Now()
 			err := svc.InitService()
+			startup.Singleton.Service(svc.ServiceName(), time.Since(start))
 			if err == nil {
 				mgr.initialisedMu.Lock()
 				defer mgr.initialisedMu.Unlock()
@@ -14,6 +14,10 @@ type Static struct {
 	EncoreCompiler string
 	AppCommit      CommitInfo // The commit which this service was built from
 
+	// InfraHash is a hash of the infrastructure the app requires,
+	// such as its databases and Pub/Sub topics, at the time of build.
+	InfraHash string `json:"infra_hash,omitempty"`
+
 	CORSAllowHeaders  []string // Headers to be allowed by cors
 	CORSExposeHeaders []string // Headers to be exposed by cors
 	PubsubTopics      map[string]*StaticPubsubTopic
@@ -35,6 +39,10 @@ type Static struct {
 
 	// EmbeddedEnvs is a set of embedded environment variables.
 	EmbeddedEnvs map[string]string
+
+	// MessageCatalog contains the localized message templates from the app's
+	// message catalog, keyed by language and message key.
+	MessageCatalog map[string]map[string]string `json:"message_catalog,omitempty"`
 }
 
 type Runtime struct {
@@ -52,20 +60,26 @@ type Runtime struct {
 	TraceSamplingConfig map[string]float64 `json:"trace_sampling_config,omitempty"`
 	AuthKeys            []EncoreAuthKey    `json:"auth_keys,omitempty"`
 	CORS                *CORS              `json:"cors,omitempty"`
+	GatewayTransforms   []GatewayTransform `json:"gateway_transforms,omitempty"`
+	ClientMetadata      *ClientMetadata    `json:"client_metadata,omitempty"`
 	EncoreCloudAPI      *EncoreCloudAPI    `json:"ec_api,omitempty"` // If nil, the app is not running in Encore Cloud
 
-	SQLDatabases     []*SQLDatabase          `json:"sql_databases,omitempty"`
-	SQLServers       []*SQLServer            `json:"sql_servers,omitempty"`
-	PubsubProviders  []*PubsubProvider       `json:"pubsub_providers,omitempty"`
-	PubsubTopics     map[string]*PubsubTopic `json:"pubsub_topics,omitempty"`
-	RedisServers     []*RedisServer          `json:"redis_servers,omitempty"`
-	RedisDatabases   []*RedisDatabase        `json:"redis_databases,omitempty"`
-	BucketProviders  []*BucketProvider       `json:"bucket_providers,omitempty"`
-	Buckets          map[string]*Bucket      `json:"buckets,omitempty"`
-	Metrics          *Metrics                `json:"metrics,omitempty"`
-	Gateways         []Gateway               `json:"gateways,omitempty"`          // Gateways defines the gateways which should be served by the container
-	HostedServices   []string                `json:"hosted_services,omitempty"`   // List of services to be hosted within this container (zero length means all services, unless there's a gateway running)
-	ServiceDiscovery map[string]Service      `json:"service_discovery,omitempty"` // ServiceDiscovery lists where all the services are being hosted if not in this container
+	SQLDatabases     []*SQLDatabase           `json:"sql_databases,omitempty"`
+	SQLServers       []*SQLServer             `json:"sql_servers,omitempty"`
+	PubsubProviders  []*PubsubProvider        `json:"pubsub_providers,omitempty"`
+	PubsubTopics     map[string]*PubsubTopic  `json:"pubsub_topics,omitempty"`
+	RedisServers     []*RedisServer           `json:"redis_servers,omitempty"`
+	RedisDatabases   []*RedisDatabase         `json:"redis_databases,omitempty"`
+	BucketProviders  []*BucketProvider        `json:"bucket_providers,omitempty"`
+	Buckets          map[string]*Bucket       `json:"buckets,omitempty"`
+	SearchProviders  []*SearchProvider        `json:"search_providers,omitempty"`
+	SearchIndexes    map[string]*SearchIndex  `json:"search_indexes,omitempty"`
+	EncryptionKeys   []*EncryptionKey         `json:"encryption_keys,omitempty"`
+	InboundEmails    map[string]*InboundEmail `json:"inbound_emails,omitempty"`
+	Metrics          *Metrics                 `json:"metrics,omitempty"`
+	Gateways         []Gateway                `json:"gateways,omitempty"`          // Gateways defines the gateways which should be served by the container
+	HostedServices   []string                 `json:"hosted_services,omitempty"`   // List of services to be hosted within this container (zero length means all services, unless there's a gateway running)
+	ServiceDiscovery map[string]Service       `json:"service_discovery,omitempty"` // ServiceDiscovery lists where all the services are being hosted if not in this container
 
 	// ServiceAuth defines which authentication method can be used
 	// when talking to this runtime for internal service-to-service
@@ -205,6 +219,43 @@ type CORS struct {
 	AllowPrivateNetworkAccess bool `json:"allow_private_network_access,omitempty"`
 }
 
+// GatewayTransform describes a transformation the gateway applies
+// to requests to, and responses from, a set of endpoints.
+type GatewayTransform struct {
+	// Endpoints are the endpoints the transform applies to, in the form
+	// "service.endpoint". The endpoint may be "*" to match all endpoints
+	// in the service. If empty the transform applies to all endpoints.
+	Endpoints []string `json:"endpoints,omitempty"`
+
+	// RequestHeaders are headers to set on requests before
+	// they are forwarded to the endpoint.
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+
+	// ResponseHeaders are headers to set on responses before
+	// they are returned to the client.
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+
+	// StripResponseFields are top-level fields to remove from JSON response bodies.
+	StripResponseFields []string `json:"strip_response_fields,omitempty"`
+
+	// Compression are the compression algorithms ("gzip", "br" or "zstd")
+	// to use for response bodies, in order of preference.
+	Compression []string `json:"compression,omitempty"`
+}
+
+// ClientMetadata configures how the gateway enriches requests with
+// metadata about the client making them.
+type ClientMetadata struct {
+	// GeoIPDatabase is the path to a MaxMind DB file (such as GeoLite2 City)
+	// to look up the location of clients in. If empty no location is determined.
+	GeoIPDatabase string `json:"geoip_database,omitempty"`
+
+	// ClientIPHeader is the header to read the client's IP address from,
+	// such as "X-Forwarded-For" when running behind a load balancer.
+	// If empty the address of the connecting peer is used.
+	ClientIPHeader string `json:"client_ip_header,omitempty"`
+}
+
 type CommitInfo struct {
 	Revision    string `json:"revision"`
 	Uncommitted bool   `json:"uncommitted"`
@@ -335,12 +386,21 @@ type SQLServer struct {
 	// Valid formats are "hostname", "hostname:port", and "/path/to/unix.socket".
 	Host string `json:"host"`
 
+	// Engine is the database engine of the server, "postgres" or "mysql".
+	// If empty it defaults to "postgres".
+	Engine string `json:"engine,omitempty"`
+
 	// ServerCACert is the PEM-encoded server CA cert, or "" if not required.
 	ServerCACert string `json:"server_ca_cert,omitempty"`
 	// ClientCert is the PEM-encoded client cert, or "" if not required.
 	ClientCert string `json:"client_cert,omitempty"`
 	// ClientKey is the PEM-encoded client key, or "" if not required.
 	ClientKey string `json:"client_key,omitempty"`
+
+	// ReadReplicas are the read replicas of the server, which serve
+	// its databases to queries made through (*sqldb.Database).ReadOnly.
+	// Their Engine and ReadReplicas fields are unused.
+	ReadReplicas []*SQLServer `json:"read_replicas,omitempty"`
 }
 
 type SQLDatabase struct {
@@ -350,6 +410,12 @@ type SQLDatabase struct {
 	User         string `json:"user"`
 	Password     string `json:"password"`
 
+	// ReadOnlyUser and ReadOnlyPassword are the credentials services
+	// granted read access to the database connect with, if any.
+	// If empty they connect with User and Password.
+	ReadOnlyUser     string `json:"read_only_user,omitempty"`
+	ReadOnlyPassword string `json:"read_only_password,omitempty"`
+
 	// MinConnections is the minimum number of open connections to use
 	// for this database. If zero it defaults to 2.
 	MinConnections int `json:"min_connections"`
@@ -451,6 +517,83 @@ type Bucket struct {
 	PublicBaseURL string `json:"public_base_url"`
 }
 
+type SearchProvider struct {
+	Postgres    *PostgresSearchProvider `json:"postgres,omitempty"`    // set if the provider is Postgres
+	OpenSearch  *OpenSearchProvider     `json:"opensearch,omitempty"`  // set if the provider is OpenSearch
+	Meilisearch *MeilisearchProvider    `json:"meilisearch,omitempty"` // set if the provider is Meilisearch
+}
+
+// PostgresSearchProvider stores search indexes in a SQL database,
+// using Postgres full-text search.
+type PostgresSearchProvider struct {
+	// The Encore name of the database storing the indexes, in (*Runtime).SQLDatabases.
+	Database string `json:"database"`
+}
+
+type OpenSearchProvider struct {
+	URL string `json:"url"`
+
+	// The credentials to use for basic authentication, if any.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+type MeilisearchProvider struct {
+	URL    string `json:"url"`
+	APIKey string `json:"api_key,omitempty"`
+}
+
+type SearchIndex struct {
+	ProviderID int    `json:"provider_id"` // the index into (*Runtime).SearchProviders
+	EncoreName string `json:"encore_name"` // the Encore name for the index
+	CloudName  string `json:"cloud_name"`  // the cloud name for the index
+}
+
+// EncryptionKey is a key encrypting the data keys of encrypted data.
+//
+// The first key in (*Runtime).EncryptionKeys encrypts new data;
+// the others are only used to decrypt data, to support key rotation.
+type EncryptionKey struct {
+	Local  *LocalEncryptionKey `json:"local,omitempty"`   // set if the key is a local key
+	AWSKMS *AWSKMSKey          `json:"aws_kms,omitempty"` // set if the key is an AWS KMS key
+	GCPKMS *GCPKMSKey          `json:"gcp_kms,omitempty"` // set if the key is a GCP Cloud KMS key
+}
+
+// LocalEncryptionKey is a key held by the application itself.
+type LocalEncryptionKey struct {
+	// Key is the base64-encoded 256-bit AES key.
+	Key string `json:"key"`
+}
+
+type AWSKMSKey struct {
+	// KeyARN is the ARN of the symmetric KMS key.
+	KeyARN string `json:"key_arn"`
+}
+
+type GCPKMSKey struct {
+	// KeyName is the resource name of the symmetric Cloud KMS key,
+	// in the form "projects/*/locations/*/keyRings/*/cryptoKeys/*".
+	KeyName string `json:"key_name"`
+}
+
+// InboundEmail configures the email provider delivering the messages
+// of an inbound email address to the app's inbound webhook.
+type InboundEmail struct {
+	EncoreName string               `json:"encore_name"`       // the Encore name for the inbound email address
+	Mailgun    *MailgunInboundEmail `json:"mailgun,omitempty"` // set if the provider is Mailgun
+	SES        *SESInboundEmail     `json:"ses,omitempty"`     // set if the provider is Amazon SES
+}
+
+type MailgunInboundEmail struct {
+	// SigningKey is the HTTP webhook signing key webhook requests are signed with.
+	SigningKey string `json:"signing_key"`
+}
+
+type SESInboundEmail struct {
+	// TopicARN is the ARN of the SNS topic the SES receipt rule publishes messages to.
+	TopicARN string `json:"topic_arn"`
+}
+
 type Metrics struct {
 	CollectionInterval time.Duration                  `json:"collection_interval,omitempty"`
 	EncoreCloud        *GCPCloudMonitoringProvider    `json:"encore_cloud,omitempty"`
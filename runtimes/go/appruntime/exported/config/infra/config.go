@@ -1,6 +1,7 @@
 package infra
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +21,9 @@ type InfraConfig struct {
 	PubSub           []*PubSub                    `json:"pubsub,omitempty"`
 	Secrets          Secrets                      `json:"secrets,omitempty"`
 	ObjectStorage    []*ObjectStorage             `json:"object_storage,omitempty"`
+	Search           []*Search                    `json:"search,omitempty"`
+	EncryptionKeys   []*EncryptionKey             `json:"encryption_keys,omitempty"`
+	InboundEmail     map[string]*InboundEmail     `json:"inbound_email,omitempty"`
 
 	// Log configuration for the application.
 	// If empty it defaults to "trace".
@@ -35,6 +39,9 @@ type InfraConfig struct {
 	HostedServices []string `json:"hosted_services,omitempty"`
 	HostedGateways []string `json:"hosted_gateways,omitempty"`
 	CORS           *CORS    `json:"cors,omitempty"`
+
+	GatewayTransforms []*GatewayTransform `json:"gateway_transforms,omitempty"`
+	ClientMetadata    *ClientMetadata     `json:"client_metadata,omitempty"`
 }
 
 type ObjectStorage struct {
@@ -175,6 +182,14 @@ type Bucket struct {
 
 func (a *Bucket) Validate(v *validator) {
 	v.ValidateField("name", NotZero(a.Name))
+	if storage := Ancestor[*ObjectStorage](v); storage != nil {
+		switch storage.Type {
+		case "s3":
+			v.ValidateField("name", S3BucketName(a.Name))
+		case "gcs":
+			v.ValidateField("name", GCSBucketName(a.Name))
+		}
+	}
 
 	v.ValidateField("public_base_url", func() error {
 		if a.PublicBaseURL != "" {
@@ -186,6 +201,115 @@ func (a *Bucket) Validate(v *validator) {
 	})
 }
 
+// Search configures a search engine hosting search indexes.
+type Search struct {
+	// Type is the type of search engine: "postgres", "opensearch" or "meilisearch".
+	Type string `json:"type"`
+
+	// Database is the name of the database storing the indexes, for Postgres.
+	// It must be configured in sql_servers.
+	Database string `json:"database,omitempty"`
+
+	// URL is the base URL of the search engine, for OpenSearch and Meilisearch.
+	URL string `json:"url,omitempty"`
+
+	// The credentials to use for basic authentication with OpenSearch, if any.
+	Username string    `json:"username,omitempty"`
+	Password EnvString `json:"password,omitempty"`
+
+	// The API key to use with Meilisearch, if any.
+	APIKey EnvString `json:"api_key,omitempty"`
+
+	Indexes map[string]*SearchIndex `json:"indexes,omitempty"`
+}
+
+func (s *Search) Validate(v *validator) {
+	v.ValidateField("type", OneOf(s.Type, "postgres", "opensearch", "meilisearch"))
+	switch s.Type {
+	case "postgres":
+		v.ValidateField("database", NotZero(s.Database))
+	case "opensearch", "meilisearch":
+		v.ValidateField("url", func() error {
+			if _, err := url.ParseRequestURI(s.URL); err != nil {
+				return fmt.Errorf("Not a valid URL: %v", err)
+			}
+			return nil
+		})
+	}
+	if s.Username != "" {
+		v.ValidateEnvString("password", s.Password, "OpenSearch Password", NotZero[string])
+	}
+	ValidateChildMap(v, "indexes", s.Indexes)
+}
+
+type SearchIndex struct {
+	Name string `json:"name,omitempty"`
+}
+
+func (a *SearchIndex) Validate(v *validator) {
+	v.ValidateField("name", NotZero(a.Name))
+}
+
+// EncryptionKey configures a key encrypting the data keys of encrypted fields.
+// The first key encrypts new data; the others only decrypt existing data.
+type EncryptionKey struct {
+	// Type is the type of key: "local", "aws_kms" or "gcp_kms".
+	Type string `json:"type"`
+
+	// Key is the base64-encoded 256-bit AES key, for local keys.
+	Key EnvString `json:"key,omitempty"`
+
+	// KeyARN is the ARN of the symmetric key, for AWS KMS.
+	KeyARN string `json:"key_arn,omitempty"`
+
+	// KeyName is the resource name of the symmetric key, for GCP Cloud KMS.
+	KeyName string `json:"key_name,omitempty"`
+}
+
+func (k *EncryptionKey) Validate(v *validator) {
+	v.ValidateField("type", OneOf(k.Type, "local", "aws_kms", "gcp_kms"))
+	switch k.Type {
+	case "local":
+		v.ValidateEnvString("key", k.Key, "Encryption Key", func(key string) Predicate {
+			return func() error {
+				if b, err := base64.StdEncoding.DecodeString(key); err != nil || len(b) != 32 {
+					return errors.New("Must be a base64-encoded 256-bit key")
+				}
+				return nil
+			}
+		})
+	case "aws_kms":
+		v.ValidateField("key_arn", NotZero(k.KeyARN))
+		v.ValidateField("key_arn", AWSKMSKeyARN(k.KeyARN))
+	case "gcp_kms":
+		v.ValidateField("key_name", NotZero(k.KeyName))
+		v.ValidateField("key_name", GCPKMSKeyName(k.KeyName))
+	}
+}
+
+// InboundEmail configures the email provider delivering the messages
+// of an inbound email address.
+type InboundEmail struct {
+	// Type is the type of provider: "mailgun" or "ses".
+	Type string `json:"type"`
+
+	// SigningKey is the HTTP webhook signing key, for Mailgun.
+	SigningKey EnvString `json:"signing_key,omitempty"`
+
+	// TopicARN is the ARN of the SNS topic messages are published to, for Amazon SES.
+	TopicARN string `json:"topic_arn,omitempty"`
+}
+
+func (e *InboundEmail) Validate(v *validator) {
+	v.ValidateField("type", OneOf(e.Type, "mailgun", "ses"))
+	switch e.Type {
+	case "mailgun":
+		v.ValidateEnvString("signing_key", e.SigningKey, "Mailgun Signing Key", NotZero[string])
+	case "ses":
+		v.ValidateField("topic_arn", NotZero(e.TopicARN))
+	}
+}
+
 type Metadata struct {
 	AppID   string `json:"app_id,omitempty"`
 	EnvName string `json:"env_name,omitempty"`
@@ -203,11 +327,30 @@ type CORS struct {
 	AllowOriginsWithCredentials    []string `json:"allow_origins_with_credentials,omitempty"`
 }
 
+// Copy of the GatewayTransform struct from the appfile
+type GatewayTransform struct {
+	Endpoints           []string          `json:"endpoints,omitempty"`
+	RequestHeaders      map[string]string `json:"request_headers,omitempty"`
+	ResponseHeaders     map[string]string `json:"response_headers,omitempty"`
+	StripResponseFields []string          `json:"strip_response_fields,omitempty"`
+	Compression         []string          `json:"compression,omitempty"`
+}
+
+// ClientMetadata configures how the gateway enriches requests with
+// metadata about the client making them.
+type ClientMetadata struct {
+	GeoIPDatabase  string `json:"geoip_database,omitempty"`
+	ClientIPHeader string `json:"client_ip_header,omitempty"`
+}
+
 func (i *InfraConfig) Validate(v *validator) {
 	v.ValidateChild("graceful_shutdown", i.GracefulShutdown)
 	ValidateChildList(v, "auth", i.Auth)
 	ValidateChildMap(v, "service_discovery", i.ServiceDiscovery)
 	ValidateChildList(v, "object_storage", i.ObjectStorage)
+	ValidateChildList(v, "search", i.Search)
+	ValidateChildList(v, "encryption_keys", i.EncryptionKeys)
+	ValidateChildMap(v, "inbound_email", i.InboundEmail)
 	v.ValidateChild("metrics", i.Metrics)
 	ValidateChildList(v, "sql_servers", i.SQLServers)
 	ValidateChildMap(v, "redis", i.Redis)
@@ -446,6 +589,7 @@ type GCPCloudMonitoring struct {
 
 func (g *GCPCloudMonitoring) Validate(v *validator) {
 	v.ValidateField("project_id", NotZero(g.ProjectID))
+	v.ValidateField("project_id", GCPProjectID(g.ProjectID))
 	v.ValidateField("monitored_resource_type", NotZero(g.MonitoredResourceType))
 }
 
@@ -456,18 +600,38 @@ type AWSCloudWatch struct {
 
 func (a *AWSCloudWatch) Validate(v *validator) {
 	v.ValidateField("namespace", NotZero(a.Namespace))
+	v.ValidateField("namespace", CloudWatchNamespace(a.Namespace))
 }
 
 type SQLServer struct {
-	Host      string                  `json:"host,omitempty"`
-	TLSConfig *TLSConfig              `json:"tls_config,omitempty"`
-	Databases map[string]*SQLDatabase `json:"databases,omitempty"`
+	Host         string                  `json:"host,omitempty"`
+	Engine       string                  `json:"engine,omitempty"`
+	TLSConfig    *TLSConfig              `json:"tls_config,omitempty"`
+	Databases    map[string]*SQLDatabase `json:"databases,omitempty"`
+	ReadReplicas []*SQLReadReplica       `json:"read_replicas,omitempty"`
 }
 
 func (s *SQLServer) Validate(v *validator) {
 	v.ValidateField("host", NotZero(s.Host))
+	v.ValidateField("engine", OneOf(s.Engine, "", "postgres", "mysql"))
 	v.ValidateChild("tls_config", s.TLSConfig)
 	ValidateChildMap(v, "databases", s.Databases)
+	ValidateChildList(v, "read_replicas", s.ReadReplicas)
+}
+
+// SQLReadReplica is a read replica of a SQL server. It serves the
+// same databases as the server, accessed with the same credentials.
+type SQLReadReplica struct {
+	Host string `json:"host,omitempty"`
+
+	// TLSConfig is the TLS configuration for connecting to the replica.
+	// If nil the server's TLS configuration is used.
+	TLSConfig *TLSConfig `json:"tls_config,omitempty"`
+}
+
+func (r *SQLReadReplica) Validate(v *validator) {
+	v.ValidateField("host", NotZero(r.Host))
+	v.ValidateChild("tls_config", r.TLSConfig)
 }
 
 type TLSConfig struct {
@@ -492,9 +656,15 @@ type SQLDatabase struct {
 }
 
 func (s *SQLDatabase) Validate(v *validator) {
+	v.ValidateField("name", PostgresIdent(s.Name))
 	v.ValidateField("max_connections", GreaterOrEqual(s.MinConnections)(s.MaxConnections))
 	v.ValidateField("min_connections", GreaterOrEqual(0)(s.MinConnections))
-	v.ValidateEnvString("username", s.Username, "Database Username", NotZero[string])
+	v.ValidateEnvString("username", s.Username, "Database Username", func(name string) Predicate {
+		if name == "" {
+			return NotZero(name)
+		}
+		return PostgresIdent(name)
+	})
 	v.ValidateEnvString("password", s.Password, "Database Password", NotZero[string])
 	v.ValidateChild("client_cert", s.ClientCert)
 }
@@ -613,6 +783,7 @@ type GCPPubsub struct {
 }
 
 func (g *GCPPubsub) Validate(v *validator) {
+	v.ValidateField("project_id", GCPProjectID(g.ProjectID))
 	ValidateChildMap(v, "topics", g.Topics)
 }
 
@@ -634,8 +805,10 @@ type GCPTopic struct {
 
 func (g *GCPTopic) Validate(v *validator) {
 	v.ValidateField("name", NotZero(g.Name))
+	v.ValidateField("name", GCPPubSubName(g.Name))
 	pubsub := Ancestor[*PubSub](v)
 	v.ValidateField("project_id", AnyNonZero(g.ProjectID, pubsub.GCP.ProjectID))
+	v.ValidateField("project_id", GCPProjectID(g.ProjectID))
 	ValidateChildMap(v, "subscriptions", g.Subscriptions)
 }
 
@@ -657,8 +830,10 @@ type GCPSub struct {
 
 func (g *GCPSub) Validate(v *validator) {
 	v.ValidateField("name", NotZero(g.Name))
+	v.ValidateField("name", GCPPubSubName(g.Name))
 	pubsub := Ancestor[*PubSub](v)
 	v.ValidateField("project_id", AnyNonZero(g.ProjectID, pubsub.GCP.ProjectID))
+	v.ValidateField("project_id", GCPProjectID(g.ProjectID))
 	v.ValidateChild("push_config", g.PushConfig)
 }
 
@@ -670,6 +845,7 @@ type PushConfig struct {
 
 func (p *PushConfig) Validate(v *validator) {
 	v.ValidateField("service_account", NotZero(p.ServiceAccount))
+	v.ValidateField("service_account", GCPServiceAccount(p.ServiceAccount))
 	v.ValidateField("jwt_audience", NotZero(p.JWTAudience))
 	v.ValidateField("id", NotZero(p.ID))
 }
@@ -700,6 +876,7 @@ type AWSTopic struct {
 
 func (a *AWSTopic) Validate(v *validator) {
 	v.ValidateField("arn", NotZero(a.ARN))
+	v.ValidateField("arn", AWSSNSTopicARN(a.ARN))
 	ValidateChildMap(v, "subscriptions", a.Subscriptions)
 }
 
@@ -719,6 +896,7 @@ type AWSSub struct {
 
 func (a *AWSSub) Validate(v *validator) {
 	v.ValidateField("url", NotZero(a.URL))
+	v.ValidateField("url", AWSSQSQueueURL(a.URL))
 }
 
 // NSQPubsub specific configuration.
@@ -749,6 +927,7 @@ type NSQTopic struct {
 
 func (n *NSQTopic) Validate(v *validator) {
 	v.ValidateField("name", NotZero(n.Name))
+	v.ValidateField("name", NSQName(n.Name))
 	ValidateChildMap(v, "subscriptions", n.Subscriptions)
 }
 
@@ -768,6 +947,7 @@ type NSQSub struct {
 
 func (n *NSQSub) Validate(v *validator) {
 	v.ValidateField("name", NotZero(n.Name))
+	v.ValidateField("name", NSQName(n.Name))
 }
 
 // MarshalJSON custom marshaller for PubSub.
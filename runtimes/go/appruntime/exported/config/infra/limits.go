@@ -0,0 +1,223 @@
+package infra
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// This file contains predicates checking names and identifiers against the
+// constraints of the cloud providers, so invalid configs are reported when
+// building rather than when deploying or at runtime.
+
+// maxPostgresIdentLen is the maximum length of Postgres identifiers,
+// such as database and role names. Longer identifiers are truncated.
+const maxPostgresIdentLen = 63
+
+// PostgresIdent checks that s is a valid Postgres database or role name.
+func PostgresIdent(s string) Predicate {
+	return func() error {
+		if len(s) > maxPostgresIdentLen {
+			return fmt.Errorf("Must be at most %d bytes long, as Postgres truncates longer names (got %d)", maxPostgresIdentLen, len(s))
+		}
+		return nil
+	}
+}
+
+var (
+	s3BucketRe  = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`)
+	gcsBucketRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*[a-z0-9]$`)
+)
+
+// S3BucketName checks that s is a valid S3 bucket name.
+// See https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucketnamingrules.html.
+func S3BucketName(s string) Predicate {
+	return func() error {
+		switch {
+		case s == "":
+			return nil
+		case len(s) < 3 || len(s) > 63:
+			return fmt.Errorf("S3 bucket names must be between 3 and 63 characters long (got %d)", len(s))
+		case !s3BucketRe.MatchString(s):
+			return errors.New("S3 bucket names can only contain lowercase letters, numbers, dots and hyphens, and must begin and end with a letter or number")
+		case strings.Contains(s, ".."):
+			return errors.New("S3 bucket names must not contain two adjacent dots")
+		case net.ParseIP(s) != nil:
+			return errors.New("S3 bucket names must not be formatted as IP addresses")
+		case strings.HasPrefix(s, "xn--") || strings.HasPrefix(s, "sthree-"):
+			return errors.New(`S3 bucket names must not start with "xn--" or "sthree-"`)
+		case strings.HasSuffix(s, "-s3alias") || strings.HasSuffix(s, "--ol-s3"):
+			return errors.New(`S3 bucket names must not end with "-s3alias" or "--ol-s3"`)
+		}
+		return nil
+	}
+}
+
+// GCSBucketName checks that s is a valid Google Cloud Storage bucket name.
+// See https://cloud.google.com/storage/docs/buckets#naming.
+func GCSBucketName(s string) Predicate {
+	return func() error {
+		switch {
+		case s == "":
+			return nil
+		case !gcsBucketRe.MatchString(s):
+			return errors.New("GCS bucket names can only contain lowercase letters, numbers, dots, hyphens and underscores, and must begin and end with a letter or number")
+		case !strings.Contains(s, ".") && (len(s) < 3 || len(s) > 63):
+			return fmt.Errorf("GCS bucket names must be between 3 and 63 characters long (got %d)", len(s))
+		case len(s) > 222:
+			return fmt.Errorf("GCS bucket names containing dots must be at most 222 characters long (got %d)", len(s))
+		case net.ParseIP(s) != nil:
+			return errors.New("GCS bucket names must not be formatted as IP addresses")
+		case strings.HasPrefix(s, "goog") || strings.Contains(s, "google") || strings.Contains(s, "g00gle"):
+			return errors.New(`GCS bucket names must not start with "goog" or contain "google"`)
+		}
+		for _, part := range strings.Split(s, ".") {
+			if len(part) > 63 {
+				return fmt.Errorf("Each dot-separated part of GCS bucket names must be at most 63 characters long (got %d)", len(part))
+			}
+		}
+		return nil
+	}
+}
+
+var (
+	gcpProjectIDRe    = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+	gcpPubSubNameRe   = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9._~+%-]{2,254}$`)
+	gcpServiceAcctRe  = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]@[a-z0-9.-]+$`)
+	gcpKMSKeyNameRe   = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+	awsSNSTopicARNRe  = regexp.MustCompile(`^arn:aws[a-z-]*:sns:[a-z0-9-]+:\d{12}:([^:]+)$`)
+	awsSNSTopicNameRe = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,256}$`)
+	awsSQSQueueNameRe = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,80}$`)
+	awsKMSKeyARNRe    = regexp.MustCompile(`^arn:aws[a-z-]*:kms:[a-z0-9-]+:\d{12}:(key|alias)/.+$`)
+	cloudWatchNSRe    = regexp.MustCompile(`^[a-zA-Z0-9.\-_/#:]{1,255}$`)
+	nsqNameRe         = regexp.MustCompile(`^[.a-zA-Z0-9_-]+(#ephemeral)?$`)
+)
+
+// GCPProjectID checks that s is a valid GCP project ID.
+func GCPProjectID(s string) Predicate {
+	return func() error {
+		if s != "" && !gcpProjectIDRe.MatchString(s) {
+			return errors.New("GCP project IDs must be 6 to 30 lowercase letters, digits or hyphens, start with a letter and not end with a hyphen")
+		}
+		return nil
+	}
+}
+
+// GCPPubSubName checks that s is a valid GCP Pub/Sub topic or subscription name.
+// See https://cloud.google.com/pubsub/docs/create-topic#resource_names.
+func GCPPubSubName(s string) Predicate {
+	return func() error {
+		switch {
+		case s == "":
+			return nil
+		case strings.HasPrefix(s, "goog"):
+			return errors.New(`GCP Pub/Sub names must not start with "goog"`)
+		case len(s) < 3 || len(s) > 255:
+			return fmt.Errorf("GCP Pub/Sub names must be between 3 and 255 characters long (got %d)", len(s))
+		case !gcpPubSubNameRe.MatchString(s):
+			return errors.New("GCP Pub/Sub names must start with a letter and only contain letters, numbers and the characters - _ . ~ + %")
+		}
+		return nil
+	}
+}
+
+// GCPServiceAccount checks that s is a valid GCP service account email.
+func GCPServiceAccount(s string) Predicate {
+	return func() error {
+		if s != "" && !gcpServiceAcctRe.MatchString(s) {
+			return errors.New("Must be a service account email, such as name@project.iam.gserviceaccount.com, whose name is 6 to 30 lowercase letters, digits or hyphens")
+		}
+		return nil
+	}
+}
+
+// GCPKMSKeyName checks that s is the resource name of a GCP Cloud KMS key.
+func GCPKMSKeyName(s string) Predicate {
+	return func() error {
+		if s != "" && !gcpKMSKeyNameRe.MatchString(s) {
+			return errors.New("Must be a key resource name: projects/PROJECT/locations/LOCATION/keyRings/RING/cryptoKeys/KEY")
+		}
+		return nil
+	}
+}
+
+// AWSSNSTopicARN checks that s is a valid SNS topic ARN.
+func AWSSNSTopicARN(s string) Predicate {
+	return func() error {
+		if s == "" {
+			return nil
+		}
+		m := awsSNSTopicARNRe.FindStringSubmatch(s)
+		if m == nil {
+			return errors.New("Must be an SNS topic ARN: arn:aws:sns:REGION:ACCOUNT_ID:TOPIC_NAME")
+		}
+		if name := strings.TrimSuffix(m[1], ".fifo"); !awsSNSTopicNameRe.MatchString(name) {
+			return errors.New("SNS topic names must be 1 to 256 letters, numbers, hyphens or underscores, optionally followed by .fifo")
+		}
+		return nil
+	}
+}
+
+// AWSSQSQueueURL checks that s is a valid SQS queue URL.
+func AWSSQSQueueURL(s string) Predicate {
+	return func() error {
+		if s == "" {
+			return nil
+		}
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return errors.New("Must be an SQS queue URL: https://sqs.REGION.amazonaws.com/ACCOUNT_ID/QUEUE_NAME")
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) != 2 {
+			return errors.New("Must be an SQS queue URL: https://sqs.REGION.amazonaws.com/ACCOUNT_ID/QUEUE_NAME")
+		}
+		if name := strings.TrimSuffix(parts[1], ".fifo"); !awsSQSQueueNameRe.MatchString(name) {
+			return errors.New("SQS queue names must be 1 to 80 letters, numbers, hyphens or underscores, optionally followed by .fifo")
+		}
+		return nil
+	}
+}
+
+// AWSKMSKeyARN checks that s is a valid KMS key or alias ARN.
+func AWSKMSKeyARN(s string) Predicate {
+	return func() error {
+		if s != "" && !awsKMSKeyARNRe.MatchString(s) {
+			return errors.New("Must be a KMS key ARN: arn:aws:kms:REGION:ACCOUNT_ID:key/KEY_ID")
+		}
+		return nil
+	}
+}
+
+// CloudWatchNamespace checks that s is a valid CloudWatch metrics namespace.
+func CloudWatchNamespace(s string) Predicate {
+	return func() error {
+		switch {
+		case s == "":
+			return nil
+		case !cloudWatchNSRe.MatchString(s):
+			return errors.New("CloudWatch namespaces must be 1 to 255 letters, numbers or the characters . - _ / # :")
+		case strings.HasPrefix(s, "AWS/"):
+			return errors.New(`CloudWatch namespaces must not start with "AWS/", which is reserved for AWS services`)
+		}
+		return nil
+	}
+}
+
+// NSQName checks that s is a valid NSQ topic or channel name.
+func NSQName(s string) Predicate {
+	return func() error {
+		switch {
+		case s == "":
+			return nil
+		case len(s) > 64:
+			return fmt.Errorf("NSQ names must be at most 64 characters long (got %d)", len(s))
+		case !nsqNameRe.MatchString(s):
+			return errors.New("NSQ names can only contain letters, numbers and the characters . _ -, optionally followed by #ephemeral")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,105 @@
+package infra
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		pred  func(string) Predicate
+		valid []string
+		inval []string
+	}{
+		{
+			name:  "PostgresIdent",
+			pred:  PostgresIdent,
+			valid: []string{"", "users", strings.Repeat("a", 63)},
+			inval: []string{strings.Repeat("a", 64)},
+		},
+		{
+			name:  "S3BucketName",
+			pred:  S3BucketName,
+			valid: []string{"", "my-bucket", "my.bucket.1"},
+			inval: []string{"ab", "My-Bucket", "-bucket", "my..bucket", "192.168.1.1", "xn--bucket", "bucket-s3alias", strings.Repeat("a", 64)},
+		},
+		{
+			name:  "GCSBucketName",
+			pred:  GCSBucketName,
+			valid: []string{"", "my_bucket", "assets.example.com"},
+			inval: []string{"ab", "Bucket", "goog-bucket", "my-google-bucket", strings.Repeat("a", 64)},
+		},
+		{
+			name:  "GCPProjectID",
+			pred:  GCPProjectID,
+			valid: []string{"", "my-project-123"},
+			inval: []string{"proj", "My-Project", "1project", "project-", strings.Repeat("a", 31)},
+		},
+		{
+			name:  "GCPPubSubName",
+			pred:  GCPPubSubName,
+			valid: []string{"", "orders", "orders.created-v1"},
+			inval: []string{"ab", "1orders", "goog-orders", "orders/created", strings.Repeat("a", 256)},
+		},
+		{
+			name:  "AWSSNSTopicARN",
+			pred:  AWSSNSTopicARN,
+			valid: []string{"", "arn:aws:sns:us-east-1:123456789012:orders", "arn:aws:sns:us-east-1:123456789012:orders.fifo"},
+			inval: []string{"orders", "arn:aws:sns:us-east-1:1234:orders", "arn:aws:sns:us-east-1:123456789012:" + strings.Repeat("a", 257)},
+		},
+		{
+			name:  "AWSSQSQueueURL",
+			pred:  AWSSQSQueueURL,
+			valid: []string{"", "https://sqs.us-east-1.amazonaws.com/123456789012/orders"},
+			inval: []string{"orders", "https://sqs.us-east-1.amazonaws.com/orders", "https://sqs.us-east-1.amazonaws.com/123456789012/" + strings.Repeat("a", 81)},
+		},
+		{
+			name:  "CloudWatchNamespace",
+			pred:  CloudWatchNamespace,
+			valid: []string{"", "MyApp/Prod"},
+			inval: []string{"AWS/MyApp", "my app"},
+		},
+		{
+			name:  "NSQName",
+			pred:  NSQName,
+			valid: []string{"", "orders", "orders#ephemeral"},
+			inval: []string{"orders/created", strings.Repeat("a", 65)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			for _, s := range tt.valid {
+				c.Check(tt.pred(s)(), qt.IsNil, qt.Commentf("value %q", s))
+			}
+			for _, s := range tt.inval {
+				c.Check(tt.pred(s)(), qt.IsNotNil, qt.Commentf("value %q", s))
+			}
+		})
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	c := qt.New(t)
+	data, err := os.ReadFile("testdata/infra.config.json")
+	c.Assert(err, qt.IsNil)
+	var config InfraConfig
+	c.Assert(json.Unmarshal(data, &config), qt.IsNil)
+
+	// The test config is within the provider limits.
+	_, errs := Validate(&config)
+	c.Assert(errs, qt.HasLen, 0)
+
+	// Invalid names are reported at their path.
+	config.PubSub[0].GCP.Topics["encore-topic"].Name = "goog-topic"
+	_, errs = Validate(&config)
+	c.Assert(errs, qt.HasLen, 1)
+	for path := range errs {
+		c.Assert(strings.HasSuffix(path.String(), ".name"), qt.IsTrue, qt.Commentf("path %s", path))
+	}
+}
@@ -230,14 +230,14 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 	cfg.SQLServers = make([]*SQLServer, len(infraCfg.SQLServers))
 	for i, sqlServer := range infraCfg.SQLServers {
 		cfg.SQLServers[i] = &SQLServer{
-			Host: sqlServer.Host,
+			Host:   sqlServer.Host,
+			Engine: sqlServer.Engine,
 		}
-		if sqlServer.TLSConfig != nil && !sqlServer.TLSConfig.Disabled {
-			cfg.SQLServers[i].ServerCACert = sqlServer.TLSConfig.CA
-			if sqlServer.TLSConfig.ClientCert != nil {
-				cfg.SQLServers[i].ClientCert = sqlServer.TLSConfig.ClientCert.Cert
-				cfg.SQLServers[i].ClientKey = sqlServer.TLSConfig.ClientCert.Key.Value()
-			}
+		setSQLServerTLS(cfg.SQLServers[i], sqlServer.TLSConfig)
+		for _, replica := range sqlServer.ReadReplicas {
+			r := &SQLServer{Host: replica.Host}
+			setSQLServerTLS(r, orDefault(replica.TLSConfig, sqlServer.TLSConfig))
+			cfg.SQLServers[i].ReadReplicas = append(cfg.SQLServers[i].ReadReplicas, r)
 		}
 
 		for dbName, db := range sqlServer.Databases {
@@ -413,6 +413,71 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 		}
 	}
 
+	// Map Search indexes
+	cfg.SearchProviders = make([]*SearchProvider, len(infraCfg.Search))
+	cfg.SearchIndexes = map[string]*SearchIndex{}
+	for i, search := range infraCfg.Search {
+		switch search.Type {
+		case "postgres":
+			cfg.SearchProviders[i] = &SearchProvider{
+				Postgres: &PostgresSearchProvider{Database: search.Database},
+			}
+		case "opensearch":
+			cfg.SearchProviders[i] = &SearchProvider{
+				OpenSearch: &OpenSearchProvider{
+					URL:      search.URL,
+					Username: search.Username,
+					Password: search.Password.Value(),
+				},
+			}
+		case "meilisearch":
+			cfg.SearchProviders[i] = &SearchProvider{
+				Meilisearch: &MeilisearchProvider{
+					URL:    search.URL,
+					APIKey: search.APIKey.Value(),
+				},
+			}
+		}
+		for indexName, index := range search.Indexes {
+			cfg.SearchIndexes[indexName] = &SearchIndex{
+				ProviderID: i,
+				EncoreName: indexName,
+				CloudName:  index.Name,
+			}
+		}
+	}
+
+	// Map encryption keys
+	for _, key := range infraCfg.EncryptionKeys {
+		switch key.Type {
+		case "local":
+			cfg.EncryptionKeys = append(cfg.EncryptionKeys, &EncryptionKey{
+				Local: &LocalEncryptionKey{Key: key.Key.Value()},
+			})
+		case "aws_kms":
+			cfg.EncryptionKeys = append(cfg.EncryptionKeys, &EncryptionKey{
+				AWSKMS: &AWSKMSKey{KeyARN: key.KeyARN},
+			})
+		case "gcp_kms":
+			cfg.EncryptionKeys = append(cfg.EncryptionKeys, &EncryptionKey{
+				GCPKMS: &GCPKMSKey{KeyName: key.KeyName},
+			})
+		}
+	}
+
+	// Map inbound email addresses
+	cfg.InboundEmails = map[string]*InboundEmail{}
+	for name, inbound := range infraCfg.InboundEmail {
+		ie := &InboundEmail{EncoreName: name}
+		switch inbound.Type {
+		case "mailgun":
+			ie.Mailgun = &MailgunInboundEmail{SigningKey: inbound.SigningKey.Value()}
+		case "ses":
+			ie.SES = &SESInboundEmail{TopicARN: inbound.TopicARN}
+		}
+		cfg.InboundEmails[name] = ie
+	}
+
 	if infraCfg.CORS != nil {
 		cfg.CORS = &CORS{
 			Debug:                          infraCfg.CORS.Debug,
@@ -424,6 +489,15 @@ func parseInfraConfigEnv(infraCfgPath string) *Runtime {
 			AllowPrivateNetworkAccess:      true,
 		}
 	}
+	for _, t := range infraCfg.GatewayTransforms {
+		cfg.GatewayTransforms = append(cfg.GatewayTransforms, GatewayTransform(*t))
+	}
+	if m := infraCfg.ClientMetadata; m != nil {
+		cfg.ClientMetadata = &ClientMetadata{
+			GeoIPDatabase:  m.GeoIPDatabase,
+			ClientIPHeader: m.ClientIPHeader,
+		}
+	}
 	// Map hosted services
 	cfg.HostedServices = infraCfg.HostedServices
 	cfg.Gateways = make([]Gateway, len(infraCfg.HostedGateways))
@@ -444,6 +518,17 @@ func nilOr[T comparable](val T) *T {
 
 }
 
+// setSQLServerTLS sets the TLS fields of srv from tls, which may be nil.
+func setSQLServerTLS(srv *SQLServer, tls *infra.TLSConfig) {
+	if tls != nil && !tls.Disabled {
+		srv.ServerCACert = tls.CA
+		if tls.ClientCert != nil {
+			srv.ClientCert = tls.ClientCert.Cert
+			srv.ClientKey = tls.ClientCert.Key.Value()
+		}
+	}
+}
+
 func orDefaultPtr[T any](val *T, def T) T {
 	if val == nil {
 		return def
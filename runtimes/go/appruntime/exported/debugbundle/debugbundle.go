@@ -0,0 +1,219 @@
+// Package debugbundle defines debug bundles: the state observed by a failed
+// request, captured so it can be inspected after the fact.
+//
+// When enabled, each request records the database queries it makes along with
+// the rows it reads, and the Pub/Sub messages it publishes. If the request fails
+// the recorded state is combined with the request payload and error into a Bundle.
+package debugbundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// EndpointEnvVar is the environment variable holding the URL to submit
+// debug bundles to. Debug bundles are only captured if it is set.
+const EndpointEnvVar = "ENCORE_DEBUG_BUNDLE_ENDPOINT"
+
+const (
+	// MaxQueries is the maximum number of queries captured per request.
+	MaxQueries = 100
+	// MaxRows is the maximum number of rows captured per query.
+	MaxRows = 20
+	// MaxEvents is the maximum number of published messages captured per request.
+	MaxEvents = 100
+)
+
+// Bundle is the state observed by a failed request.
+type Bundle struct {
+	TraceID  string    `json:"trace_id"`
+	SpanID   string    `json:"span_id"`
+	Time     time.Time `json:"time"`
+	Duration Duration  `json:"duration"`
+
+	Service      string `json:"service"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	Topic        string `json:"topic,omitempty"`
+	Subscription string `json:"subscription,omitempty"`
+
+	// Payload is the JSON-encoded request payload, or Pub/Sub message, if any.
+	Payload json.RawMessage `json:"payload,omitempty"`
+
+	// Code and Error describe the error the request failed with.
+	Code  string `json:"code"`
+	Error string `json:"error"`
+
+	Queries []Query `json:"queries"`
+	Events  []Event `json:"events"`
+
+	// Truncated is true if queries or events were dropped
+	// because the request exceeded MaxQueries or MaxEvents.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Query is a database query made by a request.
+type Query struct {
+	Database string   `json:"database"`
+	Query    string   `json:"query"`
+	Args     []any    `json:"args,omitempty"`
+	Columns  []string `json:"columns,omitempty"`
+	Rows     [][]any  `json:"rows,omitempty"`
+	Error    string   `json:"error,omitempty"`
+
+	// Truncated is true if rows were dropped because
+	// the query read more than MaxRows rows.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Event is a Pub/Sub message published by a request.
+type Event struct {
+	Topic     string          `json:"topic"`
+	MessageID string          `json:"message_id,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Duration is a time.Duration that is encoded as a string, such as "1.5s".
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	*d = Duration(v)
+	return err
+}
+
+// Capture records the state observed by a request.
+// A nil *Capture records nothing.
+type Capture struct {
+	mu        sync.Mutex
+	queries   []*Query
+	events    []Event
+	truncated bool
+}
+
+// CapturedQuery is a query being recorded by a Capture.
+// A nil *CapturedQuery records nothing.
+type CapturedQuery struct {
+	c *Capture
+	q *Query
+}
+
+// Query records a query made against the given database.
+func (c *Capture) Query(database, query string, args []any) *CapturedQuery {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.queries) >= MaxQueries {
+		c.truncated = true
+		return nil
+	}
+	q := &Query{Database: database, Query: query}
+	for _, arg := range args {
+		q.Args = append(q.Args, jsonValue(arg))
+	}
+	c.queries = append(c.queries, q)
+	return &CapturedQuery{c: c, q: q}
+}
+
+// Publish records a published Pub/Sub message.
+func (c *Capture) Publish(topic, messageID string, payload []byte, err error) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.events) >= MaxEvents {
+		c.truncated = true
+		return
+	}
+	ev := Event{Topic: topic, MessageID: messageID}
+	if json.Valid(payload) {
+		ev.Payload = payload
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	c.events = append(c.events, ev)
+}
+
+// Row records a row read by the query. The columns are only
+// requested for the first row.
+func (cq *CapturedQuery) Row(columns func() []string, values []any) {
+	if cq == nil {
+		return
+	}
+	cq.c.mu.Lock()
+	defer cq.c.mu.Unlock()
+	q := cq.q
+	if len(q.Rows) >= MaxRows {
+		q.Truncated = true
+		return
+	}
+	if q.Columns == nil {
+		q.Columns = columns()
+	}
+	row := make([]any, len(values))
+	for i, v := range values {
+		row[i] = jsonValue(v)
+	}
+	q.Rows = append(q.Rows, row)
+}
+
+// Err records the error the query failed with, if any.
+func (cq *CapturedQuery) Err(err error) {
+	if cq == nil || err == nil {
+		return
+	}
+	cq.c.mu.Lock()
+	defer cq.c.mu.Unlock()
+	cq.q.Error = err.Error()
+}
+
+// Fill fills in the queries and events recorded by c.
+func (c *Capture) Fill(b *Bundle) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b.Queries = make([]Query, len(c.queries))
+	for i, q := range c.queries {
+		b.Queries[i] = *q
+	}
+	b.Events = append([]Event(nil), c.events...)
+	b.Truncated = c.truncated
+}
+
+// jsonValue converts a database value to a value that encodes well as JSON.
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case nil, bool, string, time.Time,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return v
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return v // encoded as base64
+	case [16]byte:
+		// UUIDs are decoded as [16]byte.
+		return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
+	case fmt.Stringer:
+		return v.String()
+	}
+	if _, err := json.Marshal(v); err == nil {
+		return v
+	}
+	return fmt.Sprint(v)
+}
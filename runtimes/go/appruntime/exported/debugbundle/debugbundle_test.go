@@ -0,0 +1,64 @@
+package debugbundle
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCapture(t *testing.T) {
+	c := qt.New(t)
+
+	capture := &Capture{}
+	q := capture.Query("todo", "SELECT id, title FROM todo WHERE done = $1", []any{false})
+	cols := func() []string { return []string{"id", "title"} }
+	for i := 0; i < MaxRows+5; i++ {
+		q.Row(cols, []any{i, []byte("buy milk")})
+	}
+	capture.Query("todo", "DELETE FROM todo", nil).Err(errors.New("permission denied"))
+	capture.Publish("todo-deleted", "msg-1", []byte(`{"id":1}`), nil)
+
+	var b Bundle
+	capture.Fill(&b)
+	c.Assert(b.Queries, qt.HasLen, 2)
+	c.Assert(b.Queries[0].Columns, qt.DeepEquals, []string{"id", "title"})
+	c.Assert(b.Queries[0].Rows, qt.HasLen, MaxRows)
+	c.Assert(b.Queries[0].Rows[1], qt.DeepEquals, []any{1, "buy milk"})
+	c.Assert(b.Queries[0].Truncated, qt.IsTrue)
+	c.Assert(b.Queries[1].Error, qt.Equals, "permission denied")
+	c.Assert(b.Events, qt.DeepEquals, []Event{{Topic: "todo-deleted", MessageID: "msg-1", Payload: json.RawMessage(`{"id":1}`)}})
+	c.Assert(b.Truncated, qt.IsFalse)
+}
+
+func TestCaptureLimits(t *testing.T) {
+	c := qt.New(t)
+
+	capture := &Capture{}
+	for i := 0; i < MaxQueries+1; i++ {
+		capture.Query("db", "SELECT 1", nil)
+	}
+	var b Bundle
+	capture.Fill(&b)
+	c.Assert(b.Queries, qt.HasLen, MaxQueries)
+	c.Assert(b.Truncated, qt.IsTrue)
+}
+
+func TestNilCapture(t *testing.T) {
+	var capture *Capture
+	q := capture.Query("db", "SELECT 1", nil)
+	q.Row(func() []string { return nil }, []any{1})
+	q.Err(errors.New("boom"))
+	capture.Publish("topic", "", nil, nil)
+	capture.Fill(&Bundle{})
+}
+
+func TestJSONValue(t *testing.T) {
+	c := qt.New(t)
+	uuid := [16]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}
+	c.Assert(jsonValue(uuid), qt.Equals, "12345678-9abc-def0-1234-56789abcdef0")
+	c.Assert(jsonValue([]byte("text")), qt.Equals, "text")
+	c.Assert(jsonValue([]byte{0xff, 0xfe}), qt.DeepEquals, []byte{0xff, 0xfe})
+	c.Assert(jsonValue(make(chan int)), qt.Matches, "0x.*")
+}
@@ -3,11 +3,14 @@ package model
 import (
 	"context"
 	"net/http"
+	"net/netip"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"encore.dev/appruntime/exported/debugbundle"
 	"encore.dev/appruntime/exported/scrub"
 	"github.com/rs/zerolog"
 )
@@ -64,6 +67,14 @@ type Request struct {
 	Traced bool
 	DefLoc uint32
 
+	// Deadline is the deadline of the context the request was started with,
+	// or the zero time if it had none.
+	Deadline time.Time
+
+	// CancelCause reports why the context the request was started with
+	// was canceled, or nil if it is not canceled. It may itself be nil.
+	CancelCause func() error
+
 	// SvcNum is the 1-based index of the service into the service list.
 	// It's here instead of within RPCData/MsgData/Test for performance.
 	SvcNum uint16
@@ -76,6 +87,10 @@ type Request struct {
 
 	// If we're running a test, this contains the test information.
 	Test *TestData
+
+	// Debug records the state observed by the request,
+	// if debug bundles are enabled. It is nil otherwise.
+	Debug *debugbundle.Capture
 }
 
 // Service reports the current service, if any.
@@ -130,6 +145,38 @@ type RPCData struct {
 
 	// Mocked is true if the request was handled by a mock.
 	Mocked bool
+
+	// Client is the metadata about the client that made the request,
+	// as determined by the API gateway. It is nil if the gateway
+	// does not enrich requests with client metadata.
+	Client *ClientInfo
+}
+
+// ClientInfo describes the client that made a request.
+type ClientInfo struct {
+	IP        netip.Addr `json:"ip"`
+	Geo       *GeoInfo   `json:"geo,omitempty"` // nil if no location is known for the IP
+	UserAgent UserAgent  `json:"user_agent"`
+}
+
+// GeoInfo describes the location of a client, based on its IP address.
+type GeoInfo struct {
+	CountryCode string  `json:"country_code,omitempty"` // ISO 3166-1 alpha-2 code
+	Country     string  `json:"country,omitempty"`      // English name
+	Region      string  `json:"region,omitempty"`       // English name of the largest subdivision
+	City        string  `json:"city,omitempty"`         // English name
+	Latitude    float64 `json:"latitude,omitempty"`
+	Longitude   float64 `json:"longitude,omitempty"`
+	TimeZone    string  `json:"time_zone,omitempty"` // IANA time zone name
+}
+
+// UserAgent is a parsed User-Agent header.
+type UserAgent struct {
+	Raw            string `json:"raw,omitempty"`
+	Browser        string `json:"browser,omitempty"`
+	BrowserVersion string `json:"browser_version,omitempty"`
+	OS             string `json:"os,omitempty"`
+	Device         string `json:"device,omitempty"` // "desktop", "mobile", "tablet", "bot" or "" if unknown
 }
 
 type PubSubTopicDesc struct {
@@ -174,7 +221,63 @@ type TestData struct {
 	ServiceInstancesMu sync.Mutex
 	ServiceInstances   map[string]any // The service instances isolated to this test
 
+	DatabasesMu sync.Mutex
+	Databases   map[string]any // The databases isolated to this test, keyed by database name
+
 	Wait sync.WaitGroup // If we're spun up async go routines, this wait allows to the test to wait for them to end
+
+	// Meter is the active usage meter for the test, if any.
+	// It is set while measuring a budget with et.AssertBudget.
+	Meter atomic.Pointer[UsageMeter]
+}
+
+// UsageMeter records the resources used by requests within a test.
+type UsageMeter struct {
+	Parent *UsageMeter // the enclosing meter, if measurements are nested
+
+	mu        sync.Mutex
+	queries   []string
+	publishes []string
+}
+
+// Queries returns the database queries recorded by the meter.
+func (m *UsageMeter) Queries() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.queries...)
+}
+
+// Publishes returns the topics of the Pub/Sub messages recorded by the meter.
+func (m *UsageMeter) Publishes() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.publishes...)
+}
+
+// RecordDBQuery records a database query made by the request,
+// if it is part of a test with an active usage meter.
+func (req *Request) RecordDBQuery(query string) {
+	if req == nil || req.Test == nil {
+		return
+	}
+	for m := req.Test.Meter.Load(); m != nil; m = m.Parent {
+		m.mu.Lock()
+		m.queries = append(m.queries, query)
+		m.mu.Unlock()
+	}
+}
+
+// RecordPublish records a Pub/Sub message published by the request,
+// if it is part of a test with an active usage meter.
+func (req *Request) RecordPublish(topic string) {
+	if req == nil || req.Test == nil {
+		return
+	}
+	for m := req.Test.Meter.Load(); m != nil; m = m.Parent {
+		m.mu.Lock()
+		m.publishes = append(m.publishes, topic)
+		m.mu.Unlock()
+	}
 }
 
 // TestConfig contains configuration for testing,
@@ -189,10 +292,12 @@ type TestConfig struct {
 	// Lock for the below fields
 	Mu sync.RWMutex
 
-	ServiceMocks     map[string]ServiceMock
-	APIMocks         map[string]map[string]ApiMock
-	IsolatedServices *bool                // Whether to isolate services for this test
-	EndCallbacks     []func(t *testing.T) // Callbacks to run when the test ends
+	ServiceMocks      map[string]ServiceMock
+	APIMocks          map[string]map[string]ApiMock
+	IsolatedServices  *bool                // Whether to isolate services for this test
+	IsolatedDatabases *bool                // Whether to isolate databases for this test
+	BeforeEach        []func(t *testing.T) // Fixtures to run when each sub-test starts
+	EndCallbacks      []func(t *testing.T) // Callbacks to run when the test ends
 }
 
 type ServiceMock struct {
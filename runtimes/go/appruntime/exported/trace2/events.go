@@ -228,6 +228,34 @@ func (l *Log) RequestSpanStart(req *model.Request, goid uint32) {
 	tb.String(string(data.UserID))
 	tb.Bool(data.Mocked)
 
+	// Record the remaining time until the request's deadline, if any.
+	tb.Bool(!req.Deadline.IsZero())
+	if !req.Deadline.IsZero() {
+		tb.Duration(req.Deadline.Sub(req.Start))
+	}
+
+	// Record the metadata of the client that made the request, if known.
+	tb.Bool(data.Client != nil)
+	if c := data.Client; c != nil {
+		var geo model.GeoInfo
+		if c.Geo != nil {
+			geo = *c.Geo
+		}
+		ip := ""
+		if c.IP.IsValid() {
+			ip = c.IP.String()
+		}
+		tb.String(ip)
+		tb.String(geo.CountryCode)
+		tb.String(geo.Country)
+		tb.String(geo.Region)
+		tb.String(geo.City)
+		tb.String(c.UserAgent.Browser)
+		tb.String(c.UserAgent.BrowserVersion)
+		tb.String(c.UserAgent.OS)
+		tb.String(c.UserAgent.Device)
+	}
+
 	l.Add(Event{
 		Type:    RequestSpanStart,
 		TraceID: req.TraceID,
@@ -253,7 +281,7 @@ func (l *Log) RequestSpanEnd(p RequestSpanEndParams) {
 		Err:           p.Resp.Err,
 		ParentTraceID: p.Req.ParentTraceID,
 		ParentSpanID:  p.Req.ParentSpanID,
-		ExtraSpace:    len(desc.Service) + len(desc.Endpoint) + 64 + len(scrubbedResponse) + len(uid) + 2,
+		ExtraSpace:    len(desc.Service) + len(desc.Endpoint) + 64 + len(scrubbedResponse) + len(uid) + 4,
 	})
 
 	tb.String(desc.Service)
@@ -265,6 +293,16 @@ func (l *Log) RequestSpanEnd(p RequestSpanEndParams) {
 	tb.UVarint(uint64(p.CallerEventID))
 	tb.String(uid)
 
+	var cancelCause string
+	if p.Req.CancelCause != nil {
+		if err := p.Req.CancelCause(); err != nil {
+			cancelCause = err.Error()
+		}
+	}
+	deadline := p.Req.Deadline
+	tb.String(cancelCause)
+	tb.Bool(!deadline.IsZero() && p.Req.Start.Add(p.Resp.Duration).After(deadline))
+
 	l.Add(Event{
 		Type:    RequestSpanEnd,
 		TraceID: p.TraceID,
@@ -0,0 +1,100 @@
+// Package clientinfo determines metadata about the clients making requests:
+// their IP address, their location based on a MaxMind GeoIP database,
+// and their parsed user agent.
+package clientinfo
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+)
+
+// Enricher determines the metadata of clients making requests.
+type Enricher struct {
+	db       *GeoDB // nil if no GeoIP database is configured
+	ipHeader string
+}
+
+// New returns an Enricher configured by cfg.
+// If the GeoIP database cannot be opened it returns an error.
+func New(cfg *config.ClientMetadata) (*Enricher, error) {
+	e := &Enricher{ipHeader: cfg.ClientIPHeader}
+	if cfg.GeoIPDatabase != "" {
+		db, err := OpenGeoDB(cfg.GeoIPDatabase)
+		if err != nil {
+			return nil, err
+		}
+		e.db = db
+	}
+	return e, nil
+}
+
+// Lookup returns the metadata of the client making req.
+func (e *Enricher) Lookup(req *http.Request) *model.ClientInfo {
+	info := &model.ClientInfo{
+		IP:        e.clientIP(req),
+		UserAgent: ParseUserAgent(req.Header.Get("User-Agent")),
+	}
+	if e.db != nil && info.IP.IsValid() {
+		if rec, err := e.db.lookup(info.IP); err == nil && rec != nil {
+			info.Geo = geoInfo(rec)
+		}
+	}
+	return info
+}
+
+// clientIP returns the IP address of the client making req.
+func (e *Enricher) clientIP(req *http.Request) netip.Addr {
+	if e.ipHeader != "" {
+		// Headers like X-Forwarded-For list the originating client first.
+		for _, v := range req.Header.Values(e.ipHeader) {
+			for _, part := range strings.Split(v, ",") {
+				if ip, err := netip.ParseAddr(strings.TrimSpace(part)); err == nil {
+					return ip.Unmap()
+				}
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip, _ := netip.ParseAddr(host)
+	return ip.Unmap()
+}
+
+// geoInfo extracts the location from a GeoIP2 or GeoLite2 City or Country record.
+func geoInfo(rec map[string]any) *model.GeoInfo {
+	geo := &model.GeoInfo{}
+	if country, ok := rec["country"].(map[string]any); ok {
+		geo.CountryCode, _ = country["iso_code"].(string)
+		geo.Country = englishName(country)
+	}
+	if subdivs, ok := rec["subdivisions"].([]any); ok && len(subdivs) > 0 {
+		if sub, ok := subdivs[0].(map[string]any); ok {
+			geo.Region = englishName(sub)
+		}
+	}
+	if city, ok := rec["city"].(map[string]any); ok {
+		geo.City = englishName(city)
+	}
+	if loc, ok := rec["location"].(map[string]any); ok {
+		geo.Latitude, _ = loc["latitude"].(float64)
+		geo.Longitude, _ = loc["longitude"].(float64)
+		geo.TimeZone, _ = loc["time_zone"].(string)
+	}
+	if *geo == (model.GeoInfo{}) {
+		return nil
+	}
+	return geo
+}
+
+func englishName(m map[string]any) string {
+	names, _ := m["names"].(map[string]any)
+	name, _ := names["en"].(string)
+	return name
+}
@@ -0,0 +1,227 @@
+package clientinfo
+
+import (
+	"encoding/binary"
+	"math"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestGeoDB(t *testing.T) {
+	c := qt.New(t)
+	london := map[string]any{
+		"city":    map[string]any{"names": map[string]any{"en": "London"}},
+		"country": map[string]any{"iso_code": "GB", "names": map[string]any{"en": "United Kingdom"}},
+		"location": map[string]any{
+			"latitude":  51.5142,
+			"longitude": -0.0931,
+			"time_zone": "Europe/London",
+		},
+		"subdivisions": []any{map[string]any{"names": map[string]any{"en": "England"}}},
+	}
+	sweden := map[string]any{
+		"country": map[string]any{"iso_code": "SE", "names": map[string]any{"en": "Sweden"}},
+	}
+
+	for _, recordSize := range []int{24, 28, 32} {
+		db, err := NewGeoDB(buildTestDB(recordSize, map[netip.Prefix]any{
+			netip.MustParsePrefix("::81.2.69.0/120"): london,
+			netip.MustParsePrefix("2a02:aa0::/32"):   sweden,
+		}))
+		c.Assert(err, qt.IsNil)
+		e := &Enricher{db: db}
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = "81.2.69.142:1234"
+		info := e.Lookup(req)
+		c.Assert(info.IP, qt.Equals, netip.MustParseAddr("81.2.69.142"))
+		c.Assert(info.Geo, qt.DeepEquals, &model.GeoInfo{
+			CountryCode: "GB",
+			Country:     "United Kingdom",
+			Region:      "England",
+			City:        "London",
+			Latitude:    51.5142,
+			Longitude:   -0.0931,
+			TimeZone:    "Europe/London",
+		})
+
+		req.RemoteAddr = "[2a02:aa0::1]:1234"
+		c.Assert(e.Lookup(req).Geo, qt.DeepEquals, &model.GeoInfo{CountryCode: "SE", Country: "Sweden"})
+
+		req.RemoteAddr = "10.0.0.1:1234"
+		c.Assert(e.Lookup(req).Geo, qt.IsNil)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	c := qt.New(t)
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+
+	c.Assert((&Enricher{}).clientIP(req), qt.Equals, netip.MustParseAddr("10.0.0.1"))
+	c.Assert((&Enricher{ipHeader: "X-Forwarded-For"}).clientIP(req), qt.Equals, netip.MustParseAddr("203.0.113.7"))
+
+	req.Header.Del("X-Forwarded-For")
+	c.Assert((&Enricher{ipHeader: "X-Forwarded-For"}).clientIP(req), qt.Equals, netip.MustParseAddr("10.0.0.1"))
+}
+
+func TestParseUserAgent(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		ua   string
+		want model.UserAgent
+	}{
+		{
+			ua:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+			want: model.UserAgent{Browser: "Safari", BrowserVersion: "17.4", OS: "macOS", Device: "desktop"},
+		},
+		{
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51",
+			want: model.UserAgent{Browser: "Edge", BrowserVersion: "124.0.2478.51", OS: "Windows", Device: "desktop"},
+		},
+		{
+			ua:   "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/124.0.6367.88 Mobile/15E148 Safari/604.1",
+			want: model.UserAgent{Browser: "Chrome", BrowserVersion: "124.0.6367.88", OS: "iOS", Device: "mobile"},
+		},
+		{
+			ua:   "Mozilla/5.0 (Linux; Android 14; SM-X710) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			want: model.UserAgent{Browser: "Chrome", BrowserVersion: "124.0.0.0", OS: "Android", Device: "tablet"},
+		},
+		{
+			ua:   "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want: model.UserAgent{Device: "bot"},
+		},
+		{
+			ua:   "curl/8.4.0",
+			want: model.UserAgent{Browser: "curl", BrowserVersion: "8.4.0"},
+		},
+	}
+	for _, test := range tests {
+		test.want.Raw = test.ua
+		c.Check(ParseUserAgent(test.ua), qt.DeepEquals, test.want, qt.Commentf("ua: %s", test.ua))
+	}
+}
+
+// buildTestDB builds an IPv6 MaxMind DB mapping the given networks to records.
+func buildTestDB(recordSize int, networks map[netip.Prefix]any) []byte {
+	type node struct {
+		children [2]*node
+		data     []byte // set for leaves
+	}
+	root := &node{}
+	var data []byte
+	for prefix, rec := range networks {
+		enc := encodeTestData(rec)
+		offset := len(data)
+		data = append(data, enc...)
+
+		addr := prefix.Addr().As16()
+		n := root
+		for i := 0; i < prefix.Bits(); i++ {
+			bit := addr[i/8] >> (7 - i%8) & 1
+			if n.children[bit] == nil {
+				n.children[bit] = &node{}
+			}
+			n = n.children[bit]
+		}
+		n.data = binary.BigEndian.AppendUint32(nil, uint32(offset))
+	}
+
+	// Number the inner nodes.
+	var nodes []*node
+	ids := make(map[*node]int)
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n == nil || n.data != nil {
+			return
+		}
+		ids[n] = len(nodes)
+		nodes = append(nodes, n)
+		walk(n.children[0])
+		walk(n.children[1])
+	}
+	walk(root)
+
+	nodeCount := len(nodes)
+	var tree []byte
+	for _, n := range nodes {
+		var recs [2]uint32
+		for bit, child := range n.children {
+			switch {
+			case child == nil:
+				recs[bit] = uint32(nodeCount)
+			case child.data != nil:
+				recs[bit] = uint32(nodeCount) + 16 + binary.BigEndian.Uint32(child.data)
+			default:
+				recs[bit] = uint32(ids[child])
+			}
+		}
+		switch recordSize {
+		case 24:
+			tree = append(tree, byte(recs[0]>>16), byte(recs[0]>>8), byte(recs[0]),
+				byte(recs[1]>>16), byte(recs[1]>>8), byte(recs[1]))
+		case 28:
+			tree = append(tree, byte(recs[0]>>16), byte(recs[0]>>8), byte(recs[0]),
+				byte(recs[0]>>20&0xF0|recs[1]>>24&0x0F),
+				byte(recs[1]>>16), byte(recs[1]>>8), byte(recs[1]))
+		case 32:
+			tree = binary.BigEndian.AppendUint32(tree, recs[0])
+			tree = binary.BigEndian.AppendUint32(tree, recs[1])
+		}
+	}
+
+	buf := append(tree, make([]byte, 16)...)
+	buf = append(buf, data...)
+	buf = append(buf, metadataMarker...)
+	buf = append(buf, encodeTestData(map[string]any{
+		"node_count":  uint64(nodeCount),
+		"record_size": uint64(recordSize),
+		"ip_version":  uint64(6),
+	})...)
+	return buf
+}
+
+// encodeTestData encodes v in the MaxMind DB data format.
+func encodeTestData(v any) []byte {
+	ctrl := func(typ, size int) []byte {
+		if typ > 7 {
+			return []byte{byte(size), byte(typ - 7)}
+		}
+		return []byte{byte(typ<<5 | size)}
+	}
+	switch v := v.(type) {
+	case string:
+		return append(ctrl(typeString, len(v)), v...)
+	case float64:
+		return binary.BigEndian.AppendUint64(ctrl(typeDouble, 8), math.Float64bits(v))
+	case uint64:
+		return binary.BigEndian.AppendUint32(ctrl(typeUint32, 4), uint32(v))
+	case []any:
+		buf := ctrl(typeArray, len(v))
+		for _, elem := range v {
+			buf = append(buf, encodeTestData(elem)...)
+		}
+		return buf
+	case map[string]any:
+		buf := ctrl(typeMap, len(v))
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			buf = append(buf, encodeTestData(k)...)
+			buf = append(buf, encodeTestData(v[k])...)
+		}
+		return buf
+	default:
+		panic("unsupported type")
+	}
+}
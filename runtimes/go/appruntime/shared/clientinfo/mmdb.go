@@ -0,0 +1,309 @@
+package clientinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"os"
+)
+
+// metadataMarker precedes the metadata section at the end of a MaxMind DB file.
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// GeoDB is a MaxMind DB file, such as GeoLite2 City, loaded into memory.
+// See https://maxmind.github.io/MaxMind-DB/ for the format.
+type GeoDB struct {
+	buf        []byte
+	tree       []byte // the search tree
+	data       []byte // the data section
+	nodeCount  uint
+	recordSize uint // in bits
+	ipVersion  uint
+	ipv4Start  uint // the node to start IPv4 lookups at in an IPv6 tree
+}
+
+// OpenGeoDB reads the MaxMind DB file at path.
+func OpenGeoDB(path string) (*GeoDB, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewGeoDB(buf)
+}
+
+// NewGeoDB parses a MaxMind DB file from buf.
+func NewGeoDB(buf []byte) (*GeoDB, error) {
+	idx := bytes.LastIndex(buf, metadataMarker)
+	if idx < 0 {
+		return nil, errors.New("mmdb: metadata section not found")
+	}
+	d := decoder{buf: buf[idx+len(metadataMarker):]}
+	v, _, err := d.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("mmdb: decode metadata: %w", err)
+	}
+	md, ok := v.(map[string]any)
+	if !ok {
+		return nil, errors.New("mmdb: invalid metadata")
+	}
+
+	db := &GeoDB{
+		buf:        buf,
+		nodeCount:  uint(toUint(md["node_count"])),
+		recordSize: uint(toUint(md["record_size"])),
+		ipVersion:  uint(toUint(md["ip_version"])),
+	}
+	switch db.recordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("mmdb: unsupported record size %d", db.recordSize)
+	}
+	if db.ipVersion != 4 && db.ipVersion != 6 {
+		return nil, fmt.Errorf("mmdb: unsupported ip version %d", db.ipVersion)
+	}
+
+	treeSize := db.nodeCount * db.recordSize / 4
+	if treeSize+16 > uint(idx) {
+		return nil, errors.New("mmdb: search tree exceeds file size")
+	}
+	db.tree = buf[:treeSize]
+	db.data = buf[treeSize+16 : idx]
+
+	// IPv4 addresses are stored in IPv6 trees under ::/96.
+	if db.ipVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < db.nodeCount; i++ {
+			node = db.record(node, 0)
+		}
+		db.ipv4Start = node
+	}
+	return db, nil
+}
+
+// lookup returns the data record for ip, or nil if there is none.
+func (db *GeoDB) lookup(ip netip.Addr) (map[string]any, error) {
+	ip = ip.Unmap()
+	var addr []byte
+	node := uint(0)
+	switch {
+	case ip.Is4():
+		a := ip.As4()
+		addr = a[:]
+		if db.ipVersion == 6 {
+			node = db.ipv4Start
+		}
+	case db.ipVersion == 6:
+		a := ip.As16()
+		addr = a[:]
+	default:
+		return nil, nil // IPv6 address in an IPv4 database
+	}
+
+	for i := 0; i < len(addr)*8 && node < db.nodeCount; i++ {
+		bit := uint(addr[i/8]>>(7-i%8)) & 1
+		node = db.record(node, bit)
+	}
+	if node <= db.nodeCount {
+		// node == nodeCount means there is no data for the address.
+		return nil, nil
+	}
+
+	d := decoder{buf: db.data}
+	v, _, err := d.decode(node - db.nodeCount - 16)
+	if err != nil {
+		return nil, fmt.Errorf("mmdb: decode record: %w", err)
+	}
+	m, _ := v.(map[string]any)
+	return m, nil
+}
+
+// record returns the left (bit == 0) or right (bit == 1) record of node.
+func (db *GeoDB) record(node, bit uint) uint {
+	size := db.recordSize / 4 // bytes per node
+	b := db.tree[node*size : (node+1)*size]
+	switch db.recordSize {
+	case 24:
+		b = b[bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default: // 32
+		return uint(binary.BigEndian.Uint32(b[bit*4:]))
+	}
+}
+
+// Data types in the MaxMind DB data section.
+const (
+	typeExtended = 0
+	typePointer  = 1
+	typeString   = 2
+	typeDouble   = 3
+	typeBytes    = 4
+	typeUint16   = 5
+	typeUint32   = 6
+	typeMap      = 7
+	typeInt32    = 8
+	typeUint64   = 9
+	typeUint128  = 10
+	typeArray    = 11
+	typeBool     = 14
+	typeFloat    = 15
+)
+
+type decoder struct {
+	buf []byte
+}
+
+var errTruncated = errors.New("truncated data")
+
+// decode decodes the value at offset, returning it and the offset after it.
+func (d *decoder) decode(offset uint) (v any, next uint, err error) {
+	if offset >= uint(len(d.buf)) {
+		return nil, 0, errTruncated
+	}
+	ctrl := d.buf[offset]
+	offset++
+	typ := uint(ctrl >> 5)
+	if typ == typePointer {
+		ptr, next, err := d.pointer(ctrl, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		v, _, err := d.decode(ptr)
+		return v, next, err
+	}
+	if typ == typeExtended {
+		if offset >= uint(len(d.buf)) {
+			return nil, 0, errTruncated
+		}
+		typ = 7 + uint(d.buf[offset])
+		offset++
+	}
+
+	size := uint(ctrl & 0x1F)
+	if size >= 29 {
+		n := size - 28 // number of size bytes
+		if offset+n > uint(len(d.buf)) {
+			return nil, 0, errTruncated
+		}
+		var extra uint
+		for _, b := range d.buf[offset : offset+n] {
+			extra = extra<<8 | uint(b)
+		}
+		offset += n
+		switch n {
+		case 1:
+			size = 29 + extra
+		case 2:
+			size = 285 + extra
+		default:
+			size = 65821 + extra
+		}
+	}
+
+	switch typ {
+	case typeMap:
+		m := make(map[string]any, size)
+		for range size {
+			var key, val any
+			if key, offset, err = d.decode(offset); err != nil {
+				return nil, 0, err
+			}
+			if val, offset, err = d.decode(offset); err != nil {
+				return nil, 0, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, 0, errors.New("map key is not a string")
+			}
+			m[k] = val
+		}
+		return m, offset, nil
+	case typeArray:
+		a := make([]any, 0, size)
+		for range size {
+			var val any
+			if val, offset, err = d.decode(offset); err != nil {
+				return nil, 0, err
+			}
+			a = append(a, val)
+		}
+		return a, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(d.buf)) {
+		return nil, 0, errTruncated
+	}
+	b := d.buf[offset : offset+size]
+	next = offset + size
+	switch typ {
+	case typeString:
+		return string(b), next, nil
+	case typeBytes:
+		return bytes.Clone(b), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("invalid double size %d", size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("invalid float size %d", size)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), next, nil
+	case typeUint16, typeUint32, typeUint64, typeUint128:
+		if size > 8 {
+			// Larger than we can represent; nothing we look up uses them.
+			return nil, next, nil
+		}
+		var u uint64
+		for _, c := range b {
+			u = u<<8 | uint64(c)
+		}
+		return u, next, nil
+	case typeInt32:
+		var u uint32
+		for _, c := range b {
+			u = u<<8 | uint32(c)
+		}
+		return int64(int32(u)), next, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported data type %d", typ)
+	}
+}
+
+// pointer decodes the pointer with the given control byte, whose
+// remaining bytes start at offset. It returns the offset the pointer
+// points to and the offset after the pointer.
+func (d *decoder) pointer(ctrl byte, offset uint) (ptr, next uint, err error) {
+	n := uint(ctrl>>3)&0x3 + 1
+	if offset+n > uint(len(d.buf)) {
+		return 0, 0, errTruncated
+	}
+	b := d.buf[offset : offset+n]
+	v := uint(ctrl & 0x7)
+	switch n {
+	case 1:
+		ptr = v<<8 | uint(b[0])
+	case 2:
+		ptr = (v<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+	case 3:
+		ptr = (v<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+	default:
+		ptr = uint(binary.BigEndian.Uint32(b))
+	}
+	return ptr, offset + n, nil
+}
+
+func toUint(v any) uint64 {
+	u, _ := v.(uint64)
+	return u
+}
@@ -0,0 +1,105 @@
+package clientinfo
+
+import (
+	"strings"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+// browsers are the browsers recognized in User-Agent headers, by the
+// product token identifying them. Browsers built on others include
+// the tokens of those too, so more specific tokens come first.
+var browsers = []struct{ token, name string }{
+	{"Edg/", "Edge"},
+	{"EdgA/", "Edge"},
+	{"EdgiOS/", "Edge"},
+	{"OPR/", "Opera"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"CriOS/", "Chrome"},
+	{"Chrome/", "Chrome"},
+	{"Version/", "Safari"}, // Safari reports its version in the Version token
+	{"curl/", "curl"},
+	{"Wget/", "Wget"},
+	{"PostmanRuntime/", "Postman"},
+	{"okhttp/", "OkHttp"},
+	{"python-requests/", "Python Requests"},
+	{"Go-http-client/", "Go"},
+}
+
+// operatingSystems are the operating systems recognized in User-Agent headers.
+// More specific tokens come first, as with browsers.
+var operatingSystems = []struct{ token, name string }{
+	{"Windows", "Windows"},
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Android", "Android"},
+	{"CrOS", "ChromeOS"},
+	{"Mac OS X", "macOS"},
+	{"Macintosh", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// botTokens are substrings identifying crawlers and other automated clients.
+var botTokens = []string{"bot", "crawler", "spider", "slurp", "headless"}
+
+// ParseUserAgent parses a User-Agent header value.
+// Parts that are not recognized are left empty.
+func ParseUserAgent(ua string) model.UserAgent {
+	res := model.UserAgent{Raw: ua}
+	if ua == "" {
+		return res
+	}
+
+	for _, b := range browsers {
+		if idx := strings.Index(ua, b.token); idx >= 0 {
+			if b.name == "Safari" && !strings.Contains(ua, "Safari/") {
+				continue
+			}
+			res.Browser = b.name
+			res.BrowserVersion = versionAt(ua[idx+len(b.token):])
+			break
+		}
+	}
+	for _, sys := range operatingSystems {
+		if strings.Contains(ua, sys.token) {
+			res.OS = sys.name
+			break
+		}
+	}
+
+	lower := strings.ToLower(ua)
+	switch {
+	case containsAny(lower, botTokens):
+		res.Device = "bot"
+	case res.OS == "iPadOS" || strings.Contains(lower, "tablet") ||
+		(res.OS == "Android" && !strings.Contains(ua, "Mobile")):
+		res.Device = "tablet"
+	case strings.Contains(ua, "Mobi") || res.OS == "iOS":
+		res.Device = "mobile"
+	case res.OS == "Windows" || res.OS == "macOS" || res.OS == "Linux" || res.OS == "ChromeOS":
+		res.Device = "desktop"
+	}
+	return res
+}
+
+// versionAt returns the version number at the start of s.
+func versionAt(s string) string {
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end < 0 {
+		end = len(s)
+	}
+	return s[:end]
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
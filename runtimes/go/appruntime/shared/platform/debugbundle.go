@@ -0,0 +1,43 @@
+package platform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"encore.dev/appruntime/exported/debugbundle"
+)
+
+// SendDebugBundle sends a debug bundle to the given endpoint.
+func (c *Client) SendDebugBundle(endpoint string, b *debugbundle.Bundle) error {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Encore-App-ID", c.runtime.AppID)
+	req.Header.Set("X-Encore-Env-ID", c.runtime.EnvID)
+	c.addAuthKey(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("http %s: %s", resp.Status, body)
+	}
+	return nil
+}
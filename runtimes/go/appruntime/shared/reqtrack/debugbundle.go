@@ -0,0 +1,54 @@
+package reqtrack
+
+import (
+	"encoding/json"
+
+	"encore.dev/appruntime/exported/debugbundle"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/beta/errs"
+)
+
+// EnableDebugBundles makes requests capture the state they observe,
+// and calls submit with a debug bundle for each request that fails.
+// It must be called before any requests begin.
+func (t *RequestTracker) EnableDebugBundles(submit func(*debugbundle.Bundle)) {
+	t.submitBundle = submit
+}
+
+// ReportFailure submits a debug bundle for req, which failed with resp.Err,
+// if debug bundles are enabled.
+func (t *RequestTracker) ReportFailure(req *model.Request, resp *model.Response) {
+	if t.submitBundle == nil || req.Debug == nil || resp.Err == nil {
+		return
+	}
+
+	b := &debugbundle.Bundle{
+		TraceID:  req.TraceID.String(),
+		SpanID:   req.SpanID.String(),
+		Time:     req.Start,
+		Duration: debugbundle.Duration(resp.Duration),
+		Code:     errs.Code(resp.Err).String(),
+		Error:    resp.Err.Error(),
+	}
+	var payload []byte
+	switch {
+	case req.RPCData != nil:
+		b.Service = req.RPCData.Desc.Service
+		b.Endpoint = req.RPCData.Desc.Endpoint
+		payload = req.RPCData.NonRawPayload
+		if payload == nil {
+			payload = resp.RawRequestPayload
+		}
+	case req.MsgData != nil:
+		b.Service = req.MsgData.Desc.Service
+		b.Topic = req.MsgData.Desc.Topic
+		b.Subscription = req.MsgData.Desc.Subscription
+		payload = req.MsgData.Payload
+	}
+	if json.Valid(payload) {
+		b.Payload = payload
+	}
+	req.Debug.Fill(b)
+
+	go t.submitBundle(b)
+}
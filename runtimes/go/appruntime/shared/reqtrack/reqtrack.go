@@ -3,6 +3,7 @@ package reqtrack
 import (
 	"github.com/rs/zerolog"
 
+	"encore.dev/appruntime/exported/debugbundle"
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/trace2"
 	"encore.dev/appruntime/shared/traceprovider"
@@ -30,6 +31,10 @@ type RequestTracker struct {
 	impl       reqTrackImpl
 	trace      traceprovider.Factory // nil if tracing is not enabled
 	rootLogger zerolog.Logger
+
+	// submitBundle submits debug bundles of failed requests.
+	// It is nil if debug bundles are not enabled.
+	submitBundle func(*debugbundle.Bundle)
 }
 
 func (t *RequestTracker) BeginOperation() {
@@ -45,6 +50,9 @@ func (t *RequestTracker) BeginRequest(req *model.Request) {
 		copyReqInfoFromParent(req, prev)
 		t.clearReq()
 	}
+	if t.submitBundle != nil {
+		req.Debug = &debugbundle.Capture{}
+	}
 	t.beginReq(req, req.Traced)
 }
 
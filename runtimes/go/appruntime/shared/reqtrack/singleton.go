@@ -3,7 +3,9 @@
 package reqtrack
 
 import (
+	"encore.dev/appruntime/exported/debugbundle"
 	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/encoreenv"
 	"encore.dev/appruntime/shared/logging"
 	"encore.dev/appruntime/shared/platform"
 	"encore.dev/appruntime/shared/traceprovider"
@@ -24,4 +26,12 @@ func init() {
 	}
 
 	Singleton = New(logging.RootLogger, platform.Singleton, traceFactory)
+
+	if endpoint := encoreenv.Get(debugbundle.EndpointEnvVar); endpoint != "" {
+		Singleton.EnableDebugBundles(func(b *debugbundle.Bundle) {
+			if err := platform.Singleton.SendDebugBundle(endpoint, b); err != nil {
+				logging.RootLogger.Error().Err(err).Msg("failed to send debug bundle")
+			}
+		})
+	}
 }
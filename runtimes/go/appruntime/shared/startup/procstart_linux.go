@@ -0,0 +1,52 @@
+package startup
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"time"
+)
+
+// clockTicks is the number of clock ticks per second the process start time
+// is reported in. It's 100 on all architectures Go supports on Linux.
+const clockTicks = 100
+
+// osProcessStart returns when the process started, from the time since boot
+// the process started at and the current time since boot.
+func osProcessStart() (time.Time, bool) {
+	now := time.Now()
+	stat, err := os.ReadFile("/proc/self/stat")
+	if err != nil {
+		return time.Time{}, false
+	}
+	uptime, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// The second field is the executable name in parentheses, which may
+	// contain spaces; the start time is the 20th field after it.
+	idx := bytes.LastIndexByte(stat, ')')
+	if idx < 0 {
+		return time.Time{}, false
+	}
+	fields := bytes.Fields(stat[idx+1:])
+	if len(fields) < 20 {
+		return time.Time{}, false
+	}
+	ticks, err := strconv.ParseInt(string(fields[19]), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	up := bytes.Fields(uptime)
+	if len(up) == 0 {
+		return time.Time{}, false
+	}
+	upSecs, err := strconv.ParseFloat(string(up[0]), 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	sinceStart := time.Duration(upSecs*float64(time.Second)) - time.Duration(ticks)*time.Second/clockTicks
+	return now.Add(-sinceStart), true
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package startup
+
+import (
+	"time"
+)
+
+// osProcessStart reports false as the process start time
+// is only determined on Linux.
+func osProcessStart() (time.Time, bool) {
+	return time.Time{}, false
+}
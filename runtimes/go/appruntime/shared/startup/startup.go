@@ -0,0 +1,168 @@
+// Package startup measures where the time goes when an Encore application
+// starts, so slow cold starts can be attributed to package initialization,
+// infrastructure resource setup or service initialization.
+package startup
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// initStart is when the package was initialized. It's used in place of
+// the process start time where the latter can't be determined.
+var initStart = time.Now()
+
+// Singleton records the startup of the running application.
+var Singleton = NewRecorder(processStart())
+
+// processStart returns when the process started,
+// or when the package was initialized if that's not known.
+func processStart() time.Time {
+	if start, ok := osProcessStart(); ok && !start.After(initStart) && initStart.Sub(start) < time.Minute {
+		return start
+	}
+	return initStart
+}
+
+// Recorder records the phases of the startup of an application.
+type Recorder struct {
+	start time.Time // when the process started
+
+	mu            sync.Mutex
+	main          time.Time // when main was called, or zero
+	servicesStart time.Time // when service initialization started, or zero
+	ready         time.Time // when all services were initialized, or zero
+	resources     []Timing
+	services      []Timing
+}
+
+// NewRecorder returns a Recorder for a process started at start.
+func NewRecorder(start time.Time) *Recorder {
+	return &Recorder{start: start}
+}
+
+// Timing is the time it took to set up an infrastructure resource
+// or initialize a service.
+type Timing struct {
+	Kind     string        `json:"kind,omitempty"` // the kind of resource, like "database"; empty for services
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// Resource records that setting up the resource of the given kind and name
+// started, and returns a function to call when it's done.
+// Resources are declared during package initialization.
+func (r *Recorder) Resource(kind, name string) (done func()) {
+	start := time.Now()
+	return func() {
+		t := Timing{Kind: kind, Name: name, Duration: time.Since(start)}
+		r.mu.Lock()
+		r.resources = append(r.resources, t)
+		r.mu.Unlock()
+	}
+}
+
+// Main records that package initialization is complete and main was called.
+func (r *Recorder) Main() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.main.IsZero() {
+		r.main = time.Now()
+	}
+}
+
+// ServicesStarting records that the initialization of the services started.
+func (r *Recorder) ServicesStarting() {
+	r.mu.Lock()
+	r.servicesStart = time.Now()
+	r.mu.Unlock()
+}
+
+// Service records that initializing the named service took d.
+func (r *Recorder) Service(name string, d time.Duration) {
+	r.mu.Lock()
+	r.services = append(r.services, Timing{Name: name, Duration: d})
+	r.mu.Unlock()
+}
+
+// Ready records that all services have been initialized,
+// completing the startup, and returns the report.
+func (r *Recorder) Ready() Report {
+	r.mu.Lock()
+	if r.ready.IsZero() {
+		r.ready = time.Now()
+	}
+	r.mu.Unlock()
+	return r.Report()
+}
+
+// Report is the breakdown of the startup of an application.
+type Report struct {
+	// Complete is whether all services have been initialized.
+	// Until then only the completed phases are reported.
+	Complete bool `json:"complete"`
+
+	// Total is the time from the process starting until
+	// all services were initialized.
+	Total time.Duration `json:"total_ns"`
+
+	// PackageInit is the time from the process starting until main was called:
+	// the initialization of all packages, including ResourceSetup.
+	PackageInit time.Duration `json:"package_init_ns"`
+
+	// ResourceSetup is the time spent declaring infrastructure resources,
+	// like setting up database connection pools, during package initialization.
+	ResourceSetup time.Duration `json:"resource_setup_ns"`
+
+	// RuntimeSetup is the time from main being called until
+	// the initialization of the services started.
+	RuntimeSetup time.Duration `json:"runtime_setup_ns"`
+
+	// ServiceInit is the time it took to initialize the services,
+	// which are initialized concurrently.
+	ServiceInit time.Duration `json:"service_init_ns"`
+
+	// Resources and Services are the time it took to set up each
+	// resource and to initialize each service, slowest first.
+	Resources []Timing `json:"resources"`
+	Services  []Timing `json:"services"`
+}
+
+// Report returns the breakdown of the startup so far.
+func (r *Recorder) Report() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep := Report{
+		Complete:  !r.ready.IsZero(),
+		Resources: slowestFirst(r.resources),
+		Services:  slowestFirst(r.services),
+	}
+	for _, t := range r.resources {
+		rep.ResourceSetup += t.Duration
+	}
+	if !r.main.IsZero() {
+		rep.PackageInit = r.main.Sub(r.start)
+		if !r.servicesStart.IsZero() {
+			rep.RuntimeSetup = r.servicesStart.Sub(r.main)
+			if rep.Complete {
+				rep.ServiceInit = r.ready.Sub(r.servicesStart)
+			}
+		}
+	}
+	if rep.Complete {
+		rep.Total = r.ready.Sub(r.start)
+	}
+	return rep
+}
+
+func slowestFirst(timings []Timing) []Timing {
+	sorted := slices.Clone(timings)
+	if sorted == nil {
+		sorted = []Timing{}
+	}
+	slices.SortStableFunc(sorted, func(a, b Timing) int { return cmp.Compare(b.Duration, a.Duration) })
+	return sorted
+}
@@ -0,0 +1,42 @@
+package startup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	start := time.Now()
+	r := NewRecorder(start)
+
+	r.Resource("database", "orders")()
+	r.Resource("topic", "order-created")()
+	if rep := r.Report(); rep.Complete || rep.Total != 0 || rep.PackageInit != 0 || len(rep.Resources) != 2 {
+		t.Fatalf("got report %+v before main, want only the resources", rep)
+	}
+
+	r.Main()
+	r.ServicesStarting()
+	r.Service("orders", 2*time.Millisecond)
+	r.Service("users", 5*time.Millisecond)
+	rep := r.Ready()
+
+	if !rep.Complete {
+		t.Fatal("got incomplete report after Ready")
+	}
+	if sum := rep.PackageInit + rep.RuntimeSetup + rep.ServiceInit; sum != rep.Total {
+		t.Errorf("got phases summing to %v, want the total %v", sum, rep.Total)
+	}
+	if rep.ResourceSetup != rep.Resources[0].Duration+rep.Resources[1].Duration {
+		t.Errorf("got resource setup %v, want the sum of %+v", rep.ResourceSetup, rep.Resources)
+	}
+	if len(rep.Services) != 2 || rep.Services[0].Name != "users" || rep.Services[1].Name != "orders" {
+		t.Errorf("got services %+v, want the slowest first", rep.Services)
+	}
+}
+
+func TestProcessStart(t *testing.T) {
+	if start := processStart(); start.After(initStart) || initStart.Sub(start) > time.Minute {
+		t.Errorf("got process start %v, want shortly before the package was initialized at %v", start, initStart)
+	}
+}
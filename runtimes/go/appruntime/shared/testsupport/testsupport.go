@@ -2,6 +2,7 @@ package testsupport
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -32,6 +33,11 @@ type Manager struct {
 	testServiceOnce sync.Once
 	testService     string
 	testServiceNum  uint16
+
+	fixtureMu    sync.Mutex
+	beforeAll    []func(ctx context.Context) error
+	beforeAllRan bool  // whether the beforeAll fixtures have been run
+	beforeAllErr error // the error returned by the beforeAll fixtures, if any
 }
 
 func NewManager(static *config.Static, rt *reqtrack.RequestTracker, rootLogger zerolog.Logger) *Manager {
@@ -102,6 +108,7 @@ func (mgr *Manager) StartTest(t *testing.T, fn func(*testing.T)) {
 			TestLine:         uint32(testLine),
 			Config:           newTestConfig(parentConfig),
 			ServiceInstances: make(map[string]any),
+			Databases:        make(map[string]any),
 		},
 		Logger: &logger,
 		SvcNum: svcNum,
@@ -110,6 +117,71 @@ func (mgr *Manager) StartTest(t *testing.T, fn func(*testing.T)) {
 	if curr := mgr.rt.Current(); curr.Trace != nil {
 		curr.Trace.TestSpanStart(req, curr.Goctr)
 	}
+
+	mgr.runFixtures(t, req.Test)
+}
+
+// AddBeforeAll registers fn as a fixture to run once in the test binary,
+// before the first test starts. It reports an error if tests have already started.
+func (mgr *Manager) AddBeforeAll(fn func(ctx context.Context) error) error {
+	mgr.fixtureMu.Lock()
+	defer mgr.fixtureMu.Unlock()
+	if mgr.beforeAllRan {
+		return errors.New("tests have already started")
+	}
+	mgr.beforeAll = append(mgr.beforeAll, fn)
+	return nil
+}
+
+// AddBeforeEach registers fn as a fixture to run when each sub-test of the current test starts,
+// or when each test starts if no test is running.
+func (mgr *Manager) AddBeforeEach(fn func(t *testing.T)) {
+	cfg := mgr.currentConfig()
+	cfg.Mu.Lock()
+	defer cfg.Mu.Unlock()
+	cfg.BeforeEach = append(cfg.BeforeEach, fn)
+}
+
+// runFixtures runs the fixtures for a test that is starting.
+//
+// The fixtures registered with AddBeforeAll are run before the first top-level test,
+// followed by the fixtures registered with AddBeforeEach by the test's ancestors,
+// outermost first.
+func (mgr *Manager) runFixtures(t *testing.T, td *model.TestData) {
+	if td.Parent == nil {
+		if err := mgr.runBeforeAll(td.Ctx); err != nil {
+			t.Fatalf("encore: test fixture failed: %v", err)
+		}
+	}
+
+	var hooks [][]func(t *testing.T)
+	for cfg := td.Config.Parent; cfg != nil; cfg = cfg.Parent {
+		cfg.Mu.RLock()
+		hooks = append(hooks, slices.Clone(cfg.BeforeEach))
+		cfg.Mu.RUnlock()
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		for _, fn := range hooks[i] {
+			fn(t)
+		}
+	}
+}
+
+// runBeforeAll runs the fixtures registered with AddBeforeAll, if they haven't run yet.
+// If a fixture fails, the same error is returned to all tests.
+func (mgr *Manager) runBeforeAll(ctx context.Context) error {
+	mgr.fixtureMu.Lock()
+	defer mgr.fixtureMu.Unlock()
+	if !mgr.beforeAllRan {
+		mgr.beforeAllRan = true
+		for _, fn := range mgr.beforeAll {
+			if err := fn(ctx); err != nil {
+				mgr.beforeAllErr = err
+				break
+			}
+		}
+	}
+	return mgr.beforeAllErr
 }
 
 // PauseTest is called when a test is paused. This allows Encore's testing framework to
@@ -280,6 +352,27 @@ func (mgr *Manager) GetIsolatedServices() bool {
 	return *result
 }
 
+// SetIsolatedDatabases sets whether isolated databases should be enabled for the current test
+func (mgr *Manager) SetIsolatedDatabases(enabled bool) {
+	cfg := mgr.currentConfig()
+	cfg.Mu.Lock()
+	defer cfg.Mu.Unlock()
+	cfg.IsolatedDatabases = &enabled
+}
+
+// GetIsolatedDatabases returns whether isolated databases are enabled for the current test
+func (mgr *Manager) GetIsolatedDatabases() bool {
+	result, _ := walkConfig(mgr.currentConfig(), func(cfg *TestConfig) (value *bool, found bool) {
+		value, found = cfg.IsolatedDatabases, cfg.IsolatedDatabases != nil
+		return
+	})
+
+	if result == nil {
+		return false
+	}
+	return *result
+}
+
 // SetServiceMock allows us to set a mock for a service for the current test
 func (mgr *Manager) SetServiceMock(service string, mock any, runMiddleware bool) {
 	service = strings.TrimSpace(strings.ToLower(service))
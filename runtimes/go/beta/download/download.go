@@ -0,0 +1,271 @@
+// Package download provides helpers for serving files and other large
+// responses from raw endpoints, with support for range requests.
+//
+// For more information see https://encore.dev/docs/go/primitives/raw-endpoints#serving-files.
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"encore.dev/beta/httpcache"
+	"encore.dev/storage/objects"
+)
+
+// Content describes content to serve.
+type Content struct {
+	// Name is the file name of the content. It is used to determine
+	// the content type if ContentType is empty, and as the file name
+	// when Attachment is true.
+	Name string
+
+	// ContentType is the MIME type of the content.
+	// If empty it is determined from the extension of Name,
+	// defaulting to "application/octet-stream".
+	ContentType string
+
+	// Size is the size of the content in bytes, or -1 if unknown.
+	// Range requests are only supported when the size is known.
+	Size int64
+
+	// ModTime is the last modification time of the content, if known.
+	ModTime time.Time
+
+	// ETag is the entity tag of the content, if known.
+	// It must be a quoted string, like `"abc"` or `W/"abc"`.
+	ETag string
+
+	// Attachment, if true, asks the client to save the content
+	// as a file instead of displaying it.
+	Attachment bool
+
+	// Open opens the content for reading length bytes starting at offset.
+	// If length is negative the content should be read until the end.
+	Open func(ctx context.Context, offset, length int64) (io.ReadCloser, error)
+}
+
+// FromReader returns a Content that serves the data read from r.
+// The size is the number of bytes r will return, or -1 if unknown.
+//
+// Since r can only be read once, the returned Content can only be served once.
+func FromReader(name string, r io.Reader, size int64) Content {
+	return Content{
+		Name: name,
+		Size: size,
+		Open: func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			if offset > 0 {
+				if s, ok := r.(io.Seeker); ok {
+					if _, err := s.Seek(offset, io.SeekCurrent); err != nil {
+						return nil, err
+					}
+				} else if _, err := io.CopyN(io.Discard, r, offset); err != nil {
+					return nil, err
+				}
+			}
+
+			var rd io.Reader = r
+			if length >= 0 {
+				rd = io.LimitReader(r, length)
+			}
+			if c, ok := r.(io.Closer); ok {
+				return struct {
+					io.Reader
+					io.Closer
+				}{rd, c}, nil
+			}
+			return io.NopCloser(rd), nil
+		},
+	}
+}
+
+// Bucket is the interface for object storage buckets that can be served from.
+// It is implemented by *objects.Bucket and by bucket references
+// with the objects.Downloader and objects.Attrser permissions.
+type Bucket interface {
+	objects.Downloader
+	objects.Attrser
+}
+
+// FromObject returns a Content that serves the given object from bucket.
+// It returns an error wrapping objects.ErrObjectNotFound if the object does not exist.
+func FromObject(ctx context.Context, bucket Bucket, object string) (Content, error) {
+	attrs, err := bucket.Attrs(ctx, object)
+	if err != nil {
+		return Content{}, err
+	}
+
+	etag := attrs.ETag
+	if etag != "" && !strings.HasPrefix(etag, `"`) && !strings.HasPrefix(etag, `W/"`) {
+		etag = strconv.Quote(etag)
+	}
+
+	return Content{
+		Name:        filepath.Base(object),
+		ContentType: attrs.ContentType,
+		Size:        attrs.Size,
+		ETag:        etag,
+		Open: func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+			opts := []objects.DownloadOption{objects.WithVersion(attrs.Version)}
+			if offset > 0 || length >= 0 {
+				opts = append(opts, objects.WithRange(offset, length))
+			}
+			r := bucket.Download(ctx, object, opts...)
+			if err := r.Err(); err != nil {
+				return nil, err
+			}
+			return r, nil
+		},
+	}, nil
+}
+
+// Serve writes c as the response to req.
+//
+// It handles conditional requests (If-None-Match, If-Modified-Since)
+// and single-range requests (Range, If-Range), responding with
+// 304 Not Modified, 206 Partial Content and 416 Range Not Satisfiable
+// as appropriate. The content is streamed to the client as it is read,
+// so slow clients slow down reading the content rather than having it
+// buffered in memory.
+//
+// It returns an error if the content could not be opened or written.
+func Serve(w http.ResponseWriter, req *http.Request, c Content) error {
+	hdr := w.Header()
+	hdr.Set("Content-Type", contentType(c))
+	if c.ETag != "" {
+		hdr.Set("ETag", c.ETag)
+	}
+	if !c.ModTime.IsZero() {
+		hdr.Set("Last-Modified", c.ModTime.UTC().Format(http.TimeFormat))
+	}
+	if c.Attachment {
+		hdr.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": c.Name}))
+	}
+	if c.Size >= 0 {
+		hdr.Set("Accept-Ranges", "bytes")
+	}
+
+	if (req.Method == http.MethodGet || req.Method == http.MethodHead) && httpcache.NotModified(req.Header, c.ETag, c.ModTime) {
+		hdr.Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	offset, length, status := int64(0), c.Size, http.StatusOK
+	if rangeHdr := req.Header.Get("Range"); rangeHdr != "" && c.Size >= 0 && ifRangeMatches(req.Header.Get("If-Range"), c) {
+		start, n, ok, err := parseRange(rangeHdr, c.Size)
+		if err != nil {
+			hdr.Set("Content-Range", fmt.Sprintf("bytes */%d", c.Size))
+			http.Error(w, err.Error(), http.StatusRequestedRangeNotSatisfiable)
+			return nil
+		}
+		if ok {
+			offset, length, status = start, n, http.StatusPartialContent
+			hdr.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+n-1, c.Size))
+		}
+	}
+	if length >= 0 {
+		hdr.Set("Content-Length", strconv.FormatInt(length, 10))
+	}
+
+	if req.Method == http.MethodHead {
+		w.WriteHeader(status)
+		return nil
+	}
+
+	r, err := c.Open(req.Context(), offset, length)
+	if err != nil {
+		hdr.Del("Content-Length")
+		hdr.Del("Content-Range")
+		if errors.Is(err, objects.ErrObjectNotFound) {
+			http.Error(w, "not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "unable to read content", http.StatusInternalServerError)
+		}
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	w.WriteHeader(status)
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func contentType(c Content) string {
+	if c.ContentType != "" {
+		return c.ContentType
+	}
+	if ct := mime.TypeByExtension(filepath.Ext(c.Name)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+// ifRangeMatches reports whether a Range header should be honored,
+// given the value of the If-Range header.
+func ifRangeMatches(ifRange string, c Content) bool {
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, `W/`) {
+		// If-Range requires a strong comparison.
+		return c.ETag != "" && !strings.HasPrefix(c.ETag, "W/") && ifRange == c.ETag
+	}
+	t, err := http.ParseTime(ifRange)
+	return err == nil && !c.ModTime.IsZero() && c.ModTime.Truncate(time.Second).Equal(t)
+}
+
+var errUnsatisfiableRange = errors.New("requested range not satisfiable")
+
+// parseRange parses a Range header value for content of the given size.
+//
+// It reports ok == false if the header should be ignored, which is the case
+// for malformed headers and for requests for multiple ranges.
+// It returns an error if the range can't be satisfied.
+func parseRange(s string, size int64) (offset, length int64, ok bool, err error) {
+	spec, found := strings.CutPrefix(s, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false, nil
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false, nil
+	}
+
+	if first == "" {
+		// Suffix range: the last n bytes.
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return 0, 0, false, nil
+		}
+		if n == 0 || size == 0 {
+			return 0, 0, false, errUnsatisfiableRange
+		}
+		n = min(n, size)
+		return size - n, n, true, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, false, nil
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, false, nil
+		}
+		end = min(end, size-1)
+	}
+	if start >= size {
+		return 0, 0, false, errUnsatisfiableRange
+	}
+	return start, end - start + 1, true, nil
+}
@@ -0,0 +1,88 @@
+package download
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		header     string
+		size       int64
+		wantOffset int64
+		wantLength int64
+		wantOK     bool
+		wantErr    bool
+	}{
+		{"bytes=0-4", 10, 0, 5, true, false},
+		{"bytes=5-", 10, 5, 5, true, false},
+		{"bytes=-3", 10, 7, 3, true, false},
+		{"bytes=-30", 10, 0, 10, true, false},
+		{"bytes=8-100", 10, 8, 2, true, false},
+		{"bytes=10-", 10, 0, 0, false, true},
+		{"bytes=-0", 10, 0, 0, false, true},
+		{"bytes=0-1,3-4", 10, 0, 0, false, false},
+		{"bytes=4-2", 10, 0, 0, false, false},
+		{"items=0-1", 10, 0, 0, false, false},
+	}
+	for _, tt := range tests {
+		offset, length, ok, err := parseRange(tt.header, tt.size)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseRange(%q): got err %v, want err %v", tt.header, err, tt.wantErr)
+			continue
+		}
+		if ok != tt.wantOK || offset != tt.wantOffset || length != tt.wantLength {
+			t.Errorf("parseRange(%q) = %d, %d, %v, want %d, %d, %v",
+				tt.header, offset, length, ok, tt.wantOffset, tt.wantLength, tt.wantOK)
+		}
+	}
+}
+
+func TestServe(t *testing.T) {
+	const data = "hello, world"
+	tests := []struct {
+		name         string
+		method       string
+		header       http.Header
+		wantCode     int
+		wantBody     string
+		wantRangeHdr string
+	}{
+		{"full", "GET", nil, http.StatusOK, data, ""},
+		{"range", "GET", http.Header{"Range": {"bytes=7-11"}}, http.StatusPartialContent, "world", "bytes 7-11/12"},
+		{"head_range", "HEAD", http.Header{"Range": {"bytes=7-"}}, http.StatusPartialContent, "", "bytes 7-11/12"},
+		{"unsatisfiable", "GET", http.Header{"Range": {"bytes=20-"}}, http.StatusRequestedRangeNotSatisfiable, "", "bytes */12"},
+		{"if_range_match", "GET", http.Header{"Range": {"bytes=0-4"}, "If-Range": {`"v1"`}}, http.StatusPartialContent, "hello", "bytes 0-4/12"},
+		{"if_range_mismatch", "GET", http.Header{"Range": {"bytes=0-4"}, "If-Range": {`"v0"`}}, http.StatusOK, data, ""},
+		{"not_modified", "GET", http.Header{"If-None-Match": {`"v1"`}}, http.StatusNotModified, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := FromReader("greeting.txt", strings.NewReader(data), int64(len(data)))
+			c.ETag = `"v1"`
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			if err := Serve(w, req, c); err != nil {
+				t.Fatal(err)
+			}
+
+			if w.Code != tt.wantCode {
+				t.Errorf("got code %d, want %d", w.Code, tt.wantCode)
+			}
+			if got := w.Header().Get("Content-Range"); got != tt.wantRangeHdr {
+				t.Errorf("got Content-Range %q, want %q", got, tt.wantRangeHdr)
+			}
+			if tt.wantCode != http.StatusRequestedRangeNotSatisfiable {
+				if got := w.Body.String(); got != tt.wantBody {
+					t.Errorf("got body %q, want %q", got, tt.wantBody)
+				}
+			}
+		})
+	}
+}
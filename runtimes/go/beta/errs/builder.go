@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"encore.dev/appruntime/exported/stack"
+	"encore.dev/beta/i18n"
 )
 
 // A Builder allows for gradual construction of an error.
@@ -18,9 +19,11 @@ type Builder struct {
 	stack    stack.Stack
 	stackSet bool
 
-	msg  string
-	meta []interface{}
-	err  error
+	msg     string
+	msgKey  string
+	msgArgs map[string]string
+	meta    []interface{}
+	err     error
 }
 
 // B is a shorthand for creating a new Builder.
@@ -45,6 +48,23 @@ func (b *Builder) Msgf(format string, args ...interface{}) *Builder {
 	return b
 }
 
+// MsgKey sets the key of a localized message in the app's message catalog,
+// along with key-value pairs for the message's placeholders.
+//
+// The message returned to external clients is localized based on the
+// request's Accept-Language header. If Msg has not been set, the message
+// is also used as the error message, in the default language.
+//
+// See https://encore.dev/docs/go/primitives/api-errors#localized-error-messages.
+func (b *Builder) MsgKey(key string, argPairs ...interface{}) *Builder {
+	b.msgKey = key
+	b.msgArgs = make(map[string]string, len(argPairs)/2)
+	for i := 0; i+1 < len(argPairs); i += 2 {
+		b.msgArgs[fmt.Sprint(argPairs[i])] = fmt.Sprint(argPairs[i+1])
+	}
+	return b
+}
+
 // Meta appends metadata key-value pairs.
 func (b *Builder) Meta(metaPairs ...interface{}) *Builder {
 	b.meta = append(b.meta, metaPairs...)
@@ -91,6 +111,13 @@ func (b *Builder) Err() error {
 	}
 
 	msg := b.msg
+	if msg == "" && b.msgKey != "" {
+		if m, _, ok := i18n.Localize("", b.msgKey, b.msgArgs); ok {
+			msg = m
+		} else {
+			msg = b.msgKey
+		}
+	}
 	if msg == "" && b.err == nil {
 		msg = "unknown error"
 	}
@@ -111,6 +138,8 @@ func (b *Builder) Err() error {
 		Message:    msg,
 		Meta:       mergeMeta(errMeta, b.meta),
 		Details:    b.det,
+		msgKey:     b.msgKey,
+		msgArgs:    b.msgArgs,
 		underlying: b.err,
 		stack:      s,
 	}
@@ -46,6 +46,11 @@ type Error struct {
 	// the Encore application. They are not exposed to external clients.
 	Meta Metadata `json:"-"`
 
+	// msgKey and msgArgs describe the localized message
+	// in the app's message catalog, if any.
+	msgKey  string
+	msgArgs map[string]string
+
 	// underlying is the underlying error,
 	// for use with errors.Is and errors.As.
 	// It is not propagated across RPC boundaries.
@@ -6,12 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"net/http"
 
 	jsoniter "github.com/json-iterator/go"
 
 	"encore.dev/appruntime/apisdk/api/errmarshalling"
 	"encore.dev/appruntime/exported/stack"
+	"encore.dev/beta/i18n"
 )
 
 var statusToCode = map[int]ErrCode{
@@ -66,6 +68,8 @@ func RoundTrip(err error) error {
 		e2 := &Error{
 			Code:    e.Code,
 			Message: e.Message,
+			msgKey:  e.msgKey,
+			msgArgs: maps.Clone(e.msgArgs),
 			stack:   stack.Build(3), // skip caller of RoundTrip as well
 		}
 
@@ -232,6 +236,17 @@ func writeErrorFieldsToInternalStream(e *Error, stream *jsoniter.Stream) {
 		}
 	}
 
+	if e.msgKey != "" {
+		stream.WriteMore()
+		stream.WriteObjectField("msg_key")
+		stream.WriteString(e.msgKey)
+		if len(e.msgArgs) > 0 {
+			stream.WriteMore()
+			stream.WriteObjectField("msg_args")
+			stream.WriteVal(e.msgArgs)
+		}
+	}
+
 	if e.underlying != nil {
 		stream.WriteMore()
 		stream.WriteObjectField(errmarshalling.WrappedKey)
@@ -248,6 +263,10 @@ func unmarshalFromInternalIterator(e *Error, itr *jsoniter.Iterator) {
 			e.Message = itr.ReadString()
 		case "meta":
 			itr.ReadVal(&e.Meta)
+		case "msg_key":
+			e.msgKey = itr.ReadString()
+		case "msg_args":
+			itr.ReadVal(&e.msgArgs)
 		case errmarshalling.WrappedKey:
 			e.underlying = errmarshalling.UnmarshalError(itr)
 		default:
@@ -261,3 +280,24 @@ func unmarshalFromInternalIterator(e *Error, itr *jsoniter.Iterator) {
 func init() {
 	errmarshalling.RegisterErrorMarshaller(writeErrorFieldsToInternalStream, unmarshalFromInternalIterator)
 }
+
+// Localize returns a copy of err with its message localized according to
+// acceptLanguage (the value of an Accept-Language header), along with the
+// language of the message.
+//
+// If err has no localized message, or the message is not available,
+// it returns err unmodified and an empty language.
+func Localize(err error, acceptLanguage string) (localized error, lang string) {
+	var e *Error
+	if !errors.As(err, &e) || e.msgKey == "" {
+		return err, ""
+	}
+	msg, lang, ok := i18n.Localize(acceptLanguage, e.msgKey, e.msgArgs)
+	if !ok {
+		return err, ""
+	}
+
+	e2 := *e
+	e2.Message = msg
+	return &e2, lang
+}
@@ -0,0 +1,84 @@
+// Package httpcache provides helpers for implementing HTTP caching
+// using entity tags (ETags) and conditional requests.
+//
+// When an endpoint sets the ETag or Last-Modified response headers,
+// the API Gateway automatically responds with 304 Not Modified to
+// GET and HEAD requests whose If-None-Match or If-Modified-Since
+// headers show that the client's cached copy is still fresh.
+//
+// For more information see https://encore.dev/docs/go/primitives/defining-apis#http-caching.
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ETag returns a strong entity tag for v, computed from its JSON encoding.
+// The returned value is quoted and can be used as-is for the ETag header.
+func ETag(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return ETagBytes(data), nil
+}
+
+// WeakETag is like ETag but returns a weak entity tag,
+// for responses that are semantically but not byte-for-byte equivalent.
+func WeakETag(v any) (string, error) {
+	tag, err := ETag(v)
+	if err != nil {
+		return "", err
+	}
+	return "W/" + tag, nil
+}
+
+// ETagBytes returns a strong entity tag for the given response body.
+func ETagBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// NotModified reports whether a GET or HEAD request with the given headers
+// can be responded to with 304 Not Modified, for a resource with the given
+// entity tag and last modification time. Either may be the zero value if unknown.
+//
+// As specified by RFC 9110, If-Modified-Since is only considered
+// if the request does not contain an If-None-Match header.
+func NotModified(reqHeaders http.Header, etag string, lastModified time.Time) bool {
+	if inm := reqHeaders.Get("If-None-Match"); inm != "" {
+		return etag != "" && etagListMatches(inm, etag)
+	}
+
+	ims := reqHeaders.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// HTTP dates have a resolution of one second.
+	return !lastModified.Truncate(time.Second).After(t)
+}
+
+// etagListMatches reports whether the If-None-Match header value list
+// contains etag, using the weak comparison function.
+func etagListMatches(list, etag string) bool {
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
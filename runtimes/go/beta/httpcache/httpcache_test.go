@@ -0,0 +1,64 @@
+package httpcache
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestETag(t *testing.T) {
+	a, err := ETag(map[string]int{"a": 1, "b": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ETag(map[string]int{"b": 2, "a": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Errorf("got different etags for equal values: %s != %s", a, b)
+	}
+	if a[0] != '"' || a[len(a)-1] != '"' {
+		t.Errorf("etag %s is not quoted", a)
+	}
+
+	weak, err := WeakETag(map[string]int{"a": 1, "b": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weak != "W/"+a {
+		t.Errorf("got weak etag %s, want W/%s", weak, a)
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC)
+	tests := []struct {
+		name    string
+		headers http.Header
+		etag    string
+		modTime time.Time
+		want    bool
+	}{
+		{"no_conditions", http.Header{}, `"abc"`, modTime, false},
+		{"etag_match", http.Header{"If-None-Match": {`"abc"`}}, `"abc"`, time.Time{}, true},
+		{"etag_list_match", http.Header{"If-None-Match": {`"xyz", "abc"`}}, `"abc"`, time.Time{}, true},
+		{"etag_weak_match", http.Header{"If-None-Match": {`W/"abc"`}}, `"abc"`, time.Time{}, true},
+		{"etag_mismatch", http.Header{"If-None-Match": {`"xyz"`}}, `"abc"`, time.Time{}, false},
+		{"etag_wildcard", http.Header{"If-None-Match": {"*"}}, `"abc"`, time.Time{}, true},
+		{"etag_takes_precedence", http.Header{
+			"If-None-Match":     {`"xyz"`},
+			"If-Modified-Since": {modTime.Format(http.TimeFormat)},
+		}, `"abc"`, modTime, false},
+		{"not_modified_since", http.Header{"If-Modified-Since": {modTime.Format(http.TimeFormat)}}, "", modTime, true},
+		{"modified_since", http.Header{"If-Modified-Since": {modTime.Add(-time.Hour).Format(http.TimeFormat)}}, "", modTime, false},
+		{"invalid_date", http.Header{"If-Modified-Since": {"yesterday"}}, "", modTime, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NotModified(tt.headers, tt.etag, tt.modTime); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
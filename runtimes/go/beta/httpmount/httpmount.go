@@ -0,0 +1,141 @@
+// Package httpmount mounts existing net/http applications, such as those
+// built with chi or echo, as raw endpoints of an Encore service.
+//
+// This makes it possible to migrate a legacy service to Encore incrementally:
+// the existing router keeps handling its routes, while Encore provides
+// routing, authentication and tracing for the requests it receives.
+//
+// For more information see https://encore.dev/docs/go/primitives/raw-endpoints#mounting-existing-http-handlers.
+package httpmount
+
+import (
+	"net/http"
+	"strings"
+
+	"encore.dev"
+)
+
+// PrefixHeader is the header set to the path the handler is mounted at,
+// for handlers that need to generate absolute URLs.
+const PrefixHeader = "X-Forwarded-Prefix"
+
+// currentRequest and userID are set when running in an Encore app.
+var (
+	currentRequest = func() *encore.Request { return nil }
+	userID         = func() (string, bool) { return "", false }
+)
+
+// Option configures how a handler is mounted.
+type Option func(*mount)
+
+// UserIDHeader passes the authenticated user's id to the handler in the given header,
+// for handlers that identify users by a header set by an authenticating proxy.
+//
+// Any value of the header sent by the client is removed, so the handler
+// can trust that it was set by Encore.
+func UserIDHeader(name string) Option {
+	return func(m *mount) {
+		m.userIDHeader = http.CanonicalHeaderKey(name)
+	}
+}
+
+// Handler returns a handler that serves requests to a raw endpoint with h.
+//
+// The endpoint's path must end with a wildcard parameter, like "/legacy/*path",
+// or the endpoint must be a fallback endpoint ("/!fallback"). The request path
+// passed to h is the value of the wildcard parameter, so h sees requests to
+// "/legacy/users/1" as requests to "/users/1". Requests to other endpoints
+// are passed to h unmodified.
+//
+// For example:
+//
+//	var legacy = httpmount.Handler(newLegacyRouter())
+//
+//	//encore:api public raw path=/legacy/*path
+//	func Legacy(w http.ResponseWriter, req *http.Request) {
+//		legacy.ServeHTTP(w, req)
+//	}
+//
+// The handler runs within the endpoint's request, so it is traced and has access
+// to the endpoint's authentication data, and any logs written with encore.dev/rlog
+// and calls to other endpoints are included in the trace.
+func Handler(h http.Handler, opts ...Option) http.Handler {
+	m := &mount{h: h}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+type mount struct {
+	h            http.Handler
+	userIDHeader string
+}
+
+func (m *mount) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if cr := currentRequest(); cr != nil && len(cr.PathParams) > 0 {
+		// The wildcard is always the last path parameter.
+		rest := cr.PathParams[len(cr.PathParams)-1].Value
+		req = stripPrefix(req, rest)
+	}
+
+	if m.userIDHeader != "" {
+		req = shallowClone(req)
+		req.Header.Del(m.userIDHeader)
+		if uid, ok := userID(); ok {
+			req.Header.Set(m.userIDHeader, uid)
+		}
+	}
+	m.h.ServeHTTP(w, req)
+}
+
+// stripPrefix returns a request for the path rest, with the prefix
+// preceding it removed from the request path. If the request path
+// does not end with rest, req is returned unmodified.
+func stripPrefix(req *http.Request, rest string) *http.Request {
+	rest = "/" + strings.TrimPrefix(rest, "/")
+	path := req.URL.Path
+	if !strings.HasSuffix(path, rest) {
+		// The trailing slash of a wildcard's mount point is optional.
+		if rest != "/" || strings.HasSuffix(path, "/") {
+			return req
+		}
+		path += "/"
+	}
+	prefix := strings.TrimSuffix(path, rest)
+
+	r2 := shallowClone(req)
+	r2.URL.Path = rest
+	if req.URL.RawPath != "" {
+		// Strip the same number of segments from the escaped path.
+		r2.URL.RawPath = "/" + dropSegments(req.URL.RawPath, strings.Count(prefix, "/"))
+	}
+	if prefix != "" {
+		r2.Header.Set(PrefixHeader, prefix)
+	}
+	return r2
+}
+
+// dropSegments removes the first n segments from path.
+func dropSegments(path string, n int) string {
+	path = strings.TrimPrefix(path, "/")
+	for range n {
+		idx := strings.IndexByte(path, '/')
+		if idx < 0 {
+			return ""
+		}
+		path = path[idx+1:]
+	}
+	return path
+}
+
+// shallowClone returns a copy of req with a copy of its URL and headers,
+// so they can be modified without affecting req.
+func shallowClone(req *http.Request) *http.Request {
+	r2 := new(http.Request)
+	*r2 = *req
+	u := *req.URL
+	r2.URL = &u
+	r2.Header = req.Header.Clone()
+	return r2
+}
@@ -0,0 +1,66 @@
+package httpmount
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"encore.dev"
+)
+
+func TestStripPrefix(t *testing.T) {
+	tests := []struct {
+		target     string
+		rest       string
+		wantPath   string
+		wantRaw    string
+		wantPrefix string
+	}{
+		{"/legacy/users/1", "users/1", "/users/1", "", "/legacy"},
+		{"/legacy/", "", "/", "", "/legacy"},
+		{"/legacy", "", "/", "", "/legacy"},
+		{"/v1/legacy/a%2Fb/c", "a/b/c", "/a/b/c", "/a%2Fb/c", "/v1/legacy"},
+		{"/users/1", "users/1", "/users/1", "", ""},
+		{"/other", "users", "/other", "", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.target, nil)
+		got := stripPrefix(req, tt.rest)
+		if got.URL.Path != tt.wantPath || got.URL.RawPath != tt.wantRaw || got.Header.Get(PrefixHeader) != tt.wantPrefix {
+			t.Errorf("stripPrefix(%q, %q) = path %q, raw path %q, prefix %q, want %q, %q, %q",
+				tt.target, tt.rest, got.URL.Path, got.URL.RawPath, got.Header.Get(PrefixHeader),
+				tt.wantPath, tt.wantRaw, tt.wantPrefix)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	origReq, origUID := currentRequest, userID
+	t.Cleanup(func() { currentRequest, userID = origReq, origUID })
+
+	currentRequest = func() *encore.Request {
+		return &encore.Request{PathParams: encore.PathParams{{Name: "path", Value: "users/1"}}}
+	}
+	userID = func() (string, bool) { return "alice", true }
+
+	var gotPath, gotUser string
+	h := Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotPath, gotUser = req.URL.Path, req.Header.Get("X-User-Id")
+	}), UserIDHeader("x-user-id"))
+
+	req := httptest.NewRequest("GET", "/legacy/users/1", nil)
+	req.Header.Set("X-User-Id", "mallory")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if gotPath != "/users/1" || gotUser != "alice" {
+		t.Errorf("got path %q and user %q, want %q and %q", gotPath, gotUser, "/users/1", "alice")
+	}
+	if req.Header.Get("X-User-Id") != "mallory" {
+		t.Errorf("original request was modified")
+	}
+
+	userID = func() (string, bool) { return "", false }
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if gotUser != "" {
+		t.Errorf("got user %q for unauthenticated request, want none", gotUser)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build encore_app
+
+package httpmount
+
+import (
+	"encore.dev"
+	"encore.dev/beta/auth"
+)
+
+func init() {
+	currentRequest = encore.CurrentRequest
+	userID = func() (string, bool) {
+		uid, ok := auth.UserID()
+		return string(uid), ok
+	}
+}
@@ -0,0 +1,346 @@
+// Package i18n provides localization of API error messages.
+//
+// Messages are defined in a message catalog: a "locales" directory in the root
+// of the app containing one JSON file per language, named after the language tag
+// (like "en.json" or "pt-BR.json"). Each file maps message keys to message templates,
+// where placeholders are written as {name}:
+//
+//	{
+//	    "user.not_found": "User {id} was not found"
+//	}
+//
+// The catalog is validated and embedded into the application when it's built.
+// Errors reference messages with errs.Builder.MsgKey, and the message returned to
+// external clients is localized based on the request's Accept-Language header.
+//
+// For more information see https://encore.dev/docs/go/primitives/api-errors#localized-error-messages.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is the language used when none of the
+// languages requested by the client are available,
+// unless overridden with SetDefaultLanguage.
+const DefaultLanguage = "en"
+
+// Catalog is a validated set of message templates, keyed by language and message key.
+type Catalog struct {
+	messages map[string]map[string]string // canonical lang -> key -> template
+	tags     map[string]string            // canonical lang -> lang tag
+}
+
+// NewCatalog returns a catalog with the given messages, keyed by language and message key.
+//
+// It reports an error if a language tag or message template is invalid,
+// or if the translations of a message use different placeholders.
+func NewCatalog(messages map[string]map[string]string) (*Catalog, error) {
+	c := &Catalog{
+		messages: make(map[string]map[string]string, len(messages)),
+		tags:     make(map[string]string, len(messages)),
+	}
+
+	// placeholders tracks the placeholders of each message key,
+	// and which language they were first seen in.
+	type seen struct {
+		lang  string
+		names []string
+	}
+	placeholders := make(map[string]seen)
+
+	// Iterate in a deterministic order so errors are stable.
+	langs := make([]string, 0, len(messages))
+	for lang := range messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		if !validLangTag(lang) {
+			return nil, fmt.Errorf("invalid language tag %q", lang)
+		}
+		msgs := messages[lang]
+
+		keys := make([]string, 0, len(msgs))
+		for key := range msgs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if key == "" {
+				return nil, fmt.Errorf("language %s: empty message key", lang)
+			}
+			names, err := Placeholders(msgs[key])
+			if err != nil {
+				return nil, fmt.Errorf("language %s: message %q: %v", lang, key, err)
+			}
+			if prev, ok := placeholders[key]; !ok {
+				placeholders[key] = seen{lang: lang, names: names}
+			} else if !slices.Equal(prev.names, names) {
+				return nil, fmt.Errorf("message %q: language %s uses placeholders {%s} but language %s uses {%s}",
+					key, lang, strings.Join(names, "}, {"), prev.lang, strings.Join(prev.names, "}, {"))
+			}
+		}
+		c.messages[canonicalLang(lang)] = msgs
+		c.tags[canonicalLang(lang)] = lang
+	}
+	return c, nil
+}
+
+// LoadCatalog loads a catalog from the "*.json" files in the root of fsys,
+// where each file is named after the language it contains messages for.
+func LoadCatalog(fsys fs.FS) (*Catalog, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var msgs map[string]string
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			return nil, fmt.Errorf("%s: invalid message catalog: %v", file, err)
+		}
+		messages[strings.TrimSuffix(path.Base(file), ".json")] = msgs
+	}
+	return NewCatalog(messages)
+}
+
+// Languages returns the languages in the catalog, sorted.
+func (c *Catalog) Languages() []string {
+	langs := make([]string, 0, len(c.tags))
+	for _, lang := range c.tags {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Messages returns the message templates for lang, keyed by message key.
+// The returned map must not be modified.
+func (c *Catalog) Messages(lang string) map[string]string {
+	return c.messages[canonicalLang(lang)]
+}
+
+// Lookup returns the message template for key in the given language.
+func (c *Catalog) Lookup(lang, key string) (template string, ok bool) {
+	template, ok = c.messages[canonicalLang(lang)][key]
+	return template, ok
+}
+
+var placeholderRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Placeholders returns the sorted, unique placeholder names used in template.
+// Literal braces are written as "{{" and "}}".
+// It reports an error if the template is malformed.
+func Placeholders(template string) ([]string, error) {
+	var names []string
+	err := parseTemplate(template, func(lit string) {}, func(name string) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	})
+	sort.Strings(names)
+	return names, err
+}
+
+// Format formats template, replacing each placeholder with the corresponding argument.
+// Placeholders without a corresponding argument are left as is.
+func Format(template string, args map[string]string) string {
+	var b strings.Builder
+	err := parseTemplate(template, func(lit string) {
+		b.WriteString(lit)
+	}, func(name string) {
+		if v, ok := args[name]; ok {
+			b.WriteString(v)
+		} else {
+			b.WriteString("{" + name + "}")
+		}
+	})
+	if err != nil {
+		return template
+	}
+	return b.String()
+}
+
+func parseTemplate(s string, lit, placeholder func(string)) error {
+	for len(s) > 0 {
+		i := strings.IndexAny(s, "{}")
+		if i < 0 {
+			lit(s)
+			return nil
+		}
+		lit(s[:i])
+
+		switch {
+		case strings.HasPrefix(s[i:], "{{"):
+			lit("{")
+			s = s[i+2:]
+		case strings.HasPrefix(s[i:], "}}"):
+			lit("}")
+			s = s[i+2:]
+		case s[i] == '}':
+			return fmt.Errorf("unexpected '}' at offset %d", i)
+		default:
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return fmt.Errorf("unterminated placeholder at offset %d", i)
+			}
+			name := s[i+1 : i+end]
+			if !placeholderRe.MatchString(name) {
+				return fmt.Errorf("invalid placeholder {%s}", name)
+			}
+			placeholder(name)
+			s = s[i+end+1:]
+		}
+	}
+	return nil
+}
+
+// FallbackFunc returns the languages to try, in order, when a message
+// is not available in the language lang. For example a fallback for "nb"
+// (Norwegian Bokmål) could return []string{"no", "da"}.
+//
+// Base languages (like "pt" for "pt-BR") are tried after the languages
+// returned by the fallback function, and the default language is tried last.
+type FallbackFunc func(lang string) []string
+
+var (
+	mu          sync.RWMutex
+	catalog     *Catalog
+	fallback    FallbackFunc
+	defaultLang = DefaultLanguage
+)
+
+// SetCatalog sets the catalog used for localizing messages.
+// By default the catalog in the app's "locales" directory is used.
+func SetCatalog(c *Catalog) {
+	mu.Lock()
+	defer mu.Unlock()
+	catalog = c
+}
+
+// SetFallback sets the function used for determining fallback languages.
+func SetFallback(fn FallbackFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	fallback = fn
+}
+
+// SetDefaultLanguage sets the language used when none of the languages
+// requested by the client are available. It defaults to DefaultLanguage.
+func SetDefaultLanguage(lang string) {
+	mu.Lock()
+	defer mu.Unlock()
+	defaultLang = lang
+}
+
+// Localize formats the message with the given key in the best available language,
+// based on the languages in acceptLanguage (the value of an Accept-Language header).
+//
+// It returns the formatted message and the language it is in,
+// and reports false if the message is not available in any language.
+func Localize(acceptLanguage, key string, args map[string]string) (msg, lang string, ok bool) {
+	mu.RLock()
+	c, fb, def := catalog, fallback, defaultLang
+	mu.RUnlock()
+	if c == nil {
+		return "", "", false
+	}
+
+	for _, lang := range candidates(ParseAcceptLanguage(acceptLanguage), fb, def) {
+		if template, ok := c.Lookup(lang, key); ok {
+			return Format(template, args), c.tags[lang], true
+		}
+	}
+	return "", "", false
+}
+
+// candidates returns the languages to try, in order of preference.
+func candidates(requested []string, fb FallbackFunc, def string) []string {
+	var langs []string
+	add := func(lang string) {
+		lang = canonicalLang(lang)
+		if lang != "" && !slices.Contains(langs, lang) {
+			langs = append(langs, lang)
+		}
+	}
+
+	for _, lang := range requested {
+		add(lang)
+		if fb != nil {
+			for _, l := range fb(lang) {
+				add(l)
+			}
+		}
+		if base, _, found := strings.Cut(lang, "-"); found {
+			add(base)
+		}
+	}
+	add(def)
+	return langs
+}
+
+// ParseAcceptLanguage parses an Accept-Language header value,
+// returning the requested languages ordered by preference.
+// The wildcard "*" and languages with a quality of 0 are omitted.
+func ParseAcceptLanguage(header string) []string {
+	type entry struct {
+		lang string
+		q    float64
+	}
+	var entries []entry
+	for _, part := range strings.Split(header, ",") {
+		lang, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang = strings.TrimSpace(lang)
+		if lang == "" || lang == "*" || !validLangTag(lang) {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if q > 0 {
+			entries = append(entries, entry{lang: lang, q: q})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+	langs := make([]string, len(entries))
+	for i, e := range entries {
+		langs[i] = e.lang
+	}
+	return langs
+}
+
+var langTagRe = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+
+func validLangTag(lang string) bool {
+	return langTagRe.MatchString(lang)
+}
+
+// canonicalLang returns the canonical form of a language tag
+// for comparison purposes, like "pt-br" for "pt-BR".
+func canonicalLang(lang string) string {
+	return strings.ToLower(lang)
+}
@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewCatalog(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages map[string]map[string]string
+		wantErr  string
+	}{
+		{
+			name: "valid",
+			messages: map[string]map[string]string{
+				"en":    {"user.not_found": "User {id} was not found", "braces": "Use {{ and }}"},
+				"pt-BR": {"user.not_found": "Usuário {id} não encontrado"},
+			},
+		},
+		{
+			name:     "invalid_lang",
+			messages: map[string]map[string]string{"en_US": {"a": "b"}},
+			wantErr:  `invalid language tag "en_US"`,
+		},
+		{
+			name:     "unterminated",
+			messages: map[string]map[string]string{"en": {"a": "hello {name"}},
+			wantErr:  "unterminated placeholder",
+		},
+		{
+			name: "placeholder_mismatch",
+			messages: map[string]map[string]string{
+				"en": {"a": "hello {name}"},
+				"sv": {"a": "hej {namn}"},
+			},
+			wantErr: `message "a": language sv uses placeholders {namn} but language en uses {name}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCatalog(tt.messages)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("got err %v, want nil", err)
+			} else if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("got err %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	got := ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, de;q=0, *;q=0.5")
+	want := []string{"fr-CH", "fr", "en"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	c, err := NewCatalog(map[string]map[string]string{
+		"en":    {"greeting": "Hello, {name}!", "bye": "Goodbye"},
+		"pt":    {"greeting": "Olá, {name}!"},
+		"nb":    {"greeting": "Hei, {name}!"},
+		"pt-BR": {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetCatalog(c)
+	SetFallback(func(lang string) []string {
+		if lang == "nn" {
+			return []string{"nb"}
+		}
+		return nil
+	})
+	t.Cleanup(func() {
+		SetCatalog(nil)
+		SetFallback(nil)
+	})
+
+	args := map[string]string{"name": "Ada"}
+	tests := []struct {
+		accept   string
+		key      string
+		wantMsg  string
+		wantLang string
+	}{
+		{"pt-BR", "greeting", "Olá, Ada!", "pt"},
+		{"nn, en;q=0.5", "greeting", "Hei, Ada!", "nb"},
+		{"de", "greeting", "Hello, Ada!", "en"},
+		{"pt", "bye", "Goodbye", "en"},
+		{"", "greeting", "Hello, Ada!", "en"},
+	}
+	for _, tt := range tests {
+		msg, lang, ok := Localize(tt.accept, tt.key, args)
+		if !ok || msg != tt.wantMsg || lang != tt.wantLang {
+			t.Errorf("Localize(%q, %q) = %q, %q, %v, want %q, %q, true",
+				tt.accept, tt.key, msg, lang, ok, tt.wantMsg, tt.wantLang)
+		}
+	}
+
+	if _, _, ok := Localize("en", "missing", nil); ok {
+		t.Errorf("Localize of missing key: got ok, want !ok")
+	}
+}
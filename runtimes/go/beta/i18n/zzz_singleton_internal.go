@@ -0,0 +1,16 @@
+//go:build encore_app
+
+package i18n
+
+import (
+	"encore.dev/appruntime/shared/appconf"
+)
+
+func init() {
+	if msgs := appconf.Static.MessageCatalog; len(msgs) > 0 {
+		// The catalog is validated when the app is built.
+		if c, err := NewCatalog(msgs); err == nil {
+			catalog = c
+		}
+	}
+}
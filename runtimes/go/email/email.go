@@ -0,0 +1,18 @@
+//go:build encore_app
+
+package email
+
+// NewInbound declares a new inbound email address, delivering
+// the messages it receives to the configured handler.
+//
+// It must be called when declaring a package level variable:
+//
+//	var _ = email.NewInbound("support", email.InboundConfig{
+//		Handler:     HandleSupportEmail,
+//		Attachments: objects.BucketRef[objects.Uploader](SupportAttachments),
+//	})
+//
+// The name must be unique within the application.
+func NewInbound(name string, cfg InboundConfig) *Inbound {
+	return newInbound(Singleton, name, cfg)
+}
@@ -0,0 +1,258 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"encore.dev/storage/objects"
+)
+
+// maxMessageSize is the maximum size of a message, including its attachments.
+const maxMessageSize = 32 << 20
+
+// InboundConfig configures an inbound email address.
+type InboundConfig struct {
+	// Handler is called with every message received.
+	// If it returns an error the message is rejected,
+	// and the sender retries delivering it later.
+	Handler func(ctx context.Context, msg *Message) error
+
+	// Attachments is the bucket the attachments of messages are stored in.
+	// If nil the attachments are kept in memory, in (*Attachment).Data.
+	Attachments objects.Uploader
+}
+
+// Inbound is an inbound email address, declared with NewInbound.
+type Inbound struct {
+	name string
+	cfg  InboundConfig
+}
+
+// Name returns the name of the inbound email address.
+func (in *Inbound) Name() string {
+	return in.name
+}
+
+// Message is a received email message.
+type Message struct {
+	// ID is the message's Message-ID header, without angle brackets.
+	ID string
+
+	From    *mail.Address
+	To      []*mail.Address
+	Cc      []*mail.Address
+	ReplyTo []*mail.Address
+	Subject string
+	Date    time.Time
+
+	// Header contains all the message's headers.
+	Header mail.Header
+
+	// Recipients are the addresses the message was delivered to,
+	// which includes recipients not listed in To and Cc, such as Bcc recipients.
+	Recipients []string
+
+	// Text and HTML are the plain text and HTML bodies of the message, if any.
+	Text string
+	HTML string
+
+	Attachments []*Attachment
+}
+
+// Attachment describes an attachment of a received message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+
+	// ContentID is the attachment's Content-ID header, without angle brackets,
+	// used by inline images referenced from the HTML body.
+	ContentID string
+
+	// Size is the size of the attachment, in bytes.
+	Size int64
+
+	// Key is the key of the object the attachment is stored as,
+	// in the inbound's Attachments bucket.
+	Key string
+
+	// Data is the content of the attachment,
+	// set when the inbound has no Attachments bucket.
+	Data []byte
+}
+
+func newInbound(mgr *Manager, name string, cfg InboundConfig) *Inbound {
+	if cfg.Handler == nil {
+		mgr.rootLogger.Fatal().Msgf("inbound email %s: no handler configured", name)
+	}
+	in := &Inbound{name: name, cfg: cfg}
+	mgr.register(in)
+	return in
+}
+
+// deliver parses the raw message, stores its attachments
+// and calls the handler with it.
+func (in *Inbound) deliver(ctx context.Context, raw []byte, recipients []string) error {
+	msg, parts, err := parseMessage(raw)
+	if err != nil {
+		return err
+	}
+	msg.Recipients = recipients
+
+	// Store the attachments under a prefix unique to the delivery,
+	// as the Message-ID header can't be trusted to be unique.
+	prefix := in.name + "/" + xid.New().String()
+	for i, a := range msg.Attachments {
+		if in.cfg.Attachments == nil {
+			a.Data = parts[i]
+			continue
+		}
+		a.Key = fmt.Sprintf("%s/%d-%s", prefix, i, attachmentObjectName(a.Filename))
+		w := in.cfg.Attachments.Upload(ctx, a.Key,
+			objects.WithUploadAttrs(objects.UploadAttrs{ContentType: a.ContentType}))
+		if _, err := w.Write(parts[i]); err != nil {
+			w.Abort(err)
+			return fmt.Errorf("store attachment %q: %w", a.Filename, err)
+		}
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("store attachment %q: %w", a.Filename, err)
+		}
+	}
+
+	return in.cfg.Handler(ctx, msg)
+}
+
+// attachmentObjectName returns the name an attachment is stored under,
+// given its filename.
+func attachmentObjectName(filename string) string {
+	name := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if name == "." || name == "/" || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+// parseMessage parses a raw RFC 5322 message.
+// It returns the parsed message along with the content of its attachments,
+// in the same order as msg.Attachments.
+func parseMessage(raw []byte) (msg *Message, attachments [][]byte, err error) {
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse message: %w", err)
+	}
+
+	msg = &Message{
+		ID:      strings.Trim(strings.TrimSpace(m.Header.Get("Message-Id")), "<>"),
+		Subject: decodeHeader(m.Header.Get("Subject")),
+		Header:  m.Header,
+	}
+	if from, err := m.Header.AddressList("From"); err == nil && len(from) > 0 {
+		msg.From = from[0]
+	}
+	msg.To, _ = m.Header.AddressList("To")
+	msg.Cc, _ = m.Header.AddressList("Cc")
+	msg.ReplyTo, _ = m.Header.AddressList("Reply-To")
+	msg.Date, _ = m.Header.Date()
+
+	p := &partWalker{msg: msg}
+	if err := p.walk(m.Header, m.Body); err != nil {
+		return nil, nil, fmt.Errorf("parse message body: %w", err)
+	}
+	return msg, p.data, nil
+}
+
+// partHeader is the subset of the header of a MIME part
+// needed to parse its content.
+type partHeader interface {
+	Get(key string) string
+}
+
+type partWalker struct {
+	msg  *Message
+	data [][]byte
+}
+
+// walk parses a MIME part, recursing into multipart parts.
+func (p *partWalker) walk(h partHeader, body io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		r := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := r.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if err := p.walk(part.Header, part); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransferEncoding(h.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(h.Get("Content-Disposition"))
+	filename := dispParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	isAttachment := disposition == "attachment" || filename != ""
+
+	switch {
+	case !isAttachment && mediaType == "text/plain" && p.msg.Text == "":
+		p.msg.Text = string(content)
+	case !isAttachment && mediaType == "text/html" && p.msg.HTML == "":
+		p.msg.HTML = string(content)
+	default:
+		p.msg.Attachments = append(p.msg.Attachments, &Attachment{
+			Filename:    decodeHeader(filename),
+			ContentType: mediaType,
+			ContentID:   strings.Trim(strings.TrimSpace(h.Get("Content-Id")), "<>"),
+			Size:        int64(len(content)),
+		})
+		p.data = append(p.data, content)
+	}
+	return nil
+}
+
+func decodeTransferEncoding(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		// The decoder ignores the line breaks base64 encoded parts are wrapped with.
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+var wordDecoder = &mime.WordDecoder{}
+
+// decodeHeader decodes the RFC 2047 encoded words in a header value.
+func decodeHeader(s string) string {
+	if dec, err := wordDecoder.DecodeHeader(s); err == nil {
+		return dec
+	}
+	return s
+}
@@ -0,0 +1,114 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+const testMessage = `From: "Alice" <alice@example.com>
+To: support@example.com, Bob <bob@example.com>
+Subject: =?UTF-8?Q?Caf=C3=A9_order?=
+Message-ID: <123@example.com>
+Date: Mon, 2 Jan 2006 15:04:05 +0000
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+Hello=20there
+--inner
+Content-Type: text/html; charset=utf-8
+
+<p>Hello there</p>
+--inner--
+--outer
+Content-Type: text/csv; name="order.csv"
+Content-Disposition: attachment; filename="order.csv"
+Content-Transfer-Encoding: base64
+
+aWQscXR5
+CjEsMgo=
+--outer--
+`
+
+func TestParseMessage(t *testing.T) {
+	c := qt.New(t)
+	raw := strings.ReplaceAll(testMessage, "\n", "\r\n")
+	msg, data, err := parseMessage([]byte(raw))
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(msg.ID, qt.Equals, "123@example.com")
+	c.Assert(msg.From.Address, qt.Equals, "alice@example.com")
+	c.Assert(msg.To, qt.HasLen, 2)
+	c.Assert(msg.To[1].Name, qt.Equals, "Bob")
+	c.Assert(msg.Subject, qt.Equals, "Café order")
+	c.Assert(msg.Date.Equal(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)), qt.IsTrue)
+	c.Assert(msg.Text, qt.Equals, "Hello there")
+	c.Assert(msg.HTML, qt.Equals, "<p>Hello there</p>")
+
+	c.Assert(msg.Attachments, qt.HasLen, 1)
+	c.Assert(msg.Attachments[0].Filename, qt.Equals, "order.csv")
+	c.Assert(msg.Attachments[0].ContentType, qt.Equals, "text/csv")
+	c.Assert(msg.Attachments[0].Size, qt.Equals, int64(11))
+	c.Assert(string(data[0]), qt.Equals, "id,qty\n1,2\n")
+}
+
+func TestParseMessage_PlainText(t *testing.T) {
+	c := qt.New(t)
+	msg, data, err := parseMessage([]byte("From: alice@example.com\r\nSubject: Hi\r\n\r\nJust text.\r\n"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(msg.Subject, qt.Equals, "Hi")
+	c.Assert(msg.Text, qt.Equals, "Just text.\r\n")
+	c.Assert(msg.Attachments, qt.HasLen, 0)
+	c.Assert(data, qt.HasLen, 0)
+}
+
+func TestAttachmentObjectName(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(attachmentObjectName("report.pdf"), qt.Equals, "report.pdf")
+	c.Assert(attachmentObjectName(`C:\Users\alice\report.pdf`), qt.Equals, "report.pdf")
+	c.Assert(attachmentObjectName("../../etc/passwd"), qt.Equals, "passwd")
+	c.Assert(attachmentObjectName(""), qt.Equals, "attachment")
+}
+
+func TestVerifyMailgunSignature(t *testing.T) {
+	c := qt.New(t)
+	now := time.Unix(1700000000, 0)
+	sign := func(key, timestamp, token string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(timestamp + token))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	err := verifyMailgunSignature("key", ts, "token", sign("key", ts, "token"), now)
+	c.Assert(err, qt.IsNil)
+
+	err = verifyMailgunSignature("key", ts, "token", sign("other", ts, "token"), now)
+	c.Assert(errors.Is(err, errUnauthenticated), qt.IsTrue)
+
+	old := strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)
+	err = verifyMailgunSignature("key", old, "token", sign("key", old, "token"), now)
+	c.Assert(errors.Is(err, errUnauthenticated), qt.IsTrue)
+}
+
+func TestCheckSNSURL(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(checkSNSURL("https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem"), qt.IsNil)
+	c.Assert(checkSNSURL("http://sns.us-east-1.amazonaws.com/cert.pem"), qt.IsNotNil)
+	c.Assert(checkSNSURL("https://sns.us-east-1.amazonaws.com.evil.com/cert.pem"), qt.IsNotNil)
+	c.Assert(checkSNSURL("https://evil.com/sns.us-east-1.amazonaws.com"), qt.IsNotNil)
+}
@@ -0,0 +1,115 @@
+package email
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/beta/errs"
+	"encore.dev/internal/platformauth"
+)
+
+// recipientsHeader is the header the local development server
+// passes the envelope recipients of a message in.
+const recipientsHeader = "X-Encore-Email-Recipients"
+
+type Manager struct {
+	runtime    *config.Runtime
+	rootLogger zerolog.Logger
+	client     *http.Client
+
+	mu       sync.Mutex
+	inbounds map[string]*Inbound
+	certs    map[string]*snsCert // SNS signing certificates by URL
+}
+
+func NewManager(runtime *config.Runtime, rootLogger zerolog.Logger) *Manager {
+	return &Manager{
+		runtime:    runtime,
+		rootLogger: rootLogger,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		inbounds:   make(map[string]*Inbound),
+		certs:      make(map[string]*snsCert),
+	}
+}
+
+func (mgr *Manager) register(in *Inbound) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if _, ok := mgr.inbounds[in.name]; ok {
+		mgr.rootLogger.Fatal().Msgf("inbound email %s: declared multiple times", in.name)
+	}
+	mgr.inbounds[in.name] = in
+}
+
+// errUnauthenticated is reported for webhook requests that fail verification.
+var errUnauthenticated = errors.New("invalid request signature")
+
+// handleInbound delivers a message to an inbound email address.
+//
+// Requests from the Encore platform, which includes the local development
+// SMTP server, carry the raw message as the body. Other requests are webhook
+// requests from the email provider configured for the address.
+func (mgr *Manager) handleInbound(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	name := httprouter.ParamsFromContext(ctx).ByName("name")
+
+	mgr.mu.Lock()
+	in := mgr.inbounds[name]
+	mgr.mu.Unlock()
+	if in == nil {
+		errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("inbound email address not found").Err())
+		return
+	}
+
+	var (
+		raw        []byte
+		recipients []string
+		err        error
+	)
+	cfg := mgr.runtime.InboundEmails[name]
+	switch {
+	case platformauth.IsEncorePlatformRequest(ctx):
+		raw, err = io.ReadAll(http.MaxBytesReader(w, req.Body, maxMessageSize))
+		if h := req.Header.Get(recipientsHeader); h != "" {
+			recipients = strings.Split(h, ",")
+		}
+	case cfg != nil && cfg.Mailgun != nil:
+		raw, recipients, err = readMailgunWebhook(req, cfg.Mailgun, time.Now())
+	case cfg != nil && cfg.SES != nil:
+		raw, recipients, err = mgr.readSESWebhook(req, cfg.SES)
+	default:
+		errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("inbound email address not found").Err())
+		return
+	}
+
+	logger := mgr.rootLogger.With().Str("inbound", name).Logger()
+	if errors.Is(err, errUnauthenticated) {
+		logger.Warn().Err(err).Msg("rejected inbound email webhook request")
+		errs.HTTPError(w, errs.B().Code(errs.Unauthenticated).Cause(err).Err())
+		return
+	} else if err != nil {
+		logger.Error().Err(err).Msg("invalid inbound email webhook request")
+		errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Cause(err).Err())
+		return
+	} else if raw == nil {
+		// The request didn't carry a message, such as SNS subscription confirmations.
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := in.deliver(ctx, raw, recipients); err != nil {
+		// Fail the request so the provider retries delivering the message.
+		logger.Error().Err(err).Msg("inbound email handler failed")
+		errs.HTTPError(w, errs.B().Code(errs.Internal).Msg("inbound email handler failed").Err())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
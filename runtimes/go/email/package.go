@@ -0,0 +1,9 @@
+// Package email provides support for receiving email.
+//
+// Inbound email is delivered to a handler function, with the message parsed
+// into its headers, text and HTML bodies and attachments. When running locally
+// with `encore run` messages are received by a local SMTP server, and in production
+// from an email provider's inbound webhooks, such as Amazon SES or Mailgun.
+//
+// For more information see https://encore.dev/docs/primitives/inbound-email
+package email
@@ -0,0 +1,284 @@
+package email
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+// maxWebhookAge is how old webhook requests may be, to prevent replay attacks.
+const maxWebhookAge = 5 * time.Minute
+
+// readMailgunWebhook reads a message from a Mailgun webhook request,
+// as sent by a route forwarding messages to a URL ending with "mime".
+func readMailgunWebhook(req *http.Request, cfg *config.MailgunInboundEmail, now time.Time) (raw []byte, recipients []string, err error) {
+	if err := req.ParseMultipartForm(maxMessageSize); err != nil {
+		return nil, nil, fmt.Errorf("parse form: %w", err)
+	}
+	if err := verifyMailgunSignature(cfg.SigningKey, req.PostFormValue("timestamp"),
+		req.PostFormValue("token"), req.PostFormValue("signature"), now); err != nil {
+		return nil, nil, err
+	}
+
+	body := req.PostFormValue("body-mime")
+	if body == "" {
+		return nil, nil, fmt.Errorf("missing body-mime field, make sure the route forwards to a URL ending with /mime")
+	}
+	if r := req.PostFormValue("recipient"); r != "" {
+		recipients = strings.Split(r, ",")
+	}
+	return []byte(body), recipients, nil
+}
+
+// verifyMailgunSignature verifies the signature of a Mailgun webhook request.
+func verifyMailgunSignature(signingKey, timestamp, token, signature string, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", errUnauthenticated)
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > maxWebhookAge || age < -maxWebhookAge {
+		return fmt.Errorf("%w: timestamp too old", errUnauthenticated)
+	}
+
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return errUnauthenticated
+	}
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(timestamp + token))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return errUnauthenticated
+	}
+	return nil
+}
+
+// snsMessage is a message delivered by an SNS HTTPS subscription.
+type snsMessage struct {
+	Type             string
+	MessageId        string
+	Token            string
+	TopicArn         string
+	Subject          string
+	Message          string
+	Timestamp        string
+	SignatureVersion string
+	Signature        string
+	SigningCertURL   string
+	SubscribeURL     string
+}
+
+// sesNotification is the SNS notification published by an SES receipt rule's SNS action.
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	Receipt          struct {
+		Recipients []string `json:"recipients"`
+		Action     struct {
+			Encoding string `json:"encoding"`
+		} `json:"action"`
+	} `json:"receipt"`
+	Content string `json:"content"`
+}
+
+// readSESWebhook reads a message from an SNS notification published by SES.
+// It confirms subscriptions to the configured topic, in which case no message is returned.
+func (mgr *Manager) readSESWebhook(req *http.Request, cfg *config.SESInboundEmail) (raw []byte, recipients []string, err error) {
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxMessageSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	var msg snsMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, nil, fmt.Errorf("parse SNS message: %w", err)
+	}
+	if msg.TopicArn != cfg.TopicARN {
+		return nil, nil, fmt.Errorf("%w: unexpected topic %q", errUnauthenticated, msg.TopicArn)
+	}
+	if err := mgr.verifySNSSignature(&msg); err != nil {
+		return nil, nil, err
+	}
+
+	switch msg.Type {
+	case "SubscriptionConfirmation":
+		return nil, nil, mgr.confirmSNSSubscription(&msg)
+	case "Notification":
+	default:
+		return nil, nil, nil
+	}
+
+	var n sesNotification
+	if err := json.Unmarshal([]byte(msg.Message), &n); err != nil {
+		return nil, nil, fmt.Errorf("parse SES notification: %w", err)
+	}
+	if n.NotificationType != "Received" {
+		return nil, nil, nil
+	}
+	if n.Content == "" {
+		return nil, nil, fmt.Errorf("SES notification has no content, make sure the receipt rule uses an SNS action")
+	}
+	if strings.EqualFold(n.Receipt.Action.Encoding, "BASE64") {
+		raw, err = base64.StdEncoding.DecodeString(n.Content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode SES notification content: %w", err)
+		}
+	} else {
+		raw = []byte(n.Content)
+	}
+	return raw, n.Receipt.Recipients, nil
+}
+
+// snsHost matches the hosts SNS signing certificates and subscription URLs are served from.
+var snsHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// checkSNSURL reports whether rawURL is an SNS endpoint.
+func checkSNSURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || !snsHost.MatchString(u.Host) {
+		return fmt.Errorf("%w: unexpected SNS URL %q", errUnauthenticated, rawURL)
+	}
+	return nil
+}
+
+type snsCert struct {
+	key     *rsa.PublicKey
+	expires time.Time
+}
+
+func (mgr *Manager) verifySNSSignature(msg *snsMessage) error {
+	var hash crypto.Hash
+	switch msg.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("%w: unsupported signature version %q", errUnauthenticated, msg.SignatureVersion)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return errUnauthenticated
+	}
+	key, err := mgr.snsSigningKey(msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	var digest []byte
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum([]byte(snsStringToSign(msg)))
+		digest = sum[:]
+	} else {
+		sum := sha256.Sum256([]byte(snsStringToSign(msg)))
+		digest = sum[:]
+	}
+	if err := rsa.VerifyPKCS1v15(key, hash, digest, sig); err != nil {
+		return errUnauthenticated
+	}
+	return nil
+}
+
+// snsStringToSign returns the string an SNS message's signature is computed over.
+func snsStringToSign(msg *snsMessage) string {
+	var b strings.Builder
+	add := func(key, value string) {
+		b.WriteString(key)
+		b.WriteByte('\n')
+		b.WriteString(value)
+		b.WriteByte('\n')
+	}
+	add("Message", msg.Message)
+	add("MessageId", msg.MessageId)
+	if msg.Type == "Notification" {
+		if msg.Subject != "" {
+			add("Subject", msg.Subject)
+		}
+	} else {
+		add("SubscribeURL", msg.SubscribeURL)
+	}
+	add("Timestamp", msg.Timestamp)
+	if msg.Type != "Notification" {
+		add("Token", msg.Token)
+	}
+	add("TopicArn", msg.TopicArn)
+	add("Type", msg.Type)
+	return b.String()
+}
+
+// snsSigningKey returns the public key of the SNS signing certificate at certURL,
+// fetching it if it isn't cached.
+func (mgr *Manager) snsSigningKey(certURL string) (*rsa.PublicKey, error) {
+	if err := checkSNSURL(certURL); err != nil {
+		return nil, err
+	}
+
+	mgr.mu.Lock()
+	cert := mgr.certs[certURL]
+	mgr.mu.Unlock()
+	if cert != nil && time.Now().Before(cert.expires) {
+		return cert.key, nil
+	}
+
+	resp, err := mgr.client.Get(certURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch SNS signing certificate: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch SNS signing certificate: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("fetch SNS signing certificate: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid SNS signing certificate")
+	}
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SNS signing certificate: %w", err)
+	}
+	key, ok := c.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid SNS signing certificate: not an RSA key")
+	}
+
+	mgr.mu.Lock()
+	mgr.certs[certURL] = &snsCert{key: key, expires: c.NotAfter}
+	mgr.mu.Unlock()
+	return key, nil
+}
+
+// confirmSNSSubscription confirms the subscription of the webhook to an SNS topic.
+func (mgr *Manager) confirmSNSSubscription(msg *snsMessage) error {
+	if err := checkSNSURL(msg.SubscribeURL); err != nil {
+		return err
+	}
+	resp, err := mgr.client.Get(msg.SubscribeURL)
+	if err != nil {
+		return fmt.Errorf("confirm SNS subscription: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("confirm SNS subscription: %s", resp.Status)
+	}
+	mgr.rootLogger.Info().Str("topic", msg.TopicArn).Msg("confirmed SNS subscription for inbound email")
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:build encore_app
+
+package email
+
+import (
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/logging"
+)
+
+//publicapigen:drop
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(appconf.Runtime, logging.RootLogger)
+	api.RegisterWebhookRoute("POST", "/email/inbound/:name", Singleton.handleInbound)
+	api.RegisterWebhookRoute("POST", "/email/inbound/:name/mime", Singleton.handleInbound)
+}
@@ -0,0 +1,141 @@
+package envvar
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Format describes the format of the value of an environment variable.
+type Format string
+
+const (
+	// String accepts any value. It's the default format.
+	String Format = "string"
+	// Int accepts integers, such as "42".
+	Int Format = "int"
+	// Bool accepts the values strconv.ParseBool accepts, such as "true" and "0".
+	Bool Format = "bool"
+	// URL accepts absolute URLs, such as "https://api.example.com".
+	URL Format = "url"
+	// Duration accepts the durations time.ParseDuration accepts, such as "30s".
+	Duration Format = "duration"
+)
+
+// Validate reports whether value is valid for the format.
+func (f Format) Validate(value string) error {
+	switch f {
+	case String, "":
+		return nil
+	case Int:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+	case Bool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+	case URL:
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("%q is not an absolute URL", value)
+		}
+	case Duration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("%q is not a duration", value)
+		}
+	default:
+		return fmt.Errorf("unknown format %q", string(f))
+	}
+	return nil
+}
+
+// Config configures an environment variable.
+type Config struct {
+	// Description describes what the variable configures,
+	// for the generated documentation.
+	Description string
+
+	// Required specifies whether the application needs the variable to be set.
+	// `encore run` refuses to start the application if it's missing.
+	Required bool
+
+	// Format is the format of the variable's value.
+	// If empty it defaults to String.
+	Format Format
+}
+
+// Var is a declared environment variable.
+type Var struct {
+	name string
+	cfg  Config
+}
+
+// New declares an environment variable the application reads.
+//
+// It must be called when declaring a package level variable:
+//
+//	var StripeURL = envvar.New("STRIPE_URL", envvar.Config{
+//		Description: "Base URL of the Stripe API",
+//		Required:    true,
+//		Format:      envvar.URL,
+//	})
+//
+// The name must be in SCREAMING_SNAKE_CASE, and may not start with "ENCORE_".
+// The same variable may be declared by several services, as long as
+// the declarations agree on whether it's required and its format.
+func New(name string, cfg Config) *Var {
+	if cfg.Format == "" {
+		cfg.Format = String
+	}
+	return &Var{name: name, cfg: cfg}
+}
+
+// Name returns the name of the environment variable.
+func (v *Var) Name() string {
+	return v.name
+}
+
+// Get returns the value of the environment variable, or "" if it's not set.
+func (v *Var) Get() string {
+	return os.Getenv(v.name)
+}
+
+// Lookup returns the value of the environment variable
+// and reports whether it's set.
+func (v *Var) Lookup() (string, bool) {
+	return os.LookupEnv(v.name)
+}
+
+// Int returns the value of the environment variable parsed as an integer.
+// It returns 0 if the variable isn't set or isn't an integer.
+func (v *Var) Int() int {
+	n, _ := strconv.Atoi(v.Get())
+	return n
+}
+
+// Bool returns the value of the environment variable parsed as a boolean.
+// It returns false if the variable isn't set or isn't a boolean.
+func (v *Var) Bool() bool {
+	b, _ := strconv.ParseBool(v.Get())
+	return b
+}
+
+// Duration returns the value of the environment variable parsed as a duration.
+// It returns 0 if the variable isn't set or isn't a duration.
+func (v *Var) Duration() time.Duration {
+	d, _ := time.ParseDuration(v.Get())
+	return d
+}
+
+// URL returns the value of the environment variable parsed as a URL.
+// It returns nil if the variable isn't set or isn't an absolute URL.
+func (v *Var) URL() *url.URL {
+	val := v.Get()
+	if URL.Validate(val) != nil {
+		return nil
+	}
+	u, _ := url.Parse(val)
+	return u
+}
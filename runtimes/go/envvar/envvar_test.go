@@ -0,0 +1,60 @@
+package envvar
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestFormat_Validate(t *testing.T) {
+	tests := []struct {
+		format Format
+		value  string
+		valid  bool
+	}{
+		{String, "anything", true},
+		{"", "", true},
+		{Int, "42", true},
+		{Int, "-7", true},
+		{Int, "4.2", false},
+		{Bool, "true", true},
+		{Bool, "0", true},
+		{Bool, "yes", false},
+		{URL, "https://api.example.com/v1", true},
+		{URL, "api.example.com", false},
+		{URL, "/relative", false},
+		{Duration, "1m30s", true},
+		{Duration, "90", false},
+		{"uuid", "x", false},
+	}
+	for _, tt := range tests {
+		err := tt.format.Validate(tt.value)
+		if tt.valid && err != nil {
+			t.Errorf("%s.Validate(%q) = %v, want nil", tt.format, tt.value, err)
+		} else if !tt.valid && err == nil {
+			t.Errorf("%s.Validate(%q) = nil, want an error", tt.format, tt.value)
+		}
+	}
+}
+
+func TestVar(t *testing.T) {
+	c := qt.New(t)
+
+	v := New("TEST_ENVVAR_VALUE", Config{Format: Duration})
+	c.Assert(v.Name(), qt.Equals, "TEST_ENVVAR_VALUE")
+	_, ok := v.Lookup()
+	c.Assert(ok, qt.IsFalse)
+	c.Assert(v.Duration(), qt.Equals, time.Duration(0))
+
+	t.Setenv("TEST_ENVVAR_VALUE", "2s")
+	c.Assert(v.Get(), qt.Equals, "2s")
+	c.Assert(v.Duration(), qt.Equals, 2*time.Second)
+
+	t.Setenv("TEST_ENVVAR_VALUE", "12")
+	c.Assert(v.Int(), qt.Equals, 12)
+	c.Assert(v.URL(), qt.IsNil)
+
+	t.Setenv("TEST_ENVVAR_VALUE", "https://example.com")
+	c.Assert(v.URL().Host, qt.Equals, "example.com")
+}
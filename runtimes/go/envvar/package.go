@@ -0,0 +1,11 @@
+// Package envvar provides support for declaring the environment variables
+// an application expects.
+//
+// Declaring an environment variable documents it and lets Encore validate it:
+// the declarations are checked when the application is built, and `encore run`
+// reports required variables that are missing, or values in the wrong format,
+// before starting the application. `encore gen env-docs` generates
+// documentation of the declared variables for the people operating the application.
+//
+// For more information see https://encore.dev/docs/primitives/environment-variables
+package envvar
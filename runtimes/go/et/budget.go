@@ -0,0 +1,122 @@
+//go:build encore_app
+
+package et
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+// BudgetOption configures a budget for AssertBudget.
+type BudgetOption func(*budget)
+
+type budget struct {
+	maxLatency   time.Duration
+	maxQueries   int
+	maxPublishes int
+}
+
+// MaxLatency fails the test if the measured code takes longer than d to complete.
+func MaxLatency(d time.Duration) BudgetOption {
+	return func(b *budget) { b.maxLatency = d }
+}
+
+// MaxQueries fails the test if the measured code executes more than n database queries.
+//
+// Transaction control statements (BEGIN, COMMIT and ROLLBACK) are not counted.
+func MaxQueries(n int) BudgetOption {
+	return func(b *budget) { b.maxQueries = n }
+}
+
+// MaxPublishes fails the test if the measured code publishes more than n Pub/Sub messages.
+func MaxPublishes(n int) BudgetOption {
+	return func(b *budget) { b.maxPublishes = n }
+}
+
+// Usage describes the resources used by the code measured by AssertBudget.
+type Usage struct {
+	// Latency is how long the measured code took to complete.
+	Latency time.Duration
+
+	// Queries are the database queries executed, in order.
+	Queries []string
+
+	// Publishes are the names of the topics Pub/Sub messages
+	// were published to, in order, with one entry per message.
+	Publishes []string
+}
+
+// AssertBudget calls fn and fails the test if it exceeds the budget
+// described by opts. It's designed to catch performance regressions
+// such as N+1 queries early:
+//
+//	et.AssertBudget(t, func() {
+//		_, err := ListOrders(ctx, &ListParams{Limit: 50})
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//	}, et.MaxQueries(2), et.MaxLatency(100*time.Millisecond))
+//
+// Usage is measured across all API calls made by fn within the current test,
+// including calls to other services. Limits that are not set are not enforced.
+// It returns the measured usage.
+func AssertBudget(t testing.TB, fn func(), opts ...BudgetOption) Usage {
+	t.Helper()
+	if Singleton.runtime.EnvType != "test" {
+		panic("et: cannot assert budget in non-test environment")
+	}
+
+	b := budget{maxLatency: -1, maxQueries: -1, maxPublishes: -1}
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	usage := Singleton.measureUsage(fn)
+
+	if b.maxLatency >= 0 && usage.Latency > b.maxLatency {
+		t.Errorf("et: latency budget exceeded: took %v, budget is %v", usage.Latency, b.maxLatency)
+	}
+	if b.maxQueries >= 0 && len(usage.Queries) > b.maxQueries {
+		t.Errorf("et: query budget exceeded: executed %d queries, budget is %d:\n%s",
+			len(usage.Queries), b.maxQueries, formatList(usage.Queries))
+	}
+	if b.maxPublishes >= 0 && len(usage.Publishes) > b.maxPublishes {
+		t.Errorf("et: publish budget exceeded: published %d messages, budget is %d:\n%s",
+			len(usage.Publishes), b.maxPublishes, formatList(usage.Publishes))
+	}
+	return usage
+}
+
+// measureUsage calls fn and returns the resources it used.
+func (mgr *Manager) measureUsage(fn func()) Usage {
+	req := mgr.rt.Current().Req
+	if req == nil || req.Test == nil {
+		panic("et: AssertBudget called outside of a test")
+	}
+	test := req.Test
+	meter := &model.UsageMeter{Parent: test.Meter.Load()}
+	test.Meter.Store(meter)
+	defer test.Meter.Store(meter.Parent)
+
+	start := time.Now()
+	fn()
+	latency := time.Since(start)
+
+	return Usage{
+		Latency:   latency,
+		Queries:   meter.Queries(),
+		Publishes: meter.Publishes(),
+	}
+}
+
+func formatList(items []string) string {
+	var b strings.Builder
+	for i, item := range items {
+		fmt.Fprintf(&b, "\t%d: %s\n", i+1, strings.Join(strings.Fields(item), " "))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
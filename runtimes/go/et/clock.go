@@ -0,0 +1,329 @@
+package et
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"encore.dev/cron"
+)
+
+// Clock is a virtual clock that drives time-based events in tests, such as cron jobs
+// and retries of failed Pub/Sub deliveries. Events only happen when the test advances
+// the clock, which makes time-driven scenarios reproducible without sleeping.
+//
+// The clock does not affect time.Now. To make the code under test use the virtual time,
+// have it read the time from a function that the test can replace with Clock.Now.
+//
+// Advancing the clock is not safe for concurrent use.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+
+	crons  []*clockCron
+	topics []clockTopic
+}
+
+// NewClock returns a virtual clock starting at the given time.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the current virtual time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AddCron schedules the given cron jobs on the clock. Each time the clock passes the
+// time a job is scheduled to run at, its endpoint is called. Schedules are evaluated in UTC.
+//
+// It panics if a job's schedule or endpoint is invalid.
+func (c *Clock) AddCron(jobs ...*cron.Job) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, job := range jobs {
+		cc, err := newClockCron(job, c.now)
+		if err != nil {
+			panic(fmt.Sprintf("et: invalid cron job %q: %v", job.ID, err))
+		}
+		c.crons = append(c.crons, cc)
+	}
+}
+
+// clockTopic is a Pub/Sub topic whose deliveries are driven by a clock.
+type clockTopic interface {
+	DeliverAt(ctx context.Context, now time.Time) error
+	NextRetry() (at time.Time, ok bool)
+}
+
+func (c *Clock) addTopic(t clockTopic) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.topics = append(c.topics, t)
+}
+
+// Advance advances the clock by d, running the events scheduled up to the new time
+// in chronological order. Messages published during the events are delivered
+// at the virtual time they were published.
+//
+// It returns the errors returned by cron job endpoints and Pub/Sub subscriptions.
+func (c *Clock) Advance(d time.Duration) error {
+	if d < 0 {
+		panic("et: cannot advance clock by a negative duration")
+	}
+	return c.AdvanceTo(c.Now().Add(d))
+}
+
+// AdvanceTo advances the clock to the time t, like Advance.
+func (c *Clock) AdvanceTo(t time.Time) error {
+	ctx := context.Background()
+	var errs []error
+	deliver := func() {
+		for _, topic := range c.topics {
+			if err := topic.DeliverAt(ctx, c.Now()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	// Deliver the messages published since the clock was last advanced.
+	deliver()
+	for {
+		at, job, ok := c.nextEvent()
+		if !ok || at.After(t) {
+			break
+		}
+
+		c.mu.Lock()
+		if at.After(c.now) {
+			c.now = at
+		}
+		c.mu.Unlock()
+
+		if job != nil {
+			if err := job.run(ctx, at); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		deliver()
+	}
+
+	c.mu.Lock()
+	if t.After(c.now) {
+		c.now = t
+	}
+	c.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// nextEvent returns the time of the next event. If the event is
+// a cron job it is returned, otherwise it's a Pub/Sub retry.
+func (c *Clock) nextEvent() (at time.Time, job *clockCron, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cc := range c.crons {
+		if next := cc.next(); !ok || next.Before(at) {
+			at, job, ok = next, cc, true
+		}
+	}
+	for _, topic := range c.topics {
+		if next, found := topic.NextRetry(); found && (!ok || next.Before(at)) {
+			at, job, ok = next, nil, true
+		}
+	}
+	return at, job, ok
+}
+
+// clockCron is a cron job scheduled on a clock.
+type clockCron struct {
+	job      *cron.Job
+	endpoint reflect.Value
+	sched    *cronSchedule // nil if the job uses Every
+	last     time.Time     // the time the job last ran, or the time it was added
+}
+
+var ctxType = reflect.TypeFor[context.Context]()
+
+func newClockCron(job *cron.Job, now time.Time) (*clockCron, error) {
+	fn := reflect.ValueOf(job.Endpoint)
+	if fn.Kind() != reflect.Func {
+		return nil, errors.New("endpoint is not a function")
+	}
+	typ := fn.Type()
+	if typ.NumIn() != 1 || typ.In(0) != ctxType || typ.NumOut() < 1 || typ.NumOut() > 2 || typ.Out(typ.NumOut()-1) != reflect.TypeFor[error]() {
+		return nil, errors.New("endpoint must have the signature func(context.Context) error or func(context.Context) (T, error)")
+	}
+
+	cc := &clockCron{job: job, endpoint: fn, last: now}
+	switch {
+	case job.Every > 0 && job.Schedule == "":
+		if (24*cron.Hour)%job.Every != 0 {
+			return nil, errors.New("the interval must divide 24 hours evenly")
+		}
+	case job.Every == 0 && job.Schedule != "":
+		sched, err := parseCronSchedule(job.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		cc.sched = sched
+	default:
+		return nil, errors.New("exactly one of Every and Schedule must be set")
+	}
+	return cc, nil
+}
+
+// next returns the next time the job runs.
+func (cc *clockCron) next() time.Time {
+	last := cc.last.UTC()
+	if cc.sched == nil {
+		// Intervals divide 24 hours, so truncating relative to the zero time aligns them with midnight.
+		every := time.Duration(cc.job.Every) * time.Second
+		return last.Truncate(every).Add(every)
+	}
+	return cc.sched.next(last)
+}
+
+func (cc *clockCron) run(ctx context.Context, at time.Time) error {
+	cc.last = at
+	out := cc.endpoint.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err, _ := out[len(out)-1].Interface().(error); err != nil {
+		return fmt.Errorf("cron job %s at %s: %w", cc.job.ID, at.UTC().Format(time.RFC3339), err)
+	}
+	return nil
+}
+
+// cronSchedule is a parsed cron expression with the fields
+// minute, hour, day of month, month and day of week.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64 // bitsets of the matching values
+
+	// domStar and dowStar report whether the day of month and day of week fields
+	// are unrestricted. If both are restricted, a day matches if either field matches.
+	domStar, dowStar bool
+}
+
+var (
+	monthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	dowNames   = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, got %d", expr, len(fields))
+	}
+
+	var s cronSchedule
+	var err error
+	parse := func(dst *uint64, field string, lo, hi int, names map[string]int) {
+		if err == nil {
+			*dst, err = parseCronField(field, lo, hi, names)
+		}
+	}
+	parse(&s.minute, fields[0], 0, 59, nil)
+	parse(&s.hour, fields[1], 0, 23, nil)
+	parse(&s.dom, fields[2], 1, 31, nil)
+	parse(&s.month, fields[3], 1, 12, monthNames)
+	parse(&s.dow, fields[4], 0, 7, dowNames)
+	if err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1 // both 0 and 7 mean sunday
+	}
+	s.domStar = fields[2] == "*" || fields[2] == "?"
+	s.dowStar = fields[4] == "*" || fields[4] == "?"
+	return &s, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges and steps
+// (like "1,5-10,*/15") into a bitset of the matching values.
+func parseCronField(field string, lo, hi int, names map[string]int) (uint64, error) {
+	value := func(s string) (int, error) {
+		if n, ok := names[strings.ToLower(s)]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < lo || n > hi {
+			return 0, fmt.Errorf("invalid value %q in cron field %q", s, field)
+		}
+		return n, nil
+	}
+
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in cron field %q", stepStr, field)
+			}
+			step = n
+		}
+
+		start, end := lo, hi
+		if rng != "*" && rng != "?" {
+			from, to, isRange := strings.Cut(rng, "-")
+			var err error
+			if start, err = value(from); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = value(to); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = hi
+			}
+			if end < start {
+				return 0, fmt.Errorf("invalid range %q in cron field %q", rng, field)
+			}
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << i
+		}
+	}
+	return bits, nil
+}
+
+// next returns the first time after t that matches the schedule.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// Every schedule matches at least once in a few years, except for impossible
+	// dates like February 30th, so give up after that to guarantee termination.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<t.Month()) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<t.Hour()) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	// Never matches; return a time far enough in the future to never run.
+	return time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<t.Weekday()) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
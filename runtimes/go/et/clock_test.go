@@ -0,0 +1,93 @@
+package et
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"encore.dev/cron"
+)
+
+func TestClockCron(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // a Monday
+	clock := NewClock(start)
+
+	var every, weekdays []time.Time
+	clock.AddCron(
+		cron.NewJob("every", cron.JobConfig{
+			Every: 6 * cron.Hour,
+			Endpoint: func(ctx context.Context) error {
+				every = append(every, clock.Now())
+				return nil
+			},
+		}),
+		cron.NewJob("weekdays", cron.JobConfig{
+			Schedule: "30 9 * * mon-fri",
+			Endpoint: func(ctx context.Context) (string, error) {
+				weekdays = append(weekdays, clock.Now())
+				return "", nil
+			},
+		}),
+	)
+
+	if err := clock.Advance(7 * 24 * time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if len(every) != 28 {
+		t.Errorf("got %d runs of interval job, want 28", len(every))
+	}
+	if len(weekdays) != 5 {
+		t.Fatalf("got %d runs of scheduled job, want 5", len(weekdays))
+	}
+	if want := time.Date(2024, 1, 5, 9, 30, 0, 0, time.UTC); !weekdays[4].Equal(want) {
+		t.Errorf("got last run at %s, want %s", weekdays[4], want)
+	}
+	if got, want := clock.Now(), start.Add(7*24*time.Hour); !got.Equal(want) {
+		t.Errorf("got clock time %s, want %s", got, want)
+	}
+}
+
+func TestClockCronError(t *testing.T) {
+	clock := NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	boom := errors.New("boom")
+	clock.AddCron(cron.NewJob("fail", cron.JobConfig{
+		Schedule: "0 0 1 * *",
+		Endpoint: func(ctx context.Context) error { return boom },
+	}))
+
+	if err := clock.Advance(24 * time.Hour); err != nil {
+		t.Fatalf("got error before the job ran: %v", err)
+	}
+	if err := clock.AdvanceTo(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, boom) {
+		t.Fatalf("got error %v, want %v", err, boom)
+	}
+}
+
+func TestParseCronSchedule(t *testing.T) {
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, 1, 1, 10, 7, 0, 0, time.UTC), time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1 * sun", time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC)},
+		{"0 8 * jan-mar 7", time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC), time.Date(2025, 1, 5, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := parseCronSchedule(tt.expr)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tt.expr, err)
+		}
+		if got := s.next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("%q: got next %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "* * * foo *", "5-1 * * * *", "*/0 * * * *"} {
+		if _, err := parseCronSchedule(expr); err == nil {
+			t.Errorf("%q: got no error", expr)
+		}
+	}
+}
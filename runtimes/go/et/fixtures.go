@@ -0,0 +1,329 @@
+package et
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/types/uuid"
+)
+
+// GenOption configures how values are generated by a Generator.
+type GenOption func(*genOptions)
+
+type genOptions struct {
+	seed       int64
+	maxLen     int
+	iterations int
+	fields     map[string]func(r *rand.Rand) any
+	types      map[reflect.Type]func(r *rand.Rand) reflect.Value
+}
+
+// WithSeed sets the seed used for generating values.
+// Generators with the same seed and options generate the same sequence of values.
+// By default a random seed is used.
+func WithSeed(seed int64) GenOption {
+	return func(o *genOptions) { o.seed = seed }
+}
+
+// WithMaxLen sets the maximum length of generated strings, slices and maps.
+// It defaults to 8.
+func WithMaxLen(n int) GenOption {
+	return func(o *genOptions) { o.maxLen = n }
+}
+
+// WithIterations sets the number of values CheckProperty tests. It defaults to 100.
+func WithIterations(n int) GenOption {
+	return func(o *genOptions) { o.iterations = n }
+}
+
+// WithField uses fn to generate the value of the struct field at the given path.
+// The path consists of Go field names separated by dots, like "Address.City",
+// and applies to the field in all elements of slices, arrays and maps.
+//
+// The value returned by fn must be assignable to the field.
+func WithField(path string, fn func(r *rand.Rand) any) GenOption {
+	return func(o *genOptions) {
+		if o.fields == nil {
+			o.fields = make(map[string]func(r *rand.Rand) any)
+		}
+		o.fields[path] = fn
+	}
+}
+
+// WithType uses fn to generate all values of type V.
+func WithType[V any](fn func(r *rand.Rand) V) GenOption {
+	return func(o *genOptions) {
+		if o.types == nil {
+			o.types = make(map[reflect.Type]func(r *rand.Rand) reflect.Value)
+		}
+		o.types[reflect.TypeFor[V]()] = func(r *rand.Rand) reflect.Value {
+			return reflect.ValueOf(fn(r))
+		}
+	}
+}
+
+// Generator generates random values of type T, for use in
+// property-based and table-driven tests.
+//
+// Values are generated the way the type is described in the app's API schema:
+// fields that are not part of the schema (unexported fields and fields tagged
+// with `json:"-"`) are left as their zero value, optional fields are sometimes
+// left unset, and header, query string and cookie fields only contain characters
+// that can be transmitted in those locations.
+//
+// A Generator is not safe for concurrent use.
+type Generator[T any] struct {
+	opts genOptions
+	rnd  *rand.Rand
+}
+
+// NewGenerator returns a generator of values of type T.
+func NewGenerator[T any](opts ...GenOption) *Generator[T] {
+	o := genOptions{seed: time.Now().UnixNano(), maxLen: 8, iterations: 100}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Generator[T]{opts: o, rnd: rand.New(rand.NewSource(o.seed))}
+}
+
+// Seed returns the seed the generator was created with.
+func (g *Generator[T]) Seed() int64 {
+	return g.opts.seed
+}
+
+// Next generates the next value.
+func (g *Generator[T]) Next() T {
+	var v T
+	g.fill(reflect.ValueOf(&v).Elem(), "", 0, false)
+	return v
+}
+
+// Generate generates a single random value of type T.
+func Generate[T any](opts ...GenOption) T {
+	return NewGenerator[T](opts...).Next()
+}
+
+// CheckProperty checks that prop holds for randomly generated values of type T,
+// running it as a subtest for each value. It stops at the first failure,
+// logging the failing value and the seed to reproduce it with WithSeed.
+func CheckProperty[T any](t *testing.T, prop func(t *testing.T, v T), opts ...GenOption) {
+	t.Helper()
+	g := NewGenerator[T](opts...)
+	for i := 0; i < g.opts.iterations; i++ {
+		v := g.Next()
+		if !t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) { prop(t, v) }) {
+			data, err := json.Marshal(v)
+			if err != nil {
+				data = []byte(fmt.Sprintf("%+v", v))
+			}
+			t.Errorf("et: property failed for value #%d: %s\nreproduce with et.WithSeed(%d)", i, data, g.opts.seed)
+			return
+		}
+	}
+}
+
+// maxGenDepth is the maximum nesting depth of generated values,
+// to guarantee termination for recursive types.
+const maxGenDepth = 5
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	uuidType      = reflect.TypeFor[uuid.UUID]()
+	rawJSONType   = reflect.TypeFor[json.RawMessage]()
+	unmarshalType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// fill sets v to a generated value. The path is the field path of v,
+// and plain reports whether v is transmitted in a header, query string or cookie.
+func (g *Generator[T]) fill(v reflect.Value, path string, depth int, plain bool) {
+	if fn, ok := g.opts.fields[path]; ok && path != "" {
+		v.Set(reflect.ValueOf(fn(g.rnd)).Convert(v.Type()))
+		return
+	}
+	if fn, ok := g.opts.types[v.Type()]; ok {
+		v.Set(fn(g.rnd))
+		return
+	}
+	if depth > maxGenDepth {
+		return
+	}
+
+	r := g.rnd
+	switch typ := v.Type(); {
+	case typ == timeType:
+		v.Set(reflect.ValueOf(time.Unix(r.Int63n(4102444800), 0).UTC())) // 1970 to 2100
+		return
+	case typ == uuidType:
+		var u uuid.UUID
+		_, _ = r.Read(u[:])
+		u.SetVersion(uuid.V4)
+		u[8] = (u[8] & 0x3f) | 0x80 // RFC 4122 variant
+		v.Set(reflect.ValueOf(u))
+		return
+	case typ == rawJSONType:
+		data, _ := json.Marshal(map[string]any{g.str(true): r.Intn(1000)})
+		v.SetBytes(data)
+		return
+	case typ.Kind() == reflect.Struct && typ.PkgPath() == "encore.dev/types/option":
+		// Options can only be constructed through their exported API,
+		// so set them by unmarshalling the JSON representation of a value.
+		if r.Intn(3) == 0 {
+			return
+		}
+		if getter, ok := typ.MethodByName("MustGet"); ok {
+			elem := reflect.New(getter.Type.Out(0)).Elem()
+			g.fill(elem, path, depth+1, plain)
+			g.unmarshalInto(v, elem.Interface())
+		}
+		return
+	case typ.Kind() == reflect.Struct && typ.PkgPath() == "encore.dev/types/upload" && typ.Name() == "File":
+		data := make([]byte, r.Intn(g.opts.maxLen*8))
+		_, _ = r.Read(data)
+		g.unmarshalInto(v, map[string]any{
+			"filename":     g.str(true) + ".bin",
+			"content_type": "application/octet-stream",
+			"data":         data,
+		})
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := v.Type().Bits()
+		switch r.Intn(8) {
+		case 0:
+			v.SetInt(-1 << (bits - 1)) // minimum value
+		case 1:
+			v.SetInt(1<<(bits-1) - 1) // maximum value
+		default:
+			v.SetInt(r.Int63n(2001) - 1000)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		bits := v.Type().Bits()
+		switch r.Intn(8) {
+		case 0:
+			v.SetUint(1<<bits - 1) // maximum value
+		default:
+			v.SetUint(uint64(r.Int63n(1001)))
+		}
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.Float64()*2000 - 1000)
+	case reflect.String:
+		v.SetString(g.str(plain))
+	case reflect.Slice:
+		n := r.Intn(g.opts.maxLen + 1)
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, n)
+			_, _ = r.Read(b)
+			v.SetBytes(b)
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			g.fill(s.Index(i), path, depth+1, plain)
+		}
+		v.Set(s)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			g.fill(v.Index(i), path, depth+1, plain)
+		}
+	case reflect.Map:
+		n := r.Intn(g.opts.maxLen + 1)
+		m := reflect.MakeMapWithSize(v.Type(), n)
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			g.fill(key, path, depth+1, true)
+			val := reflect.New(v.Type().Elem()).Elem()
+			g.fill(val, path, depth+1, plain)
+			m.SetMapIndex(key, val)
+		}
+		v.Set(m)
+	case reflect.Pointer:
+		if r.Intn(4) == 0 && depth > 0 {
+			return
+		}
+		p := reflect.New(v.Type().Elem())
+		g.fill(p.Elem(), path, depth+1, plain)
+		v.Set(p)
+	case reflect.Struct:
+		g.fillStruct(v, path, depth)
+	default:
+		// Interfaces, functions and channels are left as nil.
+	}
+}
+
+func (g *Generator[T]) fillStruct(v reflect.Value, path string, depth int) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		fieldPath := f.Name
+		if path != "" {
+			fieldPath = path + "." + f.Name
+		}
+		if _, custom := g.opts.fields[fieldPath]; !custom && isOptionalField(f) && g.rnd.Intn(3) == 0 {
+			continue
+		}
+
+		plain := f.Tag.Get("header") != "" || f.Tag.Get("query") != "" || f.Tag.Get("qs") != "" || f.Tag.Get("cookie") != ""
+		g.fill(v.Field(i), fieldPath, depth+1, plain)
+	}
+}
+
+// isOptionalField reports whether f is tagged as optional.
+func isOptionalField(f reflect.StructField) bool {
+	for _, opt := range strings.Split(f.Tag.Get("encore"), ",") {
+		if opt == "optional" {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarshalInto sets v, which must implement json.Unmarshaler
+// through a pointer, to the JSON representation of val.
+func (g *Generator[T]) unmarshalInto(v reflect.Value, val any) {
+	if !v.Addr().Type().Implements(unmarshalType) {
+		return
+	}
+	data, err := json.Marshal(val)
+	if err == nil {
+		err = v.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(data)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("et: cannot generate value of type %s: %v", v.Type(), err))
+	}
+}
+
+const (
+	plainChars   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	unicodeChars = plainChars + " -_.,!?'\"/\\<>&é日本🙂"
+)
+
+// str generates a string. If plain is true it only contains
+// letters and digits, to be safe for headers, query strings and cookies.
+func (g *Generator[T]) str(plain bool) string {
+	chars := []rune(unicodeChars)
+	if plain {
+		chars = []rune(plainChars)
+	}
+	s := make([]rune, g.rnd.Intn(g.opts.maxLen+1))
+	for i := range s {
+		s[i] = chars[g.rnd.Intn(len(chars))]
+	}
+	return string(s)
+}
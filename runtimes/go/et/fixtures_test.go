@@ -0,0 +1,106 @@
+package et
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+	"unicode"
+
+	"encore.dev/types/option"
+	"encore.dev/types/uuid"
+)
+
+type fixtureParams struct {
+	Name     string
+	Token    string `header:"X-Token"`
+	Limit    int8   `query:"limit"`
+	Nick     string `encore:"optional"`
+	Ignored  string `json:"-"`
+	Created  time.Time
+	ID       uuid.UUID
+	Parent   option.Option[string]
+	Tags     []string
+	Children []*fixtureParams
+
+	internal string
+}
+
+func TestGenerator(t *testing.T) {
+	g := NewGenerator[fixtureParams](WithSeed(1))
+	sawNick, sawNoNick := false, false
+	for i := 0; i < 200; i++ {
+		v := g.Next()
+		if v.Ignored != "" || v.internal != "" {
+			t.Fatalf("generated value for field outside the schema: %+v", v)
+		}
+		for _, r := range v.Token {
+			if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				t.Fatalf("header field contains %q", r)
+			}
+		}
+		if v.ID.Version() != uuid.V4 {
+			t.Fatalf("got UUID version %d, want 4", v.ID.Version())
+		}
+		if v.Created.IsZero() {
+			t.Fatalf("got zero time")
+		}
+		if len(v.Tags) > 8 {
+			t.Fatalf("got %d tags, want at most 8", len(v.Tags))
+		}
+		if v.Nick != "" {
+			sawNick = true
+		} else {
+			sawNoNick = true
+		}
+	}
+	if !sawNick || !sawNoNick {
+		t.Errorf("optional field was always or never set")
+	}
+}
+
+func TestGeneratorDeterministic(t *testing.T) {
+	a := NewGenerator[fixtureParams](WithSeed(42))
+	b := NewGenerator[fixtureParams](WithSeed(42))
+	for i := 0; i < 20; i++ {
+		if x, y := a.Next(), b.Next(); !reflect.DeepEqual(x, y) {
+			t.Fatalf("generators with the same seed differ:\n%+v\n%+v", x, y)
+		}
+	}
+}
+
+func TestGeneratorCustom(t *testing.T) {
+	g := NewGenerator[fixtureParams](
+		WithField("Name", func(r *rand.Rand) any { return "fixed" }),
+		WithField("Children.Name", func(r *rand.Rand) any { return "child" }),
+		WithType(func(r *rand.Rand) int8 { return 7 }),
+		WithMaxLen(2),
+	)
+	for i := 0; i < 50; i++ {
+		v := g.Next()
+		if v.Name != "fixed" || v.Limit != 7 {
+			t.Fatalf("custom generators not used: %+v", v)
+		}
+		for _, c := range v.Children {
+			if c != nil && c.Name != "child" {
+				t.Fatalf("got child name %q, want %q", c.Name, "child")
+			}
+		}
+		if len(v.Tags) > 2 {
+			t.Fatalf("got %d tags, want at most 2", len(v.Tags))
+		}
+	}
+}
+
+func TestCheckProperty(t *testing.T) {
+	n := 0
+	CheckProperty(t, func(t *testing.T, v []int) {
+		n++
+		if len(v) > 8 {
+			t.Errorf("got %d elements", len(v))
+		}
+	}, WithIterations(10))
+	if n != 10 {
+		t.Errorf("property checked %d times, want 10", n)
+	}
+}
@@ -5,6 +5,7 @@ package et
 import (
 	"context"
 	"fmt"
+	"testing"
 
 	"encore.dev/beta/auth"
 	"encore.dev/storage/sqldb"
@@ -50,6 +51,52 @@ func EnableServiceInstanceIsolation() {
 	Singleton.testMgr.SetIsolatedServices(true)
 }
 
+// EnableDatabaseIsolation causes all SQL databases to be isolated to each test
+// from this test and on any of its sub-tests. (Calling this in a TestMain has the impact
+// of isolating all tests in the package.)
+//
+// Each isolated test gets a fresh, fully-migrated database cloned from the template
+// database Encore sets up when running tests, which is dropped when the test completes.
+// Sub-tests share the database of their parent test.
+//
+// To isolate databases without changing the test code, use "encore test --db-isolation=test".
+func EnableDatabaseIsolation() {
+	if Singleton.runtime.EnvType != "test" {
+		panic("et: cannot enable database isolation in non-test environment")
+	}
+	Singleton.testMgr.SetIsolatedDatabases(true)
+}
+
+// BeforeAll registers fn as a fixture that runs once in the test binary, before the first test
+// in the package starts. It runs after the app's infrastructure has been provisioned, making it
+// suitable for seeding data, creating objects in buckets or priming caches.
+//
+// BeforeAll must be called before the tests start, for example in an init function or in TestMain.
+// If fn returns an error, all tests in the package fail.
+//
+// Packages are tested in parallel and by default share the same databases. To isolate the data
+// written by fixtures from other packages, use "encore test --db-isolation=package".
+func BeforeAll(fn func(ctx context.Context) error) {
+	if Singleton.runtime.EnvType != "test" {
+		panic("et: cannot register test fixture in non-test environment")
+	}
+	if err := Singleton.testMgr.AddBeforeAll(fn); err != nil {
+		panic("et: BeforeAll must be called before tests start: " + err.Error())
+	}
+}
+
+// BeforeEach registers fn as a fixture that runs at the start of each sub-test of the current test,
+// before the sub-test's own code. (Calling this in a TestMain or init function has the impact
+// of running fn at the start of every test in the package.)
+//
+// Fixtures registered by a test run before the fixtures registered by its sub-tests.
+func BeforeEach(fn func(t *testing.T)) {
+	if Singleton.runtime.EnvType != "test" {
+		panic("et: cannot register test fixture in non-test environment")
+	}
+	Singleton.testMgr.AddBeforeEach(fn)
+}
+
 //publicapigen:keep
 type stringLiteral string
 
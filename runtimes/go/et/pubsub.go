@@ -3,6 +3,12 @@
 package et
 
 import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
 	"encore.dev/pubsub"
 )
 
@@ -11,7 +17,9 @@ func Topic[T any](topic *pubsub.Topic[T]) TopicHelpers[T] {
 	if Singleton.runtime.EnvType != "test" {
 		panic("et: cannot mock topic in non-test environment")
 	}
-	return pubsub.GetTestTopicInstance(topic).(TopicHelpers[T])
+	return &topicHelpers[T]{
+		testTopicInstance: pubsub.GetTestTopicInstance(topic).(testTopicInstance[T]),
+	}
 }
 
 // TopicHelpers provides functions for interacting with the backing topic implementation
@@ -22,4 +30,135 @@ func Topic[T any](topic *pubsub.Topic[T]) TopicHelpers[T] {
 type TopicHelpers[T any] interface {
 	// PublishedMessages returns a slice of all messages published during this test on this topic.
 	PublishedMessages() []T
+
+	// Published returns all messages published during this test on this topic,
+	// in the order they were published, along with their metadata.
+	Published() []PublishedMessage[T]
+
+	// AssertPublished reports a test failure unless exactly the given messages
+	// were published during this test on this topic, in the given order.
+	// Calling it without any messages asserts that nothing was published.
+	AssertPublished(t testing.TB, want ...T)
+
+	// AssertPublishedMatching reports a test failure and stops the test
+	// unless a message matching the given function was published during this test
+	// on this topic. It returns the first matching message.
+	AssertPublishedMatching(t testing.TB, match func(msg PublishedMessage[T]) bool) PublishedMessage[T]
+
+	// Deliver delivers the messages published during this test on this topic that have
+	// not yet been delivered to each subscription of the topic, and waits for them to be processed.
+	//
+	// Messages are delivered one at a time in the order they were published, and to
+	// the subscriptions in order of their name. Messages published to this topic while
+	// delivering are delivered as well. It returns the errors returned by the subscriptions.
+	Deliver() error
+
+	// EnableSubscriptions causes messages published on this topic during the rest of this test
+	// to be delivered to the subscriptions asynchronously, as they would be in a real system.
+	// The test waits for the subscriptions to finish processing the messages before it completes.
+	EnableSubscriptions()
+
+	// UseClock delivers the messages published on this topic when the given virtual clock advances,
+	// instead of when calling Deliver. Failed deliveries are retried according to the subscription's
+	// retry policy once the clock reaches the time of the retry. Use clock.Advance(0) to deliver
+	// the published messages without advancing the time.
+	UseClock(clock *Clock)
+}
+
+// PublishedMessage is a message published on a topic during a test.
+type PublishedMessage[T any] struct {
+	// ID is the message ID returned by Publish.
+	ID string
+
+	// Message is the published message.
+	Message T
+
+	// Attributes are the message attributes, as set by
+	// the fields of the message tagged with `pubsub-attr`.
+	Attributes map[string]string
+
+	// OrderingKey is the ordering key of the message, if the topic is ordered.
+	OrderingKey string
+
+	// PublishedAt is the time the message was published.
+	PublishedAt time.Time
+}
+
+// testTopicInstance is the test instance of a topic, implemented by the pubsub package.
+//
+//publicapigen:drop
+type testTopicInstance[T any] interface {
+	PublishedMessages() []T
+	RangePublished(fn func(id, orderingKey string, attrs map[string]string, published time.Time, msg T))
+	Deliver(ctx context.Context) error
+	DeliverAt(ctx context.Context, now time.Time) error
+	NextRetry() (at time.Time, ok bool)
+	EnableSubscriptions()
+}
+
+//publicapigen:drop
+type topicHelpers[T any] struct {
+	testTopicInstance[T]
+}
+
+func (h *topicHelpers[T]) Published() []PublishedMessage[T] {
+	var msgs []PublishedMessage[T]
+	h.RangePublished(func(id, orderingKey string, attrs map[string]string, published time.Time, msg T) {
+		msgs = append(msgs, PublishedMessage[T]{
+			ID:          id,
+			Message:     msg,
+			Attributes:  attrs,
+			OrderingKey: orderingKey,
+			PublishedAt: published,
+		})
+	})
+	return msgs
+}
+
+func (h *topicHelpers[T]) AssertPublished(t testing.TB, want ...T) {
+	t.Helper()
+	got := h.PublishedMessages()
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("et: unexpected published messages:\n got: %s\nwant: %s", marshalMessages(got), marshalMessages(want))
+	}
+}
+
+func (h *topicHelpers[T]) AssertPublishedMatching(t testing.TB, match func(msg PublishedMessage[T]) bool) PublishedMessage[T] {
+	t.Helper()
+	msgs := h.Published()
+	for _, msg := range msgs {
+		if match(msg) {
+			return msg
+		}
+	}
+
+	got := make([]T, len(msgs))
+	for i, msg := range msgs {
+		got[i] = msg.Message
+	}
+	t.Fatalf("et: no matching message was published, got: %s", marshalMessages(got))
+	return PublishedMessage[T]{}
+}
+
+func (h *topicHelpers[T]) Deliver() error {
+	return h.testTopicInstance.Deliver(context.Background())
+}
+
+func (h *topicHelpers[T]) UseClock(clock *Clock) {
+	clock.addTopic(h.testTopicInstance)
+}
+
+// marshalMessages formats msgs for use in test failures.
+func marshalMessages[T any](msgs []T) string {
+	if len(msgs) == 0 {
+		return "(none)"
+	}
+	data, err := json.MarshalIndent(msgs, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
 }
@@ -2,6 +2,7 @@ package encore
 
 import (
 	"net/url"
+	"runtime"
 	"time"
 
 	"encore.dev/appruntime/exported/config"
@@ -41,6 +42,9 @@ func (mgr *Manager) Meta() *AppMetadata {
 		Build: BuildMeta{
 			Revision:           mgr.static.AppCommit.Revision,
 			UncommittedChanges: mgr.static.AppCommit.Uncommitted,
+			EncoreCompiler:     mgr.static.EncoreCompiler,
+			GoVersion:          runtime.Version(),
+			InfraHash:          mgr.static.InfraHash,
 		},
 		Deploy: DeployMeta{
 			ID:   mgr.runtime.DeployID,
@@ -94,6 +98,18 @@ type BuildMeta struct {
 
 	// true if there were uncommitted changes on top of the Commit.
 	UncommittedChanges bool
+
+	// The version of Encore the binary was built with.
+	// It is for informational use only, and its format should not be relied on.
+	EncoreCompiler string
+
+	// The version of Go the binary was built with, such as "go1.23.4".
+	GoVersion string
+
+	// A hash of the infrastructure the app requires, such as its databases,
+	// Pub/Sub topics and secrets. It changes only when those requirements do,
+	// so it can be compared to tell whether two builds need the same infrastructure.
+	InfraHash string
 }
 
 type DeployMeta struct {
@@ -122,6 +122,11 @@ func (g *Gauge[V]) Add(val V) {
 	}
 }
 
+//publicapigen:drop
+func NewGaugeGroupInternal[L Labels, V Value](reg *Registry, name string, cfg GaugeConfig) *GaugeGroup[L, V] {
+	return newGaugeGroup[L, V](reg, name, cfg)
+}
+
 func newGaugeGroup[L Labels, V Value](mgr *Registry, name string, cfg GaugeConfig) *GaugeGroup[L, V] {
 	labelMapper := cfg.EncoreInternal_LabelMapper.(func(L) []KeyValue)
 	m := newMetricInfo[V](mgr, name, GaugeType, cfg.EncoreInternal_SvcNum)
@@ -200,6 +200,26 @@ func TestGaugeGroup(t *testing.T) {
 	eq(t, countryRegistry(&mgr.registry), 2)
 }
 
+func TestRegistry_Collector(t *testing.T) {
+	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
+	mgr := NewRegistry(rt, 1)
+	g := newGauge(newMetricInfo[int64](mgr, "foo", GaugeType, 1))
+
+	// The collector runs before the metrics are collected,
+	// so the collected value is the sampled one.
+	sample := int64(0)
+	mgr.RegisterCollector(func() {
+		sample++
+		g.Set(sample)
+	})
+
+	for want := int64(1); want <= 2; want++ {
+		collected := mgr.Collect()
+		eq(t, len(collected), 1)
+		eq(t, collected[0].Val.([]int64)[0], want)
+	}
+}
+
 func BenchmarkCounter_Inc(b *testing.B) {
 	b.ReportAllocs()
 	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
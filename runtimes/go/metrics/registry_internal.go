@@ -14,13 +14,32 @@ type Registry struct {
 	numSvcs  uint16
 	tsid     uint64
 	registry sync.Map // map[registryKey]*timeseries
+
+	collectorsMu sync.Mutex
+	collectors   []func()
 }
 
 func NewRegistry(rt *reqtrack.RequestTracker, numServicesInBinary int) *Registry {
 	return &Registry{rt: rt, numSvcs: uint16(numServicesInBinary)}
 }
 
+// RegisterCollector registers fn to be called at the start of each
+// collection, to update metrics sampled from elsewhere, such as the
+// statistics of a connection pool.
+func (r *Registry) RegisterCollector(fn func()) {
+	r.collectorsMu.Lock()
+	r.collectors = append(r.collectors, fn)
+	r.collectorsMu.Unlock()
+}
+
 func (r *Registry) Collect() []CollectedMetric {
+	r.collectorsMu.Lock()
+	collectors := r.collectors
+	r.collectorsMu.Unlock()
+	for _, fn := range collectors {
+		fn()
+	}
+
 	metrics := make([]CollectedMetric, 0, 128)
 	r.registry.Range(func(key, value any) bool {
 		switch val := value.(type) {
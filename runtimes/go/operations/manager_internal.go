@@ -0,0 +1,83 @@
+package operations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/beta/errs"
+	"encore.dev/internal/platformauth"
+)
+
+type Manager struct {
+	runtime    *config.Runtime
+	rootLogger zerolog.Logger
+
+	mu       sync.Mutex
+	trackers map[string]*Tracker
+}
+
+func NewManager(runtime *config.Runtime, rootLogger zerolog.Logger) *Manager {
+	return &Manager{
+		runtime:    runtime,
+		rootLogger: rootLogger,
+		trackers:   make(map[string]*Tracker),
+	}
+}
+
+func newTracker(mgr *Manager, name string, cfg TrackerConfig) *Tracker {
+	if cfg.DB == nil {
+		mgr.rootLogger.Fatal().Msgf("invalid operation tracker %s: no database", name)
+	}
+	return mgr.register(name, cfg, &sqlStore{db: cfg.DB})
+}
+
+func (mgr *Manager) register(name string, cfg TrackerConfig, s store) *Tracker {
+	t := &Tracker{mgr: mgr, name: name, cfg: cfg, store: s}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if _, ok := mgr.trackers[name]; ok {
+		mgr.rootLogger.Fatal().Msgf("duplicate operation tracker %s", name)
+	}
+	mgr.trackers[name] = t
+	return t
+}
+
+// handleStatus serves the status of an operation, for clients to poll.
+//
+// Operation ids are unguessable, so knowing the id of an operation grants
+// access to its status. The status of operations of private trackers is
+// only served to the Encore platform.
+func (mgr *Manager) handleStatus(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	params := httprouter.ParamsFromContext(ctx)
+	name, id := params.ByName("tracker"), params.ByName("id")
+
+	mgr.mu.Lock()
+	t := mgr.trackers[name]
+	mgr.mu.Unlock()
+	if t == nil || (t.cfg.Private && !platformauth.IsEncorePlatformRequest(ctx)) {
+		errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("operation not found").Err())
+		return
+	}
+
+	st, err := t.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("operation not found").Err())
+		return
+	} else if err != nil {
+		mgr.rootLogger.Error().Err(err).Str("tracker", name).Str("operation", id).Msg("unable to get operation status")
+		errs.HTTPError(w, errs.B().Code(errs.Internal).Msg("unable to get operation status").Err())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(st)
+}
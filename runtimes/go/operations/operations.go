@@ -0,0 +1,17 @@
+//go:build encore_app
+
+package operations
+
+// NewTracker declares a new operation tracker, storing its operations
+// in the configured database.
+//
+// It must be called when declaring a package level variable:
+//
+//	var Exports = operations.NewTracker("exports", operations.TrackerConfig{
+//		DB: db,
+//	})
+//
+// The name must be unique within the application.
+func NewTracker(name string, cfg TrackerConfig) *Tracker {
+	return newTracker(Singleton, name, cfg)
+}
@@ -0,0 +1,9 @@
+// Package operations provides support for tracking long-running operations.
+//
+// An operation is created when a long-running task starts, such as an export or
+// an import, and reports its progress as it runs until it succeeds or fails.
+// Operations are persisted in the service's database, and clients poll their
+// status from the application's operations endpoint until they're done.
+//
+// For more information see https://encore.dev/docs/primitives/operations
+package operations
@@ -0,0 +1,156 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"encore.dev/storage/sqldb"
+)
+
+// store persists operations.
+type store interface {
+	insert(ctx context.Context, st *Status) error
+
+	// get returns the status of an operation,
+	// or ErrNotFound if it doesn't exist.
+	get(ctx context.Context, tracker, id string) (*Status, error)
+
+	// update applies upd to an operation, or reports ErrNotFound
+	// if it doesn't exist and ErrDone if it's already done.
+	update(ctx context.Context, tracker, id string, upd update) error
+}
+
+// schema contains the statements creating the table operations are stored in.
+var schema = []string{`
+CREATE TABLE IF NOT EXISTS encore_operations (
+	id             TEXT PRIMARY KEY,
+	tracker        TEXT NOT NULL,
+	state          TEXT NOT NULL,
+	progress_done  BIGINT NOT NULL DEFAULT 0,
+	progress_total BIGINT NOT NULL DEFAULT 0,
+	message        TEXT NOT NULL DEFAULT '',
+	metadata       JSONB,
+	result         JSONB,
+	error          TEXT NOT NULL DEFAULT '',
+	created_at     TIMESTAMPTZ NOT NULL,
+	updated_at     TIMESTAMPTZ NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS encore_operations_created_at ON encore_operations (created_at)`,
+}
+
+// sqlStore stores operations in the encore_operations table of a database,
+// which is created on first use.
+type sqlStore struct {
+	db *sqldb.Database
+
+	mu    sync.Mutex
+	ready bool // whether the table has been created
+}
+
+func (s *sqlStore) ensureTable(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ready {
+		return nil
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Serialize creating the table, as concurrent CREATE TABLE IF NOT EXISTS
+	// statements can fail when instances of the app start at the same time.
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('encore.operations'))"); err != nil {
+		return err
+	}
+	for _, stmt := range schema {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.ready = true
+	return nil
+}
+
+func (s *sqlStore) insert(ctx context.Context, st *Status) error {
+	if err := s.ensureTable(ctx); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO encore_operations (id, tracker, state, metadata, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		st.ID, st.Tracker, string(st.State), nullJSON(st.Metadata), st.CreatedAt, st.UpdatedAt)
+	return err
+}
+
+func (s *sqlStore) get(ctx context.Context, tracker, id string) (*Status, error) {
+	if err := s.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	st := &Status{ID: id, Tracker: tracker}
+	var state string
+	var metadata, result []byte
+	err := s.db.QueryRow(ctx, `
+		SELECT state, progress_done, progress_total, message, metadata, result, error, created_at, updated_at
+		FROM encore_operations
+		WHERE tracker = $1 AND id = $2`, tracker, id).Scan(
+		&state, &st.Progress.Done, &st.Progress.Total, &st.Progress.Message,
+		&metadata, &result, &st.Error, &st.CreatedAt, &st.UpdatedAt)
+	if errors.Is(err, sqldb.ErrNoRows) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	st.State = State(state)
+	st.Metadata, st.Result = metadata, result
+	return st, nil
+}
+
+func (s *sqlStore) update(ctx context.Context, tracker, id string, upd update) error {
+	if err := s.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	var done, total *int64
+	var message *string
+	if p := upd.progress; p != nil {
+		done, total, message = &p.Done, &p.Total, &p.Message
+	}
+	res, err := s.db.Exec(ctx, `
+		UPDATE encore_operations SET
+			state = $3,
+			progress_done = COALESCE($4, progress_done),
+			progress_total = COALESCE($5, progress_total),
+			message = COALESCE($6, message),
+			result = $7,
+			error = $8,
+			updated_at = $9
+		WHERE tracker = $1 AND id = $2 AND state IN ('pending', 'running')`,
+		tracker, id, string(upd.state), done, total, message, nullJSON(upd.result), upd.err, upd.updatedAt)
+	if err != nil {
+		return err
+	} else if res.RowsAffected() > 0 {
+		return nil
+	}
+
+	// Report whether the operation doesn't exist or is already done.
+	if _, err := s.get(ctx, tracker, id); err != nil {
+		return err
+	}
+	return ErrDone
+}
+
+// nullJSON returns the value to store for the JSON value b,
+// which is NULL if b is empty.
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
@@ -0,0 +1,284 @@
+package operations
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// State is the state of an operation.
+type State string
+
+const (
+	// Pending operations have been created but haven't reported any progress yet.
+	Pending State = "pending"
+	// Running operations have reported progress.
+	Running State = "running"
+	// Succeeded operations have completed with a result.
+	Succeeded State = "succeeded"
+	// Failed operations have completed with an error.
+	Failed State = "failed"
+)
+
+// Done reports whether operations in the state are done,
+// meaning they've either succeeded or failed.
+func (s State) Done() bool {
+	return s == Succeeded || s == Failed
+}
+
+var (
+	// ErrNotFound is reported for operations that don't exist.
+	ErrNotFound = errors.New("operations: operation not found")
+
+	// ErrDone is reported when updating operations that are already done.
+	ErrDone = errors.New("operations: operation is already done")
+)
+
+// TrackerConfig configures an operation tracker.
+type TrackerConfig struct {
+	// DB is the database operations are stored in,
+	// in the encore_operations table.
+	DB *sqldb.Database
+
+	// Private, if true, doesn't serve the status of operations
+	// to clients, which can only be fetched by the application.
+	Private bool
+}
+
+// Progress is the progress of an operation.
+type Progress struct {
+	// Done and Total are the amount of work done and to do,
+	// in a unit of the operation's choosing such as rows or bytes.
+	// Total is zero if the amount of work isn't known.
+	Done  int64 `json:"done"`
+	Total int64 `json:"total"`
+
+	// Message optionally describes what the operation is doing.
+	Message string `json:"message,omitempty"`
+}
+
+// Status is the status of an operation.
+type Status struct {
+	ID       string   `json:"id"`
+	Tracker  string   `json:"tracker"`
+	State    State    `json:"state"`
+	Progress Progress `json:"progress"`
+
+	// Metadata is the JSON encoded metadata the operation was created with, if any.
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+
+	// Result is the JSON encoded result of succeeded operations, if any.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error is the error message of failed operations.
+	Error string `json:"error,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Done reports whether the operation is done,
+// meaning it has either succeeded or failed.
+func (s *Status) Done() bool {
+	return s.State.Done()
+}
+
+// Tracker tracks long-running operations.
+//
+// See NewTracker for more information on how to declare a Tracker.
+type Tracker struct {
+	mgr   *Manager
+	name  string
+	cfg   TrackerConfig
+	store store
+}
+
+// Name returns the name of the tracker.
+func (t *Tracker) Name() string {
+	return t.name
+}
+
+// Create creates a new pending operation.
+//
+// The metadata, which may be nil, is encoded as JSON and returned as part of
+// the operation's status, for example to describe what the operation is about.
+func (t *Tracker) Create(ctx context.Context, metadata any) (*Operation, error) {
+	return t.create(ctx, Pending, metadata)
+}
+
+func (t *Tracker) create(ctx context.Context, state State, metadata any) (*Operation, error) {
+	md, err := marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("operations: marshal metadata: %w", err)
+	}
+
+	now := time.Now().UTC()
+	st := &Status{
+		ID:        newID(),
+		Tracker:   t.name,
+		State:     state,
+		Metadata:  md,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := t.store.insert(ctx, st); err != nil {
+		return nil, fmt.Errorf("operations: create operation: %w", err)
+	}
+	return t.Operation(st.ID), nil
+}
+
+// Operation returns the operation with the given id,
+// for example to report its progress from another service or process.
+//
+// It doesn't check whether the operation exists.
+func (t *Tracker) Operation(id string) *Operation {
+	return &Operation{t: t, id: id}
+}
+
+// Get returns the status of the operation with the given id.
+// It reports ErrNotFound if there is no such operation.
+func (t *Tracker) Get(ctx context.Context, id string) (*Status, error) {
+	st, err := t.store.get(ctx, t.name, id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		err = fmt.Errorf("operations: get operation %s: %w", id, err)
+	}
+	return st, err
+}
+
+// Run creates a new running operation and calls fn in the background,
+// completing the operation with the result it returns or failing it
+// with its error.
+//
+// The context passed to fn isn't canceled when ctx is. As fn runs in the
+// current process, operations are left running if the process stops before
+// fn returns. Use Create, and report progress from a Pub/Sub subscription,
+// for operations that need to survive restarts.
+func (t *Tracker) Run(ctx context.Context, metadata any, fn func(ctx context.Context, op *Operation) (result any, err error)) (*Operation, error) {
+	op, err := t.create(ctx, Running, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		result, err := run(ctx, op, fn)
+		if err != nil {
+			err = op.Fail(ctx, err)
+		} else {
+			err = op.Complete(ctx, result)
+		}
+		if err != nil && !errors.Is(err, ErrDone) {
+			t.mgr.rootLogger.Error().Err(err).Str("tracker", t.name).Str("operation", op.id).
+				Msg("unable to complete operation")
+		}
+	}()
+	return op, nil
+}
+
+// run calls fn, recovering from panics.
+func run(ctx context.Context, op *Operation, fn func(ctx context.Context, op *Operation) (any, error)) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx, op)
+}
+
+// Operation is a long-running operation.
+type Operation struct {
+	t  *Tracker
+	id string
+}
+
+// ID returns the unique id of the operation.
+func (op *Operation) ID() string {
+	return op.id
+}
+
+// Status returns the status of the operation.
+func (op *Operation) Status(ctx context.Context) (*Status, error) {
+	return op.t.Get(ctx, op.id)
+}
+
+// Progress reports the progress of the operation,
+// moving pending operations to the Running state.
+//
+// It reports ErrDone if the operation is already done.
+func (op *Operation) Progress(ctx context.Context, done, total int64, message string) error {
+	return op.update(ctx, update{
+		state:    Running,
+		progress: &Progress{Done: done, Total: total, Message: message},
+	})
+}
+
+// Complete marks the operation as succeeded with the given result,
+// which is encoded as JSON and may be nil.
+//
+// It reports ErrDone if the operation is already done.
+func (op *Operation) Complete(ctx context.Context, result any) error {
+	res, err := marshal(result)
+	if err != nil {
+		return fmt.Errorf("operations: marshal result: %w", err)
+	}
+	return op.update(ctx, update{state: Succeeded, result: res})
+}
+
+// Fail marks the operation as failed with the given error.
+//
+// It reports ErrDone if the operation is already done.
+func (op *Operation) Fail(ctx context.Context, err error) error {
+	msg := "unknown error"
+	if err != nil {
+		msg = err.Error()
+	}
+	return op.update(ctx, update{state: Failed, err: msg})
+}
+
+func (op *Operation) update(ctx context.Context, upd update) error {
+	upd.updatedAt = time.Now().UTC()
+	err := op.t.store.update(ctx, op.t.name, op.id, upd)
+	if err != nil && !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrDone) {
+		err = fmt.Errorf("operations: update operation %s: %w", op.id, err)
+	}
+	return err
+}
+
+// update is an update of an operation that isn't done.
+type update struct {
+	state     State
+	progress  *Progress       // nil to keep the current progress
+	result    json.RawMessage // for succeeded operations
+	err       string          // for failed operations
+	updatedAt time.Time
+}
+
+// marshal encodes v as JSON, or returns nil if v is nil.
+func marshal(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// idEncoding is the encoding of operation ids.
+var idEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newID returns a new random operation id.
+//
+// Ids are unguessable, as they grant access to the status of
+// the operation to clients knowing them.
+func newID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("operations: generate id: %v", err))
+	}
+	return "op_" + strings.ToLower(idEncoding.EncodeToString(b[:]))
+}
@@ -0,0 +1,178 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+// memStore stores operations in memory.
+type memStore struct {
+	mu  sync.Mutex
+	ops map[string]Status
+}
+
+func (s *memStore) insert(ctx context.Context, st *Status) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ops[st.Tracker+"/"+st.ID] = *st
+	return nil
+}
+
+func (s *memStore) get(ctx context.Context, tracker, id string) (*Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.ops[tracker+"/"+id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &st, nil
+}
+
+func (s *memStore) update(ctx context.Context, tracker, id string, upd update) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.ops[tracker+"/"+id]
+	if !ok {
+		return ErrNotFound
+	} else if st.Done() {
+		return ErrDone
+	}
+	st.State = upd.state
+	if upd.progress != nil {
+		st.Progress = *upd.progress
+	}
+	st.Result, st.Error, st.UpdatedAt = upd.result, upd.err, upd.updatedAt
+	s.ops[tracker+"/"+id] = st
+	return nil
+}
+
+func newTestTracker(name string, private bool) (*Manager, *Tracker) {
+	mgr := NewManager(&config.Runtime{}, zerolog.Nop())
+	t := mgr.register(name, TrackerConfig{Private: private}, &memStore{ops: make(map[string]Status)})
+	return mgr, t
+}
+
+func TestOperationLifecycle(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	_, tr := newTestTracker("exports", false)
+
+	op, err := tr.Create(ctx, map[string]string{"format": "csv"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(op.ID(), qt.Matches, `op_[a-z2-7]{26}`)
+
+	st, err := op.Status(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(st.State, qt.Equals, Pending)
+	c.Assert(string(st.Metadata), qt.Equals, `{"format":"csv"}`)
+
+	c.Assert(op.Progress(ctx, 5, 10, "exporting"), qt.IsNil)
+	st, err = tr.Get(ctx, op.ID())
+	c.Assert(err, qt.IsNil)
+	c.Assert(st.State, qt.Equals, Running)
+	c.Assert(st.Progress, qt.Equals, Progress{Done: 5, Total: 10, Message: "exporting"})
+
+	c.Assert(op.Complete(ctx, map[string]string{"url": "https://example.com"}), qt.IsNil)
+	st, err = op.Status(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(st.Done(), qt.IsTrue)
+	c.Assert(st.State, qt.Equals, Succeeded)
+	c.Assert(string(st.Result), qt.Equals, `{"url":"https://example.com"}`)
+
+	c.Assert(op.Progress(ctx, 10, 10, ""), qt.ErrorIs, ErrDone)
+	c.Assert(op.Fail(ctx, errors.New("boom")), qt.ErrorIs, ErrDone)
+
+	_, err = tr.Get(ctx, "op_unknown")
+	c.Assert(err, qt.ErrorIs, ErrNotFound)
+	c.Assert(tr.Operation("op_unknown").Complete(ctx, nil), qt.ErrorIs, ErrNotFound)
+}
+
+func TestRun(t *testing.T) {
+	c := qt.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	_, tr := newTestTracker("imports", false)
+
+	wait := func(op *Operation) *Status {
+		for {
+			st, err := op.Status(context.Background())
+			c.Assert(err, qt.IsNil)
+			if st.Done() {
+				return st
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	release := make(chan struct{})
+	op, err := tr.Run(ctx, nil, func(ctx context.Context, op *Operation) (any, error) {
+		<-release
+		if err := op.Progress(ctx, 1, 1, ""); err != nil {
+			return nil, err
+		}
+		return 42, ctx.Err()
+	})
+	c.Assert(err, qt.IsNil)
+	st, err := op.Status(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(st.State, qt.Equals, Running)
+
+	// Canceling the context of the request starting the operation
+	// doesn't cancel the operation.
+	cancel()
+	close(release)
+	st = wait(op)
+	c.Assert(st.State, qt.Equals, Succeeded)
+	c.Assert(string(st.Result), qt.Equals, "42")
+
+	op, err = tr.Run(context.Background(), nil, func(ctx context.Context, op *Operation) (any, error) {
+		panic("boom")
+	})
+	c.Assert(err, qt.IsNil)
+	st = wait(op)
+	c.Assert(st.State, qt.Equals, Failed)
+	c.Assert(st.Error, qt.Equals, "panic: boom")
+}
+
+func TestHandleStatus(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	mgr, tr := newTestTracker("exports", false)
+	private := mgr.register("internal", TrackerConfig{Private: true}, &memStore{ops: make(map[string]Status)})
+
+	op, err := tr.Create(ctx, nil)
+	c.Assert(err, qt.IsNil)
+	privateOp, err := private.Create(ctx, nil)
+	c.Assert(err, qt.IsNil)
+
+	router := httprouter.New()
+	router.HandlerFunc("GET", "/operations/:tracker/:id", mgr.handleStatus)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	w := get("/operations/exports/" + op.ID())
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+	var st Status
+	c.Assert(json.Unmarshal(w.Body.Bytes(), &st), qt.IsNil)
+	c.Assert(st.ID, qt.Equals, op.ID())
+	c.Assert(st.Tracker, qt.Equals, "exports")
+	c.Assert(st.State, qt.Equals, Pending)
+
+	c.Assert(get("/operations/exports/op_unknown").Code, qt.Equals, http.StatusNotFound)
+	c.Assert(get("/operations/unknown/"+op.ID()).Code, qt.Equals, http.StatusNotFound)
+	c.Assert(get("/operations/internal/"+privateOp.ID()).Code, qt.Equals, http.StatusNotFound)
+}
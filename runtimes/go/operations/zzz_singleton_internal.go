@@ -0,0 +1,17 @@
+//go:build encore_app
+
+package operations
+
+import (
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/logging"
+)
+
+//publicapigen:drop
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(appconf.Runtime, logging.RootLogger)
+	api.RegisterWebhookRoute("GET", "/operations/:tracker/:id", Singleton.handleStatus)
+}
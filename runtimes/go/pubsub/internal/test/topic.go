@@ -2,7 +2,11 @@ package test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -28,6 +32,7 @@ type TestTopic[T any] struct {
 	m           sync.RWMutex
 	instances   map[*testing.T]*testInstance[T]
 	subscribers map[string]types.RawSubscriptionCallback
+	retries     map[string]*types.RetryPolicy // the retry policies of the subscribers
 }
 
 func NewTopic[T any](ts *testsupport.Manager, name string) types.TopicImplementation {
@@ -36,6 +41,7 @@ func NewTopic[T any](ts *testsupport.Manager, name string) types.TopicImplementa
 		name:        name,
 		instances:   make(map[*testing.T]*testInstance[T]),
 		subscribers: make(map[string]types.RawSubscriptionCallback),
+		retries:     make(map[string]*types.RetryPolicy),
 	}
 }
 
@@ -55,29 +61,27 @@ func (t *TestTopic[T]) PublishMessage(ctx context.Context, orderingKey string, a
 
 	instance := t.TestInstance(test)
 
-	msgID, err := instance.publishMessage(unmarshalled)
+	msg, err := instance.publishMessage(orderingKey, attrs, data, unmarshalled)
 	if err != nil {
 		return "", err
 	}
 
 	// If subscriptions are enabled for this test, then trigger those subscribers asynchronously
 	// allowing the publishing code to continue as it would in a real system
-	if instance.subscriptionsEnabled {
-		published := time.Now()
-
-		for name, sub := range t.subscribers {
+	if msg.delivered {
+		for name, sub := range t.subscriptions() {
 			name := name
 			sub := sub
 			t.ts.RunAsyncCodeInTest(test, func(ctx context.Context) {
-				if err := sub(ctx, msgID, published, 1, attrs, data); err != nil {
-					test.Errorf("an error was returned while processing subscription %s for message %s: %s", name, msgID, err)
+				if err := sub(ctx, msg.id, msg.published, 1, attrs, data); err != nil {
+					test.Errorf("an error was returned while processing subscription %s for message %s: %s", name, msg.id, err)
 					test.Fail()
 				}
 			})
 		}
 	}
 
-	return msgID, nil
+	return msg.id, nil
 }
 
 // Subscribe will register a new subscriber for the pub sub topic. By default these will not be called during tests
@@ -85,6 +89,28 @@ func (t *TestTopic[T]) Subscribe(logger *zerolog.Logger, maxConcurrency int, ack
 	t.m.Lock()
 	defer t.m.Unlock()
 	t.subscribers[implCfg.EncoreName] = f
+	t.retries[implCfg.EncoreName] = retryPolicy
+}
+
+// subscriptions returns a copy of the subscribers to the topic.
+func (t *TestTopic[T]) subscriptions() map[string]types.RawSubscriptionCallback {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	subs := make(map[string]types.RawSubscriptionCallback, len(t.subscribers))
+	for name, sub := range t.subscribers {
+		subs[name] = sub
+	}
+	return subs
+}
+
+// retryPolicy returns the retry policy of the given subscriber.
+func (t *TestTopic[T]) retryPolicy(name string) *types.RetryPolicy {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	if p := t.retries[name]; p != nil {
+		return p
+	}
+	return &types.RetryPolicy{}
 }
 
 // TestInstance returns this tests specific instance of the topic and creates it if it does not exist
@@ -100,6 +126,7 @@ func (t *TestTopic[T]) TestInstance(test *testing.T) *testInstance[T] {
 	defer t.m.Unlock()
 	if _, found := t.instances[test]; !found {
 		t.instances[test] = &testInstance[T]{
+			topic:     t,
 			topicName: t.name,
 			t:         test,
 		}
@@ -111,29 +138,196 @@ func (t *TestTopic[T]) TestInstance(test *testing.T) *testInstance[T] {
 // testInstance represents a topic, as it is seen from a test
 // This struct implements test.TestTopic[T] to allow the testing package to interface with it
 type testInstance[T any] struct {
-	topicName            string     // The topic name
-	t                    *testing.T // The test we're running against
-	msgID                int32      // The last message ID we sent (updated atomically)
-	m                    sync.Mutex // Mutex for the published messages
-	messages             []T        // What messages have been published
-	subscriptionsEnabled bool       // If subscriptions are enabled for this test
+	topic                *TestTopic[T]          // The topic this is an instance of
+	topicName            string                 // The topic name
+	t                    *testing.T             // The test we're running against
+	msgID                int32                  // The last message ID we sent (updated atomically)
+	m                    sync.Mutex             // Mutex for the published messages
+	messages             []*publishedMessage[T] // What messages have been published
+	subscriptionsEnabled bool                   // If subscriptions are enabled for this test
+	scheduled            []*scheduledRetry[T]   // Failed deliveries to retry, when using a virtual clock
+}
+
+// publishedMessage is a message published during a test.
+type publishedMessage[T any] struct {
+	id          string
+	orderingKey string
+	attrs       map[string]string
+	data        []byte
+	published   time.Time
+	msg         T
+	delivered   bool // whether the message has been delivered to the subscribers
 }
 
 // publishMessage records the message which was sent, and generates a deterministic message ID
 // which is guaranteed to be unique across all tests
-func (t *testInstance[T]) publishMessage(unmarshalled T) (id string, err error) {
+func (t *testInstance[T]) publishMessage(orderingKey string, attrs map[string]string, data []byte, unmarshalled T) (*publishedMessage[T], error) {
 	msgID := atomic.AddInt32(&t.msgID, 1)
 
 	t.m.Lock()
 	defer t.m.Unlock()
-	t.messages = append(t.messages, unmarshalled)
-
-	// we use "/" as the separator to mirror the behaviour of tests and sub tests
-	return fmt.Sprintf("%s/%s/%d", t.t.Name(), t.topicName, msgID), nil
+	msg := &publishedMessage[T]{
+		// we use "/" as the separator to mirror the behaviour of tests and sub tests
+		id:          fmt.Sprintf("%s/%s/%d", t.t.Name(), t.topicName, msgID),
+		orderingKey: orderingKey,
+		attrs:       attrs,
+		data:        data,
+		published:   time.Now(),
+		msg:         unmarshalled,
+		delivered:   t.subscriptionsEnabled,
+	}
+	t.messages = append(t.messages, msg)
+	return msg, nil
 }
 
 func (t *testInstance[T]) PublishedMessages() []T {
 	t.m.Lock()
 	defer t.m.Unlock()
-	return t.messages
+	msgs := make([]T, len(t.messages))
+	for i, msg := range t.messages {
+		msgs[i] = msg.msg
+	}
+	return msgs
+}
+
+// RangePublished calls fn for each message published during this test, in the order they were published.
+// The attributes passed to fn exclude the attributes Encore uses internally.
+func (t *testInstance[T]) RangePublished(fn func(id, orderingKey string, attrs map[string]string, published time.Time, msg T)) {
+	t.m.Lock()
+	msgs := slices.Clone(t.messages)
+	t.m.Unlock()
+
+	for _, msg := range msgs {
+		attrs := make(map[string]string, len(msg.attrs))
+		for k, v := range msg.attrs {
+			if !strings.HasPrefix(k, "encore_") {
+				attrs[k] = v
+			}
+		}
+		fn(msg.id, msg.orderingKey, attrs, msg.published, msg.msg)
+	}
+}
+
+// EnableSubscriptions causes messages published during this test to be
+// delivered to the subscribers asynchronously, as they would be in a real system.
+func (t *testInstance[T]) EnableSubscriptions() {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.subscriptionsEnabled = true
+}
+
+// maxDeliveries is the maximum number of messages Deliver delivers in a single call,
+// to guard against subscribers that publish to the topic they subscribe to indefinitely.
+const maxDeliveries = 10000
+
+// Deliver synchronously delivers the messages that have not yet been delivered
+// to each subscriber of the topic, in the order they were published.
+// Subscribers are called in order of their name, and messages published while
+// delivering are delivered as well.
+//
+// It returns the errors returned by the subscribers. Failed deliveries are not retried.
+func (t *testInstance[T]) Deliver(ctx context.Context) error {
+	return t.deliver(ctx, time.Time{}, false)
+}
+
+// DeliverAt is like Deliver, but for a virtual clock at the time now: failed deliveries
+// are retried according to the subscriber's retry policy once the clock reaches the time
+// of the retry, and the retries that are due at now are delivered as well.
+func (t *testInstance[T]) DeliverAt(ctx context.Context, now time.Time) error {
+	return t.deliver(ctx, now, true)
+}
+
+// NextRetry returns the time of the earliest scheduled retry, if any.
+func (t *testInstance[T]) NextRetry() (at time.Time, ok bool) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	for _, r := range t.scheduled {
+		if !ok || r.due.Before(at) {
+			at, ok = r.due, true
+		}
+	}
+	return at, ok
+}
+
+// scheduledRetry is a failed delivery to be retried at a later time.
+type scheduledRetry[T any] struct {
+	msg     *publishedMessage[T]
+	sub     string // the subscriber to deliver to
+	attempt int    // the delivery attempt
+	due     time.Time
+}
+
+func (t *testInstance[T]) deliver(ctx context.Context, now time.Time, retry bool) error {
+	subs := t.topic.subscriptions()
+	names := slices.Sorted(maps.Keys(subs))
+
+	var errs []error
+	deliverTo := func(name string, msg *publishedMessage[T], attempt int) {
+		err := subs[name](ctx, msg.id, msg.published, attempt, msg.attrs, msg.data)
+		if err == nil {
+			return
+		}
+		errs = append(errs, fmt.Errorf("subscription %s failed to process message %s (attempt %d): %w", name, msg.id, attempt, err))
+
+		policy := t.topic.retryPolicy(name)
+		if shouldRetry, backoff := utils.GetDelay(policy.MaxRetries, policy.MinBackoff, policy.MaxBackoff, uint16(attempt)); retry && shouldRetry {
+			t.m.Lock()
+			t.scheduled = append(t.scheduled, &scheduledRetry[T]{msg: msg, sub: name, attempt: attempt + 1, due: now.Add(backoff)})
+			t.m.Unlock()
+		}
+	}
+
+	for n := 0; ; n++ {
+		if n >= maxDeliveries {
+			return errors.Join(append(errs, fmt.Errorf("pubsub: delivered more than %d messages on topic %s, aborting", maxDeliveries, t.topicName))...)
+		}
+
+		if msg := t.nextUndelivered(); msg != nil {
+			for _, name := range names {
+				deliverTo(name, msg, 1)
+			}
+			continue
+		}
+
+		if retry {
+			if r := t.nextDueRetry(now); r != nil {
+				deliverTo(r.sub, r.msg, r.attempt)
+				continue
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// nextDueRetry removes the earliest retry that is due at now from the schedule
+// and returns it. It returns nil if no retry is due.
+func (t *testInstance[T]) nextDueRetry(now time.Time) *scheduledRetry[T] {
+	t.m.Lock()
+	defer t.m.Unlock()
+	idx := -1
+	for i, r := range t.scheduled {
+		if !r.due.After(now) && (idx < 0 || r.due.Before(t.scheduled[idx].due)) {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+	r := t.scheduled[idx]
+	t.scheduled = slices.Delete(t.scheduled, idx, idx+1)
+	return r
+}
+
+// nextUndelivered marks the first message that has not been delivered
+// as delivered and returns it. It returns nil if all messages have been delivered.
+func (t *testInstance[T]) nextUndelivered() *publishedMessage[T] {
+	t.m.Lock()
+	defer t.m.Unlock()
+	for _, msg := range t.messages {
+		if !msg.delivered {
+			msg.delivered = true
+			return msg
+		}
+	}
+	return nil
 }
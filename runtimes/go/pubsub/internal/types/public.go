@@ -133,4 +133,43 @@ type TopicConfig struct {
 	// [AWS SQS Quotas]: https://docs.aws.amazon.com/AWSSimpleQueueService/latest/SQSDeveloperGuide/quotas-messages.html
 	// [GCP PubSub Quotas]: https://cloud.google.com/pubsub/quotas#resource_limits
 	OrderingAttribute string
+
+	// CloudEvents, if set, publishes messages to the topic as CloudEvents,
+	// for interoperability with systems like Knative and Amazon EventBridge.
+	//
+	// Subscriptions to the topic also accept messages published as CloudEvents
+	// by external systems, in either content mode, and decode their data
+	// into the message type. The event's context attributes can be read
+	// using `pubsub-attr` tags prefixed with "ce-", like `pubsub-attr:"ce-type"`.
+	//
+	// See https://cloudevents.io for more information.
+	CloudEvents *CloudEventsConfig
+}
+
+// CloudEventsMode is the content mode messages are published as CloudEvents in.
+type CloudEventsMode string
+
+const (
+	// CloudEventsBinary publishes the message as the event data, with the
+	// event's context attributes as "ce-" prefixed message attributes.
+	CloudEventsBinary CloudEventsMode = "binary"
+
+	// CloudEventsStructured publishes the message wrapped in a JSON event
+	// envelope holding the event's context attributes.
+	CloudEventsStructured CloudEventsMode = "structured"
+)
+
+// CloudEventsConfig configures how messages are published as CloudEvents.
+type CloudEventsConfig struct {
+	// Mode is the content mode to publish messages in.
+	// Defaults to CloudEventsBinary.
+	Mode CloudEventsMode
+
+	// Source identifies the context the events occur in, as a URI reference.
+	// Defaults to "/topics/<topic name>".
+	Source string
+
+	// Type is the type of the events, like "com.example.order.created".
+	// Defaults to the topic name.
+	Type string
 }
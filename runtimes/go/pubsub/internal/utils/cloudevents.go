@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"encore.dev/pubsub/internal/types"
+)
+
+const (
+	// CloudEventsAttrPrefix is the prefix of the message attributes holding
+	// the context attributes of CloudEvents in binary content mode.
+	CloudEventsAttrPrefix = "ce-"
+
+	// ContentTypeAttr is the message attribute holding the content type of the message.
+	ContentTypeAttr = "content-type"
+
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsContentType = "application/cloudevents+json"
+	jsonContentType        = "application/json"
+)
+
+// EncodeCloudEvent encodes the JSON message data published to the given topic
+// as a CloudEvent, according to cfg. It returns the attributes and data to publish.
+func EncodeCloudEvent(cfg *types.CloudEventsConfig, topic string, attrs map[string]string, data []byte, now time.Time) (map[string]string, []byte, error) {
+	source := cfg.Source
+	if source == "" {
+		source = "/topics/" + topic
+	}
+	typ := cfg.Type
+	if typ == "" {
+		typ = topic
+	}
+	ctxAttrs := map[string]string{
+		"specversion": cloudEventsSpecVersion,
+		"id":          xid.New().String(),
+		"source":      source,
+		"type":        typ,
+		"time":        now.UTC().Format(time.RFC3339Nano),
+	}
+
+	attrs = maps.Clone(attrs)
+	if attrs == nil {
+		attrs = make(map[string]string)
+	}
+
+	switch cfg.Mode {
+	case types.CloudEventsBinary, "":
+		for k, v := range ctxAttrs {
+			attrs[CloudEventsAttrPrefix+k] = v
+		}
+		attrs[ContentTypeAttr] = jsonContentType
+		return attrs, data, nil
+
+	case types.CloudEventsStructured:
+		envelope := make(map[string]any, len(ctxAttrs)+2)
+		for k, v := range ctxAttrs {
+			envelope[k] = v
+		}
+		envelope["datacontenttype"] = jsonContentType
+		envelope["data"] = json.RawMessage(data)
+		data, err := json.Marshal(envelope)
+		if err != nil {
+			return nil, nil, err
+		}
+		attrs[ContentTypeAttr] = cloudEventsContentType
+		return attrs, data, nil
+
+	default:
+		return nil, nil, errors.New("unknown CloudEvents mode " + string(cfg.Mode))
+	}
+}
+
+// DecodeCloudEvent unwraps the data of a CloudEvent in structured content mode,
+// returning the event data and the message attributes with the event's context
+// attributes added as "ce-" prefixed attributes, like in binary content mode.
+//
+// Messages marked as structured CloudEvents by their content type are always
+// unwrapped. If sniff is true, so are JSON objects that look like CloudEvents.
+// Other messages, including CloudEvents in binary content mode, are returned as-is.
+func DecodeCloudEvent(attrs map[string]string, data []byte, sniff bool) (map[string]string, []byte, error) {
+	structured := strings.HasPrefix(attrs[ContentTypeAttr], cloudEventsContentType)
+	if !structured && (!sniff || attrs[CloudEventsAttrPrefix+"specversion"] != "") {
+		return attrs, data, nil
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		if structured {
+			return nil, nil, errors.New("CloudEvent is not a JSON object")
+		}
+		return attrs, data, nil
+	}
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &envelope); err != nil {
+		if structured {
+			return nil, nil, err
+		}
+		return attrs, data, nil
+	}
+	if !structured {
+		for _, k := range []string{"specversion", "id", "source", "type"} {
+			if _, ok := envelope[k]; !ok {
+				return attrs, data, nil
+			}
+		}
+	}
+
+	attrs = maps.Clone(attrs)
+	if attrs == nil {
+		attrs = make(map[string]string)
+	}
+	for k, v := range envelope {
+		if k == "data" || k == "data_base64" {
+			continue
+		}
+		var str string
+		if err := json.Unmarshal(v, &str); err != nil {
+			// Extension attributes can be numbers or booleans.
+			str = string(v)
+		}
+		if _, exists := attrs[CloudEventsAttrPrefix+k]; !exists {
+			attrs[CloudEventsAttrPrefix+k] = str
+		}
+	}
+
+	// The attributes now describe the event data.
+	attrs[ContentTypeAttr] = attrs[CloudEventsAttrPrefix+"datacontenttype"]
+	if attrs[ContentTypeAttr] == "" {
+		attrs[ContentTypeAttr] = jsonContentType
+	}
+
+	if b64, ok := envelope["data_base64"]; ok {
+		var str string
+		if err := json.Unmarshal(b64, &str); err != nil {
+			return nil, nil, err
+		}
+		data, err := base64.StdEncoding.DecodeString(str)
+		if err != nil {
+			return nil, nil, err
+		}
+		return attrs, data, nil
+	}
+	data, ok := envelope["data"]
+	if !ok {
+		data = json.RawMessage("null")
+	}
+	return attrs, data, nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"encore.dev/pubsub/internal/types"
+)
+
+type OrderEvent struct {
+	OrderID string `pubsub-attr:"order-id"`
+	Total   int
+	Type    string `pubsub-attr:"ce-type"`
+}
+
+func TestCloudEventRoundTrip(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, mode := range []types.CloudEventsMode{types.CloudEventsBinary, types.CloudEventsStructured} {
+		t.Run(string(mode), func(t *testing.T) {
+			cfg := &types.CloudEventsConfig{Mode: mode, Type: "com.example.order.created"}
+			attrs, data, err := EncodeCloudEvent(cfg, "orders", map[string]string{"order-id": "o1"}, []byte(`{"Total":42}`), now)
+			Assert(t, err, IsNil)
+			Assert(t, attrs["order-id"], Equals, "o1")
+
+			if mode == types.CloudEventsBinary {
+				Assert(t, string(data), Equals, `{"Total":42}`)
+				Assert(t, attrs["ce-specversion"], Equals, "1.0")
+				Assert(t, attrs["ce-source"], Equals, "/topics/orders")
+				Assert(t, attrs["ce-time"], Equals, "2024-05-01T12:00:00Z")
+				Assert(t, attrs["content-type"], Equals, "application/json")
+			} else {
+				Assert(t, attrs["content-type"], Equals, "application/cloudevents+json")
+				Assert(t, attrs["ce-type"], Equals, "")
+			}
+
+			msg, err := UnmarshalMessage[*OrderEvent](attrs, data)
+			Assert(t, err, IsNil)
+			Assert(t, msg.OrderID, Equals, "o1")
+			Assert(t, msg.Total, Equals, 42)
+			Assert(t, msg.Type, Equals, "com.example.order.created")
+		})
+	}
+}
+
+func TestDecodeExternalCloudEvent(t *testing.T) {
+	event := []byte(`{
+		"specversion": "1.0",
+		"id": "A234-1234-1234",
+		"source": "https://github.com/cloudevents/spec/pull",
+		"type": "com.example.order.created",
+		"priority": 5,
+		"data": {"Total": 7}
+	}`)
+
+	// Without a content type, the event is only decoded when sniffing.
+	_, data, err := DecodeCloudEvent(nil, event, false)
+	Assert(t, err, IsNil)
+	Assert(t, string(data), Equals, string(event))
+
+	attrs, data, err := DecodeCloudEvent(nil, event, true)
+	Assert(t, err, IsNil)
+	Assert(t, string(data), Equals, `{"Total": 7}`)
+	Assert(t, attrs["ce-id"], Equals, "A234-1234-1234")
+	Assert(t, attrs["ce-priority"], Equals, "5")
+	Assert(t, attrs["content-type"], Equals, "application/json")
+
+	// Base64 encoded data is decoded.
+	attrs, data, err = DecodeCloudEvent(map[string]string{"content-type": "application/cloudevents+json"},
+		[]byte(`{"specversion": "1.0", "id": "1", "source": "/", "type": "t", "data_base64": "eyJUb3RhbCI6IDh9"}`), false)
+	Assert(t, err, IsNil)
+	Assert(t, string(data), Equals, `{"Total": 8}`)
+	Assert(t, attrs["ce-type"], Equals, "t")
+
+	// Plain messages are left as-is, even when sniffing.
+	_, data, err = DecodeCloudEvent(nil, []byte(`{"Total": 9, "type": "x"}`), true)
+	Assert(t, err, IsNil)
+	Assert(t, string(data), Equals, `{"Total": 9, "type": "x"}`)
+}
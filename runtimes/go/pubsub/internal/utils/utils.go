@@ -17,8 +17,14 @@ import (
 
 const AttrTag = "pubsub-attr"
 
-// UnmarshalMessage unmarshals a message into a struct. The message must be a JSON object.
+// UnmarshalMessage unmarshals a message into a struct. The message must be a JSON object,
+// or a structured CloudEvent with a JSON object as data (see DecodeCloudEvent).
 func UnmarshalMessage[T any](attrs map[string]string, data []byte) (msg T, err error) {
+	if attrs, data, err = DecodeCloudEvent(attrs, data, false); err != nil {
+		err = errs.B().Cause(err).Code(errs.InvalidArgument).Msg("failed to decode CloudEvent").Err()
+		return
+	}
+
 	if err = json.Unmarshal(data, &msg); err != nil {
 		err = errs.B().Cause(err).Code(errs.InvalidArgument).Msg("failed to unmarshal message").Err()
 		return
@@ -2,6 +2,8 @@
 
 package pubsub
 
+import "encore.dev/appruntime/shared/startup"
+
 // NewTopic is used to declare a Topic. Encore will use static
 // analysis to identify Topics and automatically provision them
 // for you.
@@ -37,5 +39,6 @@ package pubsub
 //	  return nil
 //	}
 func NewTopic[T any](name string, cfg TopicConfig) *Topic[T] {
+	defer startup.Singleton.Resource("topic", name)()
 	return newTopic[T](Singleton, name, cfg)
 }
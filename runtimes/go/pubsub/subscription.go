@@ -11,6 +11,7 @@ import (
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/trace2"
 	"encore.dev/appruntime/shared/cfgutil"
+	"encore.dev/appruntime/shared/startup"
 	"encore.dev/beta/errs"
 	"encore.dev/pubsub/internal/noop"
 	"encore.dev/pubsub/internal/utils"
@@ -61,6 +62,7 @@ type Subscription[T any] struct {
 //		  return nil
 //		}
 func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig[T]) *Subscription[T] {
+	defer startup.Singleton.Resource("subscription", name)()
 	if topic.runtimeCfg == nil || topic.topic == nil || topic.mgr == nil {
 		panic("pubsub topic was not created using pubsub.NewTopic")
 	}
@@ -133,6 +135,15 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 			defer mgr.rt.FinishOperation()
 		}
 
+		if topic.appCfg.CloudEvents != nil {
+			// Also accept structured CloudEvents from external systems
+			// that don't set the content type attribute.
+			if attrs, data, err = utils.DecodeCloudEvent(attrs, data, true); err != nil {
+				log.Err(err).Str("msg_id", msgID).Int("delivery_attempt", deliveryAttempt).Msg("failed to decode CloudEvent")
+				return errs.B().Code(errs.Internal).Cause(err).Msg("failed to decode CloudEvent").Err()
+			}
+		}
+
 		msg, err := utils.UnmarshalMessage[T](attrs, data)
 		if err != nil {
 			log.Err(err).Str("msg_id", msgID).Int("delivery_attempt", deliveryAttempt).Msg("failed to unmarshal message")
@@ -222,12 +233,12 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 
 		err = panicCatchWrapper(ctx, msg)
 
+		resp := &model.Response{
+			Duration:   time.Since(req.Start),
+			Err:        err,
+			HTTPStatus: errs.HTTPStatus(err),
+		}
 		if curr.Trace != nil {
-			resp := &model.Response{
-				Duration:   time.Since(req.Start),
-				Err:        err,
-				HTTPStatus: errs.HTTPStatus(err),
-			}
 			curr.Trace.PubsubMessageSpanEnd(trace2.PubsubMessageSpanEndParams{
 				EventParams: trace2.EventParams{
 					TraceID: req.TraceID,
@@ -237,6 +248,7 @@ func NewSubscription[T any](topic *Topic[T], name string, cfg SubscriptionConfig
 				Resp: resp,
 			})
 		}
+		mgr.rt.ReportFailure(req, resp)
 		mgr.rt.FinishRequest(false)
 
 		return err
@@ -3,6 +3,7 @@ package pubsub
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/model"
@@ -167,8 +168,17 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 		}
 	}
 
+	// Encode the message as a CloudEvent, if configured
+	if ce := t.appCfg.CloudEvents; ce != nil {
+		attrs, data, err = utils.EncodeCloudEvent(ce, t.runtimeCfg.EncoreName, attrs, data, time.Now())
+		if err != nil {
+			return "", errs.B().Cause(err).Code(errs.InvalidArgument).Msgf("failed to encode message as CloudEvent for topic %s", t.runtimeCfg.EncoreName).Err()
+		}
+	}
+
 	// Start the trace span
 	curr := t.mgr.rt.Current()
+	curr.Req.RecordPublish(t.runtimeCfg.EncoreName)
 	var startEventID trace2.EventID
 	if curr.Req != nil && curr.Trace != nil {
 		desc := &model.PubSubTopicDesc{
@@ -208,6 +218,9 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 			Err:       err,
 		})
 	}
+	if curr.Req != nil {
+		curr.Req.Debug.Publish(t.runtimeCfg.EncoreName, id, data, err)
+	}
 
 	if err != nil {
 		return "", errs.B().Cause(err).Code(errs.Unavailable).Msgf("failed to publish message to %s", t.runtimeCfg.EncoreName).Err()
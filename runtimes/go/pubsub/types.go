@@ -110,3 +110,13 @@ const (
 )
 
 type TopicConfig = types.TopicConfig
+
+type CloudEventsConfig = types.CloudEventsConfig
+
+type CloudEventsMode = types.CloudEventsMode
+
+const (
+	CloudEventsBinary = types.CloudEventsBinary
+
+	CloudEventsStructured = types.CloudEventsStructured
+)
@@ -2,6 +2,7 @@ package encore
 
 import (
 	"net/http"
+	"net/netip"
 	"reflect"
 	"slices"
 	"time"
@@ -62,6 +63,14 @@ type Request struct {
 	// This behavior may change in the future.
 	Headers http.Header
 
+	// Client contains metadata about the client that made the API call,
+	// as determined by the API gateway. It is also set for service-to-service
+	// calls made while handling the client's request.
+	//
+	// It is nil unless the gateway is configured to enrich requests
+	// with client metadata.
+	Client *ClientInfo
+
 	// PubSubMessage specific parameters.
 	// Message contains information about the PubSub message,
 	Message *MessageData
@@ -115,6 +124,42 @@ type MessageData struct {
 	DeliveryAttempt int
 }
 
+// ClientInfo describes the client that made an API call.
+type ClientInfo struct {
+	// IP is the IP address of the client.
+	IP netip.Addr
+
+	// Geo is the location of the client, looked up from its IP address
+	// in the configured GeoIP database. It is nil if no database is
+	// configured or the database has no location for the address.
+	Geo *GeoInfo
+
+	// UserAgent is the client's parsed User-Agent header.
+	UserAgent UserAgent
+}
+
+// GeoInfo describes the location of a client.
+// Fields are empty if the GeoIP database has no value for them.
+type GeoInfo struct {
+	CountryCode string  // ISO 3166-1 alpha-2 country code, such as "SE"
+	Country     string  // English name of the country
+	Region      string  // English name of the region, such as a state or province
+	City        string  // English name of the city
+	Latitude    float64 // Approximate latitude
+	Longitude   float64 // Approximate longitude
+	TimeZone    string  // IANA time zone, such as "Europe/Stockholm"
+}
+
+// UserAgent describes a parsed User-Agent header.
+// Fields are empty if they could not be determined.
+type UserAgent struct {
+	Raw            string // the User-Agent header value
+	Browser        string // such as "Chrome" or "Safari"
+	BrowserVersion string // such as "124.0.6367.88"
+	OS             string // such as "macOS" or "Android"
+	Device         string // "desktop", "mobile", "tablet" or "bot"
+}
+
 // RequestType describes how the currently running code was triggered
 type RequestType string
 
@@ -185,6 +230,16 @@ func (mgr *Manager) CurrentRequest() *Request {
 		}
 		result.Method = data.HTTPMethod
 		result.Headers = data.RequestHeaders
+		if c := data.Client; c != nil {
+			result.Client = &ClientInfo{
+				IP:        c.IP,
+				UserAgent: UserAgent(c.UserAgent),
+			}
+			if c.Geo != nil {
+				geo := GeoInfo(*c.Geo)
+				result.Client.Geo = &geo
+			}
+		}
 
 		result.API = &APIDesc{
 			RequestType:  desc.RequestType,
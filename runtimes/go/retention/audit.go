@@ -0,0 +1,41 @@
+package retention
+
+import (
+	"time"
+)
+
+// AuditKind is the kind of operation an audit record describes.
+type AuditKind string
+
+const (
+	// AuditPolicy is the enforcement of a retention policy.
+	AuditPolicy AuditKind = "policy"
+
+	// AuditSubject is the deletion of a data subject's data by a subject deletion handler.
+	AuditSubject AuditKind = "subject"
+)
+
+// AuditRecord describes a policy enforcement, or a call to a subject deletion handler.
+type AuditRecord struct {
+	Kind AuditKind
+
+	// Name is the name of the policy or subject deletion handler.
+	Name string
+
+	// SubjectID is the id of the data subject, for subject deletions.
+	SubjectID string
+
+	// Deleted is the number of rows or other items deleted,
+	// or that would have been deleted for a dry run.
+	Deleted int64
+
+	// DryRun is whether nothing was actually deleted.
+	DryRun bool
+
+	Start    time.Time
+	Duration time.Duration
+
+	// Err is the error the operation failed with, if any.
+	// Items may have been deleted even if it failed.
+	Err error
+}
@@ -0,0 +1,232 @@
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+// initialDelay is how long after startup policies are first enforced,
+// at most, to not slow down the startup of the app.
+const initialDelay = time.Minute
+
+type Manager struct {
+	ctx        context.Context
+	cancelCtx  func()
+	runtime    *config.Runtime
+	rootLogger zerolog.Logger
+
+	mu       sync.Mutex
+	policies map[string]*Policy
+	handlers map[string]SubjectHandler
+	auditors []func(AuditRecord)
+}
+
+func NewManager(runtime *config.Runtime, rootLogger zerolog.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		ctx:        ctx,
+		cancelCtx:  cancel,
+		runtime:    runtime,
+		rootLogger: rootLogger,
+		policies:   make(map[string]*Policy),
+		handlers:   make(map[string]SubjectHandler),
+	}
+}
+
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	// Once it's time to force-close tasks, stop enforcing policies.
+	go func() {
+		<-p.ForceCloseTasks.Done()
+		mgr.cancelCtx()
+	}()
+	return nil
+}
+
+func newTablePolicy(mgr *Manager, name string, cfg TablePolicy) *Policy {
+	if err := cfg.validate(); err != nil {
+		mgr.rootLogger.Fatal().Msgf("invalid retention policy %s: %v", name, err)
+	}
+	p := &Policy{mgr: mgr, name: name, cfg: cfg}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if _, ok := mgr.policies[name]; ok {
+		mgr.rootLogger.Fatal().Msgf("duplicate retention policy %s", name)
+	}
+	mgr.policies[name] = p
+
+	// Policies are enforced by tests explicitly, if at all.
+	if mgr.runtime.EnvType != "test" {
+		go mgr.schedule(p)
+	}
+	return p
+}
+
+// schedule enforces p periodically until the manager shuts down.
+func (mgr *Manager) schedule(p *Policy) {
+	timer := time.NewTimer(min(initialDelay, p.cfg.Every))
+	defer timer.Stop()
+	for {
+		select {
+		case <-mgr.ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		// Policies on databases this process doesn't use are
+		// enforced by the processes that do.
+		if !p.cfg.DB.IsConfigured() {
+			return
+		}
+		// Errors are logged by the audit.
+		_, _ = p.Enforce(mgr.ctx)
+		timer.Reset(p.cfg.Every)
+	}
+}
+
+func (mgr *Manager) handleSubjectDeletion(name string, h SubjectHandler) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if _, ok := mgr.handlers[name]; ok {
+		mgr.rootLogger.Fatal().Msgf("duplicate subject deletion handler %s", name)
+	}
+	mgr.handlers[name] = h
+}
+
+func (mgr *Manager) onAudit(fn func(AuditRecord)) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.auditors = append(mgr.auditors, fn)
+}
+
+// deleteSubject calls all subject deletion handlers with req.
+func (mgr *Manager) deleteSubject(ctx context.Context, req SubjectRequest) (*SubjectReport, error) {
+	if req.SubjectID == "" {
+		return nil, errors.New("retention: empty subject id")
+	}
+
+	mgr.mu.Lock()
+	names := slices.Sorted(maps.Keys(mgr.handlers))
+	handlers := maps.Clone(mgr.handlers)
+	mgr.mu.Unlock()
+
+	report := &SubjectReport{SubjectID: req.SubjectID}
+	var errs []error
+	for _, name := range names {
+		rec := mgr.startAudit(AuditSubject, name, req.SubjectID, req.DryRun)
+		n, err := handlers[name](ctx, req)
+		mgr.finishAudit(rec, n, err)
+
+		report.Results = append(report.Results, SubjectResult{Handler: name, Deleted: n, Err: err})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return report, fmt.Errorf("retention: delete subject %s: %w", req.SubjectID, errors.Join(errs...))
+	}
+	return report, nil
+}
+
+func (mgr *Manager) startAudit(kind AuditKind, name, subjectID string, dryRun bool) *AuditRecord {
+	return &AuditRecord{Kind: kind, Name: name, SubjectID: subjectID, DryRun: dryRun, Start: time.Now()}
+}
+
+// finishAudit completes the audit record rec, logs it and passes it to the auditors.
+func (mgr *Manager) finishAudit(rec *AuditRecord, deleted int64, err error) {
+	rec.Duration = time.Since(rec.Start)
+	rec.Deleted, rec.Err = deleted, err
+
+	ev := mgr.rootLogger.Info()
+	if err != nil {
+		ev = mgr.rootLogger.Error().Err(err)
+	}
+	ev = ev.Str("kind", string(rec.Kind)).Str("name", rec.Name).
+		Int64("deleted", rec.Deleted).Bool("dry_run", rec.DryRun).Dur("duration", rec.Duration)
+	if rec.SubjectID != "" {
+		ev = ev.Str("subject_id", rec.SubjectID)
+	}
+	ev.Msg("retention audit")
+
+	mgr.mu.Lock()
+	auditors := slices.Clone(mgr.auditors)
+	mgr.mu.Unlock()
+	for _, fn := range auditors {
+		fn(*rec)
+	}
+}
+
+// DryRunResponse is the response of the dry run endpoint.
+type DryRunResponse struct {
+	Policies []PolicyDryRun  `json:"policies"`
+	Subjects []SubjectDryRun `json:"subjects"`
+}
+
+type PolicyDryRun struct {
+	Name        string `json:"name"`
+	Table       string `json:"table"`
+	MaxAge      string `json:"max_age"`
+	WouldDelete int64  `json:"would_delete"`
+	Error       string `json:"error,omitempty"`
+}
+
+type SubjectDryRun struct {
+	Handler     string `json:"handler"`
+	WouldDelete int64  `json:"would_delete"`
+	Error       string `json:"error,omitempty"`
+}
+
+// handleDryRun reports what the policies would delete if enforced now,
+// and, given a "subject" query parameter, what deleting the subject would delete.
+// Policies on databases this process doesn't use are omitted.
+func (mgr *Manager) handleDryRun(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	mgr.mu.Lock()
+	policies := slices.SortedFunc(maps.Values(mgr.policies), func(a, b *Policy) int {
+		return strings.Compare(a.name, b.name)
+	})
+	mgr.mu.Unlock()
+
+	resp := DryRunResponse{Policies: []PolicyDryRun{}, Subjects: []SubjectDryRun{}}
+	for _, p := range policies {
+		if !p.cfg.DB.IsConfigured() {
+			continue
+		}
+		res := PolicyDryRun{Name: p.name, Table: p.cfg.Table, MaxAge: p.cfg.MaxAge.String()}
+		n, err := p.DryRun(ctx)
+		res.WouldDelete = n
+		if err != nil {
+			res.Error = err.Error()
+		}
+		resp.Policies = append(resp.Policies, res)
+	}
+
+	if subject := req.URL.Query().Get("subject"); subject != "" {
+		// The handler errors are reported in the report.
+		report, _ := mgr.deleteSubject(ctx, SubjectRequest{SubjectID: subject, DryRun: true})
+		for _, r := range report.Results {
+			res := SubjectDryRun{Handler: r.Handler, WouldDelete: r.Deleted}
+			if r.Err != nil {
+				res.Error = r.Err.Error()
+			}
+			resp.Subjects = append(resp.Subjects, res)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,9 @@
+// Package retention provides a framework for data retention and deletion:
+// retention policies that delete rows from SQL tables once they're too old,
+// and handlers deleting the data about a data subject, such as for GDPR requests.
+//
+// Retention policies are enforced periodically by the Encore runtime,
+// and every enforcement and subject deletion is audited.
+//
+// For more information see https://encore.dev/docs/primitives/data-retention
+package retention
@@ -0,0 +1,175 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"encore.dev/storage/sqldb"
+)
+
+const (
+	// defaultEvery is how often policies are enforced by default.
+	defaultEvery = time.Hour
+
+	// defaultBatchSize is the default number of rows deleted per statement.
+	defaultBatchSize = 1000
+)
+
+// TablePolicy configures a retention policy deleting the rows of a SQL table
+// once they're older than MaxAge.
+type TablePolicy struct {
+	// DB is the database containing the table.
+	DB *sqldb.Database
+
+	// Table is the name of the table, optionally qualified by its schema
+	// as in "archive.events".
+	Table string
+
+	// TimeColumn is the name of the timestamp column
+	// the age of rows is measured from.
+	TimeColumn string
+
+	// MaxAge is how long rows are kept.
+	MaxAge time.Duration
+
+	// Where is an optional SQL condition restricting which rows are deleted,
+	// such as "status = 'closed'".
+	Where string
+
+	// Every is how often the policy is enforced.
+	// If zero it defaults to an hour.
+	Every time.Duration
+
+	// BatchSize is the maximum number of rows deleted per statement,
+	// to avoid holding locks for long.
+	// If zero it defaults to 1000.
+	BatchSize int
+}
+
+// Policy is a retention policy.
+//
+// See NewTablePolicy for more information on how to declare a Policy.
+type Policy struct {
+	mgr  *Manager
+	name string
+	cfg  TablePolicy
+}
+
+// Name returns the name of the policy.
+func (p *Policy) Name() string {
+	return p.name
+}
+
+// Enforce deletes the rows the policy no longer retains,
+// returning the number of rows deleted.
+//
+// Policies are enforced periodically by the Encore runtime;
+// Enforce can be used to enforce a policy immediately.
+func (p *Policy) Enforce(ctx context.Context) (deleted int64, err error) {
+	rec := p.mgr.startAudit(AuditPolicy, p.name, "", false)
+	defer func() { p.mgr.finishAudit(rec, deleted, err) }()
+
+	cutoff := time.Now().Add(-p.cfg.MaxAge)
+	query := deleteQuery(p.cfg)
+	for {
+		n, ok, err := p.deleteBatch(ctx, query, cutoff)
+		deleted += n
+		if err != nil {
+			return deleted, fmt.Errorf("retention: enforce %s: %w", p.name, err)
+		} else if !ok || n < int64(p.cfg.BatchSize) {
+			// Either another instance is enforcing the policy, or we're done.
+			return deleted, nil
+		}
+	}
+}
+
+// deleteBatch deletes a batch of rows older than cutoff.
+// It reports ok=false if another process holds the policy's lock.
+func (p *Policy) deleteBatch(ctx context.Context, query string, cutoff time.Time) (deleted int64, ok bool, err error) {
+	tx, err := p.cfg.DB.Begin(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Take a transaction-level lock so that instances of the app
+	// don't enforce the same policy at the same time.
+	if err := tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock(hashtext($1))", "encore.retention:"+p.name).Scan(&ok); err != nil || !ok {
+		return 0, false, err
+	}
+	res, err := tx.Exec(ctx, query, cutoff, p.cfg.BatchSize)
+	if err != nil {
+		return 0, true, err
+	}
+	return res.RowsAffected(), true, tx.Commit()
+}
+
+// DryRun returns the number of rows Enforce would delete, without deleting them.
+func (p *Policy) DryRun(ctx context.Context) (n int64, err error) {
+	rec := p.mgr.startAudit(AuditPolicy, p.name, "", true)
+	defer func() { p.mgr.finishAudit(rec, n, err) }()
+
+	err = p.cfg.DB.QueryRow(ctx, countQuery(p.cfg), time.Now().Add(-p.cfg.MaxAge)).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("retention: dry run %s: %w", p.name, err)
+	}
+	return n, nil
+}
+
+// validate reports an error if the policy is misconfigured,
+// and sets the defaults of the unset fields.
+func (cfg *TablePolicy) validate() error {
+	switch {
+	case cfg.DB == nil:
+		return errors.New("no database")
+	case cfg.Table == "":
+		return errors.New("no table")
+	case cfg.TimeColumn == "":
+		return errors.New("no time column")
+	case cfg.MaxAge <= 0:
+		return errors.New("max age must be positive")
+	case cfg.Every < 0 || cfg.BatchSize < 0:
+		return errors.New("every and batch size must not be negative")
+	}
+	if cfg.Every == 0 {
+		cfg.Every = defaultEvery
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	return nil
+}
+
+// condition returns the SQL condition matching the expired rows,
+// given the cutoff time as $1.
+func condition(cfg TablePolicy) string {
+	cond := pgx.Identifier{cfg.TimeColumn}.Sanitize() + " < $1"
+	if cfg.Where != "" {
+		cond += " AND (" + cfg.Where + ")"
+	}
+	return cond
+}
+
+// tableName returns the sanitized name of the table of the policy.
+func tableName(cfg TablePolicy) string {
+	return pgx.Identifier(strings.Split(cfg.Table, ".")).Sanitize()
+}
+
+// deleteQuery returns the query deleting a batch of expired rows,
+// given the cutoff time as $1 and the batch size as $2.
+func deleteQuery(cfg TablePolicy) string {
+	table := tableName(cfg)
+	return "DELETE FROM " + table + " WHERE ctid IN (SELECT ctid FROM " + table +
+		" WHERE " + condition(cfg) + " LIMIT $2)"
+}
+
+// countQuery returns the query counting the expired rows,
+// given the cutoff time as $1.
+func countQuery(cfg TablePolicy) string {
+	return "SELECT count(*) FROM " + tableName(cfg) + " WHERE " + condition(cfg)
+}
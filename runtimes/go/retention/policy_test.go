@@ -0,0 +1,84 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/sqldb"
+)
+
+func TestQueries(t *testing.T) {
+	cfg := TablePolicy{Table: "archive.events", TimeColumn: "created_at", Where: "status = 'closed'"}
+	if got, want := deleteQuery(cfg),
+		`DELETE FROM "archive"."events" WHERE ctid IN (SELECT ctid FROM "archive"."events" WHERE "created_at" < $1 AND (status = 'closed') LIMIT $2)`; got != want {
+		t.Errorf("deleteQuery = %s, want %s", got, want)
+	}
+
+	cfg = TablePolicy{Table: "sessions", TimeColumn: "created_at"}
+	if got, want := countQuery(cfg), `SELECT count(*) FROM "sessions" WHERE "created_at" < $1`; got != want {
+		t.Errorf("countQuery = %s, want %s", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	db := &sqldb.Database{}
+	tests := []struct {
+		cfg     TablePolicy
+		wantErr string
+	}{
+		{cfg: TablePolicy{DB: db, Table: "t", TimeColumn: "c", MaxAge: time.Hour}},
+		{cfg: TablePolicy{Table: "t", TimeColumn: "c", MaxAge: time.Hour}, wantErr: "no database"},
+		{cfg: TablePolicy{DB: db, TimeColumn: "c", MaxAge: time.Hour}, wantErr: "no table"},
+		{cfg: TablePolicy{DB: db, Table: "t", MaxAge: time.Hour}, wantErr: "no time column"},
+		{cfg: TablePolicy{DB: db, Table: "t", TimeColumn: "c"}, wantErr: "max age must be positive"},
+		{cfg: TablePolicy{DB: db, Table: "t", TimeColumn: "c", MaxAge: time.Hour, Every: -1}, wantErr: "every and batch size must not be negative"},
+	}
+	for _, test := range tests {
+		err := test.cfg.validate()
+		if test.wantErr == "" {
+			if err != nil {
+				t.Errorf("validate(%+v) = %v, want nil", test.cfg, err)
+			} else if test.cfg.Every != defaultEvery || test.cfg.BatchSize != defaultBatchSize {
+				t.Errorf("validate(%+v) did not set the defaults", test.cfg)
+			}
+		} else if err == nil || err.Error() != test.wantErr {
+			t.Errorf("validate(%+v) = %v, want %s", test.cfg, err, test.wantErr)
+		}
+	}
+}
+
+func TestDeleteSubject(t *testing.T) {
+	mgr := NewManager(&config.Runtime{EnvType: "test"}, zerolog.Nop())
+	var calls []string
+	mgr.handleSubjectDeletion("orders", func(ctx context.Context, req SubjectRequest) (int64, error) {
+		calls = append(calls, "orders")
+		return 3, nil
+	})
+	mgr.handleSubjectDeletion("accounts", func(ctx context.Context, req SubjectRequest) (int64, error) {
+		calls = append(calls, "accounts")
+		return 1, errors.New("boom")
+	})
+	var audits []AuditRecord
+	mgr.onAudit(func(rec AuditRecord) { audits = append(audits, rec) })
+
+	report, err := mgr.deleteSubject(context.Background(), SubjectRequest{SubjectID: "user1"})
+	if err == nil || err.Error() != "retention: delete subject user1: accounts: boom" {
+		t.Errorf("deleteSubject error = %v", err)
+	}
+
+	// All handlers are called, in order of name.
+	if len(calls) != 2 || calls[0] != "accounts" || calls[1] != "orders" {
+		t.Errorf("handlers called = %v, want [accounts orders]", calls)
+	}
+	if len(report.Results) != 2 || report.Results[1].Handler != "orders" || report.Results[1].Deleted != 3 {
+		t.Errorf("report = %+v", report)
+	}
+	if len(audits) != 2 || audits[0].Kind != AuditSubject || audits[0].SubjectID != "user1" || audits[0].Err == nil {
+		t.Errorf("audits = %+v", audits)
+	}
+}
@@ -0,0 +1,51 @@
+//go:build encore_app
+
+package retention
+
+import (
+	"context"
+)
+
+// NewTablePolicy declares a retention policy deleting the rows of a SQL table
+// once they're older than the policy's MaxAge.
+//
+// The Encore runtime enforces the policy periodically, as configured by Every.
+// It must be called when declaring a package level variable:
+//
+//	var _ = retention.NewTablePolicy("expire-sessions", retention.TablePolicy{
+//		DB:         db,
+//		Table:      "sessions",
+//		TimeColumn: "created_at",
+//		MaxAge:     30 * 24 * time.Hour,
+//	})
+//
+// The name must be unique within the application.
+func NewTablePolicy(name string, cfg TablePolicy) *Policy {
+	return newTablePolicy(Singleton, name, cfg)
+}
+
+// HandleSubjectDeletion registers a handler deleting the data about a data subject,
+// which is called by DeleteSubject.
+//
+// The name identifies the handler in audit records and must be unique
+// within the application.
+func HandleSubjectDeletion(name string, h SubjectHandler) {
+	Singleton.handleSubjectDeletion(name, h)
+}
+
+// DeleteSubject deletes the data about the data subject with the given id,
+// by calling every registered subject deletion handler.
+//
+// All handlers are called even if some of them fail,
+// in which case an error is returned along with the report.
+func DeleteSubject(ctx context.Context, subjectID string) (*SubjectReport, error) {
+	return Singleton.deleteSubject(ctx, SubjectRequest{SubjectID: subjectID})
+}
+
+// OnAudit registers fn to be called with the audit record of every
+// policy enforcement and subject deletion, for example to persist them.
+//
+// Audit records are logged regardless.
+func OnAudit(fn func(AuditRecord)) {
+	Singleton.onAudit(fn)
+}
@@ -0,0 +1,58 @@
+package retention
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"encore.dev/storage/sqldb"
+)
+
+// SubjectRequest is a request to delete the data about a data subject.
+type SubjectRequest struct {
+	// SubjectID is the id of the data subject, such as a user id.
+	SubjectID string
+
+	// DryRun, if true, requests the handler to not delete anything,
+	// only to report how much it would delete.
+	DryRun bool
+}
+
+// SubjectHandler deletes the data about a data subject.
+// It returns the number of rows or other items it deleted,
+// or would delete for a dry run.
+type SubjectHandler func(ctx context.Context, req SubjectRequest) (deleted int64, err error)
+
+// SubjectTable returns a SubjectHandler deleting the rows of a SQL table
+// whose column holds the id of the data subject.
+func SubjectTable(db *sqldb.Database, table, column string) SubjectHandler {
+	tbl := tableName(TablePolicy{Table: table})
+	cond := pgx.Identifier{column}.Sanitize() + " = $1"
+	return func(ctx context.Context, req SubjectRequest) (int64, error) {
+		if req.DryRun {
+			var n int64
+			err := db.QueryRow(ctx, "SELECT count(*) FROM "+tbl+" WHERE "+cond, req.SubjectID).Scan(&n)
+			return n, err
+		}
+		res, err := db.Exec(ctx, "DELETE FROM "+tbl+" WHERE "+cond, req.SubjectID)
+		if err != nil {
+			return 0, err
+		}
+		return res.RowsAffected(), nil
+	}
+}
+
+// SubjectReport reports the deletion of a data subject's data.
+type SubjectReport struct {
+	SubjectID string
+
+	// Results are the results of the subject deletion handlers, ordered by name.
+	Results []SubjectResult
+}
+
+// SubjectResult is the result of a subject deletion handler.
+type SubjectResult struct {
+	Handler string
+	Deleted int64
+	Err     error
+}
@@ -0,0 +1,19 @@
+//go:build encore_app
+
+package retention
+
+import (
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+//publicapigen:drop
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(appconf.Runtime, logging.RootLogger)
+	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	api.RegisterPlatformRoute("GET", "/retention/dry-run", Singleton.handleDryRun)
+}
@@ -2,10 +2,13 @@
 
 package cache
 
+import "encore.dev/appruntime/shared/startup"
+
 // NewCluster declares a new cache cluster.
 //
 // See https://encore.dev/docs/develop/caching for more information.
 func NewCluster(name string, cfg ClusterConfig) *Cluster {
+	defer startup.Singleton.Resource("cache cluster", name)()
 	return &Cluster{
 		cfg: cfg,
 		mgr: Singleton,
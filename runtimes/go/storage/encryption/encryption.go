@@ -0,0 +1,61 @@
+//go:build encore_app
+
+package encryption
+
+import (
+	"context"
+)
+
+// Encrypt encrypts data, such as an object storage payload,
+// with the application's encryption key.
+func Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	return Singleton.encryptBinary(ctx, plaintext)
+}
+
+// Decrypt decrypts data encrypted with Encrypt.
+func Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	return Singleton.decryptBinary(ctx, ciphertext)
+}
+
+// EncryptFields encrypts the fields tagged `encore:"encrypted"` of the struct
+// pointed to by v in place, including those of the structs it contains.
+// Fields that are already encrypted are left as is.
+//
+// Encrypted fields must be of type string, *string or []byte.
+//
+// Use it before writing the fields to database columns:
+//
+//	if err := encryption.EncryptFields(ctx, &user); err != nil {
+//		return err
+//	}
+//	_, err := db.Exec(ctx, "INSERT INTO users (id, email) VALUES ($1, $2)", user.ID, user.Email)
+//
+// Encrypted values read with encore.dev/storage/sqldb are decrypted transparently.
+func EncryptFields(ctx context.Context, v any) error {
+	return Singleton.encryptFields(ctx, v)
+}
+
+// DecryptFields decrypts the fields tagged `encore:"encrypted"` of the struct
+// pointed to by v in place, including those of the structs it contains.
+// Fields that aren't encrypted are left as is.
+func DecryptFields(ctx context.Context, v any) error {
+	return Singleton.decryptFields(ctx, v)
+}
+
+// MarshalJSON encodes the struct v as JSON with its encrypted fields encrypted.
+// It's called by the MarshalJSON methods Encore generates for structs with
+// encrypted fields.
+//
+//publicapigen:drop
+func MarshalJSON(v any) ([]byte, error) {
+	return Singleton.marshalJSON(v)
+}
+
+// UnmarshalJSON decodes the JSON encoding of the struct pointed to by v,
+// decrypting its encrypted fields. It's called by the UnmarshalJSON methods
+// Encore generates for structs with encrypted fields.
+//
+//publicapigen:drop
+func UnmarshalJSON(data []byte, v any) error {
+	return Singleton.unmarshalJSON(data, v)
+}
@@ -0,0 +1,188 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func newTestManager(t *testing.T, keys ...string) *Manager {
+	t.Helper()
+	cfg := &config.Runtime{}
+	for _, k := range keys {
+		cfg.EncryptionKeys = append(cfg.EncryptionKeys, &config.EncryptionKey{
+			Local: &config.LocalEncryptionKey{Key: k},
+		})
+	}
+	return NewManager(cfg, zerolog.Nop())
+}
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestBinary(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTestManager(t, testKey(1))
+
+	enc, err := mgr.encryptBinary(ctx, []byte("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isEncryptedBinary(enc) || bytes.Contains(enc, []byte("payload")) {
+		t.Fatalf("data not encrypted: %q", enc)
+	}
+	dec, err := mgr.decryptBinary(ctx, enc)
+	if err != nil {
+		t.Fatal(err)
+	} else if string(dec) != "payload" {
+		t.Fatalf("got %q, want %q", dec, "payload")
+	}
+
+	// Tampering with the data must be detected.
+	enc[len(enc)-1] ^= 1
+	if _, err := mgr.decryptBinary(ctx, enc); err == nil {
+		t.Fatal("decrypted tampered data")
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	old := newTestManager(t, testKey(1))
+	enc, err := old.encryptText(ctx, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A new primary key can still decrypt data encrypted with the old key.
+	rotated := newTestManager(t, testKey(2), testKey(1))
+	if dec, err := rotated.decryptText(ctx, enc); err != nil || dec != "secret" {
+		t.Fatalf("got %q, %v; want %q", dec, err, "secret")
+	}
+
+	// But not once the old key is removed.
+	removed := newTestManager(t, testKey(2))
+	if _, err := removed.decryptText(ctx, enc); err == nil || !strings.Contains(err.Error(), "unknown key") {
+		t.Fatalf("got err %v, want unknown key", err)
+	}
+}
+
+func TestNoKey(t *testing.T) {
+	mgr := newTestManager(t)
+	if _, err := mgr.encryptText(context.Background(), "secret"); err != errNoKey {
+		t.Fatalf("got err %v, want %v", err, errNoKey)
+	}
+}
+
+type address struct {
+	Street string `encore:"encrypted"`
+	City   string
+}
+
+type user struct {
+	ID       int
+	Email    string  `json:"email" encore:"encrypted"`
+	Phone    *string `encore:"encrypted"`
+	Avatar   []byte  `encore:"encrypted"`
+	Addresses []address
+}
+
+func TestFields(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTestManager(t, testKey(1))
+
+	phone := "555-1234"
+	u := &user{
+		ID:       1,
+		Email:    "jane@example.com",
+		Phone:    &phone,
+		Avatar:   []byte("png"),
+		Addresses: []address{{Street: "Main St", City: "Springfield"}},
+	}
+	if err := mgr.encryptFields(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	if !isEncryptedText(u.Email) || !isEncryptedText(*u.Phone) || !isEncryptedBinary(u.Avatar) ||
+		!isEncryptedText(u.Addresses[0].Street) || u.Addresses[0].City != "Springfield" {
+		t.Fatalf("fields not encrypted: %+v", u)
+	}
+	if phone != "555-1234" {
+		t.Fatalf("shared pointer was modified: %q", phone)
+	}
+
+	// Encrypting again leaves encrypted fields as is.
+	email := u.Email
+	if err := mgr.encryptFields(ctx, u); err != nil {
+		t.Fatal(err)
+	} else if u.Email != email {
+		t.Fatal("encrypted field encrypted twice")
+	}
+
+	if err := mgr.decryptFields(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	if u.Email != "jane@example.com" || *u.Phone != "555-1234" || string(u.Avatar) != "png" || u.Addresses[0].Street != "Main St" {
+		t.Fatalf("fields not decrypted: %+v", u)
+	}
+
+	if err := mgr.encryptFields(ctx, *u); err == nil {
+		t.Fatal("encrypted fields of a non-pointer")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	mgr := newTestManager(t, testKey(1))
+
+	in := user{ID: 1, Email: "jane@example.com"}
+	data, err := mgr.marshalJSON(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "jane@example.com") {
+		t.Fatalf("email not encrypted: %s", data)
+	}
+	if in.Email != "jane@example.com" {
+		t.Fatal("marshalling modified the value")
+	}
+
+	var out user
+	if err := mgr.unmarshalJSON(data, &out); err != nil {
+		t.Fatal(err)
+	} else if out.Email != "jane@example.com" || out.ID != 1 {
+		t.Fatalf("got %+v", out)
+	}
+
+	// Data written before the field was encrypted can still be read.
+	var legacy user
+	if err := mgr.unmarshalJSON([]byte(`{"ID": 2, "email": "john@example.com"}`), &legacy); err != nil {
+		t.Fatal(err)
+	} else if legacy.Email != "john@example.com" {
+		t.Fatalf("got %+v", legacy)
+	}
+	if _, err := json.Marshal(legacy); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDecryptScanned(t *testing.T) {
+	ctx := context.Background()
+	mgr := newTestManager(t, testKey(1))
+
+	email, _ := mgr.encryptText(ctx, "jane@example.com")
+	avatar, _ := mgr.encryptBinary(ctx, []byte("png"))
+	name := "Jane"
+	var id int
+	if err := mgr.decryptScanned(ctx, []any{&id, &name, &email, &avatar}); err != nil {
+		t.Fatal(err)
+	}
+	if name != "Jane" || email != "jane@example.com" || string(avatar) != "png" {
+		t.Fatalf("got %q, %q, %q", name, email, avatar)
+	}
+}
@@ -0,0 +1,89 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// Encrypted data is an envelope holding the wrapped data key along with
+// the data encrypted with it:
+//
+//	version (1 byte) | key id length (1 byte) | key id
+//	wrapped data key length (2 bytes) | wrapped data key
+//	nonce | ciphertext
+//
+// Everything before the nonce is the header, which is authenticated
+// as additional data. Encrypted binary data is the envelope prefixed
+// with binaryMagic, and encrypted text is textPrefix followed by the
+// base64-encoded envelope.
+const (
+	envelopeVersion = 1
+	binaryMagic     = "\x00ENC"
+	textPrefix      = "encore:enc:"
+)
+
+var errMalformed = errors.New("encryption: malformed encrypted data")
+
+// encodeHeader returns the envelope header for a data key.
+func encodeHeader(keyID string, wrapped []byte) []byte {
+	h := make([]byte, 0, 4+len(keyID)+len(wrapped))
+	h = append(h, envelopeVersion, byte(len(keyID)))
+	h = append(h, keyID...)
+	h = binary.BigEndian.AppendUint16(h, uint16(len(wrapped)))
+	return append(h, wrapped...)
+}
+
+// envelope is a decoded envelope.
+type envelope struct {
+	header  []byte
+	keyID   string
+	wrapped []byte
+	sealed  []byte // the nonce followed by the ciphertext
+}
+
+func decodeEnvelope(data []byte) (*envelope, error) {
+	if len(data) < 2 || data[0] != envelopeVersion {
+		return nil, errMalformed
+	}
+	n := int(data[1])
+	rest := data[2:]
+	if len(rest) < n+2 {
+		return nil, errMalformed
+	}
+	env := &envelope{keyID: string(rest[:n])}
+	rest = rest[n:]
+	m := int(binary.BigEndian.Uint16(rest))
+	rest = rest[2:]
+	if len(rest) < m {
+		return nil, errMalformed
+	}
+	env.wrapped = rest[:m]
+	env.sealed = rest[m:]
+	env.header = data[:len(data)-len(env.sealed)]
+	return env, nil
+}
+
+// isEncryptedBinary reports whether data is encrypted binary data.
+func isEncryptedBinary(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(binaryMagic))
+}
+
+// isEncryptedText reports whether s is encrypted text.
+func isEncryptedText(s string) bool {
+	return strings.HasPrefix(s, textPrefix)
+}
+
+func toText(env []byte) string {
+	return textPrefix + base64.StdEncoding.EncodeToString(env)
+}
+
+func fromText(s string) ([]byte, error) {
+	env, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, textPrefix))
+	if err != nil {
+		return nil, errMalformed
+	}
+	return env, nil
+}
@@ -0,0 +1,266 @@
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// isEncryptedField reports whether the struct field is tagged `encore:"encrypted"`.
+func isEncryptedField(f reflect.StructField) bool {
+	tag, ok := f.Tag.Lookup("encore")
+	return ok && slices.Contains(strings.Split(tag, ","), "encrypted")
+}
+
+// encryptBinary encrypts data into encrypted binary data.
+func (mgr *Manager) encryptBinary(ctx context.Context, data []byte) ([]byte, error) {
+	env, err := mgr.encrypt(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(binaryMagic), env...), nil
+}
+
+// decryptBinary decrypts encrypted binary data.
+func (mgr *Manager) decryptBinary(ctx context.Context, data []byte) ([]byte, error) {
+	if !isEncryptedBinary(data) {
+		return nil, fmt.Errorf("encryption: data is not encrypted")
+	}
+	return mgr.decrypt(ctx, data[len(binaryMagic):])
+}
+
+func (mgr *Manager) encryptText(ctx context.Context, s string) (string, error) {
+	env, err := mgr.encrypt(ctx, []byte(s))
+	if err != nil {
+		return "", err
+	}
+	return toText(env), nil
+}
+
+func (mgr *Manager) decryptText(ctx context.Context, s string) (string, error) {
+	env, err := fromText(s)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := mgr.decrypt(ctx, env)
+	return string(plaintext), err
+}
+
+// encryptField encrypts the value of an encrypted field, unless it's already encrypted.
+// Pointers are replaced rather than updated, as they may be shared.
+func (mgr *Manager) encryptField(ctx context.Context, f reflect.Value) error {
+	switch {
+	case f.Kind() == reflect.String:
+		if s := f.String(); !isEncryptedText(s) {
+			enc, err := mgr.encryptText(ctx, s)
+			if err != nil {
+				return err
+			}
+			f.SetString(enc)
+		}
+	case f.Kind() == reflect.Pointer && f.Type().Elem().Kind() == reflect.String:
+		if !f.IsNil() && !isEncryptedText(f.Elem().String()) {
+			enc, err := mgr.encryptText(ctx, f.Elem().String())
+			if err != nil {
+				return err
+			}
+			ptr := reflect.New(f.Type().Elem())
+			ptr.Elem().SetString(enc)
+			f.Set(ptr)
+		}
+	case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.Uint8:
+		if !f.IsNil() && !isEncryptedBinary(f.Bytes()) {
+			enc, err := mgr.encryptBinary(ctx, f.Bytes())
+			if err != nil {
+				return err
+			}
+			f.SetBytes(enc)
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", f.Type())
+	}
+	return nil
+}
+
+// decryptField decrypts the value of an encrypted field, if it's encrypted.
+// Values that aren't encrypted are left as is, so that data written before
+// a field was encrypted can still be read.
+func (mgr *Manager) decryptField(ctx context.Context, f reflect.Value) error {
+	switch {
+	case f.Kind() == reflect.String:
+		if s := f.String(); isEncryptedText(s) {
+			dec, err := mgr.decryptText(ctx, s)
+			if err != nil {
+				return err
+			}
+			f.SetString(dec)
+		}
+	case f.Kind() == reflect.Pointer && f.Type().Elem().Kind() == reflect.String:
+		if !f.IsNil() && isEncryptedText(f.Elem().String()) {
+			dec, err := mgr.decryptText(ctx, f.Elem().String())
+			if err != nil {
+				return err
+			}
+			ptr := reflect.New(f.Type().Elem())
+			ptr.Elem().SetString(dec)
+			f.Set(ptr)
+		}
+	case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.Uint8:
+		if isEncryptedBinary(f.Bytes()) {
+			dec, err := mgr.decryptBinary(ctx, f.Bytes())
+			if err != nil {
+				return err
+			}
+			f.SetBytes(dec)
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", f.Type())
+	}
+	return nil
+}
+
+// walkFields calls fn with the encrypted fields of the struct v.
+// If nested is true, it also calls fn with the encrypted fields of
+// the structs v contains, directly or through pointers, slices and arrays.
+func walkFields(v reflect.Value, nested bool, fn func(path string, f reflect.Value) error) error {
+	return walk(v, "", nested, fn)
+}
+
+func walk(v reflect.Value, path string, nested bool, fn func(path string, f reflect.Value) error) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			return walk(v.Elem(), path, nested, fn)
+		}
+	case reflect.Slice, reflect.Array:
+		switch v.Type().Elem().Kind() {
+		case reflect.Struct, reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Array:
+		default:
+			return nil // no structs to walk
+		}
+		if !nested {
+			return nil
+		}
+		for i := range v.Len() {
+			if err := walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i), nested, fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			fieldPath := sf.Name
+			if path != "" {
+				fieldPath = path + "." + sf.Name
+			}
+			if isEncryptedField(sf) {
+				if err := fn(fieldPath, v.Field(i)); err != nil {
+					return fmt.Errorf("encryption: field %s: %w", fieldPath, err)
+				}
+			} else if nested {
+				if err := walk(v.Field(i), fieldPath, nested, fn); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// encryptFields encrypts the encrypted fields of the struct pointed to by v, in place.
+func (mgr *Manager) encryptFields(ctx context.Context, v any) error {
+	rv, err := structPointer(v)
+	if err != nil {
+		return err
+	}
+	return walkFields(rv, true, func(_ string, f reflect.Value) error {
+		return mgr.encryptField(ctx, f)
+	})
+}
+
+// decryptFields decrypts the encrypted fields of the struct pointed to by v, in place.
+func (mgr *Manager) decryptFields(ctx context.Context, v any) error {
+	rv, err := structPointer(v)
+	if err != nil {
+		return err
+	}
+	return walkFields(rv, true, func(_ string, f reflect.Value) error {
+		return mgr.decryptField(ctx, f)
+	})
+}
+
+func structPointer(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("encryption: expected a non-nil pointer to a struct, got %T", v)
+	}
+	return rv.Elem(), nil
+}
+
+// marshalJSON encodes the struct v as JSON, with its encrypted fields encrypted.
+// Structs it contains are expected to encrypt their own fields when encoded.
+func (mgr *Manager) marshalJSON(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("encryption: expected a struct, got %T", v)
+	}
+	cp := reflect.New(rv.Type()).Elem()
+	cp.Set(rv)
+	err := walkFields(cp, false, func(_ string, f reflect.Value) error {
+		return mgr.encryptField(mgr.ctx, f)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(cp.Interface())
+}
+
+// unmarshalJSON decodes the JSON encoding of the struct pointed to by v,
+// decrypting its encrypted fields.
+func (mgr *Manager) unmarshalJSON(data []byte, v any) error {
+	rv, err := structPointer(v)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	return walkFields(rv, false, func(_ string, f reflect.Value) error {
+		return mgr.decryptField(mgr.ctx, f)
+	})
+}
+
+// decryptScanned decrypts the values of a database row scanned into dest
+// that are encrypted.
+func (mgr *Manager) decryptScanned(ctx context.Context, dest []any) error {
+	for i, d := range dest {
+		var err error
+		switch d := d.(type) {
+		case *string:
+			if isEncryptedText(*d) {
+				*d, err = mgr.decryptText(ctx, *d)
+			}
+		case **string:
+			if *d != nil && isEncryptedText(**d) {
+				var dec string
+				dec, err = mgr.decryptText(ctx, **d)
+				*d = &dec
+			}
+		case *[]byte:
+			if isEncryptedBinary(*d) {
+				*d, err = mgr.decryptBinary(ctx, *d)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("column %d: %w", i, err)
+		}
+	}
+	return nil
+}
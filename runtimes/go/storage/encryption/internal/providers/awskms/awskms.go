@@ -0,0 +1,142 @@
+// Package awskms implements encryption keys with AWS KMS.
+//
+// It calls the KMS JSON API directly, signing requests with the credentials
+// of the default AWS configuration.
+package awskms
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/encryption/internal/types"
+)
+
+type Manager struct {
+	ctx    context.Context
+	client *http.Client
+
+	cfgOnce sync.Once
+	awsCfg  aws.Config
+	cfgErr  error
+}
+
+func NewManager(ctx context.Context, client *http.Client) *Manager {
+	return &Manager{ctx: ctx, client: client}
+}
+
+func (mgr *Manager) ProviderName() string { return "aws_kms" }
+
+func (mgr *Manager) Matches(cfg *config.EncryptionKey) bool {
+	return cfg.AWSKMS != nil
+}
+
+func (mgr *Manager) NewKey(cfg *config.EncryptionKey) (types.KeyImpl, error) {
+	arn := cfg.AWSKMS.KeyARN
+	// The ARN has the form arn:aws:kms:<region>:<account>:key/<id>.
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[2] != "kms" || parts[3] == "" {
+		return nil, fmt.Errorf("invalid KMS key ARN %q", arn)
+	}
+	return &key{
+		mgr:      mgr,
+		arn:      arn,
+		region:   parts[3],
+		endpoint: "https://kms." + parts[3] + ".amazonaws.com/",
+	}, nil
+}
+
+// credentials returns the credentials of the default AWS configuration.
+func (mgr *Manager) credentials() (aws.CredentialsProvider, error) {
+	mgr.cfgOnce.Do(func() {
+		mgr.awsCfg, mgr.cfgErr = awsConfig.LoadDefaultConfig(mgr.ctx)
+	})
+	if mgr.cfgErr != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", mgr.cfgErr)
+	}
+	return mgr.awsCfg.Credentials, nil
+}
+
+type key struct {
+	mgr      *Manager
+	arn      string
+	region   string
+	endpoint string
+}
+
+func (k *key) ID() string { return k.arn }
+
+func (k *key) NewDataKey(ctx context.Context) (dataKey, wrapped []byte, err error) {
+	var resp struct {
+		CiphertextBlob []byte
+		Plaintext      []byte
+	}
+	err = k.call(ctx, "GenerateDataKey", map[string]any{"KeyId": k.arn, "KeySpec": "AES_256"}, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Plaintext, resp.CiphertextBlob, nil
+}
+
+func (k *key) UnwrapDataKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	var resp struct {
+		Plaintext []byte
+	}
+	err := k.call(ctx, "Decrypt", map[string]any{"KeyId": k.arn, "CiphertextBlob": wrapped}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+// call calls the KMS operation op, encoding the request body and decoding the response into out.
+func (k *key) call(ctx context.Context, op string, body, out any) error {
+	creds, err := k.mgr.credentials()
+	if err != nil {
+		return err
+	}
+	c, err := creds.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("aws kms: retrieve credentials: %w", err)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+op)
+
+	sum := sha256.Sum256(data)
+	if err := v4.NewSigner().SignHTTP(ctx, c, req, hex.EncodeToString(sum[:]), "kms", k.region, time.Now()); err != nil {
+		return fmt.Errorf("aws kms: sign request: %w", err)
+	}
+
+	resp, err := k.mgr.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("aws kms: %s: %w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("aws kms: %s: unexpected status %d: %s", op, resp.StatusCode, msg)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,71 @@
+package awskms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsCreds "github.com/aws/aws-sdk-go-v2/credentials"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestDataKeys(t *testing.T) {
+	const arn = "arn:aws:kms:eu-west-1:123456789012:key/abcd"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/kms/aws4_request") {
+			t.Errorf("request not signed for kms in eu-west-1: %q", r.Header.Get("Authorization"))
+		}
+		var req struct {
+			KeyId          string
+			CiphertextBlob []byte
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.KeyId != arn {
+			t.Errorf("bad request body: %+v, %v", req, err)
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GenerateDataKey":
+			_ = json.NewEncoder(w).Encode(map[string]any{"Plaintext": []byte("key"), "CiphertextBlob": []byte("wrapped")})
+		case "TrentService.Decrypt":
+			if string(req.CiphertextBlob) != "wrapped" {
+				t.Errorf("got ciphertext %q", req.CiphertextBlob)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"Plaintext": []byte("key")})
+		default:
+			http.Error(w, "unknown operation", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	mgr := NewManager(context.Background(), srv.Client())
+	mgr.cfgOnce.Do(func() {
+		mgr.awsCfg = aws.Config{Credentials: awsCreds.NewStaticCredentialsProvider("id", "secret", "")}
+	})
+	impl, err := mgr.NewKey(&config.EncryptionKey{AWSKMS: &config.AWSKMSKey{KeyARN: arn}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := impl.(*key)
+	k.endpoint = srv.URL
+
+	ctx := context.Background()
+	plain, wrapped, err := k.NewDataKey(ctx)
+	if err != nil {
+		t.Fatal(err)
+	} else if string(plain) != "key" || string(wrapped) != "wrapped" {
+		t.Fatalf("got %q, %q", plain, wrapped)
+	}
+	if plain, err := k.UnwrapDataKey(ctx, wrapped); err != nil {
+		t.Fatal(err)
+	} else if string(plain) != "key" {
+		t.Fatalf("got %q", plain)
+	}
+
+	if _, err := mgr.NewKey(&config.EncryptionKey{AWSKMS: &config.AWSKMSKey{KeyARN: "abcd"}}); err == nil {
+		t.Fatal("accepted invalid ARN")
+	}
+}
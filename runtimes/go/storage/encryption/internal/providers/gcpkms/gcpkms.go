@@ -0,0 +1,125 @@
+// Package gcpkms implements encryption keys with GCP Cloud KMS.
+//
+// Cloud KMS doesn't generate data keys, so they're generated locally
+// and encrypted with the Cloud KMS key.
+package gcpkms
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2/google"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/encryption/internal/types"
+)
+
+const scope = "https://www.googleapis.com/auth/cloudkms"
+
+type Manager struct {
+	ctx context.Context
+
+	clientOnce sync.Once
+	client     *http.Client
+	clientErr  error
+}
+
+func NewManager(ctx context.Context) *Manager {
+	return &Manager{ctx: ctx}
+}
+
+func (mgr *Manager) ProviderName() string { return "gcp_kms" }
+
+func (mgr *Manager) Matches(cfg *config.EncryptionKey) bool {
+	return cfg.GCPKMS != nil
+}
+
+func (mgr *Manager) NewKey(cfg *config.EncryptionKey) (types.KeyImpl, error) {
+	name := cfg.GCPKMS.KeyName
+	if !strings.HasPrefix(name, "projects/") || !strings.Contains(name, "/cryptoKeys/") {
+		return nil, fmt.Errorf("invalid Cloud KMS key name %q", name)
+	}
+	return &key{
+		client:  mgr.httpClient,
+		name:    name,
+		baseURL: "https://cloudkms.googleapis.com/v1/",
+	}, nil
+}
+
+// httpClient returns a client authenticating with the application default credentials.
+func (mgr *Manager) httpClient() (*http.Client, error) {
+	mgr.clientOnce.Do(func() {
+		mgr.client, mgr.clientErr = google.DefaultClient(mgr.ctx, scope)
+	})
+	if mgr.clientErr != nil {
+		return nil, fmt.Errorf("unable to find GCP credentials: %w", mgr.clientErr)
+	}
+	return mgr.client, nil
+}
+
+type key struct {
+	client  func() (*http.Client, error)
+	name    string
+	baseURL string
+}
+
+func (k *key) ID() string { return k.name }
+
+func (k *key) NewDataKey(ctx context.Context) (dataKey, wrapped []byte, err error) {
+	dataKey = make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, err
+	}
+	var resp struct {
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	if err := k.call(ctx, "encrypt", map[string]any{"plaintext": dataKey}, &resp); err != nil {
+		return nil, nil, err
+	}
+	return dataKey, resp.Ciphertext, nil
+}
+
+func (k *key) UnwrapDataKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	var resp struct {
+		Plaintext []byte `json:"plaintext"`
+	}
+	if err := k.call(ctx, "decrypt", map[string]any{"ciphertext": wrapped}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+// call calls the Cloud KMS method of the key, encoding the request body and decoding the response into out.
+func (k *key) call(ctx context.Context, method string, body, out any) error {
+	client, err := k.client()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.baseURL+k.name+":"+method, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gcp kms: %s: %w", method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("gcp kms: %s: unexpected status %d: %s", method, resp.StatusCode, msg)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,86 @@
+// Package local implements encryption keys held by the application itself.
+package local
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/encryption/internal/types"
+)
+
+type Manager struct{}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+func (mgr *Manager) ProviderName() string { return "local" }
+
+func (mgr *Manager) Matches(cfg *config.EncryptionKey) bool {
+	return cfg.Local != nil
+}
+
+func (mgr *Manager) NewKey(cfg *config.EncryptionKey) (types.KeyImpl, error) {
+	key, err := base64.StdEncoding.DecodeString(cfg.Local.Key)
+	if err != nil {
+		return nil, fmt.Errorf("decode local key: %w", err)
+	}
+	return NewKey(key)
+}
+
+// NewKey returns a key wrapping data keys with the given 256-bit AES key.
+func NewKey(key []byte) (types.KeyImpl, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("local key must be 256 bits, got %d", len(key)*8)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Identify the key by its fingerprint, which doesn't reveal it.
+	sum := sha256.Sum256(key)
+	return &localKey{id: "local:" + hex.EncodeToString(sum[:8]), aead: aead}, nil
+}
+
+type localKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+func (k *localKey) ID() string { return k.id }
+
+func (k *localKey) NewDataKey(ctx context.Context) (key, wrapped []byte, err error) {
+	key = make([]byte, 32)
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, err
+	} else if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	return key, k.aead.Seal(nonce, nonce, key, nil), nil
+}
+
+func (k *localKey) UnwrapDataKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	n := k.aead.NonceSize()
+	if len(wrapped) < n {
+		return nil, errors.New("wrapped data key too short")
+	}
+	key, err := k.aead.Open(nil, wrapped[:n], wrapped[n:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,19 @@
+package types
+
+import (
+	"context"
+)
+
+// KeyImpl is a key encryption key, which encrypts ("wraps") the data keys
+// that encrypt the data.
+type KeyImpl interface {
+	// ID identifies the key in the encrypted data,
+	// so that it can be decrypted after the key is rotated.
+	ID() string
+
+	// NewDataKey returns a new random 256-bit data key and its wrapped form.
+	NewDataKey(ctx context.Context) (key, wrapped []byte, err error)
+
+	// UnwrapDataKey returns the data key of its wrapped form.
+	UnwrapDataKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
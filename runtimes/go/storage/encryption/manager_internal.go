@@ -0,0 +1,212 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/shutdown"
+	"encore.dev/storage/encryption/internal/types"
+)
+
+const (
+	// dataKeyMaxAge and dataKeyMaxUses limit how long and how many times
+	// a data key is used to encrypt data before a new one is generated.
+	dataKeyMaxAge  = time.Hour
+	dataKeyMaxUses = 1 << 20
+
+	// maxCachedDataKeys is the maximum number of unwrapped data keys
+	// cached for decryption.
+	maxCachedDataKeys = 1024
+)
+
+var errNoKey = errors.New("encryption: no encryption key configured")
+
+type Manager struct {
+	ctx        context.Context
+	cancelCtx  func()
+	runtime    *config.Runtime
+	rootLogger zerolog.Logger
+	keys       []types.KeyImpl // the first key encrypts new data
+
+	mu      sync.Mutex
+	current *dataKey               // the data key encrypting new data, if any
+	cache   map[string]cipher.AEAD // unwrapped data keys, keyed by envelope header
+}
+
+// dataKey is a data key encrypting new data.
+type dataKey struct {
+	aead    cipher.AEAD
+	header  []byte
+	expires time.Time
+	uses    int
+}
+
+func NewManager(runtime *config.Runtime, rootLogger zerolog.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := &Manager{
+		ctx:        ctx,
+		cancelCtx:  cancel,
+		runtime:    runtime,
+		rootLogger: rootLogger,
+		cache:      make(map[string]cipher.AEAD),
+	}
+
+	var providers []provider
+	for _, p := range providerRegistry {
+		providers = append(providers, p(mgr.ctx, mgr.runtime))
+	}
+
+KeyLoop:
+	for i, cfg := range runtime.EncryptionKeys {
+		tried := make([]string, 0, len(providers))
+		for _, p := range providers {
+			if p.Matches(cfg) {
+				key, err := p.NewKey(cfg)
+				if err != nil {
+					rootLogger.Fatal().Err(err).Msgf("invalid encryption key[%d]", i)
+				}
+				mgr.keys = append(mgr.keys, key)
+				continue KeyLoop
+			}
+			tried = append(tried, p.ProviderName())
+		}
+		rootLogger.Fatal().Msgf("unsupported encryption key provider for key[%d], tried: %v", i, tried)
+	}
+
+	return mgr
+}
+
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	// Once it's time to force-close tasks, cancel the base context.
+	go func() {
+		<-p.ForceCloseTasks.Done()
+		mgr.cancelCtx()
+	}()
+
+	return nil
+}
+
+// encrypt returns the envelope of plaintext, encrypted with the current data key.
+func (mgr *Manager) encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	dk, err := mgr.dataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, dk.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	env := append([]byte(nil), dk.header...)
+	env = append(env, nonce...)
+	return dk.aead.Seal(env, nonce, plaintext, dk.header), nil
+}
+
+// decrypt returns the plaintext of an envelope.
+func (mgr *Manager) decrypt(ctx context.Context, data []byte) ([]byte, error) {
+	env, err := decodeEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := mgr.unwrap(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	n := aead.NonceSize()
+	if len(env.sealed) < n {
+		return nil, errMalformed
+	}
+	plaintext, err := aead.Open(nil, env.sealed[:n], env.sealed[n:], env.header)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+// dataKey returns the data key to encrypt new data with,
+// generating a new one if the current one has been used enough.
+func (mgr *Manager) dataKey(ctx context.Context) (*dataKey, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if dk := mgr.current; dk != nil && dk.uses < dataKeyMaxUses && time.Now().Before(dk.expires) {
+		dk.uses++
+		return dk, nil
+	}
+
+	if len(mgr.keys) == 0 {
+		return nil, errNoKey
+	}
+	key := mgr.keys[0]
+	plain, wrapped, err := key.NewDataKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: generate data key: %w", err)
+	}
+	aead, err := newAEAD(plain)
+	if err != nil {
+		return nil, err
+	}
+	mgr.current = &dataKey{
+		aead:    aead,
+		header:  encodeHeader(key.ID(), wrapped),
+		expires: time.Now().Add(dataKeyMaxAge),
+		uses:    1,
+	}
+	if len(mgr.cache) >= maxCachedDataKeys {
+		clear(mgr.cache)
+	}
+	mgr.cache[string(mgr.current.header)] = aead
+	return mgr.current, nil
+}
+
+// unwrap returns the data key of an envelope, unwrapping it with its key if it's not cached.
+func (mgr *Manager) unwrap(ctx context.Context, env *envelope) (cipher.AEAD, error) {
+	mgr.mu.Lock()
+	aead, ok := mgr.cache[string(env.header)]
+	mgr.mu.Unlock()
+	if ok {
+		return aead, nil
+	}
+
+	var key types.KeyImpl
+	for _, k := range mgr.keys {
+		if k.ID() == env.keyID {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("encryption: data encrypted with unknown key %q", env.keyID)
+	}
+	plain, err := key.UnwrapDataKey(ctx, env.wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: unwrap data key: %w", err)
+	}
+	aead, err = newAEAD(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.mu.Lock()
+	if len(mgr.cache) >= maxCachedDataKeys {
+		clear(mgr.cache)
+	}
+	mgr.cache[string(env.header)] = aead
+	mgr.mu.Unlock()
+	return aead, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: invalid data key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,16 @@
+// Package encryption provides Encore applications with encryption at rest
+// for sensitive data, such as personal information stored in databases
+// or object storage.
+//
+// Struct fields tagged with `encore:"encrypted"` are encrypted whenever the
+// struct is encoded as JSON, and can be encrypted in place with EncryptFields
+// before being written to a database column. Encrypted values read from
+// databases with encore.dev/storage/sqldb are decrypted transparently.
+//
+// Data is encrypted with envelope encryption: each value is encrypted with a
+// data key, which is itself encrypted with a key managed by a key provider.
+// When running locally the key is stored in a key file managed by Encore,
+// and in other environments it can be an AWS KMS or GCP Cloud KMS key.
+//
+// For more information see https://encore.dev/docs/primitives/encryption
+package encryption
@@ -0,0 +1,17 @@
+//go:build !encore_no_aws
+
+package encryption
+
+import (
+	"context"
+	"net/http"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/encryption/internal/providers/awskms"
+)
+
+func init() {
+	registerProvider(func(ctx context.Context, runtimeCfg *config.Runtime) provider {
+		return awskms.NewManager(ctx, http.DefaultClient)
+	})
+}
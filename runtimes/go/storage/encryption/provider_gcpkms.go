@@ -0,0 +1,16 @@
+//go:build !encore_no_gcp
+
+package encryption
+
+import (
+	"context"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/encryption/internal/providers/gcpkms"
+)
+
+func init() {
+	registerProvider(func(ctx context.Context, runtimeCfg *config.Runtime) provider {
+		return gcpkms.NewManager(ctx)
+	})
+}
@@ -0,0 +1,14 @@
+package encryption
+
+import (
+	"context"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/encryption/internal/providers/local"
+)
+
+func init() {
+	registerProvider(func(ctx context.Context, runtimeCfg *config.Runtime) provider {
+		return local.NewManager()
+	})
+}
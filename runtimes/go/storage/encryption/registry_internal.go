@@ -0,0 +1,20 @@
+package encryption
+
+import (
+	"context"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/encryption/internal/types"
+)
+
+type provider interface {
+	ProviderName() string
+	Matches(cfg *config.EncryptionKey) bool
+	NewKey(cfg *config.EncryptionKey) (types.KeyImpl, error)
+}
+
+var providerRegistry []func(context.Context, *config.Runtime) provider
+
+func registerProvider(p func(context.Context, *config.Runtime) provider) {
+	providerRegistry = append(providerRegistry, p)
+}
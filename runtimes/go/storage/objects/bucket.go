@@ -272,6 +272,7 @@ func (b *Bucket) Download(ctx context.Context, object string, options ...Downloa
 		Ctx:     ctx,
 		Object:  b.toCloudObject(object),
 		Version: opt.version,
+		Range:   opt.byteRange,
 	})
 	return &Reader{r: r, err: err, curr: curr, startEventID: startEventID}
 }
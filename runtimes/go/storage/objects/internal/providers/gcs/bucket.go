@@ -66,7 +66,15 @@ func (b *bucket) Download(data types.DownloadData) (types.Downloader, error) {
 			obj = obj.Generation(gen)
 		}
 	}
-	r, err := obj.NewReader(data.Ctx)
+	var (
+		r   *storage.Reader
+		err error
+	)
+	if data.Range != nil {
+		r, err = obj.NewRangeReader(data.Ctx, data.Range.Offset, data.Range.Length)
+	} else {
+		r, err = obj.NewReader(data.Ctx)
+	}
 	return r, mapErr(err)
 }
 
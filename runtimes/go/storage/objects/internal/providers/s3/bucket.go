@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"iter"
+	"strings"
 	"sync"
 
 	"cloud.google.com/go/storage"
@@ -60,10 +62,15 @@ func (mgr *Manager) NewBucket(provider *config.BucketProvider, runtimeCfg *confi
 
 func (b *bucket) Download(data types.DownloadData) (types.Downloader, error) {
 	object := string(data.Object)
+	if data.Range != nil && data.Range.Length == 0 {
+		// HTTP ranges are inclusive, so an empty range can't be expressed.
+		return io.NopCloser(strings.NewReader("")), nil
+	}
 	resp, err := b.client.GetObject(data.Ctx, &s3.GetObjectInput{
 		Bucket:    &b.cfg.CloudName,
 		Key:       &object,
 		VersionId: ptrOrNil(data.Version),
+		Range:     ptrOrNil(rangeHeader(data.Range)),
 	})
 	if err != nil {
 		return nil, mapErr(err)
@@ -71,6 +78,19 @@ func (b *bucket) Download(data types.DownloadData) (types.Downloader, error) {
 	return resp.Body, nil
 }
 
+// rangeHeader returns the HTTP Range header value for r,
+// or "" if r is nil.
+func rangeHeader(r *types.ByteRange) string {
+	switch {
+	case r == nil:
+		return ""
+	case r.Length < 0:
+		return fmt.Sprintf("bytes=%d-", r.Offset)
+	default:
+		return fmt.Sprintf("bytes=%d-%d", r.Offset, r.Offset+r.Length-1)
+	}
+}
+
 func (b *bucket) Upload(data types.UploadData) (types.Uploader, error) {
 	return newUploader(b.client, b.cfg.CloudName, data), nil
 }
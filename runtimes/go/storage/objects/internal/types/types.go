@@ -52,6 +52,15 @@ type DownloadData struct {
 
 	// Non-zero to download a specific version
 	Version string
+
+	// Non-nil to download only part of the object
+	Range *ByteRange
+}
+
+// ByteRange describes a contiguous range of bytes in an object.
+type ByteRange struct {
+	Offset int64
+	Length int64 // negative to read until the end of the object
 }
 
 type Downloader interface {
@@ -2,10 +2,13 @@
 
 package objects
 
+import "encore.dev/appruntime/shared/startup"
+
 // NewBucket declares a new object storage bucket.
 //
 // See https://encore.dev/docs/primitives/object-storage for more information.
 func NewBucket(name string, cfg BucketConfig) *Bucket {
+	defer startup.Singleton.Resource("bucket", name)()
 	return newBucket(Singleton, name)
 }
 
@@ -62,9 +62,29 @@ type withTTLOption struct {
 	TTL time.Duration
 }
 
+// WithRange specifies that only length bytes of the object, starting at
+// offset, should be downloaded. If length is negative the rest of the object
+// starting at offset is downloaded.
+func WithRange(offset, length int64) withRangeOption {
+	return withRangeOption{offset: offset, length: length}
+}
+
+//publicapigen:keep
+type withRangeOption struct {
+	offset, length int64
+}
+
+//publicapigen:keep
+func (o withRangeOption) downloadOption() {}
+
+func (o withRangeOption) applyDownload(opts *downloadOptions) {
+	opts.byteRange = &types.ByteRange{Offset: o.offset, Length: o.length}
+}
+
 //publicapigen:keep
 type downloadOptions struct {
-	version string
+	version   string
+	byteRange *types.ByteRange
 }
 
 // UploadOption describes available options for the Upload operation.
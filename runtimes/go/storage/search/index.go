@@ -0,0 +1,235 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"encore.dev/storage/search/internal/providers/noop"
+	"encore.dev/storage/search/internal/types"
+)
+
+// defaultLimit is the number of hits returned when a query doesn't specify a limit.
+const defaultLimit = 20
+
+// Index is a full-text search index of documents of type Doc.
+//
+// See NewIndex for more information on how to declare an Index.
+type Index[Doc any] struct {
+	mgr  *Manager
+	name string
+	cfg  IndexConfig
+	impl types.IndexImpl
+}
+
+// IndexConfig is the configuration for an Index.
+//
+// Fields are referred to by their names in the JSON encoding of the documents.
+type IndexConfig struct {
+	// SearchableFields are the fields queries match against, in order of importance:
+	// matches in earlier fields rank higher. They must be strings or lists of strings.
+	SearchableFields []string
+
+	// FilterableFields are the fields queries can filter on.
+	FilterableFields []string
+}
+
+func newIndex[Doc any](mgr *Manager, name string, cfg IndexConfig) *Index[Doc] {
+	if err := checkFields(reflect.TypeFor[Doc](), cfg); err != nil {
+		mgr.rootLogger.Fatal().Msgf("invalid config for search index %s: %v", name, err)
+	}
+
+	idx, ok := mgr.runtime.SearchIndexes[name]
+	if !ok {
+		// No runtime config; return the noop implementation.
+		return &Index[Doc]{
+			mgr:  mgr,
+			name: name,
+			cfg:  cfg,
+			impl: &noop.IndexImpl{EncoreName: name},
+		}
+	}
+
+	// Look up the provider config
+	provider := mgr.runtime.SearchProviders[idx.ProviderID]
+	implCfg := types.Config{
+		SearchableFields: cfg.SearchableFields,
+		FilterableFields: cfg.FilterableFields,
+	}
+
+	tried := make([]string, 0, len(mgr.providers))
+	for _, p := range mgr.providers {
+		if p.Matches(provider) {
+			return &Index[Doc]{
+				mgr:  mgr,
+				name: name,
+				cfg:  cfg,
+				impl: p.NewIndex(provider, idx, implCfg),
+			}
+		}
+		tried = append(tried, p.ProviderName())
+	}
+
+	mgr.rootLogger.Fatal().Msgf("unsupported search provider for provider[%d], tried: %v",
+		idx.ProviderID, tried)
+	panic("unreachable")
+}
+
+// Index adds the document with the given id to the index,
+// replacing any existing document with that id.
+//
+// Depending on the search engine, the document may not be
+// returned by searches immediately.
+func (i *Index[Doc]) Index(ctx context.Context, id string, doc Doc) error {
+	if id == "" {
+		return i.wrapErr("index", errors.New("empty document id"))
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return i.wrapErr("index", fmt.Errorf("encode document: %w", err))
+	}
+	return i.wrapErr("index", i.impl.Index(ctx, id, data))
+}
+
+// Delete removes the document with the given id from the index.
+// It does nothing if there is no such document.
+func (i *Index[Doc]) Delete(ctx context.Context, id string) error {
+	return i.wrapErr("delete", i.impl.Delete(ctx, id))
+}
+
+// Query describes a search of an index.
+type Query struct {
+	// Text is the text to search for.
+	// If empty, all documents matching the filters are returned.
+	Text string
+
+	// Filters restricts the results to documents whose fields
+	// have the given values. The fields must be filterable.
+	Filters map[string]any
+
+	// Limit is the maximum number of hits to return.
+	// If zero, it defaults to 20.
+	Limit int
+
+	// Offset is the number of hits to skip, for pagination.
+	Offset int
+}
+
+// Results are the results of a search.
+type Results[Doc any] struct {
+	// Hits are the matching documents, best match first.
+	Hits []Hit[Doc]
+
+	// Total is the total number of matching documents,
+	// which some search engines estimate.
+	Total int
+}
+
+// Hit is a document matching a search.
+type Hit[Doc any] struct {
+	ID  string
+	Doc Doc
+
+	// Score is the relevance of the document to the search, higher being more relevant.
+	// Scores are only comparable between hits of the same search.
+	Score float64
+}
+
+// Search searches the index for documents matching q.
+func (i *Index[Doc]) Search(ctx context.Context, q Query) (*Results[Doc], error) {
+	for field := range q.Filters {
+		if !slices.Contains(i.cfg.FilterableFields, field) {
+			return nil, i.wrapErr("search", fmt.Errorf("field %q is not filterable", field))
+		}
+	}
+	if q.Limit < 0 || q.Offset < 0 {
+		return nil, i.wrapErr("search", errors.New("negative limit or offset"))
+	} else if q.Limit == 0 {
+		q.Limit = defaultLimit
+	}
+
+	res, err := i.impl.Search(ctx, types.Query{
+		Text:    q.Text,
+		Filters: q.Filters,
+		Limit:   q.Limit,
+		Offset:  q.Offset,
+	})
+	if err != nil {
+		return nil, i.wrapErr("search", err)
+	}
+
+	out := &Results[Doc]{Total: res.Total, Hits: make([]Hit[Doc], 0, len(res.Hits))}
+	for _, h := range res.Hits {
+		hit := Hit[Doc]{ID: h.ID, Score: h.Score}
+		if err := json.Unmarshal(h.Doc, &hit.Doc); err != nil {
+			return nil, i.wrapErr("search", fmt.Errorf("decode document %s: %w", h.ID, err))
+		}
+		out.Hits = append(out.Hits, hit)
+	}
+	return out, nil
+}
+
+func (i *Index[Doc]) wrapErr(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("search: %s %s: %w", op, i.name, err)
+}
+
+// checkFields reports an error if the fields of cfg aren't fields of
+// the JSON encoding of docType, or if searchable fields aren't text.
+// Types that aren't structs are not checked.
+func checkFields(docType reflect.Type, cfg IndexConfig) error {
+	for docType.Kind() == reflect.Pointer {
+		docType = docType.Elem()
+	}
+	if docType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := jsonFields(docType)
+	for _, name := range cfg.SearchableFields {
+		typ, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("searchable field %q does not exist", name)
+		}
+		if typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.String {
+			return fmt.Errorf("searchable field %q must be a string or a list of strings", name)
+		}
+	}
+	for _, name := range cfg.FilterableFields {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("filterable field %q does not exist", name)
+		}
+	}
+	return nil
+}
+
+// jsonFields returns the types of the top-level fields of the JSON encoding
+// of the struct type t, keyed by name. Fields of embedded structs are included.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for _, f := range reflect.VisibleFields(t) {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		} else if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			continue // its fields are visible fields too
+		} else if name == "" {
+			name = f.Name
+		}
+		typ := f.Type
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		fields[name] = typ
+	}
+	return fields
+}
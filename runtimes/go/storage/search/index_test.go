@@ -0,0 +1,43 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testDoc struct {
+	Title    string   `json:"title"`
+	Tags     []string `json:"tags,omitempty"`
+	Price    int      `json:"price"`
+	Internal string   `json:"-"`
+	Meta
+}
+
+type Meta struct {
+	Author *string
+}
+
+func TestCheckFields(t *testing.T) {
+	tests := []struct {
+		cfg     IndexConfig
+		wantErr string
+	}{
+		{cfg: IndexConfig{SearchableFields: []string{"title", "tags", "Author"}, FilterableFields: []string{"price"}}},
+		{cfg: IndexConfig{SearchableFields: []string{"Title"}}, wantErr: `searchable field "Title" does not exist`},
+		{cfg: IndexConfig{SearchableFields: []string{"price"}}, wantErr: `searchable field "price" must be a string or a list of strings`},
+		{cfg: IndexConfig{FilterableFields: []string{"Internal"}}, wantErr: `filterable field "Internal" does not exist`},
+	}
+	for _, test := range tests {
+		err := checkFields(reflect.TypeFor[*testDoc](), test.cfg)
+		if test.wantErr == "" && err != nil {
+			t.Errorf("checkFields(%v): unexpected error: %v", test.cfg, err)
+		} else if test.wantErr != "" && (err == nil || err.Error() != test.wantErr) {
+			t.Errorf("checkFields(%v): got error %v, want %q", test.cfg, err, test.wantErr)
+		}
+	}
+
+	// Types that aren't structs are not checked.
+	if err := checkFields(reflect.TypeFor[map[string]any](), IndexConfig{SearchableFields: []string{"x"}}); err != nil {
+		t.Errorf("checkFields(map): unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+// Package meilisearch implements search indexes with Meilisearch.
+//
+// Documents are stored as {"id": id, "doc": document} so that documents
+// don't need to contain their own id, and their fields are nested under "doc".
+package meilisearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/search/internal/types"
+)
+
+type Manager struct {
+	client *http.Client
+}
+
+func NewManager(client *http.Client) *Manager {
+	return &Manager{client: client}
+}
+
+func (mgr *Manager) ProviderName() string { return "meilisearch" }
+
+func (mgr *Manager) Matches(cfg *config.SearchProvider) bool {
+	return cfg.Meilisearch != nil
+}
+
+func (mgr *Manager) NewIndex(provider *config.SearchProvider, runtimeCfg *config.SearchIndex, cfg types.Config) types.IndexImpl {
+	p := provider.Meilisearch
+	return &index{
+		client:  mgr.client,
+		baseURL: strings.TrimSuffix(p.URL, "/"),
+		uid:     runtimeCfg.CloudName,
+		apiKey:  p.APIKey,
+		cfg:     cfg,
+	}
+}
+
+type index struct {
+	client  *http.Client
+	baseURL string // the URL of the server
+	uid     string // the uid of the index
+	apiKey  string
+	cfg     types.Config
+
+	mu      sync.Mutex
+	created bool
+}
+
+// validID matches the document ids Meilisearch accepts.
+var validID = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,511}$`)
+
+// ensureIndex creates the index and configures its fields.
+// Meilisearch processes the changes asynchronously, in order.
+func (i *index) ensureIndex(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.created {
+		return nil
+	}
+
+	// Creating an index that exists fails asynchronously, which is harmless.
+	err := i.do(ctx, http.MethodPost, "/indexes", map[string]any{"uid": i.uid, "primaryKey": "id"}, nil)
+	if err == nil {
+		err = i.do(ctx, http.MethodPatch, i.indexPath("/settings"), map[string]any{
+			"searchableAttributes": docFields(i.cfg.SearchableFields),
+			"filterableAttributes": docFields(i.cfg.FilterableFields),
+		}, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("create index: %w", err)
+	}
+	i.created = true
+	return nil
+}
+
+func (i *index) Index(ctx context.Context, id string, doc []byte) error {
+	if !validID.MatchString(id) {
+		return fmt.Errorf("meilisearch: invalid document id %q: ids can only contain a-z, A-Z, 0-9, '-' and '_'", id)
+	}
+	if err := i.ensureIndex(ctx); err != nil {
+		return err
+	}
+	docs := []any{map[string]any{"id": id, "doc": json.RawMessage(doc)}}
+	return i.do(ctx, http.MethodPost, i.indexPath("/documents"), docs, nil)
+}
+
+func (i *index) Delete(ctx context.Context, id string) error {
+	if err := i.ensureIndex(ctx); err != nil {
+		return err
+	}
+	return i.do(ctx, http.MethodDelete, i.indexPath("/documents/"+url.PathEscape(id)), nil, nil)
+}
+
+func (i *index) Search(ctx context.Context, q types.Query) (*types.Results, error) {
+	if err := i.ensureIndex(ctx); err != nil {
+		return nil, err
+	}
+	req, err := searchRequest(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Hits []struct {
+			ID    string          `json:"id"`
+			Doc   json.RawMessage `json:"doc"`
+			Score float64         `json:"_rankingScore"`
+		} `json:"hits"`
+		EstimatedTotalHits int `json:"estimatedTotalHits"`
+	}
+	err = i.do(ctx, http.MethodPost, i.indexPath("/search"), req, &resp)
+	if e, ok := err.(*apiError); ok && e.status == http.StatusNotFound {
+		// The index hasn't been created yet.
+		return &types.Results{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	res := &types.Results{Total: resp.EstimatedTotalHits}
+	for _, h := range resp.Hits {
+		res.Hits = append(res.Hits, types.Hit{ID: h.ID, Score: h.Score, Doc: h.Doc})
+	}
+	return res, nil
+}
+
+// searchRequest returns the body of the search request for q.
+func searchRequest(q types.Query) (map[string]any, error) {
+	filter := []string{}
+	for _, field := range slices.Sorted(maps.Keys(q.Filters)) {
+		// JSON encoding quotes and escapes strings the way filter expressions expect.
+		val, err := json.Marshal(q.Filters[field])
+		if err != nil {
+			return nil, fmt.Errorf("encode filter %s: %w", field, err)
+		}
+		filter = append(filter, "doc."+field+" = "+string(val))
+	}
+	return map[string]any{
+		"q":                q.Text,
+		"offset":           q.Offset,
+		"limit":            q.Limit,
+		"filter":           filter,
+		"showRankingScore": true,
+	}, nil
+}
+
+// docFields returns the attribute names of the given document fields.
+func docFields(fields []string) []string {
+	attrs := make([]string, len(fields))
+	for idx, f := range fields {
+		attrs[idx] = "doc." + f
+	}
+	return attrs
+}
+
+func (i *index) indexPath(path string) string {
+	return "/indexes/" + url.PathEscape(i.uid) + path
+}
+
+type apiError struct {
+	status int
+	body   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("meilisearch: unexpected status %d: %s", e.status, e.body)
+}
+
+// do makes a request to the server, encoding body and decoding the response into out, if non-nil.
+func (i *index) do(ctx context.Context, method, path string, body, out any) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, i.baseURL+path, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if i.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+i.apiKey)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &apiError{status: resp.StatusCode, body: string(data)}
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package meilisearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/search/internal/types"
+)
+
+func TestIndexAndSearch(t *testing.T) {
+	bodies := make(map[string]string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if got := req.Header.Get("Authorization"); got != "Bearer key" {
+			t.Errorf("got authorization %q", got)
+		}
+		body, _ := io.ReadAll(req.Body)
+		bodies[req.Method+" "+req.URL.Path] = string(body)
+		if req.URL.Path == "/indexes/products/search" {
+			_, _ = io.WriteString(w, `{"hits":[{"id":"p1","doc":{"title":"Blue shoes"},"_rankingScore":0.9}],"estimatedTotalHits":3}`)
+		} else {
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer srv.Close()
+
+	mgr := NewManager(srv.Client())
+	idx := mgr.NewIndex(
+		&config.SearchProvider{Meilisearch: &config.MeilisearchProvider{URL: srv.URL, APIKey: "key"}},
+		&config.SearchIndex{EncoreName: "products", CloudName: "products"},
+		types.Config{SearchableFields: []string{"title"}, FilterableFields: []string{"status"}},
+	)
+	ctx := context.Background()
+
+	if err := idx.Index(ctx, "not valid!", []byte(`{}`)); err == nil {
+		t.Error("expected an error for an invalid id")
+	}
+	if err := idx.Index(ctx, "p1", []byte(`{"title":"Blue shoes"}`)); err != nil {
+		t.Fatal(err)
+	}
+	res, err := idx.Search(ctx, types.Query{Text: "shoes", Filters: map[string]any{"status": `say "hi"`}, Limit: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &types.Results{Total: 3, Hits: []types.Hit{{ID: "p1", Score: 0.9, Doc: []byte(`{"title":"Blue shoes"}`)}}}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("got results %+v, want %+v", res, want)
+	}
+
+	wantBodies := map[string]string{
+		"POST /indexes":                    `{"primaryKey":"id","uid":"products"}`,
+		"PATCH /indexes/products/settings": `{"filterableAttributes":["doc.status"],"searchableAttributes":["doc.title"]}`,
+		"POST /indexes/products/documents": `[{"doc":{"title":"Blue shoes"},"id":"p1"}]`,
+		"POST /indexes/products/search":    `{"filter":["doc.status = \"say \\\"hi\\\"\""],"limit":20,"offset":0,"q":"shoes","showRankingScore":true}`,
+	}
+	for key, want := range wantBodies {
+		var got, wantVal any
+		_ = json.Unmarshal([]byte(bodies[key]), &got)
+		_ = json.Unmarshal([]byte(want), &wantVal)
+		if !reflect.DeepEqual(got, wantVal) {
+			t.Errorf("%s: got body %s, want %s", key, bodies[key], want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package noop
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/storage/search/internal/types"
+)
+
+type IndexImpl struct {
+	EncoreName string
+}
+
+func (i *IndexImpl) Index(ctx context.Context, id string, doc []byte) error {
+	return fmt.Errorf("cannot index documents in noop search index %s", i.EncoreName)
+}
+
+func (i *IndexImpl) Delete(ctx context.Context, id string) error {
+	return fmt.Errorf("cannot delete documents from noop search index %s", i.EncoreName)
+}
+
+func (i *IndexImpl) Search(ctx context.Context, q types.Query) (*types.Results, error) {
+	return nil, fmt.Errorf("cannot search noop search index %s", i.EncoreName)
+}
@@ -0,0 +1,214 @@
+// Package opensearch implements search indexes with OpenSearch,
+// or Elasticsearch which has a compatible API.
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/search/internal/types"
+)
+
+type Manager struct {
+	client *http.Client
+}
+
+func NewManager(client *http.Client) *Manager {
+	return &Manager{client: client}
+}
+
+func (mgr *Manager) ProviderName() string { return "opensearch" }
+
+func (mgr *Manager) Matches(cfg *config.SearchProvider) bool {
+	return cfg.OpenSearch != nil
+}
+
+func (mgr *Manager) NewIndex(provider *config.SearchProvider, runtimeCfg *config.SearchIndex, cfg types.Config) types.IndexImpl {
+	p := provider.OpenSearch
+	return &index{
+		client:   mgr.client,
+		baseURL:  strings.TrimSuffix(p.URL, "/") + "/" + url.PathEscape(runtimeCfg.CloudName),
+		username: p.Username,
+		password: p.Password,
+		cfg:      cfg,
+	}
+}
+
+type index struct {
+	client   *http.Client
+	baseURL  string // the URL of the index
+	username string
+	password string
+	cfg      types.Config
+
+	mu      sync.Mutex
+	created bool
+}
+
+// ensureIndex creates the index with mappings for its fields if it doesn't exist.
+func (i *index) ensureIndex(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.created {
+		return nil
+	}
+
+	props := make(map[string]any)
+	for _, f := range i.cfg.SearchableFields {
+		props[f] = map[string]any{"type": "text"}
+	}
+	for _, f := range i.cfg.FilterableFields {
+		if _, ok := props[f]; ok {
+			props[f] = map[string]any{"type": "text", "fields": map[string]any{"keyword": map[string]any{"type": "keyword"}}}
+		} else {
+			props[f] = map[string]any{"type": "keyword"}
+		}
+	}
+	body := map[string]any{"mappings": map[string]any{"properties": props}}
+	err := i.do(ctx, http.MethodPut, "", body, nil)
+	if e, ok := err.(*apiError); ok && strings.Contains(e.body, "resource_already_exists_exception") {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("create index: %w", err)
+	}
+	i.created = true
+	return nil
+}
+
+func (i *index) Index(ctx context.Context, id string, doc []byte) error {
+	if err := i.ensureIndex(ctx); err != nil {
+		return err
+	}
+	return i.do(ctx, http.MethodPut, "/_doc/"+url.PathEscape(id), json.RawMessage(doc), nil)
+}
+
+func (i *index) Delete(ctx context.Context, id string) error {
+	if err := i.ensureIndex(ctx); err != nil {
+		return err
+	}
+	err := i.do(ctx, http.MethodDelete, "/_doc/"+url.PathEscape(id), nil, nil)
+	if e, ok := err.(*apiError); ok && e.status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func (i *index) Search(ctx context.Context, q types.Query) (*types.Results, error) {
+	if err := i.ensureIndex(ctx); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID     string          `json:"_id"`
+				Score  *float64        `json:"_score"`
+				Source json.RawMessage `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := i.do(ctx, http.MethodPost, "/_search", i.searchRequest(q), &resp); err != nil {
+		return nil, err
+	}
+
+	res := &types.Results{Total: resp.Hits.Total.Value}
+	for _, h := range resp.Hits.Hits {
+		hit := types.Hit{ID: h.ID, Doc: h.Source}
+		if h.Score != nil {
+			hit.Score = *h.Score
+		}
+		res.Hits = append(res.Hits, hit)
+	}
+	return res, nil
+}
+
+// searchRequest returns the body of the search request for q.
+func (i *index) searchRequest(q types.Query) map[string]any {
+	must := []any{map[string]any{"match_all": map[string]any{}}}
+	if q.Text != "" {
+		must = []any{map[string]any{"multi_match": map[string]any{
+			"query":  q.Text,
+			"fields": i.cfg.SearchableFields,
+		}}}
+	}
+
+	filter := []any{}
+	for _, field := range slices.Sorted(maps.Keys(q.Filters)) {
+		// Fields that are also searchable are mapped as text,
+		// with a keyword sub-field for exact matches.
+		name := field
+		if slices.Contains(i.cfg.SearchableFields, field) {
+			name += ".keyword"
+		}
+		filter = append(filter, map[string]any{"term": map[string]any{name: q.Filters[field]}})
+	}
+
+	return map[string]any{
+		"from":             q.Offset,
+		"size":             q.Limit,
+		"track_total_hits": true,
+		"query": map[string]any{"bool": map[string]any{
+			"must":   must,
+			"filter": filter,
+		}},
+	}
+}
+
+type apiError struct {
+	status int
+	body   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("opensearch: unexpected status %d: %s", e.status, e.body)
+}
+
+// do makes a request to the index, encoding body and decoding the response into out, if non-nil.
+func (i *index) do(ctx context.Context, method, path string, body, out any) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, i.baseURL+path, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if i.username != "" {
+		req.SetBasicAuth(i.username, i.password)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &apiError{status: resp.StatusCode, body: string(data)}
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/search/internal/types"
+)
+
+func TestSearch(t *testing.T) {
+	var requests []string
+	var searchBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		if user, pass, _ := req.BasicAuth(); user != "user" || pass != "pass" {
+			t.Errorf("got basic auth %q:%q", user, pass)
+		}
+		switch req.URL.Path {
+		case "/products":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":{"type":"resource_already_exists_exception"}}`)
+		case "/products/_search":
+			_ = json.NewDecoder(req.Body).Decode(&searchBody)
+			_, _ = io.WriteString(w, `{"hits":{"total":{"value":7},"hits":[{"_id":"p1","_score":1.5,"_source":{"title":"Blue shoes"}}]}}`)
+		}
+	}))
+	defer srv.Close()
+
+	mgr := NewManager(srv.Client())
+	idx := mgr.NewIndex(
+		&config.SearchProvider{OpenSearch: &config.OpenSearchProvider{URL: srv.URL + "/", Username: "user", Password: "pass"}},
+		&config.SearchIndex{EncoreName: "products", CloudName: "products"},
+		types.Config{SearchableFields: []string{"title", "status"}, FilterableFields: []string{"status", "price"}},
+	)
+	res, err := idx.Search(context.Background(), types.Query{
+		Text:    "shoes",
+		Filters: map[string]any{"status": "active", "price": 10},
+		Limit:   20,
+		Offset:  40,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &types.Results{Total: 7, Hits: []types.Hit{{ID: "p1", Score: 1.5, Doc: []byte(`{"title":"Blue shoes"}`)}}}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("got results %+v, want %+v", res, want)
+	}
+	if want := []string{"PUT /products", "POST /products/_search"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("got requests %v, want %v", requests, want)
+	}
+
+	var wantBody map[string]any
+	_ = json.Unmarshal([]byte(`{
+		"from": 40, "size": 20, "track_total_hits": true,
+		"query": {"bool": {
+			"must": [{"multi_match": {"query": "shoes", "fields": ["title", "status"]}}],
+			"filter": [{"term": {"price": 10}}, {"term": {"status.keyword": "active"}}]
+		}}
+	}`), &wantBody)
+	if !reflect.DeepEqual(searchBody, wantBody) {
+		t.Errorf("got search request %v, want %v", searchBody, wantBody)
+	}
+}
@@ -0,0 +1,217 @@
+// Package postgres implements search indexes with Postgres full-text search.
+//
+// Each index is a table holding the documents as JSONB,
+// along with a weighted tsvector of their searchable fields.
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/search/internal/types"
+	"encore.dev/storage/sqldb"
+)
+
+// textSearchConfig is the text search configuration used to parse documents and queries.
+// It doesn't stem words, as documents can be in any language.
+const textSearchConfig = "simple"
+
+type Manager struct {
+	getDB func(encoreName string) *sqldb.Database
+}
+
+// NewManager returns a manager getting the databases storing the indexes with getDB.
+func NewManager(getDB func(encoreName string) *sqldb.Database) *Manager {
+	return &Manager{getDB: getDB}
+}
+
+func (mgr *Manager) ProviderName() string { return "postgres" }
+
+func (mgr *Manager) Matches(cfg *config.SearchProvider) bool {
+	return cfg.Postgres != nil
+}
+
+func (mgr *Manager) NewIndex(provider *config.SearchProvider, runtimeCfg *config.SearchIndex, cfg types.Config) types.IndexImpl {
+	return &index{
+		db:    mgr.getDB(provider.Postgres.Database),
+		table: pgx.Identifier{runtimeCfg.CloudName}.Sanitize(),
+		name:  runtimeCfg.CloudName,
+		cfg:   cfg,
+	}
+}
+
+type index struct {
+	db    *sqldb.Database
+	table string // the sanitized table name
+	name  string
+	cfg   types.Config
+
+	mu      sync.Mutex
+	created bool
+}
+
+// ensureTable creates the table of the index if it doesn't exist.
+func (i *index) ensureTable(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.created {
+		return nil
+	}
+
+	_, err := i.db.Exec(ctx, `CREATE TABLE IF NOT EXISTS `+i.table+` (
+		id TEXT PRIMARY KEY,
+		doc JSONB NOT NULL,
+		tsv TSVECTOR NOT NULL
+	)`)
+	if err == nil {
+		_, err = i.db.Exec(ctx, `CREATE INDEX IF NOT EXISTS `+pgx.Identifier{i.name + "_tsv"}.Sanitize()+
+			` ON `+i.table+` USING GIN (tsv)`)
+	}
+	if err != nil {
+		return fmt.Errorf("create table: %w", err)
+	}
+	i.created = true
+	return nil
+}
+
+func (i *index) Index(ctx context.Context, id string, doc []byte) error {
+	if err := i.ensureTable(ctx); err != nil {
+		return err
+	}
+	tsv, args, err := vectorExpr(doc, i.cfg.SearchableFields, 3)
+	if err != nil {
+		return err
+	}
+	args = append([]any{id, string(doc)}, args...)
+	_, err = i.db.Exec(ctx, `INSERT INTO `+i.table+` (id, doc, tsv) VALUES ($1, $2::jsonb, `+tsv+`)
+		ON CONFLICT (id) DO UPDATE SET doc = excluded.doc, tsv = excluded.tsv`, args...)
+	return err
+}
+
+func (i *index) Delete(ctx context.Context, id string) error {
+	if err := i.ensureTable(ctx); err != nil {
+		return err
+	}
+	_, err := i.db.Exec(ctx, `DELETE FROM `+i.table+` WHERE id = $1`, id)
+	return err
+}
+
+func (i *index) Search(ctx context.Context, q types.Query) (*types.Results, error) {
+	if err := i.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	query, args, err := searchQuery(i.table, q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := i.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := &types.Results{}
+	for rows.Next() {
+		var (
+			hit types.Hit
+			doc string
+		)
+		if err := rows.Scan(&hit.ID, &doc, &hit.Score, &res.Total); err != nil {
+			return nil, err
+		}
+		hit.Doc = []byte(doc)
+		res.Hits = append(res.Hits, hit)
+	}
+	return res, rows.Err()
+}
+
+// weights are the tsvector weights of the searchable fields, in order of importance.
+// Fields after the last weight get the lowest weight.
+var weights = []string{"A", "B", "C", "D"}
+
+// vectorExpr returns the SQL expression computing the tsvector of a document
+// from its searchable fields, and the arguments it references,
+// numbered from firstArg.
+func vectorExpr(doc []byte, fields []string, firstArg int) (expr string, args []any, err error) {
+	var values map[string]any
+	if err := json.Unmarshal(doc, &values); err != nil {
+		return "", nil, fmt.Errorf("decode document: %w", err)
+	}
+
+	var parts []string
+	for idx, field := range fields {
+		weight := weights[min(idx, len(weights)-1)]
+		args = append(args, fieldText(values[field]))
+		parts = append(parts, fmt.Sprintf("setweight(to_tsvector('%s', $%d), '%s')",
+			textSearchConfig, firstArg+idx, weight))
+	}
+	if len(parts) == 0 {
+		return "''::tsvector", nil, nil
+	}
+	return strings.Join(parts, " || "), args, nil
+}
+
+// fieldText returns the text of a searchable field,
+// which is either a string or a list of strings.
+func fieldText(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []any:
+		var texts []string
+		for _, elem := range v {
+			if s, ok := elem.(string); ok {
+				texts = append(texts, s)
+			}
+		}
+		return strings.Join(texts, " ")
+	default:
+		return ""
+	}
+}
+
+// searchQuery returns the SQL query searching the given table, and its arguments.
+func searchQuery(table string, q types.Query) (query string, args []any, err error) {
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	var b strings.Builder
+	var conds []string
+	if q.Text != "" {
+		b.WriteString("SELECT id, doc::text, ts_rank(tsv, query)::float8 AS score, count(*) OVER () AS total\nFROM " + table +
+			", websearch_to_tsquery('" + textSearchConfig + "', " + arg(q.Text) + ") AS query")
+		conds = append(conds, "tsv @@ query")
+	} else {
+		b.WriteString("SELECT id, doc::text, 0::float8 AS score, count(*) OVER () AS total\nFROM " + table)
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(q.Filters)) {
+		val, err := json.Marshal(q.Filters[field])
+		if err != nil {
+			return "", nil, fmt.Errorf("encode filter %s: %w", field, err)
+		}
+		conds = append(conds, "doc->"+arg(field)+"::text = "+arg(string(val))+"::jsonb")
+	}
+	if len(conds) > 0 {
+		b.WriteString("\nWHERE " + strings.Join(conds, " AND "))
+	}
+
+	if q.Text != "" {
+		b.WriteString("\nORDER BY score DESC, id")
+	} else {
+		b.WriteString("\nORDER BY id")
+	}
+	b.WriteString("\nLIMIT " + arg(q.Limit) + " OFFSET " + arg(q.Offset))
+	return b.String(), args, nil
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	"encore.dev/storage/search/internal/types"
+)
+
+func TestVectorExpr(t *testing.T) {
+	doc := []byte(`{"title": "Blue shoes", "tags": ["sale", 3, "summer"], "price": 10}`)
+	expr, args, err := vectorExpr(doc, []string{"title", "tags", "price"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantExpr := "setweight(to_tsvector('simple', $3), 'A') || " +
+		"setweight(to_tsvector('simple', $4), 'B') || " +
+		"setweight(to_tsvector('simple', $5), 'C')"
+	if expr != wantExpr {
+		t.Errorf("got expr %q, want %q", expr, wantExpr)
+	}
+	if want := []any{"Blue shoes", "sale summer", ""}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	query, args, err := searchQuery(`"products"`, types.Query{
+		Text:    "blue shoes",
+		Filters: map[string]any{"status": "active", "price": 10},
+		Limit:   20,
+		Offset:  40,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQuery := `SELECT id, doc::text, ts_rank(tsv, query)::float8 AS score, count(*) OVER () AS total
+FROM "products", websearch_to_tsquery('simple', $1) AS query
+WHERE tsv @@ query AND doc->$2::text = $3::jsonb AND doc->$4::text = $5::jsonb
+ORDER BY score DESC, id
+LIMIT $6 OFFSET $7`
+	if query != wantQuery {
+		t.Errorf("got query:\n%s\nwant:\n%s", query, wantQuery)
+	}
+	if want := []any{"blue shoes", "price", "10", "status", `"active"`, 20, 40}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	query, args, err = searchQuery(`"products"`, types.Query{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQuery = `SELECT id, doc::text, 0::float8 AS score, count(*) OVER () AS total
+FROM "products"
+ORDER BY id
+LIMIT $1 OFFSET $2`
+	if query != wantQuery {
+		t.Errorf("got query:\n%s\nwant:\n%s", query, wantQuery)
+	}
+	if want := []any{10, 0}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+}
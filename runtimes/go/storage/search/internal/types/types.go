@@ -0,0 +1,40 @@
+package types
+
+import (
+	"context"
+)
+
+type IndexImpl interface {
+	// Index adds or replaces the document with the given id.
+	// The document is JSON encoded.
+	Index(ctx context.Context, id string, doc []byte) error
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, q Query) (*Results, error)
+}
+
+// Config is the configuration of an index, from its declaration.
+type Config struct {
+	// SearchableFields are the JSON fields queries match against, in order of importance.
+	SearchableFields []string
+
+	// FilterableFields are the JSON fields queries can filter on.
+	FilterableFields []string
+}
+
+type Query struct {
+	Text    string
+	Filters map[string]any // field -> value; the fields are filterable
+	Limit   int
+	Offset  int
+}
+
+type Results struct {
+	Hits  []Hit
+	Total int
+}
+
+type Hit struct {
+	ID    string
+	Score float64
+	Doc   []byte // JSON encoded
+}
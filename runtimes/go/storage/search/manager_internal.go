@@ -0,0 +1,44 @@
+package search
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+type Manager struct {
+	ctx        context.Context
+	cancelCtx  func()
+	runtime    *config.Runtime
+	rootLogger zerolog.Logger
+	providers  []provider
+}
+
+func NewManager(runtime *config.Runtime, rootLogger zerolog.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := &Manager{
+		ctx:        ctx,
+		cancelCtx:  cancel,
+		runtime:    runtime,
+		rootLogger: rootLogger,
+	}
+
+	for _, p := range providerRegistry {
+		mgr.providers = append(mgr.providers, p(mgr.ctx, mgr.runtime))
+	}
+
+	return mgr
+}
+
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	// Once it's time to force-close tasks, cancel the base context.
+	go func() {
+		<-p.ForceCloseTasks.Done()
+		mgr.cancelCtx()
+	}()
+
+	return nil
+}
@@ -0,0 +1,8 @@
+// Package search provides Encore applications with full-text search indexes,
+// for searching documents by their text in a cloud-agnostic manner.
+//
+// Search indexes are backed by Postgres full-text search when running locally,
+// and can be backed by Postgres, OpenSearch or Meilisearch in other environments.
+//
+// For more information see https://encore.dev/docs/primitives/search
+package search
@@ -0,0 +1,15 @@
+package search
+
+import (
+	"context"
+	"net/http"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/search/internal/providers/meilisearch"
+)
+
+func init() {
+	registerProvider(func(ctx context.Context, runtimeCfg *config.Runtime) provider {
+		return meilisearch.NewManager(http.DefaultClient)
+	})
+}
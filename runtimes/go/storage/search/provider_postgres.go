@@ -0,0 +1,17 @@
+//go:build encore_app
+
+package search
+
+import (
+	"context"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/search/internal/providers/postgres"
+	"encore.dev/storage/sqldb"
+)
+
+func init() {
+	registerProvider(func(ctx context.Context, runtimeCfg *config.Runtime) provider {
+		return postgres.NewManager(sqldb.Singleton.GetDB)
+	})
+}
@@ -0,0 +1,20 @@
+package search
+
+import (
+	"context"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/search/internal/types"
+)
+
+type provider interface {
+	ProviderName() string
+	Matches(providerCfg *config.SearchProvider) bool
+	NewIndex(providerCfg *config.SearchProvider, runtimeCfg *config.SearchIndex, cfg types.Config) types.IndexImpl
+}
+
+var providerRegistry []func(context.Context, *config.Runtime) provider
+
+func registerProvider(p func(context.Context, *config.Runtime) provider) {
+	providerRegistry = append(providerRegistry, p)
+}
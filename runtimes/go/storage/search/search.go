@@ -0,0 +1,13 @@
+//go:build encore_app
+
+package search
+
+// NewIndex declares a new search index of documents of type Doc.
+//
+// Doc must be a type that can be encoded as a JSON object,
+// typically a struct.
+//
+// See https://encore.dev/docs/primitives/search for more information.
+func NewIndex[Doc any](name string, cfg IndexConfig) *Index[Doc] {
+	return newIndex[Doc](Singleton, name, cfg)
+}
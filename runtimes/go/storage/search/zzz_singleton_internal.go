@@ -0,0 +1,22 @@
+//go:build encore_app
+
+package search
+
+import (
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+// Initialize the singleton instance.
+// NOTE: This file is named zzz_singleton_internal.go so that
+// the init function is initialized after all the providers
+// have been registered.
+
+//publicapigen:drop
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(appconf.Runtime, logging.RootLogger)
+	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -27,15 +28,28 @@ type Database struct {
 	origName string // original name if this was cloned.
 	mgr      *Manager
 	hooks    *hookList
+	session  *sessionState
 
 	noopDB bool // true if this is a dummy database that does nothing and returns errors for all operations
 
+	// readGrant is whether the database is accessed through a read access grant,
+	// from a service other than the one owning it.
+	readGrant bool
+
 	initOnce sync.Once
 	pool     *pgxpool.Pool
 	connStr  string
 
+	// mysql is the connection pool of databases using the MySQL engine,
+	// which are used through database/sql instead of pool.
+	mysql *sql.DB
+
+	// replicas are the connection pools of the database's read replicas,
+	// used by ReadOnly. It's nil if there are none.
+	replicas *replicaSet
+
 	stdlibOnce sync.Once
-	stdlib     *sql.DB
+	stdlib     atomic.Pointer[sql.DB] // set once by Stdlib; read concurrently by Stats
 }
 
 // Hooks defines callbacks that can be registered for database lifecycle events.
@@ -51,7 +65,8 @@ type hookList struct {
 }
 
 // AddHooks registers callbacks to run for this database.
-// Does not have any effect when using the database/sql integration via the (*Database).Stdlib method.
+// Does not have any effect when using the database/sql integration via the (*Database).Stdlib method,
+// or for databases using the MySQL engine.
 func (db *Database) AddHooks(h Hooks) {
 	db.hooks.mu.Lock()
 	db.hooks.hooks = append(db.hooks.hooks, h)
@@ -85,17 +100,26 @@ func (db *Database) init() {
 	}
 
 	db.initOnce.Do(func() {
-		if db.pool == nil {
-			pool, found := db.mgr.getPool(db.origName, db.name, db.hooks)
+		if db.pool == nil && db.mysql == nil {
+			pool, found := db.mgr.getPool(db.origName, db.name, db.readGrant, db.hooks, db.session)
 			db.pool, db.noopDB = pool, !found
 		}
 
-		if !db.noopDB {
+		if !db.noopDB && db.pool != nil {
 			db.connStr = stdlibdriver.RegisterConnConfig(db.pool.Config().ConnConfig)
+			db.replicas = db.mgr.getReplicaPools(db.origName, db.name, db.readGrant, db.hooks, db.session)
 		}
 	})
 }
 
+// IsConfigured reports whether this process is configured to use the database.
+//
+//publicapigen:drop
+func (db *Database) IsConfigured() bool {
+	db.init()
+	return !db.noopDB
+}
+
 // Stdlib returns a *sql.DB object that is connected to the same db,
 // for use with libraries that expect a *sql.DB.
 func (db *Database) Stdlib() *sql.DB {
@@ -108,20 +132,29 @@ func (db *Database) Stdlib() *sql.DB {
 		return sql.OpenDB(noopConnector{})
 	}
 
+	db, err := db.isolated(context.Background())
+	if err != nil {
+		panic(err.Error())
+	}
+
 	db.init()
+	if db.mysql != nil {
+		return db.mysql
+	}
 
 	var openErr error
 	db.stdlibOnce.Do(func() {
 		c, err := registerStdlibDriver(db.mgr).(driver.DriverContext).OpenConnector(db.connStr)
 		if err == nil {
-			db.stdlib = sql.OpenDB(c)
+			std := sql.OpenDB(c)
 
 			// Set the pool size based on the config.
 			cfg := db.pool.Config()
 			maxConns := int(cfg.MaxConns)
-			db.stdlib.SetMaxOpenConns(maxConns)
-			db.stdlib.SetConnMaxIdleTime(cfg.MaxConnIdleTime)
-			db.stdlib.SetMaxIdleConns(maxConns)
+			std.SetMaxOpenConns(maxConns)
+			std.SetConnMaxIdleTime(cfg.MaxConnIdleTime)
+			std.SetMaxIdleConns(maxConns)
+			db.stdlib.Store(std)
 		}
 		openErr = err
 	})
@@ -130,15 +163,19 @@ func (db *Database) Stdlib() *sql.DB {
 		// Guard it with a panic so we detect it as early as possible in case this changes.
 		panic("sqldb: stdlib.OpenConnector failed: " + openErr.Error())
 	}
-	return db.stdlib
+	return db.stdlib.Load()
 }
 
 func (db *Database) shutdown() {
 	if db.pool != nil {
 		db.pool.Close()
 	}
-	if db.stdlib != nil {
-		_ = db.stdlib.Close()
+	db.replicas.close()
+	if std := db.stdlib.Load(); std != nil {
+		_ = std.Close()
+	}
+	if db.mysql != nil {
+		_ = db.mysql.Close()
 	}
 }
 
@@ -209,6 +246,11 @@ func (db *Database) Exec(ctx context.Context, query string, args ...interface{})
 		return nil, errNoopDB
 	}
 
+	db, err := db.isolated(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	db.init()
 
 	var (
@@ -232,8 +274,16 @@ func (db *Database) Exec(ctx context.Context, query string, args ...interface{})
 		})
 	}
 
-	res, err := db.pool.Exec(markTraced(ctx), query, args...)
+	var res ExecResult
+	if db.mysql != nil {
+		var sqlRes sql.Result
+		sqlRes, err = db.mysql.ExecContext(ctx, query, args...)
+		res = sqlResult{sqlRes}
+	} else {
+		res, err = db.pool.Exec(markTraced(ctx), query, args...)
+	}
 	err = convertErr(err)
+	captureQuery(curr.Req, db.name, query, args).Err(err)
 
 	if curr.Trace != nil {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
@@ -247,10 +297,20 @@ func (db *Database) Exec(ctx context.Context, query string, args ...interface{})
 //
 // See (*database/sql.DB).QueryContext() for additional documentation.
 func (db *Database) Query(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	return db.query(ctx, false, query, args)
+}
+
+// query implements Query, running it on a read replica if readOnly is set.
+func (db *Database) query(ctx context.Context, readOnly bool, query string, args []any) (*Rows, error) {
 	if db.noopDB {
 		return nil, errNoopDB
 	}
 
+	db, err := db.isolated(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	db.init()
 
 	var (
@@ -269,12 +329,29 @@ func (db *Database) Query(ctx context.Context, query string, args ...interface{}
 		startEventID = curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
 			EventParams: eventParams,
 			Query:       query,
-			Stack:       stack.Build(4),
+			Stack:       stack.Build(5),
 		})
 	}
 
-	rows, err := db.pool.Query(markTraced(ctx), query, args...)
+	if db.mysql != nil {
+		rows, err := db.mysql.QueryContext(ctx, query, args...)
+		err = convertErr(err)
+		captureQuery(curr.Req, db.name, query, args).Err(err)
+
+		if curr.Trace != nil {
+			curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		return &Rows{ctx: ctx, sql: rows}, nil
+	}
+
+	rows, err := db.pgxQuery(markTraced(ctx), readOnly, query, args)
 	err = convertErr(err)
+	capture := captureQuery(curr.Req, db.name, query, args)
+	capture.Err(err)
 
 	if curr.Trace != nil {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
@@ -283,17 +360,27 @@ func (db *Database) Query(ctx context.Context, query string, args ...interface{}
 	if err != nil {
 		return nil, err
 	}
-	return &Rows{std: rows}, nil
+	return &Rows{ctx: ctx, std: rows, capture: capture}, nil
 }
 
 // QueryRow executes a query that is expected to return at most one row.
 //
 // See (*database/sql.DB).QueryRowContext() for additional documentation.
 func (db *Database) QueryRow(ctx context.Context, query string, args ...interface{}) *Row {
+	return db.queryRow(ctx, false, query, args)
+}
+
+// queryRow implements QueryRow, running it on a read replica if readOnly is set.
+func (db *Database) queryRow(ctx context.Context, readOnly bool, query string, args []any) *Row {
 	if db.noopDB {
 		return &Row{err: errNoopDB}
 	}
 
+	db, err := db.isolated(ctx)
+	if err != nil {
+		return &Row{err: err}
+	}
+
 	db.init()
 
 	var (
@@ -312,16 +399,26 @@ func (db *Database) QueryRow(ctx context.Context, query string, args ...interfac
 		startEventID = curr.Trace.DBQueryStart(trace2.DBQueryStartParams{
 			EventParams: eventParams,
 			Query:       query,
-			Stack:       stack.Build(4),
+			Stack:       stack.Build(5),
 		})
 	}
 
-	rows, err := db.pool.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
-	r := &Row{rows: rows, err: err}
+	var r *Row
+	if db.mysql != nil {
+		rows, err := db.mysql.QueryContext(ctx, query, args...)
+		err = convertErr(err)
+		captureQuery(curr.Req, db.name, query, args).Err(err)
+		r = &Row{ctx: ctx, sql: rows, err: err}
+	} else {
+		rows, err := db.pgxQuery(markTraced(ctx), readOnly, query, args)
+		err = convertErr(err)
+		capture := captureQuery(curr.Req, db.name, query, args)
+		capture.Err(err)
+		r = &Row{ctx: ctx, rows: rows, err: err, capture: capture}
+	}
 
 	if curr.Trace != nil {
-		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		curr.Trace.DBQueryEnd(eventParams, startEventID, r.err)
 	}
 
 	return r
@@ -335,8 +432,21 @@ func (db *Database) Begin(ctx context.Context) (*Tx, error) {
 		return nil, errNoopDB
 	}
 
+	db, err := db.isolated(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	db.init()
-	tx, err := db.pool.Begin(markTraced(ctx))
+	var (
+		tx    pgx.Tx
+		sqlTx *sql.Tx
+	)
+	if db.mysql != nil {
+		sqlTx, err = db.mysql.BeginTx(ctx, nil)
+	} else {
+		tx, err = db.pool.Begin(markTraced(ctx))
+	}
 	err = convertErr(err)
 	if err != nil {
 		return nil, err
@@ -352,10 +462,11 @@ func (db *Database) Begin(ctx context.Context) (*Tx, error) {
 		}, stack.Build(4))
 	}
 
-	return &Tx{mgr: db.mgr, std: tx, startID: startID}, nil
+	return &Tx{mgr: db.mgr, db: db.name, std: tx, sql: sqlTx, startID: startID}, nil
 }
 
 // Driver returns the underlying database driver for this database connection pool.
+// It's not supported by databases using the MySQL engine; use (*Database).Stdlib instead.
 //
 //	var db = sqldb.Driver[*pgxpool.Pool](sqldb.Named("mydatabase"))
 //
@@ -366,11 +477,19 @@ func (db *Database) Begin(ctx context.Context) (*Tx, error) {
 // this will be made with backwards compatibility in mind, providing ample notice and
 // time to migrate in an opt-in fashion.
 func Driver[T SupportedDrivers](db *Database) T {
-	db.init()
 	if db.noopDB {
 		var zero T
 		return zero
 	}
+	db, err := db.isolated(context.Background())
+	if err != nil {
+		panic(err.Error())
+	}
+
+	db.init()
+	if db.mysql != nil {
+		panic(errMySQLUnsupported.Error() + ": use (*Database).Stdlib instead of sqldb.Driver")
+	}
 
 	return any(db.pool).(T)
 }
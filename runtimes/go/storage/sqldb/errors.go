@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 
@@ -85,9 +86,14 @@ func (pe *Error) Unwrap() error {
 }
 
 func convertErr(err error) error {
-	var pgerr *pgconn.PgError
+	var (
+		pgerr *pgconn.PgError
+		myerr *mysql.MySQLError
+	)
 	if errors.As(err, &pgerr) {
 		err = convertPgError(pgerr)
+	} else if errors.As(err, &myerr) {
+		err = convertMySQLError(myerr)
 	}
 
 	switch {
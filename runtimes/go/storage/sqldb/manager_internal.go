@@ -2,7 +2,10 @@ package sqldb
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -12,6 +15,7 @@ import (
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/metrics"
 )
 
 // Manager manages database connections.
@@ -20,19 +24,37 @@ type Manager struct {
 	rt         *reqtrack.RequestTracker
 	ts         *testsupport.Manager
 	rootLogger zerolog.Logger
+	metrics    *poolMetrics // nil if the pool metrics aren't exported
 
-	mu  sync.RWMutex
-	dbs map[string]*Database
+	// isolationMode is the database isolation mode for tests, if any.
+	isolationMode string
+
+	mu        sync.RWMutex
+	dbs       map[string]*Database
+	roDBs     map[string]*Database // databases accessed through read access grants, keyed by name
+	pkgClones map[string]*Database // databases isolated to the test binary, keyed by name
 }
 
-func NewManager(runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager, rootLogger zerolog.Logger) *Manager {
-	return &Manager{
+func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager, reg *metrics.Registry, rootLogger zerolog.Logger) *Manager {
+	mgr := &Manager{
 		runtime:    runtime,
 		rt:         rt,
 		ts:         ts,
 		rootLogger: rootLogger,
 		dbs:        make(map[string]*Database),
+		roDBs:      make(map[string]*Database),
+		pkgClones:  make(map[string]*Database),
+	}
+	if runtime.EnvType == "test" {
+		mgr.isolationMode = os.Getenv("ENCORE_TEST_DB_ISOLATION")
+	}
+	// Metrics are reported per service, so they're only exported
+	// by processes running services.
+	if reg != nil && len(static.BundledServices) > 0 {
+		mgr.metrics = newPoolMetrics(reg, static.BundledServices)
+		reg.RegisterCollector(func() { mgr.metrics.collect(mgr) })
 	}
+	return mgr
 }
 
 // GetCurrentDB gets the database for the current request.
@@ -62,50 +84,138 @@ func (mgr *Manager) GetDB(dbName string) *Database {
 	if db, ok := mgr.dbs[dbName]; ok {
 		return db
 	}
-	hooks := &hookList{}
-	pool, found := mgr.getPool(dbName, "", hooks)
 	db = &Database{
 		name:     dbName,
 		origName: dbName,
 		mgr:      mgr,
-		noopDB:   !found,
-		pool:     pool,
-		hooks:    hooks,
+		hooks:    &hookList{},
+		session:  &sessionState{},
+	}
+	if srv, dbCfg, found := mgr.dbConfig(dbName); !found {
+		db.noopDB = true
+	} else if isMySQL(srv) {
+		pool, err := openMySQL(srv, dbCfg, "")
+		if err != nil {
+			panic("sqldb: setup db: " + err.Error())
+		}
+		db.mysql = pool
+	} else {
+		db.pool, _ = mgr.getPool(dbName, "", false, db.hooks, db.session)
 	}
 	mgr.dbs[dbName] = db
 	return db
 }
 
-// getPool returns a database connection pool for the given database name.
-// Each time it's called it returns a new pool.
-func (mgr *Manager) getPool(encoreName, dbNameOverride string, hooks *hookList) (pool *pgxpool.Pool, found bool) {
-	var db *config.SQLDatabase
+// GetReadOnlyDB gets the database with the given name for access through
+// a read access grant, connecting with the database's read-only credentials.
+func (mgr *Manager) GetReadOnlyDB(dbName string) *Database {
+	mgr.mu.RLock()
+	db, ok := mgr.roDBs[dbName]
+	mgr.mu.RUnlock()
+	if ok {
+		return db
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if db, ok := mgr.roDBs[dbName]; ok {
+		return db
+	}
+	db = &Database{
+		name:      dbName,
+		origName:  dbName,
+		mgr:       mgr,
+		hooks:     &hookList{},
+		session:   &sessionState{},
+		readGrant: true,
+	}
+	if srv, dbCfg, found := mgr.dbConfig(dbName); !found {
+		db.noopDB = true
+	} else if isMySQL(srv) {
+		pool, err := openMySQL(srv, dbCfg, "")
+		if err != nil {
+			panic("sqldb: setup db: " + err.Error())
+		}
+		db.mysql = pool
+	} else {
+		db.pool, _ = mgr.getPool(dbName, "", true, db.hooks, db.session)
+	}
+	mgr.roDBs[dbName] = db
+	return db
+}
+
+// dbConfig returns the configuration of the database with the given name,
+// and of the server it's on.
+func (mgr *Manager) dbConfig(encoreName string) (srv *config.SQLServer, db *config.SQLDatabase, found bool) {
 	for _, d := range mgr.runtime.SQLDatabases {
 		if d.EncoreName == encoreName {
-			db = d
-			break
+			return mgr.runtime.SQLServers[d.ServerID], d, true
 		}
 	}
-	if db == nil {
+	return nil, nil, false
+}
+
+// getPool returns a database connection pool for the given database name.
+// If readGrant is true the pool connects for access through a read access grant.
+// Each time it's called it returns a new pool.
+func (mgr *Manager) getPool(encoreName, dbNameOverride string, readGrant bool, hooks *hookList, session *sessionState) (pool *pgxpool.Pool, found bool) {
+	srv, db, found := mgr.dbConfig(encoreName)
+	if !found {
 		return nil, false
+	} else if isMySQL(srv) {
+		panic(fmt.Sprintf("sqldb: database %s uses the MySQL engine", encoreName))
+	}
+
+	return mgr.newPool(srv, db, dbNameOverride, readGrant, hooks, session), true
+}
+
+// getReplicaPools returns connection pools for the read replicas
+// of the given database, or nil if it has none.
+// Each time it's called it returns new pools.
+func (mgr *Manager) getReplicaPools(encoreName, dbNameOverride string, readGrant bool, hooks *hookList, session *sessionState) *replicaSet {
+	srv, db, found := mgr.dbConfig(encoreName)
+	if !found || isMySQL(srv) || len(srv.ReadReplicas) == 0 {
+		return nil
 	}
+	rs := &replicaSet{downUntil: make([]atomic.Int64, len(srv.ReadReplicas))}
+	for _, replica := range srv.ReadReplicas {
+		rs.pools = append(rs.pools, mgr.newPool(replica, db, dbNameOverride, readGrant, hooks, session))
+	}
+	return rs
+}
 
-	srv := mgr.runtime.SQLServers[db.ServerID]
+// newPool returns a new connection pool for the database db on the server srv.
+//
+// If readGrant is true the pool connects with the database's read-only credentials,
+// if it has any, and its transactions are read-only.
+func (mgr *Manager) newPool(srv *config.SQLServer, db *config.SQLDatabase, dbNameOverride string, readGrant bool, hooks *hookList, session *sessionState) *pgxpool.Pool {
 	cfg, err := dbConf(srv, db, dbNameOverride)
 	if err != nil {
 		panic("sqldb: " + err.Error())
 	}
+	if readGrant && db.ReadOnlyUser != "" {
+		cfg.ConnConfig.User = db.ReadOnlyUser
+		cfg.ConnConfig.Password = db.ReadOnlyPassword
+	}
 
 	cfg.ConnConfig.Tracer = &pgxTracer{mgr: mgr}
 	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		if readGrant {
+			if _, err := conn.Exec(ctx, "SET SESSION CHARACTERISTICS AS TRANSACTION READ ONLY"); err != nil {
+				return err
+			}
+		}
 		return hooks.runAfterConnectHooks(ctx, conn)
 	}
-	pool, err = pgxpool.NewWithConfig(context.Background(), cfg)
+	cfg.PrepareConn = func(ctx context.Context, conn *pgx.Conn) (bool, error) {
+		return mgr.prepareConn(ctx, session, conn)
+	}
+	cfg.BeforeClose = session.forget
+	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
 	if err != nil {
 		panic("sqldb: setup db: " + err.Error())
 	}
-
-	return pool, true
+	return pool
 }
 
 func (mgr *Manager) Shutdown(p *shutdown.Process) error {
@@ -117,13 +227,15 @@ func (mgr *Manager) Shutdown(p *shutdown.Process) error {
 	mgr.mu.RLock()
 	defer mgr.mu.RUnlock()
 
-	wg.Add(len(mgr.dbs))
-	for _, db := range mgr.dbs {
-		db := db
-		go func() {
-			defer wg.Done()
-			db.shutdown()
-		}()
+	wg.Add(len(mgr.dbs) + len(mgr.roDBs))
+	for _, dbs := range []map[string]*Database{mgr.dbs, mgr.roDBs} {
+		for _, db := range dbs {
+			db := db
+			go func() {
+				defer wg.Done()
+				db.shutdown()
+			}()
+		}
 	}
 	wg.Wait()
 	return nil
@@ -0,0 +1,165 @@
+package sqldb
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+
+	"encore.dev/metrics"
+)
+
+type poolLabels struct {
+	database string
+}
+
+func (l poolLabels) keyValues() []metrics.KeyValue {
+	return []metrics.KeyValue{{Key: "database", Value: l.database}}
+}
+
+// poolMetrics exports the connection pool statistics of the databases
+// as metrics, sampled each time the metrics are collected.
+type poolMetrics struct {
+	reg  *metrics.Registry
+	svcs []string // the services bundled in the binary
+
+	mu     sync.Mutex
+	groups map[uint16]*poolMetricGroups // keyed by service number
+	last   map[string]PoolStats         // the previous sample of each database
+}
+
+// poolMetricGroups are the pool metrics reported for a service.
+type poolMetricGroups struct {
+	maxConns    *metrics.GaugeGroup[poolLabels, int64]
+	openConns   *metrics.GaugeGroup[poolLabels, int64]
+	inUse       *metrics.GaugeGroup[poolLabels, int64]
+	idle        *metrics.GaugeGroup[poolLabels, int64]
+	waits       *metrics.CounterGroup[poolLabels, uint64]
+	waitSeconds *metrics.CounterGroup[poolLabels, float64]
+}
+
+func newPoolMetrics(reg *metrics.Registry, svcs []string) *poolMetrics {
+	return &poolMetrics{
+		reg:    reg,
+		svcs:   svcs,
+		groups: make(map[uint16]*poolMetricGroups),
+		last:   make(map[string]PoolStats),
+	}
+}
+
+// svcNum returns the service number the metrics of a database are reported
+// for: the service with the same name as the database if it's bundled in the
+// binary, as is the case for databases declared with sqldb.NewDatabase,
+// and otherwise the first bundled service.
+func (m *poolMetrics) svcNum(dbName string) uint16 {
+	if idx := slices.Index(m.svcs, dbName); idx >= 0 {
+		return uint16(idx + 1)
+	}
+	return 1
+}
+
+func (m *poolMetrics) groupsFor(svcNum uint16) *poolMetricGroups {
+	if g, ok := m.groups[svcNum]; ok {
+		return g
+	}
+	gauge := func(name string) *metrics.GaugeGroup[poolLabels, int64] {
+		return metrics.NewGaugeGroupInternal[poolLabels, int64](m.reg, name, metrics.GaugeConfig{
+			EncoreInternal_LabelMapper: poolLabels.keyValues,
+			EncoreInternal_SvcNum:      svcNum,
+		})
+	}
+	g := &poolMetricGroups{
+		maxConns:  gauge("e_sqldb_pool_conns_max"),
+		openConns: gauge("e_sqldb_pool_conns_open"),
+		inUse:     gauge("e_sqldb_pool_conns_in_use"),
+		idle:      gauge("e_sqldb_pool_conns_idle"),
+		waits: metrics.NewCounterGroupInternal[poolLabels, uint64](m.reg, "e_sqldb_pool_wait_total", metrics.CounterConfig{
+			EncoreInternal_LabelMapper: poolLabels.keyValues,
+			EncoreInternal_SvcNum:      svcNum,
+		}),
+		waitSeconds: metrics.NewCounterGroupInternal[poolLabels, float64](m.reg, "e_sqldb_pool_wait_seconds_total", metrics.CounterConfig{
+			EncoreInternal_LabelMapper: poolLabels.keyValues,
+			EncoreInternal_SvcNum:      svcNum,
+		}),
+	}
+	m.groups[svcNum] = g
+	return g
+}
+
+// collect samples the pool statistics of the databases used by the process.
+func (m *poolMetrics) collect(mgr *Manager) {
+	stats := mgr.poolStats()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, s := range stats {
+		g := m.groupsFor(m.svcNum(name))
+		labels := poolLabels{database: name}
+		g.maxConns.With(labels).Set(int64(s.MaxConns))
+		g.openConns.With(labels).Set(int64(s.OpenConns))
+		g.inUse.With(labels).Set(int64(s.InUse))
+		g.idle.With(labels).Set(int64(s.Idle))
+
+		// The pools report cumulative totals; add what changed since the last sample.
+		prev := m.last[name]
+		if s.WaitCount >= prev.WaitCount && s.WaitDuration >= prev.WaitDuration {
+			g.waits.With(labels).Add(uint64(s.WaitCount - prev.WaitCount))
+			g.waitSeconds.With(labels).Add((s.WaitDuration - prev.WaitDuration).Seconds())
+		}
+		m.last[name] = s
+	}
+}
+
+// poolStats returns the pool statistics of the databases
+// the process is configured to use, keyed by database name.
+func (mgr *Manager) poolStats() map[string]PoolStats {
+	mgr.mu.RLock()
+	dbs := slices.Collect(maps.Values(mgr.dbs))
+	mgr.mu.RUnlock()
+
+	stats := make(map[string]PoolStats, len(dbs))
+	for _, db := range dbs {
+		if db.IsConfigured() {
+			stats[db.name] = db.Stats()
+		}
+	}
+	return stats
+}
+
+// PoolStatsResponse is the response of the pool statistics route.
+type PoolStatsResponse struct {
+	Databases []DatabasePoolStats `json:"databases"`
+}
+
+type DatabasePoolStats struct {
+	Name           string `json:"name"`
+	MaxConns       int    `json:"max_conns"`
+	OpenConns      int    `json:"open_conns"`
+	InUse          int    `json:"in_use"`
+	Idle           int    `json:"idle"`
+	WaitCount      int64  `json:"wait_count"`
+	WaitDurationNs int64  `json:"wait_duration_ns"`
+}
+
+// handlePoolStats reports the pool statistics of the databases
+// the process uses, for the local development dashboard.
+func (mgr *Manager) handlePoolStats(w http.ResponseWriter, req *http.Request) {
+	stats := mgr.poolStats()
+	resp := PoolStatsResponse{Databases: []DatabasePoolStats{}}
+	for _, name := range slices.Sorted(maps.Keys(stats)) {
+		s := stats[name]
+		resp.Databases = append(resp.Databases, DatabasePoolStats{
+			Name:           name,
+			MaxConns:       s.MaxConns,
+			OpenConns:      s.OpenConns,
+			InUse:          s.InUse,
+			Idle:           s.Idle,
+			WaitCount:      s.WaitCount,
+			WaitDurationNs: s.WaitDuration.Nanoseconds(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,145 @@
+package sqldb
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/storage/sqldb/sqlerr"
+)
+
+// isMySQL reports whether srv is a MySQL server.
+func isMySQL(srv *config.SQLServer) bool {
+	return Engine(srv.Engine) == MySQL
+}
+
+// mysqlConf computes a suitable go-sql-driver/mysql config given a database config.
+// If dbNameOverride is provided, it overrides the database name used when connecting.
+func mysqlConf(srv *config.SQLServer, db *config.SQLDatabase, dbNameOverride string) (*mysql.Config, error) {
+	cfg := mysql.NewConfig()
+	cfg.User = db.User
+	cfg.Passwd = db.Password
+	cfg.DBName = db.DatabaseName
+	if dbNameOverride != "" {
+		cfg.DBName = dbNameOverride
+	}
+
+	// Scan DATE and DATETIME columns into time.Time.
+	cfg.ParseTime = true
+
+	// Handle different ways of expressing the host
+	if strings.HasPrefix(srv.Host, "/") {
+		cfg.Net, cfg.Addr = "unix", srv.Host // unix socket
+	} else if _, _, err := net.SplitHostPort(srv.Host); err == nil {
+		cfg.Net, cfg.Addr = "tcp", srv.Host // host:port
+	} else {
+		cfg.Net, cfg.Addr = "tcp", net.JoinHostPort(srv.Host, "3306") // hostname
+	}
+
+	if srv.ServerCACert != "" || srv.ClientCert != "" {
+		host, _, _ := net.SplitHostPort(cfg.Addr)
+		tlsCfg := &tls.Config{ServerName: host}
+
+		// If we have a server CA, set it in the TLS config.
+		if srv.ServerCACert != "" {
+			caCertPool := x509.NewCertPool()
+			if !caCertPool.AppendCertsFromPEM([]byte(srv.ServerCACert)) {
+				return nil, fmt.Errorf("invalid server ca cert")
+			}
+			tlsCfg.RootCAs = caCertPool
+		}
+
+		// If we have a client cert, set it in the TLS config.
+		if srv.ClientCert != "" {
+			cert, err := tls.X509KeyPair([]byte(srv.ClientCert), []byte(srv.ClientKey))
+			if err != nil {
+				return nil, fmt.Errorf("parse client cert: %v", err)
+			}
+			tlsCfg.Certificates = []tls.Certificate{cert}
+		}
+		cfg.TLS = tlsCfg
+	}
+
+	return cfg, nil
+}
+
+// openMySQL opens a connection pool to a database on a MySQL server.
+func openMySQL(srv *config.SQLServer, db *config.SQLDatabase, dbNameOverride string) (*sql.DB, error) {
+	cfg, err := mysqlConf(srv, db, dbNameOverride)
+	if err != nil {
+		return nil, err
+	}
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database config: %v", err)
+	}
+
+	pool := sql.OpenDB(connector)
+	maxConns := 30
+	if n := db.MaxConnections; n > 0 {
+		maxConns = n
+	}
+	pool.SetMaxOpenConns(maxConns)
+	pool.SetMaxIdleConns(maxConns)
+	return pool, nil
+}
+
+// sqlResult adapts the result of a query run with database/sql to ExecResult.
+type sqlResult struct {
+	res sql.Result
+}
+
+func (r sqlResult) RowsAffected() int64 {
+	n, _ := r.res.RowsAffected()
+	return n
+}
+
+// LastInsertId returns the id of the row inserted by the query,
+// such as the value of an AUTO_INCREMENT column.
+func (r sqlResult) LastInsertId() (int64, error) {
+	return r.res.LastInsertId()
+}
+
+// convertMySQLError converts an error reported by a MySQL server to an *Error.
+func convertMySQLError(src *mysql.MySQLError) error {
+	return &Error{
+		Code:         mapMySQLCode(src.Number),
+		Severity:     sqlerr.SeverityError,
+		DatabaseCode: string(src.SQLState[:]),
+		Message:      src.Message,
+		driverErr:    src,
+	}
+}
+
+// mapMySQLCode maps a MySQL error number to a Code.
+// MySQL reports many constraint violations with the same SQLSTATE,
+// so they're told apart by their error number instead.
+func mapMySQLCode(number uint16) sqlerr.Code {
+	switch number {
+	case 1048: // ER_BAD_NULL_ERROR
+		return sqlerr.NotNullViolation
+	case 1216, 1217, 1451, 1452: // ER_NO_REFERENCED_ROW(_2), ER_ROW_IS_REFERENCED(_2)
+		return sqlerr.ForeignKeyViolation
+	case 1062, 1586: // ER_DUP_ENTRY, ER_DUP_ENTRY_WITH_KEY_NAME
+		return sqlerr.UniqueViolation
+	case 3819: // ER_CHECK_CONSTRAINT_VIOLATED
+		return sqlerr.CheckViolation
+	case 1213: // ER_LOCK_DEADLOCK
+		return sqlerr.DeadlockDetected
+	case 1040: // ER_CON_COUNT_ERROR
+		return sqlerr.TooManyConnections
+	default:
+		return sqlerr.Other
+	}
+}
+
+// errMySQLUnsupported is reported when using functionality
+// that isn't supported by databases using the MySQL engine.
+var errMySQLUnsupported = errors.New("sqldb: not supported by databases using the MySQL engine")
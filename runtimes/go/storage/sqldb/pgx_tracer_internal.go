@@ -2,6 +2,7 @@ package sqldb
 
 import (
 	"context"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 
@@ -35,11 +36,16 @@ type queryValue struct {
 }
 
 func (t *pgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	curr := t.mgr.rt.Current()
+	if !isTxControl(data.SQL) {
+		// Record the query for test budgets, regardless of how it is traced.
+		curr.Req.RecordDBQuery(data.SQL)
+	}
+
 	if ctx.Value(pgxAlreadyTracedKey) != nil {
 		return ctx
 	}
 
-	curr := t.mgr.rt.Current()
 	if curr.Req != nil && curr.Trace != nil {
 		eventParams := trace2.EventParams{
 			TraceID: curr.Req.TraceID,
@@ -67,6 +73,17 @@ func (t *pgxTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.
 	}
 }
 
+// isTxControl reports whether query is a transaction control statement
+// issued by pgx itself when beginning or ending a transaction.
+func isTxControl(query string) bool {
+	verb, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	switch strings.ToLower(verb) {
+	case "begin", "commit", "rollback":
+		return true
+	}
+	return false
+}
+
 var (
 	_ pgx.QueryTracer = (*pgxTracer)(nil)
 	_ pgx.QueryTracer = (*pgxTracer)(nil)
@@ -4,6 +4,8 @@ package sqldb
 
 import (
 	"context"
+
+	"encore.dev/appruntime/shared/startup"
 )
 
 // NewDatabase declares a new SQL database.
@@ -19,7 +21,12 @@ import (
 // in kebab-case (lowercase alphanumerics and hyphen separated). Once created and deployed never
 // change the database name, or else a new database will be created.
 func NewDatabase(name string, config DatabaseConfig) *Database {
-	return Singleton.GetDB(name)
+	defer startup.Singleton.Resource("database", name)()
+	db := Singleton.GetDB(name)
+	if config.RowLevelSecurity {
+		db.session.rowLevelSecurity.Store(true)
+	}
+	return db
 }
 
 // DatabaseConfig specifies configuration for declaring a new database.
@@ -33,6 +40,33 @@ type DatabaseConfig struct {
 	//
 	// Migrations are an ordered sequence of sql files of the format <number>_<description>.up.sql.
 	Migrations string
+
+	// RowLevelSecurity enables support for Postgres row-level security.
+	//
+	// Encore verifies at build time that every table created by the migrations
+	// has row-level security enabled and at least one policy, or has it
+	// explicitly disabled with "ALTER TABLE ... DISABLE ROW LEVEL SECURITY".
+	//
+	// Queries set the session variable "encore.user_id" to the uid of the
+	// authenticated user, along with the session variables provided by
+	// auth data implementing SessionVarsProvider, for use in policies.
+	RowLevelSecurity bool
+
+	// Engine is the database engine to use, either Postgres or MySQL.
+	// If unset it defaults to Postgres.
+	//
+	// The migrations must be written in the SQL dialect of the engine.
+	// Changing the engine of a database that has been deployed
+	// creates a new, empty database.
+	Engine Engine
+
+	// ReadAccess lists the services granted read-only access to the database,
+	// which they get with NamedReadOnly. Other services can't use the database,
+	// which is otherwise possible with Named.
+	//
+	// It must be a slice literal of service names, and is only supported
+	// by databases using the Postgres engine.
+	ReadAccess []string
 }
 
 // Exec executes a query without returning any rows.
@@ -117,6 +151,18 @@ func Named(name constStr) *Database {
 	return Singleton.GetDB(string(name))
 }
 
+// NamedReadOnly returns a read-only handle to the database with the given name,
+// owned by another service. The database must grant the service read access
+// with ReadAccess in its DatabaseConfig, which Encore verifies at build time.
+//
+// The queries run in read-only transactions, connecting with the
+// database's read-only credentials where they're configured.
+//
+// The name must be a string literal constant, to facilitate static analysis.
+func NamedReadOnly(name constStr) *ReadOnlyDatabase {
+	return &ReadOnlyDatabase{db: Singleton.GetReadOnlyDB(string(name))}
+}
+
 func getCurrentDB() *Database {
 	return Singleton.GetCurrentDB()
 }
@@ -0,0 +1,70 @@
+package sqldb
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PoolStats are statistics about the connection pool of a database,
+// for diagnosing pool exhaustion.
+//
+// Encore also exports them as metrics (see https://encore.dev/docs/go/observability/metrics),
+// labeled with the name of the database.
+type PoolStats struct {
+	// MaxConns is the maximum number of open connections.
+	MaxConns int
+
+	// OpenConns is the number of open connections, both in use and idle.
+	OpenConns int
+
+	// InUse is the number of connections currently in use.
+	InUse int
+
+	// Idle is the number of idle connections.
+	Idle int
+
+	// WaitCount is the total number of times a query had to wait
+	// for a connection because all connections were in use.
+	WaitCount int64
+
+	// WaitDuration is the total time spent waiting for a connection.
+	WaitDuration time.Duration
+}
+
+// Stats returns statistics about the connection pool of the database,
+// including the connections opened through [Database.Stdlib].
+//
+// If the service isn't configured to use the database
+// it returns the zero value.
+func (db *Database) Stats() PoolStats {
+	db.init()
+	if db.noopDB {
+		return PoolStats{}
+	}
+
+	var stats PoolStats
+	if db.pool != nil {
+		s := db.pool.Stat()
+		stats = PoolStats{
+			MaxConns:     int(s.MaxConns()),
+			OpenConns:    int(s.TotalConns()),
+			InUse:        int(s.AcquiredConns()),
+			Idle:         int(s.IdleConns()),
+			WaitCount:    s.EmptyAcquireCount(),
+			WaitDuration: s.EmptyAcquireWaitTime(),
+		}
+	}
+	for _, std := range []*sql.DB{db.mysql, db.stdlib.Load()} {
+		if std == nil {
+			continue
+		}
+		s := std.Stats()
+		stats.MaxConns += s.MaxOpenConnections
+		stats.OpenConns += s.OpenConnections
+		stats.InUse += s.InUse
+		stats.Idle += s.Idle
+		stats.WaitCount += s.WaitCount
+		stats.WaitDuration += s.WaitDuration
+	}
+	return stats
+}
@@ -0,0 +1,84 @@
+package sqldb
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/metrics"
+)
+
+func TestPoolMetrics(t *testing.T) {
+	openDB := func(name string, maxConns int) *Database {
+		std, err := sql.Open("mysql", "user@/"+name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = std.Close() })
+		std.SetMaxOpenConns(maxConns)
+		return &Database{name: name, origName: name, mysql: std}
+	}
+	mgr := &Manager{dbs: map[string]*Database{
+		"orders": openDB("orders", 5),
+		"shared": openDB("shared", 7),
+		"other":  {name: "other", origName: "other", noopDB: true},
+	}}
+
+	if got := mgr.dbs["orders"].Stats(); got != (PoolStats{MaxConns: 5}) {
+		t.Fatalf("got stats %+v, want only MaxConns 5", got)
+	}
+
+	reg := metrics.NewRegistry(reqtrack.New(zerolog.Logger{}, nil, nil), 2)
+	m := newPoolMetrics(reg, []string{"users", "orders"})
+	reg.RegisterCollector(func() { m.collect(mgr) })
+
+	type key struct {
+		name, database string
+	}
+	type sample struct {
+		svcNum uint16
+		val    any
+	}
+	got := make(map[key]sample)
+	for _, c := range reg.Collect() {
+		if len(c.Labels) != 1 || c.Labels[0].Key != "database" {
+			t.Fatalf("metric %s: got labels %+v, want the database", c.Info.Name(), c.Labels)
+		}
+		k := key{c.Info.Name(), c.Labels[0].Value}
+		switch vals := c.Val.(type) {
+		case []int64:
+			got[k] = sample{c.Info.SvcNum(), vals[0]}
+		case []uint64:
+			got[k] = sample{c.Info.SvcNum(), vals[0]}
+		case []float64:
+			got[k] = sample{c.Info.SvcNum(), vals[0]}
+		}
+	}
+
+	// The metrics of a database are reported for the service with the same name,
+	// or else the first service. Databases that aren't configured are omitted.
+	want := map[key]sample{
+		{"e_sqldb_pool_conns_max", "orders"}:          {2, int64(5)},
+		{"e_sqldb_pool_conns_open", "orders"}:         {2, int64(0)},
+		{"e_sqldb_pool_conns_in_use", "orders"}:       {2, int64(0)},
+		{"e_sqldb_pool_conns_idle", "orders"}:         {2, int64(0)},
+		{"e_sqldb_pool_wait_total", "orders"}:         {2, uint64(0)},
+		{"e_sqldb_pool_wait_seconds_total", "orders"}: {2, float64(0)},
+		{"e_sqldb_pool_conns_max", "shared"}:          {1, int64(7)},
+		{"e_sqldb_pool_conns_open", "shared"}:         {1, int64(0)},
+		{"e_sqldb_pool_conns_in_use", "shared"}:       {1, int64(0)},
+		{"e_sqldb_pool_conns_idle", "shared"}:         {1, int64(0)},
+		{"e_sqldb_pool_wait_total", "shared"}:         {1, uint64(0)},
+		{"e_sqldb_pool_wait_seconds_total", "shared"}: {1, float64(0)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d metrics, want %d: %+v", len(got), len(want), got)
+	}
+	for k, w := range want {
+		if got[k] != w {
+			t.Errorf("%s{database=%s}: got %+v, want %+v", k.name, k.database, got[k], w)
+		}
+	}
+}
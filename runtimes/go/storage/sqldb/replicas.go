@@ -0,0 +1,111 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReadOnly returns a handle to the database for read-only queries.
+// The queries are routed to the database's read replicas, configured
+// in the infrastructure configuration, spreading them across the replicas.
+//
+// If a replica can't be reached its queries fall back to the primary,
+// and it isn't used again for a while. If the database has no read replicas,
+// such as when running locally, or uses the MySQL engine,
+// the queries are run on the primary.
+//
+// Replicas may lag behind the primary, so queries that must
+// observe the effects of recent writes should use the database itself.
+func (db *Database) ReadOnly() *ReadOnlyDatabase {
+	return &ReadOnlyDatabase{db: db}
+}
+
+// ReadOnlyDatabase is a handle to a database for read-only queries,
+// returned by [Database.ReadOnly].
+type ReadOnlyDatabase struct {
+	db *Database
+}
+
+// Query executes a query that returns rows, typically a SELECT,
+// on a read replica of the database.
+// The args are for any placeholder parameters in the query.
+//
+// See (*database/sql.DB).QueryContext() for additional documentation.
+func (ro *ReadOnlyDatabase) Query(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	return ro.db.query(ctx, true, query, args)
+}
+
+// QueryRow executes a query that is expected to return at most one row,
+// on a read replica of the database.
+//
+// See (*database/sql.DB).QueryRowContext() for additional documentation.
+func (ro *ReadOnlyDatabase) QueryRow(ctx context.Context, query string, args ...interface{}) *Row {
+	return ro.db.queryRow(ctx, true, query, args)
+}
+
+// replicaDownDuration is how long a replica that couldn't be reached
+// isn't used for before it's tried again.
+const replicaDownDuration = 30 * time.Second
+
+// replicaSet is the set of connection pools of a database's read replicas.
+type replicaSet struct {
+	pools     []*pgxpool.Pool
+	downUntil []atomic.Int64 // unix nanoseconds until when each replica isn't used
+	next      atomic.Uint32
+}
+
+// pick returns the next replica to use, in round-robin order,
+// skipping the replicas that are down. It reports false if all are.
+func (rs *replicaSet) pick() (idx int, pool *pgxpool.Pool, ok bool) {
+	now := time.Now().UnixNano()
+	start := int(rs.next.Add(1))
+	for i := range rs.pools {
+		idx := (start + i) % len(rs.pools)
+		if rs.downUntil[idx].Load() <= now {
+			return idx, rs.pools[idx], true
+		}
+	}
+	return 0, nil, false
+}
+
+// markDown marks the replica idx as down.
+func (rs *replicaSet) markDown(idx int) {
+	rs.downUntil[idx].Store(time.Now().Add(replicaDownDuration).UnixNano())
+}
+
+func (rs *replicaSet) close() {
+	if rs == nil {
+		return
+	}
+	for _, pool := range rs.pools {
+		pool.Close()
+	}
+}
+
+// pgxQuery runs a query on the primary, or on a read replica if readOnly is set
+// and the database has any. If the replica can't be reached it falls back to the primary.
+func (db *Database) pgxQuery(ctx context.Context, readOnly bool, query string, args []any) (pgx.Rows, error) {
+	if readOnly && db.replicas != nil {
+		if idx, pool, ok := db.replicas.pick(); ok {
+			rows, err := pool.Query(ctx, query, args...)
+			if err == nil || !isUnreachable(err) || ctx.Err() != nil {
+				return rows, err
+			}
+			db.replicas.markDown(idx)
+		}
+	}
+	return db.pool.Query(ctx, query, args...)
+}
+
+// isUnreachable reports whether err indicates the server couldn't be reached,
+// before the query was sent to it.
+func isUnreachable(err error) bool {
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || pgconn.SafeToRetry(err)
+}
@@ -0,0 +1,58 @@
+package sqldb
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestReplicaSetPick(t *testing.T) {
+	a, b := &pgxpool.Pool{}, &pgxpool.Pool{}
+	rs := &replicaSet{pools: []*pgxpool.Pool{a, b}, downUntil: make([]atomic.Int64, 2)}
+
+	seen := map[*pgxpool.Pool]int{}
+	for range 4 {
+		_, pool, ok := rs.pick()
+		if !ok {
+			t.Fatal("no replica picked")
+		}
+		seen[pool]++
+	}
+	if seen[a] != 2 || seen[b] != 2 {
+		t.Fatalf("replicas not picked in round-robin order: %v", seen)
+	}
+
+	idx, _, _ := rs.pick()
+	rs.markDown(idx)
+	for range 3 {
+		if got, _, ok := rs.pick(); !ok || got == idx {
+			t.Fatalf("picked replica %d (ok=%v), want the replica that isn't down", got, ok)
+		}
+	}
+
+	rs.markDown(1 - idx)
+	if _, _, ok := rs.pick(); ok {
+		t.Fatal("picked a replica while all are down")
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	cfg, err := pgxpool.ParseConfig("host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	_, err = pool.Query(context.Background(), "SELECT 1")
+	if err == nil {
+		t.Fatal("query succeeded without a server")
+	} else if !isUnreachable(err) {
+		t.Fatalf("isUnreachable(%v) = false, want true", err)
+	}
+}
@@ -0,0 +1,143 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SessionVars are Postgres configuration parameters to set on the connections
+// running queries, for use in row-level security policies with current_setting.
+//
+// The names of custom parameters must contain a dot, like "app.tenant_id".
+type SessionVars map[string]string
+
+// UserIDSessionVar is the session variable set to the uid of the user
+// making the request, for databases using row-level security.
+// It's set to the empty string when there is no authenticated user.
+const UserIDSessionVar = "encore.user_id"
+
+// SessionVarsProvider is implemented by auth data types that provide session
+// variables to set for queries made by authenticated requests, like the
+// id of the tenant the user belongs to.
+//
+// They're only set for databases using row-level security.
+type SessionVarsProvider interface {
+	SQLSessionVars() SessionVars
+}
+
+type sessionVarsKey struct{}
+
+// WithSessionVars returns a copy of ctx that sets the given session variables
+// for queries made with it, in addition to those of ctx.
+// They take precedence over the session variables Encore sets automatically.
+//
+// Session variables are not set for queries made with the *sql.DB returned by Stdlib.
+func WithSessionVars(ctx context.Context, vars SessionVars) context.Context {
+	merged := maps.Clone(sessionVarsFromContext(ctx))
+	if merged == nil {
+		merged = make(SessionVars, len(vars))
+	}
+	maps.Copy(merged, vars)
+	return context.WithValue(ctx, sessionVarsKey{}, merged)
+}
+
+func sessionVarsFromContext(ctx context.Context) SessionVars {
+	vars, _ := ctx.Value(sessionVarsKey{}).(SessionVars)
+	return vars
+}
+
+// sessionState tracks the session variables set on the connections of a database.
+type sessionState struct {
+	// rowLevelSecurity is whether the database uses row-level security,
+	// in which case session variables are set from the request's auth information.
+	rowLevelSecurity atomic.Bool
+
+	mu    sync.Mutex
+	conns map[*pgx.Conn]SessionVars // the variables set on each connection
+}
+
+// sessionVars computes the session variables for a query made with ctx
+// by the current request.
+func (mgr *Manager) sessionVars(ctx context.Context, s *sessionState) SessionVars {
+	explicit := sessionVarsFromContext(ctx)
+	if !s.rowLevelSecurity.Load() {
+		return explicit
+	}
+
+	vars := SessionVars{UserIDSessionVar: ""}
+	if curr := mgr.rt.Current(); curr.Req != nil {
+		var authData any
+		if data := curr.Req.RPCData; data != nil {
+			vars[UserIDSessionVar], authData = string(data.UserID), data.AuthData
+		} else if test := curr.Req.Test; test != nil {
+			vars[UserIDSessionVar], authData = string(test.UserID), test.AuthData
+		}
+		if p, ok := authData.(SessionVarsProvider); ok {
+			maps.Copy(vars, p.SQLSessionVars())
+		}
+	}
+	maps.Copy(vars, explicit)
+	return vars
+}
+
+// prepareConn sets the session variables for a query made with ctx
+// on the connection before it's used, unless they're already set.
+func (mgr *Manager) prepareConn(ctx context.Context, s *sessionState, conn *pgx.Conn) (bool, error) {
+	want := mgr.sessionVars(ctx, s)
+
+	s.mu.Lock()
+	have := s.conns[conn]
+	s.mu.Unlock()
+
+	names, values := sessionVarChanges(have, want)
+	if len(names) == 0 {
+		return true, nil
+	}
+	_, err := conn.Exec(ctx, "SELECT set_config(name, value, false) FROM unnest($1::text[], $2::text[]) AS vars(name, value)", names, values)
+	if err != nil {
+		// Destroy the connection, as it's unknown which variables are set on it.
+		s.forget(conn)
+		return false, fmt.Errorf("sqldb: set session variables: %w", err)
+	}
+
+	s.mu.Lock()
+	if s.conns == nil {
+		s.conns = make(map[*pgx.Conn]SessionVars)
+	}
+	s.conns[conn] = want
+	s.mu.Unlock()
+	return true, nil
+}
+
+// forget forgets the session variables of a closed connection.
+func (s *sessionState) forget(conn *pgx.Conn) {
+	s.mu.Lock()
+	delete(s.conns, conn)
+	s.mu.Unlock()
+}
+
+// sessionVarChanges returns the session variables to set on a connection
+// with the variables have set to set the variables want.
+// Variables that are no longer wanted are set to the empty string,
+// which current_setting returns for unset variables that have been set before.
+func sessionVarChanges(have, want SessionVars) (names, values []string) {
+	for _, name := range slices.Sorted(maps.Keys(want)) {
+		if val, ok := have[name]; !ok || val != want[name] {
+			names = append(names, name)
+			values = append(values, want[name])
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(have)) {
+		if _, ok := want[name]; !ok && have[name] != "" {
+			names = append(names, name)
+			values = append(values, "")
+		}
+	}
+	return names, values
+}
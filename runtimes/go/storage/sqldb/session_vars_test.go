@@ -0,0 +1,55 @@
+package sqldb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestWithSessionVars(t *testing.T) {
+	ctx := WithSessionVars(context.Background(), SessionVars{"app.tenant_id": "a", "app.role": "admin"})
+	child := WithSessionVars(ctx, SessionVars{"app.tenant_id": "b"})
+
+	if got, want := sessionVarsFromContext(child), (SessionVars{"app.tenant_id": "b", "app.role": "admin"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("child vars = %v, want %v", got, want)
+	}
+	if got, want := sessionVarsFromContext(ctx), (SessionVars{"app.tenant_id": "a", "app.role": "admin"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("parent vars = %v, want %v", got, want)
+	}
+}
+
+func TestSessionVarChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		have, want SessionVars
+		names      []string
+		values     []string
+	}{
+		{
+			name: "unchanged",
+			have: SessionVars{"encore.user_id": "u1"},
+			want: SessionVars{"encore.user_id": "u1"},
+		},
+		{
+			name:   "new_connection",
+			want:   SessionVars{"encore.user_id": "", "app.tenant_id": "t1"},
+			names:  []string{"app.tenant_id", "encore.user_id"},
+			values: []string{"t1", ""},
+		},
+		{
+			name:   "changed_and_removed",
+			have:   SessionVars{"encore.user_id": "u1", "app.tenant_id": "t1", "app.unset": ""},
+			want:   SessionVars{"encore.user_id": "u2"},
+			names:  []string{"encore.user_id", "app.tenant_id"},
+			values: []string{"u2", ""},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			names, values := sessionVarChanges(test.have, test.want)
+			if !reflect.DeepEqual(names, test.names) || !reflect.DeepEqual(values, test.values) {
+				t.Errorf("got %v=%v, want %v=%v", names, values, test.names, test.values)
+			}
+		})
+	}
+}
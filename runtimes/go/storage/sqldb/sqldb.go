@@ -9,6 +9,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 
+	"encore.dev/appruntime/exported/debugbundle"
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/exported/trace2"
@@ -19,7 +20,24 @@ import (
 // It must be tested against with errors.Is.
 var ErrNoRows = sql.ErrNoRows
 
+// Engine is the database engine of a database.
+type Engine string
+
+const (
+	// Postgres is the PostgreSQL engine. It's the default engine.
+	Postgres Engine = "postgres"
+
+	// MySQL is the MySQL engine.
+	//
+	// Databases using MySQL don't support row-level security, hooks
+	// or [Driver]; use [Database.Stdlib] for direct access to the database.
+	MySQL Engine = "mysql"
+)
+
 // ExecResult is the result of an Exec query.
+//
+// For databases using the MySQL engine it also implements
+// interface{ LastInsertId() (int64, error) }.
 type ExecResult interface {
 	// RowsAffected returns the number of rows affected. If the result was not
 	// for a row affecting command (e.g. "CREATE TABLE") then it returns 0.
@@ -31,7 +49,9 @@ type ExecResult interface {
 // See *database/sql.Tx for additional documentation.
 type Tx struct {
 	mgr *Manager
+	db  string // database name
 	std pgx.Tx
+	sql *sql.Tx // set instead of std for databases using the MySQL engine
 
 	startID model.TraceEventID
 }
@@ -47,7 +67,12 @@ func (tx *Tx) Commit() error { return tx.commit() }
 func (tx *Tx) Rollback() error { return tx.rollback() }
 
 func (tx *Tx) commit() error {
-	err := tx.std.Commit(markTraced(context.Background()))
+	var err error
+	if tx.sql != nil {
+		err = tx.sql.Commit()
+	} else {
+		err = tx.std.Commit(markTraced(context.Background()))
+	}
 	err = convertErr(err)
 
 	if curr := tx.mgr.rt.Current(); curr.Req != nil && curr.Trace != nil {
@@ -69,7 +94,12 @@ func (tx *Tx) commit() error {
 }
 
 func (tx *Tx) rollback() error {
-	err := tx.std.Rollback(markTraced(context.Background()))
+	var err error
+	if tx.sql != nil {
+		err = tx.sql.Rollback()
+	} else {
+		err = tx.std.Rollback(markTraced(context.Background()))
+	}
 	err = convertErr(err)
 
 	if curr := tx.mgr.rt.Current(); curr.Req != nil && curr.Trace != nil {
@@ -117,8 +147,19 @@ func (tx *Tx) exec(ctx context.Context, query string, args ...interface{}) (Exec
 		})
 	}
 
-	res, err := tx.std.Exec(markTraced(ctx), query, args...)
+	var (
+		res ExecResult
+		err error
+	)
+	if tx.sql != nil {
+		var sqlRes sql.Result
+		sqlRes, err = tx.sql.ExecContext(ctx, query, args...)
+		res = sqlResult{sqlRes}
+	} else {
+		res, err = tx.std.Exec(markTraced(ctx), query, args...)
+	}
 	err = convertErr(err)
+	captureQuery(curr.Req, tx.db, query, args).Err(err)
 
 	if startEventID > 0 {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
@@ -150,8 +191,25 @@ func (tx *Tx) Query(ctx context.Context, query string, args ...interface{}) (*Ro
 		})
 	}
 
+	if tx.sql != nil {
+		rows, err := tx.sql.QueryContext(ctx, query, args...)
+		err = convertErr(err)
+		captureQuery(curr.Req, tx.db, query, args).Err(err)
+
+		if startEventID > 0 {
+			curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		return &Rows{ctx: ctx, sql: rows}, nil
+	}
+
 	rows, err := tx.std.Query(markTraced(ctx), query, args...)
 	err = convertErr(err)
+	capture := captureQuery(curr.Req, tx.db, query, args)
+	capture.Err(err)
 
 	if startEventID > 0 {
 		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
@@ -160,7 +218,7 @@ func (tx *Tx) Query(ctx context.Context, query string, args ...interface{}) (*Ro
 	if err != nil {
 		return nil, err
 	}
-	return &Rows{std: rows}, nil
+	return &Rows{ctx: ctx, std: rows, capture: capture}, nil
 }
 
 func (tx *Tx) QueryRow(ctx context.Context, query string, args ...interface{}) *Row {
@@ -186,14 +244,24 @@ func (tx *Tx) QueryRow(ctx context.Context, query string, args ...interface{}) *
 		})
 	}
 
-	// pgx currently does not support .Err() on Row.
-	// Work around this by using Query.
-	rows, err := tx.std.Query(markTraced(ctx), query, args...)
-	err = convertErr(err)
-	r := &Row{rows: rows, err: err}
+	var r *Row
+	if tx.sql != nil {
+		rows, err := tx.sql.QueryContext(ctx, query, args...)
+		err = convertErr(err)
+		captureQuery(curr.Req, tx.db, query, args).Err(err)
+		r = &Row{ctx: ctx, sql: rows, err: err}
+	} else {
+		// pgx currently does not support .Err() on Row.
+		// Work around this by using Query.
+		rows, err := tx.std.Query(markTraced(ctx), query, args...)
+		err = convertErr(err)
+		capture := captureQuery(curr.Req, tx.db, query, args)
+		capture.Err(err)
+		r = &Row{ctx: ctx, rows: rows, err: err, capture: capture}
+	}
 
 	if startEventID > 0 {
-		curr.Trace.DBQueryEnd(eventParams, startEventID, err)
+		curr.Trace.DBQueryEnd(eventParams, startEventID, r.err)
 	}
 
 	return r
@@ -204,26 +272,56 @@ func (tx *Tx) QueryRow(ctx context.Context, query string, args ...interface{}) *
 //
 // See *database/sql.Rows for additional documentation.
 type Rows struct {
-	std pgx.Rows
+	ctx     context.Context // the context of the query
+	std     pgx.Rows
+	sql     *sql.Rows                  // set instead of std for databases using the MySQL engine
+	capture *debugbundle.CapturedQuery // nil if not capturing
 }
 
 // Close closes the Rows, preventing further enumeration.
 //
 // See (*database/sql.Rows).Close() for additional documentation.
-func (r *Rows) Close() { r.std.Close() }
+func (r *Rows) Close() {
+	if r.sql != nil {
+		_ = r.sql.Close()
+		return
+	}
+	r.std.Close()
+}
 
 // Scan copies the columns in the current row into the values pointed
 // at by dest. The number of values in dest must be the same as the
 // number of columns in Rows.
 //
 // See (*database/sql.Rows).Scan() for additional documentation.
-func (r *Rows) Scan(dest ...interface{}) error { return r.std.Scan(dest...) }
+func (r *Rows) Scan(dest ...interface{}) error {
+	if r.sql != nil {
+		if err := r.sql.Scan(dest...); err != nil {
+			return convertErr(err)
+		}
+		return decryptScanned(r.ctx, dest)
+	}
+
+	err := r.std.Scan(dest...)
+	if err == nil && r.capture != nil {
+		captureRow(r.capture, r.std)
+	}
+	if err == nil {
+		err = decryptScanned(r.ctx, dest)
+	}
+	return err
+}
 
 // Err returns the error, if any, that was encountered during iteration.
 // Err may be called after an explicit or implicit Close.
 //
 // See (*database/sql.Rows).Err() for additional documentation.
-func (r *Rows) Err() error { return r.std.Err() }
+func (r *Rows) Err() error {
+	if r.sql != nil {
+		return r.sql.Err()
+	}
+	return r.std.Err()
+}
 
 // Next prepares the next result row for reading with the Scan method. It
 // returns true on success, or false if there is no next result row or an error
@@ -233,14 +331,22 @@ func (r *Rows) Err() error { return r.std.Err() }
 // Every call to Scan, even the first one, must be preceded by a call to Next.
 //
 // See (*database/sql.Rows).Next() for additional documentation.
-func (r *Rows) Next() bool { return r.std.Next() }
+func (r *Rows) Next() bool {
+	if r.sql != nil {
+		return r.sql.Next()
+	}
+	return r.std.Next()
+}
 
 // Row is the result of calling QueryRow to select a single row.
 //
 // See *database/sql.Row for additional documentation.
 type Row struct {
-	rows pgx.Rows
-	err  error
+	ctx     context.Context // the context of the query
+	rows    pgx.Rows
+	sql     *sql.Rows // set instead of rows for databases using the MySQL engine
+	err     error
+	capture *debugbundle.CapturedQuery // nil if not capturing
 }
 
 // Scan copies the columns from the matched row into the values
@@ -251,20 +357,93 @@ func (r *Row) Scan(dest ...interface{}) error {
 	if r.err != nil {
 		return r.err
 	}
+	if r.sql != nil {
+		return r.scanSQL(dest)
+	}
 	if !r.rows.Next() {
 		if err := r.rows.Err(); err != nil {
 			return convertErr(err)
 		}
 		return errs.DropStackFrame(errs.WrapCode(sql.ErrNoRows, errs.NotFound, ""))
 	}
-	_ = r.rows.Scan(dest...)
+	err := r.rows.Scan(dest...)
+	if err == nil && r.capture != nil {
+		captureRow(r.capture, r.rows)
+	}
 	r.rows.Close()
-	return convertErr(r.rows.Err())
+	if err := r.rows.Err(); err != nil {
+		return convertErr(err)
+	}
+	return decryptScanned(r.ctx, dest)
 }
 
 func (r *Row) Err() error {
 	if r.err != nil {
 		return r.err
 	}
+	if r.sql != nil {
+		return convertErr(r.sql.Err())
+	}
 	return convertErr(r.rows.Err())
 }
+
+// scanSQL is like Scan, for rows queried with database/sql.
+func (r *Row) scanSQL(dest []any) error {
+	defer func() { _ = r.sql.Close() }()
+	if !r.sql.Next() {
+		if err := r.sql.Err(); err != nil {
+			return convertErr(err)
+		}
+		return errs.DropStackFrame(errs.WrapCode(sql.ErrNoRows, errs.NotFound, ""))
+	}
+	if err := r.sql.Scan(dest...); err != nil {
+		return convertErr(err)
+	}
+	if err := r.sql.Close(); err != nil {
+		return convertErr(err)
+	}
+	return decryptScanned(r.ctx, dest)
+}
+
+// scanDecrypter decrypts the values scanned into dest that are encrypted.
+// It's registered by encore.dev/storage/encryption, if the app uses it.
+var scanDecrypter func(ctx context.Context, dest []any) error
+
+// RegisterScanDecrypter registers fn to decrypt the encrypted values
+// scanned from query results.
+//
+//publicapigen:drop
+func RegisterScanDecrypter(fn func(ctx context.Context, dest []any) error) {
+	scanDecrypter = fn
+}
+
+func decryptScanned(ctx context.Context, dest []any) error {
+	if scanDecrypter == nil {
+		return nil
+	}
+	return scanDecrypter(ctx, dest)
+}
+
+// captureQuery records a query for the debug bundle of req, if it is capturing one.
+func captureQuery(req *model.Request, database, query string, args []any) *debugbundle.CapturedQuery {
+	if req == nil {
+		return nil
+	}
+	return req.Debug.Query(database, query, args)
+}
+
+// captureRow records the current row of rows for the debug bundle.
+func captureRow(capture *debugbundle.CapturedQuery, rows pgx.Rows) {
+	values, err := rows.Values()
+	if err != nil {
+		return
+	}
+	capture.Row(func() []string {
+		fields := rows.FieldDescriptions()
+		cols := make([]string, len(fields))
+		for i, f := range fields {
+			cols[i] = f.Name
+		}
+		return cols
+	}, values)
+}
@@ -28,8 +28,22 @@ func (mgr *Manager) NewTestDatabase(ctx context.Context, name string) (*Database
 	db := mgr.GetDB(name)
 	if db.noopDB {
 		return nil, fmt.Errorf("et: unknown database name: %q", name)
+	} else if db.mysql != nil {
+		return nil, fmt.Errorf("et: new test database %q: %w", name, errMySQLUnsupported)
 	}
 
+	clone, err := mgr.cloneFromTemplate(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	mgr.ts.AddEndCallback(func(t *testing.T) {
+		mgr.dropClone(clone)
+	})
+	return clone, nil
+}
+
+// cloneFromTemplate creates a new database from the migrated template of db.
+func (mgr *Manager) cloneFromTemplate(ctx context.Context, db *Database) (*Database, error) {
 	dbName := db.origName + "_" + xid.New().String()
 	templateName := db.origName + "_template"
 
@@ -41,25 +55,111 @@ func (mgr *Manager) NewTestDatabase(ctx context.Context, name string) (*Database
 		return nil, err
 	}
 
-	clone := &Database{
+	return &Database{
 		name:     dbName,
 		origName: db.origName,
 		mgr:      mgr,
 		hooks:    db.hooks,
+		session:  db.session,
+	}, nil
+}
+
+// dropClone shuts down the connection pools of a cloned database
+// and attempts to drop it.
+func (mgr *Manager) dropClone(clone *Database) {
+	clone.shutdown()
+	err := mgr.execAsMigrator(context.Background(), clone.origName, fmt.Sprintf(
+		"DROP DATABASE %s WITH (FORCE)",
+		pgx.Identifier{clone.name}.Sanitize(),
+	))
+	if err != nil {
+		mgr.rootLogger.Error().Err(err).Str("database", clone.name).Msg("failed to clean up test database")
 	}
+}
 
-	mgr.ts.AddEndCallback(func(t *testing.T) {
-		// Shut down the connection pools and attempt to drop the database.
-		clone.shutdown()
-		err := mgr.execAsMigrator(context.Background(), db.origName, fmt.Sprintf(
-			"DROP DATABASE %s WITH (FORCE)",
-			pgx.Identifier{dbName}.Sanitize(),
-		))
+// Database isolation modes, configured with the ENCORE_TEST_DB_ISOLATION
+// environment variable by "encore test --db-isolation".
+const (
+	isolatePackage = "package" // all tests in a package share a database cloned from the template
+	isolateTest    = "test"    // each test gets its own database cloned from the template
+)
+
+// isolated returns the database to use in place of db for the current test.
+//
+// If database isolation is enabled, either with et.EnableDatabaseIsolation
+// or with "encore test --db-isolation", it returns a clone of db
+// created from the migrated template database. Otherwise it returns db.
+func (db *Database) isolated(ctx context.Context) (*Database, error) {
+	mgr := db.mgr
+	if mgr.runtime.EnvType != "test" || db.noopDB || db.name != db.origName || db.mysql != nil {
+		// Not running tests, db is already a clone,
+		// or db uses MySQL, which has no template databases to clone.
+		return db, nil
+	}
+
+	curr := mgr.rt.Current()
+	if curr.Req == nil || curr.Req.Test == nil {
+		return db, nil
+	}
+
+	mode := mgr.isolationMode
+	if mgr.ts.GetIsolatedDatabases() {
+		mode = isolateTest
+	}
+
+	if db.readGrant && (mode == isolatePackage || mode == isolateTest) {
+		// Read the clone the owning service's tests write to.
+		return mgr.GetDB(db.origName).isolated(ctx)
+	}
+
+	switch mode {
+	case isolatePackage:
+		mgr.mu.Lock()
+		defer mgr.mu.Unlock()
+		if clone, ok := mgr.pkgClones[db.origName]; ok {
+			return clone, nil
+		}
+		// The clone lives until the test binary exits;
+		// stale clones are dropped by the next "encore test" run.
+		clone, err := mgr.cloneFromTemplate(ctx, db)
 		if err != nil {
-			mgr.rootLogger.Error().Err(err).Str("database", dbName).Msg("failed to clean up test database")
+			return nil, fmt.Errorf("sqldb: isolate database %s: %w", db.origName, err)
 		}
-	})
-	return clone, nil
+		mgr.pkgClones[db.origName] = clone
+		return clone, nil
+
+	case isolateTest:
+		// Use the database of the closest test that already has one,
+		// so that subtests see the data written by their parent test.
+		for req := curr.Req; req != nil && req.Test != nil; req = req.Test.Parent {
+			td := req.Test
+			td.DatabasesMu.Lock()
+			clone, ok := td.Databases[db.origName]
+			td.DatabasesMu.Unlock()
+			if ok {
+				return clone.(*Database), nil
+			}
+		}
+
+		td := curr.Req.Test
+		td.DatabasesMu.Lock()
+		defer td.DatabasesMu.Unlock()
+		if clone, ok := td.Databases[db.origName]; ok {
+			return clone.(*Database), nil
+		}
+		clone, err := mgr.cloneFromTemplate(ctx, db)
+		if err != nil {
+			return nil, fmt.Errorf("sqldb: isolate database %s: %w", db.origName, err)
+		}
+		td.Databases[db.origName] = clone
+		mgr.ts.AddEndCallback(func(t *testing.T) {
+			mgr.dropClone(clone)
+		})
+		return clone, nil
+
+	default:
+		return db, nil
+	}
 }
 
 // execAsMigrator opens a one-shot connection to the local dbproxy as
@@ -112,9 +212,13 @@ func (mgr *Manager) execAsMigrator(ctx context.Context, dbEncoreName, stmt strin
 //
 //publicapigen:drop
 func (mgr *Manager) WithSuperuser(db *Database) *Database {
-	if db.noopDB {
+	if db.noopDB || db.mysql != nil {
 		return db
 	}
+	db, err := db.isolated(context.Background())
+	if err != nil {
+		panic(err.Error())
+	}
 
 	var dbCfg *config.SQLDatabase
 	for _, d := range mgr.runtime.SQLDatabases {
@@ -155,6 +259,7 @@ func (mgr *Manager) WithSuperuser(db *Database) *Database {
 		origName: db.origName,
 		mgr:      mgr,
 		hooks:    db.hooks,
+		session:  db.session,
 		pool:     pool,
 	}
 	mgr.ts.AddEndCallback(func(t *testing.T) {
@@ -3,11 +3,13 @@
 package sqldb
 
 import (
+	"encore.dev/appruntime/apisdk/api"
 	"encore.dev/appruntime/shared/appconf"
 	"encore.dev/appruntime/shared/logging"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/appruntime/shared/shutdown"
 	"encore.dev/appruntime/shared/testsupport"
+	"encore.dev/metrics"
 )
 
 // Initialize the singleton instance.
@@ -19,6 +21,7 @@ import (
 var Singleton *Manager
 
 func init() {
-	Singleton = NewManager(appconf.Runtime, reqtrack.Singleton, testsupport.Singleton, logging.RootLogger)
+	Singleton = NewManager(appconf.Static, appconf.Runtime, reqtrack.Singleton, testsupport.Singleton, metrics.Singleton, logging.RootLogger)
 	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	api.RegisterPlatformRoute("GET", "/sqldb/pool-stats", Singleton.handlePoolStats)
 }
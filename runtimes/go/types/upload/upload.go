@@ -0,0 +1,148 @@
+// Package upload provides the File type for receiving
+// files in multipart/form-data API requests.
+//
+// File fields are declared in request types using the `form` struct tag:
+//
+//	type UploadParams struct {
+//		Title  string       `form:"title"`
+//		Avatar *upload.File `form:"avatar,maxsize=5MB"`
+//	}
+//
+// Files larger than a few megabytes are stored in temporary files on disk
+// while the request is being processed, and removed when the request completes.
+package upload
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// MaxMemory is the maximum number of bytes of a multipart form
+// that are kept in memory. The remainder is stored in temporary files.
+const MaxMemory = 8 << 20 // 8 MiB
+
+// File is a file uploaded as part of a multipart/form-data request.
+type File struct {
+	// Filename is the name of the file, as provided by the client.
+	// It must not be trusted to be a safe file system path.
+	Filename string
+
+	// ContentType is the content type of the file, as provided by the client.
+	ContentType string
+
+	// Size is the size of the file, in bytes.
+	Size int64
+
+	hdr  *multipart.FileHeader // set for files parsed from a form
+	data []byte                // set for files decoded from JSON
+}
+
+// Open opens the file for reading.
+// The caller must close the file when done.
+func (f *File) Open() (multipart.File, error) {
+	if f.hdr != nil {
+		return f.hdr.Open()
+	}
+	return nopCloser{bytes.NewReader(f.data)}, nil
+}
+
+type nopCloser struct {
+	*bytes.Reader
+}
+
+func (nopCloser) Close() error { return nil }
+
+// jsonFile is the JSON representation of a File, used when a request
+// containing files is sent between services.
+type jsonFile struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
+// MarshalJSON implements json.Marshaler, including the file contents.
+func (f File) MarshalJSON() ([]byte, error) {
+	data := f.data
+	if f.hdr != nil {
+		r, err := f.hdr.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = r.Close() }()
+		data = make([]byte, f.Size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(jsonFile{Filename: f.Filename, ContentType: f.ContentType, Data: data})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *File) UnmarshalJSON(b []byte) error {
+	var v jsonFile
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*f = File{
+		Filename:    v.Filename,
+		ContentType: v.ContentType,
+		Size:        int64(len(v.Data)),
+		data:        v.Data,
+	}
+	return nil
+}
+
+// ErrTooLarge is reported when an uploaded file exceeds its size limit.
+var ErrTooLarge = errors.New("upload: file too large")
+
+// IsForm reports whether req has a form body, meaning it has
+// a multipart/form-data or application/x-www-form-urlencoded content type.
+//
+//publicapigen:drop
+func IsForm(req *http.Request) bool {
+	ct := req.Header.Get("Content-Type")
+	return strings.HasPrefix(ct, "multipart/form-data") || strings.HasPrefix(ct, "application/x-www-form-urlencoded")
+}
+
+// ParseForm parses the form in the request body.
+// Temporary files created while parsing are removed by the HTTP server
+// when the request completes.
+//
+//publicapigen:drop
+func ParseForm(req *http.Request) error {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data") {
+		return req.ParseMultipartForm(MaxMemory)
+	}
+	return req.ParseForm()
+}
+
+// FormFiles returns the files uploaded in the form field name,
+// which must already have been parsed with ParseForm.
+// If maxSize is positive, it reports ErrTooLarge if a file is larger than maxSize bytes.
+//
+//publicapigen:drop
+func FormFiles(req *http.Request, name string, maxSize int64) ([]*File, error) {
+	if req.MultipartForm == nil {
+		return nil, nil
+	}
+	hdrs := req.MultipartForm.File[name]
+	files := make([]*File, 0, len(hdrs))
+	for _, hdr := range hdrs {
+		if maxSize > 0 && hdr.Size > maxSize {
+			return nil, fmt.Errorf("%w: field %s: %d bytes exceeds the limit of %d bytes", ErrTooLarge, name, hdr.Size, maxSize)
+		}
+		files = append(files, &File{
+			Filename:    hdr.Filename,
+			ContentType: hdr.Header.Get("Content-Type"),
+			Size:        hdr.Size,
+			hdr:         hdr,
+		})
+	}
+	return files, nil
+}
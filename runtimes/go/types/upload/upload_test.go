@@ -0,0 +1,69 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFormFiles(t *testing.T) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField("title", "greeting")
+	fw, err := mw.CreateFormFile("doc", "hello.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = fw.Write([]byte("hello, world"))
+	_ = mw.Close()
+
+	req := httptest.NewRequest("POST", "/", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if !IsForm(req) {
+		t.Fatal("IsForm = false, want true")
+	}
+	if err := ParseForm(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Form.Get("title"); got != "greeting" {
+		t.Errorf("got title %q, want %q", got, "greeting")
+	}
+
+	files, err := FormFiles(req, "doc", 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+	f := files[0]
+	if f.Filename != "hello.txt" || f.Size != 12 {
+		t.Errorf("got file %q (%d bytes), want %q (%d bytes)", f.Filename, f.Size, "hello.txt", 12)
+	}
+
+	if _, err := FormFiles(req, "doc", 5); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("got err %v, want ErrTooLarge", err)
+	}
+
+	// Round-trip through JSON, as done for service-to-service calls.
+	data, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got File
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	r, err := got.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+	content, _ := io.ReadAll(r)
+	if string(content) != "hello, world" || got.Filename != "hello.txt" || got.Size != 12 {
+		t.Errorf("got %q (%q, %d bytes) after round-trip", content, got.Filename, got.Size)
+	}
+}
@@ -21,4 +21,21 @@ export class Service {
 
 export interface ServiceConfig {
   middlewares?: Middleware[];
+
+  /**
+   * The team owning the service. Encore uses it to group build errors
+   * and crashes by team, and shows it in the local development dashboard.
+   */
+  owner?: ServiceOwner;
+}
+
+export interface ServiceOwner {
+  /** The name of the owning team. */
+  team: string;
+
+  /** The team's Slack channel, like "#payments". */
+  slack?: string;
+
+  /** How to reach the team's on-call, like the name of a PagerDuty schedule. */
+  oncall?: string;
 }
@@ -95,6 +95,12 @@ impl MetaBuilder<'_> {
                 // We no longer care about migrations in a service, so just set
                 // this to the empty array. The field is required for backwards compatibility.
                 migrations: vec![],
+
+                owner: svc.owner.as_ref().map(|o| v1::ServiceOwner {
+                    team: o.team.clone(),
+                    slack_channel: o.slack_channel.clone().unwrap_or_default(),
+                    on_call: o.on_call.clone().unwrap_or_default(),
+                }),
             });
         }
 
@@ -232,7 +238,9 @@ impl MetaBuilder<'_> {
                             if ep.expose {
                                 map.insert(
                                     DEFAULT_API_GATEWAY_NAME.to_string(),
-                                    v1::rpc::ExposeOptions {},
+                                    v1::rpc::ExposeOptions {
+                                        internal_only: false,
+                                    },
                                 );
                             }
                             map
@@ -240,6 +248,9 @@ impl MetaBuilder<'_> {
                         streaming_request: ep.streaming_request,
                         streaming_response: ep.streaming_response,
                         static_assets,
+                        routing_conditions: vec![],
+                        deprecated: ep.doc.as_deref().and_then(deprecation_notice),
+                        experiment: None,
                     };
 
                     let Some(service_idx) =
@@ -886,6 +897,7 @@ impl MetaBuilder<'_> {
                         filename: m.file_name.clone(),
                         description: m.description.clone(),
                         number: m.number,
+                        down_filename: m.down_file_name.clone().unwrap_or_default(),
                     })
                     .collect::<Vec<_>>();
                 (Some(rel_path), migrations, spec.non_seq_migrations)
@@ -899,6 +911,8 @@ impl MetaBuilder<'_> {
             migration_rel_path,
             migrations,
             allow_non_sequential_migrations,
+            engine: v1::sql_database::Engine::Postgres as i32,
+            read_access: vec![],
         })
     }
 
@@ -1098,9 +1112,24 @@ fn new_meta() -> v1::Data {
         buckets: vec![],
         gateways: vec![],
         language: v1::Lang::Typescript as i32,
+        search_indexes: vec![],
+        inbound_emails: vec![],
+        operation_trackers: vec![],
+        env_vars: vec![],
     }
 }
 
+/// Returns the deprecation notice in a doc comment, if the doc comment has
+/// a JSDoc `@deprecated` tag or a line starting with "Deprecated:".
+pub(crate) fn deprecation_notice(doc: &str) -> Option<String> {
+    doc.lines().find_map(|line| {
+        let line = line.trim();
+        line.strip_prefix("@deprecated")
+            .or_else(|| line.strip_prefix("Deprecated:"))
+            .map(|notice| notice.trim().to_string())
+    })
+}
+
 #[cfg(test)]
 mod tests {
     use swc_common::errors::{Handler, HANDLER};
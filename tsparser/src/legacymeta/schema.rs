@@ -10,6 +10,7 @@ use swc_common::errors::HANDLER;
 use crate::encore::parser::schema::v1::r#type as styp;
 use crate::encore::parser::schema::v1::{self as schema};
 use crate::legacymeta::api_schema::strip_path_params;
+use crate::legacymeta::deprecation_notice;
 use crate::parser::parser::ParseContext;
 
 use crate::parser::resources::apis::api::Endpoint;
@@ -425,6 +426,7 @@ impl BuilderCtx<'_, '_> {
                 tags,
                 raw_tag,
                 query_string_name,
+                deprecated: doc.as_deref().and_then(deprecation_notice),
                 doc: doc.unwrap_or_else(|| "".into()),
             });
         }
@@ -18,6 +18,7 @@ use walkdir::WalkDir;
 use crate::parser::module_loader::ModuleLoader;
 use crate::parser::resourceparser::bind::{Bind, BindKind};
 use crate::parser::resourceparser::PassOneParser;
+use crate::parser::resources::apis::service::ServiceOwner;
 use crate::parser::resources::apis::service_client::ServiceClient;
 use crate::parser::resources::Resource;
 use crate::parser::service_discovery::{discover_services, DiscoveredService};
@@ -489,6 +490,9 @@ pub struct Service {
     /// Associated documentation.
     pub doc: Option<String>,
 
+    /// The team owning the service, if declared.
+    pub owner: Option<ServiceOwner>,
+
     /// The root directory of the service.
     pub root: PathBuf,
 
@@ -510,6 +514,7 @@ fn collect_services(
             // Filled in below.
             binds: vec![],
             doc: None,
+            owner: None,
         });
     }
 
@@ -536,9 +541,10 @@ fn collect_services(
                 if idx > 0 {
                     let svc = &mut services[idx - 1];
                     if path.starts_with(&svc.root) {
-                        // If we have a service resource, copy its documentation.
+                        // If we have a service resource, copy its documentation and owner.
                         if let Resource::Service(s) = &b.resource {
                             svc.doc = s.doc.clone();
+                            svc.owner = s.owner.clone();
                         }
 
                         svc.binds.push(b.clone());
@@ -1,4 +1,4 @@
-use litparser::{report_and_continue, ParseResult};
+use litparser::{report_and_continue, ParseResult, Sp};
 use swc_common::sync::Lrc;
 use swc_common::Spanned;
 use swc_ecma_ast::{self as ast};
@@ -24,12 +24,29 @@ pub struct Service {
     pub range: Range,
     pub name: String,
     pub doc: Option<String>,
+    pub owner: Option<ServiceOwner>,
+}
+
+/// The team owning a service.
+#[derive(Debug, Clone)]
+pub struct ServiceOwner {
+    pub team: String,
+    pub slack_channel: Option<String>,
+    pub on_call: Option<String>,
 }
 
 #[allow(dead_code)]
 #[derive(LitParser, Default, Debug)]
 struct DecodedServiceConfig {
     middlewares: Option<ast::Expr>,
+    owner: Option<DecodedServiceOwner>,
+}
+
+#[derive(LitParser, Debug)]
+struct DecodedServiceOwner {
+    team: Sp<String>,
+    slack: Option<Sp<String>>,
+    oncall: Option<String>,
 }
 
 pub static SERVICE_PARSER: ResourceParser = ResourceParser {
@@ -61,10 +78,31 @@ pub static SERVICE_PARSER: ResourceParser = ResourceParser {
                     }
                 }
 
+                let owner = r.config.and_then(|cfg| cfg.owner).and_then(|o| {
+                    if o.team.is_empty() {
+                        o.team.span().err("service owner team must not be empty");
+                        return None;
+                    }
+                    if let Some(slack) = &o.slack {
+                        if !slack.starts_with('#') {
+                            slack
+                                .span()
+                                .err("service owner Slack channel must start with \"#\"");
+                            return None;
+                        }
+                    }
+                    Some(ServiceOwner {
+                        team: o.team.take(),
+                        slack_channel: o.slack.map(|s| s.take()),
+                        on_call: o.oncall,
+                    })
+                });
+
                 let resource = Resource::Service(Lrc::new(Service {
                     range: r.range,
                     name: r.resource_name,
                     doc: r.doc_comment,
+                    owner,
                 }));
                 pass.add_resource(resource.clone());
                 pass.add_bind(BindData {
@@ -1,3 +1,4 @@
+use std::collections::HashMap;
 use std::path::{Path, PathBuf};
 use std::str::FromStr;
 
@@ -79,6 +80,8 @@ pub struct DBMigration {
     pub file_name: String,
     pub description: String,
     pub number: u64,
+    /// The file name of the down migration reverting this migration, if any.
+    pub down_file_name: Option<String>,
 }
 
 #[derive(LitParser, Debug)]
@@ -232,6 +235,7 @@ fn visit_dirs(
 #[cfg(not(target_arch = "wasm32"))]
 fn parse_default(span: Span, dir: &Path) -> ParseResult<Vec<DBMigration>> {
     let mut migrations = vec![];
+    let mut downs = HashMap::new();
     static FILENAME_RE: Lazy<Regex> =
         Lazy::new(|| Regex::new(r"^(\d+)_([^.]+)\.(up|down).sql$").unwrap());
 
@@ -256,18 +260,28 @@ fn parse_default(span: Span, dir: &Path) -> ParseResult<Vec<DBMigration>> {
         let captures = FILENAME_RE
             .captures(name)
             .ok_or(span.parse_err(format!("invalid migration filename: {name}")))?;
+        let number = captures[1]
+            .parse::<u64>()
+            .map_err(|err| span.parse_err(err.to_string()))?;
         if captures[3].eq("up") {
             migrations.push(DBMigration {
                 file_name: name.to_string(),
                 description: captures[2].to_string(),
-                number: captures[1]
-                    .parse::<u64>()
-                    .map_err(|err| span.parse_err(err.to_string()))?,
+                number,
+                down_file_name: None,
             });
+        } else {
+            downs.insert(number, name.to_string());
         }
         Ok(())
     })?;
 
+    // Pair the down migrations with the migrations they revert.
+    // Down migrations without a corresponding up migration are ignored.
+    for m in &mut migrations {
+        m.down_file_name = downs.remove(&m.number);
+    }
+
     migrations.sort_by_key(|m| m.number);
     Ok(migrations)
 }
@@ -305,6 +319,7 @@ fn parse_drizzle(span: Span, dir: &Path) -> ParseResult<Vec<DBMigration>> {
             number: captures[1]
                 .parse::<u64>()
                 .map_err(|err| span.parse_err(err.to_string()))?,
+            down_file_name: None,
         });
 
         Ok(())
@@ -355,6 +370,7 @@ fn parse_prisma(span: Span, dir: &Path) -> ParseResult<Vec<DBMigration>> {
             number: captures[1]
                 .parse::<u64>()
                 .map_err(|err| span.parse_err(err.to_string()))?,
+            down_file_name: None,
         });
         Ok(())
     })?;
@@ -422,6 +438,7 @@ fn parse_drizzle_v1(span: Span, dir: &Path) -> ParseResult<Vec<DBMigration>> {
             number: captures[1]
                 .parse::<u64>()
                 .map_err(|err| span.parse_err(err.to_string()))?,
+            down_file_name: None,
         });
 
         Ok(())
@@ -29,6 +29,13 @@ type Desc struct {
 
 	// ResourceUsageOutsideServices describes resources that are used outside of a service.
 	ResourceUsageOutsideServices map[resource.Resource][]usage.Usage
+
+	// MessageCatalog contains the app's localized message templates,
+	// keyed by language and message key. It is nil if the app has no message catalog.
+	MessageCatalog map[string]map[string]string
+
+	// EncryptedStructs are the struct types with fields tagged `encore:"encrypted"`.
+	EncryptedStructs []*EncryptedStruct
 }
 
 // MatchingMiddleware reports which middleware applies to the given RPC,
@@ -82,6 +89,7 @@ func ValidateAndDescribe(pc *parsectx.Context, result *parser.Result) *Desc {
 
 	// First we want to discover the service layout
 	services := discoverServices(pc, result)
+	assignServiceOwners(pc, services, result)
 
 	// We always have a default API gateway, for now.
 	gateways := []*Gateway{{EncoreName: "api-gateway"}}
@@ -5,7 +5,8 @@ import (
 )
 
 const (
-	serviceHelp = "For more information on services and how to define them, see https://encore.dev/docs/primitives/services"
+	serviceHelp    = "For more information on services and how to define them, see https://encore.dev/docs/primitives/services"
+	encryptionHelp = "For more information on encrypted fields, see https://encore.dev/docs/go/primitives/encryption"
 )
 
 var (
@@ -41,4 +42,34 @@ var (
 		"Infrastructure resources can only be referenced within services.",
 		errors.WithDetails("To use infrastructure resources outside services, instead pass a reference to the resource into the library."),
 	)
+
+	errInvalidEncryptedFieldType = errRange.New(
+		"Invalid encrypted field",
+		"Encrypted fields must be named fields of type string, *string or []byte.",
+		errors.WithDetails(encryptionHelp),
+	)
+
+	errGenericEncryptedStruct = errRange.New(
+		"Invalid encrypted struct",
+		"Structs with encrypted fields cannot have type parameters.",
+		errors.WithDetails(encryptionHelp),
+	)
+
+	errEncryptedStructJSONMethods = errRange.New(
+		"Invalid encrypted struct",
+		"Structs with encrypted fields cannot define MarshalJSON or UnmarshalJSON methods, as Encore generates them to encrypt the fields.",
+		errors.WithDetails(encryptionHelp),
+	)
+
+	errEncryptedFieldInAPI = errRange.New(
+		"Encrypted field used in API",
+		"Structs with encrypted fields cannot be used in API requests or responses, as the fields would be sent encrypted.",
+		errors.WithDetails("Copy the data to a separate type without encrypted fields for the API. "+encryptionHelp),
+	)
+
+	errInvalidMessageCatalog = errRange.New(
+		"Invalid message catalog",
+		"The message catalog in the \"locales\" directory is invalid.",
+		errors.WithDetails("For more information on localized error messages, see https://encore.dev/docs/go/primitives/api-errors#localized-error-messages"),
+	)
 )
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"go/token"
 	gotoken "go/token"
+	"path"
 	"slices"
 	"sort"
+	"strings"
 
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/paths"
@@ -23,9 +25,13 @@ import (
 	"encr.dev/v2/parser/infra/caches"
 	"encr.dev/v2/parser/infra/config"
 	"encr.dev/v2/parser/infra/crons"
+	"encr.dev/v2/parser/infra/email"
+	"encr.dev/v2/parser/infra/envvar"
 	"encr.dev/v2/parser/infra/metrics"
 	"encr.dev/v2/parser/infra/objects"
+	"encr.dev/v2/parser/infra/operations"
 	"encr.dev/v2/parser/infra/pubsub"
+	"encr.dev/v2/parser/infra/search"
 	"encr.dev/v2/parser/infra/secrets"
 	"encr.dev/v2/parser/infra/sqldb"
 	"encr.dev/v2/parser/resource"
@@ -81,6 +87,14 @@ func (b *builder) Build() *meta.Data {
 		svcByName[svc.Name] = out
 		md.Svcs = append(md.Svcs, out)
 
+		if o, ok := svc.Owner.Get(); ok {
+			out.Owner = &meta.ServiceOwner{
+				Team:         o.Team,
+				SlackChannel: o.SlackChannel,
+				OnCall:       o.OnCall,
+			}
+		}
+
 		if fw, ok := svc.Framework.Get(); ok {
 			out.RelPath = b.relPath(fw.RootPkg.ImportPath)
 			for _, ep := range fw.Endpoints {
@@ -97,19 +111,37 @@ func (b *builder) Build() *meta.Data {
 					Tags:           ep.Tags.ToProto(),
 					Sensitive:      ep.Sensitive,
 					Expose:         make(map[string]*meta.RPC_ExposeOptions),
+					Deprecated:     deprecationNotice(ep.Doc),
 				}
 				if ep.Raw {
 					rpc.Proto = meta.RPC_RAW
 				}
+				if exp, ok := ep.Experiment.Get(); ok {
+					rpc.Experiment = &meta.RPC_Experiment{
+						Name:    exp.Name,
+						Percent: int32(exp.Percent),
+					}
+				}
+				for _, cond := range ep.RoutingConditions {
+					source := meta.RPC_RoutingCondition_HEADER
+					if cond.Source == api.RouteByQuery {
+						source = meta.RPC_RoutingCondition_QUERY
+					}
+					rpc.RoutingConditions = append(rpc.RoutingConditions, &meta.RPC_RoutingCondition{
+						Source: source,
+						Name:   cond.Name,
+						Value:  cond.Value,
+					})
+				}
 
 				switch ep.Access {
 				case api.Public:
 					rpc.AccessType = meta.RPC_PUBLIC
-					rpc.Expose["api-gateway"] = &meta.RPC_ExposeOptions{}
+					rpc.Expose["api-gateway"] = &meta.RPC_ExposeOptions{InternalOnly: ep.InternalOnly}
 					rpc.AllowUnauthenticated = true
 				case api.Auth:
 					rpc.AccessType = meta.RPC_AUTH
-					rpc.Expose["api-gateway"] = &meta.RPC_ExposeOptions{}
+					rpc.Expose["api-gateway"] = &meta.RPC_ExposeOptions{InternalOnly: ep.InternalOnly}
 				case api.Private:
 					rpc.AccessType = meta.RPC_PRIVATE
 					rpc.AllowUnauthenticated = true
@@ -253,6 +285,12 @@ func (b *builder) Build() *meta.Data {
 				Doc:              zeroNil(r.Doc),
 				MigrationRelPath: zeroNil(r.MigrationDir.String()),
 				Migrations:       fns.Map(r.Migrations, transformMigration),
+				ReadAccess: fns.Map(r.ReadAccess, func(g sqldb.ReadGrant) string {
+					return g.Service
+				}),
+			}
+			if r.Engine == sqldb.MySQL {
+				db.Engine = meta.SQLDatabase_MYSQL
 			}
 			md.SqlDatabases = append(md.SqlDatabases, db)
 
@@ -325,6 +363,52 @@ func (b *builder) Build() *meta.Data {
 			}
 			md.PubsubTopics = append(md.PubsubTopics, topic)
 
+		case *search.Index:
+			md.SearchIndexes = append(md.SearchIndexes, &meta.SearchIndex{
+				Name:             r.Name,
+				Doc:              zeroNil(r.Doc),
+				SearchableFields: r.SearchableFields,
+				FilterableFields: r.FilterableFields,
+			})
+
+		case *email.Inbound:
+			inbound := &meta.InboundEmail{
+				Name: r.Name,
+				Doc:  zeroNil(r.Doc),
+			}
+			if svc, ok := b.app.ServiceForPath(r.File.FSPath); ok {
+				inbound.ServiceName = svc.Name
+			}
+			md.InboundEmails = append(md.InboundEmails, inbound)
+
+		case *operations.Tracker:
+			tracker := &meta.OperationTracker{
+				Name:    r.Name,
+				Doc:     zeroNil(r.Doc),
+				Private: r.Private,
+			}
+			if svc, ok := b.app.ServiceForPath(r.File.FSPath); ok {
+				tracker.ServiceName = svc.Name
+			}
+			md.OperationTrackers = append(md.OperationTrackers, tracker)
+
+		case *envvar.Var:
+			pos := r.File.Token().Position(r.AST.Pos())
+			v := &meta.EnvVar{
+				Name:        r.Name,
+				Doc:         zeroNil(r.Doc),
+				Description: r.Description,
+				Required:    r.Required,
+				Format:      string(r.Format),
+				Filepath:    path.Join(b.relPath(r.File.Pkg.ImportPath), r.File.Name),
+				SrcLine:     int32(pos.Line),
+				SrcCol:      int32(pos.Column),
+			}
+			if svc, ok := b.app.ServiceForPath(r.File.FSPath); ok {
+				v.ServiceName = &svc.Name
+			}
+			md.EnvVars = append(md.EnvVars, v)
+
 		case *objects.Bucket:
 			bkt := &meta.Bucket{
 				Name:      r.Name,
@@ -606,9 +690,10 @@ func (b *builder) apiPath(pos gotoken.Pos, path *resourcepaths.Path) *meta.Path
 
 func transformMigration(res sqldb.MigrationFile) *meta.DBMigration {
 	return &meta.DBMigration{
-		Filename:    res.Filename,
-		Number:      uint64(res.Number),
-		Description: res.Description,
+		Filename:     res.Filename,
+		Number:       uint64(res.Number),
+		Description:  res.Description,
+		DownFilename: res.DownFilename,
 	}
 }
 
@@ -641,6 +726,20 @@ func (b *builder) relPath(pkg paths.Pkg) string {
 	return rel.String()
 }
 
+// deprecationNotice returns the deprecation notice in the given doc comment,
+// following the Go convention of a paragraph starting with "Deprecated: ".
+// It returns nil if the doc comment has no such paragraph.
+func deprecationNotice(doc string) *string {
+	for _, para := range strings.Split(doc, "\n\n") {
+		para = strings.TrimSpace(para)
+		if notice, ok := strings.CutPrefix(para, "Deprecated:"); ok {
+			notice = strings.Join(strings.Fields(notice), " ")
+			return &notice
+		}
+	}
+	return nil
+}
+
 func zeroNil[T comparable](val T) *T {
 	var zero T
 	if val == zero {
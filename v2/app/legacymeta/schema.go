@@ -3,6 +3,7 @@ package legacymeta
 import (
 	"fmt"
 	"go/ast"
+	"strings"
 
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/idents"
@@ -11,6 +12,7 @@ import (
 	"encr.dev/v2/internals/pkginfo"
 	schemav2 "encr.dev/v2/internals/schema"
 	"encr.dev/v2/internals/schema/schemautil"
+	"encr.dev/v2/parser/apis/api/apienc"
 	"github.com/fatih/structtag"
 )
 
@@ -56,6 +58,8 @@ func (b *builder) builtinType(typ schemav2.BuiltinType) schema.Builtin {
 		return schema.Builtin_JSON
 	case schemav2.UserID:
 		return schema.Builtin_USER_ID
+	case schemav2.File:
+		return schema.Builtin_FILE
 
 	default:
 		panic(fmt.Sprintf("unknown builtin type %v", typ.Kind))
@@ -175,6 +179,7 @@ func (b *builder) structField(f schemav2.StructField) *schema.Field {
 		QueryStringName: "",
 		RawTag:          f.Tag.String(),
 		Tags:            nil,
+		Deprecated:      deprecationNotice(f.Doc),
 	}
 
 	for _, tag := range f.Tag.Tags() {
@@ -257,6 +262,26 @@ func (b *builder) structField(f schemav2.StructField) *schema.Field {
 		}
 	}
 
+	// Set WireSpec for form fields
+	if form, _ := f.Tag.Get("form"); form != nil && form.Name != "-" {
+		formSpec := &schema.WireSpec_Form{}
+		if form.Name != "" {
+			formSpec.Name = &form.Name
+		}
+		for _, opt := range form.Options {
+			if v, ok := strings.CutPrefix(opt, "maxsize="); ok {
+				if size, err := apienc.ParseSize(v); err == nil {
+					formSpec.MaxSize = &size
+				}
+			}
+		}
+		field.Wire = &schema.WireSpec{
+			Location: &schema.WireSpec_Form_{
+				Form: formSpec,
+			},
+		}
+	}
+
 	if js, _ := f.Tag.Get("json"); js != nil {
 		if v := js.Name; v != "" {
 			field.JsonName = v
@@ -5,6 +5,7 @@ import (
 	"encr.dev/pkg/paths"
 	"encr.dev/v2/app/apiframework"
 	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/parser/apis/owner"
 	"encr.dev/v2/parser/resource"
 	"encr.dev/v2/parser/resource/usage"
 )
@@ -20,6 +21,9 @@ type Service struct {
 	// FSRoot is the root directory of the service.
 	FSRoot paths.FS
 
+	// Owner is the team owning the service, if declared.
+	Owner option.Option[*owner.Owner]
+
 	// Framework contains API Framework-specific data for this service.
 	Framework option.Option[*apiframework.ServiceDesc]
 
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"slices"
+
+	"encr.dev/pkg/errors"
+	"encr.dev/pkg/option"
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/parser"
+	"encr.dev/v2/parser/apis/owner"
+)
+
+// assignServiceOwners assigns the owners declared with encore:owner directives
+// to the services whose root packages they're declared in.
+func assignServiceOwners(pc *parsectx.Context, services []*Service, result *parser.Result) {
+	for _, o := range parser.Resources[*owner.Owner](result) {
+		idx := slices.IndexFunc(services, func(svc *Service) bool {
+			return svc.FSRoot == o.Package().FSPath
+		})
+		if idx < 0 {
+			pc.Errs.Add(owner.ErrNotServiceRoot.AtGoNode(o.Dir))
+			continue
+		}
+
+		svc := services[idx]
+		if prev, ok := svc.Owner.Get(); ok {
+			pc.Errs.Add(owner.ErrDuplicateOwners.
+				AtGoNode(o.Dir).
+				AtGoNode(prev.Dir, errors.AsHelp("previously declared here")))
+			continue
+		}
+		svc.Owner = option.Some(o)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encr.dev/v2/parser/infra/caches"
 	"encr.dev/v2/parser/infra/objects"
 	"encr.dev/v2/parser/infra/pubsub"
+	"encr.dev/v2/parser/infra/search"
 	"encr.dev/v2/parser/infra/secrets"
 	"encr.dev/v2/parser/infra/sqldb"
 )
@@ -16,6 +17,9 @@ import (
 func (d *Desc) validate(pc *parsectx.Context, result *parser.Result) {
 	defer pc.Trace("app.validate").Done()
 
+	// Find the encrypted structs first, as validating APIs checks their use.
+	d.findEncryptedStructs(pc, result)
+
 	// Validate the framework
 	if fw, ok := d.Framework.Get(); ok {
 		d.validateAuthHandlers(pc, fw)
@@ -31,6 +35,13 @@ func (d *Desc) validate(pc *parsectx.Context, result *parser.Result) {
 	d.validateDatabases(pc, result)
 	d.validatePubSub(pc, result)
 	d.validateObjects(pc, result)
+	d.validateSearch(pc, result)
+	d.validateEmail(pc, result)
+	d.validateOperations(pc, result)
+	d.validateEnvVars(pc, result)
+
+	// Validate the message catalog
+	d.validateMessageCatalog(pc)
 
 	// Validate all resources are defined within a service
 	for _, b := range result.AllBinds() {
@@ -42,6 +53,9 @@ func (d *Desc) validate(pc *parsectx.Context, result *parser.Result) {
 		case *objects.Bucket:
 			// We allow buckets to be declared outside of service code
 			continue
+		case *search.Index:
+			// We allow search indexes to be declared outside of service code
+			continue
 		case *middleware.Middleware:
 			// Middleware is also allowed to be declared outside of service code if it's global (validateMiddleware checks this already)
 			continue
@@ -2,6 +2,8 @@ package app
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"encr.dev/pkg/errors"
 	"encr.dev/v2/app/apiframework"
@@ -21,6 +23,14 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 
 	apiPaths := resourcepaths.NewSet()
 
+	// routed tracks the endpoints registered for each method and path,
+	// so that raw endpoints with distinct routing conditions can share a path.
+	routed := make(map[string][]*api.Endpoint)
+
+	// experiments are the endpoints that are alternate implementations
+	// of other endpoints, validated once all endpoints are routed.
+	var experiments []*api.Endpoint
+
 	for _, svc := range d.Services {
 		fwSvc, ok := svc.Framework.Get()
 		if !ok {
@@ -37,10 +47,22 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 				)
 			}
 
-			// Check for duplicate paths by adding them to the set
-			// Note, errors will be reported automatically to pc.Errs
-			for _, method := range ep.HTTPMethods {
-				apiPaths.Add(pc.Errs, method, ep.Path)
+			if ep.Experiment.Present() {
+				// Experiments share the path of the endpoint they're for,
+				// so they're validated once all endpoints are routed.
+				experiments = append(experiments, ep)
+			} else {
+				// Check for duplicate paths by adding them to the set
+				// Note, errors will be reported automatically to pc.Errs
+				for _, method := range ep.HTTPMethods {
+					key := method + " " + routingPath(ep.Path)
+					if others := routed[key]; len(others) > 0 {
+						validateRoutingConditions(pc, ep, others)
+					} else {
+						apiPaths.Add(pc.Errs, method, ep.Path)
+					}
+					routed[key] = append(routed[key], ep)
+				}
 			}
 
 			if receiver, ok := ep.Recv.Get(); ok {
@@ -125,4 +147,101 @@ func (d *Desc) validateAPIs(pc *parsectx.Context, fw *apiframework.AppDesc, resu
 			}
 		}
 	}
+
+	validateExperiments(pc, routed, experiments)
+}
+
+// validateExperiments validates that each experiment has the same path and method
+// as a regular endpoint, and that the experiments of an endpoint have distinct names
+// and are routed at most 100% of its requests.
+func validateExperiments(pc *parsectx.Context, routed map[string][]*api.Endpoint, experiments []*api.Endpoint) {
+	var keys []string
+	byKey := make(map[string][]*api.Endpoint) // experiments by method and path
+
+ExperimentLoop:
+	for _, ep := range experiments {
+		exp := ep.Experiment.MustGet()
+		for _, method := range ep.HTTPMethods {
+			key := method + " " + routingPath(ep.Path)
+			hasBase := slices.ContainsFunc(routed[key], func(other *api.Endpoint) bool {
+				return len(other.RoutingConditions) == 0 && other.Path.String() == ep.Path.String()
+			})
+			if !hasBase {
+				pc.Errs.Add(api.ErrExperimentWithoutEndpoint.
+					AtGoNode(ep.Path, errors.AsError("no regular endpoint has this path and method")).
+					AtGoNode(exp.Field, errors.AsHelp("declared as an experiment here")))
+				continue ExperimentLoop
+			}
+			for _, other := range byKey[key] {
+				if other.Experiment.MustGet().Name == exp.Name {
+					pc.Errs.Add(api.ErrDuplicateExperiment.
+						AtGoNode(exp.Field).
+						AtGoNode(other.Experiment.MustGet().Field, errors.AsHelp("previously declared here")))
+					continue ExperimentLoop
+				}
+			}
+			if _, ok := byKey[key]; !ok {
+				keys = append(keys, key)
+			}
+			byKey[key] = append(byKey[key], ep)
+		}
+	}
+
+	for _, key := range keys {
+		total := 0
+		for _, ep := range byKey[key] {
+			total += ep.Experiment.MustGet().Percent
+		}
+		if total > 100 {
+			err := api.ErrExperimentTrafficTooHigh(total)
+			for _, ep := range byKey[key] {
+				err = err.AtGoNode(ep.Experiment.MustGet().Field)
+			}
+			pc.Errs.Add(err)
+		}
+	}
+}
+
+// validateRoutingConditions validates that ep can share its path and method
+// with the already registered endpoints in others.
+func validateRoutingConditions(pc *parsectx.Context, ep *api.Endpoint, others []*api.Endpoint) {
+	for _, other := range others {
+		if !ep.Raw || !other.Raw || routingConditionsKey(ep) == routingConditionsKey(other) {
+			pc.Errs.Add(api.ErrConflictingRoutingConditions.
+				AtGoNode(ep.Path, errors.AsError("conflicts with this path")).
+				AtGoNode(other.Path, errors.AsHelp("defined here")))
+			return
+		}
+	}
+}
+
+// routingPath returns a representation of the path
+// that ignores the names of path parameters.
+func routingPath(path *resourcepaths.Path) string {
+	var b strings.Builder
+	for _, s := range path.Segments {
+		b.WriteByte('/')
+		switch s.Type {
+		case resourcepaths.Literal:
+			b.WriteString(s.Value)
+		case resourcepaths.Param:
+			b.WriteByte(':')
+		case resourcepaths.Wildcard:
+			b.WriteByte('*')
+		case resourcepaths.Fallback:
+			b.WriteByte('!')
+		}
+	}
+	return b.String()
+}
+
+// routingConditionsKey returns a key that is equal for
+// endpoints with the same set of routing conditions.
+func routingConditionsKey(ep *api.Endpoint) string {
+	conds := make([]string, len(ep.RoutingConditions))
+	for i, c := range ep.RoutingConditions {
+		conds[i] = c.String()
+	}
+	slices.Sort(conds)
+	return strings.Join(conds, "&")
 }
@@ -1,10 +1,15 @@
 package app
 
 import (
+	"fmt"
+	"go/ast"
+	"slices"
+
 	"encr.dev/pkg/errors"
 	"encr.dev/v2/internals/parsectx"
 	"encr.dev/v2/parser"
 	"encr.dev/v2/parser/infra/sqldb"
+	"encr.dev/v2/parser/resource"
 )
 
 func (d *Desc) validateDatabases(pc *parsectx.Context, result *parser.Result) {
@@ -32,4 +37,72 @@ func (d *Desc) validateDatabases(pc *parsectx.Context, result *parser.Result) {
 			}
 		}
 	}
+
+	d.validateReadAccess(pc, result, dbs, foundDBs)
+}
+
+// validateReadAccess validates the read access databases grant to other services,
+// and that the services reference the databases as granted.
+func (d *Desc) validateReadAccess(pc *parsectx.Context, result *parser.Result, dbs []*sqldb.Database, dbsByName map[string]*sqldb.Database) {
+	ownerName := func(db *sqldb.Database) string {
+		if svc, ok := d.ServiceForPath(db.Pkg.FSPath); ok {
+			return svc.Name
+		}
+		return ""
+	}
+	granted := func(db *sqldb.Database, svcName string) bool {
+		return slices.ContainsFunc(db.ReadAccess, func(g sqldb.ReadGrant) bool { return g.Service == svcName })
+	}
+
+	for _, db := range dbs {
+		owner := ownerName(db)
+		for _, g := range db.ReadAccess {
+			exists := slices.ContainsFunc(d.Services, func(svc *Service) bool { return svc.Name == g.Service })
+			if !exists {
+				pc.Errs.Add(sqldb.ErrInvalidReadGrant(db.Name, fmt.Sprintf("the unknown service %q", g.Service)).AtGoNode(g.AST))
+			} else if g.Service == owner {
+				pc.Errs.Add(sqldb.ErrInvalidReadGrant(db.Name, fmt.Sprintf("its own service %q", g.Service)).AtGoNode(g.AST))
+			}
+		}
+	}
+
+	// Check the read-only references are granted read access.
+	readOnlyBinds := make(map[*ast.Ident]bool)
+	for _, ref := range parser.Resources[*sqldb.ReadOnlyRef](result) {
+		if id, ok := ref.BoundName.Get(); ok {
+			readOnlyBinds[id] = true
+		}
+		db, ok := dbsByName[ref.DBName]
+		if !ok || ref.File.TestFile {
+			continue
+		}
+		svc, ok := d.ServiceForPath(ref.File.Pkg.FSPath)
+		if !ok || svc.Name == ownerName(db) {
+			continue
+		}
+		if !granted(db, svc.Name) {
+			pc.Errs.Add(sqldb.ErrReadAccessNotGranted(svc.Name, db.Name).AtGoNode(ref.AST))
+		}
+	}
+
+	// Check other services only use databases with grants through granted read-only references.
+	for _, svc := range d.Services {
+		for _, db := range dbs {
+			if len(db.ReadAccess) == 0 || svc.Name == ownerName(db) {
+				continue
+			}
+			for _, u := range svc.ResourceUsage[db] {
+				if u.DeclaredIn().TestFile {
+					continue
+				}
+				bind, ok := u.ResourceBind().(*resource.PkgDeclBind)
+				if !ok || !readOnlyBinds[bind.BoundName] {
+					pc.Errs.Add(sqldb.ErrCrossServiceDatabaseAccess(db.Name).AtGoNode(u, errors.AsError("used here")))
+				} else if refSvc, ok := d.ServiceForPath(bind.Package().FSPath); (!ok || refSvc != svc) && !granted(db, svc.Name) {
+					// References within the service are reported where they're declared.
+					pc.Errs.Add(sqldb.ErrReadAccessNotGranted(svc.Name, db.Name).AtGoNode(u, errors.AsError("used here")))
+				}
+			}
+		}
+	}
 }
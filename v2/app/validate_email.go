@@ -0,0 +1,26 @@
+package app
+
+import (
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/parser"
+	"encr.dev/v2/parser/infra/email"
+)
+
+func (d *Desc) validateEmail(pc *parsectx.Context, result *parser.Result) {
+	inbounds := make(map[string]*email.Inbound)
+
+	for _, res := range d.Parse.Resources() {
+		switch res := res.(type) {
+		case *email.Inbound:
+			if existing, ok := inbounds[res.Name]; ok {
+				pc.Errs.Add(email.ErrInboundNameNotUnique.
+					AtGoNode(existing.AST.Args[0], errors.AsHelp("originally defined here")).
+					AtGoNode(res.AST.Args[0], errors.AsError("duplicated here")),
+				)
+			} else {
+				inbounds[res.Name] = res
+			}
+		}
+	}
+}
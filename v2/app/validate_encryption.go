@@ -0,0 +1,132 @@
+package app
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/internals/schema"
+	"encr.dev/v2/parser"
+)
+
+// EncryptedStruct is a struct type with fields tagged `encore:"encrypted"`,
+// which are encrypted whenever the struct is encoded as JSON.
+type EncryptedStruct struct {
+	File   *pkginfo.File
+	Spec   *ast.TypeSpec
+	Fields []string // the names of the encrypted fields
+}
+
+// findEncryptedStructs finds the struct types of the app with encrypted fields,
+// and validates them.
+func (d *Desc) findEncryptedStructs(pc *parsectx.Context, result *parser.Result) {
+	for _, pkg := range result.AppPackages() {
+		for _, file := range pkg.Files {
+			if file.TestFile {
+				continue
+			}
+			for _, decl := range file.AST().Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					if s := d.encryptedStruct(pc, file, spec.(*ast.TypeSpec)); s != nil {
+						d.EncryptedStructs = append(d.EncryptedStructs, s)
+					}
+				}
+			}
+		}
+	}
+}
+
+// encryptedStruct returns the encrypted struct declared by spec, if it is one.
+func (d *Desc) encryptedStruct(pc *parsectx.Context, file *pkginfo.File, spec *ast.TypeSpec) *EncryptedStruct {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok || spec.Assign.IsValid() {
+		return nil
+	}
+
+	s := &EncryptedStruct{File: file, Spec: spec}
+	for _, field := range st.Fields.List {
+		if !isEncryptedField(field) {
+			continue
+		}
+		if !isEncryptableType(field.Type) {
+			pc.Errs.Add(errInvalidEncryptedFieldType.AtGoNode(field.Type))
+		}
+		if len(field.Names) == 0 {
+			pc.Errs.Add(errInvalidEncryptedFieldType.AtGoNode(field))
+		}
+		for _, name := range field.Names {
+			s.Fields = append(s.Fields, name.Name)
+		}
+	}
+	if len(s.Fields) == 0 {
+		return nil
+	}
+
+	if spec.TypeParams != nil {
+		pc.Errs.Add(errGenericEncryptedStruct.AtGoNode(spec.Name))
+		return nil
+	}
+
+	// We generate the JSON methods, so the struct can't have its own.
+	for _, f := range file.Pkg.Files {
+		for _, decl := range f.AST().Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || !slices.Contains([]string{"MarshalJSON", "UnmarshalJSON"}, fd.Name.Name) {
+				continue
+			}
+			recv := fd.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if id, ok := recv.(*ast.Ident); ok && id.Name == spec.Name.Name {
+				pc.Errs.Add(errEncryptedStructJSONMethods.AtGoNode(fd.Name))
+				return nil
+			}
+		}
+	}
+	return s
+}
+
+// isEncryptedField reports whether the field is tagged `encore:"encrypted"`.
+func isEncryptedField(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return false
+	}
+	val, ok := reflect.StructTag(tag).Lookup("encore")
+	return ok && slices.Contains(strings.Split(val, ","), "encrypted")
+}
+
+// isEncryptableType reports whether typ is string, *string or []byte.
+func isEncryptableType(typ ast.Expr) bool {
+	isIdent := func(e ast.Expr, name string) bool {
+		id, ok := e.(*ast.Ident)
+		return ok && id.Name == name
+	}
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		return isIdent(t.X, "string")
+	case *ast.ArrayType:
+		return t.Len == nil && isIdent(t.Elt, "byte")
+	default:
+		return isIdent(t, "string")
+	}
+}
+
+// hasEncryptedTag reports whether the schema field is tagged `encore:"encrypted"`.
+func hasEncryptedTag(field schema.StructField) bool {
+	tag, err := field.Tag.Get("encore")
+	return err == nil && (tag.Name == "encrypted" || slices.Contains(tag.Options, "encrypted"))
+}
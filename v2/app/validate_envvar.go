@@ -0,0 +1,30 @@
+package app
+
+import (
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/parser"
+	"encr.dev/v2/parser/infra/envvar"
+)
+
+func (d *Desc) validateEnvVars(pc *parsectx.Context, result *parser.Result) {
+	vars := make(map[string]*envvar.Var)
+
+	for _, res := range d.Parse.Resources() {
+		switch res := res.(type) {
+		case *envvar.Var:
+			// Several services may read the same variable,
+			// as long as they agree on what it is.
+			if existing, ok := vars[res.Name]; ok {
+				if existing.Required != res.Required || existing.Format != res.Format {
+					pc.Errs.Add(envvar.ErrConflictingDeclarations(res.Name).
+						AtGoNode(existing.AST.Args[1], errors.AsHelp("originally declared here")).
+						AtGoNode(res.AST.Args[1], errors.AsError("declared differently here")),
+					)
+				}
+			} else {
+				vars[res.Name] = res
+			}
+		}
+	}
+}
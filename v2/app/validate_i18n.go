@@ -0,0 +1,34 @@
+package app
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"encore.dev/beta/i18n"
+	"encr.dev/v2/internals/parsectx"
+)
+
+// messageCatalogDir is the directory, relative to the app root,
+// containing the app's message catalog.
+const messageCatalogDir = "locales"
+
+// validateMessageCatalog validates the app's message catalog, if any,
+// and adds it to the application description.
+func (d *Desc) validateMessageCatalog(pc *parsectx.Context) {
+	dir := d.MainModule.RootDir.Join(messageCatalogDir).ToIO()
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+
+	catalog, err := i18n.LoadCatalog(os.DirFS(dir))
+	if err != nil {
+		pc.Errs.Add(errInvalidMessageCatalog.InFile(dir).Wrapping(err))
+		return
+	}
+
+	d.MessageCatalog = make(map[string]map[string]string)
+	for _, lang := range catalog.Languages() {
+		d.MessageCatalog[lang] = catalog.Messages(lang)
+	}
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/parser"
+	"encr.dev/v2/parser/infra/operations"
+)
+
+func (d *Desc) validateOperations(pc *parsectx.Context, result *parser.Result) {
+	trackers := make(map[string]*operations.Tracker)
+
+	for _, res := range d.Parse.Resources() {
+		switch res := res.(type) {
+		case *operations.Tracker:
+			if existing, ok := trackers[res.Name]; ok {
+				pc.Errs.Add(operations.ErrTrackerNameNotUnique.
+					AtGoNode(existing.AST.Args[0], errors.AsHelp("originally defined here")).
+					AtGoNode(res.AST.Args[0], errors.AsError("duplicated here")),
+				)
+			} else {
+				trackers[res.Name] = res
+			}
+		}
+	}
+}
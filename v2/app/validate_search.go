@@ -0,0 +1,26 @@
+package app
+
+import (
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/parsectx"
+	"encr.dev/v2/parser"
+	"encr.dev/v2/parser/infra/search"
+)
+
+func (d *Desc) validateSearch(pc *parsectx.Context, result *parser.Result) {
+	indexes := make(map[string]*search.Index)
+
+	for _, res := range d.Parse.Resources() {
+		switch res := res.(type) {
+		case *search.Index:
+			if existing, ok := indexes[res.Name]; ok {
+				pc.Errs.Add(search.ErrIndexNameNotUnique.
+					AtGoNode(existing.AST.Args[0], errors.AsHelp("originally defined here")).
+					AtGoNode(res.AST.Args[0], errors.AsError("duplicated here")),
+				)
+			} else {
+				indexes[res.Name] = res
+			}
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/rogpeppe/go-internal/testscript"
 
 	"encr.dev/pkg/errinsrc/srcerrors"
+	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
 	"encr.dev/v2/app/apiframework"
 	"encr.dev/v2/internals/perr"
@@ -182,6 +183,9 @@ func parse(ts *testscript.TestScript, neg bool, args []string) {
 			}
 			sort.Strings(dbNames)
 			printf("svc %s dbs=%s", svc.Name, strings.Join(dbNames, ","))
+			if o, ok := svc.Owner.Get(); ok {
+				printf("owner %s team=%s slack=%s oncall=%s", svc.Name, o.Team, o.SlackChannel, o.OnCall)
+			}
 		}
 	}
 
@@ -241,6 +245,12 @@ func parse(ts *testscript.TestScript, neg bool, args []string) {
 		case *crons.Job:
 			printf("cronJob %s title=%q", res.Name, res.Title)
 		case *sqldb.Database:
+			printf("sqldb %s engine=%s", res.Name, res.Engine)
+			if len(res.ReadAccess) > 0 {
+				printf("sqldb %s readAccess=%s", res.Name, strings.Join(fns.Map(res.ReadAccess, func(g sqldb.ReadGrant) string {
+					return g.Service
+				}), ","))
+			}
 			for _, b := range desc.Parse.PkgDeclBinds(res) {
 				printf("resource SQLDBResource %s.%s db=%s",
 					b.File.Pkg.Name, b.BoundName.Name, res.Name)
@@ -23,6 +23,13 @@ func (d *Desc) validateType(pc *parsectx.Context, usedAt ast.Node, typ schema.Ty
 		switch t := t.(type) {
 		case schema.StructType:
 			for _, field := range t.Fields {
+				if hasEncryptedTag(field) {
+					pc.Errs.Add(
+						errEncryptedFieldInAPI.
+							AtGoNode(field.AST, errors.AsError("defined here")).
+							AtGoNode(usedAt, errors.AsHelp("used here")),
+					)
+				}
 				if field.IsAnonymous() {
 					// We don't support anonymous fields anywhere within
 					// Encore types that we need to marshal.
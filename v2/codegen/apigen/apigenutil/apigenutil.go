@@ -104,6 +104,71 @@ func DecodeBody(g *Group, ioReaderExpr *Statement, paramsExpr *Statement, dec *g
 	g.Line()
 }
 
+// DecodeForm generates code for decoding a form request body from the http request
+// given by httpReqExpr and storing the result into the params given by paramsExpr.
+//
+// Requests with a form content type (multipart/form-data or application/x-www-form-urlencoded)
+// are parsed as forms, while other requests (such as service-to-service calls) are decoded from JSON.
+// Errors parsing the form are returned using the code generated by returnErr.
+func DecodeForm(g *Group, httpReqExpr, paramsExpr *Statement, returnErr func(err Code) Code, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
+	if len(params) == 0 {
+		return
+	}
+
+	const uploadPkg = "encore.dev/types/upload"
+	g.Comment("Decode form")
+	g.If(Qual(uploadPkg, "IsForm").Call(httpReqExpr.Clone())).BlockFunc(func(g *Group) {
+		g.If(Err().Op(":=").Qual(uploadPkg, "ParseForm").Call(httpReqExpr.Clone()), Err().Op("!=").Nil()).Block(
+			returnErr(Qual("encore.dev/beta/errs", "WrapCode").Call(Err(), Qual("encore.dev/beta/errs", "InvalidArgument"), Lit("invalid form"))),
+		)
+		g.Id("form").Op(":=").Add(httpReqExpr.Clone()).Dot("Form")
+
+		for _, f := range params {
+			isFile, isList := fileKind(f.Type)
+			if !isFile {
+				singleValExpr := Id("form").Dot("Get").Call(Lit(f.WireName))
+				listValExpr := Id("form").Index(Lit(f.WireName))
+				decodeExpr := dec.UnmarshalQueryOrHeader(f.Type, f.WireName, singleValExpr, listValExpr)
+				g.Add(paramsExpr.Clone()).Dot(f.SrcName).Op("=").Add(decodeExpr)
+				continue
+			}
+
+			_, isPtr := f.Type.(schema.PointerType)
+			g.BlockFunc(func(g *Group) {
+				g.List(Id("files"), Err()).Op(":=").Qual(uploadPkg, "FormFiles").Call(httpReqExpr.Clone(), Lit(f.WireName), Lit(f.MaxSize))
+				g.If(Err().Op("!=").Nil()).Block(
+					returnErr(Qual("encore.dev/beta/errs", "WrapCode").Call(Err(), Qual("encore.dev/beta/errs", "InvalidArgument"), Lit("invalid file "+f.WireName))),
+				)
+				switch {
+				case isList:
+					g.Add(paramsExpr.Clone()).Dot(f.SrcName).Op("=").Id("files")
+				case isPtr:
+					g.If(Len(Id("files")).Op(">").Lit(0)).Block(
+						paramsExpr.Clone().Dot(f.SrcName).Op("=").Id("files").Index(Lit(0)),
+					)
+				default:
+					g.If(Len(Id("files")).Op(">").Lit(0)).Block(
+						paramsExpr.Clone().Dot(f.SrcName).Op("=").Op("*").Id("files").Index(Lit(0)),
+					)
+				}
+			})
+		}
+	}).Else().BlockFunc(func(g *Group) {
+		DecodeBody(g, httpReqExpr.Clone().Dot("Body"), paramsExpr, dec, params)
+	})
+	g.Line()
+}
+
+// fileKind reports whether typ is an uploaded file (upload.File or *upload.File)
+// or a list of uploaded files ([]*upload.File).
+func fileKind(typ schema.Type) (isFile, isList bool) {
+	if list, ok := typ.(schema.ListType); ok {
+		typ, isList = list.Elem, true
+	}
+	typ, _ = schemautil.Deref(typ)
+	return schemautil.IsBuiltinKind(typ, schema.File), isList
+}
+
 // EncodeHeaders generates code for encoding HTTP headers into a http.Header map.
 func EncodeHeaders(errs *perr.List, g *Group, httpHeaderExpr, paramExpr *Statement, params []*apienc.ParameterEncoding) {
 	if len(params) == 0 {
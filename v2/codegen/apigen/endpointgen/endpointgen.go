@@ -82,10 +82,7 @@ func genAPIDesc(
 
 	pos := ep.Decl.AST.Pos()
 	desc := f.VarDecl("APIDesc", ep.Name)
-	desc.Value(Op("&").Add(apiQ("Desc")).Types(
-		reqDesc.Type(),
-		respDesc.Type(),
-	).Values(Dict{
+	fields := Dict{
 		Id("Service"):        Lit(svc.Name),
 		Id("SvcNum"):         Lit(svc.Num),
 		Id("Endpoint"):       Lit(ep.Name),
@@ -119,12 +116,42 @@ func genAPIDesc(
 		Id("ScrubRequestHeaders"):  typescrub.HeadersToJen(reqScrub.Headers),
 		Id("ScrubResponsePaths"):   typescrub.PathsToJen(respScrub.Payload),
 		Id("ScrubResponseHeaders"): typescrub.HeadersToJen(respScrub.Headers),
-	}))
+	}
+	if ep.InternalOnly {
+		fields[Id("InternalOnly")] = True()
+	}
+	if len(ep.RoutingConditions) > 0 {
+		fields[Id("RouteConditions")] = routeConditions(ep)
+	}
+	if exp, ok := ep.Experiment.Get(); ok {
+		fields[Id("Experiment")] = Op("&").Add(apiQ("Experiment")).Values(Dict{
+			Id("Name"):    Lit(exp.Name),
+			Id("Percent"): Lit(exp.Percent),
+		})
+	}
+	desc.Value(Op("&").Add(apiQ("Desc")).Types(
+		reqDesc.Type(),
+		respDesc.Type(),
+	).Values(fields))
 
 	handler.desc = desc
 	return handler
 }
 
+// routeConditions yields a []api.RouteCondition literal
+// containing the endpoint's routing conditions.
+func routeConditions(ep *api.Endpoint) *Statement {
+	return Index().Add(apiQ("RouteCondition")).ValuesFunc(func(g *Group) {
+		for _, c := range ep.RoutingConditions {
+			g.Values(Dict{
+				Id("Query"): Lit(c.Source == api.RouteByQuery),
+				Id("Name"):  Lit(c.Name),
+				Id("Value"): Lit(c.Value),
+			})
+		}
+	})
+}
+
 func serviceMiddleware(ep *api.Endpoint, fw *apiframework.ServiceDesc, svcMiddleware map[*middleware.Middleware]*codegen.VarDecl) *Statement {
 	return Index().Op("*").Add(apiQ("Middleware")).ValuesFunc(func(g *Group) {
 		for _, mw := range fw.Middleware {
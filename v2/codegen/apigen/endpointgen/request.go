@@ -249,6 +249,9 @@ func (d *requestDesc) decodeRequestParameters(g *Group, dec *genutil.TypeUnmarsh
 	apigenutil.DecodeHeaders(g, d.httpReqExpr().Dot("Header"), Id("params"), dec, req.HeaderParameters)
 	apigenutil.DecodeQuery(g, d.httpReqExpr().Dot("URL").Dot("Query").Call(), Id("params"), dec, req.QueryParameters)
 	apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters)
+	apigenutil.DecodeForm(g, d.httpReqExpr(), Id("params"), func(err Code) Code {
+		return Return(Nil(), Nil(), err)
+	}, dec, req.FormParameters)
 }
 
 // Clone returns the function literal to clone the request.
@@ -363,6 +366,8 @@ func (d *requestDesc) EncodeExternalReq() *Statement {
 		apigenutil.EncodeHeaders(d.gu.Errs, g, d.httpHeaderExpr(), Id("params"), enc.HeaderParameters)
 		apigenutil.EncodeQuery(d.gu.Errs, g, d.queryStringExpr(), Id("params"), enc.QueryParameters)
 		apigenutil.EncodeBody(d.gu, g, d.jsonStream(), Id("params"), enc.BodyParameters)
+		// Form parameters are sent as JSON for service-to-service calls.
+		apigenutil.EncodeBody(d.gu, g, d.jsonStream(), Id("params"), enc.FormParameters)
 
 		g.Return(d.httpHeaderExpr(), d.queryStringExpr(), Err())
 	})
@@ -51,6 +51,7 @@ func GenAppConfig(p GenParams, test option.Option[testParams]) *config.Static {
 		BundledServices:    bundledServices(p.Desc),
 		EnabledExperiments: p.Gen.Build.Experiments.StringList(),
 		EmbeddedEnvs:       make(map[string]string),
+		MessageCatalog:     p.Desc.MessageCatalog,
 	}
 
 	if test, ok := test.Get(); ok {
@@ -27,6 +27,7 @@ var importNames = map[string]string{
 	"encore.dev/appruntime/apisdk/service":  "__service",
 	"encore.dev/beta/errs":                  "errs",
 	"encore.dev/storage/sqldb":              "sqldb",
+	"encore.dev/types/upload":               "upload",
 	"encore.dev/types/uuid":                 "uuid",
 }
 
@@ -0,0 +1,31 @@
+package encryptiongen
+
+import (
+	. "github.com/dave/jennifer/jen"
+
+	"encr.dev/v2/app"
+	"encr.dev/v2/codegen"
+	"encr.dev/v2/internals/pkginfo"
+)
+
+const encryptionPkg = "encore.dev/storage/encryption"
+
+// Gen generates JSON methods for the structs with encrypted fields,
+// so the fields are encrypted whenever the structs are encoded as JSON.
+func Gen(gen *codegen.Generator, pkg *pkginfo.Package, structs []*app.EncryptedStruct) {
+	f := gen.File(pkg, "encryption")
+	for _, s := range structs {
+		name := s.Spec.Name.Name
+
+		// The plain type has the same fields but not the methods,
+		// to avoid the methods calling themselves.
+		f.Add(Func().Params(Id("x").Id(name)).Id("MarshalJSON").Params().Params(Index().Byte(), Error()).Block(
+			Type().Id("plain").Id(name),
+			Return(Qual(encryptionPkg, "MarshalJSON").Call(Id("plain").Call(Id("x")))),
+		))
+		f.Add(Func().Params(Id("x").Op("*").Id(name)).Id("UnmarshalJSON").Params(Id("data").Index().Byte()).Error().Block(
+			Type().Id("plain").Id(name),
+			Return(Qual(encryptionPkg, "UnmarshalJSON").Call(Id("data"), Parens(Op("*").Id("plain")).Call(Id("x")))),
+		))
+	}
+}
@@ -0,0 +1,18 @@
+package encryptiongen_test
+
+import (
+	"testing"
+
+	"encr.dev/v2/app"
+	"encr.dev/v2/codegen"
+	"encr.dev/v2/codegen/infragen"
+	"encr.dev/v2/codegen/internal/codegentest"
+)
+
+func TestCodegen(t *testing.T) {
+	fn := func(gen *codegen.Generator, desc *app.Desc) {
+		infragen.Process(gen, desc)
+	}
+
+	codegentest.Run(t, fn)
+}
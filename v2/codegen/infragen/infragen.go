@@ -8,6 +8,7 @@ import (
 	"encr.dev/v2/codegen"
 	"encr.dev/v2/codegen/infragen/cachegen"
 	"encr.dev/v2/codegen/infragen/configgen"
+	"encr.dev/v2/codegen/infragen/encryptiongen"
 	"encr.dev/v2/codegen/infragen/metricsgen"
 	"encr.dev/v2/codegen/infragen/pubsubgen"
 	"encr.dev/v2/codegen/infragen/secretsgen"
@@ -83,4 +84,13 @@ func Process(gg *codegen.Generator, appDesc *app.Desc) {
 			}))
 		}
 	}
+	encrypted := make(map[paths.Pkg][]*app.EncryptedStruct)
+	for _, s := range appDesc.EncryptedStructs {
+		pkg := s.File.Pkg
+		encrypted[pkg.ImportPath] = append(encrypted[pkg.ImportPath], s)
+		pkgMap[pkg.ImportPath] = pkg
+	}
+	for pkgPath, structs := range encrypted {
+		encryptiongen.Gen(gg, pkgMap[pkgPath], structs)
+	}
 }
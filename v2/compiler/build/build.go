@@ -274,6 +274,13 @@ func (b *builder) buildMain() {
 		}
 		args = append(args, "-ldflags", ldflags.String())
 
+		if build.Reproducible {
+			// Keep the paths of the build directories, which vary between
+			// builds, and the VCS stamp out of the binary. The app's
+			// revision is recorded in the static config instead.
+			args = append(args, "-trimpath", "-buildvcs=false")
+		}
+
 		if b.cfg.Ctx.Build.Debug > builderpkg.DebugModeDisabled {
 			// Disable inlining for better debugging.
 			args = append(args, "-gcflags", "all=-N -l")
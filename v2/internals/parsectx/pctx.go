@@ -146,6 +146,10 @@ type BuildInfo struct {
 	// DisableSensitiveScrubbing, if true, disables scrubbing of sensitive fields.
 	// Used for local development.
 	DisableSensitiveScrubbing bool
+
+	// Reproducible, if true, omits build paths and other
+	// host-specific details from the compiled binary.
+	Reproducible bool
 }
 
 // Trace traces the execution of a function.
@@ -370,6 +370,7 @@ const (
 	uuidImportPath   paths.Pkg = "encore.dev/types/uuid"
 	optionImportPath paths.Pkg = "encore.dev/types/option"
 	authImportPath   paths.Pkg = "encore.dev/beta/auth"
+	uploadImportPath paths.Pkg = "encore.dev/types/upload"
 )
 
 // parseRecv parses a receiver AST into a Receiver.
@@ -418,6 +419,8 @@ func (r *typeResolver) parseEncoreBuiltin(pkgPath paths.Pkg, name string) (Built
 		return UUID, true
 	case pkgPath == authImportPath && name == "UID":
 		return UserID, true
+	case pkgPath == uploadImportPath && name == "File":
+		return File, true
 	case pkgPath == "time" && name == "Time":
 		return Time, true
 	case pkgPath == "encoding/json" && name == "RawMessage":
@@ -75,7 +75,7 @@ func IsValidHeaderType(t schema.Type) bool {
 func isValidHeaderTypeValue(t schema.Type) bool {
 	switch t := t.(type) {
 	case schema.BuiltinType:
-		return true
+		return t.Kind != schema.File
 	case schema.OptionType:
 		return isValidHeaderTypeValue(t.Value)
 	default:
@@ -94,7 +94,7 @@ func IsValidQueryType(t schema.Type) bool {
 func isValidQueryTypeValue(t schema.Type) bool {
 	switch t := t.(type) {
 	case schema.BuiltinType:
-		return true
+		return t.Kind != schema.File
 	case schema.OptionType:
 		return isValidHeaderTypeValue(t.Value)
 	default:
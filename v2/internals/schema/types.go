@@ -189,6 +189,7 @@ const (
 	JSON
 	UserID
 	Error // builtin "error" type, for convenience
+	File  // encore.dev/types/upload.File
 
 	// unsupported is a special value used
 	// to indicate the particular builtin is known,
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"net/http"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -30,6 +32,38 @@ const (
 	Auth AccessType = "auth"
 )
 
+// RoutingSource describes which part of the request a routing condition matches.
+type RoutingSource string
+
+const (
+	RouteByHeader RoutingSource = "header"
+	RouteByQuery  RoutingSource = "query"
+)
+
+// RoutingCondition is a condition a request must match to be routed
+// to an endpoint, in addition to the endpoint's path and HTTP method.
+type RoutingCondition struct {
+	Source RoutingSource
+	Name   string // the header or query parameter name
+	Value  string // the value to match; if empty the name need only be present
+}
+
+func (c RoutingCondition) String() string {
+	if c.Value == "" {
+		return string(c.Source) + ":" + c.Name
+	}
+	return string(c.Source) + ":" + c.Name + "=" + c.Value
+}
+
+// Experiment describes an endpoint that is an alternate implementation of
+// the endpoint with the same path and HTTP method, that the API gateway
+// routes a share of the requests to.
+type Experiment struct {
+	Name    string
+	Percent int // the percentage of requests routed to the experiment
+	Field   directive.Field
+}
+
 type Endpoint struct {
 	errs *perr.List
 
@@ -52,6 +86,21 @@ type Endpoint struct {
 	// meaning all request/response information will be redacted in traces.
 	Sensitive bool
 
+	// InternalOnly indicates the endpoint is only exposed by the API gateway
+	// to requests originating from internal (private) networks.
+	InternalOnly bool
+
+	// RoutingConditions are additional conditions a request must match
+	// to be routed to this endpoint. They allow multiple raw endpoints
+	// to share the same path. RoutingConditionsField is the first
+	// directive field declaring them.
+	RoutingConditions      []RoutingCondition
+	RoutingConditionsField option.Option[directive.Field]
+
+	// Experiment, if set, makes the endpoint an alternate implementation
+	// of the endpoint with the same path and HTTP method.
+	Experiment option.Option[*Experiment]
+
 	reqEncOnce  sync.Once
 	reqEncoding []*apienc.RequestEncoding
 
@@ -306,11 +355,12 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 
 	var accessField directive.Field
 	var rawTag directive.Field
+	var internalTag directive.Field
 
 	accessOptions := []string{"public", "private", "auth"}
 	ok := directive.Validate(errs, dir, directive.ValidateSpec{
-		AllowedOptions: append([]string{"raw", "sensitive"}, accessOptions...),
-		AllowedFields:  []string{"path", "method"},
+		AllowedOptions: append([]string{"raw", "sensitive", "internal"}, accessOptions...),
+		AllowedFields:  []string{"path", "method", "header", "query", "experiment"},
 
 		ValidateOption: func(errs *perr.List, opt directive.Field) (ok bool) {
 			// If this is an access option, check for duplicates.
@@ -329,6 +379,9 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 				rawTag = opt
 			case "sensitive":
 				endpoint.Sensitive = true
+			case "internal":
+				internalTag = opt
+				endpoint.InternalOnly = true
 			}
 
 			return true
@@ -364,6 +417,34 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 						}
 					}
 				}
+
+			case "header", "query":
+				for _, cond := range f.List() {
+					name, value, _ := strings.Cut(cond, ":")
+					if name == "" {
+						errs.Add(errInvalidRoutingCondition(cond).AtGoNode(f))
+						return false
+					}
+					if f.Key == "header" {
+						name = http.CanonicalHeaderKey(name)
+					}
+					endpoint.RoutingConditions = append(endpoint.RoutingConditions, RoutingCondition{
+						Source: RoutingSource(f.Key),
+						Name:   name,
+						Value:  value,
+					})
+				}
+				if endpoint.RoutingConditionsField.Empty() {
+					endpoint.RoutingConditionsField = option.Some(f)
+				}
+
+			case "experiment":
+				exp, ok := parseExperiment(f)
+				if !ok {
+					errs.Add(errInvalidExperiment(f.Value).AtGoNode(f))
+					return false
+				}
+				endpoint.Experiment = option.Some(exp)
 			}
 			return true
 		},
@@ -389,6 +470,48 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 		errs.Add(errRawEndpointCantBePrivate.AtGoNode(rawTag, errors.AsError("declared as raw here")).AtGoNode(accessField, errors.AsError("set as private here")))
 		return nil, false
 	}
+	if endpoint.Access == Private && endpoint.InternalOnly {
+		// Private APIs are never exposed by the gateway to begin with.
+		errs.Add(errInternalEndpointCantBePrivate.AtGoNode(internalTag, errors.AsError("declared as internal here")).AtGoNode(accessField, errors.AsError("set as private here")))
+		return nil, false
+	}
+	if f, ok := endpoint.RoutingConditionsField.Get(); ok && !endpoint.Raw {
+		errs.Add(errRoutingConditionsRequireRaw.AtGoNode(f))
+		return nil, false
+	}
+	if exp, ok := endpoint.Experiment.Get(); ok {
+		if endpoint.Access == Private {
+			errs.Add(errExperimentCantBePrivate.AtGoNode(exp.Field, errors.AsError("declared as an experiment here")))
+			return nil, false
+		}
+		if f, ok := endpoint.RoutingConditionsField.Get(); ok {
+			errs.Add(errExperimentWithRoutingConditions.AtGoNode(f).AtGoNode(exp.Field, errors.AsHelp("declared as an experiment here")))
+			return nil, false
+		}
+	}
 
 	return endpoint, true
 }
+
+// parseExperiment parses an experiment=name[:percent] directive field.
+func parseExperiment(f directive.Field) (*Experiment, bool) {
+	name, percent, hasPercent := strings.Cut(f.Value, ":")
+	if name == "" {
+		return nil, false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			return nil, false
+		}
+	}
+
+	exp := &Experiment{Name: name, Field: f}
+	if hasPercent {
+		n, err := strconv.Atoi(strings.TrimSuffix(percent, "%"))
+		if err != nil || n < 0 || n > 100 {
+			return nil, false
+		}
+		exp.Percent = n
+	}
+	return exp, true
+}
@@ -119,6 +119,78 @@ func Raw(w http.ResponseWriter, req *http.Request) {}
 				HTTPMethods: []string{"*"},
 			},
 		},
+		{
+			name:    "raw_routing_conditions",
+			imports: []string{"net/http"},
+			def: `
+//encore:api public raw path=/webhook header=x-github-event:push,X-Hub-Signature query=v:2
+func Raw(w http.ResponseWriter, req *http.Request) {}
+`,
+			want: &Endpoint{
+				Name:        "Raw",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Raw:         true,
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "webhook", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"*"},
+				RoutingConditions: []RoutingCondition{
+					{Source: RouteByHeader, Name: "X-Github-Event", Value: "push"},
+					{Source: RouteByHeader, Name: "X-Hub-Signature"},
+					{Source: RouteByQuery, Name: "v", Value: "2"},
+				},
+				RoutingConditionsField: option.Some(directive.Field{Key: "header", Value: "x-github-event:push,X-Hub-Signature"}),
+			},
+		},
+		{
+			name: "routing_conditions_require_raw",
+			def: `
+//encore:api public path=/webhook header=X-Event:push
+func Foo(ctx context.Context) error {}
+`,
+			wantErrs: []string{`.*Routing conditions \(header=... and query=...\) are only supported for raw endpoints.*`},
+		},
+		{
+			name: "experiment",
+			def: `
+//encore:api public method=POST path=/checkout experiment=checkout-v2:10
+func CheckoutV2(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "CheckoutV2",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "checkout", ValueType: schema.String},
+				}},
+				HTTPMethods:      []string{"POST"},
+				HTTPMethodsField: option.Some(directive.Field{Key: "method", Value: "POST"}),
+				Experiment: option.Some(&Experiment{
+					Name:    "checkout-v2",
+					Percent: 10,
+					Field:   directive.Field{Key: "experiment", Value: "checkout-v2:10"},
+				}),
+			},
+		},
+		{
+			name: "experiment_invalid_percent",
+			def: `
+//encore:api public path=/checkout experiment=checkout-v2:150
+func CheckoutV2(ctx context.Context) error {}
+`,
+			wantErrs: []string{`.*Invalid experiment "checkout-v2:150".*`},
+		},
+		{
+			name: "experiment_private",
+			def: `
+//encore:api private path=/checkout experiment=checkout-v2
+func CheckoutV2(ctx context.Context) error {}
+`,
+			wantErrs: []string{`.*Private APIs cannot be experiments.*`},
+		},
 	}
 
 	// testArchive renders the txtar archive to use for a given test.
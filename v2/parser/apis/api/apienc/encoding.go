@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"encr.dev/pkg/errors"
@@ -25,6 +26,7 @@ const (
 	Query      WireLoc = "query"      // Parameter is placed in the query string
 	Body       WireLoc = "body"       // Parameter is placed in the body
 	Cookie     WireLoc = "cookie"     // Parameter is placed in cookies
+	Form       WireLoc = "form"       // Parameter is placed in a form body
 	HTTPStatus WireLoc = "httpstatus" // Parameter represents the HTTP status code
 )
 
@@ -53,6 +55,10 @@ var (
 		location:        HTTPStatus,
 		overrideDefault: true,
 	}
+	FormTag = tagDescription{
+		location:        Form,
+		overrideDefault: true,
+	}
 )
 
 // requestTags is a description of tags used for requests
@@ -61,6 +67,7 @@ var requestTags = map[string]tagDescription{
 	"qs":     QsTag,
 	"header": HeaderTag,
 	"json":   JSONTag,
+	"form":   FormTag,
 }
 
 // responseTags is a description of tags used for responses
@@ -150,10 +157,11 @@ type RequestEncoding struct {
 	HeaderParameters []*ParameterEncoding `json:"header_parameters"`
 	QueryParameters  []*ParameterEncoding `json:"query_parameters"`
 	BodyParameters   []*ParameterEncoding `json:"body_parameters"`
+	FormParameters   []*ParameterEncoding `json:"form_parameters"`
 }
 
 func (r *RequestEncoding) AllParameters() []*ParameterEncoding {
-	return append(append(r.HeaderParameters, r.QueryParameters...), r.BodyParameters...)
+	return append(append(append(r.HeaderParameters, r.QueryParameters...), r.BodyParameters...), r.FormParameters...)
 }
 
 // ParameterEncoding expresses how a parameter should be encoded on the wire
@@ -170,6 +178,9 @@ type ParameterEncoding struct {
 	Doc string `json:"doc"`
 	// Type is the field's type description.
 	Type schema.Type `json:"type"`
+	// MaxSize is the maximum size in bytes of uploaded files, for form parameters.
+	// It is zero if there is no limit.
+	MaxSize int64 `json:"max_size"`
 }
 
 // DescribeResponse generates a ParameterEncoding per field of the response struct and returns it as
@@ -324,11 +335,49 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 			}
 		}
 
+		// Form parameters are sent in the request body, and files
+		// can only be sent as form parameters.
+		for _, field := range fields[Form] {
+			if location == Query {
+				err := errFormParamWithoutBody.
+					AtGoNode(field.Type.ASTExpr(), errors.AsError("form parameter")).
+					AtGoNode(requestAST.AST, errors.AsHelp("used here"))
+				if field, ok := methodsField.Get(); ok {
+					err = err.AtGoNode(field, errors.AsHelp("you could change this to a POST or PUT request"))
+				}
+				errs.Add(err)
+			} else if !IsValidFormType(field.Type) {
+				errs.Add(
+					errInvalidFormType(field.Type.String()).
+						AtGoNode(field.Type.ASTExpr(), errors.AsError("unsupported type")).
+						AtGoNode(requestAST.AST, errors.AsHelp("used here")),
+				)
+			}
+		}
+		if len(fields[Form]) > 0 && len(fields[Body]) > 0 {
+			err := errFormAndBodyParams.AtGoNode(requestSchema.ASTExpr())
+			for _, field := range fields[Body] {
+				err = err.AtGoNode(field.Type.ASTExpr(), errors.AsError("body parameter"))
+			}
+			errs.Add(err)
+		}
+		for _, loc := range []WireLoc{Header, Query, Body} {
+			for _, field := range fields[loc] {
+				if isFileType(field.Type) {
+					errs.Add(
+						errFileOutsideForm.
+							AtGoNode(field.Type.ASTExpr(), errors.AsError(fmt.Sprintf("found %s", field.Location))).
+							AtGoNode(requestAST.AST, errors.AsHelp("used here")),
+					)
+				}
+			}
+		}
+
 		if errs.Len() > 0 {
 			return nil
 		}
 
-		if keys := keyDiff(fields, Query, Header, Body); len(keys) > 0 {
+		if keys := keyDiff(fields, Query, Header, Body, Form); len(keys) > 0 {
 			err := errRequestInvalidLocation.AtGoNode(requestSchema.ASTExpr())
 
 			for _, k := range keys {
@@ -344,6 +393,7 @@ func DescribeRequest(errs *perr.List, requestAST schema.Param, requestSchema sch
 			QueryParameters:  fields[Query],
 			HeaderParameters: fields[Header],
 			BodyParameters:   fields[Body],
+			FormParameters:   fields[Form],
 		})
 	}
 
@@ -531,6 +581,19 @@ func describeParam(errs *perr.List, encodingHints *encodingHints, field schema.S
 				}
 			}
 			param.OmitEmpty = tag.HasOption("omitempty")
+
+			if location == Form {
+				for _, opt := range tag.Options {
+					if v, ok := strings.CutPrefix(opt, "maxsize="); ok {
+						size, err := ParseSize(v)
+						if err != nil {
+							errs.Add(errInvalidMaxSize(v).AtGoNode(field.AST.Tag))
+							return nil, false
+						}
+						param.MaxSize = size
+					}
+				}
+			}
 		}
 	}
 
@@ -554,3 +617,52 @@ func isValidHTTPStatusType(typ schema.Type) bool {
 		return false
 	}
 }
+
+// IsValidFormType reports whether the given type is valid for use as a form parameter.
+// Form parameters can be files (upload.File, *upload.File or []*upload.File),
+// or any type valid in a query string.
+func IsValidFormType(t schema.Type) bool {
+	if list, ok := t.(schema.ListType); ok {
+		if ptr, ok := list.Elem.(schema.PointerType); ok && schemautil.IsBuiltinKind(ptr.Elem, schema.File) {
+			return list.Len == -1
+		}
+	} else if t, n := schemautil.Deref(t); n <= 1 && schemautil.IsBuiltinKind(t, schema.File) {
+		return true
+	}
+	return schemautil.IsValidQueryType(t)
+}
+
+// isFileType reports whether t is upload.File, *upload.File or a slice of them.
+func isFileType(t schema.Type) bool {
+	if list, ok := t.(schema.ListType); ok {
+		t = list.Elem
+	}
+	t, _ = schemautil.Deref(t)
+	return schemautil.IsBuiltinKind(t, schema.File)
+}
+
+// ParseSize parses a size such as "512", "10KB", "5MB" or "1GB" into a number of bytes.
+func ParseSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		mult   int64
+	}{
+		{"KB", 1 << 10},
+		{"MB", 1 << 20},
+		{"GB", 1 << 30},
+		{"B", 1},
+	}
+
+	num, mult := strings.ToUpper(strings.TrimSpace(s)), int64(1)
+	for _, u := range units {
+		if n, ok := strings.CutSuffix(num, u.suffix); ok {
+			num, mult = strings.TrimSpace(n), u.mult
+			break
+		}
+	}
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * mult, nil
+}
@@ -38,7 +38,7 @@ var (
 
 	errRequestInvalidLocation = errRange.New(
 		"Invalid request type",
-		"API request must only contain query, body, and header parameters.",
+		"API request must only contain query, body, form, and header parameters.",
 	)
 
 	errReservedHeaderPrefix = errRange.New(
@@ -88,4 +88,32 @@ var (
 		"Invalid response type",
 		"Fields tagged with encore:\"httpstatus\" must be of an integer type.",
 	)
+
+	errFormParamWithoutBody = errRange.New(
+		"Invalid request type",
+		"Form parameters are sent in the request body, and cannot be used with GET, HEAD or DELETE requests.",
+	)
+
+	errInvalidFormType = errRange.Newf(
+		"Invalid request type",
+		"API request parameters of type %s are not supported in forms. You can only "+
+			"use upload.File, built-in types, or slices of built-in types such as strings, booleans, int, time.Time.",
+	)
+
+	errFormAndBodyParams = errRange.New(
+		"Invalid request type",
+		"API requests with form parameters cannot also contain body parameters. "+
+			"Use the `form` struct tag for all fields sent in the request body.",
+	)
+
+	errFileOutsideForm = errRange.New(
+		"Invalid request type",
+		"Files can only be sent as form parameters. Add a `form` struct tag to the field.",
+		errors.WithDetails("See https://encore.dev/docs/go/primitives/defining-apis#file-uploads for more information."),
+	)
+
+	errInvalidMaxSize = errRange.Newf(
+		"Invalid request type",
+		"Invalid maxsize %q. Specify the size in bytes, or with a KB, MB or GB suffix, like \"maxsize=5MB\".",
+	)
 )
@@ -8,6 +8,10 @@ const rawHint = `hint: signature must be func(http.ResponseWriter, *http.Request
 
 For more information on how to use raw APIs see https://encore.dev/docs/primitives/raw-endpoints`
 
+const experimentHint = `hint: experiments are declared like //encore:api public method=POST path=/checkout experiment=checkout-v2:10
+
+For more information on API experiments see https://encore.dev/docs/go/primitives/api-experiments`
+
 const baseHint = "For more information on how to use APIs see https://encore.dev/docs/primitives/apis"
 
 var (
@@ -44,6 +48,44 @@ For more information on how to use APIs, see https://encore.dev/docs/primitives/
 		"Private APIs cannot be declared as raw endpoints.",
 	)
 
+	errInternalEndpointCantBePrivate = errRange.New(
+		"Invalid API Directive",
+		"Private APIs cannot be declared as internal, as they are not exposed by the API Gateway. Use public or auth together with internal.",
+	)
+
+	errInvalidRoutingCondition = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid routing condition %q, expected the form \"name\" or \"name:value\".",
+	)
+
+	errRoutingConditionsRequireRaw = errRange.New(
+		"Invalid API Directive",
+		"Routing conditions (header=... and query=...) are only supported for raw endpoints.",
+
+		errors.WithDetails(rawHint),
+	)
+
+	errInvalidExperiment = errRange.Newf(
+		"Invalid API Directive",
+		"Invalid experiment %q, expected the form \"name\" or \"name:percent\", where the name consists of letters, digits, '-' and '_', and the percent is between 0 and 100.",
+
+		errors.WithDetails(experimentHint),
+	)
+
+	errExperimentCantBePrivate = errRange.New(
+		"Invalid API Directive",
+		"Private APIs cannot be experiments, as they are not exposed by the API Gateway.",
+
+		errors.WithDetails(experimentHint),
+	)
+
+	errExperimentWithRoutingConditions = errRange.New(
+		"Invalid API Directive",
+		"Experiments cannot have routing conditions (header=... and query=...).",
+
+		errors.WithDetails(experimentHint),
+	)
+
 	errWrongNumberParams = errRange.Newf(
 		"Invalid API Function",
 		"API functions must have at least 1 parameter, found %d parameters.",
@@ -130,6 +172,34 @@ For more information on how to use APIs, see https://encore.dev/docs/primitives/
 		errors.WithDetails(baseHint),
 	)
 
+	ErrConflictingRoutingConditions = errRange.New(
+		"Conflicting API Routes",
+		"Endpoints sharing the same path and method must be raw endpoints with distinct routing conditions, and at most one of them may omit routing conditions.",
+
+		errors.WithDetails(rawHint),
+	)
+
+	ErrExperimentWithoutEndpoint = errRange.New(
+		"Invalid API Experiment",
+		"Experiments must have the same path and method as a regular endpoint they're an alternate implementation of, including the names of path parameters.",
+
+		errors.WithDetails(experimentHint),
+	)
+
+	ErrDuplicateExperiment = errRange.New(
+		"Invalid API Experiment",
+		"Experiments of the same endpoint must have distinct names.",
+
+		errors.WithDetails(experimentHint),
+	)
+
+	ErrExperimentTrafficTooHigh = errRange.Newf(
+		"Invalid API Experiment",
+		"The experiments of an endpoint are routed %d%% of the requests to it, but at most 100%% can be routed to experiments.",
+
+		errors.WithDetails(experimentHint),
+	)
+
 	ErrRawEndpointsCannotBeCalled = errRange.New(
 		"Invalid API call",
 		"Raw APIs cannot be called from within an Encore application.",
@@ -14,4 +14,9 @@ var (
 		"Invalid directive",
 		"Unexpected directive %q on function declaration.",
 	)
+
+	errUnexpectedPackageDirective = errRange.Newf(
+		"Invalid directive",
+		"Unexpected directive %q on package declaration.",
+	)
 )
@@ -0,0 +1,34 @@
+package owner
+
+import (
+	"encr.dev/pkg/errors"
+)
+
+var (
+	errRange = errors.Range(
+		"owner",
+		"For more information on service ownership, see https://encore.dev/docs/go/primitives/services#service-ownership",
+
+		errors.WithRangeSize(20),
+	)
+
+	errMissingTeam = errRange.New(
+		"Invalid encore:owner directive",
+		"encore:owner directives must specify the owning team, like \"encore:owner team=payments\".",
+	)
+
+	errInvalidSlackChannel = errRange.Newf(
+		"Invalid encore:owner directive",
+		"Invalid Slack channel %q: it must start with \"#\", like \"slack=#payments\".",
+	)
+
+	ErrNotServiceRoot = errRange.New(
+		"Invalid encore:owner directive",
+		"encore:owner directives must be placed in the root package of a service.",
+	)
+
+	ErrDuplicateOwners = errRange.New(
+		"Multiple owners for service",
+		"A service can only have one encore:owner directive.",
+	)
+)
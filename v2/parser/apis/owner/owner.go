@@ -0,0 +1,68 @@
+package owner
+
+import (
+	"go/token"
+	"strings"
+
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/parser/apis/directive"
+	"encr.dev/v2/parser/resource"
+)
+
+// Owner describes the team owning a service, declared with an
+// encore:owner directive in the package documentation of the service's root package:
+//
+//	//encore:owner team=payments slack=#payments oncall=payments-primary
+//	package billing
+type Owner struct {
+	File *pkginfo.File
+	Dir  *directive.Directive
+
+	Team         string
+	SlackChannel string // the team's Slack channel, like "#payments", if any
+	OnCall       string // how to reach the team's on-call, if any
+}
+
+func (o *Owner) Kind() resource.Kind       { return resource.ServiceOwner }
+func (o *Owner) Package() *pkginfo.Package { return o.File.Pkg }
+func (o *Owner) Pos() token.Pos            { return o.Dir.Pos() }
+func (o *Owner) End() token.Pos            { return o.Dir.End() }
+func (o *Owner) SortKey() string           { return o.File.Pkg.ImportPath.String() }
+
+type ParseData struct {
+	Errs *perr.List
+	File *pkginfo.File
+	Dir  *directive.Directive
+}
+
+// Parse parses the encore:owner directive in the package documentation of a file.
+// It returns nil if the directive is invalid.
+func Parse(d ParseData) *Owner {
+	ok := directive.Validate(d.Errs, d.Dir, directive.ValidateSpec{
+		AllowedFields: []string{"team", "slack", "oncall"},
+		ValidateField: func(errs *perr.List, f directive.Field) bool {
+			if f.Key == "slack" && !strings.HasPrefix(f.Value, "#") {
+				errs.Add(errInvalidSlackChannel(f.Value).AtGoNode(f))
+				return false
+			}
+			return true
+		},
+	})
+	if !ok {
+		return nil
+	}
+
+	o := &Owner{
+		File:         d.File,
+		Dir:          d.Dir,
+		Team:         d.Dir.Get("team"),
+		SlackChannel: d.Dir.Get("slack"),
+		OnCall:       d.Dir.Get("oncall"),
+	}
+	if o.Team == "" {
+		d.Errs.Add(errMissingTeam.AtGoNode(d.Dir))
+		return nil
+	}
+	return o
+}
@@ -8,6 +8,7 @@ import (
 	"encr.dev/v2/parser/apis/authhandler"
 	"encr.dev/v2/parser/apis/directive"
 	"encr.dev/v2/parser/apis/middleware"
+	"encr.dev/v2/parser/apis/owner"
 	"encr.dev/v2/parser/apis/servicestruct"
 	"encr.dev/v2/parser/resource/resourceparser"
 )
@@ -18,6 +19,24 @@ var Parser = &resourceparser.Parser{
 	InterestingImports: resourceparser.RunAlways,
 	Run: func(p *resourceparser.Pass) {
 		for _, file := range p.Pkg.Files {
+			// The package documentation may declare the owner of the service.
+			if doc := file.AST().Doc; doc != nil {
+				if dir, _, ok := directive.Parse(p.Errs, doc); ok && dir != nil {
+					if dir.Name == "owner" {
+						o := owner.Parse(owner.ParseData{
+							Errs: p.Errs,
+							File: file,
+							Dir:  dir,
+						})
+						if o != nil {
+							p.RegisterResource(o)
+						}
+					} else {
+						p.Errs.Add(errUnexpectedPackageDirective(dir.Name).AtGoNode(dir))
+					}
+				}
+			}
+
 			for _, decl := range file.AST().Decls {
 				switch decl := decl.(type) {
 				case *ast.FuncDecl:
@@ -0,0 +1,29 @@
+package email
+
+import (
+	"encr.dev/pkg/errors"
+)
+
+const (
+	emailNewInboundHelp = "For example `email.NewInbound(\"support\", email.InboundConfig{ Handler: HandleSupportEmail })`"
+)
+
+var (
+	errRange = errors.Range(
+		"email",
+		"For more information on inbound email, see https://encore.dev/docs/primitives/inbound-email",
+	)
+
+	errNewInboundArgCount = errRange.Newf(
+		"Invalid email.NewInbound call",
+		"A call to email.NewInbound requires 2 arguments; the inbound email name and the config object, got %d arguments.",
+		errors.PrependDetails(emailNewInboundHelp),
+	)
+
+	ErrInboundNameNotUnique = errRange.New(
+		"Duplicate inbound email name",
+		"An inbound email name must be unique.",
+
+		errors.PrependDetails("Each inbound email resource receives the messages sent to its own address."),
+	)
+)
@@ -0,0 +1,96 @@
+package email
+
+import (
+	"go/ast"
+	"go/token"
+
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/pkginfo"
+	literals "encr.dev/v2/parser/infra/internal/literals"
+	parseutil "encr.dev/v2/parser/infra/internal/parseutil"
+	"encr.dev/v2/parser/resource"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+type Inbound struct {
+	AST     *ast.CallExpr
+	File    *pkginfo.File
+	Name    string // The unique name of the inbound email resource
+	Doc     string // The documentation on the resource
+	Handler ast.Expr
+}
+
+func (i *Inbound) Kind() resource.Kind       { return resource.InboundEmail }
+func (i *Inbound) Package() *pkginfo.Package { return i.File.Pkg }
+func (i *Inbound) ASTExpr() ast.Expr         { return i.AST }
+func (i *Inbound) ResourceName() string      { return i.Name }
+func (i *Inbound) Pos() token.Pos            { return i.AST.Pos() }
+func (i *Inbound) End() token.Pos            { return i.AST.End() }
+func (i *Inbound) SortKey() string           { return i.Name }
+
+var InboundParser = &resourceparser.Parser{
+	Name: "Inbound Email",
+
+	InterestingImports: []paths.Pkg{"encore.dev/email"},
+	Run: func(p *resourceparser.Pass) {
+		name := pkginfo.QualifiedName{Name: "NewInbound", PkgPath: "encore.dev/email"}
+
+		spec := &parseutil.ReferenceSpec{
+			MinTypeArgs: 0,
+			MaxTypeArgs: 0,
+			Parse:       parseInbound,
+		}
+
+		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+			parseutil.ParseReference(p, spec, parseutil.ReferenceData{
+				File:         file,
+				Stack:        stack,
+				ResourceFunc: name,
+			})
+		})
+	},
+}
+
+func parseInbound(d parseutil.ReferenceInfo) {
+	errs := d.Pass.Errs
+
+	if len(d.Call.Args) != 2 {
+		errs.Add(errNewInboundArgCount(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	}
+
+	inboundName := parseutil.ParseResourceName(d.Pass.Errs, "email.NewInbound", "inbound email name",
+		d.Call.Args[0], parseutil.KebabName, "")
+	if inboundName == "" {
+		// we already reported the error inside ParseResourceName
+		return
+	}
+
+	cfgLit, ok := literals.ParseStruct(d.Pass.Errs, d.File, "email.InboundConfig", d.Call.Args[1])
+	if !ok {
+		return // error reported by ParseStruct
+	}
+
+	// Decode the config
+	type decodedConfig struct {
+		Handler ast.Expr `literal:",dynamic,required"`
+
+		// Attachments is a bucket reference, which is
+		// tracked as a usage of the bucket.
+		Attachments ast.Expr `literal:",optional,dynamic"`
+	}
+	cfg := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	if cfg.Handler == nil {
+		return
+	}
+
+	inbound := &Inbound{
+		AST:     d.Call,
+		File:    d.File,
+		Name:    inboundName,
+		Doc:     d.Doc,
+		Handler: cfg.Handler,
+	}
+	d.Pass.RegisterResource(inbound)
+	d.Pass.AddBind(d.File, d.Ident, inbound)
+}
@@ -0,0 +1,64 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseInbound(t *testing.T) {
+	tests := []resourcetest.Case[*Inbound]{
+		{
+			Name: "basic",
+			Code: `
+// Inbound docs
+var x = email.NewInbound("support", email.InboundConfig{
+	Handler: handle,
+})
+
+func handle(ctx context.Context, msg *email.Message) error { return nil }
+`,
+			Want: &Inbound{
+				Name: "support",
+				Doc:  "Inbound docs\n",
+			},
+		},
+		{
+			Name: "attachments",
+			Code: `
+var bkt = objects.NewBucket("attachments", objects.BucketConfig{})
+
+var x = email.NewInbound("support", email.InboundConfig{
+	Handler:     handle,
+	Attachments: objects.BucketRef[objects.Uploader](bkt),
+})
+
+func handle(ctx context.Context, msg *email.Message) error { return nil }
+`,
+			Imports: []string{"encore.dev/storage/objects"},
+			Want: &Inbound{
+				Name: "support",
+			},
+		},
+		{
+			Name: "no_handler",
+			Code: `
+var x = email.NewInbound("support", email.InboundConfig{})
+`,
+			WantErrs: []string{`.*Handler.*`},
+		},
+		{
+			Name: "invalid_name",
+			Code: `
+var x = email.NewInbound("Support", email.InboundConfig{
+	Handler: func(ctx context.Context, msg *email.Message) error { return nil },
+})
+`,
+			WantErrs: []string{`.*Invalid resource name.*`},
+		},
+	}
+
+	resourcetest.Run(t, InboundParser, tests, cmpopts.IgnoreFields(Inbound{}, "AST", "File"))
+}
@@ -0,0 +1,107 @@
+package envvar
+
+import (
+	"go/ast"
+	"go/token"
+
+	"encore.dev/envvar"
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/pkginfo"
+	literals "encr.dev/v2/parser/infra/internal/literals"
+	parseutil "encr.dev/v2/parser/infra/internal/parseutil"
+	"encr.dev/v2/parser/resource"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+type Var struct {
+	AST         *ast.CallExpr
+	File        *pkginfo.File
+	Name        string // The name of the environment variable
+	Doc         string // The documentation on the declaration
+	Description string
+	Required    bool
+	Format      envvar.Format
+}
+
+func (v *Var) Kind() resource.Kind       { return resource.EnvVar }
+func (v *Var) Package() *pkginfo.Package { return v.File.Pkg }
+func (v *Var) ASTExpr() ast.Expr         { return v.AST }
+func (v *Var) ResourceName() string      { return v.Name }
+func (v *Var) Pos() token.Pos            { return v.AST.Pos() }
+func (v *Var) End() token.Pos            { return v.AST.End() }
+func (v *Var) SortKey() string           { return v.Name }
+
+var VarParser = &resourceparser.Parser{
+	Name: "Environment Variable",
+
+	InterestingImports: []paths.Pkg{"encore.dev/envvar"},
+	Run: func(p *resourceparser.Pass) {
+		name := pkginfo.QualifiedName{Name: "New", PkgPath: "encore.dev/envvar"}
+
+		spec := &parseutil.ReferenceSpec{
+			MinTypeArgs: 0,
+			MaxTypeArgs: 0,
+			Parse:       parseVar,
+		}
+
+		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+			parseutil.ParseReference(p, spec, parseutil.ReferenceData{
+				File:         file,
+				Stack:        stack,
+				ResourceFunc: name,
+			})
+		})
+	},
+}
+
+func parseVar(d parseutil.ReferenceInfo) {
+	errs := d.Pass.Errs
+
+	if len(d.Call.Args) != 2 {
+		errs.Add(errNewArgCount(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	}
+
+	varName := parseutil.ParseResourceName(d.Pass.Errs, "envvar.New", "environment variable name",
+		d.Call.Args[0], parseutil.ScreamingSnakeName, "ENCORE_")
+	if varName == "" {
+		// we already reported the error inside ParseResourceName
+		return
+	}
+
+	cfgLit, ok := literals.ParseStruct(d.Pass.Errs, d.File, "envvar.Config", d.Call.Args[1])
+	if !ok {
+		return // error reported by ParseStruct
+	}
+
+	// Decode the config
+	type decodedConfig struct {
+		Description string `literal:",optional"`
+		Required    bool   `literal:",optional"`
+		Format      string `literal:",optional,default"`
+	}
+	defaultValues := decodedConfig{
+		Format: string(envvar.String),
+	}
+	cfg := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, &defaultValues)
+
+	switch envvar.Format(cfg.Format) {
+	case envvar.String, envvar.Int, envvar.Bool, envvar.URL, envvar.Duration:
+		// all good
+	default:
+		errs.Add(errInvalidFormat(cfg.Format).AtGoNode(cfgLit.Expr("Format")))
+		return
+	}
+
+	v := &Var{
+		AST:         d.Call,
+		File:        d.File,
+		Name:        varName,
+		Doc:         d.Doc,
+		Description: cfg.Description,
+		Required:    cfg.Required,
+		Format:      envvar.Format(cfg.Format),
+	}
+	d.Pass.RegisterResource(v)
+	d.Pass.AddBind(d.File, d.Ident, v)
+}
@@ -0,0 +1,66 @@
+package envvar
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"encore.dev/envvar"
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseVar(t *testing.T) {
+	tests := []resourcetest.Case[*Var]{
+		{
+			Name: "basic",
+			Code: `
+// Var docs
+var x = envvar.New("STRIPE_URL", envvar.Config{
+	Description: "Base URL of the Stripe API",
+	Required:    true,
+	Format:      envvar.URL,
+})
+`,
+			Want: &Var{
+				Name:        "STRIPE_URL",
+				Doc:         "Var docs\n",
+				Description: "Base URL of the Stripe API",
+				Required:    true,
+				Format:      envvar.URL,
+			},
+		},
+		{
+			Name: "default_format",
+			Code: `
+var x = envvar.New("REGION", envvar.Config{})
+`,
+			Want: &Var{
+				Name:   "REGION",
+				Format: envvar.String,
+			},
+		},
+		{
+			Name: "invalid_format",
+			Code: `
+var x = envvar.New("REGION", envvar.Config{Format: "uuid"})
+`,
+			WantErrs: []string{`.*Invalid environment variable format.*`},
+		},
+		{
+			Name: "invalid_name",
+			Code: `
+var x = envvar.New("stripe_url", envvar.Config{})
+`,
+			WantErrs: []string{`.*Invalid resource name.*`},
+		},
+		{
+			Name: "reserved_name",
+			Code: `
+var x = envvar.New("ENCORE_REGION", envvar.Config{})
+`,
+			WantErrs: []string{`.*reserved.*`},
+		},
+	}
+
+	resourcetest.Run(t, VarParser, tests, cmpopts.IgnoreFields(Var{}, "AST", "File"))
+}
@@ -0,0 +1,46 @@
+package envvar
+
+import (
+	"encr.dev/pkg/errors"
+)
+
+const (
+	envvarNewHelp = "For example `envvar.New(\"STRIPE_URL\", envvar.Config{ Description: \"Base URL of the Stripe API\", Format: envvar.URL })`"
+)
+
+var (
+	errRange = errors.Range(
+		"envvar",
+		"For more information on environment variables, see https://encore.dev/docs/primitives/environment-variables",
+	)
+
+	errNewArgCount = errRange.Newf(
+		"Invalid envvar.New call",
+		"A call to envvar.New requires 2 arguments; the environment variable name and the config object, got %d arguments.",
+		errors.PrependDetails(envvarNewHelp),
+	)
+
+	errInvalidFormat = errRange.Newf(
+		"Invalid environment variable format",
+		"The format %q is not supported.",
+		errors.PrependDetails("The format must be one of envvar.String, envvar.Int, envvar.Bool, envvar.URL and envvar.Duration."),
+	)
+
+	ErrConflictingDeclarations = errRange.Newf(
+		"Conflicting environment variable declarations",
+		"The environment variable %s is declared more than once with a different configuration.",
+		errors.PrependDetails("An environment variable may be declared by several services, but the declarations must agree on whether it's required and its format."),
+	)
+
+	ErrMissing = errRange.Newf(
+		"Missing environment variable",
+		"The environment variable %s is required, but it's not set.",
+		errors.PrependDetails("Set it in your shell, with `encore run --env`, or in a file loaded with `encore run --env-file`."),
+	)
+
+	ErrInvalidValue = errRange.Newf(
+		"Invalid environment variable",
+		"The value of the environment variable %s is invalid: %v.",
+		errors.PrependDetails("Change it to match the format it's declared with."),
+	)
+)
@@ -4,6 +4,7 @@ import (
 	"go/constant"
 	"time"
 
+	"encore.dev/envvar"
 	"encore.dev/storage/cache"
 	"encr.dev/pkg/paths"
 )
@@ -14,6 +15,9 @@ var constants = map[paths.Pkg]map[string]any{
 		"InfiniteRetries": -1,
 		"AtLeastOnce":     1,
 		"ExactlyOnce":     2,
+
+		"CloudEventsBinary":     "binary",
+		"CloudEventsStructured": "structured",
 	},
 	"encore.dev/cron": {
 		"Minute": 60,
@@ -29,6 +33,17 @@ var constants = map[paths.Pkg]map[string]any{
 		"VolatileRandom": string(cache.VolatileRandom),
 		"NoEviction":     string(cache.NoEviction),
 	},
+	"encore.dev/storage/sqldb": {
+		"Postgres": "postgres",
+		"MySQL":    "mysql",
+	},
+	"encore.dev/envvar": {
+		"String":   string(envvar.String),
+		"Int":      string(envvar.Int),
+		"Bool":     string(envvar.Bool),
+		"URL":      string(envvar.URL),
+		"Duration": string(envvar.Duration),
+	},
 	"time": {
 		"Nanosecond":  int64(time.Nanosecond),
 		"Microsecond": int64(time.Microsecond),
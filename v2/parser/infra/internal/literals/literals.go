@@ -74,7 +74,12 @@ elemLoop:
 				}
 
 			case *ast.CompositeLit:
-				subStruct = value
+				// Slice and map literals are kept as dynamic values.
+				switch value.Type.(type) {
+				case *ast.ArrayType, *ast.MapType:
+				default:
+					subStruct = value
+				}
 			}
 
 			if subStruct != nil {
@@ -86,6 +91,9 @@ elemLoop:
 			} else {
 				// Parse the value
 				lit.allFields[ident.Name] = elem.Value
+				if _, isComposite := elem.Value.(*ast.CompositeLit); isComposite {
+					continue elemLoop
+				}
 				value := ParseConstant(errs, file, elem.Value)
 				if value.Kind() != constant.Unknown {
 					lit.constantFields[ident.Name] = value
@@ -53,6 +53,21 @@ var SnakeName = resourceNameSpec{
 	},
 }
 
+var ScreamingSnakeName = resourceNameSpec{
+	regexp:     regexp.MustCompile(`^[A-Z][_A-Z0-9]*$`),
+	errDetails: resourceNameHelpScreamingSnakeCase,
+	invalidNameErr: func(node ast.Node, resourceType, paramName, name string) errors.Template {
+		err := errResourceNameNotCorrectFormat(resourceType, paramName, "SCREAMING_SNAKE_CASE").
+			WithDetails(resourceNameHelpScreamingSnakeCase(resourceType, paramName)).
+			AtGoNode(node, errors.AsError(fmt.Sprintf("try %s?", idents.GenerateSuggestion(name, idents.ScreamingSnakeCase))))
+
+		return err
+	},
+	reservedErr: func(fset *token.FileSet, node ast.Node, resourceType, paramName, name, reservedPrefix string) error {
+		return srcerrors.ResourceNameReserved(fset, node, resourceType, paramName, name, reservedPrefix, true)
+	},
+}
+
 // ParseResourceName checks the given node is a string literal
 // and that it conforms to the given spec.
 //
@@ -108,3 +123,11 @@ func resourceNameHelpSnakeCase(resourceName string, paramName string) string {
 		resourceName, paramName,
 	)
 }
+
+func resourceNameHelpScreamingSnakeCase(resourceName string, paramName string) string {
+	return fmt.Sprintf("%s %s's must be defined as string literals, "+
+		"be between 1 and 63 characters long, and defined in \"SCREAMING_SNAKE_CASE\", meaning it must start with a letter "+
+		"and only contain upper case letters, numbers and underscores.",
+		resourceName, paramName,
+	)
+}
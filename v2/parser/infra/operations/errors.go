@@ -0,0 +1,29 @@
+package operations
+
+import (
+	"encr.dev/pkg/errors"
+)
+
+const (
+	operationsNewTrackerHelp = "For example `operations.NewTracker(\"exports\", operations.TrackerConfig{ DB: db })`"
+)
+
+var (
+	errRange = errors.Range(
+		"operations",
+		"For more information on long-running operations, see https://encore.dev/docs/primitives/operations",
+	)
+
+	errNewTrackerArgCount = errRange.Newf(
+		"Invalid operations.NewTracker call",
+		"A call to operations.NewTracker requires 2 arguments; the tracker name and the config object, got %d arguments.",
+		errors.PrependDetails(operationsNewTrackerHelp),
+	)
+
+	ErrTrackerNameNotUnique = errRange.New(
+		"Duplicate operation tracker name",
+		"An operation tracker name must be unique.",
+
+		errors.PrependDetails("The name identifies the tracker in the polling endpoint, so it must be unique within the application."),
+	)
+)
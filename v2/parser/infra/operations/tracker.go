@@ -0,0 +1,95 @@
+package operations
+
+import (
+	"go/ast"
+	"go/token"
+
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/pkginfo"
+	literals "encr.dev/v2/parser/infra/internal/literals"
+	parseutil "encr.dev/v2/parser/infra/internal/parseutil"
+	"encr.dev/v2/parser/resource"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+type Tracker struct {
+	AST     *ast.CallExpr
+	File    *pkginfo.File
+	Name    string // The unique name of the operation tracker
+	Doc     string // The documentation on the resource
+	Private bool   // Whether the status of operations isn't served to clients
+}
+
+func (t *Tracker) Kind() resource.Kind       { return resource.OperationTracker }
+func (t *Tracker) Package() *pkginfo.Package { return t.File.Pkg }
+func (t *Tracker) ASTExpr() ast.Expr         { return t.AST }
+func (t *Tracker) ResourceName() string      { return t.Name }
+func (t *Tracker) Pos() token.Pos            { return t.AST.Pos() }
+func (t *Tracker) End() token.Pos            { return t.AST.End() }
+func (t *Tracker) SortKey() string           { return t.Name }
+
+var TrackerParser = &resourceparser.Parser{
+	Name: "Operation Tracker",
+
+	InterestingImports: []paths.Pkg{"encore.dev/operations"},
+	Run: func(p *resourceparser.Pass) {
+		name := pkginfo.QualifiedName{Name: "NewTracker", PkgPath: "encore.dev/operations"}
+
+		spec := &parseutil.ReferenceSpec{
+			MinTypeArgs: 0,
+			MaxTypeArgs: 0,
+			Parse:       parseTracker,
+		}
+
+		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+			parseutil.ParseReference(p, spec, parseutil.ReferenceData{
+				File:         file,
+				Stack:        stack,
+				ResourceFunc: name,
+			})
+		})
+	},
+}
+
+func parseTracker(d parseutil.ReferenceInfo) {
+	errs := d.Pass.Errs
+
+	if len(d.Call.Args) != 2 {
+		errs.Add(errNewTrackerArgCount(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	}
+
+	trackerName := parseutil.ParseResourceName(d.Pass.Errs, "operations.NewTracker", "operation tracker name",
+		d.Call.Args[0], parseutil.KebabName, "")
+	if trackerName == "" {
+		// we already reported the error inside ParseResourceName
+		return
+	}
+
+	cfgLit, ok := literals.ParseStruct(d.Pass.Errs, d.File, "operations.TrackerConfig", d.Call.Args[1])
+	if !ok {
+		return // error reported by ParseStruct
+	}
+
+	// Decode the config
+	type decodedConfig struct {
+		// DB is the database operations are stored in, which is
+		// tracked as a usage of the database.
+		DB      ast.Expr `literal:",dynamic,required"`
+		Private bool     `literal:",optional"`
+	}
+	cfg := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
+	if cfg.DB == nil {
+		return
+	}
+
+	tracker := &Tracker{
+		AST:     d.Call,
+		File:    d.File,
+		Name:    trackerName,
+		Doc:     d.Doc,
+		Private: cfg.Private,
+	}
+	d.Pass.RegisterResource(tracker)
+	d.Pass.AddBind(d.File, d.Ident, tracker)
+}
@@ -0,0 +1,65 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseTracker(t *testing.T) {
+	tests := []resourcetest.Case[*Tracker]{
+		{
+			Name: "basic",
+			Code: `
+var db = sqldb.NewDatabase("exports", sqldb.DatabaseConfig{Migrations: "./migrations"})
+
+// Tracker docs
+var x = operations.NewTracker("exports", operations.TrackerConfig{
+	DB: db,
+})
+`,
+			Imports: []string{"encore.dev/storage/sqldb"},
+			Want: &Tracker{
+				Name: "exports",
+				Doc:  "Tracker docs\n",
+			},
+		},
+		{
+			Name: "private",
+			Code: `
+var db = sqldb.NewDatabase("exports", sqldb.DatabaseConfig{Migrations: "./migrations"})
+
+var x = operations.NewTracker("exports", operations.TrackerConfig{
+	DB:      db,
+	Private: true,
+})
+`,
+			Imports: []string{"encore.dev/storage/sqldb"},
+			Want: &Tracker{
+				Name:    "exports",
+				Private: true,
+			},
+		},
+		{
+			Name: "no_db",
+			Code: `
+var x = operations.NewTracker("exports", operations.TrackerConfig{})
+`,
+			WantErrs: []string{`.*DB.*`},
+		},
+		{
+			Name: "invalid_name",
+			Code: `
+var db = sqldb.NewDatabase("exports", sqldb.DatabaseConfig{Migrations: "./migrations"})
+
+var x = operations.NewTracker("Exports", operations.TrackerConfig{DB: db})
+`,
+			Imports:  []string{"encore.dev/storage/sqldb"},
+			WantErrs: []string{`.*Invalid resource name.*`},
+		},
+	}
+
+	resourcetest.Run(t, TrackerParser, tests, cmpopts.IgnoreFields(Tracker{}, "AST", "File"))
+}
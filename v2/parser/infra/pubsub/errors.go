@@ -48,6 +48,12 @@ var (
 		errors.PrependDetails(pubsubNewTopicHelp),
 	)
 
+	errInvalidCloudEventsMode = errRange.New(
+		"Invalid PubSub topic config",
+		"The configuration field named \"CloudEvents.Mode\" must be set to pubsub.CloudEventsBinary or pubsub.CloudEventsStructured.",
+		errors.PrependDetails(pubsubNewTopicHelp),
+	)
+
 	errInvalidTopicUsage = errRange.New(
 		"Invalid reference to pubsub.Topic",
 		"A reference to pubsub.Topic is not permissible here.",
@@ -95,6 +95,11 @@ func parsePubSubTopic(d parseutil.ReferenceInfo) {
 	type decodedConfig struct {
 		DeliveryGuarantee int    `literal:",optional"` // optional rather than required because we check for a zero value below
 		OrderingAttribute string `literal:",optional"`
+		CloudEvents       struct {
+			Mode   string `literal:",optional"`
+			Source string `literal:",optional"`
+			Type   string `literal:",optional"`
+		} `literal:",optional"`
 	}
 	config := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
 
@@ -119,6 +124,10 @@ func parsePubSubTopic(d parseutil.ReferenceInfo) {
 		}
 	}
 
+	if mode := config.CloudEvents.Mode; mode != "" && mode != "binary" && mode != "structured" {
+		errs.Add(errInvalidCloudEventsMode.AtGoNode(cfgLit.Expr("CloudEvents.Mode")))
+	}
+
 	deliveryGuarantee := DeliveryGuarantee(config.DeliveryGuarantee) - 1 // The runtime variables are 1 indexed so we can detect a zero value
 	if deliveryGuarantee != AtLeastOnce && deliveryGuarantee != ExactlyOnce {
 		pos := cfgLit.Pos("DeliveryGuarantee")
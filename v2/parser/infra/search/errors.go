@@ -0,0 +1,41 @@
+package search
+
+import (
+	"encr.dev/pkg/errors"
+)
+
+const (
+	searchNewIndexHelp = "For example `search.NewIndex[Article](\"articles\", search.IndexConfig{ SearchableFields: []string{\"title\", \"body\"} })`"
+)
+
+var (
+	errRange = errors.Range(
+		"search",
+		"For more information on Search, see https://encore.dev/docs/primitives/search",
+	)
+
+	errNewIndexArgCount = errRange.Newf(
+		"Invalid search.NewIndex call",
+		"A call to search.NewIndex requires 2 arguments; the index name and the config object, got %d arguments.",
+		errors.PrependDetails(searchNewIndexHelp),
+	)
+
+	errInvalidIndexConfig = errRange.Newf(
+		"Invalid search.IndexConfig",
+		"%s",
+		errors.PrependDetails(searchNewIndexHelp),
+	)
+
+	errNoSearchableFields = errRange.New(
+		"Invalid search.IndexConfig",
+		"A search index requires at least one searchable field.",
+		errors.PrependDetails(searchNewIndexHelp),
+	)
+
+	ErrIndexNameNotUnique = errRange.New(
+		"Duplicate search index name",
+		"A search index name must be unique.",
+
+		errors.PrependDetails("If you wish to reuse the same index, then you can export the original Index object and reference it from here."),
+	)
+)
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"slices"
+
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/pkginfo"
+	literals "encr.dev/v2/parser/infra/internal/literals"
+	parseutil "encr.dev/v2/parser/infra/internal/parseutil"
+	"encr.dev/v2/parser/resource"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+type Index struct {
+	AST              *ast.CallExpr
+	File             *pkginfo.File
+	Name             string // The unique name of the index
+	Doc              string // The documentation on the index
+	SearchableFields []string
+	FilterableFields []string
+}
+
+func (t *Index) Kind() resource.Kind       { return resource.SearchIndex }
+func (t *Index) Package() *pkginfo.Package { return t.File.Pkg }
+func (t *Index) ASTExpr() ast.Expr         { return t.AST }
+func (t *Index) ResourceName() string      { return t.Name }
+func (t *Index) Pos() token.Pos            { return t.AST.Pos() }
+func (t *Index) End() token.Pos            { return t.AST.End() }
+func (t *Index) SortKey() string           { return t.Name }
+
+var IndexParser = &resourceparser.Parser{
+	Name: "Search Index",
+
+	InterestingImports: []paths.Pkg{"encore.dev/storage/search"},
+	Run: func(p *resourceparser.Pass) {
+		name := pkginfo.QualifiedName{Name: "NewIndex", PkgPath: "encore.dev/storage/search"}
+
+		spec := &parseutil.ReferenceSpec{
+			MinTypeArgs: 1,
+			MaxTypeArgs: 1,
+			Parse:       parseIndex,
+		}
+
+		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+			parseutil.ParseReference(p, spec, parseutil.ReferenceData{
+				File:         file,
+				Stack:        stack,
+				ResourceFunc: name,
+			})
+		})
+	},
+}
+
+func parseIndex(d parseutil.ReferenceInfo) {
+	errs := d.Pass.Errs
+
+	if len(d.Call.Args) != 2 {
+		errs.Add(errNewIndexArgCount(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	}
+
+	indexName := parseutil.ParseResourceName(d.Pass.Errs, "search.NewIndex", "index name",
+		d.Call.Args[0], parseutil.KebabName, "")
+	if indexName == "" {
+		// we already reported the error inside ParseResourceName
+		return
+	}
+
+	// The config consists of string lists, which literals.ParseStruct doesn't support,
+	// so parse it here.
+	cfgLit, ok := d.Call.Args[1].(*ast.CompositeLit)
+	if !ok {
+		errs.Add(errInvalidIndexConfig("The config must be a search.IndexConfig literal.").AtGoNode(d.Call.Args[1]))
+		return
+	}
+	fields := make(map[string][]string)
+	for _, elem := range cfgLit.Elts {
+		kv, ok := elem.(*ast.KeyValueExpr)
+		if !ok {
+			errs.Add(errInvalidIndexConfig("The config fields must be given by name.").AtGoNode(elem))
+			return
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || (key.Name != "SearchableFields" && key.Name != "FilterableFields") {
+			errs.Add(errInvalidIndexConfig("Unknown config field.").AtGoNode(kv.Key))
+			return
+		}
+		list, ok := parseStringList(d, kv.Value)
+		if !ok {
+			return // error reported by parseStringList
+		}
+		fields[key.Name] = list
+	}
+
+	if len(fields["SearchableFields"]) == 0 {
+		errs.Add(errNoSearchableFields.AtGoNode(cfgLit))
+		return
+	}
+
+	idx := &Index{
+		AST:              d.Call,
+		File:             d.File,
+		Name:             indexName,
+		Doc:              d.Doc,
+		SearchableFields: fields["SearchableFields"],
+		FilterableFields: fields["FilterableFields"],
+	}
+	d.Pass.RegisterResource(idx)
+	d.Pass.AddBind(d.File, d.Ident, idx)
+}
+
+// parseStringList parses a []string literal of distinct, constant strings.
+func parseStringList(d parseutil.ReferenceInfo, expr ast.Expr) (list []string, ok bool) {
+	errs := d.Pass.Errs
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		errs.Add(errInvalidIndexConfig("The fields must be a []string literal.").AtGoNode(expr))
+		return nil, false
+	}
+
+	for _, elem := range lit.Elts {
+		val := literals.ParseConstant(errs, d.File, elem)
+		if val.Kind() != constant.String || constant.StringVal(val) == "" {
+			errs.Add(errInvalidIndexConfig("The field names must be non-empty constant strings.").AtGoNode(elem))
+			return nil, false
+		}
+		name := constant.StringVal(val)
+		if slices.Contains(list, name) {
+			errs.Add(errInvalidIndexConfig("The field " + name + " is listed more than once.").AtGoNode(elem))
+			return nil, false
+		}
+		list = append(list, name)
+	}
+	return list, true
+}
@@ -0,0 +1,76 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseIndex(t *testing.T) {
+	tests := []resourcetest.Case[*Index]{
+		{
+			Name: "basic",
+			Code: `
+type Article struct {
+	Title  string ` + "`json:\"title\"`" + `
+	Body   string ` + "`json:\"body\"`" + `
+	Author string ` + "`json:\"author\"`" + `
+}
+
+// Index docs
+var x = search.NewIndex[Article]("articles", search.IndexConfig{
+	SearchableFields: []string{"title", "body"},
+	FilterableFields: []string{"author"},
+})
+`,
+			Want: &Index{
+				Name:             "articles",
+				Doc:              "Index docs\n",
+				SearchableFields: []string{"title", "body"},
+				FilterableFields: []string{"author"},
+			},
+		},
+		{
+			Name: "constant_fields",
+			Code: `
+const titleField = "title"
+
+var x = search.NewIndex[map[string]any]("articles", search.IndexConfig{
+	SearchableFields: []string{titleField},
+})
+`,
+			WantErrs: []string{`.*The field names must be non-empty constant strings.*`},
+		},
+		{
+			Name: "no_searchable_fields",
+			Code: `
+var x = search.NewIndex[map[string]any]("articles", search.IndexConfig{
+	FilterableFields: []string{"author"},
+})
+`,
+			WantErrs: []string{`.*requires at least one searchable field.*`},
+		},
+		{
+			Name: "duplicate_field",
+			Code: `
+var x = search.NewIndex[map[string]any]("articles", search.IndexConfig{
+	SearchableFields: []string{"title", "title"},
+})
+`,
+			WantErrs: []string{`.*The field title is listed more than once.*`},
+		},
+		{
+			Name: "invalid_name",
+			Code: `
+var x = search.NewIndex[map[string]any]("Articles", search.IndexConfig{
+	SearchableFields: []string{"title"},
+})
+`,
+			WantErrs: []string{`.*Invalid resource name.*`},
+		},
+	}
+
+	resourcetest.Run(t, IndexParser, tests, cmpopts.IgnoreFields(Index{}, "AST", "File"))
+}
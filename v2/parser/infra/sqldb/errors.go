@@ -59,4 +59,44 @@ var (
 		"Unknown sqldb database",
 		"No database named %q was found in the application. Ensure it is created somewhere using sqldb.NewDatabase to be able to reference it.",
 	)
+	errInvalidEngine = errRange.Newf(
+		"Invalid sqldb.NewDatabase call",
+		"The database engine must be sqldb.Postgres or sqldb.MySQL, got %q.",
+	)
+	errRowLevelSecurityRequiresPostgres = errRange.New(
+		"Invalid sqldb.NewDatabase call",
+		"Row-level security is only supported by databases using the Postgres engine.",
+	)
+	errReadAccessRequiresPostgres = errRange.New(
+		"Invalid sqldb.NewDatabase call",
+		"Read access grants are only supported by databases using the Postgres engine.",
+	)
+	errInvalidReadAccess = errRange.New(
+		"Invalid sqldb.NewDatabase call",
+		"ReadAccess must be a slice literal of service names, like []string{\"orders\"}.",
+	)
+	errNamedReadOnlyRequiresDatabaseName = errRange.Newf(
+		"Invalid call to sqldb.NamedReadOnly",
+		"sqldb.NamedReadOnly requires a database name to be passed as the only argument, got %d arguments.",
+	)
+	ErrInvalidReadGrant = errRange.Newf(
+		"Invalid read access grant",
+		"The database %q grants read access to %s.",
+	)
+	ErrReadAccessNotGranted = errRange.Newf(
+		"Read access not granted",
+		"The service %q references the database %q with sqldb.NamedReadOnly, "+
+			"but the database does not grant it read access. Add the service to ReadAccess in the database's sqldb.DatabaseConfig.",
+	)
+	ErrCrossServiceDatabaseAccess = errRange.Newf(
+		"Invalid cross-service database access",
+		"The database %q declares the services granted read access to it, so other services must reference it with sqldb.NamedReadOnly.",
+	)
+)
+
+var errMissingRowLevelSecurity = errRange.Newf(
+	"Missing row-level security policy",
+	"Row-level security is enabled for the database, but %s. "+
+		"Enable row-level security with \"ALTER TABLE <table> ENABLE ROW LEVEL SECURITY\" and define policies with \"CREATE POLICY\" in a migration, "+
+		"or opt the table out with \"ALTER TABLE <table> DISABLE ROW LEVEL SECURITY\".",
 )
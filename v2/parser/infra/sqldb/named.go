@@ -3,8 +3,10 @@ package sqldb
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 
 	"encr.dev/pkg/errors"
+	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
 	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/parser/infra/internal/literals"
@@ -18,15 +20,26 @@ var NamedParser = &resourceparser.Parser{
 
 	InterestingImports: []paths.Pkg{"encore.dev/storage/sqldb"},
 	Run: func(p *resourceparser.Pass) {
-		name := pkginfo.QualifiedName{Name: "Named", PkgPath: "encore.dev/storage/sqldb"}
+		named := pkginfo.QualifiedName{Name: "Named", PkgPath: "encore.dev/storage/sqldb"}
+		namedReadOnly := pkginfo.QualifiedName{Name: "NamedReadOnly", PkgPath: "encore.dev/storage/sqldb"}
 
-		spec := &parseutil.ReferenceSpec{
+		namedSpec := &parseutil.ReferenceSpec{
 			Parse:       parseNamedSQLDB,
 			MinTypeArgs: 0,
 			MaxTypeArgs: 0,
 		}
+		readOnlySpec := &parseutil.ReferenceSpec{
+			Parse:       parseNamedReadOnlySQLDB,
+			MinTypeArgs: 0,
+			MaxTypeArgs: 0,
+		}
 
-		parseutil.FindPkgNameRefs(p.Pkg, []pkginfo.QualifiedName{name}, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+		names := []pkginfo.QualifiedName{named, namedReadOnly}
+		parseutil.FindPkgNameRefs(p.Pkg, names, func(file *pkginfo.File, name pkginfo.QualifiedName, stack []ast.Node) {
+			spec := namedSpec
+			if name == namedReadOnly {
+				spec = readOnlySpec
+			}
 			parseutil.ParseReference(p, spec, parseutil.ReferenceData{
 				File:         file,
 				Stack:        stack,
@@ -36,19 +49,64 @@ var NamedParser = &resourceparser.Parser{
 	},
 }
 
+// ReadOnlyRef is a read-only reference to a database, made with sqldb.NamedReadOnly.
+// Referencing the database of another service requires it to grant read access.
+type ReadOnlyRef struct {
+	AST    *ast.CallExpr
+	File   *pkginfo.File
+	DBName string
+
+	// BoundName is the package-level identifier the reference is bound to, if any.
+	BoundName option.Option[*ast.Ident]
+}
+
+func (r *ReadOnlyRef) Kind() resource.Kind       { return resource.SQLDatabaseReadOnlyRef }
+func (r *ReadOnlyRef) Package() *pkginfo.Package { return r.File.Pkg }
+func (r *ReadOnlyRef) Pos() token.Pos            { return r.AST.Pos() }
+func (r *ReadOnlyRef) End() token.Pos            { return r.AST.End() }
+func (r *ReadOnlyRef) SortKey() string {
+	return fmt.Sprintf("%s.%s:%d", r.File.Pkg.ImportPath, r.DBName, r.AST.Pos())
+}
+
 func parseNamedSQLDB(d parseutil.ReferenceInfo) {
 	if len(d.Call.Args) != 1 {
 		d.Pass.Errs.Add(errNamedRequiresDatabaseName(len(d.Call.Args)).AtGoNode(d.Call))
 		return
 	}
+	dbName, ok := parseDatabaseName(d)
+	if !ok {
+		return
+	}
+	d.Pass.AddPathBind(d.File, d.Ident, resource.Path{{resource.SQLDatabase, dbName}})
+}
 
+func parseNamedReadOnlySQLDB(d parseutil.ReferenceInfo) {
+	if len(d.Call.Args) != 1 {
+		d.Pass.Errs.Add(errNamedReadOnlyRequiresDatabaseName(len(d.Call.Args)).AtGoNode(d.Call))
+		return
+	}
+	dbName, ok := parseDatabaseName(d)
+	if !ok {
+		return
+	}
+	d.Pass.RegisterResource(&ReadOnlyRef{
+		AST:       d.Call,
+		File:      d.File,
+		DBName:    dbName,
+		BoundName: d.Ident,
+	})
+	d.Pass.AddPathBind(d.File, d.Ident, resource.Path{{resource.SQLDatabase, dbName}})
+}
+
+// parseDatabaseName parses the database name passed to sqldb.Named or sqldb.NamedReadOnly.
+func parseDatabaseName(d parseutil.ReferenceInfo) (string, bool) {
 	dbName, ok := literals.ParseString(d.Call.Args[0])
 	if !ok {
 		d.Pass.Errs.Add(
 			errNamedRequiresDatabaseNameString.
 				AtGoNode(d.Call.Args[0], errors.AsError(fmt.Sprintf("got %v", parseutil.NodeType(d.Call.Args[0])))),
 		)
-		return
+		return "", false
 	}
 
 	if len(dbName) <= 0 {
@@ -56,6 +114,5 @@ func parseNamedSQLDB(d parseutil.ReferenceInfo) {
 			errNamedRequiresDatabaseNameString.AtGoNode(d.Call.Args[0], errors.AsError("got an empty string")),
 		)
 	}
-
-	d.Pass.AddPathBind(d.File, d.Ident, resource.Path{{resource.SQLDatabase, dbName}})
+	return dbName, true
 }
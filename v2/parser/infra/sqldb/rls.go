@@ -0,0 +1,141 @@
+package sqldb
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"encr.dev/pkg/paths"
+)
+
+var (
+	tableNamePattern = `((?:"[^"]+"|[A-Za-z_][\w$]*)(?:\s*\.\s*(?:"[^"]+"|[A-Za-z_][\w$]*))?)`
+	identPattern     = `("[^"]+"|[A-Za-z_][\w$]*)`
+
+	createTableRe  = regexp.MustCompile(`(?is)^CREATE\s+(?:(?:GLOBAL|LOCAL)\s+)?((?:TEMP|TEMPORARY)\s+)?(?:UNLOGGED\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?` + tableNamePattern)
+	dropTableRe    = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(.+?)(?:\s+(?:CASCADE|RESTRICT))?$`)
+	alterTableRe   = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?` + tableNamePattern + `\s+(.+)$`)
+	renameTableRe  = regexp.MustCompile(`(?is)^RENAME\s+TO\s+` + identPattern + `$`)
+	toggleRLSRe    = regexp.MustCompile(`(?is)^(ENABLE|DISABLE|FORCE|NO\s+FORCE)\s+ROW\s+LEVEL\s+SECURITY$`)
+	createPolicyRe = regexp.MustCompile(`(?is)^CREATE\s+POLICY\s+` + identPattern + `\s+ON\s+` + tableNamePattern + `(?:\s|$)`)
+	dropPolicyRe   = regexp.MustCompile(`(?is)^DROP\s+POLICY\s+(?:IF\s+EXISTS\s+)?` + identPattern + `\s+ON\s+` + tableNamePattern + `(?:\s|$)`)
+	renamePolicyRe = regexp.MustCompile(`(?is)^ALTER\s+POLICY\s+` + identPattern + `\s+ON\s+` + tableNamePattern + `\s+RENAME\s+TO\s+` + identPattern + `$`)
+
+	qualifiedNameRe = regexp.MustCompile(`^("[^"]+"|[^."\s]+)\s*\.\s*("[^"]+"|[^."\s]+)$`)
+	lineCommentRe   = regexp.MustCompile(`--[^\n]*`)
+	blockCommentRe  = regexp.MustCompile(`(?s)/\*.*?\*/`)
+)
+
+// rlsTable is the row-level security state of a table.
+type rlsTable struct {
+	name     string // the qualified table name
+	state    string // "" if unset, "enabled" or "disabled"
+	policies []string
+}
+
+// checkRowLevelSecurity applies the migrations in migrationDir in order,
+// and describes the tables that neither have row-level security enabled
+// with at least one policy, nor have it explicitly disabled.
+func checkRowLevelSecurity(migrationDir paths.FS, migrations []MigrationFile) ([]string, error) {
+	var tables []*rlsTable
+	find := func(name string) *rlsTable {
+		idx := slices.IndexFunc(tables, func(t *rlsTable) bool { return t.name == name })
+		if idx < 0 {
+			return nil
+		}
+		return tables[idx]
+	}
+
+	for _, m := range migrations {
+		data, err := os.ReadFile(migrationDir.Join(m.Filename).ToIO())
+		if err != nil {
+			return nil, err
+		}
+		sql := blockCommentRe.ReplaceAllString(string(data), "")
+		sql = lineCommentRe.ReplaceAllString(sql, "")
+
+		for _, stmt := range strings.Split(sql, ";") {
+			stmt = strings.TrimSpace(stmt)
+			if m := createTableRe.FindStringSubmatch(stmt); m != nil {
+				// Temporary tables are private to the session creating them.
+				if m[1] == "" && find(parseTable(m[2])) == nil {
+					tables = append(tables, &rlsTable{name: parseTable(m[2])})
+				}
+			} else if m := dropTableRe.FindStringSubmatch(stmt); m != nil {
+				for _, name := range strings.Split(m[1], ",") {
+					name := parseTable(strings.TrimSpace(name))
+					tables = slices.DeleteFunc(tables, func(t *rlsTable) bool { return t.name == name })
+				}
+			} else if m := createPolicyRe.FindStringSubmatch(stmt); m != nil {
+				if t := find(parseTable(m[2])); t != nil {
+					t.policies = append(t.policies, parseIdent(m[1]))
+				}
+			} else if m := dropPolicyRe.FindStringSubmatch(stmt); m != nil {
+				if t := find(parseTable(m[2])); t != nil {
+					policy := parseIdent(m[1])
+					t.policies = slices.DeleteFunc(t.policies, func(p string) bool { return p == policy })
+				}
+			} else if m := renamePolicyRe.FindStringSubmatch(stmt); m != nil {
+				if t := find(parseTable(m[2])); t != nil {
+					if idx := slices.Index(t.policies, parseIdent(m[1])); idx >= 0 {
+						t.policies[idx] = parseIdent(m[3])
+					}
+				}
+			} else if m := alterTableRe.FindStringSubmatch(stmt); m != nil {
+				t := find(parseTable(m[1]))
+				if t == nil {
+					continue
+				}
+				action := strings.TrimSpace(m[2])
+				if m := renameTableRe.FindStringSubmatch(action); m != nil {
+					// Renamed tables stay in the same schema.
+					schema, _, _ := strings.Cut(t.name, ".")
+					t.name = schema + "." + parseIdent(m[1])
+				} else {
+					for _, part := range strings.Split(action, ",") {
+						if m := toggleRLSRe.FindStringSubmatch(strings.TrimSpace(part)); m != nil {
+							switch strings.ToUpper(m[1]) {
+							case "ENABLE":
+								t.state = "enabled"
+							case "DISABLE":
+								t.state = "disabled"
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	var missing []string
+	for _, t := range tables {
+		name := strings.TrimPrefix(t.name, "public.")
+		switch {
+		case t.state == "disabled":
+			// Explicitly opted out.
+		case t.state == "":
+			missing = append(missing, fmt.Sprintf("table %q does not have row-level security enabled", name))
+		case len(t.policies) == 0:
+			missing = append(missing, fmt.Sprintf("table %q has no row-level security policies", name))
+		}
+	}
+	return missing, nil
+}
+
+// parseTable parses a possibly qualified table name into the form "schema.name".
+func parseTable(s string) string {
+	if m := qualifiedNameRe.FindStringSubmatch(s); m != nil {
+		return parseIdent(m[1]) + "." + parseIdent(m[2])
+	}
+	return "public." + parseIdent(s)
+}
+
+// parseIdent parses an SQL identifier. Unquoted identifiers are case-insensitive.
+func parseIdent(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return strings.ToLower(s)
+}
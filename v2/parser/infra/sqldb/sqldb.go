@@ -32,8 +32,32 @@ type Database struct {
 	File         option.Option[*pkginfo.File]
 	MigrationDir paths.MainModuleRelSlash
 	Migrations   []MigrationFile
+
+	// RowLevelSecurity is whether the database uses row-level security,
+	// requiring every table to have it enabled with at least one policy.
+	RowLevelSecurity bool
+
+	// Engine is the database engine of the database.
+	Engine Engine
+
+	// ReadAccess are the services granted read-only access to the database.
+	ReadAccess []ReadGrant
+}
+
+// ReadGrant grants a service read-only access to a database.
+type ReadGrant struct {
+	AST     ast.Expr // the service name in the ReadAccess list
+	Service string
 }
 
+// Engine is the database engine of a database.
+type Engine string
+
+const (
+	Postgres Engine = "postgres"
+	MySQL    Engine = "mysql"
+)
+
 func (d *Database) Kind() resource.Kind       { return resource.SQLDatabase }
 func (d *Database) Package() *pkginfo.Package { return d.Pkg }
 func (d *Database) ResourceName() string      { return d.Name }
@@ -45,6 +69,10 @@ type MigrationFile struct {
 	Filename    string
 	Number      uint64
 	Description string
+
+	// DownFilename is the filename of the down migration reverting
+	// this migration, or "" if there is none.
+	DownFilename string
 }
 
 var DatabaseParser = &resourceparser.Parser{
@@ -92,10 +120,39 @@ func parseDatabase(d parseutil.ReferenceInfo) {
 
 	// Decode the config
 	type decodedConfig struct {
-		Migrations string `literal:",required"`
+		Migrations       string `literal:",required"`
+		RowLevelSecurity bool
+		Engine           string
+		ReadAccess       ast.Expr `literal:",optional,dynamic"`
 	}
 	config := literals.Decode[decodedConfig](d.Pass.Errs, cfgLit, nil)
 
+	engine := Postgres
+	switch Engine(config.Engine) {
+	case "", Postgres:
+	case MySQL:
+		engine = MySQL
+	default:
+		errs.Add(errInvalidEngine(config.Engine).AtGoNode(cfgLit.Expr("Engine")))
+		return
+	}
+	if engine == MySQL && config.RowLevelSecurity {
+		errs.Add(errRowLevelSecurityRequiresPostgres.AtGoNode(cfgLit.Expr("RowLevelSecurity")))
+		return
+	}
+
+	var readAccess []ReadGrant
+	if config.ReadAccess != nil {
+		if engine == MySQL {
+			errs.Add(errReadAccessRequiresPostgres.AtGoNode(config.ReadAccess))
+			return
+		}
+		var ok bool
+		if readAccess, ok = parseReadAccess(d, config.ReadAccess); !ok {
+			return
+		}
+	}
+
 	if path.IsAbs(config.Migrations) {
 		errs.Add(errNewDatabaseAbsPath.AtGoNode(cfgLit.Expr("Migrations")))
 		return
@@ -128,18 +185,61 @@ func parseDatabase(d parseutil.ReferenceInfo) {
 		return
 	}
 
+	if config.RowLevelSecurity {
+		missing, err := checkRowLevelSecurity(migrationDir, migrations)
+		if err != nil {
+			errs.Add(errUnableToParseMigrations.AtGoNode(cfgLit.Expr("Migrations")).Wrapping(err))
+			return
+		}
+		for _, m := range missing {
+			errs.Add(errMissingRowLevelSecurity(m).AtGoNode(cfgLit.Expr("RowLevelSecurity")))
+		}
+	}
+
 	db := &Database{
-		AST:          d.Call,
-		Pkg:          d.Pass.Pkg,
-		Name:         databaseName,
-		Doc:          d.Doc,
-		MigrationDir: paths.MainModuleRelSlash(filepath.ToSlash(relMigrationDir)),
-		Migrations:   migrations,
+		AST:              d.Call,
+		Pkg:              d.Pass.Pkg,
+		Name:             databaseName,
+		Doc:              d.Doc,
+		MigrationDir:     paths.MainModuleRelSlash(filepath.ToSlash(relMigrationDir)),
+		Migrations:       migrations,
+		RowLevelSecurity: config.RowLevelSecurity,
+		Engine:           engine,
+		ReadAccess:       readAccess,
 	}
 	d.Pass.RegisterResource(db)
 	d.Pass.AddBind(d.File, d.Ident, db)
 }
 
+// parseReadAccess parses the services granted read-only access to a database,
+// which must be given as a slice literal of service names.
+func parseReadAccess(d parseutil.ReferenceInfo, expr ast.Expr) (grants []ReadGrant, ok bool) {
+	lit, isLit := expr.(*ast.CompositeLit)
+	if !isLit {
+		d.Pass.Errs.Add(errInvalidReadAccess.AtGoNode(expr))
+		return nil, false
+	}
+	if arr, isArr := lit.Type.(*ast.ArrayType); !isArr || arr.Len != nil {
+		d.Pass.Errs.Add(errInvalidReadAccess.AtGoNode(expr))
+		return nil, false
+	}
+
+	seen := make(map[string]bool, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		svc, isStr := literals.ParseString(elt)
+		if !isStr || svc == "" {
+			d.Pass.Errs.Add(errInvalidReadAccess.AtGoNode(elt))
+			return nil, false
+		}
+		if seen[svc] {
+			continue
+		}
+		seen[svc] = true
+		grants = append(grants, ReadGrant{AST: elt, Service: svc})
+	}
+	return grants, true
+}
+
 var MigrationParser = &resourceparser.Parser{
 	Name: "SQL Database",
 
@@ -194,6 +294,7 @@ func parseMigrations(migrationDir paths.FS) ([]MigrationFile, error) {
 		return nil, fmt.Errorf("could not read migrations: %v", err)
 	}
 	migrations := make([]MigrationFile, 0, len(files))
+	downs := make(map[uint64]string)
 	for _, f := range files {
 		if f.IsDir() {
 			continue
@@ -225,6 +326,11 @@ func parseMigrations(migrationDir paths.FS) ([]MigrationFile, error) {
 				Number:      num,
 				Description: description,
 			})
+		} else if prev, ok := downs[num]; ok {
+			return nil, fmt.Errorf("db migration %s: duplicate down migration with number %d (also defined by %s)",
+				f.Name(), num, prev)
+		} else {
+			downs[num] = f.Name()
 		}
 	}
 	sort.Slice(migrations, func(i, j int) bool {
@@ -243,6 +349,12 @@ func parseMigrations(migrationDir paths.FS) ([]MigrationFile, error) {
 		seen[num] = true
 	}
 
+	// Pair the down migrations with the migrations they revert.
+	// Down migrations without a corresponding up migration are ignored.
+	for i := range migrations {
+		migrations[i].DownFilename = downs[migrations[i].Number]
+	}
+
 	return migrations, nil
 }
 
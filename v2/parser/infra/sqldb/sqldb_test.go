@@ -19,6 +19,7 @@ var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
 			Want: &Database{
 				Name:         "name",
 				MigrationDir: "some/migration/path",
+				Engine:       Postgres,
 			},
 		},
 		{
@@ -38,6 +39,83 @@ CREATE TABLE foo (id int);
 					Number:      1,
 					Description: "foo",
 				}},
+				Engine: Postgres,
+			},
+		},
+		{
+			Name: "down_migration",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+})
+-- migrations/1_foo.up.sql --
+CREATE TABLE foo (id int);
+-- migrations/1_foo.down.sql --
+DROP TABLE foo;
+-- migrations/2_bar.up.sql --
+CREATE TABLE bar (id int);
+`,
+			Want: &Database{
+				Name:         "name",
+				MigrationDir: "migrations",
+				Migrations: []MigrationFile{{
+					Filename:     "1_foo.up.sql",
+					Number:       1,
+					Description:  "foo",
+					DownFilename: "1_foo.down.sql",
+				}, {
+					Filename:    "2_bar.up.sql",
+					Number:      2,
+					Description: "bar",
+				}},
+				Engine: Postgres,
+			},
+		},
+		{
+			Name: "row_level_security",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations:       "migrations",
+	RowLevelSecurity: true,
+})
+-- migrations/1_init.up.sql --
+CREATE TABLE orders (id int, tenant_id text);
+CREATE TABLE countries (code text);
+ALTER TABLE orders ENABLE ROW LEVEL SECURITY, FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON orders USING (tenant_id = current_setting('app.tenant_id'));
+-- Countries are shared between tenants.
+ALTER TABLE countries DISABLE ROW LEVEL SECURITY;
+`,
+			Want: &Database{
+				Name:         "name",
+				MigrationDir: "migrations",
+				Migrations: []MigrationFile{{
+					Filename:    "1_init.up.sql",
+					Number:      1,
+					Description: "init",
+				}},
+				RowLevelSecurity: true,
+				Engine:           Postgres,
+			},
+		},
+		{
+			Name: "row_level_security_missing",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations:       "migrations",
+	RowLevelSecurity: true,
+})
+-- migrations/1_init.up.sql --
+CREATE TABLE orders (id int);
+CREATE TABLE invoices (id int);
+ALTER TABLE invoices ENABLE ROW LEVEL SECURITY;
+-- migrations/2_rename.up.sql --
+CREATE POLICY p ON orders USING (true);
+ALTER TABLE orders RENAME TO purchases;
+`,
+			WantErrs: []string{
+				`.*table "purchases" does not have.*`,
+				`.*table "invoices" has no.*`,
 			},
 		},
 		{
@@ -58,6 +136,80 @@ var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
 `,
 			WantErrs: []string{`.*The migration path must be a relative path.*`},
 		},
+		{
+			Name: "engine_postgres",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+	Engine:     sqldb.Postgres,
+})
+-- migrations/1_foo.up.sql --
+CREATE TABLE foo (id int);
+`,
+			Want: &Database{
+				Name:         "name",
+				MigrationDir: "migrations",
+				Migrations: []MigrationFile{{
+					Filename:    "1_foo.up.sql",
+					Number:      1,
+					Description: "foo",
+				}},
+				Engine: Postgres,
+			},
+		},
+		{
+			Name: "engine_mysql",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+	Engine:     sqldb.MySQL,
+})
+-- migrations/1_foo.up.sql --
+CREATE TABLE foo (id BIGINT AUTO_INCREMENT PRIMARY KEY);
+`,
+			Want: &Database{
+				Name:         "name",
+				MigrationDir: "migrations",
+				Migrations: []MigrationFile{{
+					Filename:    "1_foo.up.sql",
+					Number:      1,
+					Description: "foo",
+				}},
+				Engine: MySQL,
+			},
+		},
+		{
+			Name: "engine_invalid",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+	Engine:     "oracle",
+})
+`,
+			WantErrs: []string{`.*The database engine must be sqldb.Postgres or sqldb.MySQL, got "oracle".*`},
+		},
+		{
+			Name: "engine_mysql_row_level_security",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations:       "migrations",
+	Engine:           sqldb.MySQL,
+	RowLevelSecurity: true,
+})
+`,
+			WantErrs: []string{`.*Row-level security is only supported by databases using the Postgres engine.*`},
+		},
+		{
+			Name: "engine_mysql_read_access",
+			Code: `
+var x = sqldb.NewDatabase("name", sqldb.DatabaseConfig{
+	Migrations: "migrations",
+	Engine:     sqldb.MySQL,
+	ReadAccess: []string{"users"},
+})
+`,
+			WantErrs: []string{`.*Read access grants are only supported by databases using the Postgres engine.*`},
+		},
 	}
 
 	resourcetest.Run(t, DatabaseParser, tests)
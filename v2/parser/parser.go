@@ -17,9 +17,13 @@ import (
 	"encr.dev/v2/parser/infra/caches"
 	"encr.dev/v2/parser/infra/config"
 	"encr.dev/v2/parser/infra/crons"
+	"encr.dev/v2/parser/infra/email"
+	"encr.dev/v2/parser/infra/envvar"
 	"encr.dev/v2/parser/infra/metrics"
 	"encr.dev/v2/parser/infra/objects"
+	"encr.dev/v2/parser/infra/operations"
 	"encr.dev/v2/parser/infra/pubsub"
+	"encr.dev/v2/parser/infra/search"
 	"encr.dev/v2/parser/infra/secrets"
 	"encr.dev/v2/parser/infra/sqldb"
 	"encr.dev/v2/parser/resource"
@@ -150,6 +154,10 @@ var allParsers = []*resourceparser.Parser{
 	sqldb.MigrationParser,
 	sqldb.NamedParser,
 	objects.BucketParser,
+	search.IndexParser,
+	email.InboundParser,
+	envvar.VarParser,
+	operations.TrackerParser,
 }
 
 func newUsageResolver() *usage.Resolver {
@@ -22,12 +22,18 @@ const (
 	ConfigLoad
 	Secrets
 	Bucket
+	SearchIndex
+	InboundEmail
+	OperationTracker
+	EnvVar
+	SQLDatabaseReadOnlyRef
 
 	// API Framework Resources
 	APIEndpoint
 	AuthHandler
 	Middleware
 	ServiceStruct
+	ServiceOwner
 )
 
 type Resource interface {
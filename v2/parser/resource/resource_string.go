@@ -19,15 +19,21 @@ func _() {
 	_ = x[ConfigLoad-8]
 	_ = x[Secrets-9]
 	_ = x[Bucket-10]
-	_ = x[APIEndpoint-11]
-	_ = x[AuthHandler-12]
-	_ = x[Middleware-13]
-	_ = x[ServiceStruct-14]
+	_ = x[SearchIndex-11]
+	_ = x[InboundEmail-12]
+	_ = x[OperationTracker-13]
+	_ = x[EnvVar-14]
+	_ = x[SQLDatabaseReadOnlyRef-15]
+	_ = x[APIEndpoint-16]
+	_ = x[AuthHandler-17]
+	_ = x[Middleware-18]
+	_ = x[ServiceStruct-19]
+	_ = x[ServiceOwner-20]
 }
 
-const _Kind_name = "UnknownPubSubTopicPubSubSubscriptionSQLDatabaseMetricCronJobCacheClusterCacheKeyspaceConfigLoadSecretsBucketAPIEndpointAuthHandlerMiddlewareServiceStruct"
+const _Kind_name = "UnknownPubSubTopicPubSubSubscriptionSQLDatabaseMetricCronJobCacheClusterCacheKeyspaceConfigLoadSecretsBucketSearchIndexInboundEmailOperationTrackerEnvVarSQLDatabaseReadOnlyRefAPIEndpointAuthHandlerMiddlewareServiceStructServiceOwner"
 
-var _Kind_index = [...]uint8{0, 7, 18, 36, 47, 53, 60, 72, 85, 95, 102, 108, 119, 130, 140, 153}
+var _Kind_index = [...]uint8{0, 7, 18, 36, 47, 53, 60, 72, 85, 95, 102, 108, 119, 131, 147, 153, 175, 186, 197, 207, 220, 232}
 
 func (i Kind) String() string {
 	if i < 0 || i >= Kind(len(_Kind_index)-1) {
@@ -20,10 +20,12 @@ import (
 	"encr.dev/internal/env"
 	"encr.dev/internal/lookpath"
 	"encr.dev/internal/version"
+	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
+	"encr.dev/pkg/protogen"
 	metav1 "encr.dev/proto/encore/parser/meta/v1"
 )
 
@@ -180,6 +182,12 @@ func (i *BuilderImpl) Parse(ctx context.Context, p builder.ParseParams) (*builde
 	// Get prepared data from Prepare()
 	data := p.Prepare.Data.(*data)
 
+	// Generate the types for the app's .proto files, if any, before parsing
+	// so they can be used in the app.
+	if err := protogen.Generate(ctx, p.App.Root(), appfile.LangTS); err != nil {
+		return nil, err
+	}
+
 	// Send parse command
 	input, _ := json.Marshal(parseInput{
 		AppRoot:    p.App.Root(),
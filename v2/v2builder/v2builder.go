@@ -20,11 +20,14 @@ import (
 	"encr.dev/internal/env"
 	"encr.dev/internal/etrace"
 	"encr.dev/internal/version"
+	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/cueutil"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
+	"encr.dev/pkg/protogen"
+	"encr.dev/pkg/provenance"
 	"encr.dev/pkg/vfs"
 	"encr.dev/v2/app"
 	"encr.dev/v2/app/legacymeta"
@@ -88,6 +91,7 @@ func (*BuilderImpl) Parse(ctx context.Context, p builder.ParseParams) (*builder.
 				UncommittedChanges:        p.Build.UncommittedChanges,
 				MainPkg:                   p.Build.MainPkg,
 				DisableSensitiveScrubbing: p.Build.DisableSensitiveScrubbing,
+				Reproducible:              p.Build.Reproducible,
 			},
 			MainModuleDir: paths.RootedFSPath(p.App.Root(), "."),
 			FS:            fset,
@@ -95,6 +99,12 @@ func (*BuilderImpl) Parse(ctx context.Context, p builder.ParseParams) (*builder.
 			Errs:          errs,
 		}
 
+		// Generate the types for the app's .proto files, if any, before parsing
+		// so they can be used in the app.
+		if err := protogen.Generate(ctx, p.App.Root(), appfile.LangGo); err != nil {
+			return nil, err
+		}
+
 		parser := parser.NewParser(pc)
 		parserResult := parser.Parse()
 		appDesc := app.ValidateAndDescribe(pc, parserResult)
@@ -149,6 +159,7 @@ func (*BuilderImpl) Compile(ctx context.Context, p builder.CompileParams) (*buil
 			AppUncommitted:    p.Build.UncommittedChanges,
 			ExecScriptMainPkg: p.Build.MainPkg,
 		})
+		staticConfig.InfraHash = provenance.InfraHash(p.Parse.Meta)
 
 		if pd.pc.Errs.Len() > 0 {
 			p.OpTracker.Fail(codegenOp, pd.pc.Errs.AsError())